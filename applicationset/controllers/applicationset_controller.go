@@ -18,16 +18,22 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"reflect"
 	"runtime/debug"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -49,6 +55,7 @@ import (
 	"github.com/argoproj/argo-cd/v3/applicationset/controllers/template"
 	"github.com/argoproj/argo-cd/v3/applicationset/generators"
 	"github.com/argoproj/argo-cd/v3/applicationset/metrics"
+	"github.com/argoproj/argo-cd/v3/applicationset/services"
 	"github.com/argoproj/argo-cd/v3/applicationset/status"
 	"github.com/argoproj/argo-cd/v3/applicationset/utils"
 	"github.com/argoproj/argo-cd/v3/common"
@@ -100,6 +107,15 @@ type ApplicationSetReconciler struct {
 	GlobalPreservedAnnotations []string
 	GlobalPreservedLabels      []string
 	Metrics                    *metrics.ApplicationsetMetrics
+	// GitHubRateLimitBudget and GitHubAPIRateLimitReserve defer the GitHub API calls of the generators during the
+	// periodic refreshes of the ApplicationSets while the remaining GitHub API rate limit of their credentials is below
+	// GitHubAPIRateLimitReserve percent of the limit
+	GitHubRateLimitBudget     *services.GitHubRateLimitBudget
+	GitHubAPIRateLimitReserve int
+	// generatedGenerations are the generations of the ApplicationSets whose applications were last generated
+	generatedGenerations sync.Map
+	// generatedParams are the parameters last generated by the generators calling the GitHub API, by ApplicationSet
+	generatedParams sync.Map
 }
 
 // +kubebuilder:rbac:groups=argoproj.io,resources=applicationsets,verbs=get;list;watch;create;update;patch;delete
@@ -109,6 +125,16 @@ func (r *ApplicationSetReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 	startReconcile := time.Now()
 	logCtx := log.WithField("applicationset", req.NamespacedName)
 
+	ctx, span := tracer.Start(ctx, "reconcile", trace.WithAttributes(
+		attribute.String("applicationset.namespace", req.Namespace),
+		attribute.String("applicationset.name", req.Name)))
+	defer func() {
+		if err != nil {
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
 	defer func() {
 		if rec := recover(); rec != nil {
 			logCtx.Errorf("Recovered from panic: %+v\n%s", rec, debug.Stack())
@@ -127,6 +153,8 @@ func (r *ApplicationSetReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 	if err := r.Get(ctx, req.NamespacedName, &applicationSetInfo); err != nil {
 		if client.IgnoreNotFound(err) != nil {
 			logCtx.WithError(err).Infof("unable to get ApplicationSet: '%v' ", err)
+		} else {
+			r.forgetApplicationSet(req.NamespacedName)
 		}
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
@@ -164,6 +192,7 @@ func (r *ApplicationSetReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		if err := r.Update(ctx, &applicationSetInfo); err != nil {
 			return ctrl.Result{}, err
 		}
+		r.forgetApplicationSet(req.NamespacedName)
 		return ctrl.Result{}, nil
 	}
 
@@ -172,10 +201,18 @@ func (r *ApplicationSetReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		return ctrl.Result{}, err
 	}
 
+	deferredUntil, deferred := r.deferGitHubAPICalls(&applicationSetInfo)
+	if deferred {
+		logCtx.WithField("deferredUntil", deferredUntil).Info("deferring the GitHub API calls of the generators, the GitHub API rate limit budget is low")
+		r.Recorder.Eventf(&applicationSetInfo, corev1.EventTypeWarning, "GitHubAPIRateLimitLow", "Deferred the GitHub API calls of the generators until %s, the remaining GitHub API rate limit is below %d%%", deferredUntil.UTC().Format(time.RFC3339), r.GitHubAPIRateLimitReserve)
+		r.Metrics.IncDeferredRefresh(&applicationSetInfo)
+	}
+	appSetGenerators, generatedParams := r.getGenerators(&applicationSetInfo, deferred)
+
 	// Log a warning if there are unrecognized generators
 	_ = utils.CheckInvalidGenerators(&applicationSetInfo)
 	// desiredApplications is the main list of all expected Applications from all generators in this appset.
-	generatedApplications, applicationSetReason, err := template.GenerateApplications(logCtx, applicationSetInfo, r.Generators, r.Renderer, r.Client)
+	generatedApplications, applicationSetReason, err := template.GenerateApplications(ctx, logCtx, applicationSetInfo, appSetGenerators, r.Renderer, r.Client)
 	if err != nil {
 		logCtx.Errorf("unable to generate applications: %v", err)
 		_ = r.setApplicationSetStatusCondition(ctx,
@@ -192,6 +229,10 @@ func (r *ApplicationSetReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 	}
 
 	parametersGenerated = true
+	r.generatedGenerations.Store(req.NamespacedName, applicationSetInfo.Generation)
+	if generatedParams != nil {
+		r.generatedParams.Store(req.NamespacedName, generatedParams)
+	}
 
 	validateErrors, err := r.validateGeneratedApplications(ctx, generatedApplications, applicationSetInfo)
 	if err != nil {
@@ -376,6 +417,10 @@ func (r *ApplicationSetReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		// Ensure that the request is requeued if there are validation errors.
 		requeueAfter = ReconcileRequeueOnValidationError
 	}
+	// the deferred GitHub API calls are made once the rate limit is reset
+	if deferred && (requeueAfter == time.Duration(0) || time.Until(deferredUntil) < requeueAfter) {
+		requeueAfter = time.Until(deferredUntil)
+	}
 
 	logCtx.WithField("requeueAfter", requeueAfter).Info("end reconcile in ", time.Since(startReconcile))
 
@@ -646,6 +691,58 @@ func (r *ApplicationSetReconciler) SetupWithManager(mgr ctrl.Manager, enableProg
 		Complete(r)
 }
 
+// getMetadataPropagationPolicies returns the propagation policies of the labels and the annotations of an ApplicationSet,
+// nil when they aren't set
+func getMetadataPropagationPolicies(applicationSet *argov1alpha1.ApplicationSet) (*argov1alpha1.MetadataPropagationPolicy, *argov1alpha1.MetadataPropagationPolicy) {
+	if applicationSet.Spec.MetadataPropagation == nil {
+		return nil, nil
+	}
+	return applicationSet.Spec.MetadataPropagation.Labels, applicationSet.Spec.MetadataPropagation.Annotations
+}
+
+// applyMetadataStrategy returns the labels or annotations of a generated Application, given the current ones of the
+// Application, the generated ones, and the comma separated keys generated by the previous reconciliation
+func applyMetadataStrategy(strategy argov1alpha1.MetadataPropagationStrategy, current map[string]string, generated map[string]string, previouslyManaged string, created bool) map[string]string {
+	if created {
+		return generated
+	}
+	switch strategy {
+	case argov1alpha1.MetadataPropagationStrategyIgnore:
+		return current
+	case argov1alpha1.MetadataPropagationStrategyMerge:
+		res := maps.Clone(current)
+		if res == nil {
+			res = map[string]string{}
+		}
+		if previouslyManaged != "" {
+			// prune the keys which are no longer generated
+			for _, key := range strings.Split(previouslyManaged, ",") {
+				if _, exists := generated[key]; !exists {
+					delete(res, key)
+				}
+			}
+		}
+		maps.Copy(res, generated)
+		return res
+	default:
+		return generated
+	}
+}
+
+// trackManagedMetadata records the keys of the labels or annotations generated by the ApplicationSet in an annotation
+// of the generated Application, for the merge strategy to prune them once they are no longer generated
+func trackManagedMetadata(annotations map[string]string, annotation string, strategy argov1alpha1.MetadataPropagationStrategy, keys []string) map[string]string {
+	if strategy != argov1alpha1.MetadataPropagationStrategyMerge || len(keys) == 0 {
+		delete(annotations, annotation)
+		return annotations
+	}
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[annotation] = strings.Join(keys, ",")
+	return annotations
+}
+
 // createOrUpdateInCluster will create / update application resources in the cluster.
 // - For new applications, it will call create
 // - For existing application, it will call update
@@ -670,7 +767,8 @@ func (r *ApplicationSetReconciler) createOrUpdateInCluster(ctx context.Context,
 			},
 		}
 
-		action, err := utils.CreateOrUpdate(ctx, appLog, r.Client, applicationSet.Spec.IgnoreApplicationDifferences, normalizers.IgnoreNormalizerOpts{}, found, func() error {
+		appCtx, span := tracer.Start(ctx, "create-or-update", trace.WithAttributes(attribute.String("application.name", generatedApp.Name)))
+		action, err := utils.CreateOrUpdate(appCtx, appLog, r.Client, applicationSet.Spec.IgnoreApplicationDifferences, normalizers.IgnoreNormalizerOpts{}, found, func() error {
 			// Copy only the Application/ObjectMeta fields that are significant, from the generatedApp
 			found.Spec = generatedApp.Spec
 
@@ -679,6 +777,11 @@ func (r *ApplicationSetReconciler) createOrUpdateInCluster(ctx context.Context,
 				found.Operation = generatedApp.Operation
 			}
 
+			// The generated labels and annotations, before the preserved ones are copied, are the ones managed by the
+			// ApplicationSet
+			managedLabels := slices.Sorted(maps.Keys(generatedApp.Labels))
+			managedAnnotations := slices.Sorted(maps.Keys(generatedApp.Annotations))
+
 			preservedAnnotations := make([]string, 0)
 			preservedLabels := make([]string, 0)
 
@@ -729,13 +832,24 @@ func (r *ApplicationSetReconciler) createOrUpdateInCluster(ctx context.Context,
 				}
 			}
 
-			found.Annotations = generatedApp.Annotations
+			labelsPolicy, annotationsPolicy := getMetadataPropagationPolicies(&applicationSet)
+			created := found.ResourceVersion == ""
+			labels := applyMetadataStrategy(labelsPolicy.GetStrategy(), found.Labels, generatedApp.Labels, found.Annotations[common.AnnotationApplicationSetManagedLabels], created)
+			annotations := applyMetadataStrategy(annotationsPolicy.GetStrategy(), found.Annotations, generatedApp.Annotations, found.Annotations[common.AnnotationApplicationSetManagedAnnotations], created)
+			annotations = trackManagedMetadata(annotations, common.AnnotationApplicationSetManagedLabels, labelsPolicy.GetStrategy(), managedLabels)
+			annotations = trackManagedMetadata(annotations, common.AnnotationApplicationSetManagedAnnotations, annotationsPolicy.GetStrategy(), managedAnnotations)
+			found.Annotations = annotations
 
 			found.Finalizers = generatedApp.Finalizers
-			found.Labels = generatedApp.Labels
+			found.Labels = labels
 
 			return controllerutil.SetControllerReference(&applicationSet, found, r.Scheme)
 		})
+		span.SetAttributes(attribute.String("action", string(action)))
+		if err != nil {
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
 		if err != nil {
 			appLog.WithError(err).WithField("action", action).Errorf("failed to %s Application", action)
 			if firstError == nil {
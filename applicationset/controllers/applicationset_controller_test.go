@@ -32,6 +32,7 @@ import (
 	"github.com/argoproj/argo-cd/v3/applicationset/generators"
 	"github.com/argoproj/argo-cd/v3/applicationset/generators/mocks"
 	appsetmetrics "github.com/argoproj/argo-cd/v3/applicationset/metrics"
+	"github.com/argoproj/argo-cd/v3/applicationset/services"
 	"github.com/argoproj/argo-cd/v3/applicationset/utils"
 	argocommon "github.com/argoproj/argo-cd/v3/common"
 	"github.com/argoproj/argo-cd/v3/pkg/apis/application"
@@ -658,6 +659,166 @@ func TestCreateOrUpdateInCluster(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Ensure that the merge strategy only prunes the labels previously generated",
+			appSet: v1alpha1.ApplicationSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "name",
+					Namespace: "namespace",
+				},
+				Spec: v1alpha1.ApplicationSetSpec{
+					Template: v1alpha1.ApplicationSetTemplate{
+						Spec: v1alpha1.ApplicationSpec{
+							Project: "project",
+						},
+					},
+					MetadataPropagation: &v1alpha1.ApplicationSetMetadataPropagation{
+						Labels: &v1alpha1.MetadataPropagationPolicy{
+							Strategy: v1alpha1.MetadataPropagationStrategyMerge,
+						},
+					},
+				},
+			},
+			existingApps: []v1alpha1.Application{
+				{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       "Application",
+						APIVersion: "argoproj.io/v1alpha1",
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Name:            "app1",
+						Namespace:       "namespace",
+						ResourceVersion: "2",
+						Labels: map[string]string{
+							"label-key":         "label-value",
+							"removed-label-key": "removed-label-value",
+							"foreign-label-key": "foreign-label-value",
+						},
+						Annotations: map[string]string{
+							argocommon.AnnotationApplicationSetManagedLabels: "label-key,removed-label-key",
+						},
+					},
+					Spec: v1alpha1.ApplicationSpec{
+						Project: "project",
+					},
+				},
+			},
+			desiredApps: []v1alpha1.Application{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "app1",
+						Namespace: "namespace",
+						Labels: map[string]string{
+							"label-key":     "new-label-value",
+							"new-label-key": "new-label-value",
+						},
+					},
+					Spec: v1alpha1.ApplicationSpec{
+						Project: "project",
+					},
+				},
+			},
+			expected: []v1alpha1.Application{
+				{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       "Application",
+						APIVersion: "argoproj.io/v1alpha1",
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Name:            "app1",
+						Namespace:       "namespace",
+						ResourceVersion: "3",
+						Labels: map[string]string{
+							"label-key":         "new-label-value",
+							"new-label-key":     "new-label-value",
+							"foreign-label-key": "foreign-label-value",
+						},
+						Annotations: map[string]string{
+							argocommon.AnnotationApplicationSetManagedLabels: "label-key,new-label-key",
+						},
+					},
+					Spec: v1alpha1.ApplicationSpec{
+						Project: "project",
+					},
+				},
+			},
+		},
+		{
+			name: "Ensure that the ignore strategy doesn't update the annotations of an existing app",
+			appSet: v1alpha1.ApplicationSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "name",
+					Namespace: "namespace",
+				},
+				Spec: v1alpha1.ApplicationSetSpec{
+					Template: v1alpha1.ApplicationSetTemplate{
+						Spec: v1alpha1.ApplicationSpec{
+							Project: "project",
+						},
+					},
+					MetadataPropagation: &v1alpha1.ApplicationSetMetadataPropagation{
+						Annotations: &v1alpha1.MetadataPropagationPolicy{
+							Strategy: v1alpha1.MetadataPropagationStrategyIgnore,
+						},
+					},
+				},
+			},
+			existingApps: []v1alpha1.Application{
+				{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       "Application",
+						APIVersion: "argoproj.io/v1alpha1",
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Name:            "app1",
+						Namespace:       "namespace",
+						ResourceVersion: "2",
+						Annotations: map[string]string{
+							"annot-key": "annot-value",
+						},
+						Labels: map[string]string{
+							"label-key": "label-value",
+						},
+					},
+					Spec: v1alpha1.ApplicationSpec{
+						Project: "project",
+					},
+				},
+			},
+			desiredApps: []v1alpha1.Application{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "app1",
+						Namespace: "namespace",
+						Annotations: map[string]string{
+							"annot-key": "new-annot-value",
+						},
+					},
+					Spec: v1alpha1.ApplicationSpec{
+						Project: "project",
+					},
+				},
+			},
+			expected: []v1alpha1.Application{
+				{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       "Application",
+						APIVersion: "argoproj.io/v1alpha1",
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Name:            "app1",
+						Namespace:       "namespace",
+						ResourceVersion: "3",
+						Annotations: map[string]string{
+							"annot-key": "annot-value",
+						},
+					},
+					Spec: v1alpha1.ApplicationSpec{
+						Project: "project",
+					},
+				},
+			},
+		},
 		{
 			name: "Ensure that the app spec is normalized before applying",
 			appSet: v1alpha1.ApplicationSet{
@@ -1920,6 +2081,150 @@ func TestRequeueGeneratorFails(t *testing.T) {
 	assert.Equal(t, ReconcileRequeueOnValidationError, res.RequeueAfter)
 }
 
+func TestDeferGitHubAPIRefresh(t *testing.T) {
+	scheme := runtime.NewScheme()
+	err := v1alpha1.AddToScheme(scheme)
+	require.NoError(t, err)
+
+	generator := v1alpha1.ApplicationSetGenerator{
+		PullRequest: &v1alpha1.PullRequestGenerator{},
+	}
+	appSet := v1alpha1.ApplicationSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "name",
+			Namespace: "argocd",
+		},
+		Spec: v1alpha1.ApplicationSetSpec{
+			Generators: []v1alpha1.ApplicationSetGenerator{generator},
+		},
+	}
+	client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&appSet).WithStatusSubresource(&appSet).WithIndex(&v1alpha1.Application{}, ".metadata.controller", appControllerIndexer).Build()
+
+	generatorMock := mocks.Generator{}
+	generatorMock.On("GetTemplate", &generator).
+		Return(&v1alpha1.ApplicationSetTemplate{})
+	generatorMock.On("GenerateParams", &generator, mock.AnythingOfType("*v1alpha1.ApplicationSet"), mock.Anything).
+		Return([]map[string]any{}, nil)
+	generatorMock.On("GetRequeueAfter", &generator).
+		Return(30 * time.Minute)
+
+	budget := services.NewGitHubRateLimitBudget()
+	recorder := record.NewFakeRecorder(1)
+	r := ApplicationSetReconciler{
+		Client:   client,
+		Scheme:   scheme,
+		Renderer: &utils.Render{},
+		Recorder: recorder,
+		Generators: map[string]generators.Generator{
+			"PullRequest": &generatorMock,
+		},
+		Metrics:                   appsetmetrics.NewFakeAppsetMetrics(),
+		GitHubRateLimitBudget:     budget,
+		GitHubAPIRateLimitReserve: 10,
+	}
+	req := ctrl.Request{
+		NamespacedName: types.NamespacedName{
+			Namespace: "argocd",
+			Name:      "name",
+		},
+	}
+
+	res, err := r.Reconcile(t.Context(), req)
+	require.NoError(t, err)
+	assert.Equal(t, 30*time.Minute, res.RequeueAfter)
+	generatorMock.AssertNumberOfCalls(t, "GenerateParams", 1)
+
+	// the generator calling the GitHub API returns its last parameters, the ApplicationSet being requeued when the
+	// rate limit is reset
+	reset := time.Now().Add(10 * time.Minute)
+	budget.Record(&services.MetricsContext{AppSetNamespace: "argocd", AppSetName: "name", Credentials: "token:test"}, "core", 100, 5000, reset)
+	res, err = r.Reconcile(t.Context(), req)
+	require.NoError(t, err)
+	assert.Greater(t, res.RequeueAfter, 9*time.Minute)
+	assert.LessOrEqual(t, res.RequeueAfter, 10*time.Minute)
+	generatorMock.AssertNumberOfCalls(t, "GenerateParams", 1)
+	assert.Contains(t, <-recorder.Events, "GitHubAPIRateLimitLow")
+
+	// the refreshes requested by webhooks aren't deferred
+	var retrievedApplicationSet v1alpha1.ApplicationSet
+	require.NoError(t, r.Get(t.Context(), req.NamespacedName, &retrievedApplicationSet))
+	retrievedApplicationSet.Annotations = map[string]string{argocommon.AnnotationApplicationSetRefresh: "true"}
+	require.NoError(t, r.Update(t.Context(), &retrievedApplicationSet))
+	_, err = r.Reconcile(t.Context(), req)
+	require.NoError(t, err)
+	generatorMock.AssertNumberOfCalls(t, "GenerateParams", 2)
+
+	// the state of the deleted ApplicationSets is forgotten
+	require.NoError(t, r.Get(t.Context(), req.NamespacedName, &retrievedApplicationSet))
+	require.NoError(t, r.Delete(t.Context(), &retrievedApplicationSet))
+	_, err = r.Reconcile(t.Context(), req)
+	require.NoError(t, err)
+	_, ok := r.generatedGenerations.Load(req.NamespacedName)
+	assert.False(t, ok)
+	_, ok = r.generatedParams.Load(req.NamespacedName)
+	assert.False(t, ok)
+	_, low := budget.IsLow("argocd", "name", 10)
+	assert.False(t, low)
+}
+
+func TestDeferGitHubAPIRefresh_Matrix(t *testing.T) {
+	scheme := runtime.NewScheme()
+	err := v1alpha1.AddToScheme(scheme)
+	require.NoError(t, err)
+
+	pullRequestGenerator := v1alpha1.ApplicationSetNestedGenerator{PullRequest: &v1alpha1.PullRequestGenerator{}}
+	listGenerator := v1alpha1.ApplicationSetNestedGenerator{List: &v1alpha1.ListGenerator{Elements: []apiextensionsv1.JSON{{Raw: []byte(`{"cluster": "in-cluster"}`)}}}}
+	appSet := v1alpha1.ApplicationSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "name",
+			Namespace: "argocd",
+		},
+		Spec: v1alpha1.ApplicationSetSpec{
+			Generators: []v1alpha1.ApplicationSetGenerator{{
+				Matrix: &v1alpha1.MatrixGenerator{Generators: []v1alpha1.ApplicationSetNestedGenerator{listGenerator, pullRequestGenerator}},
+			}},
+		},
+	}
+	client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&appSet).WithStatusSubresource(&appSet).WithIndex(&v1alpha1.Application{}, ".metadata.controller", appControllerIndexer).Build()
+
+	generatorMock := mocks.Generator{}
+	generatorMock.On("GetTemplate", mock.Anything).
+		Return(&v1alpha1.ApplicationSetTemplate{})
+	generatorMock.On("GenerateParams", mock.Anything, mock.AnythingOfType("*v1alpha1.ApplicationSet"), mock.Anything).
+		Return([]map[string]any{{"number": "1"}}, nil)
+	generatorMock.On("GetRequeueAfter", mock.Anything).
+		Return(30 * time.Minute)
+
+	budget := services.NewGitHubRateLimitBudget()
+	r := ApplicationSetReconciler{
+		Client:   client,
+		Scheme:   scheme,
+		Renderer: &utils.Render{},
+		Recorder: record.NewFakeRecorder(1),
+		Generators: generators.ComposeGenerators(map[string]generators.Generator{
+			"List":        generators.NewListGenerator(),
+			"PullRequest": &generatorMock,
+		}),
+		Metrics:                   appsetmetrics.NewFakeAppsetMetrics(),
+		GitHubRateLimitBudget:     budget,
+		GitHubAPIRateLimitReserve: 10,
+	}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "argocd", Name: "name"}}
+
+	_, err = r.Reconcile(t.Context(), req)
+	require.NoError(t, err)
+	generatorMock.AssertNumberOfCalls(t, "GenerateParams", 1)
+
+	// the nested generator calling the GitHub API returns its last parameters
+	budget.Record(&services.MetricsContext{AppSetNamespace: "argocd", AppSetName: "name", Credentials: "token:test"}, "core", 100, 5000, time.Now().Add(time.Hour))
+	_, err = r.Reconcile(t.Context(), req)
+	require.NoError(t, err)
+	generatorMock.AssertNumberOfCalls(t, "GenerateParams", 1)
+	generated, ok := r.generatedParams.Load(req.NamespacedName)
+	require.True(t, ok)
+	assert.Len(t, generated.(*generatedParams).params, 1)
+}
+
 func TestValidateGeneratedApplications(t *testing.T) {
 	t.Parallel()
 
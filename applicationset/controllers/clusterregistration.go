@@ -0,0 +1,254 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/util/workqueue"
+
+	"github.com/argoproj/argo-cd/v3/common"
+	argoprojiov1alpha1 "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	"github.com/argoproj/argo-cd/v3/util/db"
+)
+
+const (
+	// clusterRegistrationResyncPeriod is the period of the re-synchronization of the registered clusters, which also
+	// picks up the rotations of the kubeconfig secrets of the Cluster API clusters
+	clusterRegistrationResyncPeriod = 3 * time.Minute
+	// clusterAPIKubeconfigSecretSuffix is the suffix of the name of the secret holding the kubeconfig of a Cluster API cluster
+	clusterAPIKubeconfigSecretSuffix = "-kubeconfig"
+	// clusterAPIKubeconfigSecretKey is the key of the kubeconfig in the kubeconfig secret of a Cluster API cluster
+	clusterAPIKubeconfigSecretKey = "value"
+)
+
+var clusterAPIClusterGVR = schema.GroupVersionResource{Group: "cluster.x-k8s.io", Version: "v1beta1", Resource: "clusters"}
+
+// ClusterRegistrationController registers the Cluster API clusters as Argo CD clusters. The cluster secret of a
+// Cluster API cluster is created once its control plane is ready, is kept in sync with the labels and the kubeconfig
+// of the cluster, and is deleted with the cluster. The registered clusters carry the labels of the Cluster API clusters,
+// so that they can be selected by the cluster generators of the ApplicationSets.
+type ClusterRegistrationController struct {
+	DynamicClient dynamic.Interface
+	KubeClientset kubernetes.Interface
+	ArgoDB        db.ArgoDB
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable, so that only the leader registers the clusters
+func (c *ClusterRegistrationController) NeedLeaderElection() bool {
+	return true
+}
+
+// Start watches the Cluster API clusters and registers them until the context is done
+func (c *ClusterRegistrationController) Start(ctx context.Context) error {
+	factory := dynamicinformer.NewDynamicSharedInformerFactory(c.DynamicClient, clusterRegistrationResyncPeriod)
+	informer := factory.ForResource(clusterAPIClusterGVR).Informer()
+	queue := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[string]())
+	defer queue.ShutDown()
+
+	enqueue := func(obj any) {
+		if key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj); err == nil {
+			queue.Add(key)
+		}
+	}
+	if _, err := informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    enqueue,
+		UpdateFunc: func(_, obj any) { enqueue(obj) },
+		DeleteFunc: enqueue,
+	}); err != nil {
+		return fmt.Errorf("error adding the Cluster API cluster event handler: %w", err)
+	}
+	factory.Start(ctx.Done())
+	if !cache.WaitForCacheSync(ctx.Done(), informer.HasSynced) {
+		return errors.New("timed out waiting for the Cluster API cluster cache to sync")
+	}
+
+	// the clusters registered from the Cluster API clusters deleted while the controller was not running are deleted
+	registered, err := c.registeredClusters(ctx)
+	if err != nil {
+		return err
+	}
+	for key := range registered {
+		queue.Add(key)
+	}
+
+	go wait.UntilWithContext(ctx, func(ctx context.Context) {
+		for c.processNextItem(ctx, queue, informer.GetIndexer()) {
+		}
+	}, time.Second)
+	<-ctx.Done()
+	return nil
+}
+
+func (c *ClusterRegistrationController) processNextItem(ctx context.Context, queue workqueue.TypedRateLimitingInterface[string], indexer cache.Indexer) bool {
+	key, shutdown := queue.Get()
+	if shutdown {
+		return false
+	}
+	defer queue.Done(key)
+
+	var capiCluster *unstructured.Unstructured
+	obj, exists, err := indexer.GetByKey(key)
+	if err == nil && exists {
+		capiCluster, _ = obj.(*unstructured.Unstructured)
+	}
+	if err := c.syncCluster(ctx, key, capiCluster); err != nil {
+		log.WithField("cluster", key).Warnf("Failed to register Cluster API cluster: %v", err)
+		queue.AddRateLimited(key)
+		return true
+	}
+	queue.Forget(key)
+	return true
+}
+
+// registeredClusters returns the clusters registered from Cluster API clusters, by namespace/name of their source
+func (c *ClusterRegistrationController) registeredClusters(ctx context.Context) (map[string][]argoprojiov1alpha1.Cluster, error) {
+	clusters, err := c.ArgoDB.ListClusters(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error listing clusters: %w", err)
+	}
+	registered := map[string][]argoprojiov1alpha1.Cluster{}
+	for _, cluster := range clusters.Items {
+		if cluster.Labels[common.LabelKeyClusterRegistration] != common.LabelValueClusterRegistrationClusterAPI {
+			continue
+		}
+		source := cluster.Annotations[common.AnnotationKeyClusterRegistrationSource]
+		registered[source] = append(registered[source], cluster)
+	}
+	return registered, nil
+}
+
+// syncCluster creates, updates or deletes the cluster registered from the Cluster API cluster with the given key,
+// which is nil if the Cluster API cluster doesn't exist anymore
+func (c *ClusterRegistrationController) syncCluster(ctx context.Context, key string, capiCluster *unstructured.Unstructured) error {
+	var desired *argoprojiov1alpha1.Cluster
+	if capiCluster != nil && capiCluster.GetDeletionTimestamp() == nil {
+		if ready, _, _ := unstructured.NestedBool(capiCluster.Object, "status", "controlPlaneReady"); !ready {
+			// the cluster is registered once its control plane is ready
+			return nil
+		}
+		var err error
+		if desired, err = c.clusterFromClusterAPI(ctx, key, capiCluster); err != nil {
+			return err
+		}
+	}
+
+	registered, err := c.registeredClusters(ctx)
+	if err != nil {
+		return err
+	}
+	var current *argoprojiov1alpha1.Cluster
+	for i, cluster := range registered[key] {
+		if desired != nil && cluster.Server == desired.Server {
+			current = &registered[key][i]
+			continue
+		}
+		if err := c.ArgoDB.DeleteCluster(ctx, cluster.Server); err != nil && status.Code(err) != codes.NotFound {
+			return fmt.Errorf("error deleting cluster %s: %w", cluster.Server, err)
+		}
+		log.WithField("cluster", key).Infof("Deleted cluster %s registered from Cluster API cluster", cluster.Server)
+	}
+	if desired == nil {
+		return nil
+	}
+	if current != nil {
+		// the labels mirroring the tags of the cloud cluster are maintained by the ClusterTagSyncController
+		for k, v := range current.Labels {
+			if strings.HasPrefix(k, common.LabelKeyPrefixCloudClusterTag) {
+				desired.Labels[k] = v
+			}
+		}
+	}
+	if current != nil && current.Name == desired.Name && reflect.DeepEqual(current.Config, desired.Config) &&
+		maps.Equal(current.Labels, desired.Labels) && current.Annotations[common.AnnotationKeyClusterRegistrationSource] == key {
+		return nil
+	}
+	if current == nil {
+		// the clusters which weren't registered from this Cluster API cluster are never overwritten
+		existing, err := c.ArgoDB.GetCluster(ctx, desired.Server)
+		if err == nil && existing.Annotations[common.AnnotationKeyClusterRegistrationSource] != key {
+			return fmt.Errorf("cluster %s is already registered", desired.Server)
+		}
+		if err != nil && status.Code(err) != codes.NotFound {
+			return fmt.Errorf("error getting cluster %s: %w", desired.Server, err)
+		}
+	} else {
+		// the settings of the cluster which don't come from the Cluster API cluster are preserved
+		desired.Namespaces, desired.ClusterResources, desired.Project, desired.Shard = current.Namespaces, current.ClusterResources, current.Project, current.Shard
+		maps.Copy(desired.Annotations, current.Annotations)
+		desired.Annotations[common.AnnotationKeyClusterRegistrationSource] = key
+	}
+	if _, err := c.ArgoDB.UpdateCluster(ctx, desired); err != nil {
+		return fmt.Errorf("error registering cluster %s: %w", desired.Server, err)
+	}
+	log.WithField("cluster", key).Infof("Registered Cluster API cluster as cluster %s", desired.Server)
+	return nil
+}
+
+// clusterFromClusterAPI returns the Argo CD cluster of a Cluster API cluster, with the credentials of its kubeconfig
+// secret and its labels
+func (c *ClusterRegistrationController) clusterFromClusterAPI(ctx context.Context, key string, capiCluster *unstructured.Unstructured) (*argoprojiov1alpha1.Cluster, error) {
+	secretName := capiCluster.GetName() + clusterAPIKubeconfigSecretSuffix
+	secret, err := c.KubeClientset.CoreV1().Secrets(capiCluster.GetNamespace()).Get(ctx, secretName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error getting kubeconfig secret %s: %w", secretName, err)
+	}
+	kubeconfig, err := clientcmd.Load(secret.Data[clusterAPIKubeconfigSecretKey])
+	if err != nil {
+		return nil, fmt.Errorf("error loading kubeconfig of secret %s: %w", secretName, err)
+	}
+	kubeContext, ok := kubeconfig.Contexts[kubeconfig.CurrentContext]
+	if !ok {
+		return nil, fmt.Errorf("current context %q not found in kubeconfig of secret %s", kubeconfig.CurrentContext, secretName)
+	}
+	server, ok := kubeconfig.Clusters[kubeContext.Cluster]
+	if !ok {
+		return nil, fmt.Errorf("cluster %q not found in kubeconfig of secret %s", kubeContext.Cluster, secretName)
+	}
+	authInfo, ok := kubeconfig.AuthInfos[kubeContext.AuthInfo]
+	if !ok {
+		return nil, fmt.Errorf("user %q not found in kubeconfig of secret %s", kubeContext.AuthInfo, secretName)
+	}
+	if authInfo.Token == "" && len(authInfo.ClientCertificateData) == 0 {
+		return nil, fmt.Errorf("kubeconfig of secret %s has neither a token nor a client certificate", secretName)
+	}
+
+	labels := maps.Clone(capiCluster.GetLabels())
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	labels[common.LabelKeyClusterRegistration] = common.LabelValueClusterRegistrationClusterAPI
+	return &argoprojiov1alpha1.Cluster{
+		Name:   capiCluster.GetName(),
+		Server: server.Server,
+		Config: argoprojiov1alpha1.ClusterConfig{
+			BearerToken: authInfo.Token,
+			TLSClientConfig: argoprojiov1alpha1.TLSClientConfig{
+				Insecure:   server.InsecureSkipTLSVerify,
+				ServerName: server.TLSServerName,
+				CertData:   authInfo.ClientCertificateData,
+				KeyData:    authInfo.ClientKeyData,
+				CAData:     server.CertificateAuthorityData,
+			},
+			ProxyUrl: server.ProxyURL,
+		},
+		Labels:      labels,
+		Annotations: map[string]string{common.AnnotationKeyClusterRegistrationSource: key},
+	}, nil
+}
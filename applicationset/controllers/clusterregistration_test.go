@@ -0,0 +1,110 @@
+package controllers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/argoproj/argo-cd/v3/common"
+	"github.com/argoproj/argo-cd/v3/util/db"
+	"github.com/argoproj/argo-cd/v3/util/settings"
+)
+
+const testClusterAPIKubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: workload
+  cluster:
+    server: https://workload.example.com:6443
+contexts:
+- name: workload-admin@workload
+  context:
+    cluster: workload
+    user: workload-admin
+current-context: workload-admin@workload
+users:
+- name: workload-admin
+  user:
+    token: workload-token
+`
+
+func newTestClusterAPICluster(ready bool) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "cluster.x-k8s.io/v1beta1",
+		"kind":       "Cluster",
+		"metadata": map[string]any{
+			"name":      "workload",
+			"namespace": "fleet",
+			"labels":    map[string]any{"env": "staging"},
+		},
+		"status": map[string]any{"controlPlaneReady": ready},
+	}}
+}
+
+func TestClusterRegistrationController_SyncCluster(t *testing.T) {
+	kubeconfigSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "workload-kubeconfig", Namespace: "fleet"},
+		Data:       map[string][]byte{"value": []byte(testClusterAPIKubeconfig)},
+	}
+	kubeclientset := getDefaultTestClientSet(kubeconfigSecret)
+	argodb := db.NewDB("argocd", settings.NewSettingsManager(t.Context(), kubeclientset, "argocd"), kubeclientset)
+	controller := &ClusterRegistrationController{KubeClientset: kubeclientset, ArgoDB: argodb}
+
+	t.Run("control plane not ready", func(t *testing.T) {
+		require.NoError(t, controller.syncCluster(t.Context(), "fleet/workload", newTestClusterAPICluster(false)))
+		clusters, err := argodb.ListClusters(t.Context())
+		require.NoError(t, err)
+		for _, cluster := range clusters.Items {
+			assert.NotEqual(t, "https://workload.example.com:6443", cluster.Server)
+		}
+	})
+
+	t.Run("control plane ready", func(t *testing.T) {
+		require.NoError(t, controller.syncCluster(t.Context(), "fleet/workload", newTestClusterAPICluster(true)))
+		cluster, err := argodb.GetCluster(t.Context(), "https://workload.example.com:6443")
+		require.NoError(t, err)
+		assert.Equal(t, "workload", cluster.Name)
+		assert.Equal(t, "workload-token", cluster.Config.BearerToken)
+		assert.Equal(t, "staging", cluster.Labels["env"])
+		assert.Equal(t, common.LabelValueClusterRegistrationClusterAPI, cluster.Labels[common.LabelKeyClusterRegistration])
+		assert.Equal(t, "fleet/workload", cluster.Annotations[common.AnnotationKeyClusterRegistrationSource])
+	})
+
+	t.Run("cluster deleted", func(t *testing.T) {
+		require.NoError(t, controller.syncCluster(t.Context(), "fleet/workload", nil))
+		_, err := argodb.GetCluster(t.Context(), "https://workload.example.com:6443")
+		require.Error(t, err)
+	})
+}
+
+func TestClusterRegistrationController_SyncCluster_AlreadyRegistered(t *testing.T) {
+	kubeconfigSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "workload-kubeconfig", Namespace: "fleet"},
+		Data:       map[string][]byte{"value": []byte(testClusterAPIKubeconfig)},
+	}
+	clusterSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "workload",
+			Namespace: "argocd",
+			Labels:    map[string]string{common.LabelKeySecretType: common.LabelValueSecretTypeCluster},
+		},
+		Data: map[string][]byte{
+			"name":   []byte("workload"),
+			"server": []byte("https://workload.example.com:6443"),
+			"config": []byte("{}"),
+		},
+	}
+	kubeclientset := getDefaultTestClientSet(kubeconfigSecret, clusterSecret)
+	argodb := db.NewDB("argocd", settings.NewSettingsManager(t.Context(), kubeclientset, "argocd"), kubeclientset)
+	controller := &ClusterRegistrationController{KubeClientset: kubeclientset, ArgoDB: argodb}
+
+	err := controller.syncCluster(t.Context(), "fleet/workload", newTestClusterAPICluster(true))
+	require.ErrorContains(t, err, "already registered")
+	cluster, err := argodb.GetCluster(t.Context(), "https://workload.example.com:6443")
+	require.NoError(t, err)
+	assert.Empty(t, cluster.Config.BearerToken)
+}
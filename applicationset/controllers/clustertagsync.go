@@ -0,0 +1,88 @@
+package controllers
+
+import (
+	"context"
+	"maps"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/argoproj/argo-cd/v3/common"
+	"github.com/argoproj/argo-cd/v3/util/db"
+)
+
+// ClusterTagSyncController mirrors the tags of the cloud managed clusters (EKS, GKE or AKS) onto the labels of their
+// cluster secrets, so that the clusters can be selected by the cluster generators of the ApplicationSets by their cloud
+// metadata. The cloud cluster of a cluster secret is referenced by its argocd.argoproj.io/cloud-cluster-id annotation.
+type ClusterTagSyncController struct {
+	ArgoDB db.ArgoDB
+	// Interval is the period at which the tags of the cloud clusters are read
+	Interval time.Duration
+	// CloudClusterTags returns the tags of the cloud cluster with the given ID
+	CloudClusterTags func(ctx context.Context, cloudClusterID string) (map[string]string, error)
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable, so that only the leader updates the cluster secrets
+func (c *ClusterTagSyncController) NeedLeaderElection() bool {
+	return true
+}
+
+// Start mirrors the tags of the cloud clusters periodically until the context is done
+func (c *ClusterTagSyncController) Start(ctx context.Context) error {
+	wait.UntilWithContext(ctx, c.syncClusters, c.Interval)
+	return nil
+}
+
+func (c *ClusterTagSyncController) syncClusters(ctx context.Context) {
+	clusters, err := c.ArgoDB.ListClusters(ctx)
+	if err != nil {
+		log.Warnf("Failed to list clusters to mirror the cloud cluster tags: %v", err)
+		return
+	}
+	for i := range clusters.Items {
+		cluster := &clusters.Items[i]
+		cloudClusterID := cluster.Annotations[common.AnnotationKeyCloudClusterID]
+		if cloudClusterID == "" {
+			continue
+		}
+		logCtx := log.WithFields(log.Fields{"cluster": cluster.Server, "cloudCluster": cloudClusterID})
+		tags, err := c.CloudClusterTags(ctx, cloudClusterID)
+		if err != nil {
+			logCtx.Warnf("Failed to read the tags of the cloud cluster: %v", err)
+			continue
+		}
+		labels := cloudClusterTagLabels(cluster.Labels, tags, logCtx)
+		if maps.Equal(cluster.Labels, labels) {
+			continue
+		}
+		cluster.Labels = labels
+		if _, err := c.ArgoDB.UpdateCluster(ctx, cluster); err != nil {
+			logCtx.Warnf("Failed to update the labels of the cluster: %v", err)
+			continue
+		}
+		logCtx.Info("Updated the labels of the cluster with the tags of the cloud cluster")
+	}
+}
+
+// cloudClusterTagLabels returns the given labels with the labels mirroring the given tags, which replace the labels
+// mirroring the previous tags. The tags which aren't valid label names or values are ignored.
+func cloudClusterTagLabels(labels map[string]string, tags map[string]string, logCtx *log.Entry) map[string]string {
+	result := map[string]string{}
+	for k, v := range labels {
+		if !strings.HasPrefix(k, common.LabelKeyPrefixCloudClusterTag) {
+			result[k] = v
+		}
+	}
+	for k, v := range tags {
+		key := common.LabelKeyPrefixCloudClusterTag + k
+		if errs := append(validation.IsQualifiedName(key), validation.IsValidLabelValue(v)...); len(errs) > 0 {
+			logCtx.Debugf("Ignoring cloud cluster tag %q: %s", k, strings.Join(errs, ", "))
+			continue
+		}
+		result[key] = v
+	}
+	return result
+}
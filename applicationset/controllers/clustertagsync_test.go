@@ -0,0 +1,65 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/argoproj/argo-cd/v3/common"
+	"github.com/argoproj/argo-cd/v3/util/db"
+	"github.com/argoproj/argo-cd/v3/util/settings"
+)
+
+func TestClusterTagSyncController_SyncClusters(t *testing.T) {
+	newClusterSecret := func(name string, annotations map[string]string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "argocd",
+				Labels: map[string]string{
+					common.LabelKeySecretType: common.LabelValueSecretTypeCluster,
+					"env":                     "staging",
+					common.LabelKeyPrefixCloudClusterTag + "stale": "true",
+				},
+				Annotations: annotations,
+			},
+			Data: map[string][]byte{
+				"name":   []byte(name),
+				"server": []byte("https://" + name + ".example.com"),
+				"config": []byte("{}"),
+			},
+		}
+	}
+	kubeclientset := getDefaultTestClientSet(
+		newClusterSecret("eks", map[string]string{common.AnnotationKeyCloudClusterID: "arn:aws:eks:us-west-2:123456789012:cluster/eks"}),
+		newClusterSecret("failing", map[string]string{common.AnnotationKeyCloudClusterID: "projects/p/locations/l/clusters/failing"}),
+		newClusterSecret("unmanaged", nil),
+	)
+	argodb := db.NewDB("argocd", settings.NewSettingsManager(t.Context(), kubeclientset, "argocd"), kubeclientset)
+	controller := &ClusterTagSyncController{
+		ArgoDB: argodb,
+		CloudClusterTags: func(_ context.Context, cloudClusterID string) (map[string]string, error) {
+			if cloudClusterID != "arn:aws:eks:us-west-2:123456789012:cluster/eks" {
+				return nil, errors.New("not found")
+			}
+			return map[string]string{"team": "payments", "aws:cloudformation:stack-name": "eks", "owner": "Platform Team"}, nil
+		},
+	}
+
+	controller.syncClusters(t.Context())
+
+	cluster, err := argodb.GetCluster(t.Context(), "https://eks.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"env": "staging", common.LabelKeyPrefixCloudClusterTag + "team": "payments"}, cluster.Labels)
+
+	for _, server := range []string{"https://failing.example.com", "https://unmanaged.example.com"} {
+		cluster, err := argodb.GetCluster(t.Context(), server)
+		require.NoError(t, err)
+		assert.Equal(t, "true", cluster.Labels[common.LabelKeyPrefixCloudClusterTag+"stale"], server)
+	}
+}
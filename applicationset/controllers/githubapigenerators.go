@@ -0,0 +1,122 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/argoproj/argo-cd/v3/applicationset/generators"
+	argov1alpha1 "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+)
+
+// gitHubAPIGenerators are the generators calling the GitHub API, whose calls are deferred while the GitHub API rate
+// limit budget of an ApplicationSet is low
+var gitHubAPIGenerators = []string{"PullRequest", "SCMProvider"}
+
+// generatedParams are the parameters generated by the generators calling the GitHub API for an ApplicationSet, by
+// generator spec
+type generatedParams struct {
+	lock   sync.Mutex
+	params map[string][]map[string]any
+}
+
+func (p *generatedParams) get(key string) ([]map[string]any, bool) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	params, ok := p.params[key]
+	return params, ok
+}
+
+func (p *generatedParams) set(key string, params []map[string]any) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.params[key] = params
+}
+
+// paramsRecordingGenerator records the parameters generated by a generator calling the GitHub API. When the calls
+// are deferred, it returns the parameters last generated for the same generator spec instead, if any.
+type paramsRecordingGenerator struct {
+	generators.Generator
+	lastParams *generatedParams
+	params     *generatedParams
+	deferred   bool
+}
+
+func (g *paramsRecordingGenerator) GenerateParams(appSetGenerator *argov1alpha1.ApplicationSetGenerator, applicationSetInfo *argov1alpha1.ApplicationSet, client client.Client) ([]map[string]any, error) {
+	key, err := json.Marshal(appSetGenerator)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling generator: %w", err)
+	}
+	if g.deferred && g.lastParams != nil {
+		if params, ok := g.lastParams.get(string(key)); ok {
+			g.params.set(string(key), params)
+			return params, nil
+		}
+	}
+	params, err := g.Generator.GenerateParams(appSetGenerator, applicationSetInfo, client)
+	if err != nil {
+		return nil, err
+	}
+	g.params.set(string(key), params)
+	return params, nil
+}
+
+// getGenerators returns the generators of an ApplicationSet, along with the parameters the generators calling the
+// GitHub API generate with them. When the calls are deferred, these generators return the parameters they last
+// generated for the ApplicationSet instead of calling the GitHub API.
+func (r *ApplicationSetReconciler) getGenerators(applicationSetInfo *argov1alpha1.ApplicationSet, deferred bool) (map[string]generators.Generator, *generatedParams) {
+	if !r.isGitHubAPIDeferralEnabled() {
+		return r.Generators, nil
+	}
+	var lastParams *generatedParams
+	if value, ok := r.generatedParams.Load(client.ObjectKeyFromObject(applicationSetInfo)); ok {
+		lastParams = value.(*generatedParams)
+	}
+	params := &generatedParams{params: map[string][]map[string]any{}}
+	// the Matrix and Merge generators are composed again, so that they also combine the recording generators
+	terminalGenerators := map[string]generators.Generator{}
+	for name, generator := range r.Generators {
+		if name != "Matrix" && name != "Merge" {
+			terminalGenerators[name] = generator
+		}
+	}
+	for _, name := range gitHubAPIGenerators {
+		if generator, ok := terminalGenerators[name]; ok {
+			terminalGenerators[name] = &paramsRecordingGenerator{Generator: generator, lastParams: lastParams, params: params, deferred: deferred}
+		}
+	}
+	return generators.ComposeGenerators(terminalGenerators), params
+}
+
+// deferGitHubAPICalls returns whether the GitHub API calls of the generators of an ApplicationSet are deferred while
+// the GitHub API rate limit budget of its credentials is low, along with the time the budget is replenished. Only the
+// calls of the refreshes which can wait are deferred, the ApplicationSets refreshed by a webhook or whose spec changed
+// since their last generation being generated with the GitHub API.
+func (r *ApplicationSetReconciler) deferGitHubAPICalls(applicationSetInfo *argov1alpha1.ApplicationSet) (time.Time, bool) {
+	if !r.isGitHubAPIDeferralEnabled() || applicationSetInfo.RefreshRequired() {
+		return time.Time{}, false
+	}
+	key := client.ObjectKeyFromObject(applicationSetInfo)
+	generation, ok := r.generatedGenerations.Load(key)
+	if !ok || generation != applicationSetInfo.Generation {
+		return time.Time{}, false
+	}
+	return r.GitHubRateLimitBudget.IsLow(key.Namespace, key.Name, r.GitHubAPIRateLimitReserve)
+}
+
+func (r *ApplicationSetReconciler) isGitHubAPIDeferralEnabled() bool {
+	return r.GitHubRateLimitBudget != nil && r.GitHubAPIRateLimitReserve > 0
+}
+
+// forgetApplicationSet forgets the generations, the generated parameters and the rate limits of a deleted
+// ApplicationSet
+func (r *ApplicationSetReconciler) forgetApplicationSet(key client.ObjectKey) {
+	r.generatedGenerations.Delete(key)
+	r.generatedParams.Delete(key)
+	if r.GitHubRateLimitBudget != nil {
+		r.GitHubRateLimitBudget.Forget(key.Namespace, key.Name)
+	}
+}
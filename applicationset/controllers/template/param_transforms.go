@@ -0,0 +1,193 @@
+package template
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"strconv"
+	"strings"
+
+	"github.com/argoproj/argo-cd/v3/applicationset/utils"
+	appv1 "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+)
+
+// applyParamTransforms returns a copy of the parameters generated for an Application, transformed in order by the
+// parameter transformations of the ApplicationSet
+func applyParamTransforms(r utils.Renderer, params map[string]any, applicationSetInfo appv1.ApplicationSet) (map[string]any, error) {
+	if len(applicationSetInfo.Spec.ParamTransforms) == 0 {
+		return params, nil
+	}
+	useGoTemplate := applicationSetInfo.Spec.GoTemplate
+	res := cloneParams(params)
+	for i, transform := range applicationSetInfo.Spec.ParamTransforms {
+		if err := applyParamTransform(r, res, transform, useGoTemplate, applicationSetInfo.Spec.GoTemplateOptions); err != nil {
+			return nil, fmt.Errorf("error applying paramTransforms[%d] setting %q: %w", i, transform.Key, err)
+		}
+	}
+	return res, nil
+}
+
+func applyParamTransform(r utils.Renderer, params map[string]any, transform appv1.ApplicationSetParamTransform, useGoTemplate bool, goTemplateOptions []string) error {
+	if transform.Key == "" {
+		return errors.New("key is required")
+	}
+	if (transform.From == "") == (transform.Value == "") {
+		return errors.New("exactly one of from and value must be set")
+	}
+
+	var value any
+	if transform.From != "" {
+		var ok bool
+		value, ok = getParam(params, transform.From, useGoTemplate)
+		if !ok {
+			return fmt.Errorf("parameter %q not found", transform.From)
+		}
+		deleteParam(params, transform.From, useGoTemplate)
+	} else {
+		rendered, err := r.Replace(transform.Value, params, useGoTemplate, goTemplateOptions)
+		if err != nil {
+			return fmt.Errorf("error rendering value: %w", err)
+		}
+		value = rendered
+	}
+
+	if mapped, ok := transform.Map[fmt.Sprint(value)]; ok {
+		value = mapped
+	}
+
+	value, err := convertParam(value, transform.Type)
+	if err != nil {
+		return err
+	}
+	// the parameters of fasttemplate templates are strings
+	if !useGoTemplate {
+		if value, err = formatParam(value); err != nil {
+			return err
+		}
+	}
+	return setParam(params, transform.Key, value, useGoTemplate)
+}
+
+// convertParam converts a parameter value to a type, keeping it as is when the type isn't set
+func convertParam(value any, paramType string) (any, error) {
+	if paramType == "" {
+		return value, nil
+	}
+	str := fmt.Sprint(value)
+	switch paramType {
+	case "string":
+		return str, nil
+	case "int":
+		i, err := strconv.ParseInt(strings.TrimSpace(str), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("error converting %q to int: %w", str, err)
+		}
+		return i, nil
+	case "float":
+		f, err := strconv.ParseFloat(strings.TrimSpace(str), 64)
+		if err != nil {
+			return nil, fmt.Errorf("error converting %q to float: %w", str, err)
+		}
+		return f, nil
+	case "bool":
+		b, err := strconv.ParseBool(strings.TrimSpace(str))
+		if err != nil {
+			return nil, fmt.Errorf("error converting %q to bool: %w", str, err)
+		}
+		return b, nil
+	case "json":
+		var res any
+		if err := json.Unmarshal([]byte(str), &res); err != nil {
+			return nil, fmt.Errorf("error converting %q from json: %w", str, err)
+		}
+		return res, nil
+	default:
+		return nil, fmt.Errorf("unknown type %q", paramType)
+	}
+}
+
+// formatParam formats a parameter value as a string
+func formatParam(value any) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case map[string]any, []any:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return "", fmt.Errorf("error formatting value: %w", err)
+		}
+		return string(data), nil
+	default:
+		return fmt.Sprint(v), nil
+	}
+}
+
+// paramPath returns the keys of a parameter, nested with Go templates
+func paramPath(key string, useGoTemplate bool) []string {
+	if !useGoTemplate {
+		return []string{key}
+	}
+	return strings.Split(key, ".")
+}
+
+func getParam(params map[string]any, key string, useGoTemplate bool) (any, bool) {
+	path := paramPath(key, useGoTemplate)
+	current := params
+	for _, k := range path[:len(path)-1] {
+		nested, ok := current[k].(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		current = nested
+	}
+	value, ok := current[path[len(path)-1]]
+	return value, ok
+}
+
+func deleteParam(params map[string]any, key string, useGoTemplate bool) {
+	path := paramPath(key, useGoTemplate)
+	current := params
+	for _, k := range path[:len(path)-1] {
+		nested, ok := current[k].(map[string]any)
+		if !ok {
+			return
+		}
+		current = nested
+	}
+	delete(current, path[len(path)-1])
+}
+
+func setParam(params map[string]any, key string, value any, useGoTemplate bool) error {
+	path := paramPath(key, useGoTemplate)
+	current := params
+	for i, k := range path[:len(path)-1] {
+		switch nested := current[k].(type) {
+		case map[string]any:
+			current = nested
+		case nil:
+			created := map[string]any{}
+			current[k] = created
+			current = created
+		default:
+			return fmt.Errorf("parameter %q is not an object", strings.Join(path[:i+1], "."))
+		}
+	}
+	current[path[len(path)-1]] = value
+	return nil
+}
+
+// cloneParams deeply copies the nested objects of parameters, so that the transformations don't modify the generated
+// parameters, which may be shared by several templates
+func cloneParams(params map[string]any) map[string]any {
+	res := maps.Clone(params)
+	if res == nil {
+		res = map[string]any{}
+	}
+	for k, v := range res {
+		if nested, ok := v.(map[string]any); ok {
+			res[k] = cloneParams(nested)
+		}
+	}
+	return res
+}
@@ -0,0 +1,145 @@
+package template
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/argoproj/argo-cd/v3/applicationset/utils"
+	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+)
+
+func TestApplyParamTransforms(t *testing.T) {
+	for _, c := range []struct {
+		name          string
+		goTemplate    bool
+		transforms    []v1alpha1.ApplicationSetParamTransform
+		params        map[string]any
+		expected      map[string]any
+		expectedError string
+	}{
+		{
+			name:     "no transforms",
+			params:   map[string]any{"name": "in-cluster"},
+			expected: map[string]any{"name": "in-cluster"},
+		},
+		{
+			name:       "rename nested parameter",
+			goTemplate: true,
+			transforms: []v1alpha1.ApplicationSetParamTransform{
+				{Key: "env", From: "metadata.labels.environment"},
+			},
+			params: map[string]any{
+				"name":     "staging-01",
+				"metadata": map[string]any{"labels": map[string]any{"environment": "staging", "org": "foo"}},
+			},
+			expected: map[string]any{
+				"name":     "staging-01",
+				"env":      "staging",
+				"metadata": map[string]any{"labels": map[string]any{"org": "foo"}},
+			},
+		},
+		{
+			name:       "compute parameters in order",
+			goTemplate: true,
+			transforms: []v1alpha1.ApplicationSetParamTransform{
+				{Key: "app.name", Value: `{{ .name | lower | trunc 5 }}`},
+				{Key: "app.fullName", Value: `{{ .app.name }}-{{ .region }}`},
+				{Key: "app.replicas", Value: `{{ if eq .region "eu" }}3{{ else }}1{{ end }}`, Type: "int"},
+			},
+			params: map[string]any{"name": "Guestbook", "region": "eu"},
+			expected: map[string]any{
+				"name":   "Guestbook",
+				"region": "eu",
+				"app":    map[string]any{"name": "guest", "fullName": "guest-eu", "replicas": int64(3)},
+			},
+		},
+		{
+			name:       "map and convert values",
+			goTemplate: true,
+			transforms: []v1alpha1.ApplicationSetParamTransform{
+				{Key: "env", From: "env", Map: map[string]string{"prod": "production", "stg": "staging"}},
+				{Key: "autoSync", Value: `{{ eq .env "staging" }}`, Type: "bool"},
+				{Key: "weight", Value: "0.5", Type: "float"},
+				{Key: "values", Value: `{"replicas": 2}`, Type: "json"},
+			},
+			params: map[string]any{"env": "stg"},
+			expected: map[string]any{
+				"env":      "staging",
+				"autoSync": true,
+				"weight":   0.5,
+				"values":   map[string]any{"replicas": float64(2)},
+			},
+		},
+		{
+			name: "fasttemplate parameters are strings",
+			transforms: []v1alpha1.ApplicationSetParamTransform{
+				{Key: "env", From: "metadata.labels.environment"},
+				{Key: "url", Value: "https://{{ env }}.example.com"},
+				{Key: "replicas", Value: "007", Type: "int"},
+			},
+			params: map[string]any{"metadata.labels.environment": "staging"},
+			expected: map[string]any{
+				"env":      "staging",
+				"url":      "https://staging.example.com",
+				"replicas": "7",
+			},
+		},
+		{
+			name: "missing parameter",
+			transforms: []v1alpha1.ApplicationSetParamTransform{
+				{Key: "env", From: "environment"},
+			},
+			params:        map[string]any{},
+			expectedError: `error applying paramTransforms[0] setting "env": parameter "environment" not found`,
+		},
+		{
+			name: "from and value",
+			transforms: []v1alpha1.ApplicationSetParamTransform{
+				{Key: "env", From: "environment", Value: "staging"},
+			},
+			params:        map[string]any{"environment": "staging"},
+			expectedError: `error applying paramTransforms[0] setting "env": exactly one of from and value must be set`,
+		},
+		{
+			name:       "invalid conversion",
+			goTemplate: true,
+			transforms: []v1alpha1.ApplicationSetParamTransform{
+				{Key: "replicas", Value: "three", Type: "int"},
+			},
+			params:        map[string]any{},
+			expectedError: `error applying paramTransforms[0] setting "replicas": error converting "three" to int: strconv.ParseInt: parsing "three": invalid syntax`,
+		},
+		{
+			name:       "key nested in a value",
+			goTemplate: true,
+			transforms: []v1alpha1.ApplicationSetParamTransform{
+				{Key: "name.short", Value: "guest"},
+			},
+			params:        map[string]any{"name": "guestbook"},
+			expectedError: `error applying paramTransforms[0] setting "name.short": parameter "name" is not an object`,
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			appSet := v1alpha1.ApplicationSet{
+				Spec: v1alpha1.ApplicationSetSpec{
+					GoTemplate:      c.goTemplate,
+					ParamTransforms: c.transforms,
+				},
+			}
+			original := cloneParams(c.params)
+
+			got, err := applyParamTransforms(&utils.Render{}, c.params, appSet)
+
+			if c.expectedError != "" {
+				require.EqualError(t, err, c.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, c.expected, got)
+			// the generated parameters are left as is
+			assert.Equal(t, original, c.params)
+		})
+	}
+}
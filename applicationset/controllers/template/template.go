@@ -1,26 +1,45 @@
 package template
 
 import (
+	"context"
 	"fmt"
+	"strings"
 
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	log "github.com/sirupsen/logrus"
 
 	"github.com/argoproj/argo-cd/v3/applicationset/generators"
 	"github.com/argoproj/argo-cd/v3/applicationset/utils"
+	"github.com/argoproj/argo-cd/v3/common"
+	"github.com/argoproj/argo-cd/v3/util/glob"
 
 	argov1alpha1 "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
 )
 
-func GenerateApplications(logCtx *log.Entry, applicationSetInfo argov1alpha1.ApplicationSet, g map[string]generators.Generator, renderer utils.Renderer, client client.Client) ([]argov1alpha1.Application, argov1alpha1.ApplicationSetReasonType, error) {
+// tracer creates the spans of the generator execution and template rendering, which are exported when OTLP tracing is
+// enabled
+var tracer = otel.Tracer("github.com/argoproj/argo-cd/v3/applicationset/controllers/template")
+
+func GenerateApplications(ctx context.Context, logCtx *log.Entry, applicationSetInfo argov1alpha1.ApplicationSet, g map[string]generators.Generator, renderer utils.Renderer, client client.Client) ([]argov1alpha1.Application, argov1alpha1.ApplicationSetReasonType, error) {
 	var res []argov1alpha1.Application
 
 	var firstError error
 	var applicationSetReason argov1alpha1.ApplicationSetReasonType
 
 	for _, requestedGenerator := range applicationSetInfo.Spec.Generators {
+		generatorType := attribute.String("generator.type", strings.Join(generators.GetGeneratorTypes(&requestedGenerator), ","))
+		_, generateSpan := tracer.Start(ctx, "generate", trace.WithAttributes(generatorType))
 		t, err := generators.Transform(requestedGenerator, g, applicationSetInfo.Spec.Template, &applicationSetInfo, map[string]any{}, client)
+		if err != nil {
+			generateSpan.SetStatus(codes.Error, err.Error())
+		}
+		generateSpan.End()
 		if err != nil {
 			logCtx.WithError(err).WithField("generator", requestedGenerator).
 				Error("error generating application from params")
@@ -31,10 +50,25 @@ func GenerateApplications(logCtx *log.Entry, applicationSetInfo argov1alpha1.App
 			continue
 		}
 
+		_, renderSpan := tracer.Start(ctx, "render", trace.WithAttributes(generatorType))
+		renderErrors := 0
 		for _, a := range t {
 			tmplApplication := GetTempApplication(a.Template)
 
 			for _, p := range a.Params {
+				p, err := applyParamTransforms(renderer, p, applicationSetInfo)
+				if err != nil {
+					logCtx.WithError(err).WithField("params", a.Params).WithField("generator", requestedGenerator).
+						Error("error transforming params")
+
+					if firstError == nil {
+						firstError = err
+						applicationSetReason = argov1alpha1.ApplicationSetReasonRenderTemplateParamsError
+					}
+					renderErrors++
+					continue
+				}
+
 				app, err := renderer.RenderTemplateParams(tmplApplication, applicationSetInfo.Spec.SyncPolicy, p, applicationSetInfo.Spec.GoTemplate, applicationSetInfo.Spec.GoTemplateOptions)
 				if err != nil {
 					logCtx.WithError(err).WithField("params", a.Params).WithField("generator", requestedGenerator).
@@ -44,6 +78,7 @@ func GenerateApplications(logCtx *log.Entry, applicationSetInfo argov1alpha1.App
 						firstError = err
 						applicationSetReason = argov1alpha1.ApplicationSetReasonRenderTemplateParamsError
 					}
+					renderErrors++
 					continue
 				}
 
@@ -57,6 +92,7 @@ func GenerateApplications(logCtx *log.Entry, applicationSetInfo argov1alpha1.App
 							firstError = err
 							applicationSetReason = argov1alpha1.ApplicationSetReasonRenderTemplateParamsError
 						}
+						renderErrors++
 						continue
 					}
 
@@ -66,9 +102,14 @@ func GenerateApplications(logCtx *log.Entry, applicationSetInfo argov1alpha1.App
 				// The app's namespace must be the same as the AppSet's namespace to preserve the appsets-in-any-namespace
 				// security boundary.
 				app.Namespace = applicationSetInfo.Namespace
+				propagateMetadata(app, applicationSetInfo)
 				res = append(res, *app)
 			}
 		}
+		if renderErrors > 0 {
+			renderSpan.SetStatus(codes.Error, fmt.Sprintf("failed to render %d applications", renderErrors))
+		}
+		renderSpan.End()
 		if log.IsLevelEnabled(log.DebugLevel) {
 			logCtx.WithField("generator", requestedGenerator).Debugf("apps from generator: %+v", res)
 		} else {
@@ -88,6 +129,41 @@ func renderTemplatePatch(r utils.Renderer, app *argov1alpha1.Application, applic
 	return applyTemplatePatch(app, replacedTemplate)
 }
 
+// nonPropagatedMetadata are the labels and annotations of the ApplicationSet which are never propagated to the
+// generated Applications, since they are specific to the ApplicationSet
+var nonPropagatedMetadata = map[string]bool{
+	common.AnnotationApplicationSetRefresh:            true,
+	common.AnnotationApplicationSetManagedLabels:      true,
+	common.AnnotationApplicationSetManagedAnnotations: true,
+	common.AnnotationKeyAppInstance:                   true,
+	common.LabelKeyAppInstance:                        true,
+	corev1.LastAppliedConfigAnnotation:                true,
+}
+
+// propagateMetadata copies the labels and annotations of the ApplicationSet matching the propagation policies to a
+// generated Application, unless it already has them
+func propagateMetadata(app *argov1alpha1.Application, applicationSetInfo argov1alpha1.ApplicationSet) {
+	propagation := applicationSetInfo.Spec.MetadataPropagation
+	if propagation == nil {
+		return
+	}
+	app.Labels = propagateKeys(app.Labels, applicationSetInfo.Labels, propagation.Labels.GetPropagate())
+	app.Annotations = propagateKeys(app.Annotations, applicationSetInfo.Annotations, propagation.Annotations.GetPropagate())
+}
+
+func propagateKeys(dest map[string]string, src map[string]string, patterns []string) map[string]string {
+	for key, value := range src {
+		if _, exists := dest[key]; exists || nonPropagatedMetadata[key] || !glob.MatchStringInList(patterns, key, glob.GLOB) {
+			continue
+		}
+		if dest == nil {
+			dest = map[string]string{}
+		}
+		dest[key] = value
+	}
+	return dest
+}
+
 func GetTempApplication(applicationSetTemplate argov1alpha1.ApplicationSetTemplate) *argov1alpha1.Application {
 	var tmplApplication argov1alpha1.Application
 	tmplApplication.Annotations = applicationSetTemplate.Annotations
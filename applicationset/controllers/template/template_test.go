@@ -119,7 +119,7 @@ func TestGenerateApplications(t *testing.T) {
 			}
 			renderer := &rendererMock
 
-			got, reason, err := GenerateApplications(log.NewEntry(log.StandardLogger()), v1alpha1.ApplicationSet{
+			got, reason, err := GenerateApplications(t.Context(), log.NewEntry(log.StandardLogger()), v1alpha1.ApplicationSet{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "name",
 					Namespace: "namespace",
@@ -221,7 +221,7 @@ func TestMergeTemplateApplications(t *testing.T) {
 			}
 			renderer := &rendererMock
 
-			got, _, _ := GenerateApplications(log.NewEntry(log.StandardLogger()), v1alpha1.ApplicationSet{
+			got, _, _ := GenerateApplications(t.Context(), log.NewEntry(log.StandardLogger()), v1alpha1.ApplicationSet{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "name",
 					Namespace: "namespace",
@@ -328,7 +328,7 @@ func TestGenerateAppsUsingPullRequestGenerator(t *testing.T) {
 			}
 			renderer := &utils.Render{}
 
-			gotApp, _, _ := GenerateApplications(log.NewEntry(log.StandardLogger()), v1alpha1.ApplicationSet{
+			gotApp, _, _ := GenerateApplications(t.Context(), log.NewEntry(log.StandardLogger()), v1alpha1.ApplicationSet{
 				Spec: v1alpha1.ApplicationSetSpec{
 					GoTemplate: true,
 					Generators: []v1alpha1.ApplicationSetGenerator{{
@@ -348,3 +348,42 @@ func TestGenerateAppsUsingPullRequestGenerator(t *testing.T) {
 		})
 	}
 }
+
+func TestPropagateMetadata(t *testing.T) {
+	appSet := v1alpha1.ApplicationSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "name",
+			Namespace: "namespace",
+			Labels: map[string]string{
+				"team":                       "appset-team",
+				"env":                        "prod",
+				"example.com/owner":          "owner",
+				"app.kubernetes.io/instance": "appsets",
+			},
+			Annotations: map[string]string{
+				"example.com/cost-center": "cc",
+				"notes":                   "notes",
+				"argocd.argoproj.io/application-set-refresh": "true",
+			},
+		},
+	}
+
+	t.Run("no propagation", func(t *testing.T) {
+		app := &v1alpha1.Application{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"team": "app-team"}}}
+		propagateMetadata(app, appSet)
+		assert.Equal(t, map[string]string{"team": "app-team"}, app.Labels)
+		assert.Nil(t, app.Annotations)
+	})
+
+	t.Run("propagated keys", func(t *testing.T) {
+		appSet := appSet.DeepCopy()
+		appSet.Spec.MetadataPropagation = &v1alpha1.ApplicationSetMetadataPropagation{
+			Labels:      &v1alpha1.MetadataPropagationPolicy{Propagate: []string{"team", "example.com/*", "app.kubernetes.io/*"}},
+			Annotations: &v1alpha1.MetadataPropagationPolicy{Propagate: []string{"*"}},
+		}
+		app := &v1alpha1.Application{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"team": "app-team"}}}
+		propagateMetadata(app, *appSet)
+		assert.Equal(t, map[string]string{"team": "app-team", "example.com/owner": "owner"}, app.Labels)
+		assert.Equal(t, map[string]string{"example.com/cost-center": "cc", "notes": "notes"}, app.Annotations)
+	})
+}
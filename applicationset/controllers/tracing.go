@@ -0,0 +1,6 @@
+package controllers
+
+import "go.opentelemetry.io/otel"
+
+// tracer creates the spans of the ApplicationSet controller, which are exported when OTLP tracing is enabled
+var tracer = otel.Tracer("github.com/argoproj/argo-cd/v3/applicationset/controllers")
@@ -60,9 +60,14 @@ func (g *ClusterGenerator) GenerateParams(appSetGenerator *argoappsetv1alpha1.Ap
 		return nil, ErrEmptyAppSetGenerator
 	}
 
-	// Do not include the local cluster in the cluster parameters IF there is a non-empty selector
+	// Do not include the local cluster in the cluster parameters IF all the selector groups are non-empty
 	// - Since local clusters do not have secrets, they do not have labels to match against
-	ignoreLocalClusters := len(appSetGenerator.Clusters.Selector.MatchExpressions) > 0 || len(appSetGenerator.Clusters.Selector.MatchLabels) > 0
+	ignoreLocalClusters := true
+	for _, selector := range appSetGenerator.Clusters.GetSelectors() {
+		if len(selector.MatchExpressions) == 0 && len(selector.MatchLabels) == 0 {
+			ignoreLocalClusters = false
+		}
+	}
 
 	// ListCluster will include the local cluster in the list of clusters
 	clustersFromArgoCD, err := utils.ListClusters(g.ctx, g.clientset, g.namespace)
@@ -177,26 +182,28 @@ func (g *ClusterGenerator) getClusterParameters(cluster corev1.Secret, appSet *a
 	return params
 }
 
+// getSecretsByClusterName returns the secrets of the clusters matching any of the selector groups of the generator
 func (g *ClusterGenerator) getSecretsByClusterName(log *log.Entry, appSetGenerator *argoappsetv1alpha1.ApplicationSetGenerator) (map[string]corev1.Secret, error) {
-	clusterSecretList := &corev1.SecretList{}
+	res := map[string]corev1.Secret{}
 
-	selector := metav1.AddLabelToSelector(&appSetGenerator.Clusters.Selector, common.LabelKeySecretType, common.LabelValueSecretTypeCluster)
-	secretSelector, err := metav1.LabelSelectorAsSelector(selector)
-	if err != nil {
-		return nil, fmt.Errorf("error converting label selector: %w", err)
-	}
+	for _, selector := range appSetGenerator.Clusters.GetSelectors() {
+		clusterSecretList := &corev1.SecretList{}
 
-	if err := g.List(context.Background(), clusterSecretList, client.MatchingLabelsSelector{Selector: secretSelector}); err != nil {
-		return nil, err
-	}
-	log.Debugf("clusters matching labels: %d", len(clusterSecretList.Items))
+		secretSelector, err := metav1.LabelSelectorAsSelector(metav1.AddLabelToSelector(selector.DeepCopy(), common.LabelKeySecretType, common.LabelValueSecretTypeCluster))
+		if err != nil {
+			return nil, fmt.Errorf("error converting label selector: %w", err)
+		}
 
-	res := map[string]corev1.Secret{}
+		if err := g.List(context.Background(), clusterSecretList, client.MatchingLabelsSelector{Selector: secretSelector}); err != nil {
+			return nil, err
+		}
+		log.Debugf("clusters matching labels: %d", len(clusterSecretList.Items))
 
-	for _, cluster := range clusterSecretList.Items {
-		clusterName := string(cluster.Data["name"])
+		for _, cluster := range clusterSecretList.Items {
+			clusterName := string(cluster.Data["name"])
 
-		res[clusterName] = cluster
+			res[clusterName] = cluster
+		}
 	}
 
 	return res, nil
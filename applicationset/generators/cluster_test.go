@@ -87,6 +87,7 @@ func TestGenerateParams(t *testing.T) {
 	testCases := []struct {
 		name       string
 		selector   metav1.LabelSelector
+		selectors  []metav1.LabelSelector
 		isFlatMode bool
 		values     map[string]string
 		expected   []map[string]any
@@ -297,6 +298,71 @@ func TestGenerateParams(t *testing.T) {
 			clientError:   false,
 			expectedError: nil,
 		},
+		{
+			name: "production or staging label selector groups",
+			selectors: []metav1.LabelSelector{
+				{MatchLabels: map[string]string{"environment": "production"}},
+				{MatchLabels: map[string]string{"org": "foo"}},
+				{MatchLabels: map[string]string{"org": "bar"}},
+			},
+			values: nil,
+			expected: []map[string]any{
+				{
+					"name": "production_01/west", "nameNormalized": "production-01-west", "server": "https://production-01.example.com", "metadata.labels.environment": "production", "metadata.labels.org": "bar",
+					"metadata.labels.argocd.argoproj.io/secret-type": "cluster", "metadata.annotations.foo.argoproj.io": "production", "project": "prod-project",
+				},
+				{
+					"name": "staging-01", "nameNormalized": "staging-01", "server": "https://staging-01.example.com", "metadata.labels.environment": "staging", "metadata.labels.org": "foo",
+					"metadata.labels.argocd.argoproj.io/secret-type": "cluster", "metadata.annotations.foo.argoproj.io": "staging", "project": "",
+				},
+			},
+			clientError:   false,
+			expectedError: nil,
+		},
+		{
+			name: "selector and label selector groups",
+			selector: metav1.LabelSelector{
+				MatchLabels: map[string]string{"environment": "staging"},
+			},
+			selectors: []metav1.LabelSelector{
+				{MatchLabels: map[string]string{"org": "baz"}},
+			},
+			values: nil,
+			expected: []map[string]any{
+				{
+					"name": "staging-01", "nameNormalized": "staging-01", "server": "https://staging-01.example.com", "metadata.labels.environment": "staging", "metadata.labels.org": "foo",
+					"metadata.labels.argocd.argoproj.io/secret-type": "cluster", "metadata.annotations.foo.argoproj.io": "staging", "project": "",
+				},
+			},
+			clientError:   false,
+			expectedError: nil,
+		},
+		{
+			name: "empty label selector group with flat mode",
+			selectors: []metav1.LabelSelector{
+				{MatchLabels: map[string]string{"environment": "production"}},
+				{},
+			},
+			isFlatMode: true,
+			values:     nil,
+			expected: []map[string]any{
+				{
+					"clusters": []map[string]any{
+						{"nameNormalized": "in-cluster", "name": "in-cluster", "server": "https://kubernetes.default.svc", "project": ""},
+						{
+							"name": "production_01/west", "nameNormalized": "production-01-west", "server": "https://production-01.example.com", "metadata.labels.environment": "production", "metadata.labels.org": "bar",
+							"metadata.labels.argocd.argoproj.io/secret-type": "cluster", "metadata.annotations.foo.argoproj.io": "production", "project": "prod-project",
+						},
+						{
+							"name": "staging-01", "nameNormalized": "staging-01", "server": "https://staging-01.example.com", "metadata.labels.environment": "staging", "metadata.labels.org": "foo",
+							"metadata.labels.argocd.argoproj.io/secret-type": "cluster", "metadata.annotations.foo.argoproj.io": "staging", "project": "",
+						},
+					},
+				},
+			},
+			clientError:   false,
+			expectedError: nil,
+		},
 	}
 
 	// convert []client.Object to []runtime.Object, for use by kubefake package
@@ -326,9 +392,10 @@ func TestGenerateParams(t *testing.T) {
 
 			got, err := clusterGenerator.GenerateParams(&argoprojiov1alpha1.ApplicationSetGenerator{
 				Clusters: &argoprojiov1alpha1.ClusterGenerator{
-					Selector: testCase.selector,
-					Values:   testCase.values,
-					FlatList: testCase.isFlatMode,
+					Selector:  testCase.selector,
+					Selectors: testCase.selectors,
+					Values:    testCase.values,
+					FlatList:  testCase.isFlatMode,
 				},
 			}, &applicationSetInfo, nil)
 
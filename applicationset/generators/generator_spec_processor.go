@@ -104,6 +104,17 @@ func Transform(requestedGenerator argoprojiov1alpha1.ApplicationSetGenerator, al
 func GetRelevantGenerators(requestedGenerator *argoprojiov1alpha1.ApplicationSetGenerator, generators map[string]Generator) []Generator {
 	var res []Generator
 
+	for _, name := range GetGeneratorTypes(requestedGenerator) {
+		res = append(res, generators[name])
+	}
+
+	return res
+}
+
+// GetGeneratorTypes returns the types of the generators set in the requested generator, e.g. Git or Matrix
+func GetGeneratorTypes(requestedGenerator *argoprojiov1alpha1.ApplicationSetGenerator) []string {
+	var res []string
+
 	v := reflect.Indirect(reflect.ValueOf(requestedGenerator))
 	for i := 0; i < v.NumField(); i++ {
 		field := v.Field(i)
@@ -116,7 +127,7 @@ func GetRelevantGenerators(requestedGenerator *argoprojiov1alpha1.ApplicationSet
 		}
 
 		if !reflect.ValueOf(field.Interface()).IsNil() {
-			res = append(res, generators[name])
+			res = append(res, name)
 		}
 	}
 
@@ -400,6 +400,21 @@ func TestGetRelevantGenerators(t *testing.T) {
 	assert.IsType(t, &GitGenerator{}, relevantGenerators[0])
 }
 
+func TestGetGeneratorTypes(t *testing.T) {
+	requestedGenerator := &argov1alpha1.ApplicationSetGenerator{
+		List:     &argov1alpha1.ListGenerator{},
+		Selector: &metav1.LabelSelector{},
+	}
+	assert.Equal(t, []string{"List"}, GetGeneratorTypes(requestedGenerator))
+
+	requestedGenerator = &argov1alpha1.ApplicationSetGenerator{
+		Matrix: &argov1alpha1.MatrixGenerator{},
+	}
+	assert.Equal(t, []string{"Matrix"}, GetGeneratorTypes(requestedGenerator))
+
+	assert.Empty(t, GetGeneratorTypes(&argov1alpha1.ApplicationSetGenerator{}))
+}
+
 func TestInterpolateGenerator(t *testing.T) {
 	requestedGenerator := &argov1alpha1.ApplicationSetGenerator{
 		Clusters: &argov1alpha1.ClusterGenerator{
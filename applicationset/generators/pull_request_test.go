@@ -56,6 +56,41 @@ func TestPullRequestGithubGenerateParams(t *testing.T) {
 			},
 			expectedErr: nil,
 		},
+		{
+			selectFunc: func(context.Context, *argoprojiov1alpha1.PullRequestGenerator, *argoprojiov1alpha1.ApplicationSet) (pullrequest.PullRequestService, error) {
+				return pullrequest.NewFakeService(
+					ctx,
+					[]*pullrequest.PullRequest{
+						{
+							Number:         1,
+							Title:          "title1",
+							Branch:         "branch1",
+							TargetBranch:   "master",
+							HeadSHA:        "089d92cbf9ff857a39e6feccd32798ca700fb958",
+							Author:         "testName",
+							PipelineStatus: "success",
+						},
+					},
+					nil,
+				)
+			},
+			expected: []map[string]any{
+				{
+					"number":             "1",
+					"title":              "title1",
+					"branch":             "branch1",
+					"branch_slug":        "branch1",
+					"target_branch":      "master",
+					"target_branch_slug": "master",
+					"head_sha":           "089d92cbf9ff857a39e6feccd32798ca700fb958",
+					"head_short_sha":     "089d92cb",
+					"head_short_sha_7":   "089d92c",
+					"author":             "testName",
+					"pipeline_status":    "success",
+				},
+			},
+			expectedErr: nil,
+		},
 		{
 			selectFunc: func(context.Context, *argoprojiov1alpha1.PullRequestGenerator, *argoprojiov1alpha1.ApplicationSet) (pullrequest.PullRequestService, error) {
 				return pullrequest.NewFakeService(
@@ -296,6 +296,7 @@ func (g *SCMProviderGenerator) githubProvider(ctx context.Context, github *argop
 		}
 
 		if g.enableGitHubAPIMetrics {
+			metricsCtx.Credentials = services.GitHubAppCredentials(*auth)
 			return scm_provider.NewGithubAppProviderFor(*auth, github.Organization, github.API, github.AllBranches, httpClient)
 		}
 		return scm_provider.NewGithubAppProviderFor(*auth, github.Organization, github.API, github.AllBranches)
@@ -307,6 +308,7 @@ func (g *SCMProviderGenerator) githubProvider(ctx context.Context, github *argop
 	}
 
 	if g.enableGitHubAPIMetrics {
+		metricsCtx.Credentials = services.GitHubTokenCredentials(token)
 		return scm_provider.NewGithubProvider(github.Organization, token, github.API, github.AllBranches, httpClient)
 	}
 	return scm_provider.NewGithubProvider(github.Organization, token, github.API, github.AllBranches)
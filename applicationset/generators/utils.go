@@ -2,6 +2,7 @@ package generators
 
 import (
 	"context"
+	"maps"
 
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
@@ -21,29 +22,19 @@ func GetGenerators(ctx context.Context, c client.Client, k8sClient kubernetes.In
 		"Plugin":                  NewPluginGenerator(c, namespace),
 	}
 
-	nestedGenerators := map[string]Generator{
-		"List":                    terminalGenerators["List"],
-		"Clusters":                terminalGenerators["Clusters"],
-		"Git":                     terminalGenerators["Git"],
-		"SCMProvider":             terminalGenerators["SCMProvider"],
-		"ClusterDecisionResource": terminalGenerators["ClusterDecisionResource"],
-		"PullRequest":             terminalGenerators["PullRequest"],
-		"Plugin":                  terminalGenerators["Plugin"],
-		"Matrix":                  NewMatrixGenerator(terminalGenerators),
-		"Merge":                   NewMergeGenerator(terminalGenerators),
-	}
+	return ComposeGenerators(terminalGenerators)
+}
 
-	topLevelGenerators := map[string]Generator{
-		"List":                    terminalGenerators["List"],
-		"Clusters":                terminalGenerators["Clusters"],
-		"Git":                     terminalGenerators["Git"],
-		"SCMProvider":             terminalGenerators["SCMProvider"],
-		"ClusterDecisionResource": terminalGenerators["ClusterDecisionResource"],
-		"PullRequest":             terminalGenerators["PullRequest"],
-		"Plugin":                  terminalGenerators["Plugin"],
-		"Matrix":                  NewMatrixGenerator(nestedGenerators),
-		"Merge":                   NewMergeGenerator(nestedGenerators),
-	}
+// ComposeGenerators returns the top level generators, i.e. the terminal generators along with the Matrix and Merge
+// generators combining them
+func ComposeGenerators(terminalGenerators map[string]Generator) map[string]Generator {
+	nestedGenerators := maps.Clone(terminalGenerators)
+	nestedGenerators["Matrix"] = NewMatrixGenerator(terminalGenerators)
+	nestedGenerators["Merge"] = NewMergeGenerator(terminalGenerators)
+
+	topLevelGenerators := maps.Clone(terminalGenerators)
+	topLevelGenerators["Matrix"] = NewMatrixGenerator(nestedGenerators)
+	topLevelGenerators["Merge"] = NewMergeGenerator(nestedGenerators)
 
 	return topLevelGenerators
 }
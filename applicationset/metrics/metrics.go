@@ -32,7 +32,8 @@ var (
 )
 
 type ApplicationsetMetrics struct {
-	reconcileHistogram *prometheus.HistogramVec
+	reconcileHistogram     *prometheus.HistogramVec
+	deferredRefreshCounter *prometheus.CounterVec
 }
 
 type appsetCollector struct {
@@ -52,24 +53,44 @@ func NewApplicationsetMetrics(appsetLister applisters.ApplicationSetLister, apps
 		descAppsetDefaultLabels,
 	)
 
+	deferredRefreshCounter := newDeferredRefreshCounter()
+
 	appsetCollector := newAppsetCollector(appsetLister, appsetLabels, appsetFilter)
 
 	// Register collectors and metrics
 	metrics.Registry.MustRegister(reconcileHistogram)
+	metrics.Registry.MustRegister(deferredRefreshCounter)
 	metrics.Registry.MustRegister(appsetCollector)
 
 	kubectl.RegisterWithClientGo()
 	kubectl.RegisterWithPrometheus(metrics.Registry)
 
 	return ApplicationsetMetrics{
-		reconcileHistogram: reconcileHistogram,
+		reconcileHistogram:     reconcileHistogram,
+		deferredRefreshCounter: deferredRefreshCounter,
 	}
 }
 
+func newDeferredRefreshCounter() *prometheus.CounterVec {
+	return prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "argocd_appset_github_api_deferred_refreshes_total",
+			Help: "Number of applicationset refreshes whose GitHub API calls were deferred because the GitHub API rate limit budget is low.",
+		},
+		descAppsetDefaultLabels,
+	)
+}
+
 func (m *ApplicationsetMetrics) ObserveReconcile(appset *argoappv1.ApplicationSet, duration time.Duration) {
 	m.reconcileHistogram.WithLabelValues(appset.Namespace, appset.Name).Observe(duration.Seconds())
 }
 
+// IncDeferredRefresh increments the number of refreshes of an applicationset whose GitHub API calls were deferred to
+// spare the GitHub API rate limit
+func (m *ApplicationsetMetrics) IncDeferredRefresh(appset *argoappv1.ApplicationSet) {
+	m.deferredRefreshCounter.WithLabelValues(appset.Namespace, appset.Name).Inc()
+}
+
 func newAppsetCollector(lister applisters.ApplicationSetLister, labels []string, filter func(appset *argoappv1.ApplicationSet) bool) *appsetCollector {
 	descAppsetDefaultLabels = []string{"namespace", "name"}
 
@@ -231,6 +231,25 @@ argocd_appset_reconcile_count{name="test1",namespace="argocd"} 1
 `)
 }
 
+func TestIncDeferredRefresh(t *testing.T) {
+	appsetList := newFakeAppsets(fakeAppsetList)
+	client := initializeClient(appsetList)
+	metrics.Registry = prometheus.NewRegistry()
+
+	appsetMetrics := NewApplicationsetMetrics(utils.NewAppsetLister(client), collectedLabels, filter)
+
+	req, err := http.NewRequest(http.MethodGet, "/metrics", http.NoBody)
+	require.NoError(t, err)
+	rr := httptest.NewRecorder()
+	handler := promhttp.HandlerFor(metrics.Registry, promhttp.HandlerOpts{})
+	appsetMetrics.IncDeferredRefresh(&appsetList[0])
+	appsetMetrics.IncDeferredRefresh(&appsetList[0])
+	handler.ServeHTTP(rr, req)
+	assert.Contains(t, rr.Body.String(), `
+argocd_appset_github_api_deferred_refreshes_total{name="test1",namespace="argocd"} 2
+`)
+}
+
 func initializeClient(appsets []argoappv1.ApplicationSet) ctrlclient.WithWatch {
 	scheme := runtime.NewScheme()
 	err := argoappv1.AddToScheme(scheme)
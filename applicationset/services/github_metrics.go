@@ -123,6 +123,9 @@ func init() {
 type MetricsContext struct {
 	AppSetNamespace string
 	AppSetName      string
+	// Credentials identifies the token or the GitHub App installation the requests are authenticated with, whose rate
+	// limit is recorded in the rate limit budget
+	Credentials string
 }
 
 // GitHubMetricsTransport is a custom http.RoundTripper that collects GitHub API metrics
@@ -130,6 +133,7 @@ type GitHubMetricsTransport struct {
 	transport      http.RoundTripper
 	metricsContext *MetricsContext
 	metrics        *GitHubMetrics
+	budget         *GitHubRateLimitBudget
 }
 
 // RoundTrip implements http.RoundTripper interface and collects metrics along with debug logging
@@ -165,6 +169,7 @@ func (t *GitHubMetricsTransport) RoundTrip(req *http.Request) (*http.Response, e
 	t.metrics.RequestTotal.WithLabelValues(method, endpoint, status, appsetNamespace, appsetName).Inc()
 
 	if resp != nil {
+		var resetAt time.Time
 		resetHumanReadableTime := ""
 		remainingInt := 0
 		limitInt := 0
@@ -175,6 +180,7 @@ func (t *GitHubMetricsTransport) RoundTrip(req *http.Request) (*http.Response, e
 		if resetTime := resp.Header.Get("X-RateLimit-Reset"); resetTime != "" {
 			if resetUnix, err := strconv.ParseInt(resetTime, 10, 64); err == nil {
 				// Calculate seconds until reset (reset timestamp - current time)
+				resetAt = time.Unix(resetUnix, 0)
 				secondsUntilReset := resetUnix - time.Now().Unix()
 				t.metrics.RateLimitReset.WithLabelValues(endpoint, appsetNamespace, appsetName, resource).Set(float64(secondsUntilReset))
 				resetHumanReadableTime = time.Unix(resetUnix, 0).Local().Format("2006-01-02 15:04:05 MST")
@@ -196,6 +202,10 @@ func (t *GitHubMetricsTransport) RoundTrip(req *http.Request) (*http.Response, e
 			}
 		}
 
+		if t.budget != nil && !resetAt.IsZero() {
+			t.budget.Record(t.metricsContext, resource, remainingInt, limitInt, resetAt)
+		}
+
 		log.WithFields(log.Fields{
 			"endpoint":       endpoint,
 			"reset":          resetHumanReadableTime,
@@ -215,11 +225,13 @@ func NewGitHubMetricsTransport(
 	transport http.RoundTripper,
 	metricsContext *MetricsContext,
 	metrics *GitHubMetrics,
+	budget *GitHubRateLimitBudget,
 ) *GitHubMetricsTransport {
 	return &GitHubMetricsTransport{
 		transport:      transport,
 		metricsContext: metricsContext,
 		metrics:        metrics,
+		budget:         budget,
 	}
 }
 
@@ -229,6 +241,7 @@ func NewDefaultGitHubMetricsTransport(transport http.RoundTripper, metricsContex
 		transport,
 		metricsContext,
 		globalGitHubMetrics,
+		globalGitHubRateLimitBudget,
 	)
 }
 
@@ -88,12 +88,14 @@ func TestGitHubMetrics_CollectorApproach_Success(t *testing.T) {
 	}))
 	defer ts.Close()
 
-	metricsCtx := &MetricsContext{AppSetNamespace: appsetNamespace, AppSetName: appsetName}
+	metricsCtx := &MetricsContext{AppSetNamespace: appsetNamespace, AppSetName: appsetName, Credentials: "token:test"}
+	budget := NewGitHubRateLimitBudget()
 	client := &http.Client{
 		Transport: NewGitHubMetricsTransport(
 			http.DefaultTransport,
 			metricsCtx,
 			metrics,
+			budget,
 		),
 	}
 
@@ -104,6 +106,11 @@ func TestGitHubMetrics_CollectorApproach_Success(t *testing.T) {
 	}
 	resp.Body.Close()
 
+	_, low := budget.IsLow(appsetNamespace, appsetName, 50)
+	assert.True(t, low)
+	_, low = budget.IsLow(appsetNamespace, appsetName, 40)
+	assert.False(t, low)
+
 	// Expose and scrape metrics
 	handler := promhttp.HandlerFor(reg, promhttp.HandlerOpts{})
 	server := httptest.NewServer(handler)
@@ -0,0 +1,117 @@
+package services
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/argoproj/argo-cd/v3/applicationset/services/github_app_auth"
+)
+
+// GitHubRateLimitBudget tracks the remaining GitHub API rate limit of the credentials, i.e. the tokens and the GitHub
+// App installations, used by the ApplicationSets, from the rate limit headers of the GitHub API responses
+type GitHubRateLimitBudget struct {
+	lock sync.RWMutex
+	// rateLimits are the last rate limits returned by the GitHub API, by credentials and rate limit resource
+	rateLimits map[string]gitHubRateLimit
+	// appSetRateLimits are the keys of the rate limits consumed by the ApplicationSets, by namespace and name
+	appSetRateLimits map[string]map[string]bool
+}
+
+type gitHubRateLimit struct {
+	remaining int
+	limit     int
+	reset     time.Time
+}
+
+// NewGitHubRateLimitBudget returns an empty budget
+func NewGitHubRateLimitBudget() *GitHubRateLimitBudget {
+	return &GitHubRateLimitBudget{
+		rateLimits:       map[string]gitHubRateLimit{},
+		appSetRateLimits: map[string]map[string]bool{},
+	}
+}
+
+// Global budget, recorded by the GitHub API clients with metrics
+var globalGitHubRateLimitBudget = NewGitHubRateLimitBudget()
+
+// DefaultGitHubRateLimitBudget returns the budget recorded by the GitHub API clients with metrics
+func DefaultGitHubRateLimitBudget() *GitHubRateLimitBudget {
+	return globalGitHubRateLimitBudget
+}
+
+// GitHubTokenCredentials returns the credentials identifying the rate limit of a GitHub token, without disclosing it
+func GitHubTokenCredentials(token string) string {
+	if token == "" {
+		return "anonymous"
+	}
+	sum := sha256.Sum256([]byte(token))
+	return "token:" + hex.EncodeToString(sum[:8])
+}
+
+// GitHubAppCredentials returns the credentials identifying the rate limit of a GitHub App installation
+func GitHubAppCredentials(auth github_app_auth.Authentication) string {
+	return fmt.Sprintf("app:%d/%d", auth.Id, auth.InstallationId)
+}
+
+// Record records the rate limit of a rate limit resource, e.g. core or graphql, returned for a request of an
+// ApplicationSet
+func (b *GitHubRateLimitBudget) Record(metricsContext *MetricsContext, resource string, remaining, limit int, reset time.Time) {
+	if metricsContext == nil || metricsContext.Credentials == "" || limit <= 0 {
+		return
+	}
+	key := metricsContext.Credentials + "/" + resource
+	appSetKey := metricsContext.AppSetNamespace + "/" + metricsContext.AppSetName
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.rateLimits[key] = gitHubRateLimit{remaining: remaining, limit: limit, reset: reset}
+	if b.appSetRateLimits[appSetKey] == nil {
+		b.appSetRateLimits[appSetKey] = map[string]bool{}
+	}
+	b.appSetRateLimits[appSetKey][key] = true
+}
+
+// IsLow returns whether the remaining rate limit of one of the credentials used by an ApplicationSet is below
+// reservePercent percent of the limit, along with the time when the low rate limits are all reset. The rate limits
+// whose window is over are replenished and never low.
+func (b *GitHubRateLimitBudget) IsLow(appSetNamespace, appSetName string, reservePercent int) (time.Time, bool) {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	var reset time.Time
+	now := time.Now()
+	for key := range b.appSetRateLimits[appSetNamespace+"/"+appSetName] {
+		rateLimit := b.rateLimits[key]
+		if !rateLimit.reset.After(now) || rateLimit.remaining*100 >= rateLimit.limit*reservePercent {
+			continue
+		}
+		if rateLimit.reset.After(reset) {
+			reset = rateLimit.reset
+		}
+	}
+	return reset, !reset.IsZero()
+}
+
+// Forget forgets the rate limits consumed by a deleted ApplicationSet, along with the rate limits of the credentials
+// no other ApplicationSet uses
+func (b *GitHubRateLimitBudget) Forget(appSetNamespace, appSetName string) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	appSetKey := appSetNamespace + "/" + appSetName
+	keys := b.appSetRateLimits[appSetKey]
+	delete(b.appSetRateLimits, appSetKey)
+	for key := range keys {
+		used := false
+		for _, appSetKeys := range b.appSetRateLimits {
+			if appSetKeys[key] {
+				used = true
+				break
+			}
+		}
+		if !used {
+			delete(b.rateLimits, key)
+		}
+	}
+}
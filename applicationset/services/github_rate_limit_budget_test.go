@@ -0,0 +1,65 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/argoproj/argo-cd/v3/applicationset/services/github_app_auth"
+)
+
+func TestGitHubRateLimitBudget(t *testing.T) {
+	appSet := &MetricsContext{AppSetNamespace: "argocd", AppSetName: "appset", Credentials: GitHubTokenCredentials("token")}
+	otherAppSet := &MetricsContext{AppSetNamespace: "argocd", AppSetName: "other-appset", Credentials: GitHubAppCredentials(github_app_auth.Authentication{Id: 1, InstallationId: 2})}
+	reset := time.Now().Add(time.Hour).Truncate(time.Second)
+
+	budget := NewGitHubRateLimitBudget()
+	_, low := budget.IsLow("argocd", "appset", 10)
+	assert.False(t, low)
+
+	budget.Record(appSet, "core", 400, 5000, reset)
+	budget.Record(appSet, "graphql", 4000, 5000, reset.Add(time.Minute))
+	budget.Record(otherAppSet, "core", 4000, 5000, reset)
+	_, low = budget.IsLow("argocd", "appset", 5)
+	assert.False(t, low)
+	resetAt, low := budget.IsLow("argocd", "appset", 10)
+	assert.True(t, low)
+	assert.Equal(t, reset, resetAt)
+	_, low = budget.IsLow("argocd", "other-appset", 10)
+	assert.False(t, low)
+
+	// the ApplicationSets using the same credentials share their rate limit
+	budget.Record(&MetricsContext{AppSetNamespace: "argocd", AppSetName: "other-appset", Credentials: GitHubTokenCredentials("token")}, "core", 100, 5000, reset)
+	_, low = budget.IsLow("argocd", "other-appset", 10)
+	assert.True(t, low)
+
+	// the rate limit is replenished once its window is over
+	budget.Record(appSet, "core", 0, 5000, time.Now().Add(-time.Second))
+	_, low = budget.IsLow("argocd", "appset", 10)
+	assert.False(t, low)
+
+	// the requests without credentials aren't tracked
+	budget.Record(&MetricsContext{AppSetNamespace: "argocd", AppSetName: "untracked"}, "core", 0, 5000, reset)
+	_, low = budget.IsLow("argocd", "untracked", 10)
+	assert.False(t, low)
+
+	// the rate limits of the deleted ApplicationSets are forgotten, unless other ApplicationSets use their credentials
+	budget.Record(appSet, "core", 100, 5000, reset)
+	budget.Forget("argocd", "appset")
+	_, low = budget.IsLow("argocd", "appset", 10)
+	assert.False(t, low)
+	assert.NotContains(t, budget.appSetRateLimits, "argocd/appset")
+	assert.Contains(t, budget.rateLimits, GitHubTokenCredentials("token")+"/core")
+	assert.NotContains(t, budget.rateLimits, GitHubTokenCredentials("token")+"/graphql")
+	budget.Forget("argocd", "other-appset")
+	assert.Empty(t, budget.appSetRateLimits)
+	assert.Empty(t, budget.rateLimits)
+}
+
+func TestGitHubTokenCredentials(t *testing.T) {
+	assert.Equal(t, "anonymous", GitHubTokenCredentials(""))
+	assert.Equal(t, GitHubTokenCredentials("token"), GitHubTokenCredentials("token"))
+	assert.NotEqual(t, GitHubTokenCredentials("token"), GitHubTokenCredentials("other-token"))
+	assert.NotContains(t, GitHubTokenCredentials("token"), "token:token")
+}
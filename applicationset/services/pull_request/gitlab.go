@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"slices"
 
 	"github.com/hashicorp/go-retryablehttp"
 	gitlab "gitlab.com/gitlab-org/api/client-go"
@@ -17,11 +18,13 @@ type GitLabService struct {
 	project          string
 	labels           []string
 	pullRequestState string
+	pipelineStatuses []string
+	approved         *bool
 }
 
 var _ PullRequestService = (*GitLabService)(nil)
 
-func NewGitLabService(token, url, project string, labels []string, pullRequestState string, scmRootCAPath string, insecure bool, caCerts []byte) (PullRequestService, error) {
+func NewGitLabService(token, url, project string, labels []string, pullRequestState string, pipelineStatuses []string, approved *bool, scmRootCAPath string, insecure bool, caCerts []byte) (PullRequestService, error) {
 	var clientOptionFns []gitlab.ClientOptionFunc
 
 	// Set a custom Gitlab base URL if one is provided
@@ -51,6 +54,8 @@ func NewGitLabService(token, url, project string, labels []string, pullRequestSt
 		project:          project,
 		labels:           labels,
 		pullRequestState: pullRequestState,
+		pipelineStatuses: pipelineStatuses,
+		approved:         approved,
 	}, nil
 }
 
@@ -84,14 +89,35 @@ func (g *GitLabService) List(ctx context.Context) ([]*PullRequest, error) {
 			return nil, fmt.Errorf("error listing merge requests for project '%s': %w", g.project, err)
 		}
 		for _, mr := range mrs {
+			// The pipeline status and the approval state are not listed, they are only fetched to filter the MRs on them
+			var pipelineStatus string
+			if len(g.pipelineStatuses) > 0 {
+				pipelineStatus, err = g.getPipelineStatus(ctx, mr.IID, mr.SHA)
+				if err != nil {
+					return nil, err
+				}
+				if !slices.Contains(g.pipelineStatuses, pipelineStatus) {
+					continue
+				}
+			}
+			if g.approved != nil {
+				approvals, _, err := g.client.MergeRequestApprovals.GetConfiguration(g.project, mr.IID, gitlab.WithContext(ctx))
+				if err != nil {
+					return nil, fmt.Errorf("error getting approvals of merge request %d for project '%s': %w", mr.IID, g.project, err)
+				}
+				if approvals.Approved != *g.approved {
+					continue
+				}
+			}
 			pullRequests = append(pullRequests, &PullRequest{
-				Number:       mr.IID,
-				Title:        mr.Title,
-				Branch:       mr.SourceBranch,
-				TargetBranch: mr.TargetBranch,
-				HeadSHA:      mr.SHA,
-				Labels:       mr.Labels,
-				Author:       mr.Author.Username,
+				Number:         mr.IID,
+				Title:          mr.Title,
+				Branch:         mr.SourceBranch,
+				TargetBranch:   mr.TargetBranch,
+				HeadSHA:        mr.SHA,
+				Labels:         mr.Labels,
+				Author:         mr.Author.Username,
+				PipelineStatus: pipelineStatus,
 			})
 		}
 		if resp.NextPage == 0 {
@@ -101,3 +127,17 @@ func (g *GitLabService) List(ctx context.Context) ([]*PullRequest, error) {
 	}
 	return pullRequests, nil
 }
+
+// getPipelineStatus returns the status of the head pipeline of a merge request, i.e. the last pipeline of its source
+// branch, or an empty string when it has no pipeline for its head commit
+func (g *GitLabService) getPipelineStatus(ctx context.Context, iid int, sha string) (string, error) {
+	mr, _, err := g.client.MergeRequests.GetMergeRequest(g.project, iid, nil, gitlab.WithContext(ctx))
+	if err != nil {
+		return "", fmt.Errorf("error getting merge request %d for project '%s': %w", iid, g.project, err)
+	}
+	// the head pipeline may still be the one of a previous commit, until the pipeline of the head commit is created
+	if mr.HeadPipeline == nil || mr.HeadPipeline.SHA != sha {
+		return "", nil
+	}
+	return mr.HeadPipeline.Status, nil
+}
@@ -3,6 +3,7 @@ package pull_request
 import (
 	"crypto/x509"
 	"encoding/pem"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -34,7 +35,7 @@ func TestGitLabServiceCustomBaseURL(t *testing.T) {
 		writeMRListResponse(t, w)
 	})
 
-	svc, err := NewGitLabService("", server.URL, "278964", nil, "", "", false, nil)
+	svc, err := NewGitLabService("", server.URL, "278964", nil, "", nil, nil, "", false, nil)
 	require.NoError(t, err)
 
 	_, err = svc.List(t.Context())
@@ -53,7 +54,7 @@ func TestGitLabServiceToken(t *testing.T) {
 		writeMRListResponse(t, w)
 	})
 
-	svc, err := NewGitLabService("token-123", server.URL, "278964", nil, "", "", false, nil)
+	svc, err := NewGitLabService("token-123", server.URL, "278964", nil, "", nil, nil, "", false, nil)
 	require.NoError(t, err)
 
 	_, err = svc.List(t.Context())
@@ -72,7 +73,7 @@ func TestList(t *testing.T) {
 		writeMRListResponse(t, w)
 	})
 
-	svc, err := NewGitLabService("", server.URL, "278964", []string{}, "", "", false, nil)
+	svc, err := NewGitLabService("", server.URL, "278964", []string{}, "", nil, nil, "", false, nil)
 	require.NoError(t, err)
 
 	prs, err := svc.List(t.Context())
@@ -98,7 +99,7 @@ func TestListWithLabels(t *testing.T) {
 		writeMRListResponse(t, w)
 	})
 
-	svc, err := NewGitLabService("", server.URL, "278964", []string{"feature", "ready"}, "", "", false, nil)
+	svc, err := NewGitLabService("", server.URL, "278964", []string{"feature", "ready"}, "", nil, nil, "", false, nil)
 	require.NoError(t, err)
 
 	_, err = svc.List(t.Context())
@@ -117,13 +118,94 @@ func TestListWithState(t *testing.T) {
 		writeMRListResponse(t, w)
 	})
 
-	svc, err := NewGitLabService("", server.URL, "278964", []string{}, "opened", "", false, nil)
+	svc, err := NewGitLabService("", server.URL, "278964", []string{}, "opened", nil, nil, "", false, nil)
 	require.NoError(t, err)
 
 	_, err = svc.List(t.Context())
 	require.NoError(t, err)
 }
 
+func TestListWithPipelineStatuses(t *testing.T) {
+	for _, c := range []struct {
+		name             string
+		headPipeline     string
+		pipelineStatuses []string
+		expectedStatus   string
+		expectedCount    int
+	}{
+		{name: "matching status", headPipeline: `{"id": 1, "sha": "2fc4e8b972ff3208ec63b6143e34ad67ff343ad7", "status": "success"}`, pipelineStatuses: []string{"success", "running"}, expectedStatus: "success", expectedCount: 1},
+		{name: "other status", headPipeline: `{"id": 1, "sha": "2fc4e8b972ff3208ec63b6143e34ad67ff343ad7", "status": "failed"}`, pipelineStatuses: []string{"success"}, expectedCount: 0},
+		{name: "no pipeline", headPipeline: `null`, pipelineStatuses: []string{"success"}, expectedCount: 0},
+		{name: "pipeline of a previous commit", headPipeline: `{"id": 1, "sha": "0000000000000000000000000000000000000000", "status": "success"}`, pipelineStatuses: []string{"success"}, expectedCount: 0},
+		{name: "no filter", headPipeline: `{"id": 1, "sha": "2fc4e8b972ff3208ec63b6143e34ad67ff343ad7", "status": "success"}`, expectedCount: 1},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			mux := http.NewServeMux()
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			mux.HandleFunc("/api/v4/projects/278964/merge_requests", func(w http.ResponseWriter, _ *http.Request) {
+				writeMRListResponse(t, w)
+			})
+			mrFetched := false
+			mux.HandleFunc("/api/v4/projects/278964/merge_requests/15442", func(w http.ResponseWriter, _ *http.Request) {
+				mrFetched = true
+				_, err := io.WriteString(w, `{"iid": 15442, "head_pipeline": `+c.headPipeline+`}`)
+				require.NoError(t, err)
+			})
+
+			svc, err := NewGitLabService("", server.URL, "278964", []string{}, "", c.pipelineStatuses, nil, "", false, nil)
+			require.NoError(t, err)
+
+			prs, err := svc.List(t.Context())
+			require.NoError(t, err)
+			require.Len(t, prs, c.expectedCount)
+			if c.expectedCount > 0 {
+				assert.Equal(t, c.expectedStatus, prs[0].PipelineStatus)
+			}
+			// the MR is only fetched to filter it on its pipeline status
+			assert.Equal(t, len(c.pipelineStatuses) > 0, mrFetched)
+		})
+	}
+}
+
+func TestListWithApproved(t *testing.T) {
+	for _, c := range []struct {
+		name          string
+		approved      bool
+		mrApproved    bool
+		expectedCount int
+	}{
+		{name: "approved MRs, approved MR", approved: true, mrApproved: true, expectedCount: 1},
+		{name: "approved MRs, not approved MR", approved: true, mrApproved: false, expectedCount: 0},
+		{name: "not approved MRs, not approved MR", approved: false, mrApproved: false, expectedCount: 1},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			mux := http.NewServeMux()
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			mux.HandleFunc("/api/v4/projects/278964/merge_requests", func(w http.ResponseWriter, _ *http.Request) {
+				writeMRListResponse(t, w)
+			})
+			mux.HandleFunc("/api/v4/projects/278964/merge_requests/15442/approvals", func(w http.ResponseWriter, _ *http.Request) {
+				_, err := fmt.Fprintf(w, `{"iid": 15442, "approved": %t}`, c.mrApproved)
+				require.NoError(t, err)
+			})
+
+			svc, err := NewGitLabService("", server.URL, "278964", []string{}, "", nil, &c.approved, "", false, nil)
+			require.NoError(t, err)
+
+			prs, err := svc.List(t.Context())
+			require.NoError(t, err)
+			assert.Len(t, prs, c.expectedCount)
+			for _, pr := range prs {
+				assert.Empty(t, pr.PipelineStatus)
+			}
+		})
+	}
+}
+
 func TestListWithStateTLS(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -179,7 +261,7 @@ func TestListWithStateTLS(t *testing.T) {
 				}
 			}
 
-			svc, err := NewGitLabService("", ts.URL, "278964", []string{}, "opened", "", test.tlsInsecure, certs)
+			svc, err := NewGitLabService("", ts.URL, "278964", []string{}, "opened", nil, nil, "", test.tlsInsecure, certs)
 			require.NoError(t, err)
 
 			_, err = svc.List(t.Context())
@@ -205,7 +287,7 @@ func TestGitLabListReturnsRepositoryNotFoundError(t *testing.T) {
 		_, _ = w.Write([]byte(`{"message": "404 Project Not Found"}`))
 	})
 
-	svc, err := NewGitLabService("", server.URL, "nonexistent", []string{}, "", "", false, nil)
+	svc, err := NewGitLabService("", server.URL, "nonexistent", []string{}, "", nil, nil, "", false, nil)
 	require.NoError(t, err)
 
 	prs, err := svc.List(t.Context())
@@ -20,6 +20,9 @@ type PullRequest struct {
 	Labels []string
 	// Author is the author of the pull request.
 	Author string
+	// PipelineStatus is the status of the last pipeline of the head commit of the pull request, only set for GitLab when
+	// the pull requests are filtered on it.
+	PipelineStatus string
 }
 
 type PullRequestService interface {
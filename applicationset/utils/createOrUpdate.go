@@ -2,10 +2,13 @@ package utils
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	stderrors "errors"
 	"fmt"
 
+	jsonpatch "github.com/evanphx/json-patch"
 	log "github.com/sirupsen/logrus"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -18,6 +21,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
+	"github.com/argoproj/argo-cd/v3/common"
 	argov1alpha1 "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
 	"github.com/argoproj/argo-cd/v3/util/argo"
 	argodiff "github.com/argoproj/argo-cd/v3/util/argo/diff"
@@ -46,6 +50,11 @@ func CreateOrUpdate(ctx context.Context, logCtx *log.Entry, c client.Client, ign
 		if err := mutate(f, key, obj); err != nil {
 			return controllerutil.OperationResultNone, err
 		}
+		_, generatedFields, err := selectIgnoreDifferences(ignoreAppDifferences, nil, obj, ignoreNormalizerOpts)
+		if err != nil {
+			return controllerutil.OperationResultNone, fmt.Errorf("failed to select ignore differences: %w", err)
+		}
+		setGeneratedFieldsAnnotation(obj, generatedFields)
 		if err := c.Create(ctx, obj); err != nil {
 			return controllerutil.OperationResultNone, err
 		}
@@ -59,12 +68,20 @@ func CreateOrUpdate(ctx context.Context, logCtx *log.Entry, c client.Client, ign
 		return controllerutil.OperationResultNone, err
 	}
 
+	// Select the ignoreApplicationDifferences rules applying to the live state, i.e. skip the rules preserving the
+	// fields modified by users when the fields are not modified.
+	ignoreAppDifferences, generatedFields, err := selectIgnoreDifferences(ignoreAppDifferences, normalizedLive, obj, ignoreNormalizerOpts)
+	if err != nil {
+		return controllerutil.OperationResultNone, fmt.Errorf("failed to select ignore differences: %w", err)
+	}
+
 	// Apply ignoreApplicationDifferences rules to remove ignored fields from both the live and the desired state. This
 	// prevents those differences from appearing in the diff and therefore in the patch.
-	err := applyIgnoreDifferences(ignoreAppDifferences, normalizedLive, obj, ignoreNormalizerOpts)
+	err = applyIgnoreDifferences(ignoreAppDifferences, normalizedLive, obj, ignoreNormalizerOpts)
 	if err != nil {
 		return controllerutil.OperationResultNone, fmt.Errorf("failed to apply ignore differences: %w", err)
 	}
+	setGeneratedFieldsAnnotation(obj, generatedFields)
 
 	// Normalize to avoid diffing on unimportant differences.
 	normalizedLive.Spec = *argo.NormalizeApplicationSpec(&normalizedLive.Spec)
@@ -194,6 +211,111 @@ func applyIgnoreDifferences(applicationSetIgnoreDifferences argov1alpha1.Applica
 	return nil
 }
 
+// selectIgnoreDifferences returns the ignoreApplicationDifferences rules applying to a live application, along with the
+// hashes of the generated values of the fields of the rules preserving the fields modified by users, by rule. These
+// rules only apply while the hash of the live values differs from the one of the values last generated, recorded in
+// an annotation of the application. The live application is nil when it is created.
+func selectIgnoreDifferences(ignoreAppDifferences argov1alpha1.ApplicationSetIgnoreDifferences, live *argov1alpha1.Application, generatedApp *argov1alpha1.Application, ignoreNormalizerOpts normalizers.IgnoreNormalizerOpts) (argov1alpha1.ApplicationSetIgnoreDifferences, map[string]string, error) {
+	lastGeneratedFields := map[string]string{}
+	if live != nil && live.Annotations[common.AnnotationApplicationSetGeneratedFields] != "" {
+		// an invalid annotation is handled like a missing one, the fields being updated
+		if err := json.Unmarshal([]byte(live.Annotations[common.AnnotationApplicationSetGeneratedFields]), &lastGeneratedFields); err != nil {
+			log.Warnf("Failed to parse annotation %s of application %s: %v", common.AnnotationApplicationSetGeneratedFields, live.Name, err)
+		}
+	}
+
+	var selected argov1alpha1.ApplicationSetIgnoreDifferences
+	generatedFields := map[string]string{}
+	for _, rule := range ignoreAppDifferences {
+		if rule.Preserve != argov1alpha1.IgnoreDifferencesPreserveUserModified {
+			selected = append(selected, rule)
+			continue
+		}
+		ruleKey, err := hashJSON(rule)
+		if err != nil {
+			return nil, nil, err
+		}
+		if lastGenerated, ok := lastGeneratedFields[ruleKey]; ok && live != nil {
+			liveHash, err := ignoredFieldsHash(rule, live, ignoreNormalizerOpts)
+			if err != nil {
+				return nil, nil, err
+			}
+			if liveHash != lastGenerated {
+				// the fields are modified by a user, the values last generated remain the reference until the user
+				// restores them
+				selected = append(selected, rule)
+				generatedFields[ruleKey] = lastGenerated
+				continue
+			}
+		}
+		generatedHash, err := ignoredFieldsHash(rule, generatedApp, ignoreNormalizerOpts)
+		if err != nil {
+			return nil, nil, err
+		}
+		generatedFields[ruleKey] = generatedHash
+	}
+	return selected, generatedFields, nil
+}
+
+// ignoredFieldsHash returns the hash of the values of the fields of an application ignored by a rule
+func ignoredFieldsHash(rule argov1alpha1.ApplicationSetResourceIgnoreDifferences, app *argov1alpha1.Application, ignoreNormalizerOpts normalizers.IgnoreNormalizerOpts) (string, error) {
+	diffConfig, err := argodiff.NewDiffConfigBuilder().
+		WithDiffSettings([]argov1alpha1.ResourceIgnoreDifferences{rule.ToApplicationResourceIgnoreDifferences()}, nil, false, ignoreNormalizerOpts).
+		WithNoCache().
+		Build()
+	if err != nil {
+		return "", fmt.Errorf("failed to build diff config: %w", err)
+	}
+	unstructuredApp, err := appToUnstructured(app)
+	if err != nil {
+		return "", fmt.Errorf("failed to convert application to unstructured: %w", err)
+	}
+	result, err := argodiff.Normalize([]*unstructured.Unstructured{unstructuredApp}, []*unstructured.Unstructured{nil}, diffConfig)
+	if err != nil {
+		return "", fmt.Errorf("failed to normalize application: %w", err)
+	}
+	if len(result.Lives) != 1 {
+		return "", fmt.Errorf("expected 1 normalized application, got %d", len(result.Lives))
+	}
+	appJSON, err := json.Marshal(unstructuredApp.Object)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal application to json: %w", err)
+	}
+	normalizedJSON, err := json.Marshal(result.Lives[0].Object)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal normalized application to json: %w", err)
+	}
+	// the merge patch restoring the normalized application contains the fields ignored by the rule
+	ignoredFields, err := jsonpatch.CreateMergePatch(normalizedJSON, appJSON)
+	if err != nil {
+		return "", fmt.Errorf("failed to compute ignored fields: %w", err)
+	}
+	return hashJSON(json.RawMessage(ignoredFields))
+}
+
+func hashJSON(v any) (string, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal to json: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:8]), nil
+}
+
+// setGeneratedFieldsAnnotation records the hashes of the generated fields preserved when modified by users in an
+// annotation of an application, removing it when there are none
+func setGeneratedFieldsAnnotation(app *argov1alpha1.Application, generatedFields map[string]string) {
+	if len(generatedFields) == 0 {
+		delete(app.Annotations, common.AnnotationApplicationSetGeneratedFields)
+		return
+	}
+	data, _ := json.Marshal(generatedFields)
+	if app.Annotations == nil {
+		app.Annotations = map[string]string{}
+	}
+	app.Annotations[common.AnnotationApplicationSetGeneratedFields] = string(data)
+}
+
 func appToUnstructured(app client.Object) (*unstructured.Unstructured, error) {
 	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(app)
 	if err != nil {
@@ -235,3 +235,66 @@ spec:
 		})
 	}
 }
+
+func Test_selectIgnoreDifferences(t *testing.T) {
+	t.Parallel()
+
+	appMeta := metav1.TypeMeta{
+		APIVersion: v1alpha1.ApplicationSchemaGroupVersionKind.GroupVersion().String(),
+		Kind:       v1alpha1.ApplicationSchemaGroupVersionKind.Kind,
+	}
+	app := func(targetRevision string, generatedFields map[string]string) *v1alpha1.Application {
+		a := &v1alpha1.Application{
+			TypeMeta:   appMeta,
+			ObjectMeta: metav1.ObjectMeta{Name: "app"},
+			Spec: v1alpha1.ApplicationSpec{
+				Source: &v1alpha1.ApplicationSource{RepoURL: "https://git.example.com/test-org/test-repo", TargetRevision: targetRevision},
+			},
+		}
+		setGeneratedFieldsAnnotation(a, generatedFields)
+		return a
+	}
+	alwaysRule := v1alpha1.ApplicationSetResourceIgnoreDifferences{JQPathExpressions: []string{".spec.syncPolicy"}}
+	userModifiedRule := v1alpha1.ApplicationSetResourceIgnoreDifferences{
+		JQPathExpressions: []string{".spec.source.targetRevision"},
+		Preserve:          v1alpha1.IgnoreDifferencesPreserveUserModified,
+	}
+	rules := v1alpha1.ApplicationSetIgnoreDifferences{alwaysRule, userModifiedRule}
+	opts := normalizers.IgnoreNormalizerOpts{}
+
+	// the application is created with the generated fields
+	selected, createdFields, err := selectIgnoreDifferences(rules, nil, app("v1", nil), opts)
+	require.NoError(t, err)
+	assert.Equal(t, v1alpha1.ApplicationSetIgnoreDifferences{alwaysRule}, selected)
+	require.Len(t, createdFields, 1)
+
+	// the fields not modified by a user are updated
+	selected, updatedFields, err := selectIgnoreDifferences(rules, app("v1", createdFields), app("v2", nil), opts)
+	require.NoError(t, err)
+	assert.Equal(t, v1alpha1.ApplicationSetIgnoreDifferences{alwaysRule}, selected)
+	assert.NotEqual(t, createdFields, updatedFields)
+
+	// the fields modified by a user are preserved, the values last generated remaining the reference
+	selected, preservedFields, err := selectIgnoreDifferences(rules, app("pinned", updatedFields), app("v3", nil), opts)
+	require.NoError(t, err)
+	assert.Equal(t, rules, selected)
+	assert.Equal(t, updatedFields, preservedFields)
+
+	// the fields restored by the user to the values last generated are updated again
+	selected, restoredFields, err := selectIgnoreDifferences(rules, app("v2", updatedFields), app("v3", nil), opts)
+	require.NoError(t, err)
+	assert.Equal(t, v1alpha1.ApplicationSetIgnoreDifferences{alwaysRule}, selected)
+	assert.NotEqual(t, updatedFields, restoredFields)
+
+	// the fields of applications without the annotation are updated
+	selected, _, err = selectIgnoreDifferences(rules, app("pinned", nil), app("v3", nil), opts)
+	require.NoError(t, err)
+	assert.Equal(t, v1alpha1.ApplicationSetIgnoreDifferences{alwaysRule}, selected)
+
+	// the annotation is removed without rules preserving the fields modified by users
+	_, noFields, err := selectIgnoreDifferences(v1alpha1.ApplicationSetIgnoreDifferences{alwaysRule}, app("pinned", updatedFields), app("v3", nil), opts)
+	require.NoError(t, err)
+	a := app("v3", updatedFields)
+	setGeneratedFieldsAnnotation(a, noFields)
+	assert.NotContains(t, a.Annotations, "argocd.argoproj.io/application-set-generated-fields")
+}
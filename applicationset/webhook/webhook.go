@@ -12,6 +12,10 @@ import (
 	"strings"
 	"sync"
 
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -117,6 +121,9 @@ func (h *WebhookHandler) startWorkerPool(webhookParallelism int) {
 	}
 }
 
+// tracer creates the spans of the webhook processing, which are exported when OTLP tracing is enabled
+var tracer = otel.Tracer("github.com/argoproj/argo-cd/v3/applicationset/webhook")
+
 func (h *WebhookHandler) HandleEvent(payload any) {
 	gitGenInfo := getGitGeneratorInfo(payload)
 	prGenInfo := getPRGeneratorInfo(payload)
@@ -124,9 +131,13 @@ func (h *WebhookHandler) HandleEvent(payload any) {
 		return
 	}
 
+	ctx, span := tracer.Start(context.Background(), "webhook", trace.WithAttributes(attribute.String("event.type", fmt.Sprintf("%T", payload))))
+	defer span.End()
+
 	appSetList := &v1alpha1.ApplicationSetList{}
-	err := h.client.List(context.Background(), appSetList, &client.ListOptions{})
+	err := h.client.List(ctx, appSetList, &client.ListOptions{})
 	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
 		log.Errorf("Failed to list applicationsets: %v", err)
 		return
 	}
@@ -145,7 +156,7 @@ func (h *WebhookHandler) HandleEvent(payload any) {
 			}
 		}
 		if shouldRefresh {
-			err := refreshApplicationSet(h.client, &appSet)
+			err := refreshApplicationSet(ctx, h.client, &appSet)
 			if err != nil {
 				log.Errorf("Failed to refresh ApplicationSet '%s' for controller reprocessing", appSet.Name)
 				continue
@@ -605,10 +616,14 @@ func (h *WebhookHandler) shouldRefreshMergeGenerator(gen *v1alpha1.MergeGenerato
 	return false
 }
 
-func refreshApplicationSet(c client.Client, appSet *v1alpha1.ApplicationSet) error {
+func refreshApplicationSet(ctx context.Context, c client.Client, appSet *v1alpha1.ApplicationSet) error {
+	ctx, span := tracer.Start(ctx, "refresh", trace.WithAttributes(
+		attribute.String("applicationset.namespace", appSet.Namespace),
+		attribute.String("applicationset.name", appSet.Name)))
+	defer span.End()
 	// patch the ApplicationSet with the refresh annotation to reconcile
-	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
-		err := c.Get(context.Background(), types.NamespacedName{Name: appSet.Name, Namespace: appSet.Namespace}, appSet)
+	err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		err := c.Get(ctx, types.NamespacedName{Name: appSet.Name, Namespace: appSet.Namespace}, appSet)
 		if err != nil {
 			return fmt.Errorf("error getting ApplicationSet: %w", err)
 		}
@@ -616,6 +631,10 @@ func refreshApplicationSet(c client.Client, appSet *v1alpha1.ApplicationSet) err
 			appSet.Annotations = map[string]string{}
 		}
 		appSet.Annotations[common.AnnotationApplicationSetRefresh] = "true"
-		return c.Patch(context.Background(), appSet, client.Merge)
+		return c.Patch(ctx, appSet, client.Merge)
 	})
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
 }
@@ -22,12 +22,14 @@ import (
 	commitclient "github.com/argoproj/argo-cd/v3/commitserver/apiclient"
 	"github.com/argoproj/argo-cd/v3/common"
 	"github.com/argoproj/argo-cd/v3/controller"
+	"github.com/argoproj/argo-cd/v3/controller/metrics"
 	"github.com/argoproj/argo-cd/v3/controller/sharding"
 	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
 	appclientset "github.com/argoproj/argo-cd/v3/pkg/client/clientset/versioned"
 	"github.com/argoproj/argo-cd/v3/pkg/ratelimiter"
 	"github.com/argoproj/argo-cd/v3/reposerver/apiclient"
 	"github.com/argoproj/argo-cd/v3/util/argo"
+	argodiff "github.com/argoproj/argo-cd/v3/util/argo/diff"
 	"github.com/argoproj/argo-cd/v3/util/argo/normalizers"
 	cacheutil "github.com/argoproj/argo-cd/v3/util/cache"
 	appstatecache "github.com/argoproj/argo-cd/v3/util/cache/appstate"
@@ -35,6 +37,7 @@ import (
 	"github.com/argoproj/argo-cd/v3/util/env"
 	"github.com/argoproj/argo-cd/v3/util/errors"
 	kubeutil "github.com/argoproj/argo-cd/v3/util/kube"
+	"github.com/argoproj/argo-cd/v3/util/profile"
 	"github.com/argoproj/argo-cd/v3/util/settings"
 	"github.com/argoproj/argo-cd/v3/util/tls"
 	"github.com/argoproj/argo-cd/v3/util/trace"
@@ -64,6 +67,7 @@ func NewCommand() *cobra.Command {
 		repoServerAddress                string
 		repoServerTimeoutSeconds         int
 		commitServerAddress              string
+		commitServerTimeoutSeconds       int
 		selfHealTimeoutSeconds           int
 		selfHealBackoffTimeoutSeconds    int
 		selfHealBackoffFactor            int
@@ -78,9 +82,14 @@ func NewCommand() *cobra.Command {
 		metricsAplicationLabels          []string
 		metricsAplicationConditions      []string
 		metricsClusterLabels             []string
+		metricsDropLabels                []string
+		metricsHashLabels                []string
+		metricsProjectAggregated         []string
+		metricsResourceKindsLimit        int
 		kubectlParallelismLimit          int64
+		repoServerProjectLimit           int64
 		cacheSource                      func() (*appstatecache.Cache, error)
-		redisClient                      *redis.Client
+		redisClient                      redis.UniversalClient
 		repoServerPlaintext              bool
 		repoServerStrictTLS              bool
 		otlpAddress                      string
@@ -89,9 +98,14 @@ func NewCommand() *cobra.Command {
 		otlpAttrs                        []string
 		applicationNamespaces            []string
 		persistResourceHealth            bool
+		resourceTreeSnapshotInterval     time.Duration
+		resourceTreeSnapshotRetention    time.Duration
+		flappingThreshold                int
+		flappingPauseSelfHeal            bool
 		shardingAlgorithm                string
 		enableDynamicClusterDistribution bool
 		serverSideDiff                   bool
+		serverSideDryRunOpts             argodiff.ServerSideDryRunOpts
 		ignoreNormalizerOpts             normalizers.IgnoreNormalizerOpts
 
 		// argocd k8s event logging flag
@@ -166,7 +180,7 @@ func NewCommand() *cobra.Command {
 
 			repoClientset := apiclient.NewRepoServerClientset(repoServerAddress, repoServerTimeoutSeconds, tlsConfig)
 
-			commitClientset := commitclient.NewCommitServerClientset(commitServerAddress)
+			commitClientset := commitclient.NewCommitServerClientset(commitServerAddress, commitServerTimeoutSeconds, commitclient.TLSConfiguration{DisableTLS: true})
 
 			cache, err := cacheSource()
 			errors.CheckError(err)
@@ -211,18 +225,29 @@ func NewCommand() *cobra.Command {
 				metricsAplicationConditions,
 				metricsClusterLabels,
 				kubectlParallelismLimit,
+				repoServerProjectLimit,
 				persistResourceHealth,
+				resourceTreeSnapshotInterval,
+				resourceTreeSnapshotRetention,
+				flappingThreshold,
+				flappingPauseSelfHeal,
 				clusterSharding,
 				applicationNamespaces,
 				&workqueueRateLimit,
 				serverSideDiff,
+				serverSideDryRunOpts,
 				enableDynamicClusterDistribution,
 				ignoreNormalizerOpts,
 				enableK8sEvent,
 				hydratorEnabled,
 			)
 			errors.CheckError(err)
+			metricsLabelRules, err := metrics.NewLabelRules(metricsDropLabels, metricsHashLabels, metricsProjectAggregated)
+			errors.CheckError(err)
+			appController.GetMetricsServer().SetLabelRules(metricsLabelRules)
+			appController.GetMetricsServer().SetResourceKindsLimit(metricsResourceKindsLimit)
 			cacheutil.CollectMetrics(redisClient, appController.GetMetricsServer(), nil)
+			cache.Cache.SetMetricsRegistry(appController.GetMetricsServer())
 
 			stats.RegisterStackDumper()
 			stats.StartStatsTicker(10 * time.Minute)
@@ -235,6 +260,7 @@ func NewCommand() *cobra.Command {
 				}
 				defer closeTracer()
 			}
+			profile.StartExporter(ctx, cliName, profile.ExportConfigFromEnv())
 
 			// Graceful shutdown code
 			sigCh := make(chan os.Signal, 1)
@@ -263,6 +289,7 @@ func NewCommand() *cobra.Command {
 	command.Flags().StringVar(&repoServerAddress, "repo-server", env.StringFromEnv("ARGOCD_APPLICATION_CONTROLLER_REPO_SERVER", common.DefaultRepoServerAddr), "Repo server address.")
 	command.Flags().IntVar(&repoServerTimeoutSeconds, "repo-server-timeout-seconds", env.ParseNumFromEnv("ARGOCD_APPLICATION_CONTROLLER_REPO_SERVER_TIMEOUT_SECONDS", 60, 0, math.MaxInt64), "Repo server RPC call timeout seconds.")
 	command.Flags().StringVar(&commitServerAddress, "commit-server", env.StringFromEnv("ARGOCD_APPLICATION_CONTROLLER_COMMIT_SERVER", common.DefaultCommitServerAddr), "Commit server address.")
+	command.Flags().IntVar(&commitServerTimeoutSeconds, "commit-server-timeout-seconds", env.ParseNumFromEnv("ARGOCD_APPLICATION_CONTROLLER_COMMIT_SERVER_TIMEOUT_SECONDS", 0, 0, math.MaxInt64), "Commit server RPC call timeout seconds, 0 disables the timeout.")
 	command.Flags().IntVar(&statusProcessors, "status-processors", env.ParseNumFromEnv("ARGOCD_APPLICATION_CONTROLLER_STATUS_PROCESSORS", 20, 0, math.MaxInt32), "Number of application status processors")
 	command.Flags().IntVar(&operationProcessors, "operation-processors", env.ParseNumFromEnv("ARGOCD_APPLICATION_CONTROLLER_OPERATION_PROCESSORS", 10, 0, math.MaxInt32), "Number of application operation processors")
 	command.Flags().StringVar(&cmdutil.LogFormat, "logformat", env.StringFromEnv("ARGOCD_APPLICATION_CONTROLLER_LOGFORMAT", "json"), "Set the logging format. One of: json|text")
@@ -277,17 +304,26 @@ func NewCommand() *cobra.Command {
 	command.Flags().IntVar(&selfHealBackoffCooldownSeconds, "self-heal-backoff-cooldown-seconds", env.ParseNumFromEnv("ARGOCD_APPLICATION_CONTROLLER_SELF_HEAL_BACKOFF_COOLDOWN_SECONDS", 330, 0, math.MaxInt32), "Specifies period of time the app needs to stay synced before the self heal backoff can reset")
 	command.Flags().IntVar(&syncTimeout, "sync-timeout", env.ParseNumFromEnv("ARGOCD_APPLICATION_CONTROLLER_SYNC_TIMEOUT", 0, 0, math.MaxInt32), "Specifies the timeout after which a sync would be terminated. 0 means no timeout (default 0).")
 	command.Flags().Int64Var(&kubectlParallelismLimit, "kubectl-parallelism-limit", env.ParseInt64FromEnv("ARGOCD_APPLICATION_CONTROLLER_KUBECTL_PARALLELISM_LIMIT", 20, 0, math.MaxInt64), "Number of allowed concurrent kubectl fork/execs. Any value less than 1 means no limit.")
+	command.Flags().Int64Var(&repoServerProjectLimit, "repo-server-project-parallelism-limit", env.ParseInt64FromEnv("ARGOCD_APPLICATION_CONTROLLER_REPO_SERVER_PROJECT_PARALLELISM_LIMIT", 0, 0, math.MaxInt64), "Number of allowed concurrent manifest generation requests per project. Any value less than 1 means no limit.")
 	command.Flags().BoolVar(&repoServerPlaintext, "repo-server-plaintext", env.ParseBoolFromEnv("ARGOCD_APPLICATION_CONTROLLER_REPO_SERVER_PLAINTEXT", false), "Disable TLS on connections to repo server")
 	command.Flags().BoolVar(&repoServerStrictTLS, "repo-server-strict-tls", env.ParseBoolFromEnv("ARGOCD_APPLICATION_CONTROLLER_REPO_SERVER_STRICT_TLS", false), "Whether to use strict validation of the TLS cert presented by the repo server")
 	command.Flags().StringSliceVar(&metricsAplicationLabels, "metrics-application-labels", []string{}, "List of Application labels that will be added to the argocd_application_labels metric")
 	command.Flags().StringSliceVar(&metricsAplicationConditions, "metrics-application-conditions", []string{}, "List of Application conditions that will be added to the argocd_application_conditions metric")
 	command.Flags().StringSliceVar(&metricsClusterLabels, "metrics-cluster-labels", []string{}, "List of Cluster labels that will be added to the argocd_cluster_labels metric")
+	command.Flags().StringSliceVar(&metricsDropLabels, "metrics-drop-labels", env.StringsFromEnv("ARGOCD_APPLICATION_CONTROLLER_METRICS_DROP_LABELS", []string{}, ","), "List of <metric>:<label> pairs of labels dropped from metrics, series which become identical are summed. Use * as metric to drop a label from all metrics")
+	command.Flags().StringSliceVar(&metricsHashLabels, "metrics-hash-labels", env.StringsFromEnv("ARGOCD_APPLICATION_CONTROLLER_METRICS_HASH_LABELS", []string{}, ","), "List of <metric>:<label> pairs of labels whose values are replaced by a short hash. Use * as metric to hash a label of all metrics")
+	command.Flags().StringSliceVar(&metricsProjectAggregated, "metrics-project-aggregated", env.StringsFromEnv("ARGOCD_APPLICATION_CONTROLLER_METRICS_PROJECT_AGGREGATED", []string{}, ","), "List of metrics aggregated at the project level by dropping their namespace and name labels")
+	command.Flags().IntVar(&metricsResourceKindsLimit, "metrics-resource-kinds-limit", env.ParseNumFromEnv("ARGOCD_APPLICATION_CONTROLLER_METRICS_RESOURCE_KINDS_LIMIT", 0, 0, math.MaxInt32), "Maximum number of resource kinds per application exposed by the argocd_app_resource_kind_count metric, the resources of the other kinds are counted together (disabled by default)")
 	command.Flags().StringVar(&otlpAddress, "otlp-address", env.StringFromEnv("ARGOCD_APPLICATION_CONTROLLER_OTLP_ADDRESS", ""), "OpenTelemetry collector address to send traces to")
 	command.Flags().BoolVar(&otlpInsecure, "otlp-insecure", env.ParseBoolFromEnv("ARGOCD_APPLICATION_CONTROLLER_OTLP_INSECURE", true), "OpenTelemetry collector insecure mode")
 	command.Flags().StringToStringVar(&otlpHeaders, "otlp-headers", env.ParseStringToStringFromEnv("ARGOCD_APPLICATION_CONTROLLER_OTLP_HEADERS", map[string]string{}, ","), "List of OpenTelemetry collector extra headers sent with traces, headers are comma-separated key-value pairs(e.g. key1=value1,key2=value2)")
 	command.Flags().StringSliceVar(&otlpAttrs, "otlp-attrs", env.StringsFromEnv("ARGOCD_APPLICATION_CONTROLLER_OTLP_ATTRS", []string{}, ","), "List of OpenTelemetry collector extra attrs when send traces, each attribute is separated by a colon(e.g. key:value)")
 	command.Flags().StringSliceVar(&applicationNamespaces, "application-namespaces", env.StringsFromEnv("ARGOCD_APPLICATION_NAMESPACES", []string{}, ","), "List of additional namespaces that applications are allowed to be reconciled from")
 	command.Flags().BoolVar(&persistResourceHealth, "persist-resource-health", env.ParseBoolFromEnv("ARGOCD_APPLICATION_CONTROLLER_PERSIST_RESOURCE_HEALTH", false), "Enables storing the managed resources health in the Application CRD")
+	command.Flags().DurationVar(&resourceTreeSnapshotInterval, "resource-tree-snapshot-interval", env.ParseDurationFromEnv("ARGOCD_APPLICATION_CONTROLLER_RESOURCE_TREE_SNAPSHOT_INTERVAL", 0, 0, math.MaxInt64), "Interval between the snapshots of the resource trees of the applications, which can be diffed to inspect the changes of the trees over time. 0 disables the snapshots.")
+	command.Flags().DurationVar(&resourceTreeSnapshotRetention, "resource-tree-snapshot-retention", env.ParseDurationFromEnv("ARGOCD_APPLICATION_CONTROLLER_RESOURCE_TREE_SNAPSHOT_RETENTION", 7*24*time.Hour, time.Minute, math.MaxInt64), "Duration the snapshots of the resource trees of the applications are kept")
+	command.Flags().IntVar(&flappingThreshold, "flapping-threshold", env.ParseNumFromEnv("ARGOCD_APPLICATION_CONTROLLER_FLAPPING_THRESHOLD", 0, 0, math.MaxInt32), "Number of transitions per hour between Synced and OutOfSync, or between Healthy and Degraded, above which an application is reported as flapping by a FlappingWarning condition. 0 disables the detection.")
+	command.Flags().BoolVar(&flappingPauseSelfHeal, "flapping-pause-self-heal", env.ParseBoolFromEnv("ARGOCD_APPLICATION_CONTROLLER_FLAPPING_PAUSE_SELF_HEAL", false), "Pauses the self heal of the resources whose sync status is flapping")
 	command.Flags().StringVar(&shardingAlgorithm, "sharding-method", env.StringFromEnv(common.EnvControllerShardingAlgorithm, common.DefaultShardingAlgorithm), "Enables choice of sharding method. Supported sharding methods are : [legacy, round-robin, consistent-hashing] ")
 	// global queue rate limit config
 	command.Flags().Int64Var(&workqueueRateLimit.BucketSize, "wq-bucket-size", env.ParseInt64FromEnv("WORKQUEUE_BUCKET_SIZE", 500, 1, math.MaxInt64), "Set Workqueue Rate Limiter Bucket Size, default 500")
@@ -300,14 +336,18 @@ func NewCommand() *cobra.Command {
 	command.Flags().Float64Var(&workqueueRateLimit.BackoffFactor, "wq-backoff-factor", env.ParseFloat64FromEnv("WORKQUEUE_BACKOFF_FACTOR", 1.5, 0, math.MaxFloat64), "Set Workqueue Per Item Rate Limiter Backoff Factor, default is 1.5")
 	command.Flags().BoolVar(&enableDynamicClusterDistribution, "dynamic-cluster-distribution-enabled", env.ParseBoolFromEnv(common.EnvEnableDynamicClusterDistribution, false), "Enables dynamic cluster distribution.")
 	command.Flags().BoolVar(&serverSideDiff, "server-side-diff-enabled", env.ParseBoolFromEnv(common.EnvServerSideDiff, false), "Feature flag to enable ServerSide diff. Default (\"false\")")
+	command.Flags().IntVar(&serverSideDryRunOpts.BatchSize, "server-side-diff-dry-run-batch-size", env.ParseNumFromEnv("ARGOCD_APPLICATION_CONTROLLER_SERVER_SIDE_DIFF_DRY_RUN_BATCH_SIZE", 0, 0, math.MaxInt32), "Number of resources whose server-side diff dry-run applies are run per batch, the batches being run one after the other. 0 runs them in a single batch.")
+	command.Flags().IntVar(&serverSideDryRunOpts.Parallelism, "server-side-diff-dry-run-parallelism", env.ParseNumFromEnv("ARGOCD_APPLICATION_CONTROLLER_SERVER_SIDE_DIFF_DRY_RUN_PARALLELISM", 1, 1, math.MaxInt32), "Number of server-side diff dry-run applies of a batch run concurrently")
+	command.Flags().DurationVar(&serverSideDryRunOpts.Timeout, "server-side-diff-dry-run-timeout", env.ParseDurationFromEnv("ARGOCD_APPLICATION_CONTROLLER_SERVER_SIDE_DIFF_DRY_RUN_TIMEOUT", 0, 0, math.MaxInt64), "Timeout of each server-side diff dry-run apply. 0 disables the timeout.")
 	command.Flags().DurationVar(&ignoreNormalizerOpts.JQExecutionTimeout, "ignore-normalizer-jq-execution-timeout-seconds", env.ParseDurationFromEnv("ARGOCD_IGNORE_NORMALIZER_JQ_TIMEOUT", 0*time.Second, 0, math.MaxInt64), "Set ignore normalizer JQ execution timeout")
 	// argocd k8s event logging flag
 	command.Flags().StringSliceVar(&enableK8sEvent, "enable-k8s-event", env.StringsFromEnv("ARGOCD_ENABLE_K8S_EVENT", argo.DefaultEnableEventList(), ","), "Enable ArgoCD to use k8s event. For disabling all events, set the value as `none`. (e.g --enable-k8s-event=none), For enabling specific events, set the value as `event reason`. (e.g --enable-k8s-event=StatusRefreshed,ResourceCreated)")
 	command.Flags().BoolVar(&hydratorEnabled, "hydrator-enabled", env.ParseBoolFromEnv("ARGOCD_HYDRATOR_ENABLED", false), "Feature flag to enable Hydrator. Default (\"false\")")
 	cacheSource = appstatecache.AddCacheFlagsToCmd(&command, cacheutil.Options{
-		OnClientCreated: func(client *redis.Client) {
+		OnClientCreated: func(client redis.UniversalClient) {
 			redisClient = client
 		},
+		EmbeddedBackendSupported: true,
 	})
 	return &command
 }
@@ -40,9 +40,11 @@ import (
 	"github.com/argoproj/argo-cd/v3/applicationset/services"
 	appv1alpha1 "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
 	"github.com/argoproj/argo-cd/v3/util/cli"
+	"github.com/argoproj/argo-cd/v3/util/clustertags"
 	"github.com/argoproj/argo-cd/v3/util/db"
 	"github.com/argoproj/argo-cd/v3/util/errors"
 	argosettings "github.com/argoproj/argo-cd/v3/util/settings"
+	"github.com/argoproj/argo-cd/v3/util/trace"
 )
 
 var gitSubmoduleEnabled = env.ParseBoolFromEnv(common.EnvGitSubmoduleEnabled, true)
@@ -66,6 +68,9 @@ func NewCommand() *cobra.Command {
 		dryRun                       bool
 		enableProgressiveSyncs       bool
 		enableNewGitFileGlobbing     bool
+		enableClusterRegistration    bool
+		enableClusterTagSync         bool
+		clusterTagSyncInterval       time.Duration
 		repoServerPlaintext          bool
 		repoServerStrictTLS          bool
 		repoServerTimeoutSeconds     int
@@ -75,10 +80,15 @@ func NewCommand() *cobra.Command {
 		globalPreservedAnnotations   []string
 		globalPreservedLabels        []string
 		enableGitHubAPIMetrics       bool
+		githubAPIRateLimitReserve    int
 		metricsAplicationsetLabels   []string
 		enableScmProviders           bool
 		webhookParallelism           int
 		tokenRefStrictMode           bool
+		otlpAddress                  string
+		otlpInsecure                 bool
+		otlpHeaders                  map[string]string
+		otlpAttrs                    []string
 	)
 	scheme := runtime.NewScheme()
 	_ = clientgoscheme.AddToScheme(scheme)
@@ -114,6 +124,14 @@ func NewCommand() *cobra.Command {
 				}
 			}()
 
+			if otlpAddress != "" {
+				closeTracer, err := trace.InitTracer(ctx, "argocd-applicationset-controller", otlpAddress, otlpInsecure, otlpHeaders, otlpAttrs)
+				if err != nil {
+					log.Fatalf("failed to initialize tracing: %v", err)
+				}
+				defer closeTracer()
+			}
+
 			restConfig, err := clientConfig.ClientConfig()
 			errors.CheckError(err)
 
@@ -177,6 +195,9 @@ func NewCommand() *cobra.Command {
 			argoSettingsMgr := argosettings.NewSettingsManager(ctx, k8sClient, namespace)
 			argoCDDB := db.NewDB(namespace, argoSettingsMgr, k8sClient)
 
+			if githubAPIRateLimitReserve > 0 && !enableGitHubAPIMetrics {
+				log.Warn("The GitHub API rate limit reserve requires the GitHub API metrics to be enabled, the refreshes won't be deferred")
+			}
 			scmConfig := generators.NewSCMConfig(scmRootCAPath, allowedScmProviders, enableScmProviders, enableGitHubAPIMetrics, github_app.NewAuthCredentials(argoCDDB.(db.RepoCredsDB)), tokenRefStrictMode)
 
 			tlsConfig := apiclient.TLSConfiguration{
@@ -231,11 +252,35 @@ func NewCommand() *cobra.Command {
 				GlobalPreservedAnnotations: globalPreservedAnnotations,
 				GlobalPreservedLabels:      globalPreservedLabels,
 				Metrics:                    &metrics,
+				GitHubRateLimitBudget:      services.DefaultGitHubRateLimitBudget(),
+				GitHubAPIRateLimitReserve:  githubAPIRateLimitReserve,
 			}).SetupWithManager(mgr, enableProgressiveSyncs, maxConcurrentReconciliations); err != nil {
 				log.Error(err, "unable to create controller", "controller", "ApplicationSet")
 				os.Exit(1)
 			}
 
+			if enableClusterRegistration {
+				if err := mgr.Add(&controllers.ClusterRegistrationController{
+					DynamicClient: dynamicClient,
+					KubeClientset: k8sClient,
+					ArgoDB:        argoCDDB,
+				}); err != nil {
+					log.Error(err, "unable to create controller", "controller", "ClusterRegistration")
+					os.Exit(1)
+				}
+			}
+
+			if enableClusterTagSync {
+				if err := mgr.Add(&controllers.ClusterTagSyncController{
+					ArgoDB:           argoCDDB,
+					Interval:         clusterTagSyncInterval,
+					CloudClusterTags: clustertags.CloudClusterTags,
+				}); err != nil {
+					log.Error(err, "unable to create controller", "controller", "ClusterTagSync")
+					os.Exit(1)
+				}
+			}
+
 			stats.StartStatsTicker(10 * time.Minute)
 			log.Info("Starting manager")
 			if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
@@ -265,6 +310,9 @@ func NewCommand() *cobra.Command {
 	command.Flags().BoolVar(&tokenRefStrictMode, "token-ref-strict-mode", env.ParseBoolFromEnv("ARGOCD_APPLICATIONSET_CONTROLLER_TOKENREF_STRICT_MODE", false), fmt.Sprintf("Set to true to require secrets referenced by SCM providers to have the %s=%s label set (Default: false)", common.LabelKeySecretType, common.LabelValueSecretTypeSCMCreds))
 	command.Flags().BoolVar(&enableProgressiveSyncs, "enable-progressive-syncs", env.ParseBoolFromEnv("ARGOCD_APPLICATIONSET_CONTROLLER_ENABLE_PROGRESSIVE_SYNCS", false), "Enable use of the experimental progressive syncs feature.")
 	command.Flags().BoolVar(&enableNewGitFileGlobbing, "enable-new-git-file-globbing", env.ParseBoolFromEnv("ARGOCD_APPLICATIONSET_CONTROLLER_ENABLE_NEW_GIT_FILE_GLOBBING", false), "Enable new globbing in Git files generator.")
+	command.Flags().BoolVar(&enableClusterRegistration, "enable-cluster-registration", env.ParseBoolFromEnv("ARGOCD_APPLICATIONSET_CONTROLLER_ENABLE_CLUSTER_REGISTRATION", false), "Enable the registration of the Cluster API clusters as Argo CD clusters.")
+	command.Flags().BoolVar(&enableClusterTagSync, "enable-cluster-tag-sync", env.ParseBoolFromEnv("ARGOCD_APPLICATIONSET_CONTROLLER_ENABLE_CLUSTER_TAG_SYNC", false), "Enable the mirroring of the tags of the EKS, GKE and AKS clusters onto the labels of their cluster secrets.")
+	command.Flags().DurationVar(&clusterTagSyncInterval, "cluster-tag-sync-interval", env.ParseDurationFromEnv("ARGOCD_APPLICATIONSET_CONTROLLER_CLUSTER_TAG_SYNC_INTERVAL", 10*time.Minute, time.Minute, math.MaxInt64), "Interval at which the tags of the cloud clusters are mirrored onto the labels of their cluster secrets.")
 	command.Flags().BoolVar(&repoServerPlaintext, "repo-server-plaintext", env.ParseBoolFromEnv("ARGOCD_APPLICATIONSET_CONTROLLER_REPO_SERVER_PLAINTEXT", false), "Disable TLS on connections to repo server")
 	command.Flags().BoolVar(&repoServerStrictTLS, "repo-server-strict-tls", env.ParseBoolFromEnv("ARGOCD_APPLICATIONSET_CONTROLLER_REPO_SERVER_STRICT_TLS", false), "Whether to use strict validation of the TLS cert presented by the repo server")
 	command.Flags().IntVar(&repoServerTimeoutSeconds, "repo-server-timeout-seconds", env.ParseNumFromEnv("ARGOCD_APPLICATIONSET_CONTROLLER_REPO_SERVER_TIMEOUT_SECONDS", 60, 0, math.MaxInt64), "Repo server RPC call timeout seconds.")
@@ -275,6 +323,11 @@ func NewCommand() *cobra.Command {
 	command.Flags().IntVar(&webhookParallelism, "webhook-parallelism-limit", env.ParseNumFromEnv("ARGOCD_APPLICATIONSET_CONTROLLER_WEBHOOK_PARALLELISM_LIMIT", 50, 1, 1000), "Number of webhook requests processed concurrently")
 	command.Flags().StringSliceVar(&metricsAplicationsetLabels, "metrics-applicationset-labels", []string{}, "List of Application labels that will be added to the argocd_applicationset_labels metric")
 	command.Flags().BoolVar(&enableGitHubAPIMetrics, "enable-github-api-metrics", env.ParseBoolFromEnv("ARGOCD_APPLICATIONSET_CONTROLLER_ENABLE_GITHUB_API_METRICS", false), "Enable GitHub API metrics for generators that use the GitHub API")
+	command.Flags().IntVar(&githubAPIRateLimitReserve, "github-api-rate-limit-reserve", env.ParseNumFromEnv("ARGOCD_APPLICATIONSET_CONTROLLER_GITHUB_API_RATE_LIMIT_RESERVE", 0, 0, 100), "Percentage of the GitHub API rate limit reserved to the refreshes of the ApplicationSets triggered by webhooks or spec changes, the GitHub API calls of the other refreshes being deferred until the rate limit is reset. Requires the GitHub API metrics. Disabled when 0")
+	command.Flags().StringVar(&otlpAddress, "otlp-address", env.StringFromEnv("ARGOCD_APPLICATIONSET_CONTROLLER_OTLP_ADDRESS", ""), "OpenTelemetry collector address to send traces to")
+	command.Flags().BoolVar(&otlpInsecure, "otlp-insecure", env.ParseBoolFromEnv("ARGOCD_APPLICATIONSET_CONTROLLER_OTLP_INSECURE", true), "OpenTelemetry collector insecure mode")
+	command.Flags().StringToStringVar(&otlpHeaders, "otlp-headers", env.ParseStringToStringFromEnv("ARGOCD_APPLICATIONSET_CONTROLLER_OTLP_HEADERS", map[string]string{}, ","), "List of OpenTelemetry collector extra headers sent with traces, headers are comma-separated key-value pairs(e.g. key1=value1,key2=value2)")
+	command.Flags().StringSliceVar(&otlpAttrs, "otlp-attrs", env.StringsFromEnv("ARGOCD_APPLICATIONSET_CONTROLLER_OTLP_ATTRS", []string{}, ","), "List of OpenTelemetry collector extra attrs when send traces, each attribute is separated by a colon(e.g. key:value)")
 
 	return &command
 }
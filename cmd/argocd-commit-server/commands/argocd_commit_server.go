@@ -58,7 +58,8 @@ func NewCommand() *cobra.Command {
 			go func() { errors.CheckError(askPassServer.Run()) }()
 
 			server := commitserver.NewServer(askPassServer, metricsServer)
-			grpc := server.CreateGRPC()
+			grpc, err := server.CreateGRPC()
+			errors.CheckError(err)
 
 			listener, err := net.Listen("tcp", fmt.Sprintf("%s:%d", listenHost, listenPort))
 			errors.CheckError(err)
@@ -67,7 +68,7 @@ func NewCommand() *cobra.Command {
 				if val, ok := r.URL.Query()["full"]; ok && len(val) > 0 && val[0] == "true" {
 					// connect to itself to make sure commit server is able to serve connection
 					// used by liveness probe to auto restart commit server
-					conn, err := apiclient.NewConnection(fmt.Sprintf("localhost:%d", listenPort))
+					conn, err := apiclient.NewConnection(fmt.Sprintf("localhost:%d", listenPort), 0, &apiclient.TLSConfiguration{DisableTLS: true})
 					if err != nil {
 						return err
 					}
@@ -145,6 +145,7 @@ func NewCommand() *cobra.Command {
 			defer argocdService.Close()
 
 			registry := controller.NewMetricsRegistry("argocd")
+			notificationscontroller.RegisterDeliveryMetrics(prometheus.DefaultRegisterer)
 			http.Handle("/metrics", promhttp.HandlerFor(prometheus.Gatherers{registry, prometheus.DefaultGatherer}, promhttp.HandlerOpts{}))
 
 			go func() {
@@ -31,9 +31,11 @@ import (
 	"github.com/argoproj/argo-cd/v3/util/cli"
 	"github.com/argoproj/argo-cd/v3/util/env"
 	"github.com/argoproj/argo-cd/v3/util/errors"
+	"github.com/argoproj/argo-cd/v3/util/fips"
 	"github.com/argoproj/argo-cd/v3/util/gpg"
 	"github.com/argoproj/argo-cd/v3/util/healthz"
 	utilio "github.com/argoproj/argo-cd/v3/util/io"
+	"github.com/argoproj/argo-cd/v3/util/profile"
 	"github.com/argoproj/argo-cd/v3/util/tls"
 	traceutil "github.com/argoproj/argo-cd/v3/util/trace"
 )
@@ -56,6 +58,7 @@ func NewCommand() *cobra.Command {
 		parallelismLimit                   int64
 		listenPort                         int
 		listenHost                         string
+		unixSocketPath                     string
 		metricsPort                        int
 		metricsHost                        string
 		otlpAddress                        string
@@ -65,7 +68,7 @@ func NewCommand() *cobra.Command {
 		cacheSrc                           func() (*reposervercache.Cache, error)
 		tlsConfigCustomizer                tls.ConfigCustomizer
 		tlsConfigCustomizerSrc             func() (tls.ConfigCustomizer, error)
-		redisClient                        *redis.Client
+		redisClient                        redis.UniversalClient
 		disableTLS                         bool
 		maxCombinedDirectoryManifestsSize  string
 		cmpTarExcludedGlobs                []string
@@ -137,6 +140,7 @@ func NewCommand() *cobra.Command {
 			askPassServer := askpass.NewServer(askpass.SocketPath)
 			metricsServer := metrics.NewMetricsServer()
 			cacheutil.CollectMetrics(redisClient, metricsServer, nil)
+			cache.SetMetricsRegistry(metricsServer)
 			server, err := reposerver.NewServer(metricsServer, cache, tlsConfigCustomizer, repository.RepoServerInitConstants{
 				ParallelismLimit: parallelismLimit,
 				PauseGenerationAfterFailedGenerationAttempts: pauseGenerationAfterFailedGenerationAttempts,
@@ -172,6 +176,20 @@ func NewCommand() *cobra.Command {
 			listener, err := net.Listen("tcp", fmt.Sprintf("%s:%d", listenHost, listenPort))
 			errors.CheckError(err)
 
+			// the clients within the pod, such as a sidecar application controller, can connect without TLS nor TCP
+			stopLocalGRPC := func() {}
+			if unixSocketPath != "" {
+				if err := os.Remove(unixSocketPath); err != nil && !os.IsNotExist(err) {
+					errors.CheckError(err)
+				}
+				unixListener, err := net.Listen("unix", unixSocketPath)
+				errors.CheckError(err)
+				localGRPC := server.CreateLocalGRPC()
+				stopLocalGRPC = localGRPC.GracefulStop
+				log.Infof("argocd-repo-server is listening on unix://%s", unixSocketPath)
+				go func() { errors.CheckError(localGRPC.Serve(unixListener)) }()
+			}
+
 			healthz.ServeHealthCheck(http.DefaultServeMux, func(r *http.Request) error {
 				if val, ok := r.URL.Query()["full"]; ok && len(val) > 0 && val[0] == "true" {
 					// connect to itself to make sure repo server is able to serve connection
@@ -195,6 +213,10 @@ func NewCommand() *cobra.Command {
 				return nil
 			})
 			http.Handle("/metrics", metricsServer.GetHandler())
+			profile.RegisterProfiler(http.DefaultServeMux)
+			fips.RegisterStatusHandler(http.DefaultServeMux)
+			server.RegisterSettingsStatusHandler(http.DefaultServeMux)
+			profile.StartExporter(ctx, cliName, profile.ExportConfigFromEnv())
 			go func() { errors.CheckError(http.ListenAndServe(fmt.Sprintf("%s:%d", metricsHost, metricsPort), nil)) }()
 			go func() { errors.CheckError(askPassServer.Run()) }()
 
@@ -225,6 +247,7 @@ func NewCommand() *cobra.Command {
 				s := <-sigCh
 				log.Printf("got signal %v, attempting graceful shutdown", s)
 				grpc.GracefulStop()
+				stopLocalGRPC()
 				wg.Done()
 			}()
 
@@ -244,6 +267,7 @@ func NewCommand() *cobra.Command {
 	command.Flags().Int64Var(&parallelismLimit, "parallelismlimit", int64(env.ParseNumFromEnv("ARGOCD_REPO_SERVER_PARALLELISM_LIMIT", 0, 0, math.MaxInt32)), "Limit on number of concurrent manifests generate requests. Any value less the 1 means no limit.")
 	command.Flags().StringVar(&listenHost, "address", env.StringFromEnv("ARGOCD_REPO_SERVER_LISTEN_ADDRESS", common.DefaultAddressRepoServer), "Listen on given address for incoming connections")
 	command.Flags().IntVar(&listenPort, "port", common.DefaultPortRepoServer, "Listen on given port for incoming connections")
+	command.Flags().StringVar(&unixSocketPath, "unix-socket", env.StringFromEnv("ARGOCD_REPO_SERVER_UNIX_SOCKET", ""), "Also listen without TLS on the given Unix domain socket for the connections within the pod")
 	command.Flags().StringVar(&metricsHost, "metrics-address", env.StringFromEnv("ARGOCD_REPO_SERVER_METRICS_LISTEN_ADDRESS", common.DefaultAddressRepoServerMetrics), "Listen on given address for metrics")
 	command.Flags().IntVar(&metricsPort, "metrics-port", common.DefaultPortRepoServerMetrics, "Start metrics server on given port")
 	command.Flags().StringVar(&otlpAddress, "otlp-address", env.StringFromEnv("ARGOCD_REPO_SERVER_OTLP_ADDRESS", ""), "OpenTelemetry collector address to send traces to")
@@ -266,9 +290,10 @@ func NewCommand() *cobra.Command {
 	command.Flags().StringSliceVar(&ociMediaTypes, "oci-layer-media-types", env.StringsFromEnv("ARGOCD_REPO_SERVER_OCI_LAYER_MEDIA_TYPES", []string{"application/vnd.oci.image.layer.v1.tar", "application/vnd.oci.image.layer.v1.tar+gzip", "application/vnd.cncf.helm.chart.content.v1.tar+gzip"}, ","), "Comma separated list of allowed media types for OCI media types. This only accounts for media types within layers.")
 	tlsConfigCustomizerSrc = tls.AddTLSFlagsToCmd(&command)
 	cacheSrc = reposervercache.AddCacheFlagsToCmd(&command, cacheutil.Options{
-		OnClientCreated: func(client *redis.Client) {
+		OnClientCreated: func(client redis.UniversalClient) {
 			redisClient = client
 		},
+		EmbeddedBackendSupported: true,
 	})
 	return &command
 }
@@ -35,6 +35,7 @@ import (
 	"github.com/argoproj/argo-cd/v3/util/env"
 	"github.com/argoproj/argo-cd/v3/util/errors"
 	"github.com/argoproj/argo-cd/v3/util/kube"
+	"github.com/argoproj/argo-cd/v3/util/profile"
 	"github.com/argoproj/argo-cd/v3/util/templates"
 	"github.com/argoproj/argo-cd/v3/util/tls"
 	traceutil "github.com/argoproj/argo-cd/v3/util/trace"
@@ -54,7 +55,7 @@ var (
 // NewCommand returns a new instance of an argocd command
 func NewCommand() *cobra.Command {
 	var (
-		redisClient              *redis.Client
+		redisClient              redis.UniversalClient
 		insecure                 bool
 		listenHost               string
 		listenPort               int
@@ -87,8 +88,11 @@ func NewCommand() *cobra.Command {
 		applicationNamespaces    []string
 		enableProxyExtension     bool
 		webhookParallelism       int
+		webhookPrecacheLimit     int
 		hydratorEnabled          bool
 		syncWithReplaceAllowed   bool
+		controllerMetricsAddress string
+		repoServerMetricsAddress string
 
 		// ApplicationSet
 		enableNewGitFileGlobbing bool
@@ -217,37 +221,40 @@ func NewCommand() *cobra.Command {
 			}
 
 			argoCDOpts := server.ArgoCDServerOpts{
-				Insecure:                insecure,
-				ListenPort:              listenPort,
-				ListenHost:              listenHost,
-				MetricsPort:             metricsPort,
-				MetricsHost:             metricsHost,
-				Namespace:               namespace,
-				BaseHRef:                baseHRef,
-				RootPath:                rootPath,
-				DynamicClientset:        dynamicClient,
-				KubeControllerClientset: controllerClient,
-				KubeClientset:           kubeclientset,
-				AppClientset:            appClientSet,
-				RepoClientset:           repoclientset,
-				DexServerAddr:           dexServerAddress,
-				DexTLSConfig:            dexTLSConfig,
-				DisableAuth:             disableAuth,
-				ContentTypes:            contentTypesList,
-				EnableGZip:              enableGZip,
-				TLSConfigCustomizer:     tlsConfigCustomizer,
-				Cache:                   cache,
-				RepoServerCache:         repoServerCache,
-				XFrameOptions:           frameOptions,
-				ContentSecurityPolicy:   contentSecurityPolicy,
-				RedisClient:             redisClient,
-				StaticAssetsDir:         staticAssetsDir,
-				ApplicationNamespaces:   applicationNamespaces,
-				EnableProxyExtension:    enableProxyExtension,
-				WebhookParallelism:      webhookParallelism,
-				EnableK8sEvent:          enableK8sEvent,
-				HydratorEnabled:         hydratorEnabled,
-				SyncWithReplaceAllowed:  syncWithReplaceAllowed,
+				Insecure:                 insecure,
+				ListenPort:               listenPort,
+				ListenHost:               listenHost,
+				MetricsPort:              metricsPort,
+				MetricsHost:              metricsHost,
+				Namespace:                namespace,
+				BaseHRef:                 baseHRef,
+				RootPath:                 rootPath,
+				DynamicClientset:         dynamicClient,
+				KubeControllerClientset:  controllerClient,
+				KubeClientset:            kubeclientset,
+				AppClientset:             appClientSet,
+				RepoClientset:            repoclientset,
+				DexServerAddr:            dexServerAddress,
+				DexTLSConfig:             dexTLSConfig,
+				DisableAuth:              disableAuth,
+				ContentTypes:             contentTypesList,
+				EnableGZip:               enableGZip,
+				TLSConfigCustomizer:      tlsConfigCustomizer,
+				Cache:                    cache,
+				RepoServerCache:          repoServerCache,
+				XFrameOptions:            frameOptions,
+				ContentSecurityPolicy:    contentSecurityPolicy,
+				RedisClient:              redisClient,
+				StaticAssetsDir:          staticAssetsDir,
+				ApplicationNamespaces:    applicationNamespaces,
+				EnableProxyExtension:     enableProxyExtension,
+				WebhookParallelism:       webhookParallelism,
+				WebhookPrecacheLimit:     webhookPrecacheLimit,
+				EnableK8sEvent:           enableK8sEvent,
+				HydratorEnabled:          hydratorEnabled,
+				SyncWithReplaceAllowed:   syncWithReplaceAllowed,
+				ControllerMetricsAddress: controllerMetricsAddress,
+				RepoServerMetricsAddress: repoServerMetricsAddress,
 			}
 
 			appsetOpts := server.ApplicationSetOpts{
@@ -262,6 +269,7 @@ func NewCommand() *cobra.Command {
 			stats.RegisterStackDumper()
 			stats.StartStatsTicker(10 * time.Minute)
 			stats.RegisterHeapDumper("memprofile")
+			profile.StartExporter(ctx, cliName, profile.ExportConfigFromEnv())
 			argocd := server.NewServer(ctx, argoCDOpts, appsetOpts)
 			argocd.Init(ctx)
 			for {
@@ -326,9 +334,12 @@ func NewCommand() *cobra.Command {
 	command.Flags().StringSliceVar(&applicationNamespaces, "application-namespaces", env.StringsFromEnv("ARGOCD_APPLICATION_NAMESPACES", []string{}, ","), "List of additional namespaces where application resources can be managed in")
 	command.Flags().BoolVar(&enableProxyExtension, "enable-proxy-extension", env.ParseBoolFromEnv("ARGOCD_SERVER_ENABLE_PROXY_EXTENSION", false), "Enable Proxy Extension feature")
 	command.Flags().IntVar(&webhookParallelism, "webhook-parallelism-limit", env.ParseNumFromEnv("ARGOCD_SERVER_WEBHOOK_PARALLELISM_LIMIT", 50, 1, 1000), "Number of webhook requests processed concurrently")
+	command.Flags().IntVar(&webhookPrecacheLimit, "webhook-precache-parallelism-limit", env.ParseNumFromEnv("ARGOCD_SERVER_WEBHOOK_PRECACHE_PARALLELISM_LIMIT", 0, 0, 1000), "Number of applications refreshed by webhook events whose manifests are rendered concurrently to warm up the repo-server cache, 0 to disable")
 	command.Flags().StringSliceVar(&enableK8sEvent, "enable-k8s-event", env.StringsFromEnv("ARGOCD_ENABLE_K8S_EVENT", argo.DefaultEnableEventList(), ","), "Enable ArgoCD to use k8s event. For disabling all events, set the value as `none`. (e.g --enable-k8s-event=none), For enabling specific events, set the value as `event reason`. (e.g --enable-k8s-event=StatusRefreshed,ResourceCreated)")
 	command.Flags().BoolVar(&hydratorEnabled, "hydrator-enabled", env.ParseBoolFromEnv("ARGOCD_HYDRATOR_ENABLED", false), "Feature flag to enable Hydrator. Default (\"false\")")
 	command.Flags().BoolVar(&syncWithReplaceAllowed, "sync-with-replace-allowed", env.ParseBoolFromEnv("ARGOCD_SYNC_WITH_REPLACE_ALLOWED", true), "Whether to allow users to select replace for syncs from UI/CLI")
+	command.Flags().StringVar(&controllerMetricsAddress, "controller-metrics-address", env.StringFromEnv("ARGOCD_SERVER_CONTROLLER_METRICS_ADDRESS", fmt.Sprintf("argocd-metrics:%d", common.DefaultPortArgoCDMetrics)), "Address of the application controller metrics endpoint, which profiles are captured from")
+	command.Flags().StringVar(&repoServerMetricsAddress, "repo-server-metrics-address", env.StringFromEnv("ARGOCD_SERVER_REPO_SERVER_METRICS_ADDRESS", fmt.Sprintf("argocd-repo-server:%d", common.DefaultPortRepoServerMetrics)), "Address of the repo server metrics endpoint, which profiles are captured from")
 
 	// Flags related to the applicationSet component.
 	command.Flags().StringVar(&scmRootCAPath, "appset-scm-root-ca-path", env.StringFromEnv("ARGOCD_APPLICATIONSET_CONTROLLER_SCM_ROOT_CA_PATH", ""), "Provide Root CA Path for self-signed TLS Certificates")
@@ -339,7 +350,7 @@ func NewCommand() *cobra.Command {
 
 	tlsConfigCustomizerSrc = tls.AddTLSFlagsToCmd(command)
 	cacheSrc = servercache.AddCacheFlagsToCmd(command, cacheutil.Options{
-		OnClientCreated: func(client *redis.Client) {
+		OnClientCreated: func(client redis.UniversalClient) {
 			redisClient = client
 		},
 	})
@@ -71,6 +71,7 @@ $ argocd admin initial-password reset
 	}
 
 	command.AddCommand(NewClusterCommand(clientOpts, pathOpts))
+	command.AddCommand(NewControllerCommand(clientOpts))
 	command.AddCommand(NewProjectsCommand())
 	command.AddCommand(NewSettingsCommand())
 	command.AddCommand(NewAppCommand(clientOpts))
@@ -81,6 +82,7 @@ $ argocd admin initial-password reset
 	command.AddCommand(NewNotificationsCommand())
 	command.AddCommand(NewInitialPasswordCommand())
 	command.AddCommand(NewRedisInitialPasswordCommand())
+	command.AddCommand(NewReencryptSecretsCommand())
 
 	command.Flags().StringVar(&cmdutil.LogFormat, "logformat", "json", "Set the logging format. One of: json|text")
 	command.Flags().StringVar(&cmdutil.LogLevel, "loglevel", "info", "Set the logging level. One of: debug|info|warn|error")
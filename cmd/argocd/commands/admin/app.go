@@ -34,6 +34,7 @@ import (
 	appinformers "github.com/argoproj/argo-cd/v3/pkg/client/informers/externalversions"
 	reposerverclient "github.com/argoproj/argo-cd/v3/reposerver/apiclient"
 	"github.com/argoproj/argo-cd/v3/util/argo"
+	argodiff "github.com/argoproj/argo-cd/v3/util/argo/diff"
 	"github.com/argoproj/argo-cd/v3/util/argo/normalizers"
 	cacheutil "github.com/argoproj/argo-cd/v3/util/cache"
 	appstatecache "github.com/argoproj/argo-cd/v3/util/cache/appstate"
@@ -51,6 +52,12 @@ func NewAppCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
 		Use:   "app",
 		Short: "Manage applications configuration",
 		Example: `
+# Measure refresh and sync latencies using 50 synthetic applications
+argocd admin app benchmark --count 50
+
+# Delete the cache entries of the applications which don't exist anymore
+argocd admin app cache-gc
+
 # Compare results of two reconciliations and print diff
 argocd admin app diff-reconcile-results APPNAME [flags]
 
@@ -68,6 +75,8 @@ argocd admin app get-reconcile-results APPNAME
 	command.AddCommand(NewGenAppSpecCommand())
 	command.AddCommand(NewReconcileCommand(clientOpts))
 	command.AddCommand(NewDiffReconcileResults())
+	command.AddCommand(NewBenchmarkCommand())
+	command.AddCommand(NewCacheGCCommand(clientOpts))
 	return command
 }
 
@@ -422,7 +431,10 @@ func reconcileApplications(
 		false,
 		0,
 		serverSideDiff,
+		argodiff.ServerSideDryRunOpts{},
 		ignoreNormalizerOpts,
+		appLister,
+		projLister,
 	)
 
 	appsList, err := appClientset.ArgoprojV1alpha1().Applications(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
@@ -478,5 +490,5 @@ func reconcileApplications(
 }
 
 func newLiveStateCache(argoDB db.ArgoDB, appInformer kubecache.SharedIndexInformer, settingsMgr *settings.SettingsManager, server *metrics.MetricsServer) cache.LiveStateCache {
-	return cache.NewLiveStateCache(argoDB, appInformer, settingsMgr, server, func(_ map[string]bool, _ corev1.ObjectReference) {}, &sharding.ClusterSharding{}, argo.NewResourceTracking())
+	return cache.NewLiveStateCache(argoDB, appInformer, nil, settingsMgr, server, func(_ map[string]bool, _ corev1.ObjectReference) {}, &sharding.ClusterSharding{}, argo.NewResourceTracking())
 }
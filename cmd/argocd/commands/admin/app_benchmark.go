@@ -0,0 +1,383 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	synccommon "github.com/argoproj/gitops-engine/pkg/sync/common"
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	appclientset "github.com/argoproj/argo-cd/v3/pkg/client/clientset/versioned"
+	applicationsv1alpha1 "github.com/argoproj/argo-cd/v3/pkg/client/clientset/versioned/typed/application/v1alpha1"
+	"github.com/argoproj/argo-cd/v3/util/argo"
+	"github.com/argoproj/argo-cd/v3/util/cli"
+	"github.com/argoproj/argo-cd/v3/util/errors"
+)
+
+const (
+	// benchmarkLabel is set on the applications and namespaces created by a benchmark run, its value is the run ID
+	benchmarkLabel = "argocd.argoproj.io/benchmark"
+	// benchmarkPollInterval is the interval at which the benchmark applications are polled, which bounds the
+	// precision of the measured latencies
+	benchmarkPollInterval = time.Second
+
+	benchmarkPhaseInitialReconcile = "initial-reconcile"
+	benchmarkPhaseRefresh          = "hard-refresh"
+	benchmarkPhaseSync             = "sync"
+)
+
+type benchmarkOptions struct {
+	count           int
+	repoURL         string
+	path            string
+	revision        string
+	project         string
+	destServer      string
+	namespacePrefix string
+	timeout         time.Duration
+	skipSync        bool
+}
+
+// benchmarkPhaseResult holds the latencies measured for one phase of a benchmark run
+type benchmarkPhaseResult struct {
+	Phase  string        `json:"phase"`
+	Count  int           `json:"count"`
+	Failed int           `json:"failed"`
+	P50    time.Duration `json:"p50"`
+	P90    time.Duration `json:"p90"`
+	P99    time.Duration `json:"p99"`
+	Max    time.Duration `json:"max"`
+}
+
+// NewBenchmarkCommand returns a new instance of an `argocd admin app benchmark` command
+func NewBenchmarkCommand() *cobra.Command {
+	var (
+		clientConfig clientcmd.ClientConfig
+		opts         benchmarkOptions
+		keep         bool
+		output       string
+	)
+	command := &cobra.Command{
+		Use:   "benchmark",
+		Short: "Measure refresh and sync latencies using synthetic applications",
+		Long: `Measure refresh and sync latencies using synthetic applications.
+
+Creates the given number of applications from a test repository, each deploying into its own namespace, and measures
+how long the application controller takes to reconcile, hard refresh and sync them. The latencies are reported as
+percentiles with a precision of one second. The applications, their resources and their namespaces are deleted
+once the benchmark has finished unless --keep is set.
+
+The command talks to the Kubernetes API directly and requires a running application controller and repo server.
+Do not run it against a production instance.`,
+		Example: `
+# Measure latencies using 50 applications deploying the guestbook example
+argocd admin app benchmark --count 50
+
+# Only measure refresh latencies of a Helm chart and keep the applications for inspection
+argocd admin app benchmark --count 100 --repo https://github.com/argoproj/argocd-example-apps.git --path helm-guestbook --skip-sync --keep
+`,
+		Run: func(c *cobra.Command, args []string) {
+			ctx := c.Context()
+
+			if len(args) != 0 {
+				c.HelpFunc()(c, args)
+				os.Exit(1)
+			}
+			if opts.count < 1 {
+				errors.Fatal(errors.ErrorGeneric, "--count must be at least 1")
+			}
+			if output != "text" && output != "json" {
+				errors.Fatalf(errors.ErrorGeneric, "unknown output format: %s", output)
+			}
+
+			cfg, err := clientConfig.ClientConfig()
+			errors.CheckError(err)
+			namespace, _, err := clientConfig.Namespace()
+			errors.CheckError(err)
+			appIf := appclientset.NewForConfigOrDie(cfg).ArgoprojV1alpha1().Applications(namespace)
+			kubeClientset := kubernetes.NewForConfigOrDie(cfg)
+
+			runID := rand.String(5)
+			printLine("Starting benchmark %s with %d applications", runID, opts.count)
+			results, err := runBenchmark(ctx, appIf, runID, opts)
+			if keep {
+				printLine("Keeping applications, delete them using: kubectl delete applications -n %s -l %s=%s", namespace, benchmarkLabel, runID)
+			} else {
+				printLine("Deleting applications of benchmark %s", runID)
+				errors.CheckError(teardownBenchmark(ctx, appIf, kubeClientset, runID, opts.timeout))
+			}
+			errors.CheckError(err)
+
+			if output == "json" {
+				data, err := json.MarshalIndent(results, "", "  ")
+				errors.CheckError(err)
+				fmt.Println(string(data))
+				return
+			}
+			printBenchmarkResults(os.Stdout, results)
+		},
+	}
+	clientConfig = cli.AddKubectlFlagsToCmd(command)
+	command.Flags().IntVar(&opts.count, "count", 10, "Number of applications to create")
+	command.Flags().StringVar(&opts.repoURL, "repo", "https://github.com/argoproj/argocd-example-apps.git", "Repository URL of the applications")
+	command.Flags().StringVar(&opts.path, "path", "guestbook", "Path of the applications within the repository")
+	command.Flags().StringVar(&opts.revision, "revision", "HEAD", "Revision of the applications")
+	command.Flags().StringVar(&opts.project, "project", "default", "Project of the applications")
+	command.Flags().StringVar(&opts.destServer, "dest-server", v1alpha1.KubernetesInternalAPIServerAddr, "Cluster the applications deploy to")
+	command.Flags().StringVar(&opts.namespacePrefix, "dest-namespace-prefix", "argocd-benchmark", "Prefix of the namespaces the applications deploy to")
+	command.Flags().DurationVar(&opts.timeout, "timeout", 10*time.Minute, "Maximum duration of every phase of the benchmark")
+	command.Flags().BoolVar(&opts.skipSync, "skip-sync", false, "Only measure reconciliation and refresh latencies")
+	command.Flags().BoolVar(&keep, "keep", false, "Keep the applications once the benchmark has finished")
+	command.Flags().StringVarP(&output, "output", "o", "text", "Output format. One of: text|json")
+	return command
+}
+
+// newBenchmarkApplication returns the i-th synthetic application of a benchmark run
+func newBenchmarkApplication(runID string, i int, opts benchmarkOptions) *v1alpha1.Application {
+	labels := map[string]string{benchmarkLabel: runID}
+	return &v1alpha1.Application{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       fmt.Sprintf("benchmark-%s-%d", runID, i),
+			Labels:     labels,
+			Finalizers: []string{v1alpha1.ResourcesFinalizerName},
+		},
+		Spec: v1alpha1.ApplicationSpec{
+			Project: opts.project,
+			Source: &v1alpha1.ApplicationSource{
+				RepoURL:        opts.repoURL,
+				Path:           opts.path,
+				TargetRevision: opts.revision,
+			},
+			Destination: v1alpha1.ApplicationDestination{
+				Server:    opts.destServer,
+				Namespace: fmt.Sprintf("%s-%s-%d", opts.namespacePrefix, runID, i),
+			},
+			SyncPolicy: &v1alpha1.SyncPolicy{
+				SyncOptions:              v1alpha1.SyncOptions{"CreateNamespace=true"},
+				ManagedNamespaceMetadata: &v1alpha1.ManagedNamespaceMetadata{Labels: labels},
+			},
+		},
+	}
+}
+
+// runBenchmark creates the benchmark applications and measures the latencies of every phase
+func runBenchmark(ctx context.Context, appIf applicationsv1alpha1.ApplicationInterface, runID string, opts benchmarkOptions) ([]benchmarkPhaseResult, error) {
+	selector := fmt.Sprintf("%s=%s", benchmarkLabel, runID)
+	var results []benchmarkPhaseResult
+
+	started := make(map[string]time.Time)
+	for i := 0; i < opts.count; i++ {
+		app, err := appIf.Create(ctx, newBenchmarkApplication(runID, i, opts), metav1.CreateOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("error creating benchmark application: %w", err)
+		}
+		started[app.Name] = time.Now()
+	}
+	printLine("Waiting for the initial reconciliation of %d applications", opts.count)
+	latencies, failed, err := waitForBenchmarkApps(ctx, appIf, selector, started, opts.timeout, func(app *v1alpha1.Application) (bool, bool) {
+		return app.Status.ReconciledAt != nil, false
+	})
+	if err != nil {
+		return nil, err
+	}
+	results = append(results, summarizeLatencies(benchmarkPhaseInitialReconcile, latencies, failed))
+
+	started = make(map[string]time.Time)
+	for name := range latencies {
+		if _, err := argo.RefreshApp(appIf, name, v1alpha1.RefreshTypeHard, false); err != nil {
+			return nil, fmt.Errorf("error refreshing benchmark application %s: %w", name, err)
+		}
+		started[name] = time.Now()
+	}
+	printLine("Waiting for the hard refresh of %d applications", len(started))
+	latencies, failed, err = waitForBenchmarkApps(ctx, appIf, selector, started, opts.timeout, func(app *v1alpha1.Application) (bool, bool) {
+		_, refreshing := app.Annotations[v1alpha1.AnnotationKeyRefresh]
+		return !refreshing, false
+	})
+	if err != nil {
+		return nil, err
+	}
+	results = append(results, summarizeLatencies(benchmarkPhaseRefresh, latencies, failed))
+
+	if opts.skipSync {
+		return results, nil
+	}
+	operation, err := json.Marshal(map[string]any{
+		"operation": v1alpha1.Operation{
+			Sync:        &v1alpha1.SyncOperation{Revision: opts.revision, SyncOptions: v1alpha1.SyncOptions{"CreateNamespace=true"}},
+			InitiatedBy: v1alpha1.OperationInitiator{Username: "admin-benchmark"},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling sync operation: %w", err)
+	}
+	started = make(map[string]time.Time)
+	for name := range latencies {
+		if _, err := appIf.Patch(ctx, name, types.MergePatchType, operation, metav1.PatchOptions{}); err != nil {
+			return nil, fmt.Errorf("error syncing benchmark application %s: %w", name, err)
+		}
+		started[name] = time.Now()
+	}
+	printLine("Waiting for the sync of %d applications", len(started))
+	latencies, failed, err = waitForBenchmarkApps(ctx, appIf, selector, started, opts.timeout, func(app *v1alpha1.Application) (bool, bool) {
+		state := app.Status.OperationState
+		if state == nil || !state.Phase.Completed() {
+			return false, false
+		}
+		return true, state.Phase != synccommon.OperationSucceeded
+	})
+	if err != nil {
+		return nil, err
+	}
+	results = append(results, summarizeLatencies(benchmarkPhaseSync, latencies, failed))
+	return results, nil
+}
+
+// waitForBenchmarkApps polls the benchmark applications until every started application is done or the timeout
+// expires. Returns the latencies of the successfully finished applications and the number of applications which
+// either failed or did not finish in time.
+func waitForBenchmarkApps(ctx context.Context, appIf applicationsv1alpha1.ApplicationInterface, selector string, started map[string]time.Time, timeout time.Duration, done func(app *v1alpha1.Application) (finished bool, failed bool)) (map[string]time.Duration, int, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	latencies := make(map[string]time.Duration)
+	failed := 0
+	pending := len(started)
+	finished := make(map[string]bool)
+	ticker := time.NewTicker(benchmarkPollInterval)
+	defer ticker.Stop()
+	for pending > 0 {
+		apps, err := appIf.List(ctx, metav1.ListOptions{LabelSelector: selector})
+		if err != nil {
+			if ctx.Err() != nil {
+				break
+			}
+			return nil, 0, fmt.Errorf("error listing benchmark applications: %w", err)
+		}
+		now := time.Now()
+		for i := range apps.Items {
+			app := &apps.Items[i]
+			start, ok := started[app.Name]
+			if !ok || finished[app.Name] {
+				continue
+			}
+			isFinished, isFailed := done(app)
+			if !isFinished {
+				continue
+			}
+			finished[app.Name] = true
+			pending--
+			if isFailed {
+				failed++
+			} else {
+				latencies[app.Name] = now.Sub(start)
+			}
+		}
+		if pending == 0 {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			pending = 0
+		case <-ticker.C:
+		}
+	}
+	failed += len(started) - len(finished)
+	return latencies, failed, nil
+}
+
+// teardownBenchmark deletes the applications of a benchmark run, waits for their resources to be deleted and then
+// deletes the namespaces created for them
+func teardownBenchmark(ctx context.Context, appIf applicationsv1alpha1.ApplicationInterface, kubeClientset kubernetes.Interface, runID string, timeout time.Duration) error {
+	selector := fmt.Sprintf("%s=%s", benchmarkLabel, runID)
+	apps, err := appIf.List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return fmt.Errorf("error listing benchmark applications: %w", err)
+	}
+	for _, app := range apps.Items {
+		if err := appIf.Delete(ctx, app.Name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("error deleting benchmark application %s: %w", app.Name, err)
+		}
+	}
+
+	deadline := time.Now().Add(timeout)
+	for {
+		apps, err = appIf.List(ctx, metav1.ListOptions{LabelSelector: selector})
+		if err != nil {
+			return fmt.Errorf("error listing benchmark applications: %w", err)
+		}
+		if len(apps.Items) == 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out waiting for the deletion of %d benchmark applications", len(apps.Items))
+		}
+		time.Sleep(benchmarkPollInterval)
+	}
+
+	namespaces, err := kubeClientset.CoreV1().Namespaces().List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return fmt.Errorf("error listing benchmark namespaces: %w", err)
+	}
+	for _, ns := range namespaces.Items {
+		if err := kubeClientset.CoreV1().Namespaces().Delete(ctx, ns.Name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("error deleting benchmark namespace %s: %w", ns.Name, err)
+		}
+	}
+	return nil
+}
+
+// latencyPercentile returns the p-th percentile of the sorted latencies using the nearest-rank method
+func latencyPercentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+func summarizeLatencies(phase string, latencies map[string]time.Duration, failed int) benchmarkPhaseResult {
+	sorted := make([]time.Duration, 0, len(latencies))
+	for _, latency := range latencies {
+		sorted = append(sorted, latency)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i] < sorted[j]
+	})
+	return benchmarkPhaseResult{
+		Phase:  phase,
+		Count:  len(sorted),
+		Failed: failed,
+		P50:    latencyPercentile(sorted, 50),
+		P90:    latencyPercentile(sorted, 90),
+		P99:    latencyPercentile(sorted, 99),
+		Max:    latencyPercentile(sorted, 100),
+	}
+}
+
+func printBenchmarkResults(out io.Writer, results []benchmarkPhaseResult) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintf(w, "PHASE\tCOUNT\tFAILED\tP50\tP90\tP99\tMAX\n")
+	for _, res := range results {
+		_, _ = fmt.Fprintf(w, "%s\t%d\t%d\t%s\t%s\t%s\t%s\n", res.Phase, res.Count, res.Failed,
+			res.P50.Round(time.Millisecond), res.P90.Round(time.Millisecond), res.P99.Round(time.Millisecond), res.Max.Round(time.Millisecond))
+	}
+	_ = w.Flush()
+}
@@ -0,0 +1,104 @@
+package admin
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	appfake "github.com/argoproj/argo-cd/v3/pkg/client/clientset/versioned/fake"
+)
+
+func TestLatencyPercentile(t *testing.T) {
+	assert.Equal(t, time.Duration(0), latencyPercentile(nil, 50))
+
+	sorted := make([]time.Duration, 0, 10)
+	for i := 1; i <= 10; i++ {
+		sorted = append(sorted, time.Duration(i)*time.Second)
+	}
+	assert.Equal(t, time.Second, latencyPercentile(sorted, 0))
+	assert.Equal(t, 5*time.Second, latencyPercentile(sorted, 50))
+	assert.Equal(t, 9*time.Second, latencyPercentile(sorted, 90))
+	assert.Equal(t, 10*time.Second, latencyPercentile(sorted, 99))
+	assert.Equal(t, 10*time.Second, latencyPercentile(sorted, 100))
+}
+
+func TestSummarizeLatencies(t *testing.T) {
+	res := summarizeLatencies(benchmarkPhaseSync, map[string]time.Duration{
+		"a": 3 * time.Second,
+		"b": time.Second,
+		"c": 2 * time.Second,
+	}, 1)
+	assert.Equal(t, benchmarkPhaseResult{
+		Phase:  benchmarkPhaseSync,
+		Count:  3,
+		Failed: 1,
+		P50:    2 * time.Second,
+		P90:    3 * time.Second,
+		P99:    3 * time.Second,
+		Max:    3 * time.Second,
+	}, res)
+}
+
+func TestNewBenchmarkApplication(t *testing.T) {
+	app := newBenchmarkApplication("abcde", 3, benchmarkOptions{
+		repoURL:         "https://github.com/argoproj/argocd-example-apps.git",
+		path:            "guestbook",
+		revision:        "HEAD",
+		project:         "default",
+		destServer:      v1alpha1.KubernetesInternalAPIServerAddr,
+		namespacePrefix: "argocd-benchmark",
+	})
+	assert.Equal(t, "benchmark-abcde-3", app.Name)
+	assert.Equal(t, "abcde", app.Labels[benchmarkLabel])
+	assert.Equal(t, []string{v1alpha1.ResourcesFinalizerName}, app.Finalizers)
+	assert.Equal(t, "argocd-benchmark-abcde-3", app.Spec.Destination.Namespace)
+	assert.Equal(t, "guestbook", app.Spec.GetSource().Path)
+	require.NotNil(t, app.Spec.SyncPolicy.ManagedNamespaceMetadata)
+	assert.Equal(t, "abcde", app.Spec.SyncPolicy.ManagedNamespaceMetadata.Labels[benchmarkLabel])
+	assert.True(t, app.Spec.SyncPolicy.SyncOptions.HasOption("CreateNamespace=true"))
+}
+
+func TestWaitForBenchmarkApps(t *testing.T) {
+	reconciledAt := metav1.Now()
+	appIf := appfake.NewSimpleClientset(
+		&v1alpha1.Application{
+			ObjectMeta: metav1.ObjectMeta{Name: "reconciled", Namespace: "argocd", Labels: map[string]string{benchmarkLabel: "abcde"}},
+			Status:     v1alpha1.ApplicationStatus{ReconciledAt: &reconciledAt},
+		},
+		&v1alpha1.Application{
+			ObjectMeta: metav1.ObjectMeta{Name: "pending", Namespace: "argocd", Labels: map[string]string{benchmarkLabel: "abcde"}},
+		},
+	).ArgoprojV1alpha1().Applications("argocd")
+
+	start := time.Now()
+	latencies, failed, err := waitForBenchmarkApps(t.Context(), appIf, benchmarkLabel+"=abcde", map[string]time.Time{
+		"reconciled": start,
+		"pending":    start,
+	}, 10*time.Millisecond, func(app *v1alpha1.Application) (bool, bool) {
+		return app.Status.ReconciledAt != nil, false
+	})
+	require.NoError(t, err)
+	assert.Len(t, latencies, 1)
+	assert.Contains(t, latencies, "reconciled")
+	assert.Equal(t, 1, failed)
+}
+
+func TestPrintBenchmarkResults(t *testing.T) {
+	var out bytes.Buffer
+	printBenchmarkResults(&out, []benchmarkPhaseResult{{
+		Phase: benchmarkPhaseRefresh,
+		Count: 10,
+		P50:   1500 * time.Millisecond,
+		P90:   2 * time.Second,
+		P99:   3 * time.Second,
+		Max:   3 * time.Second,
+	}})
+	assert.Equal(t, `PHASE         COUNT  FAILED  P50   P90  P99  MAX
+hard-refresh  10     0       1.5s  2s   3s   3s
+`, out.String())
+}
@@ -0,0 +1,95 @@
+package admin
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+
+	argocdclient "github.com/argoproj/argo-cd/v3/pkg/apiclient"
+	appclientset "github.com/argoproj/argo-cd/v3/pkg/client/clientset/versioned"
+	cacheutil "github.com/argoproj/argo-cd/v3/util/cache"
+	appstatecache "github.com/argoproj/argo-cd/v3/util/cache/appstate"
+	"github.com/argoproj/argo-cd/v3/util/cli"
+	"github.com/argoproj/argo-cd/v3/util/errors"
+)
+
+// NewCacheGCCommand returns a new instance of an `argocd admin app cache-gc` command
+func NewCacheGCCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
+	var (
+		clientConfig     clientcmd.ClientConfig
+		cacheSrc         func() (*appstatecache.Cache, error)
+		portForwardRedis bool
+		dryRun           bool
+	)
+	command := &cobra.Command{
+		Use:   "cache-gc",
+		Short: "Delete the cache entries of the applications which don't exist anymore",
+		Long:  "Delete the managed resources, resource trees and manifests cached for the applications which don't exist anymore, which otherwise linger in the cache until they expire.",
+		Example: `
+# Print the stale cache entries of the deleted applications
+argocd admin app cache-gc --dry-run
+
+# Delete the stale cache entries of the deleted applications
+argocd admin app cache-gc
+`,
+		Run: func(c *cobra.Command, _ []string) {
+			ctx := c.Context()
+
+			log.SetLevel(log.WarnLevel)
+
+			clientCfg, err := clientConfig.ClientConfig()
+			errors.CheckError(err)
+			namespace, _, err := clientConfig.Namespace()
+			errors.CheckError(err)
+			kubeClient := kubernetes.NewForConfigOrDie(clientCfg)
+			appClient := appclientset.NewForConfigOrDie(clientCfg)
+
+			// the applications of all the namespaces are listed, so that the entries of the applications outside of
+			// the control plane namespace are kept
+			apps, err := appClient.ArgoprojV1alpha1().Applications(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+			errors.CheckError(err)
+			existing := map[string]bool{}
+			for _, app := range apps.Items {
+				existing[app.InstanceName(namespace)] = true
+			}
+
+			cache, err := getAppStateCache(ctx, kubeClient, namespace, portForwardRedis, cacheSrc, clientOpts.RedisName, clientOpts.RedisHaProxyName, clientOpts.RedisCompression)
+			errors.CheckError(err)
+			stale, err := cache.CollectGarbage(ctx, func(appName string) bool {
+				return existing[appName]
+			}, dryRun)
+			errors.CheckError(err)
+
+			var freed int64
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			_, _ = fmt.Fprintf(w, "APP\tTYPE\tSIZE\tKEY\n")
+			for _, entry := range stale {
+				freed += entry.Size
+				_, _ = fmt.Fprintf(w, "%s\t%s\t%d\t%s\n", entry.AppName, cacheutil.CacheEntryType(entry.Key), entry.Size, entry.Key)
+			}
+			_ = w.Flush()
+			if dryRun {
+				fmt.Printf("\n%d stale entries of %d bytes found (dry run)\n", len(stale), freed)
+			} else {
+				fmt.Printf("\n%d stale entries deleted, %d bytes freed\n", len(stale), freed)
+			}
+		},
+	}
+	clientConfig = cli.AddKubectlFlagsToCmd(command)
+	command.Flags().BoolVar(&portForwardRedis, "port-forward-redis", true, "Automatically port-forward ha proxy redis from current namespace?")
+	command.Flags().BoolVar(&dryRun, "dry-run", false, "Only print the stale cache entries without deleting them")
+
+	cacheSrc = appstatecache.AddCacheFlagsToCmd(command)
+
+	// parse all added flags so far to get the redis-compression flag that was added by AddCacheFlagsToCmd() above
+	// we can ignore unchecked error here as the command will be parsed again and checked when command.Execute() is run later
+	//nolint:errcheck
+	command.ParseFlags(os.Args[1:])
+	return command
+}
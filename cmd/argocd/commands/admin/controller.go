@@ -0,0 +1,287 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"text/tabwriter"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/argoproj/argo-cd/v3/common"
+	"github.com/argoproj/argo-cd/v3/controller/sharding"
+	argocdclient "github.com/argoproj/argo-cd/v3/pkg/apiclient"
+	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	"github.com/argoproj/argo-cd/v3/pkg/client/clientset/versioned"
+	"github.com/argoproj/argo-cd/v3/util/argo"
+	"github.com/argoproj/argo-cd/v3/util/cli"
+	"github.com/argoproj/argo-cd/v3/util/db"
+	"github.com/argoproj/argo-cd/v3/util/errors"
+	"github.com/argoproj/argo-cd/v3/util/settings"
+)
+
+// shardingAlgorithmCmdParamKey is the argocd-cmd-params-cm key holding the controller sharding algorithm
+const shardingAlgorithmCmdParamKey = "controller.sharding.algorithm"
+
+func NewControllerCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
+	command := &cobra.Command{
+		Use:   "controller",
+		Short: "Manage the application controller",
+		Example: `
+#Print the current distribution of clusters and applications across controller shards and the one expected with 4 replicas
+argocd admin controller rebalance-shards --replicas 4`,
+		Run: func(c *cobra.Command, args []string) {
+			c.HelpFunc()(c, args)
+		},
+	}
+
+	command.AddCommand(NewControllerRebalanceShardsCommand(clientOpts))
+	return command
+}
+
+// shardAssignment describes the shard a cluster is assigned to before and after a rebalance
+type shardAssignment struct {
+	Server       string
+	Name         string
+	Apps         int
+	CurrentShard int
+	PlannedShard int
+}
+
+// shardRebalancePlan is the expected distribution of clusters across controller shards after a rebalance
+type shardRebalancePlan struct {
+	CurrentReplicas int
+	PlannedReplicas int
+	Assignments     []shardAssignment
+}
+
+// Moves returns the assignments of the clusters which move to a different shard
+func (p *shardRebalancePlan) Moves() []shardAssignment {
+	var moves []shardAssignment
+	for _, a := range p.Assignments {
+		if a.CurrentShard != a.PlannedShard {
+			moves = append(moves, a)
+		}
+	}
+	return moves
+}
+
+// planShardRebalance computes the shard of every cluster with the current and the planned sharding configuration.
+// appsByServer holds the number of applications deployed to each cluster.
+func planShardRebalance(clusters *v1alpha1.ClusterList, apps *v1alpha1.ApplicationList, appsByServer map[string]int, currentReplicas int, currentAlgorithm string, plannedReplicas int, plannedAlgorithm string) *shardRebalancePlan {
+	distribution := func(replicas int, algorithm string) map[string]int {
+		clusterSharding := sharding.NewClusterSharding(nil, 0, replicas, algorithm)
+		clusterSharding.Init(clusters, apps)
+		return clusterSharding.GetDistribution()
+	}
+	current := distribution(currentReplicas, currentAlgorithm)
+	planned := distribution(plannedReplicas, plannedAlgorithm)
+
+	plan := &shardRebalancePlan{CurrentReplicas: currentReplicas, PlannedReplicas: plannedReplicas}
+	for _, c := range clusters.Items {
+		plan.Assignments = append(plan.Assignments, shardAssignment{
+			Server:       c.Server,
+			Name:         c.Name,
+			Apps:         appsByServer[c.Server],
+			CurrentShard: current[c.Server],
+			PlannedShard: planned[c.Server],
+		})
+	}
+	sort.Slice(plan.Assignments, func(i, j int) bool {
+		return plan.Assignments[i].Server < plan.Assignments[j].Server
+	})
+	return plan
+}
+
+// getPlannedReplicas returns the planned replicas count, which defaults to the current one when --replicas isn't set
+func getPlannedReplicas(replicas int, replicasSet bool, currentReplicas int) (int, error) {
+	if !replicasSet {
+		replicas = currentReplicas
+	}
+	if replicas < 1 {
+		if replicasSet {
+			return 0, fmt.Errorf("--replicas must be at least 1, got %d", replicas)
+		}
+		return 0, fmt.Errorf("the application controller runs with %d replicas, use --replicas to set the planned replicas count", currentReplicas)
+	}
+	return replicas, nil
+}
+
+func printShardRebalancePlan(out io.Writer, plan *shardRebalancePlan) {
+	type shardStats struct {
+		clusters int
+		apps     int
+	}
+	replicas := max(plan.CurrentReplicas, plan.PlannedReplicas, 1)
+	current := make([]shardStats, replicas)
+	planned := make([]shardStats, replicas)
+	for _, a := range plan.Assignments {
+		current[a.CurrentShard].clusters++
+		current[a.CurrentShard].apps += a.Apps
+		planned[a.PlannedShard].clusters++
+		planned[a.PlannedShard].apps += a.Apps
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintf(w, "SHARD\tCLUSTERS\tAPPS\tPLANNED CLUSTERS\tPLANNED APPS\n")
+	for shard := 0; shard < replicas; shard++ {
+		_, _ = fmt.Fprintf(w, "%d\t%d\t%d\t%d\t%d\n", shard, current[shard].clusters, current[shard].apps, planned[shard].clusters, planned[shard].apps)
+	}
+	_ = w.Flush()
+
+	moves := plan.Moves()
+	if len(moves) == 0 {
+		_, _ = fmt.Fprintln(out, "\nNo cluster changes shard.")
+		return
+	}
+	movedApps := 0
+	_, _ = fmt.Fprintln(out)
+	w = tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintf(w, "SERVER\tNAME\tAPPS\tFROM SHARD\tTO SHARD\n")
+	for _, m := range moves {
+		movedApps += m.Apps
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%d\n", m.Server, m.Name, m.Apps, m.CurrentShard, m.PlannedShard)
+	}
+	_ = w.Flush()
+	_, _ = fmt.Fprintf(out, "\n%d cluster(s) with %d application(s) will change shard.\n", len(moves), movedApps)
+}
+
+// getControllerShardingAlgorithm returns the sharding algorithm configured in argocd-cmd-params-cm
+func getControllerShardingAlgorithm(ctx context.Context, kubeClient kubernetes.Interface, namespace string) (string, error) {
+	cm, err := kubeClient.CoreV1().ConfigMaps(namespace).Get(ctx, common.ArgoCDCmdParamsConfigMapName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return common.DefaultShardingAlgorithm, nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("error getting %s: %w", common.ArgoCDCmdParamsConfigMapName, err)
+	}
+	if algorithm := cm.Data[shardingAlgorithmCmdParamKey]; algorithm != "" {
+		return algorithm, nil
+	}
+	return common.DefaultShardingAlgorithm, nil
+}
+
+// getControllerDeploymentReplicas returns the replicas of the application controller deployment, which only exists
+// when dynamic cluster distribution is enabled. Returns false if the controller is not deployed as a deployment.
+func getControllerDeploymentReplicas(ctx context.Context, kubeClient kubernetes.Interface, namespace string, appControllerName string) (int, bool, error) {
+	deployment, err := kubeClient.AppsV1().Deployments(namespace).Get(ctx, appControllerName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("error getting application controller deployment: %w", err)
+	}
+	if deployment.Spec.Replicas == nil {
+		return 1, true, nil
+	}
+	return int(*deployment.Spec.Replicas), true, nil
+}
+
+func NewControllerRebalanceShardsCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
+	var (
+		replicas          int
+		shardingAlgorithm string
+		apply             bool
+		clientConfig      clientcmd.ClientConfig
+	)
+	command := cobra.Command{
+		Use:   "rebalance-shards",
+		Short: "Print the distribution of clusters and applications across controller shards and plan a rebalance",
+		Long: `Print the distribution of clusters and applications across controller shards and the distribution expected with
+the given number of replicas and sharding method, including the clusters which change shard.
+With --apply, the application controller deployment is scaled to the planned number of replicas, which triggers the
+rebalance when dynamic cluster distribution is enabled.`,
+		Example: `
+#Show the expected cluster movement when scaling the controller to 4 replicas
+argocd admin controller rebalance-shards --replicas 4
+
+#Show the expected cluster movement when switching to the round-robin sharding method
+argocd admin controller rebalance-shards --sharding-method round-robin
+
+#Scale the controller deployment to 4 replicas and let the dynamic cluster distribution rebalance the clusters
+argocd admin controller rebalance-shards --replicas 4 --apply`,
+		Run: func(cmd *cobra.Command, _ []string) {
+			ctx := cmd.Context()
+
+			log.SetLevel(log.WarnLevel)
+
+			clientCfg, err := clientConfig.ClientConfig()
+			errors.CheckError(err)
+			namespace, _, err := clientConfig.Namespace()
+			errors.CheckError(err)
+			kubeClient := kubernetes.NewForConfigOrDie(clientCfg)
+			appClient := versioned.NewForConfigOrDie(clientCfg)
+
+			currentReplicas, isDeployment, err := getControllerDeploymentReplicas(ctx, kubeClient, namespace, clientOpts.AppControllerName)
+			errors.CheckError(err)
+			if !isDeployment {
+				currentReplicas, err = getControllerReplicas(ctx, kubeClient, namespace, clientOpts.AppControllerName)
+				errors.CheckError(err)
+			}
+			currentAlgorithm, err := getControllerShardingAlgorithm(ctx, kubeClient, namespace)
+			errors.CheckError(err)
+
+			plannedReplicas, err := getPlannedReplicas(replicas, cmd.Flags().Changed("replicas"), currentReplicas)
+			errors.CheckError(err)
+			plannedAlgorithm := shardingAlgorithm
+			if plannedAlgorithm == "" {
+				plannedAlgorithm = currentAlgorithm
+			}
+
+			if apply {
+				if !isDeployment {
+					errors.Fatal(errors.ErrorGeneric, "--apply requires the application controller to run as a deployment with dynamic cluster distribution enabled")
+				}
+				if plannedAlgorithm != currentAlgorithm {
+					errors.Fatalf(errors.ErrorGeneric, "changing the sharding method requires updating '%s' in %s and restarting the application controller", shardingAlgorithmCmdParamKey, common.ArgoCDCmdParamsConfigMapName)
+				}
+			}
+
+			settingsMgr := settings.NewSettingsManager(ctx, kubeClient, namespace)
+			argoDB := db.NewDB(namespace, settingsMgr, kubeClient)
+			clusters, err := argoDB.ListClusters(ctx)
+			errors.CheckError(err)
+			apps, err := appClient.ArgoprojV1alpha1().Applications(namespace).List(ctx, metav1.ListOptions{})
+			errors.CheckError(err)
+			appsByServer := map[string]int{}
+			for _, app := range apps.Items {
+				destCluster, err := argo.GetDestinationCluster(ctx, app.Spec.Destination, argoDB)
+				if err != nil {
+					log.Warnf("Skipping application '%s': %v", app.QualifiedName(), err)
+					continue
+				}
+				appsByServer[destCluster.Server]++
+			}
+
+			fmt.Printf("Current: %d replica(s), sharding method %s\n", currentReplicas, currentAlgorithm)
+			fmt.Printf("Planned: %d replica(s), sharding method %s\n\n", plannedReplicas, plannedAlgorithm)
+			plan := planShardRebalance(clusters, apps, appsByServer, currentReplicas, currentAlgorithm, plannedReplicas, plannedAlgorithm)
+			printShardRebalancePlan(os.Stdout, plan)
+
+			if !apply {
+				return
+			}
+			if plannedReplicas == currentReplicas {
+				fmt.Println("\nThe application controller already runs with the planned number of replicas.")
+				return
+			}
+			patch := fmt.Sprintf(`{"spec":{"replicas":%d}}`, plannedReplicas)
+			_, err = kubeClient.AppsV1().Deployments(namespace).Patch(ctx, clientOpts.AppControllerName, types.MergePatchType, []byte(patch), metav1.PatchOptions{})
+			errors.CheckError(err)
+			fmt.Printf("\nScaled deployment '%s' to %d replica(s).\n", clientOpts.AppControllerName, plannedReplicas)
+		},
+	}
+	clientConfig = cli.AddKubectlFlagsToCmd(&command)
+	command.Flags().IntVar(&replicas, "replicas", 0, "Planned application controller replicas count. Defaults to the current replicas count")
+	command.Flags().StringVar(&shardingAlgorithm, "sharding-method", "", "Planned sharding method. Defaults to the current sharding method. Supported sharding methods are : [legacy, round-robin, consistent-hashing] ")
+	command.Flags().BoolVar(&apply, "apply", false, "Scale the application controller deployment to the planned replicas count")
+	return &command
+}
@@ -0,0 +1,110 @@
+package admin
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
+
+	"github.com/argoproj/argo-cd/v3/common"
+	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+)
+
+func testRebalanceClusters() *v1alpha1.ClusterList {
+	return &v1alpha1.ClusterList{Items: []v1alpha1.Cluster{
+		{ID: "1", Server: "https://cluster-1", Name: "cluster-1"},
+		{ID: "2", Server: "https://cluster-2", Name: "cluster-2"},
+		{ID: "3", Server: "https://cluster-3", Name: "cluster-3"},
+	}}
+}
+
+func TestPlanShardRebalance(t *testing.T) {
+	appsByServer := map[string]int{"https://cluster-1": 3, "https://cluster-2": 5}
+	plan := planShardRebalance(testRebalanceClusters(), &v1alpha1.ApplicationList{}, appsByServer, 1, common.RoundRobinShardingAlgorithm, 2, common.RoundRobinShardingAlgorithm)
+
+	assert.Equal(t, []shardAssignment{
+		{Server: "https://cluster-1", Name: "cluster-1", Apps: 3, CurrentShard: 0, PlannedShard: 0},
+		{Server: "https://cluster-2", Name: "cluster-2", Apps: 5, CurrentShard: 0, PlannedShard: 1},
+		{Server: "https://cluster-3", Name: "cluster-3", Apps: 0, CurrentShard: 0, PlannedShard: 0},
+	}, plan.Assignments)
+	assert.Equal(t, []shardAssignment{plan.Assignments[1]}, plan.Moves())
+
+	var out bytes.Buffer
+	printShardRebalancePlan(&out, plan)
+	assert.Equal(t, `SHARD  CLUSTERS  APPS  PLANNED CLUSTERS  PLANNED APPS
+0      3         8     2                 3
+1      0         0     1                 5
+
+SERVER             NAME       APPS  FROM SHARD  TO SHARD
+https://cluster-2  cluster-2  5     0           1
+
+1 cluster(s) with 5 application(s) will change shard.
+`, out.String())
+}
+
+func TestPlanShardRebalance_PinnedShard(t *testing.T) {
+	clusters := testRebalanceClusters()
+	clusters.Items[1].Shard = ptr.To(int64(0))
+	plan := planShardRebalance(clusters, &v1alpha1.ApplicationList{}, nil, 1, common.RoundRobinShardingAlgorithm, 2, common.RoundRobinShardingAlgorithm)
+	assert.Empty(t, plan.Moves())
+
+	var out bytes.Buffer
+	printShardRebalancePlan(&out, plan)
+	assert.Contains(t, out.String(), "No cluster changes shard.")
+}
+
+func TestGetPlannedReplicas(t *testing.T) {
+	replicas, err := getPlannedReplicas(0, false, 2)
+	require.NoError(t, err)
+	assert.Equal(t, 2, replicas)
+
+	replicas, err = getPlannedReplicas(4, true, 2)
+	require.NoError(t, err)
+	assert.Equal(t, 4, replicas)
+
+	_, err = getPlannedReplicas(0, true, 2)
+	require.EqualError(t, err, "--replicas must be at least 1, got 0")
+
+	_, err = getPlannedReplicas(-1, true, 2)
+	require.EqualError(t, err, "--replicas must be at least 1, got -1")
+
+	_, err = getPlannedReplicas(0, false, 0)
+	require.EqualError(t, err, "the application controller runs with 0 replicas, use --replicas to set the planned replicas count")
+}
+
+func TestGetControllerShardingAlgorithm(t *testing.T) {
+	ctx := t.Context()
+	algorithm, err := getControllerShardingAlgorithm(ctx, fake.NewClientset(), "argocd")
+	require.NoError(t, err)
+	assert.Equal(t, common.DefaultShardingAlgorithm, algorithm)
+
+	kubeClient := fake.NewClientset(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: common.ArgoCDCmdParamsConfigMapName, Namespace: "argocd"},
+		Data:       map[string]string{shardingAlgorithmCmdParamKey: common.RoundRobinShardingAlgorithm},
+	})
+	algorithm, err = getControllerShardingAlgorithm(ctx, kubeClient, "argocd")
+	require.NoError(t, err)
+	assert.Equal(t, common.RoundRobinShardingAlgorithm, algorithm)
+}
+
+func TestGetControllerDeploymentReplicas(t *testing.T) {
+	ctx := t.Context()
+	_, isDeployment, err := getControllerDeploymentReplicas(ctx, fake.NewClientset(), "argocd", common.DefaultApplicationControllerName)
+	require.NoError(t, err)
+	assert.False(t, isDeployment)
+
+	kubeClient := fake.NewClientset(&appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: common.DefaultApplicationControllerName, Namespace: "argocd"},
+		Spec:       appsv1.DeploymentSpec{Replicas: ptr.To(int32(3))},
+	})
+	replicas, isDeployment, err := getControllerDeploymentReplicas(ctx, kubeClient, "argocd", common.DefaultApplicationControllerName)
+	require.NoError(t, err)
+	assert.True(t, isDeployment)
+	assert.Equal(t, 3, replicas)
+}
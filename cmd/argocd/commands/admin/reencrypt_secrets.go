@@ -0,0 +1,40 @@
+package admin
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/argoproj/argo-cd/v3/util/cli"
+	"github.com/argoproj/argo-cd/v3/util/db"
+	"github.com/argoproj/argo-cd/v3/util/errors"
+)
+
+// NewReencryptSecretsCommand defines a new command to encrypt the credentials of the Argo CD secrets with the current KMS key.
+func NewReencryptSecretsCommand() *cobra.Command {
+	var clientConfig clientcmd.ClientConfig
+	command := cobra.Command{
+		Use:   "reencrypt-secrets",
+		Short: "Encrypts the credentials of the repository and cluster secrets with the key of ARGOCD_SECRET_ENCRYPTION_KEY",
+		Long:  "Encrypts the credentials of the repository, repository credentials and cluster secrets with the KMS key of the ARGOCD_SECRET_ENCRYPTION_KEY environment variable. The credentials encrypted with a previous key, which must be listed in the ARGOCD_SECRET_DECRYPTION_KEYS environment variable, are encrypted again, and the plaintext credentials are encrypted.",
+		Example: `  # Rotate the KMS key encrypting the secrets
+  ARGOCD_SECRET_ENCRYPTION_KEY=awskms://arn:aws:kms:us-east-1:111122223333:key/new ARGOCD_SECRET_DECRYPTION_KEYS=awskms://arn:aws:kms:us-east-1:111122223333:key/old argocd admin reencrypt-secrets -n argocd`,
+		Run: func(c *cobra.Command, _ []string) {
+			config, err := clientConfig.ClientConfig()
+			errors.CheckError(err)
+			namespace, _, err := clientConfig.Namespace()
+			errors.CheckError(err)
+
+			updated, err := db.ReencryptSecrets(c.Context(), kubernetes.NewForConfigOrDie(config), namespace)
+			for _, name := range updated {
+				fmt.Printf("Secret %s encrypted\n", name)
+			}
+			errors.CheckError(err)
+		},
+	}
+	clientConfig = cli.AddKubectlFlagsToCmd(&command)
+
+	return &command
+}
@@ -145,9 +145,9 @@ func NewGenRepoSpecCommand() *cobra.Command {
 				errors.CheckError(stderrors.New("must specify --name for repos of type 'helm'"))
 			}
 
-			// If the user set a username, but didn't supply password via --password,
-			// then we prompt for it
-			if repoOpts.Repo.Username != "" && repoOpts.Repo.Password == "" {
+			// If the user set a username, but didn't supply password via --password
+			// nor a token exchange endpoint, then we prompt for it
+			if repoOpts.Repo.Username != "" && repoOpts.Repo.Password == "" && repoOpts.Repo.OIDCTokenExchangeURL == "" {
 				repoOpts.Repo.Password = cli.PromptPassword(repoOpts.Repo.Password)
 			}
 
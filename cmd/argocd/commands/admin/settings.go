@@ -16,8 +16,10 @@ import (
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/tools/clientcmd"
@@ -31,13 +33,16 @@ import (
 	"github.com/argoproj/argo-cd/v3/util/errors"
 	"github.com/argoproj/argo-cd/v3/util/lua"
 	"github.com/argoproj/argo-cd/v3/util/settings"
+	"github.com/argoproj/argo-cd/v3/util/settings/validation"
 )
 
 type settingsOpts struct {
-	argocdCMPath        string
-	argocdSecretPath    string
-	loadClusterSettings bool
-	clientConfig        clientcmd.ClientConfig
+	argocdCMPath              string
+	argocdSecretPath          string
+	argocdRBACCMPath          string
+	argocdNotificationsCMPath string
+	loadClusterSettings       bool
+	clientConfig              clientcmd.ClientConfig
 }
 
 type commandContext interface {
@@ -123,7 +128,21 @@ func (opts *settingsOpts) createSettingsManager(ctx context.Context) (*settings.
 		}
 	}
 	setSettingsMeta(argocdSecret)
-	clientset := fake.NewClientset(argocdSecret, argocdCM)
+	objs := []runtime.Object{argocdSecret, argocdCM}
+
+	for name, path := range map[string]string{
+		common.ArgoCDRBACConfigMapName:          opts.argocdRBACCMPath,
+		common.ArgoCDNotificationsConfigMapName: opts.argocdNotificationsCMPath,
+	} {
+		cm, err := opts.loadOptionalConfigMap(ctx, name, path)
+		if err != nil {
+			return nil, err
+		}
+		if cm != nil {
+			objs = append(objs, cm)
+		}
+	}
+	clientset := fake.NewClientset(objs...)
 
 	manager := settings.NewSettingsManager(ctx, clientset, "default")
 	errors.CheckError(manager.ResyncInformers())
@@ -131,6 +150,40 @@ func (opts *settingsOpts) createSettingsManager(ctx context.Context) (*settings.
 	return manager, nil
 }
 
+// loadOptionalConfigMap loads a ConfigMap from the given file or, if cluster settings are loaded, from the cluster.
+// Returns nil if the ConfigMap is neither provided nor present in the cluster.
+func (opts *settingsOpts) loadOptionalConfigMap(ctx context.Context, name string, path string) (*corev1.ConfigMap, error) {
+	var cm *corev1.ConfigMap
+	switch {
+	case path != "":
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		err = yaml.Unmarshal(data, &cm)
+		if err != nil {
+			return nil, err
+		}
+	case opts.loadClusterSettings:
+		realClientset, ns, err := opts.getK8sClient()
+		if err != nil {
+			return nil, err
+		}
+		cm, err = realClientset.CoreV1().ConfigMaps(ns).Get(ctx, name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, nil
+	}
+	cm.Name = name
+	setSettingsMeta(cm)
+	return cm, nil
+}
+
 func (opts *settingsOpts) getK8sClient() (*kubernetes.Clientset, string, error) {
 	namespace, _, err := opts.clientConfig.Namespace()
 	if err != nil {
@@ -168,6 +221,8 @@ func NewSettingsCommand() *cobra.Command {
 	opts.clientConfig = cli.AddKubectlFlagsToCmd(command)
 	command.PersistentFlags().StringVar(&opts.argocdCMPath, "argocd-cm-path", "", "Path to local argocd-cm.yaml file")
 	command.PersistentFlags().StringVar(&opts.argocdSecretPath, "argocd-secret-path", "", "Path to local argocd-secret.yaml file")
+	command.PersistentFlags().StringVar(&opts.argocdRBACCMPath, "argocd-rbac-cm-path", "", "Path to local argocd-rbac-cm.yaml file")
+	command.PersistentFlags().StringVar(&opts.argocdNotificationsCMPath, "argocd-notifications-cm-path", "", "Path to local argocd-notifications-cm.yaml file")
 	command.PersistentFlags().BoolVar(&opts.loadClusterSettings, "load-cluster-settings", false,
 		"Indicates that config map and secret should be loaded from cluster unless local file path is provided")
 	return command
@@ -262,6 +317,10 @@ var validatorsByGroup = map[string]settingValidator{
 		}
 		return fmt.Sprintf("%d resource overrides", len(overrides)), nil
 	},
+	"lua":                validation.ValidateLuaCustomizations,
+	"ignore-differences": validation.ValidateIgnoreDifferences,
+	"rbac":               validation.ValidateRBAC,
+	"notifications":      validation.ValidateNotifications,
 }
 
 func NewValidateSettingsCommand(cmdCtx commandContext) *cobra.Command {
@@ -278,11 +337,14 @@ func NewValidateSettingsCommand(cmdCtx commandContext) *cobra.Command {
 	command := &cobra.Command{
 		Use:   "validate",
 		Short: "Validate settings",
-		Long:  "Validates settings specified in 'argocd-cm' ConfigMap and 'argocd-secret' Secret",
+		Long:  "Validates settings specified in 'argocd-cm', 'argocd-rbac-cm' and 'argocd-notifications-cm' ConfigMaps and 'argocd-secret' Secret. Lua scripts of resource customizations are compiled, ignoreDifferences rules, RBAC policies and notification triggers are parsed.",
 		Example: `
 #Validates all settings in the specified YAML file
 argocd admin settings validate --argocd-cm-path ./argocd-cm.yaml
 
+#Validates the RBAC policies and notifications configuration in the specified YAML files
+argocd admin settings validate --group rbac --group notifications --argocd-cm-path ./argocd-cm.yaml --argocd-rbac-cm-path ./argocd-rbac-cm.yaml --argocd-notifications-cm-path ./argocd-notifications-cm.yaml
+
 #Validates accounts and plugins settings in Kubernetes cluster of current kubeconfig context
 argocd admin settings validate --group accounts --group plugins --load-cluster-settings`,
 		Run: func(c *cobra.Command, _ []string) {
@@ -43,6 +43,8 @@ var resourceMap = map[string]string{
 	"log":             rbac.ResourceLogs,
 	"logs":            rbac.ResourceLogs,
 	"exec":            rbac.ResourceExec,
+	"profile":         rbac.ResourceProfiles,
+	"profiles":        rbac.ResourceProfiles,
 	"proj":            rbac.ResourceProjects,
 	"projs":           rbac.ResourceProjects,
 	"project":         rbac.ResourceProjects,
@@ -62,6 +64,7 @@ var validRBACResourcesActions = map[string]actionTraitMap{
 	rbac.ResourceGPGKeys:         defaultCRDActions,
 	rbac.ResourceLogs:            logsActions,
 	rbac.ResourceExec:            execActions,
+	rbac.ResourceProfiles:        profilesActions,
 	rbac.ResourceProjects:        defaultCRUDActions,
 	rbac.ResourceRepositories:    defaultCRUDActions,
 }
@@ -107,6 +110,10 @@ var extensionActions = actionTraitMap{
 	rbac.ActionInvoke: rbacTrait{},
 }
 
+var profilesActions = actionTraitMap{
+	rbac.ActionGet: rbacTrait{},
+}
+
 // NewRBACCommand is the command for 'rbac'
 func NewRBACCommand() *cobra.Command {
 	command := &cobra.Command{
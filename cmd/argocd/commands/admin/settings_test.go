@@ -174,6 +174,50 @@ admissionregistration.k8s.io/MutatingWebhookConfiguration:
 			},
 			containsSummary: "2 resource overrides",
 		},
+		"Lua_Valid": {
+			validator: "lua",
+			data: map[string]string{
+				"resource.customizations.health.example.com_Foo": `hs = {}
+hs.status = "Healthy"
+return hs`,
+			},
+			containsSummary: "1 Lua scripts",
+		},
+		"Lua_SyntaxError": {
+			validator: "lua",
+			data: map[string]string{
+				"resource.customizations.health.example.com_Foo": `hs = {}
+if obj.status ~= nil then
+  hs.status =
+end
+return hs`,
+			},
+			containsError: "invalid health.lua of example.com/Foo",
+		},
+		"IgnoreDifferences_InvalidJQ": {
+			validator: "ignore-differences",
+			data: map[string]string{
+				"resource.customizations.ignoreDifferences.apps_Deployment": `jqPathExpressions:
+- .spec.template.spec.containers[`,
+			},
+			containsError: "invalid ignoreDifferences: JQ path expression '.spec.template.spec.containers[' is invalid",
+		},
+		"IgnoreDifferences_InvalidJSONPointer": {
+			validator: "ignore-differences",
+			data: map[string]string{
+				"resource.customizations.ignoreDifferences.apps_Deployment": `jsonPointers:
+- spec/replicas`,
+			},
+			containsError: "JSON pointer 'spec/replicas' must start with '/'",
+		},
+		"RBAC_NotProvided": {
+			validator:       "rbac",
+			containsSummary: "argocd-rbac-cm is not provided",
+		},
+		"Notifications_NotProvided": {
+			validator:       "notifications",
+			containsSummary: "argocd-notifications-cm is not provided",
+		},
 	}
 	for name := range testCases {
 		tc := testCases[name]
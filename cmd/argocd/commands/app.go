@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"bufio"
 	"context"
 	"encoding/json"
 	stderrors "errors"
@@ -88,9 +89,15 @@ func NewApplicationCommand(clientOpts *argocdclient.ClientOptions) *cobra.Comman
 	command.AddCommand(NewApplicationSyncCommand(clientOpts))
 	command.AddCommand(NewApplicationHistoryCommand(clientOpts))
 	command.AddCommand(NewApplicationRollbackCommand(clientOpts))
+	command.AddCommand(NewApplicationPinCommand(clientOpts))
+	command.AddCommand(NewApplicationPromotePinsCommand(clientOpts))
+	command.AddCommand(NewApplicationFreezeCommand(clientOpts))
+	command.AddCommand(NewApplicationThawCommand(clientOpts))
+	command.AddCommand(NewApplicationCascadeCommand(clientOpts))
 	command.AddCommand(NewApplicationListCommand(clientOpts))
 	command.AddCommand(NewApplicationDeleteCommand(clientOpts))
 	command.AddCommand(NewApplicationWaitCommand(clientOpts))
+	command.AddCommand(NewApplicationSyncProgressCommand(clientOpts))
 	command.AddCommand(NewApplicationManifestsCommand(clientOpts))
 	command.AddCommand(NewApplicationTerminateOpCommand(clientOpts))
 	command.AddCommand(NewApplicationEditCommand(clientOpts))
@@ -99,21 +106,25 @@ func NewApplicationCommand(clientOpts *argocdclient.ClientOptions) *cobra.Comman
 	command.AddCommand(NewApplicationDeleteResourceCommand(clientOpts))
 	command.AddCommand(NewApplicationResourceActionsCommand(clientOpts))
 	command.AddCommand(NewApplicationListResourcesCommand(clientOpts))
+	command.AddCommand(NewApplicationResourceTreeDiffCommand(clientOpts))
+	command.AddCommand(NewApplicationOrphanedResourcesCommand(clientOpts))
 	command.AddCommand(NewApplicationLogsCommand(clientOpts))
 	command.AddCommand(NewApplicationAddSourceCommand(clientOpts))
 	command.AddCommand(NewApplicationRemoveSourceCommand(clientOpts))
 	command.AddCommand(NewApplicationConfirmDeletionCommand(clientOpts))
+	command.AddCommand(NewApplicationBlueprintsCommand(clientOpts))
 	return command
 }
 
 type watchOpts struct {
-	sync      bool
-	health    bool
-	operation bool
-	suspended bool
-	degraded  bool
-	delete    bool
-	hydrated  bool
+	sync       bool
+	health     bool
+	operation  bool
+	suspended  bool
+	degraded   bool
+	delete     bool
+	hydrated   bool
+	conditions *waitConditions
 }
 
 // NewApplicationCreateCommand returns a new instance of an `argocd app create` command
@@ -301,7 +312,7 @@ func parentChildDetails(ctx context.Context, appIf application.ApplicationServic
 	return mapUIDToNode, mapParentToChild, parentNode
 }
 
-func printHeader(ctx context.Context, acdClient argocdclient.Client, app *argoappv1.Application, windows *argoappv1.SyncWindows, showOperation bool, showParams bool, sourcePosition int) {
+func printHeader(ctx context.Context, acdClient argocdclient.Client, app *argoappv1.Application, windows *argoappv1.SyncWindows, showOperation bool, showHookOutput bool, showParams bool, sourcePosition int) {
 	appURL := getAppURL(ctx, acdClient, app.Name)
 	printAppSummaryTable(app, appURL, windows)
 
@@ -316,6 +327,9 @@ func printHeader(ctx context.Context, acdClient argocdclient.Client, app *argoap
 		fmt.Println()
 		printOperationResult(app.Status.OperationState)
 	}
+	if showHookOutput && app.Status.OperationState != nil {
+		printHookOutputs(os.Stdout, app.QualifiedName(), app.Status.OperationState)
+	}
 	if showParams {
 		printParams(app, sourcePosition)
 	}
@@ -341,6 +355,7 @@ func NewApplicationGetCommand(clientOpts *argocdclient.ClientOptions) *cobra.Com
 		timeout        uint
 		showParams     bool
 		showOperation  bool
+		showHookOutput bool
 		appNamespace   string
 		sourcePosition int
 		sourceName     string
@@ -383,6 +398,7 @@ func NewApplicationGetCommand(clientOpts *argocdclient.ClientOptions) *cobra.Com
   argocd app get my-app --output tree=detailed
   		`),
 
+		ValidArgsFunction: completeApplicationNames(clientOpts, false),
 		Run: func(c *cobra.Command, args []string) {
 			ctx, cancel := context.WithCancel(c.Context())
 			defer cancel()
@@ -479,7 +495,7 @@ func NewApplicationGetCommand(clientOpts *argocdclient.ClientOptions) *cobra.Com
 				err := PrintResource(app, output)
 				errors.CheckError(err)
 			case "wide", "":
-				printHeader(ctx, acdClient, app, windows, showOperation, showParams, sourcePosition)
+				printHeader(ctx, acdClient, app, windows, showOperation, showHookOutput, showParams, sourcePosition)
 				if len(app.Status.Resources) > 0 {
 					fmt.Println()
 					w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
@@ -487,14 +503,14 @@ func NewApplicationGetCommand(clientOpts *argocdclient.ClientOptions) *cobra.Com
 					_ = w.Flush()
 				}
 			case "tree":
-				printHeader(ctx, acdClient, app, windows, showOperation, showParams, sourcePosition)
+				printHeader(ctx, acdClient, app, windows, showOperation, showHookOutput, showParams, sourcePosition)
 				mapUIDToNode, mapParentToChild, parentNode, mapNodeNameToResourceState := resourceParentChild(ctx, acdClient, appName, appNs)
 				if len(mapUIDToNode) > 0 {
 					fmt.Println()
 					printTreeView(mapUIDToNode, mapParentToChild, parentNode, mapNodeNameToResourceState)
 				}
 			case "tree=detailed":
-				printHeader(ctx, acdClient, app, windows, showOperation, showParams, sourcePosition)
+				printHeader(ctx, acdClient, app, windows, showOperation, showHookOutput, showParams, sourcePosition)
 				mapUIDToNode, mapParentToChild, parentNode, mapNodeNameToResourceState := resourceParentChild(ctx, acdClient, appName, appNs)
 				if len(mapUIDToNode) > 0 {
 					fmt.Println()
@@ -508,6 +524,7 @@ func NewApplicationGetCommand(clientOpts *argocdclient.ClientOptions) *cobra.Com
 	command.Flags().StringVarP(&output, "output", "o", "wide", "Output format. One of: json|yaml|wide|tree")
 	command.Flags().UintVar(&timeout, "timeout", defaultCheckTimeoutSeconds, "Time out after this many seconds")
 	command.Flags().BoolVar(&showOperation, "show-operation", false, "Show application operation")
+	command.Flags().BoolVar(&showHookOutput, "show-hook-output", false, "Show the status of the containers of the completed hooks of the application operation")
 	command.Flags().BoolVar(&showParams, "show-params", false, "Show application parameters and overrides")
 	command.Flags().BoolVar(&refresh, "refresh", false, "Refresh application data when retrieving")
 	command.Flags().BoolVar(&hardRefresh, "hard-refresh", false, "Refresh application data as well as target manifests cache")
@@ -718,6 +735,9 @@ func printAppSummaryTable(app *argoappv1.Application, appURL string, windows *ar
 		syncPolicy = "Manual"
 	}
 	fmt.Printf(printOpFmtStr, "Sync Policy:", syncPolicy)
+	if app.Status.Freeze != nil {
+		fmt.Printf(printOpFmtStr, "Frozen:", formatFreeze(app.Status.Freeze))
+	}
 	syncStatusStr := string(app.Status.Sync.Status)
 	switch app.Status.Sync.Status {
 	case argoappv1.SyncStatusCodeSynced:
@@ -867,6 +887,7 @@ func NewApplicationSetCommand(clientOpts *argocdclient.ClientOptions) *cobra.Com
   argocd app set my-app --parameter key1=value1 --parameter key2=value2 --namespace my-namespace
   		`),
 
+		ValidArgsFunction: completeApplicationNames(clientOpts, false),
 		Run: func(c *cobra.Command, args []string) {
 			ctx := c.Context()
 
@@ -982,6 +1003,7 @@ func NewApplicationUnsetCommand(clientOpts *argocdclient.ClientOptions) *cobra.C
   # Unset parameter override
   argocd app unset my-app -p COMPONENT=PARAM`,
 
+		ValidArgsFunction: completeApplicationNames(clientOpts, false),
 		Run: func(c *cobra.Command, args []string) {
 			ctx := c.Context()
 
@@ -1281,11 +1303,14 @@ func NewApplicationDiffCommand(clientOpts *argocdclient.ClientOptions) *cobra.Co
 		revision             string
 		localRepoRoot        string
 		serverSideGenerate   bool
+		localUpload          bool
 		localIncludes        []string
 		appNamespace         string
 		revisions            []string
 		sourcePositions      []int64
 		sourceNames          []string
+		revisionRange        string
+		includeIgnored       bool
 		ignoreNormalizerOpts normalizers.IgnoreNormalizerOpts
 	)
 	shortDesc := "Perform a diff against the target and live state."
@@ -1293,6 +1318,24 @@ func NewApplicationDiffCommand(clientOpts *argocdclient.ClientOptions) *cobra.Co
 		Use:   "diff APPNAME",
 		Short: shortDesc,
 		Long:  shortDesc + "\nUses 'diff' to render the difference. KUBECTL_EXTERNAL_DIFF environment variable can be used to select your own diff tool.\nReturns the following exit codes: 2 on general errors, 1 when a diff is found, and 0 when no diff is found\nKubernetes Secrets are ignored from this diff.",
+		Example: `  # Compare the live state of an application with its target state
+  argocd app diff my-app
+
+  # Show which resources every commit between two revisions changes, using the git checkout in the current directory
+  argocd app diff my-app --revision-range v1.0.0..main
+
+  # Show the same for the second source of a multi-source application
+  argocd app diff my-app --revision-range v1.0.0..main --source-positions 2
+
+  # Also list the differences which are hidden by ignoreDifferences rules
+  argocd app diff my-app --include-ignored
+
+  # Compare the live state with the local checkout of the source repository, rendered by the repo-server
+  argocd app diff my-app --local . --local-upload
+
+  # Compare the live state with the local checkout of the repository of the second source of a multi-source application
+  argocd app diff my-app --local ../values-repo --local-upload --source-positions 2`,
+		ValidArgsFunction: completeApplicationNames(clientOpts, false),
 		Run: func(c *cobra.Command, args []string) {
 			ctx := c.Context()
 
@@ -1305,14 +1348,34 @@ func NewApplicationDiffCommand(clientOpts *argocdclient.ClientOptions) *cobra.Co
 				errors.Fatal(errors.ErrorGeneric, "Only one of source-positions and source-names can be specified.")
 			}
 
-			if len(sourcePositions) > 0 && len(revisions) != len(sourcePositions) {
+			if localUpload && local == "" {
+				errors.Fatal(errors.ErrorGeneric, "--local-upload requires --local.")
+			}
+
+			if localUpload && len(sourcePositions)+len(sourceNames) > 1 {
+				errors.Fatal(errors.ErrorGeneric, "--local-upload supports at most one source position or source name.")
+			}
+
+			if revisionRange != "" && (revision != "" || local != "" || len(revisions) > 0) {
+				errors.Fatal(errors.ErrorGeneric, "--revision-range cannot be combined with --revision, --revisions or --local.")
+			}
+
+			if revisionRange != "" && len(sourcePositions)+len(sourceNames) > 1 {
+				errors.Fatal(errors.ErrorGeneric, "--revision-range supports at most one source position or source name.")
+			}
+
+			if !localUpload && revisionRange == "" && len(sourcePositions) > 0 && len(revisions) != len(sourcePositions) {
 				errors.Fatal(errors.ErrorGeneric, "While using --revisions and --source-positions, length of values for both flags should be same.")
 			}
 
-			if len(sourceNames) > 0 && len(revisions) != len(sourceNames) {
+			if !localUpload && revisionRange == "" && len(sourceNames) > 0 && len(revisions) != len(sourceNames) {
 				errors.Fatal(errors.ErrorGeneric, "While using --revisions and --source-names, length of values for both flags should be same.")
 			}
 
+			if includeIgnored && (revisionRange != "" || revision != "" || local != "" || len(revisions) > 0) {
+				errors.Fatal(errors.ErrorGeneric, "--include-ignored cannot be combined with --revision-range, --revision, --revisions or --local.")
+			}
+
 			clientset := headless.NewClientOrDie(clientOpts, c)
 			conn, appIf := clientset.NewApplicationClientOrDie()
 			defer utilio.Close(conn)
@@ -1336,6 +1399,19 @@ func NewApplicationDiffCommand(clientOpts *argocdclient.ClientOptions) *cobra.Co
 				}
 			}
 
+			if revisionRange != "" {
+				sourcePosition := int64(1)
+				if len(sourcePositions) > 0 {
+					sourcePosition = sourcePositions[0]
+				}
+				foundDiffs, err := findAndPrintRevisionRangeDiff(ctx, os.Stdout, appIf, app, revisionRange, sourcePosition)
+				errors.CheckError(err)
+				if foundDiffs && exitCode {
+					os.Exit(diffExitCode)
+				}
+				return
+			}
+
 			resources, err := appIf.ManagedResources(ctx, &application.ResourcesQuery{ApplicationName: &appName, AppNamespace: &appNs})
 			errors.CheckError(err)
 			conn, settingsIf := clientset.NewSettingsClientOrDie()
@@ -1374,11 +1450,24 @@ func NewApplicationDiffCommand(clientOpts *argocdclient.ClientOptions) *cobra.Co
 				diffOption.res = res
 				diffOption.revision = revision
 			case local != "":
-				if serverSideGenerate {
+				if serverSideGenerate || localUpload {
+					inclusions := localIncludes
+					var exclusions []string
+					var sourcePosition int64
+					if localUpload {
+						// the whole local sources are rendered by the repo-server, e.g. the Helm templates or the plugin inputs
+						if !c.Flags().Changed("local-include") {
+							inclusions = nil
+						}
+						exclusions = []string{".git"}
+						if len(sourcePositions) > 0 {
+							sourcePosition = sourcePositions[0]
+						}
+					}
 					client, err := appIf.GetManifestsWithFiles(ctx, grpc_retry.Disable())
 					errors.CheckError(err)
 
-					err = manifeststream.SendApplicationManifestQueryWithFiles(ctx, client, appName, appNs, local, localIncludes)
+					err = manifeststream.SendApplicationManifestQueryWithFiles(ctx, client, appName, appNs, local, inclusions, exclusions, sourcePosition)
 					errors.CheckError(err)
 
 					res, err := client.CloseAndRecv()
@@ -1386,7 +1475,7 @@ func NewApplicationDiffCommand(clientOpts *argocdclient.ClientOptions) *cobra.Co
 
 					diffOption.serversideRes = res
 				} else {
-					fmt.Fprintf(os.Stderr, "Warning: local diff without --server-side-generate is deprecated and does not work with plugins. Server-side generation will be the default in v2.7.")
+					fmt.Fprintf(os.Stderr, "Warning: local diff without --server-side-generate or --local-upload is deprecated and does not work with plugins. Server-side generation will be the default in v2.7.")
 					conn, clusterIf := clientset.NewClusterClientOrDie()
 					defer utilio.Close(conn)
 					cluster, err := clusterIf.Get(ctx, &clusterpkg.ClusterQuery{Name: app.Spec.Destination.Name, Server: app.Spec.Destination.Server})
@@ -1399,6 +1488,9 @@ func NewApplicationDiffCommand(clientOpts *argocdclient.ClientOptions) *cobra.Co
 			}
 			proj := getProject(ctx, c, clientOpts, app.Spec.Project)
 			foundDiffs := findandPrintDiff(ctx, app, proj.Project, resources, argoSettings, diffOption, ignoreNormalizerOpts)
+			if includeIgnored {
+				printSuppressedDiffs(resources.Items)
+			}
 			if foundDiffs && exitCode {
 				os.Exit(diffExitCode)
 			}
@@ -1412,15 +1504,42 @@ func NewApplicationDiffCommand(clientOpts *argocdclient.ClientOptions) *cobra.Co
 	command.Flags().StringVar(&revision, "revision", "", "Compare live app to a particular revision")
 	command.Flags().StringVar(&localRepoRoot, "local-repo-root", "/", "Path to the repository root. Used together with --local allows setting the repository root")
 	command.Flags().BoolVar(&serverSideGenerate, "server-side-generate", false, "Used with --local, this will send your manifests to the server for diffing")
-	command.Flags().StringArrayVar(&localIncludes, "local-include", []string{"*.yaml", "*.yml", "*.json"}, "Used with --server-side-generate, specify patterns of filenames to send. Matching is based on filename and not path.")
+	command.Flags().BoolVar(&localUpload, "local-upload", false, "Used with --local, upload the local checkout of the source repository to the repo-server, which renders it with its own Helm, Kustomize and plugin versions and settings. All the files except the .git directory are uploaded unless --local-include is set. Use --source-positions or --source-names to select the source of a multi-source application")
+	command.Flags().StringArrayVar(&localIncludes, "local-include", []string{"*.yaml", "*.yml", "*.json"}, "Used with --server-side-generate or --local-upload, specify patterns of filenames to send. Matching is based on filename and not path.")
 	command.Flags().StringVarP(&appNamespace, "app-namespace", "N", "", "Only render the difference in namespace")
 	command.Flags().StringArrayVar(&revisions, "revisions", []string{}, "Show manifests at specific revisions for source position in source-positions")
 	command.Flags().Int64SliceVar(&sourcePositions, "source-positions", []int64{}, "List of source positions. Default is empty array. Counting start at 1.")
 	command.Flags().StringArrayVar(&sourceNames, "source-names", []string{}, "List of source names. Default is an empty array.")
+	command.Flags().StringVar(&revisionRange, "revision-range", "", "Show the resources changed by every commit of a revision range (e.g. v1.0.0..main) touching the application path. Commits are listed from the git checkout in the current directory, which must have a remote pointing to the application repository")
+	command.Flags().BoolVar(&includeIgnored, "include-ignored", false, "Also list the differences between the target and live state which are hidden by ignoreDifferences rules, and the rule hiding them")
 	command.Flags().DurationVar(&ignoreNormalizerOpts.JQExecutionTimeout, "ignore-normalizer-jq-execution-timeout", normalizers.DefaultJQExecutionTimeout, "Set ignore normalizer JQ execution timeout")
 	return command
 }
 
+// printSuppressedDiffs prints the differences of the managed resources which are hidden by ignoreDifferences rules
+func printSuppressedDiffs(items []*argoappv1.ResourceDiff) {
+	var suppressed bool
+	for _, item := range items {
+		if len(item.SuppressedDiff) > 0 {
+			suppressed = true
+			break
+		}
+	}
+	fmt.Printf("\n===== Ignored differences ======\n")
+	if !suppressed {
+		fmt.Println("No differences are hidden by ignoreDifferences rules")
+		return
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintf(w, "GROUP\tKIND\tNAMESPACE\tNAME\tPATH\tRULE\n")
+	for _, item := range items {
+		for _, diff := range item.SuppressedDiff {
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", item.Group, item.Kind, item.Namespace, item.Name, diff.Path, diff.Rule)
+		}
+	}
+	_ = w.Flush()
+}
+
 // DifferenceOption struct to store diff options
 type DifferenceOption struct {
 	local         string
@@ -1579,6 +1698,7 @@ func NewApplicationDeleteCommand(clientOpts *argocdclient.ClientOptions) *cobra.
   argocd app delete -l app.kubernetes.io/instance
   argocd app delete -l '!app.kubernetes.io/instance'
   argocd app delete -l 'app.kubernetes.io/instance notin (my-app,other-app)'`,
+		ValidArgsFunction: completeApplicationNames(clientOpts, true),
 		Run: func(c *cobra.Command, args []string) {
 			ctx := c.Context()
 
@@ -1708,6 +1828,7 @@ func NewApplicationListCommand(clientOpts *argocdclient.ClientOptions) *cobra.Co
 		repo         string
 		appNamespace string
 		cluster      string
+		frozen       bool
 	)
 	command := &cobra.Command{
 		Use:   "list",
@@ -1720,16 +1841,23 @@ func NewApplicationListCommand(clientOpts *argocdclient.ClientOptions) *cobra.Co
   argocd app list -l app.kubernetes.io/instance!=my-app
   argocd app list -l app.kubernetes.io/instance
   argocd app list -l '!app.kubernetes.io/instance'
-  argocd app list -l 'app.kubernetes.io/instance notin (my-app,other-app)'`,
+  argocd app list -l 'app.kubernetes.io/instance notin (my-app,other-app)'
+
+  # List the frozen apps
+  argocd app list --frozen`,
 		Run: func(c *cobra.Command, _ []string) {
 			ctx := c.Context()
 
 			conn, appIf := headless.NewClientOrDie(clientOpts, c).NewApplicationClientOrDie()
 			defer utilio.Close(conn)
-			apps, err := appIf.List(ctx, &application.ApplicationQuery{
+			query := &application.ApplicationQuery{
 				Selector:     ptr.To(selector),
 				AppNamespace: &appNamespace,
-			})
+			}
+			if c.Flags().Changed("frozen") {
+				query.Frozen = &frozen
+			}
+			apps, err := appIf.List(ctx, query)
 
 			errors.CheckError(err)
 			appList := apps.Items
@@ -1763,6 +1891,7 @@ func NewApplicationListCommand(clientOpts *argocdclient.ClientOptions) *cobra.Co
 	command.Flags().StringVarP(&repo, "repo", "r", "", "List apps by source repo URL")
 	command.Flags().StringVarP(&appNamespace, "app-namespace", "N", "", "Only list applications in namespace")
 	command.Flags().StringVarP(&cluster, "cluster", "c", "", "List apps by cluster name or url")
+	command.Flags().BoolVar(&frozen, "frozen", false, "List the frozen apps, or the apps which aren't frozen if false")
 	return command
 }
 
@@ -1883,6 +2012,7 @@ func NewApplicationWaitCommand(clientOpts *argocdclient.ClientOptions) *cobra.Co
 		resources    []string
 		output       string
 		appNamespace string
+		conditions   []string
 	)
 	command := &cobra.Command{
 		Use:   "wait [APPNAME.. | -l selector]",
@@ -1908,7 +2038,15 @@ func NewApplicationWaitCommand(clientOpts *argocdclient.ClientOptions) *cobra.Co
   argocd app wait -l app.kubernetes.io/instance!=my-app
   argocd app wait -l app.kubernetes.io/instance
   argocd app wait -l '!app.kubernetes.io/instance'
-  argocd app wait -l 'app.kubernetes.io/instance notin (my-app,other-app)'`,
+  argocd app wait -l 'app.kubernetes.io/instance notin (my-app,other-app)'
+
+  # Wait for an arbitrary condition on the application status, all conditions have to be met
+  argocd app wait my-app --condition "app.status.operationState?.phase == 'Succeeded'" --condition "app.status.health.status == 'Healthy'"
+  argocd app wait my-app --condition "app.status.sync.revision == 'a1b2c3d'"
+
+  # Wait for all apps matching a selector, the exit code is non-zero if any of them does not reach the desired state
+  argocd app wait -l team=payments --health --timeout 300`,
+		ValidArgsFunction: completeApplicationNames(clientOpts, true),
 		Run: func(c *cobra.Command, args []string) {
 			ctx := c.Context()
 
@@ -1916,6 +2054,9 @@ func NewApplicationWaitCommand(clientOpts *argocdclient.ClientOptions) *cobra.Co
 				c.HelpFunc()(c, args)
 				os.Exit(1)
 			}
+			var err error
+			watch.conditions, err = newWaitConditions(conditions)
+			errors.CheckError(err)
 			watch = getWatchOpts(watch)
 			selectedResources, err := parseSelectedResources(resources)
 			errors.CheckError(err)
@@ -1930,13 +2071,24 @@ func NewApplicationWaitCommand(clientOpts *argocdclient.ClientOptions) *cobra.Co
 					appNames = append(appNames, i.QualifiedName())
 				}
 			}
+			var failedApps []string
 			for _, appName := range appNames {
 				// Construct QualifiedName
 				if appNamespace != "" && !strings.Contains(appName, "/") {
 					appName = appNamespace + "/" + appName
 				}
 				_, _, err := waitOnApplicationStatus(ctx, acdClient, appName, timeout, watch, selectedResources, output)
-				errors.CheckError(err)
+				if err != nil {
+					if len(appNames) == 1 {
+						errors.CheckError(err)
+					}
+					// keep waiting for the remaining apps and report a combined result at the end
+					log.Error(err)
+					failedApps = append(failedApps, appName)
+				}
+			}
+			if len(failedApps) > 0 {
+				errors.Fatalf(errors.ErrorGeneric, "%d of %d applications did not reach the desired state: %s", len(failedApps), len(appNames), strings.Join(failedApps, ", "))
 			}
 		},
 	}
@@ -1945,6 +2097,7 @@ func NewApplicationWaitCommand(clientOpts *argocdclient.ClientOptions) *cobra.Co
 	command.Flags().BoolVar(&watch.suspended, "suspended", false, "Wait for suspended")
 	command.Flags().BoolVar(&watch.degraded, "degraded", false, "Wait for degraded")
 	command.Flags().BoolVar(&watch.delete, "delete", false, "Wait for delete")
+	command.Flags().StringArrayVar(&conditions, "condition", []string{}, "Wait until an expression evaluated against the application (available as 'app') is true. Can be repeated, all conditions have to be met")
 	command.Flags().BoolVar(&watch.hydrated, "hydrated", false, "Wait for hydration operations")
 	command.Flags().StringVarP(&selector, "selector", "l", "", "Wait for apps by label. Supports '=', '==', '!=', in, notin, exists & not exists. Matching apps must satisfy all of the specified label constraints.")
 	command.Flags().StringArrayVar(&resources, "resource", []string{}, fmt.Sprintf("Sync only specific resources as GROUP%[1]sKIND%[1]sNAME or %[2]sGROUP%[1]sKIND%[1]sNAME. Fields may be blank and '*' can be used. This option may be specified repeatedly", resourceFieldDelimiter, resourceExcludeIndicator))
@@ -2009,9 +2162,13 @@ func NewApplicationSyncCommand(clientOpts *argocdclient.ClientOptions) *cobra.Co
 		infos                   []string
 		diffChanges             bool
 		diffChangesConfirm      bool
+		interactive             bool
 		projects                []string
 		output                  string
 		appNamespace            string
+		justification           string
+		approveBreakGlass       bool
+		priority                int64
 		ignoreNormalizerOpts    normalizers.IgnoreNormalizerOpts
 	)
 	command := &cobra.Command{
@@ -2042,7 +2199,17 @@ func NewApplicationSyncCommand(clientOpts *argocdclient.ClientOptions) *cobra.Co
   argocd app sync my-app --resource apps:Deployment:my-service --resource :Service:my-service
   argocd app sync my-app --resource '!*:Service:*'
   # Specify namespace if the application has resources with the same name in different namespaces
-  argocd app sync my-app --resource argoproj.io:Rollout:my-namespace/my-rollout`,
+  argocd app sync my-app --resource argoproj.io:Rollout:my-namespace/my-rollout
+
+  # Review the resources which are going to be created, updated and pruned, grouped by sync wave, and select which of them to sync
+  argocd app sync my-app --interactive --prune
+
+  # Request a break-glass sync during a deny sync window or to a protected cluster, which must be approved by another user
+  argocd app sync my-app --justification "Rollback of the faulty release, see INC-123"
+
+  # Approve the break-glass sync requested by another user
+  argocd app sync my-app --approve-break-glass`,
+		ValidArgsFunction: completeApplicationNames(clientOpts, true),
 		Run: func(c *cobra.Command, args []string) {
 			ctx := c.Context()
 			if len(args) == 0 && selector == "" && len(projects) == 0 {
@@ -2128,6 +2295,8 @@ func NewApplicationSyncCommand(clientOpts *argocdclient.ClientOptions) *cobra.Co
 				}
 			}
 
+			// a single reader is shared by the reviews of all the applications, so that no buffered input is lost
+			stdin := bufio.NewReader(os.Stdin)
 			for _, appQualifiedName := range appNames {
 				// Construct QualifiedName
 				if appNamespace != "" && !strings.Contains(appQualifiedName, "/") {
@@ -2261,6 +2430,15 @@ func NewApplicationSyncCommand(clientOpts *argocdclient.ClientOptions) *cobra.Co
 					Revisions:       revisions,
 					SourcePositions: sourcePositions,
 				}
+				if justification != "" {
+					syncReq.Justification = &justification
+				}
+				if approveBreakGlass {
+					syncReq.ApproveBreakGlass = &approveBreakGlass
+				}
+				if priority != 0 {
+					syncReq.Priority = &priority
+				}
 
 				switch strategy {
 				case "apply":
@@ -2309,6 +2487,25 @@ func NewApplicationSyncCommand(clientOpts *argocdclient.ClientOptions) *cobra.Co
 						}
 					}
 				}
+				if interactive {
+					plan := getSyncPlan(app, selectedResources, prune)
+					if len(plan) == 0 {
+						fmt.Printf("====== Application %s has no out of sync resources ======\n", appQualifiedName)
+						continue
+					}
+					fmt.Printf("====== Sync plan of application %s ======\n", appQualifiedName)
+					confirmed, err := reviewSyncPlan(stdin, os.Stdout, plan)
+					errors.CheckError(err)
+					if !confirmed {
+						return
+					}
+					var planPrune bool
+					syncReq.Resources, planPrune = syncPlanResources(plan, filteredResources)
+					syncReq.Prune = ptr.To(prune || planPrune)
+					if len(syncReq.Resources) > 0 {
+						selectedResources = syncReq.Resources
+					}
+				}
 				_, err = appIf.Sync(ctx, &syncReq)
 				errors.CheckError(err)
 
@@ -2353,6 +2550,7 @@ func NewApplicationSyncCommand(clientOpts *argocdclient.ClientOptions) *cobra.Co
 	command.Flags().StringArrayVar(&infos, "info", []string{}, "A list of key-value pairs during sync process. These infos will be persisted in app.")
 	command.Flags().BoolVar(&diffChangesConfirm, "assumeYes", false, "Assume yes as answer for all user queries or prompts")
 	command.Flags().BoolVar(&diffChanges, "preview-changes", false, "Preview difference against the target and live state before syncing app and wait for user confirmation")
+	command.Flags().BoolVar(&interactive, "interactive", false, "Review the resources to create, update and prune grouped by sync wave, select which of them to sync and confirm before syncing")
 	command.Flags().StringArrayVar(&projects, "project", []string{}, "Sync apps that belong to the specified projects. This option may be specified repeatedly.")
 	command.Flags().StringVarP(&output, "output", "o", "wide", "Output format. One of: json|yaml|wide|tree|tree=detailed")
 	command.Flags().StringVarP(&appNamespace, "app-namespace", "N", "", "Only sync an application in namespace")
@@ -2360,6 +2558,9 @@ func NewApplicationSyncCommand(clientOpts *argocdclient.ClientOptions) *cobra.Co
 	command.Flags().StringArrayVar(&revisions, "revisions", []string{}, "Show manifests at specific revisions for source position in source-positions")
 	command.Flags().Int64SliceVar(&sourcePositions, "source-positions", []int64{}, "List of source positions. Default is empty array. Counting start at 1.")
 	command.Flags().StringArrayVar(&sourceNames, "source-names", []string{}, "List of source names. Default is an empty array.")
+	command.Flags().StringVar(&justification, "justification", "", "Justification of a break-glass sync during a deny sync window or to a protected cluster, which requests the approval of another user")
+	command.Flags().BoolVar(&approveBreakGlass, "approve-break-glass", false, "Approve the break-glass sync requested by another user")
+	command.Flags().Int64Var(&priority, "priority", 0, "Priority of the sync operation. The pending operations are resumed by order of decreasing priority when the application controller starts")
 	return command
 }
 
@@ -2756,6 +2957,10 @@ func waitOnApplicationStatus(ctx context.Context, acdClient argocdclient.Client,
 			selectedResourcesAreReady = checkResourceStatus(watch, string(app.Status.Health.Status), string(app.Status.Sync.Status), appEvent.Application.Operation, hydrationFinished)
 		}
 
+		if selectedResourcesAreReady && !watch.conditions.satisfied(app) {
+			selectedResourcesAreReady = false
+		}
+
 		if selectedResourcesAreReady && (!operationInProgress || !watch.operation) {
 			app = printFinalStatus(app)
 			return app, finalOperationState, nil
@@ -2842,7 +3047,10 @@ func printApplicationHistoryTable(revHistory []argoappv1.RevisionHistory) {
 		if depInfo.Sources != nil {
 			for i, sourceInfo := range depInfo.Sources {
 				rev := sourceInfo.TargetRevision
-				if len(depInfo.Revisions) == len(depInfo.Sources) && len(depInfo.Revisions[i]) >= maxAllowedRevisions {
+				if len(depInfo.Revisions) == len(depInfo.Sources) && len(depInfo.VersionConstraints) > i && depInfo.VersionConstraints[i] != "" {
+					// the version the version constraint resolved to
+					rev = fmt.Sprintf("%s (%s)", rev, depInfo.Revisions[i])
+				} else if len(depInfo.Revisions) == len(depInfo.Sources) && len(depInfo.Revisions[i]) >= maxAllowedRevisions {
 					rev = fmt.Sprintf("%s (%s)", rev, depInfo.Revisions[i][0:maxAllowedRevisions])
 				}
 				if _, ok := varHistory[sourceInfo.RepoURL]; !ok {
@@ -2856,7 +3064,9 @@ func printApplicationHistoryTable(revHistory []argoappv1.RevisionHistory) {
 			}
 		} else {
 			rev := depInfo.Source.TargetRevision
-			if len(depInfo.Revision) >= maxAllowedRevisions {
+			if len(depInfo.VersionConstraints) > 0 && depInfo.VersionConstraints[0] != "" {
+				rev = fmt.Sprintf("%s (%s)", rev, depInfo.Revision)
+			} else if len(depInfo.Revision) >= maxAllowedRevisions {
 				rev = fmt.Sprintf("%s (%s)", rev, depInfo.Revision[0:maxAllowedRevisions])
 			}
 			if _, ok := varHistory[depInfo.Source.RepoURL]; !ok {
@@ -2890,8 +3100,9 @@ func NewApplicationHistoryCommand(clientOpts *argocdclient.ClientOptions) *cobra
 		appNamespace string
 	)
 	command := &cobra.Command{
-		Use:   "history APPNAME",
-		Short: "Show application deployment history",
+		Use:               "history APPNAME",
+		Short:             "Show application deployment history",
+		ValidArgsFunction: completeApplicationNames(clientOpts, false),
 		Run: func(c *cobra.Command, args []string) {
 			ctx := c.Context()
 
@@ -2915,6 +3126,7 @@ func NewApplicationHistoryCommand(clientOpts *argocdclient.ClientOptions) *cobra
 			}
 		},
 	}
+	command.AddCommand(NewApplicationHistoryDiffCommand(clientOpts))
 	command.Flags().StringVarP(&appNamespace, "app-namespace", "N", "", "Only show application deployment history in namespace")
 	command.Flags().StringVarP(&output, "output", "o", "wide", "Output format. One of: wide|id")
 	return command
@@ -2937,6 +3149,40 @@ func findRevisionHistory(application *argoappv1.Application, historyId int64) (*
 	return nil, fmt.Errorf("application '%s' does not have deployment id '%d' in history", application.Name, historyId)
 }
 
+// findRevisionHistoryAt returns the history entry which was active at the given time, which is the last deployment
+// completed at or before that time
+func findRevisionHistoryAt(application *argoappv1.Application, at time.Time) (*argoappv1.RevisionHistory, error) {
+	var active *argoappv1.RevisionHistory
+	for i, di := range application.Status.History {
+		if di.DeployedAt.Time.After(at) {
+			continue
+		}
+		if active == nil || di.DeployedAt.After(active.DeployedAt.Time) {
+			active = &application.Status.History[i]
+		}
+	}
+	if active == nil {
+		return nil, fmt.Errorf("application '%s' does not have a deployment in history completed at or before %s", application.Name, at.Format(time.RFC3339))
+	}
+	return active, nil
+}
+
+// printRollbackTarget prints the deployment an application is rolled back to, with the revision of every source
+func printRollbackTarget(depInfo *argoappv1.RevisionHistory) {
+	fmt.Printf("Rolling back to deployment id '%d' deployed at %s\n", depInfo.ID, depInfo.DeployedAt.Format(time.RFC3339))
+	if len(depInfo.Sources) == 0 {
+		fmt.Printf(printOpFmtStr, depInfo.Source.RepoURL, depInfo.Revision)
+		return
+	}
+	for i, source := range depInfo.Sources {
+		revision := source.TargetRevision
+		if i < len(depInfo.Revisions) {
+			revision = depInfo.Revisions[i]
+		}
+		fmt.Printf(printOpFmtStr, source.RepoURL, revision)
+	}
+}
+
 // NewApplicationRollbackCommand returns a new instance of an `argocd app rollback` command
 func NewApplicationRollbackCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
 	var (
@@ -2944,10 +3190,21 @@ func NewApplicationRollbackCommand(clientOpts *argocdclient.ClientOptions) *cobr
 		timeout      uint
 		output       string
 		appNamespace string
+		toTime       string
 	)
 	command := &cobra.Command{
 		Use:   "rollback APPNAME [ID]",
 		Short: "Rollback application to a previous deployed version by History ID, omitted will Rollback to the previous version",
+		Example: templates.Examples(`
+  # Rollback an application to the previous deployment
+  argocd app rollback my-app
+
+  # Rollback an application to the deployment with history ID 3
+  argocd app rollback my-app 3
+
+  # Rollback an application to the deployment which was active at the given time
+  argocd app rollback my-app --to-time 2024-05-01T12:00:00Z
+		`),
 		Run: func(c *cobra.Command, args []string) {
 			ctx := c.Context()
 			if len(args) == 0 {
@@ -2958,9 +3215,19 @@ func NewApplicationRollbackCommand(clientOpts *argocdclient.ClientOptions) *cobr
 			var err error
 			depID := -1
 			if len(args) > 1 {
+				if toTime != "" {
+					errors.Fatal(errors.ErrorGeneric, "ID and --to-time cannot be specified together")
+				}
 				depID, err = strconv.Atoi(args[1])
 				errors.CheckError(err)
 			}
+			var rollbackTime time.Time
+			if toTime != "" {
+				rollbackTime, err = time.Parse(time.RFC3339, toTime)
+				if err != nil {
+					errors.Fatalf(errors.ErrorGeneric, "--to-time must be a RFC3339 timestamp (e.g. 2024-05-01T12:00:00Z): %v", err)
+				}
+			}
 			acdClient := headless.NewClientOrDie(clientOpts, c)
 			conn, appIf := acdClient.NewApplicationClientOrDie()
 			defer utilio.Close(conn)
@@ -2970,8 +3237,15 @@ func NewApplicationRollbackCommand(clientOpts *argocdclient.ClientOptions) *cobr
 			})
 			errors.CheckError(err)
 
-			depInfo, err := findRevisionHistory(app, int64(depID))
-			errors.CheckError(err)
+			var depInfo *argoappv1.RevisionHistory
+			if toTime != "" {
+				depInfo, err = findRevisionHistoryAt(app, rollbackTime)
+				errors.CheckError(err)
+				printRollbackTarget(depInfo)
+			} else {
+				depInfo, err = findRevisionHistory(app, int64(depID))
+				errors.CheckError(err)
+			}
 
 			_, err = appIf.Rollback(ctx, &application.ApplicationRollbackRequest{
 				Name:         &appName,
@@ -2991,9 +3265,294 @@ func NewApplicationRollbackCommand(clientOpts *argocdclient.ClientOptions) *cobr
 	command.Flags().UintVar(&timeout, "timeout", defaultCheckTimeoutSeconds, "Time out after this many seconds")
 	command.Flags().StringVarP(&output, "output", "o", "wide", "Output format. One of: json|yaml|wide|tree|tree=detailed")
 	command.Flags().StringVarP(&appNamespace, "app-namespace", "N", "", "Rollback application in namespace")
+	command.Flags().StringVar(&toTime, "to-time", "", "Rollback to the deployment which was active at the given RFC3339 timestamp, i.e. the last deployment completed at or before it")
+	return command
+}
+
+// NewApplicationPinCommand returns a new instance of an `argocd app pin` command
+func NewApplicationPinCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
+	var (
+		appNamespace    string
+		revisions       []string
+		sourcePositions []int64
+		resolved        bool
+	)
+	command := &cobra.Command{
+		Use:   "pin APPNAME",
+		Short: "Pin sources of a multi-source application to revisions, overriding their target revisions",
+		Example: templates.Examples(`
+  # Pin the second source of an application to a revision
+  argocd app pin my-app --source-positions 2 --revisions 1.2.3
+
+  # Pin the first and second sources of an application
+  argocd app pin my-app --source-positions 1 --revisions 8f3a2c1 --source-positions 2 --revisions 1.2.3
+
+  # Unpin the second source of an application
+  argocd app pin my-app --source-positions 2 --revisions ""
+
+  # Pin the sources whose target revision is a version constraint, e.g. 1.2.*, to the versions it resolved to
+  argocd app pin my-app --resolved
+		`),
+		Run: func(c *cobra.Command, args []string) {
+			ctx := c.Context()
+			if len(args) != 1 {
+				c.HelpFunc()(c, args)
+				os.Exit(1)
+			}
+			if resolved && len(revisions) > 0 {
+				errors.Fatal(errors.ErrorGeneric, "--revisions cannot be used with --resolved.")
+			}
+			if !resolved && (len(sourcePositions) == 0 || len(sourcePositions) != len(revisions)) {
+				errors.Fatal(errors.ErrorGeneric, "While using --revisions and --source-positions, length of values for both flags should be same.")
+			}
+			appName, appNs := argo.ParseFromQualifiedName(args[0], appNamespace)
+			conn, appIf := headless.NewClientOrDie(clientOpts, c).NewApplicationClientOrDie()
+			defer utilio.Close(conn)
+			app, err := appIf.PinSources(ctx, &application.ApplicationPinSourcesRequest{
+				Name:            &appName,
+				AppNamespace:    &appNs,
+				SourcePositions: sourcePositions,
+				Revisions:       revisions,
+				Resolved:        &resolved,
+			})
+			errors.CheckError(err)
+			printPinnedRevisions(app)
+		},
+	}
+	command.Flags().StringVarP(&appNamespace, "app-namespace", "N", "", "Namespace of the application")
+	command.Flags().StringArrayVar(&revisions, "revisions", []string{}, "Revisions to pin the sources in source-positions to, an empty revision unpins the source")
+	command.Flags().Int64SliceVar(&sourcePositions, "source-positions", []int64{}, "List of source positions. Counting start at 1.")
+	command.Flags().BoolVar(&resolved, "resolved", false, "Pin the sources to the versions their version constraints resolved to at the last comparison, all of them unless source-positions are specified. The target revision of a single source application is replaced.")
+	return command
+}
+
+// NewApplicationPromotePinsCommand returns a new instance of an `argocd app promote-pins` command
+func NewApplicationPromotePinsCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
+	var (
+		appNamespace string
+		from         string
+	)
+	command := &cobra.Command{
+		Use:   "promote-pins APPNAME --from SOURCE_APPNAME",
+		Short: "Copy the pinned revisions of another application to the sources of an application with the same repository, chart and path",
+		Example: templates.Examples(`
+  # Promote the revisions pinned in staging to production
+  argocd app promote-pins my-app-production --from my-app-staging
+		`),
+		Run: func(c *cobra.Command, args []string) {
+			ctx := c.Context()
+			if len(args) != 1 || from == "" {
+				c.HelpFunc()(c, args)
+				os.Exit(1)
+			}
+			appName, appNs := argo.ParseFromQualifiedName(args[0], appNamespace)
+			fromName, fromNs := argo.ParseFromQualifiedName(from, appNamespace)
+			conn, appIf := headless.NewClientOrDie(clientOpts, c).NewApplicationClientOrDie()
+			defer utilio.Close(conn)
+			app, err := appIf.PromotePins(ctx, &application.ApplicationPromotePinsRequest{
+				Name:             &appName,
+				AppNamespace:     &appNs,
+				FromName:         &fromName,
+				FromAppNamespace: &fromNs,
+			})
+			errors.CheckError(err)
+			printPinnedRevisions(app)
+		},
+	}
+	command.Flags().StringVarP(&appNamespace, "app-namespace", "N", "", "Namespace of the applications")
+	command.Flags().StringVar(&from, "from", "", "Name of the application to promote the pinned revisions from")
+	return command
+}
+
+// NewApplicationFreezeCommand returns a new instance of an `argocd app freeze` command
+func NewApplicationFreezeCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
+	var (
+		appNamespace string
+		until        string
+		duration     time.Duration
+		reason       string
+		noRefresh    bool
+	)
+	command := &cobra.Command{
+		Use:   "freeze APPNAME",
+		Short: "Freeze an application, disabling its automated sync and self heal until it is thawed",
+		Example: templates.Examples(`
+  # Freeze an application until it is thawed
+  argocd app freeze my-app --reason "incident 1234"
+
+  # Freeze an application for two hours
+  argocd app freeze my-app --duration 2h
+
+  # Freeze an application until a given time, disabling its refresh as well
+  argocd app freeze my-app --until 2026-01-01T00:00:00Z --no-refresh
+		`),
+		Run: func(c *cobra.Command, args []string) {
+			ctx := c.Context()
+			if len(args) != 1 {
+				c.HelpFunc()(c, args)
+				os.Exit(1)
+			}
+			if until != "" && duration != 0 {
+				errors.Fatal(errors.ErrorGeneric, "--until cannot be used with --duration.")
+			}
+			if duration != 0 {
+				until = time.Now().Add(duration).UTC().Format(time.RFC3339)
+			}
+			appName, appNs := argo.ParseFromQualifiedName(args[0], appNamespace)
+			conn, appIf := headless.NewClientOrDie(clientOpts, c).NewApplicationClientOrDie()
+			defer utilio.Close(conn)
+			app, err := appIf.Freeze(ctx, &application.ApplicationFreezeRequest{
+				Name:         &appName,
+				AppNamespace: &appNs,
+				Until:        &until,
+				Reason:       &reason,
+				NoRefresh:    &noRefresh,
+			})
+			errors.CheckError(err)
+			freeze, err := app.GetFreeze()
+			errors.CheckError(err)
+			fmt.Printf("Application '%s' frozen %s\n", app.QualifiedName(), formatFreeze(freeze))
+		},
+	}
+	command.Flags().StringVarP(&appNamespace, "app-namespace", "N", "", "Namespace of the application")
+	command.Flags().StringVar(&until, "until", "", "Time at which the application is thawed, in RFC3339 format")
+	command.Flags().DurationVar(&duration, "duration", 0, "Duration of the freeze, e.g. 2h")
+	command.Flags().StringVar(&reason, "reason", "", "Reason why the application is frozen")
+	command.Flags().BoolVar(&noRefresh, "no-refresh", false, "Disable the refresh of the application in addition to its automated sync and self heal")
+	return command
+}
+
+// NewApplicationThawCommand returns a new instance of an `argocd app thaw` command
+func NewApplicationThawCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
+	var appNamespace string
+	command := &cobra.Command{
+		Use:   "thaw APPNAME",
+		Short: "Thaw a frozen application",
+		Example: templates.Examples(`
+  # Thaw a frozen application
+  argocd app thaw my-app
+		`),
+		Run: func(c *cobra.Command, args []string) {
+			ctx := c.Context()
+			if len(args) != 1 {
+				c.HelpFunc()(c, args)
+				os.Exit(1)
+			}
+			appName, appNs := argo.ParseFromQualifiedName(args[0], appNamespace)
+			conn, appIf := headless.NewClientOrDie(clientOpts, c).NewApplicationClientOrDie()
+			defer utilio.Close(conn)
+			app, err := appIf.Thaw(ctx, &application.ApplicationThawRequest{
+				Name:         &appName,
+				AppNamespace: &appNs,
+			})
+			errors.CheckError(err)
+			fmt.Printf("Application '%s' thawed\n", app.QualifiedName())
+		},
+	}
+	command.Flags().StringVarP(&appNamespace, "app-namespace", "N", "", "Namespace of the application")
 	return command
 }
 
+// NewApplicationCascadeCommand returns a new instance of an `argocd app cascade` command
+func NewApplicationCascadeCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
+	var (
+		appNamespace string
+		refresh      bool
+		prune        bool
+		async        bool
+		timeout      uint
+	)
+	command := &cobra.Command{
+		Use:   "cascade APPNAME",
+		Short: "Sync or refresh the child applications of an application, wave by wave",
+		Long: `Sync or refresh the child applications of an application, wave by wave.
+
+The child applications are ordered by their argocd.argoproj.io/cascade-wave annotation, 0 by default. The operation of a
+wave starts once every child application of the previous waves completed its operation, and the synced child applications
+are healthy.`,
+		Example: templates.Examples(`
+  # Sync the child applications of an application
+  argocd app cascade my-apps
+
+  # Sync the child applications of an application, pruning their resources
+  argocd app cascade my-apps --prune
+
+  # Refresh the child applications of an application without waiting
+  argocd app cascade my-apps --refresh --async
+		`),
+		Run: func(c *cobra.Command, args []string) {
+			ctx := c.Context()
+			if len(args) != 1 {
+				c.HelpFunc()(c, args)
+				os.Exit(1)
+			}
+			if refresh && prune {
+				errors.Fatal(errors.ErrorGeneric, "--prune cannot be used with --refresh.")
+			}
+			opType := string(argoappv1.CascadeOperationTypeSync)
+			if refresh {
+				opType = string(argoappv1.CascadeOperationTypeRefresh)
+			}
+			acdClient := headless.NewClientOrDie(clientOpts, c)
+			appName, appNs := argo.ParseFromQualifiedName(args[0], appNamespace)
+			conn, appIf := acdClient.NewApplicationClientOrDie()
+			defer utilio.Close(conn)
+			app, err := appIf.Cascade(ctx, &application.ApplicationCascadeRequest{
+				Name:         &appName,
+				AppNamespace: &appNs,
+				Type:         &opType,
+				Prune:        &prune,
+			})
+			errors.CheckError(err)
+			fmt.Printf("Cascade %s of the child applications of '%s' started\n", strings.ToLower(opType), app.QualifiedName())
+			if async {
+				return
+			}
+			_, opState, err := waitOnApplicationStatus(ctx, acdClient, app.QualifiedName(), timeout, watchOpts{operation: true}, nil, "")
+			errors.CheckError(err)
+			if !opState.Phase.Successful() {
+				log.Fatalf("Operation has completed with phase: %s", opState.Phase)
+			}
+		},
+	}
+	command.Flags().StringVarP(&appNamespace, "app-namespace", "N", "", "Namespace of the application")
+	command.Flags().BoolVar(&refresh, "refresh", false, "Refresh the child applications instead of syncing them")
+	command.Flags().BoolVar(&prune, "prune", false, "Allow deleting unexpected resources of the child applications")
+	command.Flags().BoolVar(&async, "async", false, "Do not wait for the operation to complete")
+	command.Flags().UintVar(&timeout, "timeout", defaultCheckTimeoutSeconds, "Time out after this many seconds")
+	return command
+}
+
+// formatFreeze formats the freeze of an application, e.g. "until 2026-01-01T00:00:00Z by admin (incident 1234)"
+func formatFreeze(freeze *argoappv1.ApplicationFreeze) string {
+	message := "until thawed"
+	if freeze.Until != nil {
+		message = "until " + freeze.Until.UTC().Format(time.RFC3339)
+	}
+	if freeze.NoRefresh {
+		message += ", without refresh"
+	}
+	if freeze.FrozenBy != "" {
+		message += " by " + freeze.FrozenBy
+	}
+	if freeze.Reason != "" {
+		message += " (" + freeze.Reason + ")"
+	}
+	return message
+}
+
+// printPinnedRevisions prints the revisions the sources of the application are pinned to
+func printPinnedRevisions(app *argoappv1.Application) {
+	pins, err := app.GetPinnedRevisions()
+	errors.CheckError(err)
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintf(w, "POSITION\tREPO\tTARGET REVISION\tPINNED REVISION\n")
+	for i, source := range app.Spec.GetSources() {
+		_, _ = fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", i+1, source.RepoURL, source.TargetRevision, pins[i+1])
+	}
+	_ = w.Flush()
+}
+
 const (
 	printOpFmtStr              = "%-20s%s\n"
 	defaultCheckTimeoutSeconds = 0
@@ -3003,6 +3562,9 @@ func printOperationResult(opState *argoappv1.OperationState) {
 	if opState == nil {
 		return
 	}
+	if opState.Operation.Cascade != nil {
+		fmt.Printf(printOpFmtStr, "Operation:", "Cascade "+string(opState.Operation.Cascade.Type))
+	}
 	if opState.SyncResult != nil {
 		fmt.Printf(printOpFmtStr, "Operation:", "Sync")
 		if opState.SyncResult.Sources != nil && opState.SyncResult.Revisions != nil {
@@ -3024,6 +3586,44 @@ func printOperationResult(opState *argoappv1.OperationState) {
 	if opState.Message != "" {
 		fmt.Printf(printOpFmtStr, "Message:", opState.Message)
 	}
+	if opState.CascadeResult != nil && len(opState.CascadeResult.Children) > 0 {
+		fmt.Println()
+		printCascadeResult(os.Stdout, opState.CascadeResult)
+	}
+}
+
+// printCascadeResult prints the progress of the child applications of a cascade operation
+func printCascadeResult(out io.Writer, result *argoappv1.CascadeOperationResult) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintf(w, "WAVE\tNAMESPACE\tNAME\tPHASE\tMESSAGE\n")
+	for _, child := range result.Children {
+		_, _ = fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\n", child.Wave, child.Namespace, child.Name, child.Phase, child.Message)
+	}
+	_ = w.Flush()
+}
+
+// printHookOutputs prints the final status of the containers of the pods of the completed hooks of an operation. Their
+// logs are shown by the logs command.
+func printHookOutputs(out io.Writer, appName string, opState *argoappv1.OperationState) {
+	if opState.SyncResult == nil {
+		return
+	}
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	hasOutputs := false
+	for _, res := range opState.SyncResult.Resources {
+		for _, output := range res.HookOutputs {
+			if !hasOutputs {
+				_, _ = fmt.Fprintln(w, "\n===== Hooks ======")
+				_, _ = fmt.Fprintln(w, "HOOK\tKIND\tNAME\tPOD\tCONTAINER\tREASON\tEXIT CODE")
+				hasOutputs = true
+			}
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%d\n", res.HookType, res.Kind, res.Name, output.Pod, output.Container, output.Reason, output.ExitCode)
+		}
+	}
+	_ = w.Flush()
+	if hasOutputs {
+		_, _ = fmt.Fprintf(out, "\nThe logs of the hooks are shown with: argocd app logs %s --kind KIND --name NAME\n", appName)
+	}
 }
 
 // NewApplicationManifestsCommand returns a new instance of an `argocd app manifests` command
@@ -3053,6 +3653,7 @@ func NewApplicationManifestsCommand(clientOpts *argocdclient.ClientOptions) *cob
   # Get manifests for a multi-source application at specific revisions for specific sources
   argocd app manifests my-app --revisions 0.0.1 --source-positions 1 --revisions 0.0.2 --source-positions 2
   		`),
+		ValidArgsFunction: completeApplicationNames(clientOpts, false),
 		Run: func(c *cobra.Command, args []string) {
 			ctx := c.Context()
 
@@ -3190,8 +3791,9 @@ func NewApplicationManifestsCommand(clientOpts *argocdclient.ClientOptions) *cob
 // NewApplicationTerminateOpCommand returns a new instance of an `argocd app terminate-op` command
 func NewApplicationTerminateOpCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
 	command := &cobra.Command{
-		Use:   "terminate-op APPNAME",
-		Short: "Terminate running operation of an application",
+		Use:               "terminate-op APPNAME",
+		Short:             "Terminate running operation of an application",
+		ValidArgsFunction: completeApplicationNames(clientOpts, false),
 		Run: func(c *cobra.Command, args []string) {
 			ctx := c.Context()
 
@@ -3216,8 +3818,9 @@ func NewApplicationTerminateOpCommand(clientOpts *argocdclient.ClientOptions) *c
 func NewApplicationEditCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
 	var appNamespace string
 	command := &cobra.Command{
-		Use:   "edit APPNAME",
-		Short: "Edit application",
+		Use:               "edit APPNAME",
+		Short:             "Edit application",
+		ValidArgsFunction: completeApplicationNames(clientOpts, false),
 		Run: func(c *cobra.Command, args []string) {
 			ctx := c.Context()
 
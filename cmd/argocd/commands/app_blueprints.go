@@ -0,0 +1,186 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/argoproj/argo-cd/v3/cmd/argocd/commands/headless"
+	argocdclient "github.com/argoproj/argo-cd/v3/pkg/apiclient"
+	"github.com/argoproj/argo-cd/v3/pkg/apiclient/application"
+	argoappv1 "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	"github.com/argoproj/argo-cd/v3/util/argo"
+	"github.com/argoproj/argo-cd/v3/util/errors"
+	utilio "github.com/argoproj/argo-cd/v3/util/io"
+	"github.com/argoproj/argo-cd/v3/util/templates"
+)
+
+// NewApplicationBlueprintsCommand returns a new instance of an `argocd app blueprints` command
+func NewApplicationBlueprintsCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
+	command := &cobra.Command{
+		Use:   "blueprints",
+		Short: "Manage applications from the catalog of application blueprints",
+		Run: func(c *cobra.Command, args []string) {
+			c.HelpFunc()(c, args)
+			os.Exit(1)
+		},
+	}
+	command.AddCommand(NewApplicationBlueprintsListCommand(clientOpts))
+	command.AddCommand(NewApplicationBlueprintsGetCommand(clientOpts))
+	command.AddCommand(NewApplicationBlueprintsInstantiateCommand(clientOpts))
+	return command
+}
+
+// NewApplicationBlueprintsListCommand returns a new instance of an `argocd app blueprints list` command
+func NewApplicationBlueprintsListCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
+	var (
+		output  string
+		project string
+	)
+	command := &cobra.Command{
+		Use:   "list",
+		Short: "List the application blueprints",
+		Example: templates.Examples(`
+  # List the application blueprints
+  argocd app blueprints list
+
+  # List the application blueprints from which applications can be created in a project
+  argocd app blueprints list --project my-project
+		`),
+		Run: func(c *cobra.Command, _ []string) {
+			ctx := c.Context()
+			conn, appIf := headless.NewClientOrDie(clientOpts, c).NewApplicationClientOrDie()
+			defer utilio.Close(conn)
+			list, err := appIf.ListBlueprints(ctx, &application.ApplicationBlueprintQuery{Project: &project})
+			errors.CheckError(err)
+			switch output {
+			case "yaml", "json":
+				errors.CheckError(PrintResourceList(list.Items, output, false))
+			case "name":
+				for _, blueprint := range list.Items {
+					fmt.Println(blueprint.Name)
+				}
+			case "wide", "":
+				printBlueprintTable(os.Stdout, list.Items)
+			default:
+				errors.CheckError(fmt.Errorf("unknown output format: %s", output))
+			}
+		},
+	}
+	command.Flags().StringVarP(&output, "output", "o", "wide", "Output format. One of: wide|name|json|yaml")
+	command.Flags().StringVarP(&project, "project", "p", "", "Only list the blueprints from which applications can be created in project")
+	return command
+}
+
+// NewApplicationBlueprintsGetCommand returns a new instance of an `argocd app blueprints get` command
+func NewApplicationBlueprintsGetCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
+	var output string
+	command := &cobra.Command{
+		Use:   "get BLUEPRINT",
+		Short: "Get the details of an application blueprint",
+		Example: templates.Examples(`
+  # Get the parameters of an application blueprint
+  argocd app blueprints get web-service
+		`),
+		Run: func(c *cobra.Command, args []string) {
+			ctx := c.Context()
+			if len(args) != 1 {
+				c.HelpFunc()(c, args)
+				os.Exit(1)
+			}
+			conn, appIf := headless.NewClientOrDie(clientOpts, c).NewApplicationClientOrDie()
+			defer utilio.Close(conn)
+			list, err := appIf.ListBlueprints(ctx, &application.ApplicationBlueprintQuery{Name: &args[0]})
+			errors.CheckError(err)
+			if len(list.Items) == 0 {
+				errors.CheckError(fmt.Errorf("application blueprint %q not found", args[0]))
+			}
+			switch output {
+			case "yaml", "json":
+				errors.CheckError(PrintResource(list.Items[0], output))
+			case "wide", "":
+				printBlueprint(os.Stdout, &list.Items[0])
+			default:
+				errors.CheckError(fmt.Errorf("unknown output format: %s", output))
+			}
+		},
+	}
+	command.Flags().StringVarP(&output, "output", "o", "wide", "Output format. One of: wide|json|yaml")
+	return command
+}
+
+// NewApplicationBlueprintsInstantiateCommand returns a new instance of an `argocd app blueprints instantiate` command
+func NewApplicationBlueprintsInstantiateCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
+	var (
+		appNamespace string
+		project      string
+		parameters   []string
+	)
+	command := &cobra.Command{
+		Use:   "instantiate BLUEPRINT APPNAME",
+		Short: "Create an application from an application blueprint",
+		Example: templates.Examples(`
+  # Create an application from an application blueprint in a project
+  argocd app blueprints instantiate web-service my-app --project my-project --param image=nginx --param replicas=2
+		`),
+		Run: func(c *cobra.Command, args []string) {
+			ctx := c.Context()
+			if len(args) != 2 {
+				c.HelpFunc()(c, args)
+				os.Exit(1)
+			}
+			appName, appNs := argo.ParseFromQualifiedName(args[1], appNamespace)
+			conn, appIf := headless.NewClientOrDie(clientOpts, c).NewApplicationClientOrDie()
+			defer utilio.Close(conn)
+			created, err := appIf.InstantiateBlueprint(ctx, &application.ApplicationInstantiateBlueprintRequest{
+				Blueprint:    &args[0],
+				Name:         &appName,
+				AppNamespace: &appNs,
+				Project:      &project,
+				Parameters:   parameters,
+			})
+			errors.CheckError(err)
+			fmt.Printf("application '%s' created\n", created.Name)
+		},
+	}
+	command.Flags().StringVarP(&appNamespace, "app-namespace", "N", "", "Namespace of the application")
+	command.Flags().StringVarP(&project, "project", "p", "", "Project of the application")
+	command.Flags().StringArrayVar(&parameters, "param", []string{}, "Value of a parameter of the blueprint, in the form name=value")
+	errors.CheckError(command.MarkFlagRequired("project"))
+	return command
+}
+
+// printBlueprintTable prints a table of application blueprints
+func printBlueprintTable(out io.Writer, blueprints []argoappv1.ApplicationBlueprint) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintf(w, "NAME\tPROJECTS\tPARAMETERS\tDESCRIPTION\n")
+	for _, blueprint := range blueprints {
+		var params []string
+		for _, param := range blueprint.Parameters {
+			params = append(params, param.Name)
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", blueprint.Name, strings.Join(blueprint.Projects, ","), strings.Join(params, ","), blueprint.Description)
+	}
+	_ = w.Flush()
+}
+
+// printBlueprint prints the details and the parameters of an application blueprint
+func printBlueprint(out io.Writer, blueprint *argoappv1.ApplicationBlueprint) {
+	_, _ = fmt.Fprintf(out, printOpFmtStr, "Name:", blueprint.Name)
+	_, _ = fmt.Fprintf(out, printOpFmtStr, "Description:", blueprint.Description)
+	_, _ = fmt.Fprintf(out, printOpFmtStr, "Projects:", strings.Join(blueprint.Projects, ","))
+	if len(blueprint.Parameters) == 0 {
+		return
+	}
+	_, _ = fmt.Fprintln(out)
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintf(w, "PARAMETER\tREQUIRED\tDEFAULT\tPATTERN\tDESCRIPTION\n")
+	for _, param := range blueprint.Parameters {
+		_, _ = fmt.Fprintf(w, "%s\t%t\t%s\t%s\t%s\n", param.Name, param.Required && param.Default == "", param.Default, param.Pattern, param.Description)
+	}
+	_ = w.Flush()
+}
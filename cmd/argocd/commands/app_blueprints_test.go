@@ -0,0 +1,42 @@
+package commands
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	argoappv1 "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+)
+
+var testBlueprint = argoappv1.ApplicationBlueprint{
+	Name:        "web-service",
+	Description: "A web service",
+	Projects:    []string{"default", "team-*"},
+	Parameters: []argoappv1.ApplicationBlueprintParameter{
+		{Name: "image", Required: true, Description: "The image of the service"},
+		{Name: "replicas", Required: true, Default: "1", Pattern: "[0-9]+"},
+	},
+}
+
+func TestPrintBlueprintTable(t *testing.T) {
+	var out bytes.Buffer
+	printBlueprintTable(&out, []argoappv1.ApplicationBlueprint{testBlueprint, {Name: "job", Projects: []string{"*"}}})
+	assert.Equal(t, `NAME         PROJECTS        PARAMETERS      DESCRIPTION
+web-service  default,team-*  image,replicas  A web service
+job          *                               
+`, out.String())
+}
+
+func TestPrintBlueprint(t *testing.T) {
+	var out bytes.Buffer
+	printBlueprint(&out, &testBlueprint)
+	assert.Equal(t, `Name:               web-service
+Description:        A web service
+Projects:           default,team-*
+
+PARAMETER  REQUIRED  DEFAULT  PATTERN  DESCRIPTION
+image      true                        The image of the service
+replicas   false     1        [0-9]+   
+`, out.String())
+}
@@ -0,0 +1,254 @@
+package commands
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"reflect"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/argoproj/gitops-engine/pkg/sync/hook"
+	"github.com/argoproj/gitops-engine/pkg/utils/kube"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/argoproj/argo-cd/v3/pkg/apiclient/application"
+	argoappv1 "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	"github.com/argoproj/argo-cd/v3/util/git"
+)
+
+const (
+	resourceChangeCreate = "create"
+	resourceChangeUpdate = "update"
+	resourceChangeDelete = "delete"
+)
+
+// rangeCommit is a single commit of a revision range
+type rangeCommit struct {
+	sha     string
+	subject string
+}
+
+// resourceChange describes how a resource changed between two renders of an application
+type resourceChange struct {
+	action string
+	key    kube.ResourceKey
+}
+
+// parseRevisionRange splits a git style revision range (e.g. "v1.0.0..main") into its base and head revisions
+func parseRevisionRange(revisionRange string) (string, string, error) {
+	if strings.Contains(revisionRange, "...") {
+		return "", "", fmt.Errorf("symmetric difference ranges are not supported, use A..B: %s", revisionRange)
+	}
+	base, head, ok := strings.Cut(revisionRange, "..")
+	if !ok || base == "" || head == "" {
+		return "", "", fmt.Errorf("revision range must be in the form A..B: %s", revisionRange)
+	}
+	return base, head, nil
+}
+
+// revisionRangePathspec returns the git pathspec matching the files tracked by an application source
+func revisionRangePathspec(path string) []string {
+	path = strings.Trim(path, "/")
+	if path == "" || path == "." {
+		return nil
+	}
+	// the ":(top)" magic makes the pathspec relative to the repository root regardless of the working directory
+	return []string{":(top)" + path}
+}
+
+// checkRangeRepoURL returns an error unless one of the remotes of the git checkout in dir points to repoURL, so that
+// commits are never enumerated from an unrelated repository
+func checkRangeRepoURL(ctx context.Context, dir, repoURL string) error {
+	cmd := exec.CommandContext(ctx, "git", "config", "--get-regexp", `^remote\..*\.url$`)
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	// git config exits with 1 when no remote is configured
+	var exitErr *exec.ExitError
+	if err != nil && (!stderrors.As(err, &exitErr) || exitErr.ExitCode() != 1) {
+		if exitErr != nil {
+			return fmt.Errorf("failed to list the remotes of the git checkout: %s", strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return fmt.Errorf("failed to list the remotes of the git checkout: %w", err)
+	}
+	for _, line := range strings.Split(string(out), "\n") {
+		_, remoteURL, ok := strings.Cut(strings.TrimSpace(line), " ")
+		if ok && repoLocation(remoteURL) == repoLocation(repoURL) {
+			return nil
+		}
+	}
+	return fmt.Errorf("the git checkout in the current directory has no remote pointing to %s", repoURL)
+}
+
+// repoLocation returns the host and path of a repository URL, so that SSH and HTTPS clones of a repository match
+func repoLocation(repoURL string) string {
+	location := git.NormalizeGitURLAllowInvalid(repoURL)
+	if _, rest, ok := strings.Cut(location, "://"); ok {
+		location = rest
+	}
+	if _, rest, ok := strings.Cut(location, "@"); ok {
+		location = rest
+	}
+	return location
+}
+
+// listRangeCommits returns the commits of the base..head range touching the given paths, oldest first. Commits
+// are enumerated from the git checkout in dir, which must contain both revisions.
+func listRangeCommits(ctx context.Context, dir, base, head string, paths []string) ([]rangeCommit, error) {
+	args := []string{"log", "--reverse", "--format=%H%x00%s", base + ".." + head}
+	if len(paths) > 0 {
+		args = append(args, "--")
+		args = append(args, paths...)
+	}
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if stderrors.As(err, &exitErr) {
+			return nil, fmt.Errorf("failed to list commits of %s..%s: %s", base, head, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return nil, fmt.Errorf("failed to list commits of %s..%s: %w", base, head, err)
+	}
+	return parseRangeCommits(string(out)), nil
+}
+
+func parseRangeCommits(out string) []rangeCommit {
+	var commits []rangeCommit
+	for _, line := range strings.Split(out, "\n") {
+		if line == "" {
+			continue
+		}
+		sha, subject, _ := strings.Cut(line, "\x00")
+		commits = append(commits, rangeCommit{sha: sha, subject: subject})
+	}
+	return commits
+}
+
+// renderedObjectsByKey converts rendered manifests into objects keyed by resource key. Hooks are skipped since they
+// are not part of the application's desired state.
+func renderedObjectsByKey(manifests []string) (map[kube.ResourceKey]*unstructured.Unstructured, error) {
+	objs := make(map[kube.ResourceKey]*unstructured.Unstructured)
+	for _, mfst := range manifests {
+		obj, err := argoappv1.UnmarshalToUnstructured(mfst)
+		if err != nil {
+			return nil, err
+		}
+		if hook.IsHook(obj) {
+			continue
+		}
+		objs[kube.GetResourceKey(obj)] = obj
+	}
+	return objs, nil
+}
+
+// diffRenderedObjects returns the resources created, updated and deleted between two renders, sorted by key
+func diffRenderedObjects(before, after map[kube.ResourceKey]*unstructured.Unstructured) []resourceChange {
+	var changes []resourceChange
+	for key, obj := range after {
+		prev, ok := before[key]
+		switch {
+		case !ok:
+			changes = append(changes, resourceChange{action: resourceChangeCreate, key: key})
+		case !reflect.DeepEqual(prev.Object, obj.Object):
+			changes = append(changes, resourceChange{action: resourceChangeUpdate, key: key})
+		}
+	}
+	for key := range before {
+		if _, ok := after[key]; !ok {
+			changes = append(changes, resourceChange{action: resourceChangeDelete, key: key})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].key.String() < changes[j].key.String()
+	})
+	return changes
+}
+
+// printRangeCommitChanges prints the resource changes introduced by a single commit of a revision range
+func printRangeCommitChanges(out io.Writer, commit rangeCommit, changes []resourceChange) {
+	fmt.Fprintf(out, "\n===== %s %s ======\n", commit.sha, commit.subject)
+	if len(changes) == 0 {
+		fmt.Fprintln(out, "No resource changes")
+		return
+	}
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintf(w, "ACTION\tGROUP\tKIND\tNAMESPACE\tNAME\n")
+	for _, c := range changes {
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", c.action, c.key.Group, c.key.Kind, c.key.Namespace, c.key.Name)
+	}
+	_ = w.Flush()
+}
+
+// findAndPrintRevisionRangeDiff renders the application at the base of the revision range and at every commit of the
+// range touching the application source path, and prints the resources changed by each commit. Returns true if any
+// commit changes at least one resource.
+func findAndPrintRevisionRangeDiff(ctx context.Context, out io.Writer, appIf application.ApplicationServiceClient, app *argoappv1.Application, revisionRange string, sourcePosition int64) (bool, error) {
+	base, head, err := parseRevisionRange(revisionRange)
+	if err != nil {
+		return false, err
+	}
+	if app.Spec.HasMultipleSources() && (sourcePosition <= 0 || sourcePosition > int64(len(app.Spec.Sources))) {
+		return false, fmt.Errorf("source position %d is out of range, the application has %d sources", sourcePosition, len(app.Spec.Sources))
+	}
+	source := app.Spec.GetSourcePtrByPosition(int(sourcePosition))
+	if source == nil {
+		return false, fmt.Errorf("application %s has no source", app.Name)
+	}
+	if source.IsHelm() || source.IsOCI() {
+		return false, fmt.Errorf("revision ranges are only supported for git sources, %s is not a git repository", source.RepoURL)
+	}
+	if err := checkRangeRepoURL(ctx, ".", source.RepoURL); err != nil {
+		return false, err
+	}
+	commits, err := listRangeCommits(ctx, ".", base, head, revisionRangePathspec(source.Path))
+	if err != nil {
+		return false, err
+	}
+	if len(commits) == 0 {
+		fmt.Fprintf(out, "No commits in %s touch path '%s'\n", revisionRange, source.Path)
+		return false, nil
+	}
+
+	render := func(revision string) (map[kube.ResourceKey]*unstructured.Unstructured, error) {
+		appName := app.Name
+		appNs := app.Namespace
+		q := &application.ApplicationManifestQuery{
+			Name:         &appName,
+			AppNamespace: &appNs,
+		}
+		if app.Spec.HasMultipleSources() {
+			q.Revisions = []string{revision}
+			q.SourcePositions = []int64{sourcePosition}
+		} else {
+			q.Revision = &revision
+		}
+		res, err := appIf.GetManifests(ctx, q)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render manifests at revision %s: %w", revision, err)
+		}
+		return renderedObjectsByKey(res.Manifests)
+	}
+
+	prev, err := render(base)
+	if err != nil {
+		return false, err
+	}
+	var foundDiffs bool
+	for _, commit := range commits {
+		objs, err := render(commit.sha)
+		if err != nil {
+			return foundDiffs, err
+		}
+		changes := diffRenderedObjects(prev, objs)
+		if len(changes) > 0 {
+			foundDiffs = true
+		}
+		printRangeCommitChanges(out, commit, changes)
+		prev = objs
+	}
+	return foundDiffs, nil
+}
@@ -0,0 +1,100 @@
+package commands
+
+import (
+	"bytes"
+	"os/exec"
+	"testing"
+
+	"github.com/argoproj/gitops-engine/pkg/utils/kube"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRevisionRange(t *testing.T) {
+	base, head, err := parseRevisionRange("v1.0.0..main")
+	require.NoError(t, err)
+	assert.Equal(t, "v1.0.0", base)
+	assert.Equal(t, "main", head)
+
+	for _, invalid := range []string{"", "main", "..main", "v1.0.0..", "v1.0.0...main"} {
+		_, _, err := parseRevisionRange(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestRevisionRangePathspec(t *testing.T) {
+	assert.Nil(t, revisionRangePathspec(""))
+	assert.Nil(t, revisionRangePathspec("."))
+	assert.Equal(t, []string{":(top)apps/guestbook"}, revisionRangePathspec("/apps/guestbook/"))
+}
+
+func TestParseRangeCommits(t *testing.T) {
+	commits := parseRangeCommits("aaa\x00first commit\nbbb\x00second: with colon\n")
+	assert.Equal(t, []rangeCommit{{sha: "aaa", subject: "first commit"}, {sha: "bbb", subject: "second: with colon"}}, commits)
+	assert.Empty(t, parseRangeCommits(""))
+}
+
+func TestDiffRenderedObjects(t *testing.T) {
+	before, err := renderedObjectsByKey([]string{
+		`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"unchanged","namespace":"default"},"data":{"a":"b"}}`,
+		`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"updated","namespace":"default"},"data":{"a":"b"}}`,
+		`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"deleted","namespace":"default"}}`,
+	})
+	require.NoError(t, err)
+	after, err := renderedObjectsByKey([]string{
+		`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"unchanged","namespace":"default"},"data":{"a":"b"}}`,
+		`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"updated","namespace":"default"},"data":{"a":"c"}}`,
+		`{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"created","namespace":"default"}}`,
+		`{"apiVersion":"batch/v1","kind":"Job","metadata":{"name":"hook","namespace":"default","annotations":{"argocd.argoproj.io/hook":"PreSync"}}}`,
+	})
+	require.NoError(t, err)
+
+	changes := diffRenderedObjects(before, after)
+	assert.Equal(t, []resourceChange{
+		{action: resourceChangeDelete, key: kube.ResourceKey{Kind: "ConfigMap", Namespace: "default", Name: "deleted"}},
+		{action: resourceChangeUpdate, key: kube.ResourceKey{Kind: "ConfigMap", Namespace: "default", Name: "updated"}},
+		{action: resourceChangeCreate, key: kube.ResourceKey{Group: "apps", Kind: "Deployment", Namespace: "default", Name: "created"}},
+	}, changes)
+
+	assert.Empty(t, diffRenderedObjects(before, before))
+}
+
+func TestPrintRangeCommitChanges(t *testing.T) {
+	var out bytes.Buffer
+	printRangeCommitChanges(&out, rangeCommit{sha: "abc", subject: "bump image"}, []resourceChange{
+		{action: resourceChangeUpdate, key: kube.ResourceKey{Group: "apps", Kind: "Deployment", Namespace: "default", Name: "guestbook"}},
+	})
+	assert.Equal(t, `
+===== abc bump image ======
+ACTION  GROUP  KIND        NAMESPACE  NAME
+update  apps   Deployment  default    guestbook
+`, out.String())
+
+	out.Reset()
+	printRangeCommitChanges(&out, rangeCommit{sha: "def", subject: "docs"}, nil)
+	assert.Equal(t, "\n===== def docs ======\nNo resource changes\n", out.String())
+}
+
+func TestCheckRangeRepoURL(t *testing.T) {
+	dir := t.TempDir()
+	for _, args := range [][]string{
+		{"init", "--quiet"},
+		{"remote", "add", "origin", "git@github.com:argoproj/argocd-example-apps.git"},
+		{"remote", "add", "fork", "https://github.com/someone/argocd-example-apps.git"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		require.NoError(t, cmd.Run())
+	}
+
+	require.NoError(t, checkRangeRepoURL(t.Context(), dir, "https://github.com/argoproj/argocd-example-apps"))
+	require.NoError(t, checkRangeRepoURL(t.Context(), dir, "https://github.com/someone/argocd-example-apps.git"))
+	require.EqualError(t, checkRangeRepoURL(t.Context(), dir, "https://github.com/argoproj/argo-cd.git"),
+		"the git checkout in the current directory has no remote pointing to https://github.com/argoproj/argo-cd.git")
+
+	noRemotes := t.TempDir()
+	cmd := exec.Command("git", "init", "--quiet")
+	cmd.Dir = noRemotes
+	require.NoError(t, cmd.Run())
+	require.Error(t, checkRangeRepoURL(t.Context(), noRemotes, "https://github.com/argoproj/argo-cd.git"))
+}
@@ -0,0 +1,150 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/argoproj/gitops-engine/pkg/utils/kube"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/argoproj/argo-cd/v3/cmd/argocd/commands/headless"
+	argocdclient "github.com/argoproj/argo-cd/v3/pkg/apiclient"
+	"github.com/argoproj/argo-cd/v3/pkg/apiclient/application"
+	argoappv1 "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	"github.com/argoproj/argo-cd/v3/util/argo"
+	"github.com/argoproj/argo-cd/v3/util/cli"
+	"github.com/argoproj/argo-cd/v3/util/errors"
+	"github.com/argoproj/argo-cd/v3/util/git"
+	utilio "github.com/argoproj/argo-cd/v3/util/io"
+	"github.com/argoproj/argo-cd/v3/util/templates"
+)
+
+// NewApplicationHistoryDiffCommand returns a new instance of an `argocd app history diff` command
+func NewApplicationHistoryDiffCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
+	var (
+		appNamespace string
+		exitCode     bool
+	)
+	command := &cobra.Command{
+		Use:   "diff APPNAME ID1 ID2",
+		Short: "Show the resource differences between two deployments of the application history",
+		Long:  "Show the resource differences between two deployments of the application history. Both deployments are rendered by the repo server at the revisions recorded in the history. The sources recorded in the history must still be sources of the application.",
+		Example: templates.Examples(`
+  # Show the resources changed between the deployments with history ID 3 and 5
+  argocd app history diff my-app 3 5`),
+		Run: func(c *cobra.Command, args []string) {
+			ctx := c.Context()
+
+			if len(args) != 3 {
+				c.HelpFunc()(c, args)
+				os.Exit(1)
+			}
+			fromID, err := strconv.ParseInt(args[1], 10, 64)
+			errors.CheckError(err)
+			toID, err := strconv.ParseInt(args[2], 10, 64)
+			errors.CheckError(err)
+
+			conn, appIf := headless.NewClientOrDie(clientOpts, c).NewApplicationClientOrDie()
+			defer utilio.Close(conn)
+			appName, appNs := argo.ParseFromQualifiedName(args[0], appNamespace)
+			app, err := appIf.Get(ctx, &application.ApplicationQuery{
+				Name:         &appName,
+				AppNamespace: &appNs,
+			})
+			errors.CheckError(err)
+
+			from, err := findRevisionHistory(app, fromID)
+			errors.CheckError(err)
+			to, err := findRevisionHistory(app, toID)
+			errors.CheckError(err)
+
+			fromObjs, err := renderRevisionHistory(ctx, appIf, app, from)
+			errors.CheckError(err)
+			toObjs, err := renderRevisionHistory(ctx, appIf, app, to)
+			errors.CheckError(err)
+
+			foundDiffs := printHistoryDiff(fromObjs, toObjs)
+			if foundDiffs && exitCode {
+				os.Exit(1)
+			}
+		},
+	}
+	command.Flags().StringVarP(&appNamespace, "app-namespace", "N", "", "Only compare application deployment history in namespace")
+	command.Flags().BoolVar(&exitCode, "exit-code", true, "Return non-zero exit code when there is a diff")
+	return command
+}
+
+// historyManifestQuery returns the query rendering an application at the revisions recorded in a history entry.
+// The GetManifests API renders the current sources of the application, so every source recorded in the entry is paired
+// with the current source with the same repository, path and chart, and the entry is rejected if the sources changed.
+func historyManifestQuery(app *argoappv1.Application, history *argoappv1.RevisionHistory) (*application.ApplicationManifestQuery, error) {
+	appName := app.Name
+	appNs := app.Namespace
+	q := &application.ApplicationManifestQuery{
+		Name:         &appName,
+		AppNamespace: &appNs,
+	}
+	if !app.Spec.HasMultipleSources() {
+		if len(history.Sources) > 0 {
+			return nil, fmt.Errorf("deployment id '%d' was deployed from multiple sources, but application '%s' has a single source", history.ID, app.Name)
+		}
+		if !sameHistorySource(history.Source, app.Spec.GetSource()) {
+			return nil, fmt.Errorf("deployment id '%d' was deployed from a different source than the current source of application '%s'", history.ID, app.Name)
+		}
+		q.Revision = &history.Revision
+		return q, nil
+	}
+	if len(history.Sources) != len(app.Spec.Sources) || len(history.Revisions) != len(history.Sources) {
+		return nil, fmt.Errorf("deployment id '%d' has %d sources, but application '%s' has %d sources", history.ID, len(history.Sources), app.Name, len(app.Spec.Sources))
+	}
+	paired := make([]bool, len(app.Spec.Sources))
+	for i, historySource := range history.Sources {
+		position := -1
+		for j, source := range app.Spec.Sources {
+			if !paired[j] && sameHistorySource(historySource, source) {
+				position = j
+				break
+			}
+		}
+		if position < 0 {
+			return nil, fmt.Errorf("source '%s' of deployment id '%d' is not a source of application '%s' anymore", historySource.RepoURL, history.ID, app.Name)
+		}
+		paired[position] = true
+		q.Revisions = append(q.Revisions, history.Revisions[i])
+		q.SourcePositions = append(q.SourcePositions, int64(position+1))
+	}
+	return q, nil
+}
+
+// sameHistorySource returns whether a source recorded in the history renders the same manifests as a current source
+// of the application when given the same revision
+func sameHistorySource(historySource, source argoappv1.ApplicationSource) bool {
+	return git.SameURL(historySource.RepoURL, source.RepoURL) && historySource.Path == source.Path && historySource.Chart == source.Chart
+}
+
+// renderRevisionHistory renders the manifests of an application at the revisions of a history entry
+func renderRevisionHistory(ctx context.Context, appIf application.ApplicationServiceClient, app *argoappv1.Application, history *argoappv1.RevisionHistory) (map[kube.ResourceKey]*unstructured.Unstructured, error) {
+	q, err := historyManifestQuery(app, history)
+	if err != nil {
+		return nil, err
+	}
+	res, err := appIf.GetManifests(ctx, q)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render manifests of deployment id '%d': %w", history.ID, err)
+	}
+	return renderedObjectsByKey(res.Manifests)
+}
+
+// printHistoryDiff prints the diff of every resource created, updated or deleted between two renders of an
+// application. Returns true if any resource differs.
+func printHistoryDiff(from, to map[kube.ResourceKey]*unstructured.Unstructured) bool {
+	changes := diffRenderedObjects(from, to)
+	for _, change := range changes {
+		fmt.Printf("\n===== %s/%s %s/%s ======\n", change.key.Group, change.key.Kind, change.key.Namespace, change.key.Name)
+		_ = cli.PrintDiff(change.key.Name, from[change.key], to[change.key])
+	}
+	return len(changes) > 0
+}
@@ -0,0 +1,63 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	argoappv1 "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+)
+
+func TestHistoryManifestQuery_SingleSource(t *testing.T) {
+	app := &argoappv1.Application{}
+	app.Name = "my-app"
+	app.Namespace = "argocd"
+	app.Spec.Source = &argoappv1.ApplicationSource{RepoURL: "https://github.com/argoproj/argocd-example-apps", Path: "guestbook"}
+
+	q, err := historyManifestQuery(app, &argoappv1.RevisionHistory{ID: 1, Revision: "abc", Source: *app.Spec.Source})
+	require.NoError(t, err)
+	assert.Equal(t, "my-app", q.GetName())
+	assert.Equal(t, "argocd", q.GetAppNamespace())
+	assert.Equal(t, "abc", q.GetRevision())
+	assert.Empty(t, q.Revisions)
+
+	_, err = historyManifestQuery(app, &argoappv1.RevisionHistory{ID: 2, Revisions: []string{"abc", "def"}, Sources: argoappv1.ApplicationSources{{}, {}}})
+	assert.ErrorContains(t, err, "multiple sources")
+
+	_, err = historyManifestQuery(app, &argoappv1.RevisionHistory{ID: 3, Revision: "abc", Source: argoappv1.ApplicationSource{RepoURL: app.Spec.Source.RepoURL, Path: "helm-guestbook"}})
+	assert.ErrorContains(t, err, "different source")
+}
+
+func TestHistoryManifestQuery_MultipleSources(t *testing.T) {
+	app := &argoappv1.Application{}
+	app.Name = "my-app"
+	app.Spec.Sources = argoappv1.ApplicationSources{{RepoURL: "https://a"}, {RepoURL: "https://b"}}
+
+	q, err := historyManifestQuery(app, &argoappv1.RevisionHistory{ID: 1, Revisions: []string{"abc", "def"}, Sources: app.Spec.Sources})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"abc", "def"}, q.Revisions)
+	assert.Equal(t, []int64{1, 2}, q.SourcePositions)
+
+	// the revisions follow the sources recorded in the history when the sources were reordered since
+	q, err = historyManifestQuery(app, &argoappv1.RevisionHistory{ID: 2, Revisions: []string{"def", "abc"}, Sources: argoappv1.ApplicationSources{{RepoURL: "https://b"}, {RepoURL: "https://a"}}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"def", "abc"}, q.Revisions)
+	assert.Equal(t, []int64{2, 1}, q.SourcePositions)
+
+	_, err = historyManifestQuery(app, &argoappv1.RevisionHistory{ID: 3, Revisions: []string{"abc", "def"}, Sources: argoappv1.ApplicationSources{{RepoURL: "https://a"}, {RepoURL: "https://c"}}})
+	require.EqualError(t, err, "source 'https://c' of deployment id '3' is not a source of application 'my-app' anymore")
+
+	_, err = historyManifestQuery(app, &argoappv1.RevisionHistory{ID: 4, Revision: "abc"})
+	assert.ErrorContains(t, err, "has 0 sources")
+}
+
+func TestHistoryManifestQuery_DuplicateSources(t *testing.T) {
+	app := &argoappv1.Application{}
+	app.Name = "my-app"
+	app.Spec.Sources = argoappv1.ApplicationSources{{RepoURL: "https://a"}, {RepoURL: "https://a"}}
+
+	q, err := historyManifestQuery(app, &argoappv1.RevisionHistory{ID: 1, Revisions: []string{"abc", "def"}, Sources: app.Spec.Sources})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1, 2}, q.SourcePositions)
+}
@@ -4,10 +4,15 @@ import (
 	"bytes"
 	"testing"
 	"text/tabwriter"
+	"time"
 
+	"github.com/argoproj/gitops-engine/pkg/health"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 
+	applicationpkg "github.com/argoproj/argo-cd/v3/pkg/apiclient/application"
 	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
 )
 
@@ -117,3 +122,74 @@ func TestPrintResourcesTree(t *testing.T) {
 
 	assert.Equal(t, expectation, output)
 }
+
+func TestParseResourceTreeDiffTime(t *testing.T) {
+	now := time.Date(2025, 1, 2, 10, 0, 0, 0, time.UTC)
+	value, err := parseResourceTreeDiffTime("24h", now)
+	require.NoError(t, err)
+	assert.Equal(t, "2025-01-01T10:00:00Z", value)
+
+	value, err = parseResourceTreeDiffTime("2025-01-01T12:00:00+02:00", now)
+	require.NoError(t, err)
+	assert.Equal(t, "2025-01-01T12:00:00+02:00", value)
+
+	value, err = parseResourceTreeDiffTime("", now)
+	require.NoError(t, err)
+	assert.Empty(t, value)
+
+	_, err = parseResourceTreeDiffTime("yesterday", now)
+	require.ErrorContains(t, err, `invalid time "yesterday"`)
+}
+
+func TestPrintResourceTreeDiff(t *testing.T) {
+	from := metav1.NewTime(time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC))
+	to := metav1.NewTime(time.Date(2025, 1, 2, 10, 0, 0, 0, time.UTC))
+	healthy := &v1alpha1.HealthStatus{Status: health.HealthStatusHealthy}
+	degraded := &v1alpha1.HealthStatus{Status: health.HealthStatusDegraded}
+	buf := &bytes.Buffer{}
+	printResourceTreeDiff(buf, &v1alpha1.ResourceTreeDiff{
+		From:          from,
+		To:            to,
+		Appeared:      []v1alpha1.ResourceNode{{ResourceRef: v1alpha1.ResourceRef{Group: "apps", Kind: "ReplicaSet", Namespace: "ns", Name: "rs-2"}, Health: healthy}},
+		Disappeared:   []v1alpha1.ResourceNode{{ResourceRef: v1alpha1.ResourceRef{Group: "apps", Kind: "ReplicaSet", Namespace: "ns", Name: "rs-1"}, Health: healthy}},
+		HealthChanged: []v1alpha1.ResourceNodeHealthChange{{ResourceRef: v1alpha1.ResourceRef{Group: "apps", Kind: "Deployment", Namespace: "ns", Name: "deploy"}, Health: degraded, PreviousHealth: healthy}},
+	})
+	assert.Equal(t, `Changes from 2025-01-01T10:00:00Z to 2025-01-02T10:00:00Z
+
+CHANGE         GROUP  KIND        NAMESPACE  NAME    HEALTH
+Appeared       apps   ReplicaSet  ns         rs-2    Healthy
+Disappeared    apps   ReplicaSet  ns         rs-1    Healthy
+HealthChanged  apps   Deployment  ns         deploy  Healthy -> Degraded
+`, buf.String())
+}
+
+func TestFilterOrphanedResources(t *testing.T) {
+	resources := []*applicationpkg.OrphanedResource{
+		{Kind: ptr.To("ConfigMap"), Name: ptr.To("cm")},
+		{Group: ptr.To("apps"), Kind: ptr.To("Deployment"), Name: ptr.To("deploy")},
+	}
+	assert.Len(t, filterOrphanedResources(resources, false, "", "", ""), 2)
+	assert.Equal(t, resources[:1], filterOrphanedResources(resources, true, "", "", ""))
+	assert.Equal(t, resources[1:], filterOrphanedResources(resources, false, "", "Deployment", ""))
+	assert.Empty(t, filterOrphanedResources(resources, false, "", "Deployment", "cm"))
+}
+
+func TestPrintOrphanedResources(t *testing.T) {
+	resources := []*applicationpkg.OrphanedResource{
+		{Version: ptr.To("v1"), Kind: ptr.To("ConfigMap"), Namespace: ptr.To("ns"), Name: ptr.To("cm"), Origin: ptr.To("Helm"), OriginName: ptr.To("my-release"), Managers: []string{"helm"}},
+		{Group: ptr.To("apps"), Version: ptr.To("v1"), Kind: ptr.To("Deployment"), Namespace: ptr.To("ns"), Name: ptr.To("deploy"), Origin: ptr.To("kubectl"), Managers: []string{"kubectl-client-side-apply", "kube-controller-manager"}},
+	}
+	buf := &bytes.Buffer{}
+	printOrphanedResources(buf, resources, false)
+	assert.Equal(t, `GROUP  KIND        NAMESPACE  NAME    ORIGIN   ORIGIN NAME
+       ConfigMap   ns         cm      Helm     my-release
+apps   Deployment  ns         deploy  kubectl  
+`, buf.String())
+
+	buf.Reset()
+	printOrphanedResources(buf, resources, true)
+	assert.Equal(t, `GROUP  KIND        NAMESPACE  NAME    ORIGIN   ORIGIN NAME  MANAGERS
+       ConfigMap   ns         cm      Helm     my-release   helm
+apps   Deployment  ns         deploy  kubectl               kubectl-client-side-apply,kube-controller-manager
+`, buf.String())
+}
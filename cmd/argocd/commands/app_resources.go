@@ -2,8 +2,11 @@ package commands
 
 import (
 	"fmt"
+	"io"
 	"os"
+	"strings"
 	"text/tabwriter"
+	"time"
 
 	"github.com/argoproj/argo-cd/v3/cmd/argocd/commands/utils"
 	"github.com/argoproj/argo-cd/v3/cmd/util"
@@ -20,6 +23,7 @@ import (
 	"github.com/argoproj/argo-cd/v3/util/argo"
 	"github.com/argoproj/argo-cd/v3/util/errors"
 	utilio "github.com/argoproj/argo-cd/v3/util/io"
+	"github.com/argoproj/argo-cd/v3/util/templates"
 )
 
 func NewApplicationPatchResourceCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
@@ -32,8 +36,9 @@ func NewApplicationPatchResourceCommand(clientOpts *argocdclient.ClientOptions)
 	var all bool
 	var project string
 	command := &cobra.Command{
-		Use:   "patch-resource APPNAME",
-		Short: "Patch resource in an application",
+		Use:               "patch-resource APPNAME",
+		Short:             "Patch resource in an application",
+		ValidArgsFunction: completeApplicationNames(clientOpts, false),
 	}
 
 	command.Flags().StringVar(&patch, "patch", "", "Patch")
@@ -256,19 +261,62 @@ func printResources(listAll bool, orphaned bool, appResourceTree *v1alpha1.Appli
 	_ = w.Flush()
 }
 
+// printResourceTree prints the full resource tree of an application including the children of every resource. The wide
+// output additionally includes the sync wave, hook type and age of every resource.
+func printResourceTree(out io.Writer, listAll bool, orphaned bool, appResourceTree *v1alpha1.ApplicationTree, app *v1alpha1.Application, wide bool) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	syncInfos := resourceSyncInfoByKey(app)
+	if wide {
+		_, _ = fmt.Fprintf(w, "NAME\tNAMESPACE\tORPHANED\tHEALTH\tSYNC WAVE\tHOOK\tAGE\tMESSAGE\n")
+	} else {
+		_, _ = fmt.Fprintf(w, "NAME\tNAMESPACE\tORPHANED\tHEALTH\n")
+	}
+	if !orphaned || listAll {
+		mapUIDToNode, mapParentToChild, _ := parentChildInfo(appResourceTree.Nodes)
+		for _, root := range resourceTreeRoots(mapUIDToNode) {
+			fullTreeViewAppResources("", mapUIDToNode, mapParentToChild, root, "No", syncInfos, wide, w)
+		}
+	}
+	if orphaned || listAll {
+		mapUIDToNode, mapParentToChild, _ := parentChildInfo(appResourceTree.OrphanedNodes)
+		for _, root := range resourceTreeRoots(mapUIDToNode) {
+			fullTreeViewAppResources("", mapUIDToNode, mapParentToChild, root, "Yes", syncInfos, wide, w)
+		}
+	}
+	_ = w.Flush()
+}
+
 func NewApplicationListResourcesCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
 	var orphaned bool
 	var output string
 	var project string
+	var tree bool
 	command := &cobra.Command{
 		Use:   "resources APPNAME",
 		Short: "List resource of application",
+		Example: templates.Examples(`
+  # List the top level resources of an application
+  argocd app resources my-app
+
+  # Print the full resource tree of an application
+  argocd app resources my-app --tree
+
+  # Print the full resource tree including the health, sync wave, hook type and age of every resource
+  argocd app resources my-app --tree -o wide
+`),
+		ValidArgsFunction: completeApplicationNames(clientOpts, false),
 		Run: func(c *cobra.Command, args []string) {
 			ctx := c.Context()
 			if len(args) != 1 {
 				c.HelpFunc()(c, args)
 				os.Exit(1)
 			}
+			if tree && output != "" && output != "wide" {
+				errors.Fatal(errors.ErrorGeneric, "--tree only supports the wide output format")
+			}
+			if !tree && output == "wide" {
+				errors.Fatal(errors.ErrorGeneric, "the wide output format requires --tree")
+			}
 			listAll := !c.Flag("orphaned").Changed
 			appName, appNs := argo.ParseFromQualifiedName(args[0], "")
 			conn, appIf := headless.NewClientOrDie(clientOpts, c).NewApplicationClientOrDie()
@@ -279,11 +327,251 @@ func NewApplicationListResourcesCommand(clientOpts *argocdclient.ClientOptions)
 				Project:         &project,
 			})
 			errors.CheckError(err)
-			printResources(listAll, orphaned, appResourceTree, output)
+			if !tree {
+				printResources(listAll, orphaned, appResourceTree, output)
+				return
+			}
+			var app *v1alpha1.Application
+			if output == "wide" {
+				query := &applicationpkg.ApplicationQuery{Name: &appName, AppNamespace: &appNs}
+				if project != "" {
+					query.Project = []string{project}
+				}
+				app, err = appIf.Get(ctx, query)
+				errors.CheckError(err)
+			}
+			printResourceTree(os.Stdout, listAll, orphaned, appResourceTree, app, output == "wide")
 		},
 	}
 	command.Flags().BoolVar(&orphaned, "orphaned", false, "Lists only orphaned resources")
-	command.Flags().StringVar(&output, "output", "", "Provides the tree view of the resources")
+	command.Flags().StringVarP(&output, "output", "o", "", "Provides the tree view of the resources. One of: tree|tree=detailed, or wide together with --tree")
+	command.Flags().BoolVar(&tree, "tree", false, "Print the full resource tree of the application including the children of every resource")
+	command.Flags().StringVar(&project, "project", "", `The name of the application's project - specifying this allows the command to report "not found" instead of "permission denied" if the app does not exist`)
+	return command
+}
+
+func NewApplicationResourceTreeDiffCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
+	var from string
+	var to string
+	var output string
+	var project string
+	command := &cobra.Command{
+		Use:   "resources-diff APPNAME",
+		Short: "Print the resources which appeared, disappeared or changed health between two snapshots of the resource tree of an application",
+		Long: "Print the resources which appeared, disappeared or changed health between two snapshots of the resource tree of an application. " +
+			"The snapshots are taken by the application controller when its --resource-tree-snapshot-interval flag is set.",
+		Example: templates.Examples(`
+  # Print what changed in the resource tree of an application since yesterday
+  argocd app resources-diff my-app --from 24h
+
+  # Print what changed between two snapshots
+  argocd app resources-diff my-app --from 2025-01-01T10:00:00Z --to 2025-01-01T12:00:00Z
+`),
+		ValidArgsFunction: completeApplicationNames(clientOpts, false),
+		Run: func(c *cobra.Command, args []string) {
+			ctx := c.Context()
+			if len(args) != 1 {
+				c.HelpFunc()(c, args)
+				os.Exit(1)
+			}
+			now := time.Now()
+			fromTime, err := parseResourceTreeDiffTime(from, now)
+			errors.CheckError(err)
+			toTime, err := parseResourceTreeDiffTime(to, now)
+			errors.CheckError(err)
+			appName, appNs := argo.ParseFromQualifiedName(args[0], "")
+			conn, appIf := headless.NewClientOrDie(clientOpts, c).NewApplicationClientOrDie()
+			defer utilio.Close(conn)
+			diff, err := appIf.ResourceTreeDiff(ctx, &applicationpkg.ResourceTreeDiffQuery{
+				Name:         &appName,
+				AppNamespace: &appNs,
+				Project:      &project,
+				From:         &fromTime,
+				To:           &toTime,
+			})
+			errors.CheckError(err)
+			switch output {
+			case "json", "yaml":
+				errors.CheckError(PrintResource(diff, output))
+			case "":
+				printResourceTreeDiff(os.Stdout, diff)
+			default:
+				errors.Fatal(errors.ErrorGeneric, fmt.Sprintf("unknown output format: %s", output))
+			}
+		},
+	}
+	command.Flags().StringVar(&from, "from", "", "Start of the changes, as a time in RFC3339 format or a duration before now, such as 24h. Defaults to the oldest snapshot")
+	command.Flags().StringVar(&to, "to", "", "End of the changes, as a time in RFC3339 format or a duration before now. Defaults to the current resource tree")
+	command.Flags().StringVarP(&output, "output", "o", "", "Output format. One of: json|yaml")
+	command.Flags().StringVar(&project, "project", "", `The name of the application's project - specifying this allows the command to report "not found" instead of "permission denied" if the app does not exist`)
+	return command
+}
+
+// parseResourceTreeDiffTime converts a time in RFC3339 format or a duration before now to the RFC3339 format
+func parseResourceTreeDiffTime(value string, now time.Time) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+	if duration, err := time.ParseDuration(value); err == nil {
+		return now.Add(-duration).UTC().Format(time.RFC3339), nil
+	}
+	if _, err := time.Parse(time.RFC3339, value); err != nil {
+		return "", fmt.Errorf("invalid time %q, expected a time in RFC3339 format or a duration", value)
+	}
+	return value, nil
+}
+
+func printResourceTreeDiff(w io.Writer, diff *v1alpha1.ResourceTreeDiff) {
+	_, _ = fmt.Fprintf(w, "Changes from %s to %s\n\n", diff.From.UTC().Format(time.RFC3339), diff.To.UTC().Format(time.RFC3339))
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintf(tw, "CHANGE\tGROUP\tKIND\tNAMESPACE\tNAME\tHEALTH\n")
+	healthStatus := func(health *v1alpha1.HealthStatus) string {
+		if health == nil {
+			return ""
+		}
+		return string(health.Status)
+	}
+	for _, node := range diff.Appeared {
+		_, _ = fmt.Fprintf(tw, "Appeared\t%s\t%s\t%s\t%s\t%s\n", node.Group, node.Kind, node.Namespace, node.Name, healthStatus(node.Health))
+	}
+	for _, node := range diff.Disappeared {
+		_, _ = fmt.Fprintf(tw, "Disappeared\t%s\t%s\t%s\t%s\t%s\n", node.Group, node.Kind, node.Namespace, node.Name, healthStatus(node.Health))
+	}
+	for _, change := range diff.HealthChanged {
+		_, _ = fmt.Fprintf(tw, "HealthChanged\t%s\t%s\t%s\t%s\t%s -> %s\n", change.Group, change.Kind, change.Namespace, change.Name, healthStatus(change.PreviousHealth), healthStatus(change.Health))
+	}
+	_ = tw.Flush()
+}
+
+func NewApplicationOrphanedResourcesCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
+	var adopt bool
+	var deleteResources bool
+	var resourceName string
+	var kind string
+	var group string
+	var output string
+	var project string
+	command := &cobra.Command{
+		Use:   "orphaned-resources APPNAME",
+		Short: "List the orphaned resources in the destination namespace of an application, and adopt or delete them",
+		Long: "List the orphaned resources in the destination namespace of an application along with the best guess of their origin, " +
+			"inferred from their Helm release metadata, last applied configuration and field managers, and adopt or delete them. " +
+			"Adopting a resource sets the tracking metadata of the application on it. Requires the orphaned resources monitoring of the project of the application.",
+		Example: templates.Examples(`
+  # List the orphaned resources of an application
+  argocd app orphaned-resources my-app
+
+  # Adopt all the orphaned ConfigMaps of an application
+  argocd app orphaned-resources my-app --kind ConfigMap --adopt
+
+  # Delete an orphaned Deployment of an application
+  argocd app orphaned-resources my-app --group apps --kind Deployment --resource-name my-deployment --delete
+`),
+		ValidArgsFunction: completeApplicationNames(clientOpts, false),
+		Run: func(c *cobra.Command, args []string) {
+			ctx := c.Context()
+			if len(args) != 1 {
+				c.HelpFunc()(c, args)
+				os.Exit(1)
+			}
+			if adopt && deleteResources {
+				errors.Fatal(errors.ErrorGeneric, "--adopt and --delete are mutually exclusive")
+			}
+			appName, appNs := argo.ParseFromQualifiedName(args[0], "")
+			conn, appIf := headless.NewClientOrDie(clientOpts, c).NewApplicationClientOrDie()
+			defer utilio.Close(conn)
+			orphaned, err := appIf.ListOrphanedResources(ctx, &applicationpkg.OrphanedResourcesQuery{
+				Name:         &appName,
+				AppNamespace: &appNs,
+				Project:      &project,
+			})
+			errors.CheckError(err)
+			resources := filterOrphanedResources(orphaned.Items, c.Flags().Changed("group"), group, kind, resourceName)
+
+			if !adopt && !deleteResources {
+				switch output {
+				case "json", "yaml":
+					errors.CheckError(PrintResourceList(resources, output, false))
+				case "", "wide":
+					printOrphanedResources(os.Stdout, resources, output == "wide")
+				default:
+					errors.Fatal(errors.ErrorGeneric, fmt.Sprintf("unknown output format: %s", output))
+				}
+				return
+			}
+
+			if len(resources) == 0 {
+				fmt.Println("No matching orphaned resources")
+				return
+			}
+			action := applicationpkg.OrphanedResourcesActionRequest{
+				Name:         &appName,
+				AppNamespace: &appNs,
+				Project:      &project,
+				Resources:    resources,
+			}
+			verb, done := "adopt", "adopted"
+			if deleteResources {
+				verb, done = "delete", "deleted"
+			}
+			action.Action = ptr.To(verb)
+			promptUtil := utils.NewPrompt(clientOpts.PromptsEnabled)
+			if !promptUtil.Confirm(fmt.Sprintf("Are you sure you want to %s %d orphaned resources of application '%s'? [y/n]", verb, len(resources), appName)) {
+				fmt.Printf("The command to %s the orphaned resources was cancelled.\n", verb)
+				return
+			}
+			res, err := appIf.RunOrphanedResourcesAction(ctx, &action)
+			errors.CheckError(err)
+			failed := false
+			for _, result := range res.Results {
+				r := result.Resource
+				if result.GetError() != "" {
+					failed = true
+					log.Errorf("Failed to %s %s/%s %s/%s: %s", verb, r.GetGroup(), r.GetKind(), r.GetNamespace(), r.GetName(), result.GetError())
+					continue
+				}
+				log.Infof("Resource %s/%s %s/%s %s", r.GetGroup(), r.GetKind(), r.GetNamespace(), r.GetName(), done)
+			}
+			if failed {
+				os.Exit(1)
+			}
+		},
+	}
+	command.Flags().BoolVar(&adopt, "adopt", false, "Adopt the matching orphaned resources")
+	command.Flags().BoolVar(&deleteResources, "delete", false, "Delete the matching orphaned resources")
+	command.Flags().StringVar(&resourceName, "resource-name", "", "Name of the resources")
+	command.Flags().StringVar(&kind, "kind", "", "Kind of the resources")
+	command.Flags().StringVar(&group, "group", "", "Group of the resources")
+	command.Flags().StringVarP(&output, "output", "o", "", "Output format. One of: json|yaml|wide")
 	command.Flags().StringVar(&project, "project", "", `The name of the application's project - specifying this allows the command to report "not found" instead of "permission denied" if the app does not exist`)
 	return command
 }
+
+// filterOrphanedResources returns the orphaned resources matching the group, if filtered by group, kind and name
+func filterOrphanedResources(resources []*applicationpkg.OrphanedResource, filterByGroup bool, group, kind, name string) []*applicationpkg.OrphanedResource {
+	var filtered []*applicationpkg.OrphanedResource
+	for _, r := range resources {
+		if (filterByGroup && r.GetGroup() != group) || (kind != "" && r.GetKind() != kind) || (name != "" && r.GetName() != name) {
+			continue
+		}
+		filtered = append(filtered, r)
+	}
+	return filtered
+}
+
+func printOrphanedResources(w io.Writer, resources []*applicationpkg.OrphanedResource, wide bool) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	if wide {
+		_, _ = fmt.Fprintf(tw, "GROUP\tKIND\tNAMESPACE\tNAME\tORIGIN\tORIGIN NAME\tMANAGERS\n")
+	} else {
+		_, _ = fmt.Fprintf(tw, "GROUP\tKIND\tNAMESPACE\tNAME\tORIGIN\tORIGIN NAME\n")
+	}
+	for _, r := range resources {
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s", r.GetGroup(), r.GetKind(), r.GetNamespace(), r.GetName(), r.GetOrigin(), r.GetOriginName())
+		if wide {
+			_, _ = fmt.Fprintf(tw, "\t%s", strings.Join(r.Managers, ","))
+		}
+		_, _ = fmt.Fprintln(tw)
+	}
+	_ = tw.Flush()
+}
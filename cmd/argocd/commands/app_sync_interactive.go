@@ -0,0 +1,166 @@
+package commands
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/argoproj/gitops-engine/pkg/health"
+
+	argoappv1 "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	"github.com/argoproj/argo-cd/v3/util/argo"
+)
+
+const (
+	syncPlanActionCreate = "create"
+	syncPlanActionUpdate = "update"
+	syncPlanActionPrune  = "prune"
+)
+
+// syncPlanItem is a resource which is going to be changed by a sync operation
+type syncPlanItem struct {
+	resource argoappv1.ResourceStatus
+	action   string
+	selected bool
+}
+
+// getSyncPlan returns the out of sync resources of an application, ordered by sync wave. Resources which require
+// pruning are only selected by default if pruning is enabled. Hooks are not part of the plan since they are always
+// executed as part of a full sync.
+func getSyncPlan(app *argoappv1.Application, selectedResources []*argoappv1.SyncOperationResource, prune bool) []*syncPlanItem {
+	var plan []*syncPlanItem
+	for _, res := range app.Status.Resources {
+		if res.Hook || res.Status != argoappv1.SyncStatusCodeOutOfSync {
+			continue
+		}
+		if len(selectedResources) > 0 && !argo.IncludeResource(res.Name, res.Namespace, res.GroupVersionKind(), selectedResources) {
+			continue
+		}
+		item := &syncPlanItem{resource: res, action: syncPlanActionUpdate, selected: true}
+		switch {
+		case res.RequiresPruning:
+			item.action = syncPlanActionPrune
+			item.selected = prune
+		case res.Health != nil && res.Health.Status == health.HealthStatusMissing:
+			item.action = syncPlanActionCreate
+		}
+		plan = append(plan, item)
+	}
+	sort.SliceStable(plan, func(i, j int) bool {
+		return plan[i].resource.SyncWave < plan[j].resource.SyncWave
+	})
+	return plan
+}
+
+// printSyncPlan prints the sync plan grouped by sync wave, numbering every resource starting at 1
+func printSyncPlan(out io.Writer, plan []*syncPlanItem) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	for i, item := range plan {
+		if i == 0 || plan[i-1].resource.SyncWave != item.resource.SyncWave {
+			_, _ = fmt.Fprintf(w, "WAVE %d\n", item.resource.SyncWave)
+		}
+		mark := " "
+		if item.selected {
+			mark = "x"
+		}
+		res := item.resource
+		_, _ = fmt.Fprintf(w, "  [%s] %d\t%s\t%s\t%s\t%s\t%s\n", mark, i+1, item.action, res.Group, res.Kind, res.Namespace, res.Name)
+	}
+	_ = w.Flush()
+}
+
+// parseSyncPlanSelection parses a comma separated list of resource numbers and ranges (e.g. "1,3-5")
+func parseSyncPlanSelection(selection string, size int) ([]int, error) {
+	var indexes []int
+	for _, part := range strings.Split(selection, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		from, to, isRange := strings.Cut(part, "-")
+		start, err := strconv.Atoi(strings.TrimSpace(from))
+		if err != nil {
+			return nil, fmt.Errorf("invalid resource number '%s'", part)
+		}
+		end := start
+		if isRange {
+			end, err = strconv.Atoi(strings.TrimSpace(to))
+			if err != nil {
+				return nil, fmt.Errorf("invalid resource range '%s'", part)
+			}
+		}
+		if start < 1 || end > size || start > end {
+			return nil, fmt.Errorf("resource numbers must be between 1 and %d: '%s'", size, part)
+		}
+		for i := start; i <= end; i++ {
+			indexes = append(indexes, i-1)
+		}
+	}
+	return indexes, nil
+}
+
+// reviewSyncPlan lets the user include or exclude resources of the sync plan until the plan is either confirmed or
+// aborted. Returns true if the user confirmed the plan.
+func reviewSyncPlan(reader *bufio.Reader, out io.Writer, plan []*syncPlanItem) (bool, error) {
+	for {
+		_, _ = fmt.Fprintln(out)
+		printSyncPlan(out, plan)
+		_, _ = fmt.Fprint(out, "\nToggle resources by number (e.g. 1,3-5), 'a' to select all, 'n' to select none, 'y' to sync the selected resources or 'q' to abort: ")
+		input, err := reader.ReadString('\n')
+		if err != nil && (err != io.EOF || input == "") {
+			return false, fmt.Errorf("failed to read selection: %w", err)
+		}
+		switch answer := strings.ToLower(strings.TrimSpace(input)); answer {
+		case "y", "yes":
+			if !slices.ContainsFunc(plan, func(item *syncPlanItem) bool { return item.selected }) {
+				_, _ = fmt.Fprintln(out, "No resources selected")
+				continue
+			}
+			return true, nil
+		case "q", "quit":
+			return false, nil
+		case "a", "all", "n", "none":
+			for _, item := range plan {
+				item.selected = answer == "a" || answer == "all"
+			}
+		default:
+			indexes, err := parseSyncPlanSelection(answer, len(plan))
+			if err != nil {
+				_, _ = fmt.Fprintln(out, err.Error())
+				continue
+			}
+			for _, i := range indexes {
+				plan[i].selected = !plan[i].selected
+			}
+		}
+	}
+}
+
+// syncPlanResources returns the resources to sync according to the reviewed plan and whether pruning is required.
+// If every resource of the plan is selected, the originally selected resources are returned so that a full sync
+// including hooks is performed when no resources were selected.
+func syncPlanResources(plan []*syncPlanItem, selectedResources []*argoappv1.SyncOperationResource) ([]*argoappv1.SyncOperationResource, bool) {
+	allSelected := true
+	prune := false
+	var resources []*argoappv1.SyncOperationResource
+	for _, item := range plan {
+		if !item.selected {
+			allSelected = false
+			continue
+		}
+		if item.action == syncPlanActionPrune {
+			prune = true
+		}
+		res := item.resource
+		resources = append(resources, &argoappv1.SyncOperationResource{Group: res.Group, Kind: res.Kind, Name: res.Name, Namespace: res.Namespace})
+	}
+	if allSelected {
+		return selectedResources, prune
+	}
+	return resources, prune
+}
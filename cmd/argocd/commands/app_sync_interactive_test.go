@@ -0,0 +1,108 @@
+package commands
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/argoproj/gitops-engine/pkg/health"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+)
+
+func testSyncPlanApp() *v1alpha1.Application {
+	return &v1alpha1.Application{
+		Status: v1alpha1.ApplicationStatus{
+			Resources: []v1alpha1.ResourceStatus{
+				{Group: "apps", Kind: "Deployment", Namespace: "default", Name: "web", Status: v1alpha1.SyncStatusCodeOutOfSync, SyncWave: 1},
+				{Kind: "ConfigMap", Namespace: "default", Name: "config", Status: v1alpha1.SyncStatusCodeOutOfSync, Health: &v1alpha1.HealthStatus{Status: health.HealthStatusMissing}},
+				{Kind: "Service", Namespace: "default", Name: "web", Status: v1alpha1.SyncStatusCodeSynced},
+				{Kind: "Secret", Namespace: "default", Name: "old", Status: v1alpha1.SyncStatusCodeOutOfSync, RequiresPruning: true, SyncWave: 1},
+				{Group: "batch", Kind: "Job", Namespace: "default", Name: "migrate", Status: v1alpha1.SyncStatusCodeOutOfSync, Hook: true},
+			},
+		},
+	}
+}
+
+func TestGetSyncPlan(t *testing.T) {
+	plan := getSyncPlan(testSyncPlanApp(), nil, false)
+	require.Len(t, plan, 3)
+	assert.Equal(t, "config", plan[0].resource.Name)
+	assert.Equal(t, syncPlanActionCreate, plan[0].action)
+	assert.True(t, plan[0].selected)
+	assert.Equal(t, "web", plan[1].resource.Name)
+	assert.Equal(t, syncPlanActionUpdate, plan[1].action)
+	assert.Equal(t, "old", plan[2].resource.Name)
+	assert.Equal(t, syncPlanActionPrune, plan[2].action)
+	assert.False(t, plan[2].selected)
+
+	plan = getSyncPlan(testSyncPlanApp(), nil, true)
+	assert.True(t, plan[2].selected)
+
+	plan = getSyncPlan(testSyncPlanApp(), []*v1alpha1.SyncOperationResource{{Group: "apps", Kind: "Deployment", Name: "web"}}, false)
+	require.Len(t, plan, 1)
+	assert.Equal(t, "Deployment", plan[0].resource.Kind)
+}
+
+func TestParseSyncPlanSelection(t *testing.T) {
+	indexes, err := parseSyncPlanSelection("1, 3-4", 4)
+	require.NoError(t, err)
+	assert.Equal(t, []int{0, 2, 3}, indexes)
+
+	for _, invalid := range []string{"0", "5", "a", "3-1", "1-x"} {
+		_, err := parseSyncPlanSelection(invalid, 4)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestReviewSyncPlan(t *testing.T) {
+	plan := getSyncPlan(testSyncPlanApp(), nil, false)
+	var out bytes.Buffer
+	confirmed, err := reviewSyncPlan(bufio.NewReader(strings.NewReader("2\n3\ny\n")), &out, plan)
+	require.NoError(t, err)
+	assert.True(t, confirmed)
+	assert.Contains(t, out.String(), "WAVE 0")
+	assert.Contains(t, out.String(), "WAVE 1")
+
+	resources, prune := syncPlanResources(plan, nil)
+	assert.True(t, prune)
+	assert.Equal(t, []*v1alpha1.SyncOperationResource{
+		{Kind: "ConfigMap", Namespace: "default", Name: "config"},
+		{Kind: "Secret", Namespace: "default", Name: "old"},
+	}, resources)
+}
+
+func TestReviewSyncPlan_NoneSelected(t *testing.T) {
+	plan := getSyncPlan(testSyncPlanApp(), nil, false)
+	var out bytes.Buffer
+	confirmed, err := reviewSyncPlan(bufio.NewReader(strings.NewReader("n\ny\nq\n")), &out, plan)
+	require.NoError(t, err)
+	assert.False(t, confirmed)
+	assert.Contains(t, out.String(), "No resources selected")
+
+	_, err = reviewSyncPlan(bufio.NewReader(strings.NewReader("")), &out, plan)
+	assert.Error(t, err)
+}
+
+func TestReviewSyncPlan_SharedReader(t *testing.T) {
+	reader := bufio.NewReader(strings.NewReader("y\nq\n"))
+	var out bytes.Buffer
+	confirmed, err := reviewSyncPlan(reader, &out, getSyncPlan(testSyncPlanApp(), nil, false))
+	require.NoError(t, err)
+	assert.True(t, confirmed)
+
+	// the answer for the second application is not lost in the buffer of the first review
+	confirmed, err = reviewSyncPlan(reader, &out, getSyncPlan(testSyncPlanApp(), nil, false))
+	require.NoError(t, err)
+	assert.False(t, confirmed)
+}
+
+func TestSyncPlanResources_AllSelected(t *testing.T) {
+	plan := getSyncPlan(testSyncPlanApp(), nil, true)
+	resources, prune := syncPlanResources(plan, nil)
+	assert.Nil(t, resources)
+	assert.True(t, prune)
+}
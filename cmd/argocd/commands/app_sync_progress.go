@@ -0,0 +1,76 @@
+package commands
+
+import (
+	stderrors "errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/argoproj/argo-cd/v3/cmd/argocd/commands/headless"
+	argocdclient "github.com/argoproj/argo-cd/v3/pkg/apiclient"
+	"github.com/argoproj/argo-cd/v3/pkg/apiclient/application"
+	argoappv1 "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	"github.com/argoproj/argo-cd/v3/util/argo"
+	"github.com/argoproj/argo-cd/v3/util/errors"
+	utilio "github.com/argoproj/argo-cd/v3/util/io"
+	"github.com/argoproj/argo-cd/v3/util/templates"
+)
+
+// NewApplicationSyncProgressCommand returns a new instance of an `argocd app sync-progress` command
+func NewApplicationSyncProgressCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
+	var appNamespace string
+	command := &cobra.Command{
+		Use:   "sync-progress APPNAME",
+		Short: "Follow the progress of the resources of the sync operation of an application",
+		Example: templates.Examples(`
+  # Follow the progress of the sync operation of an application until it completes
+  argocd app sync-progress my-app
+		`),
+		Run: func(c *cobra.Command, args []string) {
+			ctx := c.Context()
+			if len(args) != 1 {
+				c.HelpFunc()(c, args)
+				os.Exit(1)
+			}
+			appName, appNs := argo.ParseFromQualifiedName(args[0], appNamespace)
+			conn, appIf := headless.NewClientOrDie(clientOpts, c).NewApplicationClientOrDie()
+			defer utilio.Close(conn)
+			stream, err := appIf.WatchSyncProgress(ctx, &application.ApplicationSyncProgressQuery{
+				Name:         &appName,
+				AppNamespace: &appNs,
+			})
+			errors.CheckError(err)
+			for {
+				event, err := stream.Recv()
+				if stderrors.Is(err, io.EOF) {
+					return
+				}
+				errors.CheckError(err)
+				printSyncProgress(os.Stdout, event)
+				if event.Phase.Completed() {
+					return
+				}
+			}
+		},
+	}
+	command.Flags().StringVarP(&appNamespace, "app-namespace", "N", "", "Only follow the sync operation of an application in namespace")
+	return command
+}
+
+// printSyncProgress prints the stage of the resources of a sync progress event, followed by the phase of the operation
+func printSyncProgress(out io.Writer, event *argoappv1.ApplicationSyncProgressEvent) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	for _, res := range event.Resources {
+		message := res.Message
+		if res.LogsPath != "" {
+			message = strings.TrimSpace(message + " (logs: " + res.LogsPath + ")")
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", res.SyncPhase, res.Stage, res.Group, res.Kind, res.Namespace, res.Name, message)
+	}
+	_ = w.Flush()
+	_, _ = fmt.Fprintf(out, "Phase: %s %s\n", event.Phase, event.Message)
+}
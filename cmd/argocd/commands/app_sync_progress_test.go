@@ -0,0 +1,42 @@
+package commands
+
+import (
+	"bytes"
+	"testing"
+
+	synccommon "github.com/argoproj/gitops-engine/pkg/sync/common"
+	"github.com/stretchr/testify/assert"
+
+	argoappv1 "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+)
+
+func TestPrintSyncProgress(t *testing.T) {
+	var out bytes.Buffer
+	printSyncProgress(&out, &argoappv1.ApplicationSyncProgressEvent{
+		Phase:   synccommon.OperationRunning,
+		Message: "waiting for completion of hook batch/Job/smoke-test",
+		Resources: []argoappv1.ResourceSyncProgress{{
+			Group:     "apps",
+			Kind:      "Deployment",
+			Namespace: "default",
+			Name:      "guestbook-ui",
+			SyncPhase: synccommon.SyncPhaseSync,
+			Stage:     argoappv1.SyncProgressStageSucceeded,
+			Message:   "deployment.apps/guestbook-ui configured",
+		}, {
+			Group:     "batch",
+			Kind:      "Job",
+			Namespace: "default",
+			Name:      "smoke-test",
+			SyncPhase: synccommon.SyncPhasePostSync,
+			Stage:     argoappv1.SyncProgressStageHookRunning,
+			HookType:  synccommon.HookTypePostSync,
+			LogsPath:  "/api/v1/applications/guestbook/logs?kind=Job",
+		}},
+	})
+	expectation := `Sync      succeeded     apps   Deployment  default  guestbook-ui  deployment.apps/guestbook-ui configured
+PostSync  hook-running  batch  Job         default  smoke-test    (logs: /api/v1/applications/guestbook/logs?kind=Job)
+Phase: Running waiting for completion of hook batch/Job/smoke-test
+`
+	assert.Equal(t, expectation, out.String())
+}
@@ -359,6 +359,38 @@ func TestFindRevisionHistoryWithPassedIdThatNotExist(t *testing.T) {
 	require.EqualError(t, err, "application '' does not have deployment id '4' in history", "Find revision history should fail with correct error message")
 }
 
+func TestFindRevisionHistoryAt(t *testing.T) {
+	deployedAt := func(value string) metav1.Time {
+		parsed, err := time.Parse(time.RFC3339, value)
+		require.NoError(t, err)
+		return metav1.NewTime(parsed)
+	}
+	application := v1alpha1.Application{
+		Status: v1alpha1.ApplicationStatus{
+			History: v1alpha1.RevisionHistories{
+				{ID: 1, Revision: "aaa", DeployedAt: deployedAt("2024-05-01T10:00:00Z")},
+				{ID: 2, Revision: "bbb", DeployedAt: deployedAt("2024-05-01T12:00:00Z")},
+				{ID: 3, Revision: "ccc", DeployedAt: deployedAt("2024-05-02T09:00:00Z")},
+			},
+		},
+	}
+
+	history, err := findRevisionHistoryAt(&application, deployedAt("2024-05-01T15:30:00Z").Time)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), history.ID)
+
+	history, err = findRevisionHistoryAt(&application, deployedAt("2024-05-01T12:00:00Z").Time)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), history.ID)
+
+	history, err = findRevisionHistoryAt(&application, deployedAt("2024-06-01T00:00:00Z").Time)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), history.ID)
+
+	_, err = findRevisionHistoryAt(&application, deployedAt("2024-04-30T00:00:00Z").Time)
+	require.EqualError(t, err, "application '' does not have a deployment in history completed at or before 2024-04-30T00:00:00Z")
+}
+
 func Test_groupObjsByKey(t *testing.T) {
 	localObjs := []*unstructured.Unstructured{
 		{
@@ -586,6 +618,30 @@ func TestPrintApplicationHistoryTable(t *testing.T) {
 	require.Equalf(t, output, expectation, "Incorrect print operation output %q, should be %q", output, expectation)
 }
 
+func TestPrintApplicationHistoryTableWithVersionConstraints(t *testing.T) {
+	histories := []v1alpha1.RevisionHistory{
+		{
+			ID:       1,
+			Revision: "8.1.3",
+			Source: v1alpha1.ApplicationSource{
+				TargetRevision: "8.*",
+				RepoURL:        "test",
+				Chart:          "chart",
+			},
+			VersionConstraints: []string{"8.*"},
+		},
+	}
+
+	output, _ := captureOutput(func() error {
+		printApplicationHistoryTable(histories)
+		return nil
+	})
+
+	expectation := "SOURCE  test\nID      DATE                           REVISION\n1       0001-01-01 00:00:00 +0000 UTC  8.* (8.1.3)\n"
+
+	require.Equalf(t, output, expectation, "Incorrect print operation output %q, should be %q", output, expectation)
+}
+
 func TestPrintApplicationHistoryTableWithMultipleSources(t *testing.T) {
 	histories := []v1alpha1.RevisionHistory{
 		{
@@ -875,6 +931,67 @@ func TestPrintAppConditions(t *testing.T) {
 	require.Equalf(t, output, expectation, "Incorrect print app conditions output %q, should be %q", output, expectation)
 }
 
+func TestPrintSuppressedDiffs(t *testing.T) {
+	output, err := captureOutput(func() error {
+		printSuppressedDiffs([]*v1alpha1.ResourceDiff{{
+			Group:     "apps",
+			Kind:      "Deployment",
+			Namespace: "default",
+			Name:      "guestbook-ui",
+			SuppressedDiff: []v1alpha1.SuppressedDifference{
+				{Path: "/spec/replicas", Rule: "spec.ignoreDifferences[0]: jsonPointer /spec/replicas"},
+			},
+		}, {
+			Kind:      "Service",
+			Namespace: "default",
+			Name:      "guestbook-ui",
+		}})
+		return nil
+	})
+	require.NoError(t, err)
+	expectation := `
+===== Ignored differences ======
+GROUP  KIND        NAMESPACE  NAME          PATH            RULE
+apps   Deployment  default    guestbook-ui  /spec/replicas  spec.ignoreDifferences[0]: jsonPointer /spec/replicas
+`
+	assert.Equal(t, expectation, output)
+
+	output, err = captureOutput(func() error {
+		printSuppressedDiffs([]*v1alpha1.ResourceDiff{{Kind: "Service", Namespace: "default", Name: "guestbook-ui"}})
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "\n===== Ignored differences ======\nNo differences are hidden by ignoreDifferences rules\n", output)
+}
+
+func TestPrintHookOutputs(t *testing.T) {
+	var out strings.Builder
+	printHookOutputs(&out, "guestbook", &v1alpha1.OperationState{SyncResult: &v1alpha1.SyncOperationResult{Resources: v1alpha1.ResourceResults{{
+		Group:    "batch",
+		Kind:     "Job",
+		Name:     "migrate",
+		HookType: "PreSync",
+		HookOutputs: []v1alpha1.HookOutput{
+			{Pod: "migrate-x7k2p", Container: "main", Reason: "Error", ExitCode: 1},
+		},
+	}, {
+		Kind: "Service",
+		Name: "guestbook-ui",
+	}}}})
+	expectation := `
+===== Hooks ======
+HOOK     KIND  NAME     POD            CONTAINER  REASON  EXIT CODE
+PreSync  Job   migrate  migrate-x7k2p  main       Error   1
+
+The logs of the hooks are shown with: argocd app logs guestbook --kind KIND --name NAME
+`
+	assert.Equal(t, expectation, out.String())
+
+	out.Reset()
+	printHookOutputs(&out, "guestbook", &v1alpha1.OperationState{SyncResult: &v1alpha1.SyncOperationResult{}})
+	assert.Empty(t, out.String())
+}
+
 func TestPrintParams(t *testing.T) {
 	testCases := []struct {
 		name           string
@@ -2253,6 +2370,50 @@ func (c *fakeAppServiceClient) ListResourceLinks(_ context.Context, _ *applicati
 	return nil, nil
 }
 
+func (c *fakeAppServiceClient) PinSources(_ context.Context, _ *applicationpkg.ApplicationPinSourcesRequest, _ ...grpc.CallOption) (*v1alpha1.Application, error) {
+	return nil, nil
+}
+
+func (c *fakeAppServiceClient) PromotePins(_ context.Context, _ *applicationpkg.ApplicationPromotePinsRequest, _ ...grpc.CallOption) (*v1alpha1.Application, error) {
+	return nil, nil
+}
+
+func (c *fakeAppServiceClient) WatchSyncProgress(_ context.Context, _ *applicationpkg.ApplicationSyncProgressQuery, _ ...grpc.CallOption) (applicationpkg.ApplicationService_WatchSyncProgressClient, error) {
+	return nil, nil
+}
+
+func (c *fakeAppServiceClient) ListBlueprints(_ context.Context, _ *applicationpkg.ApplicationBlueprintQuery, _ ...grpc.CallOption) (*v1alpha1.ApplicationBlueprintList, error) {
+	return nil, nil
+}
+
+func (c *fakeAppServiceClient) InstantiateBlueprint(_ context.Context, _ *applicationpkg.ApplicationInstantiateBlueprintRequest, _ ...grpc.CallOption) (*v1alpha1.Application, error) {
+	return nil, nil
+}
+
+func (c *fakeAppServiceClient) Freeze(_ context.Context, _ *applicationpkg.ApplicationFreezeRequest, _ ...grpc.CallOption) (*v1alpha1.Application, error) {
+	return nil, nil
+}
+
+func (c *fakeAppServiceClient) Thaw(_ context.Context, _ *applicationpkg.ApplicationThawRequest, _ ...grpc.CallOption) (*v1alpha1.Application, error) {
+	return nil, nil
+}
+
+func (c *fakeAppServiceClient) Cascade(_ context.Context, _ *applicationpkg.ApplicationCascadeRequest, _ ...grpc.CallOption) (*v1alpha1.Application, error) {
+	return nil, nil
+}
+
+func (c *fakeAppServiceClient) ResourceTreeDiff(_ context.Context, _ *applicationpkg.ResourceTreeDiffQuery, _ ...grpc.CallOption) (*v1alpha1.ResourceTreeDiff, error) {
+	return nil, nil
+}
+
+func (c *fakeAppServiceClient) ListOrphanedResources(_ context.Context, _ *applicationpkg.OrphanedResourcesQuery, _ ...grpc.CallOption) (*applicationpkg.OrphanedResourcesResponse, error) {
+	return nil, nil
+}
+
+func (c *fakeAppServiceClient) RunOrphanedResourcesAction(_ context.Context, _ *applicationpkg.OrphanedResourcesActionRequest, _ ...grpc.CallOption) (*applicationpkg.OrphanedResourcesActionResponse, error) {
+	return nil, nil
+}
+
 type fakeAcdClient struct {
 	simulateTimeout uint
 }
@@ -2382,3 +2543,16 @@ func (c *fakeAcdClient) WatchApplicationWithRetry(_ context.Context, _ string, _
 	}()
 	return appEventsCh
 }
+
+func TestFormatFreeze(t *testing.T) {
+	freeze := &v1alpha1.ApplicationFreeze{}
+	assert.Equal(t, "until thawed", formatFreeze(freeze))
+
+	freeze = &v1alpha1.ApplicationFreeze{
+		FrozenBy:  "alice",
+		Reason:    "incident 1234",
+		Until:     &metav1.Time{Time: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)},
+		NoRefresh: true,
+	}
+	assert.Equal(t, "until 2026-01-01T00:00:00Z, without refresh by alice (incident 1234)", formatFreeze(freeze))
+}
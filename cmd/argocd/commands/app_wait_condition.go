@@ -0,0 +1,61 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/expr-lang/expr"
+	"github.com/expr-lang/expr/vm"
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	argoappv1 "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+)
+
+// waitConditions is a set of expressions evaluated against an application which all have to be true
+// for `argocd app wait` to finish. Expressions use the same language as notification triggers and
+// have access to the application as `app`, e.g. app.status.health.status == 'Healthy'.
+type waitConditions struct {
+	expressions []string
+	programs    []*vm.Program
+}
+
+func newWaitConditions(expressions []string) (*waitConditions, error) {
+	if len(expressions) == 0 {
+		return nil, nil
+	}
+	conditions := &waitConditions{expressions: expressions}
+	env := map[string]any{"app": map[string]any{}}
+	for _, e := range expressions {
+		program, err := expr.Compile(e, expr.Env(env), expr.AsBool())
+		if err != nil {
+			return nil, fmt.Errorf("invalid wait condition '%s': %w", e, err)
+		}
+		conditions.programs = append(conditions.programs, program)
+	}
+	return conditions, nil
+}
+
+// satisfied returns true if all conditions evaluate to true for the given application. Conditions which fail to
+// evaluate, e.g. because they reference a field which is not set yet, are considered not satisfied.
+func (c *waitConditions) satisfied(app *argoappv1.Application) bool {
+	if c == nil {
+		return true
+	}
+	obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(app)
+	if err != nil {
+		log.Warnf("Failed to convert application '%s' to evaluate wait conditions: %v", app.QualifiedName(), err)
+		return false
+	}
+	env := map[string]any{"app": obj}
+	for i, program := range c.programs {
+		res, err := expr.Run(program, env)
+		if err != nil {
+			log.Debugf("Wait condition '%s' could not be evaluated for application '%s': %v", c.expressions[i], app.QualifiedName(), err)
+			return false
+		}
+		if ok, _ := res.(bool); !ok {
+			return false
+		}
+	}
+	return true
+}
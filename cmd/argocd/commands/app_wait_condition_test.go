@@ -0,0 +1,59 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/argoproj/gitops-engine/pkg/health"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+)
+
+func TestNewWaitConditions(t *testing.T) {
+	conditions, err := newWaitConditions(nil)
+	require.NoError(t, err)
+	assert.Nil(t, conditions)
+
+	_, err = newWaitConditions([]string{"app.status.health.status =="})
+	require.Error(t, err)
+
+	_, err = newWaitConditions([]string{"'not a boolean'"})
+	require.Error(t, err)
+}
+
+func TestWaitConditionsSatisfied(t *testing.T) {
+	app := &v1alpha1.Application{
+		Status: v1alpha1.ApplicationStatus{
+			Health: v1alpha1.AppHealthStatus{Status: health.HealthStatusHealthy},
+			Sync:   v1alpha1.SyncStatus{Status: v1alpha1.SyncStatusCodeSynced, Revision: "abc"},
+		},
+	}
+
+	var nilConditions *waitConditions
+	assert.True(t, nilConditions.satisfied(app))
+
+	conditions, err := newWaitConditions([]string{"app.status.health.status == 'Healthy'", "app.status.sync.revision == 'abc'"})
+	require.NoError(t, err)
+	assert.True(t, conditions.satisfied(app))
+
+	conditions, err = newWaitConditions([]string{"app.status.health.status == 'Healthy'", "app.status.sync.revision == 'def'"})
+	require.NoError(t, err)
+	assert.False(t, conditions.satisfied(app))
+
+	conditions, err = newWaitConditions([]string{"app.status.operationState.phase == 'Succeeded'"})
+	require.NoError(t, err)
+	assert.False(t, conditions.satisfied(app))
+
+	app.Status.OperationState = &v1alpha1.OperationState{Phase: "Succeeded"}
+	assert.True(t, conditions.satisfied(app))
+}
+
+func TestGetWatchOptsWithConditions(t *testing.T) {
+	conditions, err := newWaitConditions([]string{"app.status.health.status == 'Healthy'"})
+	require.NoError(t, err)
+	opts := getWatchOpts(watchOpts{conditions: conditions})
+	assert.False(t, opts.sync)
+	assert.False(t, opts.health)
+	assert.False(t, opts.operation)
+}
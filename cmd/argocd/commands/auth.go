@@ -0,0 +1,122 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientauthv1 "k8s.io/client-go/pkg/apis/clientauthentication/v1"
+
+	argocdclient "github.com/argoproj/argo-cd/v3/pkg/apiclient"
+	"github.com/argoproj/argo-cd/v3/util/errors"
+)
+
+const (
+	// execInfoEnvVar is set by client-go when running a credential plugin and describes the expected ExecCredential
+	execInfoEnvVar = "KUBERNETES_EXEC_INFO"
+
+	execCredentialAPIVersionV1      = "client.authentication.k8s.io/v1"
+	execCredentialAPIVersionV1beta1 = "client.authentication.k8s.io/v1beta1"
+)
+
+// NewAuthCommand returns a new instance of an `argocd auth` command
+func NewAuthCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
+	command := &cobra.Command{
+		Use:   "auth",
+		Short: "Manage authentication tokens of the current context",
+		Run: func(c *cobra.Command, args []string) {
+			c.HelpFunc()(c, args)
+			os.Exit(1)
+		},
+	}
+	command.AddCommand(NewAuthTokenCommand(clientOpts))
+	return command
+}
+
+// NewAuthTokenCommand returns a new instance of an `argocd auth token` command
+func NewAuthTokenCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
+	var execCredential bool
+	command := &cobra.Command{
+		Use:   "token",
+		Short: "Print the auth token of the current context, refreshing it if it has expired",
+		Long: `Print the auth token of the current context, refreshing it if it has expired.
+
+With --exec-credential the token is printed as a client.authentication.k8s.io ExecCredential, so the command can be
+used as a credential plugin by tools which support the Kubernetes exec plugin mechanism. The API version requested
+in the KUBERNETES_EXEC_INFO environment variable is honored.
+
+The command is not available in core mode, where the CLI authenticates with the credentials of the current kube
+context: use the credential plugin of the kube context instead.`,
+		Example: `  # Print the auth token of the current context
+  argocd auth token
+
+  # Print the auth token as an ExecCredential
+  argocd auth token --exec-credential`,
+		Run: func(c *cobra.Command, args []string) {
+			if len(args) != 0 {
+				c.HelpFunc()(c, args)
+				os.Exit(1)
+			}
+			if clientOpts.Core {
+				errors.Fatal(errors.ErrorGeneric, "auth tokens are not used in core mode, the credentials of the current kube context are used instead")
+			}
+
+			// creating the client redeems the refresh token of the context if the auth token has expired
+			acdClient, err := argocdclient.NewClient(clientOpts)
+			errors.CheckError(err)
+			token := acdClient.ClientOptions().AuthToken
+			if token == "" {
+				errors.Fatal(errors.ErrorGeneric, "no auth token found, login using `argocd login`")
+			}
+
+			if !execCredential {
+				fmt.Println(token)
+				return
+			}
+			out, err := formatExecCredential(token, execCredentialAPIVersion(os.Getenv(execInfoEnvVar)))
+			errors.CheckError(err)
+			fmt.Println(out)
+		},
+	}
+	command.Flags().BoolVar(&execCredential, "exec-credential", false, "Print the token as a client.authentication.k8s.io ExecCredential")
+	return command
+}
+
+// execCredentialAPIVersion returns the ExecCredential API version requested in the exec info passed by client-go,
+// defaulting to v1
+func execCredentialAPIVersion(execInfo string) string {
+	if execInfo == "" {
+		return execCredentialAPIVersionV1
+	}
+	var info metav1.TypeMeta
+	if err := json.Unmarshal([]byte(execInfo), &info); err != nil || info.APIVersion != execCredentialAPIVersionV1beta1 {
+		return execCredentialAPIVersionV1
+	}
+	return execCredentialAPIVersionV1beta1
+}
+
+// formatExecCredential returns the token as an ExecCredential. The expiration of the token, if any, is used as the
+// expiration of the credential so that the token is requested again once it has expired.
+func formatExecCredential(token string, apiVersion string) (string, error) {
+	status := &clientauthv1.ExecCredentialStatus{Token: token}
+	var claims jwt.RegisteredClaims
+	if _, _, err := jwt.NewParser(jwt.WithoutClaimsValidation()).ParseUnverified(token, &claims); err == nil && claims.ExpiresAt != nil {
+		expiration := metav1.NewTime(claims.ExpiresAt.Time)
+		status.ExpirationTimestamp = &expiration
+	}
+	execCredential := &clientauthv1.ExecCredential{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: apiVersion,
+			Kind:       "ExecCredential",
+		},
+		Status: status,
+	}
+	out, err := json.Marshal(execCredential)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal ExecCredential: %w", err)
+	}
+	return string(out), nil
+}
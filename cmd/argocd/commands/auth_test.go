@@ -0,0 +1,44 @@
+package commands
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecCredentialAPIVersion(t *testing.T) {
+	assert.Equal(t, execCredentialAPIVersionV1, execCredentialAPIVersion(""))
+	assert.Equal(t, execCredentialAPIVersionV1, execCredentialAPIVersion("invalid"))
+	assert.Equal(t, execCredentialAPIVersionV1, execCredentialAPIVersion(`{"apiVersion":"client.authentication.k8s.io/v1","kind":"ExecCredential"}`))
+	assert.Equal(t, execCredentialAPIVersionV1beta1, execCredentialAPIVersion(`{"apiVersion":"client.authentication.k8s.io/v1beta1","kind":"ExecCredential"}`))
+}
+
+func TestFormatExecCredential(t *testing.T) {
+	expiresAt := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
+		Subject:   "admin",
+		ExpiresAt: jwt.NewNumericDate(expiresAt),
+	}).SignedString([]byte("secret"))
+	require.NoError(t, err)
+
+	out, err := formatExecCredential(token, execCredentialAPIVersionV1)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+  "apiVersion": "client.authentication.k8s.io/v1",
+  "kind": "ExecCredential",
+  "spec": {"interactive": false},
+  "status": {"expirationTimestamp": "2030-01-02T03:04:05Z", "token": "`+token+`"}
+}`, out)
+
+	out, err = formatExecCredential("opaque-token", execCredentialAPIVersionV1beta1)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+  "apiVersion": "client.authentication.k8s.io/v1beta1",
+  "kind": "ExecCredential",
+  "spec": {"interactive": false},
+  "status": {"token": "opaque-token"}
+}`, out)
+}
@@ -38,12 +38,20 @@ const (
 	clusterFieldName = "name"
 	// cluster field is 'namespaces'
 	clusterFieldNamespaces = "namespaces"
+	// cluster field is 'resourceGroups'
+	clusterFieldResourceGroups = "resourceGroups"
+	// cluster field is 'draining'
+	clusterFieldDraining = "draining"
+	// cluster field is 'minReadyNodes'
+	clusterFieldMinReadyNodes = "minReadyNodes"
 	// cluster field is 'labels'
 	clusterFieldLabel = "labels"
 	// cluster field is 'annotations'
 	clusterFieldAnnotation = "annotations"
 	// indicates managing all namespaces
 	allNamespaces = "*"
+	// indicates caching the resources of all API groups
+	allResourceGroups = "*"
 )
 
 // NewClusterCommand returns a new instance of an `argocd cluster` command
@@ -115,7 +123,19 @@ func NewClusterAddCommand(clientOpts *argocdclient.ClientOptions, pathOpts *clie
 				errors.CheckError(err)
 				conf.Proxy = http.ProxyURL(u)
 			}
-			clientset, err := kubernetes.NewForConfig(conf)
+			jumpHostConf, err := clusterOpts.JumpHostConfig()
+			errors.CheckError(err)
+			// the cluster is reached through the same proxy and jump host as Argo CD does
+			connConf, err := (&argoappv1.Cluster{Server: conf.Host, Config: argoappv1.ClusterConfig{
+				ProxyUrl:       clusterOpts.ProxyUrl,
+				ProxyUsername:  clusterOpts.ProxyUsername,
+				ProxyPassword:  clusterOpts.ProxyPassword,
+				JumpHostConfig: jumpHostConf,
+			}}).RawRestConfig()
+			errors.CheckError(err)
+			localConf := rest.CopyConfig(conf)
+			localConf.Proxy, localConf.Dial = connConf.Proxy, connConf.Dial
+			clientset, err := kubernetes.NewForConfig(localConf)
 			errors.CheckError(err)
 			managerBearerToken := ""
 			var awsAuthConf *argoappv1.AWSAuthConfig
@@ -167,6 +187,9 @@ func NewClusterAddCommand(clientOpts *argocdclient.ClientOptions, pathOpts *clie
 				contextName = clusterOpts.Name
 			}
 			clst := cmdutil.NewCluster(contextName, clusterOpts.Namespaces, clusterOpts.ClusterResources, conf, managerBearerToken, awsAuthConf, execProviderConf, labelsMap, annotationsMap)
+			clst.Config.ProxyUsername = clusterOpts.ProxyUsername
+			clst.Config.ProxyPassword = clusterOpts.ProxyPassword
+			clst.Config.JumpHostConfig = jumpHostConf
 			if clusterOpts.InClusterEndpoint() {
 				clst.Server = argoappv1.KubernetesInternalAPIServerAddr
 			} else if clusterOpts.ClusterEndpoint == string(cmdutil.KubePublicEndpoint) {
@@ -203,6 +226,12 @@ func NewClusterAddCommand(clientOpts *argocdclient.ClientOptions, pathOpts *clie
 	command.Flags().StringArrayVar(&labels, "label", nil, "Set metadata labels (e.g. --label key=value)")
 	command.Flags().StringArrayVar(&annotations, "annotation", nil, "Set metadata annotations (e.g. --annotation key=value)")
 	command.Flags().StringVar(&clusterOpts.ProxyUrl, "proxy-url", "", "use proxy to connect cluster")
+	command.Flags().StringVar(&clusterOpts.ProxyUsername, "proxy-username", "", "username authenticating to the proxy")
+	command.Flags().StringVar(&clusterOpts.ProxyPassword, "proxy-password", "", "password authenticating to the proxy")
+	command.Flags().StringVar(&clusterOpts.JumpHost, "jump-host", "", "host:port address of an SSH jump host through which the cluster is reached")
+	command.Flags().StringVar(&clusterOpts.JumpHostUsername, "jump-host-username", "", "user authenticating to the SSH jump host")
+	command.Flags().StringVar(&clusterOpts.JumpHostSSHKeyPath, "jump-host-ssh-private-key-path", "", "path to the SSH private key authenticating to the jump host")
+	command.Flags().StringVar(&clusterOpts.JumpHostKnownHostsPath, "jump-host-known-hosts-path", "", "path to the known_hosts file holding the host key of the jump host")
 	cmdutil.AddClusterFlags(command, &clusterOpts)
 	return command
 }
@@ -244,7 +273,12 @@ func NewClusterSetCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command
 		Short: "Set cluster information",
 		Example: `  # Set cluster information
   argocd cluster set CLUSTER_NAME --name new-cluster-name --namespace '*'
-  argocd cluster set CLUSTER_NAME --name new-cluster-name --namespace namespace-one --namespace namespace-two`,
+  argocd cluster set CLUSTER_NAME --name new-cluster-name --namespace namespace-one --namespace namespace-two
+  # Only cache the resources of the core, apps and batch API groups
+  argocd cluster set CLUSTER_NAME --resource-group apps --resource-group batch
+  # Defer the syncs to the cluster while it is drained
+  argocd cluster set CLUSTER_NAME --draining`,
+		ValidArgsFunction: completeClusterNames(clientOpts, false),
 		Run: func(c *cobra.Command, args []string) {
 			ctx := c.Context()
 			if len(args) != 1 {
@@ -257,11 +291,22 @@ func NewClusterSetCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command
 			defer utilio.Close(conn)
 			// checks the fields that needs to be updated
 			updatedFields := checkFieldsToUpdate(clusterOptions, labels, annotations)
+			if c.Flags().Changed("draining") {
+				updatedFields = append(updatedFields, clusterFieldDraining)
+			}
+			if c.Flags().Changed("min-ready-nodes") {
+				updatedFields = append(updatedFields, clusterFieldMinReadyNodes)
+			}
 			namespaces := clusterOptions.Namespaces
 			// check if all namespaces have to be considered
 			if len(namespaces) == 1 && strings.EqualFold(namespaces[0], allNamespaces) {
 				namespaces[0] = ""
 			}
+			resourceGroups := clusterOptions.ResourceGroups
+			// check if the resources of all API groups have to be cached
+			if len(resourceGroups) == 1 && resourceGroups[0] == allResourceGroups {
+				resourceGroups = nil
+			}
 			// parse the labels you're receiving from the label flag
 			labelsMap, err := label.Parse(labels)
 			errors.CheckError(err)
@@ -271,10 +316,13 @@ func NewClusterSetCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command
 			if updatedFields != nil {
 				clusterUpdateRequest := clusterpkg.ClusterUpdateRequest{
 					Cluster: &argoappv1.Cluster{
-						Name:        clusterOptions.Name,
-						Namespaces:  namespaces,
-						Labels:      labelsMap,
-						Annotations: annotationsMap,
+						Name:           clusterOptions.Name,
+						Namespaces:     namespaces,
+						ResourceGroups: resourceGroups,
+						Labels:         labelsMap,
+						Annotations:    annotationsMap,
+						Draining:       clusterOptions.Draining,
+						MinReadyNodes:  clusterOptions.MinReadyNodes,
 					},
 					UpdatedFields: updatedFields,
 					Id: &clusterpkg.ClusterID{
@@ -297,6 +345,9 @@ func NewClusterSetCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command
 	}
 	command.Flags().StringVar(&clusterOptions.Name, "name", "", "Overwrite the cluster name")
 	command.Flags().StringArrayVar(&clusterOptions.Namespaces, "namespace", nil, "List of namespaces which are allowed to manage. Specify '*' to manage all namespaces")
+	command.Flags().StringArrayVar(&clusterOptions.ResourceGroups, "resource-group", nil, "List of API groups whose resources are cached, in addition to the core API group. Specify '*' to cache the resources of all API groups")
+	command.Flags().BoolVar(&clusterOptions.Draining, "draining", false, "Defer the syncs to the cluster, e.g. while it is drained or upgraded")
+	command.Flags().Int64Var(&clusterOptions.MinReadyNodes, "min-ready-nodes", 0, "Minimum number of ready nodes of the cluster for the syncs to the cluster to start")
 	command.Flags().StringArrayVar(&labels, "label", nil, "Set metadata labels (e.g. --label key=value)")
 	command.Flags().StringArrayVar(&annotations, "annotation", nil, "Set metadata annotations (e.g. --annotation key=value)")
 	return command
@@ -311,6 +362,9 @@ func checkFieldsToUpdate(clusterOptions cmdutil.ClusterOptions, labels []string,
 	if clusterOptions.Namespaces != nil {
 		updatedFields = append(updatedFields, clusterFieldNamespaces)
 	}
+	if clusterOptions.ResourceGroups != nil {
+		updatedFields = append(updatedFields, clusterFieldResourceGroups)
+	}
 	if labels != nil {
 		updatedFields = append(updatedFields, clusterFieldLabel)
 	}
@@ -328,6 +382,7 @@ func NewClusterGetCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command
 		Short: "Get cluster information",
 		Example: `argocd cluster get https://12.34.567.89
 argocd cluster get in-cluster`,
+		ValidArgsFunction: completeClusterNames(clientOpts, false),
 		Run: func(c *cobra.Command, args []string) {
 			ctx := c.Context()
 
@@ -391,7 +446,9 @@ func printClusterDetails(clusters []argoappv1.Cluster) {
 		fmt.Printf("  oAuth authentication:  %v\n", cluster.Config.BearerToken != "")
 		fmt.Printf("  AWS authentication:    %v\n", cluster.Config.AWSAuthConfig != nil)
 		fmt.Printf("\nDisable compression: %v\n", cluster.Config.DisableCompression)
+		fmt.Printf("\nDraining: %v\n", cluster.Draining)
 		fmt.Printf("\nUse proxy: %v\n", cluster.Config.ProxyUrl != "")
+		fmt.Printf("\nUse jump host: %v\n", cluster.Config.JumpHostConfig != nil)
 		fmt.Println()
 	}
 }
@@ -404,6 +461,7 @@ func NewClusterRemoveCommand(clientOpts *argocdclient.ClientOptions, pathOpts *c
 		Short: "Remove cluster credentials",
 		Example: `argocd cluster rm https://12.34.567.89
 argocd cluster rm cluster-name`,
+		ValidArgsFunction: completeClusterNames(clientOpts, true),
 		Run: func(c *cobra.Command, args []string) {
 			ctx := c.Context()
 
@@ -124,18 +124,6 @@ __argocd_proj_role(){
 
 __argocd_custom_func() {
 	case ${last_command} in
-		argocd_app_delete | \
-		argocd_app_diff | \
-		argocd_app_edit | \
-		argocd_app_get | \
-		argocd_app_history | \
-		argocd_app_manifests | \
-		argocd_app_patch-resource | \
-		argocd_app_set | \
-		argocd_app_sync | \
-		argocd_app_terminate-op | \
-		argocd_app_unset | \
-		argocd_app_wait | \
 		argocd_app_create)
 			__argocd_list_apps
 			return
@@ -144,9 +132,6 @@ __argocd_custom_func() {
 			__argocd_app_rollback
 			return
 			;;
-		argocd_cluster_get | \
-		argocd_cluster_rm | \
-		argocd_cluster_set | \
 		argocd_login | \
 		argocd_cluster_add)
 			__argocd_list_servers
@@ -162,20 +147,6 @@ __argocd_custom_func() {
 			__argocd_proj_server_namespace
 			return
 			;;
-		argocd_proj_add-source | \
-		argocd_proj_remove-source | \
-		argocd_proj_allow-cluster-resource | \
-		argocd_proj_allow-namespace-resource | \
-		argocd_proj_deny-cluster-resource | \
-		argocd_proj_deny-namespace-resource | \
-		argocd_proj_delete | \
-		argocd_proj_edit | \
-		argocd_proj_get | \
-		argocd_proj_set | \
-		argocd_proj_role_list)
-			__argocd_list_projects
-			return
-			;;
 		argocd_proj_role_remove-policy | \
 		argocd_proj_role_add-policy | \
 		argocd_proj_role_create | \
@@ -211,6 +182,10 @@ compdef _argocd argocd
 Optionally, also add the following, in case you are getting errors involving compdef & compinit such as command not found: compdef:
 autoload -Uz compinit
 compinit 
+
+Application, project and cluster names are completed by asking the Argo CD API server of the current context for
+the names starting with the typed prefix. Fetched names are cached for 30 seconds in completion-cache.json next to
+the Argo CD config, so completing a longer prefix does not query the API server again.
 `,
 		Example: `# For bash
 $ source <(argocd completion bash)
@@ -0,0 +1,208 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	argocdclient "github.com/argoproj/argo-cd/v3/pkg/apiclient"
+	applicationpkg "github.com/argoproj/argo-cd/v3/pkg/apiclient/application"
+	clusterpkg "github.com/argoproj/argo-cd/v3/pkg/apiclient/cluster"
+	projectpkg "github.com/argoproj/argo-cd/v3/pkg/apiclient/project"
+	utilio "github.com/argoproj/argo-cd/v3/util/io"
+)
+
+const (
+	// completionTimeout bounds the time spent on fetching names from the API server during shell completion
+	completionTimeout = 2 * time.Second
+	// completionCacheTTL is the duration for which fetched names are reused by subsequent completions
+	completionCacheTTL = 30 * time.Second
+	// completionCacheFile is the name of the completion cache file, stored next to the Argo CD config
+	completionCacheFile = "completion-cache.json"
+)
+
+// completionCacheEntry holds the names fetched for a prefix. The names are valid for every prefix which starts with
+// the prefix they were fetched for.
+type completionCacheEntry struct {
+	Prefix    string    `json:"prefix"`
+	Names     []string  `json:"names"`
+	FetchedAt time.Time `json:"fetchedAt"`
+}
+
+// completionNamesFetcher returns the names starting with the given prefix
+type completionNamesFetcher func(ctx context.Context, acdClient argocdclient.Client, prefix string) ([]string, error)
+
+// completeApplicationNames returns a completion function which completes qualified application names, e.g.
+// "argocd/guestbook", as printed by `argocd app list -o name`. Only the applications of the namespace are fetched once
+// the namespace has been typed.
+func completeApplicationNames(clientOpts *argocdclient.ClientOptions, multiple bool) cobra.CompletionFunc {
+	return func(c *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		if len(args) > 0 && !multiple {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		appNs, _, qualified := strings.Cut(toComplete, "/")
+		kind := "applications"
+		if qualified {
+			kind += "/" + appNs
+		}
+		return completeNames(c, clientOpts, kind, toComplete, func(ctx context.Context, acdClient argocdclient.Client, prefix string) ([]string, error) {
+			conn, appIf, err := acdClient.NewApplicationClient()
+			if err != nil {
+				return nil, err
+			}
+			defer utilio.Close(conn)
+			q := &applicationpkg.ApplicationQuery{}
+			if qualified {
+				namePrefix := strings.TrimPrefix(prefix, appNs+"/")
+				q.AppNamespace = &appNs
+				q.NamePrefix = &namePrefix
+			}
+			apps, err := appIf.List(ctx, q)
+			if err != nil {
+				return nil, err
+			}
+			names := make([]string, 0, len(apps.Items))
+			for _, app := range apps.Items {
+				names = append(names, app.QualifiedName())
+			}
+			return names, nil
+		}), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// completeProjectNames returns a completion function which completes the name of a project as first argument
+func completeProjectNames(clientOpts *argocdclient.ClientOptions) cobra.CompletionFunc {
+	return func(c *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return completeNames(c, clientOpts, "projects", toComplete, func(ctx context.Context, acdClient argocdclient.Client, prefix string) ([]string, error) {
+			conn, projIf, err := acdClient.NewProjectClient()
+			if err != nil {
+				return nil, err
+			}
+			defer utilio.Close(conn)
+			projects, err := projIf.List(ctx, &projectpkg.ProjectQuery{NamePrefix: prefix})
+			if err != nil {
+				return nil, err
+			}
+			names := make([]string, 0, len(projects.Items))
+			for _, proj := range projects.Items {
+				names = append(names, proj.Name)
+			}
+			return names, nil
+		}), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// completeClusterNames returns a completion function which completes cluster names
+func completeClusterNames(clientOpts *argocdclient.ClientOptions, multiple bool) cobra.CompletionFunc {
+	return func(c *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		if len(args) > 0 && !multiple {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return completeNames(c, clientOpts, "clusters", toComplete, func(ctx context.Context, acdClient argocdclient.Client, prefix string) ([]string, error) {
+			conn, clusterIf, err := acdClient.NewClusterClient()
+			if err != nil {
+				return nil, err
+			}
+			defer utilio.Close(conn)
+			clusters, err := clusterIf.List(ctx, &clusterpkg.ClusterQuery{NamePrefix: prefix})
+			if err != nil {
+				return nil, err
+			}
+			names := make([]string, 0, len(clusters.Items))
+			for _, cluster := range clusters.Items {
+				if cluster.Name != "" {
+					names = append(names, cluster.Name)
+				}
+			}
+			return names, nil
+		}), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// completeNames returns the names of the given kind which start with the prefix. Names are taken from the completion
+// cache if they were recently fetched for a shorter prefix, otherwise they are fetched from the
+// API server. Completion never fails, errors result in no names being completed.
+func completeNames(c *cobra.Command, clientOpts *argocdclient.ClientOptions, kind string, prefix string, fetch completionNamesFetcher) []string {
+	if clientOpts.Core {
+		// starting an in-process API server is too slow for shell completion
+		return nil
+	}
+	acdClient, err := argocdclient.NewClient(clientOpts)
+	if err != nil {
+		cobra.CompDebugln(err.Error(), false)
+		return nil
+	}
+	cachePath := ""
+	if clientOpts.ConfigPath != "" {
+		cachePath = filepath.Join(filepath.Dir(clientOpts.ConfigPath), completionCacheFile)
+	}
+	cacheKey := acdClient.ClientOptions().ServerAddr + "/" + kind
+	cache := readCompletionCache(cachePath)
+	if entry, ok := cache[cacheKey]; ok && strings.HasPrefix(prefix, entry.Prefix) && time.Since(entry.FetchedAt) < completionCacheTTL {
+		return filterCompletionNames(entry.Names, prefix)
+	}
+
+	ctx, cancel := context.WithTimeout(c.Context(), completionTimeout)
+	defer cancel()
+	names, err := fetch(ctx, acdClient, prefix)
+	if err != nil {
+		cobra.CompDebugln(err.Error(), false)
+		return nil
+	}
+	cache[cacheKey] = completionCacheEntry{Prefix: prefix, Names: names, FetchedAt: time.Now()}
+	writeCompletionCache(cachePath, cache)
+	return filterCompletionNames(names, prefix)
+}
+
+// filterCompletionNames returns the names which start with the prefix
+func filterCompletionNames(names []string, prefix string) []string {
+	var filtered []string
+	for _, name := range names {
+		if strings.HasPrefix(name, prefix) {
+			filtered = append(filtered, name)
+		}
+	}
+	return filtered
+}
+
+func readCompletionCache(path string) map[string]completionCacheEntry {
+	cache := make(map[string]completionCacheEntry)
+	if path == "" {
+		return cache
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return cache
+	}
+	if err := json.Unmarshal(data, &cache); err != nil {
+		return make(map[string]completionCacheEntry)
+	}
+	// drop expired entries so the cache does not grow with every server and namespace ever completed
+	for key, entry := range cache {
+		if time.Since(entry.FetchedAt) >= completionCacheTTL {
+			delete(cache, key)
+		}
+	}
+	return cache
+}
+
+func writeCompletionCache(path string, cache map[string]completionCacheEntry) {
+	if path == "" {
+		return
+	}
+	data, err := json.Marshal(cache)
+	if err != nil {
+		return
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		cobra.CompDebugln(err.Error(), false)
+	}
+}
@@ -0,0 +1,53 @@
+package commands
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	argocdclient "github.com/argoproj/argo-cd/v3/pkg/apiclient"
+)
+
+func TestFilterCompletionNames(t *testing.T) {
+	names := []string{"guestbook", "guestbook-dev", "helm-guestbook"}
+	assert.Equal(t, names, filterCompletionNames(names, ""))
+	assert.Equal(t, []string{"guestbook", "guestbook-dev"}, filterCompletionNames(names, "guest"))
+	assert.Empty(t, filterCompletionNames(names, "kustomize"))
+}
+
+func TestCompletionCache(t *testing.T) {
+	path := filepath.Join(t.TempDir(), completionCacheFile)
+	assert.Empty(t, readCompletionCache(path))
+
+	writeCompletionCache(path, map[string]completionCacheEntry{
+		"argocd.example.com/applications": {Prefix: "gu", Names: []string{"guestbook"}, FetchedAt: time.Now()},
+		"argocd.example.com/projects":     {Prefix: "", Names: []string{"default"}, FetchedAt: time.Now().Add(-completionCacheTTL)},
+	})
+
+	cache := readCompletionCache(path)
+	require.Len(t, cache, 1)
+	assert.Equal(t, "gu", cache["argocd.example.com/applications"].Prefix)
+	assert.Equal(t, []string{"guestbook"}, cache["argocd.example.com/applications"].Names)
+}
+
+func TestCompleteNamesOnlyFirstArgument(t *testing.T) {
+	clientOpts := &argocdclient.ClientOptions{}
+	names, directive := completeApplicationNames(clientOpts, false)(&cobra.Command{}, []string{"guestbook"}, "")
+	assert.Empty(t, names)
+	assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+
+	names, directive = completeProjectNames(clientOpts)(&cobra.Command{}, []string{"default"}, "")
+	assert.Empty(t, names)
+	assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+}
+
+func TestCompleteNamesCoreMode(t *testing.T) {
+	clientOpts := &argocdclient.ClientOptions{Core: true}
+	names, directive := completeClusterNames(clientOpts, true)(&cobra.Command{}, []string{"in-cluster"}, "")
+	assert.Empty(t, names)
+	assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+}
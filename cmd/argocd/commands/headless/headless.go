@@ -112,6 +112,12 @@ func (c *forwardCacheClient) NotifyUpdated(key string) error {
 	})
 }
 
+func (c *forwardCacheClient) Scan(ctx context.Context, prefix string, callback func(key string, size int64) error) error {
+	return c.doLazy(func(client cache.CacheClient) error {
+		return client.Scan(ctx, prefix, callback)
+	})
+}
+
 type forwardRepoClientset struct {
 	namespace      string
 	context        string
@@ -152,6 +152,7 @@ func NewProjectSetCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command
 			# Set project parameters with some denied namespaced resources [RES1,RES2,...] for project with name PROJECT
 			argocd proj set PROJECT ---deny-namespaced-resource [RES1,RES2,...]
 		`),
+		ValidArgsFunction: completeProjectNames(clientOpts),
 		Run: func(c *cobra.Command, args []string) {
 			ctx := c.Context()
 
@@ -475,6 +476,7 @@ func NewProjectAddSourceCommand(clientOpts *argocdclient.ClientOptions) *cobra.C
 			# Add a source repository (URL) to the project with name PROJECT
 			argocd proj add-source PROJECT URL
 		`),
+		ValidArgsFunction: completeProjectNames(clientOpts),
 		Run: func(c *cobra.Command, args []string) {
 			ctx := c.Context()
 
@@ -629,9 +631,10 @@ func modifyResourceListCmd(cmdUse, cmdDesc, examples string, clientOpts *argocdc
 		defaultList = "allow"
 	}
 	command := &cobra.Command{
-		Use:     cmdUse,
-		Short:   cmdDesc,
-		Example: templates.Examples(examples),
+		Use:               cmdUse,
+		Short:             cmdDesc,
+		Example:           templates.Examples(examples),
+		ValidArgsFunction: completeProjectNames(clientOpts),
 		Run: func(c *cobra.Command, args []string) {
 			ctx := c.Context()
 
@@ -729,6 +732,7 @@ func NewProjectRemoveSourceCommand(clientOpts *argocdclient.ClientOptions) *cobr
 			# Remove URL source repository to project PROJECT
 			argocd proj remove-source PROJECT URL
 		`),
+		ValidArgsFunction: completeProjectNames(clientOpts),
 		Run: func(c *cobra.Command, args []string) {
 			ctx := c.Context()
 
@@ -773,6 +777,7 @@ func NewProjectDeleteCommand(clientOpts *argocdclient.ClientOptions) *cobra.Comm
 			# Delete the project with name PROJECT
 			argocd proj delete PROJECT
 		`),
+		ValidArgsFunction: completeProjectNames(clientOpts),
 		Run: func(c *cobra.Command, args []string) {
 			ctx := c.Context()
 
@@ -1017,6 +1022,7 @@ func NewProjectGetCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command
 			argocd proj get PROJECT -o yaml
 
 		`),
+		ValidArgsFunction: completeProjectNames(clientOpts),
 		Run: func(c *cobra.Command, args []string) {
 			ctx := c.Context()
 
@@ -1058,6 +1064,7 @@ func NewProjectEditCommand(clientOpts *argocdclient.ClientOptions) *cobra.Comman
 			# Edit the information on project with name PROJECT
 			argocd proj edit PROJECT
 		`),
+		ValidArgsFunction: completeProjectNames(clientOpts),
 		Run: func(c *cobra.Command, args []string) {
 			ctx := c.Context()
 
@@ -537,6 +537,7 @@ func NewProjectRoleListCommand(clientOpts *argocdclient.ClientOptions) *cobra.Co
 
   		`),
 
+		ValidArgsFunction: completeProjectNames(clientOpts),
 		Run: func(c *cobra.Command, args []string) {
 			ctx := c.Context()
 
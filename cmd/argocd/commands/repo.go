@@ -55,7 +55,10 @@ argocd repo rm https://github.com/yourusername/your-repo.git
 
 // NewRepoAddCommand returns a new instance of an `argocd repo add` command
 func NewRepoAddCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
-	var repoOpts cmdutil.RepoOptions
+	var (
+		repoOpts cmdutil.RepoOptions
+		file     string
+	)
 
 	// For better readability and easier formatting
 	repoAddExamples := `  # Add a Git repository via SSH using a private key for authentication, ignoring the server's host key:
@@ -102,6 +105,9 @@ func NewRepoAddCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
 
   # Add a private Git repository on Google Cloud Sources via GCP service account credentials
   argocd repo add https://source.developers.google.com/p/my-google-cloud-project/r/my-repo --gcp-service-account-key-path service-account-key.json
+
+  # Add or update all repositories and credential templates listed in a file
+  argocd repo add -f repos.yaml
 `
 
 	command := &cobra.Command{
@@ -111,6 +117,27 @@ func NewRepoAddCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
 		Run: func(c *cobra.Command, args []string) {
 			ctx := c.Context()
 
+			if file != "" {
+				if len(args) != 0 {
+					errors.Fatal(errors.ErrorGeneric, "REPOURL cannot be combined with --file")
+				}
+				repoFile, err := readRepositoriesFile(file)
+				errors.CheckError(err)
+
+				acdClient := headless.NewClientOrDie(clientOpts, c)
+				conn, repoIf := acdClient.NewRepoClientOrDie()
+				defer utilio.Close(conn)
+				credsConn, credsIf := acdClient.NewRepoCredsClientOrDie()
+				defer utilio.Close(credsConn)
+
+				results, err := addRepositoriesFromFile(ctx, repoIf, credsIf, repoFile)
+				errors.CheckError(err)
+				if failed := printRepoFileResults(os.Stdout, results); failed > 0 {
+					errors.Fatalf(errors.ErrorGeneric, "%d of %d entries could not be added", failed, len(results))
+				}
+				return
+			}
+
 			if len(args) != 1 {
 				c.HelpFunc()(c, args)
 				os.Exit(1)
@@ -203,9 +230,9 @@ func NewRepoAddCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
 			conn, repoIf := headless.NewClientOrDie(clientOpts, c).NewRepoClientOrDie()
 			defer utilio.Close(conn)
 
-			// If the user set a username, but didn't supply password via --password,
-			// then we prompt for it
-			if repoOpts.Repo.Username != "" && repoOpts.Repo.Password == "" {
+			// If the user set a username, but didn't supply password via --password
+			// nor a token exchange endpoint, then we prompt for it
+			if repoOpts.Repo.Username != "" && repoOpts.Repo.Password == "" && repoOpts.Repo.OIDCTokenExchangeURL == "" {
 				repoOpts.Repo.Password = cli.PromptPassword(repoOpts.Repo.Password)
 			}
 
@@ -223,30 +250,7 @@ func NewRepoAddCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
 			// Skip validation if we are just adding credentials template, chances
 			// are high that we do not have the given URL pointing to a valid Git
 			// repo anyway.
-			repoAccessReq := repositorypkg.RepoAccessQuery{
-				Repo:                       repoOpts.Repo.Repo,
-				Type:                       repoOpts.Repo.Type,
-				Name:                       repoOpts.Repo.Name,
-				Username:                   repoOpts.Repo.Username,
-				Password:                   repoOpts.Repo.Password,
-				BearerToken:                repoOpts.Repo.BearerToken,
-				SshPrivateKey:              repoOpts.Repo.SSHPrivateKey,
-				TlsClientCertData:          repoOpts.Repo.TLSClientCertData,
-				TlsClientCertKey:           repoOpts.Repo.TLSClientCertKey,
-				Insecure:                   repoOpts.Repo.IsInsecure(),
-				EnableOci:                  repoOpts.Repo.EnableOCI,
-				GithubAppPrivateKey:        repoOpts.Repo.GithubAppPrivateKey,
-				GithubAppID:                repoOpts.Repo.GithubAppId,
-				GithubAppInstallationID:    repoOpts.Repo.GithubAppInstallationId,
-				GithubAppEnterpriseBaseUrl: repoOpts.Repo.GitHubAppEnterpriseBaseURL,
-				Proxy:                      repoOpts.Proxy,
-				Project:                    repoOpts.Repo.Project,
-				GcpServiceAccountKey:       repoOpts.Repo.GCPServiceAccountKey,
-				ForceHttpBasicAuth:         repoOpts.Repo.ForceHttpBasicAuth,
-				UseAzureWorkloadIdentity:   repoOpts.Repo.UseAzureWorkloadIdentity,
-				InsecureOciForceHttp:       repoOpts.Repo.InsecureOCIForceHttp,
-			}
-			_, err = repoIf.ValidateAccess(ctx, &repoAccessReq)
+			_, err = repoIf.ValidateAccess(ctx, newRepoAccessQuery(&repoOpts.Repo))
 			errors.CheckError(err)
 
 			repoCreateReq := repositorypkg.RepoCreateRequest{
@@ -260,6 +264,7 @@ func NewRepoAddCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
 		},
 	}
 	command.Flags().BoolVar(&repoOpts.Upsert, "upsert", false, "Override an existing repository with the same name even if the spec differs")
+	command.Flags().StringVarP(&file, "file", "f", "", "Add or update the repositories and credential templates listed in a file instead of a single repository")
 	cmdutil.AddRepoFlags(command, &repoOpts)
 	return command
 }
@@ -0,0 +1,196 @@
+package commands
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	cmdutil "github.com/argoproj/argo-cd/v3/cmd/util"
+	repocredspkg "github.com/argoproj/argo-cd/v3/pkg/apiclient/repocreds"
+	repositorypkg "github.com/argoproj/argo-cd/v3/pkg/apiclient/repository"
+	appsv1 "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	"github.com/argoproj/argo-cd/v3/util/config"
+	"github.com/argoproj/argo-cd/v3/util/git"
+)
+
+const (
+	repoFileEntryRepository         = "repository"
+	repoFileEntryCredentialTemplate = "repocreds"
+
+	repoFileResultCreated = "created"
+	repoFileResultUpdated = "updated"
+	repoFileResultFailed  = "failed"
+)
+
+// repositoriesFile is the declarative list of repositories and credential templates accepted by `argocd repo add -f`
+type repositoriesFile struct {
+	CredentialTemplates []appsv1.RepoCreds  `json:"credentialTemplates,omitempty"`
+	Repositories        []appsv1.Repository `json:"repositories,omitempty"`
+}
+
+// repoFileEntryResult is the outcome of adding a single entry of a repositories file
+type repoFileEntryResult struct {
+	entryType string
+	url       string
+	project   string
+	result    string
+	err       error
+}
+
+func readRepositoriesFile(path string) (*repositoriesFile, error) {
+	var file repositoriesFile
+	if err := config.UnmarshalLocalFile(path, &file); err != nil {
+		return nil, fmt.Errorf("error reading repositories file %s: %w", path, err)
+	}
+	if len(file.Repositories) == 0 && len(file.CredentialTemplates) == 0 {
+		return nil, fmt.Errorf("repositories file %s does not contain any repositories or credential templates", path)
+	}
+	return &file, nil
+}
+
+// validateRepositoryEntry performs the same checks on a repository of a file as `argocd repo add` does on its flags
+func validateRepositoryEntry(repo *appsv1.Repository) error {
+	if repo.Repo == "" {
+		return stderrors.New("repository URL must not be empty")
+	}
+	if repo.Type == "helm" && repo.Name == "" {
+		return stderrors.New("name is mandatory for repositories of type 'helm'")
+	}
+	if err := cmdutil.ValidateBearerTokenAndPasswordCombo(repo.BearerToken, repo.Password); err != nil {
+		return err
+	}
+	if err := cmdutil.ValidateBearerTokenForGitOnly(repo.BearerToken, repo.Type); err != nil {
+		return err
+	}
+	return cmdutil.ValidateBearerTokenForHTTPSRepoOnly(repo.BearerToken, git.IsHTTPSURL(repo.Repo))
+}
+
+// validateCredentialTemplateEntry performs the same checks on a credential template of a file as
+// `argocd repocreds add` does on its flags
+func validateCredentialTemplateEntry(creds *appsv1.RepoCreds) error {
+	if creds.URL == "" {
+		return stderrors.New("credential template URL must not be empty")
+	}
+	if err := cmdutil.ValidateBearerTokenAndPasswordCombo(creds.BearerToken, creds.Password); err != nil {
+		return err
+	}
+	if err := cmdutil.ValidateBearerTokenForGitOnly(creds.BearerToken, creds.Type); err != nil {
+		return err
+	}
+	return cmdutil.ValidateBearerTokenForHTTPSRepoOnly(creds.BearerToken, git.IsHTTPSURL(creds.URL))
+}
+
+// newRepoAccessQuery returns the query used to let the server check access to a repository before adding it
+func newRepoAccessQuery(repo *appsv1.Repository) *repositorypkg.RepoAccessQuery {
+	return &repositorypkg.RepoAccessQuery{
+		Repo:                       repo.Repo,
+		Type:                       repo.Type,
+		Name:                       repo.Name,
+		Username:                   repo.Username,
+		Password:                   repo.Password,
+		BearerToken:                repo.BearerToken,
+		SshPrivateKey:              repo.SSHPrivateKey,
+		TlsClientCertData:          repo.TLSClientCertData,
+		TlsClientCertKey:           repo.TLSClientCertKey,
+		Insecure:                   repo.IsInsecure(),
+		EnableOci:                  repo.EnableOCI,
+		GithubAppPrivateKey:        repo.GithubAppPrivateKey,
+		GithubAppID:                repo.GithubAppId,
+		GithubAppInstallationID:    repo.GithubAppInstallationId,
+		GithubAppEnterpriseBaseUrl: repo.GitHubAppEnterpriseBaseURL,
+		Proxy:                      repo.Proxy,
+		Project:                    repo.Project,
+		GcpServiceAccountKey:       repo.GCPServiceAccountKey,
+		ForceHttpBasicAuth:         repo.ForceHttpBasicAuth,
+		UseAzureWorkloadIdentity:   repo.UseAzureWorkloadIdentity,
+		InsecureOciForceHttp:       repo.InsecureOCIForceHttp,
+		OidcTokenExchangeURL:       repo.OIDCTokenExchangeURL,
+		OidcTokenExchangeAudience:  repo.OIDCTokenExchangeAudience,
+		UseKerberos:                repo.UseKerberos,
+		NoProxy:                    repo.NoProxy,
+		ProxyUsername:              repo.ProxyUsername,
+		ProxyPassword:              repo.ProxyPassword,
+	}
+}
+
+// addRepositoriesFromFile upserts all credential templates and repositories of a file. Credential templates are added
+// first so that repositories without credentials of their own can inherit them when their access is validated.
+// A failing entry does not stop the remaining entries from being added.
+func addRepositoriesFromFile(ctx context.Context, repoIf repositorypkg.RepositoryServiceClient, credsIf repocredspkg.RepoCredsServiceClient, file *repositoriesFile) ([]repoFileEntryResult, error) {
+	var results []repoFileEntryResult
+
+	if len(file.CredentialTemplates) > 0 {
+		existingCreds, err := credsIf.ListRepositoryCredentials(ctx, &repocredspkg.RepoCredsQuery{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list repository credentials: %w", err)
+		}
+		existing := map[string]bool{}
+		for _, creds := range existingCreds.Items {
+			existing[creds.URL] = true
+		}
+		for i := range file.CredentialTemplates {
+			creds := file.CredentialTemplates[i]
+			res := repoFileEntryResult{entryType: repoFileEntryCredentialTemplate, url: creds.URL, result: repoFileResultCreated}
+			if existing[creds.URL] {
+				res.result = repoFileResultUpdated
+			}
+			res.err = validateCredentialTemplateEntry(&creds)
+			if res.err == nil {
+				_, res.err = credsIf.CreateRepositoryCredentials(ctx, &repocredspkg.RepoCredsCreateRequest{Creds: &creds, Upsert: true})
+			}
+			if res.err != nil {
+				res.result = repoFileResultFailed
+			}
+			results = append(results, res)
+		}
+	}
+
+	if len(file.Repositories) > 0 {
+		existingRepos, err := repoIf.ListRepositories(ctx, &repositorypkg.RepoQuery{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list repositories: %w", err)
+		}
+		existing := map[string]bool{}
+		for _, repo := range existingRepos.Items {
+			existing[repo.Repo+"/"+repo.Project] = true
+		}
+		for i := range file.Repositories {
+			repo := file.Repositories[i]
+			res := repoFileEntryResult{entryType: repoFileEntryRepository, url: repo.Repo, project: repo.Project, result: repoFileResultCreated}
+			if existing[repo.Repo+"/"+repo.Project] {
+				res.result = repoFileResultUpdated
+			}
+			res.err = validateRepositoryEntry(&repo)
+			if res.err == nil {
+				_, res.err = repoIf.ValidateAccess(ctx, newRepoAccessQuery(&repo))
+			}
+			if res.err == nil {
+				_, res.err = repoIf.CreateRepository(ctx, &repositorypkg.RepoCreateRequest{Repo: &repo, Upsert: true})
+			}
+			if res.err != nil {
+				res.result = repoFileResultFailed
+			}
+			results = append(results, res)
+		}
+	}
+	return results, nil
+}
+
+// printRepoFileResults prints the outcome of every entry of a repositories file and returns the number of failed entries
+func printRepoFileResults(out io.Writer, results []repoFileEntryResult) int {
+	failed := 0
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintf(w, "TYPE\tURL\tPROJECT\tRESULT\n")
+	for _, res := range results {
+		result := res.result
+		if res.err != nil {
+			failed++
+			result = fmt.Sprintf("%s: %v", result, res.err)
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", res.entryType, res.url, res.project, result)
+	}
+	_ = w.Flush()
+	return failed
+}
@@ -0,0 +1,118 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	stderrors "errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	repocredspkg "github.com/argoproj/argo-cd/v3/pkg/apiclient/repocreds"
+	repositorypkg "github.com/argoproj/argo-cd/v3/pkg/apiclient/repository"
+	appsv1 "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+)
+
+type fakeRepoServiceClient struct {
+	repositorypkg.RepositoryServiceClient
+	existing []appsv1.Repository
+	created  []*appsv1.Repository
+}
+
+func (f *fakeRepoServiceClient) ListRepositories(_ context.Context, _ *repositorypkg.RepoQuery, _ ...grpc.CallOption) (*appsv1.RepositoryList, error) {
+	list := &appsv1.RepositoryList{}
+	for i := range f.existing {
+		list.Items = append(list.Items, &f.existing[i])
+	}
+	return list, nil
+}
+
+func (f *fakeRepoServiceClient) ValidateAccess(_ context.Context, q *repositorypkg.RepoAccessQuery, _ ...grpc.CallOption) (*repositorypkg.RepoResponse, error) {
+	if q.Repo == "https://github.com/argoproj/private" {
+		return nil, stderrors.New("authentication required")
+	}
+	return &repositorypkg.RepoResponse{}, nil
+}
+
+func (f *fakeRepoServiceClient) CreateRepository(_ context.Context, req *repositorypkg.RepoCreateRequest, _ ...grpc.CallOption) (*appsv1.Repository, error) {
+	f.created = append(f.created, req.Repo)
+	return req.Repo, nil
+}
+
+type fakeRepoCredsServiceClient struct {
+	repocredspkg.RepoCredsServiceClient
+	created []*appsv1.RepoCreds
+}
+
+func (f *fakeRepoCredsServiceClient) ListRepositoryCredentials(_ context.Context, _ *repocredspkg.RepoCredsQuery, _ ...grpc.CallOption) (*appsv1.RepoCredsList, error) {
+	return &appsv1.RepoCredsList{}, nil
+}
+
+func (f *fakeRepoCredsServiceClient) CreateRepositoryCredentials(_ context.Context, req *repocredspkg.RepoCredsCreateRequest, _ ...grpc.CallOption) (*appsv1.RepoCreds, error) {
+	f.created = append(f.created, req.Creds)
+	return req.Creds, nil
+}
+
+func TestReadRepositoriesFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "repos.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`credentialTemplates:
+- url: https://github.com/argoproj
+  username: git
+  password: secret
+repositories:
+- repo: https://github.com/argoproj/argocd-example-apps
+- repo: https://charts.helm.sh/stable
+  type: helm
+  name: stable
+`), 0o600))
+
+	file, err := readRepositoriesFile(path)
+	require.NoError(t, err)
+	require.Len(t, file.CredentialTemplates, 1)
+	assert.Equal(t, "secret", file.CredentialTemplates[0].Password)
+	require.Len(t, file.Repositories, 2)
+	assert.Equal(t, "stable", file.Repositories[1].Name)
+
+	emptyPath := filepath.Join(dir, "empty.yaml")
+	require.NoError(t, os.WriteFile(emptyPath, []byte(`repositories: []`), 0o600))
+	_, err = readRepositoriesFile(emptyPath)
+	assert.ErrorContains(t, err, "does not contain any repositories")
+}
+
+func TestAddRepositoriesFromFile(t *testing.T) {
+	repoIf := &fakeRepoServiceClient{existing: []appsv1.Repository{{Repo: "https://github.com/argoproj/argocd-example-apps"}}}
+	credsIf := &fakeRepoCredsServiceClient{}
+	file := &repositoriesFile{
+		CredentialTemplates: []appsv1.RepoCreds{{URL: "https://github.com/argoproj", Username: "git", Password: "secret"}},
+		Repositories: []appsv1.Repository{
+			{Repo: "https://github.com/argoproj/argocd-example-apps"},
+			{Repo: "https://github.com/argoproj/argo-cd", Project: "default"},
+			{Repo: "https://charts.helm.sh/stable", Type: "helm"},
+			{Repo: "https://github.com/argoproj/private"},
+		},
+	}
+
+	results, err := addRepositoriesFromFile(t.Context(), repoIf, credsIf, file)
+	require.NoError(t, err)
+	require.Len(t, results, 5)
+	assert.Equal(t, repoFileResultCreated, results[0].result)
+	assert.Equal(t, repoFileEntryCredentialTemplate, results[0].entryType)
+	assert.Equal(t, repoFileResultUpdated, results[1].result)
+	assert.Equal(t, repoFileResultCreated, results[2].result)
+	assert.Equal(t, repoFileResultFailed, results[3].result)
+	assert.ErrorContains(t, results[3].err, "name is mandatory")
+	assert.Equal(t, repoFileResultFailed, results[4].result)
+	assert.ErrorContains(t, results[4].err, "authentication required")
+
+	assert.Len(t, credsIf.created, 1)
+	assert.Len(t, repoIf.created, 2)
+
+	var out bytes.Buffer
+	assert.Equal(t, 2, printRepoFileResults(&out, results))
+	assert.Contains(t, out.String(), "repository  https://github.com/argoproj/argo-cd              default  created")
+}
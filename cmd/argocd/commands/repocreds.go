@@ -161,9 +161,9 @@ func NewRepoCredsAddCommand(clientOpts *argocdclient.ClientOptions) *cobra.Comma
 			conn, repoIf := headless.NewClientOrDie(clientOpts, c).NewRepoCredsClientOrDie()
 			defer utilio.Close(conn)
 
-			// If the user set a username, but didn't supply password via --password,
-			// then we prompt for it
-			if repo.Username != "" && repo.Password == "" {
+			// If the user set a username, but didn't supply password via --password
+			// nor a token exchange endpoint, then we prompt for it
+			if repo.Username != "" && repo.Password == "" && repo.OIDCTokenExchangeURL == "" {
 				repo.Password = cli.PromptPassword(repo.Password)
 			}
 
@@ -200,7 +200,13 @@ func NewRepoCredsAddCommand(clientOpts *argocdclient.ClientOptions) *cobra.Comma
 	command.Flags().StringVar(&gcpServiceAccountKeyPath, "gcp-service-account-key-path", "", "service account key for the Google Cloud Platform")
 	command.Flags().BoolVar(&repo.ForceHttpBasicAuth, "force-http-basic-auth", false, "whether to force basic auth when connecting via HTTP")
 	command.Flags().BoolVar(&repo.UseAzureWorkloadIdentity, "use-azure-workload-identity", false, "whether to use azure workload identity for authentication")
+	command.Flags().StringVar(&repo.OIDCTokenExchangeURL, "oidc-token-exchange-url", "", "URL of the OAuth 2.0 token exchange endpoint exchanging the OIDC token of the repo server for access tokens to the Git repositories")
+	command.Flags().StringVar(&repo.OIDCTokenExchangeAudience, "oidc-token-exchange-audience", "", "audience of the access tokens requested from the OIDC token exchange endpoint")
+	command.Flags().BoolVar(&repo.UseKerberos, "use-kerberos", false, "whether to authenticate with the Kerberos tickets of the repo server using SPNEGO when connecting via HTTP")
 	command.Flags().StringVar(&repo.Proxy, "proxy-url", "", "If provided, this URL will be used to connect via proxy")
+	command.Flags().StringVar(&repo.NoProxy, "no-proxy", "", "don't access these targets via proxy")
+	command.Flags().StringVar(&repo.ProxyUsername, "proxy-username", "", "username authenticating to the proxy")
+	command.Flags().StringVar(&repo.ProxyPassword, "proxy-password", "", "password authenticating to the proxy")
 	return command
 }
 
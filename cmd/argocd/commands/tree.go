@@ -2,6 +2,8 @@ package commands
 
 import (
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 	"text/tabwriter"
 	"time"
@@ -163,3 +165,80 @@ func printPrefix(p string) string {
 	}
 	return p
 }
+
+// resourceSyncInfo holds the sync details of a resource which are not part of the resource tree
+type resourceSyncInfo struct {
+	syncWave string
+	hookType string
+}
+
+func resourceSyncInfoKey(group, kind, namespace, name string) string {
+	return fmt.Sprintf("%s/%s/%s/%s", group, kind, namespace, name)
+}
+
+// resourceSyncInfoByKey returns the sync wave of the managed resources of an application and the hook type of the
+// hooks executed by the last sync operation
+func resourceSyncInfoByKey(app *v1alpha1.Application) map[string]resourceSyncInfo {
+	infos := make(map[string]resourceSyncInfo)
+	if app == nil {
+		return infos
+	}
+	for _, res := range app.Status.Resources {
+		infos[resourceSyncInfoKey(res.Group, res.Kind, res.Namespace, res.Name)] = resourceSyncInfo{syncWave: strconv.FormatInt(res.SyncWave, 10)}
+	}
+	if app.Status.OperationState != nil && app.Status.OperationState.SyncResult != nil {
+		for _, res := range app.Status.OperationState.SyncResult.Resources {
+			if res.HookType == "" {
+				continue
+			}
+			key := resourceSyncInfoKey(res.Group, res.Kind, res.Namespace, res.Name)
+			info := infos[key]
+			info.hookType = string(res.HookType)
+			infos[key] = info
+		}
+	}
+	return infos
+}
+
+// resourceTreeRoots returns the nodes which have no parent within the given nodes, sorted by group, kind, namespace
+// and name
+func resourceTreeRoots(uidToNodeMap map[string]v1alpha1.ResourceNode) []v1alpha1.ResourceNode {
+	var roots []v1alpha1.ResourceNode
+	for _, node := range uidToNodeMap {
+		if len(node.ParentRefs) > 0 {
+			if _, ok := uidToNodeMap[node.ParentRefs[0].UID]; ok {
+				continue
+			}
+		}
+		roots = append(roots, node)
+	}
+	sort.Slice(roots, func(i, j int) bool {
+		return resourceSyncInfoKey(roots[i].Group, roots[i].Kind, roots[i].Namespace, roots[i].Name) < resourceSyncInfoKey(roots[j].Group, roots[j].Kind, roots[j].Namespace, roots[j].Name)
+	})
+	return roots
+}
+
+func fullTreeViewAppResources(prefix string, uidToNodeMap map[string]v1alpha1.ResourceNode, parentChildMap map[string][]string, parent v1alpha1.ResourceNode, orphaned string, syncInfos map[string]resourceSyncInfo, wide bool, w *tabwriter.Writer) {
+	healthStatus, reason := extractHealthStatusAndReason(parent)
+	if wide {
+		age := "<unknown>"
+		if parent.CreatedAt != nil {
+			age = duration.HumanDuration(time.Since(parent.CreatedAt.Time))
+		}
+		info := syncInfos[resourceSyncInfoKey(parent.Group, parent.Kind, parent.Namespace, parent.Name)]
+		_, _ = fmt.Fprintf(w, "%s%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", printPrefix(prefix), parent.Kind+"/"+parent.Name, parent.Namespace, orphaned, healthStatus, info.syncWave, info.hookType, age, reason)
+	} else {
+		_, _ = fmt.Fprintf(w, "%s%s\t%s\t%s\t%s\n", printPrefix(prefix), parent.Kind+"/"+parent.Name, parent.Namespace, orphaned, healthStatus)
+	}
+	chs := parentChildMap[parent.UID]
+	for i, child := range chs {
+		var p string
+		switch i {
+		case len(chs) - 1:
+			p = prefix + lastElemPrefix
+		default:
+			p = prefix + firstElemPrefix
+		}
+		fullTreeViewAppResources(p, uidToNodeMap, parentChildMap, uidToNodeMap[child], orphaned, syncInfos, wide, w)
+	}
+}
@@ -2,9 +2,12 @@ package commands
 
 import (
 	"bytes"
+	"strings"
 	"testing"
 	"text/tabwriter"
 
+	"github.com/argoproj/gitops-engine/pkg/health"
+	synccommon "github.com/argoproj/gitops-engine/pkg/sync/common"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -168,6 +171,80 @@ func TestTreeViewDetailedAppResources(t *testing.T) {
 	assert.Contains(t, output, "Readiness Gate failed")
 }
 
+func TestPrintResourceTree(t *testing.T) {
+	rollout := v1alpha1.ResourceNode{
+		ResourceRef: v1alpha1.ResourceRef{Group: "argoproj.io", Kind: "Rollout", Namespace: "default", Name: "demo", UID: "rollout-uid"},
+		Health:      &v1alpha1.HealthStatus{Status: health.HealthStatusHealthy},
+	}
+	replicaSet := v1alpha1.ResourceNode{
+		ResourceRef: v1alpha1.ResourceRef{Group: "apps", Kind: "ReplicaSet", Namespace: "default", Name: "demo-5dcd5457d5", UID: "rs-uid"},
+		ParentRefs:  []v1alpha1.ResourceRef{rollout.ResourceRef},
+	}
+	pod := v1alpha1.ResourceNode{
+		ResourceRef: v1alpha1.ResourceRef{Kind: "Pod", Namespace: "default", Name: "demo-5dcd5457d5-abcde", UID: "pod-uid"},
+		ParentRefs:  []v1alpha1.ResourceRef{replicaSet.ResourceRef},
+		Health:      &v1alpha1.HealthStatus{Status: health.HealthStatusDegraded, Message: "Readiness Gate failed"},
+	}
+	job := v1alpha1.ResourceNode{
+		ResourceRef: v1alpha1.ResourceRef{Group: "batch", Kind: "Job", Namespace: "default", Name: "migrate", UID: "job-uid"},
+	}
+	orphan := v1alpha1.ResourceNode{
+		ResourceRef: v1alpha1.ResourceRef{Kind: "ConfigMap", Namespace: "default", Name: "leftover", UID: "cm-uid"},
+	}
+	resourceTree := &v1alpha1.ApplicationTree{
+		Nodes:         []v1alpha1.ResourceNode{pod, replicaSet, rollout, job},
+		OrphanedNodes: []v1alpha1.ResourceNode{orphan},
+	}
+	app := &v1alpha1.Application{
+		Status: v1alpha1.ApplicationStatus{
+			Resources: []v1alpha1.ResourceStatus{
+				{Group: "argoproj.io", Kind: "Rollout", Namespace: "default", Name: "demo", SyncWave: 2},
+				{Group: "batch", Kind: "Job", Namespace: "default", Name: "migrate", SyncWave: -1, Hook: true},
+			},
+			OperationState: &v1alpha1.OperationState{
+				SyncResult: &v1alpha1.SyncOperationResult{
+					Resources: v1alpha1.ResourceResults{
+						{Group: "batch", Kind: "Job", Namespace: "default", Name: "migrate", HookType: synccommon.HookTypePreSync},
+					},
+				},
+			},
+		},
+	}
+
+	t.Run("Tree", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		printResourceTree(buf, true, false, resourceTree, nil, false)
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		require.Len(t, lines, 6)
+		assert.Equal(t, []string{"NAME", "NAMESPACE", "ORPHANED", "HEALTH"}, strings.Fields(lines[0]))
+		assert.Equal(t, []string{"Rollout/demo", "default", "No", "Healthy"}, strings.Fields(lines[1]))
+		assert.Equal(t, []string{lastElemPrefix + "ReplicaSet/demo-5dcd5457d5", "default", "No"}, strings.Fields(lines[2]))
+		assert.Equal(t, []string{lastElemPrefix + "Pod/demo-5dcd5457d5-abcde", "default", "No", "Degraded"}, strings.Fields(lines[3]))
+		assert.True(t, strings.HasPrefix(lines[3], "  "+lastElemPrefix))
+		assert.Equal(t, []string{"Job/migrate", "default", "No"}, strings.Fields(lines[4]))
+		assert.Equal(t, []string{"ConfigMap/leftover", "default", "Yes"}, strings.Fields(lines[5]))
+	})
+
+	t.Run("Wide", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		printResourceTree(buf, false, false, resourceTree, app, true)
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		require.Len(t, lines, 5)
+		assert.Equal(t, []string{"NAME", "NAMESPACE", "ORPHANED", "HEALTH", "SYNC", "WAVE", "HOOK", "AGE", "MESSAGE"}, strings.Fields(lines[0]))
+		assert.Equal(t, []string{"Rollout/demo", "default", "No", "Healthy", "2", "<unknown>"}, strings.Fields(lines[1]))
+		assert.Equal(t, []string{lastElemPrefix + "Pod/demo-5dcd5457d5-abcde", "default", "No", "Degraded", "<unknown>", "Readiness", "Gate", "failed"}, strings.Fields(lines[3]))
+		assert.Equal(t, []string{"Job/migrate", "default", "No", "-1", "PreSync", "<unknown>"}, strings.Fields(lines[4]))
+	})
+
+	t.Run("Orphaned", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		printResourceTree(buf, false, true, resourceTree, nil, false)
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		require.Len(t, lines, 2)
+		assert.Equal(t, []string{"ConfigMap/leftover", "default", "Yes"}, strings.Fields(lines[1]))
+	})
+}
+
 func TestPrintPrefix(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -21,6 +21,8 @@ import (
 	apiserver "github.com/argoproj/argo-cd/v3/cmd/argocd-server/commands"
 	cli "github.com/argoproj/argo-cd/v3/cmd/argocd/commands"
 	"github.com/argoproj/argo-cd/v3/cmd/util"
+	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	"github.com/argoproj/argo-cd/v3/util/clustertoken"
 	"github.com/argoproj/argo-cd/v3/util/log"
 )
 
@@ -31,6 +33,7 @@ const (
 func init() {
 	// Make sure klog uses the configured log level and format.
 	klog.SetLogger(log.NewLogrusLogger(log.NewWithCurrentConfig()))
+	v1alpha1.SetClusterTokenSourceFunc(clustertoken.TokenSource)
 }
 
 func main() {
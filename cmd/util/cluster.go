@@ -158,6 +158,7 @@ type ClusterOptions struct {
 	AwsClusterName          string
 	SystemNamespace         string
 	Namespaces              []string
+	ResourceGroups          []string
 	ClusterResources        bool
 	Name                    string
 	Project                 string
@@ -170,6 +171,35 @@ type ClusterOptions struct {
 	ClusterEndpoint         string
 	DisableCompression      bool
 	ProxyUrl                string //nolint:revive //FIXME(var-naming)
+	ProxyUsername           string
+	ProxyPassword           string
+	JumpHost                string
+	JumpHostUsername        string
+	JumpHostSSHKeyPath      string
+	JumpHostKnownHostsPath  string
+	Draining                bool
+	MinReadyNodes           int64
+}
+
+// JumpHostConfig returns the configuration of the SSH jump host of the cluster, or nil if no jump host is set
+func (o ClusterOptions) JumpHostConfig() (*argoappv1.JumpHostConfig, error) {
+	if o.JumpHost == "" {
+		return nil, nil
+	}
+	sshPrivateKey, err := os.ReadFile(o.JumpHostSSHKeyPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read jump host SSH private key: %w", err)
+	}
+	knownHosts, err := os.ReadFile(o.JumpHostKnownHostsPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read jump host known hosts: %w", err)
+	}
+	return &argoappv1.JumpHostConfig{
+		Address:       o.JumpHost,
+		Username:      o.JumpHostUsername,
+		SSHPrivateKey: string(sshPrivateKey),
+		KnownHosts:    string(knownHosts),
+	}, nil
 }
 
 // InClusterEndpoint returns true if ArgoCD should reference the in-cluster
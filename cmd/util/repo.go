@@ -49,8 +49,13 @@ func AddRepoFlags(command *cobra.Command, opts *RepoOptions) {
 	command.Flags().StringVar(&opts.GitHubAppEnterpriseBaseURL, "github-app-enterprise-base-url", "", "base url to use when using GitHub Enterprise (e.g. https://ghe.example.com/api/v3")
 	command.Flags().StringVar(&opts.Proxy, "proxy", "", "use proxy to access repository")
 	command.Flags().StringVar(&opts.NoProxy, "no-proxy", "", "don't access these targets via proxy")
+	command.Flags().StringVar(&opts.Repo.ProxyUsername, "proxy-username", "", "username authenticating to the proxy")
+	command.Flags().StringVar(&opts.Repo.ProxyPassword, "proxy-password", "", "password authenticating to the proxy")
 	command.Flags().StringVar(&opts.GCPServiceAccountKeyPath, "gcp-service-account-key-path", "", "service account key for the Google Cloud Platform")
 	command.Flags().BoolVar(&opts.ForceHttpBasicAuth, "force-http-basic-auth", false, "whether to force use of basic auth when connecting repository via HTTP")
 	command.Flags().BoolVar(&opts.UseAzureWorkloadIdentity, "use-azure-workload-identity", false, "whether to use azure workload identity for authentication")
+	command.Flags().StringVar(&opts.Repo.OIDCTokenExchangeURL, "oidc-token-exchange-url", "", "URL of the OAuth 2.0 token exchange endpoint exchanging the OIDC token of the repo server for access tokens to the Git repository")
+	command.Flags().StringVar(&opts.Repo.OIDCTokenExchangeAudience, "oidc-token-exchange-audience", "", "audience of the access tokens requested from the OIDC token exchange endpoint")
+	command.Flags().BoolVar(&opts.Repo.UseKerberos, "use-kerberos", false, "whether to authenticate with the Kerberos tickets of the repo server using SPNEGO when connecting repository via HTTP")
 	command.Flags().BoolVar(&opts.InsecureOCIForceHTTP, "insecure-oci-force-http", false, "Use http when accessing an OCI repository")
 }
@@ -470,11 +470,13 @@ func (m *File) GetChunk() []byte {
 
 // CheckPluginConfigurationResponse contains a list of plugin configuration flags.
 type CheckPluginConfigurationResponse struct {
-	IsDiscoveryConfigured bool     `protobuf:"varint,1,opt,name=isDiscoveryConfigured,proto3" json:"isDiscoveryConfigured,omitempty"`
-	ProvideGitCreds       bool     `protobuf:"varint,2,opt,name=provideGitCreds,proto3" json:"provideGitCreds,omitempty"`
-	XXX_NoUnkeyedLiteral  struct{} `json:"-"`
-	XXX_unrecognized      []byte   `json:"-"`
-	XXX_sizecache         int32    `json:"-"`
+	IsDiscoveryConfigured bool `protobuf:"varint,1,opt,name=isDiscoveryConfigured,proto3" json:"isDiscoveryConfigured,omitempty"`
+	ProvideGitCreds       bool `protobuf:"varint,2,opt,name=provideGitCreds,proto3" json:"provideGitCreds,omitempty"`
+	// version of the plugin, empty if the plugin isn't versioned
+	Version              string   `protobuf:"bytes,3,opt,name=version,proto3" json:"version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *CheckPluginConfigurationResponse) Reset()         { *m = CheckPluginConfigurationResponse{} }
@@ -524,6 +526,13 @@ func (m *CheckPluginConfigurationResponse) GetProvideGitCreds() bool {
 	return false
 }
 
+func (m *CheckPluginConfigurationResponse) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*AppStreamRequest)(nil), "plugin.AppStreamRequest")
 	proto.RegisterType((*ManifestRequestMetadata)(nil), "plugin.ManifestRequestMetadata")
@@ -538,49 +547,50 @@ func init() {
 func init() { proto.RegisterFile("cmpserver/plugin/plugin.proto", fileDescriptor_b21875a7079a06ed) }
 
 var fileDescriptor_b21875a7079a06ed = []byte{
-	// 668 bytes of a gzipped FileDescriptorProto
+	// 675 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x54, 0xcd, 0x6e, 0xd3, 0x40,
 	0x10, 0x8e, 0x9b, 0xb4, 0x4d, 0x26, 0x95, 0x1a, 0xad, 0xa0, 0x98, 0xd0, 0x86, 0xe0, 0x03, 0xca,
 	0x05, 0x47, 0x4a, 0x7b, 0x45, 0xa2, 0x2d, 0xa1, 0x15, 0x28, 0x28, 0x72, 0xb9, 0xc0, 0x01, 0x69,
-	0xe3, 0x4c, 0x92, 0xa5, 0xf6, 0xee, 0xb2, 0x5e, 0x5b, 0x0a, 0x5c, 0x10, 0x2f, 0xc3, 0xab, 0x70,
-	0xe4, 0x11, 0x50, 0x5f, 0x83, 0x0b, 0xf2, 0xda, 0x4e, 0xa2, 0x36, 0x6d, 0x4f, 0x9e, 0xbf, 0xfd,
-	0xfc, 0xcd, 0xec, 0x37, 0x0b, 0x07, 0x7e, 0x28, 0x23, 0x54, 0x09, 0xaa, 0xae, 0x0c, 0xe2, 0x29,
-	0xe3, 0xf9, 0xc7, 0x95, 0x4a, 0x68, 0x41, 0xb6, 0x32, 0xaf, 0xd9, 0x9f, 0x32, 0x3d, 0x8b, 0x47,
-	0xae, 0x2f, 0xc2, 0x2e, 0x55, 0x53, 0x21, 0x95, 0xf8, 0x62, 0x8c, 0x17, 0xfe, 0xb8, 0x9b, 0x1c,
-	0x76, 0x15, 0x4a, 0x91, 0xc3, 0x18, 0x93, 0x69, 0xa1, 0xe6, 0x2b, 0x66, 0x06, 0xd7, 0x7c, 0x32,
-	0x15, 0x62, 0x1a, 0x60, 0xd7, 0x78, 0xa3, 0x78, 0xd2, 0xc5, 0x50, 0xea, 0x3c, 0xe9, 0xfc, 0xb0,
-	0xa0, 0x71, 0x2c, 0xe5, 0x85, 0x56, 0x48, 0x43, 0x0f, 0xbf, 0xc6, 0x18, 0x69, 0xf2, 0x12, 0xaa,
-	0x21, 0x6a, 0x3a, 0xa6, 0x9a, 0xda, 0x56, 0xdb, 0xea, 0xd4, 0x7b, 0x4f, 0xdd, 0x9c, 0xe1, 0x80,
-	0x72, 0x36, 0xc1, 0x48, 0xe7, 0xa5, 0x83, 0xbc, 0xec, 0xbc, 0xe4, 0x2d, 0x8e, 0x10, 0x07, 0x2a,
-	0x13, 0x16, 0xa0, 0xbd, 0x61, 0x8e, 0xee, 0x14, 0x47, 0xdf, 0xb0, 0x00, 0xcf, 0x4b, 0x9e, 0xc9,
-	0x9d, 0xd4, 0x60, 0x5b, 0x65, 0x10, 0xce, 0x2f, 0x0b, 0x1e, 0xdd, 0x02, 0x4b, 0x6c, 0xd8, 0xa6,
-	0x52, 0xbe, 0xa7, 0x21, 0x1a, 0x22, 0x35, 0xaf, 0x70, 0x49, 0x0b, 0x80, 0x4a, 0xe9, 0x61, 0x30,
-	0xa4, 0x7a, 0x66, 0x7e, 0x55, 0xf3, 0x56, 0x22, 0xa4, 0x09, 0x55, 0x7f, 0x86, 0xfe, 0x65, 0x14,
-	0x87, 0x76, 0xd9, 0x64, 0x17, 0x3e, 0x21, 0x50, 0x89, 0xd8, 0x37, 0xb4, 0x2b, 0x6d, 0xab, 0x53,
-	0xf6, 0x8c, 0x4d, 0x1c, 0x28, 0x23, 0x4f, 0xec, 0xcd, 0x76, 0xb9, 0x53, 0xef, 0x35, 0x0a, 0xce,
-	0x7d, 0x9e, 0xf4, 0xb9, 0x56, 0x73, 0x2f, 0x4d, 0x3a, 0x47, 0x50, 0x2d, 0x02, 0x29, 0x06, 0x5f,
-	0xd2, 0x32, 0x36, 0x79, 0x00, 0x9b, 0x09, 0x0d, 0x62, 0xcc, 0xe9, 0x64, 0x8e, 0x33, 0x84, 0xc6,
-	0xb2, 0xbd, 0x48, 0x0a, 0x1e, 0x21, 0xd9, 0x87, 0x5a, 0x98, 0xc7, 0x22, 0xdb, 0x6a, 0x97, 0x3b,
-	0x35, 0x6f, 0x19, 0x48, 0x7b, 0x8b, 0x44, 0xac, 0x7c, 0xfc, 0x30, 0x97, 0x05, 0xd8, 0x4a, 0xc4,
-	0x99, 0x00, 0xf1, 0x16, 0xb7, 0xbc, 0xc0, 0x6c, 0x43, 0x9d, 0x45, 0x17, 0xb1, 0x94, 0x42, 0x69,
-	0x1c, 0x1b, 0x62, 0x55, 0x6f, 0x35, 0x44, 0x5c, 0x20, 0x2c, 0x7a, 0xcd, 0x22, 0x5f, 0x24, 0xa8,
-	0xe6, 0x7d, 0x4e, 0x47, 0x01, 0x8e, 0x0d, 0x7e, 0xd5, 0x5b, 0x93, 0x71, 0xbe, 0x43, 0x6b, 0x48,
-	0x15, 0x0d, 0x51, 0xa3, 0x8a, 0x8e, 0x39, 0x17, 0x31, 0xf7, 0x31, 0x44, 0xbe, 0xec, 0xe3, 0x23,
-	0xec, 0xc9, 0xa2, 0x62, 0xb5, 0x20, 0x6b, 0xaa, 0xde, 0x7b, 0xe6, 0xae, 0xc8, 0x71, 0xb8, 0xae,
-	0xd2, 0xbb, 0x05, 0xc0, 0xd9, 0x87, 0x4a, 0xaa, 0x98, 0x74, 0xa8, 0xfe, 0x2c, 0xe6, 0x97, 0xa6,
-	0xa1, 0x1d, 0x2f, 0x73, 0x9c, 0x9f, 0x16, 0xb4, 0x4f, 0xd3, 0xfb, 0x1c, 0x9a, 0x8b, 0x3a, 0x15,
-	0x7c, 0xc2, 0xa6, 0xb1, 0xa2, 0x9a, 0x09, 0xbe, 0x60, 0x77, 0x04, 0x0f, 0x57, 0xba, 0x2a, 0x6a,
-	0x16, 0xb3, 0x59, 0x9f, 0x24, 0x1d, 0xd8, 0x95, 0x4a, 0x24, 0x6c, 0x8c, 0x67, 0x4c, 0x9f, 0x2a,
-	0x1c, 0x47, 0xf9, 0x88, 0xae, 0x87, 0x7b, 0xff, 0x36, 0xe0, 0x20, 0x3b, 0x38, 0xa0, 0x9c, 0x4e,
-	0x0d, 0xf1, 0x8c, 0xcf, 0x05, 0xaa, 0x84, 0xf9, 0x48, 0xde, 0x42, 0xe3, 0x0c, 0x39, 0x2a, 0xaa,
-	0xb1, 0xd0, 0x00, 0xb1, 0x0b, 0x71, 0x5d, 0xdf, 0xbb, 0xa6, 0x7d, 0x73, 0xcb, 0xb2, 0x4e, 0x9c,
-	0x52, 0xc7, 0x22, 0x9f, 0xc1, 0xbe, 0xad, 0x63, 0xb2, 0xe7, 0x66, 0x4b, 0xee, 0x16, 0x4b, 0xee,
-	0xf6, 0xd3, 0x25, 0x6f, 0x76, 0x0a, 0xc4, 0xfb, 0x66, 0xe5, 0x94, 0xc8, 0x3b, 0xd8, 0x1d, 0x50,
-	0xed, 0xcf, 0x96, 0xd2, 0xba, 0x83, 0x6a, 0xb3, 0xc8, 0xdc, 0x14, 0xa2, 0x21, 0x4b, 0xe1, 0xf1,
-	0x19, 0xea, 0xf5, 0xea, 0xb9, 0x03, 0xf6, 0x79, 0x91, 0xb9, 0x5b, 0x77, 0xe9, 0x2f, 0x4e, 0x5e,
-	0xfd, 0xbe, 0x6a, 0x59, 0x7f, 0xae, 0x5a, 0xd6, 0xdf, 0xab, 0x96, 0xf5, 0xa9, 0x77, 0xcf, 0x63,
-	0xb9, 0x7c, 0x72, 0xa9, 0x64, 0x7e, 0xc0, 0x90, 0xeb, 0xd1, 0x96, 0x99, 0xd6, 0xe1, 0xff, 0x00,
-	0x00, 0x00, 0xff, 0xff, 0xb0, 0x8d, 0xb8, 0x47, 0x90, 0x05, 0x00, 0x00,
+	0xe3, 0x4c, 0x92, 0xa5, 0xf6, 0xee, 0xb2, 0x5e, 0x5b, 0x0a, 0x5c, 0x78, 0x10, 0xee, 0xbc, 0x0a,
+	0x47, 0x1e, 0x01, 0xf5, 0x35, 0xb8, 0x20, 0xaf, 0xed, 0x24, 0x6a, 0xd3, 0xf6, 0xe4, 0xf9, 0xdb,
+	0xcf, 0xdf, 0xcc, 0x7e, 0xb3, 0x70, 0xe0, 0x87, 0x32, 0x42, 0x95, 0xa0, 0xea, 0xca, 0x20, 0x9e,
+	0x32, 0x9e, 0x7f, 0x5c, 0xa9, 0x84, 0x16, 0x64, 0x2b, 0xf3, 0x9a, 0xfd, 0x29, 0xd3, 0xb3, 0x78,
+	0xe4, 0xfa, 0x22, 0xec, 0x52, 0x35, 0x15, 0x52, 0x89, 0x2f, 0xc6, 0x78, 0xe1, 0x8f, 0xbb, 0xc9,
+	0x61, 0x57, 0xa1, 0x14, 0x39, 0x8c, 0x31, 0x99, 0x16, 0x6a, 0xbe, 0x62, 0x66, 0x70, 0xcd, 0x27,
+	0x53, 0x21, 0xa6, 0x01, 0x76, 0x8d, 0x37, 0x8a, 0x27, 0x5d, 0x0c, 0xa5, 0xce, 0x93, 0xce, 0x0f,
+	0x0b, 0x1a, 0xc7, 0x52, 0x5e, 0x68, 0x85, 0x34, 0xf4, 0xf0, 0x6b, 0x8c, 0x91, 0x26, 0x2f, 0xa1,
+	0x1a, 0xa2, 0xa6, 0x63, 0xaa, 0xa9, 0x6d, 0xb5, 0xad, 0x4e, 0xbd, 0xf7, 0xd4, 0xcd, 0x19, 0x0e,
+	0x28, 0x67, 0x13, 0x8c, 0x74, 0x5e, 0x3a, 0xc8, 0xcb, 0xce, 0x4b, 0xde, 0xe2, 0x08, 0x71, 0xa0,
+	0x32, 0x61, 0x01, 0xda, 0x1b, 0xe6, 0xe8, 0x4e, 0x71, 0xf4, 0x0d, 0x0b, 0xf0, 0xbc, 0xe4, 0x99,
+	0xdc, 0x49, 0x0d, 0xb6, 0x55, 0x06, 0xe1, 0xfc, 0xb2, 0xe0, 0xd1, 0x2d, 0xb0, 0xc4, 0x86, 0x6d,
+	0x2a, 0xe5, 0x7b, 0x1a, 0xa2, 0x21, 0x52, 0xf3, 0x0a, 0x97, 0xb4, 0x00, 0xa8, 0x94, 0x1e, 0x06,
+	0x43, 0xaa, 0x67, 0xe6, 0x57, 0x35, 0x6f, 0x25, 0x42, 0x9a, 0x50, 0xf5, 0x67, 0xe8, 0x5f, 0x46,
+	0x71, 0x68, 0x97, 0x4d, 0x76, 0xe1, 0x13, 0x02, 0x95, 0x88, 0x7d, 0x43, 0xbb, 0xd2, 0xb6, 0x3a,
+	0x65, 0xcf, 0xd8, 0xc4, 0x81, 0x32, 0xf2, 0xc4, 0xde, 0x6c, 0x97, 0x3b, 0xf5, 0x5e, 0xa3, 0xe0,
+	0xdc, 0xe7, 0x49, 0x9f, 0x6b, 0x35, 0xf7, 0xd2, 0xa4, 0x73, 0x04, 0xd5, 0x22, 0x90, 0x62, 0xf0,
+	0x25, 0x2d, 0x63, 0x93, 0x07, 0xb0, 0x99, 0xd0, 0x20, 0xc6, 0x9c, 0x4e, 0xe6, 0x38, 0x43, 0x68,
+	0x2c, 0xdb, 0x8b, 0xa4, 0xe0, 0x11, 0x92, 0x7d, 0xa8, 0x85, 0x79, 0x2c, 0xb2, 0xad, 0x76, 0xb9,
+	0x53, 0xf3, 0x96, 0x81, 0xb4, 0xb7, 0x48, 0xc4, 0xca, 0xc7, 0x0f, 0x73, 0x59, 0x80, 0xad, 0x44,
+	0x9c, 0x09, 0x10, 0x6f, 0x71, 0xcb, 0x0b, 0xcc, 0x36, 0xd4, 0x59, 0x74, 0x11, 0x4b, 0x29, 0x94,
+	0xc6, 0xb1, 0x21, 0x56, 0xf5, 0x56, 0x43, 0xc4, 0x05, 0xc2, 0xa2, 0xd7, 0x2c, 0xf2, 0x45, 0x82,
+	0x6a, 0xde, 0xe7, 0x74, 0x14, 0xe0, 0xd8, 0xe0, 0x57, 0xbd, 0x35, 0x19, 0xe7, 0x3b, 0xb4, 0x86,
+	0x54, 0xd1, 0x10, 0x35, 0xaa, 0xe8, 0x98, 0x73, 0x11, 0x73, 0x1f, 0x43, 0xe4, 0xcb, 0x3e, 0x3e,
+	0xc2, 0x9e, 0x2c, 0x2a, 0x56, 0x0b, 0xb2, 0xa6, 0xea, 0xbd, 0x67, 0xee, 0x8a, 0x1c, 0x87, 0xeb,
+	0x2a, 0xbd, 0x5b, 0x00, 0x9c, 0x7d, 0xa8, 0xa4, 0x8a, 0x49, 0x87, 0xea, 0xcf, 0x62, 0x7e, 0x69,
+	0x1a, 0xda, 0xf1, 0x32, 0xc7, 0xf9, 0x69, 0x41, 0xfb, 0x34, 0xbd, 0xcf, 0xa1, 0xb9, 0xa8, 0x53,
+	0xc1, 0x27, 0x6c, 0x1a, 0x2b, 0xaa, 0x99, 0xe0, 0x0b, 0x76, 0x47, 0xf0, 0x70, 0xa5, 0xab, 0xa2,
+	0x66, 0x31, 0x9b, 0xf5, 0x49, 0xd2, 0x81, 0x5d, 0xa9, 0x44, 0xc2, 0xc6, 0x78, 0xc6, 0xf4, 0xa9,
+	0xc2, 0x71, 0x94, 0x8f, 0xe8, 0x7a, 0x38, 0x55, 0x67, 0x82, 0x2a, 0x62, 0x82, 0xe7, 0x12, 0x2b,
+	0xdc, 0xde, 0xbf, 0x0d, 0x38, 0xc8, 0x20, 0x07, 0x94, 0xd3, 0xa9, 0x69, 0x29, 0x63, 0x7a, 0x81,
+	0x2a, 0x61, 0x3e, 0x92, 0xb7, 0xd0, 0x38, 0x43, 0x8e, 0x8a, 0x6a, 0x2c, 0xd4, 0x41, 0xec, 0x42,
+	0x76, 0xd7, 0x37, 0xb2, 0x69, 0xdf, 0xdc, 0xbf, 0xac, 0x47, 0xa7, 0xd4, 0xb1, 0xc8, 0x67, 0xb0,
+	0x6f, 0x9b, 0x05, 0xd9, 0x73, 0xb3, 0xf5, 0x77, 0x8b, 0xf5, 0x77, 0xfb, 0xe9, 0xfa, 0x37, 0x3b,
+	0x05, 0xe2, 0x7d, 0x53, 0x74, 0x4a, 0xe4, 0x1d, 0xec, 0x0e, 0xa8, 0xf6, 0x67, 0x4b, 0xd1, 0xdd,
+	0x41, 0xb5, 0x59, 0x64, 0x6e, 0x4a, 0xd4, 0x90, 0xa5, 0xf0, 0xf8, 0x0c, 0xf5, 0x7a, 0x5d, 0xdd,
+	0x01, 0xfb, 0xbc, 0xc8, 0xdc, 0xad, 0xc8, 0xf4, 0x17, 0x27, 0xaf, 0x7e, 0x5f, 0xb5, 0xac, 0x3f,
+	0x57, 0x2d, 0xeb, 0xef, 0x55, 0xcb, 0xfa, 0xd4, 0xbb, 0xe7, 0x19, 0x5d, 0x3e, 0xc6, 0x54, 0x32,
+	0x3f, 0x60, 0xc8, 0xf5, 0x68, 0xcb, 0x4c, 0xeb, 0xf0, 0xff, 0x00, 0xec, 0x6c, 0x43, 0x9d, 0xaa,
+	0x05, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -1261,6 +1271,13 @@ func (m *CheckPluginConfigurationResponse) MarshalToSizedBuffer(dAtA []byte) (in
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if len(m.Version) > 0 {
+		i -= len(m.Version)
+		copy(dAtA[i:], m.Version)
+		i = encodeVarintPlugin(dAtA, i, uint64(len(m.Version)))
+		i--
+		dAtA[i] = 0x1a
+	}
 	if m.ProvideGitCreds {
 		i--
 		if m.ProvideGitCreds {
@@ -1473,6 +1490,10 @@ func (m *CheckPluginConfigurationResponse) Size() (n int) {
 	if m.ProvideGitCreds {
 		n += 2
 	}
+	l = len(m.Version)
+	if l > 0 {
+		n += 1 + l + sovPlugin(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -2366,6 +2387,38 @@ func (m *CheckPluginConfigurationResponse) Unmarshal(dAtA []byte) error {
 				}
 			}
 			m.ProvideGitCreds = bool(v != 0)
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPlugin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPlugin
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPlugin
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Version = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPlugin(dAtA[iNdEx:])
@@ -873,6 +873,7 @@ func TestService_CheckPluginConfiguration(t *testing.T) {
 		// then
 		require.NoError(t, err)
 		assert.True(t, resp.IsDiscoveryConfigured)
+		assert.Equal(t, "v1.0", resp.Version)
 	})
 
 	t.Run("discovery is disabled when is not configured", func(t *testing.T) {
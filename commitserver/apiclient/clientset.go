@@ -1,44 +1,98 @@
 package apiclient
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"math"
+	"time"
 
 	"github.com/argoproj/argo-cd/v3/common"
 	"github.com/argoproj/argo-cd/v3/util/env"
 
+	grpc_retry "github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/retry"
+	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/timeout"
 	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 
+	grpc_util "github.com/argoproj/argo-cd/v3/util/grpc"
 	utilio "github.com/argoproj/argo-cd/v3/util/io"
+	"github.com/argoproj/argo-cd/v3/util/spiffe"
 )
 
 // MaxGRPCMessageSize contains max grpc message size
 var MaxGRPCMessageSize = env.ParseNumFromEnv(common.EnvGRPCMaxSizeMB, 100, 0, math.MaxInt32) * 1024 * 1024
 
+// TLSConfiguration describes parameters for TLS configuration to be used by a commit server API client
+type TLSConfiguration struct {
+	// Whether to disable TLS for connections
+	DisableTLS bool
+	// Whether to enforce strict validation of TLS certificates
+	StrictValidation bool
+	// List of certificates to validate the peer against (if StrictCerts is true)
+	Certificates *x509.CertPool
+}
+
 // Clientset represents commit server api clients
 type Clientset interface {
 	NewCommitServerClient() (utilio.Closer, CommitServiceClient, error)
 }
 
 type clientSet struct {
-	address string
+	address        string
+	timeoutSeconds int
+	tlsConfig      TLSConfiguration
 }
 
 // NewCommitServerClient creates new instance of commit server client
 func (c *clientSet) NewCommitServerClient() (utilio.Closer, CommitServiceClient, error) {
-	conn, err := NewConnection(c.address)
+	conn, err := NewConnection(c.address, c.timeoutSeconds, &c.tlsConfig)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to open a new connection to commit server: %w", err)
 	}
 	return conn, NewCommitServiceClient(conn), nil
 }
 
-// NewConnection creates new connection to commit server
-func NewConnection(address string) (*grpc.ClientConn, error) {
-	var opts []grpc.DialOption
-	opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+// NewConnection creates new connection to commit server. The calls are retried on transient errors and traced, and
+// the connection uses mTLS if a SPIFFE workload identity is configured, regardless of the TLS configuration.
+func NewConnection(address string, timeoutSeconds int, tlsConfig *TLSConfiguration) (*grpc.ClientConn, error) {
+	retryOpts := []grpc_retry.CallOption{
+		grpc_retry.WithMax(3),
+		grpc_retry.WithBackoff(grpc_retry.BackoffLinear(1000 * time.Millisecond)),
+	}
+	unaryInterceptors := []grpc.UnaryClientInterceptor{grpc_retry.UnaryClientInterceptor(retryOpts...)}
+	if timeoutSeconds > 0 {
+		unaryInterceptors = append(unaryInterceptors, timeout.UnaryClientInterceptor(time.Duration(timeoutSeconds)*time.Second))
+	}
+	opts := []grpc.DialOption{
+		grpc.WithStreamInterceptor(grpc_util.RetryOnlyForServerStreamInterceptor(retryOpts...)),
+		grpc.WithChainUnaryInterceptor(unaryInterceptors...),
+		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(MaxGRPCMessageSize), grpc.MaxCallSendMsgSize(MaxGRPCMessageSize)),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+	}
+
+	spiffeSource, err := spiffe.DefaultSource()
+	if err != nil {
+		return nil, fmt.Errorf("error loading SPIFFE workload identity: %w", err)
+	}
+	switch {
+	case spiffeSource != nil:
+		// The commit server requires mTLS if a SPIFFE workload identity is configured
+		opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(spiffeSource.ClientTLSConfig())))
+	case tlsConfig.DisableTLS:
+		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	default:
+		tlsC := &tls.Config{}
+		if !tlsConfig.StrictValidation {
+			tlsC.InsecureSkipVerify = true
+		} else {
+			tlsC.RootCAs = tlsConfig.Certificates
+		}
+		opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(tlsC)))
+	}
 
 	// TODO: switch to grpc.NewClient.
 	//nolint:staticcheck
@@ -51,6 +105,6 @@ func NewConnection(address string) (*grpc.ClientConn, error) {
 }
 
 // NewCommitServerClientset creates new instance of commit server Clientset
-func NewCommitServerClientset(address string) Clientset {
-	return &clientSet{address: address}
+func NewCommitServerClientset(address string, timeoutSeconds int, tlsConfig TLSConfiguration) Clientset {
+	return &clientSet{address: address, timeoutSeconds: timeoutSeconds, tlsConfig: tlsConfig}
 }
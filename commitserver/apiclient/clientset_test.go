@@ -0,0 +1,83 @@
+package apiclient_test
+
+import (
+	"context"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/argoproj/argo-cd/v3/commitserver/apiclient"
+)
+
+type fakeCommitServer struct {
+	apiclient.UnimplementedCommitServiceServer
+	calls    atomic.Int32
+	failures int32
+	delay    time.Duration
+}
+
+func (s *fakeCommitServer) CommitHydratedManifests(ctx context.Context, _ *apiclient.CommitHydratedManifestsRequest) (*apiclient.CommitHydratedManifestsResponse, error) {
+	if s.calls.Add(1) <= s.failures {
+		return nil, status.Error(codes.Unavailable, "unavailable")
+	}
+	select {
+	case <-time.After(s.delay):
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	return &apiclient.CommitHydratedManifestsResponse{}, nil
+}
+
+func startFakeCommitServer(t *testing.T, fake *fakeCommitServer) string {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer()
+	apiclient.RegisterCommitServiceServer(server, fake)
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+	return listener.Addr().String()
+}
+
+func TestNewCommitServerClientset(t *testing.T) {
+	clientset := apiclient.NewCommitServerClientset("localhost:8086", 1, apiclient.TLSConfiguration{
+		DisableTLS:       false,
+		StrictValidation: true,
+		Certificates:     nil,
+	})
+
+	assert.NotNil(t, clientset)
+	assert.Implements(t, (*apiclient.Clientset)(nil), clientset)
+}
+
+func TestNewConnection_RetriesUnavailable(t *testing.T) {
+	fake := &fakeCommitServer{failures: 1}
+	address := startFakeCommitServer(t, fake)
+
+	closer, client, err := apiclient.NewCommitServerClientset(address, 10, apiclient.TLSConfiguration{DisableTLS: true}).NewCommitServerClient()
+	require.NoError(t, err)
+	defer closer.Close()
+
+	_, err = client.CommitHydratedManifests(t.Context(), &apiclient.CommitHydratedManifestsRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), fake.calls.Load())
+}
+
+func TestNewConnection_Timeout(t *testing.T) {
+	fake := &fakeCommitServer{delay: 10 * time.Second}
+	address := startFakeCommitServer(t, fake)
+
+	closer, client, err := apiclient.NewCommitServerClientset(address, 1, apiclient.TLSConfiguration{DisableTLS: true}).NewCommitServerClient()
+	require.NoError(t, err)
+	defer closer.Close()
+
+	_, err = client.CommitHydratedManifests(t.Context(), &apiclient.CommitHydratedManifestsRequest{})
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+}
@@ -1,7 +1,10 @@
 package commitserver
 
 import (
+	"fmt"
+
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/health"
 	"google.golang.org/grpc/health/grpc_health_v1"
 
@@ -11,6 +14,7 @@ import (
 	versionpkg "github.com/argoproj/argo-cd/v3/pkg/apiclient/version"
 	"github.com/argoproj/argo-cd/v3/server/version"
 	"github.com/argoproj/argo-cd/v3/util/git"
+	"github.com/argoproj/argo-cd/v3/util/spiffe"
 )
 
 // ArgoCDCommitServer is the server that handles commit requests.
@@ -23,9 +27,17 @@ func NewServer(gitCredsStore git.CredsStore, metricsServer *metrics.Server) *Arg
 	return &ArgoCDCommitServer{commitService: commit.NewService(gitCredsStore, metricsServer)}
 }
 
-// CreateGRPC creates a new gRPC server.
-func (a *ArgoCDCommitServer) CreateGRPC() *grpc.Server {
-	server := grpc.NewServer(grpc.MaxRecvMsgSize(apiclient.MaxGRPCMessageSize))
+// CreateGRPC creates a new gRPC server. The server requires mTLS if a SPIFFE workload identity is configured.
+func (a *ArgoCDCommitServer) CreateGRPC() (*grpc.Server, error) {
+	serverOpts := []grpc.ServerOption{grpc.MaxRecvMsgSize(apiclient.MaxGRPCMessageSize)}
+	spiffeSource, err := spiffe.DefaultSource()
+	if err != nil {
+		return nil, fmt.Errorf("error loading SPIFFE workload identity: %w", err)
+	}
+	if spiffeSource != nil {
+		serverOpts = append(serverOpts, grpc.Creds(credentials.NewTLS(spiffeSource.ServerTLSConfig())))
+	}
+	server := grpc.NewServer(serverOpts...)
 	versionpkg.RegisterVersionServiceServer(server, version.NewServer(nil, func() (bool, error) {
 		return true, nil
 	}))
@@ -34,5 +46,5 @@ func (a *ArgoCDCommitServer) CreateGRPC() *grpc.Server {
 	healthService := health.NewServer()
 	grpc_health_v1.RegisterHealthServer(server, healthService)
 
-	return server
+	return server, nil
 }
@@ -98,6 +98,10 @@ const (
 	DefaultPluginConfigFilePath = "/home/argocd/cmp-server/config"
 	// PluginConfigFileName is the Plugin Config File is a ConfigManagementPlugin manifest located inside the plugin container
 	PluginConfigFileName = "plugin.yaml"
+	// DefaultOIDCTokenFilePath is the default path of the projected service account token exchanged for access tokens to the Git repositories
+	DefaultOIDCTokenFilePath = "/var/run/secrets/argocd/oidc/token"
+	// DefaultGitKerberosCCachePath is the default path of the Kerberos credential cache used to authenticate to the Git repositories
+	DefaultGitKerberosCCachePath = "/tmp/krb5cc_argocd"
 )
 
 // Argo CD application related constants
@@ -180,6 +184,13 @@ const (
 	LabelKeyClusterKubernetesVersion = "argocd.argoproj.io/kubernetes-version"
 	// LabelValueSecretTypeCluster indicates a secret type of cluster
 	LabelValueSecretTypeCluster = "cluster"
+	// LabelKeyClusterRegistration is set on the cluster secrets automatically registered from another source, and
+	// contains the type of the source (currently: 'cluster-api')
+	LabelKeyClusterRegistration = "argocd.argoproj.io/cluster-registration"
+	// LabelValueClusterRegistrationClusterAPI indicates a cluster secret registered from a Cluster API cluster
+	LabelValueClusterRegistrationClusterAPI = "cluster-api"
+	// LabelKeyPrefixCloudClusterTag is the prefix of the labels of the cluster secrets mirroring the tags of their cloud managed cluster
+	LabelKeyPrefixCloudClusterTag = "cloud.argocd.argoproj.io/"
 	// LabelValueSecretTypeRepository indicates a secret type of repository
 	LabelValueSecretTypeRepository = "repository"
 	// LabelValueSecretTypeRepoCreds indicates a secret type of repository credentials
@@ -193,6 +204,15 @@ const (
 	AnnotationKeyAppInstance = "argocd.argoproj.io/tracking-id"
 	AnnotationInstallationID = "argocd.argoproj.io/installation-id"
 
+	// AnnotationKeyClusterRegistrationSource contains the namespace/name of the source of an automatically registered cluster secret
+	AnnotationKeyClusterRegistrationSource = "argocd.argoproj.io/cluster-registration-source"
+	// AnnotationKeyCloudClusterID contains the ID of the cloud managed cluster (EKS, GKE or AKS) of a cluster secret,
+	// whose tags are mirrored onto the labels of the secret
+	AnnotationKeyCloudClusterID = "argocd.argoproj.io/cloud-cluster-id"
+	// AnnotationKeyCredentialsHealth contains the health of the credentials of a repository or repository credentials
+	// secret as JSON, written by the periodic validation of the credentials by the application controller
+	AnnotationKeyCredentialsHealth = "argocd.argoproj.io/credentials-health"
+
 	// AnnotationCompareOptions is a comma-separated list of options for comparison
 	AnnotationCompareOptions = "argocd.argoproj.io/compare-options"
 
@@ -221,6 +241,12 @@ const (
 	// AnnotationKeyAppSkipReconcile tells the Application to skip the Application controller reconcile.
 	// Skip reconcile when the value is "true" or any other string values that can be strconv.ParseBool() to be true.
 	AnnotationKeyAppSkipReconcile = "argocd.argoproj.io/skip-reconcile"
+	// AnnotationKeyAppSkipReconcileUntil tells the Application controller to skip the reconcile of the Application until
+	// the given RFC3339 timestamp. It sets the expiry of the skip-reconcile annotation, and can also be used alone.
+	AnnotationKeyAppSkipReconcileUntil = "argocd.argoproj.io/skip-reconcile-until"
+	// AnnotationKeyAppBlueprint is the annotation key which holds the name of the application blueprint from which an
+	// Application was created
+	AnnotationKeyAppBlueprint = "argocd.argoproj.io/blueprint"
 	// LabelKeyComponentRepoServer is the label key to identify the component as repo-server
 	LabelKeyComponentRepoServer = "app.kubernetes.io/component"
 	// LabelValueComponentRepoServer is the label value for the repo-server component
@@ -314,6 +340,20 @@ const (
 	EnvServerSideDiff = "ARGOCD_APPLICATION_CONTROLLER_SERVER_SIDE_DIFF"
 	// EnvGRPCMaxSizeMB is the environment variable to look for a max GRPC message size
 	EnvGRPCMaxSizeMB = "ARGOCD_GRPC_MAX_SIZE_MB"
+	// EnvOIDCTokenFile is the path of the file containing the OIDC token of the workload, which is exchanged for access tokens to the Git repositories
+	EnvOIDCTokenFile = "ARGOCD_OIDC_TOKEN_FILE"
+	// EnvGitKerberosPrincipal is the Kerberos principal used to authenticate to the Git repositories served over HTTP(S)
+	EnvGitKerberosPrincipal = "ARGOCD_GIT_KERBEROS_PRINCIPAL"
+	// EnvGitKerberosKeytab is the path of the keytab used to obtain the Kerberos tickets of the principal
+	EnvGitKerberosKeytab = "ARGOCD_GIT_KERBEROS_KEYTAB"
+	// EnvGitKerberosCCache is the path of the Kerberos credential cache holding the tickets used to authenticate to the Git repositories
+	EnvGitKerberosCCache = "ARGOCD_GIT_KERBEROS_CCACHE"
+	// EnvSPIFFESVIDPath is the path of the directory containing the X.509 SVID, its key and the trust bundle used for mTLS between the Argo CD components
+	EnvSPIFFESVIDPath = "ARGOCD_SPIFFE_SVID_PATH"
+	// EnvSPIFFEAllowedIDs is the comma separated patterns of the SPIFFE IDs of the peers allowed to connect over mTLS
+	EnvSPIFFEAllowedIDs = "ARGOCD_SPIFFE_ALLOWED_IDS"
+	// EnvFIPSMode enables the FIPS mode, which restricts the cryptographic algorithms used by Argo CD to the FIPS 140-3 approved ones
+	EnvFIPSMode = "ARGOCD_FIPS_MODE"
 )
 
 // Config Management Plugin related constants
@@ -406,6 +446,12 @@ func GetCMPWorkDir() string {
 const (
 	// AnnotationApplicationSetRefresh is an annotation that is added when an ApplicationSet is requested to be refreshed by a webhook. The ApplicationSet controller will remove this annotation at the end of reconciliation.
 	AnnotationApplicationSetRefresh = "argocd.argoproj.io/application-set-refresh"
+	// AnnotationApplicationSetManagedLabels is an annotation of the generated Applications listing the labels set by their ApplicationSet, which are pruned when no longer generated with the merge metadata propagation strategy.
+	AnnotationApplicationSetManagedLabels = "argocd.argoproj.io/application-set-managed-labels"
+	// AnnotationApplicationSetManagedAnnotations is an annotation of the generated Applications listing the annotations set by their ApplicationSet, which are pruned when no longer generated with the merge metadata propagation strategy.
+	AnnotationApplicationSetManagedAnnotations = "argocd.argoproj.io/application-set-managed-annotations"
+	// AnnotationApplicationSetGeneratedFields is an annotation of the generated Applications recording the hashes of the values last generated by their ApplicationSet for the fields of the ignoreApplicationDifferences rules preserving the fields modified by users.
+	AnnotationApplicationSetGeneratedFields = "argocd.argoproj.io/application-set-generated-fields"
 )
 
 // gRPC settings
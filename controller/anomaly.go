@@ -0,0 +1,61 @@
+package controller
+
+import (
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	appv1 "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	applog "github.com/argoproj/argo-cd/v3/util/app/log"
+)
+
+// appAnomaly is an anomaly detected in the reconciliations of an application
+type appAnomaly struct {
+	message string
+}
+
+// appAnomalyDetector detects anomalies in the reconciliations of the applications. The detectors observe the result
+// of every comparison of the applications, and the anomalies of each detector are reported by the application
+// conditions of the type of the detector.
+type appAnomalyDetector interface {
+	// conditionType returns the type of the application conditions reporting the anomalies of the detector
+	conditionType() appv1.ApplicationConditionType
+	// observe records the state of the application resulting from a comparison, and returns the anomaly of the
+	// application at that time, if any
+	observe(app *appv1.Application, compareResult *comparisonResult, now time.Time) *appAnomaly
+	// selfHealPaused returns true if the self heal of the resource of the application is paused by the detector
+	selfHealPaused(appKey string, resource appv1.ResourceStatus) bool
+	// forget drops the observations of a deleted application
+	forget(appKey string)
+}
+
+// detectAppAnomalies runs the anomaly detectors against the result of the comparison of the application, and sets
+// the conditions of the application reporting the anomalies
+func (ctrl *ApplicationController) detectAppAnomalies(app *appv1.Application, compareResult *comparisonResult) {
+	now := time.Now()
+	for _, detector := range ctrl.anomalyDetectors {
+		conditions := []appv1.ApplicationCondition{}
+		if anomaly := detector.observe(app, compareResult, now); anomaly != nil {
+			log.WithFields(applog.GetAppLogFields(app)).Debugf("Detected anomaly: %s", anomaly.message)
+			conditions = append(conditions, appv1.ApplicationCondition{Type: detector.conditionType(), Message: anomaly.message})
+		}
+		app.Status.SetConditions(conditions, map[appv1.ApplicationConditionType]bool{detector.conditionType(): true})
+	}
+}
+
+// isSelfHealPaused returns true if the self heal of the resource of the application is paused by an anomaly detector
+func (ctrl *ApplicationController) isSelfHealPaused(app *appv1.Application, resource appv1.ResourceStatus) bool {
+	for _, detector := range ctrl.anomalyDetectors {
+		if detector.selfHealPaused(app.QualifiedName(), resource) {
+			return true
+		}
+	}
+	return false
+}
+
+// forgetAppAnomalies drops the observations of the anomaly detectors about a deleted application
+func (ctrl *ApplicationController) forgetAppAnomalies(app *appv1.Application) {
+	for _, detector := range ctrl.anomalyDetectors {
+		detector.forget(app.QualifiedName())
+	}
+}
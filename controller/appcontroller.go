@@ -39,6 +39,7 @@ import (
 	"k8s.io/client-go/informers"
 	informerv1 "k8s.io/client-go/informers/apps/v1"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/utils/ptr"
@@ -146,6 +147,17 @@ type ApplicationController struct {
 	deploymentInformer                informerv1.DeploymentInformer
 
 	hydrator *hydrator.Hydrator
+
+	// clusterReadiness defers the syncs to the destination clusters which aren't ready
+	clusterReadiness *clusterReadinessProber
+	// comparisonSettings are the settings last used by the comparisons, see reloadComparisonSettings
+	comparisonSettings *comparisonSettings
+	// repoCredsHealth periodically validates the credentials of the repository secrets
+	repoCredsHealth *repoCredsHealthChecker
+	// resourceTreeSnapshots periodically snapshots the resource trees of the applications, see snapshotResourceTree
+	resourceTreeSnapshots *resourceTreeSnapshotter
+	// anomalyDetectors detect the anomalies in the reconciliations of the applications, see detectAppAnomalies
+	anomalyDetectors []appAnomalyDetector
 }
 
 // NewApplicationController creates new instance of ApplicationController.
@@ -172,11 +184,17 @@ func NewApplicationController(
 	metricsApplicationConditions []string,
 	metricsClusterLabels []string,
 	kubectlParallelismLimit int64,
+	repoServerProjectParallelismLimit int64,
 	persistResourceHealth bool,
+	resourceTreeSnapshotInterval time.Duration,
+	resourceTreeSnapshotRetention time.Duration,
+	flappingThreshold int,
+	flappingPauseSelfHeal bool,
 	clusterSharding sharding.ClusterShardingCache,
 	applicationNamespaces []string,
 	rateLimiterConfig *ratelimiter.AppControllerRateLimiterConfig,
 	serverSideDiff bool,
+	serverSideDryRunOpts argodiff.ServerSideDryRunOpts,
 	dynamicClusterDistributionEnabled bool,
 	ignoreNormalizerOpts normalizers.IgnoreNormalizerOpts,
 	enableK8sEvent []string,
@@ -193,9 +211,8 @@ func NewApplicationController(
 		namespace:                         namespace,
 		kubeClientset:                     kubeClientset,
 		kubectl:                           kubectl,
+		clusterReadiness:                  newClusterReadinessProber(kubectl, syncClusterReadinessGating),
 		applicationClientset:              applicationClientset,
-		appRefreshQueue:                   workqueue.NewTypedRateLimitingQueueWithConfig(ratelimiter.NewCustomAppControllerRateLimiter[string](rateLimiterConfig), workqueue.TypedRateLimitingQueueConfig[string]{Name: "app_reconciliation_queue"}),
-		appOperationQueue:                 workqueue.NewTypedRateLimitingQueueWithConfig(ratelimiter.NewCustomAppControllerRateLimiter[string](rateLimiterConfig), workqueue.TypedRateLimitingQueueConfig[string]{Name: "app_operation_processing_queue"}),
 		projectRefreshQueue:               workqueue.NewTypedRateLimitingQueueWithConfig(ratelimiter.NewCustomAppControllerRateLimiter[string](rateLimiterConfig), workqueue.TypedRateLimitingQueueConfig[string]{Name: "project_reconciliation_queue"}),
 		appComparisonTypeRefreshQueue:     workqueue.NewTypedRateLimitingQueue(ratelimiter.NewCustomAppControllerRateLimiter[string](rateLimiterConfig)),
 		appHydrateQueue:                   workqueue.NewTypedRateLimitingQueueWithConfig(ratelimiter.NewCustomAppControllerRateLimiter[string](rateLimiterConfig), workqueue.TypedRateLimitingQueueConfig[string]{Name: "app_hydration_queue"}),
@@ -218,6 +235,7 @@ func NewApplicationController(
 		dynamicClusterDistributionEnabled: dynamicClusterDistributionEnabled,
 		ignoreNormalizerOpts:              ignoreNormalizerOpts,
 		metricsClusterLabels:              metricsClusterLabels,
+		resourceTreeSnapshots:             newResourceTreeSnapshotter(resourceTreeSnapshotInterval, resourceTreeSnapshotRetention),
 	}
 	if hydratorEnabled {
 		ctrl.hydrator = hydrator.NewHydrator(&ctrl, appResyncPeriod, commitClientset, repoClientset, db)
@@ -328,8 +346,17 @@ func NewApplicationController(
 			return nil, err
 		}
 	}
-	stateCache := statecache.NewLiveStateCache(db, appInformer, ctrl.settingsMgr, ctrl.metricsServer, ctrl.handleObjectUpdated, clusterSharding, argo.NewResourceTracking())
-	appStateManager := NewAppStateManager(db, applicationClientset, repoClientset, namespace, kubectl, ctrl.onKubectlRun, ctrl.settingsMgr, stateCache, ctrl.metricsServer, argoCache, ctrl.statusRefreshTimeout, argo.NewResourceTracking(), persistResourceHealth, repoErrorGracePeriod, serverSideDiff, ignoreNormalizerOpts)
+	if flappingThreshold > 0 {
+		ctrl.anomalyDetectors = append(ctrl.anomalyDetectors, newFlappingDetector(flappingThreshold, flappingPauseSelfHeal, ctrl.metricsServer))
+	}
+	if repoServerProjectParallelismLimit > 0 {
+		repoClientset = newProjectLimitedRepoClientset(repoClientset, repoServerProjectParallelismLimit, ctrl.metricsServer)
+	}
+	ctrl.repoCredsHealth = newRepoCredsHealthChecker(db, kubeClientset, repoClientset, ctrl.auditLogger, ctrl.metricsServer)
+	ctrl.appRefreshQueue = newAppQueue(appRefreshQueueName, ratelimiter.NewCustomAppControllerRateLimiter[string](rateLimiterConfig), ctrl.metricsServer, ctrl.getAppProjectName, ctrl.getShardLabel, true)
+	ctrl.appOperationQueue = newAppQueue(appOperationQueueName, ratelimiter.NewCustomAppControllerRateLimiter[string](rateLimiterConfig), ctrl.metricsServer, ctrl.getAppProjectName, ctrl.getShardLabel, false)
+	stateCache := statecache.NewLiveStateCache(db, appInformer, projInformer, ctrl.settingsMgr, ctrl.metricsServer, ctrl.handleObjectUpdated, clusterSharding, argo.NewResourceTracking())
+	appStateManager := NewAppStateManager(db, applicationClientset, repoClientset, namespace, kubectl, ctrl.onKubectlRun, ctrl.settingsMgr, stateCache, ctrl.metricsServer, argoCache, ctrl.statusRefreshTimeout, argo.NewResourceTracking(), persistResourceHealth, repoErrorGracePeriod, serverSideDiff, serverSideDryRunOpts, ignoreNormalizerOpts, appLister, applisters.NewAppProjectLister(projInformer.GetIndexer()))
 	ctrl.appInformer = appInformer
 	ctrl.appLister = appLister
 	ctrl.projInformer = projInformer
@@ -520,11 +547,18 @@ func (ctrl *ApplicationController) setAppManagedResources(destCluster *appv1.Clu
 	if err != nil {
 		return nil, fmt.Errorf("error setting app resource tree: %w", err)
 	}
+	ctrl.snapshotResourceTree(a, tree)
+	ts.AddCheckpoint("snapshot_app_resources_tree_ms")
 	err = ctrl.cache.SetAppManagedResources(a.InstanceName(ctrl.namespace), managedResources)
 	ts.AddCheckpoint("set_app_managed_resources_ms")
 	if err != nil {
 		return nil, fmt.Errorf("error setting app managed resources: %w", err)
 	}
+	err = ctrl.cache.SetAppDiffSettingsHash(a.InstanceName(ctrl.namespace), comparisonResult.diffSettingsHash)
+	ts.AddCheckpoint("set_app_diff_settings_hash_ms")
+	if err != nil {
+		return nil, fmt.Errorf("error setting app diff settings hash: %w", err)
+	}
 	return tree, nil
 }
 
@@ -801,6 +835,8 @@ func (ctrl *ApplicationController) hideSecretData(destCluster *appv1.Cluster, ap
 			Kind:            res.Kind,
 			Hook:            res.Hook,
 			ResourceVersion: res.ResourceVersion,
+			SuppressedDiff:  res.SuppressedDiff,
+			DryRunError:     res.DryRunError,
 		}
 
 		target := res.Target
@@ -824,7 +860,11 @@ func (ctrl *ApplicationController) hideSecretData(destCluster *appv1.Cluster, ap
 			if err != nil {
 				return nil, fmt.Errorf("error getting app instance label key: %w", err)
 			}
-			trackingMethod, err := ctrl.settingsMgr.GetTrackingMethod()
+			proj, err := ctrl.getAppProj(app)
+			if err != nil {
+				return nil, fmt.Errorf("error getting app project: %w", err)
+			}
+			trackingMethod, err := argo.GetTrackingMethod(ctrl.settingsMgr, proj)
 			if err != nil {
 				return nil, fmt.Errorf("error getting tracking method: %w", err)
 			}
@@ -919,9 +959,12 @@ func (ctrl *ApplicationController) Run(ctx context.Context, statusProcessors int
 		log.Error("Timed out waiting for caches to sync")
 		return
 	}
+	ctrl.resumePendingOperations()
 
 	go func() { errors.CheckError(ctrl.stateCache.Run(ctx)) }()
+	ctrl.metricsServer.HandleFunc(settings_util.StatusPath, ctrl.settingsMgr.StatusHandler)
 	go func() { errors.CheckError(ctrl.metricsServer.ListenAndServe()) }()
+	defer ctrl.settingsMgr.RegisterConsumer("app-comparison", ctrl.reloadComparisonSettings)()
 
 	for i := 0; i < statusProcessors; i++ {
 		go wait.Until(func() {
@@ -947,6 +990,18 @@ func (ctrl *ApplicationController) Run(ctx context.Context, statusProcessors int
 		}
 	}, time.Second, ctx.Done())
 
+	if appStateCacheGCPeriod > 0 {
+		go wait.Until(func() {
+			ctrl.collectAppStateCacheGarbage(ctx)
+		}, appStateCacheGCPeriod, ctx.Done())
+	}
+
+	if repoCredsHealthCheckPeriod > 0 {
+		go wait.Until(func() {
+			ctrl.checkRepoCredsHealth(ctx)
+		}, repoCredsHealthCheckPeriod, ctx.Done())
+	}
+
 	if ctrl.hydrator != nil {
 		go wait.Until(func() {
 			for ctrl.processAppHydrateQueueItem() {
@@ -1021,6 +1076,9 @@ func (ctrl *ApplicationController) processAppOperationQueueItem() (processNext b
 		log.Warnf("Key '%s' in index is not an application", appKey)
 		return
 	}
+	if skipReconcile, _ := isReconcileSkipped(origApp, time.Now()); skipReconcile {
+		return
+	}
 	app := origApp.DeepCopy()
 	logCtx := log.WithFields(applog.GetAppLogFields(app))
 	ts := stats.NewTimingStats()
@@ -1216,6 +1274,22 @@ func (ctrl *ApplicationController) finalizeApplicationDeletion(app *appv1.Applic
 	}
 	config := metrics.AddMetricsTransportWrapper(ctrl.metricsServer, app, clusterRESTConfig)
 
+	// The resources and the post-delete hooks are deleted with the same service account as the one used for the sync
+	impersonationEnabled, err := ctrl.settingsMgr.IsImpersonationEnabled()
+	if err != nil {
+		return fmt.Errorf("error getting impersonation feature flag: %w", err)
+	}
+	if impersonationEnabled {
+		serviceAccountToImpersonate, err := deriveServiceAccountToImpersonate(proj, app, destCluster)
+		if err != nil {
+			return fmt.Errorf("failed to find a matching service account to impersonate: %w", err)
+		}
+		logCtx = logCtx.WithFields(log.Fields{"impersonationEnabled": "true", "serviceAccount": serviceAccountToImpersonate})
+		config.Impersonate = rest.ImpersonationConfig{
+			UserName: serviceAccountToImpersonate,
+		}
+	}
+
 	if app.CascadedDeletion() {
 		deletionApproved := app.IsDeletionConfirmed(app.DeletionTimestamp.Time)
 
@@ -1437,12 +1511,29 @@ func (ctrl *ApplicationController) processRequestedAppOperation(app *appv1.Appli
 	ts.AddCheckpoint("initial_operation_stage_ms")
 
 	project, err := ctrl.getAppProj(app)
-	if err == nil {
-		// Start or resume the sync
-		ctrl.appStateManager.SyncAppState(app, project, state)
-	} else {
+	if err == nil && !terminating && state.Phase == synccommon.OperationRunning && (ctrl.deferSyncUntilClusterReady(app, state) || ctrl.deferSyncUntilRolloutsComplete(app, project, state)) {
+		return
+	}
+	var quotaErr error
+	if err == nil && !terminating {
+		quotaErr = ctrl.validateSourceNamespaceQuota(app, project)
+	}
+	switch {
+	case err != nil:
 		state.Phase = synccommon.OperationError
 		state.Message = fmt.Sprintf("Failed to load application project: %v", err)
+	case quotaErr != nil:
+		state.Phase = synccommon.OperationError
+		state.Message = quotaErr.Error()
+	case state.Operation.Cascade != nil:
+		// Start or resume the operations of the child applications
+		ctrl.cascadeAppOperation(app, state)
+	default:
+		// Start or resume the sync
+		ctrl.appStateManager.SyncAppState(app, project, state)
+		if state.Phase == synccommon.OperationRunning && state.Message == manifestGenerationDeferredMessage {
+			ctrl.appOperationQueue.AddAfter(ctrl.toAppKey(app.QualifiedName()), manifestGenerationDeferDelay)
+		}
 	}
 	ts.AddCheckpoint("sync_app_state_ms")
 
@@ -1479,7 +1570,7 @@ func (ctrl *ApplicationController) processRequestedAppOperation(app *appv1.Appli
 
 	ctrl.setOperationState(app, state)
 	ts.AddCheckpoint("final_set_operation_state")
-	if state.Phase.Completed() && (app.Operation.Sync != nil && !app.Operation.Sync.DryRun) {
+	if state.Phase.Completed() && (app.Operation.Cascade != nil || app.Operation.Sync != nil && !app.Operation.Sync.DryRun) {
 		// if we just completed an operation, force a refresh so that UI will report up-to-date
 		// sync/health information
 		if _, err := cache.MetaNamespaceKeyFunc(app); err == nil {
@@ -1492,6 +1583,32 @@ func (ctrl *ApplicationController) processRequestedAppOperation(app *appv1.Appli
 	ts.AddCheckpoint("request_app_refresh_ms")
 }
 
+// deferSyncUntilClusterReady returns true if the sync operation of the application is deferred because its destination
+// cluster isn't ready. The operation stays in progress with a message explaining why, and the readiness of the cluster
+// is checked again later.
+func (ctrl *ApplicationController) deferSyncUntilClusterReady(app *appv1.Application, state *appv1.OperationState) bool {
+	if ctrl.clusterReadiness == nil || state.Operation.Sync == nil || state.Operation.Sync.DryRun {
+		return false
+	}
+	destCluster, err := argo.GetDestinationCluster(context.Background(), app.Spec.Destination, ctrl.db)
+	if err != nil {
+		// the sync fails with the error of the destination
+		return false
+	}
+	readinessErr := ctrl.clusterReadiness.check(destCluster)
+	if readinessErr == nil {
+		return false
+	}
+	message := fmt.Sprintf("Waiting for the destination cluster %s to be ready: %v", destCluster.Server, readinessErr)
+	if state.Message != message {
+		log.WithFields(applog.GetAppLogFields(app)).Infof("Deferring sync: %s", message)
+		state.Message = message
+		ctrl.setOperationState(app, state)
+	}
+	ctrl.appOperationQueue.AddAfter(ctrl.toAppKey(app.QualifiedName()), clusterReadinessRecheckPeriod)
+	return true
+}
+
 func (ctrl *ApplicationController) setOperationState(app *appv1.Application, state *appv1.OperationState) {
 	logCtx := log.WithFields(applog.GetAppLogFields(app))
 	if state.Phase == "" {
@@ -1548,9 +1665,12 @@ func (ctrl *ApplicationController) setOperationState(app *appv1.Application, sta
 	if state.Phase.Completed() {
 		eventInfo := argo.EventInfo{Reason: argo.EventReasonOperationCompleted}
 		var messages []string
-		if state.Operation.Sync != nil && len(state.Operation.Sync.Resources) > 0 {
+		switch {
+		case state.Operation.Cascade != nil:
+			messages = []string{"Cascade", strings.ToLower(string(state.Operation.Cascade.Type)), "operation"}
+		case state.Operation.Sync != nil && len(state.Operation.Sync.Resources) > 0:
 			messages = []string{"Partial sync operation"}
-		} else {
+		default:
 			messages = []string{"Sync operation"}
 		}
 		if state.SyncResult != nil {
@@ -1574,7 +1694,7 @@ func (ctrl *ApplicationController) setOperationState(app *appv1.Application, sta
 			destServer = destCluster.Server
 		}
 		ctrl.metricsServer.IncSync(app, destServer, state)
-		ctrl.metricsServer.IncAppSyncDuration(app, destServer, state)
+		ctrl.metricsServer.IncAppSyncDuration(recordSyncSpan(app, state), app, destServer, state)
 	}
 }
 
@@ -1631,13 +1751,25 @@ func (ctrl *ApplicationController) processAppRefreshQueueItem() (processNext boo
 		log.Warnf("Key '%s' in index is not an application", appKey)
 		return
 	}
+	if skipReconcile, _ := isReconcileSkipped(origApp, time.Now()); skipReconcile {
+		// the app was queued before its reconcile was skipped, or its skip was extended
+		return
+	}
 	origApp = origApp.DeepCopy()
+	freeze := ctrl.getActiveFreeze(origApp, time.Now())
+	if freeze != nil && freeze.NoRefresh {
+		// the refresh of the application is disabled by its freeze
+		ctrl.persistAppFreeze(origApp, freeze)
+		return
+	}
 	needRefresh, refreshType, comparisonLevel := ctrl.needRefreshAppStatus(origApp, ctrl.statusRefreshTimeout, ctrl.statusHardRefreshTimeout)
 
 	if !needRefresh {
+		ctrl.persistAppFreeze(origApp, freeze)
 		return
 	}
 	app := origApp.DeepCopy()
+	app.Status.Freeze = freeze
 	logCtx := log.WithFields(applog.GetAppLogFields(app)).WithFields(log.Fields{
 		"comparison-level": comparisonLevel,
 		"dest-server":      origApp.Spec.Destination.Server,
@@ -1647,9 +1779,11 @@ func (ctrl *ApplicationController) processAppRefreshQueueItem() (processNext boo
 
 	startTime := time.Now()
 	ts := stats.NewTimingStats()
+	spanCtx, span := startReconcileSpan(origApp, comparisonLevel)
 	var destCluster *appv1.Cluster
 	defer func() {
 		reconcileDuration := time.Since(startTime)
+		span.End()
 
 		// We may or may not get to the point in the code where destCluster is set. Populate the dest_server label on a
 		// best-effort basis.
@@ -1657,7 +1791,7 @@ func (ctrl *ApplicationController) processAppRefreshQueueItem() (processNext boo
 		if destCluster != nil {
 			destServer = destCluster.Server
 		}
-		ctrl.metricsServer.IncReconcile(origApp, destServer, reconcileDuration)
+		ctrl.metricsServer.IncReconcile(spanCtx, origApp, destServer, reconcileDuration)
 		for k, v := range ts.Timings() {
 			logCtx = logCtx.WithField(k, v.Milliseconds())
 		}
@@ -1755,6 +1889,11 @@ func (ctrl *ApplicationController) processAppRefreshQueueItem() (processNext boo
 		logCtx.Warnf("Ignoring temporary failed attempt to compare app state against repo: %v", err)
 		return // short circuit if git error is encountered
 	}
+	if stderrors.Is(err, errManifestGenerationDeferred) {
+		logCtx.Debugf("Deferring the comparison of the app state: %v", err)
+		ctrl.requestAppRefresh(app.QualifiedName(), &comparisonLevel, ptr.To(manifestGenerationDeferDelay))
+		return
+	}
 
 	for k, v := range compareResult.timings {
 		logCtx = logCtx.WithField(k, v.Milliseconds())
@@ -1763,6 +1902,9 @@ func (ctrl *ApplicationController) processAppRefreshQueueItem() (processNext boo
 	ctrl.normalizeApplication(origApp, app)
 	ts.AddCheckpoint("normalize_application_ms")
 
+	ctrl.detectAppAnomalies(app, compareResult)
+	ts.AddCheckpoint("detect_app_anomalies_ms")
+
 	tree, err := ctrl.setAppManagedResources(destCluster, app, compareResult)
 	ts.AddCheckpoint("set_app_managed_resources_ms")
 	if err != nil {
@@ -1772,8 +1914,13 @@ func (ctrl *ApplicationController) processAppRefreshQueueItem() (processNext boo
 	}
 
 	canSync, _ := project.Spec.SyncWindows.Matches(app).CanSync(false)
-	if canSync {
-		syncErrCond, opDuration := ctrl.autoSync(app, compareResult.syncStatus, compareResult.resources, compareResult.revisionsMayHaveChanges)
+	// the syncs to protected clusters are break-glass syncs, which are never automated
+	protectedCluster := project.IsProtectedCluster(destCluster)
+	if canSync && !protectedCluster && freeze == nil {
+		// the applications inherit the default sync policy of their project, which must not be persisted in their spec
+		syncApp := *app
+		syncApp.Spec.SyncPolicy = project.GetApplicationSyncPolicy(app.Spec.SyncPolicy)
+		syncErrCond, opDuration := ctrl.autoSync(&syncApp, compareResult.syncStatus, compareResult.resources, compareResult.revisionsMayHaveChanges)
 		setOpDuration = opDuration
 		if syncErrCond != nil {
 			app.Status.SetConditions(
@@ -1786,8 +1933,12 @@ func (ctrl *ApplicationController) processAppRefreshQueueItem() (processNext boo
 				map[appv1.ApplicationConditionType]bool{appv1.ApplicationConditionSyncError: true},
 			)
 		}
-	} else {
+	} else if !canSync {
 		logCtx.Info("Sync prevented by sync window")
+	} else if protectedCluster {
+		logCtx.Debug("Auto-sync prevented by protected destination cluster")
+	} else {
+		logCtx.Debug("Auto-sync prevented by the freeze of the application")
 	}
 	ts.AddCheckpoint("auto_sync_ms")
 
@@ -1802,6 +1953,7 @@ func (ctrl *ApplicationController) processAppRefreshQueueItem() (processNext boo
 	})
 	app.Status.SourceType = compareResult.appSourceType
 	app.Status.SourceTypes = compareResult.appSourceTypes
+	app.Status.ToolVersions = compareResult.appToolVersions
 	app.Status.ControllerNamespace = ctrl.namespace
 	ts.AddCheckpoint("app_status_update_ms")
 	patchDuration = ctrl.persistAppStatus(origApp, &app.Status)
@@ -1971,6 +2123,13 @@ func (ctrl *ApplicationController) refreshAppConditions(app *appv1.Application)
 		} else {
 			errorConditions = append(errorConditions, specConditions...)
 		}
+		errorConditions = append(errorConditions, validateApplicationRefs(app, ctrl.appLister, applisters.NewAppProjectLister(ctrl.projInformer.GetIndexer()), ctrl.namespace)...)
+		if err := ctrl.validateSourceNamespaceQuota(app, proj); err != nil {
+			errorConditions = append(errorConditions, appv1.ApplicationCondition{
+				Type:    appv1.ApplicationConditionInvalidSpecError,
+				Message: err.Error(),
+			})
+		}
 	}
 	app.Status.SetConditions(errorConditions, map[appv1.ApplicationConditionType]bool{
 		appv1.ApplicationConditionInvalidSpecError: true,
@@ -2165,14 +2324,25 @@ func (ctrl *ApplicationController) autoSync(app *appv1.Application, syncStatus *
 		}
 
 		op.Sync.SelfHealAttemptsCount++
+		selfHealPaused := false
 		for _, resource := range resources {
-			if resource.Status != appv1.SyncStatusCodeSynced {
-				op.Sync.Resources = append(op.Sync.Resources, appv1.SyncOperationResource{
-					Kind:  resource.Kind,
-					Group: resource.Group,
-					Name:  resource.Name,
-				})
+			if resource.Status == appv1.SyncStatusCodeSynced {
+				continue
 			}
+			if ctrl.isSelfHealPaused(app, resource) {
+				selfHealPaused = true
+				continue
+			}
+			op.Sync.Resources = append(op.Sync.Resources, appv1.SyncOperationResource{
+				Kind:  resource.Kind,
+				Group: resource.Group,
+				Name:  resource.Name,
+			})
+		}
+		// the sync of no resources would sync all the resources, including the ones whose self heal is paused
+		if selfHealPaused && len(op.Sync.Resources) == 0 {
+			logCtx.Infof("Skipping auto-sync: self heal is paused for the flapping resources")
+			return nil, 0
 		}
 	}
 	ts.AddCheckpoint("already_attempted_check_ms")
@@ -2322,20 +2492,16 @@ func (ctrl *ApplicationController) canProcessApp(obj any) bool {
 		return false
 	}
 
-	if annotations := app.GetAnnotations(); annotations != nil {
-		if skipVal, ok := annotations[common.AnnotationKeyAppSkipReconcile]; ok {
-			logCtx := log.WithFields(applog.GetAppLogFields(app))
-			if skipReconcile, err := strconv.ParseBool(skipVal); err == nil {
-				if skipReconcile {
-					logCtx.Debugf("Skipping Application reconcile based on annotation %s", common.AnnotationKeyAppSkipReconcile)
-					return false
-				}
-			} else {
-				logCtx.Debugf("Unable to determine if Application should skip reconcile based on annotation %s: %v", common.AnnotationKeyAppSkipReconcile, err)
-			}
-		}
+	if skipReconcile, _ := isReconcileSkipped(app, time.Now()); skipReconcile {
+		log.WithFields(applog.GetAppLogFields(app)).Debugf("Skipping Application reconcile based on annotations %s and %s", common.AnnotationKeyAppSkipReconcile, common.AnnotationKeyAppSkipReconcileUntil)
+		return false
 	}
 
+	return ctrl.isAppInShard(app)
+}
+
+// isAppInShard returns whether the destination cluster of the application is managed by the shard of the controller
+func (ctrl *ApplicationController) isAppInShard(app *appv1.Application) bool {
 	destCluster, err := argo.GetDestinationCluster(context.Background(), app.Spec.Destination, ctrl.db)
 	if err != nil {
 		return ctrl.clusterSharding.IsManagedCluster(nil)
@@ -2343,6 +2509,56 @@ func (ctrl *ApplicationController) canProcessApp(obj any) bool {
 	return ctrl.clusterSharding.IsManagedCluster(destCluster)
 }
 
+// isReconcileSkipped returns whether the reconcile of the application is skipped at the given time based on its
+// skip-reconcile and skip-reconcile-until annotations, and the time at which the skip expires. The expiry is zero when
+// the skip does not expire.
+func isReconcileSkipped(app *appv1.Application, now time.Time) (bool, time.Time) {
+	annotations := app.GetAnnotations()
+	if annotations == nil {
+		return false, time.Time{}
+	}
+	logCtx := log.WithFields(applog.GetAppLogFields(app))
+	skipReconcile, skipReconcileSet := false, false
+	if skipVal, ok := annotations[common.AnnotationKeyAppSkipReconcile]; ok {
+		var err error
+		if skipReconcile, err = strconv.ParseBool(skipVal); err == nil {
+			skipReconcileSet = true
+		} else {
+			logCtx.Debugf("Unable to determine if Application should skip reconcile based on annotation %s: %v", common.AnnotationKeyAppSkipReconcile, err)
+		}
+	}
+	untilVal, ok := annotations[common.AnnotationKeyAppSkipReconcileUntil]
+	if !ok || (skipReconcileSet && !skipReconcile) {
+		return skipReconcile, time.Time{}
+	}
+	until, err := time.Parse(time.RFC3339, untilVal)
+	if err != nil {
+		logCtx.Debugf("Unable to determine until when Application should skip reconcile based on annotation %s: %v", common.AnnotationKeyAppSkipReconcileUntil, err)
+		return skipReconcile, time.Time{}
+	}
+	if !now.Before(until) {
+		return false, time.Time{}
+	}
+	return true, until
+}
+
+// requestAppRefreshOnSkipReconcileExpiry requests the refresh of an application whose reconcile is skipped until a
+// given time when the skip expires, so that the reconcile of the application resumes without waiting for a change
+func (ctrl *ApplicationController) requestAppRefreshOnSkipReconcileExpiry(obj any) {
+	app, ok := obj.(*appv1.Application)
+	if !ok || !ctrl.isAppNamespaceAllowed(app) {
+		return
+	}
+	skipReconcile, until := isReconcileSkipped(app, time.Now())
+	if !skipReconcile || until.IsZero() || !ctrl.isAppInShard(app) {
+		return
+	}
+	key, err := cache.MetaNamespaceKeyFunc(app)
+	if err == nil {
+		ctrl.appRefreshQueue.AddAfter(key, time.Until(until))
+	}
+}
+
 func (ctrl *ApplicationController) newApplicationInformerAndLister() (cache.SharedIndexInformer, applisters.ApplicationLister) {
 	watchNamespace := ctrl.namespace
 	// If we have at least one additional namespace configured, we need to
@@ -2423,6 +2639,7 @@ func (ctrl *ApplicationController) newApplicationInformerAndLister() (cache.Shar
 		cache.ResourceEventHandlerFuncs{
 			AddFunc: func(obj any) {
 				if !ctrl.canProcessApp(obj) {
+					ctrl.requestAppRefreshOnSkipReconcileExpiry(obj)
 					return
 				}
 				key, err := cache.MetaNamespaceKeyFunc(obj)
@@ -2436,6 +2653,7 @@ func (ctrl *ApplicationController) newApplicationInformerAndLister() (cache.Shar
 			},
 			UpdateFunc: func(old, new any) {
 				if !ctrl.canProcessApp(new) {
+					ctrl.requestAppRefreshOnSkipReconcileExpiry(new)
 					return
 				}
 
@@ -2484,6 +2702,10 @@ func (ctrl *ApplicationController) newApplicationInformerAndLister() (cache.Shar
 				delApp, delOK := obj.(*appv1.Application)
 				if err == nil && delOK {
 					ctrl.clusterSharding.DeleteApp(delApp)
+					ctrl.metricsServer.DeleteResourceKindCountMetric(delApp)
+					ctrl.resourceTreeSnapshots.forget(delApp.InstanceName(ctrl.namespace))
+					ctrl.forgetAppAnomalies(delApp)
+					ctrl.metricsServer.DeleteAppFlappingMetric(delApp)
 				}
 			},
 		},
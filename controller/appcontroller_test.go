@@ -50,6 +50,7 @@ import (
 	mockrepoclient "github.com/argoproj/argo-cd/v3/reposerver/apiclient/mocks"
 	"github.com/argoproj/argo-cd/v3/test"
 	"github.com/argoproj/argo-cd/v3/util/argo"
+	argodiff "github.com/argoproj/argo-cd/v3/util/argo/diff"
 	"github.com/argoproj/argo-cd/v3/util/argo/normalizers"
 	cacheutil "github.com/argoproj/argo-cd/v3/util/cache"
 	appstatecache "github.com/argoproj/argo-cd/v3/util/cache/appstate"
@@ -82,15 +83,19 @@ type MockKubectl struct {
 
 	DeletedResources []kube.ResourceKey
 	CreatedResources []*unstructured.Unstructured
+	// ImpersonatedUsers are the users impersonated by the created and deleted resources requests
+	ImpersonatedUsers []string
 }
 
 func (m *MockKubectl) CreateResource(ctx context.Context, config *rest.Config, gvk schema.GroupVersionKind, name string, namespace string, obj *unstructured.Unstructured, createOptions metav1.CreateOptions, subresources ...string) (*unstructured.Unstructured, error) {
 	m.CreatedResources = append(m.CreatedResources, obj)
+	m.ImpersonatedUsers = append(m.ImpersonatedUsers, config.Impersonate.UserName)
 	return m.Kubectl.CreateResource(ctx, config, gvk, name, namespace, obj, createOptions, subresources...)
 }
 
 func (m *MockKubectl) DeleteResource(ctx context.Context, config *rest.Config, gvk schema.GroupVersionKind, name string, namespace string, deleteOptions metav1.DeleteOptions) error {
 	m.DeletedResources = append(m.DeletedResources, kube.NewResourceKey(gvk.Group, gvk.Kind, namespace, name))
+	m.ImpersonatedUsers = append(m.ImpersonatedUsers, config.Impersonate.UserName)
 	return m.Kubectl.DeleteResource(ctx, config, gvk, name, namespace, deleteOptions)
 }
 
@@ -185,11 +190,17 @@ func newFakeControllerWithResync(data *fakeData, appResyncPeriod time.Duration,
 		[]string{},
 		[]string{},
 		0,
+		0,
 		true,
+		0,
+		time.Hour,
+		0,
+		false,
 		nil,
 		data.applicationNamespaces,
 		nil,
 		false,
+		argodiff.ServerSideDryRunOpts{},
 		false,
 		normalizers.IgnoreNormalizerOpts{},
 		testEnableEventList,
@@ -610,6 +621,76 @@ func TestAutoSync(t *testing.T) {
 	assert.False(t, app.Operation.Sync.Prune)
 }
 
+func TestAutoSyncProtectedCluster(t *testing.T) {
+	configMap := `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"my-map","namespace":"` + test.FakeDestNamespace + `"}}`
+	refresh := func(t *testing.T, protectedClusters []string) *v1alpha1.Application {
+		t.Helper()
+		app := newFakeApp()
+		app.Status.OperationState = nil
+		proj := defaultProj.DeepCopy()
+		proj.Spec.ProtectedClusters = protectedClusters
+		ctrl := newFakeController(&fakeData{
+			apps: []runtime.Object{app, proj},
+			manifestResponse: &apiclient.ManifestResponse{
+				Manifests: []string{configMap},
+				Namespace: test.FakeDestNamespace,
+				Server:    test.FakeClusterURL,
+				Revision:  "abc123",
+			},
+			managedLiveObjs: make(map[kube.ResourceKey]*unstructured.Unstructured),
+		}, nil)
+		key, _ := cache.MetaNamespaceKeyFunc(app)
+		ctrl.appRefreshQueue.AddRateLimited(key)
+		ctrl.requestAppRefresh(app.Name, CompareWithLatest.Pointer(), nil)
+		ctrl.processAppRefreshQueueItem()
+		updatedApp, err := ctrl.applicationClientset.ArgoprojV1alpha1().Applications(test.FakeArgoCDNamespace).Get(t.Context(), app.Name, metav1.GetOptions{})
+		require.NoError(t, err)
+		return updatedApp
+	}
+
+	t.Run("auto-sync to an unprotected cluster", func(t *testing.T) {
+		app := refresh(t, []string{"https://prod-*"})
+		assert.NotNil(t, app.Operation)
+	})
+	t.Run("no auto-sync to a protected cluster", func(t *testing.T) {
+		app := refresh(t, []string{"https://localhost:*"})
+		assert.Nil(t, app.Operation)
+	})
+}
+
+func TestAutoSyncProjectDefaultSyncPolicy(t *testing.T) {
+	configMap := `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"my-map","namespace":"` + test.FakeDestNamespace + `"}}`
+	app := newFakeApp()
+	app.Spec.SyncPolicy = nil
+	app.Status.OperationState = nil
+	proj := defaultProj.DeepCopy()
+	proj.Spec.ApplicationDefaults = &v1alpha1.ApplicationDefaults{
+		SyncPolicy:  &v1alpha1.SyncPolicy{Automated: &v1alpha1.SyncPolicyAutomated{Prune: true}},
+		SyncOptions: v1alpha1.SyncOptions{"CreateNamespace=true"},
+	}
+	ctrl := newFakeController(&fakeData{
+		apps: []runtime.Object{app, proj},
+		manifestResponse: &apiclient.ManifestResponse{
+			Manifests: []string{configMap},
+			Namespace: test.FakeDestNamespace,
+			Server:    test.FakeClusterURL,
+			Revision:  "abc123",
+		},
+		managedLiveObjs: make(map[kube.ResourceKey]*unstructured.Unstructured),
+	}, nil)
+	key, _ := cache.MetaNamespaceKeyFunc(app)
+	ctrl.appRefreshQueue.AddRateLimited(key)
+	ctrl.requestAppRefresh(app.Name, CompareWithLatest.Pointer(), nil)
+	ctrl.processAppRefreshQueueItem()
+	updatedApp, err := ctrl.applicationClientset.ArgoprojV1alpha1().Applications(test.FakeArgoCDNamespace).Get(t.Context(), app.Name, metav1.GetOptions{})
+	require.NoError(t, err)
+	require.NotNil(t, updatedApp.Operation)
+	assert.True(t, updatedApp.Operation.Sync.Prune)
+	assert.Equal(t, v1alpha1.SyncOptions{"CreateNamespace=true"}, updatedApp.Operation.Sync.SyncOptions)
+	// the default sync policy is not persisted in the spec of the application
+	assert.Nil(t, updatedApp.Spec.SyncPolicy)
+}
+
 func TestAutoSyncEnabledSetToTrue(t *testing.T) {
 	app := newFakeApp()
 	enable := true
@@ -1149,6 +1230,56 @@ func TestFinalizeAppDeletion(t *testing.T) {
 		require.Equal(t, "post-delete-hook", ctrl.kubectl.(*MockKubectl).CreatedResources[0].GetName())
 	})
 
+	t.Run("PostDelete_HookIsCreatedWithImpersonation", func(t *testing.T) {
+		app := newFakeApp()
+		app.SetPostDeleteFinalizer()
+		app.Spec.Destination.Namespace = test.FakeArgoCDNamespace
+		proj := defaultProj.DeepCopy()
+		proj.Spec.DestinationServiceAccounts = []v1alpha1.ApplicationDestinationServiceAccount{{
+			Server:                "*",
+			Namespace:             "*",
+			DefaultServiceAccount: "tenant-sa",
+		}}
+		ctrl := newFakeController(&fakeData{
+			manifestResponses: []*apiclient.ManifestResponse{{
+				Manifests: []string{fakePostDeleteHook},
+			}},
+			apps:            []runtime.Object{app, proj},
+			managedLiveObjs: map[kube.ResourceKey]*unstructured.Unstructured{},
+			configMapData: map[string]string{
+				"application.sync.impersonation.enabled": "true",
+			},
+		}, nil)
+
+		err := ctrl.finalizeApplicationDeletion(app, func(_ string) ([]*v1alpha1.Cluster, error) {
+			return []*v1alpha1.Cluster{}, nil
+		})
+		require.NoError(t, err)
+		// post-delete hook is created with the service account of the destination
+		require.Len(t, ctrl.kubectl.(*MockKubectl).CreatedResources, 1)
+		assert.Equal(t, []string{"system:serviceaccount:" + test.FakeArgoCDNamespace + ":tenant-sa"}, ctrl.kubectl.(*MockKubectl).ImpersonatedUsers)
+	})
+
+	t.Run("ImpersonationWithoutMatchingServiceAccount", func(t *testing.T) {
+		app := newFakeApp()
+		app.SetCascadedDeletion(v1alpha1.ResourcesFinalizerName)
+		app.DeletionTimestamp = &now
+		app.Spec.Destination.Namespace = test.FakeArgoCDNamespace
+		ctrl := newFakeController(&fakeData{
+			apps:            []runtime.Object{app, &defaultProj},
+			managedLiveObjs: map[kube.ResourceKey]*unstructured.Unstructured{},
+			configMapData: map[string]string{
+				"application.sync.impersonation.enabled": "true",
+			},
+		}, nil)
+
+		err := ctrl.finalizeApplicationDeletion(app, func(_ string) ([]*v1alpha1.Cluster, error) {
+			return []*v1alpha1.Cluster{}, nil
+		})
+		require.ErrorContains(t, err, "failed to find a matching service account to impersonate")
+		assert.Empty(t, ctrl.kubectl.(*MockKubectl).DeletedResources)
+	})
+
 	t.Run("PostDelete_HookIsExecuted", func(t *testing.T) {
 		app := newFakeApp()
 		app.SetPostDeleteFinalizer()
@@ -2384,6 +2515,10 @@ func Test_canProcessAppSkipReconcileAnnotation(t *testing.T) {
 	appSkipReconcileFalse.Annotations = map[string]string{common.AnnotationKeyAppSkipReconcile: "false"}
 	appSkipReconcileTrue := newFakeApp()
 	appSkipReconcileTrue.Annotations = map[string]string{common.AnnotationKeyAppSkipReconcile: "true"}
+	appSkipReconcileUntilFuture := newFakeApp()
+	appSkipReconcileUntilFuture.Annotations = map[string]string{common.AnnotationKeyAppSkipReconcileUntil: time.Now().Add(time.Hour).Format(time.RFC3339)}
+	appSkipReconcileUntilPast := newFakeApp()
+	appSkipReconcileUntilPast.Annotations = map[string]string{common.AnnotationKeyAppSkipReconcile: "true", common.AnnotationKeyAppSkipReconcileUntil: time.Now().Add(-time.Hour).Format(time.RFC3339)}
 	ctrl := newFakeController(&fakeData{}, nil)
 	tests := []struct {
 		name     string
@@ -2394,6 +2529,8 @@ func Test_canProcessAppSkipReconcileAnnotation(t *testing.T) {
 		{"Contains skip reconcile annotation ", appSkipReconcileInvalid, true},
 		{"Contains skip reconcile annotation value false", appSkipReconcileFalse, true},
 		{"Contains skip reconcile annotation value true", appSkipReconcileTrue, false},
+		{"Contains skip reconcile until annotation in the future", appSkipReconcileUntilFuture, false},
+		{"Contains skip reconcile annotation value true until the past", appSkipReconcileUntilPast, true},
 	}
 
 	for _, tt := range tests {
@@ -2403,6 +2540,46 @@ func Test_canProcessAppSkipReconcileAnnotation(t *testing.T) {
 	}
 }
 
+func Test_isReconcileSkipped(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	until := now.Add(time.Hour)
+	tests := []struct {
+		name          string
+		annotations   map[string]string
+		expectedSkip  bool
+		expectedUntil time.Time
+	}{
+		{"no annotation", nil, false, time.Time{}},
+		{"skip without expiry", map[string]string{common.AnnotationKeyAppSkipReconcile: "true"}, true, time.Time{}},
+		{"skip until", map[string]string{common.AnnotationKeyAppSkipReconcileUntil: until.Format(time.RFC3339)}, true, until},
+		{"skip with expiry", map[string]string{common.AnnotationKeyAppSkipReconcile: "true", common.AnnotationKeyAppSkipReconcileUntil: until.Format(time.RFC3339)}, true, until},
+		{"expired skip", map[string]string{common.AnnotationKeyAppSkipReconcile: "true", common.AnnotationKeyAppSkipReconcileUntil: now.Format(time.RFC3339)}, false, time.Time{}},
+		{"skip disabled", map[string]string{common.AnnotationKeyAppSkipReconcile: "false", common.AnnotationKeyAppSkipReconcileUntil: until.Format(time.RFC3339)}, false, time.Time{}},
+		{"invalid expiry", map[string]string{common.AnnotationKeyAppSkipReconcile: "true", common.AnnotationKeyAppSkipReconcileUntil: "tomorrow"}, true, time.Time{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newFakeApp()
+			app.Annotations = tt.annotations
+			skip, until := isReconcileSkipped(app, now)
+			assert.Equal(t, tt.expectedSkip, skip)
+			assert.Equal(t, tt.expectedUntil, until)
+		})
+	}
+}
+
+func Test_requestAppRefreshOnSkipReconcileExpiry(t *testing.T) {
+	app := newFakeApp()
+	app.Annotations = map[string]string{common.AnnotationKeyAppSkipReconcileUntil: time.Now().Add(50 * time.Millisecond).Format(time.RFC3339Nano)}
+	ctrl := newFakeController(&fakeData{apps: []runtime.Object{app}}, nil)
+
+	ctrl.requestAppRefreshOnSkipReconcileExpiry(app)
+	assert.Equal(t, 0, ctrl.appRefreshQueue.Len())
+	assert.Eventually(t, func() bool {
+		return ctrl.appRefreshQueue.Len() == 1
+	}, time.Second, 10*time.Millisecond)
+}
+
 func Test_syncDeleteOption(t *testing.T) {
 	app := newFakeApp()
 	ctrl := newFakeController(&fakeData{apps: []runtime.Object{app}}, nil)
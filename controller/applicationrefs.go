@@ -0,0 +1,188 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+
+	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	applisters "github.com/argoproj/argo-cd/v3/pkg/client/listers/application/v1alpha1"
+	"github.com/argoproj/argo-cd/v3/util/argo"
+	cacheutil "github.com/argoproj/argo-cd/v3/util/cache"
+)
+
+// resolveApplicationRefValues replaces the Helm value files of the source referencing the outputs of another
+// application with the values exported by that application. The values of a value file $<ref>/<configmap> are the
+// data of the ConfigMap rendered by the referenced application, and the values of a value file
+// $<ref>/<configmap>/<key> are parsed from the given key of the ConfigMap. They are merged, in order, into the values
+// of the source, which take precedence over them.
+func (m *appStateManager) resolveApplicationRefValues(app *v1alpha1.Application, source v1alpha1.ApplicationSource, appRefSources map[string]*v1alpha1.ApplicationSource) (v1alpha1.ApplicationSource, error) {
+	source, appRefValueFiles := argo.SplitApplicationRefValueFiles(source, appRefSources)
+	if len(appRefValueFiles) == 0 {
+		return source, nil
+	}
+	values := make(map[string]any)
+	for _, valueFile := range appRefValueFiles {
+		refKey, filePath, _ := strings.Cut(valueFile, "/")
+		fileValues, err := m.getApplicationRefValues(app, appRefSources[refKey], filePath)
+		var deniedErr *applicationRefDeniedError
+		if err != nil {
+			if source.Helm.IgnoreMissingValueFiles && !errors.As(err, &deniedErr) {
+				continue
+			}
+			return source, fmt.Errorf("failed to resolve value file %s: %w", valueFile, err)
+		}
+		mergeValues(values, fileValues)
+	}
+	var sourceValues map[string]any
+	if err := yaml.Unmarshal(source.Helm.ValuesYAML(), &sourceValues); err != nil {
+		return source, fmt.Errorf("failed to unmarshal the values of the source: %w", err)
+	}
+	mergeValues(values, sourceValues)
+	data, err := yaml.Marshal(values)
+	if err != nil {
+		return source, fmt.Errorf("failed to marshal values: %w", err)
+	}
+	if err := source.Helm.SetValuesString(string(data)); err != nil {
+		return source, fmt.Errorf("failed to set values: %w", err)
+	}
+	return source, nil
+}
+
+// getApplicationRefValues returns the values exported by the application referenced by the ref source, from the
+// target state of its managed resources
+func (m *appStateManager) getApplicationRefValues(app *v1alpha1.Application, appRefSource *v1alpha1.ApplicationSource, filePath string) (map[string]any, error) {
+	refApp, err := getApplicationRef(app, appRefSource, m.appLister, m.projLister, m.namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	var managedResources []*v1alpha1.ResourceDiff
+	if err := m.cache.GetAppManagedResources(refApp.InstanceName(m.namespace), &managedResources); err != nil {
+		if errors.Is(err, cacheutil.ErrCacheMiss) {
+			return nil, fmt.Errorf("the outputs of application %s are not available", refApp.QualifiedName())
+		}
+		return nil, fmt.Errorf("failed to get the outputs of application %s: %w", refApp.QualifiedName(), err)
+	}
+
+	configMapName, key, hasKey := strings.Cut(filePath, "/")
+	var configMap *unstructured.Unstructured
+	for _, res := range managedResources {
+		if res.Group != "" || res.Kind != "ConfigMap" || res.Name != configMapName {
+			continue
+		}
+		obj, err := res.TargetObject()
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal ConfigMap %s of application %s: %w", configMapName, refApp.QualifiedName(), err)
+		}
+		if obj == nil {
+			continue
+		}
+		if configMap != nil {
+			return nil, fmt.Errorf("application %s has multiple ConfigMaps named %s", refApp.QualifiedName(), configMapName)
+		}
+		configMap = obj
+	}
+	if configMap == nil {
+		return nil, fmt.Errorf("application %s has no ConfigMap named %s", refApp.QualifiedName(), configMapName)
+	}
+
+	data, _, err := unstructured.NestedStringMap(configMap.Object, "data")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the data of ConfigMap %s of application %s: %w", configMapName, refApp.QualifiedName(), err)
+	}
+	if !hasKey {
+		values := make(map[string]any, len(data))
+		for k, v := range data {
+			values[k] = v
+		}
+		return values, nil
+	}
+	value, ok := data[key]
+	if !ok {
+		return nil, fmt.Errorf("ConfigMap %s of application %s has no key %s", configMapName, refApp.QualifiedName(), key)
+	}
+	var values map[string]any
+	if err := yaml.Unmarshal([]byte(value), &values); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal key %s of ConfigMap %s of application %s: %w", key, configMapName, refApp.QualifiedName(), err)
+	}
+	return values, nil
+}
+
+// applicationRefDeniedError is returned when an application isn't allowed to use the outputs of the application it
+// references
+type applicationRefDeniedError struct {
+	app    *v1alpha1.Application
+	refApp *v1alpha1.Application
+}
+
+func (e *applicationRefDeniedError) Error() string {
+	return fmt.Sprintf("application %s of project %s isn't allowed to use the outputs of application %s of project %s, which must list it in its applicationRefProjects", e.app.QualifiedName(), e.app.Spec.GetProject(), e.refApp.QualifiedName(), e.refApp.Spec.GetProject())
+}
+
+// getApplicationRef returns the application referenced by the ref source, if the application is allowed to use its
+// outputs, i.e. if it belongs to the same project, or to a project allowing the project of the application in its
+// applicationRefProjects
+func getApplicationRef(app *v1alpha1.Application, appRefSource *v1alpha1.ApplicationSource, appLister applisters.ApplicationLister, projLister applisters.AppProjectLister, controllerNamespace string) (*v1alpha1.Application, error) {
+	namespace, name := appRefSource.ApplicationRef()
+	if namespace == "" {
+		namespace = app.Namespace
+	}
+	refApp, err := appLister.Applications(namespace).Get(name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("the outputs of application %s/%s are not available", namespace, name)
+		}
+		return nil, fmt.Errorf("failed to get application %s/%s: %w", namespace, name, err)
+	}
+	if refApp.Spec.GetProject() == app.Spec.GetProject() {
+		return refApp, nil
+	}
+	refProj, err := projLister.AppProjects(controllerNamespace).Get(refApp.Spec.GetProject())
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, &applicationRefDeniedError{app: app, refApp: refApp}
+		}
+		return nil, fmt.Errorf("failed to get project %s of application %s: %w", refApp.Spec.GetProject(), refApp.QualifiedName(), err)
+	}
+	if !refProj.IsApplicationRefPermitted(app.Spec.GetProject()) {
+		return nil, &applicationRefDeniedError{app: app, refApp: refApp}
+	}
+	return refApp, nil
+}
+
+// validateApplicationRefs returns the conditions of the ref sources of the application referencing applications whose
+// outputs it isn't allowed to use
+func validateApplicationRefs(app *v1alpha1.Application, appLister applisters.ApplicationLister, projLister applisters.AppProjectLister, controllerNamespace string) []v1alpha1.ApplicationCondition {
+	var conditions []v1alpha1.ApplicationCondition
+	for _, source := range app.Spec.GetSources() {
+		if !source.IsApplicationRef() {
+			continue
+		}
+		var deniedErr *applicationRefDeniedError
+		if _, err := getApplicationRef(app, &source, appLister, projLister, controllerNamespace); errors.As(err, &deniedErr) {
+			conditions = append(conditions, v1alpha1.ApplicationCondition{
+				Type:    v1alpha1.ApplicationConditionInvalidSpecError,
+				Message: err.Error(),
+			})
+		}
+	}
+	return conditions
+}
+
+// mergeValues merges the Helm values of src into dst, the values of src taking precedence
+func mergeValues(dst, src map[string]any) {
+	for k, v := range src {
+		srcMap, srcIsMap := v.(map[string]any)
+		dstMap, dstIsMap := dst[k].(map[string]any)
+		if srcIsMap && dstIsMap {
+			mergeValues(dstMap, srcMap)
+			continue
+		}
+		dst[k] = v
+	}
+}
@@ -0,0 +1,99 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	applisters "github.com/argoproj/argo-cd/v3/pkg/client/listers/application/v1alpha1"
+	"github.com/argoproj/argo-cd/v3/util/argo"
+	cacheutil "github.com/argoproj/argo-cd/v3/util/cache"
+	appstatecache "github.com/argoproj/argo-cd/v3/util/cache/appstate"
+)
+
+func TestResolveApplicationRefValues(t *testing.T) {
+	stateCache := appstatecache.NewCache(cacheutil.NewCache(cacheutil.NewInMemoryCache(time.Minute)), time.Minute)
+	require.NoError(t, stateCache.SetAppManagedResources("platform", []*v1alpha1.ResourceDiff{
+		{Kind: "Service", Namespace: "platform", Name: "endpoints", TargetState: `{"apiVersion":"v1","kind":"Service","metadata":{"name":"endpoints"}}`},
+		{Kind: "ConfigMap", Namespace: "platform", Name: "endpoints", TargetState: `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"endpoints"},"data":{"dbHost":"db.platform.svc"}}`},
+		{Kind: "ConfigMap", Namespace: "platform", Name: "values", TargetState: `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"values"},"data":{"values.yaml":"db:\n  host: db.platform.svc\n  port: 5432\n"}}`},
+		{Kind: "ConfigMap", Namespace: "platform", Name: "pruned", TargetState: "null"},
+	}))
+	appIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	require.NoError(t, appIndexer.Add(&v1alpha1.Application{ObjectMeta: metav1.ObjectMeta{Name: "platform", Namespace: "argocd"}, Spec: v1alpha1.ApplicationSpec{Project: "platform"}}))
+	projIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	require.NoError(t, projIndexer.Add(&v1alpha1.AppProject{ObjectMeta: metav1.ObjectMeta{Name: "platform", Namespace: "argocd"}, Spec: v1alpha1.AppProjectSpec{ApplicationRefProjects: []string{"team-*"}}}))
+	appLister := applisters.NewApplicationLister(appIndexer)
+	projLister := applisters.NewAppProjectLister(projIndexer)
+	m := &appStateManager{cache: stateCache, namespace: "argocd", appLister: appLister, projLister: projLister}
+	app := &v1alpha1.Application{ObjectMeta: metav1.ObjectMeta{Name: "guestbook", Namespace: "argocd"}, Spec: v1alpha1.ApplicationSpec{Project: "team-a"}}
+	sources := v1alpha1.ApplicationSources{
+		{RepoURL: "argocd-app://platform", Ref: "platform"},
+		{
+			RepoURL: "https://argoproj.github.io/argo-helm",
+			Chart:   "guestbook",
+			Helm: &v1alpha1.ApplicationSourceHelm{
+				ValueFiles: []string{"values.yaml", "$platform/endpoints", "$platform/values/values.yaml"},
+				Values:     "db:\n  port: 5433\n",
+			},
+		},
+	}
+	appRefSources := argo.GetApplicationRefSources(sources)
+
+	t.Run("values", func(t *testing.T) {
+		source, err := m.resolveApplicationRefValues(app, sources[1], appRefSources)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"values.yaml"}, source.Helm.ValueFiles)
+		assert.Equal(t, "db:\n  host: db.platform.svc\n  port: 5433\ndbHost: db.platform.svc", source.Helm.ValuesString())
+		assert.Equal(t, "db:\n  port: 5433\n", sources[1].Helm.Values)
+	})
+
+	t.Run("no application ref", func(t *testing.T) {
+		source, err := m.resolveApplicationRefValues(app, sources[0], appRefSources)
+		require.NoError(t, err)
+		assert.Equal(t, sources[0], source)
+	})
+
+	t.Run("missing outputs", func(t *testing.T) {
+		for _, valueFile := range []string{"$platform/pruned", "$platform/values/missing.yaml"} {
+			source := *sources[1].DeepCopy()
+			source.Helm.ValueFiles = []string{valueFile}
+			_, err := m.resolveApplicationRefValues(app, source, appRefSources)
+			require.Error(t, err)
+
+			source.Helm.IgnoreMissingValueFiles = true
+			source, err = m.resolveApplicationRefValues(app, source, appRefSources)
+			require.NoError(t, err)
+			assert.Equal(t, "db:\n  port: 5433", source.Helm.ValuesString())
+		}
+	})
+
+	t.Run("project not allowed", func(t *testing.T) {
+		deniedApp := app.DeepCopy()
+		deniedApp.Spec.Project = "default"
+		deniedApp.Spec.Sources = sources
+		source := *sources[1].DeepCopy()
+		source.Helm.IgnoreMissingValueFiles = true
+		_, err := m.resolveApplicationRefValues(deniedApp, source, appRefSources)
+		require.EqualError(t, err, "failed to resolve value file $platform/endpoints: application argocd/guestbook of project default isn't allowed to use the outputs of application argocd/platform of project platform, which must list it in its applicationRefProjects")
+
+		conditions := validateApplicationRefs(deniedApp, appLister, projLister, "argocd")
+		require.Len(t, conditions, 1)
+		assert.Equal(t, v1alpha1.ApplicationConditionInvalidSpecError, conditions[0].Type)
+
+		allowedApp := app.DeepCopy()
+		allowedApp.Spec.Sources = sources
+		assert.Empty(t, validateApplicationRefs(allowedApp, appLister, projLister, "argocd"))
+	})
+
+	t.Run("application not reconciled", func(t *testing.T) {
+		refSources := v1alpha1.ApplicationSources{{RepoURL: "argocd-app://other/platform", Ref: "platform"}}
+		_, err := m.resolveApplicationRefValues(app, sources[1], argo.GetApplicationRefSources(refSources))
+		require.ErrorContains(t, err, "the outputs of application other/platform are not available")
+	})
+}
@@ -0,0 +1,154 @@
+package controller
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	"k8s.io/client-go/util/workqueue"
+
+	appv1 "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+)
+
+const (
+	appRefreshQueueName   = "app_reconciliation_queue"
+	appOperationQueueName = "app_operation_processing_queue"
+)
+
+// appQueueMetricsRecorder records the metrics of the application queues
+type appQueueMetricsRecorder interface {
+	IncWorkqueueDepth(queue, project, shard string)
+	DecWorkqueueDepth(queue, project, shard string)
+	IncWorkqueueAdds(queue, project, shard string)
+	IncWorkqueueRetries(queue, project, shard string)
+	ObserveReconcileLatency(project, shard string, latency time.Duration)
+}
+
+// queuedApp holds the labels of an application key in a queue and the time it was added
+type queuedApp struct {
+	project string
+	shard   string
+	addedAt time.Time
+}
+
+// appQueue is a queue of application keys which records its depth, adds and retries segmented by the project and the
+// shard of the applications. It mirrors the dirty and processing sets of the underlying queue so that an application
+// is counted once however many times it is added before being processed.
+type appQueue struct {
+	*workqueue.Typed[string]
+	name       string
+	metrics    appQueueMetricsRecorder
+	getProject func(appKey string) string
+	getShard   func() string
+	// observeLatency is true if the time from an application being added until it is done is a reconcile latency
+	observeLatency bool
+	now            func() time.Time
+
+	lock       sync.Mutex
+	dirty      map[string]queuedApp
+	processing map[string]queuedApp
+}
+
+// newAppQueue returns a rate limiting queue of application keys which records the application queue metrics
+func newAppQueue(name string, rateLimiter workqueue.TypedRateLimiter[string], metrics appQueueMetricsRecorder, getProject func(appKey string) string, getShard func() string, observeLatency bool) workqueue.TypedRateLimitingInterface[string] {
+	queue := &appQueue{
+		Typed:          workqueue.NewTypedWithConfig(workqueue.TypedQueueConfig[string]{Name: name}),
+		name:           name,
+		metrics:        metrics,
+		getProject:     getProject,
+		getShard:       getShard,
+		observeLatency: observeLatency,
+		now:            time.Now,
+		dirty:          map[string]queuedApp{},
+		processing:     map[string]queuedApp{},
+	}
+	delayingQueue := &appDelayingQueue{
+		TypedDelayingInterface: workqueue.NewTypedDelayingQueueWithConfig(workqueue.TypedDelayingQueueConfig[string]{Name: name, Queue: queue}),
+		queue:                  queue,
+	}
+	return workqueue.NewTypedRateLimitingQueueWithConfig(rateLimiter, workqueue.TypedRateLimitingQueueConfig[string]{Name: name, DelayingQueue: delayingQueue})
+}
+
+func (q *appQueue) labels(appKey string) queuedApp {
+	return queuedApp{project: q.getProject(appKey), shard: q.getShard(), addedAt: q.now()}
+}
+
+func (q *appQueue) Add(appKey string) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	if _, ok := q.dirty[appKey]; !ok && !q.ShuttingDown() {
+		app := q.labels(appKey)
+		q.dirty[appKey] = app
+		q.metrics.IncWorkqueueAdds(q.name, app.project, app.shard)
+		if _, ok := q.processing[appKey]; !ok {
+			q.metrics.IncWorkqueueDepth(q.name, app.project, app.shard)
+		}
+	}
+	q.Typed.Add(appKey)
+}
+
+func (q *appQueue) Get() (string, bool) {
+	appKey, shutdown := q.Typed.Get()
+	if shutdown {
+		return appKey, shutdown
+	}
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	if app, ok := q.dirty[appKey]; ok {
+		delete(q.dirty, appKey)
+		q.processing[appKey] = app
+		q.metrics.DecWorkqueueDepth(q.name, app.project, app.shard)
+	}
+	return appKey, shutdown
+}
+
+func (q *appQueue) Done(appKey string) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	if app, ok := q.processing[appKey]; ok {
+		delete(q.processing, appKey)
+		if q.observeLatency {
+			q.metrics.ObserveReconcileLatency(app.project, app.shard, q.now().Sub(app.addedAt))
+		}
+		// the application was added again while being processed and is now back in the queue
+		if app, ok := q.dirty[appKey]; ok {
+			q.metrics.IncWorkqueueDepth(q.name, app.project, app.shard)
+		}
+	}
+	q.Typed.Done(appKey)
+}
+
+// appDelayingQueue counts the applications added with a delay, which includes the rate limited ones, as retries
+type appDelayingQueue struct {
+	workqueue.TypedDelayingInterface[string]
+	queue *appQueue
+}
+
+func (q *appDelayingQueue) AddAfter(appKey string, duration time.Duration) {
+	if !q.ShuttingDown() {
+		q.queue.metrics.IncWorkqueueRetries(q.queue.name, q.queue.getProject(appKey), q.queue.getShard())
+	}
+	q.TypedDelayingInterface.AddAfter(appKey, duration)
+}
+
+// getAppProjectName returns the project of the application with the given key, or an empty string if the application
+// is not found
+func (ctrl *ApplicationController) getAppProjectName(appKey string) string {
+	if ctrl.appInformer == nil {
+		return ""
+	}
+	obj, exists, err := ctrl.appInformer.GetIndexer().GetByKey(appKey)
+	if err != nil || !exists {
+		return ""
+	}
+	app, ok := obj.(*appv1.Application)
+	if !ok {
+		return ""
+	}
+	return app.Spec.GetProject()
+}
+
+// getShardLabel returns the shard processed by the controller as a metric label
+func (ctrl *ApplicationController) getShardLabel() string {
+	return strconv.Itoa(ctrl.clusterSharding.GetShard())
+}
@@ -0,0 +1,107 @@
+package controller
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/client-go/util/workqueue"
+)
+
+type fakeAppQueueMetrics struct {
+	lock      sync.Mutex
+	depth     map[string]int
+	adds      map[string]int
+	retries   map[string]int
+	latencies map[string][]time.Duration
+}
+
+func newFakeAppQueueMetrics() *fakeAppQueueMetrics {
+	return &fakeAppQueueMetrics{depth: map[string]int{}, adds: map[string]int{}, retries: map[string]int{}, latencies: map[string][]time.Duration{}}
+}
+
+func (m *fakeAppQueueMetrics) IncWorkqueueDepth(queue, project, shard string) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.depth[queue+"/"+project+"/"+shard]++
+}
+
+func (m *fakeAppQueueMetrics) DecWorkqueueDepth(queue, project, shard string) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.depth[queue+"/"+project+"/"+shard]--
+}
+
+func (m *fakeAppQueueMetrics) IncWorkqueueAdds(queue, project, shard string) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.adds[queue+"/"+project+"/"+shard]++
+}
+
+func (m *fakeAppQueueMetrics) IncWorkqueueRetries(queue, project, shard string) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.retries[queue+"/"+project+"/"+shard]++
+}
+
+func (m *fakeAppQueueMetrics) ObserveReconcileLatency(project, shard string, latency time.Duration) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.latencies[project+"/"+shard] = append(m.latencies[project+"/"+shard], latency)
+}
+
+func newTestAppQueue(metrics appQueueMetricsRecorder, projects map[string]string) workqueue.TypedRateLimitingInterface[string] {
+	return newAppQueue("test_queue", workqueue.DefaultTypedControllerRateLimiter[string](), metrics, func(appKey string) string {
+		return projects[appKey]
+	}, func() string {
+		return "0"
+	}, true)
+}
+
+func TestAppQueue_Depth(t *testing.T) {
+	metrics := newFakeAppQueueMetrics()
+	queue := newTestAppQueue(metrics, map[string]string{"argocd/app1": "team-a", "argocd/app2": "team-a", "argocd/app3": "team-b"})
+	defer queue.ShutDown()
+
+	queue.Add("argocd/app1")
+	queue.Add("argocd/app1")
+	queue.Add("argocd/app2")
+	queue.Add("argocd/app3")
+
+	assert.Equal(t, 2, metrics.depth["test_queue/team-a/0"])
+	assert.Equal(t, 1, metrics.depth["test_queue/team-b/0"])
+	assert.Equal(t, 2, metrics.adds["test_queue/team-a/0"])
+	assert.Equal(t, 1, metrics.adds["test_queue/team-b/0"])
+
+	appKey, _ := queue.Get()
+	assert.Equal(t, "argocd/app1", appKey)
+	assert.Equal(t, 1, metrics.depth["test_queue/team-a/0"])
+
+	// adding an application being processed queues it again once it is done
+	queue.Add("argocd/app1")
+	assert.Equal(t, 1, metrics.depth["test_queue/team-a/0"])
+	queue.Done(appKey)
+	assert.Equal(t, 2, metrics.depth["test_queue/team-a/0"])
+	assert.Len(t, metrics.latencies["team-a/0"], 1)
+}
+
+func TestAppQueue_Retries(t *testing.T) {
+	metrics := newFakeAppQueueMetrics()
+	queue := newTestAppQueue(metrics, map[string]string{"argocd/app1": "team-a"})
+	defer queue.ShutDown()
+
+	queue.AddRateLimited("argocd/app1")
+	assert.Equal(t, 1, metrics.retries["test_queue/team-a/0"])
+
+	// the rate limited application is added once its delay expires
+	appKey, _ := queue.Get()
+	assert.Equal(t, "argocd/app1", appKey)
+	queue.Done(appKey)
+	assert.Equal(t, 1, metrics.adds["test_queue/team-a/0"])
+	assert.Equal(t, 0, metrics.depth["test_queue/team-a/0"])
+
+	queue.AddAfter("argocd/app1", 0)
+	assert.Equal(t, 2, metrics.retries["test_queue/team-a/0"])
+	assert.Equal(t, 2, metrics.adds["test_queue/team-a/0"])
+}
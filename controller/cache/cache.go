@@ -4,11 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"math"
 	"net"
 	"net/url"
 	"os/exec"
 	"reflect"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
@@ -75,6 +77,11 @@ const (
 	// EnvClusterCacheEventsProcessingInterval is the env variable to control the interval between processing events when BatchEventsProcessing is enabled
 	EnvClusterCacheEventsProcessingInterval = "ARGOCD_CLUSTER_CACHE_EVENTS_PROCESSING_INTERVAL"
 
+	// EnvClusterCacheNodeInfoMemoryBudget is the env variable to control the number of bytes the node details of the
+	// resources which aren't managed by an application can use before the ones of the least recently compared
+	// applications are evicted
+	EnvClusterCacheNodeInfoMemoryBudget = "ARGOCD_CLUSTER_CACHE_NODE_INFO_MEMORY_BUDGET"
+
 	// AnnotationIgnoreResourceUpdates when set to true on an untracked resource,
 	// argo will apply `ignoreResourceUpdates` configuration on it.
 	AnnotationIgnoreResourceUpdates = "argocd.argoproj.io/ignore-resource-updates"
@@ -115,6 +122,10 @@ var (
 
 	// clusterCacheEventsProcessingInterval specifies the interval between processing events when BatchEventsProcessing is enabled
 	clusterCacheEventsProcessingInterval = 100 * time.Millisecond
+
+	// clusterCacheNodeInfoMemoryBudget is the memory budget of the node details of the resources which aren't managed
+	// by an application, in bytes. The budget is unlimited if zero.
+	clusterCacheNodeInfoMemoryBudget int64
 )
 
 func init() {
@@ -128,6 +139,7 @@ func init() {
 	clusterCacheRetryUseBackoff = env.ParseBoolFromEnv(EnvClusterCacheRetryUseBackoff, false)
 	clusterCacheBatchEventsProcessing = env.ParseBoolFromEnv(EnvClusterCacheBatchEventsProcessing, true)
 	clusterCacheEventsProcessingInterval = env.ParseDurationFromEnv(EnvClusterCacheEventsProcessingInterval, clusterCacheEventsProcessingInterval, 0, math.MaxInt64)
+	clusterCacheNodeInfoMemoryBudget = env.ParseInt64FromEnv(EnvClusterCacheNodeInfoMemoryBudget, clusterCacheNodeInfoMemoryBudget, 0, math.MaxInt64)
 }
 
 type LiveStateCache interface {
@@ -185,11 +197,16 @@ type ResourceInfo struct {
 	NodeInfo *NodeInfo
 
 	manifestHash string
+	// evicted indicates that the info items, images and networking information were evicted to save memory
+	evicted bool
+	// forgotten indicates that the resource was updated or deleted since, so that its node details are no longer tracked
+	forgotten bool
 }
 
 func NewLiveStateCache(
 	db db.ArgoDB,
 	appInformer cache.SharedIndexInformer,
+	projInformer cache.SharedIndexInformer,
 	settingsMgr *settings.SettingsManager,
 	metricsServer *metrics.MetricsServer,
 	onObjectUpdated ObjectUpdatedHandler,
@@ -198,6 +215,7 @@ func NewLiveStateCache(
 ) LiveStateCache {
 	return &liveStateCache{
 		appInformer:      appInformer,
+		projInformer:     projInformer,
 		db:               db,
 		clusters:         make(map[string]clustercache.ClusterCache),
 		onObjectUpdated:  onObjectUpdated,
@@ -205,6 +223,7 @@ func NewLiveStateCache(
 		metricsServer:    metricsServer,
 		clusterSharding:  clusterSharding,
 		resourceTracking: resourceTracking,
+		nodeInfoBudget:   newNodeInfoBudget(clusterCacheNodeInfoMemoryBudget),
 	}
 }
 
@@ -212,17 +231,24 @@ type cacheSettings struct {
 	clusterSettings     clustercache.Settings
 	appInstanceLabelKey string
 	trackingMethod      appv1.TrackingMethod
-	installationID      string
+	// projectTrackingMethods are the tracking methods set in the projects, other than the one of the settings
+	projectTrackingMethods []appv1.TrackingMethod
+	installationID         string
 	// resourceOverrides provides a list of ignored differences to ignore watched resource updates
 	resourceOverrides map[string]appv1.ResourceOverride
 
 	// ignoreResourceUpdates is a flag to enable resource-ignore rules.
 	ignoreResourceUpdatesEnabled bool
+	// resourceCustomLabels are the labels of the resources shown in the resource tree
+	resourceCustomLabels []string
+	// respectRBAC is whether the permissions of the controller are respected when the resources are watched
+	respectRBAC int
 }
 
 type liveStateCache struct {
 	db                   db.ArgoDB
 	appInformer          cache.SharedIndexInformer
+	projInformer         cache.SharedIndexInformer
 	onObjectUpdated      ObjectUpdatedHandler
 	settingsMgr          *settings.SettingsManager
 	metricsServer        *metrics.MetricsServer
@@ -232,7 +258,87 @@ type liveStateCache struct {
 
 	clusters      map[string]clustercache.ClusterCache
 	cacheSettings cacheSettings
-	lock          sync.RWMutex
+	// resourceGroups holds the API groups cached for each cluster, by cluster server
+	resourceGroups map[string][]string
+	lock           sync.RWMutex
+
+	nodeInfoBudget *nodeInfoBudget
+}
+
+// resourceGroupsFilter excludes the resources of the API groups which aren't in the list of groups cached for a
+// cluster, in addition to the resources excluded by the wrapped filter. Resources of the core API group are never
+// excluded by the list of groups.
+type resourceGroupsFilter struct {
+	kube.ResourceFilter
+	groups map[string]bool
+}
+
+func (f *resourceGroupsFilter) IsExcludedResource(group, kind, cluster string) bool {
+	if group != "" && !f.groups[group] {
+		return true
+	}
+	return f.ResourceFilter != nil && f.ResourceFilter.IsExcludedResource(group, kind, cluster)
+}
+
+// projectInclusionsFilter doesn't exclude the resources included by the projects, so that they are watched even if
+// the wrapped filter excludes them. The applications of the other projects don't track them anyway, since the resources
+// excluded in the settings are filtered out when the state of the applications is compared.
+type projectInclusionsFilter struct {
+	kube.ResourceFilter
+	inclusions []appv1.ResourceFilterRule
+}
+
+func (f *projectInclusionsFilter) IsExcludedResource(group, kind, cluster string) bool {
+	for _, rule := range f.inclusions {
+		if rule.Match(group, kind, cluster) {
+			return false
+		}
+	}
+	return f.ResourceFilter != nil && f.ResourceFilter.IsExcludedResource(group, kind, cluster)
+}
+
+// clusterCacheSyncSettings returns the resync period, sync retry timeout and list page size of the cache of a cluster,
+// which are the values set in the cluster if any, and the values set for the controller otherwise
+func clusterCacheSyncSettings(cluster *appv1.Cluster) []clustercache.UpdateSettingsFunc {
+	resyncDuration := clusterCacheResyncDuration
+	if cluster.CacheResyncPeriod != "" {
+		if duration, err := time.ParseDuration(cluster.CacheResyncPeriod); err != nil {
+			log.Warnf("Invalid cache resync period of cluster %s: %v", cluster.Server, err)
+		} else {
+			resyncDuration = duration
+		}
+	}
+	syncRetryTimeout := clusterSyncRetryTimeoutDuration
+	if cluster.CacheSyncRetryTimeout != "" {
+		if duration, err := time.ParseDuration(cluster.CacheSyncRetryTimeout); err != nil {
+			log.Warnf("Invalid cache sync retry timeout of cluster %s: %v", cluster.Server, err)
+		} else {
+			syncRetryTimeout = duration
+		}
+	}
+	listPageSize := clusterCacheListPageSize
+	if cluster.CacheListPageSize > 0 {
+		listPageSize = cluster.CacheListPageSize
+	}
+	return []clustercache.UpdateSettingsFunc{
+		clustercache.SetResyncTimeout(resyncDuration),
+		clustercache.SetClusterSyncRetryTimeout(syncRetryTimeout),
+		clustercache.SetListPageSize(listPageSize),
+	}
+}
+
+// clusterCacheSettings returns the cluster cache settings restricted to the given API groups, or the settings
+// unchanged if the list of groups is empty
+func clusterCacheSettings(settings clustercache.Settings, resourceGroups []string) clustercache.Settings {
+	if len(resourceGroups) == 0 {
+		return settings
+	}
+	groups := make(map[string]bool, len(resourceGroups))
+	for _, group := range resourceGroups {
+		groups[group] = true
+	}
+	settings.ResourcesFilter = &resourceGroupsFilter{ResourceFilter: settings.ResourcesFilter, groups: groups}
+	return settings
 }
 
 func (c *liveStateCache) loadCacheSettings() (*cacheSettings, error) {
@@ -264,12 +370,105 @@ func (c *liveStateCache) loadCacheSettings() (*cacheSettings, error) {
 	if err != nil {
 		return nil, err
 	}
+	resourceCustomLabels, err := c.settingsMgr.GetResourceCustomLabels()
+	if err != nil {
+		return nil, err
+	}
+	respectRBAC, err := c.settingsMgr.RespectRBAC()
+	if err != nil {
+		// an invalid value disables the feature instead of failing the caches of all the clusters
+		log.Warnf("Failed to get the value of %s, the RBAC of the controller is not respected: %v", settings.RespectRBAC, err)
+	}
 	clusterSettings := clustercache.Settings{
 		ResourceHealthOverride: lua.ResourceHealthOverrides(resourceOverrides),
 		ResourcesFilter:        resourcesFilter,
 	}
+	if inclusions := c.getProjectResourceInclusions(); len(inclusions) > 0 {
+		clusterSettings.ResourcesFilter = &projectInclusionsFilter{ResourceFilter: resourcesFilter, inclusions: inclusions}
+	}
+	projectTrackingMethods := c.getProjectTrackingMethods(appv1.TrackingMethod(trackingMethod))
 
-	return &cacheSettings{clusterSettings, appInstanceLabelKey, appv1.TrackingMethod(trackingMethod), installationID, resourceUpdatesOverrides, ignoreResourceUpdatesEnabled}, nil
+	return &cacheSettings{clusterSettings, appInstanceLabelKey, appv1.TrackingMethod(trackingMethod), projectTrackingMethods, installationID, resourceUpdatesOverrides, ignoreResourceUpdatesEnabled, resourceCustomLabels, respectRBAC}, nil
+}
+
+// getProjectTrackingMethods returns the sorted tracking methods set in the projects, other than the given tracking
+// method of the settings
+func (c *liveStateCache) getProjectTrackingMethods(trackingMethod appv1.TrackingMethod) []appv1.TrackingMethod {
+	if c.projInformer == nil {
+		return nil
+	}
+	var trackingMethods []appv1.TrackingMethod
+	for _, obj := range c.projInformer.GetStore().List() {
+		proj, ok := obj.(*appv1.AppProject)
+		if !ok || proj.Spec.TrackingMethod == "" || proj.Spec.TrackingMethod == trackingMethod || slices.Contains(trackingMethods, proj.Spec.TrackingMethod) {
+			continue
+		}
+		trackingMethods = append(trackingMethods, proj.Spec.TrackingMethod)
+	}
+	slices.Sort(trackingMethods)
+	return trackingMethods
+}
+
+// getProjectResourceInclusions returns the resource inclusions of the projects, ordered by project name so that the
+// cache settings only change when the inclusions do
+func (c *liveStateCache) getProjectResourceInclusions() []appv1.ResourceFilterRule {
+	if c.projInformer == nil {
+		return nil
+	}
+	var projects []*appv1.AppProject
+	for _, obj := range c.projInformer.GetStore().List() {
+		if proj, ok := obj.(*appv1.AppProject); ok && len(proj.Spec.ResourceInclusions) > 0 {
+			projects = append(projects, proj)
+		}
+	}
+	slices.SortFunc(projects, func(a, b *appv1.AppProject) int {
+		return strings.Compare(a.Namespace+"/"+a.Name, b.Namespace+"/"+b.Name)
+	})
+	var inclusions []appv1.ResourceFilterRule
+	for _, proj := range projects {
+		inclusions = append(inclusions, proj.Spec.ResourceInclusions...)
+	}
+	return inclusions
+}
+
+// getAppName returns the name of the application tracking a resource with the tracking method of the settings, or
+// with the tracking methods of the projects if the resource isn't tracked with the tracking method of the settings. A
+// match is dropped unless the tracking method is the one of the project of the application, so that the resources
+// tracked with a method the application doesn't use aren't attributed to the application.
+func (c *liveStateCache) getAppName(un *unstructured.Unstructured, cacheSettings cacheSettings) string {
+	for _, trackingMethod := range append([]appv1.TrackingMethod{cacheSettings.trackingMethod}, cacheSettings.projectTrackingMethods...) {
+		appName := c.resourceTracking.GetAppName(un, cacheSettings.appInstanceLabelKey, trackingMethod, cacheSettings.installationID)
+		if appName != "" && c.getAppTrackingMethod(appName, cacheSettings.trackingMethod) == trackingMethod {
+			return appName
+		}
+	}
+	return ""
+}
+
+// getAppTrackingMethod returns the tracking method of the application with the given instance name, which is the one
+// set in its project if any, or the given tracking method of the settings
+func (c *liveStateCache) getAppTrackingMethod(appInstanceName string, trackingMethod appv1.TrackingMethod) appv1.TrackingMethod {
+	if c.appInformer == nil || c.projInformer == nil {
+		return trackingMethod
+	}
+	namespace := c.settingsMgr.GetNamespace()
+	appName, appNamespace := argo.ParseInstanceName(appInstanceName, namespace)
+	obj, exists, err := c.appInformer.GetStore().GetByKey(appNamespace + "/" + appName)
+	if err != nil || !exists {
+		return trackingMethod
+	}
+	app, ok := obj.(*appv1.Application)
+	if !ok {
+		return trackingMethod
+	}
+	obj, exists, err = c.projInformer.GetStore().GetByKey(namespace + "/" + app.Spec.GetProject())
+	if err != nil || !exists {
+		return trackingMethod
+	}
+	if proj, ok := obj.(*appv1.AppProject); ok && proj.Spec.TrackingMethod != "" {
+		return proj.Spec.TrackingMethod
+	}
+	return trackingMethod
 }
 
 func asResourceNode(r *clustercache.Resource) appv1.ResourceNode {
@@ -502,16 +701,6 @@ func (c *liveStateCache) getCluster(cluster *appv1.Cluster) (clustercache.Cluste
 		return nil, fmt.Errorf("controller is configured to ignore cluster %s", cluster.Server)
 	}
 
-	resourceCustomLabels, err := c.settingsMgr.GetResourceCustomLabels()
-	if err != nil {
-		return nil, fmt.Errorf("error getting custom label: %w", err)
-	}
-
-	respectRBAC, err := c.settingsMgr.RespectRBAC()
-	if err != nil {
-		return nil, fmt.Errorf("error getting value for %v: %w", settings.RespectRBAC, err)
-	}
-
 	clusterCacheConfig, err := cluster.RESTConfig()
 	if err != nil {
 		return nil, fmt.Errorf("error getting cluster RESTConfig: %w", err)
@@ -531,24 +720,21 @@ func (c *liveStateCache) getCluster(cluster *appv1.Cluster) (clustercache.Cluste
 
 	clusterCacheOpts := []clustercache.UpdateSettingsFunc{
 		clustercache.SetListSemaphore(semaphore.NewWeighted(clusterCacheListSemaphoreSize)),
-		clustercache.SetListPageSize(clusterCacheListPageSize),
 		clustercache.SetListPageBufferSize(clusterCacheListPageBufferSize),
 		clustercache.SetWatchResyncTimeout(clusterCacheWatchResyncDuration),
-		clustercache.SetClusterSyncRetryTimeout(clusterSyncRetryTimeoutDuration),
-		clustercache.SetResyncTimeout(clusterCacheResyncDuration),
-		clustercache.SetSettings(cacheSettings.clusterSettings),
+		clustercache.SetSettings(clusterCacheSettings(cacheSettings.clusterSettings, cluster.ResourceGroups)),
 		clustercache.SetNamespaces(cluster.Namespaces),
 		clustercache.SetClusterResources(cluster.ClusterResources),
 		clustercache.SetPopulateResourceInfoHandler(func(un *unstructured.Unstructured, isRoot bool) (any, bool) {
 			res := &ResourceInfo{}
-			populateNodeInfo(un, res, resourceCustomLabels)
 			c.lock.RLock()
 			cacheSettings := c.cacheSettings
 			c.lock.RUnlock()
+			populateNodeInfo(un, res, cacheSettings.resourceCustomLabels)
 
 			res.Health, _ = health.GetResourceHealth(un, cacheSettings.clusterSettings.ResourceHealthOverride)
 
-			appName := c.resourceTracking.GetAppName(un, cacheSettings.appInstanceLabelKey, cacheSettings.trackingMethod, cacheSettings.installationID)
+			appName := c.getAppName(un, cacheSettings)
 			if isRoot && appName != "" {
 				res.AppName = appName
 			}
@@ -570,14 +756,18 @@ func (c *liveStateCache) getCluster(cluster *appv1.Cluster) (clustercache.Cluste
 		}),
 		clustercache.SetLogr(logutils.NewLogrusLogger(log.WithField("server", cluster.Server))),
 		clustercache.SetRetryOptions(clusterCacheAttemptLimit, clusterCacheRetryUseBackoff, isRetryableError),
-		clustercache.SetRespectRBAC(respectRBAC),
+		clustercache.SetRespectRBAC(cacheSettings.respectRBAC),
 		clustercache.SetBatchEventsProcessing(clusterCacheBatchEventsProcessing),
 		clustercache.SetEventProcessingInterval(clusterCacheEventsProcessingInterval),
 	}
+	clusterCacheOpts = append(clusterCacheOpts, clusterCacheSyncSettings(cluster)...)
 
 	clusterCache = clustercache.NewClusterCache(clusterCacheConfig, clusterCacheOpts...)
 
 	_ = clusterCache.OnResourceUpdated(func(newRes *clustercache.Resource, oldRes *clustercache.Resource, namespaceResources map[kube.ResourceKey]*clustercache.Resource) {
+		if c.nodeInfoBudget.enabled() && oldRes != nil && (newRes == nil || newRes.Info != oldRes.Info) {
+			c.nodeInfoBudget.forget(resInfo(oldRes))
+		}
 		toNotify := make(map[string]bool)
 		var ref corev1.ObjectReference
 		if newRes != nil {
@@ -632,6 +822,10 @@ func (c *liveStateCache) getCluster(cluster *appv1.Cluster) (clustercache.Cluste
 	})
 
 	c.clusters[cluster.Server] = clusterCache
+	if c.resourceGroups == nil {
+		c.resourceGroups = make(map[string][]string)
+	}
+	c.resourceGroups[cluster.Server] = cluster.ResourceGroups
 
 	return clusterCache, nil
 }
@@ -653,10 +847,14 @@ func (c *liveStateCache) invalidate(cacheSettings cacheSettings) {
 	c.lock.Lock()
 	c.cacheSettings = cacheSettings
 	clusters := c.clusters
+	resourceGroups := maps.Clone(c.resourceGroups)
 	c.lock.Unlock()
 
-	for _, clust := range clusters {
-		clust.Invalidate(clustercache.SetSettings(cacheSettings.clusterSettings))
+	for server, clust := range clusters {
+		clust.Invalidate(
+			clustercache.SetSettings(clusterCacheSettings(cacheSettings.clusterSettings, resourceGroups[server])),
+			clustercache.SetRespectRBAC(cacheSettings.respectRBAC),
+		)
 	}
 	log.Info("live state cache invalidated")
 }
@@ -674,6 +872,10 @@ func (c *liveStateCache) IterateHierarchy(server *appv1.Cluster, key kube.Resour
 	if err != nil {
 		return err
 	}
+	if c.nodeInfoBudget.enabled() {
+		c.nodeInfoBudget.lock.RLock()
+		defer c.nodeInfoBudget.lock.RUnlock()
+	}
 	clusterInfo.IterateHierarchy(key, func(resource *clustercache.Resource, namespaceResources map[kube.ResourceKey]*clustercache.Resource) bool {
 		return action(asResourceNode(resource), getApp(resource, namespaceResources))
 	})
@@ -685,9 +887,33 @@ func (c *liveStateCache) IterateHierarchyV2(server *appv1.Cluster, keys []kube.R
 	if err != nil {
 		return err
 	}
+	if !c.nodeInfoBudget.enabled() {
+		clusterInfo.IterateHierarchyV2(keys, func(resource *clustercache.Resource, namespaceResources map[kube.ResourceKey]*clustercache.Resource) bool {
+			return action(asResourceNode(resource), getApp(resource, namespaceResources))
+		})
+		return nil
+	}
+
+	c.restoreEvictedNodeInfo(server, clusterInfo, keys)
+	// the node details of the children of the managed resources are tracked in the memory budget
+	managedApps := map[string]bool{}
+	children := map[string][]*ResourceInfo{}
+	c.nodeInfoBudget.lock.RLock()
 	clusterInfo.IterateHierarchyV2(keys, func(resource *clustercache.Resource, namespaceResources map[kube.ResourceKey]*clustercache.Resource) bool {
-		return action(asResourceNode(resource), getApp(resource, namespaceResources))
+		appName := getApp(resource, namespaceResources)
+		if info, ok := resource.Info.(*ResourceInfo); ok {
+			if info.AppName != "" {
+				managedApps[info.AppName] = true
+			} else if appName != "" {
+				children[appName] = append(children[appName], info)
+			}
+		}
+		return action(asResourceNode(resource), appName)
 	})
+	c.nodeInfoBudget.lock.RUnlock()
+	for appName := range managedApps {
+		c.nodeInfoBudget.track(appName, children[appName])
+	}
 	return nil
 }
 
@@ -711,6 +937,10 @@ func (c *liveStateCache) GetNamespaceTopLevelResources(server *appv1.Cluster, na
 		return nil, err
 	}
 	resources := clusterInfo.FindResources(namespace, clustercache.TopLevelResource)
+	if c.nodeInfoBudget.enabled() {
+		c.nodeInfoBudget.lock.RLock()
+		defer c.nodeInfoBudget.lock.RUnlock()
+	}
 	res := make(map[kube.ResourceKey]appv1.ResourceNode)
 	for k, r := range resources {
 		res[k] = asResourceNode(r)
@@ -754,37 +984,25 @@ func (c *liveStateCache) isClusterHasApps(apps []any, cluster *appv1.Cluster) bo
 	return false
 }
 
-func (c *liveStateCache) watchSettings(ctx context.Context) {
-	updateCh := make(chan *settings.ArgoCDSettings, 1)
-	c.settingsMgr.Subscribe(updateCh)
-
-	done := false
-	for !done {
-		select {
-		case <-updateCh:
-			nextCacheSettings, err := c.loadCacheSettings()
-			if err != nil {
-				log.Warnf("Failed to read updated settings: %v", err)
-				continue
-			}
+// reloadSettings applies the updated settings, and invalidates the cluster caches if the settings they use changed, so
+// that the resources are watched and populated with the new settings
+func (c *liveStateCache) reloadSettings() error {
+	nextCacheSettings, err := c.loadCacheSettings()
+	if err != nil {
+		return fmt.Errorf("error loading cache settings: %w", err)
+	}
 
-			c.lock.Lock()
-			needInvalidate := false
-			if !reflect.DeepEqual(c.cacheSettings, *nextCacheSettings) {
-				c.cacheSettings = *nextCacheSettings
-				needInvalidate = true
-			}
-			c.lock.Unlock()
-			if needInvalidate {
-				c.invalidate(*nextCacheSettings)
-			}
-		case <-ctx.Done():
-			done = true
-		}
+	c.lock.Lock()
+	needInvalidate := false
+	if !reflect.DeepEqual(c.cacheSettings, *nextCacheSettings) {
+		c.cacheSettings = *nextCacheSettings
+		needInvalidate = true
+	}
+	c.lock.Unlock()
+	if needInvalidate {
+		c.invalidate(*nextCacheSettings)
 	}
-	log.Info("shutting down settings watch")
-	c.settingsMgr.Unsubscribe(updateCh)
-	close(updateCh)
+	return nil
 }
 
 func (c *liveStateCache) Init() error {
@@ -798,7 +1016,27 @@ func (c *liveStateCache) Init() error {
 
 // Run watches for resource changes annotated with application label on all registered clusters and schedule corresponding app refresh.
 func (c *liveStateCache) Run(ctx context.Context) error {
-	go c.watchSettings(ctx)
+	unregister := c.settingsMgr.RegisterConsumer("live-state-cache", c.reloadSettings)
+	defer unregister()
+	if c.projInformer != nil {
+		// the resources tracked with the tracking methods set in the projects are populated with their application
+		// names, and the resources included by the projects are watched, so the caches are invalidated when the set
+		// of tracking methods or the resource inclusions of the projects change
+		reload := func() {
+			if err := c.reloadSettings(); err != nil {
+				log.Errorf("Failed to reload the cache settings: %v", err)
+			}
+		}
+		registration, err := c.projInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc:    func(_ any) { reload() },
+			UpdateFunc: func(_, _ any) { reload() },
+			DeleteFunc: func(_ any) { reload() },
+		})
+		if err != nil {
+			return fmt.Errorf("error adding project event handler: %w", err)
+		}
+		defer func() { _ = c.projInformer.RemoveEventHandler(registration) }()
+	}
 
 	kube.RetryUntilSucceed(ctx, clustercache.ClusterRetryTimeout, "watch clusters", logutils.NewLogrusLogger(logutils.NewWithCurrentConfig()), func() error {
 		return c.db.WatchClusters(ctx, c.handleAddEvent, c.handleModEvent, c.handleDeleteEvent)
@@ -841,12 +1079,15 @@ func (c *liveStateCache) handleModEvent(oldCluster *appv1.Cluster, newCluster *a
 	c.clusterSharding.Update(oldCluster, newCluster)
 	c.lock.Lock()
 	cluster, ok := c.clusters[newCluster.Server]
+	cacheSettings := c.cacheSettings
 	c.lock.Unlock()
 	if ok {
 		if !c.canHandleCluster(newCluster) {
 			cluster.Invalidate()
+			c.nodeInfoBudget.forgetClient(newCluster.Server)
 			c.lock.Lock()
 			delete(c.clusters, newCluster.Server)
+			delete(c.resourceGroups, newCluster.Server)
 			c.lock.Unlock()
 			return
 		}
@@ -856,6 +1097,7 @@ func (c *liveStateCache) handleModEvent(oldCluster *appv1.Cluster, newCluster *a
 			newClusterRESTConfig, err := newCluster.RESTConfig()
 			if err == nil {
 				updateSettings = append(updateSettings, clustercache.SetConfig(newClusterRESTConfig))
+				c.nodeInfoBudget.forgetClient(newCluster.Server)
 			} else {
 				log.Errorf("error getting cluster REST config: %v", err)
 			}
@@ -866,6 +1108,20 @@ func (c *liveStateCache) handleModEvent(oldCluster *appv1.Cluster, newCluster *a
 		if !reflect.DeepEqual(oldCluster.ClusterResources, newCluster.ClusterResources) {
 			updateSettings = append(updateSettings, clustercache.SetClusterResources(newCluster.ClusterResources))
 		}
+		if oldCluster.CacheResyncPeriod != newCluster.CacheResyncPeriod ||
+			oldCluster.CacheSyncRetryTimeout != newCluster.CacheSyncRetryTimeout ||
+			oldCluster.CacheListPageSize != newCluster.CacheListPageSize {
+			updateSettings = append(updateSettings, clusterCacheSyncSettings(newCluster)...)
+		}
+		if !reflect.DeepEqual(oldCluster.ResourceGroups, newCluster.ResourceGroups) {
+			updateSettings = append(updateSettings, clustercache.SetSettings(clusterCacheSettings(cacheSettings.clusterSettings, newCluster.ResourceGroups)))
+			c.lock.Lock()
+			if c.resourceGroups == nil {
+				c.resourceGroups = make(map[string][]string)
+			}
+			c.resourceGroups[newCluster.Server] = newCluster.ResourceGroups
+			c.lock.Unlock()
+		}
 		forceInvalidate := false
 		if newCluster.RefreshRequestedAt != nil &&
 			cluster.GetClusterInfo().LastCacheSyncTime != nil &&
@@ -890,8 +1146,10 @@ func (c *liveStateCache) handleDeleteEvent(clusterServer string) {
 	c.lock.RUnlock()
 	if ok {
 		cluster.Invalidate()
+		c.nodeInfoBudget.forgetClient(clusterServer)
 		c.lock.Lock()
 		delete(c.clusters, clusterServer)
+		delete(c.resourceGroups, clusterServer)
 		c.lock.Unlock()
 	}
 }
@@ -24,12 +24,14 @@ import (
 	"github.com/argoproj/gitops-engine/pkg/utils/kube"
 	"github.com/stretchr/testify/mock"
 	"k8s.io/client-go/kubernetes/fake"
+	k8scache "k8s.io/client-go/tools/cache"
 
 	"github.com/argoproj/argo-cd/v3/common"
 	"github.com/argoproj/argo-cd/v3/controller/metrics"
 	"github.com/argoproj/argo-cd/v3/controller/sharding"
 	"github.com/argoproj/argo-cd/v3/pkg/apis/application"
 	appv1 "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	"github.com/argoproj/argo-cd/v3/util/argo"
 	dbmocks "github.com/argoproj/argo-cd/v3/util/db/mocks"
 	argosettings "github.com/argoproj/argo-cd/v3/util/settings"
 )
@@ -148,6 +150,72 @@ func TestHandleModEvent_NoChanges(_ *testing.T) {
 	})
 }
 
+func TestHandleModEvent_ResourceGroupsChanged(t *testing.T) {
+	clusterCache := &mocks.ClusterCache{}
+	clusterCache.On("Invalidate", mock.Anything).Return(nil).Once()
+	clusterCache.On("EnsureSynced").Return(nil).Once()
+	db := &dbmocks.ArgoDB{}
+	db.On("GetApplicationControllerReplicas").Return(1)
+	clustersCache := liveStateCache{
+		clusters: map[string]cache.ClusterCache{
+			"https://mycluster": clusterCache,
+		},
+		clusterSharding: sharding.NewClusterSharding(db, 0, 1, common.DefaultShardingAlgorithm),
+	}
+
+	clustersCache.handleModEvent(&appv1.Cluster{
+		Server: "https://mycluster",
+	}, &appv1.Cluster{
+		Server:         "https://mycluster",
+		ResourceGroups: []string{"apps"},
+	})
+
+	assert.Equal(t, []string{"apps"}, clustersCache.resourceGroups["https://mycluster"])
+}
+
+func TestHandleModEvent_CacheSyncSettingsChanged(_ *testing.T) {
+	clusterCache := &mocks.ClusterCache{}
+	// the resync period, sync retry timeout and list page size are updated together
+	clusterCache.On("Invalidate", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+	clusterCache.On("EnsureSynced").Return(nil).Once()
+	db := &dbmocks.ArgoDB{}
+	db.On("GetApplicationControllerReplicas").Return(1)
+	clustersCache := liveStateCache{
+		clusters: map[string]cache.ClusterCache{
+			"https://mycluster": clusterCache,
+		},
+		clusterSharding: sharding.NewClusterSharding(db, 0, 1, common.DefaultShardingAlgorithm),
+	}
+
+	clustersCache.handleModEvent(&appv1.Cluster{
+		Server: "https://mycluster",
+	}, &appv1.Cluster{
+		Server:            "https://mycluster",
+		CacheResyncPeriod: "1h",
+		CacheListPageSize: 100,
+	})
+}
+
+func TestClusterCacheSettings_ResourceGroups(t *testing.T) {
+	resourcesFilter := &argosettings.ResourcesFilter{
+		ResourceExclusions: []argosettings.FilteredResource{{APIGroups: []string{"batch"}, Kinds: []string{"CronJob"}}},
+	}
+	settings := cache.Settings{ResourcesFilter: resourcesFilter}
+
+	t.Run("AllGroups", func(t *testing.T) {
+		assert.Same(t, resourcesFilter, clusterCacheSettings(settings, nil).ResourcesFilter)
+	})
+
+	t.Run("SelectedGroups", func(t *testing.T) {
+		filter := clusterCacheSettings(settings, []string{"apps", "batch"}).ResourcesFilter
+		assert.False(t, filter.IsExcludedResource("", "ConfigMap", "https://mycluster"))
+		assert.False(t, filter.IsExcludedResource("apps", "Deployment", "https://mycluster"))
+		assert.False(t, filter.IsExcludedResource("batch", "Job", "https://mycluster"))
+		assert.True(t, filter.IsExcludedResource("batch", "CronJob", "https://mycluster"))
+		assert.True(t, filter.IsExcludedResource("networking.k8s.io", "Ingress", "https://mycluster"))
+	})
+}
+
 func TestHandleAddEvent_ClusterExcluded(t *testing.T) {
 	db := &dbmocks.ArgoDB{}
 	db.On("GetApplicationControllerReplicas").Return(1)
@@ -803,6 +871,96 @@ func TestLoadCacheSettings(t *testing.T) {
 	assert.True(t, res.ignoreResourceUpdatesEnabled)
 }
 
+func TestLoadCacheSettings_ProjectTrackingMethods(t *testing.T) {
+	_, settingsManager := fixtures(map[string]string{
+		"application.resourceTrackingMethod": string(appv1.TrackingMethodAnnotation),
+	})
+	projInformer := k8scache.NewSharedIndexInformer(&k8scache.ListWatch{}, &appv1.AppProject{}, 0, k8scache.Indexers{})
+	for name, trackingMethod := range map[string]appv1.TrackingMethod{
+		"default":  "",
+		"label":    appv1.TrackingMethodLabel,
+		"label-2":  appv1.TrackingMethodLabel,
+		"settings": appv1.TrackingMethodAnnotation,
+	} {
+		require.NoError(t, projInformer.GetStore().Add(&appv1.AppProject{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       appv1.AppProjectSpec{TrackingMethod: trackingMethod},
+		}))
+	}
+	appInformer := k8scache.NewSharedIndexInformer(&k8scache.ListWatch{}, &appv1.Application{}, 0, k8scache.Indexers{})
+	for name, project := range map[string]string{
+		"annotation-app": "default",
+		"label-app":      "label",
+	} {
+		require.NoError(t, appInformer.GetStore().Add(&appv1.Application{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       appv1.ApplicationSpec{Project: project},
+		}))
+	}
+	ch := liveStateCache{
+		settingsMgr:      settingsManager,
+		appInformer:      appInformer,
+		projInformer:     projInformer,
+		resourceTracking: argo.NewResourceTracking(),
+	}
+	res, err := ch.loadCacheSettings()
+	require.NoError(t, err)
+	assert.Equal(t, appv1.TrackingMethodAnnotation, res.trackingMethod)
+	assert.Equal(t, []appv1.TrackingMethod{appv1.TrackingMethodLabel}, res.projectTrackingMethods)
+
+	annotated := &unstructured.Unstructured{}
+	annotated.SetAPIVersion("v1")
+	annotated.SetKind("ConfigMap")
+	annotated.SetName("annotated")
+	require.NoError(t, ch.resourceTracking.SetAppInstance(annotated, res.appInstanceLabelKey, "annotation-app", "", appv1.TrackingMethodAnnotation, ""))
+	assert.Equal(t, "annotation-app", ch.getAppName(annotated, *res))
+
+	labeled := &unstructured.Unstructured{}
+	labeled.SetAPIVersion("v1")
+	labeled.SetKind("ConfigMap")
+	labeled.SetName("labeled")
+	require.NoError(t, ch.resourceTracking.SetAppInstance(labeled, res.appInstanceLabelKey, "label-app", "", appv1.TrackingMethodLabel, ""))
+	assert.Equal(t, "label-app", ch.getAppName(labeled, *res))
+
+	// the resources tracked with a method other than the one of the project of the application are not attributed to it
+	require.NoError(t, ch.resourceTracking.SetAppInstance(annotated, res.appInstanceLabelKey, "label-app", "", appv1.TrackingMethodAnnotation, ""))
+	assert.Empty(t, ch.getAppName(annotated, *res))
+	require.NoError(t, ch.resourceTracking.SetAppInstance(labeled, res.appInstanceLabelKey, "annotation-app", "", appv1.TrackingMethodLabel, ""))
+	assert.Empty(t, ch.getAppName(labeled, *res))
+	require.NoError(t, ch.resourceTracking.SetAppInstance(labeled, res.appInstanceLabelKey, "unknown-app", "", appv1.TrackingMethodLabel, ""))
+	assert.Empty(t, ch.getAppName(labeled, *res))
+
+	require.NoError(t, ch.resourceTracking.SetAppInstance(labeled, res.appInstanceLabelKey, "label-app", "", appv1.TrackingMethodLabel, ""))
+	res.projectTrackingMethods = nil
+	assert.Empty(t, ch.getAppName(labeled, *res))
+}
+
+func TestLoadCacheSettings_ProjectResourceInclusions(t *testing.T) {
+	_, settingsManager := fixtures(map[string]string{
+		"resource.exclusions": "- apiGroups: [batch]\n  kinds: ['*']",
+	})
+	projInformer := k8scache.NewSharedIndexInformer(&k8scache.ListWatch{}, &appv1.AppProject{}, 0, k8scache.Indexers{})
+	ch := liveStateCache{settingsMgr: settingsManager, projInformer: projInformer}
+
+	res, err := ch.loadCacheSettings()
+	require.NoError(t, err)
+	assert.True(t, res.clusterSettings.ResourcesFilter.IsExcludedResource("batch", "CronJob", "https://cluster-1"))
+
+	require.NoError(t, projInformer.GetStore().Add(&appv1.AppProject{
+		ObjectMeta: metav1.ObjectMeta{Name: "team-a", Namespace: "default"},
+		Spec: appv1.AppProjectSpec{ResourceInclusions: []appv1.ResourceFilterRule{
+			{APIGroups: []string{"batch"}, Kinds: []string{"CronJob"}, Clusters: []string{"https://cluster-1"}},
+		}},
+	}))
+	res, err = ch.loadCacheSettings()
+	require.NoError(t, err)
+	filter := res.clusterSettings.ResourcesFilter
+	assert.False(t, filter.IsExcludedResource("batch", "CronJob", "https://cluster-1"))
+	assert.True(t, filter.IsExcludedResource("batch", "CronJob", "https://cluster-2"))
+	assert.True(t, filter.IsExcludedResource("batch", "Job", "https://cluster-1"))
+	assert.False(t, filter.IsExcludedResource("apps", "Deployment", "https://cluster-1"))
+}
+
 func Test_ownerRefGV(t *testing.T) {
 	tests := []struct {
 		name     string
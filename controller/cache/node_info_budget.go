@@ -0,0 +1,278 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+
+	clustercache "github.com/argoproj/gitops-engine/pkg/cache"
+	"github.com/argoproj/gitops-engine/pkg/utils/kube"
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+
+	appv1 "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+)
+
+// nodeInfoBudget bounds the memory used by the node details (info items, images and networking information) of the
+// resources which aren't managed by an application but are children of the managed resources, such as replica sets and
+// pods. The details are tracked by application, in the order in which the resource trees of the applications were
+// built. When their estimated size exceeds the budget, the details of the least recently compared applications are
+// evicted. They are fetched again from the cluster in the background the next time the resource tree of the
+// application is built, which is then refreshed.
+type nodeInfoBudget struct {
+	limit int64
+
+	// lock guards the node details of the tracked resources, in addition to the fields below
+	lock sync.RWMutex
+	size int64
+	// evicted is the number of resources whose node details are evicted
+	evicted int
+	// apps holds the tracked applications, the least recently compared last
+	apps  *list.List
+	byApp map[string]*list.Element
+	// restoring holds the evicted resources whose node details are being fetched again
+	restoring map[*ResourceInfo]bool
+
+	// clientsLock guards the dynamic clients used to fetch again evicted node details, by cluster server
+	clientsLock sync.Mutex
+	clients     map[string]dynamic.Interface
+}
+
+type nodeInfoBudgetApp struct {
+	name  string
+	infos []*ResourceInfo
+	size  int64
+}
+
+func newNodeInfoBudget(limit int64) *nodeInfoBudget {
+	return &nodeInfoBudget{
+		limit:     limit,
+		apps:      list.New(),
+		byApp:     map[string]*list.Element{},
+		restoring: map[*ResourceInfo]bool{},
+		clients:   map[string]dynamic.Interface{},
+	}
+}
+
+func (b *nodeInfoBudget) enabled() bool {
+	return b != nil && b.limit > 0
+}
+
+func (b *nodeInfoBudget) hasEvictions() bool {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	return b.evicted > 0
+}
+
+// track records the node details of the children of an application whose resource tree was just built, and evicts the
+// details of the least recently compared applications while the budget is exceeded. The details of the application
+// being tracked are never evicted, even if they exceed the budget on their own.
+func (b *nodeInfoBudget) track(appName string, infos []*ResourceInfo) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if el, ok := b.byApp[appName]; ok {
+		b.size -= el.Value.(*nodeInfoBudgetApp).size
+		b.apps.Remove(el)
+	}
+	app := &nodeInfoBudgetApp{name: appName, infos: infos}
+	for _, info := range infos {
+		app.size += estimateNodeInfoSize(info)
+	}
+	b.byApp[appName] = b.apps.PushFront(app)
+	b.size += app.size
+
+	for b.size > b.limit && b.apps.Len() > 1 {
+		el := b.apps.Back()
+		victim := el.Value.(*nodeInfoBudgetApp)
+		for _, info := range victim.infos {
+			if !info.evicted && !info.forgotten {
+				info.Info, info.Images, info.NetworkingInfo = nil, nil, nil
+				info.evicted = true
+				b.evicted++
+			}
+		}
+		log.Debugf("Evicted node details of %d resources of application %s from the live state cache", len(victim.infos), victim.name)
+		b.size -= victim.size
+		b.apps.Remove(el)
+		delete(b.byApp, victim.name)
+	}
+}
+
+// restore sets again the evicted node details of a resource
+func (b *nodeInfoBudget) restore(info *ResourceInfo, restored *ResourceInfo) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if !info.evicted {
+		return
+	}
+	info.Info, info.Images, info.NetworkingInfo = restored.Info, restored.Images, restored.NetworkingInfo
+	info.evicted = false
+	b.evicted--
+}
+
+// forget stops counting the evicted node details of a resource which was updated or deleted in the cluster cache
+func (b *nodeInfoBudget) forget(info *ResourceInfo) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	delete(b.restoring, info)
+	info.forgotten = true
+	if info.evicted {
+		info.evicted = false
+		b.evicted--
+	}
+}
+
+// startRestoring returns the given evicted resources which aren't already being fetched again, and marks them as being
+// fetched
+func (b *nodeInfoBudget) startRestoring(evicted map[kube.ResourceKey]evictedNodeInfo) map[kube.ResourceKey]evictedNodeInfo {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	for key, e := range evicted {
+		if b.restoring[e.info] || !e.info.evicted {
+			delete(evicted, key)
+			continue
+		}
+		b.restoring[e.info] = true
+	}
+	return evicted
+}
+
+func (b *nodeInfoBudget) doneRestoring(evicted map[kube.ResourceKey]evictedNodeInfo) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	for _, e := range evicted {
+		delete(b.restoring, e.info)
+	}
+}
+
+// getClient returns the dynamic client of a cluster, which is created once and reused
+func (b *nodeInfoBudget) getClient(server *appv1.Cluster) (dynamic.Interface, error) {
+	b.clientsLock.Lock()
+	defer b.clientsLock.Unlock()
+	if client, ok := b.clients[server.Server]; ok {
+		return client, nil
+	}
+	restConfig, err := server.RESTConfig()
+	if err != nil {
+		return nil, err
+	}
+	client, err := dynamic.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+	b.clients[server.Server] = client
+	return client, nil
+}
+
+// forgetClient drops the dynamic client of a cluster whose configuration changed or which was removed
+func (b *nodeInfoBudget) forgetClient(server string) {
+	if !b.enabled() {
+		return
+	}
+	b.clientsLock.Lock()
+	defer b.clientsLock.Unlock()
+	delete(b.clients, server)
+}
+
+// estimateNodeInfoSize returns the approximate number of bytes used by the node details of a resource
+func estimateNodeInfoSize(info *ResourceInfo) int64 {
+	// size of the slice and pointer headers
+	size := 56
+	for _, item := range info.Info {
+		size += 32 + len(item.Name) + len(item.Value)
+	}
+	for _, image := range info.Images {
+		size += 16 + len(image)
+	}
+	if networkingInfo := info.NetworkingInfo; networkingInfo != nil {
+		size += 128
+		for k, v := range networkingInfo.Labels {
+			size += 32 + len(k) + len(v)
+		}
+		for k, v := range networkingInfo.TargetLabels {
+			size += 32 + len(k) + len(v)
+		}
+		for _, ref := range networkingInfo.TargetRefs {
+			size += 96 + len(ref.Name) + len(ref.Namespace) + len(ref.Kind) + len(ref.Group)
+		}
+		for _, ingress := range networkingInfo.Ingress {
+			size += 32 + len(ingress.IP) + len(ingress.Hostname)
+		}
+		for _, url := range networkingInfo.ExternalURLs {
+			size += 16 + len(url)
+		}
+	}
+	return int64(size)
+}
+
+type evictedNodeInfo struct {
+	info    *ResourceInfo
+	appName string
+}
+
+// restoreEvictedNodeInfo fetches again from the cluster, in the background, the resources of a hierarchy whose node
+// details were evicted, and then refreshes the resource trees of their applications
+func (c *liveStateCache) restoreEvictedNodeInfo(server *appv1.Cluster, clusterInfo clustercache.ClusterCache, keys []kube.ResourceKey) {
+	if !c.nodeInfoBudget.hasEvictions() {
+		return
+	}
+	evicted := map[kube.ResourceKey]evictedNodeInfo{}
+	c.nodeInfoBudget.lock.RLock()
+	clusterInfo.IterateHierarchyV2(keys, func(resource *clustercache.Resource, namespaceResources map[kube.ResourceKey]*clustercache.Resource) bool {
+		if info, ok := resource.Info.(*ResourceInfo); ok && info.evicted {
+			evicted[resource.ResourceKey()] = evictedNodeInfo{info: info, appName: getApp(resource, namespaceResources)}
+		}
+		return true
+	})
+	c.nodeInfoBudget.lock.RUnlock()
+	evicted = c.nodeInfoBudget.startRestoring(evicted)
+	if len(evicted) == 0 {
+		return
+	}
+	apis := map[schema.GroupKind]kube.APIResourceInfo{}
+	for _, api := range clusterInfo.GetAPIResources() {
+		apis[api.GroupKind] = api
+	}
+	go c.fetchEvictedNodeInfo(server, apis, evicted)
+}
+
+func (c *liveStateCache) fetchEvictedNodeInfo(server *appv1.Cluster, apis map[schema.GroupKind]kube.APIResourceInfo, evicted map[kube.ResourceKey]evictedNodeInfo) {
+	defer c.nodeInfoBudget.doneRestoring(evicted)
+
+	client, err := c.nodeInfoBudget.getClient(server)
+	if err != nil {
+		log.Warnf("Failed to restore evicted node details of cluster %s: %v", server.Server, err)
+		return
+	}
+	resourceCustomLabels, err := c.settingsMgr.GetResourceCustomLabels()
+	if err != nil {
+		log.Warnf("Failed to restore evicted node details of cluster %s: %v", server.Server, err)
+		return
+	}
+	restoredApps := map[string]corev1.ObjectReference{}
+	for key, e := range evicted {
+		api, ok := apis[key.GroupKind()]
+		if !ok {
+			continue
+		}
+		un, err := client.Resource(api.GroupVersionResource).Namespace(key.Namespace).Get(context.Background(), key.Name, metav1.GetOptions{})
+		if err != nil {
+			log.Debugf("Failed to restore evicted node details of %s: %v", key.String(), err)
+			continue
+		}
+		restored := &ResourceInfo{}
+		populateNodeInfo(un, restored, resourceCustomLabels)
+		c.nodeInfoBudget.restore(e.info, restored)
+		if e.appName != "" {
+			restoredApps[e.appName] = corev1.ObjectReference{APIVersion: un.GetAPIVersion(), Kind: un.GetKind(), Namespace: un.GetNamespace(), Name: un.GetName()}
+		}
+	}
+	// the resource trees built while the node details were evicted miss them
+	for appName, ref := range restoredApps {
+		c.onObjectUpdated(map[string]bool{appName: false}, ref)
+	}
+}
@@ -0,0 +1,101 @@
+package cache
+
+import (
+	"testing"
+
+	"github.com/argoproj/gitops-engine/pkg/utils/kube"
+	"github.com/stretchr/testify/assert"
+
+	appv1 "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+)
+
+func newTestNodeInfo() *ResourceInfo {
+	return &ResourceInfo{
+		Info:   []appv1.InfoItem{{Name: "Status Reason", Value: "Running"}},
+		Images: []string{"nginx:1.27"},
+	}
+}
+
+func TestNodeInfoBudget_Disabled(t *testing.T) {
+	assert.False(t, newNodeInfoBudget(0).enabled())
+	var budget *nodeInfoBudget
+	assert.False(t, budget.enabled())
+}
+
+func TestNodeInfoBudget_EvictsLeastRecentlyCompared(t *testing.T) {
+	size := estimateNodeInfoSize(newTestNodeInfo())
+	budget := newNodeInfoBudget(2 * size)
+
+	app1 := []*ResourceInfo{newTestNodeInfo()}
+	app2 := []*ResourceInfo{newTestNodeInfo()}
+	app3 := []*ResourceInfo{newTestNodeInfo()}
+	budget.track("app1", app1)
+	budget.track("app2", app2)
+	// app1 is compared again, so app2 becomes the least recently compared application
+	budget.track("app1", app1)
+	budget.track("app3", app3)
+
+	assert.False(t, app1[0].evicted)
+	assert.NotEmpty(t, app1[0].Info)
+	assert.True(t, app2[0].evicted)
+	assert.Nil(t, app2[0].Info)
+	assert.Nil(t, app2[0].Images)
+	assert.False(t, app3[0].evicted)
+	assert.True(t, budget.hasEvictions())
+	assert.Equal(t, 2*size, budget.size)
+
+	budget.restore(app2[0], newTestNodeInfo())
+	assert.False(t, app2[0].evicted)
+	assert.Equal(t, []string{"nginx:1.27"}, app2[0].Images)
+	assert.False(t, budget.hasEvictions())
+}
+
+func TestNodeInfoBudget_KeepsLatestApp(t *testing.T) {
+	budget := newNodeInfoBudget(1)
+
+	app1 := []*ResourceInfo{newTestNodeInfo(), newTestNodeInfo()}
+	budget.track("app1", app1)
+
+	assert.False(t, app1[0].evicted)
+	assert.False(t, app1[1].evicted)
+
+	budget.track("app2", nil)
+
+	assert.True(t, app1[0].evicted)
+	assert.True(t, app1[1].evicted)
+}
+
+func TestNodeInfoBudget_Forget(t *testing.T) {
+	budget := newNodeInfoBudget(1)
+
+	app1 := []*ResourceInfo{newTestNodeInfo(), newTestNodeInfo()}
+	budget.track("app1", app1)
+	budget.track("app2", nil)
+	assert.True(t, budget.hasEvictions())
+
+	// the evicted resources are replaced in the cluster cache
+	budget.forget(app1[0])
+	budget.forget(app1[1])
+	assert.False(t, budget.hasEvictions())
+
+	// forgotten resources are no longer evicted
+	budget.track("app1", app1)
+	budget.track("app3", nil)
+	assert.False(t, budget.hasEvictions())
+}
+
+func TestNodeInfoBudget_StartRestoring(t *testing.T) {
+	budget := newNodeInfoBudget(1)
+	app1 := []*ResourceInfo{newTestNodeInfo()}
+	budget.track("app1", app1)
+	budget.track("app2", nil)
+
+	key := kube.ResourceKey{Kind: "Pod", Namespace: "default", Name: "pod"}
+	evicted := budget.startRestoring(map[kube.ResourceKey]evictedNodeInfo{key: {info: app1[0], appName: "app1"}})
+	assert.Len(t, evicted, 1)
+	// the resource is already being fetched again
+	assert.Empty(t, budget.startRestoring(map[kube.ResourceKey]evictedNodeInfo{key: {info: app1[0], appName: "app1"}}))
+
+	budget.doneRestoring(evicted)
+	assert.Len(t, budget.startRestoring(map[kube.ResourceKey]evictedNodeInfo{key: {info: app1[0], appName: "app1"}}), 1)
+}
@@ -0,0 +1,45 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	cacheutil "github.com/argoproj/argo-cd/v3/util/cache"
+	"github.com/argoproj/argo-cd/v3/util/env"
+)
+
+const (
+	// EnvAppStateCacheGCPeriod is an environment variable which sets the period of the garbage collection of the cache
+	// entries of the deleted applications, 0 disables it
+	EnvAppStateCacheGCPeriod = "ARGOCD_APPLICATION_CONTROLLER_APP_STATE_CACHE_GC_PERIOD"
+)
+
+var appStateCacheGCPeriod = env.ParseDurationFromEnv(EnvAppStateCacheGCPeriod, 1*time.Hour, 0, 24*time.Hour)
+
+// collectAppStateCacheGarbage deletes the managed resources, resource trees and manifests cached for the applications
+// which don't exist anymore, which otherwise linger until they expire. Only the first shard collects the garbage, so
+// that the replicas of the controller don't scan the cache concurrently.
+func (ctrl *ApplicationController) collectAppStateCacheGarbage(ctx context.Context) {
+	if ctrl.clusterSharding.GetShard() != 0 {
+		return
+	}
+	start := time.Now()
+	stale, err := ctrl.cache.CollectGarbage(ctx, func(appName string) bool {
+		_, exists, err := ctrl.appInformer.GetIndexer().GetByKey(ctrl.toAppKey(appName))
+		// the entries are kept if the existence of their application is unknown
+		return exists || err != nil
+	}, false)
+	var freed int64
+	for _, entry := range stale {
+		freed += entry.Size
+		ctrl.metricsServer.AddCacheGarbageCollected(cacheutil.CacheEntryType(entry.Key), entry.Size)
+	}
+	logCtx := log.WithFields(log.Fields{"entries": len(stale), "freed_bytes": freed, "time_ms": time.Since(start).Milliseconds()})
+	if err != nil {
+		logCtx.Warnf("Failed to collect the garbage of the app state cache: %v", err)
+		return
+	}
+	logCtx.Info("Collected the garbage of the app state cache")
+}
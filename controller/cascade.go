@@ -0,0 +1,217 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/argoproj/gitops-engine/pkg/health"
+	synccommon "github.com/argoproj/gitops-engine/pkg/sync/common"
+	log "github.com/sirupsen/logrus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	appv1 "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	applog "github.com/argoproj/argo-cd/v3/util/app/log"
+	"github.com/argoproj/argo-cd/v3/util/argo"
+)
+
+// cascadeRecheckPeriod is the period at which a cascade operation checks again the progress of the child applications
+const cascadeRecheckPeriod = 10 * time.Second
+
+// cascadeAppOperation starts or resumes the cascade operation of an application. The child applications are synced or
+// refreshed wave by wave: the operation of a wave starts once every child application of the previous waves completed
+// its operation, and the cascade operation fails as soon as the operation of a child application fails.
+func (ctrl *ApplicationController) cascadeAppOperation(app *appv1.Application, state *appv1.OperationState) {
+	if state.Phase == synccommon.OperationTerminating {
+		state.Phase = synccommon.OperationFailed
+		state.Message = "Operation terminated"
+		return
+	}
+	if state.CascadeResult == nil {
+		state.CascadeResult = ctrl.newCascadeOperationResult(state.Operation.Cascade)
+	}
+	if len(state.CascadeResult.Children) == 0 {
+		state.Phase = synccommon.OperationFailed
+		state.Message = "The application has no child applications"
+		return
+	}
+	opType := strings.ToLower(string(state.Operation.Cascade.Type))
+	for {
+		wave, ok := state.CascadeResult.GetCurrentWave()
+		if !ok {
+			break
+		}
+		pending := 0
+		var failed []string
+		for i := range state.CascadeResult.Children {
+			child := &state.CascadeResult.Children[i]
+			if child.Wave != wave {
+				continue
+			}
+			if !child.Phase.Completed() {
+				ctrl.cascadeChildOperation(app, state, child)
+			}
+			switch {
+			case !child.Phase.Completed():
+				pending++
+			case !child.Phase.Successful():
+				failed = append(failed, child.Namespace+"/"+child.Name)
+			}
+		}
+		if pending > 0 {
+			state.Phase = synccommon.OperationRunning
+			state.Message = fmt.Sprintf("Waiting for the %s of %d child applications of wave %d", opType, pending, wave)
+			ctrl.appOperationQueue.AddAfter(ctrl.toAppKey(app.QualifiedName()), cascadeRecheckPeriod)
+			return
+		}
+		if len(failed) > 0 {
+			state.Phase = synccommon.OperationFailed
+			state.Message = fmt.Sprintf("Cascade %s failed in wave %d: %s", opType, wave, strings.Join(failed, ", "))
+			return
+		}
+	}
+	state.Phase = synccommon.OperationSucceeded
+	state.Message = fmt.Sprintf("Successfully completed the cascade %s of %d child applications", opType, len(state.CascadeResult.Children))
+}
+
+// newCascadeOperationResult returns the result of a cascade operation which didn't start, listing the child
+// applications recorded in the operation ordered by their wave. Child applications added to the parent application
+// after the operation started aren't part of the operation, since the user who initiated it may not be allowed to
+// sync them.
+func (ctrl *ApplicationController) newCascadeOperationResult(op *appv1.CascadeOperation) *appv1.CascadeOperationResult {
+	result := &appv1.CascadeOperationResult{}
+	for _, c := range op.Children {
+		child := appv1.CascadeChildResult{Namespace: c.Namespace, Name: c.Name}
+		if childApp, err := ctrl.appLister.Applications(c.Namespace).Get(c.Name); err == nil {
+			child.Wave = getCascadeWave(childApp)
+		}
+		result.Children = append(result.Children, child)
+	}
+	sort.SliceStable(result.Children, func(i, j int) bool {
+		a, b := result.Children[i], result.Children[j]
+		if a.Wave != b.Wave {
+			return a.Wave < b.Wave
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+	return result
+}
+
+// getCascadeWave returns the cascade wave of an application, which is 0 unless set by its cascade wave annotation
+func getCascadeWave(app *appv1.Application) int64 {
+	value, ok := app.Annotations[appv1.AnnotationKeyCascadeWave]
+	if !ok {
+		return 0
+	}
+	wave, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		log.WithFields(applog.GetAppLogFields(app)).Warnf("Ignoring the invalid cascade wave %q of the application", value)
+		return 0
+	}
+	return wave
+}
+
+// cascadeChildOperation starts the operation of a child application, or updates the result of the child application
+// with the progress of its operation
+func (ctrl *ApplicationController) cascadeChildOperation(app *appv1.Application, state *appv1.OperationState, child *appv1.CascadeChildResult) {
+	childApp, err := ctrl.appLister.Applications(child.Namespace).Get(child.Name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			child.Message = "Waiting for the application to be created"
+		} else {
+			child.Message = fmt.Sprintf("Failed to get the application: %v", err)
+		}
+		return
+	}
+	op := state.Operation.Cascade
+	if child.StartedAt == nil {
+		startedAt := metav1.Now()
+		appIf := ctrl.applicationClientset.ArgoprojV1alpha1().Applications(child.Namespace)
+		if op.Type == appv1.CascadeOperationTypeRefresh {
+			_, err = argo.RefreshApp(appIf, child.Name, appv1.RefreshTypeNormal, false)
+		} else {
+			var proj *appv1.AppProject
+			proj, err = ctrl.getAppProj(childApp)
+			if err != nil {
+				child.Message = fmt.Sprintf("Failed to get the project of the application: %v", err)
+				return
+			}
+			_, err = argo.SetAppOperation(appIf, child.Name, newCascadeSyncOperation(app, childApp, proj, state))
+		}
+		if errors.Is(err, argo.ErrAnotherOperationInProgress) {
+			child.Message = "Waiting for the operation in progress to complete"
+			return
+		}
+		if err != nil {
+			child.Message = fmt.Sprintf("Failed to start the %s: %v", strings.ToLower(string(op.Type)), err)
+			return
+		}
+		child.StartedAt = &startedAt
+		child.Phase = synccommon.OperationRunning
+		child.Message = ""
+		return
+	}
+
+	if op.Type == appv1.CascadeOperationTypeRefresh {
+		if _, refreshing := childApp.IsRefreshRequested(); refreshing || childApp.Status.ReconciledAt == nil || childApp.Status.ReconciledAt.Before(child.StartedAt) {
+			return
+		}
+		child.Phase = synccommon.OperationSucceeded
+		child.Message = "Refreshed"
+		return
+	}
+
+	opState := childApp.Status.OperationState
+	if childApp.Operation != nil || opState == nil || opState.StartedAt.Before(child.StartedAt) || !opState.Phase.Completed() {
+		return
+	}
+	if !opState.Phase.Successful() {
+		child.Phase = synccommon.OperationFailed
+		child.Message = opState.Message
+		return
+	}
+	switch {
+	case childApp.Status.ReconciledAt == nil || opState.FinishedAt == nil || childApp.Status.ReconciledAt.Before(opState.FinishedAt):
+		child.Message = "Waiting for the application to be reconciled"
+	case childApp.Status.Sync.Status != appv1.SyncStatusCodeSynced:
+		child.Message = fmt.Sprintf("Waiting for the application to be synced (%s)", childApp.Status.Sync.Status)
+	case childApp.Status.Health.Status != health.HealthStatusHealthy:
+		child.Message = fmt.Sprintf("Waiting for the application to be healthy (%s)", childApp.Status.Health.Status)
+	default:
+		child.Phase = synccommon.OperationSucceeded
+		child.Message = "Synced and healthy"
+	}
+}
+
+// newCascadeSyncOperation returns the operation syncing a child application to its target revisions, using the sync
+// options and retry strategy of its sync policy, with the defaults of its project
+func newCascadeSyncOperation(app *appv1.Application, childApp *appv1.Application, proj *appv1.AppProject, state *appv1.OperationState) *appv1.Operation {
+	op := &appv1.Operation{
+		Sync: &appv1.SyncOperation{
+			Prune: state.Operation.Cascade.Prune,
+		},
+		InitiatedBy: state.Operation.InitiatedBy,
+		Info:        []*appv1.Info{{Name: "Reason", Value: "Cascaded from application " + app.QualifiedName()}},
+	}
+	if childApp.Spec.HasMultipleSources() {
+		for _, source := range childApp.Spec.Sources {
+			op.Sync.Revisions = append(op.Sync.Revisions, source.TargetRevision)
+		}
+	} else {
+		op.Sync.Revision = childApp.Spec.GetSource().TargetRevision
+	}
+	if syncPolicy := proj.GetApplicationSyncPolicy(childApp.Spec.SyncPolicy); syncPolicy != nil {
+		op.Sync.SyncOptions = syncPolicy.SyncOptions
+		if syncPolicy.Retry != nil {
+			op.Retry = *syncPolicy.Retry
+		}
+	}
+	return op
+}
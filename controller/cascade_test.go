@@ -0,0 +1,173 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/argoproj/gitops-engine/pkg/health"
+	synccommon "github.com/argoproj/gitops-engine/pkg/sync/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	"github.com/argoproj/argo-cd/v3/test"
+)
+
+func newFakeCascadeApps(opType v1alpha1.CascadeOperationType) (*v1alpha1.Application, *v1alpha1.Application, *v1alpha1.Application) {
+	parent := newFakeApp()
+	parent.Name = "parent"
+	parent.Operation = &v1alpha1.Operation{
+		Cascade: &v1alpha1.CascadeOperation{Type: opType, Prune: true, Children: []v1alpha1.CascadeChild{
+			{Namespace: test.FakeArgoCDNamespace, Name: "child-a"},
+			{Namespace: test.FakeArgoCDNamespace, Name: "child-b"},
+		}},
+		InitiatedBy: v1alpha1.OperationInitiator{Username: "alice"},
+	}
+	for _, name := range []string{"child-a", "child-b"} {
+		parent.Status.Resources = append(parent.Status.Resources, v1alpha1.ResourceStatus{
+			Group: "argoproj.io", Version: "v1alpha1", Kind: "Application", Namespace: test.FakeArgoCDNamespace, Name: name,
+		})
+	}
+	parent.Status.OperationState = nil
+	childA := newFakeApp()
+	childA.Name = "child-a"
+	childA.Annotations = map[string]string{v1alpha1.AnnotationKeyCascadeWave: "1"}
+	childA.Status.OperationState = nil
+	childB := newFakeApp()
+	childB.Name = "child-b"
+	childB.Status.OperationState = nil
+	return parent, childA, childB
+}
+
+func TestCascadeAppOperation_Sync(t *testing.T) {
+	parent, childA, childB := newFakeCascadeApps(v1alpha1.CascadeOperationTypeSync)
+	ctrl := newFakeController(&fakeData{apps: []runtime.Object{parent, childA, childB, &defaultProj}}, nil)
+	getChild := func(name string) *v1alpha1.Application {
+		t.Helper()
+		app, err := ctrl.applicationClientset.ArgoprojV1alpha1().Applications(test.FakeArgoCDNamespace).Get(t.Context(), name, metav1.GetOptions{})
+		require.NoError(t, err)
+		return app
+	}
+	completeSync := func(name string, phase synccommon.OperationPhase) {
+		t.Helper()
+		app := getChild(name)
+		startedAt := metav1.NewTime(time.Now().Add(time.Second))
+		app.Operation = nil
+		app.Status.OperationState = &v1alpha1.OperationState{Phase: phase, Message: "sync " + string(phase), StartedAt: startedAt, FinishedAt: &startedAt}
+		reconciledAt := metav1.NewTime(startedAt.Add(time.Second))
+		app.Status.ReconciledAt = &reconciledAt
+		app.Status.Sync.Status = v1alpha1.SyncStatusCodeSynced
+		app.Status.Health.Status = health.HealthStatusHealthy
+		require.NoError(t, ctrl.appInformer.GetIndexer().Update(app))
+	}
+	state := NewOperationState(*parent.Operation)
+
+	// the child applications of the first wave are synced
+	ctrl.cascadeAppOperation(parent, state)
+	assert.Equal(t, synccommon.OperationRunning, state.Phase)
+	assert.Equal(t, "Waiting for the sync of 1 child applications of wave 0", state.Message)
+	require.Len(t, state.CascadeResult.Children, 2)
+	assert.Equal(t, "child-b", state.CascadeResult.Children[0].Name)
+	assert.Equal(t, synccommon.OperationRunning, state.CascadeResult.Children[0].Phase)
+	assert.NotNil(t, state.CascadeResult.Children[0].StartedAt)
+	assert.Equal(t, "child-a", state.CascadeResult.Children[1].Name)
+	assert.Equal(t, int64(1), state.CascadeResult.Children[1].Wave)
+	assert.Empty(t, state.CascadeResult.Children[1].Phase)
+	op := getChild("child-b").Operation
+	require.NotNil(t, op)
+	require.NotNil(t, op.Sync)
+	assert.True(t, op.Sync.Prune)
+	assert.Equal(t, "alice", op.InitiatedBy.Username)
+	assert.Nil(t, getChild("child-a").Operation)
+
+	// the next wave starts once the child applications of the first wave are synced and healthy
+	completeSync("child-b", synccommon.OperationSucceeded)
+	ctrl.cascadeAppOperation(parent, state)
+	assert.Equal(t, synccommon.OperationRunning, state.Phase)
+	assert.Equal(t, "Waiting for the sync of 1 child applications of wave 1", state.Message)
+	assert.Equal(t, synccommon.OperationSucceeded, state.CascadeResult.Children[0].Phase)
+	assert.Equal(t, synccommon.OperationRunning, state.CascadeResult.Children[1].Phase)
+	assert.NotNil(t, getChild("child-a").Operation)
+
+	// the cascade operation fails with the sync of a child application
+	completeSync("child-a", synccommon.OperationFailed)
+	ctrl.cascadeAppOperation(parent, state)
+	assert.Equal(t, synccommon.OperationFailed, state.Phase)
+	assert.Equal(t, "Cascade sync failed in wave 1: "+test.FakeArgoCDNamespace+"/child-a", state.Message)
+	assert.Equal(t, "sync Failed", state.CascadeResult.Children[1].Message)
+}
+
+func TestCascadeAppOperation_UnlistedChild(t *testing.T) {
+	parent, childA, childB := newFakeCascadeApps(v1alpha1.CascadeOperationTypeSync)
+	// child-a was added to the parent application after the operation started
+	parent.Operation.Cascade.Children = parent.Operation.Cascade.Children[1:]
+	ctrl := newFakeController(&fakeData{apps: []runtime.Object{parent, childA, childB, &defaultProj}}, nil)
+	state := NewOperationState(*parent.Operation)
+
+	ctrl.cascadeAppOperation(parent, state)
+	require.Len(t, state.CascadeResult.Children, 1)
+	assert.Equal(t, "child-b", state.CascadeResult.Children[0].Name)
+	child, err := ctrl.applicationClientset.ArgoprojV1alpha1().Applications(test.FakeArgoCDNamespace).Get(t.Context(), "child-a", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Nil(t, child.Operation)
+}
+
+func TestNewCascadeSyncOperation(t *testing.T) {
+	parent, childA, _ := newFakeCascadeApps(v1alpha1.CascadeOperationTypeSync)
+	childA.Spec.SyncPolicy = nil
+	proj := defaultProj.DeepCopy()
+	proj.Spec.ApplicationDefaults = &v1alpha1.ApplicationDefaults{
+		SyncPolicy:  &v1alpha1.SyncPolicy{Retry: &v1alpha1.RetryStrategy{Limit: 3}},
+		SyncOptions: v1alpha1.SyncOptions{"CreateNamespace=true"},
+	}
+	state := NewOperationState(*parent.Operation)
+
+	// the sync options and retry strategy default to the ones of the project
+	op := newCascadeSyncOperation(parent, childA, proj, state)
+	assert.Equal(t, v1alpha1.SyncOptions{"CreateNamespace=true"}, op.Sync.SyncOptions)
+	assert.Equal(t, v1alpha1.RetryStrategy{Limit: 3}, op.Retry)
+}
+
+func TestCascadeAppOperation_Refresh(t *testing.T) {
+	parent, childA, childB := newFakeCascadeApps(v1alpha1.CascadeOperationTypeRefresh)
+	ctrl := newFakeController(&fakeData{apps: []runtime.Object{parent, childA, childB, &defaultProj}}, nil)
+	state := NewOperationState(*parent.Operation)
+
+	ctrl.cascadeAppOperation(parent, state)
+	assert.Equal(t, synccommon.OperationRunning, state.Phase)
+	child, err := ctrl.applicationClientset.ArgoprojV1alpha1().Applications(test.FakeArgoCDNamespace).Get(t.Context(), "child-b", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, string(v1alpha1.RefreshTypeNormal), child.Annotations[v1alpha1.AnnotationKeyRefresh])
+	assert.Nil(t, child.Operation)
+
+	// the refresh of the child application completes once it is reconciled
+	delete(child.Annotations, v1alpha1.AnnotationKeyRefresh)
+	reconciledAt := metav1.NewTime(time.Now().Add(time.Second))
+	child.Status.ReconciledAt = &reconciledAt
+	require.NoError(t, ctrl.appInformer.GetIndexer().Update(child))
+	ctrl.cascadeAppOperation(parent, state)
+	assert.Equal(t, synccommon.OperationSucceeded, state.CascadeResult.Children[0].Phase)
+	assert.Equal(t, "Waiting for the refresh of 1 child applications of wave 1", state.Message)
+}
+
+func TestCascadeAppOperation_Terminating(t *testing.T) {
+	parent, childA, childB := newFakeCascadeApps(v1alpha1.CascadeOperationTypeSync)
+	ctrl := newFakeController(&fakeData{apps: []runtime.Object{parent, childA, childB, &defaultProj}}, nil)
+	state := NewOperationState(*parent.Operation)
+	state.Phase = synccommon.OperationTerminating
+
+	ctrl.cascadeAppOperation(parent, state)
+	assert.Equal(t, synccommon.OperationFailed, state.Phase)
+	assert.Equal(t, "Operation terminated", state.Message)
+}
+
+func TestGetCascadeWave(t *testing.T) {
+	app := newFakeApp()
+	assert.Equal(t, int64(0), getCascadeWave(app))
+	app.Annotations = map[string]string{v1alpha1.AnnotationKeyCascadeWave: "-2"}
+	assert.Equal(t, int64(-2), getCascadeWave(app))
+	app.Annotations[v1alpha1.AnnotationKeyCascadeWave] = "first"
+	assert.Equal(t, int64(0), getCascadeWave(app))
+}
@@ -0,0 +1,138 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/argoproj/gitops-engine/pkg/utils/kube"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	appv1 "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	"github.com/argoproj/argo-cd/v3/util/env"
+)
+
+const (
+	// EnvSyncClusterReadinessGating is an environment variable which enables the deferral of the syncs to the clusters
+	// which are unreachable, instead of failing the sync operations
+	EnvSyncClusterReadinessGating = "ARGOCD_SYNC_CLUSTER_READINESS_GATING"
+
+	// clusterReadinessProbeTTL is how long the result of a readiness probe of a cluster is reused
+	clusterReadinessProbeTTL = 10 * time.Second
+	// clusterReadinessRecheckPeriod is the period at which the deferred syncs check again the readiness of their cluster
+	clusterReadinessRecheckPeriod = 30 * time.Second
+	// clusterReadinessProbeTimeout is the timeout of a readiness probe of a cluster
+	clusterReadinessProbeTimeout = 10 * time.Second
+)
+
+var syncClusterReadinessGating = env.ParseBoolFromEnv(EnvSyncClusterReadinessGating, false)
+
+// clusterReadinessProber checks whether the syncs to a cluster can start. The syncs are deferred while the cluster is
+// draining, while it has less ready nodes than its minimum number of ready nodes, and, if the reachability probes are
+// enabled, while its API server is unreachable.
+type clusterReadinessProber struct {
+	kubectl            kube.Kubectl
+	probeReachability  bool
+	newKubeClientsetFn func(server *appv1.Cluster) (kubernetes.Interface, error)
+
+	lock   sync.Mutex
+	probes map[clusterReadinessProbeKey]clusterReadinessProbe
+}
+
+type clusterReadinessProbeKey struct {
+	server        string
+	minReadyNodes int64
+}
+
+type clusterReadinessProbe struct {
+	probedAt time.Time
+	err      error
+}
+
+func newClusterReadinessProber(kubectl kube.Kubectl, probeReachability bool) *clusterReadinessProber {
+	return &clusterReadinessProber{
+		kubectl:           kubectl,
+		probeReachability: probeReachability,
+		newKubeClientsetFn: func(server *appv1.Cluster) (kubernetes.Interface, error) {
+			config, err := server.RESTConfig()
+			if err != nil {
+				return nil, err
+			}
+			return kubernetes.NewForConfig(config)
+		},
+		probes: map[clusterReadinessProbeKey]clusterReadinessProbe{},
+	}
+}
+
+// check returns why the syncs to the given cluster must be deferred, or nil if they can start
+func (p *clusterReadinessProber) check(server *appv1.Cluster) error {
+	if server.Draining {
+		return errors.New("cluster is draining")
+	}
+	if !p.probeReachability && server.MinReadyNodes <= 0 {
+		return nil
+	}
+
+	key := clusterReadinessProbeKey{server: server.Server, minReadyNodes: server.MinReadyNodes}
+	p.lock.Lock()
+	probe, ok := p.probes[key]
+	p.lock.Unlock()
+	if ok && time.Since(probe.probedAt) < clusterReadinessProbeTTL {
+		return probe.err
+	}
+	probe = clusterReadinessProbe{probedAt: time.Now(), err: p.probe(server)}
+	p.lock.Lock()
+	p.probes[key] = probe
+	p.lock.Unlock()
+	return probe.err
+}
+
+func (p *clusterReadinessProber) probe(server *appv1.Cluster) error {
+	config, err := server.RESTConfig()
+	if err != nil {
+		return fmt.Errorf("error getting cluster REST config: %w", err)
+	}
+	if _, err := p.kubectl.GetServerVersion(config); err != nil {
+		return fmt.Errorf("cluster is unreachable: %w", err)
+	}
+	if server.MinReadyNodes <= 0 {
+		return nil
+	}
+
+	clientset, err := p.newKubeClientsetFn(server)
+	if err != nil {
+		return fmt.Errorf("error creating cluster client: %w", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), clusterReadinessProbeTimeout)
+	defer cancel()
+	nodes, err := clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("error listing cluster nodes: %w", err)
+	}
+	var ready int64
+	for _, node := range nodes.Items {
+		if isNodeReady(&node) {
+			ready++
+		}
+	}
+	if ready < server.MinReadyNodes {
+		return fmt.Errorf("%d of the %d nodes of the cluster are ready, at least %d are required", ready, len(nodes.Items), server.MinReadyNodes)
+	}
+	return nil
+}
+
+func isNodeReady(node *corev1.Node) bool {
+	if node.Spec.Unschedulable {
+		return false
+	}
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
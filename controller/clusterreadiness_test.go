@@ -0,0 +1,72 @@
+package controller
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/argoproj/gitops-engine/pkg/utils/kube/kubetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+
+	appv1 "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+)
+
+type unreachableKubectl struct {
+	*kubetest.MockKubectlCmd
+}
+
+func (k *unreachableKubectl) GetServerVersion(_ *rest.Config) (string, error) {
+	return "", errors.New("connection refused")
+}
+
+func newTestNode(name string, ready bool, unschedulable bool) *corev1.Node {
+	status := corev1.ConditionFalse
+	if ready {
+		status = corev1.ConditionTrue
+	}
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       corev1.NodeSpec{Unschedulable: unschedulable},
+		Status:     corev1.NodeStatus{Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: status}}},
+	}
+}
+
+func TestClusterReadinessProber_Check(t *testing.T) {
+	t.Run("draining", func(t *testing.T) {
+		prober := newClusterReadinessProber(&kubetest.MockKubectlCmd{}, false)
+		err := prober.check(&appv1.Cluster{Server: "https://cluster", Draining: true})
+		require.EqualError(t, err, "cluster is draining")
+	})
+
+	t.Run("not probed by default", func(t *testing.T) {
+		prober := newClusterReadinessProber(&unreachableKubectl{&kubetest.MockKubectlCmd{}}, false)
+		require.NoError(t, prober.check(&appv1.Cluster{Server: "https://cluster"}))
+	})
+
+	t.Run("unreachable", func(t *testing.T) {
+		prober := newClusterReadinessProber(&unreachableKubectl{&kubetest.MockKubectlCmd{}}, true)
+		err := prober.check(&appv1.Cluster{Server: "https://cluster"})
+		require.ErrorContains(t, err, "cluster is unreachable: connection refused")
+	})
+
+	t.Run("minimum ready nodes", func(t *testing.T) {
+		clientset := fake.NewClientset(
+			newTestNode("node-1", true, false),
+			newTestNode("node-2", false, false),
+			newTestNode("node-3", true, true),
+		)
+		prober := newClusterReadinessProber(&kubetest.MockKubectlCmd{}, false)
+		prober.newKubeClientsetFn = func(_ *appv1.Cluster) (kubernetes.Interface, error) {
+			return clientset, nil
+		}
+
+		err := prober.check(&appv1.Cluster{Server: "https://cluster", MinReadyNodes: 2})
+		require.EqualError(t, err, "1 of the 3 nodes of the cluster are ready, at least 2 are required")
+		assert.NoError(t, prober.check(&appv1.Cluster{Server: "https://cluster", MinReadyNodes: 1}))
+	})
+}
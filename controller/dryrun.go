@@ -0,0 +1,25 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/argoproj/gitops-engine/pkg/diff"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/argoproj/argo-cd/v3/controller/metrics"
+)
+
+// observedDryRunner is a server-side dry runner observing the latency of the dry-run applies to a cluster
+type observedDryRunner struct {
+	diff.ServerSideDryRunner
+	metricsServer *metrics.MetricsServer
+	destServer    string
+}
+
+func (r *observedDryRunner) Run(ctx context.Context, obj *unstructured.Unstructured, manager string) (string, error) {
+	start := time.Now()
+	res, err := r.ServerSideDryRunner.Run(ctx, obj, manager)
+	r.metricsServer.ObserveServerSideDiffDryRunDuration(r.destServer, err != nil, time.Since(start))
+	return res, err
+}
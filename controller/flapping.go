@@ -0,0 +1,162 @@
+package controller
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/argoproj/gitops-engine/pkg/health"
+
+	appv1 "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+)
+
+const (
+	// flappingWindow is the period over which the transitions of the statuses of the applications are counted
+	flappingWindow = time.Hour
+
+	flappingStatusTypeSync   = "sync"
+	flappingStatusTypeHealth = "health"
+)
+
+// flappingMetrics reports the transitions of the statuses of the applications
+type flappingMetrics interface {
+	IncAppStatusTransition(app *appv1.Application, statusType string)
+	SetAppFlapping(app *appv1.Application, statusType string, flapping bool)
+}
+
+// statusTransitions tracks the transitions of a status between two values
+type statusTransitions struct {
+	last        string
+	transitions []time.Time
+}
+
+// observe records the status observed at the given time, ignoring the statuses other than the tracked ones, and
+// returns true if the status changed. The transitions older than the flapping window are dropped.
+func (t *statusTransitions) observe(status string, tracked []string, now time.Time) bool {
+	cutoff := now.Add(-flappingWindow)
+	i := 0
+	for i < len(t.transitions) && !t.transitions[i].After(cutoff) {
+		i++
+	}
+	t.transitions = t.transitions[i:]
+	if !slices.Contains(tracked, status) {
+		return false
+	}
+	changed := t.last != "" && t.last != status
+	if changed {
+		t.transitions = append(t.transitions, now)
+	}
+	t.last = status
+	return changed
+}
+
+// appStatusTransitions tracks the transitions of the statuses of an application and of its resources
+type appStatusTransitions struct {
+	sync   statusTransitions
+	health statusTransitions
+	// resources tracks the transitions of the sync statuses of the resources, by resourceStatusKey
+	resources map[string]*statusTransitions
+}
+
+// flappingDetector detects the applications whose sync status keeps changing between Synced and OutOfSync, or whose
+// health keeps changing between Healthy and Degraded, more than a threshold per hour. That usually happens when
+// the self heal fights with another controller over the resources of the application, which the detector can stop
+// by pausing the self heal of the resources whose sync status is flapping.
+type flappingDetector struct {
+	threshold     int
+	pauseSelfHeal bool
+	metrics       flappingMetrics
+	lock          sync.Mutex
+	apps          map[string]*appStatusTransitions
+}
+
+func newFlappingDetector(threshold int, pauseSelfHeal bool, metrics flappingMetrics) *flappingDetector {
+	return &flappingDetector{
+		threshold:     threshold,
+		pauseSelfHeal: pauseSelfHeal,
+		metrics:       metrics,
+		apps:          map[string]*appStatusTransitions{},
+	}
+}
+
+func (d *flappingDetector) conditionType() appv1.ApplicationConditionType {
+	return appv1.ApplicationConditionFlappingWarning
+}
+
+func (d *flappingDetector) observe(app *appv1.Application, compareResult *comparisonResult, now time.Time) *appAnomaly {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	history, ok := d.apps[app.QualifiedName()]
+	if !ok {
+		history = &appStatusTransitions{resources: map[string]*statusTransitions{}}
+		d.apps[app.QualifiedName()] = history
+	}
+
+	var messages []string
+	if history.sync.observe(string(compareResult.syncStatus.Status), []string{string(appv1.SyncStatusCodeSynced), string(appv1.SyncStatusCodeOutOfSync)}, now) {
+		d.metrics.IncAppStatusTransition(app, flappingStatusTypeSync)
+	}
+	syncFlapping := len(history.sync.transitions) > d.threshold
+	d.metrics.SetAppFlapping(app, flappingStatusTypeSync, syncFlapping)
+	if syncFlapping {
+		messages = append(messages, fmt.Sprintf("sync status changed %d times between Synced and OutOfSync", len(history.sync.transitions)))
+	}
+	if history.health.observe(string(compareResult.healthStatus), []string{string(health.HealthStatusHealthy), string(health.HealthStatusDegraded)}, now) {
+		d.metrics.IncAppStatusTransition(app, flappingStatusTypeHealth)
+	}
+	healthFlapping := len(history.health.transitions) > d.threshold
+	d.metrics.SetAppFlapping(app, flappingStatusTypeHealth, healthFlapping)
+	if healthFlapping {
+		messages = append(messages, fmt.Sprintf("health status changed %d times between Healthy and Degraded", len(history.health.transitions)))
+	}
+
+	resources := map[string]*statusTransitions{}
+	var flappingResources []string
+	for _, res := range compareResult.resources {
+		key := resourceStatusKey(res)
+		transitions, ok := history.resources[key]
+		if !ok {
+			transitions = &statusTransitions{}
+		}
+		transitions.observe(string(res.Status), []string{string(appv1.SyncStatusCodeSynced), string(appv1.SyncStatusCodeOutOfSync)}, now)
+		resources[key] = transitions
+		if len(transitions.transitions) > d.threshold {
+			flappingResources = append(flappingResources, key)
+		}
+	}
+	// the resources which aren't managed by the application anymore are dropped
+	history.resources = resources
+
+	if len(messages) == 0 {
+		return nil
+	}
+	message := fmt.Sprintf("The %s during the last hour", strings.Join(messages, " and the "))
+	if d.pauseSelfHeal && syncFlapping && len(flappingResources) > 0 {
+		sort.Strings(flappingResources)
+		message += ". The self heal is paused for the flapping resources: " + strings.Join(flappingResources, ", ")
+	}
+	return &appAnomaly{message: message}
+}
+
+func (d *flappingDetector) selfHealPaused(appKey string, resource appv1.ResourceStatus) bool {
+	if !d.pauseSelfHeal {
+		return false
+	}
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	history, ok := d.apps[appKey]
+	if !ok || len(history.sync.transitions) <= d.threshold {
+		return false
+	}
+	transitions, ok := history.resources[resourceStatusKey(resource)]
+	return ok && len(transitions.transitions) > d.threshold
+}
+
+func (d *flappingDetector) forget(appKey string) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	delete(d.apps, appKey)
+}
@@ -0,0 +1,127 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/argoproj/gitops-engine/pkg/health"
+	"github.com/argoproj/gitops-engine/pkg/utils/kube"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	"github.com/argoproj/argo-cd/v3/test"
+)
+
+type fakeFlappingMetrics struct {
+	transitions map[string]int
+	flapping    map[string]bool
+}
+
+func (m *fakeFlappingMetrics) IncAppStatusTransition(_ *v1alpha1.Application, statusType string) {
+	m.transitions[statusType]++
+}
+
+func (m *fakeFlappingMetrics) SetAppFlapping(_ *v1alpha1.Application, statusType string, flapping bool) {
+	m.flapping[statusType] = flapping
+}
+
+func flappingComparisonResult(status v1alpha1.SyncStatusCode, healthStatus health.HealthStatusCode) *comparisonResult {
+	return &comparisonResult{
+		syncStatus:   &v1alpha1.SyncStatus{Status: status},
+		healthStatus: healthStatus,
+		resources: []v1alpha1.ResourceStatus{
+			{Kind: kube.DeploymentKind, Namespace: "default", Name: "guestbook", Status: status},
+			{Kind: kube.ServiceKind, Namespace: "default", Name: "guestbook", Status: v1alpha1.SyncStatusCodeSynced},
+		},
+	}
+}
+
+func TestFlappingDetector(t *testing.T) {
+	app := newFakeApp()
+	metrics := &fakeFlappingMetrics{transitions: map[string]int{}, flapping: map[string]bool{}}
+	detector := newFlappingDetector(3, true, metrics)
+	deployment := v1alpha1.ResourceStatus{Kind: kube.DeploymentKind, Namespace: "default", Name: "guestbook"}
+	service := v1alpha1.ResourceStatus{Kind: kube.ServiceKind, Namespace: "default", Name: "guestbook"}
+	now := time.Now()
+
+	statuses := []v1alpha1.SyncStatusCode{
+		v1alpha1.SyncStatusCodeSynced,
+		v1alpha1.SyncStatusCodeOutOfSync,
+		v1alpha1.SyncStatusCodeUnknown,
+		v1alpha1.SyncStatusCodeSynced,
+		v1alpha1.SyncStatusCodeOutOfSync,
+	}
+	for i, status := range statuses {
+		assert.Nil(t, detector.observe(app, flappingComparisonResult(status, health.HealthStatusHealthy), now.Add(time.Duration(i)*time.Minute)))
+	}
+	assert.Equal(t, map[string]int{flappingStatusTypeSync: 3}, metrics.transitions)
+	assert.False(t, detector.selfHealPaused(app.QualifiedName(), deployment))
+
+	anomaly := detector.observe(app, flappingComparisonResult(v1alpha1.SyncStatusCodeSynced, health.HealthStatusHealthy), now.Add(10*time.Minute))
+	require.NotNil(t, anomaly)
+	assert.Equal(t, "The sync status changed 4 times between Synced and OutOfSync during the last hour. The self heal is paused for the flapping resources: /Deployment/default/guestbook", anomaly.message)
+	assert.Equal(t, map[string]bool{flappingStatusTypeSync: true, flappingStatusTypeHealth: false}, metrics.flapping)
+	assert.True(t, detector.selfHealPaused(app.QualifiedName(), deployment))
+	assert.False(t, detector.selfHealPaused(app.QualifiedName(), service))
+
+	// the transitions older than an hour aren't counted
+	assert.Nil(t, detector.observe(app, flappingComparisonResult(v1alpha1.SyncStatusCodeSynced, health.HealthStatusHealthy), now.Add(time.Hour+time.Minute)))
+	assert.Equal(t, map[string]bool{flappingStatusTypeSync: false, flappingStatusTypeHealth: false}, metrics.flapping)
+	assert.False(t, detector.selfHealPaused(app.QualifiedName(), deployment))
+
+	detector.forget(app.QualifiedName())
+	assert.Empty(t, detector.apps)
+}
+
+func TestFlappingDetectorHealth(t *testing.T) {
+	app := newFakeApp()
+	metrics := &fakeFlappingMetrics{transitions: map[string]int{}, flapping: map[string]bool{}}
+	detector := newFlappingDetector(1, false, metrics)
+	now := time.Now()
+
+	var anomaly *appAnomaly
+	for i, healthStatus := range []health.HealthStatusCode{health.HealthStatusHealthy, health.HealthStatusDegraded, health.HealthStatusProgressing, health.HealthStatusHealthy} {
+		anomaly = detector.observe(app, flappingComparisonResult(v1alpha1.SyncStatusCodeSynced, healthStatus), now.Add(time.Duration(i)*time.Minute))
+	}
+	require.NotNil(t, anomaly)
+	assert.Equal(t, "The health status changed 2 times between Healthy and Degraded during the last hour", anomaly.message)
+	assert.Equal(t, map[string]int{flappingStatusTypeHealth: 2}, metrics.transitions)
+	// the self heal is only paused for the resources whose sync status is flapping
+	assert.False(t, detector.selfHealPaused(app.QualifiedName(), v1alpha1.ResourceStatus{Kind: kube.DeploymentKind, Namespace: "default", Name: "guestbook"}))
+}
+
+func TestAutoSyncSelfHealPausedForFlappingResources(t *testing.T) {
+	app := newFakeApp()
+	app.Spec.SyncPolicy.Automated.SelfHeal = true
+	app.Status.OperationState.FinishedAt = &metav1.Time{Time: time.Now().Add(-time.Hour)}
+	ctrl := newFakeController(&fakeData{apps: []runtime.Object{app, &defaultProj}}, nil)
+	detector := newFlappingDetector(1, true, &fakeFlappingMetrics{transitions: map[string]int{}, flapping: map[string]bool{}})
+	ctrl.anomalyDetectors = []appAnomalyDetector{detector}
+	now := time.Now()
+	for i, status := range []v1alpha1.SyncStatusCode{v1alpha1.SyncStatusCodeOutOfSync, v1alpha1.SyncStatusCodeSynced, v1alpha1.SyncStatusCodeOutOfSync} {
+		detector.observe(app, flappingComparisonResult(status, health.HealthStatusHealthy), now.Add(time.Duration(i)*time.Minute))
+	}
+	syncStatus := v1alpha1.SyncStatus{
+		Status:   v1alpha1.SyncStatusCodeOutOfSync,
+		Revision: "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+	}
+
+	cond, _ := ctrl.autoSync(app, &syncStatus, []v1alpha1.ResourceStatus{{Kind: kube.DeploymentKind, Namespace: "default", Name: "guestbook", Status: v1alpha1.SyncStatusCodeOutOfSync}}, true)
+	assert.Nil(t, cond)
+	updatedApp, err := ctrl.applicationClientset.ArgoprojV1alpha1().Applications(test.FakeArgoCDNamespace).Get(t.Context(), "my-app", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Nil(t, updatedApp.Operation)
+
+	cond, _ = ctrl.autoSync(app, &syncStatus, []v1alpha1.ResourceStatus{
+		{Kind: kube.DeploymentKind, Namespace: "default", Name: "guestbook", Status: v1alpha1.SyncStatusCodeOutOfSync},
+		{Kind: "ConfigMap", Namespace: "default", Name: "guestbook", Status: v1alpha1.SyncStatusCodeOutOfSync},
+	}, true)
+	assert.Nil(t, cond)
+	updatedApp, err = ctrl.applicationClientset.ArgoprojV1alpha1().Applications(test.FakeArgoCDNamespace).Get(t.Context(), "my-app", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.NotNil(t, updatedApp.Operation)
+	assert.Equal(t, []v1alpha1.SyncOperationResource{{Kind: "ConfigMap", Name: "guestbook"}}, updatedApp.Operation.Sync.Resources)
+}
@@ -0,0 +1,74 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	appv1 "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	applog "github.com/argoproj/argo-cd/v3/util/app/log"
+	"github.com/argoproj/argo-cd/v3/util/argo"
+)
+
+// getActiveFreeze returns the freeze of the application if it is active at the given time. The refresh of the
+// application is requested when its freeze expires, and the freeze which expired is removed from the application.
+func (ctrl *ApplicationController) getActiveFreeze(app *appv1.Application, now time.Time) *appv1.ApplicationFreeze {
+	logCtx := log.WithFields(applog.GetAppLogFields(app))
+	freeze, err := app.GetFreeze()
+	if err != nil {
+		logCtx.Warnf("Ignoring the invalid freeze of the application: %v", err)
+		return nil
+	}
+	if freeze == nil {
+		return nil
+	}
+	if !freeze.IsActive(now) {
+		if err := ctrl.removeExpiredFreeze(app); err != nil {
+			logCtx.Errorf("Failed to remove the expired freeze: %v", err)
+		}
+		return nil
+	}
+	if freeze.Until != nil {
+		ctrl.appRefreshQueue.AddAfter(app.QualifiedName(), freeze.Until.Sub(now))
+	}
+	return freeze
+}
+
+// removeExpiredFreeze removes the freeze of an application which expired, and requests its refresh to catch up with
+// the changes made while it was frozen
+func (ctrl *ApplicationController) removeExpiredFreeze(app *appv1.Application) error {
+	patch, err := json.Marshal(map[string]any{
+		"metadata": map[string]any{
+			"annotations": map[string]any{
+				appv1.AnnotationKeyFreeze:  nil,
+				appv1.AnnotationKeyRefresh: string(appv1.RefreshTypeNormal),
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("error marshaling freeze patch: %w", err)
+	}
+	_, err = ctrl.applicationClientset.ArgoprojV1alpha1().Applications(app.Namespace).Patch(context.Background(), app.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		return fmt.Errorf("error removing freeze of application %s: %w", app.QualifiedName(), err)
+	}
+	ctrl.logAppEvent(context.TODO(), app, argo.EventInfo{Reason: argo.EventReasonThawed, Type: corev1.EventTypeNormal}, "Thawed the application as its freeze expired")
+	return nil
+}
+
+// persistAppFreeze reflects the freeze of an application in its status, when the application isn't refreshed
+func (ctrl *ApplicationController) persistAppFreeze(origApp *appv1.Application, freeze *appv1.ApplicationFreeze) {
+	if apiequality.Semantic.DeepEqual(origApp.Status.Freeze, freeze) {
+		return
+	}
+	app := origApp.DeepCopy()
+	app.Status.Freeze = freeze
+	ctrl.persistAppStatus(origApp, &app.Status)
+}
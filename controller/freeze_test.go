@@ -0,0 +1,68 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/argoproj/gitops-engine/pkg/utils/kube"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	"github.com/argoproj/argo-cd/v3/reposerver/apiclient"
+	"github.com/argoproj/argo-cd/v3/test"
+)
+
+func TestAutoSyncFrozenApp(t *testing.T) {
+	configMap := `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"my-map","namespace":"` + test.FakeDestNamespace + `"}}`
+	refresh := func(t *testing.T, freeze string) *v1alpha1.Application {
+		t.Helper()
+		app := newFakeApp()
+		app.Status.OperationState = nil
+		app.Annotations = map[string]string{v1alpha1.AnnotationKeyFreeze: freeze}
+		ctrl := newFakeController(&fakeData{
+			apps: []runtime.Object{app, defaultProj.DeepCopy()},
+			manifestResponse: &apiclient.ManifestResponse{
+				Manifests: []string{configMap},
+				Namespace: test.FakeDestNamespace,
+				Server:    test.FakeClusterURL,
+				Revision:  "abc123",
+			},
+			managedLiveObjs: make(map[kube.ResourceKey]*unstructured.Unstructured),
+		}, nil)
+		key, _ := cache.MetaNamespaceKeyFunc(app)
+		ctrl.appRefreshQueue.AddRateLimited(key)
+		ctrl.requestAppRefresh(app.Name, CompareWithLatest.Pointer(), nil)
+		ctrl.processAppRefreshQueueItem()
+		updatedApp, err := ctrl.applicationClientset.ArgoprojV1alpha1().Applications(test.FakeArgoCDNamespace).Get(t.Context(), app.Name, metav1.GetOptions{})
+		require.NoError(t, err)
+		return updatedApp
+	}
+	until := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+
+	t.Run("no auto-sync of a frozen application", func(t *testing.T) {
+		app := refresh(t, `{"frozenBy":"alice","frozenAt":"2026-01-01T00:00:00Z","until":"`+until+`"}`)
+		assert.Nil(t, app.Operation)
+		require.NotNil(t, app.Status.Freeze)
+		assert.Equal(t, "alice", app.Status.Freeze.FrozenBy)
+		// the application is still refreshed
+		assert.Equal(t, v1alpha1.SyncStatusCodeOutOfSync, app.Status.Sync.Status)
+	})
+	t.Run("no refresh of a frozen application", func(t *testing.T) {
+		app := refresh(t, `{"frozenAt":"2026-01-01T00:00:00Z","noRefresh":true}`)
+		assert.Nil(t, app.Operation)
+		require.NotNil(t, app.Status.Freeze)
+		assert.True(t, app.Status.Freeze.NoRefresh)
+		assert.Empty(t, app.Status.Sync.Status)
+	})
+	t.Run("auto-sync once the freeze expired", func(t *testing.T) {
+		app := refresh(t, `{"frozenAt":"2026-01-01T00:00:00Z","until":"2026-01-01T01:00:00Z"}`)
+		assert.NotNil(t, app.Operation)
+		assert.Nil(t, app.Status.Freeze)
+		assert.NotContains(t, app.Annotations, v1alpha1.AnnotationKeyFreeze)
+	})
+}
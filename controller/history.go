@@ -0,0 +1,102 @@
+package controller
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+
+	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+)
+
+// revisionHistoryExportTimeout is the timeout of the requests exporting the purged revision history
+const revisionHistoryExportTimeout = 10 * time.Second
+
+// revisionHistoryExport is the payload posted to the revision history export URL
+type revisionHistoryExport struct {
+	// Application is the qualified name of the application
+	Application string `json:"application"`
+	Project     string `json:"project"`
+	// History contains the history items purged from the status of the application, oldest first
+	History v1alpha1.RevisionHistories `json:"history"`
+}
+
+// exportRevisionHistory exports in the background the revision history items to purge from the status of the
+// application, and removes them from the status once they have been exported. The items are kept if the export fails,
+// so that it is retried the next time the history is persisted. Only one export runs at a time per application.
+func (m *appStateManager) exportRevisionHistory(app *v1alpha1.Application, exportURL string, purged v1alpha1.RevisionHistories) {
+	key := app.QualifiedName()
+	if _, inProgress := m.historyExports.LoadOrStore(key, true); inProgress {
+		return
+	}
+	export := revisionHistoryExport{Application: key, Project: app.Spec.GetProject(), History: purged}
+	namespace, name := app.Namespace, app.Name
+	logCtx := log.WithFields(log.Fields{"application": name, "app-namespace": namespace, "project": export.Project})
+	go func() {
+		defer m.historyExports.Delete(key)
+		if err := postRevisionHistory(exportURL, export); err != nil {
+			logCtx.Warnf("Failed to export purged revision history: %v", err)
+			return
+		}
+		if err := m.removeRevisionHistory(namespace, name, purged); err != nil {
+			logCtx.Warnf("Failed to remove exported revision history: %v", err)
+		}
+	}()
+}
+
+// postRevisionHistory posts the revision history items to the export URL
+func postRevisionHistory(exportURL string, export revisionHistoryExport) error {
+	body, err := json.Marshal(export)
+	if err != nil {
+		return fmt.Errorf("error marshaling revision history: %w", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), revisionHistoryExportTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, exportURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("error creating revision history export request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error exporting revision history: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("error exporting revision history: unexpected status %s", resp.Status)
+	}
+	return nil
+}
+
+// removeRevisionHistory removes the exported revision history items from the status of the application
+func (m *appStateManager) removeRevisionHistory(namespace string, name string, exported v1alpha1.RevisionHistories) error {
+	ids := map[int64]bool{}
+	for _, item := range exported {
+		ids[item.ID] = true
+	}
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		appIf := m.appclientset.ArgoprojV1alpha1().Applications(namespace)
+		app, err := appIf.Get(context.Background(), name, metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("error getting application: %w", err)
+		}
+		var history v1alpha1.RevisionHistories
+		for _, item := range app.Status.History {
+			if !ids[item.ID] {
+				history = append(history, item)
+			}
+		}
+		if len(history) == len(app.Status.History) {
+			return nil
+		}
+		app.Status.History = history
+		_, err = appIf.Update(context.Background(), app, metav1.UpdateOptions{})
+		return err
+	})
+}
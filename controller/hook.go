@@ -2,10 +2,14 @@ package controller
 
 import (
 	"context"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/argoproj/gitops-engine/pkg/health"
 	"github.com/argoproj/gitops-engine/pkg/sync/common"
 	"github.com/argoproj/gitops-engine/pkg/sync/hook"
+	resourceutil "github.com/argoproj/gitops-engine/pkg/sync/resource"
 	"github.com/argoproj/gitops-engine/pkg/utils/kube"
 	log "github.com/sirupsen/logrus"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -14,6 +18,7 @@ import (
 
 	"github.com/argoproj/argo-cd/v3/util/lua"
 
+	cdcommon "github.com/argoproj/argo-cd/v3/common"
 	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
 )
 
@@ -25,6 +30,11 @@ var (
 	}
 )
 
+// compareOptionExcludeCompletedHooksOlderThan is the compare option of an application which excludes from its resources
+// and its resource tree the completed hooks created more than the given duration ago, e.g.
+// ExcludeCompletedHooksOlderThan=1h
+const compareOptionExcludeCompletedHooksOlderThan = "ExcludeCompletedHooksOlderThan"
+
 func isHook(obj *unstructured.Unstructured) bool {
 	return hook.IsHook(obj) || isPostDeleteHook(obj)
 }
@@ -173,3 +183,36 @@ func (ctrl *ApplicationController) cleanupPostDeleteHooks(liveObjs map[kube.Reso
 	}
 	return true, nil
 }
+
+// getExcludeCompletedHooksOlderThan returns the age of the completed hooks excluded from the resources of the
+// application, or 0 if the completed hooks aren't excluded
+func getExcludeCompletedHooksOlderThan(app *v1alpha1.Application) (time.Duration, error) {
+	for _, option := range resourceutil.GetAnnotationCSVs(app, cdcommon.AnnotationCompareOptions) {
+		value, ok := strings.CutPrefix(option, compareOptionExcludeCompletedHooksOlderThan+"=")
+		if !ok {
+			continue
+		}
+		age, err := time.ParseDuration(value)
+		if err != nil {
+			return 0, fmt.Errorf("invalid compare option %s: %w", option, err)
+		}
+		if age <= 0 {
+			return 0, fmt.Errorf("invalid compare option %s: the duration must be positive", option)
+		}
+		return age, nil
+	}
+	return 0, nil
+}
+
+// isCompletedHookCreatedBefore returns true if the live object is a hook which completed, i.e. isn't progressing, and
+// was created before the given time. The hooks without health check are complete once created.
+func isCompletedHookCreatedBefore(obj *unstructured.Unstructured, healthOverrides health.HealthOverride, before time.Time) bool {
+	if obj == nil || !isHook(obj) || !obj.GetCreationTimestamp().Time.Before(before) {
+		return false
+	}
+	hookHealth, err := health.GetResourceHealth(obj, healthOverrides)
+	if err != nil {
+		return false
+	}
+	return hookHealth == nil || hookHealth.Status == health.HealthStatusHealthy || hookHealth.Status == health.HealthStatusDegraded
+}
@@ -0,0 +1,153 @@
+package controller
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/argoproj/gitops-engine/pkg/utils/kube"
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/utils/ptr"
+
+	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	"github.com/argoproj/argo-cd/v3/util/env"
+)
+
+const (
+	// EnvHookOutputLimitBytes is an environment variable which sets the maximum size of the logs of a container of a
+	// hook kept after the hook completes. The capture of the output of the hooks is disabled when it is set to 0.
+	EnvHookOutputLimitBytes = "ARGOCD_CONTROLLER_HOOK_OUTPUT_LIMIT_BYTES"
+	// EnvHookLogsRetention is an environment variable which sets how long the logs of the completed hooks are kept
+	EnvHookLogsRetention = "ARGOCD_CONTROLLER_HOOK_LOGS_RETENTION"
+
+	// hookOutputTailLines is the number of the last lines of the logs of a container of a hook which are captured
+	hookOutputTailLines = 100
+	// hookOutputMaxPods is the maximum number of pods of a hook whose output is captured, e.g. for the retries of a job
+	hookOutputMaxPods = 3
+	// hookLogsCaptureTimeout is the timeout of the capture of the logs of the pods of a hook
+	hookLogsCaptureTimeout = 30 * time.Second
+)
+
+var (
+	hookOutputLimitBytes = env.ParseInt64FromEnv(EnvHookOutputLimitBytes, 4*1024, 0, 64*1024)
+	hookLogsRetention    = env.ParseDurationFromEnv(EnvHookLogsRetention, 24*time.Hour, time.Minute, 30*24*time.Hour)
+)
+
+// captureHookOutputs adds the final status of the containers of the pods of the completed hooks to their results, and
+// calls onCaptured with them so that their logs can be captured. The output of a hook is only captured once, and is
+// copied from the results of the previous sync iteration afterward, so that it is kept after the pods of the hook are
+// deleted.
+func captureHookOutputs(ctx context.Context, results v1alpha1.ResourceResults, previous v1alpha1.ResourceResults, newKubeClientset func() (kubernetes.Interface, error), onCaptured func(kubeClientset kubernetes.Interface, namespace string, outputs []v1alpha1.HookOutput)) {
+	var kubeClientset kubernetes.Interface
+	for _, res := range results {
+		if res.HookType == "" || !res.HookPhase.Completed() {
+			continue
+		}
+		if _, prev := previous.Find(res.Group, res.Kind, res.Namespace, res.Name, res.SyncPhase); prev != nil && len(prev.HookOutputs) > 0 {
+			res.HookOutputs = prev.HookOutputs
+			continue
+		}
+		if !isPodHook(res) && !isJobHook(res) {
+			continue
+		}
+		if kubeClientset == nil {
+			var err error
+			if kubeClientset, err = newKubeClientset(); err != nil {
+				log.Warnf("Failed to create a client to capture the output of hooks: %v", err)
+				return
+			}
+		}
+		outputs, err := getHookOutputs(ctx, kubeClientset, res)
+		if err != nil {
+			log.Warnf("Failed to capture the output of hook %s/%s: %v", res.Kind, res.Name, err)
+			continue
+		}
+		res.HookOutputs = outputs
+		if len(outputs) > 0 {
+			onCaptured(kubeClientset, res.Namespace, outputs)
+		}
+	}
+}
+
+// captureHookLogs stores the last lines of the logs of the containers of the pods of a completed hook in the cache,
+// from which they are served by the logs API after the pods are deleted
+func (m *appStateManager) captureHookLogs(kubeClientset kubernetes.Interface, appName string, namespace string, outputs []v1alpha1.HookOutput, limit int64) {
+	ctx, cancel := context.WithTimeout(context.Background(), hookLogsCaptureTimeout)
+	defer cancel()
+	for _, output := range outputs {
+		logs, err := kubeClientset.CoreV1().Pods(namespace).GetLogs(output.Pod, &corev1.PodLogOptions{
+			Container:  output.Container,
+			TailLines:  ptr.To(int64(hookOutputTailLines)),
+			Timestamps: true,
+		}).DoRaw(ctx)
+		if err != nil {
+			log.Warnf("Failed to get the logs of container %s of hook pod %s/%s: %v", output.Container, namespace, output.Pod, err)
+			continue
+		}
+		if err := m.cache.SetHookLogs(appName, namespace, output.Pod, output.Container, truncateHookLogs(string(logs), limit), hookLogsRetention); err != nil {
+			log.Warnf("Failed to store the logs of container %s of hook pod %s/%s: %v", output.Container, namespace, output.Pod, err)
+		}
+	}
+}
+
+func isPodHook(res *v1alpha1.ResourceResult) bool {
+	return res.Group == "" && res.Kind == kube.PodKind
+}
+
+func isJobHook(res *v1alpha1.ResourceResult) bool {
+	return res.Group == "batch" && res.Kind == kube.JobKind
+}
+
+// getHookOutputs returns the final status of the containers of the pods of a pod or job hook, the most recent pods first
+func getHookOutputs(ctx context.Context, kubeClientset kubernetes.Interface, res *v1alpha1.ResourceResult) ([]v1alpha1.HookOutput, error) {
+	var pods []corev1.Pod
+	if isPodHook(res) {
+		pod, err := kubeClientset.CoreV1().Pods(res.Namespace).Get(ctx, res.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		pods = append(pods, *pod)
+	} else {
+		podList, err := kubeClientset.CoreV1().Pods(res.Namespace).List(ctx, metav1.ListOptions{LabelSelector: "job-name=" + res.Name})
+		if err != nil {
+			return nil, err
+		}
+		pods = podList.Items
+	}
+	slices.SortFunc(pods, func(a, b corev1.Pod) int {
+		return b.CreationTimestamp.Compare(a.CreationTimestamp.Time)
+	})
+	if len(pods) > hookOutputMaxPods {
+		pods = pods[:hookOutputMaxPods]
+	}
+
+	var outputs []v1alpha1.HookOutput
+	for _, pod := range pods {
+		for _, status := range append(slices.Clone(pod.Status.InitContainerStatuses), pod.Status.ContainerStatuses...) {
+			output := v1alpha1.HookOutput{Pod: pod.Name, Container: status.Name}
+			if terminated := status.State.Terminated; terminated != nil {
+				output.Reason = terminated.Reason
+				output.ExitCode = int64(terminated.ExitCode)
+			}
+			outputs = append(outputs, output)
+		}
+	}
+	return outputs, nil
+}
+
+// truncateHookLogs drops the start of the logs so that their size is at most the given limit
+func truncateHookLogs(logs string, limit int64) string {
+	if int64(len(logs)) <= limit {
+		return logs
+	}
+	logs = logs[int64(len(logs))-limit:]
+	// drop the partial first line
+	if i := strings.IndexByte(logs, '\n'); i >= 0 && i < len(logs)-1 {
+		logs = logs[i+1:]
+	}
+	return strings.ToValidUTF8(logs, "")
+}
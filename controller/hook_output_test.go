@@ -0,0 +1,93 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	synccommon "github.com/argoproj/gitops-engine/pkg/sync/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+
+	appv1 "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+)
+
+func newTestHookPod(name string, labels map[string]string, createdAt time.Time, exitCode int32) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: labels, CreationTimestamp: metav1.NewTime(createdAt)},
+		Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{
+			Name:  "main",
+			State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Reason: "Error", ExitCode: exitCode}},
+		}}},
+	}
+}
+
+func TestCaptureHookOutputs(t *testing.T) {
+	now := time.Now()
+	clientset := fake.NewClientset(
+		newTestHookPod("migrate-1", map[string]string{"job-name": "migrate"}, now.Add(-time.Minute), 1),
+		newTestHookPod("migrate-2", map[string]string{"job-name": "migrate"}, now, 2),
+		newTestHookPod("smoke-test", nil, now, 0),
+	)
+	newKubeClientset := func() (kubernetes.Interface, error) {
+		return clientset, nil
+	}
+
+	t.Run("completed hooks", func(t *testing.T) {
+		results := appv1.ResourceResults{
+			{Group: "batch", Kind: "Job", Namespace: "default", Name: "migrate", HookType: synccommon.HookTypePreSync, HookPhase: synccommon.OperationFailed, SyncPhase: synccommon.SyncPhasePreSync},
+			{Kind: "Pod", Namespace: "default", Name: "smoke-test", HookType: synccommon.HookTypePostSync, HookPhase: synccommon.OperationRunning, SyncPhase: synccommon.SyncPhasePostSync},
+			{Kind: "ConfigMap", Namespace: "default", Name: "settings", Status: synccommon.ResultCodeSynced, HookPhase: synccommon.OperationSucceeded, SyncPhase: synccommon.SyncPhaseSync},
+		}
+		var captured [][]appv1.HookOutput
+		captureHookOutputs(t.Context(), results, nil, newKubeClientset, func(_ kubernetes.Interface, namespace string, outputs []appv1.HookOutput) {
+			assert.Equal(t, "default", namespace)
+			captured = append(captured, outputs)
+		})
+		expected := []appv1.HookOutput{
+			{Pod: "migrate-2", Container: "main", Reason: "Error", ExitCode: 2},
+			{Pod: "migrate-1", Container: "main", Reason: "Error", ExitCode: 1},
+		}
+		assert.Equal(t, expected, results[0].HookOutputs)
+		assert.Equal(t, [][]appv1.HookOutput{expected}, captured)
+		assert.Empty(t, results[1].HookOutputs)
+		assert.Empty(t, results[2].HookOutputs)
+	})
+
+	t.Run("output kept from previous results", func(t *testing.T) {
+		previous := appv1.ResourceResults{{
+			Kind: "Pod", Namespace: "default", Name: "smoke-test", SyncPhase: synccommon.SyncPhasePostSync,
+			HookOutputs: []appv1.HookOutput{{Pod: "smoke-test", Container: "main", Reason: "Completed"}},
+		}}
+		results := appv1.ResourceResults{
+			{Kind: "Pod", Namespace: "default", Name: "smoke-test", HookType: synccommon.HookTypePostSync, HookPhase: synccommon.OperationSucceeded, SyncPhase: synccommon.SyncPhasePostSync},
+		}
+		captureHookOutputs(t.Context(), results, previous, func() (kubernetes.Interface, error) {
+			require.FailNow(t, "the output must not be captured again")
+			return nil, nil
+		}, func(kubernetes.Interface, string, []appv1.HookOutput) {
+			require.FailNow(t, "the logs must not be captured again")
+		})
+		assert.Equal(t, previous[0].HookOutputs, results[0].HookOutputs)
+	})
+}
+
+func TestCaptureHookLogs(t *testing.T) {
+	ctrl := newFakeController(&fakeData{}, nil)
+	manager := ctrl.appStateManager.(*appStateManager)
+	clientset := fake.NewClientset(newTestHookPod("migrate-1", map[string]string{"job-name": "migrate"}, time.Now(), 1))
+
+	manager.captureHookLogs(clientset, "my-app", "default", []appv1.HookOutput{{Pod: "migrate-1", Container: "main"}}, 4096)
+
+	var logs string
+	require.NoError(t, manager.cache.GetHookLogs("my-app", "default", "migrate-1", "main", &logs))
+	assert.Equal(t, "fake logs", logs)
+}
+
+func TestTruncateHookLogs(t *testing.T) {
+	assert.Equal(t, "line 1\nline 2\n", truncateHookLogs("line 1\nline 2\n", 100))
+	assert.Equal(t, "line 3\n", truncateHookLogs("line 1\nline 2\nline 3\n", 10))
+}
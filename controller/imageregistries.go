@@ -0,0 +1,75 @@
+package controller
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+)
+
+// podSpecContainerFields are the fields of a pod spec listing containers
+var podSpecContainerFields = []string{"initContainers", "containers", "ephemeralContainers"}
+
+// imageRegistryConditions returns the conditions reporting the manifests referencing container images which aren't
+// allowed by the image registries of the project
+func imageRegistryConditions(project *v1alpha1.AppProject, targetObjs []*unstructured.Unstructured, now *metav1.Time) []v1alpha1.ApplicationCondition {
+	if len(project.Spec.AllowedImageRegistries) == 0 {
+		return nil
+	}
+	var conditions []v1alpha1.ApplicationCondition
+	for _, obj := range targetObjs {
+		if obj == nil {
+			continue
+		}
+		var disallowed []string
+		for _, image := range containerImages(obj) {
+			if !project.IsImagePermitted(image) && !slices.Contains(disallowed, image) {
+				disallowed = append(disallowed, image)
+			}
+		}
+		if len(disallowed) == 0 {
+			continue
+		}
+		name := obj.GetName()
+		if obj.GetNamespace() != "" {
+			name = obj.GetNamespace() + "/" + name
+		}
+		conditions = append(conditions, v1alpha1.ApplicationCondition{
+			Type:               v1alpha1.ApplicationConditionImageRegistryError,
+			Message:            fmt.Sprintf("%s %s references images of registries not allowed by project %s: %s", obj.GetKind(), name, project.Name, strings.Join(disallowed, ", ")),
+			LastTransitionTime: now,
+		})
+	}
+	return conditions
+}
+
+// containerImages returns the images of the containers of the pod spec of the given workload, which is either a Pod,
+// a CronJob, or a resource with a pod template such as a Deployment
+func containerImages(obj *unstructured.Unstructured) []string {
+	podSpecPath := []string{"spec", "template", "spec"}
+	switch {
+	case obj.GroupVersionKind().Group == "" && obj.GetKind() == "Pod":
+		podSpecPath = []string{"spec"}
+	case obj.GroupVersionKind().Group == "batch" && obj.GetKind() == "CronJob":
+		podSpecPath = []string{"spec", "jobTemplate", "spec", "template", "spec"}
+	}
+	podSpec, ok, err := unstructured.NestedMap(obj.Object, podSpecPath...)
+	if !ok || err != nil {
+		return nil
+	}
+	var images []string
+	for _, field := range podSpecContainerFields {
+		containers, _ := podSpec[field].([]any)
+		for _, container := range containers {
+			container, _ := container.(map[string]any)
+			if image, _ := container["image"].(string); image != "" {
+				images = append(images, image)
+			}
+		}
+	}
+	return images
+}
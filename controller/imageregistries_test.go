@@ -0,0 +1,50 @@
+package controller
+
+import (
+	"testing"
+
+	. "github.com/argoproj/gitops-engine/pkg/utils/testing"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	appv1 "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	"github.com/argoproj/argo-cd/v3/test"
+)
+
+func TestContainerImages(t *testing.T) {
+	pod := NewPod()
+	assert.Equal(t, []string{"nginx:1.7.9"}, containerImages(pod))
+
+	deployment := test.NewDeployment()
+	assert.Equal(t, []string{"nginx:1.15.4"}, containerImages(deployment))
+
+	cronJob := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "batch/v1",
+		"kind":       "CronJob",
+		"spec": map[string]any{"jobTemplate": map[string]any{"spec": map[string]any{"template": map[string]any{"spec": map[string]any{
+			"initContainers": []any{map[string]any{"name": "init", "image": "busybox"}},
+			"containers":     []any{map[string]any{"name": "job", "image": "registry.example.com/job:1"}},
+		}}}}},
+	}}
+	assert.Equal(t, []string{"busybox", "registry.example.com/job:1"}, containerImages(cronJob))
+
+	assert.Empty(t, containerImages(NewService()))
+}
+
+func TestImageRegistryConditions(t *testing.T) {
+	now := metav1.Now()
+	targetObjs := []*unstructured.Unstructured{NewPod(), NewService()}
+	project := &appv1.AppProject{ObjectMeta: metav1.ObjectMeta{Name: "default"}}
+	assert.Empty(t, imageRegistryConditions(project, targetObjs, &now))
+
+	project.Spec.AllowedImageRegistries = []string{"registry.example.com"}
+	assert.Equal(t, []appv1.ApplicationCondition{{
+		Type:               appv1.ApplicationConditionImageRegistryError,
+		Message:            "Pod my-pod references images of registries not allowed by project default: nginx:1.7.9",
+		LastTransitionTime: &now,
+	}}, imageRegistryConditions(project, targetObjs, &now))
+
+	project.Spec.AllowedImageRegistries = []string{"docker.io/library"}
+	assert.Empty(t, imageRegistryConditions(project, targetObjs, &now))
+}
@@ -0,0 +1,99 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/semaphore"
+	"google.golang.org/grpc"
+
+	"github.com/argoproj/argo-cd/v3/reposerver/apiclient"
+	utilio "github.com/argoproj/argo-cd/v3/util/io"
+)
+
+// manifestGenerationDeferDelay is the delay after which the reconciliation or the sync of an application, whose manifest
+// generation was deferred because of the concurrency limit of its project, is retried
+const manifestGenerationDeferDelay = 5 * time.Second
+
+// errManifestGenerationDeferred is returned by the manifest generation requests exceeding the concurrency limit of their
+// project
+var errManifestGenerationDeferred = errors.New("manifest generation deferred by the concurrency limit of the project")
+
+// manifestGenerationDeferredMessage is the message of the sync operations deferred because of the concurrency limit of
+// the manifest generation requests of their project
+const manifestGenerationDeferredMessage = "Waiting for the manifest generation concurrency limit of the project"
+
+// manifestGenerationMetrics reports the manifest generation requests deferred because of the concurrency limit of their
+// project
+type manifestGenerationMetrics interface {
+	IncManifestGenerationDeferred(project string)
+}
+
+// projectLimitedRepoClientset is a repo server clientset limiting the number of concurrent manifest generation requests
+// of each project, so that the churn of the applications of a project can't use the whole capacity of the repo server.
+// The requests exceeding the limit fail with errManifestGenerationDeferred instead of waiting, so that they don't
+// block the processors of the controller: the reconciliation or the sync of the application is retried later.
+type projectLimitedRepoClientset struct {
+	apiclient.Clientset
+	limit      int64
+	metrics    manifestGenerationMetrics
+	lock       sync.Mutex
+	semaphores map[string]*semaphore.Weighted
+}
+
+func newProjectLimitedRepoClientset(clientset apiclient.Clientset, limit int64, metrics manifestGenerationMetrics) *projectLimitedRepoClientset {
+	return &projectLimitedRepoClientset{
+		Clientset:  clientset,
+		limit:      limit,
+		metrics:    metrics,
+		semaphores: map[string]*semaphore.Weighted{},
+	}
+}
+
+func (c *projectLimitedRepoClientset) NewRepoServerClient() (utilio.Closer, apiclient.RepoServerServiceClient, error) {
+	conn, client, err := c.Clientset.NewRepoServerClient()
+	if err != nil {
+		return nil, nil, err
+	}
+	return conn, &projectLimitedRepoClient{RepoServerServiceClient: client, clientset: c}, nil
+}
+
+func (c *projectLimitedRepoClientset) getSemaphore(project string) *semaphore.Weighted {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	sem, ok := c.semaphores[project]
+	if !ok {
+		sem = semaphore.NewWeighted(c.limit)
+		c.semaphores[project] = sem
+	}
+	return sem
+}
+
+// tryAcquire returns the function to call once a manifest generation request of the project completes, or
+// errManifestGenerationDeferred if the request isn't allowed by the concurrency limit of the project
+func (c *projectLimitedRepoClientset) tryAcquire(project string) (func(), error) {
+	sem := c.getSemaphore(project)
+	if !sem.TryAcquire(1) {
+		c.metrics.IncManifestGenerationDeferred(project)
+		return nil, fmt.Errorf("%w %q", errManifestGenerationDeferred, project)
+	}
+	return func() { sem.Release(1) }, nil
+}
+
+// projectLimitedRepoClient is a repo server client enforcing the manifest generation concurrency limit of the projects
+type projectLimitedRepoClient struct {
+	apiclient.RepoServerServiceClient
+	clientset *projectLimitedRepoClientset
+}
+
+func (c *projectLimitedRepoClient) GenerateManifest(ctx context.Context, in *apiclient.ManifestRequest, opts ...grpc.CallOption) (*apiclient.ManifestResponse, error) {
+	release, err := c.clientset.tryAcquire(in.ProjectName)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return c.RepoServerServiceClient.GenerateManifest(ctx, in, opts...)
+}
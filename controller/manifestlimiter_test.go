@@ -0,0 +1,84 @@
+package controller
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	"github.com/argoproj/argo-cd/v3/reposerver/apiclient"
+	mockrepoclient "github.com/argoproj/argo-cd/v3/reposerver/apiclient/mocks"
+)
+
+type fakeManifestGenerationMetrics struct {
+	lock     sync.Mutex
+	deferred map[string]int
+}
+
+func (m *fakeManifestGenerationMetrics) IncManifestGenerationDeferred(project string) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.deferred[project]++
+}
+
+func TestProjectLimitedRepoClientset(t *testing.T) {
+	metrics := &fakeManifestGenerationMetrics{deferred: map[string]int{}}
+	repoClient := &mockrepoclient.RepoServerServiceClient{}
+	repoClient.On("GenerateManifest", mock.Anything, mock.Anything).Return(&apiclient.ManifestResponse{Revision: "abc"}, nil)
+	clientset := newProjectLimitedRepoClientset(&mockrepoclient.Clientset{RepoServerServiceClient: repoClient}, 1, metrics)
+
+	t.Run("requests beyond the limit of the project are deferred", func(t *testing.T) {
+		release, err := clientset.tryAcquire("team-a")
+		require.NoError(t, err)
+
+		_, err = clientset.tryAcquire("team-a")
+		require.ErrorIs(t, err, errManifestGenerationDeferred)
+		require.ErrorContains(t, err, `"team-a"`)
+
+		// the requests of the other projects are not limited by the requests of the project
+		releaseOther, err := clientset.tryAcquire("team-b")
+		require.NoError(t, err)
+		releaseOther()
+
+		release()
+		release, err = clientset.tryAcquire("team-a")
+		require.NoError(t, err)
+		release()
+
+		assert.Equal(t, map[string]int{"team-a": 1}, metrics.deferred)
+	})
+
+	t.Run("manifest generation requests are limited", func(t *testing.T) {
+		_, client, err := clientset.NewRepoServerClient()
+		require.NoError(t, err)
+		release, err := clientset.tryAcquire("team-c")
+		require.NoError(t, err)
+
+		_, err = client.GenerateManifest(t.Context(), &apiclient.ManifestRequest{ProjectName: "team-c"})
+		require.ErrorIs(t, err, errManifestGenerationDeferred)
+		repoClient.AssertNotCalled(t, "GenerateManifest", mock.Anything, mock.Anything)
+
+		release()
+		res, err := client.GenerateManifest(t.Context(), &apiclient.ManifestRequest{ProjectName: "team-c"})
+		require.NoError(t, err)
+		assert.Equal(t, "abc", res.Revision)
+		repoClient.AssertNumberOfCalls(t, "GenerateManifest", 1)
+	})
+}
+
+func TestCompareAppState_ManifestGenerationDeferred(t *testing.T) {
+	app := newFakeApp()
+	ctrl := newFakeController(&fakeData{manifestResponses: make([]*apiclient.ManifestResponse, 2)}, fmt.Errorf("%w %q", errManifestGenerationDeferred, "default"))
+	sources := []v1alpha1.ApplicationSource{app.Spec.GetSource()}
+
+	// the comparison is deferred even with a level 3 comparison, without any comparison error
+	for _, noRevisionCache := range []bool{false, true} {
+		compRes, err := ctrl.appStateManager.CompareAppState(app, &defaultProj, []string{""}, sources, false, noRevisionCache, nil, false)
+		assert.Nil(t, compRes)
+		require.ErrorIs(t, err, errManifestGenerationDeferred)
+	}
+}
@@ -0,0 +1,237 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	"github.com/argoproj/argo-cd/v3/util/db"
+	"github.com/argoproj/argo-cd/v3/util/env"
+	utilio "github.com/argoproj/argo-cd/v3/util/io"
+	"github.com/argoproj/argo-cd/v3/util/oci"
+	"github.com/argoproj/argo-cd/v3/util/policy"
+	"github.com/argoproj/argo-cd/v3/util/settings"
+)
+
+const (
+	// EnvManifestPolicyOPAURL is an environment variable containing the URL of the OPA server evaluating the manifest
+	// policies of the projects
+	EnvManifestPolicyOPAURL = "ARGOCD_MANIFEST_POLICY_OPA_URL"
+
+	// manifestPolicyOCIRevisionTTL is how long the digest resolved from the revision of an OCI policy bundle is reused
+	manifestPolicyOCIRevisionTTL = 3 * time.Minute
+	// manifestPolicyOCIMaxExtractedSize is the maximum size of an extracted OCI policy bundle
+	manifestPolicyOCIMaxExtractedSize = 10 * 1024 * 1024
+	// manifestPolicyEvaluationTimeout is the timeout of the evaluation of the manifest policies of an application
+	manifestPolicyEvaluationTimeout = time.Minute
+	// maxManifestPolicyConditions is the maximum number of conditions reported for the violations of a policy
+	maxManifestPolicyConditions = 10
+)
+
+var (
+	manifestPolicyOPAURL = env.StringFromEnv(EnvManifestPolicyOPAURL, "")
+
+	manifestPolicyOCIMediaTypes = []string{"application/vnd.oci.image.layer.v1.tar", "application/vnd.oci.image.layer.v1.tar+gzip"}
+)
+
+// manifestPolicyEvaluator evaluates the manifest policies of the projects against the rendered manifests of their
+// applications, with an OPA server
+type manifestPolicyEvaluator struct {
+	db           db.ArgoDB
+	settingsMgr  *settings.SettingsManager
+	opa          *policy.OPAClient
+	ociPaths     utilio.TempPaths
+	newOCIClient func(repoURL string, creds oci.Creds, proxy, noProxy string, layerMediaTypes []string, opts ...oci.ClientOpts) (oci.Client, error)
+
+	lock         sync.Mutex
+	ociRevisions map[string]resolvedOCIRevision
+	ociBundles   map[string]*policy.Bundle
+}
+
+type resolvedOCIRevision struct {
+	digest     string
+	resolvedAt time.Time
+}
+
+func newManifestPolicyEvaluator(db db.ArgoDB, settingsMgr *settings.SettingsManager, opaURL string) *manifestPolicyEvaluator {
+	e := &manifestPolicyEvaluator{
+		db:           db,
+		settingsMgr:  settingsMgr,
+		ociPaths:     utilio.NewRandomizedTempPaths(os.TempDir()),
+		newOCIClient: oci.NewClient,
+		ociRevisions: map[string]resolvedOCIRevision{},
+		ociBundles:   map[string]*policy.Bundle{},
+	}
+	if opaURL != "" {
+		e.opa = policy.NewOPAClient(opaURL)
+	}
+	return e
+}
+
+// evaluate returns the conditions reporting the violations of the manifest policies of the project by the given
+// manifests. The violations of the policies which block the syncs are reported as PolicyViolationError conditions, and
+// the other violations and the warnings as PolicyViolationWarning conditions. A policy which can't be evaluated is
+// reported as violated.
+func (e *manifestPolicyEvaluator) evaluate(project *v1alpha1.AppProject, targetObjs []*unstructured.Unstructured, now metav1.Time) []v1alpha1.ApplicationCondition {
+	if len(project.Spec.ManifestPolicies) == 0 {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), manifestPolicyEvaluationTimeout)
+	defer cancel()
+
+	var conditions []v1alpha1.ApplicationCondition
+	for i := range project.Spec.ManifestPolicies {
+		p := &project.Spec.ManifestPolicies[i]
+		violationType := v1alpha1.ApplicationConditionPolicyViolationError
+		if p.IsWarning() {
+			violationType = v1alpha1.ApplicationConditionPolicyViolationWarning
+		}
+		violations, warnings, err := e.evaluatePolicy(ctx, project, p, targetObjs)
+		if err != nil {
+			conditions = append(conditions, v1alpha1.ApplicationCondition{
+				Type:               violationType,
+				Message:            fmt.Sprintf("Failed to evaluate manifest policy %s: %v", p.Name, err),
+				LastTransitionTime: &now,
+			})
+			continue
+		}
+		conditions = append(conditions, manifestPolicyConditions(violationType, "Manifest policy "+p.Name+" violated by %s", violations, &now)...)
+		conditions = append(conditions, manifestPolicyConditions(v1alpha1.ApplicationConditionPolicyViolationWarning, "Manifest policy "+p.Name+" warns about %s", warnings, &now)...)
+	}
+	return conditions
+}
+
+func (e *manifestPolicyEvaluator) evaluatePolicy(ctx context.Context, project *v1alpha1.AppProject, p *v1alpha1.ManifestPolicy, targetObjs []*unstructured.Unstructured) ([]string, []string, error) {
+	if e.opa == nil {
+		return nil, nil, fmt.Errorf("no OPA server is configured, %s must be set", EnvManifestPolicyOPAURL)
+	}
+	bundle, err := e.loadBundle(ctx, project, p)
+	if err != nil {
+		return nil, nil, err
+	}
+	policyID := strings.Join([]string{"argocd", project.Name, p.Name}, "/")
+	var violations, warnings []string
+	for _, obj := range targetObjs {
+		if obj == nil {
+			continue
+		}
+		result, err := e.opa.Evaluate(ctx, policyID, bundle, p.Package, obj.Object)
+		if err != nil {
+			return nil, nil, err
+		}
+		resource := fmt.Sprintf("%s %s", obj.GetKind(), obj.GetName())
+		if obj.GetNamespace() != "" {
+			resource = fmt.Sprintf("%s %s/%s", obj.GetKind(), obj.GetNamespace(), obj.GetName())
+		}
+		for _, msg := range result.Violations {
+			violations = append(violations, resource+": "+msg)
+		}
+		for _, msg := range result.Warnings {
+			warnings = append(warnings, resource+": "+msg)
+		}
+	}
+	return violations, warnings, nil
+}
+
+// loadBundle returns the Rego modules of the policy, from its ConfigMap or its OCI artifact
+func (e *manifestPolicyEvaluator) loadBundle(ctx context.Context, project *v1alpha1.AppProject, p *v1alpha1.ManifestPolicy) (*policy.Bundle, error) {
+	if p.ConfigMap != "" {
+		cm, err := e.settingsMgr.GetConfigMapByName(p.ConfigMap)
+		if err != nil {
+			return nil, fmt.Errorf("error getting ConfigMap %s: %w", p.ConfigMap, err)
+		}
+		bundle := &policy.Bundle{Modules: map[string]string{}}
+		for key, source := range cm.Data {
+			if strings.HasSuffix(key, policy.RegoFileExtension) {
+				bundle.Modules[key] = source
+			}
+		}
+		if len(bundle.Modules) == 0 {
+			return nil, fmt.Errorf("ConfigMap %s has no Rego module", p.ConfigMap)
+		}
+		return bundle, nil
+	}
+	if p.RepoURL == "" {
+		return nil, errors.New("the policy has neither a ConfigMap nor an OCI repository")
+	}
+
+	repo, err := e.db.GetRepository(ctx, p.RepoURL, project.Name)
+	if err != nil {
+		return nil, fmt.Errorf("error getting repository %s: %w", p.RepoURL, err)
+	}
+	client, err := e.newOCIClient(repo.Repo, repo.GetOCICreds(), repo.GetProxy(), repo.NoProxy, manifestPolicyOCIMediaTypes,
+		oci.WithImagePaths(e.ociPaths), oci.WithManifestMaxExtractedSize(manifestPolicyOCIMaxExtractedSize))
+	if err != nil {
+		return nil, fmt.Errorf("error creating OCI client of %s: %w", p.RepoURL, err)
+	}
+	revision := p.TargetRevision
+	if revision == "" {
+		revision = "latest"
+	}
+	revisionKey := p.RepoURL + "@" + revision
+
+	e.lock.Lock()
+	resolved, ok := e.ociRevisions[revisionKey]
+	e.lock.Unlock()
+	if !ok || time.Since(resolved.resolvedAt) > manifestPolicyOCIRevisionTTL {
+		digest, err := client.ResolveRevision(ctx, revision, false)
+		if err != nil {
+			return nil, fmt.Errorf("error resolving revision %s of %s: %w", revision, p.RepoURL, err)
+		}
+		resolved = resolvedOCIRevision{digest: digest, resolvedAt: time.Now()}
+		e.lock.Lock()
+		e.ociRevisions[revisionKey] = resolved
+		e.lock.Unlock()
+	}
+
+	bundleKey := p.RepoURL + "@" + resolved.digest
+	e.lock.Lock()
+	bundle, ok := e.ociBundles[bundleKey]
+	e.lock.Unlock()
+	if ok {
+		return bundle, nil
+	}
+	dir, closer, err := client.Extract(ctx, resolved.digest)
+	if err != nil {
+		return nil, fmt.Errorf("error extracting %s of %s: %w", resolved.digest, p.RepoURL, err)
+	}
+	defer utilio.Close(closer)
+	bundle, err = policy.LoadBundle(dir)
+	if err != nil {
+		return nil, fmt.Errorf("error loading %s of %s: %w", resolved.digest, p.RepoURL, err)
+	}
+	e.lock.Lock()
+	e.ociBundles[bundleKey] = bundle
+	e.lock.Unlock()
+	return bundle, nil
+}
+
+// manifestPolicyConditions returns the conditions of the given violation or warning messages of a policy, of which
+// only the first ones are reported
+func manifestPolicyConditions(conditionType v1alpha1.ApplicationConditionType, format string, messages []string, now *metav1.Time) []v1alpha1.ApplicationCondition {
+	var conditions []v1alpha1.ApplicationCondition
+	for i, msg := range messages {
+		if i == maxManifestPolicyConditions {
+			conditions = append(conditions, v1alpha1.ApplicationCondition{
+				Type:               conditionType,
+				Message:            fmt.Sprintf(format, fmt.Sprintf("%d more resources", len(messages)-i)),
+				LastTransitionTime: now,
+			})
+			break
+		}
+		conditions = append(conditions, v1alpha1.ApplicationCondition{
+			Type:               conditionType,
+			Message:            fmt.Sprintf(format, msg),
+			LastTransitionTime: now,
+		})
+	}
+	return conditions
+}
@@ -0,0 +1,142 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/kubernetes/fake"
+
+	appv1 "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	"github.com/argoproj/argo-cd/v3/util/settings"
+)
+
+// newFakeOPAServer returns an OPA server whose main package denies the manifests without a team label and warns about
+// the manifests without an owner annotation
+func newFakeOPAServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// the packages of the policies are namespaced by policy
+		if r.Method != http.MethodPost || !strings.HasPrefix(r.URL.Path, "/v1/data/argocd_policies/") || !strings.HasSuffix(r.URL.Path, "/main") {
+			_, _ = w.Write([]byte(`{}`))
+			return
+		}
+		var req struct {
+			Input struct {
+				Metadata struct {
+					Labels      map[string]string `json:"labels"`
+					Annotations map[string]string `json:"annotations"`
+				} `json:"metadata"`
+			} `json:"input"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		result := map[string]any{"deny": []any{}, "warn": []any{}}
+		if req.Input.Metadata.Labels["team"] == "" {
+			result["deny"] = []any{"missing team label"}
+		}
+		if req.Input.Metadata.Annotations["owner"] == "" {
+			result["warn"] = []any{"missing owner annotation"}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"result": result})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newManifestPolicyTestObj(name string, labels map[string]string, annotations map[string]string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion("v1")
+	obj.SetKind("ConfigMap")
+	obj.SetNamespace("default")
+	obj.SetName(name)
+	obj.SetLabels(labels)
+	obj.SetAnnotations(annotations)
+	return obj
+}
+
+func TestManifestPolicyEvaluator_Evaluate(t *testing.T) {
+	opa := newFakeOPAServer(t)
+	clientset := fake.NewClientset(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "labels-policy",
+			Namespace: "argocd",
+			Labels:    map[string]string{"app.kubernetes.io/part-of": "argocd"},
+		},
+		Data: map[string]string{"main.rego": "package main", "README.md": "# labels"},
+	})
+	settingsMgr := settings.NewSettingsManager(t.Context(), clientset, "argocd")
+	now := metav1.Now()
+	targetObjs := []*unstructured.Unstructured{
+		newManifestPolicyTestObj("compliant", map[string]string{"team": "a"}, map[string]string{"owner": "b"}),
+		newManifestPolicyTestObj("unlabeled", nil, map[string]string{"owner": "b"}),
+		newManifestPolicyTestObj("unannotated", map[string]string{"team": "a"}, nil),
+	}
+	newProject := func(policies ...appv1.ManifestPolicy) *appv1.AppProject {
+		return &appv1.AppProject{
+			ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "argocd"},
+			Spec:       appv1.AppProjectSpec{ManifestPolicies: policies},
+		}
+	}
+
+	t.Run("no policies", func(t *testing.T) {
+		evaluator := newManifestPolicyEvaluator(nil, settingsMgr, "")
+		assert.Empty(t, evaluator.evaluate(newProject(), targetObjs, now))
+	})
+
+	t.Run("blocking policy", func(t *testing.T) {
+		evaluator := newManifestPolicyEvaluator(nil, settingsMgr, opa.URL)
+		conditions := evaluator.evaluate(newProject(appv1.ManifestPolicy{Name: "labels", ConfigMap: "labels-policy", Package: "main"}), targetObjs, now)
+		assert.Equal(t, []appv1.ApplicationCondition{{
+			Type:               appv1.ApplicationConditionPolicyViolationError,
+			Message:            "Manifest policy labels violated by ConfigMap default/unlabeled: missing team label",
+			LastTransitionTime: &now,
+		}, {
+			Type:               appv1.ApplicationConditionPolicyViolationWarning,
+			Message:            "Manifest policy labels warns about ConfigMap default/unannotated: missing owner annotation",
+			LastTransitionTime: &now,
+		}}, conditions)
+	})
+
+	t.Run("warning policy", func(t *testing.T) {
+		evaluator := newManifestPolicyEvaluator(nil, settingsMgr, opa.URL)
+		conditions := evaluator.evaluate(newProject(appv1.ManifestPolicy{Name: "labels", ConfigMap: "labels-policy", Package: "main", Enforcement: appv1.ManifestPolicyEnforcementWarn}), targetObjs, now)
+		require.Len(t, conditions, 2)
+		for _, condition := range conditions {
+			assert.Equal(t, appv1.ApplicationConditionPolicyViolationWarning, condition.Type)
+		}
+	})
+
+	t.Run("too many violations", func(t *testing.T) {
+		var objs []*unstructured.Unstructured
+		for i := 0; i < maxManifestPolicyConditions+3; i++ {
+			objs = append(objs, newManifestPolicyTestObj(fmt.Sprintf("cm-%02d", i), nil, map[string]string{"owner": "b"}))
+		}
+		evaluator := newManifestPolicyEvaluator(nil, settingsMgr, opa.URL)
+		conditions := evaluator.evaluate(newProject(appv1.ManifestPolicy{Name: "labels", ConfigMap: "labels-policy", Package: "main"}), objs, now)
+		require.Len(t, conditions, maxManifestPolicyConditions+1)
+		assert.Equal(t, "Manifest policy labels violated by 3 more resources", conditions[maxManifestPolicyConditions].Message)
+	})
+
+	t.Run("policy that can't be evaluated", func(t *testing.T) {
+		evaluator := newManifestPolicyEvaluator(nil, settingsMgr, opa.URL)
+		conditions := evaluator.evaluate(newProject(appv1.ManifestPolicy{Name: "labels", ConfigMap: "missing", Package: "main"}), targetObjs, now)
+		require.Len(t, conditions, 1)
+		assert.Equal(t, appv1.ApplicationConditionPolicyViolationError, conditions[0].Type)
+		assert.Contains(t, conditions[0].Message, "Failed to evaluate manifest policy labels: error getting ConfigMap missing")
+	})
+
+	t.Run("no OPA server", func(t *testing.T) {
+		evaluator := newManifestPolicyEvaluator(nil, settingsMgr, "")
+		conditions := evaluator.evaluate(newProject(appv1.ManifestPolicy{Name: "labels", ConfigMap: "labels-policy", Package: "main"}), targetObjs, now)
+		require.Len(t, conditions, 1)
+		assert.Equal(t, "Failed to evaluate manifest policy labels: no OPA server is configured, ARGOCD_MANIFEST_POLICY_OPA_URL must be set", conditions[0].Message)
+	})
+}
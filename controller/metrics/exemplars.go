@@ -0,0 +1,43 @@
+package metrics
+
+import (
+	"context"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// traceIDExemplarLabel is the exemplar label holding the ID of the trace of an observation
+const traceIDExemplarLabel = "trace_id"
+
+// exemplarLabels returns the exemplar labels linking an observation to the trace of the context, or nil if the context
+// has no sampled span, e.g. when tracing is disabled
+func exemplarLabels(ctx context.Context) prometheus.Labels {
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.IsValid() || !spanContext.IsSampled() {
+		return nil
+	}
+	return prometheus.Labels{traceIDExemplarLabel: spanContext.TraceID().String()}
+}
+
+// observeWithExemplar observes a value, with an exemplar linking it to the trace of the context if there is one
+func observeWithExemplar(ctx context.Context, observer prometheus.Observer, value float64) {
+	if labels := exemplarLabels(ctx); labels != nil {
+		if exemplarObserver, ok := observer.(prometheus.ExemplarObserver); ok {
+			exemplarObserver.ObserveWithExemplar(value, labels)
+			return
+		}
+	}
+	observer.Observe(value)
+}
+
+// addWithExemplar adds a value to a counter, with an exemplar linking it to the trace of the context if there is one
+func addWithExemplar(ctx context.Context, counter prometheus.Counter, value float64) {
+	if labels := exemplarLabels(ctx); labels != nil {
+		if exemplarAdder, ok := counter.(prometheus.ExemplarAdder); ok {
+			exemplarAdder.AddWithExemplar(value, labels)
+			return
+		}
+	}
+	counter.Add(value)
+}
@@ -0,0 +1,177 @@
+package metrics
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+	"sync/atomic"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	// allMetrics selects all metrics in label rules
+	allMetrics = "*"
+	// hashedLabelLength is the number of hexadecimal characters kept from the hash of a label value
+	hashedLabelLength = 12
+)
+
+// projectAggregatedLabels are the labels dropped from the metrics aggregated at the project level
+var projectAggregatedLabels = []string{"namespace", "name"}
+
+// LabelRules reduces the cardinality of the exposed metrics by dropping or hashing labels of selected metrics, and by
+// aggregating selected metrics at the project level. Series whose labels become identical are merged by summing
+// their values.
+type LabelRules struct {
+	dropLabels        map[string][]string
+	hashLabels        map[string][]string
+	projectAggregated map[string]bool
+}
+
+// parseMetricLabels parses a list of <metric>:<label> pairs, where the metric may be * to select all metrics
+func parseMetricLabels(pairs []string) (map[string][]string, error) {
+	res := map[string][]string{}
+	for _, pair := range pairs {
+		metric, label, ok := strings.Cut(pair, ":")
+		if !ok || metric == "" || label == "" {
+			return nil, fmt.Errorf("invalid metric label '%s', must be <metric>:<label>", pair)
+		}
+		res[metric] = append(res[metric], label)
+	}
+	return res, nil
+}
+
+// NewLabelRules returns the label rules dropping and hashing the given <metric>:<label> pairs, and aggregating the
+// given metrics at the project level by dropping their namespace and name labels. It returns nil if there are no
+// rules.
+func NewLabelRules(dropLabels []string, hashLabels []string, projectAggregatedMetrics []string) (*LabelRules, error) {
+	if len(dropLabels) == 0 && len(hashLabels) == 0 && len(projectAggregatedMetrics) == 0 {
+		return nil, nil
+	}
+	drop, err := parseMetricLabels(dropLabels)
+	if err != nil {
+		return nil, err
+	}
+	hash, err := parseMetricLabels(hashLabels)
+	if err != nil {
+		return nil, err
+	}
+	rules := &LabelRules{dropLabels: drop, hashLabels: hash, projectAggregated: map[string]bool{}}
+	for _, metric := range projectAggregatedMetrics {
+		rules.projectAggregated[metric] = true
+	}
+	return rules, nil
+}
+
+func hashLabelValue(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])[:hashedLabelLength]
+}
+
+// apply applies the rules to the series of a metric family
+func (r *LabelRules) apply(family *dto.MetricFamily) {
+	name := family.GetName()
+	drop := append(slices.Clone(r.dropLabels[name]), r.dropLabels[allMetrics]...)
+	if r.projectAggregated[name] || r.projectAggregated[allMetrics] {
+		drop = append(drop, projectAggregatedLabels...)
+	}
+	hash := append(slices.Clone(r.hashLabels[name]), r.hashLabels[allMetrics]...)
+	if len(drop) == 0 && len(hash) == 0 {
+		return
+	}
+
+	var metrics []*dto.Metric
+	seriesIndex := map[string]int{}
+	for _, metric := range family.Metric {
+		labels := make([]*dto.LabelPair, 0, len(metric.Label))
+		var key strings.Builder
+		for _, label := range metric.Label {
+			if slices.Contains(drop, label.GetName()) {
+				continue
+			}
+			if slices.Contains(hash, label.GetName()) {
+				label = &dto.LabelPair{Name: label.Name, Value: ptr.To(hashLabelValue(label.GetValue()))}
+			}
+			labels = append(labels, label)
+			key.WriteString(label.GetName() + "=" + label.GetValue() + "\xff")
+		}
+		metric.Label = labels
+		if i, ok := seriesIndex[key.String()]; ok {
+			mergeMetric(metrics[i], metric)
+			continue
+		}
+		seriesIndex[key.String()] = len(metrics)
+		metrics = append(metrics, metric)
+	}
+	// the series are sorted by their labels like the series gathered by the registries
+	slices.SortFunc(metrics, compareMetricLabels)
+	family.Metric = metrics
+}
+
+// compareMetricLabels compares the label values of two series of a metric family, which have the same label names
+func compareMetricLabels(a, b *dto.Metric) int {
+	for i := 0; i < len(a.Label) && i < len(b.Label); i++ {
+		if c := strings.Compare(a.Label[i].GetValue(), b.Label[i].GetValue()); c != 0 {
+			return c
+		}
+	}
+	return len(a.Label) - len(b.Label)
+}
+
+// mergeMetric adds the values of a series to another series with the same labels
+func mergeMetric(into *dto.Metric, from *dto.Metric) {
+	// the timestamps and exemplars of merged series no longer describe a single series
+	into.TimestampMs = nil
+	switch {
+	case into.Counter != nil && from.Counter != nil:
+		into.Counter = &dto.Counter{Value: ptr.To(into.Counter.GetValue() + from.Counter.GetValue())}
+	case into.Gauge != nil && from.Gauge != nil:
+		into.Gauge.Value = ptr.To(into.Gauge.GetValue() + from.Gauge.GetValue())
+	case into.Untyped != nil && from.Untyped != nil:
+		into.Untyped.Value = ptr.To(into.Untyped.GetValue() + from.Untyped.GetValue())
+	case into.Histogram != nil && from.Histogram != nil:
+		histogram := &dto.Histogram{
+			SampleCount: ptr.To(into.Histogram.GetSampleCount() + from.Histogram.GetSampleCount()),
+			SampleSum:   ptr.To(into.Histogram.GetSampleSum() + from.Histogram.GetSampleSum()),
+		}
+		for _, bucket := range into.Histogram.Bucket {
+			count := bucket.GetCumulativeCount()
+			for _, fromBucket := range from.Histogram.Bucket {
+				if fromBucket.GetUpperBound() == bucket.GetUpperBound() {
+					count += fromBucket.GetCumulativeCount()
+				}
+			}
+			histogram.Bucket = append(histogram.Bucket, &dto.Bucket{UpperBound: bucket.UpperBound, CumulativeCount: ptr.To(count)})
+		}
+		into.Histogram = histogram
+	case into.Summary != nil && from.Summary != nil:
+		// quantiles cannot be merged, only the count and sum are kept
+		into.Summary = &dto.Summary{
+			SampleCount: ptr.To(into.Summary.GetSampleCount() + from.Summary.GetSampleCount()),
+			SampleSum:   ptr.To(into.Summary.GetSampleSum() + from.Summary.GetSampleSum()),
+		}
+	}
+}
+
+// labelRulesGatherer applies label rules to the metrics of a gatherer
+type labelRulesGatherer struct {
+	gatherer prometheus.Gatherer
+	rules    atomic.Pointer[LabelRules]
+}
+
+// Gather implements the prometheus.Gatherer interface
+func (g *labelRulesGatherer) Gather() ([]*dto.MetricFamily, error) {
+	families, err := g.gatherer.Gather()
+	rules := g.rules.Load()
+	if rules == nil {
+		return families, err
+	}
+	for _, family := range families {
+		rules.apply(family)
+	}
+	return families, err
+}
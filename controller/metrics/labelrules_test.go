@@ -0,0 +1,103 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewLabelRules(t *testing.T) {
+	rules, err := NewLabelRules(nil, nil, nil)
+	require.NoError(t, err)
+	assert.Nil(t, rules)
+
+	rules, err = NewLabelRules([]string{"argocd_app_info:repo", "*:dest_server"}, []string{"argocd_app_info:name"}, []string{"argocd_app_sync_total"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]string{"argocd_app_info": {"repo"}, "*": {"dest_server"}}, rules.dropLabels)
+	assert.Equal(t, map[string][]string{"argocd_app_info": {"name"}}, rules.hashLabels)
+	assert.True(t, rules.projectAggregated["argocd_app_sync_total"])
+
+	_, err = NewLabelRules([]string{"argocd_app_info"}, nil, nil)
+	require.EqualError(t, err, "invalid metric label 'argocd_app_info', must be <metric>:<label>")
+}
+
+func newLabelRulesTestRegistry(t *testing.T) *prometheus.Registry {
+	t.Helper()
+	registry := prometheus.NewRegistry()
+	counter := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "argocd_app_sync_total", Help: "Number of application syncs."}, []string{"namespace", "name", "project", "phase"})
+	counter.WithLabelValues("argocd", "app-1", "team-a", "Succeeded").Add(2)
+	counter.WithLabelValues("argocd", "app-2", "team-a", "Succeeded").Add(3)
+	counter.WithLabelValues("argocd", "app-3", "team-b", "Failed").Inc()
+	histogram := prometheus.NewHistogramVec(prometheus.HistogramOpts{Name: "argocd_app_reconcile", Help: "Application reconciliation performance in seconds.", Buckets: []float64{1, 2}}, []string{"namespace", "dest_server"})
+	histogram.WithLabelValues("argocd", "https://cluster-1").Observe(0.5)
+	histogram.WithLabelValues("argocd", "https://cluster-2").Observe(1.5)
+	require.NoError(t, registry.Register(counter))
+	require.NoError(t, registry.Register(histogram))
+	return registry
+}
+
+func TestLabelRulesGatherer(t *testing.T) {
+	t.Run("NoRules", func(t *testing.T) {
+		gatherer := &labelRulesGatherer{gatherer: newLabelRulesTestRegistry(t)}
+		count, err := testutil.GatherAndCount(gatherer, "argocd_app_sync_total")
+		require.NoError(t, err)
+		assert.Equal(t, 3, count)
+	})
+	t.Run("ProjectAggregated", func(t *testing.T) {
+		gatherer := &labelRulesGatherer{gatherer: newLabelRulesTestRegistry(t)}
+		rules, err := NewLabelRules(nil, nil, []string{"argocd_app_sync_total"})
+		require.NoError(t, err)
+		gatherer.rules.Store(rules)
+		err = testutil.GatherAndCompare(gatherer, strings.NewReader(`
+# HELP argocd_app_sync_total Number of application syncs.
+# TYPE argocd_app_sync_total counter
+argocd_app_sync_total{phase="Succeeded",project="team-a"} 5
+argocd_app_sync_total{phase="Failed",project="team-b"} 1
+`), "argocd_app_sync_total")
+		require.NoError(t, err)
+	})
+	t.Run("DropHistogramLabel", func(t *testing.T) {
+		gatherer := &labelRulesGatherer{gatherer: newLabelRulesTestRegistry(t)}
+		rules, err := NewLabelRules([]string{"*:dest_server"}, nil, nil)
+		require.NoError(t, err)
+		gatherer.rules.Store(rules)
+		err = testutil.GatherAndCompare(gatherer, strings.NewReader(`
+# HELP argocd_app_reconcile Application reconciliation performance in seconds.
+# TYPE argocd_app_reconcile histogram
+argocd_app_reconcile_bucket{namespace="argocd",le="1"} 1
+argocd_app_reconcile_bucket{namespace="argocd",le="2"} 2
+argocd_app_reconcile_bucket{namespace="argocd",le="+Inf"} 2
+argocd_app_reconcile_sum{namespace="argocd"} 2
+argocd_app_reconcile_count{namespace="argocd"} 2
+`), "argocd_app_reconcile")
+		require.NoError(t, err)
+	})
+	t.Run("HashLabel", func(t *testing.T) {
+		gatherer := &labelRulesGatherer{gatherer: newLabelRulesTestRegistry(t)}
+		rules, err := NewLabelRules(nil, []string{"argocd_app_sync_total:name"}, nil)
+		require.NoError(t, err)
+		gatherer.rules.Store(rules)
+		families, err := gatherer.Gather()
+		require.NoError(t, err)
+		for _, family := range families {
+			if family.GetName() != "argocd_app_sync_total" {
+				continue
+			}
+			require.Len(t, family.Metric, 3)
+			for _, metric := range family.Metric {
+				for _, label := range metric.Label {
+					if label.GetName() == "name" {
+						assert.Len(t, label.GetValue(), hashedLabelLength)
+						assert.NotContains(t, label.GetValue(), "app-")
+					}
+				}
+			}
+		}
+		assert.Equal(t, hashLabelValue("app-1"), hashLabelValue("app-1"))
+		assert.NotEqual(t, hashLabelValue("app-1"), hashLabelValue("app-2"))
+	})
+}
@@ -4,25 +4,33 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"net/http"
 	"os"
 	"slices"
 	"strconv"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/argoproj/gitops-engine/pkg/health"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/redis/go-redis/v9"
 	"github.com/robfig/cron/v3"
 	log "github.com/sirupsen/logrus"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
 
 	"github.com/argoproj/argo-cd/v3/common"
 	argoappv1 "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
 	applister "github.com/argoproj/argo-cd/v3/pkg/client/listers/application/v1alpha1"
+	repoapiclient "github.com/argoproj/argo-cd/v3/reposerver/apiclient"
 	"github.com/argoproj/argo-cd/v3/util/argo"
+	cacheutil "github.com/argoproj/argo-cd/v3/util/cache"
 	"github.com/argoproj/argo-cd/v3/util/db"
+	"github.com/argoproj/argo-cd/v3/util/fips"
 	"github.com/argoproj/argo-cd/v3/util/git"
 	"github.com/argoproj/argo-cd/v3/util/healthz"
 	metricsutil "github.com/argoproj/argo-cd/v3/util/metrics"
@@ -32,6 +40,7 @@ import (
 
 type MetricsServer struct {
 	*http.Server
+	mux                               *http.ServeMux
 	syncCounter                       *prometheus.CounterVec
 	syncDuration                      *prometheus.CounterVec
 	kubectlExecCounter                *prometheus.CounterVec
@@ -44,7 +53,24 @@ type MetricsServer struct {
 	redisRequestHistogram             *prometheus.HistogramVec
 	resourceEventsProcessingHistogram *prometheus.HistogramVec
 	resourceEventsNumberGauge         *prometheus.GaugeVec
+	workqueueDepthGauge               *prometheus.GaugeVec
+	workqueueAddsCounter              *prometheus.CounterVec
+	workqueueRetriesCounter           *prometheus.CounterVec
+	reconcileLatencyHistogram         *prometheus.HistogramVec
+	manifestGenDeferredCounter        *prometheus.CounterVec
+	dryRunHistogram                   *prometheus.HistogramVec
+	cacheRequestCounter               *prometheus.CounterVec
+	cacheRequestHistogram             *prometheus.HistogramVec
+	cacheGCEntriesCounter             *prometheus.CounterVec
+	cacheGCFreedBytesCounter          *prometheus.CounterVec
+	repoCredsHealthGauge              *prometheus.GaugeVec
+	repoCredsExpirationGauge          *prometheus.GaugeVec
+	resourceKindCountGauge            *prometheus.GaugeVec
+	statusTransitionsCounter          *prometheus.CounterVec
+	flappingGauge                     *prometheus.GaugeVec
+	resourceKindsLimit                atomic.Int64
 	registry                          *prometheus.Registry
+	gatherer                          *labelRulesGatherer
 	hostname                          string
 	cron                              *cron.Cron
 }
@@ -52,6 +78,9 @@ type MetricsServer struct {
 const (
 	// MetricsPath is the endpoint to collect application metrics
 	MetricsPath = "/metrics"
+	// ResourceKindOther is the kind of the argocd_app_resource_kind_count metric counting the resources of the kinds
+	// beyond the resource kinds limit
+	ResourceKindOther = "other"
 )
 
 // Follow Prometheus naming practices
@@ -69,6 +98,13 @@ var (
 		nil,
 	)
 
+	descAppFrozen = prometheus.NewDesc(
+		"argocd_app_frozen",
+		"Whether the application is frozen, only reported for the frozen applications.",
+		append(descAppDefaultLabels, "no_refresh"),
+		nil,
+	)
+
 	syncCounter = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "argocd_app_sync_total",
@@ -135,6 +171,55 @@ var (
 		[]string{"hostname", "initiator"},
 	)
 
+	cacheRequestCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "argocd_cache_request_total",
+			Help: "Number of cache requests by entry type, operation and result.",
+		},
+		[]string{"hostname", "initiator", "entry_type", "operation", "result"},
+	)
+
+	cacheRequestHistogram = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "argocd_cache_request_duration_seconds",
+			Help:    "Cache requests duration seconds by entry type and operation.",
+			Buckets: []float64{0.005, 0.01, 0.05, 0.1, 0.25, .5, 1, 2},
+		},
+		[]string{"hostname", "initiator", "entry_type", "operation"},
+	)
+
+	cacheGCEntriesCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "argocd_app_state_cache_gc_deleted_entries_total",
+			Help: "Number of cache entries of deleted applications removed by the garbage collection by entry type.",
+		},
+		[]string{"hostname", "entry_type"},
+	)
+
+	cacheGCFreedBytesCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "argocd_app_state_cache_gc_freed_bytes_total",
+			Help: "Size in bytes of the cache entries of deleted applications removed by the garbage collection by entry type.",
+		},
+		[]string{"hostname", "entry_type"},
+	)
+
+	repoCredsHealthGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "argocd_repo_credentials_health",
+			Help: "Health of the credentials of the repository and repository credentials secrets, set to 1 for their current status.",
+		},
+		[]string{"name", "secret_type", "url", "status"},
+	)
+
+	repoCredsExpirationGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "argocd_repo_credentials_expiration_timestamp_seconds",
+			Help: "Unix timestamp at which the credentials of the repository and repository credentials secrets expire.",
+		},
+		[]string{"name", "secret_type", "url"},
+	)
+
 	orphanedResourcesGauge = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Name: "argocd_app_orphaned_resources_count",
@@ -143,6 +228,30 @@ var (
 		descAppDefaultLabels,
 	)
 
+	resourceKindCountGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "argocd_app_resource_kind_count",
+			Help: "Number of managed resources per kind per application",
+		},
+		append(descAppDefaultLabels, "group", "kind"),
+	)
+
+	statusTransitionsCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "argocd_app_status_transitions_total",
+			Help: "Number of transitions of the applications between Synced and OutOfSync, or between Healthy and Degraded, by status type.",
+		},
+		append(descAppDefaultLabels, "status_type"),
+	)
+
+	flappingGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "argocd_app_flapping",
+			Help: "Reports the applications whose sync or health status is flapping, by status type.",
+		},
+		append(descAppDefaultLabels, "status_type"),
+	)
+
 	resourceEventsProcessingHistogram = prometheus.NewHistogramVec(
 		prometheus.HistogramOpts{
 			Name:    "argocd_resource_events_processing",
@@ -156,6 +265,45 @@ var (
 		Name: "argocd_resource_events_processed_in_batch",
 		Help: "Number of resource events processed in batch",
 	}, []string{"server"})
+
+	workqueueDepthGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "argocd_app_workqueue_depth",
+		Help: "Number of applications waiting in the application controller queues.",
+	}, []string{"queue", "project", "shard"})
+
+	workqueueAddsCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "argocd_app_workqueue_adds_total",
+		Help: "Number of applications added to the application controller queues.",
+	}, []string{"queue", "project", "shard"})
+
+	workqueueRetriesCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "argocd_app_workqueue_retries_total",
+		Help: "Number of applications added with a delay, or rate limited, to the application controller queues.",
+	}, []string{"queue", "project", "shard"})
+
+	reconcileLatencyHistogram = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name: "argocd_app_reconcile_latency_seconds",
+			Help: "Time from an application being queued for reconciliation until its reconciliation completes, in seconds.",
+			// Includes the time spent waiting in the queue, which grows with the number of applications
+			Buckets: []float64{0.25, .5, 1, 2, 4, 8, 16, 32, 64, 128, 256},
+		},
+		[]string{"project", "shard"},
+	)
+
+	manifestGenDeferredCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "argocd_app_manifest_generation_deferred_total",
+		Help: "Number of manifest generation requests deferred because of the concurrency limit of their project.",
+	}, []string{"project"})
+
+	dryRunHistogram = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "argocd_app_server_side_diff_dry_run_duration_seconds",
+			Help:    "Duration of the server-side dry-run applies run by the server-side diff, in seconds.",
+			Buckets: []float64{0.05, 0.1, 0.25, .5, 1, 2, 4, 8, 16},
+		},
+		[]string{"dest_server", "failed"},
+	)
 )
 
 // NewMetricsServer returns a new prometheus server which collects application metrics
@@ -187,13 +335,16 @@ func NewMetricsServer(addr string, appLister applister.ApplicationLister, appFil
 	mux := http.NewServeMux()
 	registry := NewAppRegistry(appLister, appFilter, appLabels, appConditions, db)
 
-	mux.Handle(MetricsPath, promhttp.HandlerFor(prometheus.Gatherers{
+	gatherer := &labelRulesGatherer{gatherer: prometheus.Gatherers{
 		// contains app controller specific metrics
 		registry,
 		// contains workqueue metrics, process and golang metrics
 		ctrlmetrics.Registry,
-	}, promhttp.HandlerOpts{}))
+	}}
+	// the OpenMetrics format is required to expose the exemplars linking metrics to traces
+	mux.Handle(MetricsPath, promhttp.HandlerFor(gatherer, promhttp.HandlerOpts{EnableOpenMetrics: true}))
 	profile.RegisterProfiler(mux)
+	fips.RegisterStatusHandler(mux)
 	healthz.ServeHealthCheck(mux, healthCheck)
 
 	registry.MustRegister(syncCounter)
@@ -202,18 +353,36 @@ func NewMetricsServer(addr string, appLister applister.ApplicationLister, appFil
 	registry.MustRegister(kubectlExecCounter)
 	registry.MustRegister(kubectlExecPendingGauge)
 	registry.MustRegister(orphanedResourcesGauge)
+	registry.MustRegister(resourceKindCountGauge)
+	registry.MustRegister(statusTransitionsCounter)
+	registry.MustRegister(flappingGauge)
 	registry.MustRegister(reconcileHistogram)
 	registry.MustRegister(clusterEventsCounter)
 	registry.MustRegister(redisRequestCounter)
 	registry.MustRegister(redisRequestHistogram)
+	registry.MustRegister(cacheRequestCounter)
+	registry.MustRegister(cacheRequestHistogram)
+	registry.MustRegister(cacheGCEntriesCounter)
+	registry.MustRegister(cacheGCFreedBytesCounter)
+	registry.MustRegister(repoCredsHealthGauge)
+	registry.MustRegister(repoCredsExpirationGauge)
 	registry.MustRegister(resourceEventsProcessingHistogram)
 	registry.MustRegister(resourceEventsNumberGauge)
+	registry.MustRegister(workqueueDepthGauge)
+	registry.MustRegister(workqueueAddsCounter)
+	registry.MustRegister(workqueueRetriesCounter)
+	registry.MustRegister(reconcileLatencyHistogram)
+	registry.MustRegister(manifestGenDeferredCounter)
+	registry.MustRegister(dryRunHistogram)
+	repoapiclient.RegisterMetrics(registry)
 
 	kubectl.RegisterWithClientGo()
 	kubectl.RegisterWithPrometheus(registry)
 
 	metricsServer := &MetricsServer{
+		mux:      mux,
 		registry: registry,
+		gatherer: gatherer,
 		Server: &http.Server{
 			Addr:    addr,
 			Handler: mux,
@@ -224,12 +393,27 @@ func NewMetricsServer(addr string, appLister applister.ApplicationLister, appFil
 		kubectlExecCounter:                kubectlExecCounter,
 		kubectlExecPendingGauge:           kubectlExecPendingGauge,
 		orphanedResourcesGauge:            orphanedResourcesGauge,
+		resourceKindCountGauge:            resourceKindCountGauge,
+		statusTransitionsCounter:          statusTransitionsCounter,
+		flappingGauge:                     flappingGauge,
 		reconcileHistogram:                reconcileHistogram,
 		clusterEventsCounter:              clusterEventsCounter,
 		redisRequestCounter:               redisRequestCounter,
 		redisRequestHistogram:             redisRequestHistogram,
+		cacheRequestCounter:               cacheRequestCounter,
+		cacheRequestHistogram:             cacheRequestHistogram,
+		cacheGCEntriesCounter:             cacheGCEntriesCounter,
+		cacheGCFreedBytesCounter:          cacheGCFreedBytesCounter,
+		repoCredsHealthGauge:              repoCredsHealthGauge,
+		repoCredsExpirationGauge:          repoCredsExpirationGauge,
 		resourceEventsProcessingHistogram: resourceEventsProcessingHistogram,
 		resourceEventsNumberGauge:         resourceEventsNumberGauge,
+		workqueueDepthGauge:               workqueueDepthGauge,
+		workqueueAddsCounter:              workqueueAddsCounter,
+		workqueueRetriesCounter:           workqueueRetriesCounter,
+		reconcileLatencyHistogram:         reconcileLatencyHistogram,
+		manifestGenDeferredCounter:        manifestGenDeferredCounter,
+		dryRunHistogram:                   dryRunHistogram,
 		hostname:                          hostname,
 		// This cron is used to expire the metrics cache.
 		// Currently clearing the metrics cache is logging and deleting from the map
@@ -240,11 +424,21 @@ func NewMetricsServer(addr string, appLister applister.ApplicationLister, appFil
 	return metricsServer, nil
 }
 
+// SetLabelRules sets the rules reducing the cardinality of the exposed metrics, nil exposes the metrics unchanged
+func (m *MetricsServer) SetLabelRules(rules *LabelRules) {
+	m.gatherer.rules.Store(rules)
+}
+
 func (m *MetricsServer) RegisterClustersInfoSource(ctx context.Context, source HasClustersInfo, db db.ArgoDB, clusterLabels []string) {
 	collector := NewClusterCollector(ctx, source, db.ListClusters, clusterLabels)
 	m.registry.MustRegister(collector)
 }
 
+// HandleFunc registers an additional handler on the metrics server, such as the debug endpoints of the controller
+func (m *MetricsServer) HandleFunc(pattern string, handler http.HandlerFunc) {
+	m.mux.HandleFunc(pattern, handler)
+}
+
 // IncSync increments the sync counter for an application
 func (m *MetricsServer) IncSync(app *argoappv1.Application, destServer string, state *argoappv1.OperationState) {
 	if !state.Phase.Completed() {
@@ -254,11 +448,11 @@ func (m *MetricsServer) IncSync(app *argoappv1.Application, destServer string, s
 	m.syncCounter.WithLabelValues(app.Namespace, app.Name, app.Spec.GetProject(), destServer, string(state.Phase), strconv.FormatBool(isDryRun)).Inc()
 }
 
-// IncAppSyncDuration observes app sync duration
-func (m *MetricsServer) IncAppSyncDuration(app *argoappv1.Application, destServer string, state *argoappv1.OperationState) {
+// IncAppSyncDuration adds the duration of a finished sync operation, linked to the trace of the context if there is one
+func (m *MetricsServer) IncAppSyncDuration(ctx context.Context, app *argoappv1.Application, destServer string, state *argoappv1.OperationState) {
 	if state.FinishedAt != nil {
-		m.syncDuration.WithLabelValues(app.Namespace, app.Name, app.Spec.GetProject(), destServer).
-			Add(float64(time.Duration(state.FinishedAt.Unix() - state.StartedAt.Unix())))
+		addWithExemplar(ctx, m.syncDuration.WithLabelValues(app.Namespace, app.Name, app.Spec.GetProject(), destServer),
+			float64(time.Duration(state.FinishedAt.Unix()-state.StartedAt.Unix())))
 	}
 }
 
@@ -278,6 +472,63 @@ func (m *MetricsServer) SetOrphanedResourcesMetric(app *argoappv1.Application, n
 	m.orphanedResourcesGauge.WithLabelValues(app.Namespace, app.Name, app.Spec.GetProject()).Set(float64(numOrphanedResources))
 }
 
+// SetResourceKindsLimit sets the maximum number of resource kinds exposed per application by the
+// argocd_app_resource_kind_count metric, 0 disabling the metric. The resources of the other kinds are counted together.
+func (m *MetricsServer) SetResourceKindsLimit(limit int) {
+	m.resourceKindsLimit.Store(int64(limit))
+}
+
+// SetResourceKindCountMetric sets the number of managed resources per kind of the application, replacing the kinds
+// previously exposed. Only the kinds with the most resources are exposed, up to the resource kinds limit.
+func (m *MetricsServer) SetResourceKindCountMetric(app *argoappv1.Application, counts map[schema.GroupKind]int) {
+	limit := int(m.resourceKindsLimit.Load())
+	if limit <= 0 {
+		return
+	}
+	m.DeleteResourceKindCountMetric(app)
+	kinds := slices.SortedFunc(maps.Keys(counts), func(a, b schema.GroupKind) int {
+		if counts[a] != counts[b] {
+			return counts[b] - counts[a]
+		}
+		return strings.Compare(a.String(), b.String())
+	})
+	other := 0
+	for i, gk := range kinds {
+		if i < limit {
+			m.resourceKindCountGauge.WithLabelValues(app.Namespace, app.Name, app.Spec.GetProject(), gk.Group, gk.Kind).Set(float64(counts[gk]))
+		} else {
+			other += counts[gk]
+		}
+	}
+	if other > 0 {
+		m.resourceKindCountGauge.WithLabelValues(app.Namespace, app.Name, app.Spec.GetProject(), "", ResourceKindOther).Set(float64(other))
+	}
+}
+
+// DeleteResourceKindCountMetric deletes the number of managed resources per kind of the application
+func (m *MetricsServer) DeleteResourceKindCountMetric(app *argoappv1.Application) {
+	m.resourceKindCountGauge.DeletePartialMatch(prometheus.Labels{"namespace": app.Namespace, "name": app.Name})
+}
+
+// IncAppStatusTransition increments the number of transitions of the sync or health status of the application
+func (m *MetricsServer) IncAppStatusTransition(app *argoappv1.Application, statusType string) {
+	m.statusTransitionsCounter.WithLabelValues(app.Namespace, app.Name, app.Spec.GetProject(), statusType).Inc()
+}
+
+// SetAppFlapping reports whether the sync or health status of the application is flapping
+func (m *MetricsServer) SetAppFlapping(app *argoappv1.Application, statusType string, flapping bool) {
+	if flapping {
+		m.flappingGauge.WithLabelValues(app.Namespace, app.Name, app.Spec.GetProject(), statusType).Set(1)
+	} else {
+		m.flappingGauge.DeleteLabelValues(app.Namespace, app.Name, app.Spec.GetProject(), statusType)
+	}
+}
+
+// DeleteAppFlappingMetric deletes the flapping statuses of the application
+func (m *MetricsServer) DeleteAppFlappingMetric(app *argoappv1.Application) {
+	m.flappingGauge.DeletePartialMatch(prometheus.Labels{"namespace": app.Namespace, "name": app.Name})
+}
+
 // IncClusterEventsCount increments the number of cluster events
 func (m *MetricsServer) IncClusterEventsCount(server, group, kind string) {
 	m.clusterEventsCounter.WithLabelValues(server, group, kind).Inc()
@@ -308,15 +559,89 @@ func (m *MetricsServer) ObserveRedisRequestDuration(duration time.Duration) {
 	m.redisRequestHistogram.WithLabelValues(m.hostname, common.ApplicationController).Observe(duration.Seconds())
 }
 
+// RegisterRedisClient registers the collector of the health and connection pool metrics of the Redis client
+func (m *MetricsServer) RegisterRedisClient(client redis.UniversalClient) {
+	m.registry.MustRegister(cacheutil.NewRedisClientCollector(client, common.ApplicationController))
+}
+
+// IncCacheRequest increments the cache requests counter
+func (m *MetricsServer) IncCacheRequest(entryType, operation, result string) {
+	m.cacheRequestCounter.WithLabelValues(m.hostname, common.ApplicationController, entryType, operation, result).Inc()
+}
+
+// ObserveCacheRequestDuration observes cache request duration
+func (m *MetricsServer) ObserveCacheRequestDuration(entryType, operation string, duration time.Duration) {
+	m.cacheRequestHistogram.WithLabelValues(m.hostname, common.ApplicationController, entryType, operation).Observe(duration.Seconds())
+}
+
+// AddCacheGarbageCollected increments the counters of the cache entries removed by the garbage collection and of the
+// bytes they freed
+func (m *MetricsServer) AddCacheGarbageCollected(entryType string, size int64) {
+	m.cacheGCEntriesCounter.WithLabelValues(m.hostname, entryType).Inc()
+	m.cacheGCFreedBytesCounter.WithLabelValues(m.hostname, entryType).Add(float64(size))
+}
+
+// ResetRepoCredentialsHealth clears the health and the expiration of the credentials of the repository secrets
+func (m *MetricsServer) ResetRepoCredentialsHealth() {
+	m.repoCredsHealthGauge.Reset()
+	m.repoCredsExpirationGauge.Reset()
+}
+
+// SetRepoCredentialsHealth sets the health and the expiration, if known, of the credentials of a repository secret
+func (m *MetricsServer) SetRepoCredentialsHealth(name, secretType, url, status string, expiresAt *time.Time) {
+	m.repoCredsHealthGauge.WithLabelValues(name, secretType, url, status).Set(1)
+	if expiresAt != nil {
+		m.repoCredsExpirationGauge.WithLabelValues(name, secretType, url).Set(float64(expiresAt.Unix()))
+	}
+}
+
 // ObserveResourceEventsProcessingDuration observes resource events processing duration
 func (m *MetricsServer) ObserveResourceEventsProcessingDuration(server string, duration time.Duration, processedEventsNumber int) {
 	m.resourceEventsProcessingHistogram.WithLabelValues(server).Observe(duration.Seconds())
 	m.resourceEventsNumberGauge.WithLabelValues(server).Set(float64(processedEventsNumber))
 }
 
-// IncReconcile increments the reconcile counter for an application
-func (m *MetricsServer) IncReconcile(app *argoappv1.Application, destServer string, duration time.Duration) {
-	m.reconcileHistogram.WithLabelValues(app.Namespace, destServer).Observe(duration.Seconds())
+// IncReconcile increments the reconcile counter for an application, linked to the trace of the context if there is one
+func (m *MetricsServer) IncReconcile(ctx context.Context, app *argoappv1.Application, destServer string, duration time.Duration) {
+	observeWithExemplar(ctx, m.reconcileHistogram.WithLabelValues(app.Namespace, destServer), duration.Seconds())
+}
+
+// IncWorkqueueDepth increments the number of applications of a project waiting in a queue
+func (m *MetricsServer) IncWorkqueueDepth(queue, project, shard string) {
+	m.workqueueDepthGauge.WithLabelValues(queue, project, shard).Inc()
+}
+
+// DecWorkqueueDepth decrements the number of applications of a project waiting in a queue
+func (m *MetricsServer) DecWorkqueueDepth(queue, project, shard string) {
+	m.workqueueDepthGauge.WithLabelValues(queue, project, shard).Dec()
+}
+
+// IncWorkqueueAdds increments the number of applications of a project added to a queue
+func (m *MetricsServer) IncWorkqueueAdds(queue, project, shard string) {
+	m.workqueueAddsCounter.WithLabelValues(queue, project, shard).Inc()
+}
+
+// IncWorkqueueRetries increments the number of applications of a project added with a delay to a queue
+func (m *MetricsServer) IncWorkqueueRetries(queue, project, shard string) {
+	m.workqueueRetriesCounter.WithLabelValues(queue, project, shard).Inc()
+}
+
+// ObserveReconcileLatency observes the time from an application being queued for reconciliation until its
+// reconciliation completes
+func (m *MetricsServer) ObserveReconcileLatency(project, shard string, latency time.Duration) {
+	m.reconcileLatencyHistogram.WithLabelValues(project, shard).Observe(latency.Seconds())
+}
+
+// IncManifestGenerationDeferred increments the number of manifest generation requests of a project deferred because of
+// the concurrency limit of the project
+func (m *MetricsServer) IncManifestGenerationDeferred(project string) {
+	m.manifestGenDeferredCounter.WithLabelValues(project).Inc()
+}
+
+// ObserveServerSideDiffDryRunDuration observes the duration of a server-side dry-run apply run by the server-side
+// diff to a cluster
+func (m *MetricsServer) ObserveServerSideDiffDryRunDuration(destServer string, failed bool, duration time.Duration) {
+	m.dryRunHistogram.WithLabelValues(destServer, strconv.FormatBool(failed)).Observe(duration.Seconds())
 }
 
 // HasExpiration return true if expiration is set
@@ -337,13 +662,26 @@ func (m *MetricsServer) SetExpiration(cacheExpiration time.Duration) error {
 		m.kubectlExecCounter.Reset()
 		m.kubectlExecPendingGauge.Reset()
 		m.orphanedResourcesGauge.Reset()
+		m.resourceKindCountGauge.Reset()
+		m.statusTransitionsCounter.Reset()
 		m.k8sRequestCounter.Reset()
 		m.clusterEventsCounter.Reset()
 		m.redisRequestCounter.Reset()
 		m.reconcileHistogram.Reset()
 		m.redisRequestHistogram.Reset()
+		m.cacheRequestCounter.Reset()
+		m.cacheRequestHistogram.Reset()
+		m.cacheGCEntriesCounter.Reset()
+		m.cacheGCFreedBytesCounter.Reset()
 		m.resourceEventsProcessingHistogram.Reset()
 		m.resourceEventsNumberGauge.Reset()
+		// the workqueue depth, the flapping applications and the health of the repository credentials are not reset
+		// since they track the current state
+		m.workqueueAddsCounter.Reset()
+		m.workqueueRetriesCounter.Reset()
+		m.reconcileLatencyHistogram.Reset()
+		m.manifestGenDeferredCounter.Reset()
+		m.dryRunHistogram.Reset()
 		kubectl.ResetAll()
 	})
 	if err != nil {
@@ -389,6 +727,7 @@ func (c *appCollector) Describe(ch chan<- *prometheus.Desc) {
 		ch <- descAppConditions
 	}
 	ch <- descAppInfo
+	ch <- descAppFrozen
 }
 
 // Collect implements the prometheus.Collector interface
@@ -450,6 +789,10 @@ func (c *appCollector) collectApps(ch chan<- prometheus.Metric, app *argoappv1.A
 
 	addGauge(descAppInfo, 1, strconv.FormatBool(autoSyncEnabled), git.NormalizeGitURL(app.Spec.GetSource().RepoURL), destServer, app.Spec.Destination.Namespace, string(syncStatus), string(healthStatus), operation)
 
+	if freeze := app.Status.Freeze; freeze.IsActive(time.Now()) {
+		addGauge(descAppFrozen, 1, strconv.FormatBool(freeze.NoRefresh))
+	}
+
 	if len(c.appLabels) > 0 {
 		labelValues := []string{}
 		for _, desiredLabel := range c.appLabels {
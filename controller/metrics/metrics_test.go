@@ -17,8 +17,10 @@ import (
 	"github.com/argoproj/gitops-engine/pkg/sync/common"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
@@ -374,6 +376,43 @@ argocd_app_info{autosync_enabled="false",dest_namespace="dummy-namespace",dest_s
 	}
 }
 
+func TestAppFrozenMetric(t *testing.T) {
+	frozenApp := strings.Replace(fakeApp2, `status:
+`, `status:
+  freeze:
+    frozenBy: alice
+    frozenAt: "2026-01-01T00:00:00Z"
+    noRefresh: true
+`, 1)
+	expiredApp := strings.Replace(strings.Replace(fakeApp, "name: my-app", "name: my-app-expired", 1), `status:
+`, `status:
+  freeze:
+    frozenAt: "2026-01-01T00:00:00Z"
+    until: "2026-01-01T01:00:00Z"
+`, 1)
+	cancel, appLister := newFakeLister(fakeApp, frozenApp, expiredApp)
+	defer cancel()
+	mockDB := mocks.NewArgoDB(t)
+	mockDB.On("GetClusterServersByName", mock.Anything, "cluster1").Return([]string{"https://localhost:6443"}, nil)
+	mockDB.On("GetCluster", mock.Anything, "https://localhost:6443").Return(&argoappv1.Cluster{Name: "cluster1", Server: "https://localhost:6443"}, nil)
+	metricsServ, err := NewMetricsServer("localhost:8082", appLister, appFilter, noOpHealthCheck, []string{}, []string{}, mockDB)
+	require.NoError(t, err)
+
+	req, err := http.NewRequest(http.MethodGet, "/metrics", http.NoBody)
+	require.NoError(t, err)
+	rr := httptest.NewRecorder()
+	metricsServ.Handler.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	body := rr.Body.String()
+	assertMetricsPrinted(t, `
+# HELP argocd_app_frozen Whether the application is frozen, only reported for the frozen applications.
+# TYPE argocd_app_frozen gauge
+argocd_app_frozen{name="my-app-2",namespace="argocd",no_refresh="true",project="important-project"} 1
+`, body)
+	// the applications which aren't frozen, or whose freeze expired, aren't reported
+	assert.Equal(t, 1, strings.Count(body, "argocd_app_frozen{"))
+}
+
 func TestMetricLabels(t *testing.T) {
 	type testCases struct {
 		testCombination
@@ -534,7 +573,7 @@ func TestMetricsSyncDuration(t *testing.T) {
 
 	t.Run("metric is not generated during Operation Running.", func(t *testing.T) {
 		fakeAppOperationRunning := newFakeApp(fakeAppOperationRunning)
-		metricsServ.IncAppSyncDuration(fakeAppOperationRunning, "https://localhost:6443", fakeAppOperationRunning.Status.OperationState)
+		metricsServ.IncAppSyncDuration(t.Context(), fakeAppOperationRunning, "https://localhost:6443", fakeAppOperationRunning.Status.OperationState)
 
 		req, err := http.NewRequest(http.MethodGet, "/metrics", http.NoBody)
 		require.NoError(t, err)
@@ -548,7 +587,7 @@ func TestMetricsSyncDuration(t *testing.T) {
 
 	t.Run("metric is created when Operation Finished.", func(t *testing.T) {
 		fakeAppOperationFinished := newFakeApp(fakeAppOperationFinished)
-		metricsServ.IncAppSyncDuration(fakeAppOperationFinished, "https://localhost:6443", fakeAppOperationFinished.Status.OperationState)
+		metricsServ.IncAppSyncDuration(t.Context(), fakeAppOperationFinished, "https://localhost:6443", fakeAppOperationFinished.Status.OperationState)
 
 		req, err := http.NewRequest(http.MethodGet, "/metrics", http.NoBody)
 		require.NoError(t, err)
@@ -588,7 +627,7 @@ argocd_app_reconcile_sum{dest_server="https://localhost:6443",namespace="argocd"
 argocd_app_reconcile_count{dest_server="https://localhost:6443",namespace="argocd"} 1
 `
 	fakeApp := newFakeApp(fakeApp)
-	metricsServ.IncReconcile(fakeApp, "https://localhost:6443", 5*time.Second)
+	metricsServ.IncReconcile(t.Context(), fakeApp, "https://localhost:6443", 5*time.Second)
 
 	req, err := http.NewRequest(http.MethodGet, "/metrics", http.NoBody)
 	require.NoError(t, err)
@@ -600,6 +639,32 @@ argocd_app_reconcile_count{dest_server="https://localhost:6443",namespace="argoc
 	assertMetricsPrinted(t, appReconcileMetrics, body)
 }
 
+func TestReconcileMetricsExemplar(t *testing.T) {
+	cancel, appLister := newFakeLister()
+	defer cancel()
+	mockDB := mocks.NewArgoDB(t)
+	metricsServ, err := NewMetricsServer("localhost:8082", appLister, appFilter, noOpHealthCheck, []string{}, []string{}, mockDB)
+	require.NoError(t, err)
+
+	traceID := trace.TraceID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
+	ctx := trace.ContextWithSpanContext(t.Context(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     trace.SpanID{1, 2, 3, 4, 5, 6, 7, 8},
+		TraceFlags: trace.FlagsSampled,
+	}))
+	metricsServ.IncReconcile(ctx, newFakeApp(fakeApp), "https://exemplar:6443", 5*time.Second)
+
+	req, err := http.NewRequest(http.MethodGet, "/metrics", http.NoBody)
+	require.NoError(t, err)
+	req.Header.Set("Accept", "application/openmetrics-text; version=1.0.0")
+	rr := httptest.NewRecorder()
+	metricsServ.Handler.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	body := rr.Body.String()
+	log.Println(body)
+	assert.Contains(t, body, `argocd_app_reconcile_bucket{dest_server="https://exemplar:6443",namespace="argocd",le="8.0"} 1 # {trace_id="`+traceID.String()+`"} 5.0`)
+}
+
 func TestOrphanedResourcesMetric(t *testing.T) {
 	cancel, appLister := newFakeLister()
 	defer cancel()
@@ -626,6 +691,52 @@ argocd_app_orphaned_resources_count{name="my-app-4",namespace="argocd",project="
 	assertMetricsPrinted(t, expectedMetrics, body)
 }
 
+func TestResourceKindCountMetric(t *testing.T) {
+	cancel, appLister := newFakeLister()
+	defer cancel()
+	mockDB := mocks.NewArgoDB(t)
+	metricsServ, err := NewMetricsServer("localhost:8082", appLister, appFilter, noOpHealthCheck, []string{}, []string{}, mockDB)
+	require.NoError(t, err)
+	getMetrics := func() string {
+		req, err := http.NewRequest(http.MethodGet, "/metrics", http.NoBody)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		metricsServ.Handler.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		return rr.Body.String()
+	}
+
+	app := newFakeApp(fakeApp4)
+	counts := map[schema.GroupKind]int{
+		{Kind: "ConfigMap"}:                 5,
+		{Group: "apps", Kind: "Deployment"}: 2,
+		{Kind: "Service"}:                   2,
+		{Kind: "Secret"}:                    1,
+	}
+	// the metric is disabled by default
+	metricsServ.SetResourceKindCountMetric(app, counts)
+	assert.NotContains(t, getMetrics(), "argocd_app_resource_kind_count{")
+
+	metricsServ.SetResourceKindsLimit(2)
+	metricsServ.SetResourceKindCountMetric(app, counts)
+	assertMetricsPrinted(t, `
+argocd_app_resource_kind_count{group="",kind="ConfigMap",name="my-app-4",namespace="argocd",project="important-project"} 5
+argocd_app_resource_kind_count{group="",kind="other",name="my-app-4",namespace="argocd",project="important-project"} 3
+argocd_app_resource_kind_count{group="apps",kind="Deployment",name="my-app-4",namespace="argocd",project="important-project"} 2
+`, getMetrics())
+
+	// the kinds previously exposed are replaced
+	metricsServ.SetResourceKindCountMetric(app, map[schema.GroupKind]int{{Kind: "Secret"}: 1})
+	body := getMetrics()
+	assertMetricsPrinted(t, `
+argocd_app_resource_kind_count{group="",kind="Secret",name="my-app-4",namespace="argocd",project="important-project"} 1
+`, body)
+	assert.NotContains(t, body, `kind="ConfigMap",name="my-app-4"`)
+
+	metricsServ.DeleteResourceKindCountMetric(app)
+	assert.NotContains(t, getMetrics(), `argocd_app_resource_kind_count{group="",kind="Secret",name="my-app-4"`)
+}
+
 func TestMetricsReset(t *testing.T) {
 	cancel, appLister := newFakeLister()
 	defer cancel()
@@ -695,6 +806,48 @@ workqueue_unfinished_work_seconds{controller="test",name="test"}
 	assertMetricsPrinted(t, expectedMetrics, body)
 }
 
+func TestAppWorkqueueMetrics(t *testing.T) {
+	cancel, appLister := newFakeLister()
+	defer cancel()
+	mockDB := mocks.NewArgoDB(t)
+	metricsServ, err := NewMetricsServer("localhost:8082", appLister, appFilter, noOpHealthCheck, []string{}, []string{}, mockDB)
+	require.NoError(t, err)
+
+	expectedMetrics := `
+# HELP argocd_app_workqueue_depth Number of applications waiting in the application controller queues.
+# TYPE argocd_app_workqueue_depth gauge
+argocd_app_workqueue_depth{project="important-project",queue="app_reconciliation_queue",shard="1"} 1
+# HELP argocd_app_workqueue_adds_total Number of applications added to the application controller queues.
+# TYPE argocd_app_workqueue_adds_total counter
+argocd_app_workqueue_adds_total{project="important-project",queue="app_reconciliation_queue",shard="1"} 2
+# HELP argocd_app_workqueue_retries_total Number of applications added with a delay, or rate limited, to the application controller queues.
+# TYPE argocd_app_workqueue_retries_total counter
+argocd_app_workqueue_retries_total{project="important-project",queue="app_reconciliation_queue",shard="1"} 1
+# HELP argocd_app_reconcile_latency_seconds Time from an application being queued for reconciliation until its reconciliation completes, in seconds.
+# TYPE argocd_app_reconcile_latency_seconds histogram
+argocd_app_reconcile_latency_seconds_bucket{project="important-project",shard="1",le="4"} 0
+argocd_app_reconcile_latency_seconds_bucket{project="important-project",shard="1",le="8"} 1
+argocd_app_reconcile_latency_seconds_sum{project="important-project",shard="1"} 5
+argocd_app_reconcile_latency_seconds_count{project="important-project",shard="1"} 1
+`
+	metricsServ.IncWorkqueueAdds("app_reconciliation_queue", "important-project", "1")
+	metricsServ.IncWorkqueueAdds("app_reconciliation_queue", "important-project", "1")
+	metricsServ.IncWorkqueueRetries("app_reconciliation_queue", "important-project", "1")
+	metricsServ.IncWorkqueueDepth("app_reconciliation_queue", "important-project", "1")
+	metricsServ.IncWorkqueueDepth("app_reconciliation_queue", "important-project", "1")
+	metricsServ.DecWorkqueueDepth("app_reconciliation_queue", "important-project", "1")
+	metricsServ.ObserveReconcileLatency("important-project", "1", 5*time.Second)
+
+	req, err := http.NewRequest(http.MethodGet, "/metrics", http.NoBody)
+	require.NoError(t, err)
+	rr := httptest.NewRecorder()
+	metricsServ.Handler.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	body := rr.Body.String()
+	log.Println(body)
+	assertMetricsPrinted(t, expectedMetrics, body)
+}
+
 func TestGoMetrics(t *testing.T) {
 	cancel, appLister := newFakeLister()
 	defer cancel()
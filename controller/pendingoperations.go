@@ -0,0 +1,66 @@
+package controller
+
+import (
+	"sort"
+
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+
+	appv1 "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+)
+
+// resumePendingOperations queues the operations of the applications which were requested or in progress when the
+// controller started, so that they are resumed in a deterministic order without waiting for the refresh of the
+// applications. The operations in progress are resumed first, then the pending operations by order of decreasing
+// priority and by order of request.
+func (ctrl *ApplicationController) resumePendingOperations() {
+	apps, err := ctrl.appLister.List(labels.Everything())
+	if err != nil {
+		log.Warnf("Failed to list the applications with pending operations: %v", err)
+		return
+	}
+	var pending []*appv1.Application
+	for _, app := range apps {
+		if app.Operation != nil && ctrl.canProcessApp(app) {
+			pending = append(pending, app)
+		}
+	}
+	if len(pending) == 0 {
+		return
+	}
+	sortPendingOperations(pending)
+	for _, app := range pending {
+		key, err := cache.MetaNamespaceKeyFunc(app)
+		if err != nil {
+			continue
+		}
+		ctrl.appOperationQueue.Add(key)
+	}
+	log.Infof("Resuming %d pending operations", len(pending))
+}
+
+// sortPendingOperations sorts the applications by the order in which their operations are resumed
+func sortPendingOperations(apps []*appv1.Application) {
+	sort.SliceStable(apps, func(i, j int) bool {
+		a, b := apps[i], apps[j]
+		if aRunning, bRunning := isOperationInProgress(a), isOperationInProgress(b); aRunning != bRunning {
+			return aRunning
+		} else if aRunning && !a.Status.OperationState.StartedAt.Equal(&b.Status.OperationState.StartedAt) {
+			return a.Status.OperationState.StartedAt.Before(&b.Status.OperationState.StartedAt)
+		}
+		if a.Operation.Priority != b.Operation.Priority {
+			return a.Operation.Priority > b.Operation.Priority
+		}
+		aRequestedAt, bRequestedAt := a.Operation.RequestedAt, b.Operation.RequestedAt
+		switch {
+		case aRequestedAt != nil && bRequestedAt != nil && !aRequestedAt.Equal(bRequestedAt):
+			return aRequestedAt.Before(bRequestedAt)
+		case aRequestedAt != nil && bRequestedAt == nil:
+			return true
+		case aRequestedAt == nil && bRequestedAt != nil:
+			return false
+		}
+		return a.QualifiedName() < b.QualifiedName()
+	})
+}
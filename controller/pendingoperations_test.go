@@ -0,0 +1,54 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	synccommon "github.com/argoproj/gitops-engine/pkg/sync/common"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	"github.com/argoproj/argo-cd/v3/test"
+)
+
+func TestResumePendingOperations(t *testing.T) {
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	newApp := func(name string, operation *v1alpha1.Operation, startedAt *time.Time) *v1alpha1.Application {
+		app := newFakeApp()
+		app.Name = name
+		app.Operation = operation
+		app.Status.OperationState = nil
+		if startedAt != nil {
+			app.Status.OperationState = &v1alpha1.OperationState{Phase: synccommon.OperationRunning, StartedAt: metav1.NewTime(*startedAt)}
+		}
+		return app
+	}
+	requestedAt := func(d time.Duration) *metav1.Time {
+		t := metav1.NewTime(base.Add(d))
+		return &t
+	}
+	startedAt := base.Add(-time.Hour)
+	ctrl := newFakeController(&fakeData{apps: []runtime.Object{
+		newApp("low", &v1alpha1.Operation{Sync: &v1alpha1.SyncOperation{}, Priority: -1, RequestedAt: requestedAt(0)}, nil),
+		newApp("unknown-request", &v1alpha1.Operation{Sync: &v1alpha1.SyncOperation{}}, nil),
+		newApp("late", &v1alpha1.Operation{Sync: &v1alpha1.SyncOperation{}, RequestedAt: requestedAt(time.Minute)}, nil),
+		newApp("early", &v1alpha1.Operation{Sync: &v1alpha1.SyncOperation{}, RequestedAt: requestedAt(0)}, nil),
+		newApp("high", &v1alpha1.Operation{Sync: &v1alpha1.SyncOperation{}, Priority: 10, RequestedAt: requestedAt(time.Hour)}, nil),
+		newApp("running", &v1alpha1.Operation{Sync: &v1alpha1.SyncOperation{}, RequestedAt: requestedAt(time.Hour)}, &startedAt),
+		newApp("no-operation", nil, nil),
+		&defaultProj,
+	}}, nil)
+
+	ctrl.resumePendingOperations()
+
+	var keys []string
+	for ctrl.appOperationQueue.Len() > 0 {
+		key, _ := ctrl.appOperationQueue.Get()
+		keys = append(keys, key)
+		ctrl.appOperationQueue.Done(key)
+	}
+	ns := test.FakeArgoCDNamespace + "/"
+	assert.Equal(t, []string{ns + "running", ns + "high", ns + "early", ns + "late", ns + "unknown-request", ns + "low"}, keys)
+}
@@ -0,0 +1,78 @@
+package controller
+
+import (
+	"encoding/base64"
+	"fmt"
+	"slices"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	"github.com/argoproj/argo-cd/v3/util/settings"
+)
+
+// plaintextSecretConditions returns the conditions reporting the Secrets of the given manifests with plaintext values,
+// which are the values that aren't empty and don't match an allowed value pattern. The Secrets with an allowed
+// annotation are ignored.
+func plaintextSecretConditions(plaintextSecrets *settings.PlaintextSecretsSettings, targetObjs []*unstructured.Unstructured, now *metav1.Time) []v1alpha1.ApplicationCondition {
+	conditionType := v1alpha1.ApplicationConditionPlaintextSecretError
+	if plaintextSecrets.IsWarning() {
+		conditionType = v1alpha1.ApplicationConditionPlaintextSecretWarning
+	}
+	var conditions []v1alpha1.ApplicationCondition
+	for _, obj := range targetObjs {
+		if obj == nil || obj.GroupVersionKind().Group != "" || obj.GetKind() != "Secret" {
+			continue
+		}
+		if plaintextSecrets.IsAllowedAnnotated(obj.GetAnnotations()) {
+			continue
+		}
+		keys := plaintextSecretKeys(plaintextSecrets, obj)
+		if len(keys) == 0 {
+			continue
+		}
+		name := obj.GetName()
+		if obj.GetNamespace() != "" {
+			name = obj.GetNamespace() + "/" + name
+		}
+		conditions = append(conditions, v1alpha1.ApplicationCondition{
+			Type:               conditionType,
+			Message:            fmt.Sprintf("Secret %s has plaintext values in keys: %s", name, strings.Join(keys, ", ")),
+			LastTransitionTime: now,
+		})
+	}
+	return conditions
+}
+
+// plaintextSecretKeys returns the sorted keys of the data and stringData of the Secret with plaintext values
+func plaintextSecretKeys(plaintextSecrets *settings.PlaintextSecretsSettings, obj *unstructured.Unstructured) []string {
+	var keys []string
+	isPlaintext := func(value string) bool {
+		return value != "" && !plaintextSecrets.IsAllowedValue(value)
+	}
+	if stringData, ok := obj.Object["stringData"].(map[string]any); ok {
+		for key, value := range stringData {
+			if value, ok := value.(string); ok && isPlaintext(value) {
+				keys = append(keys, key)
+			}
+		}
+	}
+	if data, ok := obj.Object["data"].(map[string]any); ok {
+		for key, value := range data {
+			value, ok := value.(string)
+			if !ok {
+				continue
+			}
+			if decoded, err := base64.StdEncoding.DecodeString(value); err == nil {
+				value = string(decoded)
+			}
+			if isPlaintext(value) && !slices.Contains(keys, key) {
+				keys = append(keys, key)
+			}
+		}
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,77 @@
+package controller
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/argoproj/argo-cd/v3/common"
+	appv1 "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	"github.com/argoproj/argo-cd/v3/util/settings"
+)
+
+func newPlaintextSecretTestObj(name string, annotations map[string]string, stringData map[string]any, data map[string]any) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]any{}}
+	obj.SetAPIVersion("v1")
+	obj.SetKind("Secret")
+	obj.SetNamespace("default")
+	obj.SetName(name)
+	obj.SetAnnotations(annotations)
+	if stringData != nil {
+		obj.Object["stringData"] = stringData
+	}
+	if data != nil {
+		obj.Object["data"] = data
+	}
+	return obj
+}
+
+func TestPlaintextSecretConditions(t *testing.T) {
+	clientset := fake.NewClientset(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      common.ArgoCDConfigMapName,
+			Namespace: "argocd",
+			Labels:    map[string]string{"app.kubernetes.io/part-of": "argocd"},
+		},
+		Data: map[string]string{
+			"resource.plaintextSecrets": "allowedAnnotations: [avp.kubernetes.io/path]\nallowedValuePatterns: ['^<path:[^>]+>$']",
+		},
+	})
+	plaintextSecrets, err := settings.NewSettingsManager(t.Context(), clientset, "argocd").GetPlaintextSecretsSettings()
+	require.NoError(t, err)
+
+	configMap := &unstructured.Unstructured{Object: map[string]any{"data": map[string]any{"password": "hunter2"}}}
+	configMap.SetAPIVersion("v1")
+	configMap.SetKind("ConfigMap")
+	configMap.SetName("config")
+	targetObjs := []*unstructured.Unstructured{
+		configMap,
+		newPlaintextSecretTestObj("empty", nil, map[string]any{"password": ""}, map[string]any{"token": ""}),
+		newPlaintextSecretTestObj("placeholders", nil, map[string]any{"password": "<path:secret/data/app#password>"}, map[string]any{
+			"token": base64.StdEncoding.EncodeToString([]byte("<path:secret/data/app#token>")),
+		}),
+		newPlaintextSecretTestObj("annotated", map[string]string{"avp.kubernetes.io/path": "secret/data/app"}, map[string]any{"password": "hunter2"}, nil),
+		newPlaintextSecretTestObj("plaintext", nil, map[string]any{"password": "hunter2", "user": "<path:secret/data/app#user>"}, map[string]any{
+			"token":    base64.StdEncoding.EncodeToString([]byte("s3cr3t")),
+			"password": base64.StdEncoding.EncodeToString([]byte("hunter2")),
+		}),
+	}
+	now := metav1.Now()
+
+	assert.Equal(t, []appv1.ApplicationCondition{{
+		Type:               appv1.ApplicationConditionPlaintextSecretError,
+		Message:            "Secret default/plaintext has plaintext values in keys: password, token",
+		LastTransitionTime: &now,
+	}}, plaintextSecretConditions(plaintextSecrets, targetObjs, &now))
+
+	plaintextSecrets.Enforcement = settings.PlaintextSecretsEnforcementWarn
+	conditions := plaintextSecretConditions(plaintextSecrets, targetObjs, &now)
+	require.Len(t, conditions, 1)
+	assert.Equal(t, appv1.ApplicationConditionPlaintextSecretWarning, conditions[0].Type)
+}
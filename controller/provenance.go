@@ -0,0 +1,111 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	"github.com/argoproj/argo-cd/v3/util/attestation"
+	"github.com/argoproj/argo-cd/v3/util/glob"
+	"github.com/argoproj/argo-cd/v3/util/oci"
+)
+
+// provenanceVerifier verifies the SLSA provenance attestations of the OCI sources of the applications against the
+// provenance policies of their projects
+type provenanceVerifier struct {
+	newOCIClient func(repoURL string, creds oci.Creds, proxy, noProxy string, layerMediaTypes []string, opts ...oci.ClientOpts) (oci.Client, error)
+
+	lock     sync.Mutex
+	verified map[string]bool
+}
+
+func newProvenanceVerifier() *provenanceVerifier {
+	return &provenanceVerifier{
+		newOCIClient: oci.NewClient,
+		verified:     map[string]bool{},
+	}
+}
+
+// verify checks that the OCI artifact of the given digest has an attestation satisfying each of the given policies.
+// The artifacts which satisfied the same policies before aren't verified again.
+func (v *provenanceVerifier) verify(ctx context.Context, policies []v1alpha1.ProvenancePolicy, repo *v1alpha1.Repository, digest string) error {
+	if len(policies) == 0 {
+		return nil
+	}
+	cacheKey := fmt.Sprintf("%s@%s:%v", repo.Repo, digest, policies)
+	v.lock.Lock()
+	verified := v.verified[cacheKey]
+	v.lock.Unlock()
+	if verified {
+		return nil
+	}
+
+	client, err := v.newOCIClient(repo.Repo, repo.GetOCICreds(), repo.GetProxy(), repo.NoProxy, nil)
+	if err != nil {
+		return fmt.Errorf("error creating OCI client of %s: %w", repo.Repo, err)
+	}
+	envelopes, err := client.Attestations(ctx, digest)
+	if err != nil {
+		return fmt.Errorf("error getting attestations of %s: %w", digest, err)
+	}
+	if len(envelopes) == 0 {
+		return fmt.Errorf("no attestation found for %s of %s", digest, repo.Repo)
+	}
+	for i := range policies {
+		if err := verifyProvenancePolicy(&policies[i], envelopes, digest); err != nil {
+			return fmt.Errorf("no attestation of %s satisfies the provenance policy of '%s': %w", digest, policies[i].RepoURL, err)
+		}
+	}
+
+	v.lock.Lock()
+	v.verified[cacheKey] = true
+	v.lock.Unlock()
+	return nil
+}
+
+// verifyProvenancePolicy checks that one of the given DSSE envelopes is signed by a key of the policy, and contains a
+// SLSA provenance of the given digest whose builder and source repository are allowed by the policy. The error of the
+// last envelope is returned if none of them satisfies the policy.
+func verifyProvenancePolicy(policy *v1alpha1.ProvenancePolicy, envelopes [][]byte, digest string) error {
+	keys, err := attestation.ParsePublicKeys(policy.PublicKey)
+	if err != nil {
+		return err
+	}
+	err = errors.New("no attestation found")
+	for _, data := range envelopes {
+		err = func() error {
+			envelope, err := attestation.ParseEnvelope(data)
+			if err != nil {
+				return err
+			}
+			payload, err := envelope.Verify(keys)
+			if err != nil {
+				return err
+			}
+			statement, err := attestation.ParseStatement(envelope.PayloadType, payload)
+			if err != nil {
+				return err
+			}
+			if !statement.HasSubject(digest) {
+				return fmt.Errorf("the attestation isn't about %s", digest)
+			}
+			provenance, err := statement.Provenance()
+			if err != nil {
+				return err
+			}
+			if policy.BuilderID != "" && !glob.Match(policy.BuilderID, provenance.BuilderID) {
+				return fmt.Errorf("builder '%s' isn't allowed", provenance.BuilderID)
+			}
+			if policy.SourceRepository != "" && !glob.Match(policy.SourceRepository, provenance.SourceRepository) {
+				return fmt.Errorf("source repository '%s' isn't allowed", provenance.SourceRepository)
+			}
+			return nil
+		}()
+		if err == nil {
+			return nil
+		}
+	}
+	return err
+}
@@ -0,0 +1,128 @@
+package controller
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	appv1 "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	"github.com/argoproj/argo-cd/v3/util/attestation"
+	"github.com/argoproj/argo-cd/v3/util/oci"
+	ocimocks "github.com/argoproj/argo-cd/v3/util/oci/mocks"
+)
+
+const provenanceTestDigest = "sha256:1b6dfd71e2b35c2f35dffc39007c2276f3c0e235cbae4c39cba74bd406174e22"
+
+func newProvenanceTestEnvelope(t *testing.T, key *ecdsa.PrivateKey, digest string, builderID string, sourceRepository string) []byte {
+	t.Helper()
+	algorithm, value, _ := strings.Cut(digest, ":")
+	payload, err := json.Marshal(map[string]any{
+		"_type":         "https://in-toto.io/Statement/v1",
+		"subject":       []any{map[string]any{"name": "chart", "digest": map[string]string{algorithm: value}}},
+		"predicateType": attestation.PredicateTypeSLSAProvenanceV1,
+		"predicate": map[string]any{
+			"buildDefinition": map[string]any{
+				"externalParameters": map[string]any{"workflow": map[string]any{"repository": sourceRepository}},
+			},
+			"runDetails": map[string]any{"builder": map[string]any{"id": builderID}},
+		},
+	})
+	require.NoError(t, err)
+	message := fmt.Sprintf("DSSEv1 %d %s %d %s", len(attestation.PayloadTypeInToto), attestation.PayloadTypeInToto, len(payload), payload)
+	hash := sha256.Sum256([]byte(message))
+	sig, err := key.Sign(rand.Reader, hash[:], crypto.SHA256)
+	require.NoError(t, err)
+	envelope, err := json.Marshal(attestation.Envelope{
+		PayloadType: attestation.PayloadTypeInToto,
+		Payload:     base64.StdEncoding.EncodeToString(payload),
+		Signatures:  []attestation.Signature{{Sig: base64.StdEncoding.EncodeToString(sig)}},
+	})
+	require.NoError(t, err)
+	return envelope
+}
+
+func TestProvenanceVerifier_Verify(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+	publicKey := string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	repo := &appv1.Repository{Repo: "oci://registry.example.com/charts/app", Type: "oci"}
+	policy := appv1.ProvenancePolicy{
+		RepoURL:          "oci://registry.example.com/charts/*",
+		BuilderID:        "https://github.com/actions/runner*",
+		SourceRepository: "https://github.com/example/*",
+		PublicKey:        publicKey,
+	}
+	newVerifier := func(envelopes ...[]byte) (*provenanceVerifier, *ocimocks.Client) {
+		client := &ocimocks.Client{}
+		client.EXPECT().Attestations(mock.Anything, provenanceTestDigest).Return(envelopes, nil).Maybe()
+		verifier := newProvenanceVerifier()
+		verifier.newOCIClient = func(string, oci.Creds, string, string, []string, ...oci.ClientOpts) (oci.Client, error) {
+			return client, nil
+		}
+		return verifier, client
+	}
+
+	t.Run("no policies", func(t *testing.T) {
+		verifier, client := newVerifier()
+		require.NoError(t, verifier.verify(t.Context(), nil, repo, provenanceTestDigest))
+		client.AssertNotCalled(t, "Attestations", mock.Anything, mock.Anything)
+	})
+
+	t.Run("trusted provenance", func(t *testing.T) {
+		verifier, client := newVerifier(
+			newProvenanceTestEnvelope(t, otherKey, provenanceTestDigest, "https://github.com/actions/runner", "https://github.com/example/app"),
+			newProvenanceTestEnvelope(t, key, provenanceTestDigest, "https://github.com/actions/runner/github-hosted", "https://github.com/example/app"),
+		)
+		require.NoError(t, verifier.verify(t.Context(), []appv1.ProvenancePolicy{policy}, repo, provenanceTestDigest))
+		require.NoError(t, verifier.verify(t.Context(), []appv1.ProvenancePolicy{policy}, repo, provenanceTestDigest))
+		client.AssertNumberOfCalls(t, "Attestations", 1)
+	})
+
+	t.Run("no attestation", func(t *testing.T) {
+		verifier, _ := newVerifier()
+		err := verifier.verify(t.Context(), []appv1.ProvenancePolicy{policy}, repo, provenanceTestDigest)
+		require.EqualError(t, err, "no attestation found for "+provenanceTestDigest+" of oci://registry.example.com/charts/app")
+	})
+
+	t.Run("untrusted key", func(t *testing.T) {
+		verifier, _ := newVerifier(newProvenanceTestEnvelope(t, otherKey, provenanceTestDigest, "https://github.com/actions/runner", "https://github.com/example/app"))
+		err := verifier.verify(t.Context(), []appv1.ProvenancePolicy{policy}, repo, provenanceTestDigest)
+		require.ErrorContains(t, err, "no signature of the DSSE envelope matches a trusted key")
+	})
+
+	t.Run("other subject", func(t *testing.T) {
+		verifier, _ := newVerifier(newProvenanceTestEnvelope(t, key, "sha256:abc", "https://github.com/actions/runner", "https://github.com/example/app"))
+		err := verifier.verify(t.Context(), []appv1.ProvenancePolicy{policy}, repo, provenanceTestDigest)
+		require.ErrorContains(t, err, "the attestation isn't about "+provenanceTestDigest)
+	})
+
+	t.Run("builder not allowed", func(t *testing.T) {
+		verifier, _ := newVerifier(newProvenanceTestEnvelope(t, key, provenanceTestDigest, "https://ci.example.com", "https://github.com/example/app"))
+		err := verifier.verify(t.Context(), []appv1.ProvenancePolicy{policy}, repo, provenanceTestDigest)
+		assert.EqualError(t, err, "no attestation of "+provenanceTestDigest+" satisfies the provenance policy of 'oci://registry.example.com/charts/*': builder 'https://ci.example.com' isn't allowed")
+	})
+
+	t.Run("source repository not allowed", func(t *testing.T) {
+		verifier, _ := newVerifier(newProvenanceTestEnvelope(t, key, provenanceTestDigest, "https://github.com/actions/runner", "https://github.com/other/app"))
+		err := verifier.verify(t.Context(), []appv1.ProvenancePolicy{policy}, repo, provenanceTestDigest)
+		assert.ErrorContains(t, err, "source repository 'https://github.com/other/app' isn't allowed")
+	})
+}
@@ -0,0 +1,427 @@
+package controller
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/argoproj/argo-cd/v3/common"
+	appv1 "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	"github.com/argoproj/argo-cd/v3/reposerver/apiclient"
+	"github.com/argoproj/argo-cd/v3/util/argo"
+	"github.com/argoproj/argo-cd/v3/util/db"
+	"github.com/argoproj/argo-cd/v3/util/env"
+	"github.com/argoproj/argo-cd/v3/util/git"
+	utilio "github.com/argoproj/argo-cd/v3/util/io"
+	jwtutil "github.com/argoproj/argo-cd/v3/util/jwt"
+)
+
+const (
+	// EnvRepoCredsHealthCheckPeriod is an environment variable which sets the period of the validation of the
+	// credentials of the repository and repository credentials secrets, 0 disables it
+	EnvRepoCredsHealthCheckPeriod = "ARGOCD_APPLICATION_CONTROLLER_REPO_CREDS_HEALTH_CHECK_PERIOD"
+	// EnvRepoCredsExpirationWarning is an environment variable which sets how long before their expiration the
+	// credentials of the repository secrets are reported as expiring
+	EnvRepoCredsExpirationWarning = "ARGOCD_APPLICATION_CONTROLLER_REPO_CREDS_EXPIRATION_WARNING"
+)
+
+var (
+	repoCredsHealthCheckPeriod   = env.ParseDurationFromEnv(EnvRepoCredsHealthCheckPeriod, 1*time.Hour, 0, 24*time.Hour)
+	repoCredsExpirationWarning   = env.ParseDurationFromEnv(EnvRepoCredsExpirationWarning, 7*24*time.Hour, 0, 365*24*time.Hour)
+	repoCredsHealthStatusesOrder = []string{repoCredsHealthy, repoCredsExpiring, repoCredsDegraded, repoCredsUnknown}
+)
+
+const (
+	// repoCredsHealthy means the repository was accessed with the credentials, which don't expire soon
+	repoCredsHealthy = "Healthy"
+	// repoCredsExpiring means the repository was accessed with the credentials, which expire soon
+	repoCredsExpiring = "Expiring"
+	// repoCredsDegraded means the secret is invalid, the credentials expired or the repository wasn't accessed
+	repoCredsDegraded = "Degraded"
+	// repoCredsUnknown means no repository uses the credentials of a repository credentials secret
+	repoCredsUnknown = "Unknown"
+)
+
+// repoCredsHealth is the health of the credentials of a repository or repository credentials secret, stored as JSON in
+// the argocd.argoproj.io/credentials-health annotation of the secret
+type repoCredsHealth struct {
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+	// RepoURL is the URL of the repository accessed with the credentials
+	RepoURL string `json:"repoURL,omitempty"`
+	// ExpiresAt is when the credentials expire, if known
+	ExpiresAt          *metav1.Time `json:"expiresAt,omitempty"`
+	LastTransitionTime metav1.Time  `json:"lastTransitionTime"`
+}
+
+// repoCredsHealthMetrics reports the health of the credentials of the repository secrets
+type repoCredsHealthMetrics interface {
+	ResetRepoCredentialsHealth()
+	SetRepoCredentialsHealth(name, secretType, url, status string, expiresAt *time.Time)
+}
+
+// repoCredsHealthChecker periodically validates the credentials of the repository and repository credentials secrets by
+// accessing their repository through the repo server, so that broken or expiring credentials are noticed before the
+// applications fail to fetch their sources. The health is written into an annotation of the secrets, exported as
+// metrics, and its changes are recorded as Kubernetes events on the secrets.
+type repoCredsHealthChecker struct {
+	db                db.ArgoDB
+	kubeClientset     kubernetes.Interface
+	repoClientset     apiclient.Clientset
+	auditLogger       *argo.AuditLogger
+	metrics           repoCredsHealthMetrics
+	expirationWarning time.Duration
+	now               func() time.Time
+}
+
+func newRepoCredsHealthChecker(argoDB db.ArgoDB, kubeClientset kubernetes.Interface, repoClientset apiclient.Clientset, auditLogger *argo.AuditLogger, metrics repoCredsHealthMetrics) *repoCredsHealthChecker {
+	return &repoCredsHealthChecker{
+		db:                argoDB,
+		kubeClientset:     kubeClientset,
+		repoClientset:     repoClientset,
+		auditLogger:       auditLogger,
+		metrics:           metrics,
+		expirationWarning: repoCredsExpirationWarning,
+		now:               time.Now,
+	}
+}
+
+// checkRepoCredsHealth validates the credentials of the repository secrets. Only the first shard validates them, so that
+// the replicas of the controller don't access the repositories concurrently.
+func (ctrl *ApplicationController) checkRepoCredsHealth(ctx context.Context) {
+	if ctrl.clusterSharding.GetShard() != 0 {
+		return
+	}
+	var appRepos []*appv1.Repository
+	for _, obj := range ctrl.appInformer.GetIndexer().List() {
+		if app, ok := obj.(*appv1.Application); ok {
+			for _, source := range app.Spec.GetSources() {
+				appRepos = append(appRepos, sourceRepository(source, app.Spec.GetProject()))
+			}
+		}
+	}
+	ctrl.repoCredsHealth.checkAll(ctx, appRepos)
+}
+
+// sourceRepository returns the repository of an application source in a project, with the type of the source, as used
+// when no repository secret configures the repository
+func sourceRepository(source appv1.ApplicationSource, project string) *appv1.Repository {
+	repo := &appv1.Repository{Repo: source.RepoURL, Project: project}
+	switch {
+	case source.IsOCI():
+		repo.Type = "oci"
+	case source.IsHelm():
+		repo.Type = "helm"
+		repo.EnableOCI = source.IsHelmOci()
+	}
+	return repo
+}
+
+// checkAll validates the credentials of all the repository and repository credentials secrets. The credentials of a
+// repository credentials secret are validated with a repository which inherits them, either configured by a
+// repository secret without credentials or used by an application, whose repositories are given by appRepos.
+func (c *repoCredsHealthChecker) checkAll(ctx context.Context, appRepos []*appv1.Repository) {
+	start := c.now()
+	secrets, err := c.db.ListRepositorySecrets(ctx)
+	if err != nil {
+		log.Warnf("Failed to list the repository secrets to validate their credentials: %v", err)
+		return
+	}
+
+	var repoCreds []*db.RepositorySecret
+	for _, secret := range secrets {
+		if secret.RepoCreds != nil {
+			repoCreds = append(repoCreds, secret)
+		}
+	}
+	inheriting := inheritingRepositories(secrets, appRepos)
+
+	counts := map[string]int{}
+	results := make([]repoCredsHealth, len(secrets))
+	for i, secret := range secrets {
+		results[i] = c.updateHealth(ctx, secret, c.checkSecret(ctx, secret, repoCreds, inheriting))
+		counts[results[i].Status]++
+	}
+
+	c.metrics.ResetRepoCredentialsHealth()
+	for i, secret := range secrets {
+		var expiresAt *time.Time
+		if results[i].ExpiresAt != nil {
+			expiresAt = &results[i].ExpiresAt.Time
+		}
+		c.metrics.SetRepoCredentialsHealth(secret.Secret.Name, secret.Secret.Labels[common.LabelKeySecretType], secretURL(secret), results[i].Status, expiresAt)
+	}
+
+	logCtx := log.WithField("time_ms", c.now().Sub(start).Milliseconds())
+	for _, healthStatus := range repoCredsHealthStatusesOrder {
+		logCtx = logCtx.WithField(strings.ToLower(healthStatus), counts[healthStatus])
+	}
+	logCtx.Info("Validated the credentials of the repository secrets")
+}
+
+// checkSecret returns the health of the credentials of a repository or repository credentials secret
+func (c *repoCredsHealthChecker) checkSecret(ctx context.Context, secret *db.RepositorySecret, repoCreds []*db.RepositorySecret, inheriting []*appv1.Repository) repoCredsHealth {
+	if secret.Err != nil {
+		return repoCredsHealth{Status: repoCredsDegraded, Message: fmt.Sprintf("invalid secret: %v", secret.Err)}
+	}
+
+	var repo *appv1.Repository
+	if secret.Repository != nil {
+		repo = secret.Repository.DeepCopy()
+		if !repo.HasCredentials() {
+			if creds := matchRepoCreds(repo.Repo, repoCreds); creds != nil && creds.Err == nil {
+				repo.CopyCredentialsFrom(creds.RepoCreds)
+			}
+		}
+	} else {
+		for _, candidate := range inheriting {
+			if matchRepoCreds(candidate.Repo, repoCreds) == secret {
+				repo = candidate.DeepCopy()
+				repo.CopyCredentialsFrom(secret.RepoCreds)
+				// the Helm OCI charts of the applications are pulled with OCI even if the credentials don't enable it
+				repo.EnableOCI = repo.EnableOCI || candidate.EnableOCI
+				break
+			}
+		}
+	}
+
+	var health repoCredsHealth
+	if expiresAt := credentialsExpiration(secret); expiresAt != nil {
+		health.ExpiresAt = &metav1.Time{Time: *expiresAt}
+	}
+	now := c.now()
+	switch {
+	case health.ExpiresAt != nil && !health.ExpiresAt.Time.After(now):
+		health.Status = repoCredsDegraded
+		health.Message = fmt.Sprintf("the credentials expired at %s", health.ExpiresAt.UTC().Format(time.RFC3339))
+		return health
+	case repo != nil:
+		health.RepoURL = repo.Repo
+		if err := c.testRepository(ctx, repo); err != nil {
+			health.Status = repoCredsDegraded
+			health.Message = status.Convert(err).Message()
+			return health
+		}
+	}
+	switch {
+	case health.ExpiresAt != nil && health.ExpiresAt.Time.Before(now.Add(c.expirationWarning)):
+		health.Status = repoCredsExpiring
+		health.Message = fmt.Sprintf("the credentials expire at %s", health.ExpiresAt.UTC().Format(time.RFC3339))
+	case repo == nil:
+		health.Status = repoCredsUnknown
+		health.Message = "no repository uses the credentials"
+	default:
+		health.Status = repoCredsHealthy
+	}
+	return health
+}
+
+func (c *repoCredsHealthChecker) testRepository(ctx context.Context, repo *appv1.Repository) error {
+	conn, repoClient, err := c.repoClientset.NewRepoServerClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to repo-server: %w", err)
+	}
+	defer utilio.Close(conn)
+
+	_, err = repoClient.TestRepository(ctx, &apiclient.TestRepositoryRequest{Repo: repo})
+	return err
+}
+
+// updateHealth writes the health into the annotation of the secret if it changed, and records an event on the secret
+// when its status changes. It returns the health with its last transition time.
+func (c *repoCredsHealthChecker) updateHealth(ctx context.Context, secret *db.RepositorySecret, health repoCredsHealth) repoCredsHealth {
+	var previous repoCredsHealth
+	if value, ok := secret.Secret.Annotations[common.AnnotationKeyCredentialsHealth]; ok {
+		if err := json.Unmarshal([]byte(value), &previous); err != nil {
+			log.Warnf("Invalid %s annotation of secret %s: %v", common.AnnotationKeyCredentialsHealth, secret.Secret.Name, err)
+		}
+	}
+	if previous.Status == health.Status {
+		health.LastTransitionTime = previous.LastTransitionTime
+	} else {
+		health.LastTransitionTime = metav1.Time{Time: c.now().Truncate(time.Second)}
+	}
+	if previous.Status == health.Status && previous.Message == health.Message && previous.RepoURL == health.RepoURL &&
+		previous.ExpiresAt.Equal(health.ExpiresAt) {
+		return health
+	}
+
+	value, err := json.Marshal(health)
+	if err != nil {
+		log.Warnf("Failed to marshal the credentials health of secret %s: %v", secret.Secret.Name, err)
+		return health
+	}
+	patch, err := json.Marshal(map[string]any{
+		"metadata": map[string]any{
+			"annotations": map[string]string{common.AnnotationKeyCredentialsHealth: string(value)},
+		},
+	})
+	if err != nil {
+		log.Warnf("Failed to marshal the credentials health of secret %s: %v", secret.Secret.Name, err)
+		return health
+	}
+	_, err = c.kubeClientset.CoreV1().Secrets(secret.Secret.Namespace).Patch(ctx, secret.Secret.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		log.Warnf("Failed to update the credentials health of secret %s: %v", secret.Secret.Name, err)
+	}
+
+	if previous.Status != health.Status {
+		c.recordEvent(secret, previous, health)
+	}
+	return health
+}
+
+// recordEvent records an event on the secret when its credentials become degraded or expiring, or recover from it
+func (c *repoCredsHealthChecker) recordEvent(secret *db.RepositorySecret, previous repoCredsHealth, health repoCredsHealth) {
+	var info argo.EventInfo
+	switch health.Status {
+	case repoCredsDegraded:
+		info = argo.EventInfo{Type: corev1.EventTypeWarning, Reason: argo.EventReasonCredentialsDegraded}
+	case repoCredsExpiring:
+		info = argo.EventInfo{Type: corev1.EventTypeWarning, Reason: argo.EventReasonCredentialsExpiring}
+	case repoCredsHealthy:
+		if previous.Status != repoCredsDegraded && previous.Status != repoCredsExpiring {
+			return
+		}
+		info = argo.EventInfo{Type: corev1.EventTypeNormal, Reason: argo.EventReasonCredentialsHealthy}
+	default:
+		return
+	}
+	message := fmt.Sprintf("Credentials of %s are %s", secretURL(secret), strings.ToLower(health.Status))
+	if health.Message != "" {
+		message = fmt.Sprintf("%s: %s", message, health.Message)
+	}
+	c.auditLogger.LogSecretEvent(secret.Secret, info, message, map[string]string{"url": secretURL(secret)})
+}
+
+// inheritingRepositories returns the repositories which may inherit the credentials of a repository credentials
+// secret, i.e. the repositories of the repository secrets without credentials and the repositories of the applications
+// which have no repository secret with credentials, sorted by URL and project. The repository of an application is
+// configured by the repository secret scoped to the project of the application if any, or by the global one otherwise,
+// the same way the repositories of the applications are resolved.
+func inheritingRepositories(secrets []*db.RepositorySecret, appRepos []*appv1.Repository) []*appv1.Repository {
+	type repoKey struct{ url, project string }
+	byKey := map[repoKey]*appv1.Repository{}
+	withCredentials := map[repoKey]bool{}
+	for _, secret := range secrets {
+		if secret.Repository == nil || secret.Err != nil {
+			continue
+		}
+		key := repoKey{git.NormalizeGitURL(secret.Repository.Repo), secret.Repository.Project}
+		if secret.Repository.HasCredentials() {
+			withCredentials[key] = true
+		} else if _, ok := byKey[key]; !ok {
+			byKey[key] = secret.Repository
+		}
+	}
+	for _, repo := range appRepos {
+		if repo.Repo == "" {
+			continue
+		}
+		key := repoKey{git.NormalizeGitURL(repo.Repo), repo.Project}
+		_, configured := byKey[key]
+		if !configured && !withCredentials[key] {
+			// the applications use the global repository secret if no repository secret is scoped to their project
+			key.project = ""
+			_, configured = byKey[key]
+			if !configured && !withCredentials[key] {
+				byKey[repoKey{key.url, repo.Project}] = repo
+			}
+		}
+	}
+
+	var result []*appv1.Repository
+	for key, repo := range byKey {
+		if !withCredentials[key] {
+			result = append(result, repo)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Repo != result[j].Repo {
+			return result[i].Repo < result[j].Repo
+		}
+		return result[i].Project < result[j].Project
+	})
+	return result
+}
+
+// matchRepoCreds returns the repository credentials secret whose URL is the longest prefix of the URL of a repository,
+// the same way the repository credentials are inherited
+func matchRepoCreds(repoURL string, repoCreds []*db.RepositorySecret) *db.RepositorySecret {
+	var match *db.RepositorySecret
+	maxLen := 0
+	repoURL = git.NormalizeGitURL(repoURL)
+	for _, creds := range repoCreds {
+		credsURL := git.NormalizeGitURL(creds.RepoCreds.URL)
+		if strings.HasPrefix(repoURL, credsURL) && len(credsURL) > maxLen {
+			maxLen = len(credsURL)
+			match = creds
+		}
+	}
+	return match
+}
+
+// credentialsExpiration returns the earliest expiration of the TLS client certificate and of the JWT bearer token or
+// password of a repository secret, or nil if none of its credentials expire
+func credentialsExpiration(secret *db.RepositorySecret) *time.Time {
+	var tlsClientCertData, bearerToken, password string
+	if secret.Repository != nil {
+		tlsClientCertData, bearerToken, password = secret.Repository.TLSClientCertData, secret.Repository.BearerToken, secret.Repository.Password
+	} else {
+		tlsClientCertData, bearerToken, password = secret.RepoCreds.TLSClientCertData, secret.RepoCreds.BearerToken, secret.RepoCreds.Password
+	}
+
+	var result *time.Time
+	earliest := func(t time.Time) {
+		if result == nil || t.Before(*result) {
+			result = &t
+		}
+	}
+	rest := []byte(tlsClientCertData)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		if cert, err := x509.ParseCertificate(block.Bytes); err == nil {
+			earliest(cert.NotAfter)
+		}
+	}
+	for _, token := range []string{bearerToken, password} {
+		if !jwtutil.IsValid(token) {
+			continue
+		}
+		claims := jwt.MapClaims{}
+		if _, _, err := jwt.NewParser().ParseUnverified(token, claims); err != nil {
+			continue
+		}
+		if exp, err := claims.GetExpirationTime(); err == nil && exp != nil {
+			earliest(exp.Time)
+		}
+	}
+	return result
+}
+
+func secretURL(secret *db.RepositorySecret) string {
+	if secret.Repository != nil {
+		return secret.Repository.Repo
+	}
+	return secret.RepoCreds.URL
+}
@@ -0,0 +1,228 @@
+package controller
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/argoproj/argo-cd/v3/common"
+	appv1 "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	"github.com/argoproj/argo-cd/v3/reposerver/apiclient"
+	mockrepoclient "github.com/argoproj/argo-cd/v3/reposerver/apiclient/mocks"
+	"github.com/argoproj/argo-cd/v3/test"
+	"github.com/argoproj/argo-cd/v3/util/argo"
+	"github.com/argoproj/argo-cd/v3/util/db"
+	dbmocks "github.com/argoproj/argo-cd/v3/util/db/mocks"
+)
+
+type fakeRepoCredsHealthMetrics struct {
+	statuses map[string]string
+	expiries map[string]time.Time
+}
+
+func (m *fakeRepoCredsHealthMetrics) ResetRepoCredentialsHealth() {
+	m.statuses = map[string]string{}
+	m.expiries = map[string]time.Time{}
+}
+
+func (m *fakeRepoCredsHealthMetrics) SetRepoCredentialsHealth(name, _, _, status string, expiresAt *time.Time) {
+	m.statuses[name] = status
+	if expiresAt != nil {
+		m.expiries[name] = *expiresAt
+	}
+}
+
+func newRepoSecret(name, secretType string, annotations map[string]string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   test.FakeArgoCDNamespace,
+			Labels:      map[string]string{common.LabelKeySecretType: secretType},
+			Annotations: annotations,
+		},
+	}
+}
+
+func newClientCertificate(t *testing.T, notAfter time.Time) string {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{SerialNumber: big.NewInt(1), NotBefore: notAfter.Add(-time.Hour), NotAfter: notAfter}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+func getCredentialsHealth(t *testing.T, secret *corev1.Secret) repoCredsHealth {
+	t.Helper()
+	var health repoCredsHealth
+	require.NoError(t, json.Unmarshal([]byte(secret.Annotations[common.AnnotationKeyCredentialsHealth]), &health))
+	return health
+}
+
+func TestRepoCredsHealthChecker(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	previouslyHealthy, err := json.Marshal(repoCredsHealth{Status: repoCredsHealthy, RepoURL: "https://github.com/org/broken"})
+	require.NoError(t, err)
+
+	healthySecret := newRepoSecret("repo-healthy", common.LabelValueSecretTypeRepository, nil)
+	brokenSecret := newRepoSecret("repo-broken", common.LabelValueSecretTypeRepository, map[string]string{common.AnnotationKeyCredentialsHealth: string(previouslyHealthy)})
+	expiringSecret := newRepoSecret("creds-org2", common.LabelValueSecretTypeRepoCreds, nil)
+	unusedSecret := newRepoSecret("creds-unused", common.LabelValueSecretTypeRepoCreds, nil)
+	invalidSecret := newRepoSecret("repo-invalid", common.LabelValueSecretTypeRepository, nil)
+	kubeClientset := fake.NewClientset(healthySecret, brokenSecret, expiringSecret, unusedSecret, invalidSecret)
+
+	argoDB := &dbmocks.ArgoDB{}
+	argoDB.On("ListRepositorySecrets", mock.Anything).Return([]*db.RepositorySecret{
+		{Secret: healthySecret, Repository: &appv1.Repository{Repo: "https://github.com/org/ok", Username: "user", Password: "pass"}},
+		{Secret: brokenSecret, Repository: &appv1.Repository{Repo: "https://github.com/org/broken", Username: "user", Password: "revoked"}},
+		{Secret: expiringSecret, RepoCreds: &appv1.RepoCreds{URL: "https://github.com/org2", Username: "org2", TLSClientCertData: newClientCertificate(t, now.Add(48*time.Hour))}},
+		{Secret: unusedSecret, RepoCreds: &appv1.RepoCreds{URL: "https://gitlab.com", Username: "unused", Password: "pass"}},
+		{Secret: invalidSecret, Repository: &appv1.Repository{Repo: "https://github.com/org/invalid"}, Err: errors.New(`invalid value "maybe" of insecure`)},
+	}, nil)
+
+	repoClient := &mockrepoclient.RepoServerServiceClient{}
+	repoClient.On("TestRepository", mock.Anything, mock.MatchedBy(func(req *apiclient.TestRepositoryRequest) bool {
+		return req.Repo.Repo == "https://github.com/org/broken"
+	})).Return(nil, errors.New("authentication required"))
+	repoClient.On("TestRepository", mock.Anything, mock.MatchedBy(func(req *apiclient.TestRepositoryRequest) bool {
+		// the credentials of the repository credentials secret are inherited by the repository of the application
+		return req.Repo.Repo == "https://github.com/org2/app" && req.Repo.Username == "org2" || req.Repo.Repo == "https://github.com/org/ok"
+	})).Return(&apiclient.TestRepositoryResponse{VerifiedRepository: true}, nil)
+
+	metrics := &fakeRepoCredsHealthMetrics{}
+	checker := newRepoCredsHealthChecker(argoDB, kubeClientset, &mockrepoclient.Clientset{RepoServerServiceClient: repoClient}, argo.NewAuditLogger(kubeClientset, common.ApplicationController, argo.DefaultEnableEventList()), metrics)
+	checker.now = func() time.Time { return now }
+	checker.checkAll(t.Context(), []*appv1.Repository{{Repo: "https://github.com/org2/app"}, {Repo: "https://github.com/org/ok"}})
+
+	assert.Equal(t, map[string]string{
+		"repo-healthy": repoCredsHealthy,
+		"repo-broken":  repoCredsDegraded,
+		"creds-org2":   repoCredsExpiring,
+		"creds-unused": repoCredsUnknown,
+		"repo-invalid": repoCredsDegraded,
+	}, metrics.statuses)
+	assert.Equal(t, map[string]time.Time{"creds-org2": now.Add(48 * time.Hour)}, metrics.expiries)
+
+	secrets := map[string]*corev1.Secret{}
+	list, err := kubeClientset.CoreV1().Secrets(test.FakeArgoCDNamespace).List(t.Context(), metav1.ListOptions{})
+	require.NoError(t, err)
+	for i := range list.Items {
+		secrets[list.Items[i].Name] = &list.Items[i]
+	}
+
+	health := getCredentialsHealth(t, secrets["repo-broken"])
+	assert.Equal(t, repoCredsDegraded, health.Status)
+	assert.Equal(t, "authentication required", health.Message)
+	assert.Equal(t, now, health.LastTransitionTime.UTC())
+
+	health = getCredentialsHealth(t, secrets["creds-org2"])
+	assert.Equal(t, repoCredsExpiring, health.Status)
+	assert.Equal(t, "https://github.com/org2/app", health.RepoURL)
+	assert.Equal(t, now.Add(48*time.Hour), health.ExpiresAt.UTC())
+
+	health = getCredentialsHealth(t, secrets["creds-unused"])
+	assert.Equal(t, repoCredsUnknown, health.Status)
+	assert.Equal(t, "no repository uses the credentials", health.Message)
+
+	health = getCredentialsHealth(t, secrets["repo-invalid"])
+	assert.Equal(t, repoCredsDegraded, health.Status)
+	assert.Contains(t, health.Message, "invalid secret")
+
+	events, err := kubeClientset.CoreV1().Events(test.FakeArgoCDNamespace).List(t.Context(), metav1.ListOptions{})
+	require.NoError(t, err)
+	reasons := map[string]string{}
+	for _, event := range events.Items {
+		reasons[event.InvolvedObject.Name] = event.Reason
+	}
+	// no event is recorded for the first validation of healthy credentials nor for the unused credentials
+	assert.Equal(t, map[string]string{
+		"repo-broken":  argo.EventReasonCredentialsDegraded,
+		"creds-org2":   argo.EventReasonCredentialsExpiring,
+		"repo-invalid": argo.EventReasonCredentialsDegraded,
+	}, reasons)
+}
+
+func TestRepoCredsHealthChecker_Expired(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	checker := newRepoCredsHealthChecker(nil, nil, nil, nil, nil)
+	checker.now = func() time.Time { return now }
+
+	// the repository isn't accessed with credentials which expired
+	health := checker.checkSecret(t.Context(), &db.RepositorySecret{
+		Secret:     newRepoSecret("repo-expired", common.LabelValueSecretTypeRepository, nil),
+		Repository: &appv1.Repository{Repo: "https://github.com/org/repo", TLSClientCertData: newClientCertificate(t, now.Add(-time.Hour))},
+	}, nil, nil)
+	assert.Equal(t, repoCredsDegraded, health.Status)
+	assert.Equal(t, "the credentials expired at 2025-12-31T23:00:00Z", health.Message)
+}
+
+func TestMatchRepoCreds(t *testing.T) {
+	org := &db.RepositorySecret{RepoCreds: &appv1.RepoCreds{URL: "https://github.com/org"}}
+	team := &db.RepositorySecret{RepoCreds: &appv1.RepoCreds{URL: "https://github.com/org/team"}}
+	repoCreds := []*db.RepositorySecret{org, team}
+
+	assert.Same(t, team, matchRepoCreds("https://github.com/org/team/repo", repoCreds))
+	assert.Same(t, org, matchRepoCreds("https://github.com/org/repo.git", repoCreds))
+	assert.Nil(t, matchRepoCreds("https://gitlab.com/org/repo", repoCreds))
+}
+
+func TestInheritingRepositories(t *testing.T) {
+	secrets := []*db.RepositorySecret{
+		{Repository: &appv1.Repository{Repo: "https://github.com/org/global", Username: "user", Password: "pass"}},
+		{Repository: &appv1.Repository{Repo: "https://github.com/org/scoped", Project: "team", Username: "user", Password: "pass"}},
+		{Repository: &appv1.Repository{Repo: "https://github.com/org/no-creds", Type: "git"}},
+	}
+	chart := appv1.ApplicationSource{RepoURL: "registry.example.com/charts", Chart: "app"}
+	appRepos := []*appv1.Repository{
+		sourceRepository(appv1.ApplicationSource{RepoURL: "https://github.com/org/global"}, "team"),
+		sourceRepository(appv1.ApplicationSource{RepoURL: "https://github.com/org/scoped"}, "team"),
+		sourceRepository(appv1.ApplicationSource{RepoURL: "https://github.com/org/scoped"}, "other"),
+		sourceRepository(appv1.ApplicationSource{RepoURL: "https://github.com/org/no-creds"}, "team"),
+		sourceRepository(chart, "team"),
+	}
+
+	repos := inheritingRepositories(secrets, appRepos)
+	// the repositories with credentials for the project of the application don't inherit the repository credentials
+	require.Len(t, repos, 3)
+	assert.Equal(t, "https://github.com/org/no-creds", repos[0].Repo)
+	assert.Empty(t, repos[0].Project)
+	assert.Equal(t, "https://github.com/org/scoped", repos[1].Repo)
+	assert.Equal(t, "other", repos[1].Project)
+	assert.Equal(t, "registry.example.com/charts", repos[2].Repo)
+	assert.Equal(t, "helm", repos[2].Type)
+	assert.True(t, repos[2].EnableOCI)
+}
+
+func TestRepoCredsHealthChecker_HelmRepository(t *testing.T) {
+	checker := newRepoCredsHealthChecker(nil, nil, nil, nil, nil)
+	repoClient := &mockrepoclient.RepoServerServiceClient{}
+	repoClient.On("TestRepository", mock.Anything, mock.MatchedBy(func(req *apiclient.TestRepositoryRequest) bool {
+		return req.Repo.Type == "helm" && req.Repo.EnableOCI && req.Repo.Username == "charts"
+	})).Return(&apiclient.TestRepositoryResponse{VerifiedRepository: true}, nil)
+	checker.repoClientset = &mockrepoclient.Clientset{RepoServerServiceClient: repoClient}
+
+	// the type of the repository is the one of the chart of the application, and OCI is enabled for its OCI registry
+	secret := &db.RepositorySecret{
+		Secret:    newRepoSecret("creds-charts", common.LabelValueSecretTypeRepoCreds, nil),
+		RepoCreds: &appv1.RepoCreds{URL: "registry.example.com", Username: "charts", Password: "pass"},
+	}
+	chart := sourceRepository(appv1.ApplicationSource{RepoURL: "registry.example.com/charts", Chart: "app"}, "default")
+	health := checker.checkSecret(t.Context(), secret, []*db.RepositorySecret{secret}, []*appv1.Repository{chart})
+	assert.Equal(t, repoCredsHealthy, health.Status)
+	repoClient.AssertExpectations(t)
+}
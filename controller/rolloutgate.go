@@ -0,0 +1,101 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/argoproj/gitops-engine/pkg/health"
+	log "github.com/sirupsen/logrus"
+
+	appv1 "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	applog "github.com/argoproj/argo-cd/v3/util/app/log"
+	"github.com/argoproj/argo-cd/v3/util/argo"
+	"github.com/argoproj/argo-cd/v3/util/env"
+	"github.com/argoproj/argo-cd/v3/util/lua"
+)
+
+const (
+	// rolloutGateRecheckPeriod is the period at which a sync waiting for the Argo Rollouts in progress checks them again
+	rolloutGateRecheckPeriod = 10 * time.Second
+	// rolloutGroup is the API group of the Argo Rollouts
+	rolloutGroup = "argoproj.io"
+	// rolloutKind is the kind of the Argo Rollouts
+	rolloutKind = "Rollout"
+
+	// EnvSuspendedRolloutsTimeout is an environment variable which sets how long the syncs wait for the paused Argo
+	// Rollouts of the application, 0 makes them wait until the rollouts are resumed or aborted
+	EnvSuspendedRolloutsTimeout = "ARGOCD_APPLICATION_CONTROLLER_SUSPENDED_ROLLOUTS_TIMEOUT"
+)
+
+var suspendedRolloutsTimeout = env.ParseDurationFromEnv(EnvSuspendedRolloutsTimeout, 1*time.Hour, 0, math.MaxInt64)
+
+// deferSyncUntilRolloutsComplete returns true if the sync operation of the application is deferred because its sync
+// policy waits for the Argo Rollouts of the application which are in progress to complete or abort, so that the new
+// revision doesn't replace the template of a rollout in the middle of a canary. The syncs which already started to
+// apply resources are never deferred, and the syncs stop waiting for the paused rollouts, which may wait indefinitely for
+// a manual promotion, once the suspended rollouts timeout expires. The operation stays in progress with a message
+// listing the rollouts, and the rollouts are checked again later.
+func (ctrl *ApplicationController) deferSyncUntilRolloutsComplete(app *appv1.Application, project *appv1.AppProject, state *appv1.OperationState) bool {
+	if state.Operation.Sync == nil || state.Operation.Sync.DryRun || state.SyncResult != nil && len(state.SyncResult.Resources) > 0 {
+		return false
+	}
+	if syncPolicy := project.GetApplicationSyncPolicy(app.Spec.SyncPolicy); syncPolicy == nil || !syncPolicy.WaitForRollouts {
+		return false
+	}
+	logCtx := log.WithFields(applog.GetAppLogFields(app))
+	includeSuspended := suspendedRolloutsTimeout == 0 || time.Since(state.StartedAt.Time) < suspendedRolloutsTimeout
+	rollouts, err := ctrl.getRolloutsInProgress(app, includeSuspended)
+	if err != nil {
+		// the sync proceeds, and fails if the live state of the application can't be loaded
+		logCtx.Warnf("Failed to get the rollouts in progress: %v", err)
+		return false
+	}
+	if len(rollouts) == 0 {
+		return false
+	}
+	message := "Waiting for the rollouts in progress to complete or abort: " + strings.Join(rollouts, ", ")
+	if state.Message != message {
+		logCtx.Infof("Deferring sync: %s", message)
+		state.Message = message
+		ctrl.setOperationState(app, state)
+	}
+	ctrl.appOperationQueue.AddAfter(ctrl.toAppKey(app.QualifiedName()), rolloutGateRecheckPeriod)
+	return true
+}
+
+// getRolloutsInProgress returns the namespaced names of the Argo Rollouts of the application which are progressing, or
+// paused if includeSuspended is true, according to the live state cache
+func (ctrl *ApplicationController) getRolloutsInProgress(app *appv1.Application, includeSuspended bool) ([]string, error) {
+	destCluster, err := argo.GetDestinationCluster(context.Background(), app.Spec.Destination, ctrl.db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the destination cluster: %w", err)
+	}
+	liveObjs, err := ctrl.stateCache.GetManagedLiveObjs(destCluster, app, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the live objects: %w", err)
+	}
+	resourceOverrides, err := ctrl.settingsMgr.GetResourceOverrides()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the resource overrides: %w", err)
+	}
+	healthOverrides := lua.ResourceHealthOverrides(resourceOverrides)
+	var rollouts []string
+	for key, obj := range liveObjs {
+		if obj == nil || key.Group != rolloutGroup || key.Kind != rolloutKind {
+			continue
+		}
+		rolloutHealth, err := health.GetResourceHealth(obj, healthOverrides)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the health of rollout %s/%s: %w", key.Namespace, key.Name, err)
+		}
+		if rolloutHealth != nil && (rolloutHealth.Status == health.HealthStatusProgressing || includeSuspended && rolloutHealth.Status == health.HealthStatusSuspended) {
+			rollouts = append(rollouts, key.Namespace+"/"+key.Name)
+		}
+	}
+	sort.Strings(rollouts)
+	return rollouts, nil
+}
@@ -0,0 +1,85 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/argoproj/gitops-engine/pkg/utils/kube"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+)
+
+func newFakeRollout(name, phase string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "argoproj.io/v1alpha1",
+		"kind":       "Rollout",
+		"metadata":   map[string]any{"name": name, "namespace": "default", "generation": int64(1)},
+		"spec":       map[string]any{"strategy": map[string]any{"canary": map[string]any{}}},
+		"status":     map[string]any{"observedGeneration": "1", "phase": phase},
+	}}
+}
+
+func TestDeferSyncUntilRolloutsComplete(t *testing.T) {
+	liveObjs := map[kube.ResourceKey]*unstructured.Unstructured{}
+	for name, phase := range map[string]string{"paused": "Paused", "progressing": "Progressing", "healthy": "Healthy", "aborted": "Degraded"} {
+		rollout := newFakeRollout(name, phase)
+		liveObjs[kube.GetResourceKey(rollout)] = rollout
+	}
+	newApp := func(waitForRollouts bool) *v1alpha1.Application {
+		app := newFakeApp()
+		app.Spec.SyncPolicy = &v1alpha1.SyncPolicy{WaitForRollouts: waitForRollouts}
+		app.Operation = &v1alpha1.Operation{Sync: &v1alpha1.SyncOperation{}}
+		app.Status.OperationState = nil
+		return app
+	}
+
+	t.Run("sync waits for the rollouts in progress", func(t *testing.T) {
+		app := newApp(true)
+		ctrl := newFakeController(&fakeData{apps: []runtime.Object{app, &defaultProj}, managedLiveObjs: liveObjs}, nil)
+		state := NewOperationState(*app.Operation)
+		assert.True(t, ctrl.deferSyncUntilRolloutsComplete(app, &defaultProj, state))
+		assert.Equal(t, "Waiting for the rollouts in progress to complete or abort: default/paused, default/progressing", state.Message)
+	})
+
+	t.Run("sync stops waiting for the paused rollouts after the timeout", func(t *testing.T) {
+		app := newApp(true)
+		ctrl := newFakeController(&fakeData{apps: []runtime.Object{app, &defaultProj}, managedLiveObjs: liveObjs}, nil)
+		state := NewOperationState(*app.Operation)
+		state.StartedAt = metav1.NewTime(time.Now().Add(-suspendedRolloutsTimeout))
+		assert.True(t, ctrl.deferSyncUntilRolloutsComplete(app, &defaultProj, state))
+		assert.Equal(t, "Waiting for the rollouts in progress to complete or abort: default/progressing", state.Message)
+
+		paused := newFakeRollout("paused", "Paused")
+		ctrl = newFakeController(&fakeData{apps: []runtime.Object{app, &defaultProj}, managedLiveObjs: map[kube.ResourceKey]*unstructured.Unstructured{
+			kube.GetResourceKey(paused): paused,
+		}}, nil)
+		assert.False(t, ctrl.deferSyncUntilRolloutsComplete(app, &defaultProj, state))
+	})
+
+	t.Run("sync which already started isn't deferred", func(t *testing.T) {
+		app := newApp(true)
+		ctrl := newFakeController(&fakeData{apps: []runtime.Object{app, &defaultProj}, managedLiveObjs: liveObjs}, nil)
+		state := NewOperationState(*app.Operation)
+		state.SyncResult = &v1alpha1.SyncOperationResult{Resources: v1alpha1.ResourceResults{{Kind: "Rollout", Name: "paused"}}}
+		assert.False(t, ctrl.deferSyncUntilRolloutsComplete(app, &defaultProj, state))
+	})
+
+	t.Run("sync policy doesn't wait for the rollouts", func(t *testing.T) {
+		app := newApp(false)
+		ctrl := newFakeController(&fakeData{apps: []runtime.Object{app, &defaultProj}, managedLiveObjs: liveObjs}, nil)
+		assert.False(t, ctrl.deferSyncUntilRolloutsComplete(app, &defaultProj, NewOperationState(*app.Operation)))
+	})
+
+	t.Run("no rollout in progress", func(t *testing.T) {
+		app := newApp(true)
+		healthy := newFakeRollout("healthy", "Healthy")
+		ctrl := newFakeController(&fakeData{apps: []runtime.Object{app, &defaultProj}, managedLiveObjs: map[kube.ResourceKey]*unstructured.Unstructured{
+			kube.GetResourceKey(healthy): healthy,
+		}}, nil)
+		assert.False(t, ctrl.deferSyncUntilRolloutsComplete(app, &defaultProj, NewOperationState(*app.Operation)))
+	})
+}
@@ -0,0 +1,80 @@
+package controller
+
+import (
+	"fmt"
+	"reflect"
+
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/labels"
+
+	appv1 "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	"github.com/argoproj/argo-cd/v3/util/settings"
+)
+
+// comparisonSettings are the settings used by the comparison of the applications with their target state
+type comparisonSettings struct {
+	resourceOverrides   map[string]appv1.ResourceOverride
+	compareOptions      settings.ArgoCDDiffOptions
+	resourcesFilter     *settings.ResourcesFilter
+	appInstanceLabelKey string
+	trackingMethod      string
+	installationID      string
+}
+
+func (ctrl *ApplicationController) loadComparisonSettings() (*comparisonSettings, error) {
+	resourceOverrides, err := ctrl.settingsMgr.GetResourceOverrides()
+	if err != nil {
+		return nil, fmt.Errorf("error getting resource overrides: %w", err)
+	}
+	compareOptions, err := ctrl.settingsMgr.GetResourceCompareOptions()
+	if err != nil {
+		return nil, fmt.Errorf("error getting resource compare options: %w", err)
+	}
+	resourcesFilter, err := ctrl.settingsMgr.GetResourcesFilter()
+	if err != nil {
+		return nil, fmt.Errorf("error getting resources filter: %w", err)
+	}
+	appInstanceLabelKey, err := ctrl.settingsMgr.GetAppInstanceLabelKey()
+	if err != nil {
+		return nil, fmt.Errorf("error getting app instance label key: %w", err)
+	}
+	trackingMethod, err := ctrl.settingsMgr.GetTrackingMethod()
+	if err != nil {
+		return nil, fmt.Errorf("error getting tracking method: %w", err)
+	}
+	installationID, err := ctrl.settingsMgr.GetInstallationID()
+	if err != nil {
+		return nil, fmt.Errorf("error getting installation ID: %w", err)
+	}
+	return &comparisonSettings{resourceOverrides, compareOptions, resourcesFilter, appInstanceLabelKey, trackingMethod, installationID}, nil
+}
+
+// reloadComparisonSettings refreshes the applications when the settings used by their comparison change, such as the
+// resource customizations or the compare options, so that their status reflects the new settings without waiting for
+// their next reconciliation. It is called serially by the settings manager, see settings.SettingsManager.RegisterConsumer.
+func (ctrl *ApplicationController) reloadComparisonSettings() error {
+	next, err := ctrl.loadComparisonSettings()
+	if err != nil {
+		return err
+	}
+	prev := ctrl.comparisonSettings
+	ctrl.comparisonSettings = next
+	if prev == nil || reflect.DeepEqual(*prev, *next) {
+		return nil
+	}
+
+	apps, err := ctrl.appLister.List(labels.Everything())
+	if err != nil {
+		return fmt.Errorf("error listing applications: %w", err)
+	}
+	refreshed := 0
+	for _, app := range apps {
+		if !ctrl.canProcessApp(app) {
+			continue
+		}
+		ctrl.requestAppRefresh(app.QualifiedName(), CompareWithRecent.Pointer(), nil)
+		refreshed++
+	}
+	log.Infof("Comparison settings changed, refreshing %d applications", refreshed)
+	return nil
+}
@@ -0,0 +1,38 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/argoproj/argo-cd/v3/common"
+	"github.com/argoproj/argo-cd/v3/test"
+)
+
+func TestReloadComparisonSettings(t *testing.T) {
+	app := newFakeApp()
+	ctrl := newFakeController(&fakeData{apps: []runtime.Object{app}}, nil)
+
+	// the first reload only records the settings, and the unchanged settings don't refresh the applications
+	require.NoError(t, ctrl.reloadComparisonSettings())
+	require.NoError(t, ctrl.reloadComparisonSettings())
+	assert.Equal(t, 0, ctrl.appRefreshQueue.Len())
+
+	cm, err := ctrl.kubeClientset.CoreV1().ConfigMaps(test.FakeArgoCDNamespace).Get(t.Context(), common.ArgoCDConfigMapName, metav1.GetOptions{})
+	require.NoError(t, err)
+	cm.Data = map[string]string{"resource.compareoptions": "ignoreAggregatedRoles: true"}
+	cm.ResourceVersion = "2"
+	_, err = ctrl.kubeClientset.CoreV1().ConfigMaps(test.FakeArgoCDNamespace).Update(t.Context(), cm, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		compareOptions, err := ctrl.settingsMgr.GetResourceCompareOptions()
+		return err == nil && compareOptions.IgnoreAggregatedRoles
+	}, 5*time.Second, 10*time.Millisecond)
+
+	require.NoError(t, ctrl.reloadComparisonSettings())
+	assert.Equal(t, 1, ctrl.appRefreshQueue.Len())
+}
@@ -0,0 +1,37 @@
+package controller
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/labels"
+
+	appv1 "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+)
+
+// validateSourceNamespaceQuota returns an error if the application exceeds the maximum number of applications of the
+// source namespace rule of its project matching its namespace. The API server rejects the applications exceeding the
+// quota, but the applications created concurrently or without the API server may still exceed it: the applications
+// of the project in the namespace are ordered by creation time and name, and the ones beyond the maximum exceed it.
+func (ctrl *ApplicationController) validateSourceNamespaceQuota(app *appv1.Application, proj *appv1.AppProject) error {
+	rule := proj.GetSourceNamespaceRule(app.Namespace)
+	if rule == nil || rule.MaxApps <= 0 {
+		return nil
+	}
+	apps, err := ctrl.appLister.Applications(app.Namespace).List(labels.Everything())
+	if err != nil {
+		return fmt.Errorf("error listing applications: %w", err)
+	}
+	count := int64(0)
+	for _, a := range apps {
+		if a.Spec.GetProject() != proj.Name || a.Name == app.Name {
+			continue
+		}
+		if a.CreationTimestamp.Before(&app.CreationTimestamp) || a.CreationTimestamp.Equal(&app.CreationTimestamp) && a.Name < app.Name {
+			count++
+		}
+	}
+	if count >= rule.MaxApps {
+		return fmt.Errorf("project %s already has the maximum number of %d applications in the namespace %s", proj.Name, rule.MaxApps, app.Namespace)
+	}
+	return nil
+}
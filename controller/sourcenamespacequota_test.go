@@ -0,0 +1,45 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+)
+
+func TestValidateSourceNamespaceQuota(t *testing.T) {
+	createdAt := metav1.NewTime(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	newApp := func(name string, creationDelay time.Duration, project string) *v1alpha1.Application {
+		app := newFakeApp()
+		app.Name = name
+		app.CreationTimestamp = metav1.NewTime(createdAt.Add(creationDelay))
+		app.Spec.Project = project
+		return app
+	}
+	proj := defaultProj.DeepCopy()
+	proj.Spec.SourceNamespaceRules = []v1alpha1.SourceNamespaceRule{{Pattern: "*", MaxApps: 2}}
+	first := newApp("first", 0, "default")
+	second := newApp("b-second", time.Minute, "default")
+	// created at the same time as the second application, but ordered after it by name
+	third := newApp("c-third", time.Minute, "default")
+	other := newApp("other", -time.Minute, "other")
+	ctrl := newFakeController(&fakeData{apps: []runtime.Object{first, second, third, other, proj}}, nil)
+
+	require.NoError(t, ctrl.validateSourceNamespaceQuota(first, proj))
+	require.NoError(t, ctrl.validateSourceNamespaceQuota(second, proj))
+	require.EqualError(t, ctrl.validateSourceNamespaceQuota(third, proj), "project default already has the maximum number of 2 applications in the namespace "+third.Namespace)
+
+	// the application exceeding the quota isn't reconciled
+	_, hasErrors := ctrl.refreshAppConditions(third)
+	assert.True(t, hasErrors)
+	require.Len(t, third.Status.Conditions, 1)
+	assert.Equal(t, v1alpha1.ApplicationConditionInvalidSpecError, third.Status.Conditions[0].Type)
+
+	proj.Spec.SourceNamespaceRules[0].MaxApps = 0
+	require.NoError(t, ctrl.validateSourceNamespaceQuota(third, proj))
+}
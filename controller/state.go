@@ -2,6 +2,7 @@ package controller
 
 import (
 	"context"
+	"crypto/sha256"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -33,6 +34,7 @@ import (
 	"github.com/argoproj/argo-cd/v3/controller/metrics"
 	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
 	appclientset "github.com/argoproj/argo-cd/v3/pkg/client/clientset/versioned"
+	applisters "github.com/argoproj/argo-cd/v3/pkg/client/listers/application/v1alpha1"
 	"github.com/argoproj/argo-cd/v3/reposerver/apiclient"
 	applog "github.com/argoproj/argo-cd/v3/util/app/log"
 	"github.com/argoproj/argo-cd/v3/util/app/path"
@@ -43,8 +45,10 @@ import (
 	"github.com/argoproj/argo-cd/v3/util/db"
 	"github.com/argoproj/argo-cd/v3/util/gpg"
 	utilio "github.com/argoproj/argo-cd/v3/util/io"
+	"github.com/argoproj/argo-cd/v3/util/lua"
 	"github.com/argoproj/argo-cd/v3/util/settings"
 	"github.com/argoproj/argo-cd/v3/util/stats"
+	"github.com/argoproj/argo-cd/v3/util/versions"
 )
 
 var ErrCompareStateRepo = errors.New("failed to get repo objects")
@@ -66,6 +70,10 @@ type managedResource struct {
 	Name            string
 	Hook            bool
 	ResourceVersion string
+	// SuppressedDiff lists the differences between the live and target resource which are hidden by ignore rules
+	SuppressedDiff []v1alpha1.SuppressedDifference
+	// DryRunError is the failure of the server-side dry-run apply of the target resource, if any
+	DryRunError *v1alpha1.ResourceDryRunError
 }
 
 // AppStateManager defines methods which allow to compare application spec and actual application state.
@@ -86,12 +94,16 @@ type comparisonResult struct {
 	appSourceType        v1alpha1.ApplicationSourceType
 	// appSourceTypes stores the SourceType for each application source under sources field
 	appSourceTypes []v1alpha1.ApplicationSourceType
+	// appToolVersions stores the tool version which rendered each application source, nil if all of them were rendered by the default versions
+	appToolVersions []string
 	// timings maps phases of comparison to the duration it took to complete (for statistical purposes)
 	timings            map[string]time.Duration
 	diffResultList     *diff.DiffResultList
 	hasPostDeleteHooks bool
 	// revisionsMayHaveChanges indicates if there are any possibilities that the revisions contain changes
 	revisionsMayHaveChanges bool
+	// diffSettingsHash is the hash of the settings affecting the diff which the comparison was performed with
+	diffSettingsHash string
 }
 
 func (res *comparisonResult) GetSyncStatus() *v1alpha1.SyncStatus {
@@ -120,7 +132,14 @@ type appStateManager struct {
 	repoErrorCache        goSync.Map
 	repoErrorGracePeriod  time.Duration
 	serverSideDiff        bool
+	serverSideDryRunOpts  argodiff.ServerSideDryRunOpts
 	ignoreNormalizerOpts  normalizers.IgnoreNormalizerOpts
+	manifestPolicies      *manifestPolicyEvaluator
+	provenance            *provenanceVerifier
+	appLister             applisters.ApplicationLister
+	projLister            applisters.AppProjectLister
+	// historyExports holds the applications with an export of purged revision history in progress
+	historyExports goSync.Map
 }
 
 // GetRepoObjs will generate the manifests for the given application delegating the
@@ -182,7 +201,7 @@ func (m *appStateManager) GetRepoObjs(app *v1alpha1.Application, sources []v1alp
 		return nil, nil, false, fmt.Errorf("failed to get Helm settings: %w", err)
 	}
 
-	trackingMethod, err := m.settingsMgr.GetTrackingMethod()
+	trackingMethod, err := argo.GetTrackingMethod(m.settingsMgr, proj)
 	if err != nil {
 		return nil, nil, false, fmt.Errorf("failed to get trackingMethod: %w", err)
 	}
@@ -192,6 +211,12 @@ func (m *appStateManager) GetRepoObjs(app *v1alpha1.Application, sources []v1alp
 		return nil, nil, false, fmt.Errorf("failed to get installation ID: %w", err)
 	}
 
+	// the version of the settings is only reported by the repo server, so the manifests are generated without it
+	settingsVersion, err := m.settingsMgr.GetSettingsVersion()
+	if err != nil {
+		log.Warnf("Failed to get the version of the settings: %v", err)
+	}
+
 	destCluster, err := argo.GetDestinationCluster(context.Background(), app.Spec.Destination, m.db)
 	if err != nil {
 		return nil, nil, false, fmt.Errorf("failed to get destination cluster: %w", err)
@@ -223,6 +248,8 @@ func (m *appStateManager) GetRepoObjs(app *v1alpha1.Application, sources []v1alp
 		return nil, nil, false, fmt.Errorf("failed to get ref sources: %w", err)
 	}
 
+	appRefSources := argo.GetApplicationRefSources(sources)
+
 	revisionsMayHaveChanges := false
 
 	keyManifestGenerateAnnotationVal, keyManifestGenerateAnnotationExists := app.Annotations[v1alpha1.AnnotationKeyManifestGeneratePaths]
@@ -231,6 +258,16 @@ func (m *appStateManager) GetRepoObjs(app *v1alpha1.Application, sources []v1alp
 		if len(revisions) < len(sources) || revisions[i] == "" {
 			revisions[i] = source.TargetRevision
 		}
+		// The ref sources referencing the outputs of another application have no manifests
+		if source.IsApplicationRef() {
+			manifestInfos = append(manifestInfos, &apiclient.ManifestResponse{})
+			continue
+		}
+		source, err = m.resolveApplicationRefValues(app, source, appRefSources)
+		if err != nil {
+			return nil, nil, false, fmt.Errorf("failed to resolve the outputs of the referenced applications for source %d of %d: %w", i+1, len(sources), err)
+		}
+		source = proj.GetApplicationSource(source)
 		repo, err := m.db.GetRepository(context.Background(), source.RepoURL, proj.Name)
 		if err != nil {
 			return nil, nil, false, fmt.Errorf("failed to get repo %q: %w", source.RepoURL, err)
@@ -325,11 +362,18 @@ func (m *appStateManager) GetRepoObjs(app *v1alpha1.Application, sources []v1alp
 			ProjectSourceRepos:              proj.Spec.SourceRepos,
 			AnnotationManifestGeneratePaths: app.GetAnnotation(v1alpha1.AnnotationKeyManifestGeneratePaths),
 			InstallationID:                  installationID,
+			SettingsVersion:                 settingsVersion,
 		})
 		if err != nil {
 			return nil, nil, false, fmt.Errorf("failed to generate manifest for source %d of %d: %w", i+1, len(sources), err)
 		}
 
+		if source.IsOCI() {
+			if err := m.provenance.verify(context.Background(), proj.GetProvenancePolicies(source.RepoURL), repo, manifestInfo.Revision); err != nil {
+				return nil, nil, false, fmt.Errorf("failed to verify the provenance of source %d of %d: %w", i+1, len(sources), err)
+			}
+		}
+
 		targetObj, err := unmarshalManifests(manifestInfo.Manifests)
 		if err != nil {
 			return nil, nil, false, fmt.Errorf("failed to unmarshal manifests for source %d of %d: %w", i+1, len(sources), err)
@@ -457,9 +501,19 @@ func normalizeClusterScopeTracking(targetObjs []*unstructured.Unstructured, info
 	return nil
 }
 
+// isApplicationResourceExcluded returns whether the resources of the API group and kind in the cluster are excluded
+// from the application, and by what. The resource exclusions and inclusions of the application and its project take
+// precedence over the settings.
+func isApplicationResourceExcluded(project *v1alpha1.AppProject, app *v1alpha1.Application, resFilter *settings.ResourcesFilter, group, kind, cluster string) (bool, string) {
+	if excluded, overridden := project.IsApplicationResourceExcluded(&app.Spec, group, kind, cluster); overridden {
+		return excluded, "by the application or its project"
+	}
+	return resFilter.IsExcludedResource(group, kind, cluster), "in the settings"
+}
+
 // getComparisonSettings will return the system level settings related to the
 // diff/normalization process.
-func (m *appStateManager) getComparisonSettings() (string, map[string]v1alpha1.ResourceOverride, *settings.ResourcesFilter, string, string, error) {
+func (m *appStateManager) getComparisonSettings(project *v1alpha1.AppProject) (string, map[string]v1alpha1.ResourceOverride, *settings.ResourcesFilter, string, string, error) {
 	resourceOverrides, err := m.settingsMgr.GetResourceOverrides()
 	if err != nil {
 		return "", nil, nil, "", "", err
@@ -476,7 +530,7 @@ func (m *appStateManager) getComparisonSettings() (string, map[string]v1alpha1.R
 	if err != nil {
 		return "", nil, nil, "", "", err
 	}
-	trackingMethod, err := m.settingsMgr.GetTrackingMethod()
+	trackingMethod, err := argo.GetTrackingMethod(m.settingsMgr, project)
 	if err != nil {
 		return "", nil, nil, "", "", err
 	}
@@ -522,8 +576,8 @@ func verifyGnuPGSignature(revision string, project *v1alpha1.AppProject, manifes
 	return conditions
 }
 
-func isManagedNamespace(ns *unstructured.Unstructured, app *v1alpha1.Application) bool {
-	return ns != nil && ns.GetKind() == kubeutil.NamespaceKind && ns.GetName() == app.Spec.Destination.Namespace && app.Spec.SyncPolicy != nil && app.Spec.SyncPolicy.ManagedNamespaceMetadata != nil
+func isManagedNamespace(ns *unstructured.Unstructured, app *v1alpha1.Application, syncPolicy *v1alpha1.SyncPolicy) bool {
+	return ns != nil && ns.GetKind() == kubeutil.NamespaceKind && ns.GetName() == app.Spec.Destination.Namespace && syncPolicy != nil && syncPolicy.ManagedNamespaceMetadata != nil
 }
 
 // CompareAppState compares application git state to the live app state, using the specified
@@ -532,6 +586,8 @@ func isManagedNamespace(ns *unstructured.Unstructured, app *v1alpha1.Application
 func (m *appStateManager) CompareAppState(app *v1alpha1.Application, project *v1alpha1.AppProject, revisions []string, sources []v1alpha1.ApplicationSource, noCache bool, noRevisionCache bool, localManifests []string, hasMultipleSources bool) (*comparisonResult, error) {
 	ts := stats.NewTimingStats()
 	logCtx := log.WithFields(applog.GetAppLogFields(app))
+	// the applications inherit the default sync policy of their project
+	syncPolicy := project.GetApplicationSyncPolicy(app.Spec.SyncPolicy)
 
 	// Build initial sync status
 	syncStatus := &v1alpha1.SyncStatus{
@@ -542,8 +598,15 @@ func (m *appStateManager) CompareAppState(app *v1alpha1.Application, project *v1
 		Status: v1alpha1.SyncStatusCodeUnknown,
 	}
 	if hasMultipleSources {
+		var pinnedRevisions []string
+		var err error
+		revisions, pinnedRevisions, err = applyPinnedRevisions(app, sources, revisions)
+		if err != nil {
+			logCtx.Warnf("Ignoring pinned revisions: %v", err)
+		}
 		syncStatus.ComparedTo.Sources = sources
 		syncStatus.Revisions = revisions
+		syncStatus.PinnedRevisions = pinnedRevisions
 	} else {
 		if len(sources) > 0 {
 			syncStatus.ComparedTo.Source = sources[0]
@@ -555,7 +618,7 @@ func (m *appStateManager) CompareAppState(app *v1alpha1.Application, project *v1
 		}
 	}
 
-	appLabelKey, resourceOverrides, resFilter, installationID, trackingMethod, err := m.getComparisonSettings()
+	appLabelKey, resourceOverrides, resFilter, installationID, trackingMethod, err := m.getComparisonSettings(project)
 	ts.AddCheckpoint("settings_ms")
 	if err != nil {
 		// return unknown comparison result if basic comparison settings cannot be loaded
@@ -596,6 +659,10 @@ func (m *appStateManager) CompareAppState(app *v1alpha1.Application, project *v1
 
 		targetObjs, manifestInfos, revisionsMayHaveChanges, err = m.GetRepoObjs(app, sources, appLabelKey, revisions, noCache, noRevisionCache, verifySignature, project, true)
 		if err != nil {
+			if errors.Is(err, errManifestGenerationDeferred) {
+				// the comparison is retried once the manifest generation is allowed by the concurrency limit of the project
+				return nil, err
+			}
 			targetObjs = make([]*unstructured.Unstructured, 0)
 			msg := "Failed to load target state: " + err.Error()
 			conditions = append(conditions, v1alpha1.ApplicationCondition{Type: v1alpha1.ApplicationConditionComparisonError, Message: msg, LastTransitionTime: &now})
@@ -661,11 +728,11 @@ func (m *appStateManager) CompareAppState(app *v1alpha1.Application, project *v1
 	for i := len(targetObjs) - 1; i >= 0; i-- {
 		targetObj := targetObjs[i]
 		gvk := targetObj.GroupVersionKind()
-		if resFilter.IsExcludedResource(gvk.Group, gvk.Kind, destCluster.Server) {
+		if excluded, excludedBy := isApplicationResourceExcluded(project, app, resFilter, gvk.Group, gvk.Kind, destCluster.Server); excluded {
 			targetObjs = append(targetObjs[:i], targetObjs[i+1:]...)
 			conditions = append(conditions, v1alpha1.ApplicationCondition{
 				Type:               v1alpha1.ApplicationConditionExcludedResourceWarning,
-				Message:            fmt.Sprintf("Resource %s/%s %s is excluded in the settings", gvk.Group, gvk.Kind, targetObj.GetName()),
+				Message:            fmt.Sprintf("Resource %s/%s %s is excluded %s", gvk.Group, gvk.Kind, targetObj.GetName(), excludedBy),
 				LastTransitionTime: &now,
 			})
 		}
@@ -673,12 +740,24 @@ func (m *appStateManager) CompareAppState(app *v1alpha1.Application, project *v1
 		// If we reach this path, this means that a namespace has been both defined in Git, as well in the
 		// application's managedNamespaceMetadata. We want to ensure that this manifest is the one being used instead
 		// of what is present in managedNamespaceMetadata.
-		if isManagedNamespace(targetObj, app) {
+		if isManagedNamespace(targetObj, app, syncPolicy) {
 			targetNsExists = true
 		}
 	}
 	ts.AddCheckpoint("dedup_ms")
 
+	conditions = append(conditions, m.manifestPolicies.evaluate(project, targetObjs, now)...)
+	ts.AddCheckpoint("manifest_policies_ms")
+
+	plaintextSecrets, err := m.settingsMgr.GetPlaintextSecretsSettings()
+	if err != nil {
+		msg := "Failed to load plaintext Secret detection settings: " + err.Error()
+		conditions = append(conditions, v1alpha1.ApplicationCondition{Type: v1alpha1.ApplicationConditionComparisonError, Message: msg, LastTransitionTime: &now})
+	} else if plaintextSecrets != nil {
+		conditions = append(conditions, plaintextSecretConditions(plaintextSecrets, targetObjs, &now)...)
+	}
+	conditions = append(conditions, imageRegistryConditions(project, targetObjs, &now)...)
+
 	liveObjByKey, err := m.liveStateCache.GetManagedLiveObjs(destCluster, app, targetObjs)
 	if err != nil {
 		liveObjByKey = make(map[kubeutil.ResourceKey]*unstructured.Unstructured)
@@ -706,6 +785,12 @@ func (m *appStateManager) CompareAppState(app *v1alpha1.Application, project *v1
 
 		if !permitted {
 			delete(liveObjByKey, k)
+			continue
+		}
+		// the excluded live resources are not tracked, so that they are never pruned. The cluster caches may hold the
+		// resources excluded in the settings, since they watch the resources included by any project.
+		if excluded, _ := isApplicationResourceExcluded(project, app, resFilter, k.Group, k.Kind, destCluster.Server); excluded {
+			delete(liveObjByKey, k)
 		}
 	}
 
@@ -733,7 +818,7 @@ func (m *appStateManager) CompareAppState(app *v1alpha1.Application, project *v1
 			//
 			// targetNsExists == true implies that it already exists as a target, so no need to add the namespace to the
 			// targetObjs array.
-			if isManagedNamespace(liveObj, app) && !targetNsExists {
+			if isManagedNamespace(liveObj, app, syncPolicy) && !targetNsExists {
 				nsSpec := &corev1.Namespace{TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: kubeutil.NamespaceKind}, ObjectMeta: metav1.ObjectMeta{Name: liveObj.GetName()}}
 				managedNs, err := kubeutil.ToUnstructured(nsSpec)
 				if err != nil {
@@ -743,7 +828,7 @@ func (m *appStateManager) CompareAppState(app *v1alpha1.Application, project *v1
 				}
 
 				// No need to care about the return value here, we just want the modified managedNs
-				_, err = syncNamespace(app.Spec.SyncPolicy)(managedNs, liveObj)
+				_, err = syncNamespace(syncPolicy)(managedNs, liveObj)
 				if err != nil {
 					conditions = append(conditions, v1alpha1.ApplicationCondition{Type: v1alpha1.ApplicationConditionComparisonError, Message: err.Error(), LastTransitionTime: &now})
 					failedToLoadObjs = true
@@ -783,7 +868,13 @@ func (m *appStateManager) CompareAppState(app *v1alpha1.Application, project *v1
 		serverSideDiff = false
 	}
 
-	useDiffCache := useDiffCache(noCache, manifestInfos, sources, app, manifestRevisions, m.statusRefreshTimeout, serverSideDiff, logCtx)
+	diffSettingsHash, err := getDiffSettingsHash(resourceOverrides, compareOptions, m.ignoreNormalizerOpts, appLabelKey, trackingMethod)
+	if err != nil {
+		logCtx.Warnf("Could not compute the hash of the diff settings: %v", err)
+	}
+	diffSettingsChanged := diffSettingsHash == "" || diffSettingsHash != m.getCachedDiffSettingsHash(app, logCtx)
+
+	useDiffCache := useDiffCache(noCache, manifestInfos, sources, app, manifestRevisions, m.statusRefreshTimeout, serverSideDiff, diffSettingsChanged, logCtx)
 
 	diffConfigBuilder := argodiff.NewDiffConfigBuilder().
 		WithDiffSettings(app.Spec.IgnoreDifferences, resourceOverrides, compareOptions.IgnoreAggregatedRoles, m.ignoreNormalizerOpts).
@@ -815,11 +906,16 @@ func (m *appStateManager) CompareAppState(app *v1alpha1.Application, project *v1
 			conditions = append(conditions, v1alpha1.ApplicationCondition{Type: v1alpha1.ApplicationConditionUnknownError, Message: err.Error(), LastTransitionTime: &now})
 		}
 		defer cleanup()
-		diffConfigBuilder.WithServerSideDryRunner(diff.NewK8sServerSideDryRunner(applier))
+		var dryRunner diff.ServerSideDryRunner = diff.NewK8sServerSideDryRunner(applier)
+		if m.metricsServer != nil {
+			dryRunner = &observedDryRunner{ServerSideDryRunner: dryRunner, metricsServer: m.metricsServer, destServer: destCluster.Server}
+		}
+		diffConfigBuilder.WithServerSideDryRunner(dryRunner)
+		diffConfigBuilder.WithServerSideDryRunOpts(m.serverSideDryRunOpts)
 	}
 
 	// enable structured merge diff if application syncs with server-side apply
-	if app.Spec.SyncPolicy != nil && app.Spec.SyncPolicy.SyncOptions.HasOption("ServerSideApply=true") {
+	if syncPolicy != nil && syncPolicy.SyncOptions.HasOption("ServerSideApply=true") {
 		diffConfigBuilder.WithStructuredMergeDiff(true)
 	}
 
@@ -827,7 +923,8 @@ func (m *appStateManager) CompareAppState(app *v1alpha1.Application, project *v1
 	// application conditions as argo.StateDiffs will validate this diffConfig again.
 	diffConfig, _ := diffConfigBuilder.Build()
 
-	diffResults, err := argodiff.StateDiffs(reconciliation.Live, reconciliation.Target, diffConfig)
+	// the failures of the server-side dry-run applies are reported per resource rather than failing the comparison
+	diffResults, dryRunErrors, err := argodiff.StateDiffsWithDryRunErrors(reconciliation.Live, reconciliation.Target, diffConfig)
 	if err != nil {
 		diffResults = &diff.DiffResultList{}
 		failedToLoadObjs = true
@@ -836,9 +933,23 @@ func (m *appStateManager) CompareAppState(app *v1alpha1.Application, project *v1
 	}
 	ts.AddCheckpoint("diff_ms")
 
+	// the error is ignored as invalid ignore rules are already reported by argo.StateDiffs. The reporter lists the
+	// differences hidden by the ignore rules, which are shown by the managed resources API.
+	ignoreReporter, _ := normalizers.NewIgnoreReporter(app.Spec.IgnoreDifferences, resourceOverrides, m.ignoreNormalizerOpts)
+
+	// the completed hooks older than the age set by the compare options of the application are excluded from its
+	// resources and its resource tree
+	var excludeHooksCreatedBefore time.Time
+	if age, err := getExcludeCompletedHooksOlderThan(app); err != nil {
+		logCtx.Warnf("Ignoring the exclusion of the completed hooks: %v", err)
+	} else if age > 0 {
+		excludeHooksCreatedBefore = now.Add(-age)
+	}
+	healthOverrides := lua.ResourceHealthOverrides(resourceOverrides)
+
 	syncCode := v1alpha1.SyncStatusCodeSynced
-	managedResources := make([]managedResource, len(reconciliation.Target))
-	resourceSummaries := make([]v1alpha1.ResourceStatus, len(reconciliation.Target))
+	managedResources := make([]managedResource, 0, len(reconciliation.Target))
+	resourceSummaries := make([]v1alpha1.ResourceStatus, 0, len(reconciliation.Target))
 	for i, targetObj := range reconciliation.Target {
 		liveObj := reconciliation.Live[i]
 		obj := liveObj
@@ -848,6 +959,9 @@ func (m *appStateManager) CompareAppState(app *v1alpha1.Application, project *v1
 		if obj == nil {
 			continue
 		}
+		if !excludeHooksCreatedBefore.IsZero() && isCompletedHookCreatedBefore(liveObj, healthOverrides, excludeHooksCreatedBefore) {
+			continue
+		}
 		gvk := obj.GroupVersionKind()
 
 		isSelfReferencedObj := m.isSelfReferencedObj(liveObj, targetObj, app.GetName(), v1alpha1.TrackingMethod(trackingMethod), installationID)
@@ -873,6 +987,9 @@ func (m *appStateManager) CompareAppState(app *v1alpha1.Application, project *v1
 		} else {
 			diffResult = diff.DiffResult{Modified: false, NormalizedLive: []byte("{}"), PredictedLive: []byte("{}")}
 		}
+		if i < len(dryRunErrors) {
+			resState.DryRunError = dryRunErrors[i]
+		}
 
 		// For the case when a namespace is managed with `managedNamespaceMetadata` AND it has resource tracking
 		// enabled (e.g. someone manually adds resource tracking labels or annotations), we need to do some
@@ -881,7 +998,7 @@ func (m *appStateManager) CompareAppState(app *v1alpha1.Application, project *v1
 		//
 		// This is in addition to the bookkeeping we do (see `isManagedNamespace` and its references) to prevent said
 		// namespace from being pruned.
-		isManagedNs := isManagedNamespace(targetObj, app) && liveObj == nil
+		isManagedNs := isManagedNamespace(targetObj, app, syncPolicy) && liveObj == nil
 
 		switch {
 		case resState.Hook || ignore.Ignore(obj) || (targetObj != nil && hookutil.Skip(targetObj)) || !isSelfReferencedObj:
@@ -922,7 +1039,14 @@ func (m *appStateManager) CompareAppState(app *v1alpha1.Application, project *v1
 		if liveObj != nil {
 			resourceVersion = liveObj.GetResourceVersion()
 		}
-		managedResources[i] = managedResource{
+		var suppressedDiff []v1alpha1.SuppressedDifference
+		if ignoreReporter != nil {
+			suppressedDiff, err = ignoreReporter.SuppressedDifferences(liveObj, targetObj)
+			if err != nil {
+				logCtx.Warnf("Failed to report the ignored differences of %s %s: %v", gvk.Kind, obj.GetName(), err)
+			}
+		}
+		managedResources = append(managedResources, managedResource{
 			Name:            resState.Name,
 			Namespace:       resState.Namespace,
 			Group:           resState.Group,
@@ -933,8 +1057,13 @@ func (m *appStateManager) CompareAppState(app *v1alpha1.Application, project *v1
 			Diff:            diffResult,
 			Hook:            resState.Hook,
 			ResourceVersion: resourceVersion,
-		}
-		resourceSummaries[i] = resState
+			SuppressedDiff:  suppressedDiff,
+			DryRunError:     resState.DryRunError,
+		})
+		resourceSummaries = append(resourceSummaries, resState)
+	}
+	if m.metricsServer != nil {
+		m.metricsServer.SetResourceKindCountMetric(app, countResourceKinds(resourceSummaries))
 	}
 
 	if failedToLoadObjs {
@@ -951,6 +1080,7 @@ func (m *appStateManager) CompareAppState(app *v1alpha1.Application, project *v1
 	} else if len(manifestRevisions) > 0 {
 		syncStatus.Revision = manifestRevisions[0]
 	}
+	syncStatus.VersionConstraints = resolvedVersionConstraints(sources, revisions, manifestRevisions)
 
 	ts.AddCheckpoint("sync_ms")
 
@@ -978,6 +1108,7 @@ func (m *appStateManager) CompareAppState(app *v1alpha1.Application, project *v1
 		diffResultList:          diffResults,
 		hasPostDeleteHooks:      hasPostDeleteHooks,
 		revisionsMayHaveChanges: revisionsMayHaveChanges,
+		diffSettingsHash:        diffSettingsHash,
 	}
 
 	if hasMultipleSources {
@@ -990,25 +1121,90 @@ func (m *appStateManager) CompareAppState(app *v1alpha1.Application, project *v1
 			break
 		}
 	}
+	compRes.appToolVersions = getToolVersions(manifestInfos)
 
 	app.Status.SetConditions(conditions, map[v1alpha1.ApplicationConditionType]bool{
 		v1alpha1.ApplicationConditionComparisonError:         true,
 		v1alpha1.ApplicationConditionSharedResourceWarning:   true,
 		v1alpha1.ApplicationConditionRepeatedResourceWarning: true,
 		v1alpha1.ApplicationConditionExcludedResourceWarning: true,
+		v1alpha1.ApplicationConditionPolicyViolationError:    true,
+		v1alpha1.ApplicationConditionPolicyViolationWarning:  true,
+		v1alpha1.ApplicationConditionPlaintextSecretError:    true,
+		v1alpha1.ApplicationConditionPlaintextSecretWarning:  true,
+		v1alpha1.ApplicationConditionImageRegistryError:      true,
 	})
 	ts.AddCheckpoint("health_ms")
 	compRes.timings = ts.Timings()
 	return &compRes, nil
 }
 
+// applyPinnedRevisions replaces the revisions of the pinned sources of the application with the revisions they are
+// pinned to, unless another revision than their target revision is explicitly requested. It returns the revisions
+// along with the pinned revisions by source index, which are nil if no source is pinned.
+func applyPinnedRevisions(app *v1alpha1.Application, sources []v1alpha1.ApplicationSource, revisions []string) ([]string, []string, error) {
+	pins, err := app.GetPinnedRevisions()
+	if err != nil || len(pins) == 0 {
+		return revisions, nil, err
+	}
+	result := make([]string, len(sources))
+	pinnedRevisions := make([]string, len(sources))
+	for i, source := range sources {
+		result[i] = source.TargetRevision
+		if len(revisions) == len(sources) {
+			result[i] = revisions[i]
+		}
+		pin, ok := pins[i+1]
+		if !ok {
+			continue
+		}
+		pinnedRevisions[i] = pin
+		if result[i] == source.TargetRevision {
+			result[i] = pin
+		}
+	}
+	return result, pinnedRevisions, nil
+}
+
+// countResourceKinds returns the number of resources per kind
+func countResourceKinds(resources []v1alpha1.ResourceStatus) map[schema.GroupKind]int {
+	counts := make(map[schema.GroupKind]int)
+	for _, res := range resources {
+		counts[schema.GroupKind{Group: res.Group, Kind: res.Kind}]++
+	}
+	return counts
+}
+
+// resolvedVersionConstraints returns the version constraints of the requested revisions of the Helm sources, by source
+// index, which the resolved revisions satisfy. It returns nil if no revision of a Helm source is a version constraint.
+func resolvedVersionConstraints(sources []v1alpha1.ApplicationSource, revisions []string, resolvedRevisions []string) []string {
+	if len(revisions) != len(sources) || len(resolvedRevisions) != len(sources) {
+		return nil
+	}
+	var constraints []string
+	for i, source := range sources {
+		if !source.IsHelm() || !versions.IsConstraint(revisions[i]) || !versions.Satisfies(resolvedRevisions[i], revisions[i]) {
+			continue
+		}
+		if constraints == nil {
+			constraints = make([]string, len(sources))
+		}
+		constraints[i] = revisions[i]
+	}
+	return constraints
+}
+
 // useDiffCache will determine if the diff should be calculated based
 // on the existing live state cache or not.
-func useDiffCache(noCache bool, manifestInfos []*apiclient.ManifestResponse, sources []v1alpha1.ApplicationSource, app *v1alpha1.Application, manifestRevisions []string, statusRefreshTimeout time.Duration, serverSideDiff bool, log *log.Entry) bool {
+func useDiffCache(noCache bool, manifestInfos []*apiclient.ManifestResponse, sources []v1alpha1.ApplicationSource, app *v1alpha1.Application, manifestRevisions []string, statusRefreshTimeout time.Duration, serverSideDiff bool, diffSettingsChanged bool, log *log.Entry) bool {
 	if noCache {
 		log.WithField("useDiffCache", "false").Debug("noCache is true")
 		return false
 	}
+	if diffSettingsChanged {
+		log.WithField("useDiffCache", "false").Debug("diffSettingsChanged")
+		return false
+	}
 	refreshType, refreshRequested := app.IsRefreshRequested()
 	if refreshRequested {
 		log.WithField("useDiffCache", "false").Debugf("refresh type %s requested", string(refreshType))
@@ -1044,6 +1240,67 @@ func useDiffCache(noCache bool, manifestInfos []*apiclient.ManifestResponse, sou
 	return true
 }
 
+// diffSettings holds the settings of the argocd-cm ConfigMap and of the controller which affect the diff of the
+// application resources.
+type diffSettings struct {
+	IgnoreDifferences  map[string]v1alpha1.OverrideIgnoreDiff `json:"ignoreDifferences,omitempty"`
+	KnownTypeFields    map[string][]v1alpha1.KnownTypeField   `json:"knownTypeFields,omitempty"`
+	CompareOptions     settings.ArgoCDDiffOptions             `json:"compareOptions"`
+	JQExecutionTimeout time.Duration                          `json:"jqExecutionTimeout,omitempty"`
+	AppLabelKey        string                                 `json:"appLabelKey,omitempty"`
+	TrackingMethod     string                                 `json:"trackingMethod,omitempty"`
+}
+
+// getDiffSettingsHash returns a hash of the settings which affect the diff of the application resources. The hash is
+// cached along with the managed resources so that a change of the resource customizations invalidates the cached diff.
+func getDiffSettingsHash(resourceOverrides map[string]v1alpha1.ResourceOverride, compareOptions settings.ArgoCDDiffOptions, ignoreNormalizerOpts normalizers.IgnoreNormalizerOpts, appLabelKey string, trackingMethod string) (string, error) {
+	s := diffSettings{
+		IgnoreDifferences:  map[string]v1alpha1.OverrideIgnoreDiff{},
+		KnownTypeFields:    map[string][]v1alpha1.KnownTypeField{},
+		CompareOptions:     compareOptions,
+		JQExecutionTimeout: ignoreNormalizerOpts.JQExecutionTimeout,
+		AppLabelKey:        appLabelKey,
+		TrackingMethod:     trackingMethod,
+	}
+	for key, override := range resourceOverrides {
+		s.IgnoreDifferences[key] = override.IgnoreDifferences
+		if len(override.KnownTypeFields) > 0 {
+			s.KnownTypeFields[key] = override.KnownTypeFields
+		}
+	}
+	data, err := json.Marshal(s)
+	if err != nil {
+		return "", fmt.Errorf("error marshaling diff settings: %w", err)
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(data)), nil
+}
+
+// getCachedDiffSettingsHash returns the hash of the diff settings the cached managed resources of the application
+// were computed with, or an empty string if it is unknown.
+func (m *appStateManager) getCachedDiffSettingsHash(app *v1alpha1.Application, logCtx *log.Entry) string {
+	if m.cache == nil {
+		return ""
+	}
+	var hash string
+	if err := m.cache.GetAppDiffSettingsHash(app.InstanceName(m.namespace), &hash); err != nil && !errors.Is(err, appstatecache.ErrCacheMiss) {
+		logCtx.Warnf("Could not get the hash of the diff settings from the cache: %v", err)
+	}
+	return hash
+}
+
+// getToolVersions returns the tool versions which rendered the manifests of the sources, or nil if all of the sources
+// were rendered by the default versions
+func getToolVersions(manifestInfos []*apiclient.ManifestResponse) []string {
+	if !slices.ContainsFunc(manifestInfos, func(info *apiclient.ManifestResponse) bool { return info.GetToolVersion() != "" }) {
+		return nil
+	}
+	toolVersions := make([]string, len(manifestInfos))
+	for i, info := range manifestInfos {
+		toolVersions[i] = info.GetToolVersion()
+	}
+	return toolVersions
+}
+
 // specEqualsCompareTo compares the application spec to the comparedTo status. It normalizes the destination to match
 // the comparedTo destination before comparing. It does not mutate the original spec or comparedTo.
 func specEqualsCompareTo(spec v1alpha1.ApplicationSpec, sources []v1alpha1.ApplicationSource, comparedTo v1alpha1.ComparedTo) bool {
@@ -1059,6 +1316,7 @@ func (m *appStateManager) persistRevisionHistory(
 	source v1alpha1.ApplicationSource,
 	revisions []string,
 	sources []v1alpha1.ApplicationSource,
+	versionConstraints []string,
 	hasMultipleSources bool,
 	startedAt metav1.Time,
 	initiatedBy v1alpha1.OperationInitiator,
@@ -1070,25 +1328,43 @@ func (m *appStateManager) persistRevisionHistory(
 
 	if hasMultipleSources {
 		app.Status.History = append(app.Status.History, v1alpha1.RevisionHistory{
-			DeployedAt:      metav1.NewTime(time.Now().UTC()),
-			DeployStartedAt: &startedAt,
-			ID:              nextID,
-			Sources:         sources,
-			Revisions:       revisions,
-			InitiatedBy:     initiatedBy,
+			DeployedAt:         metav1.NewTime(time.Now().UTC()),
+			DeployStartedAt:    &startedAt,
+			ID:                 nextID,
+			Sources:            sources,
+			Revisions:          revisions,
+			InitiatedBy:        initiatedBy,
+			VersionConstraints: versionConstraints,
 		})
 	} else {
 		app.Status.History = append(app.Status.History, v1alpha1.RevisionHistory{
-			Revision:        revision,
-			DeployedAt:      metav1.NewTime(time.Now().UTC()),
-			DeployStartedAt: &startedAt,
-			ID:              nextID,
-			Source:          source,
-			InitiatedBy:     initiatedBy,
+			Revision:           revision,
+			DeployedAt:         metav1.NewTime(time.Now().UTC()),
+			DeployStartedAt:    &startedAt,
+			ID:                 nextID,
+			Source:             source,
+			InitiatedBy:        initiatedBy,
+			VersionConstraints: versionConstraints,
 		})
 	}
 
-	app.Status.History = app.Status.History.Trunc(app.Spec.GetRevisionHistoryLimit())
+	now := time.Now().UTC()
+	history, purged, err := app.Status.History.Retain(app.Spec.GetRevisionHistoryLimit(), app.Spec.RevisionHistoryRetention, now)
+	if err != nil {
+		log.WithFields(applog.GetAppLogFields(app)).Warnf("Ignoring revision history retention: %v", err)
+		history, purged, _ = app.Status.History.Retain(app.Spec.GetRevisionHistoryLimit(), nil, now)
+	}
+	var exportURL string
+	if len(purged) > 0 {
+		if exportURL, err = m.settingsMgr.GetRevisionHistoryExportURL(); err != nil {
+			log.WithFields(applog.GetAppLogFields(app)).Warnf("Keeping purged revision history: error getting revision history export URL: %v", err)
+			history = app.Status.History
+		} else if exportURL != "" {
+			// the purged items are removed from the status once they have been exported
+			history = app.Status.History
+		}
+	}
+	app.Status.History = history
 
 	patch, err := json.Marshal(map[string]map[string][]v1alpha1.RevisionHistory{
 		"status": {
@@ -1099,7 +1375,13 @@ func (m *appStateManager) persistRevisionHistory(
 		return fmt.Errorf("error marshaling revision history patch: %w", err)
 	}
 	_, err = m.appclientset.ArgoprojV1alpha1().Applications(app.Namespace).Patch(context.Background(), app.Name, types.MergePatchType, patch, metav1.PatchOptions{})
-	return err
+	if err != nil {
+		return err
+	}
+	if len(purged) > 0 && exportURL != "" {
+		m.exportRevisionHistory(app, exportURL, purged)
+	}
+	return nil
 }
 
 // NewAppStateManager creates new instance of AppStateManager
@@ -1119,7 +1401,10 @@ func NewAppStateManager(
 	persistResourceHealth bool,
 	repoErrorGracePeriod time.Duration,
 	serverSideDiff bool,
+	serverSideDryRunOpts argodiff.ServerSideDryRunOpts,
 	ignoreNormalizerOpts normalizers.IgnoreNormalizerOpts,
+	appLister applisters.ApplicationLister,
+	projLister applisters.AppProjectLister,
 ) AppStateManager {
 	return &appStateManager{
 		liveStateCache:        liveStateCache,
@@ -1137,63 +1422,17 @@ func NewAppStateManager(
 		persistResourceHealth: persistResourceHealth,
 		repoErrorGracePeriod:  repoErrorGracePeriod,
 		serverSideDiff:        serverSideDiff,
+		serverSideDryRunOpts:  serverSideDryRunOpts,
 		ignoreNormalizerOpts:  ignoreNormalizerOpts,
+		manifestPolicies:      newManifestPolicyEvaluator(db, settingsMgr, manifestPolicyOPAURL),
+		provenance:            newProvenanceVerifier(),
+		appLister:             appLister,
+		projLister:            projLister,
 	}
 }
 
-// isSelfReferencedObj returns whether the given obj is managed by the application
-// according to the values of the tracking id (aka app instance value) annotation.
-// It returns true when all of the properties of the tracking id (app name, namespace,
-// group and kind) match the properties of the live object, or if the tracking method
-// used does not provide the required properties for matching.
-// Reference: https://github.com/argoproj/argo-cd/issues/8683
+// isSelfReferencedObj returns whether the given obj is managed by the application, according to the provider of the
+// tracking method, see argo.TrackingProvider.
 func (m *appStateManager) isSelfReferencedObj(live, config *unstructured.Unstructured, appName string, trackingMethod v1alpha1.TrackingMethod, installationID string) bool {
-	if live == nil {
-		return true
-	}
-
-	// If tracking method doesn't contain required metadata for this check,
-	// we are not able to determine and just assume the object to be managed.
-	if trackingMethod == v1alpha1.TrackingMethodLabel {
-		return true
-	}
-
-	// config != nil is the best-case scenario for constructing an accurate
-	// Tracking ID. `config` is the "desired state" (from git/helm/etc.).
-	// Using the desired state is important when there is an ApiGroup upgrade.
-	// When upgrading, the comparison must be made with the new tracking ID.
-	// Example:
-	//     live resource annotation will be:
-	//        ingress-app:extensions/Ingress:default/some-ingress
-	//     when it should be:
-	//        ingress-app:networking.k8s.io/Ingress:default/some-ingress
-	// More details in: https://github.com/argoproj/argo-cd/pull/11012
-	var aiv argo.AppInstanceValue
-	if config != nil {
-		aiv = argo.UnstructuredToAppInstanceValue(config, appName, "")
-		return isSelfReferencedObj(live, aiv)
-	}
-
-	// If config is nil then compare the live resource with the value
-	// of the annotation. In this case, in order to validate if obj is
-	// managed by this application, the values from the annotation have
-	// to match the properties from the live object. Cluster scoped objects
-	// carry the app's destination namespace in the tracking annotation,
-	// but are unique in GVK + name combination.
-	appInstance := m.resourceTracking.GetAppInstance(live, trackingMethod, installationID)
-	if appInstance != nil {
-		return isSelfReferencedObj(live, *appInstance)
-	}
-	return true
-}
-
-// isSelfReferencedObj returns true if the given Tracking ID (`aiv`) matches
-// the given object. It returns false when the ID doesn't match. This sometimes
-// happens when a tracking label or annotation gets accidentally copied to a
-// different resource.
-func isSelfReferencedObj(obj *unstructured.Unstructured, aiv argo.AppInstanceValue) bool {
-	return (obj.GetNamespace() == aiv.Namespace || obj.GetNamespace() == "") &&
-		obj.GetName() == aiv.Name &&
-		obj.GetObjectKind().GroupVersionKind().Group == aiv.Group &&
-		obj.GetObjectKind().GroupVersionKind().Kind == aiv.Kind
+	return m.resourceTracking.IsSelfReferencedObj(live, config, appName, trackingMethod, installationID)
 }
@@ -3,7 +3,10 @@ package controller
 import (
 	"encoding/json"
 	"errors"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"sort"
 	"testing"
 	"time"
 
@@ -25,6 +28,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/ptr"
 
 	"github.com/argoproj/argo-cd/v3/common"
@@ -32,6 +36,8 @@ import (
 	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
 	"github.com/argoproj/argo-cd/v3/reposerver/apiclient"
 	"github.com/argoproj/argo-cd/v3/test"
+	"github.com/argoproj/argo-cd/v3/util/argo/normalizers"
+	"github.com/argoproj/argo-cd/v3/util/settings"
 )
 
 // TestCompareAppStateEmpty tests comparison when both git and live have no objects
@@ -63,6 +69,33 @@ func TestCompareAppStateEmpty(t *testing.T) {
 	assert.Empty(t, app.Status.Conditions)
 }
 
+func TestCompareAppStateToolVersions(t *testing.T) {
+	t.Parallel()
+
+	for _, toolVersion := range []string{"", "v5.4"} {
+		app := newFakeApp()
+		data := fakeData{
+			manifestResponse: &apiclient.ManifestResponse{
+				Manifests:   []string{},
+				Namespace:   test.FakeDestNamespace,
+				Server:      test.FakeClusterURL,
+				Revision:    "abc123",
+				SourceType:  string(v1alpha1.ApplicationSourceTypeKustomize),
+				ToolVersion: toolVersion,
+			},
+			managedLiveObjs: make(map[kube.ResourceKey]*unstructured.Unstructured),
+		}
+		ctrl := newFakeController(&data, nil)
+		compRes, err := ctrl.appStateManager.CompareAppState(app, &defaultProj, []string{""}, []v1alpha1.ApplicationSource{app.Spec.GetSource()}, false, false, nil, false)
+		require.NoError(t, err)
+		if toolVersion == "" {
+			assert.Nil(t, compRes.appToolVersions)
+		} else {
+			assert.Equal(t, []string{toolVersion}, compRes.appToolVersions)
+		}
+	}
+}
+
 // TestCompareAppStateRepoError tests the case when CompareAppState notices a repo error
 func TestCompareAppStateRepoError(t *testing.T) {
 	app := newFakeApp()
@@ -326,6 +359,7 @@ func TestCompareAppStateExtra(t *testing.T) {
 	pod := NewPod()
 	pod.SetNamespace(test.FakeDestNamespace)
 	app := newFakeApp()
+	pod.SetAnnotations(map[string]string{common.AnnotationKeyAppInstance: app.Name + ":/Pod:" + test.FakeDestNamespace + "/" + pod.GetName()})
 	key := kube.ResourceKey{Group: "", Kind: "Pod", Namespace: test.FakeDestNamespace, Name: app.Name}
 	data := fakeData{
 		manifestResponse: &apiclient.ManifestResponse{
@@ -352,6 +386,41 @@ func TestCompareAppStateExtra(t *testing.T) {
 	assert.Empty(t, app.Status.Conditions)
 }
 
+// TestCompareAppStateSuppressedDiff tests that the differences hidden by ignoreDifferences rules are reported
+func TestCompareAppStateSuppressedDiff(t *testing.T) {
+	pod := NewPod()
+	pod.SetNamespace(test.FakeDestNamespace)
+	require.NoError(t, unstructured.SetNestedField(pod.Object, []any{map[string]any{"image": "nginx:1.8.0", "name": "nginx"}}, "spec", "containers"))
+	app := newFakeApp()
+	app.Spec.IgnoreDifferences = []v1alpha1.ResourceIgnoreDifferences{{
+		Kind:         "Pod",
+		JSONPointers: []string{"/spec/containers/0/image"},
+	}}
+	data := fakeData{
+		manifestResponse: &apiclient.ManifestResponse{
+			Manifests: []string{PodManifest},
+			Namespace: test.FakeDestNamespace,
+			Server:    test.FakeClusterURL,
+			Revision:  "abc123",
+		},
+		managedLiveObjs: map[kube.ResourceKey]*unstructured.Unstructured{
+			kube.GetResourceKey(pod): pod,
+		},
+	}
+	ctrl := newFakeController(&data, nil)
+	sources := make([]v1alpha1.ApplicationSource, 0)
+	sources = append(sources, app.Spec.GetSource())
+	revisions := make([]string, 0)
+	revisions = append(revisions, "")
+	compRes, err := ctrl.appStateManager.CompareAppState(app, &defaultProj, revisions, sources, false, false, nil, false)
+	require.NoError(t, err)
+	require.Len(t, compRes.managedResources, 1)
+	assert.Equal(t, []v1alpha1.SuppressedDifference{{
+		Path: "/spec/containers/0/image",
+		Rule: "spec.ignoreDifferences[0]: jsonPointer /spec/containers/0/image",
+	}}, compRes.managedResources[0].SuppressedDiff)
+}
+
 // TestCompareAppStateHook checks that hooks are detected during manifest generation, and not
 // considered as part of resources when assessing Synced status
 func TestCompareAppStateHook(t *testing.T) {
@@ -447,6 +516,125 @@ func TestCompareAppStateCompareOptionIgnoreExtraneous(t *testing.T) {
 	assert.Empty(t, app.Status.Conditions)
 }
 
+// checks that the completed hooks older than the age set by the compare options are excluded from the resources
+func TestCompareAppStateExcludeCompletedHooks(t *testing.T) {
+	newHook := func(name string, phase string, age time.Duration) *unstructured.Unstructured {
+		pod := NewPod()
+		pod.SetName(name)
+		pod.SetNamespace(test.FakeDestNamespace)
+		pod.SetUID(types.UID(name))
+		pod.SetAnnotations(map[string]string{synccommon.AnnotationKeyHook: "PreSync"})
+		pod.SetCreationTimestamp(metav1.NewTime(time.Now().Add(-age)))
+		require.NoError(t, unstructured.SetNestedField(pod.Object, phase, "status", "phase"))
+		return pod
+	}
+	hooks := []*unstructured.Unstructured{
+		newHook("old-completed", "Succeeded", 2*time.Hour),
+		newHook("old-failed", "Failed", 2*time.Hour),
+		newHook("old-running", "Running", 2*time.Hour),
+		newHook("new-completed", "Succeeded", time.Minute),
+	}
+	compare := func(t *testing.T, compareOptions string) []string {
+		t.Helper()
+		app := newFakeApp()
+		if compareOptions != "" {
+			app.Annotations = map[string]string{common.AnnotationCompareOptions: compareOptions}
+		}
+		liveObjs := make(map[kube.ResourceKey]*unstructured.Unstructured)
+		for _, hook := range hooks {
+			liveObjs[kube.GetResourceKey(hook)] = hook
+		}
+		ctrl := newFakeController(&fakeData{
+			apps: []runtime.Object{app},
+			manifestResponse: &apiclient.ManifestResponse{
+				Manifests: []string{},
+				Namespace: test.FakeDestNamespace,
+				Server:    test.FakeClusterURL,
+				Revision:  "abc123",
+			},
+			managedLiveObjs: liveObjs,
+		}, nil)
+		compRes, err := ctrl.appStateManager.CompareAppState(app, &defaultProj, []string{""}, []v1alpha1.ApplicationSource{app.Spec.GetSource()}, false, false, nil, false)
+		require.NoError(t, err)
+		require.Len(t, compRes.managedResources, len(compRes.resources))
+		var names []string
+		for _, res := range compRes.resources {
+			assert.True(t, res.Hook)
+			names = append(names, res.Name)
+		}
+		sort.Strings(names)
+		return names
+	}
+
+	assert.Equal(t, []string{"new-completed", "old-completed", "old-failed", "old-running"}, compare(t, ""))
+	assert.Equal(t, []string{"new-completed", "old-running"}, compare(t, "ExcludeCompletedHooksOlderThan=1h"))
+	// invalid durations are ignored
+	assert.Len(t, compare(t, "ExcludeCompletedHooksOlderThan=soon"), 4)
+}
+
+// checks that the resource exclusions of the application and the exclusions and inclusions of its project take
+// precedence over the settings
+func TestCompareAppStateResourceExclusionOverrides(t *testing.T) {
+	configMap := `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"my-map","namespace":"` + test.FakeDestNamespace + `"}}`
+	compare := func(t *testing.T, app *v1alpha1.Application, proj *v1alpha1.AppProject) *comparisonResult {
+		t.Helper()
+		ctrl := newFakeController(&fakeData{
+			apps: []runtime.Object{app, proj},
+			manifestResponse: &apiclient.ManifestResponse{
+				Manifests: []string{configMap},
+				Namespace: test.FakeDestNamespace,
+				Server:    test.FakeClusterURL,
+				Revision:  "abc123",
+			},
+			managedLiveObjs: make(map[kube.ResourceKey]*unstructured.Unstructured),
+			configMapData: map[string]string{
+				"resource.exclusions": "- apiGroups: ['']\n  kinds: [ConfigMap]",
+			},
+		}, nil)
+		compRes, err := ctrl.appStateManager.CompareAppState(app, proj, []string{""}, []v1alpha1.ApplicationSource{*app.Spec.Source}, false, false, nil, false)
+		require.NoError(t, err)
+		return compRes
+	}
+	configMapRule := v1alpha1.ResourceFilterRule{Kinds: []string{"ConfigMap"}}
+
+	t.Run("excluded in the settings", func(t *testing.T) {
+		app := newFakeApp()
+		compRes := compare(t, app, &defaultProj)
+		assert.Empty(t, compRes.managedResources)
+		require.Len(t, app.Status.Conditions, 1)
+		assert.Equal(t, "Resource /ConfigMap my-map is excluded in the settings", app.Status.Conditions[0].Message)
+	})
+	t.Run("included by the project", func(t *testing.T) {
+		app := newFakeApp()
+		proj := defaultProj.DeepCopy()
+		proj.Spec.ResourceInclusions = []v1alpha1.ResourceFilterRule{configMapRule}
+		compRes := compare(t, app, proj)
+		require.Len(t, compRes.managedResources, 1)
+		assert.Equal(t, "ConfigMap", compRes.managedResources[0].Kind)
+		assert.Empty(t, app.Status.Conditions)
+	})
+	t.Run("excluded by the application", func(t *testing.T) {
+		app := newFakeApp()
+		app.Spec.ResourceExclusions = []v1alpha1.ResourceFilterRule{configMapRule}
+		proj := defaultProj.DeepCopy()
+		proj.Spec.ResourceInclusions = []v1alpha1.ResourceFilterRule{configMapRule}
+		compRes := compare(t, app, proj)
+		assert.Empty(t, compRes.managedResources)
+		require.Len(t, app.Status.Conditions, 1)
+		assert.Equal(t, "Resource /ConfigMap my-map is excluded by the application or its project", app.Status.Conditions[0].Message)
+	})
+	t.Run("excluded by the project", func(t *testing.T) {
+		app := newFakeApp()
+		proj := defaultProj.DeepCopy()
+		proj.Spec.ResourceExclusions = []v1alpha1.ResourceFilterRule{configMapRule}
+		proj.Spec.ResourceInclusions = []v1alpha1.ResourceFilterRule{configMapRule}
+		compRes := compare(t, app, proj)
+		assert.Empty(t, compRes.managedResources)
+		require.Len(t, app.Status.Conditions, 1)
+		assert.Equal(t, "Resource /ConfigMap my-map is excluded by the application or its project", app.Status.Conditions[0].Message)
+	})
+}
+
 // TestCompareAppStateExtraHook tests when there is an extra _hook_ object in live but not defined in git
 func TestCompareAppStateExtraHook(t *testing.T) {
 	pod := NewPod()
@@ -914,7 +1102,7 @@ func Test_appStateManager_persistRevisionHistory(t *testing.T) {
 		app.Spec.RevisionHistoryLimit = &i
 	}
 	addHistory := func() {
-		err := manager.persistRevisionHistory(app, "my-revision", v1alpha1.ApplicationSource{}, []string{}, []v1alpha1.ApplicationSource{}, false, metav1.Time{}, v1alpha1.OperationInitiator{})
+		err := manager.persistRevisionHistory(app, "my-revision", v1alpha1.ApplicationSource{}, []string{}, []v1alpha1.ApplicationSource{}, nil, false, metav1.Time{}, v1alpha1.OperationInitiator{})
 		require.NoError(t, err)
 	}
 	addHistory()
@@ -950,7 +1138,7 @@ func Test_appStateManager_persistRevisionHistory(t *testing.T) {
 	assert.Len(t, app.Status.History, 9)
 
 	metav1NowTime := metav1.NewTime(time.Now())
-	err := manager.persistRevisionHistory(app, "my-revision", v1alpha1.ApplicationSource{}, []string{}, []v1alpha1.ApplicationSource{}, false, metav1NowTime, v1alpha1.OperationInitiator{})
+	err := manager.persistRevisionHistory(app, "my-revision", v1alpha1.ApplicationSource{}, []string{}, []v1alpha1.ApplicationSource{}, nil, false, metav1NowTime, v1alpha1.OperationInitiator{})
 	require.NoError(t, err)
 	assert.Equal(t, app.Status.History.LastRevisionHistory().DeployStartedAt, &metav1NowTime)
 
@@ -960,6 +1148,62 @@ func Test_appStateManager_persistRevisionHistory(t *testing.T) {
 	assert.Empty(t, app.Status.History)
 }
 
+func Test_appStateManager_persistRevisionHistory_export(t *testing.T) {
+	persist := func(t *testing.T, status int) (*appStateManager, *v1alpha1.Application, chan revisionHistoryExport) {
+		t.Helper()
+		exports := make(chan revisionHistoryExport, 1)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var export revisionHistoryExport
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&export))
+			w.WriteHeader(status)
+			exports <- export
+		}))
+		t.Cleanup(server.Close)
+
+		app := newFakeApp()
+		app.Spec.RevisionHistoryLimit = ptr.To(int64(1))
+		app.Status.History = v1alpha1.RevisionHistories{{ID: 0, Revision: "rev-1"}}
+		ctrl := newFakeController(&fakeData{
+			apps:          []runtime.Object{app},
+			configMapData: map[string]string{"application.revisionHistoryExportURL": server.URL},
+		}, nil)
+		manager := ctrl.appStateManager.(*appStateManager)
+		err := manager.persistRevisionHistory(app, "rev-2", v1alpha1.ApplicationSource{}, []string{}, []v1alpha1.ApplicationSource{}, nil, false, metav1.Time{}, v1alpha1.OperationInitiator{})
+		require.NoError(t, err)
+		// the purged item is kept until it has been exported
+		require.Len(t, app.Status.History, 2)
+		return manager, app, exports
+	}
+	getHistory := func(t *testing.T, manager *appStateManager, app *v1alpha1.Application) v1alpha1.RevisionHistories {
+		t.Helper()
+		updated, err := manager.appclientset.ArgoprojV1alpha1().Applications(app.Namespace).Get(t.Context(), app.Name, metav1.GetOptions{})
+		require.NoError(t, err)
+		return updated.Status.History
+	}
+
+	t.Run("exported items are removed", func(t *testing.T) {
+		manager, app, exports := persist(t, http.StatusOK)
+		export := <-exports
+		assert.Equal(t, app.QualifiedName(), export.Application)
+		require.Len(t, export.History, 1)
+		assert.Equal(t, "rev-1", export.History[0].Revision)
+		assert.Eventually(t, func() bool {
+			history := getHistory(t, manager, app)
+			return len(history) == 1 && history[0].Revision == "rev-2"
+		}, 5*time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("items are kept when the export fails", func(t *testing.T) {
+		manager, app, exports := persist(t, http.StatusInternalServerError)
+		<-exports
+		assert.Eventually(t, func() bool {
+			_, inProgress := manager.historyExports.Load(app.QualifiedName())
+			return !inProgress
+		}, 5*time.Second, 10*time.Millisecond)
+		assert.Len(t, getHistory(t, manager, app), 2)
+	})
+}
+
 // helper function to read contents of a file to string
 // panics on error
 func mustReadFile(path string) string {
@@ -1480,6 +1724,67 @@ func TestIsLiveResourceManaged(t *testing.T) {
 	})
 }
 
+func Test_applyPinnedRevisions(t *testing.T) {
+	sources := []v1alpha1.ApplicationSource{
+		{RepoURL: "https://github.com/argoproj/argocd-example-apps.git", Path: "guestbook", TargetRevision: "HEAD"},
+		{RepoURL: "https://argoproj.github.io/argo-helm", Chart: "argo-cd", TargetRevision: "8.*"},
+	}
+	newApp := func(pins string) *v1alpha1.Application {
+		app := &v1alpha1.Application{Spec: v1alpha1.ApplicationSpec{Sources: sources}}
+		if pins != "" {
+			app.Annotations = map[string]string{v1alpha1.AnnotationKeyPinnedRevisions: pins}
+		}
+		return app
+	}
+
+	t.Run("no pinned revisions", func(t *testing.T) {
+		revisions, pinnedRevisions, err := applyPinnedRevisions(newApp(""), sources, []string{"HEAD", "8.*"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"HEAD", "8.*"}, revisions)
+		assert.Nil(t, pinnedRevisions)
+	})
+
+	t.Run("pinned revisions", func(t *testing.T) {
+		revisions, pinnedRevisions, err := applyPinnedRevisions(newApp(`{"2":"8.1.0"}`), sources, []string{"HEAD", "8.*"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"HEAD", "8.1.0"}, revisions)
+		assert.Equal(t, []string{"", "8.1.0"}, pinnedRevisions)
+	})
+
+	t.Run("explicitly requested revisions", func(t *testing.T) {
+		revisions, _, err := applyPinnedRevisions(newApp(`{"1":"8f3a2c1","2":"8.1.0"}`), sources, []string{"HEAD", "8.2.0"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"8f3a2c1", "8.2.0"}, revisions)
+	})
+
+	t.Run("no revisions", func(t *testing.T) {
+		revisions, _, err := applyPinnedRevisions(newApp(`{"1":"8f3a2c1"}`), sources, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"8f3a2c1", "8.*"}, revisions)
+	})
+
+	t.Run("invalid pinned revisions", func(t *testing.T) {
+		revisions, _, err := applyPinnedRevisions(newApp(`{"1":`), sources, []string{"HEAD", "8.*"})
+		require.Error(t, err)
+		assert.Equal(t, []string{"HEAD", "8.*"}, revisions)
+	})
+}
+
+func TestResolvedVersionConstraints(t *testing.T) {
+	sources := []v1alpha1.ApplicationSource{
+		{RepoURL: "https://github.com/argoproj/argocd-example-apps.git", Path: "guestbook", TargetRevision: "v1.*"},
+		{RepoURL: "https://argoproj.github.io/argo-helm", Chart: "argo-cd", TargetRevision: "8.*"},
+	}
+
+	// only the version constraints of the Helm sources are recorded
+	assert.Equal(t, []string{"", "8.*"}, resolvedVersionConstraints(sources, []string{"v1.*", "8.*"}, []string{"8f3a2c1", "8.1.3"}))
+	// a pinned version isn't a version constraint
+	assert.Nil(t, resolvedVersionConstraints(sources, []string{"v1.*", "8.1.0"}, []string{"8f3a2c1", "8.1.0"}))
+	// the resolved revision doesn't satisfy the constraint
+	assert.Nil(t, resolvedVersionConstraints(sources, []string{"v1.*", "8.*"}, []string{"8f3a2c1", "9.0.0"}))
+	assert.Nil(t, resolvedVersionConstraints(sources, []string{"v1.*", "8.*"}, nil))
+}
+
 func TestUseDiffCache(t *testing.T) {
 	t.Parallel()
 	type fixture struct {
@@ -1492,6 +1797,7 @@ func TestUseDiffCache(t *testing.T) {
 		statusRefreshTimeout time.Duration
 		expectedUseCache     bool
 		serverSideDiff       bool
+		diffSettingsChanged  bool
 	}
 	manifestInfos := func(revision string) []*apiclient.ManifestResponse {
 		return []*apiclient.ManifestResponse{
@@ -1738,6 +2044,18 @@ func TestUseDiffCache(t *testing.T) {
 			expectedUseCache:     false,
 			serverSideDiff:       false,
 		},
+		{
+			testName:             "will return false if diff settings changed",
+			noCache:              false,
+			manifestInfos:        manifestInfos("rev1"),
+			sources:              sources(),
+			app:                  app("httpbin", "rev1", false, nil),
+			manifestRevisions:    []string{"rev1"},
+			statusRefreshTimeout: time.Hour * 24,
+			expectedUseCache:     false,
+			serverSideDiff:       false,
+			diffSettingsChanged:  true,
+		},
 	}
 
 	for _, tc := range cases {
@@ -1747,13 +2065,44 @@ func TestUseDiffCache(t *testing.T) {
 			logger, _ := logrustest.NewNullLogger()
 			log := logrus.NewEntry(logger)
 			// When
-			useDiffCache := useDiffCache(tc.noCache, tc.manifestInfos, tc.sources, tc.app, tc.manifestRevisions, tc.statusRefreshTimeout, tc.serverSideDiff, log)
+			useDiffCache := useDiffCache(tc.noCache, tc.manifestInfos, tc.sources, tc.app, tc.manifestRevisions, tc.statusRefreshTimeout, tc.serverSideDiff, tc.diffSettingsChanged, log)
 			// Then
 			assert.Equal(t, tc.expectedUseCache, useDiffCache)
 		})
 	}
 }
 
+func TestGetDiffSettingsHash(t *testing.T) {
+	overrides := func(jsonPointers ...string) map[string]v1alpha1.ResourceOverride {
+		return map[string]v1alpha1.ResourceOverride{
+			"apps/Deployment": {
+				HealthLua:         "return {}",
+				IgnoreDifferences: v1alpha1.OverrideIgnoreDiff{JSONPointers: jsonPointers},
+			},
+		}
+	}
+	hash := func(t *testing.T, resourceOverrides map[string]v1alpha1.ResourceOverride, compareOptions settings.ArgoCDDiffOptions, trackingMethod string) string {
+		t.Helper()
+		hash, err := getDiffSettingsHash(resourceOverrides, compareOptions, normalizers.IgnoreNormalizerOpts{}, common.LabelKeyAppInstance, trackingMethod)
+		require.NoError(t, err)
+		require.NotEmpty(t, hash)
+		return hash
+	}
+	base := hash(t, overrides("/spec/replicas"), settings.GetDefaultDiffOptions(), "annotation")
+
+	assert.Equal(t, base, hash(t, overrides("/spec/replicas"), settings.GetDefaultDiffOptions(), "annotation"))
+	assert.NotEqual(t, base, hash(t, overrides("/spec/template"), settings.GetDefaultDiffOptions(), "annotation"))
+	assert.NotEqual(t, base, hash(t, overrides("/spec/replicas"), settings.ArgoCDDiffOptions{IgnoreAggregatedRoles: true}, "annotation"))
+	assert.NotEqual(t, base, hash(t, overrides("/spec/replicas"), settings.GetDefaultDiffOptions(), "label"))
+
+	// settings which don't affect the diff don't change the hash
+	o := overrides("/spec/replicas")
+	deployment := o["apps/Deployment"]
+	deployment.HealthLua = "return {status = 'Healthy'}"
+	o["apps/Deployment"] = deployment
+	assert.Equal(t, base, hash(t, o, settings.GetDefaultDiffOptions(), "annotation"))
+}
+
 func TestCompareAppStateDefaultRevisionUpdated(t *testing.T) {
 	app := newFakeApp()
 	data := fakeData{
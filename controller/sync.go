@@ -23,6 +23,7 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/managedfields"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
 	"k8s.io/kubectl/pkg/util/openapi"
@@ -79,6 +80,11 @@ func (m *appStateManager) getServerSideDiffDryRunApplier(cluster *v1alpha1.Clust
 	if err != nil {
 		return nil, nil, fmt.Errorf("error getting cluster REST config: %w", err)
 	}
+	// the dry-run applier ignores the context of the dry-run applies, so their timeout is applied to the requests of
+	// the REST config
+	if m.serverSideDryRunOpts.Timeout > 0 {
+		rawConfig.Timeout = m.serverSideDryRunOpts.Timeout
+	}
 	ops, cleanup, err := kubeutil.ManageServerSideDiffDryRuns(rawConfig, clusterCache.GetOpenAPISchema(), m.onKubectlRun)
 	if err != nil {
 		return nil, nil, fmt.Errorf("error creating kubectl ResourceOperations: %w", err)
@@ -159,6 +165,11 @@ func (m *appStateManager) SyncAppState(app *v1alpha1.Application, project *v1alp
 
 	// ignore error if CompareStateRepoError, this shouldn't happen as noRevisionCache is true
 	compareResult, err := m.CompareAppState(app, project, revisions, sources, false, true, syncOp.Manifests, isMultiSourceSync)
+	if stderrors.Is(err, errManifestGenerationDeferred) {
+		// the sync is resumed once the manifest generation is allowed by the concurrency limit of the project
+		state.Message = manifestGenerationDeferredMessage
+		return
+	}
 	if err != nil && !stderrors.Is(err, ErrCompareStateRepo) {
 		state.Phase = common.OperationError
 		state.Message = err.Error()
@@ -178,16 +189,27 @@ func (m *appStateManager) SyncAppState(app *v1alpha1.Application, project *v1alp
 		return
 	}
 
-	// If there are any comparison or spec errors error conditions do not perform the operation
+	// If there are any comparison, spec, manifest policy, plaintext Secret or image registry error conditions do not
+	// perform the operation
 	if errConditions := app.Status.GetConditions(map[v1alpha1.ApplicationConditionType]bool{
-		v1alpha1.ApplicationConditionComparisonError:  true,
-		v1alpha1.ApplicationConditionInvalidSpecError: true,
+		v1alpha1.ApplicationConditionComparisonError:      true,
+		v1alpha1.ApplicationConditionInvalidSpecError:     true,
+		v1alpha1.ApplicationConditionPolicyViolationError: true,
+		v1alpha1.ApplicationConditionPlaintextSecretError: true,
+		v1alpha1.ApplicationConditionImageRegistryError:   true,
 	}); len(errConditions) > 0 {
 		state.Phase = common.OperationError
 		state.Message = argo.FormatAppConditions(errConditions)
 		return
 	}
 
+	// Do not perform the operation if it uses sync options or resource hooks denied by the project
+	if violations := syncRestrictionViolations(project, syncOp, compareResult.reconciliationResult); len(violations) > 0 {
+		state.Phase = common.OperationFailed
+		state.Message = fmt.Sprintf("Sync operation denied by project %s: %s", project.Name, strings.Join(violations, "; "))
+		return
+	}
+
 	destCluster, err := argo.GetDestinationCluster(context.Background(), app.Spec.Destination, m.db)
 	if err != nil {
 		state.Phase = common.OperationError
@@ -276,7 +298,7 @@ func (m *appStateManager) SyncAppState(app *v1alpha1.Application, project *v1alp
 		log.Errorf("Could not get installation ID: %v", err)
 		return
 	}
-	trackingMethod, err := m.settingsMgr.GetTrackingMethod()
+	trackingMethod, err := argo.GetTrackingMethod(m.settingsMgr, project)
 	if err != nil {
 		log.Errorf("Could not get trackingMethod: %v", err)
 		return
@@ -349,8 +371,10 @@ func (m *appStateManager) SyncAppState(app *v1alpha1.Application, project *v1alp
 		sync.WithSkipDryRunOnMissingResource(syncOp.SyncOptions.HasOption(common.SyncOptionSkipDryRunOnMissingResource)),
 	}
 
+	// the applications inherit the default sync policy of their project, e.g. its managed namespace metadata
+	syncPolicy := project.GetApplicationSyncPolicy(app.Spec.SyncPolicy)
 	if syncOp.SyncOptions.HasOption("CreateNamespace=true") {
-		opts = append(opts, sync.WithNamespaceModifier(syncNamespace(app.Spec.SyncPolicy)))
+		opts = append(opts, sync.WithNamespaceModifier(syncNamespace(syncPolicy)))
 	}
 
 	syncCtx, cleanup, err := sync.NewSyncContext(
@@ -380,10 +404,11 @@ func (m *appStateManager) SyncAppState(app *v1alpha1.Application, project *v1alp
 	}
 	var resState []common.ResourceSyncResult
 	state.Phase, state.Message, resState = syncCtx.GetState()
+	previousResources := state.SyncResult.Resources
 	state.SyncResult.Resources = nil
 
-	if app.Spec.SyncPolicy != nil {
-		state.SyncResult.ManagedNamespaceMetadata = app.Spec.SyncPolicy.ManagedNamespaceMetadata
+	if syncPolicy != nil {
+		state.SyncResult.ManagedNamespaceMetadata = syncPolicy.ManagedNamespaceMetadata
 	}
 
 	var apiVersion []kube.APIResourceInfo
@@ -419,10 +444,20 @@ func (m *appStateManager) SyncAppState(app *v1alpha1.Application, project *v1alp
 		})
 	}
 
+	if hookOutputLimitBytes > 0 {
+		appName := app.InstanceName(m.namespace)
+		captureHookOutputs(context.TODO(), state.SyncResult.Resources, previousResources, func() (kubernetes.Interface, error) {
+			return kubernetes.NewForConfig(restConfig)
+		}, func(kubeClientset kubernetes.Interface, namespace string, outputs []v1alpha1.HookOutput) {
+			// the logs are captured in the background, not to slow down the sync
+			go m.captureHookLogs(kubeClientset, appName, namespace, outputs, hookOutputLimitBytes)
+		})
+	}
+
 	logEntry.WithField("duration", time.Since(start)).Info("sync/terminate complete")
 
 	if !syncOp.DryRun && len(syncOp.Resources) == 0 && state.Phase.Successful() {
-		err := m.persistRevisionHistory(app, compareResult.syncStatus.Revision, compareResult.syncStatus.ComparedTo.Source, compareResult.syncStatus.Revisions, compareResult.syncStatus.ComparedTo.Sources, isMultiSourceSync, state.StartedAt, state.Operation.InitiatedBy)
+		err := m.persistRevisionHistory(app, compareResult.syncStatus.Revision, compareResult.syncStatus.ComparedTo.Source, compareResult.syncStatus.Revisions, compareResult.syncStatus.ComparedTo.Sources, compareResult.syncStatus.VersionConstraints, isMultiSourceSync, state.StartedAt, state.Operation.InitiatedBy)
 		if err != nil {
 			state.Phase = common.OperationError
 			state.Message = fmt.Sprintf("failed to record sync to history: %v", err)
@@ -559,7 +594,12 @@ func syncWindowPreventsSync(app *v1alpha1.Application, proj *v1alpha1.AppProject
 	window := proj.Spec.SyncWindows.Matches(app)
 	isManual := false
 	if app.Status.OperationState != nil {
-		isManual = !app.Status.OperationState.Operation.InitiatedBy.Automated
+		initiatedBy := app.Status.OperationState.Operation.InitiatedBy
+		// the break-glass syncs approved by a second user aren't blocked by the deny sync windows
+		if proj.Spec.BreakGlassSync && initiatedBy.ApprovedBy != "" {
+			return false, nil
+		}
+		isManual = !initiatedBy.Automated
 	}
 	canSync, err := window.CanSync(isManual)
 	if err != nil {
@@ -277,6 +277,28 @@ func TestAppStateManager_SyncAppState(t *testing.T) {
 		assert.Equal(t, synccommon.OperationFailed, opState.Phase)
 		assert.Contains(t, opState.Message, "ConfigMap/configmap1 is part of applications fake-argocd-ns/my-app and guestbook")
 	})
+
+	t.Run("will fail the sync if it uses sync options denied by the project", func(t *testing.T) {
+		// given
+		t.Parallel()
+		f := setup(nil)
+		f.project.Spec.SignatureKeys = nil
+		f.project.Spec.DeniedSyncOptions = []string{"Replace=true"}
+
+		opState := &v1alpha1.OperationState{Operation: v1alpha1.Operation{
+			Sync: &v1alpha1.SyncOperation{
+				Source:      &v1alpha1.ApplicationSource{},
+				SyncOptions: []string{"Replace=true"},
+			},
+		}}
+
+		// when
+		f.controller.appStateManager.SyncAppState(f.application, f.project, opState)
+
+		// then
+		assert.Equal(t, synccommon.OperationFailed, opState.Phase)
+		assert.Equal(t, "Sync operation denied by project default: sync option Replace=true is not permitted", opState.Message)
+	})
 }
 
 func TestSyncWindowDeniesSync(t *testing.T) {
@@ -349,6 +371,28 @@ func TestSyncWindowDeniesSync(t *testing.T) {
 		assert.Equal(t, synccommon.OperationRunning, opState.Phase)
 		assert.Contains(t, opState.Message, opMessage)
 	})
+
+	t.Run("will not block an approved break-glass sync", func(t *testing.T) {
+		// given a project allowing break-glass syncs with an active deny sync window and an approved break-glass sync
+		t.Parallel()
+		f := setup()
+		f.project.Spec.BreakGlassSync = true
+		operation := v1alpha1.Operation{
+			Sync: &v1alpha1.SyncOperation{
+				Source: &v1alpha1.ApplicationSource{},
+			},
+			InitiatedBy: v1alpha1.OperationInitiator{Username: "alice", Justification: "hotfix", ApprovedBy: "bob"},
+		}
+		f.application.Status.OperationState = &v1alpha1.OperationState{Operation: operation, Phase: synccommon.OperationRunning}
+		opState := &v1alpha1.OperationState{Operation: operation, Phase: synccommon.OperationRunning}
+
+		// when
+		f.controller.appStateManager.SyncAppState(f.application, f.project, opState)
+
+		// then
+		assert.NotContains(t, opState.Message, "Sync operation blocked by sync window")
+		assert.Equal(t, synccommon.OperationSucceeded, opState.Phase)
+	})
 }
 
 func TestNormalizeTargetResources(t *testing.T) {
@@ -0,0 +1,77 @@
+package controller
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/argoproj/gitops-engine/pkg/sync"
+	"github.com/argoproj/gitops-engine/pkg/sync/common"
+	"github.com/argoproj/gitops-engine/pkg/sync/hook"
+	resourceutil "github.com/argoproj/gitops-engine/pkg/sync/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	"github.com/argoproj/argo-cd/v3/util/argo"
+)
+
+// syncRestrictionViolations returns the sync options and the resource hooks of the sync operation which aren't
+// permitted by the project. Both the sync options of the operation and the ones of the sync-options annotation of the
+// synced resources are checked.
+func syncRestrictionViolations(project *v1alpha1.AppProject, syncOp v1alpha1.SyncOperation, reconciliationResult sync.ReconciliationResult) []string {
+	if len(project.Spec.DeniedSyncOptions) == 0 && len(project.Spec.DeniedHookTypes) == 0 {
+		return nil
+	}
+	var violations []string
+	options := slices.Clone(syncOp.SyncOptions)
+	if syncOp.SyncStrategy.Force() {
+		options = options.AddOption(common.SyncOptionForce)
+	}
+	for _, option := range options {
+		if !project.IsSyncOptionPermitted(option) {
+			violations = append(violations, fmt.Sprintf("sync option %s is not permitted", option))
+		}
+	}
+	for _, obj := range slices.Concat(reconciliationResult.Target, reconciliationResult.Hooks) {
+		if obj == nil || !isSyncedResource(syncOp, obj) {
+			continue
+		}
+		name := obj.GetName()
+		if obj.GetNamespace() != "" {
+			name = obj.GetNamespace() + "/" + name
+		}
+		for _, option := range resourceutil.GetAnnotationCSVs(obj, common.AnnotationSyncOptions) {
+			if !project.IsSyncOptionPermitted(option) {
+				violations = append(violations, fmt.Sprintf("sync option %s of %s %s is not permitted", option, obj.GetKind(), name))
+			}
+		}
+		for _, hookType := range resourceHookTypes(obj) {
+			if !project.IsHookTypePermitted(hookType) {
+				violations = append(violations, fmt.Sprintf("%s hook %s %s is not permitted", hookType, obj.GetKind(), name))
+			}
+		}
+	}
+	return violations
+}
+
+// isSyncedResource returns whether the resource is synced by the sync operation, i.e. whether the operation syncs all
+// the resources or the resource is one of the selected ones
+func isSyncedResource(syncOp v1alpha1.SyncOperation, obj *unstructured.Unstructured) bool {
+	if len(syncOp.Resources) == 0 || isPostDeleteHook(obj) {
+		return true
+	}
+	gvk := obj.GroupVersionKind()
+	return argo.ContainsSyncResource(obj.GetName(), obj.GetNamespace(), schema.GroupVersionKind{Kind: gvk.Kind, Group: gvk.Group}, syncOp.Resources)
+}
+
+// resourceHookTypes returns the hook types of the resource, including the PostDelete hooks handled by Argo CD
+func resourceHookTypes(obj *unstructured.Unstructured) []string {
+	var types []string
+	for _, hookType := range hook.Types(obj) {
+		types = append(types, string(hookType))
+	}
+	if isPostDeleteHook(obj) {
+		types = append(types, postDeleteHook)
+	}
+	return types
+}
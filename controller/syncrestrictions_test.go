@@ -0,0 +1,51 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/argoproj/gitops-engine/pkg/sync"
+	. "github.com/argoproj/gitops-engine/pkg/utils/testing"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	appv1 "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+)
+
+func TestSyncRestrictionViolations(t *testing.T) {
+	pod := NewPod()
+	pod.SetAnnotations(map[string]string{"argocd.argoproj.io/sync-options": "Replace=true,Validate=false"})
+	preSyncHook := NewPod()
+	preSyncHook.SetName("pre-sync")
+	preSyncHook.SetAnnotations(map[string]string{"argocd.argoproj.io/hook": "PreSync"})
+	helmHook := NewPod()
+	helmHook.SetName("helm-hook")
+	helmHook.SetAnnotations(map[string]string{"helm.sh/hook": "post-delete"})
+	reconciliationResult := sync.ReconciliationResult{
+		Target: []*unstructured.Unstructured{pod, nil, NewService()},
+		Hooks:  []*unstructured.Unstructured{preSyncHook, helmHook},
+	}
+	syncOp := appv1.SyncOperation{
+		SyncOptions:  appv1.SyncOptions{"Replace=true", "CreateNamespace=true"},
+		SyncStrategy: &appv1.SyncStrategy{Hook: &appv1.SyncStrategyHook{SyncStrategyApply: appv1.SyncStrategyApply{Force: true}}},
+	}
+
+	project := &appv1.AppProject{ObjectMeta: metav1.ObjectMeta{Name: "default"}}
+	assert.Empty(t, syncRestrictionViolations(project, syncOp, reconciliationResult))
+
+	project.Spec.DeniedSyncOptions = []string{"Replace=true", "Force=true"}
+	project.Spec.DeniedHookTypes = []string{"PreSync", "PostDelete"}
+	assert.Equal(t, []string{
+		"sync option Replace=true is not permitted",
+		"sync option Force=true is not permitted",
+		"sync option Replace=true of Pod my-pod is not permitted",
+		"PreSync hook Pod pre-sync is not permitted",
+		"PostDelete hook Pod helm-hook is not permitted",
+	}, syncRestrictionViolations(project, syncOp, reconciliationResult))
+
+	// only the selected resources are checked by a partial sync
+	syncOp = appv1.SyncOperation{Resources: []appv1.SyncOperationResource{{Kind: "Service", Name: "my-service"}}}
+	assert.Equal(t, []string{
+		"PostDelete hook Pod helm-hook is not permitted",
+	}, syncRestrictionViolations(project, syncOp, reconciliationResult))
+}
@@ -0,0 +1,56 @@
+package controller
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	appv1 "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+)
+
+// tracer creates the spans of the application controller. The spans are only exported when OTLP tracing is enabled,
+// in which case the reconcile and sync duration metrics carry exemplars linking them to the spans.
+var tracer = otel.Tracer("github.com/argoproj/argo-cd/v3/controller")
+
+func appSpanAttributes(app *appv1.Application) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.String("app.namespace", app.Namespace),
+		attribute.String("app.name", app.Name),
+		attribute.String("app.project", app.Spec.GetProject()),
+	}
+}
+
+// startReconcileSpan starts the span of the reconciliation of an application
+func startReconcileSpan(app *appv1.Application, comparisonLevel CompareWith) (context.Context, trace.Span) {
+	return tracer.Start(context.Background(), "reconcile",
+		trace.WithSpanKind(trace.SpanKindInternal),
+		trace.WithAttributes(appSpanAttributes(app)...),
+		trace.WithAttributes(attribute.Int("comparison-level", int(comparisonLevel))))
+}
+
+// recordSyncSpan records the span of a completed sync operation, from its start to its end, and returns the context
+// of the span. Operations span several reconciliations, so the span is recorded once the operation is completed.
+func recordSyncSpan(app *appv1.Application, state *appv1.OperationState) context.Context {
+	opts := []trace.SpanStartOption{
+		trace.WithSpanKind(trace.SpanKindInternal),
+		trace.WithAttributes(appSpanAttributes(app)...),
+		trace.WithAttributes(attribute.String("phase", string(state.Phase))),
+		trace.WithTimestamp(state.StartedAt.Time),
+	}
+	if state.SyncResult != nil {
+		opts = append(opts, trace.WithAttributes(attribute.String("revision", state.SyncResult.Revision)))
+	}
+	ctx, span := tracer.Start(context.Background(), "sync", opts...)
+	if !state.Phase.Successful() {
+		span.SetStatus(codes.Error, state.Message)
+	}
+	var endOpts []trace.SpanEndOption
+	if state.FinishedAt != nil {
+		endOpts = append(endOpts, trace.WithTimestamp(state.FinishedAt.Time))
+	}
+	span.End(endOpts...)
+	return ctx
+}
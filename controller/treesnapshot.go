@@ -0,0 +1,88 @@
+package controller
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	appv1 "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	applog "github.com/argoproj/argo-cd/v3/util/app/log"
+	appstatecache "github.com/argoproj/argo-cd/v3/util/cache/appstate"
+)
+
+// resourceTreeSnapshotter tracks when the resource trees of the applications were last snapshotted
+type resourceTreeSnapshotter struct {
+	interval  time.Duration
+	retention time.Duration
+	// lastSnapshots holds the time of the last snapshot of each application, so that the snapshots stored in the cache
+	// are only loaded once the interval has elapsed
+	lastSnapshots sync.Map
+}
+
+func newResourceTreeSnapshotter(interval time.Duration, retention time.Duration) *resourceTreeSnapshotter {
+	return &resourceTreeSnapshotter{interval: interval, retention: retention}
+}
+
+// due returns true if the last snapshot of the resource tree of the application is older than the snapshot interval
+func (s *resourceTreeSnapshotter) due(appName string, now time.Time) bool {
+	if s.interval <= 0 {
+		return false
+	}
+	last, ok := s.lastSnapshots.Load(appName)
+	return !ok || now.Sub(last.(time.Time)) >= s.interval
+}
+
+func (s *resourceTreeSnapshotter) forget(appName string) {
+	s.lastSnapshots.Delete(appName)
+}
+
+// snapshotResourceTree appends a snapshot of the resource tree of the application to the snapshots stored in the
+// cache once the last snapshot is older than the snapshot interval, and drops the snapshots older than the retention.
+// The orphaned nodes and the details of the nodes which aren't needed to diff the snapshots are left out. Failures are
+// only logged, since the snapshots are only used to inspect the history of the tree.
+func (ctrl *ApplicationController) snapshotResourceTree(a *appv1.Application, tree *appv1.ApplicationTree) {
+	snapshotter := ctrl.resourceTreeSnapshots
+	appName := a.InstanceName(ctrl.namespace)
+	// the times are truncated to the second like the times returned by the API, so that they can be used to select
+	// the snapshots
+	now := time.Now().UTC().Truncate(time.Second)
+	if !snapshotter.due(appName, now) {
+		return
+	}
+	logCtx := log.WithFields(applog.GetAppLogFields(a))
+	var snapshots []appstatecache.ResourceTreeSnapshot
+	err := ctrl.cache.GetAppResourcesTreeSnapshots(appName, &snapshots)
+	if err != nil && !errors.Is(err, appstatecache.ErrCacheMiss) {
+		logCtx.Warnf("Failed to get the resource tree snapshots: %v", err)
+		return
+	}
+	if len(snapshots) > 0 && now.Sub(snapshots[len(snapshots)-1].Time) < snapshotter.interval {
+		// the last snapshot was taken before a restart of the controller or by another controller
+		snapshotter.lastSnapshots.Store(appName, snapshots[len(snapshots)-1].Time)
+		return
+	}
+	var retained []appstatecache.ResourceTreeSnapshot
+	for _, snapshot := range snapshots {
+		if now.Sub(snapshot.Time) < snapshotter.retention {
+			retained = append(retained, snapshot)
+		}
+	}
+	snapshot := appstatecache.ResourceTreeSnapshot{Time: now}
+	for _, node := range tree.Nodes {
+		snapshot.Nodes = append(snapshot.Nodes, appv1.ResourceNode{
+			ResourceRef: node.ResourceRef,
+			ParentRefs:  node.ParentRefs,
+			Images:      node.Images,
+			Health:      node.Health,
+			CreatedAt:   node.CreatedAt,
+		})
+	}
+	retained = append(retained, snapshot)
+	if err := ctrl.cache.SetAppResourcesTreeSnapshots(appName, retained, snapshotter.retention); err != nil {
+		logCtx.Warnf("Failed to store the resource tree snapshots: %v", err)
+		return
+	}
+	snapshotter.lastSnapshots.Store(appName, now)
+}
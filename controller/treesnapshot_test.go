@@ -0,0 +1,77 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/argoproj/gitops-engine/pkg/health"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	appv1 "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	appstatecache "github.com/argoproj/argo-cd/v3/util/cache/appstate"
+)
+
+func TestSnapshotResourceTree(t *testing.T) {
+	app := newFakeApp()
+	tree := &appv1.ApplicationTree{
+		Nodes: []appv1.ResourceNode{{
+			ResourceRef:     appv1.ResourceRef{Kind: "Pod", Namespace: "default", Name: "my-pod"},
+			Info:            []appv1.InfoItem{{Name: "Status Reason", Value: "Running"}},
+			ResourceVersion: "123",
+			Images:          []string{"nginx:1.27"},
+			Health:          &appv1.HealthStatus{Status: health.HealthStatusHealthy},
+		}},
+		OrphanedNodes: []appv1.ResourceNode{{ResourceRef: appv1.ResourceRef{Kind: "ConfigMap", Namespace: "default", Name: "orphan"}}},
+	}
+	getSnapshots := func(ctrl *ApplicationController) []appstatecache.ResourceTreeSnapshot {
+		var snapshots []appstatecache.ResourceTreeSnapshot
+		err := ctrl.cache.GetAppResourcesTreeSnapshots(app.InstanceName(ctrl.namespace), &snapshots)
+		if err != nil {
+			require.ErrorIs(t, err, appstatecache.ErrCacheMiss)
+		}
+		return snapshots
+	}
+
+	t.Run("snapshots are disabled", func(t *testing.T) {
+		ctrl := newFakeController(&fakeData{apps: []runtime.Object{app, &defaultProj}}, nil)
+		ctrl.snapshotResourceTree(app, tree)
+		assert.Empty(t, getSnapshots(ctrl))
+	})
+
+	t.Run("tree is snapshotted once per interval", func(t *testing.T) {
+		ctrl := newFakeController(&fakeData{apps: []runtime.Object{app, &defaultProj}}, nil)
+		ctrl.resourceTreeSnapshots = newResourceTreeSnapshotter(time.Hour, 24*time.Hour)
+		ctrl.snapshotResourceTree(app, tree)
+		ctrl.snapshotResourceTree(app, tree)
+		snapshots := getSnapshots(ctrl)
+		require.Len(t, snapshots, 1)
+		assert.WithinDuration(t, time.Now(), snapshots[0].Time, 2*time.Second)
+		assert.Equal(t, []appv1.ResourceNode{{
+			ResourceRef: appv1.ResourceRef{Kind: "Pod", Namespace: "default", Name: "my-pod"},
+			Images:      []string{"nginx:1.27"},
+			Health:      &appv1.HealthStatus{Status: health.HealthStatusHealthy},
+		}}, snapshots[0].Nodes)
+
+		// a snapshot taken by another controller is taken into account
+		ctrl.resourceTreeSnapshots.forget(app.InstanceName(ctrl.namespace))
+		ctrl.snapshotResourceTree(app, tree)
+		assert.Len(t, getSnapshots(ctrl), 1)
+	})
+
+	t.Run("snapshots older than the retention are dropped", func(t *testing.T) {
+		ctrl := newFakeController(&fakeData{apps: []runtime.Object{app, &defaultProj}}, nil)
+		ctrl.resourceTreeSnapshots = newResourceTreeSnapshotter(time.Hour, 24*time.Hour)
+		now := time.Now().UTC()
+		require.NoError(t, ctrl.cache.SetAppResourcesTreeSnapshots(app.InstanceName(ctrl.namespace), []appstatecache.ResourceTreeSnapshot{
+			{Time: now.Add(-25 * time.Hour)},
+			{Time: now.Add(-2 * time.Hour)},
+		}, time.Hour))
+		ctrl.snapshotResourceTree(app, tree)
+		snapshots := getSnapshots(ctrl)
+		require.Len(t, snapshots, 2)
+		assert.Equal(t, now.Add(-2*time.Hour), snapshots[0].Time)
+		assert.Len(t, snapshots[1].Nodes, 1)
+	})
+}
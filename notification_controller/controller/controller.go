@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/argoproj/argo-cd/v3/util/glob"
@@ -24,6 +26,7 @@ import (
 	"github.com/argoproj/notifications-engine/pkg/subscriptions"
 	httputil "github.com/argoproj/notifications-engine/pkg/util/http"
 	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -37,6 +40,10 @@ import (
 
 const (
 	resyncPeriod = 60 * time.Second
+
+	// IgnoreProjectSubscriptionsAnnotation opts an application out of the subscriptions of its project. The value is
+	// either "true" to ignore all of them, or a comma separated list of triggers or <trigger>.<service> pairs.
+	IgnoreProjectSubscriptionsAnnotation = "notifications.argoproj.io/ignore-project-subscriptions"
 )
 
 var (
@@ -56,10 +63,14 @@ type NotificationController interface {
 
 type notificationController struct {
 	ctrl              controller.NotificationController
+	appClient         dynamic.NamespaceableResourceInterface
 	appInformer       cache.SharedIndexInformer
 	appProjInformer   cache.SharedIndexInformer
 	secretInformer    cache.SharedIndexInformer
 	configMapInformer cache.SharedIndexInformer
+	namespace         string
+	configMapName     string
+	throttler         *throttler
 }
 
 func NewController(
@@ -92,13 +103,19 @@ func NewController(
 	}
 	secretInformer := k8s.NewSecretInformer(k8sClient, notificationConfigNamespace, secretName)
 	configMapInformer := k8s.NewConfigMapInformer(k8sClient, notificationConfigNamespace, configMapName)
-	apiFactory := api.NewFactory(settings.GetFactorySettings(argocdService, secretName, configMapName, selfServiceNotificationEnabled), namespace, secretInformer, configMapInformer)
+	apiFactory := api.NewFactory(settings.GetFactorySettings(argocdService, secretName, configMapName, selfServiceNotificationEnabled, func(app *unstructured.Unstructured) *unstructured.Unstructured {
+		return getAppProj(app, appProjInformer)
+	}), namespace, secretInformer, configMapInformer)
 
 	res := &notificationController{
+		appClient:         namespaceableAppClient,
 		secretInformer:    secretInformer,
 		configMapInformer: configMapInformer,
 		appInformer:       appInformer,
 		appProjInformer:   appProjInformer,
+		namespace:         namespace,
+		configMapName:     configMapName,
+		throttler:         newThrottler(),
 	}
 	skipProcessingOpt := controller.WithSkipProcessing(func(obj metav1.Object) (bool, string) {
 		app, ok := (obj).(*unstructured.Unstructured)
@@ -112,17 +129,20 @@ func NewController(
 	})
 	metricsRegistryOpt := controller.WithMetricsRegistry(registry)
 	alterDestinationsOpt := controller.WithAlterDestinations(res.alterDestinations)
+	eventCallbackOpt := controller.WithEventCallback(res.recordDeliveries)
 
 	if !selfServiceNotificationEnabled {
 		res.ctrl = controller.NewController(namespaceableAppClient, appInformer, apiFactory,
 			skipProcessingOpt,
 			metricsRegistryOpt,
-			alterDestinationsOpt)
+			alterDestinationsOpt,
+			eventCallbackOpt)
 	} else {
 		res.ctrl = controller.NewControllerWithNamespaceSupport(namespaceableAppClient, appInformer, apiFactory,
 			skipProcessingOpt,
 			metricsRegistryOpt,
-			alterDestinationsOpt)
+			alterDestinationsOpt,
+			eventCallbackOpt)
 	}
 	return res
 }
@@ -139,10 +159,70 @@ func (c *notificationController) alterDestinations(obj metav1.Object, destinatio
 	}
 
 	if proj := getAppProj(app, c.appProjInformer); proj != nil {
-		destinations.Merge(subscriptions.NewAnnotations(proj.GetAnnotations()).GetDestinations(cfg.DefaultTriggers, cfg.ServiceDefaultTriggers))
-		destinations.Merge(settings.GetLegacyDestinations(proj.GetAnnotations(), cfg.DefaultTriggers, cfg.ServiceDefaultTriggers))
+		projDestinations := services.Destinations{}
+		projDestinations.Merge(subscriptions.NewAnnotations(proj.GetAnnotations()).GetDestinations(cfg.DefaultTriggers, cfg.ServiceDefaultTriggers))
+		projDestinations.Merge(settings.GetLegacyDestinations(proj.GetAnnotations(), cfg.DefaultTriggers, cfg.ServiceDefaultTriggers))
+		destinations.Merge(ignoreProjectDestinations(projDestinations, app.GetAnnotations()[IgnoreProjectSubscriptionsAnnotation]))
+	}
+	return c.batchDestinations(c.throttleDestinations(app, destinations), cfg)
+}
+
+// batchDestinations tags the recipients of the batched services with the trigger of their notifications, which
+// services don't receive otherwise, so that the notifications of different triggers are batched separately
+func (c *notificationController) batchDestinations(destinations services.Destinations, cfg api.Config) services.Destinations {
+	namespace := cfg.Namespace
+	if namespace == "" {
+		namespace = c.namespace
+	}
+	obj, exists, err := c.configMapInformer.GetIndexer().GetByKey(namespace + "/" + c.configMapName)
+	if err != nil || !exists {
+		return destinations
+	}
+	cm, ok := obj.(*corev1.ConfigMap)
+	if !ok {
+		return destinations
+	}
+	res := services.Destinations{}
+	for trigger, dests := range destinations {
+		for _, dest := range dests {
+			if settings.IsBatchedService(cm, dest.Service) {
+				dest.Recipient = settings.BatchedRecipient(trigger, dest.Recipient)
+			}
+			res[trigger] = append(res[trigger], dest)
+		}
+	}
+	return res
+}
+
+// ignoreProjectDestinations removes the project destinations the application opted out of using the
+// IgnoreProjectSubscriptionsAnnotation annotation
+func ignoreProjectDestinations(destinations services.Destinations, ignored string) services.Destinations {
+	ignored = strings.TrimSpace(ignored)
+	if ignored == "" {
+		return destinations
+	}
+	if ignoreAll, err := strconv.ParseBool(ignored); err == nil {
+		if ignoreAll {
+			return services.Destinations{}
+		}
+		return destinations
+	}
+	ignoredItems := map[string]bool{}
+	for _, item := range strings.Split(ignored, ",") {
+		ignoredItems[strings.TrimSpace(item)] = true
+	}
+	res := services.Destinations{}
+	for trigger, dests := range destinations {
+		if ignoredItems[trigger] {
+			continue
+		}
+		for _, dest := range dests {
+			if !ignoredItems[trigger+"."+dest.Service] {
+				res[trigger] = append(res[trigger], dest)
+			}
+		}
 	}
-	return destinations
+	return res
 }
 
 func newInformer(resClient dynamic.ResourceInterface, controllerNamespace string, applicationNamespaces []string, selector string) cache.SharedIndexInformer {
@@ -5,6 +5,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/argoproj/notifications-engine/pkg/api"
+	"github.com/argoproj/notifications-engine/pkg/services"
 	"github.com/sirupsen/logrus/hooks/test"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -192,3 +194,39 @@ func TestCheckAppNotInAdditionalNamespaces(t *testing.T) {
 	app.SetNamespace("namespace3")
 	assert.True(t, checkAppNotInAdditionalNamespaces(app, "", applicationNamespaces))
 }
+
+func TestIgnoreProjectDestinations(t *testing.T) {
+	slack := services.Destination{Service: "slack", Recipient: "my-channel"}
+	email := services.Destination{Service: "email", Recipient: "team@example.com"}
+	destinations := services.Destinations{
+		"on-sync-succeeded": {slack, email},
+		"on-sync-failed":    {slack},
+	}
+
+	assert.Equal(t, destinations, ignoreProjectDestinations(destinations, ""))
+	assert.Equal(t, destinations, ignoreProjectDestinations(destinations, "false"))
+	assert.Empty(t, ignoreProjectDestinations(destinations, "true"))
+	assert.Equal(t, services.Destinations{"on-sync-succeeded": {slack, email}}, ignoreProjectDestinations(destinations, "on-sync-failed"))
+	assert.Equal(t, services.Destinations{
+		"on-sync-succeeded": {email},
+		"on-sync-failed":    {slack},
+	}, ignoreProjectDestinations(destinations, "on-sync-succeeded.slack, on-deployed"))
+}
+
+func TestBatchDestinations(t *testing.T) {
+	c := &notificationController{
+		configMapInformer: newTestConfigMapInformer(t, map[string]string{"batching.slack": "window: 10m"}),
+		namespace:         "argocd",
+		configMapName:     "argocd-notifications-cm",
+	}
+	slack := services.Destination{Service: "slack", Recipient: "my-channel"}
+	email := services.Destination{Service: "email", Recipient: "team@example.com"}
+
+	assert.Equal(t, services.Destinations{
+		"on-sync-succeeded": {{Service: "slack", Recipient: "on-sync-succeeded|my-channel"}, email},
+		"on-sync-failed":    {{Service: "slack", Recipient: "on-sync-failed|my-channel"}},
+	}, c.batchDestinations(services.Destinations{
+		"on-sync-succeeded": {slack, email},
+		"on-sync-failed":    {slack},
+	}, api.Config{}))
+}
@@ -0,0 +1,166 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/argoproj/notifications-engine/pkg/controller"
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/argoproj/argo-cd/v3/util/notification/settings"
+)
+
+const (
+	// DeliveryStatusAnnotation holds the most recent notification delivery attempts of an application
+	DeliveryStatusAnnotation = "notifications.argoproj.io/delivery-status"
+	// maxDeliveryAttempts is the number of delivery attempts kept in the delivery status annotation
+	maxDeliveryAttempts = 10
+	// failedDeliveryRecordInterval is the minimum interval between two records of the same failure. Failed deliveries
+	// are retried on every update of the application, including the update recording the failure.
+	failedDeliveryRecordInterval = 5 * time.Minute
+
+	deliveryResultSucceeded = "succeeded"
+	deliveryResultFailed    = "failed"
+)
+
+// deliveryAttemptsCounter counts the delivery attempts by result. argocd_notifications_deliveries_total is already
+// registered by the notifications engine with trigger, service and succeeded labels.
+var deliveryAttemptsCounter = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "argocd_notifications_delivery_attempts_total",
+		Help: "Number of notification delivery attempts.",
+	},
+	[]string{"result"},
+)
+
+// RegisterDeliveryMetrics registers the metrics about notification delivery attempts
+func RegisterDeliveryMetrics(registerer prometheus.Registerer) {
+	registerer.MustRegister(deliveryAttemptsCounter)
+}
+
+// DeliveryAttempt is a single attempt to deliver a notification, as recorded in the delivery status annotation
+type DeliveryAttempt struct {
+	Time      metav1.Time `json:"time"`
+	Trigger   string      `json:"trigger,omitempty"`
+	Service   string      `json:"service,omitempty"`
+	Recipient string      `json:"recipient,omitempty"`
+	Result    string      `json:"result"`
+	Error     string      `json:"error,omitempty"`
+}
+
+// newDeliveryAttempts returns the delivery attempts of an event sequence. Notifications which had already been sent
+// are not attempted again and are skipped. Errors of the sequence carry no structured trigger or destination, their
+// message names them instead.
+func newDeliveryAttempts(eventSequence controller.NotificationEventSequence, now time.Time) []DeliveryAttempt {
+	var attempts []DeliveryAttempt
+	for _, delivery := range eventSequence.Delivered {
+		if delivery.AlreadyNotified {
+			continue
+		}
+		_, recipient := settings.ParseBatchedRecipient(delivery.Destination.Recipient)
+		attempts = append(attempts, DeliveryAttempt{
+			Time:      metav1.NewTime(now),
+			Trigger:   delivery.Trigger,
+			Service:   delivery.Destination.Service,
+			Recipient: recipient,
+			Result:    deliveryResultSucceeded,
+		})
+	}
+	for _, err := range eventSequence.Errors {
+		attempts = append(attempts, DeliveryAttempt{
+			Time:   metav1.NewTime(now),
+			Result: deliveryResultFailed,
+			Error:  err.Error(),
+		})
+	}
+	return attempts
+}
+
+// appendDeliveryAttempts appends the attempts to the delivery status found in the annotation, dropping the oldest
+// attempts so that at most maxDeliveryAttempts are kept. An invalid delivery status is replaced. Failures which were
+// already recorded less than failedDeliveryRecordInterval ago are not appended again, returns false if no attempt was
+// appended.
+func appendDeliveryAttempts(deliveryStatus string, attempts []DeliveryAttempt) ([]DeliveryAttempt, bool) {
+	var existing []DeliveryAttempt
+	if deliveryStatus != "" {
+		if err := json.Unmarshal([]byte(deliveryStatus), &existing); err != nil {
+			existing = nil
+		}
+	}
+	appended := false
+	for _, attempt := range attempts {
+		if attempt.Result == deliveryResultFailed && recentlyRecordedFailure(existing, attempt) {
+			continue
+		}
+		existing = append(existing, attempt)
+		appended = true
+	}
+	if len(existing) > maxDeliveryAttempts {
+		existing = existing[len(existing)-maxDeliveryAttempts:]
+	}
+	return existing, appended
+}
+
+// recentlyRecordedFailure returns whether the same failure as the attempt was recorded less than
+// failedDeliveryRecordInterval before it
+func recentlyRecordedFailure(recorded []DeliveryAttempt, attempt DeliveryAttempt) bool {
+	for _, r := range recorded {
+		if r.Result == deliveryResultFailed && r.Error == attempt.Error && attempt.Time.Sub(r.Time.Time) < failedDeliveryRecordInterval {
+			return true
+		}
+	}
+	return false
+}
+
+// recordDeliveries counts the delivery attempts of an event sequence and records them in the delivery status
+// annotation of the application. Repeated failures are rate limited since recording them updates the application,
+// which retries the failed deliveries.
+func (c *notificationController) recordDeliveries(eventSequence controller.NotificationEventSequence) {
+	if app, ok := eventSequence.Resource.(*unstructured.Unstructured); ok {
+		for _, delivery := range eventSequence.Delivered {
+			if !delivery.AlreadyNotified {
+				// destinations are throttled before the recipients of batched services are tagged with the trigger
+				dest := delivery.Destination
+				_, dest.Recipient = settings.ParseBatchedRecipient(dest.Recipient)
+				c.recordThrottledDeliveries(app, delivery.Trigger, dest)
+			}
+		}
+	}
+	attempts := newDeliveryAttempts(eventSequence, time.Now())
+	if len(attempts) == 0 || eventSequence.Resource == nil {
+		return
+	}
+	for _, attempt := range attempts {
+		deliveryAttemptsCounter.WithLabelValues(attempt.Result).Inc()
+	}
+
+	app := eventSequence.Resource
+	logEntry := log.WithField("app", fmt.Sprintf("%s/%s", app.GetNamespace(), app.GetName()))
+	recorded, appended := appendDeliveryAttempts(app.GetAnnotations()[DeliveryStatusAnnotation], attempts)
+	if !appended {
+		return
+	}
+	deliveryStatus, err := json.Marshal(recorded)
+	if err != nil {
+		logEntry.Warnf("Failed to marshal notification delivery status: %v", err)
+		return
+	}
+	patch, err := json.Marshal(map[string]any{
+		"metadata": map[string]any{
+			"annotations": map[string]string{DeliveryStatusAnnotation: string(deliveryStatus)},
+		},
+	})
+	if err != nil {
+		logEntry.Warnf("Failed to marshal notification delivery status patch: %v", err)
+		return
+	}
+	if _, err := c.appClient.Namespace(app.GetNamespace()).Patch(context.Background(), app.GetName(), types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+		logEntry.Warnf("Failed to record notification delivery status: %v", err)
+	}
+}
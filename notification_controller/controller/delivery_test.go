@@ -0,0 +1,129 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/argoproj/notifications-engine/pkg/controller"
+	"github.com/argoproj/notifications-engine/pkg/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic/fake"
+
+	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+)
+
+func TestNewDeliveryAttempts(t *testing.T) {
+	now := time.Now()
+	attempts := newDeliveryAttempts(controller.NotificationEventSequence{
+		Delivered: []controller.NotificationDelivery{
+			{Trigger: "on-sync-succeeded", Destination: services.Destination{Service: "slack", Recipient: "my-channel"}},
+			{Trigger: "on-deployed", Destination: services.Destination{Service: "slack", Recipient: "my-channel"}, AlreadyNotified: true},
+		},
+		Errors: []error{errors.New("failed to deliver notification on-sync-failed to {email user@example.com}: connection refused")},
+	}, now)
+
+	assert.Equal(t, []DeliveryAttempt{{
+		Time:      metav1.NewTime(now),
+		Trigger:   "on-sync-succeeded",
+		Service:   "slack",
+		Recipient: "my-channel",
+		Result:    deliveryResultSucceeded,
+	}, {
+		Time:   metav1.NewTime(now),
+		Result: deliveryResultFailed,
+		Error:  "failed to deliver notification on-sync-failed to {email user@example.com}: connection refused",
+	}}, attempts)
+
+	assert.Empty(t, newDeliveryAttempts(controller.NotificationEventSequence{}, now))
+}
+
+func TestAppendDeliveryAttempts(t *testing.T) {
+	t.Run("EmptyStatus", func(t *testing.T) {
+		attempts, appended := appendDeliveryAttempts("", []DeliveryAttempt{{Result: deliveryResultSucceeded}})
+		assert.True(t, appended)
+		assert.Len(t, attempts, 1)
+	})
+	t.Run("InvalidStatus", func(t *testing.T) {
+		attempts, appended := appendDeliveryAttempts("not-json", []DeliveryAttempt{{Result: deliveryResultFailed}})
+		assert.True(t, appended)
+		assert.Equal(t, []DeliveryAttempt{{Result: deliveryResultFailed}}, attempts)
+	})
+	t.Run("Bounded", func(t *testing.T) {
+		var existing []DeliveryAttempt
+		for i := 0; i < maxDeliveryAttempts; i++ {
+			existing = append(existing, DeliveryAttempt{Trigger: fmt.Sprintf("trigger-%d", i), Result: deliveryResultSucceeded})
+		}
+		status, err := json.Marshal(existing)
+		require.NoError(t, err)
+
+		attempts, appended := appendDeliveryAttempts(string(status), []DeliveryAttempt{{Trigger: "latest", Result: deliveryResultFailed}})
+		assert.True(t, appended)
+		require.Len(t, attempts, maxDeliveryAttempts)
+		assert.Equal(t, "trigger-1", attempts[0].Trigger)
+		assert.Equal(t, "latest", attempts[maxDeliveryAttempts-1].Trigger)
+	})
+	t.Run("RepeatedFailure", func(t *testing.T) {
+		now := time.Now()
+		failure := DeliveryAttempt{Time: metav1.NewTime(now), Result: deliveryResultFailed, Error: "connection refused"}
+		status, err := json.Marshal([]DeliveryAttempt{failure})
+		require.NoError(t, err)
+
+		retried := failure
+		retried.Time = metav1.NewTime(now.Add(time.Second))
+		attempts, appended := appendDeliveryAttempts(string(status), []DeliveryAttempt{retried})
+		assert.False(t, appended)
+		assert.Len(t, attempts, 1)
+
+		retried.Time = metav1.NewTime(now.Add(failedDeliveryRecordInterval))
+		attempts, appended = appendDeliveryAttempts(string(status), []DeliveryAttempt{retried})
+		assert.True(t, appended)
+		assert.Len(t, attempts, 2)
+
+		other := DeliveryAttempt{Time: metav1.NewTime(now.Add(time.Second)), Result: deliveryResultFailed, Error: "timeout"}
+		attempts, appended = appendDeliveryAttempts(string(status), []DeliveryAttempt{other})
+		assert.True(t, appended)
+		assert.Len(t, attempts, 2)
+	})
+}
+
+func TestRecordDeliveries(t *testing.T) {
+	scheme := runtime.NewScheme()
+	err := v1alpha1.SchemeBuilder.AddToScheme(scheme)
+	require.NoError(t, err)
+	app := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "argoproj.io/v1alpha1",
+		"kind":       "Application",
+		"metadata":   map[string]any{"name": "guestbook", "namespace": "argocd"},
+	}}
+	client := fake.NewSimpleDynamicClient(scheme, app)
+	c := &notificationController{
+		appClient:         client.Resource(applications),
+		configMapInformer: newTestConfigMapInformer(t, nil),
+		namespace:         "argocd",
+		configMapName:     "argocd-notifications-cm",
+		throttler:         newThrottler(),
+	}
+
+	c.recordDeliveries(controller.NotificationEventSequence{
+		Resource: app,
+		Delivered: []controller.NotificationDelivery{
+			{Trigger: "on-sync-succeeded", Destination: services.Destination{Service: "slack", Recipient: "my-channel"}},
+		},
+	})
+
+	updated, err := client.Resource(applications).Namespace("argocd").Get(t.Context(), "guestbook", metav1.GetOptions{})
+	require.NoError(t, err)
+	var attempts []DeliveryAttempt
+	require.NoError(t, json.Unmarshal([]byte(updated.GetAnnotations()[DeliveryStatusAnnotation]), &attempts))
+	require.Len(t, attempts, 1)
+	assert.Equal(t, "on-sync-succeeded", attempts[0].Trigger)
+	assert.Equal(t, "slack", attempts[0].Service)
+	assert.Equal(t, deliveryResultSucceeded, attempts[0].Result)
+}
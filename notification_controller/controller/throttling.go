@@ -0,0 +1,263 @@
+package controller
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/argoproj/notifications-engine/pkg/services"
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// throttlingKey is the key of the notifications ConfigMap which holds the throttling rules
+	throttlingKey = "throttling"
+
+	defaultThrottlingWindow = time.Hour
+	// throttlerPruneInterval is the interval at which the subscriptions without recent deliveries are forgotten
+	throttlerPruneInterval = 10 * time.Minute
+)
+
+// ThrottlingRule limits the notifications sent for a subscription of an application, i.e. for a trigger and a
+// destination, within a window
+type ThrottlingRule struct {
+	// Triggers the rule applies to, all triggers if empty
+	Triggers []string `json:"triggers,omitempty"`
+	// Services the rule applies to, all services if empty
+	Services []string `json:"services,omitempty"`
+	// MaxNotifications is the maximum number of notifications sent within the window, unlimited if zero
+	MaxNotifications int `json:"maxNotifications,omitempty"`
+	// Window is the duration over which notifications are counted and deduplicated, defaults to one hour
+	Window string `json:"window,omitempty"`
+	// DedupKey is a template rendered using the application. Notifications with the same key as a notification sent
+	// within the window are suppressed.
+	DedupKey string `json:"dedupKey,omitempty"`
+
+	window   time.Duration
+	dedupKey *template.Template
+}
+
+func (r *ThrottlingRule) matches(trigger string, service string) bool {
+	return (len(r.Triggers) == 0 || slices.Contains(r.Triggers, trigger)) && (len(r.Services) == 0 || slices.Contains(r.Services, service))
+}
+
+// renderDedupKey returns the dedup key of the application, or an empty key if the rule has no dedup key
+func (r *ThrottlingRule) renderDedupKey(app *unstructured.Unstructured) (string, error) {
+	if r.dedupKey == nil {
+		return "", nil
+	}
+	var out bytes.Buffer
+	if err := r.dedupKey.Execute(&out, map[string]any{"app": app.Object}); err != nil {
+		return "", fmt.Errorf("failed to render dedup key: %w", err)
+	}
+	return strings.TrimSpace(out.String()), nil
+}
+
+// parseThrottlingRules returns the throttling rules configured in the notifications ConfigMap
+func parseThrottlingRules(cm *corev1.ConfigMap) ([]ThrottlingRule, error) {
+	data, ok := cm.Data[throttlingKey]
+	if !ok || data == "" {
+		return nil, nil
+	}
+	var rules []ThrottlingRule
+	if err := yaml.Unmarshal([]byte(data), &rules); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal throttling rules: %w", err)
+	}
+	for i := range rules {
+		rule := &rules[i]
+		rule.window = defaultThrottlingWindow
+		if rule.Window != "" {
+			window, err := time.ParseDuration(rule.Window)
+			if err != nil || window <= 0 {
+				return nil, fmt.Errorf("invalid window '%s' of throttling rule %d", rule.Window, i)
+			}
+			rule.window = window
+		}
+		if rule.DedupKey != "" {
+			dedupKey, err := template.New("dedupKey").Option("missingkey=zero").Parse(rule.DedupKey)
+			if err != nil {
+				return nil, fmt.Errorf("invalid dedup key of throttling rule %d: %w", i, err)
+			}
+			rule.dedupKey = dedupKey
+		}
+	}
+	return rules, nil
+}
+
+// matchThrottlingRule returns the first rule matching the trigger and service, or nil if there is none
+func matchThrottlingRule(rules []ThrottlingRule, trigger string, service string) *ThrottlingRule {
+	for i := range rules {
+		if rules[i].matches(trigger, service) {
+			return &rules[i]
+		}
+	}
+	return nil
+}
+
+type throttledDelivery struct {
+	time     time.Time
+	dedupKey string
+	// expiresAt is the end of the window of the rule the delivery was recorded with
+	expiresAt time.Time
+}
+
+// throttler remembers the notifications sent for every subscription of the applications. The history is kept in
+// memory and starts empty whenever the controller restarts.
+type throttler struct {
+	lock       sync.Mutex
+	deliveries map[string][]throttledDelivery
+	lastPrune  time.Time
+	now        func() time.Time
+
+	// the rules parsed from the throttling rules found in the ConfigMap
+	rulesLock   sync.Mutex
+	rulesParsed bool
+	rulesData   string
+	rules       []ThrottlingRule
+	rulesErr    error
+}
+
+func newThrottler() *throttler {
+	return &throttler{deliveries: map[string][]throttledDelivery{}, now: time.Now}
+}
+
+// getRules returns the parsed throttling rules of the ConfigMap, which are only parsed again once they change
+func (t *throttler) getRules(cm *corev1.ConfigMap) ([]ThrottlingRule, error) {
+	t.rulesLock.Lock()
+	defer t.rulesLock.Unlock()
+	data := cm.Data[throttlingKey]
+	if !t.rulesParsed || data != t.rulesData {
+		t.rules, t.rulesErr = parseThrottlingRules(cm)
+		t.rulesData = data
+		t.rulesParsed = true
+	}
+	return t.rules, t.rulesErr
+}
+
+// prune forgets the subscriptions whose deliveries are all out of the window of their rule, so that deleted
+// applications and subscriptions don't stay in memory. Must be called with the lock held.
+func (t *throttler) prune() {
+	now := t.now()
+	if now.Sub(t.lastPrune) < throttlerPruneInterval {
+		return
+	}
+	t.lastPrune = now
+	for key, deliveries := range t.deliveries {
+		if !slices.ContainsFunc(deliveries, func(d throttledDelivery) bool {
+			return d.expiresAt.After(now)
+		}) {
+			delete(t.deliveries, key)
+		}
+	}
+}
+
+func subscriptionKey(app *unstructured.Unstructured, trigger string, dest services.Destination) string {
+	return strings.Join([]string{app.GetNamespace(), app.GetName(), trigger, dest.Service, dest.Recipient}, "/")
+}
+
+// recentDeliveries returns the deliveries of a subscription within the window, dropping the older ones. Must be
+// called with the lock held.
+func (t *throttler) recentDeliveries(key string, window time.Duration) []throttledDelivery {
+	cutoff := t.now().Add(-window)
+	deliveries := slices.DeleteFunc(t.deliveries[key], func(d throttledDelivery) bool {
+		return d.time.Before(cutoff)
+	})
+	if len(deliveries) == 0 {
+		delete(t.deliveries, key)
+	} else {
+		t.deliveries[key] = deliveries
+	}
+	return deliveries
+}
+
+// allow returns whether a notification with the given dedup key may be sent for a subscription
+func (t *throttler) allow(rule *ThrottlingRule, key string, dedupKey string) bool {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	deliveries := t.recentDeliveries(key, rule.window)
+	if rule.MaxNotifications > 0 && len(deliveries) >= rule.MaxNotifications {
+		return false
+	}
+	if dedupKey != "" && slices.ContainsFunc(deliveries, func(d throttledDelivery) bool {
+		return d.dedupKey == dedupKey
+	}) {
+		return false
+	}
+	return true
+}
+
+// record remembers a notification sent for a subscription
+func (t *throttler) record(rule *ThrottlingRule, key string, dedupKey string) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.prune()
+	now := t.now()
+	t.deliveries[key] = append(t.recentDeliveries(key, rule.window), throttledDelivery{time: now, dedupKey: dedupKey, expiresAt: now.Add(rule.window)})
+}
+
+// getThrottlingRules returns the throttling rules of the notifications ConfigMap of the controller namespace
+func (c *notificationController) getThrottlingRules() ([]ThrottlingRule, error) {
+	obj, exists, err := c.configMapInformer.GetIndexer().GetByKey(c.namespace + "/" + c.configMapName)
+	if err != nil || !exists {
+		return nil, err
+	}
+	cm, ok := obj.(*corev1.ConfigMap)
+	if !ok {
+		return nil, nil
+	}
+	return c.throttler.getRules(cm)
+}
+
+// throttleDestinations removes the destinations whose subscription exceeds its throttling rule
+func (c *notificationController) throttleDestinations(app *unstructured.Unstructured, destinations services.Destinations) services.Destinations {
+	logEntry := log.WithField("app", fmt.Sprintf("%s/%s", app.GetNamespace(), app.GetName()))
+	rules, err := c.getThrottlingRules()
+	if err != nil {
+		logEntry.Warnf("Failed to get notification throttling rules: %v", err)
+		return destinations
+	}
+	if len(rules) == 0 {
+		return destinations
+	}
+	res := services.Destinations{}
+	for trigger, dests := range destinations {
+		for _, dest := range dests {
+			rule := matchThrottlingRule(rules, trigger, dest.Service)
+			if rule == nil {
+				res[trigger] = append(res[trigger], dest)
+				continue
+			}
+			dedupKey, err := rule.renderDedupKey(app)
+			if err != nil {
+				logEntry.Warnf("Failed to throttle notifications of trigger %s: %v", trigger, err)
+			}
+			if !c.throttler.allow(rule, subscriptionKey(app, trigger, dest), dedupKey) {
+				logEntry.Infof("Notification of trigger %s to %s is throttled", trigger, dest)
+				continue
+			}
+			res[trigger] = append(res[trigger], dest)
+		}
+	}
+	return res
+}
+
+// recordThrottledDeliveries remembers the notifications sent for the subscriptions which have a throttling rule
+func (c *notificationController) recordThrottledDeliveries(app *unstructured.Unstructured, trigger string, dest services.Destination) {
+	rules, err := c.getThrottlingRules()
+	if err != nil {
+		return
+	}
+	rule := matchThrottlingRule(rules, trigger, dest.Service)
+	if rule == nil {
+		return
+	}
+	dedupKey, _ := rule.renderDedupKey(app)
+	c.throttler.record(rule, subscriptionKey(app, trigger, dest), dedupKey)
+}
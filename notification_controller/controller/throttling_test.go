@@ -0,0 +1,159 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/argoproj/notifications-engine/pkg/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/tools/cache"
+)
+
+func newTestConfigMapInformer(t *testing.T, data map[string]string) cache.SharedIndexInformer {
+	t.Helper()
+	informer := cache.NewSharedIndexInformer(&cache.ListWatch{}, &corev1.ConfigMap{}, 0, cache.Indexers{})
+	require.NoError(t, informer.GetIndexer().Add(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "argocd-notifications-cm", Namespace: "argocd"},
+		Data:       data,
+	}))
+	return informer
+}
+
+func newTestThrottlingApp(healthMessage string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "argoproj.io/v1alpha1",
+		"kind":       "Application",
+		"metadata":   map[string]any{"name": "guestbook", "namespace": "argocd"},
+		"status":     map[string]any{"health": map[string]any{"status": "Degraded", "message": healthMessage}},
+	}}
+}
+
+func TestParseThrottlingRules(t *testing.T) {
+	t.Run("NoRules", func(t *testing.T) {
+		rules, err := parseThrottlingRules(&corev1.ConfigMap{})
+		require.NoError(t, err)
+		assert.Empty(t, rules)
+	})
+	t.Run("DefaultWindow", func(t *testing.T) {
+		rules, err := parseThrottlingRules(&corev1.ConfigMap{Data: map[string]string{throttlingKey: `
+- triggers: [on-health-degraded]
+  maxNotifications: 3
+- services: [pagerduty]
+  window: 30m
+  dedupKey: "{{.app.status.health.message}}"
+`}})
+		require.NoError(t, err)
+		require.Len(t, rules, 2)
+		assert.Equal(t, time.Hour, rules[0].window)
+		assert.Equal(t, 30*time.Minute, rules[1].window)
+		assert.NotNil(t, rules[1].dedupKey)
+	})
+	t.Run("InvalidWindow", func(t *testing.T) {
+		_, err := parseThrottlingRules(&corev1.ConfigMap{Data: map[string]string{throttlingKey: `[{window: soon}]`}})
+		require.ErrorContains(t, err, "invalid window")
+	})
+	t.Run("InvalidDedupKey", func(t *testing.T) {
+		_, err := parseThrottlingRules(&corev1.ConfigMap{Data: map[string]string{throttlingKey: `[{dedupKey: "{{.app"}]`}})
+		require.ErrorContains(t, err, "invalid dedup key")
+	})
+}
+
+func TestMatchThrottlingRule(t *testing.T) {
+	rules := []ThrottlingRule{
+		{Triggers: []string{"on-health-degraded"}, Services: []string{"pagerduty"}, MaxNotifications: 1},
+		{Services: []string{"slack"}, MaxNotifications: 2},
+	}
+	assert.Equal(t, 1, matchThrottlingRule(rules, "on-health-degraded", "pagerduty").MaxNotifications)
+	assert.Equal(t, 2, matchThrottlingRule(rules, "on-health-degraded", "slack").MaxNotifications)
+	assert.Nil(t, matchThrottlingRule(rules, "on-sync-failed", "pagerduty"))
+}
+
+func TestThrottler(t *testing.T) {
+	now := time.Now()
+	th := newThrottler()
+	th.now = func() time.Time { return now }
+
+	t.Run("MaxNotifications", func(t *testing.T) {
+		rule := &ThrottlingRule{MaxNotifications: 2, window: time.Hour}
+		assert.True(t, th.allow(rule, "max", ""))
+		th.record(rule, "max", "")
+		th.record(rule, "max", "")
+		assert.False(t, th.allow(rule, "max", ""))
+		assert.True(t, th.allow(rule, "other", ""))
+
+		now = now.Add(time.Hour + time.Second)
+		assert.True(t, th.allow(rule, "max", ""))
+	})
+	t.Run("DedupKey", func(t *testing.T) {
+		rule := &ThrottlingRule{window: time.Hour}
+		th.record(rule, "dedup", "readiness probe failed")
+		assert.False(t, th.allow(rule, "dedup", "readiness probe failed"))
+		assert.True(t, th.allow(rule, "dedup", "image pull failed"))
+
+		now = now.Add(time.Hour + time.Second)
+		assert.True(t, th.allow(rule, "dedup", "readiness probe failed"))
+	})
+	t.Run("Prune", func(t *testing.T) {
+		rule := &ThrottlingRule{window: time.Minute}
+		th.record(rule, "stale", "")
+		now = now.Add(throttlerPruneInterval)
+		th.record(rule, "recent", "")
+
+		assert.NotContains(t, th.deliveries, "stale")
+		assert.Contains(t, th.deliveries, "recent")
+	})
+}
+
+func TestThrottlerGetRules(t *testing.T) {
+	th := newThrottler()
+	cm := &corev1.ConfigMap{Data: map[string]string{throttlingKey: "- maxNotifications: 1"}}
+	rules, err := th.getRules(cm)
+	require.NoError(t, err)
+	require.Len(t, rules, 1)
+
+	// the parsed rules are reused while the rules don't change
+	cached, err := th.getRules(cm.DeepCopy())
+	require.NoError(t, err)
+	assert.Same(t, &rules[0], &cached[0])
+
+	cm.Data[throttlingKey] = "- maxNotifications: 2\n- maxNotifications: 3"
+	rules, err = th.getRules(cm)
+	require.NoError(t, err)
+	assert.Len(t, rules, 2)
+
+	cm.Data[throttlingKey] = "- window: soon"
+	_, err = th.getRules(cm)
+	assert.Error(t, err)
+}
+
+func TestThrottleDestinations(t *testing.T) {
+	c := &notificationController{
+		configMapInformer: newTestConfigMapInformer(t, map[string]string{throttlingKey: `
+- triggers: [on-health-degraded]
+  services: [pagerduty]
+  maxNotifications: 5
+  dedupKey: "{{.app.status.health.message}}"
+`}),
+		namespace:     "argocd",
+		configMapName: "argocd-notifications-cm",
+		throttler:     newThrottler(),
+	}
+	pagerduty := services.Destination{Service: "pagerduty", Recipient: "on-call"}
+	slack := services.Destination{Service: "slack", Recipient: "alerts"}
+	destinations := func() services.Destinations {
+		return services.Destinations{"on-health-degraded": {pagerduty, slack}}
+	}
+
+	app := newTestThrottlingApp("readiness probe failed")
+	assert.Equal(t, destinations(), c.throttleDestinations(app, destinations()))
+	c.recordThrottledDeliveries(app, "on-health-degraded", pagerduty)
+	c.recordThrottledDeliveries(app, "on-health-degraded", slack)
+
+	// the same underlying issue is not sent to pagerduty again, other services are not throttled
+	assert.Equal(t, services.Destinations{"on-health-degraded": {slack}}, c.throttleDestinations(app, destinations()))
+	assert.Equal(t, destinations(), c.throttleDestinations(newTestThrottlingApp("image pull failed"), destinations()))
+}
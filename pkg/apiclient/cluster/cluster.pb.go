@@ -92,12 +92,14 @@ func (m *ClusterID) GetValue() string {
 
 // ClusterQuery is a query for cluster resources
 type ClusterQuery struct {
-	Server               string     `protobuf:"bytes,1,opt,name=server,proto3" json:"server,omitempty"`
-	Name                 string     `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Id                   *ClusterID `protobuf:"bytes,3,opt,name=id,proto3" json:"id,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
-	XXX_unrecognized     []byte     `json:"-"`
-	XXX_sizecache        int32      `json:"-"`
+	Server string     `protobuf:"bytes,1,opt,name=server,proto3" json:"server,omitempty"`
+	Name   string     `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Id     *ClusterID `protobuf:"bytes,3,opt,name=id,proto3" json:"id,omitempty"`
+	// the name prefix to restrict returned list clusters
+	NamePrefix           string   `protobuf:"bytes,4,opt,name=namePrefix,proto3" json:"namePrefix,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *ClusterQuery) Reset()         { *m = ClusterQuery{} }
@@ -154,6 +156,13 @@ func (m *ClusterQuery) GetId() *ClusterID {
 	return nil
 }
 
+func (m *ClusterQuery) GetNamePrefix() string {
+	if m != nil {
+		return m.NamePrefix
+	}
+	return ""
+}
+
 type ClusterResponse struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -322,45 +331,46 @@ func init() {
 func init() { proto.RegisterFile("server/cluster/cluster.proto", fileDescriptor_a6b5ba0b5aa57b32) }
 
 var fileDescriptor_a6b5ba0b5aa57b32 = []byte{
-	// 596 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x95, 0xcf, 0x6f, 0xd3, 0x30,
-	0x14, 0xc7, 0xe5, 0x6e, 0x74, 0xcc, 0x03, 0x06, 0xd6, 0x40, 0x51, 0xf7, 0x43, 0x25, 0x20, 0x28,
-	0x68, 0xb5, 0xd5, 0x76, 0x5c, 0xb8, 0xb1, 0x0e, 0x50, 0xa5, 0x5d, 0x08, 0xe2, 0xc2, 0x61, 0x93,
-	0x97, 0x3c, 0xa5, 0x66, 0x59, 0x62, 0x62, 0x27, 0xd2, 0x84, 0xb8, 0xec, 0xc4, 0x0d, 0x21, 0xae,
-	0x5c, 0xf9, 0x43, 0xb8, 0x21, 0x71, 0x41, 0xe2, 0x1f, 0x40, 0x15, 0x7f, 0x08, 0x8a, 0x93, 0xb4,
-	0xb4, 0xd3, 0xaa, 0x21, 0x15, 0x4e, 0xf5, 0x7b, 0xea, 0xf3, 0xf7, 0xf3, 0xbe, 0x7e, 0x8e, 0xf1,
-	0x9a, 0x82, 0x38, 0x85, 0x98, 0xb9, 0x41, 0xa2, 0xf4, 0xe8, 0x97, 0xca, 0x38, 0xd2, 0x11, 0x59,
-	0x28, 0xc2, 0xda, 0x9a, 0x1f, 0x45, 0x7e, 0x00, 0x8c, 0x4b, 0xc1, 0x78, 0x18, 0x46, 0x9a, 0x6b,
-	0x11, 0x85, 0x2a, 0xff, 0x5b, 0x6d, 0xd7, 0x17, 0xba, 0x9f, 0x1c, 0x50, 0x37, 0x3a, 0x62, 0x3c,
-	0xf6, 0x23, 0x19, 0x47, 0xaf, 0xcc, 0xa2, 0xe9, 0x7a, 0x2c, 0xed, 0x30, 0x79, 0xe8, 0x67, 0x95,
-	0x8a, 0x71, 0x29, 0x03, 0xe1, 0x9a, 0x5a, 0x96, 0xb6, 0x78, 0x20, 0xfb, 0xbc, 0xc5, 0x7c, 0x08,
-	0x21, 0xe6, 0x1a, 0xbc, 0x7c, 0x37, 0xfb, 0x01, 0x5e, 0xec, 0xe6, 0xb2, 0xbd, 0x1d, 0x42, 0xf0,
-	0xbc, 0x3e, 0x96, 0x60, 0xa1, 0x3a, 0x6a, 0x2c, 0x3a, 0x66, 0x4d, 0x56, 0xf0, 0x85, 0x94, 0x07,
-	0x09, 0x58, 0x15, 0x93, 0xcc, 0x03, 0x7b, 0x0f, 0x5f, 0x2a, 0xca, 0x9e, 0x25, 0x10, 0x1f, 0x93,
-	0x1b, 0xb8, 0x9a, 0xf7, 0x56, 0xd4, 0x16, 0x51, 0xb6, 0x63, 0xc8, 0x8f, 0xca, 0x62, 0xb3, 0x26,
-	0x36, 0xae, 0x08, 0xcf, 0x9a, 0xab, 0xa3, 0xc6, 0x52, 0x9b, 0xd0, 0xd2, 0x83, 0x21, 0x85, 0x53,
-	0x11, 0x9e, 0x7d, 0x0d, 0x2f, 0x17, 0x09, 0x07, 0x94, 0x8c, 0x42, 0x05, 0xf6, 0x7b, 0x84, 0x57,
-	0x8a, 0x5c, 0x37, 0x06, 0xae, 0xc1, 0x81, 0xd7, 0x09, 0x28, 0x4d, 0xf6, 0x71, 0xe9, 0x9c, 0x11,
-	0x5f, 0x6a, 0x3f, 0xa6, 0x23, 0x8b, 0x68, 0x69, 0x91, 0x59, 0xec, 0xbb, 0x1e, 0x4d, 0x3b, 0x54,
-	0x1e, 0xfa, 0x34, 0xb3, 0x88, 0xfe, 0x61, 0x11, 0x2d, 0x2d, 0x2a, 0x49, 0x9c, 0x72, 0xd7, 0xac,
-	0xb9, 0x44, 0x2a, 0x88, 0xb5, 0x69, 0xe3, 0xa2, 0x53, 0x44, 0xf6, 0x97, 0x11, 0xd1, 0x0b, 0xe9,
-	0xfd, 0x4f, 0xa2, 0xdb, 0xf8, 0x72, 0x62, 0x14, 0xbd, 0x27, 0x02, 0x02, 0x4f, 0x59, 0x95, 0xfa,
-	0x5c, 0x63, 0xd1, 0x19, 0x4f, 0x9e, 0xc7, 0xe8, 0xf6, 0xb7, 0x05, 0x7c, 0xa5, 0xc8, 0x3c, 0x87,
-	0x38, 0x15, 0x2e, 0x90, 0x13, 0x84, 0xe7, 0x77, 0x85, 0xd2, 0xe4, 0xfa, 0x64, 0x8d, 0x39, 0xeb,
-	0x5a, 0x6f, 0x26, 0xcd, 0x64, 0x0a, 0xb6, 0x75, 0xf2, 0xe3, 0xd7, 0xc7, 0x0a, 0x21, 0x57, 0xcd,
-	0xac, 0xa7, 0xad, 0xf2, 0x46, 0x28, 0xf2, 0x01, 0xe1, 0x6a, 0x7e, 0xcc, 0x64, 0x7d, 0x12, 0x63,
-	0xec, 0xf8, 0x6b, 0xb3, 0xf1, 0xd6, 0xbe, 0x69, 0x50, 0x56, 0xed, 0x53, 0x28, 0x0f, 0x87, 0xae,
-	0xbf, 0x43, 0x78, 0xee, 0x29, 0x9c, 0xe9, 0xcb, 0x8c, 0x40, 0x6e, 0x19, 0x90, 0x75, 0xb2, 0x3a,
-	0x09, 0xc2, 0xde, 0x08, 0x8f, 0x9a, 0xeb, 0xf7, 0x96, 0x7c, 0x42, 0xb8, 0x9a, 0xcf, 0xdc, 0x69,
-	0x7b, 0xc6, 0x66, 0x71, 0x56, 0x54, 0x9b, 0x86, 0xea, 0x4e, 0x6d, 0x1a, 0xd5, 0xc8, 0xa9, 0x3d,
-	0x5c, 0xdd, 0x81, 0x00, 0x34, 0x9c, 0xe5, 0x95, 0x35, 0x99, 0x1e, 0x5e, 0xf3, 0xa2, 0xfd, 0xfb,
-	0x53, 0xdb, 0x0f, 0x31, 0x76, 0xb2, 0xcf, 0x22, 0x3c, 0x4a, 0x74, 0xff, 0xef, 0x35, 0x98, 0xd1,
-	0xb8, 0x67, 0xdf, 0x9d, 0xa2, 0xc1, 0x62, 0x23, 0xd0, 0xe4, 0x99, 0xc2, 0x67, 0x84, 0x97, 0x7b,
-	0x61, 0xca, 0x03, 0x91, 0x59, 0xdb, 0xe5, 0x6e, 0x1f, 0xfe, 0xf1, 0x14, 0x6c, 0x19, 0x44, 0x6a,
-	0x6f, 0x4e, 0x43, 0x14, 0x43, 0xa4, 0xa6, 0x9b, 0x31, 0x6d, 0x6f, 0x7f, 0x1d, 0x6c, 0xa0, 0xef,
-	0x83, 0x0d, 0xf4, 0x73, 0xb0, 0x81, 0x5e, 0x6e, 0x9d, 0xef, 0xa5, 0x70, 0x03, 0x01, 0xa1, 0x2e,
-	0x05, 0x0e, 0xaa, 0xe6, 0x61, 0xe8, 0xfc, 0x0e, 0x00, 0x00, 0xff, 0xff, 0xf8, 0x3f, 0x7b, 0x15,
-	0xad, 0x06, 0x00, 0x00,
+	// 609 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x95, 0x41, 0x6f, 0xd3, 0x30,
+	0x18, 0x86, 0xe5, 0x6e, 0x74, 0xcc, 0x03, 0x06, 0xd6, 0x40, 0x51, 0xb7, 0x55, 0x23, 0x20, 0x18,
+	0x68, 0xb3, 0xd5, 0x76, 0x5c, 0xb8, 0xb1, 0x0e, 0x50, 0xa5, 0x1d, 0x20, 0x88, 0x0b, 0x07, 0x26,
+	0x2f, 0xf9, 0x48, 0xcd, 0xb2, 0xc4, 0x38, 0x4e, 0xc4, 0x84, 0xe0, 0xb0, 0x13, 0x37, 0x84, 0xb8,
+	0x72, 0xe5, 0x87, 0x70, 0x43, 0xe2, 0x82, 0xc4, 0x1f, 0x40, 0x15, 0x3f, 0x04, 0xc5, 0x49, 0xda,
+	0xb5, 0xd3, 0xaa, 0x21, 0x15, 0x4e, 0xb5, 0x3f, 0xe5, 0xf5, 0xfb, 0x7c, 0xaf, 0xed, 0x1a, 0x2f,
+	0xc5, 0xa0, 0x52, 0x50, 0xcc, 0x0d, 0x92, 0x58, 0x0f, 0x7e, 0xa9, 0x54, 0x91, 0x8e, 0xc8, 0x4c,
+	0x31, 0xad, 0x2d, 0xf9, 0x51, 0xe4, 0x07, 0xc0, 0xb8, 0x14, 0x8c, 0x87, 0x61, 0xa4, 0xb9, 0x16,
+	0x51, 0x18, 0xe7, 0x9f, 0xd5, 0xb6, 0x7d, 0xa1, 0xbb, 0xc9, 0x2e, 0x75, 0xa3, 0x7d, 0xc6, 0x95,
+	0x1f, 0x49, 0x15, 0xbd, 0x34, 0x83, 0x75, 0xd7, 0x63, 0x69, 0x8b, 0xc9, 0x3d, 0x3f, 0x53, 0xc6,
+	0x8c, 0x4b, 0x19, 0x08, 0xd7, 0x68, 0x59, 0xda, 0xe0, 0x81, 0xec, 0xf2, 0x06, 0xf3, 0x21, 0x04,
+	0xc5, 0x35, 0x78, 0xf9, 0x6a, 0xf6, 0x1d, 0x3c, 0xdb, 0xce, 0x6d, 0x3b, 0x5b, 0x84, 0xe0, 0x69,
+	0x7d, 0x20, 0xc1, 0x42, 0x2b, 0x68, 0x75, 0xd6, 0x31, 0x63, 0xb2, 0x80, 0xcf, 0xa4, 0x3c, 0x48,
+	0xc0, 0xaa, 0x98, 0x62, 0x3e, 0xb1, 0xdf, 0xe1, 0x73, 0x85, 0xec, 0x71, 0x02, 0xea, 0x80, 0x5c,
+	0xc1, 0xd5, 0xbc, 0xb7, 0x42, 0x5b, 0xcc, 0xb2, 0x15, 0x43, 0xbe, 0x5f, 0x8a, 0xcd, 0x98, 0xd8,
+	0xb8, 0x22, 0x3c, 0x6b, 0x6a, 0x05, 0xad, 0xce, 0x35, 0x09, 0x2d, 0x33, 0xe8, 0x53, 0x38, 0x15,
+	0xe1, 0x91, 0x3a, 0xc6, 0xd9, 0xb7, 0x8f, 0x14, 0xbc, 0x10, 0xaf, 0xad, 0x69, 0xa3, 0x3e, 0x52,
+	0xb1, 0x2f, 0xe1, 0xf9, 0x42, 0xe0, 0x40, 0x2c, 0xa3, 0x30, 0x06, 0xfb, 0x03, 0xc2, 0x0b, 0x45,
+	0xad, 0xad, 0x80, 0x6b, 0x70, 0xe0, 0x55, 0x02, 0xb1, 0x26, 0x3b, 0xb8, 0x4c, 0xd6, 0xc0, 0xcd,
+	0x35, 0xef, 0xd3, 0x41, 0x84, 0xb4, 0x8c, 0xd0, 0x0c, 0x76, 0x5c, 0x8f, 0xa6, 0x2d, 0x2a, 0xf7,
+	0x7c, 0x9a, 0x45, 0x48, 0x8f, 0x44, 0x48, 0xcb, 0x08, 0x4b, 0x52, 0xa7, 0x5c, 0x35, 0x6b, 0x3e,
+	0x91, 0x31, 0x28, 0x6d, 0xda, 0x3c, 0xeb, 0x14, 0x33, 0xfb, 0xeb, 0x80, 0xe8, 0xa9, 0xf4, 0xfe,
+	0x27, 0xd1, 0x75, 0x7c, 0x3e, 0x31, 0x8e, 0xde, 0x03, 0x01, 0x81, 0x17, 0x5b, 0x95, 0x95, 0xa9,
+	0xd5, 0x59, 0x67, 0xb8, 0x78, 0x9a, 0x8d, 0x68, 0x7e, 0x9f, 0xc1, 0x17, 0x8a, 0xca, 0x13, 0x50,
+	0xa9, 0x70, 0x81, 0x1c, 0x22, 0x3c, 0xbd, 0x2d, 0x62, 0x4d, 0x2e, 0x8f, 0x6a, 0xcc, 0x59, 0xa8,
+	0x75, 0x26, 0xd2, 0x4c, 0xe6, 0x60, 0x5b, 0x87, 0x3f, 0x7f, 0x7f, 0xaa, 0x10, 0x72, 0xd1, 0xdc,
+	0x85, 0xb4, 0x51, 0xde, 0x98, 0x98, 0x7c, 0x44, 0xb8, 0x9a, 0x6f, 0x33, 0x59, 0x1e, 0xc5, 0x18,
+	0xda, 0xfe, 0xda, 0x64, 0xb2, 0xb5, 0xaf, 0x1a, 0x94, 0x45, 0xfb, 0x18, 0xca, 0xdd, 0x7e, 0xea,
+	0xef, 0x11, 0x9e, 0x7a, 0x08, 0x27, 0xe6, 0x32, 0x21, 0x90, 0x6b, 0x06, 0x64, 0x99, 0x2c, 0x8e,
+	0x82, 0xb0, 0x37, 0xc2, 0xa3, 0xe6, 0x7a, 0xbe, 0x25, 0x9f, 0x11, 0xae, 0xe6, 0x67, 0xee, 0x78,
+	0x3c, 0x43, 0x67, 0x71, 0x52, 0x54, 0x6b, 0x86, 0xea, 0x46, 0x6d, 0x1c, 0xd5, 0x20, 0xa9, 0xe7,
+	0xb8, 0xba, 0x05, 0x01, 0x68, 0x38, 0x29, 0x2b, 0x6b, 0xb4, 0xdc, 0xbf, 0xe6, 0x45, 0xfb, 0xb7,
+	0xc7, 0xb6, 0x1f, 0x62, 0xec, 0x64, 0x7f, 0x9b, 0x70, 0x2f, 0xd1, 0xdd, 0xbf, 0xf7, 0x60, 0xc6,
+	0xe3, 0x96, 0x7d, 0x73, 0x8c, 0x07, 0x53, 0xc6, 0x60, 0x9d, 0x67, 0x0e, 0x5f, 0x10, 0x9e, 0xef,
+	0x84, 0x29, 0x0f, 0x44, 0x16, 0x6d, 0x9b, 0xbb, 0x5d, 0xf8, 0xc7, 0xa7, 0x60, 0xc3, 0x20, 0x52,
+	0x7b, 0x6d, 0x1c, 0xa2, 0xe8, 0x23, 0xad, 0xbb, 0x19, 0xd3, 0xe6, 0xe6, 0xb7, 0x5e, 0x1d, 0xfd,
+	0xe8, 0xd5, 0xd1, 0xaf, 0x5e, 0x1d, 0x3d, 0xdb, 0x38, 0xdd, 0x4b, 0xe2, 0x06, 0x02, 0x42, 0x5d,
+	0x1a, 0xec, 0x56, 0xcd, 0xc3, 0xd1, 0xfa, 0x33, 0x00, 0x7e, 0x3e, 0xc7, 0x4e, 0xcd, 0x06, 0x00,
+	0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -738,6 +748,13 @@ func (m *ClusterQuery) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if len(m.NamePrefix) > 0 {
+		i -= len(m.NamePrefix)
+		copy(dAtA[i:], m.NamePrefix)
+		i = encodeVarintCluster(dAtA, i, uint64(len(m.NamePrefix)))
+		i--
+		dAtA[i] = 0x22
+	}
 	if m.Id != nil {
 		{
 			size, err := m.Id.MarshalToSizedBuffer(dAtA[:i])
@@ -952,6 +969,10 @@ func (m *ClusterQuery) Size() (n int) {
 		l = m.Id.Size()
 		n += 1 + l + sovCluster(uint64(l))
 	}
+	l = len(m.NamePrefix)
+	if l > 0 {
+		n += 1 + l + sovCluster(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -1265,6 +1286,38 @@ func (m *ClusterQuery) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NamePrefix", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCluster
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCluster
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCluster
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NamePrefix = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipCluster(dAtA[iNdEx:])
@@ -293,7 +293,9 @@ func (m *ProjectTokenResponse) GetToken() string {
 
 // ProjectQuery is a query for Project resources
 type ProjectQuery struct {
-	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// the name prefix to restrict returned list projects
+	NamePrefix           string   `protobuf:"bytes,2,opt,name=namePrefix,proto3" json:"namePrefix,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -339,6 +341,13 @@ func (m *ProjectQuery) GetName() string {
 	return ""
 }
 
+func (m *ProjectQuery) GetNamePrefix() string {
+	if m != nil {
+		return m.NamePrefix
+	}
+	return ""
+}
+
 type ProjectUpdateRequest struct {
 	Project              *v1alpha1.AppProject `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
@@ -702,70 +711,71 @@ func init() {
 func init() { proto.RegisterFile("server/project/project.proto", fileDescriptor_5f0a51496972c9e2) }
 
 var fileDescriptor_5f0a51496972c9e2 = []byte{
-	// 1007 bytes of a gzipped FileDescriptorProto
+	// 1018 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x57, 0xcf, 0x6f, 0xe3, 0x44,
-	0x14, 0x96, 0x93, 0x36, 0xbb, 0x7d, 0x2d, 0xa5, 0xcc, 0xee, 0x76, 0xdd, 0xd0, 0x1f, 0x61, 0xd0,
-	0x56, 0x51, 0xa1, 0xb6, 0xda, 0x80, 0xb4, 0x82, 0x13, 0xdb, 0xad, 0x02, 0x52, 0x0f, 0xe0, 0x82,
-	0x40, 0x1c, 0x40, 0x8e, 0xfd, 0x94, 0x9d, 0x8d, 0x63, 0x1b, 0xcf, 0x24, 0xdb, 0x10, 0xf5, 0x82,
-	0x04, 0x48, 0x1c, 0x38, 0xc0, 0x89, 0x0b, 0x47, 0xfe, 0x0f, 0x6e, 0x1c, 0x91, 0xf8, 0x07, 0x50,
-	0xc5, 0x1f, 0x82, 0x66, 0x3c, 0x76, 0xe2, 0xa4, 0xe6, 0x87, 0x1a, 0x38, 0x65, 0x3c, 0x7e, 0xfe,
-	0xbe, 0xef, 0xbd, 0x79, 0xf3, 0xcd, 0x04, 0xb6, 0x39, 0x26, 0x43, 0x4c, 0xec, 0x38, 0x89, 0x9e,
-	0xa2, 0x27, 0xb2, 0x5f, 0x2b, 0x4e, 0x22, 0x11, 0x91, 0x5b, 0xfa, 0xb1, 0xbe, 0xdd, 0x8d, 0xa2,
-	0x6e, 0x80, 0xb6, 0x1b, 0x33, 0xdb, 0x0d, 0xc3, 0x48, 0xb8, 0x82, 0x45, 0x21, 0x4f, 0xc3, 0xea,
-	0xb4, 0xf7, 0x90, 0x5b, 0x2c, 0x52, 0x6f, 0xbd, 0x28, 0x41, 0x7b, 0x78, 0x64, 0x77, 0x31, 0xc4,
-	0xc4, 0x15, 0xe8, 0xeb, 0x98, 0xb3, 0x2e, 0x13, 0x4f, 0x06, 0x1d, 0xcb, 0x8b, 0xfa, 0xb6, 0x9b,
-	0x74, 0x23, 0x89, 0xac, 0x06, 0x87, 0x9e, 0x6f, 0x0f, 0x5b, 0x76, 0xdc, 0xeb, 0xca, 0xef, 0xb9,
-	0xed, 0xc6, 0x71, 0xc0, 0x3c, 0x85, 0x6f, 0x0f, 0x8f, 0xdc, 0x20, 0x7e, 0xe2, 0xce, 0xa3, 0x9d,
-	0xfc, 0x0d, 0x9a, 0xce, 0x6a, 0x1a, 0x6b, 0x6a, 0x9c, 0x82, 0xd0, 0xef, 0x0c, 0xb8, 0xfb, 0x6e,
-	0x9a, 0xe0, 0x49, 0x82, 0xae, 0x40, 0x07, 0x3f, 0x1b, 0x20, 0x17, 0xa4, 0x03, 0x59, 0xe2, 0xa6,
-	0xd1, 0x30, 0x9a, 0xab, 0xc7, 0x6f, 0x5b, 0x13, 0x3e, 0x2b, 0xe3, 0x53, 0x83, 0x4f, 0x3d, 0xdf,
-	0x1a, 0xb6, 0xac, 0xb8, 0xd7, 0xb5, 0xa4, 0x7a, 0x6b, 0x9a, 0x25, 0x53, 0x6f, 0xbd, 0x15, 0xc7,
-	0x9a, 0xc7, 0xc9, 0x80, 0xc9, 0x26, 0xd4, 0x06, 0x31, 0xc7, 0x44, 0x98, 0x95, 0x86, 0xd1, 0xbc,
-	0xed, 0xe8, 0x27, 0xda, 0x83, 0x2d, 0x1d, 0xfb, 0x7e, 0xd4, 0xc3, 0xf0, 0x31, 0x06, 0x38, 0x11,
-	0x66, 0x16, 0x85, 0xad, 0x4c, 0xe0, 0x08, 0x2c, 0x25, 0x51, 0x80, 0x0a, 0x6c, 0xc5, 0x51, 0x63,
-	0xb2, 0x01, 0x55, 0xe6, 0x0a, 0xb3, 0xda, 0x30, 0x9a, 0x55, 0x47, 0x0e, 0xc9, 0x3a, 0x54, 0x98,
-	0x6f, 0x2e, 0xa9, 0x98, 0x0a, 0xf3, 0xe9, 0x0f, 0x46, 0x91, 0xad, 0x58, 0x86, 0x72, 0xb6, 0x06,
-	0xac, 0xfa, 0xc8, 0xbd, 0x84, 0xc5, 0x32, 0x51, 0x4d, 0x3a, 0x3d, 0x95, 0xeb, 0xa9, 0x4e, 0xe9,
-	0xd9, 0x86, 0x15, 0xbc, 0x88, 0x59, 0x82, 0xfc, 0x9d, 0x50, 0x89, 0xa8, 0x3a, 0x93, 0x09, 0xad,
-	0x6d, 0x39, 0xd7, 0xf6, 0x6a, 0xbe, 0x38, 0x4a, 0x9a, 0x83, 0x3c, 0x8e, 0x42, 0x8e, 0xe4, 0x2e,
-	0x2c, 0x0b, 0x39, 0xa1, 0x35, 0xa5, 0x0f, 0x94, 0xc2, 0x9a, 0x8e, 0x7e, 0x6f, 0x80, 0xc9, 0x48,
-	0xf2, 0x87, 0x6e, 0x1f, 0x75, 0x90, 0x1a, 0xd3, 0xcf, 0x73, 0xc4, 0x0f, 0x62, 0xff, 0xff, 0x5d,
-	0x6e, 0xfa, 0x3c, 0x3c, 0x77, 0xda, 0x8f, 0xc5, 0x28, 0x4b, 0x83, 0xee, 0xc3, 0xc6, 0xf9, 0x28,
-	0xf4, 0x3e, 0x64, 0xa1, 0x1f, 0x3d, 0xe3, 0xe5, 0xa2, 0x47, 0x70, 0x67, 0x2a, 0x2e, 0xaf, 0x42,
-	0x07, 0x6e, 0x3d, 0x4b, 0xa7, 0x4c, 0xa3, 0x51, 0xbd, 0xb9, 0xe6, 0x09, 0x87, 0x93, 0x01, 0xd3,
-	0x0b, 0xd8, 0x6c, 0x07, 0x51, 0xc7, 0x0d, 0x74, 0x36, 0x13, 0xf6, 0x4f, 0x60, 0x99, 0x09, 0xec,
-	0x2f, 0x88, 0x7b, 0xaa, 0x5e, 0x29, 0x2c, 0xfd, 0xb9, 0x0a, 0xe6, 0x63, 0x14, 0x2e, 0x0b, 0xd0,
-	0x9f, 0x23, 0x8f, 0x61, 0xbd, 0x5b, 0x90, 0xb5, 0x70, 0x15, 0x33, 0xf8, 0xd3, 0x0d, 0x52, 0xf9,
-	0xaf, 0xfc, 0x20, 0x80, 0xb5, 0x04, 0xe3, 0x88, 0x33, 0x11, 0x25, 0x0c, 0xb9, 0x59, 0x5d, 0x44,
-	0x4e, 0x4e, 0x86, 0x38, 0x72, 0x0a, 0xe8, 0xc4, 0x85, 0xdb, 0x5e, 0x30, 0xe0, 0x02, 0x13, 0x6e,
-	0x2e, 0x29, 0xa6, 0xd3, 0x9b, 0x31, 0x9d, 0xa4, 0x68, 0x4e, 0x0e, 0x4b, 0x0f, 0xe1, 0xfe, 0x19,
-	0xe3, 0x42, 0x27, 0x7a, 0xc6, 0xc2, 0x1e, 0xcf, 0x36, 0xdc, 0x35, 0x7d, 0x7e, 0xfc, 0xe3, 0x1a,
-	0xac, 0xeb, 0xd8, 0x73, 0x4c, 0x86, 0xcc, 0x43, 0xf2, 0x8d, 0x01, 0xab, 0xa9, 0x23, 0x29, 0x07,
-	0x20, 0xd4, 0xca, 0x4e, 0xa7, 0x52, 0xcf, 0xaa, 0xef, 0x5c, 0x1b, 0x93, 0xef, 0xba, 0x87, 0x5f,
-	0xfc, 0xf6, 0xc7, 0xf7, 0x95, 0x63, 0x7a, 0xa8, 0xce, 0xaa, 0xe1, 0x51, 0x76, 0xde, 0x71, 0x7b,
-	0xac, 0x47, 0x97, 0xb6, 0xf4, 0x2a, 0x6e, 0x8f, 0xe5, 0xcf, 0xa5, 0xad, 0xdc, 0xe5, 0x0d, 0xe3,
-	0x80, 0x7c, 0x65, 0xc0, 0x6a, 0x6a, 0xc6, 0x7f, 0x25, 0xa6, 0x60, 0xd7, 0xf5, 0xcd, 0x3c, 0xa6,
-	0xb8, 0xf7, 0xdf, 0x54, 0x2a, 0x5e, 0x3f, 0x68, 0xfd, 0x2b, 0x15, 0xf6, 0x98, 0xb9, 0xe2, 0x92,
-	0x7c, 0x6b, 0x40, 0x2d, 0xcd, 0x99, 0xcc, 0x25, 0x5b, 0xac, 0xc5, 0xc2, 0xba, 0x94, 0xbe, 0xa8,
-	0x04, 0xdf, 0xa3, 0x1b, 0xb3, 0x82, 0x65, 0x65, 0xbe, 0x34, 0x60, 0x49, 0xae, 0x34, 0xb9, 0x37,
-	0x2b, 0x47, 0xb9, 0x5a, 0xfd, 0x6c, 0x51, 0x32, 0x24, 0x09, 0x35, 0x95, 0x14, 0x42, 0xe6, 0xa4,
-	0x90, 0x0b, 0x20, 0x6d, 0x14, 0x33, 0xb6, 0x51, 0x26, 0xea, 0xa5, 0x7c, 0xba, 0xcc, 0x67, 0x68,
-	0x53, 0x31, 0x51, 0xd2, 0x98, 0x5f, 0x25, 0xd9, 0xb1, 0x97, 0xb6, 0xaf, 0xbf, 0x24, 0x5f, 0x1b,
-	0x50, 0x6d, 0x63, 0x29, 0xd7, 0xe2, 0xd6, 0x61, 0x4f, 0x49, 0xda, 0x22, 0xf7, 0x4b, 0x24, 0x91,
-	0x31, 0xbc, 0xd0, 0x46, 0x51, 0x74, 0xed, 0x32, 0x59, 0x7b, 0xf9, 0xf4, 0xf5, 0x2e, 0x4f, 0x2d,
-	0xc5, 0xd6, 0x24, 0xfb, 0x65, 0x05, 0x48, 0x6d, 0x32, 0x5f, 0x80, 0x9f, 0x0c, 0xa8, 0xa5, 0x27,
-	0xeb, 0x7c, 0x67, 0x16, 0x4e, 0xdc, 0x05, 0x56, 0xa4, 0xa5, 0x34, 0x1e, 0xd6, 0x9b, 0xa5, 0x5b,
-	0xc9, 0xea, 0xa3, 0x70, 0x7d, 0x57, 0xb8, 0x96, 0x12, 0x2d, 0x3b, 0xf6, 0x23, 0xa8, 0xa5, 0x1b,
-	0xb5, 0xac, 0x34, 0x65, 0x1b, 0x57, 0xd7, 0xff, 0xa0, 0xb4, 0xfe, 0x4f, 0x01, 0x64, 0x97, 0x9e,
-	0x0e, 0x31, 0x2c, 0x2f, 0xfc, 0x8e, 0x95, 0xde, 0x97, 0x65, 0x86, 0x96, 0xbc, 0x2f, 0x5b, 0xc3,
-	0x23, 0x4b, 0x7d, 0xa2, 0x3a, 0x7c, 0x5f, 0x91, 0x34, 0xc8, 0x6e, 0x59, 0xd9, 0x31, 0x45, 0x1f,
-	0xc3, 0x9d, 0x36, 0x8a, 0xa9, 0xcb, 0xc1, 0xb9, 0x90, 0xa5, 0xdf, 0xca, 0x49, 0x67, 0xef, 0x17,
-	0xf5, 0xed, 0xeb, 0x5e, 0xe5, 0xc9, 0xbd, 0xa2, 0x78, 0x1f, 0x90, 0x97, 0xcb, 0x78, 0xf9, 0x28,
-	0xf4, 0xf4, 0xdd, 0x80, 0xc4, 0xb0, 0x22, 0xc5, 0x2a, 0x5b, 0x27, 0x8d, 0x1c, 0xb7, 0xc4, 0xf1,
-	0xeb, 0xf5, 0xc2, 0x42, 0xea, 0x57, 0x9a, 0xf7, 0x81, 0xe2, 0xdd, 0x23, 0x3b, 0x65, 0xbc, 0x81,
-	0x0c, 0x7f, 0xf4, 0xe8, 0x97, 0xab, 0x5d, 0xe3, 0xd7, 0xab, 0x5d, 0xe3, 0xf7, 0xab, 0x5d, 0xe3,
-	0xe3, 0xd7, 0xfe, 0xd9, 0xdf, 0x09, 0x2f, 0x60, 0x18, 0xe6, 0xff, 0x6a, 0x3a, 0x35, 0x75, 0xf1,
-	0x6f, 0xfd, 0x19, 0x00, 0x00, 0xff, 0xff, 0xae, 0xc9, 0xe8, 0xa8, 0xf6, 0x0c, 0x00, 0x00,
+	0x14, 0x96, 0x9b, 0xb6, 0xbb, 0x7d, 0x2d, 0xa5, 0xcc, 0x76, 0xbb, 0x6e, 0xe8, 0x8f, 0x30, 0x68,
+	0xab, 0xa8, 0x50, 0x5b, 0x6d, 0x41, 0x5a, 0xc1, 0x89, 0x76, 0xab, 0x80, 0xd4, 0xc3, 0xe2, 0x82,
+	0x40, 0x1c, 0x40, 0x8e, 0xfd, 0xc8, 0xce, 0xc6, 0xb1, 0x8d, 0x67, 0x92, 0x6d, 0x88, 0x7a, 0x41,
+	0x02, 0x24, 0x0e, 0x1c, 0xe0, 0xc4, 0x85, 0x23, 0xff, 0x07, 0x37, 0x8e, 0x48, 0xfc, 0x03, 0xa8,
+	0xe2, 0x0f, 0x41, 0x33, 0x1e, 0x3b, 0x76, 0x52, 0xf3, 0x43, 0x1b, 0x38, 0x79, 0x3c, 0x7e, 0xfe,
+	0xbe, 0xef, 0xbd, 0x79, 0xf3, 0x8d, 0x0d, 0x5b, 0x1c, 0x93, 0x01, 0x26, 0x76, 0x9c, 0x44, 0x4f,
+	0xd0, 0x13, 0xd9, 0xd5, 0x8a, 0x93, 0x48, 0x44, 0xe4, 0x96, 0xbe, 0xad, 0x6f, 0x75, 0xa2, 0xa8,
+	0x13, 0xa0, 0xed, 0xc6, 0xcc, 0x76, 0xc3, 0x30, 0x12, 0xae, 0x60, 0x51, 0xc8, 0xd3, 0xb0, 0x3a,
+	0xed, 0x3e, 0xe0, 0x16, 0x8b, 0xd4, 0x53, 0x2f, 0x4a, 0xd0, 0x1e, 0x1c, 0xda, 0x1d, 0x0c, 0x31,
+	0x71, 0x05, 0xfa, 0x3a, 0xe6, 0xbc, 0xc3, 0xc4, 0xe3, 0x7e, 0xdb, 0xf2, 0xa2, 0x9e, 0xed, 0x26,
+	0x9d, 0x48, 0x22, 0xab, 0xc1, 0x81, 0xe7, 0xdb, 0x83, 0x63, 0x3b, 0xee, 0x76, 0xe4, 0xfb, 0xdc,
+	0x76, 0xe3, 0x38, 0x60, 0x9e, 0xc2, 0xb7, 0x07, 0x87, 0x6e, 0x10, 0x3f, 0x76, 0xa7, 0xd1, 0x4e,
+	0xff, 0x06, 0x4d, 0x67, 0x55, 0xc4, 0x2a, 0x8c, 0x53, 0x10, 0xfa, 0x9d, 0x01, 0xeb, 0x8f, 0xd2,
+	0x04, 0x4f, 0x13, 0x74, 0x05, 0x3a, 0xf8, 0x59, 0x1f, 0xb9, 0x20, 0x6d, 0xc8, 0x12, 0x37, 0x8d,
+	0x86, 0xd1, 0x5c, 0x3e, 0x7a, 0xdb, 0x1a, 0xf3, 0x59, 0x19, 0x9f, 0x1a, 0x7c, 0xe2, 0xf9, 0xd6,
+	0xe0, 0xd8, 0x8a, 0xbb, 0x1d, 0x4b, 0xaa, 0xb7, 0x8a, 0x2c, 0x99, 0x7a, 0xeb, 0xad, 0x38, 0xd6,
+	0x3c, 0x4e, 0x06, 0x4c, 0x36, 0x60, 0xb1, 0x1f, 0x73, 0x4c, 0x84, 0x39, 0xd7, 0x30, 0x9a, 0xb7,
+	0x1d, 0x7d, 0x47, 0xbb, 0xb0, 0xa9, 0x63, 0xdf, 0x8b, 0xba, 0x18, 0x3e, 0xc4, 0x00, 0xc7, 0xc2,
+	0xcc, 0xb2, 0xb0, 0xa5, 0x31, 0x1c, 0x81, 0xf9, 0x24, 0x0a, 0x50, 0x81, 0x2d, 0x39, 0x6a, 0x4c,
+	0xd6, 0xa0, 0xc6, 0x5c, 0x61, 0xd6, 0x1a, 0x46, 0xb3, 0xe6, 0xc8, 0x21, 0x59, 0x85, 0x39, 0xe6,
+	0x9b, 0xf3, 0x2a, 0x66, 0x8e, 0xf9, 0xf4, 0x07, 0xa3, 0xcc, 0x56, 0x2e, 0x43, 0x35, 0x5b, 0x03,
+	0x96, 0x7d, 0xe4, 0x5e, 0xc2, 0x62, 0x99, 0xa8, 0x26, 0x2d, 0x4e, 0xe5, 0x7a, 0x6a, 0x05, 0x3d,
+	0x5b, 0xb0, 0x84, 0x97, 0x31, 0x4b, 0x90, 0xbf, 0x13, 0x2a, 0x11, 0x35, 0x67, 0x3c, 0xa1, 0xb5,
+	0x2d, 0xe4, 0xda, 0x5e, 0x85, 0xf5, 0xa2, 0x34, 0x07, 0x79, 0x1c, 0x85, 0x1c, 0xc9, 0x3a, 0x2c,
+	0x08, 0x39, 0xa1, 0x35, 0xa5, 0x37, 0xf4, 0x04, 0x56, 0x74, 0xf4, 0xbb, 0x7d, 0x4c, 0x86, 0x92,
+	0x3f, 0x74, 0x7b, 0xa8, 0x83, 0xd4, 0x98, 0xec, 0x00, 0xc8, 0xeb, 0xa3, 0x04, 0x3f, 0x65, 0x97,
+	0x5a, 0x74, 0x61, 0x86, 0x7e, 0x9e, 0x33, 0xbe, 0x1f, 0xfb, 0xff, 0x6f, 0x3b, 0xd0, 0xe7, 0xe1,
+	0xb9, 0xb3, 0x5e, 0x2c, 0x86, 0x59, 0x9a, 0x74, 0x0f, 0xd6, 0x2e, 0x86, 0xa1, 0xf7, 0x01, 0x0b,
+	0xfd, 0xe8, 0x29, 0xaf, 0x4c, 0x8a, 0x0e, 0xe1, 0x4e, 0x21, 0x2e, 0xaf, 0x52, 0x1b, 0x6e, 0x3d,
+	0x4d, 0xa7, 0x4c, 0xa3, 0x51, 0x7b, 0x76, 0xcd, 0x63, 0x0e, 0x27, 0x03, 0xa6, 0x97, 0xb0, 0xd1,
+	0x0a, 0xa2, 0xb6, 0x1b, 0xe8, 0x6c, 0xc6, 0xec, 0x1f, 0xc3, 0x02, 0x13, 0xd8, 0x9b, 0x11, 0x77,
+	0xa1, 0x5e, 0x29, 0x2c, 0xfd, 0xb9, 0x06, 0xe6, 0x43, 0x14, 0x2e, 0x0b, 0xd0, 0x9f, 0x22, 0x8f,
+	0x61, 0xb5, 0x53, 0x92, 0x35, 0x73, 0x15, 0x13, 0xf8, 0xc5, 0x06, 0x99, 0xfb, 0xaf, 0xfc, 0x22,
+	0x80, 0x95, 0x04, 0xe3, 0x88, 0x33, 0x11, 0x25, 0x0c, 0xb9, 0x59, 0x9b, 0x45, 0x4e, 0x4e, 0x86,
+	0x38, 0x74, 0x4a, 0xe8, 0xc4, 0x85, 0xdb, 0x5e, 0xd0, 0xe7, 0x02, 0x13, 0x6e, 0xce, 0x2b, 0xa6,
+	0xb3, 0x67, 0x63, 0x3a, 0x4d, 0xd1, 0x9c, 0x1c, 0x96, 0x1e, 0xc0, 0xbd, 0x73, 0xc6, 0x85, 0x4e,
+	0xf4, 0x9c, 0x85, 0x5d, 0x9e, 0x6d, 0xb8, 0x1b, 0xfa, 0xfc, 0xe8, 0xc7, 0x15, 0x58, 0xd5, 0xb1,
+	0x17, 0x98, 0x0c, 0x98, 0x87, 0xe4, 0x1b, 0x03, 0x96, 0x53, 0xc7, 0x52, 0x0e, 0x41, 0xa8, 0x95,
+	0x9d, 0x5e, 0x95, 0x9e, 0x56, 0xdf, 0xbe, 0x31, 0x26, 0xdf, 0x75, 0x0f, 0xbe, 0xf8, 0xed, 0x8f,
+	0xef, 0xe7, 0x8e, 0xe8, 0x81, 0x3a, 0xcb, 0x06, 0x87, 0xd9, 0x79, 0xc8, 0xed, 0x91, 0x1e, 0x5d,
+	0xd9, 0xd2, 0xcb, 0xb8, 0x3d, 0x92, 0x97, 0x2b, 0x5b, 0xb9, 0xcf, 0x1b, 0xc6, 0x3e, 0xf9, 0xca,
+	0x80, 0xe5, 0xd4, 0xac, 0xff, 0x4a, 0x4c, 0xc9, 0xce, 0xeb, 0x1b, 0x79, 0x4c, 0x79, 0xef, 0xbf,
+	0xa9, 0x54, 0xbc, 0xbe, 0x7f, 0xfc, 0xaf, 0x54, 0xd8, 0x23, 0xe6, 0x8a, 0x2b, 0xf2, 0xad, 0x01,
+	0x8b, 0x69, 0xce, 0x64, 0x2a, 0xd9, 0x72, 0x2d, 0x66, 0xd6, 0xa5, 0xf4, 0x45, 0x25, 0xf8, 0x2e,
+	0x5d, 0x9b, 0x14, 0x2c, 0x2b, 0xf3, 0xa5, 0x01, 0xf3, 0x72, 0xa5, 0xc9, 0xdd, 0x49, 0x39, 0xca,
+	0xd5, 0xea, 0xe7, 0xb3, 0x92, 0x21, 0x49, 0xa8, 0xa9, 0xa4, 0x10, 0x32, 0x25, 0x85, 0x5c, 0x02,
+	0x69, 0xa1, 0x98, 0xb0, 0x8d, 0x2a, 0x51, 0x2f, 0xe5, 0xd3, 0x55, 0x3e, 0x43, 0x9b, 0x8a, 0x89,
+	0x92, 0xc6, 0xf4, 0x2a, 0xc9, 0x8e, 0xbd, 0xb2, 0x7d, 0xfd, 0x26, 0xf9, 0xda, 0x80, 0x5a, 0x0b,
+	0x2b, 0xb9, 0x66, 0xb7, 0x0e, 0xbb, 0x4a, 0xd2, 0x26, 0xb9, 0x57, 0x21, 0x89, 0x8c, 0xe0, 0x85,
+	0x16, 0x8a, 0xb2, 0x6b, 0x57, 0xc9, 0xda, 0xcd, 0xa7, 0x6f, 0x76, 0x79, 0x6a, 0x29, 0xb6, 0x26,
+	0xd9, 0xab, 0x2a, 0x40, 0x6a, 0x93, 0xf9, 0x02, 0xfc, 0x64, 0xc0, 0x62, 0x7a, 0xb2, 0x4e, 0x77,
+	0x66, 0xe9, 0xc4, 0x9d, 0x61, 0x45, 0x8e, 0x95, 0xc6, 0x83, 0x7a, 0xb3, 0x72, 0x2b, 0x59, 0x3d,
+	0x14, 0xae, 0xef, 0x0a, 0xd7, 0x52, 0xa2, 0x65, 0xc7, 0x7e, 0x08, 0x8b, 0xe9, 0x46, 0xad, 0x2a,
+	0x4d, 0xd5, 0xc6, 0xd5, 0xf5, 0xdf, 0xaf, 0xac, 0xff, 0x13, 0x00, 0xd9, 0xa5, 0x67, 0x03, 0x0c,
+	0xab, 0x0b, 0xbf, 0x6d, 0xa5, 0xdf, 0xd3, 0x32, 0x43, 0xcb, 0x8b, 0x12, 0xb4, 0x06, 0x87, 0x96,
+	0x7a, 0x45, 0x75, 0xf8, 0x9e, 0x22, 0x69, 0x90, 0x9d, 0xaa, 0xb2, 0x63, 0x8a, 0x3e, 0x82, 0x3b,
+	0x2d, 0x14, 0x85, 0x8f, 0x83, 0x0b, 0x21, 0x4b, 0xbf, 0x99, 0x93, 0x4e, 0x7e, 0x5f, 0xd4, 0xb7,
+	0x6e, 0x7a, 0x94, 0x27, 0xf7, 0x8a, 0xe2, 0xbd, 0x4f, 0x5e, 0xae, 0xe2, 0xe5, 0xc3, 0xd0, 0xd3,
+	0xdf, 0x06, 0x24, 0x86, 0x25, 0x29, 0x56, 0xd9, 0x3a, 0x69, 0xe4, 0xb8, 0x15, 0x8e, 0x5f, 0xaf,
+	0x97, 0x16, 0x52, 0x3f, 0xd2, 0xbc, 0xf7, 0x15, 0xef, 0x2e, 0xd9, 0xae, 0xe2, 0x0d, 0x64, 0xf8,
+	0xc9, 0xc9, 0x2f, 0xd7, 0x3b, 0xc6, 0xaf, 0xd7, 0x3b, 0xc6, 0xef, 0xd7, 0x3b, 0xc6, 0x47, 0xaf,
+	0xfd, 0xb3, 0xdf, 0x0d, 0x2f, 0x60, 0x18, 0xe6, 0x7f, 0x3d, 0xed, 0x45, 0xf5, 0x63, 0x70, 0xfc,
+	0xe7, 0x00, 0xf2, 0x4c, 0x54, 0xc4, 0x16, 0x0d, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -1488,6 +1498,13 @@ func (m *ProjectQuery) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if len(m.NamePrefix) > 0 {
+		i -= len(m.NamePrefix)
+		copy(dAtA[i:], m.NamePrefix)
+		i = encodeVarintProject(dAtA, i, uint64(len(m.NamePrefix)))
+		i--
+		dAtA[i] = 0x12
+	}
 	if len(m.Name) > 0 {
 		i -= len(m.Name)
 		copy(dAtA[i:], m.Name)
@@ -1909,6 +1926,10 @@ func (m *ProjectQuery) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovProject(uint64(l))
 	}
+	l = len(m.NamePrefix)
+	if l > 0 {
+		n += 1 + l + sovProject(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -2666,6 +2687,38 @@ func (m *ProjectQuery) Unmarshal(dAtA []byte) error {
 			}
 			m.Name = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NamePrefix", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProject
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthProject
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthProject
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NamePrefix = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipProject(dAtA[iNdEx:])
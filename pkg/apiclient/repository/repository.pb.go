@@ -401,7 +401,19 @@ type RepoAccessQuery struct {
 	// BearerToken contains the bearer token used for Git auth at the repo server
 	BearerToken string `protobuf:"bytes,21,opt,name=bearerToken,proto3" json:"bearerToken,omitempty"`
 	// Whether https should be disabled for an OCI repo
-	InsecureOciForceHttp bool     `protobuf:"varint,22,opt,name=insecureOciForceHttp,proto3" json:"insecureOciForceHttp,omitempty"`
+	InsecureOciForceHttp bool `protobuf:"varint,22,opt,name=insecureOciForceHttp,proto3" json:"insecureOciForceHttp,omitempty"`
+	// OIDC token exchange endpoint used to get access tokens to the repository
+	OidcTokenExchangeURL string `protobuf:"bytes,23,opt,name=oidcTokenExchangeURL,proto3" json:"oidcTokenExchangeURL,omitempty"`
+	// Audience of the access tokens requested from the OIDC token exchange endpoint
+	OidcTokenExchangeAudience string `protobuf:"bytes,24,opt,name=oidcTokenExchangeAudience,proto3" json:"oidcTokenExchangeAudience,omitempty"`
+	// Whether to authenticate with the Kerberos tickets of the repo server
+	UseKerberos bool `protobuf:"varint,25,opt,name=useKerberos,proto3" json:"useKerberos,omitempty"`
+	// NoProxy specifies a list of targets where the proxy isn't used
+	NoProxy string `protobuf:"bytes,26,opt,name=noProxy,proto3" json:"noProxy,omitempty"`
+	// Username used to authenticate at the proxy
+	ProxyUsername string `protobuf:"bytes,27,opt,name=proxyUsername,proto3" json:"proxyUsername,omitempty"`
+	// Password used to authenticate at the proxy
+	ProxyPassword        string   `protobuf:"bytes,28,opt,name=proxyPassword,proto3" json:"proxyPassword,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -587,6 +599,48 @@ func (m *RepoAccessQuery) GetInsecureOciForceHttp() bool {
 	return false
 }
 
+func (m *RepoAccessQuery) GetOidcTokenExchangeURL() string {
+	if m != nil {
+		return m.OidcTokenExchangeURL
+	}
+	return ""
+}
+
+func (m *RepoAccessQuery) GetOidcTokenExchangeAudience() string {
+	if m != nil {
+		return m.OidcTokenExchangeAudience
+	}
+	return ""
+}
+
+func (m *RepoAccessQuery) GetUseKerberos() bool {
+	if m != nil {
+		return m.UseKerberos
+	}
+	return false
+}
+
+func (m *RepoAccessQuery) GetNoProxy() string {
+	if m != nil {
+		return m.NoProxy
+	}
+	return ""
+}
+
+func (m *RepoAccessQuery) GetProxyUsername() string {
+	if m != nil {
+		return m.ProxyUsername
+	}
+	return ""
+}
+
+func (m *RepoAccessQuery) GetProxyPassword() string {
+	if m != nil {
+		return m.ProxyPassword
+	}
+	return ""
+}
+
 type RepoResponse struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -757,94 +811,100 @@ func init() {
 }
 
 var fileDescriptor_8d38260443475705 = []byte{
-	// 1392 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x58, 0xdf, 0x6e, 0x1b, 0xc5,
-	0x17, 0xd6, 0x26, 0x8d, 0x9b, 0x4c, 0x9a, 0xd6, 0x9d, 0x24, 0xed, 0xfe, 0xdc, 0x34, 0xcd, 0x6f,
-	0x5b, 0xa2, 0x34, 0x6a, 0xd7, 0x4d, 0x0a, 0xa2, 0x2a, 0x02, 0xc9, 0x4d, 0x4a, 0x6b, 0x11, 0x91,
-	0xb2, 0x6d, 0xa9, 0x84, 0x40, 0x68, 0xb2, 0x3e, 0xb1, 0xb7, 0xd9, 0xec, 0x4e, 0x67, 0xc6, 0x6e,
-	0x4d, 0xd5, 0x1b, 0x84, 0x10, 0x12, 0xdc, 0x20, 0x04, 0xe2, 0x0e, 0x2e, 0x90, 0x90, 0xe0, 0x12,
-	0x89, 0x67, 0xe0, 0x12, 0x89, 0x17, 0x40, 0x15, 0x0f, 0xc1, 0x25, 0x9a, 0x33, 0xeb, 0xf5, 0x3a,
-	0xf1, 0x9f, 0x44, 0x4d, 0x73, 0x37, 0x73, 0xce, 0xec, 0xf9, 0xbe, 0xf3, 0xcd, 0x99, 0x33, 0x63,
-	0x13, 0x47, 0x82, 0x68, 0x80, 0x28, 0x0a, 0xe0, 0xb1, 0x0c, 0x54, 0x2c, 0x9a, 0x99, 0xa1, 0xcb,
-	0x45, 0xac, 0x62, 0x4a, 0xda, 0x96, 0xc2, 0x4c, 0x35, 0x8e, 0xab, 0x21, 0x14, 0x19, 0x0f, 0x8a,
-	0x2c, 0x8a, 0x62, 0xc5, 0x54, 0x10, 0x47, 0xd2, 0xac, 0x2c, 0xac, 0x55, 0x03, 0x55, 0xab, 0x6f,
-	0xb8, 0x7e, 0xbc, 0x5d, 0x64, 0xa2, 0x1a, 0x73, 0x11, 0x3f, 0xc4, 0xc1, 0x65, 0xbf, 0x52, 0x6c,
-	0x5c, 0x2d, 0xf2, 0xad, 0xaa, 0xfe, 0x52, 0x16, 0x19, 0xe7, 0x61, 0xe0, 0xe3, 0xb7, 0xc5, 0xc6,
-	0x12, 0x0b, 0x79, 0x8d, 0x2d, 0x15, 0xab, 0x10, 0x81, 0x60, 0x0a, 0x2a, 0x49, 0xb4, 0x9b, 0x03,
-	0xa2, 0x21, 0xad, 0x81, 0xf4, 0x9d, 0x26, 0x99, 0xf0, 0x80, 0xc7, 0x25, 0xce, 0xe5, 0x7b, 0x75,
-	0x10, 0x4d, 0x4a, 0xc9, 0x11, 0xbd, 0xc8, 0xb6, 0xe6, 0xac, 0x85, 0x31, 0x0f, 0xc7, 0xb4, 0x40,
-	0x46, 0x05, 0x34, 0x02, 0x19, 0xc4, 0x91, 0x3d, 0x84, 0xf6, 0x74, 0x4e, 0x6d, 0x72, 0x94, 0x71,
-	0xfe, 0x2e, 0xdb, 0x06, 0x7b, 0x18, 0x5d, 0xad, 0x29, 0x9d, 0x25, 0x84, 0x71, 0x7e, 0x47, 0xc4,
-	0x0f, 0xc1, 0x57, 0xf6, 0x11, 0x74, 0x66, 0x2c, 0xce, 0x12, 0x39, 0x5a, 0xe2, 0xbc, 0x1c, 0x6d,
-	0xc6, 0x1a, 0x54, 0x35, 0x39, 0xb4, 0x40, 0xf5, 0x58, 0xdb, 0x38, 0x53, 0xb5, 0x04, 0x10, 0xc7,
-	0xce, 0xbf, 0x16, 0x99, 0x4c, 0xe8, 0xae, 0x82, 0x62, 0x41, 0x98, 0x90, 0xae, 0x92, 0x9c, 0x8c,
-	0xeb, 0xc2, 0x37, 0x11, 0xc6, 0x97, 0xd7, 0xdd, 0xb6, 0x3a, 0x6e, 0x4b, 0x1d, 0x1c, 0x7c, 0xec,
-	0x57, 0xdc, 0xc6, 0x55, 0x97, 0x6f, 0x55, 0x5d, 0xad, 0xb5, 0x9b, 0xd1, 0xda, 0x6d, 0x69, 0xed,
-	0x96, 0xda, 0xc6, 0xbb, 0x18, 0xd6, 0x4b, 0xc2, 0x67, 0xb3, 0x1d, 0xea, 0x97, 0xed, 0xf0, 0xce,
-	0x6c, 0xe9, 0x1c, 0x19, 0x37, 0x31, 0xca, 0x51, 0x05, 0x9e, 0xa0, 0x1c, 0x23, 0x5e, 0xd6, 0x44,
-	0x67, 0xc8, 0x58, 0x03, 0x84, 0x16, 0xb5, 0x5c, 0xb1, 0x47, 0xd0, 0xdf, 0x36, 0x38, 0x6f, 0x92,
-	0x7c, 0x6b, 0xa3, 0x3c, 0x90, 0x3c, 0x8e, 0x24, 0xd0, 0x8b, 0x64, 0x24, 0x50, 0xb0, 0x2d, 0x6d,
-	0x6b, 0x6e, 0x78, 0x61, 0x7c, 0x79, 0xd2, 0xcd, 0x6c, 0x6f, 0x22, 0xad, 0x67, 0x56, 0x38, 0x3e,
-	0x19, 0xd3, 0x9f, 0xf7, 0xde, 0x63, 0x87, 0x1c, 0xdb, 0x8c, 0x75, 0xaa, 0xb0, 0x29, 0x40, 0x1a,
-	0xd9, 0x47, 0xbd, 0x0e, 0xdb, 0xa0, 0x1c, 0x9d, 0xdf, 0x72, 0xe4, 0x04, 0x92, 0xf4, 0x7d, 0x90,
-	0xfd, 0xeb, 0xa9, 0x2e, 0x41, 0x44, 0x6d, 0x19, 0xd3, 0xb9, 0xf6, 0x71, 0x26, 0xe5, 0xe3, 0x58,
-	0x54, 0x12, 0x84, 0x74, 0x4e, 0x2f, 0x90, 0x09, 0x29, 0x6b, 0x77, 0x44, 0xd0, 0x60, 0x0a, 0xde,
-	0x81, 0x66, 0x52, 0x54, 0x9d, 0x46, 0x1d, 0x21, 0x88, 0x24, 0xf8, 0x75, 0x01, 0x28, 0xe3, 0xa8,
-	0x97, 0xce, 0xe9, 0x25, 0x72, 0x52, 0x85, 0x72, 0x25, 0x0c, 0x20, 0x52, 0x2b, 0x20, 0xd4, 0x2a,
-	0x53, 0xcc, 0xce, 0x61, 0x94, 0xdd, 0x0e, 0xba, 0x48, 0xf2, 0x1d, 0x46, 0x0d, 0x79, 0x14, 0x17,
-	0xef, 0xb2, 0xa7, 0x25, 0x3c, 0xd6, 0x59, 0xc2, 0x98, 0x23, 0x31, 0x36, 0xcc, 0x6f, 0x86, 0x8c,
-	0x41, 0xc4, 0x36, 0x42, 0x58, 0xf7, 0x03, 0x7b, 0x1c, 0xe9, 0xb5, 0x0d, 0xf4, 0x0a, 0x99, 0x34,
-	0x95, 0x5b, 0xd2, 0xaa, 0xa6, 0x79, 0x1e, 0xc3, 0x00, 0xdd, 0x5c, 0xba, 0xae, 0x52, 0x73, 0x79,
-	0xd5, 0x9e, 0x98, 0xb3, 0x16, 0x86, 0xbd, 0xac, 0x89, 0x5e, 0x23, 0xa7, 0xdb, 0xd3, 0x48, 0x2a,
-	0x16, 0x86, 0x58, 0xda, 0xe5, 0x55, 0xfb, 0x38, 0xae, 0xee, 0xe5, 0xa6, 0x6f, 0x91, 0x42, 0xea,
-	0xba, 0x19, 0x29, 0x10, 0x5c, 0x04, 0x12, 0x6e, 0x30, 0x09, 0xf7, 0x45, 0x68, 0x9f, 0x40, 0x52,
-	0x7d, 0x56, 0xd0, 0x29, 0x32, 0xc2, 0x45, 0xfc, 0xa4, 0x69, 0xe7, 0x71, 0xa9, 0x99, 0xe8, 0x33,
-	0xc4, 0x93, 0x12, 0x3a, 0x69, 0xce, 0x50, 0x32, 0xa5, 0xcb, 0x64, 0xaa, 0xea, 0xf3, 0xbb, 0x20,
-	0x1a, 0x81, 0x0f, 0x25, 0xdf, 0x8f, 0xeb, 0x11, 0x6a, 0x4e, 0x71, 0x59, 0x57, 0x1f, 0x75, 0x09,
-	0xc5, 0x1a, 0xbd, 0xad, 0x14, 0xbf, 0xc1, 0x64, 0xe0, 0x97, 0xea, 0xaa, 0x66, 0x4f, 0xa2, 0xb0,
-	0x5d, 0x3c, 0xf4, 0x3a, 0xb1, 0xeb, 0x12, 0x4a, 0x9f, 0xd4, 0x05, 0x3c, 0x88, 0xc5, 0x56, 0x18,
-	0xb3, 0x4a, 0xb9, 0x02, 0x91, 0x0a, 0x54, 0xd3, 0x9e, 0xc2, 0xaf, 0x7a, 0xfa, 0xb5, 0xd6, 0x1b,
-	0xc0, 0x04, 0x88, 0x7b, 0xf1, 0x16, 0x44, 0xf6, 0x34, 0xd2, 0xca, 0x9a, 0x74, 0x06, 0xad, 0x5a,
-	0x5b, 0xf7, 0x83, 0xb7, 0x5b, 0xf0, 0xf6, 0x29, 0x8c, 0xdc, 0xd5, 0xe7, 0x1c, 0x27, 0xc7, 0xf4,
-	0xa1, 0x69, 0x9d, 0x6a, 0xe7, 0x67, 0x8b, 0x9c, 0xd4, 0x86, 0x15, 0x01, 0x4c, 0x81, 0x07, 0x8f,
-	0xea, 0x20, 0x15, 0xfd, 0x30, 0x73, 0x8e, 0xc6, 0x97, 0x6f, 0xbf, 0x58, 0x83, 0xf3, 0xd2, 0x3e,
-	0x91, 0x9c, 0xc8, 0x53, 0x24, 0x57, 0xe7, 0x12, 0x84, 0x4a, 0xce, 0x7d, 0x32, 0xd3, 0xd5, 0xea,
-	0x0b, 0xa8, 0xc8, 0xf5, 0x28, 0x6c, 0xe2, 0x71, 0x1c, 0xf5, 0xda, 0x06, 0xe7, 0x91, 0x21, 0x7a,
-	0x9f, 0x57, 0x0e, 0x8b, 0xe8, 0xf2, 0x67, 0xa7, 0x0d, 0xa6, 0x31, 0x26, 0xe5, 0x40, 0xbf, 0xb2,
-	0xc8, 0x91, 0xb5, 0x40, 0x2a, 0x3a, 0x9d, 0x6d, 0x81, 0x69, 0xc3, 0x2b, 0xac, 0x1d, 0x14, 0x0b,
-	0x0d, 0xe2, 0x9c, 0xfb, 0xf4, 0xaf, 0x7f, 0xbe, 0x19, 0x3a, 0x45, 0xa7, 0xf0, 0xa2, 0x6f, 0x2c,
-	0xb5, 0x6f, 0xd5, 0x00, 0xe4, 0x17, 0x43, 0x16, 0xfd, 0xd2, 0x22, 0xc3, 0xb7, 0xa0, 0x27, 0x9b,
-	0x03, 0xd3, 0xc4, 0x39, 0x8f, 0x4c, 0xce, 0xd2, 0x33, 0xdd, 0x98, 0x14, 0x9f, 0xea, 0xd9, 0x33,
-	0xfa, 0x9d, 0x45, 0x46, 0x6f, 0x81, 0x7a, 0x20, 0x02, 0x05, 0x2f, 0x9f, 0xd2, 0x45, 0xa4, 0x74,
-	0x9e, 0xfe, 0xbf, 0x45, 0xe9, 0xb1, 0xc6, 0xbd, 0xdc, 0x8d, 0xd8, 0xb7, 0x16, 0xc9, 0x6b, 0x41,
-	0xbd, 0x8c, 0xef, 0x70, 0x76, 0x70, 0xa6, 0xdf, 0x0e, 0xd2, 0x1f, 0x2d, 0x32, 0xad, 0x97, 0xa1,
-	0x62, 0x87, 0x4f, 0xce, 0x41, 0x72, 0x33, 0xb4, 0xd0, 0x5b, 0x41, 0xfa, 0x11, 0x19, 0x35, 0xca,
-	0x6d, 0xf6, 0x24, 0x95, 0xef, 0x34, 0x6f, 0x4a, 0x67, 0x01, 0x03, 0x3b, 0x74, 0xae, 0x4f, 0xb5,
-	0x14, 0x85, 0x0e, 0x59, 0x21, 0xe3, 0x3a, 0xfc, 0xfa, 0x4a, 0xf9, 0x1e, 0xab, 0xee, 0x03, 0xe1,
-	0x12, 0x22, 0xcc, 0xd3, 0x0b, 0xfd, 0x10, 0x62, 0x3f, 0xb8, 0xac, 0x74, 0xd8, 0x6d, 0x93, 0x84,
-	0x7e, 0xd2, 0xd0, 0xff, 0xed, 0x84, 0x48, 0x5f, 0xa4, 0x85, 0x99, 0x6e, 0xae, 0xb4, 0x5b, 0xee,
-	0x29, 0x29, 0xa6, 0x21, 0xbe, 0xb6, 0xc8, 0xc4, 0x2d, 0x50, 0xed, 0xb7, 0x23, 0x3d, 0xd7, 0x25,
-	0x72, 0xf6, 0x5d, 0x59, 0x70, 0x7a, 0x2f, 0x48, 0x09, 0xbc, 0x81, 0x04, 0x5e, 0x73, 0xae, 0x74,
-	0x27, 0x60, 0x5e, 0x78, 0x18, 0xe7, 0xbe, 0xb7, 0x86, 0x54, 0x2a, 0x26, 0xc2, 0x75, 0x6b, 0x91,
-	0x36, 0x90, 0xd2, 0x6d, 0x08, 0xb7, 0x57, 0x6a, 0x4c, 0xa8, 0x9e, 0x52, 0xcf, 0x66, 0xcd, 0xed,
-	0xe5, 0x29, 0x09, 0x17, 0x49, 0x2c, 0xd0, 0xf9, 0x7e, 0x2a, 0xd4, 0x20, 0xdc, 0xf6, 0x0d, 0xcc,
-	0xf7, 0x16, 0xc9, 0x99, 0xfb, 0x85, 0x9e, 0xdd, 0x89, 0xd8, 0x71, 0xef, 0x1c, 0x60, 0x67, 0x78,
-	0xc5, 0xd4, 0xb5, 0xd3, 0xf5, 0xd0, 0x5d, 0xc7, 0xf6, 0xae, 0x9b, 0xe7, 0x0f, 0x16, 0xc9, 0xb7,
-	0x28, 0xb4, 0xbe, 0x3d, 0x3c, 0x92, 0xce, 0x60, 0x92, 0xf4, 0x17, 0x8b, 0x4c, 0x1b, 0xfc, 0xce,
-	0x0e, 0x71, 0x88, 0x34, 0x93, 0xaa, 0x77, 0xfa, 0xf4, 0x88, 0x84, 0xec, 0x4f, 0x16, 0xc9, 0x99,
-	0x0b, 0x7a, 0x37, 0xbb, 0x8e, 0x8b, 0xfb, 0x00, 0xd9, 0x2d, 0x99, 0x6a, 0x2c, 0xf4, 0x39, 0x93,
-	0x48, 0xe5, 0x59, 0x7b, 0xd7, 0x7f, 0xb5, 0x48, 0xbe, 0x45, 0xa7, 0xb7, 0x9c, 0x2f, 0x8b, 0xb0,
-	0xbb, 0x3f, 0xc2, 0xf4, 0x77, 0x8b, 0x4c, 0x1b, 0x2e, 0x03, 0x2b, 0xe0, 0x65, 0x51, 0x7e, 0x15,
-	0x29, 0xbb, 0x85, 0xf9, 0x41, 0xf7, 0x6c, 0x07, 0x71, 0x46, 0x72, 0xab, 0x10, 0x42, 0xef, 0x87,
-	0x80, 0xbd, 0xd3, 0x9c, 0xb6, 0x98, 0x79, 0xf3, 0xd6, 0x58, 0xec, 0xf7, 0xd6, 0xd0, 0x3b, 0x59,
-	0x23, 0x79, 0x03, 0x91, 0x51, 0x65, 0xdf, 0x60, 0xe7, 0xf7, 0x00, 0x46, 0x25, 0x99, 0x36, 0x48,
-	0x3b, 0x37, 0x61, 0xdf, 0x70, 0xc9, 0xa3, 0x65, 0x71, 0x0f, 0x8f, 0x96, 0xa7, 0xe4, 0xf8, 0xfb,
-	0x2c, 0x0c, 0xf4, 0xa6, 0x9a, 0x9f, 0xb9, 0xf4, 0xcc, 0xae, 0x4b, 0xa2, 0xfd, 0xf3, 0xb7, 0x0f,
-	0xe6, 0x32, 0x62, 0x5e, 0x72, 0xfa, 0xde, 0x95, 0x8d, 0x04, 0x2a, 0xd9, 0xbe, 0xcf, 0x2d, 0x32,
-	0xd9, 0x42, 0xc7, 0xa4, 0x5f, 0x8c, 0xc2, 0x35, 0xa4, 0xb0, 0xec, 0x2c, 0x0e, 0x4c, 0x7b, 0x07,
-	0x91, 0x1b, 0x37, 0xff, 0x78, 0x3e, 0x6b, 0xfd, 0xf9, 0x7c, 0xd6, 0xfa, 0xfb, 0xf9, 0xac, 0xf5,
-	0xc1, 0xeb, 0x7b, 0xfb, 0x67, 0xcb, 0xc7, 0x1f, 0xcc, 0x99, 0xff, 0xa0, 0x36, 0x72, 0xf8, 0x27,
-	0xd4, 0xd5, 0xff, 0x02, 0x00, 0x00, 0xff, 0xff, 0xbc, 0x0b, 0xd2, 0x3f, 0x69, 0x13, 0x00, 0x00,
+	// 1474 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x58, 0x5f, 0x4f, 0x5c, 0x45,
+	0x14, 0xcf, 0x85, 0x42, 0x61, 0x28, 0xed, 0x32, 0x40, 0x7b, 0xbb, 0xa5, 0x14, 0x6f, 0x2b, 0xa1,
+	0xa4, 0xbd, 0x5b, 0xa8, 0xc6, 0xa6, 0xfe, 0x49, 0xb6, 0x80, 0x2d, 0x29, 0x11, 0xbc, 0x2d, 0x36,
+	0x31, 0x1a, 0x33, 0xdc, 0x3d, 0xec, 0xde, 0x72, 0xb9, 0x77, 0x3a, 0x33, 0xbb, 0xed, 0xda, 0xf4,
+	0xc5, 0x18, 0x63, 0xa2, 0x2f, 0xc6, 0x68, 0x7c, 0xd3, 0x07, 0x13, 0x13, 0x8d, 0xaf, 0x7e, 0x06,
+	0x1f, 0x4d, 0xfc, 0x02, 0xa6, 0xf1, 0x43, 0xf8, 0x68, 0xe6, 0xdc, 0xbf, 0x0b, 0xbb, 0x0b, 0xa4,
+	0x94, 0xb7, 0x3b, 0xbf, 0x73, 0xe6, 0x9c, 0xdf, 0xf9, 0x33, 0x67, 0x66, 0x97, 0x58, 0x12, 0x44,
+	0x03, 0x44, 0x49, 0x00, 0x0f, 0xa5, 0xa7, 0x42, 0xd1, 0xcc, 0x7d, 0xda, 0x5c, 0x84, 0x2a, 0xa4,
+	0x24, 0x43, 0x8a, 0x13, 0xd5, 0x30, 0xac, 0xfa, 0x50, 0x62, 0xdc, 0x2b, 0xb1, 0x20, 0x08, 0x15,
+	0x53, 0x5e, 0x18, 0xc8, 0x48, 0xb3, 0xb8, 0x52, 0xf5, 0x54, 0xad, 0xbe, 0x61, 0xbb, 0xe1, 0x76,
+	0x89, 0x89, 0x6a, 0xc8, 0x45, 0xf8, 0x10, 0x3f, 0xae, 0xba, 0x95, 0x52, 0xe3, 0x7a, 0x89, 0x6f,
+	0x55, 0xf5, 0x4e, 0x59, 0x62, 0x9c, 0xfb, 0x9e, 0x8b, 0x7b, 0x4b, 0x8d, 0x39, 0xe6, 0xf3, 0x1a,
+	0x9b, 0x2b, 0x55, 0x21, 0x00, 0xc1, 0x14, 0x54, 0x62, 0x6b, 0x4b, 0x7b, 0x58, 0x43, 0x5a, 0x7b,
+	0xd2, 0xb7, 0x9a, 0x64, 0xd8, 0x01, 0x1e, 0x96, 0x39, 0x97, 0xef, 0xd7, 0x41, 0x34, 0x29, 0x25,
+	0xc7, 0xb4, 0x92, 0x69, 0x4c, 0x19, 0x33, 0x83, 0x0e, 0x7e, 0xd3, 0x22, 0x19, 0x10, 0xd0, 0xf0,
+	0xa4, 0x17, 0x06, 0x66, 0x0f, 0xe2, 0xe9, 0x9a, 0x9a, 0xe4, 0x38, 0xe3, 0xfc, 0x3d, 0xb6, 0x0d,
+	0x66, 0x2f, 0x8a, 0x92, 0x25, 0x9d, 0x24, 0x84, 0x71, 0xbe, 0x26, 0xc2, 0x87, 0xe0, 0x2a, 0xf3,
+	0x18, 0x0a, 0x73, 0x88, 0x35, 0x47, 0x8e, 0x97, 0x39, 0x5f, 0x0e, 0x36, 0x43, 0xed, 0x54, 0x35,
+	0x39, 0x24, 0x4e, 0xf5, 0xb7, 0xc6, 0x38, 0x53, 0xb5, 0xd8, 0x21, 0x7e, 0x5b, 0xff, 0x19, 0x64,
+	0x34, 0xa6, 0xbb, 0x08, 0x8a, 0x79, 0x7e, 0x4c, 0xba, 0x4a, 0xfa, 0x65, 0x58, 0x17, 0x6e, 0x64,
+	0x61, 0x68, 0x7e, 0xd5, 0xce, 0xb2, 0x63, 0x27, 0xd9, 0xc1, 0x8f, 0x4f, 0xdc, 0x8a, 0xdd, 0xb8,
+	0x6e, 0xf3, 0xad, 0xaa, 0xad, 0x73, 0x6d, 0xe7, 0x72, 0x6d, 0x27, 0xb9, 0xb6, 0xcb, 0x19, 0x78,
+	0x0f, 0xcd, 0x3a, 0xb1, 0xf9, 0x7c, 0xb4, 0x3d, 0xdd, 0xa2, 0xed, 0xdd, 0x19, 0x2d, 0x9d, 0x22,
+	0x43, 0x91, 0x8d, 0xe5, 0xa0, 0x02, 0x4f, 0x30, 0x1d, 0x7d, 0x4e, 0x1e, 0xa2, 0x13, 0x64, 0xb0,
+	0x01, 0x42, 0x27, 0x75, 0xb9, 0x62, 0xf6, 0xa1, 0x3c, 0x03, 0xac, 0xb7, 0x49, 0x21, 0x29, 0x94,
+	0x03, 0x92, 0x87, 0x81, 0x04, 0x7a, 0x99, 0xf4, 0x79, 0x0a, 0xb6, 0xa5, 0x69, 0x4c, 0xf5, 0xce,
+	0x0c, 0xcd, 0x8f, 0xda, 0xb9, 0xf2, 0xc6, 0xa9, 0x75, 0x22, 0x0d, 0xcb, 0x25, 0x83, 0x7a, 0x7b,
+	0xe7, 0x1a, 0x5b, 0xe4, 0xc4, 0x66, 0xa8, 0x43, 0x85, 0x4d, 0x01, 0x32, 0x4a, 0xfb, 0x80, 0xd3,
+	0x82, 0xed, 0x15, 0xa3, 0xf5, 0xfb, 0x00, 0x39, 0x85, 0x24, 0x5d, 0x17, 0x64, 0xf7, 0x7e, 0xaa,
+	0x4b, 0x10, 0x41, 0x96, 0xc6, 0x74, 0xad, 0x65, 0x9c, 0x49, 0xf9, 0x38, 0x14, 0x95, 0xd8, 0x43,
+	0xba, 0xa6, 0x97, 0xc8, 0xb0, 0x94, 0xb5, 0x35, 0xe1, 0x35, 0x98, 0x82, 0xbb, 0xd0, 0x8c, 0x9b,
+	0xaa, 0x15, 0xd4, 0x16, 0xbc, 0x40, 0x82, 0x5b, 0x17, 0x80, 0x69, 0x1c, 0x70, 0xd2, 0x35, 0xbd,
+	0x42, 0x46, 0x94, 0x2f, 0x17, 0x7c, 0x0f, 0x02, 0xb5, 0x00, 0x42, 0x2d, 0x32, 0xc5, 0xcc, 0x7e,
+	0xb4, 0xb2, 0x5b, 0x40, 0x67, 0x49, 0xa1, 0x05, 0xd4, 0x2e, 0x8f, 0xa3, 0xf2, 0x2e, 0x3c, 0x6d,
+	0xe1, 0xc1, 0xd6, 0x16, 0xc6, 0x18, 0x49, 0x84, 0x61, 0x7c, 0x13, 0x64, 0x10, 0x02, 0xb6, 0xe1,
+	0xc3, 0xaa, 0xeb, 0x99, 0x43, 0x48, 0x2f, 0x03, 0xe8, 0x35, 0x32, 0x1a, 0x75, 0x6e, 0x99, 0xf3,
+	0x2c, 0x24, 0xf3, 0x04, 0x1a, 0x68, 0x27, 0xd2, 0x7d, 0x95, 0xc2, 0xcb, 0x8b, 0xe6, 0xf0, 0x94,
+	0x31, 0xd3, 0xeb, 0xe4, 0x21, 0x7a, 0x83, 0x9c, 0xc9, 0x96, 0x81, 0x54, 0xcc, 0xf7, 0xb1, 0xb5,
+	0x97, 0x17, 0xcd, 0x93, 0xa8, 0xdd, 0x49, 0x4c, 0xdf, 0x21, 0xc5, 0x54, 0xb4, 0x14, 0x28, 0x10,
+	0x5c, 0x78, 0x12, 0x6e, 0x31, 0x09, 0xeb, 0xc2, 0x37, 0x4f, 0x21, 0xa9, 0x2e, 0x1a, 0x74, 0x8c,
+	0xf4, 0x71, 0x11, 0x3e, 0x69, 0x9a, 0x05, 0x54, 0x8d, 0x16, 0xfa, 0x0c, 0xf1, 0xb8, 0x85, 0x46,
+	0xa2, 0x33, 0x14, 0x2f, 0xe9, 0x3c, 0x19, 0xab, 0xba, 0xfc, 0x1e, 0x88, 0x86, 0xe7, 0x42, 0xd9,
+	0x75, 0xc3, 0x7a, 0x80, 0x39, 0xa7, 0xa8, 0xd6, 0x56, 0x46, 0x6d, 0x42, 0xb1, 0x47, 0xef, 0x28,
+	0xc5, 0x6f, 0x31, 0xe9, 0xb9, 0xe5, 0xba, 0xaa, 0x99, 0xa3, 0x98, 0xd8, 0x36, 0x12, 0x7a, 0x93,
+	0x98, 0x75, 0x09, 0xe5, 0x4f, 0xeb, 0x02, 0x1e, 0x84, 0x62, 0xcb, 0x0f, 0x59, 0x65, 0xb9, 0x02,
+	0x81, 0xf2, 0x54, 0xd3, 0x1c, 0xc3, 0x5d, 0x1d, 0xe5, 0x3a, 0xd7, 0x1b, 0xc0, 0x04, 0x88, 0xfb,
+	0xe1, 0x16, 0x04, 0xe6, 0x38, 0xd2, 0xca, 0x43, 0x3a, 0x82, 0xa4, 0xd7, 0x56, 0x5d, 0xef, 0xdd,
+	0xc4, 0xbd, 0x79, 0x1a, 0x2d, 0xb7, 0x95, 0xe9, 0x3d, 0xa1, 0x57, 0x71, 0xd1, 0xc0, 0xd2, 0x13,
+	0xb7, 0xc6, 0x82, 0x2a, 0xac, 0x3b, 0x2b, 0xe6, 0x99, 0x28, 0xea, 0x76, 0x32, 0xfa, 0x16, 0x39,
+	0xbb, 0x0b, 0x2f, 0xd7, 0x2b, 0x1e, 0x04, 0x2e, 0x98, 0x26, 0x6e, 0xec, 0xac, 0xa0, 0xe3, 0xa8,
+	0x4b, 0xb8, 0x0b, 0x62, 0x03, 0x44, 0x28, 0xcd, 0xb3, 0x48, 0x2e, 0x0f, 0xe9, 0x1a, 0x05, 0xe1,
+	0x1a, 0xd6, 0xae, 0x18, 0xd5, 0x28, 0x5e, 0xea, 0x33, 0x88, 0x65, 0x5c, 0x4f, 0x0e, 0xf0, 0xb9,
+	0xe8, 0x0c, 0xb6, 0x80, 0xa9, 0xd6, 0x5a, 0x72, 0x94, 0x27, 0x72, 0x5a, 0x09, 0x68, 0x9d, 0x24,
+	0x27, 0xf4, 0xb8, 0x48, 0xe6, 0x99, 0xf5, 0x8b, 0x41, 0x46, 0x34, 0xb0, 0x20, 0x80, 0x29, 0x70,
+	0xe0, 0x51, 0x1d, 0xa4, 0xa2, 0x1f, 0xe5, 0x26, 0xc8, 0xd0, 0xfc, 0x9d, 0x17, 0x1b, 0xed, 0x4e,
+	0x3a, 0x21, 0xe3, 0x59, 0x74, 0x9a, 0xf4, 0xd7, 0xb9, 0x04, 0xa1, 0xe2, 0x89, 0x17, 0xaf, 0xf4,
+	0x39, 0x75, 0x05, 0x54, 0xe4, 0x6a, 0xe0, 0x37, 0x71, 0x10, 0x0d, 0x38, 0x19, 0x60, 0x3d, 0x8a,
+	0x88, 0xae, 0xf3, 0xca, 0x51, 0x11, 0x9d, 0xff, 0xfc, 0x0c, 0x19, 0xc9, 0xc0, 0xf8, 0x20, 0xd0,
+	0xaf, 0x0d, 0x72, 0x6c, 0xc5, 0x93, 0x8a, 0x8e, 0xe7, 0x87, 0x7f, 0x3a, 0xea, 0x8b, 0x2b, 0x87,
+	0xc5, 0x42, 0x3b, 0xb1, 0x2e, 0x7c, 0xf6, 0xf7, 0xbf, 0xdf, 0xf6, 0x9c, 0xa6, 0x63, 0xf8, 0xc4,
+	0x69, 0xcc, 0x65, 0xef, 0x09, 0x0f, 0xe4, 0x97, 0x3d, 0x06, 0xfd, 0xca, 0x20, 0xbd, 0xb7, 0xa1,
+	0x23, 0x9b, 0x43, 0xcb, 0x89, 0x75, 0x11, 0x99, 0x9c, 0xa7, 0xe7, 0xda, 0x31, 0x29, 0x3d, 0xd5,
+	0xab, 0x67, 0xf4, 0x7b, 0x83, 0x0c, 0xdc, 0x06, 0xf5, 0x40, 0x78, 0x0a, 0x5e, 0x3e, 0xa5, 0xcb,
+	0x48, 0xe9, 0x22, 0x7d, 0x25, 0xa1, 0xf4, 0x58, 0xfb, 0xbd, 0xda, 0x8e, 0xd8, 0x77, 0x06, 0x29,
+	0xe8, 0x84, 0x3a, 0x39, 0xd9, 0xd1, 0x54, 0x70, 0xa2, 0x5b, 0x05, 0xe9, 0x4f, 0x06, 0x19, 0xd7,
+	0x6a, 0x98, 0xb1, 0xa3, 0x27, 0x67, 0x21, 0xb9, 0x09, 0x5a, 0xec, 0x9c, 0x41, 0xfa, 0x31, 0x19,
+	0x88, 0x32, 0xb7, 0xd9, 0x91, 0x54, 0xa1, 0x15, 0xde, 0x94, 0xd6, 0x0c, 0x1a, 0xb6, 0xe8, 0x54,
+	0x97, 0x6e, 0x29, 0x09, 0x6d, 0xb2, 0x42, 0x86, 0xb4, 0xf9, 0xd5, 0x85, 0xe5, 0xfb, 0xac, 0x7a,
+	0x00, 0x0f, 0x57, 0xd0, 0xc3, 0x34, 0xbd, 0xd4, 0xcd, 0x43, 0xe8, 0x7a, 0x57, 0x95, 0x36, 0xbb,
+	0x1d, 0x05, 0xa1, 0x1f, 0x73, 0xf4, 0xec, 0x4e, 0x17, 0xe9, 0x5b, 0xbc, 0x38, 0xd1, 0x4e, 0x94,
+	0x4e, 0xcb, 0x7d, 0x05, 0xc5, 0xb4, 0x8b, 0x6f, 0x0c, 0x32, 0x7c, 0x1b, 0x54, 0xf6, 0x6a, 0xa6,
+	0x17, 0xda, 0x58, 0xce, 0xbf, 0xa8, 0x8b, 0x56, 0x67, 0x85, 0x94, 0xc0, 0x9b, 0x48, 0xe0, 0x75,
+	0xeb, 0x5a, 0x7b, 0x02, 0xd1, 0xdb, 0x16, 0xed, 0xac, 0x3b, 0x2b, 0x48, 0xa5, 0x12, 0x59, 0xb8,
+	0x69, 0xcc, 0xd2, 0x06, 0x52, 0xba, 0x03, 0xfe, 0xf6, 0x42, 0x8d, 0x09, 0xd5, 0x31, 0xd5, 0x93,
+	0x79, 0x38, 0x53, 0x4f, 0x49, 0xd8, 0x48, 0x62, 0x86, 0x4e, 0x77, 0xcb, 0x42, 0x0d, 0xfc, 0x6d,
+	0x37, 0x72, 0xf3, 0x83, 0x41, 0xfa, 0xa3, 0xfb, 0x85, 0x9e, 0xdf, 0xe9, 0xb1, 0xe5, 0xde, 0x39,
+	0xc4, 0xc9, 0xf0, 0x6a, 0xd4, 0xd7, 0x56, 0xdb, 0x43, 0x77, 0x13, 0xc7, 0xbb, 0x1e, 0x9e, 0x3f,
+	0x1a, 0xa4, 0x90, 0x50, 0x48, 0xf6, 0x1e, 0x1d, 0x49, 0x6b, 0x6f, 0x92, 0xf4, 0x57, 0x83, 0x8c,
+	0x47, 0xfe, 0x5b, 0x27, 0xc4, 0x11, 0xd2, 0x8c, 0xbb, 0xde, 0xea, 0x32, 0x23, 0x62, 0xb2, 0x3f,
+	0x1b, 0xa4, 0x3f, 0xba, 0xa0, 0x77, 0xb3, 0x6b, 0xb9, 0xb8, 0x0f, 0x91, 0xdd, 0x5c, 0xd4, 0x8d,
+	0xc5, 0x2e, 0x67, 0x12, 0xa9, 0x3c, 0xcb, 0xaa, 0xfe, 0x9b, 0x41, 0x0a, 0x09, 0x9d, 0xce, 0xe9,
+	0x7c, 0x59, 0x84, 0xed, 0x83, 0x11, 0xa6, 0x7f, 0x18, 0x64, 0x3c, 0xe2, 0xb2, 0x67, 0x07, 0xbc,
+	0x2c, 0xca, 0xaf, 0x21, 0x65, 0xbb, 0x38, 0xbd, 0xd7, 0x3d, 0xdb, 0x42, 0x9c, 0x91, 0xfe, 0x45,
+	0xf0, 0xa1, 0xf3, 0x43, 0xc0, 0xdc, 0x09, 0xa7, 0x23, 0x66, 0x3a, 0x7a, 0x6b, 0xcc, 0x76, 0x7b,
+	0x6b, 0xe8, 0x4a, 0xd6, 0x48, 0x21, 0x72, 0x91, 0xcb, 0xca, 0x81, 0x9d, 0x5d, 0xdc, 0x87, 0x33,
+	0x2a, 0xc9, 0x78, 0xe4, 0x69, 0x67, 0x11, 0x0e, 0xec, 0x2e, 0x7e, 0xb4, 0xcc, 0xee, 0xe3, 0xd1,
+	0xf2, 0x94, 0x9c, 0xfc, 0x80, 0xf9, 0x9e, 0x2e, 0x6a, 0xf4, 0x03, 0x9f, 0x9e, 0xdb, 0x75, 0x49,
+	0x64, 0x3f, 0xfc, 0xbb, 0xf8, 0x9c, 0x47, 0x9f, 0x57, 0xac, 0xae, 0x77, 0x65, 0x23, 0x76, 0x15,
+	0x97, 0xef, 0x0b, 0x83, 0x8c, 0x26, 0xde, 0x31, 0xe8, 0x17, 0xa3, 0x70, 0x03, 0x29, 0xcc, 0x5b,
+	0xb3, 0x7b, 0x86, 0xbd, 0x83, 0xc8, 0xad, 0xa5, 0x3f, 0x9f, 0x4f, 0x1a, 0x7f, 0x3d, 0x9f, 0x34,
+	0xfe, 0x79, 0x3e, 0x69, 0x7c, 0xf8, 0xc6, 0xfe, 0xfe, 0xd3, 0x73, 0xf1, 0xaf, 0x82, 0x2c, 0xce,
+	0xe6, 0x46, 0x3f, 0xfe, 0xfd, 0x76, 0xfd, 0xff, 0x01, 0x00, 0x70, 0x71, 0x74, 0x06, 0x63, 0x14,
+	0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -1964,6 +2024,63 @@ func (m *RepoAccessQuery) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if len(m.ProxyPassword) > 0 {
+		i -= len(m.ProxyPassword)
+		copy(dAtA[i:], m.ProxyPassword)
+		i = encodeVarintRepository(dAtA, i, uint64(len(m.ProxyPassword)))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xe2
+	}
+	if len(m.ProxyUsername) > 0 {
+		i -= len(m.ProxyUsername)
+		copy(dAtA[i:], m.ProxyUsername)
+		i = encodeVarintRepository(dAtA, i, uint64(len(m.ProxyUsername)))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xda
+	}
+	if len(m.NoProxy) > 0 {
+		i -= len(m.NoProxy)
+		copy(dAtA[i:], m.NoProxy)
+		i = encodeVarintRepository(dAtA, i, uint64(len(m.NoProxy)))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xd2
+	}
+	if m.UseKerberos {
+		i--
+		if m.UseKerberos {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xc8
+	}
+	if len(m.OidcTokenExchangeAudience) > 0 {
+		i -= len(m.OidcTokenExchangeAudience)
+		copy(dAtA[i:], m.OidcTokenExchangeAudience)
+		i = encodeVarintRepository(dAtA, i, uint64(len(m.OidcTokenExchangeAudience)))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xc2
+	}
+	if len(m.OidcTokenExchangeURL) > 0 {
+		i -= len(m.OidcTokenExchangeURL)
+		copy(dAtA[i:], m.OidcTokenExchangeURL)
+		i = encodeVarintRepository(dAtA, i, uint64(len(m.OidcTokenExchangeURL)))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xba
+	}
 	if m.InsecureOciForceHttp {
 		i--
 		if m.InsecureOciForceHttp {
@@ -2477,6 +2594,29 @@ func (m *RepoAccessQuery) Size() (n int) {
 	if m.InsecureOciForceHttp {
 		n += 3
 	}
+	l = len(m.OidcTokenExchangeURL)
+	if l > 0 {
+		n += 2 + l + sovRepository(uint64(l))
+	}
+	l = len(m.OidcTokenExchangeAudience)
+	if l > 0 {
+		n += 2 + l + sovRepository(uint64(l))
+	}
+	if m.UseKerberos {
+		n += 3
+	}
+	l = len(m.NoProxy)
+	if l > 0 {
+		n += 2 + l + sovRepository(uint64(l))
+	}
+	l = len(m.ProxyUsername)
+	if l > 0 {
+		n += 2 + l + sovRepository(uint64(l))
+	}
+	l = len(m.ProxyPassword)
+	if l > 0 {
+		n += 2 + l + sovRepository(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -3857,6 +3997,186 @@ func (m *RepoAccessQuery) Unmarshal(dAtA []byte) error {
 				}
 			}
 			m.InsecureOciForceHttp = bool(v != 0)
+		case 23:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OidcTokenExchangeURL", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRepository
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRepository
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRepository
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.OidcTokenExchangeURL = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 24:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OidcTokenExchangeAudience", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRepository
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRepository
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRepository
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.OidcTokenExchangeAudience = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 25:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UseKerberos", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRepository
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.UseKerberos = bool(v != 0)
+		case 26:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NoProxy", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRepository
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRepository
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRepository
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NoProxy = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 27:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProxyUsername", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRepository
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRepository
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRepository
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ProxyUsername = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 28:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProxyPassword", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRepository
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRepository
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRepository
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ProxyPassword = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRepository(dAtA[iNdEx:])
@@ -2,6 +2,7 @@ package v1alpha1
 
 import (
 	"fmt"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -24,6 +25,9 @@ const (
 	serviceAccountDisallowedCharSet = "!*[]{}\\/"
 )
 
+// projectHookTypes are the types of the resource hooks which can be denied by a project
+var projectHookTypes = []string{"PreSync", "Sync", "PostSync", "SyncFail", "PostDelete", "Skip"}
+
 type ErrApplicationNotAllowedToUseProject struct {
 	application string
 	namespace   string
@@ -208,6 +212,20 @@ func (proj *AppProject) ValidateProject() error {
 		srcNamespaces[ns] = true
 	}
 
+	srcNamespacePatterns := make(map[string]bool)
+	for _, rule := range proj.Spec.SourceNamespaceRules {
+		if _, err := glob.MatchWithError(rule.Pattern, ""); err != nil || rule.Pattern == "" {
+			return status.Errorf(codes.InvalidArgument, "source namespace rule pattern '%s' is invalid", rule.Pattern)
+		}
+		if _, ok := srcNamespacePatterns[rule.Pattern]; ok {
+			return status.Errorf(codes.InvalidArgument, "source namespace rule '%s' already added", rule.Pattern)
+		}
+		if rule.MaxApps < 0 {
+			return status.Errorf(codes.InvalidArgument, "source namespace rule '%s' has a negative maximum number of applications", rule.Pattern)
+		}
+		srcNamespacePatterns[rule.Pattern] = true
+	}
+
 	srcRepos := make(map[string]bool)
 	for _, src := range proj.Spec.SourceRepos {
 		if src == "!*" {
@@ -303,6 +321,83 @@ func (proj *AppProject) ValidateProject() error {
 		destServiceAccts[key] = true
 	}
 
+	manifestPolicies := make(map[string]bool)
+	for _, policy := range proj.Spec.ManifestPolicies {
+		if err := policy.Validate(); err != nil {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+		if _, ok := manifestPolicies[policy.Name]; ok {
+			return status.Errorf(codes.AlreadyExists, "manifest policy '%s' already exists", policy.Name)
+		}
+		manifestPolicies[policy.Name] = true
+	}
+
+	imageRegistries := make(map[string]bool)
+	for _, registry := range proj.Spec.AllowedImageRegistries {
+		if registry == "" || strings.ContainsAny(registry, " \t\n") {
+			return status.Errorf(codes.InvalidArgument, "image registry has an invalid format, '%s'", registry)
+		}
+		if _, ok := imageRegistries[registry]; ok {
+			return status.Errorf(codes.AlreadyExists, "image registry '%s' already exists", registry)
+		}
+		imageRegistries[registry] = true
+	}
+
+	for _, policy := range proj.Spec.ProvenancePolicies {
+		if err := policy.Validate(); err != nil {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+		for _, pattern := range []string{policy.RepoURL, policy.BuilderID, policy.SourceRepository} {
+			if _, err := globutil.Compile(pattern); err != nil {
+				return status.Errorf(codes.InvalidArgument, "provenance policy of '%s' has an invalid pattern, '%s'", policy.RepoURL, pattern)
+			}
+		}
+	}
+
+	deniedSyncOptions := make(map[string]bool)
+	for _, option := range proj.Spec.DeniedSyncOptions {
+		if key, value, ok := strings.Cut(option, "="); !ok || key == "" || value == "" || strings.ContainsAny(option, " ,") {
+			return status.Errorf(codes.InvalidArgument, "denied sync option has an invalid format, '%s', must be <option>=<value>", option)
+		}
+		if _, ok := deniedSyncOptions[option]; ok {
+			return status.Errorf(codes.AlreadyExists, "denied sync option '%s' already exists", option)
+		}
+		deniedSyncOptions[option] = true
+	}
+
+	deniedHookTypes := make(map[string]bool)
+	for _, hookType := range proj.Spec.DeniedHookTypes {
+		if !slices.Contains(projectHookTypes, hookType) {
+			return status.Errorf(codes.InvalidArgument, "denied hook type '%s' is invalid, must be one of %s", hookType, strings.Join(projectHookTypes, ", "))
+		}
+		if _, ok := deniedHookTypes[hookType]; ok {
+			return status.Errorf(codes.AlreadyExists, "denied hook type '%s' already exists", hookType)
+		}
+		deniedHookTypes[hookType] = true
+	}
+
+	protectedClusters := make(map[string]bool)
+	for _, pattern := range proj.Spec.ProtectedClusters {
+		if _, err := globutil.Compile(pattern); err != nil {
+			return status.Errorf(codes.InvalidArgument, "protected cluster has an invalid pattern, '%s'", pattern)
+		}
+		if _, ok := protectedClusters[pattern]; ok {
+			return status.Errorf(codes.AlreadyExists, "protected cluster '%s' already exists", pattern)
+		}
+		protectedClusters[pattern] = true
+	}
+
+	applicationRefProjects := make(map[string]bool)
+	for _, pattern := range proj.Spec.ApplicationRefProjects {
+		if _, err := globutil.Compile(pattern); err != nil {
+			return status.Errorf(codes.InvalidArgument, "application ref project has an invalid pattern, '%s'", pattern)
+		}
+		if _, ok := applicationRefProjects[pattern]; ok {
+			return status.Errorf(codes.AlreadyExists, "application ref project '%s' already exists", pattern)
+		}
+		applicationRefProjects[pattern] = true
+	}
+
 	return nil
 }
 
@@ -459,6 +554,183 @@ func (proj AppProject) IsSourcePermitted(src ApplicationSource) bool {
 	return anySourceMatched
 }
 
+// IsImagePermitted validates if the provided container image belongs to one of the allowed image registries of the
+// project. All images are permitted if the project has no allowed image registries.
+func (proj AppProject) IsImagePermitted(image string) bool {
+	if len(proj.Spec.AllowedImageRegistries) == 0 {
+		return true
+	}
+	image = normalizeImageReference(image)
+	for _, registry := range proj.Spec.AllowedImageRegistries {
+		prefix := strings.TrimSuffix(registry, "/")
+		if image == prefix || strings.HasPrefix(image, prefix+"/") || strings.HasPrefix(image, prefix+":") || strings.HasPrefix(image, prefix+"@") {
+			return true
+		}
+	}
+	return false
+}
+
+// IsSyncOptionPermitted validates if the applications of the project are allowed to sync with the given sync option
+func (proj AppProject) IsSyncOptionPermitted(option string) bool {
+	return !slices.Contains(proj.Spec.DeniedSyncOptions, strings.TrimSpace(option))
+}
+
+// IsHookTypePermitted validates if the applications of the project are allowed to run resource hooks of the given type
+func (proj AppProject) IsHookTypePermitted(hookType string) bool {
+	return !slices.Contains(proj.Spec.DeniedHookTypes, hookType)
+}
+
+// IsProtectedCluster validates if the cluster matches one of the protected clusters of the project, either by its
+// server URL or by its name
+func (proj AppProject) IsProtectedCluster(cluster *Cluster) bool {
+	for _, pattern := range proj.Spec.ProtectedClusters {
+		if globMatch(pattern, cluster.Server, false) || (cluster.Name != "" && globMatch(pattern, cluster.Name, false)) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsApplicationRefPermitted returns true if the applications of the given project may use the outputs of the
+// applications of the project
+func (proj AppProject) IsApplicationRefPermitted(project string) bool {
+	if project == proj.Name {
+		return true
+	}
+	for _, pattern := range proj.Spec.ApplicationRefProjects {
+		if globMatch(pattern, project, false) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetProvenancePolicies returns the provenance policies of the project which apply to the given OCI repository
+func (proj AppProject) GetProvenancePolicies(repoURL string) []ProvenancePolicy {
+	var policies []ProvenancePolicy
+	for _, policy := range proj.Spec.ProvenancePolicies {
+		if globMatch(policy.RepoURL, repoURL, false, '/') {
+			policies = append(policies, policy)
+		}
+	}
+	return policies
+}
+
+// GetApplicationSyncPolicy returns the sync policy of an application of the project with the defaults of the project:
+// the default sync policy when the application has none, and the default sync options the application doesn't set to
+// another value
+func (proj AppProject) GetApplicationSyncPolicy(policy *SyncPolicy) *SyncPolicy {
+	defaults := proj.Spec.ApplicationDefaults
+	if defaults == nil {
+		return policy
+	}
+	if policy == nil {
+		policy = defaults.SyncPolicy
+	}
+	if len(defaults.SyncOptions) == 0 {
+		return policy
+	}
+	if policy == nil {
+		policy = &SyncPolicy{}
+	} else {
+		policy = policy.DeepCopy()
+	}
+	for _, option := range defaults.SyncOptions {
+		name := syncOptionName(option)
+		if !slices.ContainsFunc(policy.SyncOptions, func(o string) bool { return syncOptionName(o) == name }) {
+			policy.SyncOptions = append(policy.SyncOptions, option)
+		}
+	}
+	return policy
+}
+
+// syncOptionName returns the name of a sync option, e.g. CreateNamespace for CreateNamespace=true
+func syncOptionName(option string) string {
+	name, _, _ := strings.Cut(option, "=")
+	return strings.TrimSpace(name)
+}
+
+// GetApplicationSource returns a source of an application of the project with the defaults of the project. The default
+// value files and Helm version only apply to the Helm sources, i.e. the charts and the sources with Helm parameters, and
+// the default components and Kustomize version only apply to the sources with Kustomize parameters.
+func (proj AppProject) GetApplicationSource(source ApplicationSource) ApplicationSource {
+	defaults := proj.Spec.ApplicationDefaults
+	if defaults == nil {
+		return source
+	}
+	if source.IsHelm() || source.Helm != nil {
+		setValueFiles := len(defaults.HelmValueFiles) > 0 && (source.Helm == nil || len(source.Helm.ValueFiles) == 0)
+		setVersion := defaults.HelmVersion != "" && (source.Helm == nil || source.Helm.Version == "")
+		if setValueFiles || setVersion {
+			if source.Helm == nil {
+				source.Helm = &ApplicationSourceHelm{}
+			} else {
+				source.Helm = source.Helm.DeepCopy()
+			}
+		}
+		if setValueFiles {
+			source.Helm.ValueFiles = slices.Clone(defaults.HelmValueFiles)
+		}
+		if setVersion {
+			source.Helm.Version = defaults.HelmVersion
+		}
+	}
+	if source.Kustomize != nil {
+		setComponents := len(defaults.KustomizeComponents) > 0 && len(source.Kustomize.Components) == 0
+		setVersion := defaults.KustomizeVersion != "" && source.Kustomize.Version == ""
+		if setComponents || setVersion {
+			source.Kustomize = source.Kustomize.DeepCopy()
+		}
+		if setComponents {
+			source.Kustomize.Components = slices.Clone(defaults.KustomizeComponents)
+		}
+		if setVersion {
+			source.Kustomize.Version = defaults.KustomizeVersion
+		}
+	}
+	return source
+}
+
+// IsApplicationResourceExcluded returns whether the resources of the API group and kind in the cluster are excluded
+// from an application of the project by the resource exclusions of the project and the application, or included by the
+// resource inclusions of the project. The exclusions take precedence over the inclusions, so that the applications can't
+// include the resources excluded by their project. The second value is false if neither the application nor the project
+// exclude or include the resources, in which case the resource.exclusions and resource.inclusions settings apply.
+func (proj AppProject) IsApplicationResourceExcluded(spec *ApplicationSpec, group, kind, cluster string) (bool, bool) {
+	match := func(rule ResourceFilterRule) bool {
+		return rule.Match(group, kind, cluster)
+	}
+	if slices.ContainsFunc(proj.Spec.ResourceExclusions, match) || slices.ContainsFunc(spec.ResourceExclusions, match) {
+		return true, true
+	}
+	if slices.ContainsFunc(proj.Spec.ResourceInclusions, match) {
+		return false, true
+	}
+	return false, false
+}
+
+// Match returns whether the rule matches the resources of the API group and kind in the cluster
+func (r ResourceFilterRule) Match(group, kind, cluster string) bool {
+	return (len(r.APIGroups) == 0 || glob.MatchStringInList(r.APIGroups, group, glob.GLOB)) &&
+		(len(r.Kinds) == 0 || slices.Contains(r.Kinds, "*") || slices.Contains(r.Kinds, kind)) &&
+		(len(r.Clusters) == 0 || glob.MatchStringInList(r.Clusters, cluster, glob.GLOB))
+}
+
+// normalizeImageReference returns the given image reference with its registry, e.g. docker.io/library/nginx for nginx
+func normalizeImageReference(image string) string {
+	domain, remainder, found := strings.Cut(image, "/")
+	if !found || (domain != "localhost" && !strings.ContainsAny(domain, ".:")) {
+		domain, remainder = "docker.io", image
+	}
+	if domain == "index.docker.io" {
+		domain = "docker.io"
+	}
+	if domain == "docker.io" && !strings.Contains(remainder, "/") {
+		remainder = "library/" + remainder
+	}
+	return domain + "/" + remainder
+}
+
 // IsDestinationPermitted validates if the provided application's destination is one of the allowed destinations for the project
 func (proj AppProject) IsDestinationPermitted(destCluster *Cluster, destNamespace string, projectClusters func(project string) ([]*Cluster, error)) (bool, error) {
 	if destCluster == nil {
@@ -606,5 +878,22 @@ func (proj AppProject) IsAppNamespacePermitted(app *Application, controllerNs st
 		return true
 	}
 
-	return glob.MatchStringInList(proj.Spec.SourceNamespaces, app.Namespace, glob.REGEXP)
+	return glob.MatchStringInList(proj.Spec.SourceNamespaces, app.Namespace, glob.REGEXP) || proj.GetSourceNamespaceRule(app.Namespace) != nil
+}
+
+// GetSourceNamespaceRule returns the most specific source namespace rule of the project matching a namespace, i.e. the
+// matching rule whose pattern has the most characters besides the wildcards, or nil if no rule matches
+func (proj AppProject) GetSourceNamespaceRule(namespace string) *SourceNamespaceRule {
+	var rule *SourceNamespaceRule
+	specificity := -1
+	for i, r := range proj.Spec.SourceNamespaceRules {
+		if !glob.Match(r.Pattern, namespace) {
+			continue
+		}
+		if s := len(r.Pattern) - strings.Count(r.Pattern, "*") - strings.Count(r.Pattern, "?"); s > specificity {
+			rule = &proj.Spec.SourceNamespaceRules[i]
+			specificity = s
+		}
+	}
+	return rule
 }
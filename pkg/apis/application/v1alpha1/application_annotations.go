@@ -6,6 +6,12 @@ const (
 	AnnotationKeyRefresh string = "argocd.argoproj.io/refresh"
 	// AnnotationKeyHydrate is the annotation key which indicates that app needs to be hydrated. Removed by application controller after app is hydrated.
 	AnnotationKeyHydrate string = "argocd.argoproj.io/hydrate"
+	// AnnotationKeyPinnedRevisions is the annotation key which contains the revisions the sources of the app are pinned to, by source position. Managed by the API server.
+	AnnotationKeyPinnedRevisions string = "argocd.argoproj.io/pinned-revisions"
+	// AnnotationKeyFreeze is the annotation key which contains the freeze of the app. Managed by the API server and removed by the application controller once the freeze expires.
+	AnnotationKeyFreeze string = "argocd.argoproj.io/freeze"
+	// AnnotationKeyCascadeWave is the annotation key of a child app which contains the wave in which the cascade operations of its parent app are performed on it. Defaults to 0.
+	AnnotationKeyCascadeWave string = "argocd.argoproj.io/cascade-wave"
 
 	// AnnotationKeyManifestGeneratePaths is an annotation that contains a list of semicolon-separated paths in the
 	// manifests repository that affects the manifest generation. Paths might be either relative or absolute. The
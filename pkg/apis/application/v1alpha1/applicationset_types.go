@@ -73,6 +73,29 @@ type ApplicationSetSpec struct {
 	ApplyNestedSelectors         bool                            `json:"applyNestedSelectors,omitempty" protobuf:"bytes,8,name=applyNestedSelectors"`
 	IgnoreApplicationDifferences ApplicationSetIgnoreDifferences `json:"ignoreApplicationDifferences,omitempty" protobuf:"bytes,9,name=ignoreApplicationDifferences"`
 	TemplatePatch                *string                         `json:"templatePatch,omitempty" protobuf:"bytes,10,name=templatePatch"`
+	// MetadataPropagation controls which labels and annotations of the ApplicationSet are propagated to the generated
+	// Applications, and how the labels and annotations of the existing Applications are updated
+	MetadataPropagation *ApplicationSetMetadataPropagation `json:"metadataPropagation,omitempty" protobuf:"bytes,11,opt,name=metadataPropagation"`
+	// ParamTransforms are the transformations applied in order to the parameters generated for each Application,
+	// before the template is rendered
+	ParamTransforms []ApplicationSetParamTransform `json:"paramTransforms,omitempty" protobuf:"bytes,12,rep,name=paramTransforms"`
+}
+
+// ApplicationSetParamTransform sets a parameter, either renamed from another parameter or computed from a template
+// rendered with the parameters
+type ApplicationSetParamTransform struct {
+	// Key is the parameter set by the transformation. With Go templates, dots separate the keys of nested parameters.
+	Key string `json:"key" protobuf:"bytes,1,opt,name=key"`
+	// From is the parameter renamed to Key
+	From string `json:"from,omitempty" protobuf:"bytes,2,opt,name=from"`
+	// Value is the template of the value, rendered with the parameters and the template functions of the ApplicationSet
+	Value string `json:"value,omitempty" protobuf:"bytes,3,opt,name=value"`
+	// Map replaces the value with the mapped value, when it has one
+	Map map[string]string `json:"map,omitempty" protobuf:"bytes,4,rep,name=map"`
+	// Type is the type the value is converted to. Possible values are string, int, float, bool and json.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=string;int;float;bool;json
+	Type string `json:"type,omitempty" protobuf:"bytes,5,opt,name=type"`
 }
 
 type ApplicationPreservedFields struct {
@@ -80,6 +103,56 @@ type ApplicationPreservedFields struct {
 	Labels      []string `json:"labels,omitempty" protobuf:"bytes,2,name=labels"`
 }
 
+// MetadataPropagationStrategy is the strategy updating the labels or the annotations of the existing generated
+// Applications
+// "replace" means the labels or annotations of the Applications are replaced with the generated ones, except the preserved ones
+// "merge" means the generated labels or annotations are merged into the ones of the Applications, only pruning the ones previously generated
+// "ignore" means the labels or annotations are only set when the Applications are created
+type MetadataPropagationStrategy string
+
+const (
+	MetadataPropagationStrategyReplace MetadataPropagationStrategy = "replace"
+	MetadataPropagationStrategyMerge   MetadataPropagationStrategy = "merge"
+	MetadataPropagationStrategyIgnore  MetadataPropagationStrategy = "ignore"
+)
+
+// ApplicationSetMetadataPropagation configures the propagation of the labels and the annotations to the generated
+// Applications
+type ApplicationSetMetadataPropagation struct {
+	// Labels is the propagation policy of the labels
+	Labels *MetadataPropagationPolicy `json:"labels,omitempty" protobuf:"bytes,1,opt,name=labels"`
+	// Annotations is the propagation policy of the annotations
+	Annotations *MetadataPropagationPolicy `json:"annotations,omitempty" protobuf:"bytes,2,opt,name=annotations"`
+}
+
+// MetadataPropagationPolicy configures how the labels or the annotations of the generated Applications are managed
+type MetadataPropagationPolicy struct {
+	// Strategy is the strategy updating the labels or annotations of the existing Applications. Possible values are
+	// replace, merge and ignore. Defaults to replace.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=replace;merge;ignore
+	Strategy MetadataPropagationStrategy `json:"strategy,omitempty" protobuf:"bytes,1,opt,name=strategy,casttype=MetadataPropagationStrategy"`
+	// Propagate are the keys of the labels or annotations of the ApplicationSet propagated to the generated
+	// Applications, unless the template sets them. Glob patterns are supported.
+	Propagate []string `json:"propagate,omitempty" protobuf:"bytes,2,rep,name=propagate"`
+}
+
+// GetStrategy returns the strategy of the policy, replace by default
+func (p *MetadataPropagationPolicy) GetStrategy() MetadataPropagationStrategy {
+	if p == nil || p.Strategy == "" {
+		return MetadataPropagationStrategyReplace
+	}
+	return p.Strategy
+}
+
+// GetPropagate returns the keys of the propagated labels or annotations
+func (p *MetadataPropagationPolicy) GetPropagate() []string {
+	if p == nil {
+		return nil
+	}
+	return p.Propagate
+}
+
 // ApplicationSetStrategy configures how generated Applications are updated in sequence.
 type ApplicationSetStrategy struct {
 	Type        string                         `json:"type,omitempty" protobuf:"bytes,1,opt,name=type"`
@@ -159,8 +232,21 @@ type ApplicationSetResourceIgnoreDifferences struct {
 	JSONPointers []string `json:"jsonPointers,omitempty" protobuf:"bytes,2,name=jsonPointers"`
 	// JQPathExpressions is a list of JQ path expressions to fields to ignore differences for.
 	JQPathExpressions []string `json:"jqPathExpressions,omitempty" protobuf:"bytes,3,name=jqExpressions"`
+	// Preserve controls when the differences are ignored. Possible values are Always, the default, and UserModified,
+	// which only ignores the differences while the fields of the live application differ from the values last
+	// generated by the ApplicationSet, i.e. while they are modified by a user.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Always;UserModified
+	Preserve string `json:"preserve,omitempty" protobuf:"bytes,4,opt,name=preserve"`
 }
 
+const (
+	// IgnoreDifferencesPreserveAlways always ignores the differences of the fields
+	IgnoreDifferencesPreserveAlways = "Always"
+	// IgnoreDifferencesPreserveUserModified ignores the differences of the fields modified by a user
+	IgnoreDifferencesPreserveUserModified = "UserModified"
+)
+
 func (a *ApplicationSetResourceIgnoreDifferences) ToApplicationResourceIgnoreDifferences() ResourceIgnoreDifferences {
 	return ResourceIgnoreDifferences{
 		Kind:              ApplicationSchemaGroupVersionKind.Kind,
@@ -385,6 +471,22 @@ type ClusterGenerator struct {
 
 	// returns the clusters a single 'clusters' value in the template
 	FlatList bool `json:"flatList,omitempty" protobuf:"bytes,4,name=flatList"`
+
+	// Selectors defines label selector groups ORed together, along with Selector when it isn't empty, to match
+	// against all clusters registered with ArgoCD. The clusters matching any of the groups are targeted.
+	Selectors []metav1.LabelSelector `json:"selectors,omitempty" protobuf:"bytes,5,rep,name=selectors"`
+}
+
+// GetSelectors returns the label selector groups matching the targeted clusters, ORed together
+func (g *ClusterGenerator) GetSelectors() []metav1.LabelSelector {
+	if len(g.Selectors) == 0 {
+		return []metav1.LabelSelector{g.Selector}
+	}
+	selectors := g.Selectors
+	if len(g.Selector.MatchLabels) > 0 || len(g.Selector.MatchExpressions) > 0 {
+		selectors = append([]metav1.LabelSelector{g.Selector}, selectors...)
+	}
+	return selectors
 }
 
 // DuckType defines a generator to match against clusters registered with ArgoCD.
@@ -708,6 +810,12 @@ type PullRequestGeneratorGitLab struct {
 	Insecure bool `json:"insecure,omitempty" protobuf:"varint,6,opt,name=insecure"`
 	// ConfigMap key holding the trusted certificates
 	CARef *ConfigMapKeyRef `json:"caRef,omitempty" protobuf:"bytes,7,opt,name=caRef"`
+	// PipelineStatuses is an additional MRs filter to get only those whose last pipeline has one of the statuses,
+	// e.g. success, running or failed. Default: all MRs, with or without pipeline.
+	PipelineStatuses []string `json:"pipelineStatuses,omitempty" protobuf:"bytes,8,rep,name=pipelineStatuses"`
+	// Approved is an additional MRs filter to get only the approved MRs when true, or the not approved MRs when false.
+	// Default: all MRs.
+	Approved *bool `json:"approved,omitempty" protobuf:"varint,9,opt,name=approved"`
 }
 
 // PullRequestGeneratorBitbucketServer defines connection info specific to BitbucketServer.
@@ -0,0 +1,94 @@
+package v1alpha1
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/argoproj/argo-cd/v3/util/glob"
+)
+
+// ApplicationBlueprint is a parameterized application spec offered by the blueprint catalog, from which users create
+// applications in the allowed projects by only providing the values of the parameters
+type ApplicationBlueprint struct {
+	// Name is the name of the blueprint
+	Name string `json:"name" protobuf:"bytes,1,opt,name=name"`
+	// Description is the description of the blueprint
+	Description string `json:"description,omitempty" protobuf:"bytes,2,opt,name=description"`
+	// Projects are the glob patterns of the projects in which applications can be created from the blueprint
+	Projects []string `json:"projects" protobuf:"bytes,3,rep,name=projects"`
+	// Parameters are the parameters of the blueprint
+	Parameters []ApplicationBlueprintParameter `json:"parameters,omitempty" protobuf:"bytes,4,rep,name=parameters"`
+	// Spec is the spec of the applications created from the blueprint. Its string fields can reference the values of
+	// the parameters with {{parameter}}, and the name, namespace and project of the application with {{app.name}},
+	// {{app.namespace}} and {{app.project}}.
+	Spec ApplicationSpec `json:"spec" protobuf:"bytes,5,opt,name=spec"`
+}
+
+// ApplicationBlueprintParameter is a parameter of an application blueprint
+type ApplicationBlueprintParameter struct {
+	// Name is the name of the parameter
+	Name string `json:"name" protobuf:"bytes,1,opt,name=name"`
+	// Description is the description of the parameter
+	Description string `json:"description,omitempty" protobuf:"bytes,2,opt,name=description"`
+	// Default is the value of the parameter when it is not set
+	Default string `json:"default,omitempty" protobuf:"bytes,3,opt,name=default"`
+	// Pattern is a regular expression which the whole value of the parameter must match
+	Pattern string `json:"pattern,omitempty" protobuf:"bytes,4,opt,name=pattern"`
+	// Required is true if the parameter must be set when it has no default value
+	Required bool `json:"required,omitempty" protobuf:"varint,5,opt,name=required"`
+}
+
+// ApplicationBlueprintList is a list of application blueprints
+type ApplicationBlueprintList struct {
+	Items []ApplicationBlueprint `json:"items" protobuf:"bytes,1,rep,name=items"`
+}
+
+// IsProjectAllowed returns whether applications can be created from the blueprint in the given project
+func (b *ApplicationBlueprint) IsProjectAllowed(project string) bool {
+	return glob.MatchStringInList(b.Projects, project, glob.GLOB)
+}
+
+// ResolveParameters returns the values of the parameters of the blueprint from the given values, with the default
+// values of the parameters which are not set. It fails if a value is set for an unknown parameter, if a required
+// parameter is not set, or if a value does not match the pattern of its parameter.
+func (b *ApplicationBlueprint) ResolveParameters(values map[string]string) (map[string]string, error) {
+	known := make(map[string]bool)
+	for _, param := range b.Parameters {
+		known[param.Name] = true
+	}
+	for name := range values {
+		if !known[name] {
+			return nil, fmt.Errorf("unknown parameter %q of blueprint %q", name, b.Name)
+		}
+	}
+
+	resolved := make(map[string]string)
+	var errs []error
+	for _, param := range b.Parameters {
+		value, ok := values[param.Name]
+		if !ok {
+			if param.Required && param.Default == "" {
+				errs = append(errs, fmt.Errorf("parameter %q is required", param.Name))
+				continue
+			}
+			value = param.Default
+		}
+		if param.Pattern != "" {
+			pattern, err := regexp.Compile("^(?:" + param.Pattern + ")$")
+			if err != nil {
+				errs = append(errs, fmt.Errorf("invalid pattern of parameter %q: %w", param.Name, err))
+				continue
+			}
+			if !pattern.MatchString(value) {
+				errs = append(errs, fmt.Errorf("value %q of parameter %q does not match %q", value, param.Name, param.Pattern))
+				continue
+			}
+		}
+		resolved[param.Name] = value
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return resolved, nil
+}
@@ -0,0 +1,44 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplicationBlueprint_IsProjectAllowed(t *testing.T) {
+	blueprint := ApplicationBlueprint{Name: "web-service", Projects: []string{"default", "team-*"}}
+	assert.True(t, blueprint.IsProjectAllowed("default"))
+	assert.True(t, blueprint.IsProjectAllowed("team-a"))
+	assert.False(t, blueprint.IsProjectAllowed("platform"))
+	assert.False(t, (&ApplicationBlueprint{Name: "web-service"}).IsProjectAllowed("default"))
+}
+
+func TestApplicationBlueprint_ResolveParameters(t *testing.T) {
+	blueprint := ApplicationBlueprint{
+		Name: "web-service",
+		Parameters: []ApplicationBlueprintParameter{
+			{Name: "image", Required: true},
+			{Name: "replicas", Default: "1", Pattern: "[0-9]+"},
+			{Name: "team"},
+		},
+	}
+
+	t.Run("values and defaults", func(t *testing.T) {
+		resolved, err := blueprint.ResolveParameters(map[string]string{"image": "nginx"})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"image": "nginx", "replicas": "1", "team": ""}, resolved)
+	})
+
+	t.Run("unknown parameter", func(t *testing.T) {
+		_, err := blueprint.ResolveParameters(map[string]string{"image": "nginx", "port": "80"})
+		require.EqualError(t, err, `unknown parameter "port" of blueprint "web-service"`)
+	})
+
+	t.Run("required parameter and pattern", func(t *testing.T) {
+		_, err := blueprint.ResolveParameters(map[string]string{"replicas": "3x"})
+		require.ErrorContains(t, err, `parameter "image" is required`)
+		require.ErrorContains(t, err, `value "3x" of parameter "replicas" does not match "[0-9]+"`)
+	})
+}
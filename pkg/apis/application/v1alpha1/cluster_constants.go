@@ -33,6 +33,10 @@ const (
 
 	// EnvK8sTCPIdleConnTimeout is the duration when idle TCP connection to the K8s API servers should timeout
 	EnvK8sTCPIdleConnTimeout = "ARGOCD_K8S_TCP_IDLE_TIMEOUT"
+
+	// EnvK8sExecProviderAllowedCommands is the comma separated list of the commands, or of glob patterns of the commands,
+	// which can be run by the exec providers of the clusters (default: every command is allowed)
+	EnvK8sExecProviderAllowedCommands = "ARGOCD_K8S_EXEC_PROVIDER_ALLOWED_COMMANDS"
 )
 
 // Configuration variables associated with the Cluster API
@@ -61,4 +65,7 @@ var (
 
 	// K8sServerSideTimeout defines which server side timeout to send with each API request
 	K8sServerSideTimeout = env.ParseDurationFromEnv(EnvK8sTCPTimeout, 0, 0, math.MaxInt32*time.Second)
+
+	// K8sExecProviderAllowedCommands defines the commands which can be run by the exec providers of the clusters
+	K8sExecProviderAllowedCommands = env.StringsFromEnv(EnvK8sExecProviderAllowedCommands, nil, ",")
 )
@@ -20,9 +20,14 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.AppProjectSpec":                          schema_pkg_apis_application_v1alpha1_AppProjectSpec(ref),
 		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.AppProjectStatus":                        schema_pkg_apis_application_v1alpha1_AppProjectStatus(ref),
 		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.Application":                             schema_pkg_apis_application_v1alpha1_Application(ref),
+		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ApplicationBlueprint":                    schema_pkg_apis_application_v1alpha1_ApplicationBlueprint(ref),
+		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ApplicationBlueprintList":                schema_pkg_apis_application_v1alpha1_ApplicationBlueprintList(ref),
+		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ApplicationBlueprintParameter":           schema_pkg_apis_application_v1alpha1_ApplicationBlueprintParameter(ref),
 		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ApplicationCondition":                    schema_pkg_apis_application_v1alpha1_ApplicationCondition(ref),
+		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ApplicationDefaults":                     schema_pkg_apis_application_v1alpha1_ApplicationDefaults(ref),
 		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ApplicationDestination":                  schema_pkg_apis_application_v1alpha1_ApplicationDestination(ref),
 		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ApplicationDestinationServiceAccount":    schema_pkg_apis_application_v1alpha1_ApplicationDestinationServiceAccount(ref),
+		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ApplicationFreeze":                       schema_pkg_apis_application_v1alpha1_ApplicationFreeze(ref),
 		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ApplicationList":                         schema_pkg_apis_application_v1alpha1_ApplicationList(ref),
 		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ApplicationMatchExpression":              schema_pkg_apis_application_v1alpha1_ApplicationMatchExpression(ref),
 		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ApplicationPreservedFields":              schema_pkg_apis_application_v1alpha1_ApplicationPreservedFields(ref),
@@ -31,7 +36,9 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ApplicationSetCondition":                 schema_pkg_apis_application_v1alpha1_ApplicationSetCondition(ref),
 		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ApplicationSetGenerator":                 schema_pkg_apis_application_v1alpha1_ApplicationSetGenerator(ref),
 		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ApplicationSetList":                      schema_pkg_apis_application_v1alpha1_ApplicationSetList(ref),
+		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ApplicationSetMetadataPropagation":       schema_pkg_apis_application_v1alpha1_ApplicationSetMetadataPropagation(ref),
 		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ApplicationSetNestedGenerator":           schema_pkg_apis_application_v1alpha1_ApplicationSetNestedGenerator(ref),
+		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ApplicationSetParamTransform":            schema_pkg_apis_application_v1alpha1_ApplicationSetParamTransform(ref),
 		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ApplicationSetResourceIgnoreDifferences": schema_pkg_apis_application_v1alpha1_ApplicationSetResourceIgnoreDifferences(ref),
 		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ApplicationSetRolloutStep":               schema_pkg_apis_application_v1alpha1_ApplicationSetRolloutStep(ref),
 		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ApplicationSetRolloutStrategy":           schema_pkg_apis_application_v1alpha1_ApplicationSetRolloutStrategy(ref),
@@ -53,12 +60,17 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ApplicationSpec":                         schema_pkg_apis_application_v1alpha1_ApplicationSpec(ref),
 		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ApplicationStatus":                       schema_pkg_apis_application_v1alpha1_ApplicationStatus(ref),
 		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ApplicationSummary":                      schema_pkg_apis_application_v1alpha1_ApplicationSummary(ref),
+		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ApplicationSyncProgressEvent":            schema_pkg_apis_application_v1alpha1_ApplicationSyncProgressEvent(ref),
 		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ApplicationTree":                         schema_pkg_apis_application_v1alpha1_ApplicationTree(ref),
 		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ApplicationWatchEvent":                   schema_pkg_apis_application_v1alpha1_ApplicationWatchEvent(ref),
 		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.Backoff":                                 schema_pkg_apis_application_v1alpha1_Backoff(ref),
 		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.BasicAuthBitbucketServer":                schema_pkg_apis_application_v1alpha1_BasicAuthBitbucketServer(ref),
 		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.BearerTokenBitbucket":                    schema_pkg_apis_application_v1alpha1_BearerTokenBitbucket(ref),
 		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.BearerTokenBitbucketCloud":               schema_pkg_apis_application_v1alpha1_BearerTokenBitbucketCloud(ref),
+		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.CascadeChild":                            schema_pkg_apis_application_v1alpha1_CascadeChild(ref),
+		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.CascadeChildResult":                      schema_pkg_apis_application_v1alpha1_CascadeChildResult(ref),
+		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.CascadeOperation":                        schema_pkg_apis_application_v1alpha1_CascadeOperation(ref),
+		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.CascadeOperationResult":                  schema_pkg_apis_application_v1alpha1_CascadeOperationResult(ref),
 		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ChartDetails":                            schema_pkg_apis_application_v1alpha1_ChartDetails(ref),
 		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.Cluster":                                 schema_pkg_apis_application_v1alpha1_Cluster(ref),
 		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ClusterCacheInfo":                        schema_pkg_apis_application_v1alpha1_ClusterCacheInfo(ref),
@@ -86,6 +98,8 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.HelmFileParameter":                       schema_pkg_apis_application_v1alpha1_HelmFileParameter(ref),
 		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.HelmOptions":                             schema_pkg_apis_application_v1alpha1_HelmOptions(ref),
 		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.HelmParameter":                           schema_pkg_apis_application_v1alpha1_HelmParameter(ref),
+		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.HelmVersion":                             schema_pkg_apis_application_v1alpha1_HelmVersion(ref),
+		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.HookOutput":                              schema_pkg_apis_application_v1alpha1_HookOutput(ref),
 		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.HostInfo":                                schema_pkg_apis_application_v1alpha1_HostInfo(ref),
 		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.HostResourceInfo":                        schema_pkg_apis_application_v1alpha1_HostResourceInfo(ref),
 		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.HydrateOperation":                        schema_pkg_apis_application_v1alpha1_HydrateOperation(ref),
@@ -95,6 +109,7 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.JWTToken":                                schema_pkg_apis_application_v1alpha1_JWTToken(ref),
 		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.JWTTokens":                               schema_pkg_apis_application_v1alpha1_JWTTokens(ref),
 		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.JsonnetVar":                              schema_pkg_apis_application_v1alpha1_JsonnetVar(ref),
+		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.JumpHostConfig":                          schema_pkg_apis_application_v1alpha1_JumpHostConfig(ref),
 		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.KnownTypeField":                          schema_pkg_apis_application_v1alpha1_KnownTypeField(ref),
 		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.KustomizeGvk":                            schema_pkg_apis_application_v1alpha1_KustomizeGvk(ref),
 		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.KustomizeOptions":                        schema_pkg_apis_application_v1alpha1_KustomizeOptions(ref),
@@ -104,8 +119,10 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.KustomizeSelector":                       schema_pkg_apis_application_v1alpha1_KustomizeSelector(ref),
 		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ListGenerator":                           schema_pkg_apis_application_v1alpha1_ListGenerator(ref),
 		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ManagedNamespaceMetadata":                schema_pkg_apis_application_v1alpha1_ManagedNamespaceMetadata(ref),
+		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ManifestPolicy":                          schema_pkg_apis_application_v1alpha1_ManifestPolicy(ref),
 		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.MatrixGenerator":                         schema_pkg_apis_application_v1alpha1_MatrixGenerator(ref),
 		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.MergeGenerator":                          schema_pkg_apis_application_v1alpha1_MergeGenerator(ref),
+		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.MetadataPropagationPolicy":               schema_pkg_apis_application_v1alpha1_MetadataPropagationPolicy(ref),
 		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.NestedMatrixGenerator":                   schema_pkg_apis_application_v1alpha1_NestedMatrixGenerator(ref),
 		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.NestedMergeGenerator":                    schema_pkg_apis_application_v1alpha1_NestedMergeGenerator(ref),
 		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.Operation":                               schema_pkg_apis_application_v1alpha1_Operation(ref),
@@ -120,6 +137,7 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.PluginGenerator":                         schema_pkg_apis_application_v1alpha1_PluginGenerator(ref),
 		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.PluginInput":                             schema_pkg_apis_application_v1alpha1_PluginInput(ref),
 		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ProjectRole":                             schema_pkg_apis_application_v1alpha1_ProjectRole(ref),
+		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ProvenancePolicy":                        schema_pkg_apis_application_v1alpha1_ProvenancePolicy(ref),
 		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.PullRequestGenerator":                    schema_pkg_apis_application_v1alpha1_PullRequestGenerator(ref),
 		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.PullRequestGeneratorAzureDevOps":         schema_pkg_apis_application_v1alpha1_PullRequestGeneratorAzureDevOps(ref),
 		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.PullRequestGeneratorBitbucket":           schema_pkg_apis_application_v1alpha1_PullRequestGeneratorBitbucket(ref),
@@ -140,15 +158,21 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ResourceActionParam":                     schema_pkg_apis_application_v1alpha1_ResourceActionParam(ref),
 		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ResourceActions":                         schema_pkg_apis_application_v1alpha1_ResourceActions(ref),
 		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ResourceDiff":                            schema_pkg_apis_application_v1alpha1_ResourceDiff(ref),
+		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ResourceDryRunError":                     schema_pkg_apis_application_v1alpha1_ResourceDryRunError(ref),
+		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ResourceFilterRule":                      schema_pkg_apis_application_v1alpha1_ResourceFilterRule(ref),
 		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ResourceIgnoreDifferences":               schema_pkg_apis_application_v1alpha1_ResourceIgnoreDifferences(ref),
 		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ResourceNetworkingInfo":                  schema_pkg_apis_application_v1alpha1_ResourceNetworkingInfo(ref),
 		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ResourceNode":                            schema_pkg_apis_application_v1alpha1_ResourceNode(ref),
+		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ResourceNodeHealthChange":                schema_pkg_apis_application_v1alpha1_ResourceNodeHealthChange(ref),
 		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ResourceOverride":                        schema_pkg_apis_application_v1alpha1_ResourceOverride(ref),
 		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ResourceRef":                             schema_pkg_apis_application_v1alpha1_ResourceRef(ref),
 		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ResourceResult":                          schema_pkg_apis_application_v1alpha1_ResourceResult(ref),
 		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ResourceStatus":                          schema_pkg_apis_application_v1alpha1_ResourceStatus(ref),
+		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ResourceSyncProgress":                    schema_pkg_apis_application_v1alpha1_ResourceSyncProgress(ref),
+		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ResourceTreeDiff":                        schema_pkg_apis_application_v1alpha1_ResourceTreeDiff(ref),
 		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.RetryStrategy":                           schema_pkg_apis_application_v1alpha1_RetryStrategy(ref),
 		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.RevisionHistory":                         schema_pkg_apis_application_v1alpha1_RevisionHistory(ref),
+		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.RevisionHistoryRetention":                schema_pkg_apis_application_v1alpha1_RevisionHistoryRetention(ref),
 		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.RevisionMetadata":                        schema_pkg_apis_application_v1alpha1_RevisionMetadata(ref),
 		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.SCMProviderGenerator":                    schema_pkg_apis_application_v1alpha1_SCMProviderGenerator(ref),
 		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.SCMProviderGeneratorAWSCodeCommit":       schema_pkg_apis_application_v1alpha1_SCMProviderGeneratorAWSCodeCommit(ref),
@@ -163,7 +187,9 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.SignatureKey":                            schema_pkg_apis_application_v1alpha1_SignatureKey(ref),
 		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.SourceHydrator":                          schema_pkg_apis_application_v1alpha1_SourceHydrator(ref),
 		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.SourceHydratorStatus":                    schema_pkg_apis_application_v1alpha1_SourceHydratorStatus(ref),
+		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.SourceNamespaceRule":                     schema_pkg_apis_application_v1alpha1_SourceNamespaceRule(ref),
 		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.SuccessfulHydrateOperation":              schema_pkg_apis_application_v1alpha1_SuccessfulHydrateOperation(ref),
+		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.SuppressedDifference":                    schema_pkg_apis_application_v1alpha1_SuppressedDifference(ref),
 		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.SyncOperation":                           schema_pkg_apis_application_v1alpha1_SyncOperation(ref),
 		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.SyncOperationResource":                   schema_pkg_apis_application_v1alpha1_SyncOperationResource(ref),
 		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.SyncOperationResult":                     schema_pkg_apis_application_v1alpha1_SyncOperationResult(ref),
@@ -177,6 +203,7 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.SyncWindow":                              schema_pkg_apis_application_v1alpha1_SyncWindow(ref),
 		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.TLSClientConfig":                         schema_pkg_apis_application_v1alpha1_TLSClientConfig(ref),
 		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.TagFilter":                               schema_pkg_apis_application_v1alpha1_TagFilter(ref),
+		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.TokenProviderConfig":                     schema_pkg_apis_application_v1alpha1_TokenProviderConfig(ref),
 		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.objectMeta":                              schema_pkg_apis_application_v1alpha1_objectMeta(ref),
 		"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.rawResourceOverride":                     schema_pkg_apis_application_v1alpha1_rawResourceOverride(ref),
 	}
@@ -495,11 +522,176 @@ func schema_pkg_apis_application_v1alpha1_AppProjectSpec(ref common.ReferenceCal
 							},
 						},
 					},
+					"manifestPolicies": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ManifestPolicies are the Rego policies evaluated against the rendered manifests of the applications of the project",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]any{},
+										Ref:     ref("github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ManifestPolicy"),
+									},
+								},
+							},
+						},
+					},
+					"allowedImageRegistries": {
+						SchemaProps: spec.SchemaProps{
+							Description: "AllowedImageRegistries contains the prefixes of the image references allowed in the rendered manifests of the applications of the project, e.g. registry.example.com/team. All images are allowed if it's empty.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"provenancePolicies": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ProvenancePolicies are the policies verifying the SLSA provenance attestations of the OCI artifacts used as sources by the applications of the project",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]any{},
+										Ref:     ref("github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ProvenancePolicy"),
+									},
+								},
+							},
+						},
+					},
+					"deniedSyncOptions": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DeniedSyncOptions contains the sync options the applications of the project aren't allowed to sync with, e.g. Replace=true, Force=true or Validate=false. They're denied both as sync options of the sync operation and in the sync-options annotation of the resources.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"deniedHookTypes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DeniedHookTypes contains the types of the resource hooks the applications of the project aren't allowed to run, e.g. PreSync or SyncFail",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"breakGlassSync": {
+						SchemaProps: spec.SchemaProps{
+							Description: "BreakGlassSync allows the manual syncs blocked by a deny sync window as break-glass syncs, which require a justification and the approval of a second user",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"protectedClusters": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ProtectedClusters contains the glob patterns of the server URLs or names of the protected destination clusters. The manual syncs to a protected cluster are break-glass syncs, and the automated syncs to it are disabled.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"applicationDefaults": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ApplicationDefaults are the defaults of the sync policy and the sources of the applications of the project, which the applications inherit unless they override them",
+							Ref:         ref("github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ApplicationDefaults"),
+						},
+					},
+					"resourceInclusions": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ResourceInclusions are the resources tracked by the applications of the project even if they are excluded in the settings, unless the project or the applications exclude them. The controller watches them in the clusters they match.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]any{},
+										Ref:     ref("github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ResourceFilterRule"),
+									},
+								},
+							},
+						},
+					},
+					"resourceExclusions": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ResourceExclusions are the resources which the applications of the project don't track. The applications can't include them.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]any{},
+										Ref:     ref("github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ResourceFilterRule"),
+									},
+								},
+							},
+						},
+					},
+					"sourceNamespaceRules": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SourceNamespaceRules configure the applications of the project in the namespaces matching their patterns, which are allowed source namespaces of the project as well",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]any{},
+										Ref:     ref("github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.SourceNamespaceRule"),
+									},
+								},
+							},
+						},
+					},
+					"trackingMethod": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TrackingMethod is the method tracking the resources of the applications of the project, overriding the tracking method of the settings",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"applicationRefProjects": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ApplicationRefProjects contains the glob patterns of the other projects whose applications may use the outputs of the applications of the project with argocd-app:// ref sources",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ApplicationDestination", "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ApplicationDestinationServiceAccount", "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.OrphanedResourcesMonitorSettings", "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ProjectRole", "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.SignatureKey", "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.SyncWindow", "k8s.io/apimachinery/pkg/apis/meta/v1.GroupKind"},
+			"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ApplicationDefaults", "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ApplicationDestination", "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ApplicationDestinationServiceAccount", "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ManifestPolicy", "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.OrphanedResourcesMonitorSettings", "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ProjectRole", "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ProvenancePolicy", "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ResourceFilterRule", "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.SignatureKey", "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.SourceNamespaceRule", "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.SyncWindow", "k8s.io/apimachinery/pkg/apis/meta/v1.GroupKind"},
 	}
 }
 
@@ -586,6 +778,152 @@ func schema_pkg_apis_application_v1alpha1_Application(ref common.ReferenceCallba
 	}
 }
 
+func schema_pkg_apis_application_v1alpha1_ApplicationBlueprint(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ApplicationBlueprint is a parameterized application spec offered by the blueprint catalog, from which users create applications in the allowed projects by only providing the values of the parameters",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name is the name of the blueprint",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"description": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Description is the description of the blueprint",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"projects": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Projects are the glob patterns of the projects in which applications can be created from the blueprint",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"parameters": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Parameters are the parameters of the blueprint",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]any{},
+										Ref:     ref("github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ApplicationBlueprintParameter"),
+									},
+								},
+							},
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Spec is the spec of the applications created from the blueprint. Its string fields can reference the values of the parameters with {{parameter}}, and the name, namespace and project of the application with {{app.name}}, {{app.namespace}} and {{app.project}}.",
+							Default:     map[string]any{},
+							Ref:         ref("github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ApplicationSpec"),
+						},
+					},
+				},
+				Required: []string{"name", "projects", "spec"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ApplicationBlueprintParameter", "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ApplicationSpec"},
+	}
+}
+
+func schema_pkg_apis_application_v1alpha1_ApplicationBlueprintList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ApplicationBlueprintList is a list of application blueprints",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"items": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]any{},
+										Ref:     ref("github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ApplicationBlueprint"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"items"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ApplicationBlueprint"},
+	}
+}
+
+func schema_pkg_apis_application_v1alpha1_ApplicationBlueprintParameter(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ApplicationBlueprintParameter is a parameter of an application blueprint",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name is the name of the parameter",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"description": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Description is the description of the parameter",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"default": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Default is the value of the parameter when it is not set",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"pattern": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Pattern is a regular expression which the whole value of the parameter must match",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"required": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Required is true if the parameter must be set when it has no default value",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+	}
+}
+
 func schema_pkg_apis_application_v1alpha1_ApplicationCondition(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -624,6 +962,86 @@ func schema_pkg_apis_application_v1alpha1_ApplicationCondition(ref common.Refere
 	}
 }
 
+func schema_pkg_apis_application_v1alpha1_ApplicationDefaults(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ApplicationDefaults are the defaults of the applications of a project, which the applications inherit unless they override them",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"syncPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SyncPolicy is the sync policy of the applications of the project which don't have a sync policy",
+							Ref:         ref("github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.SyncPolicy"),
+						},
+					},
+					"syncOptions": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SyncOptions are added to the sync options of the applications of the project, except the options the applications set to another value",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"helmValueFiles": {
+						SchemaProps: spec.SchemaProps{
+							Description: "HelmValueFiles are the value files of the Helm sources of the applications of the project which don't set value files",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"kustomizeComponents": {
+						SchemaProps: spec.SchemaProps{
+							Description: "KustomizeComponents are the components of the Kustomize sources of the applications of the project which don't set components",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"helmVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "HelmVersion is the Helm version of the Helm sources of the applications of the project which don't set a version",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"kustomizeVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "KustomizeVersion is the Kustomize version of the Kustomize sources of the applications of the project which don't set a version",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.SyncPolicy"},
+	}
+}
+
 func schema_pkg_apis_application_v1alpha1_ApplicationDestination(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -692,6 +1110,55 @@ func schema_pkg_apis_application_v1alpha1_ApplicationDestinationServiceAccount(r
 	}
 }
 
+func schema_pkg_apis_application_v1alpha1_ApplicationFreeze(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ApplicationFreeze holds the freeze of an application, which disables its automated sync and self heal, and optionally its refresh, until the freeze expires or the application is thawed",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"frozenBy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "FrozenBy is the user who froze the application",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"reason": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Reason is the reason why the application was frozen",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"frozenAt": {
+						SchemaProps: spec.SchemaProps{
+							Description: "FrozenAt is the time at which the application was frozen",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"until": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Until is the time at which the application is thawed, the application being frozen until it is thawed manually if not set",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"noRefresh": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NoRefresh disables the refresh of the application in addition to its automated sync and self heal",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"frozenAt"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
 func schema_pkg_apis_application_v1alpha1_ApplicationList(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -1105,6 +1572,33 @@ func schema_pkg_apis_application_v1alpha1_ApplicationSetList(ref common.Referenc
 	}
 }
 
+func schema_pkg_apis_application_v1alpha1_ApplicationSetMetadataPropagation(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ApplicationSetMetadataPropagation configures the propagation of the labels and the annotations to the generated Applications",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"labels": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Labels is the propagation policy of the labels",
+							Ref:         ref("github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.MetadataPropagationPolicy"),
+						},
+					},
+					"annotations": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Annotations is the propagation policy of the annotations",
+							Ref:         ref("github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.MetadataPropagationPolicy"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.MetadataPropagationPolicy"},
+	}
+}
+
 func schema_pkg_apis_application_v1alpha1_ApplicationSetNestedGenerator(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -1173,6 +1667,65 @@ func schema_pkg_apis_application_v1alpha1_ApplicationSetNestedGenerator(ref comm
 	}
 }
 
+func schema_pkg_apis_application_v1alpha1_ApplicationSetParamTransform(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ApplicationSetParamTransform sets a parameter, either renamed from another parameter or computed from a template rendered with the parameters",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"key": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Key is the parameter set by the transformation. With Go templates, dots separate the keys of nested parameters.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"from": {
+						SchemaProps: spec.SchemaProps{
+							Description: "From is the parameter renamed to Key",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"value": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Value is the template of the value, rendered with the parameters and the template functions of the ApplicationSet",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"map": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Map replaces the value with the mapped value, when it has one",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"type": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Type is the type the value is converted to. Possible values are string, int, float, bool and json.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"key"},
+			},
+		},
+	}
+}
+
 func schema_pkg_apis_application_v1alpha1_ApplicationSetResourceIgnoreDifferences(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -1217,6 +1770,13 @@ func schema_pkg_apis_application_v1alpha1_ApplicationSetResourceIgnoreDifference
 							},
 						},
 					},
+					"preserve": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Preserve controls when the differences are ignored. Possible values are Always, the default, and UserModified, which only ignores the differences while the fields of the live application differ from the values last generated by the ApplicationSet, i.e. while they are modified by a user.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 				},
 			},
 		},
@@ -1345,7 +1905,7 @@ func schema_pkg_apis_application_v1alpha1_ApplicationSetSpec(ref common.Referenc
 					},
 					"applyNestedSelectors": {
 						SchemaProps: spec.SchemaProps{
-							Description: "ApplyNestedSelectors enables selectors defined within the generators of two level-nested matrix or merge generators",
+							Description: "ApplyNestedSelectors enables selectors defined within the generators of two level-nested matrix or merge generators Deprecated: This field is ignored, and the behavior is always enabled. The field will be removed in a future version of the ApplicationSet CRD.",
 							Type:        []string{"boolean"},
 							Format:      "",
 						},
@@ -1369,12 +1929,32 @@ func schema_pkg_apis_application_v1alpha1_ApplicationSetSpec(ref common.Referenc
 							Format: "",
 						},
 					},
+					"metadataPropagation": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MetadataPropagation controls which labels and annotations of the ApplicationSet are propagated to the generated Applications, and how the labels and annotations of the existing Applications are updated",
+							Ref:         ref("github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ApplicationSetMetadataPropagation"),
+						},
+					},
+					"paramTransforms": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ParamTransforms are the transformations applied in order to the parameters generated for each Application, before the template is rendered",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]any{},
+										Ref:     ref("github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ApplicationSetParamTransform"),
+									},
+								},
+							},
+						},
+					},
 				},
 				Required: []string{"generators", "template"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ApplicationPreservedFields", "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ApplicationSetGenerator", "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ApplicationSetResourceIgnoreDifferences", "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ApplicationSetStrategy", "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ApplicationSetSyncPolicy", "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ApplicationSetTemplate"},
+			"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ApplicationPreservedFields", "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ApplicationSetGenerator", "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ApplicationSetMetadataPropagation", "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ApplicationSetParamTransform", "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ApplicationSetResourceIgnoreDifferences", "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ApplicationSetStrategy", "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ApplicationSetSyncPolicy", "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ApplicationSetTemplate"},
 	}
 }
 
@@ -1859,7 +2439,7 @@ func schema_pkg_apis_application_v1alpha1_ApplicationSourceHelm(ref common.Refer
 					},
 					"version": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Version is the Helm version to use for templating (\"3\")",
+							Description: "Version is the Helm version to use for templating, either \"v3\" or the name of a Helm version registered in the settings with a helm.path.<name> key",
 							Type:        []string{"string"},
 							Format:      "",
 						},
@@ -1920,6 +2500,20 @@ func schema_pkg_apis_application_v1alpha1_ApplicationSourceHelm(ref common.Refer
 							},
 						},
 					},
+					"skipTests": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SkipTests skips test manifest installation step (Helm's --skip-tests).",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"skipSchemaValidation": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SkipSchemaValidation skips JSON schema validation (Helm's --skip-schema-validation)",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
 				},
 			},
 		},
@@ -2330,12 +2924,32 @@ func schema_pkg_apis_application_v1alpha1_ApplicationSpec(ref common.ReferenceCa
 							Ref:         ref("github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.SourceHydrator"),
 						},
 					},
+					"revisionHistoryRetention": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RevisionHistoryRetention keeps items of the revision history by age and by day or week, in addition to the ones kept by the revision history limit.",
+							Ref:         ref("github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.RevisionHistoryRetention"),
+						},
+					},
+					"resourceExclusions": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ResourceExclusions are the resources which the application doesn't track, in addition to the ones excluded by its project or in the settings",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]any{},
+										Ref:     ref("github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ResourceFilterRule"),
+									},
+								},
+							},
+						},
+					},
 				},
 				Required: []string{"destination", "project"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ApplicationDestination", "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ApplicationSource", "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.Info", "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ResourceIgnoreDifferences", "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.SourceHydrator", "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.SyncPolicy"},
+			"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ApplicationDestination", "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ApplicationSource", "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.Info", "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ResourceFilterRule", "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ResourceIgnoreDifferences", "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.RevisionHistoryRetention", "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.SourceHydrator", "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.SyncPolicy"},
 	}
 }
 
@@ -2371,7 +2985,7 @@ func schema_pkg_apis_application_v1alpha1_ApplicationStatus(ref common.Reference
 						SchemaProps: spec.SchemaProps{
 							Description: "Health contains information about the application's current health status",
 							Default:     map[string]any{},
-							Ref:         ref("github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.HealthStatus"),
+							Ref:         ref("github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.AppHealthStatus"),
 						},
 					},
 					"history": {
@@ -2470,11 +3084,32 @@ func schema_pkg_apis_application_v1alpha1_ApplicationStatus(ref common.Reference
 							Ref:         ref("github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.SourceHydratorStatus"),
 						},
 					},
+					"freeze": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Freeze contains the freeze of the application, if it is frozen",
+							Ref:         ref("github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ApplicationFreeze"),
+						},
+					},
+					"toolVersions": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ToolVersions are the Helm, Kustomize or config management plugin versions which rendered the manifests of the sources, in the order of the sources, empty for the default versions",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ApplicationCondition", "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ApplicationSummary", "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.HealthStatus", "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.OperationState", "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ResourceStatus", "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.RevisionHistory", "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.SourceHydratorStatus", "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.SyncStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+			"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.AppHealthStatus", "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ApplicationCondition", "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ApplicationFreeze", "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ApplicationSummary", "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.OperationState", "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ResourceStatus", "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.RevisionHistory", "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.SourceHydratorStatus", "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.SyncStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
 	}
 }
 
@@ -2521,6 +3156,51 @@ func schema_pkg_apis_application_v1alpha1_ApplicationSummary(ref common.Referenc
 	}
 }
 
+func schema_pkg_apis_application_v1alpha1_ApplicationSyncProgressEvent(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ApplicationSyncProgressEvent contains the progress of the sync operation of an application. The first event of a sync operation contains the progress of all its resources, and the next events only the resources whose progress changed.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"phase": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Phase is the phase of the sync operation",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"message": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Message contains an informational or error message about the sync operation",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"resources": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Resources holds the progress of the resources of the sync operation",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]any{},
+										Ref:     ref("github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ResourceSyncProgress"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"phase"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ResourceSyncProgress"},
+	}
+}
+
 func schema_pkg_apis_application_v1alpha1_ApplicationTree(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -2570,11 +3250,60 @@ func schema_pkg_apis_application_v1alpha1_ApplicationTree(ref common.ReferenceCa
 							},
 						},
 					},
+					"shardsCount": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ShardsCount represents the total number of shards the application tree is split into. This is used to distribute resource processing across multiple shards.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"version": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Version is the version of the tree sent by a resource tree watch stream, which is incremented with each message of the stream.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"delta": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Delta indicates that the tree only holds the changes since the previous version sent by a resource tree watch stream. The added and updated nodes are in Nodes and OrphanedNodes, the removed nodes are in RemovedNodes and RemovedOrphanedNodes, and Hosts holds all the hosts.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"removedNodes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RemovedNodes contains the references of the nodes removed since the previous version of a delta.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]any{},
+										Ref:     ref("github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ResourceRef"),
+									},
+								},
+							},
+						},
+					},
+					"removedOrphanedNodes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RemovedOrphanedNodes contains the references of the orphaned nodes removed since the previous version of a delta.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]any{},
+										Ref:     ref("github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ResourceRef"),
+									},
+								},
+							},
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.HostInfo", "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ResourceNode"},
+			"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.HostInfo", "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ResourceNode", "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ResourceRef"},
 	}
 }
 
@@ -2603,116 +3332,277 @@ func schema_pkg_apis_application_v1alpha1_ApplicationWatchEvent(ref common.Refer
 				Required: []string{"type", "application"},
 			},
 		},
-		Dependencies: []string{
-			"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.Application"},
+		Dependencies: []string{
+			"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.Application"},
+	}
+}
+
+func schema_pkg_apis_application_v1alpha1_Backoff(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "Backoff is the backoff strategy to use on subsequent retries for failing syncs",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"duration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Duration is the amount to back off. Default unit is seconds, but could also be a duration (e.g. \"2m\", \"1h\")",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"factor": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Factor is a factor to multiply the base duration after each failed retry",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"maxDuration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxDuration is the maximum amount of time allowed for the backoff strategy",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_application_v1alpha1_BasicAuthBitbucketServer(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "BasicAuthBitbucketServer defines the username/(password or personal access token) for Basic auth.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"username": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Username for Basic auth",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"passwordRef": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Password (or personal access token) reference.",
+							Ref:         ref("github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.SecretRef"),
+						},
+					},
+				},
+				Required: []string{"username", "passwordRef"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.SecretRef"},
+	}
+}
+
+func schema_pkg_apis_application_v1alpha1_BearerTokenBitbucket(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "BearerTokenBitbucket defines the Bearer token for BitBucket AppToken auth.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"tokenRef": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Password (or personal access token) reference.",
+							Ref:         ref("github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.SecretRef"),
+						},
+					},
+				},
+				Required: []string{"tokenRef"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.SecretRef"},
+	}
+}
+
+func schema_pkg_apis_application_v1alpha1_BearerTokenBitbucketCloud(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "BearerTokenBitbucketCloud defines the Bearer token for BitBucket AppToken auth.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"tokenRef": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Password (or personal access token) reference.",
+							Ref:         ref("github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.SecretRef"),
+						},
+					},
+				},
+				Required: []string{"tokenRef"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.SecretRef"},
+	}
+}
+
+func schema_pkg_apis_application_v1alpha1_CascadeChild(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "CascadeChild identifies a child application of a cascade operation",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name is the name of the child application",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"namespace": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Namespace is the namespace of the child application",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
 	}
 }
 
-func schema_pkg_apis_application_v1alpha1_Backoff(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_application_v1alpha1_CascadeChildResult(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "Backoff is the backoff strategy to use on subsequent retries for failing syncs",
+				Description: "CascadeChildResult holds the progress of a cascade operation on a child application",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"duration": {
+					"name": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Duration is the amount to back off. Default unit is seconds, but could also be a duration (e.g. \"2m\", \"1h\")",
+							Description: "Name is the name of the child application",
+							Default:     "",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"factor": {
+					"namespace": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Factor is a factor to multiply the base duration after each failed retry",
+							Description: "Namespace is the namespace of the child application",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"wave": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Wave is the wave of the child application",
 							Type:        []string{"integer"},
 							Format:      "int64",
 						},
 					},
-					"maxDuration": {
+					"phase": {
 						SchemaProps: spec.SchemaProps{
-							Description: "MaxDuration is the maximum amount of time allowed for the backoff strategy",
+							Description: "Phase is the phase of the operation on the child application. Empty until the operation is started on it.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-				},
-			},
-		},
-	}
-}
-
-func schema_pkg_apis_application_v1alpha1_BasicAuthBitbucketServer(ref common.ReferenceCallback) common.OpenAPIDefinition {
-	return common.OpenAPIDefinition{
-		Schema: spec.Schema{
-			SchemaProps: spec.SchemaProps{
-				Description: "BasicAuthBitbucketServer defines the username/(password or personal access token) for Basic auth.",
-				Type:        []string{"object"},
-				Properties: map[string]spec.Schema{
-					"username": {
+					"message": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Username for Basic auth",
-							Default:     "",
+							Description: "Message holds the status of the operation on the child application",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"passwordRef": {
+					"startedAt": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Password (or personal access token) reference.",
-							Ref:         ref("github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.SecretRef"),
+							Description: "StartedAt is the time the operation was started on the child application",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
 						},
 					},
 				},
-				Required: []string{"username", "passwordRef"},
+				Required: []string{"name"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.SecretRef"},
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
 	}
 }
 
-func schema_pkg_apis_application_v1alpha1_BearerTokenBitbucket(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_application_v1alpha1_CascadeOperation(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "BearerTokenBitbucket defines the Bearer token for BitBucket AppToken auth.",
+				Description: "CascadeOperation contains the parameters of an operation performed on the child applications of an application, i.e. the applications it manages. The child applications are processed in waves, in the order of their argocd.argoproj.io/cascade-wave annotation, and a wave is started once all the applications of the previous waves completed: synced and healthy for a sync, reconciled for a refresh.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"tokenRef": {
+					"type": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Password (or personal access token) reference.",
-							Ref:         ref("github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.SecretRef"),
+							Description: "Type is the type of the operation performed on the child applications, Sync or Refresh",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"prune": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Prune specifies to delete the resources of the child applications which are no longer tracked in git",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"children": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Children are the child applications on which the operation is performed, which the user who initiated the operation is allowed to sync or refresh",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]any{},
+										Ref:     ref("github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.CascadeChild"),
+									},
+								},
+							},
 						},
 					},
 				},
-				Required: []string{"tokenRef"},
+				Required: []string{"type"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.SecretRef"},
+			"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.CascadeChild"},
 	}
 }
 
-func schema_pkg_apis_application_v1alpha1_BearerTokenBitbucketCloud(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_application_v1alpha1_CascadeOperationResult(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "BearerTokenBitbucketCloud defines the Bearer token for BitBucket AppToken auth.",
+				Description: "CascadeOperationResult is the result of a cascade operation",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"tokenRef": {
+					"children": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Password (or personal access token) reference.",
-							Ref:         ref("github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.SecretRef"),
+							Description: "Children holds the progress of the operation on each child application, in the order of their waves",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]any{},
+										Ref:     ref("github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.CascadeChildResult"),
+									},
+								},
+							},
 						},
 					},
 				},
-				Required: []string{"tokenRef"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.SecretRef"},
+			"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.CascadeChildResult"},
 	}
 }
 
@@ -2882,6 +3772,56 @@ func schema_pkg_apis_application_v1alpha1_Cluster(ref common.ReferenceCallback)
 							},
 						},
 					},
+					"resourceGroups": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Holds list of API groups whose resources are cached by the controller, in addition to the core API group which is always cached. Resources of all API groups are cached if the list is empty.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"cacheResyncPeriod": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CacheResyncPeriod is the period of the full re-synchronization of the cluster cache, e.g. 12h. The period set for the controller is used if empty.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"cacheSyncRetryTimeout": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CacheSyncRetryTimeout is the time to wait before retrying to synchronize the cluster cache after a watch or sync failure, e.g. 30s. The timeout set for the controller is used if empty.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"cacheListPageSize": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CacheListPageSize is the number of resources requested per page when listing the resources of the cluster. The page size set for the controller is used if zero.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"minReadyNodes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MinReadyNodes is the minimum number of ready nodes of the cluster for the syncs to the cluster to start. The nodes aren't checked if zero.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"draining": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Draining defers the syncs to the cluster, e.g. while the cluster is drained or upgraded",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
 				},
 				Required: []string{"server", "name", "config"},
 			},
@@ -2972,12 +3912,38 @@ func schema_pkg_apis_application_v1alpha1_ClusterConfig(ref common.ReferenceCall
 							Ref:         ref("github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ExecProviderConfig"),
 						},
 					},
+					"tokenProviderConfig": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TokenProviderConfig contains the configuration of a built-in provider of short-lived tokens",
+							Ref:         ref("github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.TokenProviderConfig"),
+						},
+					},
+					"proxyUsername": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ProxyUsername is the username authenticating to the proxy, which is kept out of the proxy URL",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"proxyPassword": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ProxyPassword is the password authenticating to the proxy, which is kept out of the proxy URL",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"jumpHostConfig": {
+						SchemaProps: spec.SchemaProps{
+							Description: "JumpHostConfig contains the configuration of an SSH jump host through which the server is reached",
+							Ref:         ref("github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.JumpHostConfig"),
+						},
+					},
 				},
 				Required: []string{"tlsClientConfig"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.AWSAuthConfig", "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ExecProviderConfig", "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.TLSClientConfig"},
+			"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.AWSAuthConfig", "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ExecProviderConfig", "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.JumpHostConfig", "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.TLSClientConfig", "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.TokenProviderConfig"},
 	}
 }
 
@@ -3024,6 +3990,20 @@ func schema_pkg_apis_application_v1alpha1_ClusterGenerator(ref common.ReferenceC
 							Format:      "",
 						},
 					},
+					"selectors": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Selectors defines label selector groups ORed together, along with Selector when it isn't empty, to match against all clusters registered with ArgoCD. The clusters matching any of the groups are targeted.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]any{},
+										Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"),
+									},
+								},
+							},
+						},
+					},
 				},
 			},
 		},
@@ -3907,10 +4887,26 @@ func schema_pkg_apis_application_v1alpha1_HelmOptions(ref common.ReferenceCallba
 							},
 						},
 					},
+					"Versions": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Versions is a list of Helm versions and their corresponding binary paths",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]any{},
+										Ref:     ref("github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.HelmVersion"),
+									},
+								},
+							},
+						},
+					},
 				},
-				Required: []string{"ValuesFileSchemes"},
+				Required: []string{"ValuesFileSchemes", "Versions"},
 			},
 		},
+		Dependencies: []string{
+			"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.HelmVersion"},
 	}
 }
 
@@ -3948,6 +4944,80 @@ func schema_pkg_apis_application_v1alpha1_HelmParameter(ref common.ReferenceCall
 	}
 }
 
+func schema_pkg_apis_application_v1alpha1_HelmVersion(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "HelmVersion holds information about additional Helm versions",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"Name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name holds Helm version name",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"Path": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Path holds the corresponding binary path",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"Name", "Path"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_application_v1alpha1_HookOutput(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "HookOutput holds the final status of a container of a pod of a hook, captured when the hook completes so that it can be inspected after the pod is deleted",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"pod": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Pod is the name of the pod",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"container": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Container is the name of the container",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"reason": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Reason is the reason of the termination of the container",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"exitCode": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ExitCode is the exit code of the container",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+				},
+				Required: []string{"pod", "container"},
+			},
+		},
+	}
+}
+
 func schema_pkg_apis_application_v1alpha1_HostInfo(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -4230,31 +5300,75 @@ func schema_pkg_apis_application_v1alpha1_JsonnetVar(ref common.ReferenceCallbac
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "JsonnetVar represents a variable to be passed to jsonnet during manifest generation",
+				Description: "JsonnetVar represents a variable to be passed to jsonnet during manifest generation",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+					"value": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+					"code": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"boolean"},
+							Format: "",
+						},
+					},
+				},
+				Required: []string{"name", "value"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_application_v1alpha1_JumpHostConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "JumpHostConfig is the configuration of an SSH jump host (bastion) through which the connections to a cluster are tunneled. When a proxy is configured too, the proxy is reached through the jump host.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"name": {
+					"address": {
 						SchemaProps: spec.SchemaProps{
-							Default: "",
-							Type:    []string{"string"},
-							Format:  "",
+							Description: "Address is the host:port address of the SSH server of the jump host",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"value": {
+					"username": {
 						SchemaProps: spec.SchemaProps{
-							Default: "",
-							Type:    []string{"string"},
-							Format:  "",
+							Description: "Username is the user authenticating to the jump host",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"code": {
+					"sshPrivateKey": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"boolean"},
-							Format: "",
+							Description: "SSHPrivateKey is the PEM-encoded private key authenticating the user to the jump host",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"knownHosts": {
+						SchemaProps: spec.SchemaProps{
+							Description: "KnownHosts contains the known_hosts entries of the jump host, used to verify its host key",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
 				},
-				Required: []string{"name", "value"},
+				Required: []string{"address", "username"},
 			},
 		},
 	}
@@ -4598,6 +5712,64 @@ func schema_pkg_apis_application_v1alpha1_ManagedNamespaceMetadata(ref common.Re
 	}
 }
 
+func schema_pkg_apis_application_v1alpha1_ManifestPolicy(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ManifestPolicy is a bundle of Rego policies evaluated against each rendered manifest of the applications of a project. The policies follow the conventions of conftest: the deny and violation rules of the package report violations, and the warn rules report warnings.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name is the name of the policy",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"configMap": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ConfigMap is the name of a ConfigMap of the Argo CD namespace, whose keys ending with .rego contain the Rego modules of the policy",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"repoURL": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RepoURL is the URL of an OCI repository, whose artifact contains the Rego modules of the policy",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"targetRevision": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TargetRevision is the tag, digest or semantic version constraint of the OCI artifact",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"package": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Package is the Rego package of the rules of the policy, e.g. main",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"enforcement": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Enforcement is either block, which blocks the syncs of the applications violating the policy, or warn, which only reports the violations (default: block)",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"name", "package"},
+			},
+		},
+	}
+}
+
 func schema_pkg_apis_application_v1alpha1_MatrixGenerator(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -4682,6 +5854,41 @@ func schema_pkg_apis_application_v1alpha1_MergeGenerator(ref common.ReferenceCal
 	}
 }
 
+func schema_pkg_apis_application_v1alpha1_MetadataPropagationPolicy(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "MetadataPropagationPolicy configures how the labels or the annotations of the generated Applications are managed",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"strategy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Strategy is the strategy updating the labels or annotations of the existing Applications. Possible values are replace, merge and ignore. Defaults to replace.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"propagate": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Propagate are the keys of the labels or annotations of the ApplicationSet propagated to the generated Applications, unless the template sets them. Glob patterns are supported.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 func schema_pkg_apis_application_v1alpha1_NestedMatrixGenerator(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -4794,11 +6001,30 @@ func schema_pkg_apis_application_v1alpha1_Operation(ref common.ReferenceCallback
 							Ref:         ref("github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.RetryStrategy"),
 						},
 					},
+					"cascade": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Cascade contains parameters for an operation performed on the child applications of the application",
+							Ref:         ref("github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.CascadeOperation"),
+						},
+					},
+					"priority": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Priority is the priority of the operation. The pending operations are resumed by order of decreasing priority when the application controller starts.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"requestedAt": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RequestedAt is the time at which the operation was requested",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.Info", "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.OperationInitiator", "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.RetryStrategy", "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.SyncOperation"},
+			"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.CascadeOperation", "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.Info", "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.OperationInitiator", "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.RetryStrategy", "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.SyncOperation", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
 	}
 }
 
@@ -4823,6 +6049,20 @@ func schema_pkg_apis_application_v1alpha1_OperationInitiator(ref common.Referenc
 							Format:      "",
 						},
 					},
+					"justification": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Justification contains the justification of a break-glass sync",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"approvedBy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ApprovedBy contains the name of the user who approved a break-glass sync",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 				},
 			},
 		},
@@ -4883,12 +6123,18 @@ func schema_pkg_apis_application_v1alpha1_OperationState(ref common.ReferenceCal
 							Format:      "int64",
 						},
 					},
+					"cascadeResult": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CascadeResult is the result of a Cascade operation",
+							Ref:         ref("github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.CascadeOperationResult"),
+						},
+					},
 				},
 				Required: []string{"operation", "phase", "startedAt"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.Operation", "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.SyncOperationResult", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+			"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.CascadeOperationResult", "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.Operation", "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.SyncOperationResult", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
 	}
 }
 
@@ -5254,6 +6500,50 @@ func schema_pkg_apis_application_v1alpha1_ProjectRole(ref common.ReferenceCallba
 	}
 }
 
+func schema_pkg_apis_application_v1alpha1_ProvenancePolicy(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ProvenancePolicy requires the OCI artifacts of matching repositories to have a SLSA provenance attestation signed with a trusted key, whose builder and source repository are allowed. The attestations are attached to the artifacts with the OCI referrers API, or with the tag scheme of cosign.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"repoURL": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RepoURL is a glob pattern matching the URLs of the OCI repositories the policy applies to, e.g. oci://registry.example.com/charts/*",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"builderID": {
+						SchemaProps: spec.SchemaProps{
+							Description: "BuilderID is a glob pattern matching the allowed builder IDs of the provenance. Any builder is allowed if it's empty.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"sourceRepository": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SourceRepository is a glob pattern matching the allowed source repositories of the provenance, e.g. https://github.com/example/*. Any source repository is allowed if it's empty.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"publicKey": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PublicKey contains the PEM encoded public keys trusted to sign the attestations",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"repoURL", "publicKey"},
+			},
+		},
+	}
+}
+
 func schema_pkg_apis_application_v1alpha1_PullRequestGenerator(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -5582,7 +6872,7 @@ func schema_pkg_apis_application_v1alpha1_PullRequestGeneratorGitLab(ref common.
 					},
 					"pullRequestState": {
 						SchemaProps: spec.SchemaProps{
-							Description: "PullRequestState is an additional MRs filter to get only those with a certain state. Default: \"\" (all states)",
+							Description: "PullRequestState is an additional MRs filter to get only those with a certain state. Default: \"\" (all states). Valid values: opened, closed, merged, locked\".",
 							Type:        []string{"string"},
 							Format:      "",
 						},
@@ -5600,6 +6890,28 @@ func schema_pkg_apis_application_v1alpha1_PullRequestGeneratorGitLab(ref common.
 							Ref:         ref("github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ConfigMapKeyRef"),
 						},
 					},
+					"pipelineStatuses": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PipelineStatuses is an additional MRs filter to get only those whose last pipeline has one of the statuses, e.g. success, running or failed. Default: all MRs, with or without pipeline.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"approved": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Approved is an additional MRs filter to get only the approved MRs when true, or the not approved MRs when false. Default: all MRs.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
 				},
 				Required: []string{"project"},
 			},
@@ -6476,10 +7788,117 @@ func schema_pkg_apis_application_v1alpha1_ResourceDiff(ref common.ReferenceCallb
 							Format: "",
 						},
 					},
-					"modified": {
+					"modified": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"boolean"},
+							Format: "",
+						},
+					},
+					"suppressedDiff": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SuppressedDiff lists the differences between the live and target resource which are hidden by ignoreDifferences rules.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]any{},
+										Ref:     ref("github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.SuppressedDifference"),
+									},
+								},
+							},
+						},
+					},
+					"dryRunError": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DryRunError is the failure of the server-side dry-run apply of the resource performed by the server-side diff, if any.",
+							Ref:         ref("github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ResourceDryRunError"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ResourceDryRunError", "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.SuppressedDifference"},
+	}
+}
+
+func schema_pkg_apis_application_v1alpha1_ResourceDryRunError(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ResourceDryRunError is the failure of the server-side dry-run apply of a resource. The resource is then diffed without server-side diff, and the sync of the resource is expected to fail for the same reason.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"reason": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Reason is the reason why the dry-run apply failed (e.g., \"WebhookRejected\", \"FieldConflict\" or \"Failed\").",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"message": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Message is the error returned by the dry-run apply.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_application_v1alpha1_ResourceFilterRule(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ResourceFilterRule matches resources by API group, kind and cluster, like the rules of the resource.exclusions and resource.inclusions settings",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"apiGroups": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIGroups are the glob patterns of the API groups of the resources, all the groups if empty",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"kinds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kinds are the kinds of the resources, all the kinds if empty or \"*\"",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"clusters": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"boolean"},
-							Format: "",
+							Description: "Clusters are the glob patterns of the server URLs of the clusters of the resources, all the clusters if empty",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
 						},
 					},
 				},
@@ -6768,6 +8187,69 @@ func schema_pkg_apis_application_v1alpha1_ResourceNode(ref common.ReferenceCallb
 	}
 }
 
+func schema_pkg_apis_application_v1alpha1_ResourceNodeHealthChange(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ResourceNodeHealthChange is a change of the health of a node of a resource tree",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"group": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"version": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"namespace": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"uid": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"health": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Health is the health of the node at the end of the change",
+							Ref:         ref("github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.HealthStatus"),
+						},
+					},
+					"previousHealth": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PreviousHealth is the health of the node at the start of the change",
+							Ref:         ref("github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.HealthStatus"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.HealthStatus"},
+	}
+}
+
 func schema_pkg_apis_application_v1alpha1_ResourceOverride(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -6896,8 +8378,199 @@ func schema_pkg_apis_application_v1alpha1_ResourceResult(ref common.ReferenceCal
 					},
 					"version": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Version specifies the API version of the resource",
-							Default:     "",
+							Description: "Version specifies the API version of the resource",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind specifies the API kind of the resource",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"namespace": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Namespace specifies the target namespace of the resource",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name specifies the name of the resource",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Status holds the final result of the sync. Will be empty if the resources is yet to be applied/pruned and is always zero-value for hooks",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"message": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Message contains an informational or error message for the last sync OR operation",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"hookType": {
+						SchemaProps: spec.SchemaProps{
+							Description: "HookType specifies the type of the hook. Empty for non-hook resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"hookPhase": {
+						SchemaProps: spec.SchemaProps{
+							Description: "HookPhase contains the state of any operation associated with this resource OR hook This can also contain values for non-hook resources.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"syncPhase": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SyncPhase indicates the particular phase of the sync that this result was acquired in",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"images": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Images contains the images related to the ResourceResult",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"hookOutputs": {
+						SchemaProps: spec.SchemaProps{
+							Description: "HookOutputs holds the final status of the containers of the pods of a completed hook. Their logs are served by the logs API.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]any{},
+										Ref:     ref("github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.HookOutput"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"group", "version", "kind", "namespace", "name"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.HookOutput"},
+	}
+}
+
+func schema_pkg_apis_application_v1alpha1_ResourceStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ResourceStatus holds the current sync and health status of a resource",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"group": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"version": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"namespace": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"health": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.HealthStatus"),
+						},
+					},
+					"hook": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"boolean"},
+							Format: "",
+						},
+					},
+					"requiresPruning": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"boolean"},
+							Format: "",
+						},
+					},
+					"syncWave": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"integer"},
+							Format: "int64",
+						},
+					},
+					"dryRunError": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DryRunError is the failure of the server-side dry-run apply of the resource performed by the server-side diff, if any.",
+							Ref:         ref("github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ResourceDryRunError"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.HealthStatus", "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ResourceDryRunError"},
+	}
+}
+
+func schema_pkg_apis_application_v1alpha1_ResourceSyncProgress(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ResourceSyncProgress holds the progress of the sync of a resource",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"group": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Group specifies the API group of the resource",
 							Type:        []string{"string"},
 							Format:      "",
 						},
@@ -6913,7 +8586,6 @@ func schema_pkg_apis_application_v1alpha1_ResourceResult(ref common.ReferenceCal
 					"namespace": {
 						SchemaProps: spec.SchemaProps{
 							Description: "Namespace specifies the target namespace of the resource",
-							Default:     "",
 							Type:        []string{"string"},
 							Format:      "",
 						},
@@ -6926,119 +8598,129 @@ func schema_pkg_apis_application_v1alpha1_ResourceResult(ref common.ReferenceCal
 							Format:      "",
 						},
 					},
-					"status": {
+					"syncPhase": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Status holds the final result of the sync. Will be empty if the resources is yet to be applied/pruned and is always zero-value for hooks",
+							Description: "SyncPhase is the phase of the sync in which the resource is synced",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"message": {
+					"stage": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Message contains an informational or error message for the last sync OR operation",
+							Description: "Stage is the stage of the sync of the resource",
+							Default:     "",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"hookType": {
+					"message": {
 						SchemaProps: spec.SchemaProps{
-							Description: "HookType specifies the type of the hook. Empty for non-hook resources",
+							Description: "Message contains an informational or error message about the sync of the resource",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"hookPhase": {
+					"hookType": {
 						SchemaProps: spec.SchemaProps{
-							Description: "HookPhase contains the state of any operation associated with this resource OR hook This can also contain values for non-hook resources.",
+							Description: "HookType specifies the type of the hook. Empty for non-hook resources",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"syncPhase": {
+					"logsPath": {
 						SchemaProps: spec.SchemaProps{
-							Description: "SyncPhase indicates the particular phase of the sync that this result was acquired in",
+							Description: "LogsPath is the path of the API returning the logs of a running hook",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
 				},
-				Required: []string{"group", "version", "kind", "namespace", "name"},
+				Required: []string{"kind", "name", "stage"},
 			},
 		},
 	}
 }
 
-func schema_pkg_apis_application_v1alpha1_ResourceStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_application_v1alpha1_ResourceTreeDiff(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "ResourceStatus holds the current sync and health status of a resource",
+				Description: "ResourceTreeDiff holds the changes of the resource tree of an application between two snapshots of the tree",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"group": {
-						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
-						},
-					},
-					"version": {
+					"from": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
-						},
-					},
-					"kind": {
-						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
-						},
-					},
-					"namespace": {
-						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
-						},
-					},
-					"name": {
-						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
+							Description: "From is the time of the snapshot the changes are computed from",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
 						},
 					},
-					"status": {
+					"to": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
+							Description: "To is the time of the snapshot the changes are computed to, or the current time if they are computed to the current tree",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
 						},
 					},
-					"health": {
+					"appeared": {
 						SchemaProps: spec.SchemaProps{
-							Ref: ref("github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.HealthStatus"),
+							Description: "Appeared holds the nodes which are in the tree at To but weren't at From",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]any{},
+										Ref:     ref("github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ResourceNode"),
+									},
+								},
+							},
 						},
 					},
-					"hook": {
+					"disappeared": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"boolean"},
-							Format: "",
+							Description: "Disappeared holds the nodes which were in the tree at From but aren't at To",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]any{},
+										Ref:     ref("github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ResourceNode"),
+									},
+								},
+							},
 						},
 					},
-					"requiresPruning": {
+					"healthChanged": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"boolean"},
-							Format: "",
+							Description: "HealthChanged holds the nodes which are in both snapshots with a different health status",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]any{},
+										Ref:     ref("github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ResourceNodeHealthChange"),
+									},
+								},
+							},
 						},
 					},
-					"syncWave": {
+					"snapshots": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"integer"},
-							Format: "int64",
+							Description: "Snapshots holds the times of all the snapshots of the tree which are available",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+									},
+								},
+							},
 						},
 					},
 				},
+				Required: []string{"from", "to"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.HealthStatus"},
+			"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ResourceNode", "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ResourceNodeHealthChange", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
 	}
 }
 
@@ -7147,6 +8829,21 @@ func schema_pkg_apis_application_v1alpha1_RevisionHistory(ref common.ReferenceCa
 							Ref:         ref("github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.OperationInitiator"),
 						},
 					},
+					"versionConstraints": {
+						SchemaProps: spec.SchemaProps{
+							Description: "VersionConstraints contains the version constraints of the target revisions of the Helm sources, e.g. 1.2.*, which were resolved to the revisions the sync was performed against. It's empty for the sources whose target revision isn't a version constraint.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
 				},
 				Required: []string{"deployedAt", "id"},
 			},
@@ -7156,6 +8853,40 @@ func schema_pkg_apis_application_v1alpha1_RevisionHistory(ref common.ReferenceCa
 	}
 }
 
+func schema_pkg_apis_application_v1alpha1_RevisionHistoryRetention(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RevisionHistoryRetention controls which items of the revision history are kept in addition to the most recent ones",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"maxAge": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxAge is the maximum age of the history items, e.g. 720h. Older items are removed, except for the most recent one.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"keepDaily": {
+						SchemaProps: spec.SchemaProps{
+							Description: "KeepDaily keeps the most recent history item of each of the last N days with a deployment",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"keepWeekly": {
+						SchemaProps: spec.SchemaProps{
+							Description: "KeepWeekly keeps the most recent history item of each of the last N weeks with a deployment",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 func schema_pkg_apis_application_v1alpha1_RevisionMetadata(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -7883,6 +9614,43 @@ func schema_pkg_apis_application_v1alpha1_SourceHydratorStatus(ref common.Refere
 	}
 }
 
+func schema_pkg_apis_application_v1alpha1_SourceNamespaceRule(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "SourceNamespaceRule configures the applications of a project in the namespaces matching a pattern. The patterns form a hierarchy: when several patterns match a namespace, e.g. team-* and team-a-*, the most specific one applies.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"pattern": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Pattern is the glob pattern of the namespaces, e.g. team-a-*",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"maxApps": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxApps is the maximum number of applications of the project in each namespace matching the pattern. There's no limit if it's 0.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"defaultDestination": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DefaultDestination is the destination of the applications created in the namespaces matching the pattern without a destination cluster",
+							Ref:         ref("github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ApplicationDestination"),
+						},
+					},
+				},
+				Required: []string{"pattern"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ApplicationDestination"},
+	}
+}
+
 func schema_pkg_apis_application_v1alpha1_SuccessfulHydrateOperation(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -7919,6 +9687,33 @@ func schema_pkg_apis_application_v1alpha1_SuccessfulHydrateOperation(ref common.
 	}
 }
 
+func schema_pkg_apis_application_v1alpha1_SuppressedDifference(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "SuppressedDifference is a difference between the live and target state of a resource which is hidden by an ignoreDifferences rule of the application or of the resource customizations.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"path": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Path is the JSON pointer of the field whose difference is hidden (e.g., \"/spec/replicas\").",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"rule": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Rule describes the rule hiding the difference (e.g., \"spec.ignoreDifferences[0]: jsonPointer /spec/replicas\").",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 func schema_pkg_apis_application_v1alpha1_SyncOperation(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -8199,6 +9994,13 @@ func schema_pkg_apis_application_v1alpha1_SyncPolicy(ref common.ReferenceCallbac
 							Ref:         ref("github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1.ManagedNamespaceMetadata"),
 						},
 					},
+					"waitForRollouts": {
+						SchemaProps: spec.SchemaProps{
+							Description: "WaitForRollouts makes the syncs wait for the Argo Rollouts of the application which are in progress, i.e. progressing or paused, to complete or abort before applying the new revision",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
 				},
 			},
 		},
@@ -8315,6 +10117,36 @@ func schema_pkg_apis_application_v1alpha1_SyncStatus(ref common.ReferenceCallbac
 							},
 						},
 					},
+					"pinnedRevisions": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PinnedRevisions contains the revisions the sources of multiple sources are pinned to, overriding their target revisions. It's empty for the sources which aren't pinned.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"versionConstraints": {
+						SchemaProps: spec.SchemaProps{
+							Description: "VersionConstraints contains the version constraints of the target revisions of the Helm sources, e.g. 1.2.*, which were resolved to the revisions the comparison has been performed to. It's empty for the sources whose target revision isn't a version constraint.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
 				},
 				Required: []string{"status"},
 			},
@@ -8567,6 +10399,49 @@ func schema_pkg_apis_application_v1alpha1_TagFilter(ref common.ReferenceCallback
 	}
 }
 
+func schema_pkg_apis_application_v1alpha1_TokenProviderConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "TokenProviderConfig is the configuration of a built-in provider minting short-lived tokens of the cluster with the cloud workload identity of the Argo CD components. The tokens are refreshed before they expire.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"type": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Type is the type of the provider: aws for EKS clusters with AWS IAM (e.g. IRSA), gcp for GKE clusters with GCP workload identity, or azure for AKS clusters with Azure workload identity",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"clusterName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ClusterName is the name of the EKS cluster, required by the aws provider",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"roleARN": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RoleARN is the ARN of an IAM role assumed by the aws provider to mint the tokens, instead of using the IAM identity of the component",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"serverApplicationID": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ServerApplicationID is the application ID of the AAD server of the AKS cluster used by the azure provider, which defaults to the ID of the AKS AAD server application",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"type"},
+			},
+		},
+	}
+}
+
 func schema_pkg_apis_application_v1alpha1_objectMeta(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
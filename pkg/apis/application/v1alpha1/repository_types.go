@@ -11,6 +11,7 @@ import (
 	"github.com/argoproj/argo-cd/v3/util/cert"
 	"github.com/argoproj/argo-cd/v3/util/git"
 	"github.com/argoproj/argo-cd/v3/util/helm"
+	"github.com/argoproj/argo-cd/v3/util/proxy"
 	"github.com/argoproj/argo-cd/v3/util/workloadidentity"
 
 	log "github.com/sirupsen/logrus"
@@ -57,6 +58,16 @@ type RepoCreds struct {
 	BearerToken string `json:"bearerToken,omitempty" protobuf:"bytes,25,opt,name=bearerToken"`
 	// InsecureOCIForceHttp specifies whether the connection to the repository uses TLS at _all_. If true, no TLS. This flag is applicable for OCI repos only.
 	InsecureOCIForceHttp bool `json:"insecureOCIForceHttp,omitempty" protobuf:"bytes,26,opt,name=insecureOCIForceHttp"` //nolint:revive //FIXME(var-naming)
+	// OIDCTokenExchangeURL specifies the URL of the OAuth 2.0 token exchange endpoint, which exchanges the OIDC token of the workload for a short-lived access token to the repo (only Git repos)
+	OIDCTokenExchangeURL string `json:"oidcTokenExchangeURL,omitempty" protobuf:"bytes,27,opt,name=oidcTokenExchangeURL"`
+	// OIDCTokenExchangeAudience specifies the audience of the access token requested from the token exchange endpoint
+	OIDCTokenExchangeAudience string `json:"oidcTokenExchangeAudience,omitempty" protobuf:"bytes,28,opt,name=oidcTokenExchangeAudience"`
+	// UseKerberos specifies whether to authenticate with the Kerberos tickets of the repo server using SPNEGO (only Git repos served over HTTP(S))
+	UseKerberos bool `json:"useKerberos,omitempty" protobuf:"bytes,29,opt,name=useKerberos"`
+	// ProxyUsername specifies the username used to authenticate at the proxy
+	ProxyUsername string `json:"proxyUsername,omitempty" protobuf:"bytes,30,opt,name=proxyUsername"`
+	// ProxyPassword specifies the password used to authenticate at the proxy
+	ProxyPassword string `json:"proxyPassword,omitempty" protobuf:"bytes,31,opt,name=proxyPassword"`
 }
 
 // Repository is a repository holding application configurations
@@ -114,6 +125,16 @@ type Repository struct {
 	BearerToken string `json:"bearerToken,omitempty" protobuf:"bytes,25,opt,name=bearerToken"`
 	// InsecureOCIForceHttp specifies whether the connection to the repository uses TLS at _all_. If true, no TLS. This flag is applicable for OCI repos only.
 	InsecureOCIForceHttp bool `json:"insecureOCIForceHttp,omitempty" protobuf:"bytes,26,opt,name=insecureOCIForceHttp"` //nolint:revive //FIXME(var-naming)
+	// OIDCTokenExchangeURL specifies the URL of the OAuth 2.0 token exchange endpoint, which exchanges the OIDC token of the workload for a short-lived access token to the repo (only Git repos)
+	OIDCTokenExchangeURL string `json:"oidcTokenExchangeURL,omitempty" protobuf:"bytes,27,opt,name=oidcTokenExchangeURL"`
+	// OIDCTokenExchangeAudience specifies the audience of the access token requested from the token exchange endpoint
+	OIDCTokenExchangeAudience string `json:"oidcTokenExchangeAudience,omitempty" protobuf:"bytes,28,opt,name=oidcTokenExchangeAudience"`
+	// UseKerberos specifies whether to authenticate with the Kerberos tickets of the repo server using SPNEGO (only Git repos served over HTTP(S))
+	UseKerberos bool `json:"useKerberos,omitempty" protobuf:"bytes,29,opt,name=useKerberos"`
+	// ProxyUsername specifies the username used to authenticate at the proxy
+	ProxyUsername string `json:"proxyUsername,omitempty" protobuf:"bytes,30,opt,name=proxyUsername"`
+	// ProxyPassword specifies the password used to authenticate at the proxy
+	ProxyPassword string `json:"proxyPassword,omitempty" protobuf:"bytes,31,opt,name=proxyPassword"`
 }
 
 // IsInsecure returns true if the repository has been configured to skip server verification or set to HTTP only
@@ -128,7 +149,7 @@ func (repo *Repository) IsLFSEnabled() bool {
 
 // HasCredentials returns true when the repository has been configured with any credentials
 func (repo *Repository) HasCredentials() bool {
-	return repo.Username != "" || repo.Password != "" || repo.BearerToken != "" || repo.SSHPrivateKey != "" || repo.TLSClientCertData != "" || repo.GithubAppPrivateKey != "" || repo.UseAzureWorkloadIdentity
+	return repo.Username != "" || repo.Password != "" || repo.BearerToken != "" || repo.SSHPrivateKey != "" || repo.TLSClientCertData != "" || repo.GithubAppPrivateKey != "" || repo.UseAzureWorkloadIdentity || repo.OIDCTokenExchangeURL != "" || repo.UseKerberos
 }
 
 // CopyCredentialsFromRepo copies all credential information from source repository to receiving repository
@@ -167,9 +188,14 @@ func (repo *Repository) CopyCredentialsFromRepo(source *Repository) {
 		if repo.GCPServiceAccountKey == "" {
 			repo.GCPServiceAccountKey = source.GCPServiceAccountKey
 		}
+		if repo.OIDCTokenExchangeURL == "" {
+			repo.OIDCTokenExchangeURL = source.OIDCTokenExchangeURL
+			repo.OIDCTokenExchangeAudience = source.OIDCTokenExchangeAudience
+		}
 		repo.InsecureOCIForceHttp = source.InsecureOCIForceHttp
 		repo.ForceHttpBasicAuth = source.ForceHttpBasicAuth
 		repo.UseAzureWorkloadIdentity = source.UseAzureWorkloadIdentity
+		repo.UseKerberos = source.UseKerberos
 	}
 }
 
@@ -209,9 +235,17 @@ func (repo *Repository) CopyCredentialsFrom(source *RepoCreds) {
 		if repo.GCPServiceAccountKey == "" {
 			repo.GCPServiceAccountKey = source.GCPServiceAccountKey
 		}
+		if repo.OIDCTokenExchangeURL == "" {
+			repo.OIDCTokenExchangeURL = source.OIDCTokenExchangeURL
+			repo.OIDCTokenExchangeAudience = source.OIDCTokenExchangeAudience
+		}
 		if repo.Proxy == "" {
 			repo.Proxy = source.Proxy
 		}
+		if repo.ProxyUsername == "" {
+			repo.ProxyUsername = source.ProxyUsername
+			repo.ProxyPassword = source.ProxyPassword
+		}
 		if repo.NoProxy == "" {
 			repo.NoProxy = source.NoProxy
 		}
@@ -223,9 +257,16 @@ func (repo *Repository) CopyCredentialsFrom(source *RepoCreds) {
 		repo.InsecureOCIForceHttp = source.InsecureOCIForceHttp
 		repo.ForceHttpBasicAuth = source.ForceHttpBasicAuth
 		repo.UseAzureWorkloadIdentity = source.UseAzureWorkloadIdentity
+		repo.UseKerberos = source.UseKerberos
 	}
 }
 
+// GetProxy returns the URL of the HTTP/HTTPS proxy used to access the repo, which authenticates with the credentials
+// of the proxy, if any
+func (repo *Repository) GetProxy() string {
+	return proxy.WithCredentials(repo.Proxy, repo.ProxyUsername, repo.ProxyPassword)
+}
+
 // GetGitCreds returns the credentials from a repository configuration used to authenticate at a Git repository
 func (repo *Repository) GetGitCreds(store git.CredsStore) git.Creds {
 	if repo == nil {
@@ -235,10 +276,10 @@ func (repo *Repository) GetGitCreds(store git.CredsStore) git.Creds {
 		return git.NewHTTPSCreds(repo.Username, repo.Password, repo.BearerToken, repo.TLSClientCertData, repo.TLSClientCertKey, repo.IsInsecure(), store, repo.ForceHttpBasicAuth)
 	}
 	if repo.SSHPrivateKey != "" {
-		return git.NewSSHCreds(repo.SSHPrivateKey, getCAPath(repo.Repo), repo.IsInsecure(), repo.Proxy)
+		return git.NewSSHCreds(repo.SSHPrivateKey, getCAPath(repo.Repo), repo.IsInsecure(), repo.GetProxy())
 	}
 	if repo.GithubAppPrivateKey != "" && repo.GithubAppId != 0 && repo.GithubAppInstallationId != 0 {
-		return git.NewGitHubAppCreds(repo.GithubAppId, repo.GithubAppInstallationId, repo.GithubAppPrivateKey, repo.GitHubAppEnterpriseBaseURL, repo.TLSClientCertData, repo.TLSClientCertKey, repo.IsInsecure(), repo.Proxy, repo.NoProxy, store)
+		return git.NewGitHubAppCreds(repo.GithubAppId, repo.GithubAppInstallationId, repo.GithubAppPrivateKey, repo.GitHubAppEnterpriseBaseURL, repo.TLSClientCertData, repo.TLSClientCertKey, repo.IsInsecure(), repo.GetProxy(), repo.NoProxy, store)
 	}
 	if repo.GCPServiceAccountKey != "" {
 		return git.NewGoogleCloudCreds(repo.GCPServiceAccountKey, store)
@@ -246,6 +287,12 @@ func (repo *Repository) GetGitCreds(store git.CredsStore) git.Creds {
 	if repo.UseAzureWorkloadIdentity {
 		return git.NewAzureWorkloadIdentityCreds(store, workloadidentity.NewWorkloadIdentityTokenProvider())
 	}
+	if repo.OIDCTokenExchangeURL != "" {
+		return git.NewOIDCTokenExchangeCreds(repo.Username, repo.OIDCTokenExchangeURL, repo.OIDCTokenExchangeAudience, repo.IsInsecure(), repo.GetProxy(), repo.NoProxy, store)
+	}
+	if repo.UseKerberos {
+		return git.NewKerberosCreds(repo.IsInsecure())
+	}
 	return git.NopCreds{}
 }
 
@@ -353,6 +400,7 @@ func (repo *Repository) Sanitized() *Repository {
 		EnableOCI:                  repo.EnableOCI,
 		Proxy:                      repo.Proxy,
 		NoProxy:                    repo.NoProxy,
+		ProxyUsername:              repo.ProxyUsername,
 		Project:                    repo.Project,
 		ForceHttpBasicAuth:         repo.ForceHttpBasicAuth,
 		InheritedCreds:             repo.InheritedCreds,
@@ -360,6 +408,7 @@ func (repo *Repository) Sanitized() *Repository {
 		GithubAppInstallationId:    repo.GithubAppInstallationId,
 		GitHubAppEnterpriseBaseURL: repo.GitHubAppEnterpriseBaseURL,
 		UseAzureWorkloadIdentity:   repo.UseAzureWorkloadIdentity,
+		UseKerberos:                repo.UseKerberos,
 	}
 }
 
@@ -2,6 +2,7 @@ package v1alpha1
 
 import (
 	"encoding/json"
+	"encoding/pem"
 	"errors"
 	"fmt"
 	"maps"
@@ -37,6 +38,7 @@ import (
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/tools/clientcmd/api"
+	"k8s.io/client-go/transport"
 	"sigs.k8s.io/yaml"
 
 	"github.com/argoproj/argo-cd/v3/util/rbac"
@@ -45,6 +47,7 @@ import (
 	"github.com/argoproj/argo-cd/v3/util/env"
 	"github.com/argoproj/argo-cd/v3/util/helm"
 	utilhttp "github.com/argoproj/argo-cd/v3/util/http"
+	"github.com/argoproj/argo-cd/v3/util/proxy"
 	"github.com/argoproj/argo-cd/v3/util/security"
 )
 
@@ -92,6 +95,23 @@ type ApplicationSpec struct {
 
 	// SourceHydrator provides a way to push hydrated manifests back to git before syncing them to the cluster.
 	SourceHydrator *SourceHydrator `json:"sourceHydrator,omitempty" protobuf:"bytes,9,opt,name=sourceHydrator"`
+
+	// RevisionHistoryRetention keeps items of the revision history by age and by day or week, in addition to the ones kept by the revision history limit.
+	RevisionHistoryRetention *RevisionHistoryRetention `json:"revisionHistoryRetention,omitempty" protobuf:"bytes,10,opt,name=revisionHistoryRetention"`
+
+	// ResourceExclusions are the resources which the application doesn't track, in addition to the ones excluded by its project or in the settings
+	ResourceExclusions []ResourceFilterRule `json:"resourceExclusions,omitempty" protobuf:"bytes,12,rep,name=resourceExclusions"`
+}
+
+// ResourceFilterRule matches resources by API group, kind and cluster, like the rules of the resource.exclusions and
+// resource.inclusions settings
+type ResourceFilterRule struct {
+	// APIGroups are the glob patterns of the API groups of the resources, all the groups if empty
+	APIGroups []string `json:"apiGroups,omitempty" protobuf:"bytes,1,rep,name=apiGroups"`
+	// Kinds are the kinds of the resources, all the kinds if empty or "*"
+	Kinds []string `json:"kinds,omitempty" protobuf:"bytes,2,rep,name=kinds"`
+	// Clusters are the glob patterns of the server URLs of the clusters of the resources, all the clusters if empty
+	Clusters []string `json:"clusters,omitempty" protobuf:"bytes,3,rep,name=clusters"`
 }
 
 type IgnoreDifferences []ResourceIgnoreDifferences
@@ -311,6 +331,26 @@ func (source *ApplicationSource) IsRef() bool {
 	return source.Ref != ""
 }
 
+// ApplicationRefURLPrefix is the prefix of the repoURL of the ref sources referencing the outputs of another
+// application, e.g. argocd-app://<namespace>/<name>
+const ApplicationRefURLPrefix = "argocd-app://"
+
+// IsApplicationRef returns true when the application source is a ref source referencing the outputs of another
+// application instead of a repository
+func (source *ApplicationSource) IsApplicationRef() bool {
+	return source.IsRef() && strings.HasPrefix(source.RepoURL, ApplicationRefURLPrefix)
+}
+
+// ApplicationRef returns the namespace and the name of the application referenced by the ref source. The namespace
+// is empty if the repoURL doesn't specify it.
+func (source *ApplicationSource) ApplicationRef() (string, string) {
+	ref := strings.TrimSuffix(strings.TrimPrefix(source.RepoURL, ApplicationRefURLPrefix), "/")
+	if namespace, name, ok := strings.Cut(ref, "/"); ok {
+		return namespace, name
+	}
+	return "", ref
+}
+
 // IsHelm returns true when the application source is of type Helm
 func (source *ApplicationSource) IsHelm() bool {
 	return source.Chart != ""
@@ -503,7 +543,7 @@ type ApplicationSourceHelm struct {
 	Values string `json:"values,omitempty" patchStrategy:"replace" protobuf:"bytes,4,opt,name=values"`
 	// FileParameters are file parameters to the helm template
 	FileParameters []HelmFileParameter `json:"fileParameters,omitempty" protobuf:"bytes,5,opt,name=fileParameters"`
-	// Version is the Helm version to use for templating ("3")
+	// Version is the Helm version to use for templating, either "v3" or the name of a Helm version registered in the settings with a helm.path.<name> key
 	Version string `json:"version,omitempty" protobuf:"bytes,6,opt,name=version"`
 	// PassCredentials pass credentials to all domains (Helm's --pass-credentials)
 	PassCredentials bool `json:"passCredentials,omitempty" protobuf:"bytes,7,opt,name=passCredentials"`
@@ -1167,6 +1207,30 @@ type ApplicationStatus struct {
 	ControllerNamespace string `json:"controllerNamespace,omitempty" protobuf:"bytes,13,opt,name=controllerNamespace"`
 	// SourceHydrator stores information about the current state of source hydration
 	SourceHydrator SourceHydratorStatus `json:"sourceHydrator,omitempty" protobuf:"bytes,14,opt,name=sourceHydrator"`
+	// Freeze contains the freeze of the application, if it is frozen
+	Freeze *ApplicationFreeze `json:"freeze,omitempty" protobuf:"bytes,15,opt,name=freeze"`
+	// ToolVersions are the Helm, Kustomize or config management plugin versions which rendered the manifests of the sources, in the order of the sources, empty for the default versions
+	ToolVersions []string `json:"toolVersions,omitempty" protobuf:"bytes,16,rep,name=toolVersions"`
+}
+
+// ApplicationFreeze holds the freeze of an application, which disables its automated sync and self heal, and optionally
+// its refresh, until the freeze expires or the application is thawed
+type ApplicationFreeze struct {
+	// FrozenBy is the user who froze the application
+	FrozenBy string `json:"frozenBy,omitempty" protobuf:"bytes,1,opt,name=frozenBy"`
+	// Reason is the reason why the application was frozen
+	Reason string `json:"reason,omitempty" protobuf:"bytes,2,opt,name=reason"`
+	// FrozenAt is the time at which the application was frozen
+	FrozenAt metav1.Time `json:"frozenAt" protobuf:"bytes,3,opt,name=frozenAt"`
+	// Until is the time at which the application is thawed, the application being frozen until it is thawed manually if not set
+	Until *metav1.Time `json:"until,omitempty" protobuf:"bytes,4,opt,name=until"`
+	// NoRefresh disables the refresh of the application in addition to its automated sync and self heal
+	NoRefresh bool `json:"noRefresh,omitempty" protobuf:"varint,5,opt,name=noRefresh"`
+}
+
+// IsActive returns whether the freeze is active at the given time
+func (f *ApplicationFreeze) IsActive(now time.Time) bool {
+	return f != nil && (f.Until == nil || now.Before(f.Until.Time))
 }
 
 // SourceHydratorStatus contains information about the current state of source hydration
@@ -1265,6 +1329,10 @@ type OperationInitiator struct {
 	Username string `json:"username,omitempty" protobuf:"bytes,1,opt,name=username"`
 	// Automated is set to true if operation was initiated automatically by the application controller.
 	Automated bool `json:"automated,omitempty" protobuf:"bytes,2,opt,name=automated"`
+	// Justification contains the justification of a break-glass sync
+	Justification string `json:"justification,omitempty" protobuf:"bytes,3,opt,name=justification"`
+	// ApprovedBy contains the name of the user who approved a break-glass sync
+	ApprovedBy string `json:"approvedBy,omitempty" protobuf:"bytes,4,opt,name=approvedBy"`
 }
 
 // Operation contains information about a requested or running operation
@@ -1277,6 +1345,13 @@ type Operation struct {
 	Info []*Info `json:"info,omitempty" protobuf:"bytes,3,name=info"`
 	// Retry controls the strategy to apply if a sync fails
 	Retry RetryStrategy `json:"retry,omitempty" protobuf:"bytes,4,opt,name=retry"`
+	// Cascade contains parameters for an operation performed on the child applications of the application
+	Cascade *CascadeOperation `json:"cascade,omitempty" protobuf:"bytes,5,opt,name=cascade"`
+	// Priority is the priority of the operation. The pending operations are resumed by order of decreasing priority when
+	// the application controller starts.
+	Priority int64 `json:"priority,omitempty" protobuf:"varint,6,opt,name=priority"`
+	// RequestedAt is the time at which the operation was requested
+	RequestedAt *metav1.Time `json:"requestedAt,omitempty" protobuf:"bytes,7,opt,name=requestedAt"`
 }
 
 // DryRun returns true if an operation was requested to be performed in dry run mode
@@ -1287,6 +1362,73 @@ func (o *Operation) DryRun() bool {
 	return false
 }
 
+// CascadeOperationType is the type of the operation performed on the child applications by a cascade operation
+type CascadeOperationType string
+
+const (
+	// CascadeOperationTypeSync syncs the child applications
+	CascadeOperationTypeSync CascadeOperationType = "Sync"
+	// CascadeOperationTypeRefresh refreshes the child applications
+	CascadeOperationTypeRefresh CascadeOperationType = "Refresh"
+)
+
+// CascadeOperation contains the parameters of an operation performed on the child applications of an application,
+// i.e. the applications it manages. The child applications are processed in waves, in the order of their
+// argocd.argoproj.io/cascade-wave annotation, and a wave is started once all the applications of the previous waves
+// completed: synced and healthy for a sync, reconciled for a refresh.
+type CascadeOperation struct {
+	// Type is the type of the operation performed on the child applications, Sync or Refresh
+	Type CascadeOperationType `json:"type" protobuf:"bytes,1,opt,name=type,casttype=CascadeOperationType"`
+	// Prune specifies to delete the resources of the child applications which are no longer tracked in git
+	Prune bool `json:"prune,omitempty" protobuf:"bytes,2,opt,name=prune"`
+	// Children are the child applications on which the operation is performed, which the user who initiated the operation is allowed to sync or refresh
+	Children []CascadeChild `json:"children,omitempty" protobuf:"bytes,3,rep,name=children"`
+}
+
+// CascadeChild identifies a child application of a cascade operation
+type CascadeChild struct {
+	// Name is the name of the child application
+	Name string `json:"name" protobuf:"bytes,1,opt,name=name"`
+	// Namespace is the namespace of the child application
+	Namespace string `json:"namespace,omitempty" protobuf:"bytes,2,opt,name=namespace"`
+}
+
+// CascadeOperationResult is the result of a cascade operation
+type CascadeOperationResult struct {
+	// Children holds the progress of the operation on each child application, in the order of their waves
+	Children []CascadeChildResult `json:"children,omitempty" protobuf:"bytes,1,rep,name=children"`
+}
+
+// CascadeChildResult holds the progress of a cascade operation on a child application
+type CascadeChildResult struct {
+	// Name is the name of the child application
+	Name string `json:"name" protobuf:"bytes,1,opt,name=name"`
+	// Namespace is the namespace of the child application
+	Namespace string `json:"namespace,omitempty" protobuf:"bytes,2,opt,name=namespace"`
+	// Wave is the wave of the child application
+	Wave int64 `json:"wave,omitempty" protobuf:"bytes,3,opt,name=wave"`
+	// Phase is the phase of the operation on the child application. Empty until the operation is started on it.
+	Phase synccommon.OperationPhase `json:"phase,omitempty" protobuf:"bytes,4,opt,name=phase"`
+	// Message holds the status of the operation on the child application
+	Message string `json:"message,omitempty" protobuf:"bytes,5,opt,name=message"`
+	// StartedAt is the time the operation was started on the child application
+	StartedAt *metav1.Time `json:"startedAt,omitempty" protobuf:"bytes,6,opt,name=startedAt"`
+}
+
+// GetCurrentWave returns the wave of the first child application whose operation isn't completed, and false if the
+// operation is completed on all the child applications
+func (r *CascadeOperationResult) GetCurrentWave() (int64, bool) {
+	if r == nil {
+		return 0, false
+	}
+	for _, child := range r.Children {
+		if !child.Phase.Completed() {
+			return child.Wave, true
+		}
+	}
+	return 0, false
+}
+
 // SyncOperationResource contains resources to sync.
 type SyncOperationResource struct {
 	Group     string `json:"group,omitempty" protobuf:"bytes,1,opt,name=group"`
@@ -1316,6 +1458,68 @@ func (in RevisionHistories) Trunc(n int) RevisionHistories {
 	return in
 }
 
+// RevisionHistoryRetention controls which items of the revision history are kept in addition to the most recent ones
+type RevisionHistoryRetention struct {
+	// MaxAge is the maximum age of the history items, e.g. 720h. Older items are removed, except for the most recent one.
+	MaxAge string `json:"maxAge,omitempty" protobuf:"bytes,1,opt,name=maxAge"`
+	// KeepDaily keeps the most recent history item of each of the last N days with a deployment
+	KeepDaily int64 `json:"keepDaily,omitempty" protobuf:"varint,2,opt,name=keepDaily"`
+	// KeepWeekly keeps the most recent history item of each of the last N weeks with a deployment
+	KeepWeekly int64 `json:"keepWeekly,omitempty" protobuf:"varint,3,opt,name=keepWeekly"`
+}
+
+// Retain returns the history items kept by the revision history limit and the retention policy at the given time,
+// along with the purged ones. Without a retention policy, the items are truncated to the limit.
+func (in RevisionHistories) Retain(limit int, retention *RevisionHistoryRetention, now time.Time) (RevisionHistories, RevisionHistories, error) {
+	var maxAge time.Duration
+	if retention != nil && retention.MaxAge != "" {
+		var err error
+		if maxAge, err = time.ParseDuration(retention.MaxAge); err != nil {
+			return in, nil, fmt.Errorf("invalid revision history max age %q: %w", retention.MaxAge, err)
+		}
+	}
+	kept := make([]bool, len(in))
+	for i := len(in) - 1; i >= 0 && i >= len(in)-limit; i-- {
+		kept[i] = true
+	}
+	if retention != nil {
+		keepPeriods := func(count int64, period func(t time.Time) string) {
+			periods := map[string]bool{}
+			for i := len(in) - 1; i >= 0 && int64(len(periods)) < count; i-- {
+				p := period(in[i].DeployedAt.UTC())
+				if !periods[p] {
+					periods[p] = true
+					kept[i] = true
+				}
+			}
+		}
+		keepPeriods(retention.KeepDaily, func(t time.Time) string {
+			return t.Format(time.DateOnly)
+		})
+		keepPeriods(retention.KeepWeekly, func(t time.Time) string {
+			year, week := t.ISOWeek()
+			return fmt.Sprintf("%d-%d", year, week)
+		})
+		if maxAge > 0 {
+			// the most recent item is kept regardless of its age, to be able to roll back to it
+			for i := 0; i < len(in)-1; i++ {
+				if now.Sub(in[i].DeployedAt.Time) > maxAge {
+					kept[i] = false
+				}
+			}
+		}
+	}
+	var result, purged RevisionHistories
+	for i, item := range in {
+		if kept[i] {
+			result = append(result, item)
+		} else {
+			purged = append(purged, item)
+		}
+	}
+	return result, purged, nil
+}
+
 // HasIdentity determines whether a sync operation is identified by a manifest
 func (r SyncOperationResource) HasIdentity(name string, namespace string, gvk schema.GroupVersionKind) bool {
 	if name == r.Name && gvk.Kind == r.Kind && gvk.Group == r.Group && (r.Namespace == "" || namespace == r.Namespace) {
@@ -1386,6 +1590,8 @@ type OperationState struct {
 	FinishedAt *metav1.Time `json:"finishedAt,omitempty" protobuf:"bytes,7,opt,name=finishedAt"`
 	// RetryCount contains time of operation retries
 	RetryCount int64 `json:"retryCount,omitempty" protobuf:"bytes,8,opt,name=retryCount"`
+	// CascadeResult is the result of a Cascade operation
+	CascadeResult *CascadeOperationResult `json:"cascadeResult,omitempty" protobuf:"bytes,9,opt,name=cascadeResult"`
 }
 
 type Info struct {
@@ -1442,6 +1648,8 @@ type SyncPolicy struct {
 	Retry *RetryStrategy `json:"retry,omitempty" protobuf:"bytes,3,opt,name=retry"`
 	// ManagedNamespaceMetadata controls metadata in the given namespace (if CreateNamespace=true)
 	ManagedNamespaceMetadata *ManagedNamespaceMetadata `json:"managedNamespaceMetadata,omitempty" protobuf:"bytes,4,opt,name=managedNamespaceMetadata"`
+	// WaitForRollouts makes the syncs wait for the Argo Rollouts of the application which are in progress, i.e. progressing or paused, to complete or abort before applying the new revision
+	WaitForRollouts bool `json:"waitForRollouts,omitempty" protobuf:"varint,5,opt,name=waitForRollouts"`
 	// If you add a field here, be sure to update IsZero.
 }
 
@@ -1455,7 +1663,7 @@ func (p *SyncPolicy) IsAutomatedSyncEnabled() bool {
 
 // IsZero returns true if the sync policy is empty
 func (p *SyncPolicy) IsZero() bool {
-	return p == nil || (p.Automated == nil && len(p.SyncOptions) == 0 && p.Retry == nil && p.ManagedNamespaceMetadata == nil)
+	return p == nil || (p.Automated == nil && len(p.SyncOptions) == 0 && p.Retry == nil && p.ManagedNamespaceMetadata == nil && !p.WaitForRollouts)
 }
 
 // RetryStrategy contains information about the strategy to apply when a sync failed
@@ -1683,6 +1891,22 @@ type ResourceResult struct {
 	SyncPhase synccommon.SyncPhase `json:"syncPhase,omitempty" protobuf:"bytes,10,opt,name=syncPhase"`
 	// Images contains the images related to the ResourceResult
 	Images []string `json:"images,omitempty" protobuf:"bytes,11,opt,name=images"`
+	// HookOutputs holds the final status of the containers of the pods of a completed hook. Their logs are served by
+	// the logs API.
+	HookOutputs []HookOutput `json:"hookOutputs,omitempty" protobuf:"bytes,12,rep,name=hookOutputs"`
+}
+
+// HookOutput holds the final status of a container of a pod of a hook, captured when the hook completes so that it can
+// be inspected after the pod is deleted
+type HookOutput struct {
+	// Pod is the name of the pod
+	Pod string `json:"pod" protobuf:"bytes,1,opt,name=pod"`
+	// Container is the name of the container
+	Container string `json:"container" protobuf:"bytes,2,opt,name=container"`
+	// Reason is the reason of the termination of the container
+	Reason string `json:"reason,omitempty" protobuf:"bytes,3,opt,name=reason"`
+	// ExitCode is the exit code of the container
+	ExitCode int64 `json:"exitCode,omitempty" protobuf:"varint,5,opt,name=exitCode"`
 }
 
 // GroupVersionKind returns the GVK schema information for a given resource within a sync result
@@ -1736,6 +1960,10 @@ type RevisionHistory struct {
 	Revisions []string `json:"revisions,omitempty" protobuf:"bytes,9,opt,name=revisions"`
 	// InitiatedBy contains information about who initiated the operations
 	InitiatedBy OperationInitiator `json:"initiatedBy,omitempty" protobuf:"bytes,10,opt,name=initiatedBy"`
+	// VersionConstraints contains the version constraints of the target revisions of the Helm sources, e.g. 1.2.*,
+	// which were resolved to the revisions the sync was performed against. It's empty for the sources whose target
+	// revision isn't a version constraint.
+	VersionConstraints []string `json:"versionConstraints,omitempty" protobuf:"bytes,11,opt,name=versionConstraints"`
 }
 
 // ApplicationWatchEvent contains information about application change.
@@ -1750,6 +1978,82 @@ type ApplicationWatchEvent struct {
 	Application Application `json:"application" protobuf:"bytes,2,opt,name=application"`
 }
 
+// SyncProgressStage is the stage of the sync of a resource
+type SyncProgressStage string
+
+const (
+	// SyncProgressStageQueued means that the resource waits for the previous sync waves to be synced
+	SyncProgressStageQueued SyncProgressStage = "queued"
+	// SyncProgressStageApplying means that the resource is being applied or pruned
+	SyncProgressStageApplying SyncProgressStage = "applying"
+	// SyncProgressStageWaitingHealth means that the resource is applied and the sync waits for it to become healthy
+	SyncProgressStageWaitingHealth SyncProgressStage = "waiting-health"
+	// SyncProgressStageHookRunning means that the hook is running
+	SyncProgressStageHookRunning SyncProgressStage = "hook-running"
+	// SyncProgressStageSucceeded means that the resource is synced, or that the hook succeeded
+	SyncProgressStageSucceeded SyncProgressStage = "succeeded"
+	// SyncProgressStageFailed means that the sync of the resource, or the hook, failed
+	SyncProgressStageFailed SyncProgressStage = "failed"
+)
+
+// ResourceSyncProgress holds the progress of the sync of a resource
+type ResourceSyncProgress struct {
+	// Group specifies the API group of the resource
+	Group string `json:"group,omitempty" protobuf:"bytes,1,opt,name=group"`
+	// Kind specifies the API kind of the resource
+	Kind string `json:"kind" protobuf:"bytes,2,opt,name=kind"`
+	// Namespace specifies the target namespace of the resource
+	Namespace string `json:"namespace,omitempty" protobuf:"bytes,3,opt,name=namespace"`
+	// Name specifies the name of the resource
+	Name string `json:"name" protobuf:"bytes,4,opt,name=name"`
+	// SyncPhase is the phase of the sync in which the resource is synced
+	SyncPhase synccommon.SyncPhase `json:"syncPhase,omitempty" protobuf:"bytes,5,opt,name=syncPhase"`
+	// Stage is the stage of the sync of the resource
+	Stage SyncProgressStage `json:"stage" protobuf:"bytes,6,opt,name=stage"`
+	// Message contains an informational or error message about the sync of the resource
+	Message string `json:"message,omitempty" protobuf:"bytes,7,opt,name=message"`
+	// HookType specifies the type of the hook. Empty for non-hook resources
+	HookType synccommon.HookType `json:"hookType,omitempty" protobuf:"bytes,8,opt,name=hookType"`
+	// LogsPath is the path of the API returning the logs of a running hook
+	LogsPath string `json:"logsPath,omitempty" protobuf:"bytes,9,opt,name=logsPath"`
+}
+
+// Key returns a key identifying the resource in the sync phase
+func (p *ResourceSyncProgress) Key() string {
+	return fmt.Sprintf("%s/%s/%s/%s/%s", p.SyncPhase, p.Group, p.Kind, p.Namespace, p.Name)
+}
+
+// ApplicationSyncProgressEvent contains the progress of the sync operation of an application. The first event of a
+// sync operation contains the progress of all its resources, and the next events only the resources whose progress
+// changed.
+type ApplicationSyncProgressEvent struct {
+	// Phase is the phase of the sync operation
+	Phase synccommon.OperationPhase `json:"phase" protobuf:"bytes,1,opt,name=phase"`
+	// Message contains an informational or error message about the sync operation
+	Message string `json:"message,omitempty" protobuf:"bytes,2,opt,name=message"`
+	// Resources holds the progress of the resources of the sync operation
+	Resources []ResourceSyncProgress `json:"resources,omitempty" protobuf:"bytes,3,rep,name=resources"`
+}
+
+// GetDelta returns the event holding only the resources whose progress changed since the given previous event, or nil
+// if neither the sync operation nor its resources changed
+func (e *ApplicationSyncProgressEvent) GetDelta(previous *ApplicationSyncProgressEvent) *ApplicationSyncProgressEvent {
+	previousByKey := make(map[string]ResourceSyncProgress, len(previous.Resources))
+	for _, res := range previous.Resources {
+		previousByKey[res.Key()] = res
+	}
+	delta := &ApplicationSyncProgressEvent{Phase: e.Phase, Message: e.Message}
+	for _, res := range e.Resources {
+		if prev, ok := previousByKey[res.Key()]; !ok || prev != res {
+			delta.Resources = append(delta.Resources, res)
+		}
+	}
+	if len(delta.Resources) == 0 && e.Phase == previous.Phase && e.Message == previous.Message {
+		return nil
+	}
+	return delta
+}
+
 // ApplicationList is list of Application resources
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 type ApplicationList struct {
@@ -1803,6 +2107,18 @@ const (
 	ApplicationConditionExcludedResourceWarning = "ExcludedResourceWarning"
 	// ApplicationConditionOrphanedResourceWarning indicates that application has orphaned resources
 	ApplicationConditionOrphanedResourceWarning = "OrphanedResourceWarning"
+	// ApplicationConditionPolicyViolationError indicates that application manifests violate a manifest policy of the project which blocks syncs
+	ApplicationConditionPolicyViolationError = "PolicyViolationError"
+	// ApplicationConditionPolicyViolationWarning indicates that application manifests violate a manifest policy of the project which only warns
+	ApplicationConditionPolicyViolationWarning = "PolicyViolationWarning"
+	// ApplicationConditionPlaintextSecretError indicates that application manifests contain Secrets with plaintext values, which blocks syncs
+	ApplicationConditionPlaintextSecretError = "PlaintextSecretError"
+	// ApplicationConditionPlaintextSecretWarning indicates that application manifests contain Secrets with plaintext values
+	ApplicationConditionPlaintextSecretWarning = "PlaintextSecretWarning"
+	// ApplicationConditionImageRegistryError indicates that application manifests reference images of registries which aren't allowed by the project
+	ApplicationConditionImageRegistryError = "ImageRegistryError"
+	// ApplicationConditionFlappingWarning indicates that the sync or health status of the application keeps changing back and forth
+	ApplicationConditionFlappingWarning = "FlappingWarning"
 )
 
 // ApplicationCondition contains details about an application condition, which is usually an error or warning
@@ -1837,6 +2153,13 @@ type SyncStatus struct {
 	Revision string `json:"revision,omitempty" protobuf:"bytes,3,opt,name=revision"`
 	// Revisions contains information about the revisions of multiple sources the comparison has been performed to
 	Revisions []string `json:"revisions,omitempty" protobuf:"bytes,4,opt,name=revisions"`
+	// PinnedRevisions contains the revisions the sources of multiple sources are pinned to, overriding their target
+	// revisions. It's empty for the sources which aren't pinned.
+	PinnedRevisions []string `json:"pinnedRevisions,omitempty" protobuf:"bytes,5,opt,name=pinnedRevisions"`
+	// VersionConstraints contains the version constraints of the target revisions of the Helm sources, e.g. 1.2.*,
+	// which were resolved to the revisions the comparison has been performed to. It's empty for the sources whose target
+	// revision isn't a version constraint.
+	VersionConstraints []string `json:"versionConstraints,omitempty" protobuf:"bytes,6,opt,name=versionConstraints"`
 }
 
 // AppHealthStatus contains information about the currently observed health state of an application
@@ -1924,6 +2247,17 @@ type ApplicationTree struct {
 	// ShardsCount represents the total number of shards the application tree is split into.
 	// This is used to distribute resource processing across multiple shards.
 	ShardsCount int64 `json:"shardsCount,omitempty" protobuf:"bytes,4,opt,name=shardsCount"`
+	// Version is the version of the tree sent by a resource tree watch stream, which is incremented with each message
+	// of the stream.
+	Version int64 `json:"version,omitempty" protobuf:"varint,5,opt,name=version"`
+	// Delta indicates that the tree only holds the changes since the previous version sent by a resource tree watch
+	// stream. The added and updated nodes are in Nodes and OrphanedNodes, the removed nodes are in RemovedNodes and
+	// RemovedOrphanedNodes, and Hosts holds all the hosts.
+	Delta bool `json:"delta,omitempty" protobuf:"varint,6,opt,name=delta"`
+	// RemovedNodes contains the references of the nodes removed since the previous version of a delta.
+	RemovedNodes []ResourceRef `json:"removedNodes,omitempty" protobuf:"bytes,7,rep,name=removedNodes"`
+	// RemovedOrphanedNodes contains the references of the orphaned nodes removed since the previous version of a delta.
+	RemovedOrphanedNodes []ResourceRef `json:"removedOrphanedNodes,omitempty" protobuf:"bytes,8,rep,name=removedOrphanedNodes"`
 }
 
 func (t *ApplicationTree) Merge(other *ApplicationTree) {
@@ -1978,6 +2312,42 @@ func (t *ApplicationTree) GetShards(size int64) []*ApplicationTree {
 	return shards
 }
 
+// GetDelta returns the changes of the tree since the given previous version of the tree, or nil if the tree is
+// unchanged. The added and updated nodes of the delta are in Nodes and OrphanedNodes, the removed nodes are in
+// RemovedNodes and RemovedOrphanedNodes, and Hosts holds all the hosts of the tree.
+func (t *ApplicationTree) GetDelta(previous *ApplicationTree) *ApplicationTree {
+	delta := &ApplicationTree{Delta: true, Hosts: t.Hosts, ShardsCount: t.ShardsCount}
+	delta.Nodes, delta.RemovedNodes = diffResourceNodes(previous.Nodes, t.Nodes)
+	delta.OrphanedNodes, delta.RemovedOrphanedNodes = diffResourceNodes(previous.OrphanedNodes, t.OrphanedNodes)
+	if len(delta.Nodes) == 0 && len(delta.RemovedNodes) == 0 && len(delta.OrphanedNodes) == 0 && len(delta.RemovedOrphanedNodes) == 0 &&
+		reflect.DeepEqual(previous.Hosts, t.Hosts) {
+		return nil
+	}
+	return delta
+}
+
+// diffResourceNodes returns the nodes added or updated since the previous nodes, and the references of the removed nodes
+func diffResourceNodes(previous []ResourceNode, current []ResourceNode) ([]ResourceNode, []ResourceRef) {
+	previousByRef := make(map[ResourceRef]*ResourceNode, len(previous))
+	for i := range previous {
+		previousByRef[previous[i].ResourceRef] = &previous[i]
+	}
+	var upserted []ResourceNode
+	for i := range current {
+		if node, ok := previousByRef[current[i].ResourceRef]; !ok || !reflect.DeepEqual(*node, current[i]) {
+			upserted = append(upserted, current[i])
+		}
+		delete(previousByRef, current[i].ResourceRef)
+	}
+	var removed []ResourceRef
+	for i := range previous {
+		if _, ok := previousByRef[previous[i].ResourceRef]; ok {
+			removed = append(removed, previous[i].ResourceRef)
+		}
+	}
+	return upserted, removed
+}
+
 // Normalize sorts application tree nodes and hosts. The persistent order allows to
 // effectively compare previously cached app tree and allows to unnecessary Redis requests.
 func (t *ApplicationTree) Normalize() {
@@ -2060,6 +2430,78 @@ func (t *ApplicationTree) GetSummary(app *Application) ApplicationSummary {
 	return ApplicationSummary{ExternalURLs: urls, Images: images}
 }
 
+// ResourceTreeDiff holds the changes of the resource tree of an application between two snapshots of the tree
+type ResourceTreeDiff struct {
+	// From is the time of the snapshot the changes are computed from
+	From metav1.Time `json:"from" protobuf:"bytes,1,opt,name=from"`
+	// To is the time of the snapshot the changes are computed to, or the current time if they are computed to the
+	// current tree
+	To metav1.Time `json:"to" protobuf:"bytes,2,opt,name=to"`
+	// Appeared holds the nodes which are in the tree at To but weren't at From
+	Appeared []ResourceNode `json:"appeared,omitempty" protobuf:"bytes,3,rep,name=appeared"`
+	// Disappeared holds the nodes which were in the tree at From but aren't at To
+	Disappeared []ResourceNode `json:"disappeared,omitempty" protobuf:"bytes,4,rep,name=disappeared"`
+	// HealthChanged holds the nodes which are in both snapshots with a different health status
+	HealthChanged []ResourceNodeHealthChange `json:"healthChanged,omitempty" protobuf:"bytes,5,rep,name=healthChanged"`
+	// Snapshots holds the times of all the snapshots of the tree which are available
+	Snapshots []metav1.Time `json:"snapshots,omitempty" protobuf:"bytes,6,rep,name=snapshots"`
+}
+
+// ResourceNodeHealthChange is a change of the health of a node of a resource tree
+type ResourceNodeHealthChange struct {
+	ResourceRef `json:",inline" protobuf:"bytes,1,opt,name=resourceRef"`
+	// Health is the health of the node at the end of the change
+	Health *HealthStatus `json:"health,omitempty" protobuf:"bytes,2,opt,name=health"`
+	// PreviousHealth is the health of the node at the start of the change
+	PreviousHealth *HealthStatus `json:"previousHealth,omitempty" protobuf:"bytes,3,opt,name=previousHealth"`
+}
+
+// NewResourceTreeDiff returns the nodes which appeared, disappeared or changed health between two versions of the
+// nodes of a resource tree. The nodes are identified by their group, kind, namespace and name, and the changes are
+// sorted by the full name of the nodes.
+func NewResourceTreeDiff(from []ResourceNode, to []ResourceNode) *ResourceTreeDiff {
+	diff := &ResourceTreeDiff{}
+	fromByName := make(map[string]*ResourceNode, len(from))
+	for i := range from {
+		fromByName[from[i].FullName()] = &from[i]
+	}
+	toByName := make(map[string]bool, len(to))
+	for i := range to {
+		node := to[i]
+		toByName[node.FullName()] = true
+		previous, ok := fromByName[node.FullName()]
+		switch {
+		case !ok:
+			diff.Appeared = append(diff.Appeared, node)
+		case healthStatusCode(previous.Health) != healthStatusCode(node.Health):
+			diff.HealthChanged = append(diff.HealthChanged, ResourceNodeHealthChange{ResourceRef: node.ResourceRef, Health: node.Health, PreviousHealth: previous.Health})
+		}
+	}
+	for i := range from {
+		if !toByName[from[i].FullName()] {
+			diff.Disappeared = append(diff.Disappeared, from[i])
+		}
+	}
+	sort.Slice(diff.Appeared, func(i, j int) bool {
+		return diff.Appeared[i].FullName() < diff.Appeared[j].FullName()
+	})
+	sort.Slice(diff.Disappeared, func(i, j int) bool {
+		return diff.Disappeared[i].FullName() < diff.Disappeared[j].FullName()
+	})
+	sort.Slice(diff.HealthChanged, func(i, j int) bool {
+		left, right := ResourceNode{ResourceRef: diff.HealthChanged[i].ResourceRef}, ResourceNode{ResourceRef: diff.HealthChanged[j].ResourceRef}
+		return left.FullName() < right.FullName()
+	})
+	return diff
+}
+
+func healthStatusCode(healthStatus *HealthStatus) health.HealthStatusCode {
+	if healthStatus == nil {
+		return ""
+	}
+	return healthStatus.Status
+}
+
 // ResourceRef includes fields which uniquely identify a resource
 type ResourceRef struct {
 	Group     string `json:"group,omitempty" protobuf:"bytes,1,opt,name=group"`
@@ -2133,6 +2575,8 @@ type ResourceStatus struct {
 	SyncWave int64 `json:"syncWave,omitempty" protobuf:"bytes,10,opt,name=syncWave"`
 	// RequiresDeletionConfirmation is true if the resource requires explicit user confirmation before deletion.
 	RequiresDeletionConfirmation bool `json:"requiresDeletionConfirmation,omitempty" protobuf:"bytes,11,opt,name=requiresDeletionConfirmation"`
+	// DryRunError is the failure of the server-side dry-run apply of the resource performed by the server-side diff, if any.
+	DryRunError *ResourceDryRunError `json:"dryRunError,omitempty" protobuf:"bytes,12,opt,name=dryRunError"`
 }
 
 // GroupVersionKind returns the GVK schema type for given resource status
@@ -2170,6 +2614,40 @@ type ResourceDiff struct {
 	ResourceVersion string `json:"resourceVersion,omitempty" protobuf:"bytes,11,opt,name=resourceVersion"`
 	// Modified indicates whether the live resource has changes compared to the target resource.
 	Modified bool `json:"modified,omitempty" protobuf:"bytes,12,opt,name=modified"`
+	// SuppressedDiff lists the differences between the live and target resource which are hidden by ignoreDifferences rules.
+	SuppressedDiff []SuppressedDifference `json:"suppressedDiff,omitempty" protobuf:"bytes,13,rep,name=suppressedDiff"`
+	// DryRunError is the failure of the server-side dry-run apply of the resource performed by the server-side diff, if any.
+	DryRunError *ResourceDryRunError `json:"dryRunError,omitempty" protobuf:"bytes,14,opt,name=dryRunError"`
+}
+
+// ResourceDryRunErrorReason is the reason why the server-side dry-run apply of a resource failed
+type ResourceDryRunErrorReason = string
+
+const (
+	// ResourceDryRunErrorReasonWebhookRejected indicates that an admission webhook rejected the resource
+	ResourceDryRunErrorReasonWebhookRejected ResourceDryRunErrorReason = "WebhookRejected"
+	// ResourceDryRunErrorReasonFieldConflict indicates that fields of the resource are managed by another field manager
+	ResourceDryRunErrorReasonFieldConflict ResourceDryRunErrorReason = "FieldConflict"
+	// ResourceDryRunErrorReasonFailed indicates that the dry-run apply failed for another reason
+	ResourceDryRunErrorReasonFailed ResourceDryRunErrorReason = "Failed"
+)
+
+// ResourceDryRunError is the failure of the server-side dry-run apply of a resource. The resource is then diffed
+// without server-side diff, and the sync of the resource is expected to fail for the same reason.
+type ResourceDryRunError struct {
+	// Reason is the reason why the dry-run apply failed (e.g., "WebhookRejected", "FieldConflict" or "Failed").
+	Reason ResourceDryRunErrorReason `json:"reason,omitempty" protobuf:"bytes,1,opt,name=reason"`
+	// Message is the error returned by the dry-run apply.
+	Message string `json:"message,omitempty" protobuf:"bytes,2,opt,name=message"`
+}
+
+// SuppressedDifference is a difference between the live and target state of a resource which is hidden by an
+// ignoreDifferences rule of the application or of the resource customizations.
+type SuppressedDifference struct {
+	// Path is the JSON pointer of the field whose difference is hidden (e.g., "/spec/replicas").
+	Path string `json:"path,omitempty" protobuf:"bytes,1,opt,name=path"`
+	// Rule describes the rule hiding the difference (e.g., "spec.ignoreDifferences[0]: jsonPointer /spec/replicas").
+	Rule string `json:"rule,omitempty" protobuf:"bytes,2,opt,name=rule"`
 }
 
 // FullName returns full name of a node that was used for diffing in the format "group/kind/namespace/name"
@@ -2232,6 +2710,18 @@ type Cluster struct {
 	Labels map[string]string `json:"labels,omitempty" protobuf:"bytes,12,opt,name=labels"`
 	// Annotations for cluster secret metadata
 	Annotations map[string]string `json:"annotations,omitempty" protobuf:"bytes,13,opt,name=annotations"`
+	// Holds list of API groups whose resources are cached by the controller, in addition to the core API group which is always cached. Resources of all API groups are cached if the list is empty.
+	ResourceGroups []string `json:"resourceGroups,omitempty" protobuf:"bytes,14,opt,name=resourceGroups"`
+	// CacheResyncPeriod is the period of the full re-synchronization of the cluster cache, e.g. 12h. The period set for the controller is used if empty.
+	CacheResyncPeriod string `json:"cacheResyncPeriod,omitempty" protobuf:"bytes,15,opt,name=cacheResyncPeriod"`
+	// CacheSyncRetryTimeout is the time to wait before retrying to synchronize the cluster cache after a watch or sync failure, e.g. 30s. The timeout set for the controller is used if empty.
+	CacheSyncRetryTimeout string `json:"cacheSyncRetryTimeout,omitempty" protobuf:"bytes,16,opt,name=cacheSyncRetryTimeout"`
+	// CacheListPageSize is the number of resources requested per page when listing the resources of the cluster. The page size set for the controller is used if zero.
+	CacheListPageSize int64 `json:"cacheListPageSize,omitempty" protobuf:"varint,17,opt,name=cacheListPageSize"`
+	// MinReadyNodes is the minimum number of ready nodes of the cluster for the syncs to the cluster to start. The nodes aren't checked if zero.
+	MinReadyNodes int64 `json:"minReadyNodes,omitempty" protobuf:"varint,18,opt,name=minReadyNodes"`
+	// Draining defers the syncs to the cluster, e.g. while the cluster is drained or upgraded
+	Draining bool `json:"draining,omitempty" protobuf:"varint,19,opt,name=draining"`
 }
 
 // Equals returns true if two cluster objects are considered to be equal
@@ -2245,6 +2735,15 @@ func (c *Cluster) Equals(other *Cluster) bool {
 	if strings.Join(c.Namespaces, ",") != strings.Join(other.Namespaces, ",") {
 		return false
 	}
+	if strings.Join(c.ResourceGroups, ",") != strings.Join(other.ResourceGroups, ",") {
+		return false
+	}
+	if c.CacheResyncPeriod != other.CacheResyncPeriod || c.CacheSyncRetryTimeout != other.CacheSyncRetryTimeout || c.CacheListPageSize != other.CacheListPageSize {
+		return false
+	}
+	if c.MinReadyNodes != other.MinReadyNodes || c.Draining != other.Draining {
+		return false
+	}
 	var shard int64 = -1
 	if c.Shard != nil {
 		shard = *c.Shard
@@ -2341,6 +2840,32 @@ type ExecProviderConfig struct {
 	InstallHint string `json:"installHint,omitempty" protobuf:"bytes,5,opt,name=installHint"`
 }
 
+// TokenProviderConfig is the configuration of a built-in provider minting short-lived tokens of the cluster with the
+// cloud workload identity of the Argo CD components. The tokens are refreshed before they expire.
+type TokenProviderConfig struct {
+	// Type is the type of the provider: aws for EKS clusters with AWS IAM (e.g. IRSA), gcp for GKE clusters with GCP
+	// workload identity, or azure for AKS clusters with Azure workload identity
+	Type string `json:"type" protobuf:"bytes,1,opt,name=type"`
+	// ClusterName is the name of the EKS cluster, required by the aws provider
+	ClusterName string `json:"clusterName,omitempty" protobuf:"bytes,2,opt,name=clusterName"`
+	// RoleARN is the ARN of an IAM role assumed by the aws provider to mint the tokens, instead of using the IAM identity of the component
+	RoleARN string `json:"roleARN,omitempty" protobuf:"bytes,3,opt,name=roleARN"`
+	// ServerApplicationID is the application ID of the AAD server of the AKS cluster used by the azure provider, which defaults to the ID of the AKS AAD server application
+	ServerApplicationID string `json:"serverApplicationID,omitempty" protobuf:"bytes,4,opt,name=serverApplicationID"`
+}
+
+// ClusterTokenSourceFunc returns the source of the tokens of a built-in token provider
+type ClusterTokenSourceFunc func(config TokenProviderConfig) (transport.ResettableTokenSource, error)
+
+// clusterTokenSourceFunc implements the built-in token providers. It is registered by the Argo CD binary, so that the
+// API types don't depend on the cloud SDKs.
+var clusterTokenSourceFunc ClusterTokenSourceFunc
+
+// SetClusterTokenSourceFunc registers the implementation of the built-in token providers of the clusters
+func SetClusterTokenSourceFunc(f ClusterTokenSourceFunc) {
+	clusterTokenSourceFunc = f
+}
+
 // ClusterConfig is the configuration attributes. This structure is subset of the go-client
 // rest.Config with annotations added for marshalling.
 type ClusterConfig struct {
@@ -2367,6 +2892,30 @@ type ClusterConfig struct {
 
 	// ProxyURL is the URL to the proxy to be used for all requests send to the server
 	ProxyUrl string `json:"proxyUrl,omitempty" protobuf:"bytes,8,opt,name=proxyUrl"` //nolint:revive //FIXME(var-naming)
+
+	// TokenProviderConfig contains the configuration of a built-in provider of short-lived tokens
+	TokenProviderConfig *TokenProviderConfig `json:"tokenProviderConfig,omitempty" protobuf:"bytes,9,opt,name=tokenProviderConfig"`
+
+	// ProxyUsername is the username authenticating to the proxy, which is kept out of the proxy URL
+	ProxyUsername string `json:"proxyUsername,omitempty" protobuf:"bytes,10,opt,name=proxyUsername"`
+	// ProxyPassword is the password authenticating to the proxy, which is kept out of the proxy URL
+	ProxyPassword string `json:"proxyPassword,omitempty" protobuf:"bytes,11,opt,name=proxyPassword"`
+
+	// JumpHostConfig contains the configuration of an SSH jump host through which the server is reached
+	JumpHostConfig *JumpHostConfig `json:"jumpHostConfig,omitempty" protobuf:"bytes,12,opt,name=jumpHostConfig"`
+}
+
+// JumpHostConfig is the configuration of an SSH jump host (bastion) through which the connections to a cluster are
+// tunneled. When a proxy is configured too, the proxy is reached through the jump host.
+type JumpHostConfig struct {
+	// Address is the host:port address of the SSH server of the jump host
+	Address string `json:"address" protobuf:"bytes,1,opt,name=address"`
+	// Username is the user authenticating to the jump host
+	Username string `json:"username" protobuf:"bytes,2,opt,name=username"`
+	// SSHPrivateKey is the PEM-encoded private key authenticating the user to the jump host
+	SSHPrivateKey string `json:"sshPrivateKey,omitempty" protobuf:"bytes,3,opt,name=sshPrivateKey"`
+	// KnownHosts contains the known_hosts entries of the jump host, used to verify its host key
+	KnownHosts string `json:"knownHosts,omitempty" protobuf:"bytes,4,opt,name=knownHosts"`
 }
 
 // TLSClientConfig contains settings to enable transport layer security
@@ -2696,6 +3245,134 @@ type AppProjectSpec struct {
 	PermitOnlyProjectScopedClusters bool `json:"permitOnlyProjectScopedClusters,omitempty" protobuf:"bytes,13,opt,name=permitOnlyProjectScopedClusters"`
 	// DestinationServiceAccounts holds information about the service accounts to be impersonated for the application sync operation for each destination.
 	DestinationServiceAccounts []ApplicationDestinationServiceAccount `json:"destinationServiceAccounts,omitempty" protobuf:"bytes,14,name=destinationServiceAccounts"`
+	// ManifestPolicies are the Rego policies evaluated against the rendered manifests of the applications of the project
+	ManifestPolicies []ManifestPolicy `json:"manifestPolicies,omitempty" protobuf:"bytes,15,rep,name=manifestPolicies"`
+	// AllowedImageRegistries contains the prefixes of the image references allowed in the rendered manifests of the applications of the project, e.g. registry.example.com/team. All images are allowed if it's empty.
+	AllowedImageRegistries []string `json:"allowedImageRegistries,omitempty" protobuf:"bytes,16,rep,name=allowedImageRegistries"`
+	// ProvenancePolicies are the policies verifying the SLSA provenance attestations of the OCI artifacts used as sources by the applications of the project
+	ProvenancePolicies []ProvenancePolicy `json:"provenancePolicies,omitempty" protobuf:"bytes,17,rep,name=provenancePolicies"`
+	// DeniedSyncOptions contains the sync options the applications of the project aren't allowed to sync with, e.g. Replace=true, Force=true or Validate=false. They're denied both as sync options of the sync operation and in the sync-options annotation of the resources.
+	DeniedSyncOptions []string `json:"deniedSyncOptions,omitempty" protobuf:"bytes,18,rep,name=deniedSyncOptions"`
+	// DeniedHookTypes contains the types of the resource hooks the applications of the project aren't allowed to run, e.g. PreSync or SyncFail
+	DeniedHookTypes []string `json:"deniedHookTypes,omitempty" protobuf:"bytes,19,rep,name=deniedHookTypes"`
+	// BreakGlassSync allows the manual syncs blocked by a deny sync window as break-glass syncs, which require a justification and the approval of a second user
+	BreakGlassSync bool `json:"breakGlassSync,omitempty" protobuf:"bytes,20,opt,name=breakGlassSync"`
+	// ProtectedClusters contains the glob patterns of the server URLs or names of the protected destination clusters. The manual syncs to a protected cluster are break-glass syncs, and the automated syncs to it are disabled.
+	ProtectedClusters []string `json:"protectedClusters,omitempty" protobuf:"bytes,21,rep,name=protectedClusters"`
+	// ApplicationDefaults are the defaults of the sync policy and the sources of the applications of the project, which the applications inherit unless they override them
+	ApplicationDefaults *ApplicationDefaults `json:"applicationDefaults,omitempty" protobuf:"bytes,22,opt,name=applicationDefaults"`
+	// ResourceInclusions are the resources tracked by the applications of the project even if they are excluded in the settings, unless the project or the applications exclude them. The controller watches them in the clusters they match.
+	ResourceInclusions []ResourceFilterRule `json:"resourceInclusions,omitempty" protobuf:"bytes,23,rep,name=resourceInclusions"`
+	// ResourceExclusions are the resources which the applications of the project don't track. The applications can't include them.
+	ResourceExclusions []ResourceFilterRule `json:"resourceExclusions,omitempty" protobuf:"bytes,24,rep,name=resourceExclusions"`
+	// SourceNamespaceRules configure the applications of the project in the namespaces matching their patterns, which are allowed source namespaces of the project as well
+	SourceNamespaceRules []SourceNamespaceRule `json:"sourceNamespaceRules,omitempty" protobuf:"bytes,25,rep,name=sourceNamespaceRules"`
+	// TrackingMethod is the method tracking the resources of the applications of the project, overriding the tracking method of the settings
+	TrackingMethod TrackingMethod `json:"trackingMethod,omitempty" protobuf:"bytes,26,opt,name=trackingMethod,casttype=TrackingMethod"`
+	// ApplicationRefProjects contains the glob patterns of the other projects whose applications may use the outputs of the applications of the project with argocd-app:// ref sources
+	ApplicationRefProjects []string `json:"applicationRefProjects,omitempty" protobuf:"bytes,27,rep,name=applicationRefProjects"`
+}
+
+// SourceNamespaceRule configures the applications of a project in the namespaces matching a pattern. The patterns
+// form a hierarchy: when several patterns match a namespace, e.g. team-* and team-a-*, the most specific one applies.
+type SourceNamespaceRule struct {
+	// Pattern is the glob pattern of the namespaces, e.g. team-a-*
+	Pattern string `json:"pattern" protobuf:"bytes,1,opt,name=pattern"`
+	// MaxApps is the maximum number of applications of the project in each namespace matching the pattern. There's no limit if it's 0.
+	MaxApps int64 `json:"maxApps,omitempty" protobuf:"varint,2,opt,name=maxApps"`
+	// DefaultDestination is the destination of the applications created in the namespaces matching the pattern without a destination cluster
+	DefaultDestination *ApplicationDestination `json:"defaultDestination,omitempty" protobuf:"bytes,3,opt,name=defaultDestination"`
+}
+
+// ApplicationDefaults are the defaults of the applications of a project, which the applications inherit unless they
+// override them
+type ApplicationDefaults struct {
+	// SyncPolicy is the sync policy of the applications of the project which don't have a sync policy
+	SyncPolicy *SyncPolicy `json:"syncPolicy,omitempty" protobuf:"bytes,1,opt,name=syncPolicy"`
+	// SyncOptions are added to the sync options of the applications of the project, except the options the applications set to another value
+	SyncOptions SyncOptions `json:"syncOptions,omitempty" protobuf:"bytes,2,opt,name=syncOptions"`
+	// HelmValueFiles are the value files of the Helm sources of the applications of the project which don't set value files
+	HelmValueFiles []string `json:"helmValueFiles,omitempty" protobuf:"bytes,3,rep,name=helmValueFiles"`
+	// KustomizeComponents are the components of the Kustomize sources of the applications of the project which don't set components
+	KustomizeComponents []string `json:"kustomizeComponents,omitempty" protobuf:"bytes,4,rep,name=kustomizeComponents"`
+	// HelmVersion is the Helm version of the Helm sources of the applications of the project which don't set a version
+	HelmVersion string `json:"helmVersion,omitempty" protobuf:"bytes,5,opt,name=helmVersion"`
+	// KustomizeVersion is the Kustomize version of the Kustomize sources of the applications of the project which don't set a version
+	KustomizeVersion string `json:"kustomizeVersion,omitempty" protobuf:"bytes,6,opt,name=kustomizeVersion"`
+}
+
+const (
+	// ManifestPolicyEnforcementBlock blocks the syncs of the applications whose manifests violate the policy
+	ManifestPolicyEnforcementBlock = "block"
+	// ManifestPolicyEnforcementWarn only reports the violations of the policy as warnings
+	ManifestPolicyEnforcementWarn = "warn"
+)
+
+// ManifestPolicy is a bundle of Rego policies evaluated against each rendered manifest of the applications of a project.
+// The policies follow the conventions of conftest: the deny and violation rules of the package report violations, and
+// the warn rules report warnings.
+type ManifestPolicy struct {
+	// Name is the name of the policy
+	Name string `json:"name" protobuf:"bytes,1,opt,name=name"`
+	// ConfigMap is the name of a ConfigMap of the Argo CD namespace, whose keys ending with .rego contain the Rego modules of the policy
+	ConfigMap string `json:"configMap,omitempty" protobuf:"bytes,2,opt,name=configMap"`
+	// RepoURL is the URL of an OCI repository, whose artifact contains the Rego modules of the policy
+	RepoURL string `json:"repoURL,omitempty" protobuf:"bytes,3,opt,name=repoURL"`
+	// TargetRevision is the tag, digest or semantic version constraint of the OCI artifact
+	TargetRevision string `json:"targetRevision,omitempty" protobuf:"bytes,4,opt,name=targetRevision"`
+	// Package is the Rego package of the rules of the policy, e.g. main
+	Package string `json:"package" protobuf:"bytes,5,opt,name=package"`
+	// Enforcement is either block, which blocks the syncs of the applications violating the policy, or warn, which only reports the violations (default: block)
+	Enforcement string `json:"enforcement,omitempty" protobuf:"bytes,6,opt,name=enforcement"`
+}
+
+// IsWarning returns whether the violations of the policy are only reported as warnings
+func (p *ManifestPolicy) IsWarning() bool {
+	return p.Enforcement == ManifestPolicyEnforcementWarn
+}
+
+// Validate checks that the manifest policy is valid
+func (p *ManifestPolicy) Validate() error {
+	if p.Name == "" {
+		return errors.New("manifest policy name is required")
+	}
+	if (p.ConfigMap == "") == (p.RepoURL == "") {
+		return fmt.Errorf("manifest policy '%s' requires exactly one of configMap or repoURL", p.Name)
+	}
+	if p.Package == "" {
+		return fmt.Errorf("manifest policy '%s' requires a package", p.Name)
+	}
+	switch p.Enforcement {
+	case "", ManifestPolicyEnforcementBlock, ManifestPolicyEnforcementWarn:
+	default:
+		return fmt.Errorf("manifest policy '%s' has an invalid enforcement '%s', must be one of %s or %s", p.Name, p.Enforcement, ManifestPolicyEnforcementBlock, ManifestPolicyEnforcementWarn)
+	}
+	return nil
+}
+
+// ProvenancePolicy requires the OCI artifacts of matching repositories to have a SLSA provenance attestation signed
+// with a trusted key, whose builder and source repository are allowed. The attestations are attached to the artifacts
+// with the OCI referrers API, or with the tag scheme of cosign.
+type ProvenancePolicy struct {
+	// RepoURL is a glob pattern matching the URLs of the OCI repositories the policy applies to, e.g. oci://registry.example.com/charts/*
+	RepoURL string `json:"repoURL" protobuf:"bytes,1,opt,name=repoURL"`
+	// BuilderID is a glob pattern matching the allowed builder IDs of the provenance. Any builder is allowed if it's empty.
+	BuilderID string `json:"builderID,omitempty" protobuf:"bytes,2,opt,name=builderID"`
+	// SourceRepository is a glob pattern matching the allowed source repositories of the provenance, e.g. https://github.com/example/*. Any source repository is allowed if it's empty.
+	SourceRepository string `json:"sourceRepository,omitempty" protobuf:"bytes,3,opt,name=sourceRepository"`
+	// PublicKey contains the PEM encoded public keys trusted to sign the attestations
+	PublicKey string `json:"publicKey" protobuf:"bytes,4,opt,name=publicKey"`
+}
+
+// Validate checks that the provenance policy is valid
+func (p *ProvenancePolicy) Validate() error {
+	if p.RepoURL == "" {
+		return errors.New("provenance policy repoURL is required")
+	}
+	if block, _ := pem.Decode([]byte(p.PublicKey)); block == nil {
+		return fmt.Errorf("provenance policy of '%s' requires a PEM encoded public key", p.RepoURL)
+	}
+	return nil
 }
 
 // SyncWindows is a collection of sync windows in this project
@@ -3062,6 +3739,32 @@ func (w SyncWindow) active(currentTime time.Time) (bool, error) {
 	return nextWindow.Before(currentTime.Add(timeZoneOffsetDuration)), nil
 }
 
+// LastEnd returns the end of the last occurrence of the sync window which ended within the given period before the
+// current time, or nil if no occurrence ended within the period
+func (w SyncWindow) LastEnd(currentTime time.Time, period time.Duration) (*time.Time, error) {
+	currentTime = currentTime.UTC()
+
+	specParser := cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow)
+	schedule, sErr := specParser.Parse(w.Schedule)
+	if sErr != nil {
+		return nil, fmt.Errorf("cannot parse schedule '%s': %w", w.Schedule, sErr)
+	}
+	duration, dErr := time.ParseDuration(w.Duration)
+	if dErr != nil {
+		return nil, fmt.Errorf("cannot parse duration '%s': %w", w.Duration, dErr)
+	}
+
+	// Offset the schedule to consider the timeZone of the sync window
+	timeZoneOffsetDuration := w.scheduleOffsetByTimeZone()
+	scheduleTime := currentTime.Add(timeZoneOffsetDuration)
+	var lastEnd *time.Time
+	for start := schedule.Next(scheduleTime.Add(-duration - period)); !start.IsZero() && !start.After(scheduleTime.Add(-duration)); start = schedule.Next(start) {
+		end := start.Add(duration - timeZoneOffsetDuration)
+		lastEnd = &end
+	}
+	return lastEnd, nil
+}
+
 // Update updates a sync window's settings with the given parameter
 func (w *SyncWindow) Update(s string, d string, a []string, n []string, c []string, tz string, description string) error {
 	if s == "" && d == "" && len(a) == 0 && len(n) == 0 && len(c) == 0 && description == "" {
@@ -3179,6 +3882,16 @@ type ConfigManagementPlugin struct {
 // HelmOptions holds helm options
 type HelmOptions struct {
 	ValuesFileSchemes []string `protobuf:"bytes,1,opt,name=valuesFileSchemes"`
+	// Versions is a list of Helm versions and their corresponding binary paths
+	Versions []HelmVersion `protobuf:"bytes,2,rep,name=versions"`
+}
+
+// HelmVersion holds information about additional Helm versions
+type HelmVersion struct {
+	// Name holds Helm version name
+	Name string `protobuf:"bytes,1,opt,name=name"`
+	// Path holds the corresponding binary path
+	Path string `protobuf:"bytes,2,opt,name=path"`
 }
 
 // KustomizeVersion holds information about additional Kustomize versions
@@ -3245,6 +3958,32 @@ func (app *Application) IsRefreshRequested() (RefreshType, bool) {
 	return refreshType, true
 }
 
+// GetPinnedRevisions returns the revisions the sources of the application are pinned to, by source position
+func (app *Application) GetPinnedRevisions() (map[int]string, error) {
+	value, ok := app.GetAnnotations()[AnnotationKeyPinnedRevisions]
+	if !ok || value == "" {
+		return nil, nil
+	}
+	var pins map[int]string
+	if err := json.Unmarshal([]byte(value), &pins); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal pinned revisions: %w", err)
+	}
+	return pins, nil
+}
+
+// GetFreeze returns the freeze of the application, or nil if the application isn't frozen
+func (app *Application) GetFreeze() (*ApplicationFreeze, error) {
+	value, ok := app.GetAnnotations()[AnnotationKeyFreeze]
+	if !ok || value == "" {
+		return nil, nil
+	}
+	var freeze ApplicationFreeze
+	if err := json.Unmarshal([]byte(value), &freeze); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal freeze: %w", err)
+	}
+	return &freeze, nil
+}
+
 // IsHydrateRequested returns whether hydration has been requested for an application
 func (app *Application) IsHydrateRequested() bool {
 	annotations := app.GetAnnotations()
@@ -3538,7 +4277,7 @@ func SetK8SConfigDefaults(config *rest.Config) error {
 	if maxRetries > 0 {
 		backoffDurationMS := env.ParseInt64FromEnv(utilhttp.EnvRetryBaseBackoff, 100, 1, math.MaxInt64)
 		backoffDuration := time.Duration(backoffDurationMS) * time.Millisecond
-		config.WrapTransport = utilhttp.WithRetry(maxRetries, backoffDuration)
+		config.Wrap(utilhttp.WithRetry(maxRetries, backoffDuration))
 	}
 	return nil
 }
@@ -3557,6 +4296,24 @@ func ParseProxyUrl(proxyUrl string) (*url.URL, error) { //nolint:revive //FIXME(
 	return u, nil
 }
 
+// IsExecProviderCommandAllowed returns whether the given command can be run by the exec provider of a cluster. Every
+// command is allowed unless the allowed commands are restricted using the ARGOCD_K8S_EXEC_PROVIDER_ALLOWED_COMMANDS
+// environment variable, in which case the command must match one of the allowed commands or glob patterns.
+func IsExecProviderCommandAllowed(command string) bool {
+	if len(K8sExecProviderAllowedCommands) == 0 {
+		return true
+	}
+	for _, allowed := range K8sExecProviderAllowedCommands {
+		if allowed == "" {
+			continue
+		}
+		if matched, err := filepath.Match(allowed, command); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
+
 // RawRestConfig returns a go-client REST config from cluster that might be serialized into the file using kube.WriteKubeConfig method.
 func (c *Cluster) RawRestConfig() (*rest.Config, error) {
 	var config *rest.Config
@@ -3594,6 +4351,17 @@ func (c *Cluster) RawRestConfig() (*rest.Config, error) {
 			CAData:     c.Config.CAData,
 		}
 		switch {
+		case c.Config.TokenProviderConfig != nil:
+			if clusterTokenSourceFunc == nil {
+				return nil, errors.New("the built-in cluster token providers aren't available")
+			}
+			var tokenSource transport.ResettableTokenSource
+			tokenSource, err = clusterTokenSourceFunc(*c.Config.TokenProviderConfig)
+			config = &rest.Config{
+				Host:            c.Server,
+				TLSClientConfig: tlsClientConfig,
+				WrapTransport:   transport.ResettableTokenSourceWrapTransport(tokenSource),
+			}
 		case c.Config.AWSAuthConfig != nil:
 			args := []string{"aws", "--cluster-name", c.Config.AWSAuthConfig.ClusterName}
 			if c.Config.AWSAuthConfig.RoleARN != "" {
@@ -3613,6 +4381,9 @@ func (c *Cluster) RawRestConfig() (*rest.Config, error) {
 				},
 			}
 		case c.Config.ExecProviderConfig != nil:
+			if !IsExecProviderCommandAllowed(c.Config.ExecProviderConfig.Command) {
+				return nil, fmt.Errorf("unable to create K8s REST config: the exec provider command %q is not allowed", c.Config.ExecProviderConfig.Command)
+			}
 			var env []api.ExecEnvVar
 			if c.Config.ExecProviderConfig.Env != nil {
 				for key, value := range c.Config.ExecProviderConfig.Env {
@@ -3652,8 +4423,23 @@ func (c *Cluster) RawRestConfig() (*rest.Config, error) {
 		if err != nil {
 			return nil, fmt.Errorf("unable to create K8s REST config, can`t parse proxy url: %w", err)
 		}
+		if c.Config.ProxyUsername != "" {
+			u.User = url.UserPassword(c.Config.ProxyUsername, c.Config.ProxyPassword)
+		}
 		config.Proxy = http.ProxyURL(u)
 	}
+	if jumpHost := c.Config.JumpHostConfig; jumpHost != nil {
+		dial, err := proxy.JumpHostDialer(proxy.JumpHostConfig{
+			Address:       jumpHost.Address,
+			Username:      jumpHost.Username,
+			SSHPrivateKey: jumpHost.SSHPrivateKey,
+			KnownHosts:    jumpHost.KnownHosts,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("unable to create K8s REST config, can`t configure jump host: %w", err)
+		}
+		config.Dial = dial
+	}
 	config.DisableCompression = c.Config.DisableCompression
 	config.Timeout = K8sServerSideTimeout
 	config.QPS = K8sClientConfigQPS
@@ -14,6 +14,7 @@ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
 See the License for the specific language governing permissions and
 limitations under the License.
 */
+
 // Code generated by deepcopy-gen. DO NOT EDIT.
 
 package v1alpha1
@@ -194,6 +195,67 @@ func (in *AppProjectSpec) DeepCopyInto(out *AppProjectSpec) {
 		*out = make([]ApplicationDestinationServiceAccount, len(*in))
 		copy(*out, *in)
 	}
+	if in.ManifestPolicies != nil {
+		in, out := &in.ManifestPolicies, &out.ManifestPolicies
+		*out = make([]ManifestPolicy, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedImageRegistries != nil {
+		in, out := &in.AllowedImageRegistries, &out.AllowedImageRegistries
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ProvenancePolicies != nil {
+		in, out := &in.ProvenancePolicies, &out.ProvenancePolicies
+		*out = make([]ProvenancePolicy, len(*in))
+		copy(*out, *in)
+	}
+	if in.DeniedSyncOptions != nil {
+		in, out := &in.DeniedSyncOptions, &out.DeniedSyncOptions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DeniedHookTypes != nil {
+		in, out := &in.DeniedHookTypes, &out.DeniedHookTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ProtectedClusters != nil {
+		in, out := &in.ProtectedClusters, &out.ProtectedClusters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ApplicationDefaults != nil {
+		in, out := &in.ApplicationDefaults, &out.ApplicationDefaults
+		*out = new(ApplicationDefaults)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ResourceInclusions != nil {
+		in, out := &in.ResourceInclusions, &out.ResourceInclusions
+		*out = make([]ResourceFilterRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ResourceExclusions != nil {
+		in, out := &in.ResourceExclusions, &out.ResourceExclusions
+		*out = make([]ResourceFilterRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SourceNamespaceRules != nil {
+		in, out := &in.SourceNamespaceRules, &out.SourceNamespaceRules
+		*out = make([]SourceNamespaceRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ApplicationRefProjects != nil {
+		in, out := &in.ApplicationRefProjects, &out.ApplicationRefProjects
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -263,6 +325,72 @@ func (in *Application) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationBlueprint) DeepCopyInto(out *ApplicationBlueprint) {
+	*out = *in
+	if in.Projects != nil {
+		in, out := &in.Projects, &out.Projects
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make([]ApplicationBlueprintParameter, len(*in))
+		copy(*out, *in)
+	}
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationBlueprint.
+func (in *ApplicationBlueprint) DeepCopy() *ApplicationBlueprint {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationBlueprint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationBlueprintList) DeepCopyInto(out *ApplicationBlueprintList) {
+	*out = *in
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ApplicationBlueprint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationBlueprintList.
+func (in *ApplicationBlueprintList) DeepCopy() *ApplicationBlueprintList {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationBlueprintList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationBlueprintParameter) DeepCopyInto(out *ApplicationBlueprintParameter) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationBlueprintParameter.
+func (in *ApplicationBlueprintParameter) DeepCopy() *ApplicationBlueprintParameter {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationBlueprintParameter)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ApplicationCondition) DeepCopyInto(out *ApplicationCondition) {
 	*out = *in
@@ -283,6 +411,42 @@ func (in *ApplicationCondition) DeepCopy() *ApplicationCondition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationDefaults) DeepCopyInto(out *ApplicationDefaults) {
+	*out = *in
+	if in.SyncPolicy != nil {
+		in, out := &in.SyncPolicy, &out.SyncPolicy
+		*out = new(SyncPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SyncOptions != nil {
+		in, out := &in.SyncOptions, &out.SyncOptions
+		*out = make(SyncOptions, len(*in))
+		copy(*out, *in)
+	}
+	if in.HelmValueFiles != nil {
+		in, out := &in.HelmValueFiles, &out.HelmValueFiles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.KustomizeComponents != nil {
+		in, out := &in.KustomizeComponents, &out.KustomizeComponents
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationDefaults.
+func (in *ApplicationDefaults) DeepCopy() *ApplicationDefaults {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationDefaults)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ApplicationDestination) DeepCopyInto(out *ApplicationDestination) {
 	*out = *in
@@ -315,6 +479,27 @@ func (in *ApplicationDestinationServiceAccount) DeepCopy() *ApplicationDestinati
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationFreeze) DeepCopyInto(out *ApplicationFreeze) {
+	*out = *in
+	in.FrozenAt.DeepCopyInto(&out.FrozenAt)
+	if in.Until != nil {
+		in, out := &in.Until, &out.Until
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationFreeze.
+func (in *ApplicationFreeze) DeepCopy() *ApplicationFreeze {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationFreeze)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ApplicationList) DeepCopyInto(out *ApplicationList) {
 	*out = *in
@@ -589,6 +774,32 @@ func (in *ApplicationSetList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationSetMetadataPropagation) DeepCopyInto(out *ApplicationSetMetadataPropagation) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = new(MetadataPropagationPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = new(MetadataPropagationPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationSetMetadataPropagation.
+func (in *ApplicationSetMetadataPropagation) DeepCopy() *ApplicationSetMetadataPropagation {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationSetMetadataPropagation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ApplicationSetNestedGenerator) DeepCopyInto(out *ApplicationSetNestedGenerator) {
 	*out = *in
@@ -677,6 +888,29 @@ func (in ApplicationSetNestedGenerators) DeepCopy() ApplicationSetNestedGenerato
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationSetParamTransform) DeepCopyInto(out *ApplicationSetParamTransform) {
+	*out = *in
+	if in.Map != nil {
+		in, out := &in.Map, &out.Map
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationSetParamTransform.
+func (in *ApplicationSetParamTransform) DeepCopy() *ApplicationSetParamTransform {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationSetParamTransform)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ApplicationSetResourceIgnoreDifferences) DeepCopyInto(out *ApplicationSetResourceIgnoreDifferences) {
 	*out = *in
@@ -797,6 +1031,18 @@ func (in *ApplicationSetSpec) DeepCopyInto(out *ApplicationSetSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.MetadataPropagation != nil {
+		in, out := &in.MetadataPropagation, &out.MetadataPropagation
+		*out = new(ApplicationSetMetadataPropagation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ParamTransforms != nil {
+		in, out := &in.ParamTransforms, &out.ParamTransforms
+		*out = make([]ApplicationSetParamTransform, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -1377,6 +1623,18 @@ func (in *ApplicationSpec) DeepCopyInto(out *ApplicationSpec) {
 		*out = new(SourceHydrator)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.RevisionHistoryRetention != nil {
+		in, out := &in.RevisionHistoryRetention, &out.RevisionHistoryRetention
+		*out = new(RevisionHistoryRetention)
+		**out = **in
+	}
+	if in.ResourceExclusions != nil {
+		in, out := &in.ResourceExclusions, &out.ResourceExclusions
+		*out = make([]ResourceFilterRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -1436,6 +1694,16 @@ func (in *ApplicationStatus) DeepCopyInto(out *ApplicationStatus) {
 		copy(*out, *in)
 	}
 	in.SourceHydrator.DeepCopyInto(&out.SourceHydrator)
+	if in.Freeze != nil {
+		in, out := &in.Freeze, &out.Freeze
+		*out = new(ApplicationFreeze)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ToolVersions != nil {
+		in, out := &in.ToolVersions, &out.ToolVersions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -1475,6 +1743,27 @@ func (in *ApplicationSummary) DeepCopy() *ApplicationSummary {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationSyncProgressEvent) DeepCopyInto(out *ApplicationSyncProgressEvent) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]ResourceSyncProgress, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationSyncProgressEvent.
+func (in *ApplicationSyncProgressEvent) DeepCopy() *ApplicationSyncProgressEvent {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationSyncProgressEvent)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ApplicationTree) DeepCopyInto(out *ApplicationTree) {
 	*out = *in
@@ -1499,6 +1788,16 @@ func (in *ApplicationTree) DeepCopyInto(out *ApplicationTree) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.RemovedNodes != nil {
+		in, out := &in.RemovedNodes, &out.RemovedNodes
+		*out = make([]ResourceRef, len(*in))
+		copy(*out, *in)
+	}
+	if in.RemovedOrphanedNodes != nil {
+		in, out := &in.RemovedOrphanedNodes, &out.RemovedOrphanedNodes
+		*out = make([]ResourceRef, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -1613,6 +1912,86 @@ func (in *BearerTokenBitbucketCloud) DeepCopy() *BearerTokenBitbucketCloud {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CascadeChild) DeepCopyInto(out *CascadeChild) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CascadeChild.
+func (in *CascadeChild) DeepCopy() *CascadeChild {
+	if in == nil {
+		return nil
+	}
+	out := new(CascadeChild)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CascadeChildResult) DeepCopyInto(out *CascadeChildResult) {
+	*out = *in
+	if in.StartedAt != nil {
+		in, out := &in.StartedAt, &out.StartedAt
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CascadeChildResult.
+func (in *CascadeChildResult) DeepCopy() *CascadeChildResult {
+	if in == nil {
+		return nil
+	}
+	out := new(CascadeChildResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CascadeOperation) DeepCopyInto(out *CascadeOperation) {
+	*out = *in
+	if in.Children != nil {
+		in, out := &in.Children, &out.Children
+		*out = make([]CascadeChild, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CascadeOperation.
+func (in *CascadeOperation) DeepCopy() *CascadeOperation {
+	if in == nil {
+		return nil
+	}
+	out := new(CascadeOperation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CascadeOperationResult) DeepCopyInto(out *CascadeOperationResult) {
+	*out = *in
+	if in.Children != nil {
+		in, out := &in.Children, &out.Children
+		*out = make([]CascadeChildResult, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CascadeOperationResult.
+func (in *CascadeOperationResult) DeepCopy() *CascadeOperationResult {
+	if in == nil {
+		return nil
+	}
+	out := new(CascadeOperationResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ChartDetails) DeepCopyInto(out *ChartDetails) {
 	*out = *in
@@ -1668,6 +2047,11 @@ func (in *Cluster) DeepCopyInto(out *Cluster) {
 			(*out)[key] = val
 		}
 	}
+	if in.ResourceGroups != nil {
+		in, out := &in.ResourceGroups, &out.ResourceGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -1715,6 +2099,16 @@ func (in *ClusterConfig) DeepCopyInto(out *ClusterConfig) {
 		*out = new(ExecProviderConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.TokenProviderConfig != nil {
+		in, out := &in.TokenProviderConfig, &out.TokenProviderConfig
+		*out = new(TokenProviderConfig)
+		**out = **in
+	}
+	if in.JumpHostConfig != nil {
+		in, out := &in.JumpHostConfig, &out.JumpHostConfig
+		*out = new(JumpHostConfig)
+		**out = **in
+	}
 	return
 }
 
@@ -1740,6 +2134,13 @@ func (in *ClusterGenerator) DeepCopyInto(out *ClusterGenerator) {
 			(*out)[key] = val
 		}
 	}
+	if in.Selectors != nil {
+		in, out := &in.Selectors, &out.Selectors
+		*out = make([]v1.LabelSelector, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -2233,6 +2634,11 @@ func (in *HelmOptions) DeepCopyInto(out *HelmOptions) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Versions != nil {
+		in, out := &in.Versions, &out.Versions
+		*out = make([]HelmVersion, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -2262,6 +2668,38 @@ func (in *HelmParameter) DeepCopy() *HelmParameter {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HelmVersion) DeepCopyInto(out *HelmVersion) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HelmVersion.
+func (in *HelmVersion) DeepCopy() *HelmVersion {
+	if in == nil {
+		return nil
+	}
+	out := new(HelmVersion)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HookOutput) DeepCopyInto(out *HookOutput) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HookOutput.
+func (in *HookOutput) DeepCopy() *HookOutput {
+	if in == nil {
+		return nil
+	}
+	out := new(HookOutput)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HostInfo) DeepCopyInto(out *HostInfo) {
 	*out = *in
@@ -2452,6 +2890,22 @@ func (in *JsonnetVar) DeepCopy() *JsonnetVar {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JumpHostConfig) DeepCopyInto(out *JumpHostConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JumpHostConfig.
+func (in *JumpHostConfig) DeepCopy() *JumpHostConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(JumpHostConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KnownTypeField) DeepCopyInto(out *KnownTypeField) {
 	*out = *in
@@ -2716,6 +3170,22 @@ func (in *ManagedNamespaceMetadata) DeepCopy() *ManagedNamespaceMetadata {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManifestPolicy) DeepCopyInto(out *ManifestPolicy) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManifestPolicy.
+func (in *ManifestPolicy) DeepCopy() *ManifestPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ManifestPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MatrixGenerator) DeepCopyInto(out *MatrixGenerator) {
 	*out = *in
@@ -2769,6 +3239,27 @@ func (in *MergeGenerator) DeepCopy() *MergeGenerator {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetadataPropagationPolicy) DeepCopyInto(out *MetadataPropagationPolicy) {
+	*out = *in
+	if in.Propagate != nil {
+		in, out := &in.Propagate, &out.Propagate
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetadataPropagationPolicy.
+func (in *MetadataPropagationPolicy) DeepCopy() *MetadataPropagationPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(MetadataPropagationPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NestedMatrixGenerator) DeepCopyInto(out *NestedMatrixGenerator) {
 	*out = *in
@@ -2857,6 +3348,15 @@ func (in *Operation) DeepCopyInto(out *Operation) {
 		}
 	}
 	in.Retry.DeepCopyInto(&out.Retry)
+	if in.Cascade != nil {
+		in, out := &in.Cascade, &out.Cascade
+		*out = new(CascadeOperation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RequestedAt != nil {
+		in, out := &in.RequestedAt, &out.RequestedAt
+		*out = (*in).DeepCopy()
+	}
 	return
 }
 
@@ -2900,6 +3400,11 @@ func (in *OperationState) DeepCopyInto(out *OperationState) {
 		in, out := &in.FinishedAt, &out.FinishedAt
 		*out = (*in).DeepCopy()
 	}
+	if in.CascadeResult != nil {
+		in, out := &in.CascadeResult, &out.CascadeResult
+		*out = new(CascadeOperationResult)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -3153,6 +3658,22 @@ func (in *ProjectRole) DeepCopy() *ProjectRole {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProvenancePolicy) DeepCopyInto(out *ProvenancePolicy) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProvenancePolicy.
+func (in *ProvenancePolicy) DeepCopy() *ProvenancePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ProvenancePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PullRequestGenerator) DeepCopyInto(out *PullRequestGenerator) {
 	*out = *in
@@ -3351,6 +3872,16 @@ func (in *PullRequestGeneratorGitLab) DeepCopyInto(out *PullRequestGeneratorGitL
 		*out = new(ConfigMapKeyRef)
 		**out = **in
 	}
+	if in.PipelineStatuses != nil {
+		in, out := &in.PipelineStatuses, &out.PipelineStatuses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Approved != nil {
+		in, out := &in.Approved, &out.Approved
+		*out = new(bool)
+		**out = **in
+	}
 	return
 }
 
@@ -3694,6 +4225,16 @@ func (in *ResourceActions) DeepCopy() *ResourceActions {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourceDiff) DeepCopyInto(out *ResourceDiff) {
 	*out = *in
+	if in.SuppressedDiff != nil {
+		in, out := &in.SuppressedDiff, &out.SuppressedDiff
+		*out = make([]SuppressedDifference, len(*in))
+		copy(*out, *in)
+	}
+	if in.DryRunError != nil {
+		in, out := &in.DryRunError, &out.DryRunError
+		*out = new(ResourceDryRunError)
+		**out = **in
+	}
 	return
 }
 
@@ -3707,6 +4248,53 @@ func (in *ResourceDiff) DeepCopy() *ResourceDiff {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceDryRunError) DeepCopyInto(out *ResourceDryRunError) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceDryRunError.
+func (in *ResourceDryRunError) DeepCopy() *ResourceDryRunError {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceDryRunError)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceFilterRule) DeepCopyInto(out *ResourceFilterRule) {
+	*out = *in
+	if in.APIGroups != nil {
+		in, out := &in.APIGroups, &out.APIGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Kinds != nil {
+		in, out := &in.Kinds, &out.Kinds
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceFilterRule.
+func (in *ResourceFilterRule) DeepCopy() *ResourceFilterRule {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceFilterRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourceIgnoreDifferences) DeepCopyInto(out *ResourceIgnoreDifferences) {
 	*out = *in
@@ -3831,6 +4419,33 @@ func (in *ResourceNode) DeepCopy() *ResourceNode {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceNodeHealthChange) DeepCopyInto(out *ResourceNodeHealthChange) {
+	*out = *in
+	out.ResourceRef = in.ResourceRef
+	if in.Health != nil {
+		in, out := &in.Health, &out.Health
+		*out = new(HealthStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PreviousHealth != nil {
+		in, out := &in.PreviousHealth, &out.PreviousHealth
+		*out = new(HealthStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceNodeHealthChange.
+func (in *ResourceNodeHealthChange) DeepCopy() *ResourceNodeHealthChange {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceNodeHealthChange)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourceOverride) DeepCopyInto(out *ResourceOverride) {
 	*out = *in
@@ -3878,6 +4493,11 @@ func (in *ResourceResult) DeepCopyInto(out *ResourceResult) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.HookOutputs != nil {
+		in, out := &in.HookOutputs, &out.HookOutputs
+		*out = make([]HookOutput, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -3925,6 +4545,11 @@ func (in *ResourceStatus) DeepCopyInto(out *ResourceStatus) {
 		*out = new(HealthStatus)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.DryRunError != nil {
+		in, out := &in.DryRunError, &out.DryRunError
+		*out = new(ResourceDryRunError)
+		**out = **in
+	}
 	return
 }
 
@@ -3938,6 +4563,68 @@ func (in *ResourceStatus) DeepCopy() *ResourceStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceSyncProgress) DeepCopyInto(out *ResourceSyncProgress) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceSyncProgress.
+func (in *ResourceSyncProgress) DeepCopy() *ResourceSyncProgress {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceSyncProgress)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceTreeDiff) DeepCopyInto(out *ResourceTreeDiff) {
+	*out = *in
+	in.From.DeepCopyInto(&out.From)
+	in.To.DeepCopyInto(&out.To)
+	if in.Appeared != nil {
+		in, out := &in.Appeared, &out.Appeared
+		*out = make([]ResourceNode, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Disappeared != nil {
+		in, out := &in.Disappeared, &out.Disappeared
+		*out = make([]ResourceNode, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.HealthChanged != nil {
+		in, out := &in.HealthChanged, &out.HealthChanged
+		*out = make([]ResourceNodeHealthChange, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Snapshots != nil {
+		in, out := &in.Snapshots, &out.Snapshots
+		*out = make([]v1.Time, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceTreeDiff.
+func (in *ResourceTreeDiff) DeepCopy() *ResourceTreeDiff {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceTreeDiff)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RetryStrategy) DeepCopyInto(out *RetryStrategy) {
 	*out = *in
@@ -4003,6 +4690,11 @@ func (in *RevisionHistory) DeepCopyInto(out *RevisionHistory) {
 		copy(*out, *in)
 	}
 	out.InitiatedBy = in.InitiatedBy
+	if in.VersionConstraints != nil {
+		in, out := &in.VersionConstraints, &out.VersionConstraints
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -4016,6 +4708,22 @@ func (in *RevisionHistory) DeepCopy() *RevisionHistory {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RevisionHistoryRetention) DeepCopyInto(out *RevisionHistoryRetention) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RevisionHistoryRetention.
+func (in *RevisionHistoryRetention) DeepCopy() *RevisionHistoryRetention {
+	if in == nil {
+		return nil
+	}
+	out := new(RevisionHistoryRetention)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RevisionMetadata) DeepCopyInto(out *RevisionMetadata) {
 	*out = *in
@@ -4435,6 +5143,27 @@ func (in *SourceHydratorStatus) DeepCopy() *SourceHydratorStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SourceNamespaceRule) DeepCopyInto(out *SourceNamespaceRule) {
+	*out = *in
+	if in.DefaultDestination != nil {
+		in, out := &in.DefaultDestination, &out.DefaultDestination
+		*out = new(ApplicationDestination)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SourceNamespaceRule.
+func (in *SourceNamespaceRule) DeepCopy() *SourceNamespaceRule {
+	if in == nil {
+		return nil
+	}
+	out := new(SourceNamespaceRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SuccessfulHydrateOperation) DeepCopyInto(out *SuccessfulHydrateOperation) {
 	*out = *in
@@ -4452,6 +5181,22 @@ func (in *SuccessfulHydrateOperation) DeepCopy() *SuccessfulHydrateOperation {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SuppressedDifference) DeepCopyInto(out *SuppressedDifference) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SuppressedDifference.
+func (in *SuppressedDifference) DeepCopy() *SuppressedDifference {
+	if in == nil {
+		return nil
+	}
+	out := new(SuppressedDifference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SyncOperation) DeepCopyInto(out *SyncOperation) {
 	*out = *in
@@ -4668,6 +5413,16 @@ func (in *SyncStatus) DeepCopyInto(out *SyncStatus) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.PinnedRevisions != nil {
+		in, out := &in.PinnedRevisions, &out.PinnedRevisions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.VersionConstraints != nil {
+		in, out := &in.VersionConstraints, &out.VersionConstraints
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -4843,3 +5598,19 @@ func (in *TagFilter) DeepCopy() *TagFilter {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TokenProviderConfig) DeepCopyInto(out *TokenProviderConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TokenProviderConfig.
+func (in *TokenProviderConfig) DeepCopy() *TokenProviderConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(TokenProviderConfig)
+	in.DeepCopyInto(out)
+	return out
+}
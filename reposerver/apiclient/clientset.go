@@ -1,25 +1,25 @@
 package apiclient
 
 import (
+	"context"
 	"crypto/tls"
 	"crypto/x509"
 	"fmt"
 	"math"
+	"net"
 	"time"
 
 	"github.com/argoproj/argo-cd/v3/common"
 	"github.com/argoproj/argo-cd/v3/util/env"
 
-	grpc_retry "github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/retry"
-	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/timeout"
 	log "github.com/sirupsen/logrus"
 	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 
-	grpc_util "github.com/argoproj/argo-cd/v3/util/grpc"
 	utilio "github.com/argoproj/argo-cd/v3/util/io"
+	"github.com/argoproj/argo-cd/v3/util/spiffe"
 )
 
 // MaxGRPCMessageSize contains max grpc message size
@@ -43,36 +43,69 @@ type Clientset interface {
 type clientSet struct {
 	address        string
 	timeoutSeconds int
+	// methodTimeouts override the timeout of the calls of the methods, by method name, e.g. GenerateManifest
+	methodTimeouts map[string]time.Duration
 	tlsConfig      TLSConfiguration
+	retryConfig    RetryConfiguration
+	keepalive      KeepaliveConfiguration
+	// circuitBreaker is shared by the connections of the clientset, nil if it is disabled
+	circuitBreaker *circuitBreaker
+	// dialer connects to a repo server running in the same process, nil for the other repo servers
+	dialer func(context.Context, string) (net.Conn, error)
 }
 
 func (c *clientSet) NewRepoServerClient() (utilio.Closer, RepoServerServiceClient, error) {
-	conn, err := NewConnection(c.address, c.timeoutSeconds, &c.tlsConfig)
+	conn, err := c.newConnection()
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to open a new connection to repo server: %w", err)
 	}
 	return conn, NewRepoServerServiceClient(conn), nil
 }
 
+// NewConnection creates new connection to repo server, retrying the calls and checking the health of the connection
+// according to the retry and keepalive configurations set by the environment variables
 func NewConnection(address string, timeoutSeconds int, tlsConfig *TLSConfiguration) (*grpc.ClientConn, error) {
-	retryOpts := []grpc_retry.CallOption{
-		grpc_retry.WithMax(3),
-		grpc_retry.WithBackoff(grpc_retry.BackoffLinear(1000 * time.Millisecond)),
+	c := &clientSet{
+		address:        address,
+		timeoutSeconds: timeoutSeconds,
+		methodTimeouts: methodTimeoutsFromEnv(),
+		tlsConfig:      *tlsConfig,
+		retryConfig:    NewRetryConfigurationFromEnv(),
+		keepalive:      NewKeepaliveConfigurationFromEnv(),
 	}
-	unaryInterceptors := []grpc.UnaryClientInterceptor{grpc_retry.UnaryClientInterceptor(retryOpts...)}
-	if timeoutSeconds > 0 {
-		unaryInterceptors = append(unaryInterceptors, timeout.UnaryClientInterceptor(time.Duration(timeoutSeconds)*time.Second))
+	return c.newConnection()
+}
+
+func (c *clientSet) newConnection() (*grpc.ClientConn, error) {
+	address, tlsConfig := c.address, &c.tlsConfig
+	var unaryInterceptors []grpc.UnaryClientInterceptor
+	if c.circuitBreaker != nil {
+		unaryInterceptors = append(unaryInterceptors, c.circuitBreaker.unaryClientInterceptor())
 	}
+	unaryInterceptors = append(unaryInterceptors, c.retryConfig.unaryClientInterceptor())
+	unaryInterceptors = append(unaryInterceptors, timeoutUnaryClientInterceptor(time.Duration(c.timeoutSeconds)*time.Second, c.methodTimeouts))
 	opts := []grpc.DialOption{
-		grpc.WithStreamInterceptor(grpc_util.RetryOnlyForServerStreamInterceptor(retryOpts...)),
+		grpc.WithStreamInterceptor(c.retryConfig.streamClientInterceptor()),
 		grpc.WithChainUnaryInterceptor(unaryInterceptors...),
 		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(MaxGRPCMessageSize), grpc.MaxCallSendMsgSize(MaxGRPCMessageSize)),
 		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
 	}
+	opts = append(opts, c.keepalive.dialOptions()...)
+	if c.dialer != nil {
+		opts = append(opts, grpc.WithContextDialer(c.dialer))
+	}
 
 	tlsC := &tls.Config{}
-	if !tlsConfig.DisableTLS {
-		if !tlsConfig.StrictValidation {
+	// the connections within the process or the pod don't use TLS
+	if !tlsConfig.DisableTLS && c.dialer == nil && !IsUnixSocketAddress(address) {
+		spiffeSource, err := spiffe.DefaultSource()
+		if err != nil {
+			return nil, fmt.Errorf("error loading SPIFFE workload identity: %w", err)
+		}
+		if spiffeSource != nil {
+			// The SPIFFE workload identity takes precedence over the configured certificates
+			tlsC = spiffeSource.ClientTLSConfig()
+		} else if !tlsConfig.StrictValidation {
 			tlsC.InsecureSkipVerify = true
 		} else {
 			tlsC.RootCAs = tlsConfig.Certificates
@@ -91,7 +124,25 @@ func NewConnection(address string, timeoutSeconds int, tlsConfig *TLSConfigurati
 	return conn, nil
 }
 
-// NewRepoServerClientset creates new instance of repo server Clientset
+// NewRepoServerClientset creates new instance of repo server Clientset, retrying the calls according to the retry
+// configuration set by the environment variables
 func NewRepoServerClientset(address string, timeoutSeconds int, tlsConfig TLSConfiguration) Clientset {
-	return &clientSet{address: address, timeoutSeconds: timeoutSeconds, tlsConfig: tlsConfig}
+	return NewRepoServerClientsetWithRetry(address, timeoutSeconds, tlsConfig, NewRetryConfigurationFromEnv())
+}
+
+// NewRepoServerClientsetWithRetry creates new instance of repo server Clientset with the given retry configuration, and
+// the keepalive configuration and timeouts of the methods set by the environment variables
+func NewRepoServerClientsetWithRetry(address string, timeoutSeconds int, tlsConfig TLSConfiguration, retryConfig RetryConfiguration) Clientset {
+	c := &clientSet{
+		address:        address,
+		timeoutSeconds: timeoutSeconds,
+		methodTimeouts: methodTimeoutsFromEnv(),
+		tlsConfig:      tlsConfig,
+		retryConfig:    retryConfig,
+		keepalive:      NewKeepaliveConfigurationFromEnv(),
+	}
+	if retryConfig.CircuitBreakerFailures > 0 {
+		c.circuitBreaker = newCircuitBreaker(retryConfig.CircuitBreakerFailures, retryConfig.CircuitBreakerOpenDuration)
+	}
+	return c
 }
@@ -0,0 +1,76 @@
+package apiclient
+
+import (
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/backoff"
+	"google.golang.org/grpc/keepalive"
+
+	"github.com/argoproj/argo-cd/v3/common"
+	"github.com/argoproj/argo-cd/v3/util/env"
+)
+
+const (
+	// EnvKeepaliveTime is the idle time of a connection to the repo server after which it is pinged, 0 disables the pings
+	EnvKeepaliveTime = "ARGOCD_REPO_SERVER_CLIENT_KEEPALIVE_TIME"
+	// EnvKeepaliveTimeout is the time after which a connection to the repo server is closed if a ping isn't answered
+	EnvKeepaliveTimeout = "ARGOCD_REPO_SERVER_CLIENT_KEEPALIVE_TIMEOUT"
+	// EnvKeepalivePermitWithoutStream enables the pings of the connections to the repo server without active calls
+	EnvKeepalivePermitWithoutStream = "ARGOCD_REPO_SERVER_CLIENT_KEEPALIVE_PERMIT_WITHOUT_STREAM"
+	// EnvWaitForReady makes the calls to the repo server wait for their connection to be re-established, instead of
+	// failing as soon as it is lost
+	EnvWaitForReady = "ARGOCD_REPO_SERVER_CLIENT_WAIT_FOR_READY"
+	// EnvReconnectMaxDelay is the maximum backoff between the attempts to re-establish a connection to the repo server
+	EnvReconnectMaxDelay = "ARGOCD_REPO_SERVER_CLIENT_RECONNECT_MAX_DELAY"
+)
+
+// KeepaliveConfiguration describes the health checks and re-establishment of the connections of a repo server API
+// client, so that the connections silently dropped, e.g. by a load balancer, are detected instead of the calls hanging
+// until their timeout
+type KeepaliveConfiguration struct {
+	// Time is the idle time of a connection after which it is pinged, 0 disables the pings
+	Time time.Duration
+	// Timeout is the time after which a connection is closed if a ping isn't answered
+	Timeout time.Duration
+	// PermitWithoutStream enables the pings of the connections without active calls
+	PermitWithoutStream bool
+	// WaitForReady makes the calls wait, until their deadline, for their connection to be re-established after it was
+	// lost or closed by the repo server with a GOAWAY, instead of failing immediately
+	WaitForReady bool
+	// ReconnectMaxDelay is the maximum backoff between the attempts to re-establish a connection
+	ReconnectMaxDelay time.Duration
+}
+
+// NewKeepaliveConfigurationFromEnv returns the keepalive configuration set by the environment variables. The pings are
+// sent at twice the minimum interval enforced by the Argo CD gRPC servers by default.
+func NewKeepaliveConfigurationFromEnv() KeepaliveConfiguration {
+	return KeepaliveConfiguration{
+		Time:                env.ParseDurationFromEnv(EnvKeepaliveTime, common.GetGRPCKeepAliveTime(), 0, time.Hour),
+		Timeout:             env.ParseDurationFromEnv(EnvKeepaliveTimeout, 20*time.Second, time.Second, time.Hour),
+		PermitWithoutStream: env.ParseBoolFromEnv(EnvKeepalivePermitWithoutStream, false),
+		WaitForReady:        env.ParseBoolFromEnv(EnvWaitForReady, false),
+		ReconnectMaxDelay:   env.ParseDurationFromEnv(EnvReconnectMaxDelay, backoff.DefaultConfig.MaxDelay, time.Second, time.Hour),
+	}
+}
+
+// dialOptions returns the dial options of the connections. gRPC re-establishes a connection lost or closed with a
+// GOAWAY on its next call, with a backoff between the attempts.
+func (c *KeepaliveConfiguration) dialOptions() []grpc.DialOption {
+	backoffConfig := backoff.DefaultConfig
+	backoffConfig.MaxDelay = c.ReconnectMaxDelay
+	opts := []grpc.DialOption{
+		grpc.WithConnectParams(grpc.ConnectParams{Backoff: backoffConfig}),
+	}
+	if c.Time > 0 {
+		opts = append(opts, grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                c.Time,
+			Timeout:             c.Timeout,
+			PermitWithoutStream: c.PermitWithoutStream,
+		}))
+	}
+	if c.WaitForReady {
+		opts = append(opts, grpc.WithDefaultCallOptions(grpc.WaitForReady(true)))
+	}
+	return opts
+}
@@ -0,0 +1,44 @@
+package apiclient
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/argoproj/argo-cd/v3/common"
+)
+
+func TestNewKeepaliveConfigurationFromEnv(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		config := NewKeepaliveConfigurationFromEnv()
+
+		assert.Equal(t, common.GetGRPCKeepAliveTime(), config.Time)
+		assert.Equal(t, 20*time.Second, config.Timeout)
+		assert.False(t, config.PermitWithoutStream)
+		assert.False(t, config.WaitForReady)
+		assert.Equal(t, 120*time.Second, config.ReconnectMaxDelay)
+		// the connect parameters and the keepalive parameters
+		assert.Len(t, config.dialOptions(), 2)
+	})
+
+	t.Run("Configured", func(t *testing.T) {
+		t.Setenv(EnvKeepaliveTime, "0")
+		t.Setenv(EnvKeepaliveTimeout, "5s")
+		t.Setenv(EnvKeepalivePermitWithoutStream, "true")
+		t.Setenv(EnvWaitForReady, "true")
+		t.Setenv(EnvReconnectMaxDelay, "10s")
+
+		config := NewKeepaliveConfigurationFromEnv()
+
+		assert.Equal(t, KeepaliveConfiguration{
+			Time:                0,
+			Timeout:             5 * time.Second,
+			PermitWithoutStream: true,
+			WaitForReady:        true,
+			ReconnectMaxDelay:   10 * time.Second,
+		}, config)
+		// the connect parameters and the wait for ready call option, the pings being disabled
+		assert.Len(t, config.dialOptions(), 2)
+	})
+}
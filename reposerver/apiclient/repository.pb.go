@@ -63,7 +63,9 @@ type ManifestRequest struct {
 	// argocd.argoproj.io/manifest-generate-paths annotation value of the Application to allow optimize which resources propagated to cmpserver
 	AnnotationManifestGeneratePaths string `protobuf:"bytes,26,opt,name=annotationManifestGeneratePaths,proto3" json:"annotationManifestGeneratePaths,omitempty"`
 	// Holds instance installation id
-	InstallationID       string   `protobuf:"bytes,27,opt,name=installationID,proto3" json:"installationID,omitempty"`
+	InstallationID string `protobuf:"bytes,27,opt,name=installationID,proto3" json:"installationID,omitempty"`
+	// SettingsVersion is the version of the settings of the component sending the request
+	SettingsVersion      string   `protobuf:"bytes,28,opt,name=settingsVersion,proto3" json:"settingsVersion,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -270,6 +272,13 @@ func (m *ManifestRequest) GetInstallationID() string {
 	return ""
 }
 
+func (m *ManifestRequest) GetSettingsVersion() string {
+	if m != nil {
+		return m.SettingsVersion
+	}
+	return ""
+}
+
 type ManifestRequestWithFiles struct {
 	// Types that are valid to be assigned to Part:
 	//	*ManifestRequestWithFiles_Request
@@ -711,7 +720,9 @@ type ManifestResponse struct {
 	// Raw response of git verify-commit operation (always the empty string for Helm)
 	VerifyResult string `protobuf:"bytes,7,opt,name=verifyResult,proto3" json:"verifyResult,omitempty"`
 	// Commands is the list of commands used to hydrate the manifests
-	Commands             []string `protobuf:"bytes,8,rep,name=commands,proto3" json:"commands,omitempty"`
+	Commands []string `protobuf:"bytes,8,rep,name=commands,proto3" json:"commands,omitempty"`
+	// ToolVersion is the Helm, Kustomize or config management plugin version which rendered the manifests, empty for the default version
+	ToolVersion          string   `protobuf:"bytes,9,opt,name=toolVersion,proto3" json:"toolVersion,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -799,6 +810,13 @@ func (m *ManifestResponse) GetCommands() []string {
 	return nil
 }
 
+func (m *ManifestResponse) GetToolVersion() string {
+	if m != nil {
+		return m.ToolVersion
+	}
+	return ""
+}
+
 type ListRefsRequest struct {
 	Repo                 *v1alpha1.Repository `protobuf:"bytes,1,opt,name=repo,proto3" json:"repo,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
@@ -2478,158 +2496,159 @@ func init() {
 }
 
 var fileDescriptor_dd8723cfcc820480 = []byte{
-	// 2407 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xdc, 0x1a, 0xcb, 0x72, 0x1c, 0x49,
-	0x71, 0x9e, 0xd2, 0x4c, 0xea, 0x5d, 0xb6, 0xe5, 0xf6, 0xd8, 0x16, 0xda, 0x06, 0x3b, 0xbc, 0xf6,
-	0xee, 0x28, 0x6c, 0xc7, 0xae, 0xc1, 0xbb, 0x2c, 0xa1, 0x95, 0x6d, 0x49, 0x6b, 0xcb, 0x16, 0x6d,
-	0xef, 0x12, 0x06, 0x03, 0x51, 0xd3, 0x53, 0xea, 0xe9, 0x55, 0x3f, 0xca, 0xdd, 0xd5, 0x5a, 0xe4,
-	0x08, 0x2e, 0x40, 0x70, 0xe1, 0xc2, 0x69, 0x0f, 0x5c, 0xf9, 0x06, 0x82, 0x23, 0x27, 0x02, 0x8e,
-	0x04, 0x17, 0x2e, 0x44, 0x40, 0xf8, 0x4b, 0x88, 0x7a, 0xf4, 0x73, 0x7a, 0x46, 0x5a, 0x8f, 0xad,
-	0x05, 0x2e, 0x52, 0x57, 0x56, 0x56, 0x66, 0x56, 0x56, 0x66, 0x56, 0x66, 0xd6, 0xc0, 0xe5, 0x80,
-	0x50, 0x3f, 0x24, 0xc1, 0x01, 0x09, 0xd6, 0xc4, 0xa7, 0xcd, 0xfc, 0xe0, 0x30, 0xf3, 0xd9, 0xa5,
-	0x81, 0xcf, 0x7c, 0x04, 0x29, 0xa4, 0xf3, 0xc0, 0xb2, 0xd9, 0x20, 0xea, 0x75, 0x4d, 0xdf, 0x5d,
-	0xc3, 0x81, 0xe5, 0xd3, 0xc0, 0xff, 0x5c, 0x7c, 0xbc, 0x6b, 0xf6, 0xd7, 0x0e, 0x6e, 0xae, 0xd1,
-	0x7d, 0x6b, 0x0d, 0x53, 0x3b, 0x5c, 0xc3, 0x94, 0x3a, 0xb6, 0x89, 0x99, 0xed, 0x7b, 0x6b, 0x07,
-	0xd7, 0xb1, 0x43, 0x07, 0xf8, 0xfa, 0x9a, 0x45, 0x3c, 0x12, 0x60, 0x46, 0xfa, 0x92, 0x72, 0xe7,
-	0xbc, 0xe5, 0xfb, 0x96, 0x43, 0xd6, 0xc4, 0xa8, 0x17, 0xed, 0xad, 0x11, 0x97, 0x32, 0xc5, 0x56,
-	0xff, 0xe7, 0x1c, 0x2c, 0xec, 0x60, 0xcf, 0xde, 0x23, 0x21, 0x33, 0xc8, 0xf3, 0x88, 0x84, 0x0c,
-	0x3d, 0x83, 0x06, 0x17, 0x46, 0xab, 0xae, 0x56, 0xaf, 0xcc, 0xdc, 0xd8, 0xea, 0xa6, 0xd2, 0x74,
-	0x63, 0x69, 0xc4, 0xc7, 0x4f, 0xcd, 0x7e, 0xf7, 0xe0, 0x66, 0x97, 0xee, 0x5b, 0x5d, 0x2e, 0x4d,
-	0x37, 0x23, 0x4d, 0x37, 0x96, 0xa6, 0x6b, 0x24, 0xdb, 0x32, 0x04, 0x55, 0xd4, 0x81, 0x56, 0x40,
-	0x0e, 0xec, 0xd0, 0xf6, 0x3d, 0xad, 0xb6, 0x5a, 0xbd, 0xd2, 0x36, 0x92, 0x31, 0xd2, 0x60, 0xda,
-	0xf3, 0x37, 0xb0, 0x39, 0x20, 0x5a, 0x7d, 0xb5, 0x7a, 0xa5, 0x65, 0xc4, 0x43, 0xb4, 0x0a, 0x33,
-	0x98, 0xd2, 0x07, 0xb8, 0x47, 0x9c, 0xfb, 0xe4, 0x50, 0x6b, 0x88, 0x85, 0x59, 0x10, 0x5f, 0x8b,
-	0x29, 0x7d, 0x88, 0x5d, 0xa2, 0x35, 0xc5, 0x6c, 0x3c, 0x44, 0x17, 0xa0, 0xed, 0x61, 0x97, 0x84,
-	0x14, 0x9b, 0x44, 0x6b, 0x89, 0xb9, 0x14, 0x80, 0x7e, 0x0e, 0x4b, 0x19, 0xc1, 0x1f, 0xfb, 0x51,
-	0x60, 0x12, 0x0d, 0xc4, 0xd6, 0x1f, 0x4d, 0xb6, 0xf5, 0xf5, 0x22, 0x59, 0x63, 0x98, 0x13, 0xfa,
-	0x09, 0x34, 0xc5, 0xc9, 0x6b, 0x33, 0xab, 0xf5, 0xd7, 0xaa, 0x6d, 0x49, 0x16, 0x79, 0x30, 0x4d,
-	0x9d, 0xc8, 0xb2, 0xbd, 0x50, 0x9b, 0x15, 0x1c, 0x9e, 0x4c, 0xc6, 0x61, 0xc3, 0xf7, 0xf6, 0x6c,
-	0x6b, 0x07, 0x7b, 0xd8, 0x22, 0x2e, 0xf1, 0xd8, 0xae, 0x20, 0x6e, 0xc4, 0x4c, 0xd0, 0x0b, 0x58,
-	0xdc, 0x8f, 0x42, 0xe6, 0xbb, 0xf6, 0x0b, 0xf2, 0x88, 0xf2, 0xb5, 0xa1, 0x36, 0x27, 0xb4, 0xf9,
-	0x70, 0x32, 0xc6, 0xf7, 0x0b, 0x54, 0x8d, 0x21, 0x3e, 0xdc, 0x48, 0xf6, 0xa3, 0x1e, 0xf9, 0x8c,
-	0x04, 0xc2, 0xba, 0xe6, 0xa5, 0x91, 0x64, 0x40, 0xd2, 0x8c, 0x6c, 0x35, 0x0a, 0xb5, 0x85, 0xd5,
-	0xba, 0x34, 0xa3, 0x04, 0x84, 0xae, 0xc0, 0xc2, 0x01, 0x09, 0xec, 0xbd, 0xc3, 0xc7, 0xb6, 0xe5,
-	0x61, 0x16, 0x05, 0x44, 0x5b, 0x14, 0xa6, 0x58, 0x04, 0x23, 0x17, 0xe6, 0x06, 0xc4, 0x71, 0xb9,
-	0xca, 0x37, 0x02, 0xd2, 0x0f, 0xb5, 0x25, 0xa1, 0xdf, 0xcd, 0xc9, 0x4f, 0x50, 0x90, 0x33, 0xf2,
-	0xd4, 0xb9, 0x60, 0x9e, 0x6f, 0x28, 0x4f, 0x91, 0x3e, 0x82, 0xa4, 0x60, 0x05, 0x30, 0xba, 0x0c,
-	0xf3, 0x2c, 0xc0, 0xe6, 0xbe, 0xed, 0x59, 0x3b, 0x84, 0x0d, 0xfc, 0xbe, 0x76, 0x4a, 0x68, 0xa2,
-	0x00, 0x45, 0x26, 0x20, 0xe2, 0xe1, 0x9e, 0x43, 0xfa, 0xd2, 0x16, 0x9f, 0x1c, 0x52, 0x12, 0x6a,
-	0xa7, 0xc5, 0x2e, 0x6e, 0x76, 0x33, 0x11, 0xaa, 0x10, 0x20, 0xba, 0x77, 0x87, 0x56, 0xdd, 0xf5,
-	0x58, 0x70, 0x68, 0x94, 0x90, 0x43, 0xfb, 0x30, 0xc3, 0xf7, 0x11, 0x9b, 0xc2, 0x19, 0x61, 0x0a,
-	0xdb, 0x93, 0xe9, 0x68, 0x2b, 0x25, 0x68, 0x64, 0xa9, 0xa3, 0x2e, 0xa0, 0x01, 0x0e, 0x77, 0x22,
-	0x87, 0xd9, 0xd4, 0x21, 0x52, 0x8c, 0x50, 0x5b, 0x16, 0x6a, 0x2a, 0x99, 0x41, 0xf7, 0x01, 0x02,
-	0xb2, 0x17, 0xe3, 0x9d, 0x15, 0x3b, 0xbf, 0x36, 0x6e, 0xe7, 0x46, 0x82, 0x2d, 0x77, 0x9c, 0x59,
-	0xce, 0x99, 0xf3, 0x6d, 0x10, 0x93, 0x29, 0x6f, 0x17, 0x6e, 0xad, 0x09, 0x13, 0x2b, 0x99, 0xe1,
-	0xb6, 0xa8, 0xa0, 0x22, 0x68, 0x9d, 0x93, 0xd6, 0x9a, 0x01, 0xa1, 0x2d, 0xf8, 0x06, 0xf6, 0x3c,
-	0x9f, 0x89, 0xed, 0xc7, 0xa2, 0x6c, 0xaa, 0xf0, 0xbe, 0x8b, 0xd9, 0x20, 0xd4, 0x3a, 0x62, 0xd5,
-	0x51, 0x68, 0xdc, 0x24, 0x6c, 0x2f, 0x64, 0xd8, 0x71, 0x04, 0xd2, 0xf6, 0x1d, 0xed, 0xbc, 0x34,
-	0x89, 0x3c, 0xb4, 0x73, 0x17, 0xce, 0x8e, 0x38, 0x5c, 0xb4, 0x08, 0xf5, 0x7d, 0x72, 0x28, 0x2e,
-	0x85, 0xb6, 0xc1, 0x3f, 0xd1, 0x69, 0x68, 0x1e, 0x60, 0x27, 0x22, 0x22, 0x8c, 0xb7, 0x0c, 0x39,
-	0xb8, 0x5d, 0xfb, 0x76, 0xb5, 0xf3, 0xeb, 0x2a, 0x2c, 0x14, 0x54, 0x55, 0xb2, 0xfe, 0xc7, 0xd9,
-	0xf5, 0xaf, 0xc1, 0x71, 0xf6, 0x9e, 0xe0, 0xc0, 0x22, 0x2c, 0x23, 0x88, 0xfe, 0xf7, 0x2a, 0x68,
-	0x85, 0x33, 0xfc, 0x81, 0xcd, 0x06, 0xf7, 0x6c, 0x87, 0x84, 0xe8, 0x16, 0x4c, 0x07, 0x12, 0xa6,
-	0xae, 0xba, 0xf3, 0x63, 0x8e, 0x7e, 0xab, 0x62, 0xc4, 0xd8, 0xe8, 0x23, 0x68, 0xb9, 0x84, 0xe1,
-	0x3e, 0x66, 0x58, 0xc9, 0xbe, 0x5a, 0xb6, 0x92, 0x73, 0xd9, 0x51, 0x78, 0x5b, 0x15, 0x23, 0x59,
-	0x83, 0xde, 0x83, 0xa6, 0x39, 0x88, 0xbc, 0x7d, 0x71, 0xc9, 0xcd, 0xdc, 0xb8, 0x38, 0x6a, 0xf1,
-	0x06, 0x47, 0xda, 0xaa, 0x18, 0x12, 0xfb, 0xe3, 0x29, 0x68, 0x50, 0x1c, 0x30, 0xfd, 0x1e, 0x9c,
-	0x2e, 0x63, 0xc1, 0x6f, 0x56, 0x73, 0x40, 0xcc, 0xfd, 0x30, 0x72, 0x95, 0x9a, 0x93, 0x31, 0x42,
-	0xd0, 0x08, 0xed, 0x17, 0x52, 0xd5, 0x75, 0x43, 0x7c, 0xeb, 0x6f, 0xc3, 0xd2, 0x10, 0x37, 0x7e,
-	0xa8, 0x52, 0x36, 0x4e, 0x61, 0x56, 0xb1, 0xd6, 0x23, 0x38, 0xf3, 0x44, 0xe8, 0x22, 0xb9, 0x5e,
-	0x4e, 0x22, 0x57, 0xd0, 0xb7, 0x60, 0xb9, 0xc8, 0x36, 0xa4, 0xbe, 0x17, 0x12, 0xee, 0x6c, 0x22,
-	0x1e, 0xdb, 0xa4, 0x9f, 0xce, 0x0a, 0x29, 0x5a, 0x46, 0xc9, 0x8c, 0xfe, 0xfb, 0x1a, 0x2c, 0x1b,
-	0x24, 0xf4, 0x9d, 0x03, 0x12, 0x07, 0xcb, 0x93, 0x49, 0x77, 0x7e, 0x04, 0x75, 0x4c, 0xa9, 0x32,
-	0x93, 0xed, 0xd7, 0x96, 0x50, 0x18, 0x9c, 0x2a, 0x7a, 0x07, 0x96, 0xb0, 0xdb, 0xb3, 0xad, 0xc8,
-	0x8f, 0xc2, 0x78, 0x5b, 0xc2, 0xa8, 0xda, 0xc6, 0xf0, 0x04, 0x0f, 0x38, 0xa1, 0xf0, 0xc8, 0x6d,
-	0xaf, 0x4f, 0x7e, 0x26, 0x72, 0xa8, 0xba, 0x91, 0x05, 0xe9, 0x26, 0x9c, 0x1d, 0x52, 0x92, 0x52,
-	0x78, 0x36, 0x6d, 0xab, 0x16, 0xd2, 0xb6, 0x52, 0x31, 0x6a, 0x23, 0xc4, 0xd0, 0x5f, 0x56, 0x61,
-	0x31, 0x75, 0x2e, 0x45, 0xfe, 0x02, 0xb4, 0x5d, 0x05, 0x0b, 0xb5, 0xaa, 0x88, 0x99, 0x29, 0x20,
-	0x9f, 0xc1, 0xd5, 0x8a, 0x19, 0xdc, 0x32, 0x4c, 0xc9, 0x04, 0x5b, 0x6d, 0x5d, 0x8d, 0x72, 0x22,
-	0x37, 0x0a, 0x22, 0xaf, 0x00, 0x84, 0x49, 0x84, 0xd3, 0xa6, 0xc4, 0x6c, 0x06, 0x82, 0x74, 0x98,
-	0x95, 0xf7, 0xbd, 0x41, 0xc2, 0xc8, 0x61, 0xda, 0xb4, 0xc0, 0xc8, 0xc1, 0x84, 0xbf, 0xf9, 0xae,
-	0x8b, 0xbd, 0x7e, 0xa8, 0xb5, 0x84, 0xc8, 0xc9, 0x58, 0xf7, 0x61, 0xe1, 0x81, 0xcd, 0xf7, 0xb7,
-	0x17, 0x9e, 0x8c, 0xab, 0xbc, 0x0f, 0x0d, 0xce, 0x8c, 0x0b, 0xd5, 0x0b, 0xb0, 0x67, 0x0e, 0x48,
-	0xac, 0xc7, 0x64, 0xcc, 0x83, 0x00, 0xc3, 0x56, 0xa8, 0xd5, 0x04, 0x5c, 0x7c, 0xeb, 0x7f, 0xac,
-	0x49, 0x49, 0xd7, 0x29, 0x0d, 0xbf, 0xfe, 0x02, 0xa0, 0x3c, 0x25, 0xa9, 0x0f, 0xa7, 0x24, 0x05,
-	0x91, 0xbf, 0x4a, 0x4a, 0xf2, 0x9a, 0x2e, 0x39, 0x3d, 0x82, 0xe9, 0x75, 0x4a, 0xb9, 0x20, 0xe8,
-	0x3a, 0x34, 0x30, 0xa5, 0x52, 0xe1, 0x85, 0x78, 0xae, 0x50, 0xf8, 0x7f, 0x25, 0x92, 0x40, 0xed,
-	0xdc, 0x82, 0x76, 0x02, 0x3a, 0x8a, 0x6d, 0x3b, 0xcb, 0x76, 0x15, 0x40, 0xe6, 0xdc, 0xdb, 0xde,
-	0x9e, 0xcf, 0x8f, 0x94, 0x3b, 0x82, 0x5a, 0x2a, 0xbe, 0xf5, 0xdb, 0x31, 0x86, 0x90, 0xed, 0x1d,
-	0x68, 0xda, 0x8c, 0xb8, 0xb1, 0x70, 0xcb, 0x59, 0xe1, 0x52, 0x42, 0x86, 0x44, 0xd2, 0xff, 0xd2,
-	0x82, 0x73, 0xfc, 0xc4, 0x1e, 0x0b, 0x17, 0x5a, 0xa7, 0xf4, 0x0e, 0x61, 0xd8, 0x76, 0xc2, 0xef,
-	0x47, 0x24, 0x38, 0x7c, 0xc3, 0x86, 0x61, 0xc1, 0x94, 0xf4, 0x40, 0x15, 0x2d, 0x5f, 0x7b, 0xf9,
-	0xa5, 0xc8, 0xa7, 0x35, 0x57, 0xfd, 0xcd, 0xd4, 0x5c, 0x65, 0x35, 0x50, 0xe3, 0x84, 0x6a, 0xa0,
-	0xd1, 0x65, 0x70, 0xa6, 0xb8, 0x9e, 0xca, 0x17, 0xd7, 0x25, 0xa5, 0xc5, 0xf4, 0x71, 0x4b, 0x8b,
-	0x56, 0x69, 0x69, 0xe1, 0x96, 0xfa, 0x71, 0x5b, 0xa8, 0xfb, 0xbb, 0x59, 0x0b, 0x1c, 0x69, 0x6b,
-	0x93, 0x14, 0x19, 0xf0, 0x46, 0x8b, 0x8c, 0x4f, 0x73, 0x45, 0x83, 0x2c, 0xdb, 0xdf, 0x3b, 0xde,
-	0x9e, 0xc6, 0x94, 0x0f, 0xff, 0x77, 0xa9, 0xf7, 0xaf, 0x44, 0xc6, 0x45, 0xfd, 0x54, 0x07, 0xc9,
-	0x65, 0xcf, 0xef, 0x21, 0x7e, 0xed, 0xaa, 0xa0, 0xc5, 0xbf, 0xd1, 0x35, 0x68, 0x70, 0x25, 0xab,
-	0x94, 0xf8, 0x6c, 0x56, 0x9f, 0xfc, 0x24, 0xd6, 0x29, 0x7d, 0x4c, 0x89, 0x69, 0x08, 0x24, 0x74,
-	0x1b, 0xda, 0x89, 0xe1, 0x2b, 0xcf, 0xba, 0x90, 0x5d, 0x91, 0xf8, 0x49, 0xbc, 0x2c, 0x45, 0xe7,
-	0x6b, 0xfb, 0x76, 0x40, 0x4c, 0x91, 0x30, 0x36, 0x87, 0xd7, 0xde, 0x89, 0x27, 0x93, 0xb5, 0x09,
-	0x3a, 0xba, 0x0e, 0x53, 0xb2, 0xcf, 0x21, 0x3c, 0x68, 0xe6, 0xc6, 0xb9, 0xe1, 0x60, 0x1a, 0xaf,
-	0x52, 0x88, 0xfa, 0x9f, 0xab, 0xf0, 0x56, 0x6a, 0x10, 0xb1, 0x37, 0xc5, 0x39, 0xfb, 0xd7, 0x7f,
-	0xe3, 0x5e, 0x86, 0x79, 0x51, 0x24, 0xa4, 0xed, 0x0e, 0xd9, 0x79, 0x2b, 0x40, 0xf5, 0x3f, 0x54,
-	0xe1, 0xd2, 0xf0, 0x3e, 0x36, 0x06, 0x38, 0x60, 0xc9, 0xf1, 0x9e, 0xc4, 0x5e, 0xe2, 0x0b, 0xaf,
-	0x96, 0x5e, 0x78, 0xb9, 0xfd, 0xd5, 0xf3, 0xfb, 0xd3, 0xff, 0x54, 0x83, 0x99, 0x8c, 0x01, 0x95,
-	0x5d, 0x98, 0x3c, 0x19, 0x14, 0x76, 0x2b, 0xca, 0x42, 0x71, 0x29, 0xb4, 0x8d, 0x0c, 0x04, 0xed,
-	0x03, 0x50, 0x1c, 0x60, 0x97, 0x30, 0x12, 0xf0, 0x48, 0xce, 0x3d, 0xfe, 0xfe, 0xe4, 0xd1, 0x65,
-	0x37, 0xa6, 0x69, 0x64, 0xc8, 0xf3, 0x6c, 0x56, 0xb0, 0x0e, 0x55, 0xfc, 0x56, 0x23, 0xf4, 0x05,
-	0xcc, 0xef, 0xd9, 0x0e, 0xd9, 0x4d, 0x05, 0x99, 0x12, 0x82, 0x3c, 0x9a, 0x5c, 0x90, 0x7b, 0x59,
-	0xba, 0x46, 0x81, 0x8d, 0x7e, 0x15, 0x16, 0x8b, 0xfe, 0xc4, 0x85, 0xb4, 0x5d, 0x6c, 0x25, 0xda,
-	0x52, 0x23, 0x1d, 0xc1, 0x62, 0xd1, 0x7f, 0xf4, 0x7f, 0xd5, 0xe0, 0x4c, 0x42, 0x6e, 0xdd, 0xf3,
-	0xfc, 0xc8, 0x33, 0x45, 0xeb, 0xb0, 0xf4, 0x2c, 0x4e, 0x43, 0x93, 0xd9, 0xcc, 0x49, 0x12, 0x1f,
-	0x31, 0xe0, 0x77, 0x17, 0xf3, 0x7d, 0x87, 0xd9, 0x54, 0x1d, 0x70, 0x3c, 0x94, 0x67, 0xff, 0x3c,
-	0xb2, 0x03, 0xd2, 0x17, 0x91, 0xa0, 0x65, 0x24, 0x63, 0x3e, 0xc7, 0xb3, 0x1a, 0x91, 0xe2, 0x4b,
-	0x65, 0x26, 0x63, 0x61, 0xf7, 0xbe, 0xe3, 0x10, 0x93, 0xab, 0x23, 0x53, 0x04, 0x14, 0xa0, 0xa2,
-	0xb8, 0x60, 0x81, 0xed, 0x59, 0xaa, 0x04, 0x50, 0x23, 0x2e, 0x27, 0x0e, 0x02, 0x7c, 0xa8, 0x32,
-	0x7f, 0x39, 0x40, 0x1f, 0x42, 0xdd, 0xc5, 0x54, 0x5d, 0x74, 0x57, 0x73, 0xd1, 0xa1, 0x4c, 0x03,
-	0xdd, 0x1d, 0x4c, 0xe5, 0x4d, 0xc0, 0x97, 0x75, 0xde, 0x87, 0x56, 0x0c, 0xf8, 0x4a, 0x29, 0xe1,
-	0xe7, 0x30, 0x97, 0x0b, 0x3e, 0xe8, 0x29, 0x2c, 0xa7, 0x16, 0x95, 0x65, 0xa8, 0x92, 0xc0, 0xb7,
-	0x8e, 0x94, 0xcc, 0x18, 0x41, 0x40, 0x7f, 0x0e, 0x4b, 0xdc, 0x64, 0x84, 0xe3, 0x9f, 0x50, 0x69,
-	0xf3, 0x01, 0xb4, 0x13, 0x96, 0xa5, 0x36, 0xd3, 0x81, 0xd6, 0x41, 0xdc, 0xd2, 0x95, 0xb5, 0x4d,
-	0x32, 0xd6, 0xd7, 0x01, 0x65, 0xe5, 0x55, 0x37, 0xd0, 0xb5, 0x7c, 0x52, 0x7c, 0xa6, 0x78, 0xdd,
-	0x08, 0xf4, 0x38, 0x27, 0xfe, 0x47, 0x0d, 0x16, 0x36, 0x6d, 0xd1, 0x23, 0x39, 0xa1, 0x20, 0x77,
-	0x15, 0x16, 0xc3, 0xa8, 0xe7, 0xfa, 0xfd, 0xc8, 0x21, 0x2a, 0x29, 0x50, 0x37, 0xfd, 0x10, 0x7c,
-	0x5c, 0xf0, 0xe3, 0xca, 0xa2, 0x98, 0x0d, 0x54, 0xf5, 0x2b, 0xbe, 0xd1, 0x87, 0x70, 0xee, 0x21,
-	0xf9, 0x42, 0xed, 0x67, 0xd3, 0xf1, 0x7b, 0x3d, 0xdb, 0xb3, 0x62, 0x26, 0x4d, 0xc1, 0x64, 0x34,
-	0x42, 0x59, 0xaa, 0x38, 0x55, 0x9e, 0x2a, 0x26, 0x15, 0xf4, 0x86, 0xef, 0xba, 0x36, 0x53, 0x19,
-	0x65, 0x0e, 0xa6, 0xff, 0xb2, 0x0a, 0x8b, 0xa9, 0x66, 0xd5, 0xd9, 0xdc, 0x92, 0x3e, 0x24, 0x4f,
-	0xe6, 0x52, 0xf6, 0x64, 0x8a, 0xa8, 0xaf, 0xee, 0x3e, 0xb3, 0x59, 0xf7, 0xf9, 0x4d, 0x0d, 0xce,
-	0x6c, 0xda, 0x2c, 0x0e, 0x5c, 0xf6, 0xff, 0xda, 0x29, 0x97, 0x9c, 0x49, 0xe3, 0x78, 0x67, 0xd2,
-	0x2c, 0x39, 0x93, 0x2e, 0x2c, 0x17, 0x95, 0xa1, 0x0e, 0xe6, 0x34, 0x34, 0xa9, 0x68, 0x3a, 0xcb,
-	0xbe, 0x82, 0x1c, 0xe8, 0xbf, 0x98, 0x86, 0x8b, 0x9f, 0xd2, 0x3e, 0x66, 0x49, 0xcf, 0xe8, 0x9e,
-	0x1f, 0x88, 0xae, 0xf3, 0xc9, 0x68, 0xb1, 0xf0, 0x32, 0x58, 0x1b, 0xfb, 0x32, 0x58, 0x1f, 0xf3,
-	0x32, 0xd8, 0x38, 0xd6, 0xcb, 0x60, 0xf3, 0xc4, 0x5e, 0x06, 0x87, 0x6b, 0xad, 0xa9, 0xd2, 0x5a,
-	0xeb, 0x69, 0xae, 0x1e, 0x99, 0x16, 0x6e, 0xf3, 0x9d, 0xac, 0xdb, 0x8c, 0x3d, 0x9d, 0xb1, 0x4f,
-	0x1a, 0x85, 0x07, 0xb5, 0xd6, 0x91, 0x0f, 0x6a, 0xed, 0xe1, 0x07, 0xb5, 0xf2, 0x37, 0x19, 0x18,
-	0xf9, 0x26, 0x73, 0x19, 0xe6, 0xc3, 0x43, 0xcf, 0x24, 0xfd, 0xa4, 0x93, 0x38, 0x23, 0xb7, 0x9d,
-	0x87, 0xe6, 0x3c, 0x62, 0xb6, 0xe0, 0x11, 0x89, 0xa5, 0xce, 0x65, 0x2c, 0xb5, 0xcc, 0x4f, 0xe6,
-	0x47, 0x96, 0xb9, 0x85, 0xe7, 0x92, 0x85, 0xd2, 0xe7, 0x92, 0xff, 0x9a, 0x62, 0xeb, 0x33, 0x58,
-	0x19, 0x75, 0xca, 0xca, 0x79, 0x35, 0x98, 0x36, 0x07, 0xd8, 0xb3, 0x44, 0x5b, 0x50, 0x54, 0xff,
-	0x6a, 0x38, 0xae, 0x3a, 0xb8, 0xf1, 0xe5, 0x2c, 0x2c, 0xa5, 0x59, 0x3f, 0xff, 0x6b, 0x9b, 0x04,
-	0x3d, 0x82, 0xc5, 0xf8, 0x79, 0x29, 0x6e, 0xe4, 0xa2, 0x71, 0x6f, 0x27, 0x9d, 0x0b, 0xe5, 0x93,
-	0x52, 0x34, 0xbd, 0x82, 0x4c, 0x38, 0x57, 0x24, 0x98, 0x3e, 0xd3, 0x7c, 0x6b, 0x0c, 0xe5, 0x04,
-	0xeb, 0x28, 0x16, 0x57, 0xaa, 0xe8, 0x29, 0xcc, 0xe7, 0x1f, 0x13, 0x50, 0x2e, 0x0d, 0x2a, 0x7d,
-	0xdf, 0xe8, 0xe8, 0xe3, 0x50, 0x12, 0xf9, 0x9f, 0x71, 0x33, 0xc8, 0xf5, 0xcd, 0x91, 0x9e, 0xef,
-	0x08, 0x94, 0xbd, 0x3c, 0x74, 0xbe, 0x39, 0x16, 0x27, 0xa1, 0xfe, 0x01, 0xb4, 0xe2, 0x5e, 0x72,
-	0x5e, 0xcd, 0x85, 0x0e, 0x73, 0x67, 0x31, 0x4f, 0x6f, 0x2f, 0xd4, 0x2b, 0xe8, 0x23, 0x98, 0xe1,
-	0x68, 0x8f, 0x36, 0xb6, 0x9f, 0x60, 0xeb, 0x95, 0xd6, 0xb7, 0xe2, 0x5e, 0xeb, 0xf0, 0xe2, 0x4c,
-	0x07, 0xb6, 0x73, 0xaa, 0xa4, 0xeb, 0xa9, 0x57, 0xd0, 0xf7, 0x24, 0xff, 0x5d, 0xf5, 0xf3, 0x80,
-	0xe5, 0xae, 0xfc, 0x35, 0x4a, 0x37, 0xfe, 0x35, 0x4a, 0xf7, 0xae, 0x4b, 0xd9, 0x61, 0xa7, 0xa4,
-	0x2d, 0xa9, 0x08, 0x3c, 0x83, 0xb9, 0x4d, 0xc2, 0xd2, 0x2e, 0x02, 0xba, 0x74, 0xac, 0x5e, 0x4b,
-	0x47, 0x2f, 0xa2, 0x0d, 0x37, 0x22, 0xf4, 0x0a, 0xfa, 0xb2, 0x0a, 0xa7, 0x36, 0x09, 0x2b, 0xd6,
-	0xe5, 0xe8, 0xdd, 0x72, 0x26, 0x23, 0xea, 0xf7, 0xce, 0xc3, 0x49, 0x7d, 0x3a, 0x4f, 0x56, 0xaf,
-	0xa0, 0xdf, 0x56, 0x61, 0x7e, 0x93, 0xf0, 0x73, 0x4b, 0x64, 0xba, 0x3e, 0x5e, 0xa6, 0x92, 0x5a,
-	0xbc, 0x33, 0x61, 0x0f, 0x2c, 0xc3, 0x5d, 0xaf, 0xa0, 0xdf, 0x55, 0xe1, 0x6c, 0x46, 0x57, 0x59,
-	0x7e, 0xaf, 0x22, 0xdb, 0x27, 0x13, 0xfe, 0x10, 0x25, 0x43, 0x52, 0xaf, 0xa0, 0x5d, 0x61, 0x26,
-	0x69, 0xaa, 0x8f, 0x2e, 0x96, 0xe6, 0xf4, 0x09, 0xf7, 0x95, 0x51, 0xd3, 0x89, 0x69, 0x7c, 0x02,
-	0x33, 0x9b, 0x84, 0xc5, 0x39, 0x67, 0xde, 0xf8, 0x0b, 0xe5, 0x40, 0x3e, 0xfa, 0x14, 0xd3, 0x54,
-	0x61, 0xc4, 0x4b, 0x92, 0x56, 0x26, 0xaf, 0xca, 0x87, 0x9f, 0xd2, 0x04, 0x34, 0x6f, 0xc4, 0xe5,
-	0x69, 0x99, 0x5e, 0x41, 0xcf, 0x61, 0xb9, 0x3c, 0xfa, 0xa3, 0xb7, 0x8f, 0x9d, 0x07, 0x74, 0xae,
-	0x1e, 0x07, 0x35, 0x66, 0xf9, 0xf1, 0xfa, 0x5f, 0x5f, 0xae, 0x54, 0xff, 0xf6, 0x72, 0xa5, 0xfa,
-	0xef, 0x97, 0x2b, 0xd5, 0x1f, 0xde, 0x3c, 0xe2, 0x07, 0x6b, 0x99, 0xdf, 0xc0, 0x61, 0x6a, 0x9b,
-	0x8e, 0x4d, 0x3c, 0xd6, 0x9b, 0x12, 0x21, 0xe0, 0xe6, 0x7f, 0x02, 0x00, 0x00, 0xff, 0xff, 0xc9,
-	0x9b, 0xb9, 0x62, 0x22, 0x27, 0x00, 0x00,
+	// 2432 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xdc, 0x3a, 0x4b, 0x73, 0xdc, 0xc6,
+	0xd1, 0xdc, 0x27, 0x77, 0x9b, 0x14, 0x1f, 0x23, 0x89, 0x82, 0x20, 0x8a, 0x1f, 0x8d, 0x2f, 0x52,
+	0xc9, 0x92, 0xbd, 0x2c, 0x49, 0x65, 0x2b, 0x91, 0x1d, 0xa7, 0x68, 0x4a, 0x22, 0x65, 0x89, 0x12,
+	0x03, 0xd1, 0x4e, 0x29, 0x51, 0x92, 0x9a, 0xc5, 0x0e, 0xb1, 0x30, 0xf1, 0x18, 0x01, 0x03, 0x3a,
+	0x54, 0x55, 0x2e, 0x89, 0xcb, 0x97, 0x5c, 0x72, 0xf2, 0x21, 0xd7, 0xfc, 0x86, 0x54, 0x8e, 0x39,
+	0xa5, 0x92, 0x63, 0x2a, 0x97, 0x1c, 0x93, 0xd2, 0x2f, 0x49, 0xcd, 0x03, 0x58, 0x00, 0x8b, 0x5d,
+	0xd2, 0x5a, 0x89, 0x4e, 0x72, 0x21, 0x31, 0x3d, 0x3d, 0xdd, 0x3d, 0x3d, 0xdd, 0x3d, 0xdd, 0x3d,
+	0x0b, 0x97, 0x43, 0x42, 0x83, 0x88, 0x84, 0x07, 0x24, 0x5c, 0x13, 0x9f, 0x0e, 0x0b, 0xc2, 0xc3,
+	0xcc, 0x67, 0x87, 0x86, 0x01, 0x0b, 0x10, 0x0c, 0x20, 0xfa, 0x43, 0xdb, 0x61, 0xfd, 0xb8, 0xdb,
+	0xb1, 0x02, 0x6f, 0x0d, 0x87, 0x76, 0x40, 0xc3, 0xe0, 0x73, 0xf1, 0xf1, 0xae, 0xd5, 0x5b, 0x3b,
+	0xb8, 0xb9, 0x46, 0xf7, 0xed, 0x35, 0x4c, 0x9d, 0x68, 0x0d, 0x53, 0xea, 0x3a, 0x16, 0x66, 0x4e,
+	0xe0, 0xaf, 0x1d, 0x5c, 0xc7, 0x2e, 0xed, 0xe3, 0xeb, 0x6b, 0x36, 0xf1, 0x49, 0x88, 0x19, 0xe9,
+	0x49, 0xca, 0xfa, 0x05, 0x3b, 0x08, 0x6c, 0x97, 0xac, 0x89, 0x51, 0x37, 0xde, 0x5b, 0x23, 0x1e,
+	0x65, 0x8a, 0xad, 0xf1, 0xd5, 0x1c, 0xcc, 0x6f, 0x63, 0xdf, 0xd9, 0x23, 0x11, 0x33, 0xc9, 0xf3,
+	0x98, 0x44, 0x0c, 0x3d, 0x83, 0x3a, 0x17, 0x46, 0xab, 0xac, 0x56, 0xae, 0xcc, 0xdc, 0xd8, 0xea,
+	0x0c, 0xa4, 0xe9, 0x24, 0xd2, 0x88, 0x8f, 0x9f, 0x5b, 0xbd, 0xce, 0xc1, 0xcd, 0x0e, 0xdd, 0xb7,
+	0x3b, 0x5c, 0x9a, 0x4e, 0x46, 0x9a, 0x4e, 0x22, 0x4d, 0xc7, 0x4c, 0xb7, 0x65, 0x0a, 0xaa, 0x48,
+	0x87, 0x56, 0x48, 0x0e, 0x9c, 0xc8, 0x09, 0x7c, 0xad, 0xba, 0x5a, 0xb9, 0xd2, 0x36, 0xd3, 0x31,
+	0xd2, 0x60, 0xda, 0x0f, 0x36, 0xb0, 0xd5, 0x27, 0x5a, 0x6d, 0xb5, 0x72, 0xa5, 0x65, 0x26, 0x43,
+	0xb4, 0x0a, 0x33, 0x98, 0xd2, 0x87, 0xb8, 0x4b, 0xdc, 0x07, 0xe4, 0x50, 0xab, 0x8b, 0x85, 0x59,
+	0x10, 0x5f, 0x8b, 0x29, 0x7d, 0x84, 0x3d, 0xa2, 0x35, 0xc4, 0x6c, 0x32, 0x44, 0xcb, 0xd0, 0xf6,
+	0xb1, 0x47, 0x22, 0x8a, 0x2d, 0xa2, 0xb5, 0xc4, 0xdc, 0x00, 0x80, 0x7e, 0x09, 0x8b, 0x19, 0xc1,
+	0x9f, 0x04, 0x71, 0x68, 0x11, 0x0d, 0xc4, 0xd6, 0x1f, 0x4f, 0xb6, 0xf5, 0xf5, 0x22, 0x59, 0x73,
+	0x98, 0x13, 0xfa, 0x19, 0x34, 0xc4, 0xc9, 0x6b, 0x33, 0xab, 0xb5, 0xd7, 0xaa, 0x6d, 0x49, 0x16,
+	0xf9, 0x30, 0x4d, 0xdd, 0xd8, 0x76, 0xfc, 0x48, 0x9b, 0x15, 0x1c, 0x76, 0x27, 0xe3, 0xb0, 0x11,
+	0xf8, 0x7b, 0x8e, 0xbd, 0x8d, 0x7d, 0x6c, 0x13, 0x8f, 0xf8, 0x6c, 0x47, 0x10, 0x37, 0x13, 0x26,
+	0xe8, 0x05, 0x2c, 0xec, 0xc7, 0x11, 0x0b, 0x3c, 0xe7, 0x05, 0x79, 0x4c, 0xf9, 0xda, 0x48, 0x3b,
+	0x25, 0xb4, 0xf9, 0x68, 0x32, 0xc6, 0x0f, 0x0a, 0x54, 0xcd, 0x21, 0x3e, 0xdc, 0x48, 0xf6, 0xe3,
+	0x2e, 0xf9, 0x8c, 0x84, 0xc2, 0xba, 0xe6, 0xa4, 0x91, 0x64, 0x40, 0xd2, 0x8c, 0x1c, 0x35, 0x8a,
+	0xb4, 0xf9, 0xd5, 0x9a, 0x34, 0xa3, 0x14, 0x84, 0xae, 0xc0, 0xfc, 0x01, 0x09, 0x9d, 0xbd, 0xc3,
+	0x27, 0x8e, 0xed, 0x63, 0x16, 0x87, 0x44, 0x5b, 0x10, 0xa6, 0x58, 0x04, 0x23, 0x0f, 0x4e, 0xf5,
+	0x89, 0xeb, 0x71, 0x95, 0x6f, 0x84, 0xa4, 0x17, 0x69, 0x8b, 0x42, 0xbf, 0x9b, 0x93, 0x9f, 0xa0,
+	0x20, 0x67, 0xe6, 0xa9, 0x73, 0xc1, 0xfc, 0xc0, 0x54, 0x9e, 0x22, 0x7d, 0x04, 0x49, 0xc1, 0x0a,
+	0x60, 0x74, 0x19, 0xe6, 0x58, 0x88, 0xad, 0x7d, 0xc7, 0xb7, 0xb7, 0x09, 0xeb, 0x07, 0x3d, 0xed,
+	0xb4, 0xd0, 0x44, 0x01, 0x8a, 0x2c, 0x40, 0xc4, 0xc7, 0x5d, 0x97, 0xf4, 0xa4, 0x2d, 0xee, 0x1e,
+	0x52, 0x12, 0x69, 0x67, 0xc4, 0x2e, 0x6e, 0x76, 0x32, 0x11, 0xaa, 0x10, 0x20, 0x3a, 0x77, 0x87,
+	0x56, 0xdd, 0xf5, 0x59, 0x78, 0x68, 0x96, 0x90, 0x43, 0xfb, 0x30, 0xc3, 0xf7, 0x91, 0x98, 0xc2,
+	0x59, 0x61, 0x0a, 0xf7, 0x27, 0xd3, 0xd1, 0xd6, 0x80, 0xa0, 0x99, 0xa5, 0x8e, 0x3a, 0x80, 0xfa,
+	0x38, 0xda, 0x8e, 0x5d, 0xe6, 0x50, 0x97, 0x48, 0x31, 0x22, 0x6d, 0x49, 0xa8, 0xa9, 0x64, 0x06,
+	0x3d, 0x00, 0x08, 0xc9, 0x5e, 0x82, 0x77, 0x4e, 0xec, 0xfc, 0xda, 0xb8, 0x9d, 0x9b, 0x29, 0xb6,
+	0xdc, 0x71, 0x66, 0x39, 0x67, 0xce, 0xb7, 0x41, 0x2c, 0x26, 0x21, 0xc2, 0x17, 0x35, 0x4d, 0x98,
+	0x58, 0xc9, 0x0c, 0xb7, 0x45, 0x05, 0x15, 0x41, 0xeb, 0xbc, 0xb4, 0xd6, 0x0c, 0x08, 0x6d, 0xc1,
+	0xff, 0x61, 0xdf, 0x0f, 0x98, 0xd8, 0x7e, 0x22, 0xca, 0xa6, 0x0a, 0xef, 0x3b, 0x98, 0xf5, 0x23,
+	0x4d, 0x17, 0xab, 0x8e, 0x42, 0xe3, 0x26, 0xe1, 0xf8, 0x11, 0xc3, 0xae, 0x2b, 0x90, 0xee, 0xdf,
+	0xd1, 0x2e, 0x48, 0x93, 0xc8, 0x43, 0xb9, 0x91, 0x45, 0x84, 0x31, 0xc7, 0xb7, 0xa3, 0xc4, 0x8b,
+	0x96, 0x05, 0x62, 0x11, 0xac, 0xdf, 0x85, 0x73, 0x23, 0xcc, 0x00, 0x2d, 0x40, 0x6d, 0x9f, 0x1c,
+	0x8a, 0xeb, 0xa3, 0x6d, 0xf2, 0x4f, 0x74, 0x06, 0x1a, 0x07, 0xd8, 0x8d, 0x89, 0x08, 0xf8, 0x2d,
+	0x53, 0x0e, 0x6e, 0x57, 0xbf, 0x5b, 0xd1, 0xbf, 0xaa, 0xc0, 0x7c, 0x41, 0xa9, 0x25, 0xeb, 0x7f,
+	0x9a, 0x5d, 0xff, 0x1a, 0x5c, 0x6c, 0x6f, 0x17, 0x87, 0x36, 0x61, 0x19, 0x41, 0x8c, 0xbf, 0x57,
+	0x40, 0x2b, 0x9c, 0xf6, 0x8f, 0x1c, 0xd6, 0xbf, 0xe7, 0xb8, 0x24, 0x42, 0xb7, 0x60, 0x3a, 0x94,
+	0x30, 0x75, 0x29, 0x5e, 0x18, 0x63, 0x24, 0x5b, 0x53, 0x66, 0x82, 0x8d, 0x3e, 0x82, 0x96, 0x47,
+	0x18, 0xee, 0x61, 0x86, 0x95, 0xec, 0xab, 0x65, 0x2b, 0x39, 0x97, 0x6d, 0x85, 0xb7, 0x35, 0x65,
+	0xa6, 0x6b, 0xd0, 0x7b, 0xd0, 0xb0, 0xfa, 0xb1, 0xbf, 0x2f, 0xae, 0xc3, 0x99, 0x1b, 0x17, 0x47,
+	0x2d, 0xde, 0xe0, 0x48, 0x5b, 0x53, 0xa6, 0xc4, 0xfe, 0xb8, 0x09, 0x75, 0x8a, 0x43, 0x66, 0xdc,
+	0x83, 0x33, 0x65, 0x2c, 0xf8, 0x1d, 0x6c, 0xf5, 0x89, 0xb5, 0x1f, 0xc5, 0x9e, 0x52, 0x73, 0x3a,
+	0x46, 0x08, 0xea, 0x91, 0xf3, 0x42, 0xaa, 0xba, 0x66, 0x8a, 0x6f, 0xe3, 0x6d, 0x58, 0x1c, 0xe2,
+	0xc6, 0x0f, 0x55, 0xca, 0xc6, 0x29, 0xcc, 0x2a, 0xd6, 0x46, 0x0c, 0x67, 0x77, 0x85, 0x2e, 0xd2,
+	0x8b, 0xe8, 0x24, 0xb2, 0x0a, 0x63, 0x0b, 0x96, 0x8a, 0x6c, 0x23, 0x1a, 0xf8, 0x11, 0xe1, 0x6e,
+	0x29, 0x22, 0xb7, 0x43, 0x7a, 0x83, 0x59, 0x21, 0x45, 0xcb, 0x2c, 0x99, 0x31, 0x7e, 0x5f, 0x85,
+	0x25, 0x93, 0x44, 0x81, 0x7b, 0x40, 0x92, 0xb0, 0x7a, 0x32, 0x89, 0xd1, 0x4f, 0xa0, 0x86, 0x29,
+	0xd5, 0xaa, 0xaf, 0x23, 0x42, 0x66, 0x52, 0x0f, 0x93, 0x53, 0x45, 0xef, 0xc0, 0x22, 0xf6, 0xba,
+	0x8e, 0x1d, 0x07, 0x71, 0x94, 0x6c, 0x4b, 0x18, 0x55, 0xdb, 0x1c, 0x9e, 0xe0, 0xa1, 0x29, 0x12,
+	0x1e, 0x79, 0xdf, 0xef, 0x91, 0x5f, 0x88, 0x6c, 0xab, 0x66, 0x66, 0x41, 0x86, 0x05, 0xe7, 0x86,
+	0x94, 0xa4, 0x14, 0x9e, 0x4d, 0xf0, 0x2a, 0x85, 0x04, 0xaf, 0x54, 0x8c, 0xea, 0x08, 0x31, 0x8c,
+	0x2f, 0xab, 0xb0, 0x30, 0x70, 0x2e, 0x45, 0x7e, 0x19, 0xda, 0x9e, 0x82, 0x45, 0x5a, 0x45, 0x44,
+	0xd7, 0x01, 0x20, 0x9f, 0xeb, 0x55, 0x8b, 0xb9, 0xde, 0x12, 0x34, 0x65, 0x2a, 0xae, 0xb6, 0xae,
+	0x46, 0x39, 0x91, 0xeb, 0x05, 0x91, 0x57, 0x00, 0xa2, 0x34, 0xc2, 0x69, 0x4d, 0x31, 0x9b, 0x81,
+	0x20, 0x03, 0x66, 0x65, 0x66, 0x60, 0x92, 0x28, 0x76, 0x99, 0x36, 0x2d, 0x30, 0x72, 0x30, 0xe1,
+	0x6f, 0x81, 0xe7, 0x61, 0xbf, 0x17, 0x69, 0x2d, 0x21, 0x72, 0x3a, 0xe6, 0xba, 0x66, 0x41, 0xe0,
+	0x26, 0xe1, 0xb6, 0x2d, 0xaf, 0x81, 0x0c, 0xc8, 0x08, 0x60, 0xfe, 0xa1, 0xc3, 0x35, 0xb0, 0x17,
+	0x9d, 0x8c, 0x33, 0xbd, 0x0f, 0x75, 0xce, 0x8c, 0x8b, 0xdd, 0x0d, 0xb1, 0x6f, 0xf5, 0x49, 0xa2,
+	0xe9, 0x74, 0xcc, 0xc3, 0x04, 0xc3, 0x76, 0xa4, 0x55, 0x05, 0x5c, 0x7c, 0x1b, 0x7f, 0xac, 0x4a,
+	0x49, 0xd7, 0x29, 0x8d, 0xbe, 0xfd, 0x62, 0xa2, 0x3c, 0xbd, 0xa9, 0x0d, 0xa7, 0x37, 0x05, 0x91,
+	0xbf, 0x49, 0x7a, 0xf3, 0x9a, 0xae, 0x41, 0x23, 0x86, 0xe9, 0x75, 0x4a, 0xb9, 0x20, 0xe8, 0x3a,
+	0xd4, 0x31, 0xa5, 0x52, 0xe1, 0x85, 0x88, 0xaf, 0x50, 0xf8, 0x7f, 0x25, 0x92, 0x40, 0xd5, 0x6f,
+	0x41, 0x3b, 0x05, 0x1d, 0xc5, 0xb6, 0x9d, 0x65, 0xbb, 0x0a, 0x20, 0xf3, 0xf7, 0xfb, 0xfe, 0x5e,
+	0xc0, 0x8f, 0x94, 0xbb, 0x8a, 0x5a, 0x2a, 0xbe, 0x8d, 0xdb, 0x09, 0x86, 0x90, 0xed, 0x1d, 0x68,
+	0x38, 0x8c, 0x78, 0x89, 0x70, 0x4b, 0x59, 0xe1, 0x06, 0x84, 0x4c, 0x89, 0x64, 0xfc, 0xa5, 0x05,
+	0xe7, 0xf9, 0x89, 0x3d, 0x11, 0x4e, 0xb6, 0x4e, 0xe9, 0x1d, 0xc2, 0xb0, 0xe3, 0x46, 0x3f, 0x8c,
+	0x49, 0x78, 0xf8, 0x86, 0x0d, 0xc3, 0x86, 0xa6, 0xf4, 0x51, 0xad, 0xfa, 0x66, 0x4a, 0xb9, 0x66,
+	0x54, 0xa8, 0xdf, 0x6a, 0x6f, 0xa6, 0x7e, 0x2b, 0xab, 0xa7, 0xea, 0x27, 0x54, 0x4f, 0x8d, 0x2e,
+	0xa9, 0x33, 0x85, 0x7a, 0x33, 0x5f, 0xa8, 0x97, 0x94, 0x29, 0xd3, 0xc7, 0x2d, 0x53, 0x5a, 0xa5,
+	0x65, 0x8a, 0x57, 0xea, 0xc7, 0x6d, 0xa1, 0xee, 0xef, 0x67, 0x2d, 0x70, 0xa4, 0xad, 0x4d, 0x52,
+	0xb0, 0xc0, 0x1b, 0x2d, 0x58, 0x3e, 0xcd, 0x15, 0x20, 0xb2, 0x05, 0xf0, 0xde, 0xf1, 0xf6, 0x34,
+	0xa6, 0x14, 0xf9, 0x9f, 0x4b, 0xce, 0xbf, 0x14, 0x39, 0x19, 0x0d, 0x06, 0x3a, 0x48, 0xd3, 0x01,
+	0x7e, 0x0f, 0xf1, 0x8b, 0x59, 0x05, 0x2d, 0xfe, 0x8d, 0xae, 0x41, 0x9d, 0x2b, 0x59, 0x25, 0xcd,
+	0xe7, 0xb2, 0xfa, 0xe4, 0x27, 0xb1, 0x4e, 0xe9, 0x13, 0x4a, 0x2c, 0x53, 0x20, 0xa1, 0xdb, 0xd0,
+	0x4e, 0x0d, 0x5f, 0x79, 0xd6, 0x72, 0x76, 0x45, 0xea, 0x27, 0xc9, 0xb2, 0x01, 0x3a, 0x5f, 0xdb,
+	0x73, 0x42, 0x62, 0x71, 0x44, 0xad, 0x31, 0xbc, 0xf6, 0x4e, 0x32, 0x99, 0xae, 0x4d, 0xd1, 0xd1,
+	0x75, 0x68, 0xca, 0x9e, 0x89, 0xf0, 0xa0, 0x99, 0x1b, 0xe7, 0x87, 0x83, 0x69, 0xb2, 0x4a, 0x21,
+	0x1a, 0x7f, 0xae, 0xc0, 0x5b, 0x03, 0x83, 0x48, 0xbc, 0x29, 0xc9, 0xea, 0xbf, 0xfd, 0x1b, 0xf7,
+	0x32, 0xcc, 0x89, 0x32, 0x62, 0xd0, 0x3a, 0x91, 0x5d, 0xbc, 0x02, 0xd4, 0xf8, 0x43, 0x05, 0x2e,
+	0x0d, 0xef, 0x63, 0xa3, 0x8f, 0x43, 0x96, 0x1e, 0xef, 0x49, 0xec, 0x25, 0xb9, 0xf0, 0xaa, 0x83,
+	0x0b, 0x2f, 0xb7, 0xbf, 0x5a, 0x7e, 0x7f, 0xc6, 0x9f, 0xaa, 0x30, 0x93, 0x31, 0xa0, 0xb2, 0x0b,
+	0x93, 0xa7, 0x8b, 0xc2, 0x6e, 0x45, 0xe1, 0x28, 0x2e, 0x85, 0xb6, 0x99, 0x81, 0xa0, 0x7d, 0x00,
+	0x8a, 0x43, 0xec, 0x11, 0x46, 0x42, 0x1e, 0xc9, 0xb9, 0xc7, 0x3f, 0x98, 0x3c, 0xba, 0xec, 0x24,
+	0x34, 0xcd, 0x0c, 0x79, 0x9e, 0xef, 0x0a, 0xd6, 0x91, 0x8a, 0xdf, 0x6a, 0x84, 0xbe, 0x80, 0xb9,
+	0x3d, 0xc7, 0x25, 0x3b, 0x03, 0x41, 0x9a, 0xab, 0xb5, 0xc9, 0x6f, 0x49, 0x2e, 0xc8, 0xbd, 0x2c,
+	0x5d, 0xb3, 0xc0, 0xc6, 0xb8, 0x0a, 0x0b, 0x45, 0x7f, 0xe2, 0x42, 0x3a, 0x1e, 0xb6, 0x53, 0x6d,
+	0xa9, 0x91, 0x81, 0x60, 0xa1, 0xe8, 0x3f, 0xc6, 0x3f, 0xab, 0x70, 0x36, 0x25, 0xb7, 0xee, 0xfb,
+	0x41, 0xec, 0x5b, 0xa2, 0x0d, 0x59, 0x7a, 0x16, 0x67, 0xa0, 0xc1, 0x1c, 0xe6, 0xa6, 0x89, 0x8f,
+	0x18, 0xf0, 0xbb, 0x8b, 0x67, 0xd7, 0xcc, 0xa1, 0xea, 0x80, 0x93, 0xa1, 0x3c, 0xfb, 0xe7, 0xb1,
+	0x13, 0x92, 0x9e, 0x88, 0x04, 0x2d, 0x33, 0x1d, 0xf3, 0x39, 0x9e, 0xd5, 0x88, 0x22, 0x40, 0x2a,
+	0x33, 0x1d, 0x0b, 0xbb, 0x0f, 0x5c, 0x97, 0x58, 0x5c, 0x1d, 0x99, 0x32, 0xa1, 0x00, 0xe5, 0x3b,
+	0x8d, 0x58, 0xe8, 0xf8, 0xb6, 0x2a, 0x12, 0xd4, 0x88, 0xcb, 0x89, 0xc3, 0x10, 0x1f, 0xaa, 0xda,
+	0x40, 0x0e, 0xd0, 0x87, 0x50, 0xf3, 0x30, 0x55, 0x17, 0xdd, 0xd5, 0x5c, 0x74, 0x28, 0xd3, 0x40,
+	0x67, 0x1b, 0x53, 0x79, 0x13, 0xf0, 0x65, 0xfa, 0xfb, 0xd0, 0x4a, 0x00, 0xdf, 0x28, 0x25, 0xfc,
+	0x1c, 0x4e, 0xe5, 0x82, 0x0f, 0x7a, 0x0a, 0x4b, 0x03, 0x8b, 0xca, 0x32, 0x54, 0x49, 0xe0, 0x5b,
+	0x47, 0x4a, 0x66, 0x8e, 0x20, 0x60, 0x3c, 0x87, 0x45, 0x6e, 0x32, 0xc2, 0xf1, 0x4f, 0xa8, 0xb4,
+	0xf9, 0x00, 0xda, 0x29, 0xcb, 0x52, 0x9b, 0xd1, 0xa1, 0x75, 0x90, 0xb4, 0x87, 0x65, 0x6d, 0x93,
+	0x8e, 0x8d, 0x75, 0x40, 0x59, 0x79, 0xd5, 0x0d, 0x74, 0x2d, 0x9f, 0x14, 0x9f, 0x2d, 0x5e, 0x37,
+	0x02, 0x3d, 0xc9, 0x89, 0xff, 0x51, 0x85, 0xf9, 0x4d, 0x47, 0x74, 0x51, 0x4e, 0x28, 0xc8, 0x5d,
+	0x85, 0x85, 0x28, 0xee, 0x7a, 0x41, 0x2f, 0x76, 0x89, 0x4a, 0x0a, 0xd4, 0x4d, 0x3f, 0x04, 0x1f,
+	0x17, 0xfc, 0xb8, 0xb2, 0x28, 0x66, 0x7d, 0x55, 0x1f, 0x8b, 0x6f, 0xf4, 0x21, 0x9c, 0x7f, 0x44,
+	0xbe, 0x50, 0xfb, 0xd9, 0x74, 0x83, 0x6e, 0xd7, 0xf1, 0xed, 0x84, 0x49, 0x43, 0x30, 0x19, 0x8d,
+	0x50, 0x96, 0x2a, 0x36, 0xcb, 0x53, 0xc5, 0xb4, 0xc6, 0xde, 0x08, 0x3c, 0xcf, 0x61, 0x2a, 0xa3,
+	0xcc, 0xc1, 0x8c, 0x5f, 0x57, 0x60, 0x61, 0xa0, 0x59, 0x75, 0x36, 0xb7, 0xa4, 0x0f, 0xc9, 0x93,
+	0xb9, 0x94, 0x3d, 0x99, 0x22, 0xea, 0xab, 0xbb, 0xcf, 0x6c, 0xd6, 0x7d, 0x7e, 0x53, 0x85, 0xb3,
+	0x9b, 0x0e, 0x4b, 0x02, 0x97, 0xf3, 0xdf, 0x76, 0xca, 0x25, 0x67, 0x52, 0x3f, 0xde, 0x99, 0x34,
+	0x4a, 0xce, 0xa4, 0x03, 0x4b, 0x45, 0x65, 0xa8, 0x83, 0x39, 0x03, 0x0d, 0x2a, 0x1a, 0xd8, 0xb2,
+	0xaf, 0x20, 0x07, 0xc6, 0xaf, 0xa6, 0xe1, 0xe2, 0xa7, 0xb4, 0x87, 0x59, 0xda, 0x55, 0xba, 0x17,
+	0x84, 0xa2, 0x83, 0x7d, 0x32, 0x5a, 0x2c, 0xbc, 0x32, 0x56, 0xc7, 0xbe, 0x32, 0xd6, 0xc6, 0xbc,
+	0x32, 0xd6, 0x8f, 0xf5, 0xca, 0xd8, 0x38, 0xb1, 0x57, 0xc6, 0xe1, 0x5a, 0xab, 0x59, 0x5a, 0x6b,
+	0x3d, 0xcd, 0xd5, 0x23, 0xd3, 0xc2, 0x6d, 0xbe, 0x97, 0x75, 0x9b, 0xb1, 0xa7, 0x33, 0xf6, 0x79,
+	0xa4, 0xf0, 0x38, 0xd7, 0x3a, 0xf2, 0x71, 0xae, 0x3d, 0xfc, 0x38, 0x57, 0xfe, 0xbe, 0x03, 0x23,
+	0xdf, 0x77, 0x2e, 0xc3, 0x5c, 0x74, 0xe8, 0x5b, 0xa4, 0x97, 0x08, 0xac, 0xcd, 0xc8, 0x6d, 0xe7,
+	0xa1, 0x39, 0x8f, 0x98, 0x2d, 0x78, 0x44, 0x6a, 0xa9, 0xa7, 0x32, 0x96, 0x5a, 0xe6, 0x27, 0x73,
+	0x23, 0xcb, 0xdc, 0xc2, 0xd3, 0xcb, 0x7c, 0xd9, 0xd3, 0xcb, 0x7f, 0x4e, 0xb1, 0xf5, 0x19, 0xac,
+	0x8c, 0x3a, 0x65, 0xe5, 0xbc, 0x1a, 0x4c, 0x5b, 0x7d, 0xec, 0xdb, 0xa2, 0x2d, 0x28, 0xaa, 0x7f,
+	0x35, 0x1c, 0x57, 0x1d, 0xdc, 0xf8, 0x7a, 0x16, 0x16, 0x07, 0x59, 0x3f, 0xff, 0xeb, 0x58, 0x04,
+	0x3d, 0x86, 0x85, 0xe4, 0xa9, 0x2a, 0x69, 0xf5, 0xa2, 0x71, 0xaf, 0x2b, 0xfa, 0x72, 0xf9, 0xa4,
+	0x14, 0xcd, 0x98, 0x42, 0x16, 0x9c, 0x2f, 0x12, 0x1c, 0x3c, 0xe4, 0x7c, 0x67, 0x0c, 0xe5, 0x14,
+	0xeb, 0x28, 0x16, 0x57, 0x2a, 0xe8, 0x29, 0xcc, 0xe5, 0x9f, 0x1b, 0x50, 0x2e, 0x0d, 0x2a, 0x7d,
+	0x01, 0xd1, 0x8d, 0x71, 0x28, 0xa9, 0xfc, 0xcf, 0x60, 0xbe, 0xd0, 0x59, 0x47, 0x46, 0xbe, 0x23,
+	0x50, 0xf6, 0x36, 0xa1, 0xff, 0xff, 0x58, 0x9c, 0x94, 0xfa, 0x07, 0xd0, 0x4a, 0x7a, 0xc9, 0x79,
+	0x35, 0x17, 0x3a, 0xcc, 0xfa, 0x42, 0x9e, 0xde, 0x5e, 0x64, 0x4c, 0xa1, 0x8f, 0x60, 0x86, 0xa3,
+	0x3d, 0xde, 0xb8, 0xbf, 0x8b, 0xed, 0x57, 0x5a, 0xdf, 0x4a, 0x7a, 0xad, 0xc3, 0x8b, 0x33, 0x1d,
+	0x58, 0xfd, 0x74, 0x49, 0xd7, 0xd3, 0x98, 0x42, 0x3f, 0x90, 0xfc, 0x77, 0xd4, 0x4f, 0x0d, 0x96,
+	0x3a, 0xf2, 0x97, 0x2d, 0x9d, 0xe4, 0x97, 0x2d, 0x9d, 0xbb, 0xfc, 0x97, 0x2d, 0x7a, 0x49, 0x5b,
+	0x52, 0x11, 0x78, 0x06, 0xa7, 0x36, 0x09, 0x1b, 0x74, 0x11, 0xd0, 0xa5, 0x63, 0xf5, 0x5a, 0x74,
+	0xa3, 0x88, 0x36, 0xdc, 0x88, 0x30, 0xa6, 0xd0, 0xd7, 0x15, 0x38, 0xbd, 0x49, 0x58, 0xb1, 0x2e,
+	0x47, 0xef, 0x96, 0x33, 0x19, 0x51, 0xbf, 0xeb, 0x8f, 0x26, 0xf5, 0xe9, 0x3c, 0x59, 0x63, 0x0a,
+	0xfd, 0xb6, 0x02, 0x73, 0x9b, 0x84, 0x9f, 0x5b, 0x2a, 0xd3, 0xf5, 0xf1, 0x32, 0x95, 0xd4, 0xe2,
+	0xfa, 0x84, 0x3d, 0xb0, 0x0c, 0x77, 0x63, 0x0a, 0xfd, 0xae, 0x02, 0xe7, 0x32, 0xba, 0xca, 0xf2,
+	0x7b, 0x15, 0xd9, 0x3e, 0x99, 0xf0, 0x47, 0x2d, 0x19, 0x92, 0xc6, 0x14, 0xda, 0x11, 0x66, 0x32,
+	0x48, 0xf5, 0xd1, 0xc5, 0xd2, 0x9c, 0x3e, 0xe5, 0xbe, 0x32, 0x6a, 0x3a, 0x35, 0x8d, 0x4f, 0x60,
+	0x66, 0x93, 0xb0, 0x24, 0xe7, 0xcc, 0x1b, 0x7f, 0xa1, 0x1c, 0xd0, 0x97, 0xcb, 0x27, 0x33, 0x01,
+	0x62, 0x51, 0xd2, 0xca, 0xe4, 0x55, 0xf9, 0xf0, 0x53, 0x9a, 0x80, 0xea, 0xc6, 0x38, 0x94, 0x94,
+	0xfa, 0x73, 0x58, 0x2a, 0x8f, 0xfe, 0xe8, 0xed, 0x63, 0xe7, 0x01, 0xfa, 0xd5, 0xe3, 0xa0, 0x26,
+	0x2c, 0x3f, 0x5e, 0xff, 0xeb, 0xcb, 0x95, 0xca, 0xdf, 0x5e, 0xae, 0x54, 0xfe, 0xf5, 0x72, 0xa5,
+	0xf2, 0xe3, 0x9b, 0x47, 0xfc, 0xf8, 0x2d, 0xf3, 0x7b, 0x3a, 0x4c, 0x1d, 0xcb, 0x75, 0x88, 0xcf,
+	0xba, 0x4d, 0x11, 0x02, 0x6e, 0xfe, 0x7b, 0x00, 0x5f, 0xb0, 0x0c, 0x52, 0x6e, 0x27, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -3343,6 +3362,15 @@ func (m *ManifestRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if len(m.SettingsVersion) > 0 {
+		i -= len(m.SettingsVersion)
+		copy(dAtA[i:], m.SettingsVersion)
+		i = encodeVarintRepository(dAtA, i, uint64(len(m.SettingsVersion)))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xe2
+	}
 	if len(m.InstallationID) > 0 {
 		i -= len(m.InstallationID)
 		copy(dAtA[i:], m.InstallationID)
@@ -4005,6 +4033,13 @@ func (m *ManifestResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if len(m.ToolVersion) > 0 {
+		i -= len(m.ToolVersion)
+		copy(dAtA[i:], m.ToolVersion)
+		i = encodeVarintRepository(dAtA, i, uint64(len(m.ToolVersion)))
+		i--
+		dAtA[i] = 0x4a
+	}
 	if len(m.Commands) > 0 {
 		for iNdEx := len(m.Commands) - 1; iNdEx >= 0; iNdEx-- {
 			i -= len(m.Commands[iNdEx])
@@ -5708,6 +5743,10 @@ func (m *ManifestRequest) Size() (n int) {
 	if l > 0 {
 		n += 2 + l + sovRepository(uint64(l))
 	}
+	l = len(m.SettingsVersion)
+	if l > 0 {
+		n += 2 + l + sovRepository(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -5916,6 +5955,10 @@ func (m *ManifestResponse) Size() (n int) {
 			n += 1 + l + sovRepository(uint64(l))
 		}
 	}
+	l = len(m.ToolVersion)
+	if l > 0 {
+		n += 1 + l + sovRepository(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -7553,6 +7596,38 @@ func (m *ManifestRequest) Unmarshal(dAtA []byte) error {
 			}
 			m.InstallationID = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 28:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SettingsVersion", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRepository
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRepository
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRepository
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SettingsVersion = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRepository(dAtA[iNdEx:])
@@ -8618,6 +8693,38 @@ func (m *ManifestResponse) Unmarshal(dAtA []byte) error {
 			}
 			m.Commands = append(m.Commands, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ToolVersion", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRepository
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRepository
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRepository
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ToolVersion = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRepository(dAtA[iNdEx:])
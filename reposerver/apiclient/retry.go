@@ -0,0 +1,309 @@
+package apiclient
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	grpc_retry "github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/retry"
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/argoproj/argo-cd/v3/util/env"
+	grpc_util "github.com/argoproj/argo-cd/v3/util/grpc"
+)
+
+const (
+	// EnvRetryMaxAttempts is the maximum number of attempts of the calls to the repo server, 1 disables the retries
+	EnvRetryMaxAttempts = "ARGOCD_REPO_SERVER_CLIENT_RETRY_MAX_ATTEMPTS"
+	// EnvRetryBackoff is the linear backoff between the retries of the calls to the repo server
+	EnvRetryBackoff = "ARGOCD_REPO_SERVER_CLIENT_RETRY_BACKOFF"
+	// EnvRetryCodes is the comma separated list of the gRPC codes of the calls to the repo server which are retried
+	EnvRetryCodes = "ARGOCD_REPO_SERVER_CLIENT_RETRY_CODES"
+	// EnvMethodRetryCodes overrides the retried gRPC codes of some methods, e.g. "GenerateManifest=Unavailable;ResolveRevision=Unavailable,DeadlineExceeded"
+	EnvMethodRetryCodes = "ARGOCD_REPO_SERVER_CLIENT_METHOD_RETRY_CODES"
+	// EnvCircuitBreakerFailures is the number of consecutive failed calls to the repo server tripping the circuit breaker, 0 disables it
+	EnvCircuitBreakerFailures = "ARGOCD_REPO_SERVER_CLIENT_CIRCUIT_BREAKER_FAILURES"
+	// EnvCircuitBreakerOpenDuration is the duration during which the calls are rejected once the circuit breaker is tripped
+	EnvCircuitBreakerOpenDuration = "ARGOCD_REPO_SERVER_CLIENT_CIRCUIT_BREAKER_OPEN_DURATION"
+)
+
+// RetryConfiguration describes the retry policy and the circuit breaker of the calls of a repo server API client
+type RetryConfiguration struct {
+	// MaxAttempts is the maximum number of attempts of a call, including the first one, 0 or 1 disables the retries
+	MaxAttempts uint
+	// Backoff is the linear backoff between the retries
+	Backoff time.Duration
+	// RetryableCodes are the gRPC codes of the calls which are retried
+	RetryableCodes []codes.Code
+	// MethodRetryableCodes override the retryable codes of the methods, by method name, e.g. GenerateManifest
+	MethodRetryableCodes map[string][]codes.Code
+	// CircuitBreakerFailures is the number of consecutive failed calls tripping the circuit breaker, 0 disables it
+	CircuitBreakerFailures int
+	// CircuitBreakerOpenDuration is the duration during which the calls are rejected once the circuit breaker is
+	// tripped, before a single call probes whether the repo server has recovered
+	CircuitBreakerOpenDuration time.Duration
+}
+
+// defaultRetryableCodes are the codes retried by default, which are the default codes of grpc_retry
+var defaultRetryableCodes = []codes.Code{codes.ResourceExhausted, codes.Unavailable}
+
+// circuitBreakerFailureCodes are the codes of the calls counted as failures by the circuit breaker, which indicate that
+// the repo server is unavailable rather than that the request is invalid. The calls exceeding their deadline aren't
+// counted, since the deadline is set by the caller and a slow manifest generation doesn't mean the repo server is down.
+var circuitBreakerFailureCodes = []codes.Code{codes.Unavailable}
+
+// parseCodes parses a comma separated list of gRPC code names, e.g. "Unavailable,DeadlineExceeded"
+func parseCodes(value string) ([]codes.Code, error) {
+	var result []codes.Code
+	for _, name := range strings.Split(value, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		code, ok := codeByName(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown gRPC code '%s'", name)
+		}
+		result = append(result, code)
+	}
+	return result, nil
+}
+
+func codeByName(name string) (codes.Code, bool) {
+	for c := codes.OK; c <= codes.Unauthenticated; c++ {
+		if strings.EqualFold(c.String(), name) {
+			return c, true
+		}
+	}
+	return 0, false
+}
+
+// parseMethodCodes parses the retryable codes of the methods, e.g. "GenerateManifest=Unavailable;ResolveRevision=Unavailable,DeadlineExceeded"
+func parseMethodCodes(value string) (map[string][]codes.Code, error) {
+	result := map[string][]codes.Code{}
+	for _, entry := range strings.Split(value, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		method, value, ok := strings.Cut(entry, "=")
+		if !ok || strings.TrimSpace(method) == "" {
+			return nil, fmt.Errorf("invalid method retry codes '%s', expected method=code[,code]", entry)
+		}
+		methodCodes, err := parseCodes(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid retry codes of method %s: %w", method, err)
+		}
+		result[strings.TrimSpace(method)] = methodCodes
+	}
+	return result, nil
+}
+
+// NewRetryConfigurationFromEnv returns the retry configuration set by the environment variables. The invalid codes are
+// logged and replaced by the default ones.
+func NewRetryConfigurationFromEnv() RetryConfiguration {
+	config := RetryConfiguration{
+		MaxAttempts:                uint(env.ParseNumFromEnv(EnvRetryMaxAttempts, 3, 1, 100)),
+		Backoff:                    env.ParseDurationFromEnv(EnvRetryBackoff, 1*time.Second, 0, 5*time.Minute),
+		RetryableCodes:             defaultRetryableCodes,
+		CircuitBreakerFailures:     env.ParseNumFromEnv(EnvCircuitBreakerFailures, 0, 0, 1000),
+		CircuitBreakerOpenDuration: env.ParseDurationFromEnv(EnvCircuitBreakerOpenDuration, 30*time.Second, time.Second, time.Hour),
+	}
+	if value := env.StringFromEnv(EnvRetryCodes, ""); value != "" {
+		retryableCodes, err := parseCodes(value)
+		if err != nil {
+			log.Warnf("Invalid %s, using the default codes: %v", EnvRetryCodes, err)
+		} else {
+			config.RetryableCodes = retryableCodes
+		}
+	}
+	if value := env.StringFromEnv(EnvMethodRetryCodes, ""); value != "" {
+		methodCodes, err := parseMethodCodes(value)
+		if err != nil {
+			log.Warnf("Invalid %s, using the default codes: %v", EnvMethodRetryCodes, err)
+		} else {
+			config.MethodRetryableCodes = methodCodes
+		}
+	}
+	return config
+}
+
+var (
+	retriesCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "argocd_repo_server_client_retries_total",
+			Help: "Number of retries of the calls to the repo server.",
+		},
+		[]string{"method"},
+	)
+	circuitBreakerRejectedCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "argocd_repo_server_client_circuit_breaker_rejected_total",
+			Help: "Number of calls to the repo server rejected by the open circuit breaker.",
+		},
+		[]string{"method"},
+	)
+	circuitBreakerStateGauge = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "argocd_repo_server_client_circuit_breaker_state",
+			Help: "State of the circuit breaker of the calls to the repo server: 0 closed, 1 half-open, 2 open.",
+		},
+	)
+)
+
+// RegisterMetrics registers the metrics of the retries and circuit breaker of the repo server API clients
+func RegisterMetrics(registerer prometheus.Registerer) {
+	registerer.MustRegister(retriesCounter, circuitBreakerRejectedCounter, circuitBreakerStateGauge)
+}
+
+// retryOptions returns the retry options of the calls of a method
+func (c *RetryConfiguration) retryOptions(fullMethod string) []grpc_retry.CallOption {
+	method := path.Base(fullMethod)
+	retryableCodes := c.RetryableCodes
+	if methodCodes, ok := c.MethodRetryableCodes[method]; ok {
+		retryableCodes = methodCodes
+	}
+	return []grpc_retry.CallOption{
+		grpc_retry.WithMax(c.MaxAttempts),
+		grpc_retry.WithBackoff(grpc_retry.BackoffLinear(c.Backoff)),
+		grpc_retry.WithCodes(retryableCodes...),
+		grpc_retry.WithOnRetryCallback(func(_ context.Context, _ uint, _ error) {
+			retriesCounter.WithLabelValues(method).Inc()
+		}),
+	}
+}
+
+// unaryClientInterceptor retries the unary calls according to the retry policy of their method
+func (c *RetryConfiguration) unaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return grpc_retry.UnaryClientInterceptor(c.retryOptions(method)...)(ctx, method, req, reply, cc, invoker, opts...)
+	}
+}
+
+// streamClientInterceptor retries the server streaming calls according to the retry policy of their method
+func (c *RetryConfiguration) streamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return grpc_util.RetryOnlyForServerStreamInterceptor(c.retryOptions(method)...)(ctx, desc, cc, method, streamer, opts...)
+	}
+}
+
+type circuitBreakerState int
+
+const (
+	circuitBreakerClosed circuitBreakerState = iota
+	circuitBreakerHalfOpen
+	circuitBreakerOpen
+)
+
+func (s circuitBreakerState) String() string {
+	switch s {
+	case circuitBreakerHalfOpen:
+		return "half-open"
+	case circuitBreakerOpen:
+		return "open"
+	}
+	return "closed"
+}
+
+// circuitBreaker rejects the calls to the repo server after a number of consecutive failed calls, until a single call
+// succeeds after the open duration, so that the callers fail fast instead of waiting for the retries and timeouts of
+// each call while the repo server is unavailable
+type circuitBreaker struct {
+	failureThreshold int
+	openDuration     time.Duration
+	now              func() time.Time
+
+	lock     sync.Mutex
+	state    circuitBreakerState
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+func newCircuitBreaker(failureThreshold int, openDuration time.Duration) *circuitBreaker {
+	return &circuitBreaker{failureThreshold: failureThreshold, openDuration: openDuration, now: time.Now}
+}
+
+func (b *circuitBreaker) setState(state circuitBreakerState) {
+	if b.state != state {
+		log.Infof("Repo server client circuit breaker state changed from %s to %s", b.state, state)
+	}
+	b.state = state
+	circuitBreakerStateGauge.Set(float64(state))
+}
+
+// allow returns whether a call is allowed, and whether it probes the recovery of the repo server
+func (b *circuitBreaker) allow() (allowed bool, probe bool) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	switch b.state {
+	case circuitBreakerOpen:
+		if b.now().Sub(b.openedAt) < b.openDuration {
+			return false, false
+		}
+		b.setState(circuitBreakerHalfOpen)
+		b.probing = true
+		return true, true
+	case circuitBreakerHalfOpen:
+		if b.probing {
+			return false, false
+		}
+		b.probing = true
+		return true, true
+	}
+	return true, false
+}
+
+// done records the result of an allowed call. The calls cancelled by the caller or exceeding their deadline are
+// neither failures nor successes, and a cancelled probe lets the next call probe the repo server.
+func (b *circuitBreaker) done(ctx context.Context, err error, probe bool) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if probe {
+		b.probing = false
+	}
+	if code := status.Code(err); ctx.Err() != nil || code == codes.Canceled || code == codes.DeadlineExceeded {
+		return
+	}
+	failed := false
+	if err != nil {
+		code := status.Code(err)
+		for _, c := range circuitBreakerFailureCodes {
+			failed = failed || c == code
+		}
+	}
+	if !failed {
+		b.failures = 0
+		if probe || b.state == circuitBreakerClosed {
+			b.setState(circuitBreakerClosed)
+		}
+		return
+	}
+	b.failures++
+	if probe || b.failures >= b.failureThreshold {
+		b.openedAt = b.now()
+		b.setState(circuitBreakerOpen)
+	}
+}
+
+// unaryClientInterceptor rejects the unary calls while the circuit breaker is open. It must be the first interceptor,
+// so that a call failing after its retries is counted once.
+func (b *circuitBreaker) unaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		allowed, probe := b.allow()
+		if !allowed {
+			circuitBreakerRejectedCounter.WithLabelValues(path.Base(method)).Inc()
+			return status.Errorf(codes.Unavailable, "repo server circuit breaker is open after %d consecutive failures", b.failureThreshold)
+		}
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		b.done(ctx, err, probe)
+		return err
+	}
+}
@@ -0,0 +1,183 @@
+package apiclient
+
+import (
+	"context"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestParseCodes(t *testing.T) {
+	parsed, err := parseCodes("Unavailable, deadlineexceeded,")
+	require.NoError(t, err)
+	assert.Equal(t, []codes.Code{codes.Unavailable, codes.DeadlineExceeded}, parsed)
+
+	_, err = parseCodes("Unavailable,Unknown2")
+	assert.EqualError(t, err, "unknown gRPC code 'Unknown2'")
+}
+
+func TestParseMethodCodes(t *testing.T) {
+	parsed, err := parseMethodCodes("GenerateManifest=Unavailable; ResolveRevision=Unavailable,DeadlineExceeded")
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]codes.Code{
+		"GenerateManifest": {codes.Unavailable},
+		"ResolveRevision":  {codes.Unavailable, codes.DeadlineExceeded},
+	}, parsed)
+
+	_, err = parseMethodCodes("GenerateManifest")
+	assert.ErrorContains(t, err, "expected method=code[,code]")
+}
+
+func TestNewRetryConfigurationFromEnv(t *testing.T) {
+	t.Setenv(EnvRetryMaxAttempts, "5")
+	t.Setenv(EnvRetryBackoff, "2s")
+	t.Setenv(EnvRetryCodes, "Unavailable,Internal")
+	t.Setenv(EnvMethodRetryCodes, "invalid")
+	t.Setenv(EnvCircuitBreakerFailures, "10")
+
+	config := NewRetryConfigurationFromEnv()
+
+	assert.Equal(t, uint(5), config.MaxAttempts)
+	assert.Equal(t, 2*time.Second, config.Backoff)
+	assert.Equal(t, []codes.Code{codes.Unavailable, codes.Internal}, config.RetryableCodes)
+	assert.Nil(t, config.MethodRetryableCodes)
+	assert.Equal(t, 10, config.CircuitBreakerFailures)
+	assert.Equal(t, 30*time.Second, config.CircuitBreakerOpenDuration)
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	now := time.Now()
+	breaker := newCircuitBreaker(2, time.Minute)
+	breaker.now = func() time.Time { return now }
+	unavailable := status.Error(codes.Unavailable, "unavailable")
+
+	allowed, probe := breaker.allow()
+	require.True(t, allowed)
+	breaker.done(t.Context(), unavailable, probe)
+	allowed, probe = breaker.allow()
+	require.True(t, allowed)
+	// errors of invalid requests don't count as failures
+	breaker.done(t.Context(), status.Error(codes.InvalidArgument, "invalid"), probe)
+	assert.Equal(t, circuitBreakerClosed, breaker.state)
+
+	for range 2 {
+		allowed, probe = breaker.allow()
+		require.True(t, allowed)
+		breaker.done(t.Context(), unavailable, probe)
+	}
+	assert.Equal(t, circuitBreakerOpen, breaker.state)
+	allowed, _ = breaker.allow()
+	assert.False(t, allowed)
+
+	// a single call probes the recovery once the open duration elapsed
+	now = now.Add(time.Minute)
+	allowed, probe = breaker.allow()
+	require.True(t, allowed)
+	assert.True(t, probe)
+	assert.Equal(t, circuitBreakerHalfOpen, breaker.state)
+	allowed, _ = breaker.allow()
+	assert.False(t, allowed)
+
+	// the breaker is tripped again if the probe fails
+	breaker.done(t.Context(), unavailable, true)
+	assert.Equal(t, circuitBreakerOpen, breaker.state)
+
+	now = now.Add(time.Minute)
+	allowed, probe = breaker.allow()
+	require.True(t, allowed)
+	breaker.done(t.Context(), nil, probe)
+	assert.Equal(t, circuitBreakerClosed, breaker.state)
+	allowed, _ = breaker.allow()
+	assert.True(t, allowed)
+}
+
+func TestCircuitBreaker_CancelledCalls(t *testing.T) {
+	now := time.Now()
+	breaker := newCircuitBreaker(1, time.Minute)
+	breaker.now = func() time.Time { return now }
+	cancelled, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	// the calls cancelled by the caller or exceeding their deadline don't count as failures
+	breaker.done(cancelled, status.Error(codes.Unavailable, "unavailable"), false)
+	breaker.done(t.Context(), status.Error(codes.Canceled, "canceled"), false)
+	breaker.done(t.Context(), status.Error(codes.DeadlineExceeded, "deadline exceeded"), false)
+	assert.Equal(t, circuitBreakerClosed, breaker.state)
+
+	breaker.done(t.Context(), status.Error(codes.Unavailable, "unavailable"), false)
+	require.Equal(t, circuitBreakerOpen, breaker.state)
+
+	// a cancelled probe lets the next call probe the repo server
+	now = now.Add(time.Minute)
+	allowed, probe := breaker.allow()
+	require.True(t, allowed)
+	breaker.done(cancelled, status.Error(codes.Canceled, "canceled"), probe)
+	assert.Equal(t, circuitBreakerHalfOpen, breaker.state)
+	allowed, probe = breaker.allow()
+	require.True(t, allowed)
+	assert.True(t, probe)
+}
+
+type fakeRepoServer struct {
+	UnimplementedRepoServerServiceServer
+	calls atomic.Int32
+}
+
+func (s *fakeRepoServer) ResolveRevision(context.Context, *ResolveRevisionRequest) (*ResolveRevisionResponse, error) {
+	s.calls.Add(1)
+	return nil, status.Error(codes.Unavailable, "unavailable")
+}
+
+func (s *fakeRepoServer) GetGitFiles(context.Context, *GitFilesRequest) (*GitFilesResponse, error) {
+	s.calls.Add(1)
+	return nil, status.Error(codes.Internal, "internal")
+}
+
+func TestNewRepoServerClientsetWithRetry(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer()
+	fake := &fakeRepoServer{}
+	RegisterRepoServerServiceServer(server, fake)
+	go func() { _ = server.Serve(listener) }()
+	defer server.Stop()
+
+	clientset := NewRepoServerClientsetWithRetry(listener.Addr().String(), 10, TLSConfiguration{DisableTLS: true}, RetryConfiguration{
+		MaxAttempts:                3,
+		Backoff:                    time.Millisecond,
+		RetryableCodes:             []codes.Code{codes.Unavailable},
+		MethodRetryableCodes:       map[string][]codes.Code{"GetGitFiles": {codes.Internal}},
+		CircuitBreakerFailures:     2,
+		CircuitBreakerOpenDuration: time.Minute,
+	})
+	closer, client, err := clientset.NewRepoServerClient()
+	require.NoError(t, err)
+	defer closer.Close()
+
+	_, err = client.GetGitFiles(t.Context(), &GitFilesRequest{})
+	assert.Equal(t, codes.Internal, status.Code(err))
+	assert.Equal(t, int32(3), fake.calls.Load())
+
+	_, err = client.ResolveRevision(t.Context(), &ResolveRevisionRequest{})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, int32(6), fake.calls.Load())
+
+	_, err = client.ResolveRevision(t.Context(), &ResolveRevisionRequest{})
+	require.Error(t, err)
+	assert.Equal(t, int32(9), fake.calls.Load())
+
+	// the circuit breaker is shared by the connections of the clientset
+	closer2, client2, err := clientset.NewRepoServerClient()
+	require.NoError(t, err)
+	defer closer2.Close()
+	_, err = client2.ResolveRevision(t.Context(), &ResolveRevisionRequest{})
+	assert.ErrorContains(t, err, "repo server circuit breaker is open after 2 consecutive failures")
+	assert.Equal(t, int32(9), fake.calls.Load())
+}
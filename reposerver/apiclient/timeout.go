@@ -0,0 +1,76 @@
+package apiclient
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+
+	"github.com/argoproj/argo-cd/v3/util/env"
+)
+
+// EnvMethodTimeouts overrides the timeout of the calls to the repo server of some methods, e.g. "GenerateManifest=5m;ResolveRevision=10s"
+const EnvMethodTimeouts = "ARGOCD_REPO_SERVER_CLIENT_METHOD_TIMEOUTS"
+
+// parseMethodTimeouts parses the timeouts of the methods, e.g. "GenerateManifest=5m;ResolveRevision=10s"
+func parseMethodTimeouts(value string) (map[string]time.Duration, error) {
+	result := map[string]time.Duration{}
+	for _, entry := range strings.Split(value, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		method, value, ok := strings.Cut(entry, "=")
+		if !ok || strings.TrimSpace(method) == "" {
+			return nil, fmt.Errorf("invalid method timeout '%s', expected method=duration", entry)
+		}
+		timeout, err := time.ParseDuration(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("invalid timeout of method %s: %w", method, err)
+		}
+		if timeout < 0 {
+			return nil, fmt.Errorf("invalid timeout of method %s: must not be negative", method)
+		}
+		result[strings.TrimSpace(method)] = timeout
+	}
+	return result, nil
+}
+
+// methodTimeoutsFromEnv returns the timeouts of the methods set by the environment variable. The invalid timeouts are
+// logged and ignored.
+func methodTimeoutsFromEnv() map[string]time.Duration {
+	value := env.StringFromEnv(EnvMethodTimeouts, "")
+	if value == "" {
+		return nil
+	}
+	timeouts, err := parseMethodTimeouts(value)
+	if err != nil {
+		log.Warnf("Invalid %s, using the default timeout: %v", EnvMethodTimeouts, err)
+		return nil
+	}
+	return timeouts
+}
+
+// timeoutUnaryClientInterceptor sets the deadline of the unary calls whose context has no deadline, to the timeout of
+// their method or else to the default timeout, 0 meaning no timeout. The deadline of the context of the caller is
+// always honored, even if it is later than the timeout, so that the callers can give more time to the long calls.
+func timeoutUnaryClientInterceptor(defaultTimeout time.Duration, methodTimeouts map[string]time.Duration) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if _, ok := ctx.Deadline(); !ok {
+			timeout := defaultTimeout
+			if methodTimeout, ok := methodTimeouts[path.Base(method)]; ok {
+				timeout = methodTimeout
+			}
+			if timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, timeout)
+				defer cancel()
+			}
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
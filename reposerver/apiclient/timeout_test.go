@@ -0,0 +1,62 @@
+package apiclient
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+func TestParseMethodTimeouts(t *testing.T) {
+	parsed, err := parseMethodTimeouts("GenerateManifest=5m; ResolveRevision=10s")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]time.Duration{
+		"GenerateManifest": 5 * time.Minute,
+		"ResolveRevision":  10 * time.Second,
+	}, parsed)
+
+	_, err = parseMethodTimeouts("GenerateManifest")
+	require.ErrorContains(t, err, "expected method=duration")
+	_, err = parseMethodTimeouts("GenerateManifest=forever")
+	assert.ErrorContains(t, err, "invalid timeout of method GenerateManifest")
+}
+
+func TestTimeoutUnaryClientInterceptor(t *testing.T) {
+	interceptor := timeoutUnaryClientInterceptor(time.Minute, map[string]time.Duration{
+		"GenerateManifest": time.Hour,
+		"ListRefs":         0,
+	})
+	deadlineOf := func(ctx context.Context, method string) (time.Duration, bool) {
+		var remaining time.Duration
+		var ok bool
+		err := interceptor(ctx, method, nil, nil, nil, func(ctx context.Context, _ string, _, _ any, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+			var deadline time.Time
+			deadline, ok = ctx.Deadline()
+			remaining = time.Until(deadline)
+			return nil
+		})
+		require.NoError(t, err)
+		return remaining, ok
+	}
+
+	remaining, ok := deadlineOf(t.Context(), "/repository.RepoServerService/ResolveRevision")
+	require.True(t, ok)
+	assert.InDelta(t, time.Minute, remaining, float64(time.Second))
+
+	remaining, ok = deadlineOf(t.Context(), "/repository.RepoServerService/GenerateManifest")
+	require.True(t, ok)
+	assert.InDelta(t, time.Hour, remaining, float64(time.Second))
+
+	_, ok = deadlineOf(t.Context(), "/repository.RepoServerService/ListRefs")
+	assert.False(t, ok)
+
+	// the deadline of the caller is honored, even if it is later than the timeout
+	ctx, cancel := context.WithTimeout(t.Context(), 2*time.Hour)
+	defer cancel()
+	remaining, ok = deadlineOf(ctx, "/repository.RepoServerService/ResolveRevision")
+	require.True(t, ok)
+	assert.InDelta(t, 2*time.Hour, remaining, float64(time.Second))
+}
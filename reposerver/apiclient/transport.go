@@ -0,0 +1,98 @@
+package apiclient
+
+import (
+	"context"
+	"net"
+	"strings"
+	"sync"
+)
+
+// unixSocketScheme is the scheme of the addresses of the repo servers listening on a Unix domain socket, e.g.
+// unix:///var/run/argocd/reposerver.sock
+const unixSocketScheme = "unix:"
+
+// inProcessAddress is the address dialed by the clients of a repo server running in the same process
+const inProcessAddress = "passthrough:///in-process"
+
+// IsUnixSocketAddress returns whether the address of a repo server is a Unix domain socket. The connections to a Unix
+// domain socket don't use TLS, the socket being only reachable from the same pod.
+func IsUnixSocketAddress(address string) bool {
+	return strings.HasPrefix(address, unixSocketScheme)
+}
+
+// InProcessTransport connects the repo server API clients to a repo server running in the same process, without TLS
+// nor TCP, e.g. when a single binary runs both the application controller and the repo server
+type InProcessTransport struct {
+	listener *inProcessListener
+}
+
+// NewInProcessTransport returns a new in-process transport. The repo server serves its gRPC server on the listener of
+// the transport, see reposerver.ArgoCDRepoServer.CreateLocalGRPC.
+func NewInProcessTransport() *InProcessTransport {
+	return &InProcessTransport{listener: &inProcessListener{conns: make(chan net.Conn), done: make(chan struct{})}}
+}
+
+// Listener returns the listener on which the repo server accepts the in-process connections
+func (t *InProcessTransport) Listener() net.Listener {
+	return t.listener
+}
+
+func (t *InProcessTransport) dial(ctx context.Context, _ string) (net.Conn, error) {
+	return t.listener.dial(ctx)
+}
+
+// inProcessListener accepts the in-process connections, which are the server ends of in-memory pipes
+type inProcessListener struct {
+	conns     chan net.Conn
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// inProcessAddr is the address of the in-process listener and connections
+type inProcessAddr struct{}
+
+func (inProcessAddr) Network() string { return "in-process" }
+func (inProcessAddr) String() string  { return "in-process" }
+
+func (l *inProcessListener) Accept() (net.Conn, error) {
+	select {
+	case conn := <-l.conns:
+		return conn, nil
+	case <-l.done:
+		return nil, net.ErrClosed
+	}
+}
+
+func (l *inProcessListener) Close() error {
+	l.closeOnce.Do(func() { close(l.done) })
+	return nil
+}
+
+func (l *inProcessListener) Addr() net.Addr {
+	return inProcessAddr{}
+}
+
+// dial returns the client end of a pipe once the server end is accepted by the listener
+func (l *inProcessListener) dial(ctx context.Context) (net.Conn, error) {
+	client, server := net.Pipe()
+	var err error
+	select {
+	case l.conns <- server:
+		return client, nil
+	case <-l.done:
+		err = net.ErrClosed
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+	_ = client.Close()
+	_ = server.Close()
+	return nil, err
+}
+
+// NewInProcessClientset creates new instance of repo server Clientset connected to the repo server running in the same
+// process with the transport
+func NewInProcessClientset(transport *InProcessTransport, timeoutSeconds int) Clientset {
+	c := NewRepoServerClientset(inProcessAddress, timeoutSeconds, TLSConfiguration{DisableTLS: true}).(*clientSet)
+	c.dialer = transport.dial
+	return c
+}
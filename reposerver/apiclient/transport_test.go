@@ -0,0 +1,71 @@
+package apiclient
+
+import (
+	"context"
+	"net"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+type verifiedRepoServer struct {
+	UnimplementedRepoServerServiceServer
+}
+
+func (s *verifiedRepoServer) TestRepository(context.Context, *TestRepositoryRequest) (*TestRepositoryResponse, error) {
+	return &TestRepositoryResponse{VerifiedRepository: true}, nil
+}
+
+func serveVerifiedRepoServer(t *testing.T, listener net.Listener) {
+	t.Helper()
+	server := grpc.NewServer()
+	RegisterRepoServerServiceServer(server, &verifiedRepoServer{})
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+}
+
+func testRepository(t *testing.T, clientset Clientset) {
+	t.Helper()
+	closer, client, err := clientset.NewRepoServerClient()
+	require.NoError(t, err)
+	defer closer.Close()
+	res, err := client.TestRepository(t.Context(), &TestRepositoryRequest{})
+	require.NoError(t, err)
+	assert.True(t, res.VerifiedRepository)
+}
+
+func TestIsUnixSocketAddress(t *testing.T) {
+	assert.True(t, IsUnixSocketAddress("unix:///var/run/argocd/reposerver.sock"))
+	assert.True(t, IsUnixSocketAddress("unix:reposerver.sock"))
+	assert.False(t, IsUnixSocketAddress("argocd-repo-server:8081"))
+}
+
+func TestNewInProcessClientset(t *testing.T) {
+	transport := NewInProcessTransport()
+	serveVerifiedRepoServer(t, transport.Listener())
+
+	testRepository(t, NewInProcessClientset(transport, 10))
+}
+
+func TestNewRepoServerClientset_UnixSocket(t *testing.T) {
+	socketPath := filepath.Join(t.TempDir(), "reposerver.sock")
+	listener, err := net.Listen("unix", socketPath)
+	require.NoError(t, err)
+	serveVerifiedRepoServer(t, listener)
+
+	// TLS isn't used over the Unix domain socket, even if it isn't disabled
+	testRepository(t, NewRepoServerClientset("unix://"+socketPath, 10, TLSConfiguration{DisableTLS: false}))
+}
+
+func TestInProcessTransport_Close(t *testing.T) {
+	transport := NewInProcessTransport()
+	require.NoError(t, transport.Listener().Close())
+
+	_, err := transport.Listener().Accept()
+	require.ErrorIs(t, err, net.ErrClosed)
+	_, err = transport.dial(t.Context(), "")
+	require.ErrorIs(t, err, net.ErrClosed)
+}
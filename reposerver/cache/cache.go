@@ -1,6 +1,7 @@
 package cache
 
 import (
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"errors"
@@ -80,6 +81,11 @@ func AddCacheFlagsToCmd(cmd *cobra.Command, opts ...cacheutil.Options) func() (*
 	}
 }
 
+// SetMetricsRegistry sets the registry recording the requests of the cache
+func (c *Cache) SetMetricsRegistry(registry cacheutil.CacheMetricsRegistry) {
+	c.cache.SetMetricsRegistry(registry)
+}
+
 type refTargetForCacheKey struct {
 	RepoURL        string `json:"repoURL"`
 	Project        string `json:"project"`
@@ -430,6 +436,12 @@ func (c *Cache) DeleteManifests(revision string, appSrc *appv1.ApplicationSource
 		&cacheutil.CacheActionOpts{Delete: true})
 }
 
+// CoalesceManifestGeneration coalesces the manifest generations which follow concurrent cache misses of the same
+// manifests, see cacheutil.Cache.CoalesceRegeneration
+func (c *Cache) CoalesceManifestGeneration(ctx context.Context, revision string, appSrc *appv1.ApplicationSource, srcRefs appv1.RefTargetRevisionMapping, clusterInfo ClusterRuntimeInfo, namespace string, trackingMethod string, appLabelKey string, appName string, refSourceCommitSHAs ResolvedRevisions, installationID string) (func(), error) {
+	return c.cache.CoalesceRegeneration(ctx, manifestCacheKey(revision, appSrc, srcRefs, namespace, trackingMethod, appLabelKey, appName, clusterInfo, refSourceCommitSHAs, installationID))
+}
+
 func appDetailsCacheKey(revision string, appSrc *appv1.ApplicationSource, srcRefs appv1.RefTargetRevisionMapping, trackingMethod appv1.TrackingMethod, refSourceCommitSHAs ResolvedRevisions) string {
 	if trackingMethod == "" {
 		trackingMethod = appv1.TrackingMethodLabel
@@ -451,6 +463,12 @@ func (c *Cache) SetAppDetails(revision string, appSrc *appv1.ApplicationSource,
 		})
 }
 
+// CoalesceAppDetailsGeneration coalesces the app details generations which follow concurrent cache misses of the same
+// app details, see cacheutil.Cache.CoalesceRegeneration
+func (c *Cache) CoalesceAppDetailsGeneration(ctx context.Context, revision string, appSrc *appv1.ApplicationSource, srcRefs appv1.RefTargetRevisionMapping, trackingMethod appv1.TrackingMethod, refSourceCommitSHAs ResolvedRevisions) (func(), error) {
+	return c.cache.CoalesceRegeneration(ctx, appDetailsCacheKey(revision, appSrc, srcRefs, trackingMethod, refSourceCommitSHAs))
+}
+
 func revisionMetadataKey(repoURL, revision string) string {
 	return fmt.Sprintf("revisionmetadata|%s|%s", repoURL, revision)
 }
@@ -8,10 +8,14 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/collectors"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/redis/go-redis/v9"
+
+	cacheutil "github.com/argoproj/argo-cd/v3/util/cache"
 )
 
 type MetricsServer struct {
 	handler                  http.Handler
+	registry                 *prometheus.Registry
 	gitFetchFailCounter      *prometheus.CounterVec
 	gitLsRemoteFailCounter   *prometheus.CounterVec
 	gitRequestCounter        *prometheus.CounterVec
@@ -19,6 +23,8 @@ type MetricsServer struct {
 	repoPendingRequestsGauge *prometheus.GaugeVec
 	redisRequestCounter      *prometheus.CounterVec
 	redisRequestHistogram    *prometheus.HistogramVec
+	cacheRequestCounter      *prometheus.CounterVec
+	cacheRequestHistogram    *prometheus.HistogramVec
 }
 
 type GitRequestType string
@@ -99,8 +105,28 @@ func NewMetricsServer() *MetricsServer {
 	)
 	registry.MustRegister(redisRequestHistogram)
 
+	cacheRequestCounter := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "argocd_cache_request_total",
+			Help: "Number of cache requests by entry type, operation and result.",
+		},
+		[]string{"initiator", "entry_type", "operation", "result"},
+	)
+	registry.MustRegister(cacheRequestCounter)
+
+	cacheRequestHistogram := prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "argocd_cache_request_duration_seconds",
+			Help:    "Cache requests duration seconds by entry type and operation.",
+			Buckets: []float64{0.005, 0.01, 0.05, 0.1, 0.25, .5, 1, 2},
+		},
+		[]string{"initiator", "entry_type", "operation"},
+	)
+	registry.MustRegister(cacheRequestHistogram)
+
 	return &MetricsServer{
 		handler:                  promhttp.HandlerFor(registry, promhttp.HandlerOpts{}),
+		registry:                 registry,
 		gitFetchFailCounter:      gitFetchFailCounter,
 		gitLsRemoteFailCounter:   gitLsRemoteFailCounter,
 		gitRequestCounter:        gitRequestCounter,
@@ -108,6 +134,8 @@ func NewMetricsServer() *MetricsServer {
 		repoPendingRequestsGauge: repoPendingRequestsGauge,
 		redisRequestCounter:      redisRequestCounter,
 		redisRequestHistogram:    redisRequestHistogram,
+		cacheRequestCounter:      cacheRequestCounter,
+		cacheRequestHistogram:    cacheRequestHistogram,
 	}
 }
 
@@ -147,3 +175,18 @@ func (m *MetricsServer) IncRedisRequest(failed bool) {
 func (m *MetricsServer) ObserveRedisRequestDuration(duration time.Duration) {
 	m.redisRequestHistogram.WithLabelValues("argocd-repo-server").Observe(duration.Seconds())
 }
+
+// RegisterRedisClient registers the collector of the health and connection pool metrics of the Redis client
+func (m *MetricsServer) RegisterRedisClient(client redis.UniversalClient) {
+	m.registry.MustRegister(cacheutil.NewRedisClientCollector(client, "argocd-repo-server"))
+}
+
+// IncCacheRequest increments the cache requests counter
+func (m *MetricsServer) IncCacheRequest(entryType, operation, result string) {
+	m.cacheRequestCounter.WithLabelValues("argocd-repo-server", entryType, operation, result).Inc()
+}
+
+// ObserveCacheRequestDuration observes cache request duration
+func (m *MetricsServer) ObserveCacheRequestDuration(entryType, operation string, duration time.Duration) {
+	m.cacheRequestHistogram.WithLabelValues("argocd-repo-server", entryType, operation).Observe(duration.Seconds())
+}
@@ -73,6 +73,9 @@ const (
 	appSourceFile                  = ".argocd-source-%s.yaml"
 	ociPrefix                      = "oci://"
 	skipFileRenderingMarker        = "+argocd:skip-file-rendering"
+
+	// streamedCommitSHA is the revision of the sources provided as a tarball instead of a repository
+	streamedCommitSHA = "streamed"
 )
 
 var ErrExceededMaxCombinedManifestFileSize = errors.New("exceeded max combined manifest file size")
@@ -93,6 +96,8 @@ type Service struct {
 	newGitClient              func(rawRepoURL string, root string, creds git.Creds, insecure bool, enableLfs bool, proxy string, noProxy string, opts ...git.ClientOpts) (git.Client, error)
 	newHelmClient             func(repoURL string, creds helm.Creds, enableOci bool, proxy string, noProxy string, opts ...helm.ClientOpts) helm.Client
 	initConstants             RepoServerInitConstants
+	// settingsStatus holds the versions of the settings received with the manifest requests
+	settingsStatus *settings.ReceivedStatus
 	// now is usually just time.Now, but may be replaced by unit tests for testing purposes
 	now func() time.Time
 }
@@ -141,6 +146,7 @@ func NewService(metricsServer *metrics.MetricsServer, cache *cache.Cache, initCo
 			return helm.NewClientWithLock(repoURL, creds, sync.NewKeyLock(), enableOci, proxy, noProxy, opts...)
 		},
 		initConstants:      initConstants,
+		settingsStatus:     settings.NewReceivedStatus(),
 		now:                time.Now,
 		gitCredsStore:      gitCredsStore,
 		gitRepoPaths:       gitRandomizedPaths,
@@ -151,6 +157,12 @@ func NewService(metricsServer *metrics.MetricsServer, cache *cache.Cache, initCo
 	}
 }
 
+// GetSettingsStatus returns the versions of the settings received with the manifest requests. The repo server doesn't
+// watch the settings, it uses the settings of the components sending the requests.
+func (s *Service) GetSettingsStatus() *settings.ReceivedStatus {
+	return s.settingsStatus
+}
+
 func (s *Service) Init() error {
 	_, err := os.Stat(s.rootDir)
 	if os.IsNotExist(err) {
@@ -188,7 +200,7 @@ func (s *Service) Init() error {
 
 // ListOCITags List a subset of the refs (currently, branches and tags) of a git repo
 func (s *Service) ListOCITags(ctx context.Context, q *apiclient.ListRefsRequest) (*apiclient.Refs, error) {
-	ociClient, err := s.newOCIClient(q.Repo.Repo, q.Repo.GetOCICreds(), q.Repo.Proxy, q.Repo.NoProxy, s.initConstants.OCIMediaTypes, oci.WithIndexCache(s.cache), oci.WithImagePaths(s.ociPaths), oci.WithManifestMaxExtractedSize(s.initConstants.OCIManifestMaxExtractedSize), oci.WithDisableManifestMaxExtractedSize(s.initConstants.DisableOCIManifestMaxExtractedSize))
+	ociClient, err := s.newOCIClient(q.Repo.Repo, q.Repo.GetOCICreds(), q.Repo.GetProxy(), q.Repo.NoProxy, s.initConstants.OCIMediaTypes, oci.WithIndexCache(s.cache), oci.WithImagePaths(s.ociPaths), oci.WithManifestMaxExtractedSize(s.initConstants.OCIManifestMaxExtractedSize), oci.WithDisableManifestMaxExtractedSize(s.initConstants.DisableOCIManifestMaxExtractedSize))
 	if err != nil {
 		return nil, fmt.Errorf("error creating oci client: %w", err)
 	}
@@ -290,6 +302,9 @@ type operationSettings struct {
 	noCache         bool
 	noRevisionCache bool
 	allowConcurrent bool
+	// coalesceFn coalesces the operations which follow concurrent cache misses of the same cache entry, the operation
+	// is run by the first request while the other ones get the entry from the cache once it is done
+	coalesceFn func(ctx context.Context, cacheKey string, refSourceCommitSHAs cache.ResolvedRevisions) (func(), error)
 }
 
 // operationContext contains request values which are generated by runRepoOperation (on demand) by a call to the
@@ -350,7 +365,7 @@ func (s *Service) runRepoOperation(
 		return err
 	}
 
-	repoRefs, err := resolveReferencedSources(hasMultipleSources, source.Helm, refSources, s.newClientResolveRevision, gitClientOpts)
+	repoRefs, err := resolveReferencedSources(hasMultipleSources, source.Helm, refSources, s.newClientResolveRevision, s.ociRevisionResolver(ctx, settings.noCache || settings.noRevisionCache), gitClientOpts)
 	if err != nil {
 		return err
 	}
@@ -359,6 +374,20 @@ func (s *Service) runRepoOperation(
 		if ok, err := cacheFn(revision, repoRefs, true); ok {
 			return err
 		}
+		if settings.coalesceFn != nil {
+			done, err := settings.coalesceFn(ctx, revision, repoRefs)
+			if err != nil {
+				return err
+			}
+			if done == nil {
+				// the operation was run by a concurrent request, which cached its result unless it failed
+				if ok, err := cacheFn(revision, repoRefs, false); ok {
+					return err
+				}
+			} else {
+				defer done()
+			}
+		}
 	}
 
 	s.metricsServer.IncPendingRepoRequest(repo.Repo)
@@ -529,12 +558,35 @@ func getRepoSanitizerRegex(rootDir string) *regexp.Regexp {
 
 type gitClientGetter func(repo *v1alpha1.Repository, revision string, opts ...git.ClientOpts) (git.Client, string, error)
 
+// ociRevisionResolver resolves the revision of an OCI repository to a digest
+type ociRevisionResolver func(repo *v1alpha1.Repository, revision string) (string, error)
+
+// isOCIRefTarget returns whether the referenced source is an OCI artifact
+func isOCIRefTarget(refTarget *v1alpha1.RefTarget) bool {
+	return refTarget.Repo.Type == "oci" || strings.HasPrefix(refTarget.Repo.Repo, ociPrefix)
+}
+
+// refSourcePaths are the paths of the referenced sources, which are either the paths of the git repositories or the
+// paths the referenced OCI artifacts are extracted to.
+type refSourcePaths struct {
+	utilio.TempPaths
+	ociPaths map[string]string
+}
+
+// GetPathIfExists returns the path of the referenced source with the given normalized URL
+func (p *refSourcePaths) GetPathIfExists(key string) string {
+	if path, ok := p.ociPaths[key]; ok {
+		return path
+	}
+	return p.TempPaths.GetPathIfExists(key)
+}
+
 // resolveReferencedSources resolves the revisions for the given referenced sources. This lets us invalidate the cached
 // when one or more referenced sources change.
 //
 // Much of this logic is duplicated in runManifestGenAsync. If making changes here, check whether runManifestGenAsync
 // should be updated.
-func resolveReferencedSources(hasMultipleSources bool, source *v1alpha1.ApplicationSourceHelm, refSources map[string]*v1alpha1.RefTarget, newClientResolveRevision gitClientGetter, gitClientOpts git.ClientOpts) (map[string]string, error) {
+func resolveReferencedSources(hasMultipleSources bool, source *v1alpha1.ApplicationSourceHelm, refSources map[string]*v1alpha1.RefTarget, newClientResolveRevision gitClientGetter, resolveOCIRevision ociRevisionResolver, gitClientOpts git.ClientOpts) (map[string]string, error) {
 	repoRefs := make(map[string]string)
 	if !hasMultipleSources || source == nil {
 		return repoRefs, nil
@@ -568,7 +620,15 @@ func resolveReferencedSources(hasMultipleSources bool, source *v1alpha1.Applicat
 		}
 		normalizedRepoURL := git.NormalizeGitURL(refSourceMapping.Repo.Repo)
 		_, ok = repoRefs[normalizedRepoURL]
-		if !ok {
+		if !ok && isOCIRefTarget(refSourceMapping) {
+			digest, err := resolveOCIRevision(&refSourceMapping.Repo, refSourceMapping.TargetRevision)
+			if err != nil {
+				log.Errorf("Failed to resolve revision of OCI repo %s: %v", refSourceMapping.Repo.Repo, err)
+				return nil, fmt.Errorf("failed to resolve revision of OCI repo %s", refSourceMapping.Repo.Repo)
+			}
+
+			repoRefs[normalizedRepoURL] = digest
+		} else if !ok {
 			_, referencedCommitSHA, err := newClientResolveRevision(&refSourceMapping.Repo, refSourceMapping.TargetRevision, gitClientOpts)
 			if err != nil {
 				log.Errorf("Failed to get git client for repo %s: %v", refSourceMapping.Repo.Repo, err)
@@ -585,6 +645,8 @@ func (s *Service) GenerateManifest(ctx context.Context, q *apiclient.ManifestReq
 	var res *apiclient.ManifestResponse
 	var err error
 
+	s.settingsStatus.Record("manifest-generation", q.SettingsVersion)
+
 	// Skip this path for ref only sources
 	if q.HasMultipleSources && q.ApplicationSource.Path == "" && !q.ApplicationSource.IsOCI() && !q.ApplicationSource.IsHelm() && q.ApplicationSource.IsRef() {
 		log.Debugf("Skipping manifest generation for ref only source for application: %s and ref %s", q.AppName, q.ApplicationSource.Ref)
@@ -633,7 +695,11 @@ func (s *Service) GenerateManifest(ctx context.Context, q *apiclient.ManifestReq
 		return nil
 	}
 
-	settings := operationSettings{sem: s.parallelismLimitSemaphore, noCache: q.NoCache, noRevisionCache: q.NoRevisionCache, allowConcurrent: q.ApplicationSource.AllowsConcurrentProcessing()}
+	coalesceFn := func(ctx context.Context, cacheKey string, refSourceCommitSHAs cache.ResolvedRevisions) (func(), error) {
+		return s.cache.CoalesceManifestGeneration(ctx, cacheKey, q.ApplicationSource, q.RefSources, q, q.Namespace, q.TrackingMethod, q.AppLabelKey, q.AppName, refSourceCommitSHAs, q.InstallationID)
+	}
+
+	settings := operationSettings{sem: s.parallelismLimitSemaphore, noCache: q.NoCache, noRevisionCache: q.NoRevisionCache, allowConcurrent: q.ApplicationSource.AllowsConcurrentProcessing(), coalesceFn: coalesceFn}
 	err = s.runRepoOperation(ctx, q.Revision, q.Repo, q.ApplicationSource, q.VerifySignature, cacheFn, operation, settings, q.HasMultipleSources, q.RefSources)
 
 	// if the tarDoneCh message is sent it means that the manifest
@@ -684,7 +750,7 @@ func (s *Service) GenerateManifestWithFiles(stream apiclient.RepoServerService_G
 		}
 	}
 
-	promise := s.runManifestGen(stream.Context(), workDir, "streamed", metadata.Checksum, func() (*operationContext, error) {
+	promise := s.runManifestGen(stream.Context(), workDir, streamedCommitSHA, metadata.Checksum, func() (*operationContext, error) {
 		appPath, err := apppathutil.Path(workDir, req.ApplicationSource.Path)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get app path: %w", err)
@@ -776,6 +842,7 @@ func (s *Service) runManifestGenAsync(ctx context.Context, repoRoot, commitSHA,
 	// key. Overrides will break the cache anyway, because changes to overrides will change the revision.
 	appSourceCopy := q.ApplicationSource.DeepCopy()
 	repoRefs := make(map[string]repoRef)
+	refPaths := &refSourcePaths{TempPaths: s.gitRepoPaths, ociPaths: make(map[string]string)}
 
 	var manifestGenResult *apiclient.ManifestResponse
 	opContext, err := opContextSrc()
@@ -820,6 +887,16 @@ func (s *Service) runManifestGenAsync(ctx context.Context, repoRoot, commitSHA,
 							ch.errCh <- fmt.Errorf("cannot reference multiple revisions for the same repository (%s references %q while %s references %q)", refVar, refSourceMapping.TargetRevision, closer.key, closer.revision)
 							return
 						}
+					} else if isOCIRefTarget(refSourceMapping) {
+						ociPath, digest, closer, err := s.extractReferencedOCISource(ctx, refSourceMapping, q.NoRevisionCache || q.NoCache)
+						if err != nil {
+							ch.errCh <- err
+							return
+						}
+						defer utilio.Close(closer)
+
+						refPaths.ociPaths[normalizedRepoURL] = ociPath
+						repoRefs[normalizedRepoURL] = repoRef{revision: refSourceMapping.TargetRevision, commitSHA: digest, key: refVar}
 					} else {
 						gitClient, referencedCommitSHA, err := s.newClientResolveRevision(&refSourceMapping.Repo, refSourceMapping.TargetRevision, git.WithCache(s.cache, !q.NoRevisionCache && !q.NoCache))
 						if err != nil {
@@ -828,7 +905,8 @@ func (s *Service) runManifestGenAsync(ctx context.Context, repoRoot, commitSHA,
 							return
 						}
 
-						if git.NormalizeGitURL(q.ApplicationSource.RepoURL) == normalizedRepoURL && commitSHA != referencedCommitSHA {
+						// the streamed sources aren't checked out in the repository, so they can reference any of its revisions
+						if git.NormalizeGitURL(q.ApplicationSource.RepoURL) == normalizedRepoURL && commitSHA != referencedCommitSHA && commitSHA != streamedCommitSHA {
 							ch.errCh <- fmt.Errorf("cannot reference a different revision of the same repository (%s references %q which resolves to %q while the application references %q which resolves to %q)", refVar, refSourceMapping.TargetRevision, referencedCommitSHA, q.Revision, commitSHA)
 							return
 						}
@@ -873,7 +951,7 @@ func (s *Service) runManifestGenAsync(ctx context.Context, repoRoot, commitSHA,
 			}
 		}
 
-		manifestGenResult, err = GenerateManifests(ctx, opContext.appPath, repoRoot, commitSHA, q, false, s.gitCredsStore, s.initConstants.MaxCombinedDirectoryManifestsSize, s.gitRepoPaths, WithCMPTarDoneChannel(ch.tarDoneCh), WithCMPTarExcludedGlobs(s.initConstants.CMPTarExcludedGlobs), WithCMPUseManifestGeneratePaths(s.initConstants.CMPUseManifestGeneratePaths))
+		manifestGenResult, err = GenerateManifests(ctx, opContext.appPath, repoRoot, commitSHA, q, false, s.gitCredsStore, s.initConstants.MaxCombinedDirectoryManifestsSize, refPaths, WithCMPTarDoneChannel(ch.tarDoneCh), WithCMPTarExcludedGlobs(s.initConstants.CMPTarExcludedGlobs), WithCMPUseManifestGeneratePaths(s.initConstants.CMPUseManifestGeneratePaths))
 	}
 	refSourceCommitSHAs := make(map[string]string)
 	if len(repoRefs) > 0 {
@@ -943,6 +1021,38 @@ func (s *Service) runManifestGenAsync(ctx context.Context, repoRoot, commitSHA,
 	ch.responseCh <- manifestGenCacheEntry.ManifestResponse
 }
 
+// extractReferencedOCISource extracts the OCI artifact of a referenced source, and returns the path it is extracted to
+// along with its digest
+func (s *Service) extractReferencedOCISource(ctx context.Context, refSourceMapping *v1alpha1.RefTarget, noRevisionCache bool) (string, string, goio.Closer, error) {
+	ociClient, digest, err := s.newOCIClientResolveRevision(ctx, &refSourceMapping.Repo, refSourceMapping.TargetRevision, noRevisionCache)
+	if err != nil {
+		log.Errorf("Failed to get oci client for repo %s: %v", refSourceMapping.Repo.Repo, err)
+		return "", "", nil, fmt.Errorf("failed to get oci client for repo %s", refSourceMapping.Repo.Repo)
+	}
+	ociPath, closer, err := ociClient.Extract(ctx, digest)
+	if err != nil {
+		return "", "", nil, fmt.Errorf("failed to extract oci repo %s: %w", refSourceMapping.Repo.Repo, err)
+	}
+	if !s.initConstants.AllowOutOfBoundsSymlinks {
+		err := apppathutil.CheckOutOfBoundsSymlinks(ociPath)
+		if err != nil {
+			utilio.Close(closer)
+			oobError := &apppathutil.OutOfBoundsSymlinkError{}
+			if errors.As(err, &oobError) {
+				log.WithFields(log.Fields{
+					common.SecurityField: common.SecurityHigh,
+					"repo":               refSourceMapping.Repo,
+					"digest":             digest,
+					"file":               oobError.File,
+				}).Warn("oci image contains out-of-bounds symlink")
+				return "", "", nil, fmt.Errorf("oci image contains out-of-bounds symlinks. file: %s", oobError.File)
+			}
+			return "", "", nil, err
+		}
+	}
+	return ociPath, digest, closer, nil
+}
+
 // getManifestCacheEntry returns false if the 'generate manifests' operation should be run by runRepoOperation, e.g.:
 // - If the cache result is empty for the requested key
 // - If the cache is not empty, but the cached value is a manifest generation error AND we have not yet met the failure threshold (e.g. res.NumberOfConsecutiveFailures > 0 && res.NumberOfConsecutiveFailures <  s.initConstants.PauseGenerationAfterFailedGenerationAttempts)
@@ -1266,7 +1376,7 @@ func helmTemplate(appPath string, repoRoot string, env *v1alpha1.Env, q *apiclie
 
 	var proxy string
 	if q.Repo != nil {
-		proxy = q.Repo.Proxy
+		proxy = q.Repo.GetProxy()
 	}
 
 	helmRepos, err := getHelmRepos(appPath, q.Repos, q.HelmRepoCreds)
@@ -1274,7 +1384,7 @@ func helmTemplate(appPath string, repoRoot string, env *v1alpha1.Env, q *apiclie
 		return nil, "", fmt.Errorf("error getting helm repos: %w", err)
 	}
 
-	h, err := helm.NewHelmApp(appPath, helmRepos, isLocal, version, proxy, q.Repo.NoProxy, passCredentials)
+	h, err := helm.NewHelmApp(appPath, helmRepos, isLocal, version, settings.GetHelmBinaryPath(q.HelmOptions, *q.ApplicationSource), proxy, q.Repo.NoProxy, passCredentials)
 	if err != nil {
 		return nil, "", fmt.Errorf("error initializing helm app object: %w", err)
 	}
@@ -1492,30 +1602,37 @@ func GenerateManifests(ctx context.Context, appPath, repoRoot, revision string,
 	}
 
 	var commands []string
+	var toolVersion string
 
 	switch appSourceType {
 	case v1alpha1.ApplicationSourceTypeHelm:
 		var command string
 		targetObjs, command, err = helmTemplate(appPath, repoRoot, env, q, isLocal, gitRepoPaths)
 		commands = append(commands, command)
+		if q.ApplicationSource.Helm != nil {
+			toolVersion = q.ApplicationSource.Helm.Version
+		}
 	case v1alpha1.ApplicationSourceTypeKustomize:
 		var kustomizeBinary string
 		kustomizeBinary, err = settings.GetKustomizeBinaryPath(q.KustomizeOptions, *q.ApplicationSource)
 		if err != nil {
 			return nil, fmt.Errorf("error getting kustomize binary path: %w", err)
 		}
-		k := kustomize.NewKustomizeApp(repoRoot, appPath, q.Repo.GetGitCreds(gitCredsStore), repoURL, kustomizeBinary, q.Repo.Proxy, q.Repo.NoProxy)
+		k := kustomize.NewKustomizeApp(repoRoot, appPath, q.Repo.GetGitCreds(gitCredsStore), repoURL, kustomizeBinary, q.Repo.GetProxy(), q.Repo.NoProxy)
 		targetObjs, _, commands, err = k.Build(q.ApplicationSource.Kustomize, q.KustomizeOptions, env, &kustomize.BuildOpts{
 			KubeVersion: q.ApplicationSource.GetKubeVersionOrDefault(q.KubeVersion),
 			APIVersions: q.ApplicationSource.GetAPIVersionsOrDefault(q.ApiVersions),
 		})
+		if q.ApplicationSource.Kustomize != nil {
+			toolVersion = q.ApplicationSource.Kustomize.Version
+		}
 	case v1alpha1.ApplicationSourceTypePlugin:
 		pluginName := ""
 		if q.ApplicationSource.Plugin != nil {
 			pluginName = q.ApplicationSource.Plugin.Name
 		}
 		// if pluginName is provided it has to be `<metadata.name>-<spec.version>` or just `<metadata.name>` if plugin version is empty
-		targetObjs, err = runConfigManagementPluginSidecars(ctx, appPath, repoRoot, pluginName, env, q, q.Repo.GetGitCreds(gitCredsStore), opt.cmpTarDoneCh, opt.cmpTarExcludedGlobs, opt.cmpUseManifestGeneratePaths)
+		targetObjs, toolVersion, err = runConfigManagementPluginSidecars(ctx, appPath, repoRoot, pluginName, env, q, q.Repo.GetGitCreds(gitCredsStore), opt.cmpTarDoneCh, opt.cmpTarExcludedGlobs, opt.cmpUseManifestGeneratePaths)
 		if err != nil {
 			err = fmt.Errorf("plugin sidecar failed. %s", err.Error())
 		}
@@ -1573,9 +1690,10 @@ func GenerateManifests(ctx context.Context, appPath, repoRoot, revision string,
 	}
 
 	return &apiclient.ManifestResponse{
-		Manifests:  manifests,
-		SourceType: string(appSourceType),
-		Commands:   commands,
+		Manifests:   manifests,
+		SourceType:  string(appSourceType),
+		Commands:    commands,
+		ToolVersion: toolVersion,
 	}, nil
 }
 
@@ -2062,17 +2180,17 @@ func getPluginParamEnvs(envVars []string, plugin *v1alpha1.ApplicationSourcePlug
 	return env, nil
 }
 
-func runConfigManagementPluginSidecars(ctx context.Context, appPath, repoPath, pluginName string, envVars *v1alpha1.Env, q *apiclient.ManifestRequest, creds git.Creds, tarDoneCh chan<- bool, tarExcludedGlobs []string, useManifestGeneratePaths bool) ([]*unstructured.Unstructured, error) {
+func runConfigManagementPluginSidecars(ctx context.Context, appPath, repoPath, pluginName string, envVars *v1alpha1.Env, q *apiclient.ManifestRequest, creds git.Creds, tarDoneCh chan<- bool, tarExcludedGlobs []string, useManifestGeneratePaths bool) ([]*unstructured.Unstructured, string, error) {
 	// compute variables.
 	env, err := getPluginEnvs(envVars, q)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
 	// detect config management plugin server
 	conn, cmpClient, err := discovery.DetectConfigManagementPlugin(ctx, appPath, repoPath, pluginName, env, tarExcludedGlobs)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 	defer utilio.Close(conn)
 
@@ -2085,14 +2203,14 @@ func runConfigManagementPluginSidecars(ctx context.Context, appPath, repoPath, p
 
 	pluginConfigResponse, err := cmpClient.CheckPluginConfiguration(ctx, &emptypb.Empty{})
 	if err != nil {
-		return nil, fmt.Errorf("error calling cmp-server checkPluginConfiguration: %w", err)
+		return nil, "", fmt.Errorf("error calling cmp-server checkPluginConfiguration: %w", err)
 	}
 
 	if pluginConfigResponse.ProvideGitCreds {
 		if creds != nil {
 			closer, environ, err := creds.Environ()
 			if err != nil {
-				return nil, fmt.Errorf("failed to retrieve git creds environment variables: %w", err)
+				return nil, "", fmt.Errorf("failed to retrieve git creds environment variables: %w", err)
 			}
 			defer func() { _ = closer.Close() }()
 			env = append(env, environ...)
@@ -2102,7 +2220,7 @@ func runConfigManagementPluginSidecars(ctx context.Context, appPath, repoPath, p
 	// generate manifests using commands provided in plugin config file in detected cmp-server sidecar
 	cmpManifests, err := generateManifestsCMP(ctx, appPath, rootPath, env, cmpClient, tarDoneCh, tarExcludedGlobs)
 	if err != nil {
-		return nil, fmt.Errorf("error generating manifests in cmp: %w", err)
+		return nil, "", fmt.Errorf("error generating manifests in cmp: %w", err)
 	}
 	var manifests []*unstructured.Unstructured
 	for _, manifestString := range cmpManifests.Manifests {
@@ -2113,11 +2231,11 @@ func runConfigManagementPluginSidecars(ctx context.Context, appPath, repoPath, p
 				sanitizedManifestString = sanitizedManifestString[:1000]
 			}
 			log.Debugf("Failed to convert generated manifests. Beginning of generated manifests: %q", sanitizedManifestString)
-			return nil, fmt.Errorf("failed to convert CMP manifests to unstructured objects: %s", err.Error())
+			return nil, "", fmt.Errorf("failed to convert CMP manifests to unstructured objects: %s", err.Error())
 		}
 		manifests = append(manifests, manifestObjs...)
 	}
-	return manifests, nil
+	return manifests, pluginConfigResponse.Version, nil
 }
 
 // generateManifestsCMP will send the appPath files to the cmp-server over a gRPC stream.
@@ -2177,7 +2295,11 @@ func (s *Service) GetAppDetails(ctx context.Context, q *apiclient.RepoServerAppD
 		return nil
 	}
 
-	settings := operationSettings{allowConcurrent: q.Source.AllowsConcurrentProcessing(), noCache: q.NoCache, noRevisionCache: q.NoCache || q.NoRevisionCache}
+	coalesceFn := func(ctx context.Context, revision string, _ cache.ResolvedRevisions) (func(), error) {
+		return s.cache.CoalesceAppDetailsGeneration(ctx, revision, q.Source, q.RefSources, v1alpha1.TrackingMethod(q.TrackingMethod), nil)
+	}
+
+	settings := operationSettings{allowConcurrent: q.Source.AllowsConcurrentProcessing(), noCache: q.NoCache, noRevisionCache: q.NoCache || q.NoRevisionCache, coalesceFn: coalesceFn}
 	err := s.runRepoOperation(ctx, q.Source.TargetRevision, q.Repo, q.Source, false, cacheFn, operation, settings, len(q.RefSources) > 0, q.RefSources)
 
 	return res, err
@@ -2226,7 +2348,7 @@ func populateHelmAppDetails(res *apiclient.RepoAppDetailsResponse, appPath strin
 	if err != nil {
 		return err
 	}
-	h, err := helm.NewHelmApp(appPath, helmRepos, false, version, q.Repo.Proxy, q.Repo.NoProxy, passCredentials)
+	h, err := helm.NewHelmApp(appPath, helmRepos, false, version, settings.GetHelmBinaryPath(q.HelmOptions, *q.Source), q.Repo.GetProxy(), q.Repo.NoProxy, passCredentials)
 	if err != nil {
 		return err
 	}
@@ -2307,7 +2429,7 @@ func populateKustomizeAppDetails(res *apiclient.RepoAppDetailsResponse, q *apicl
 	if err != nil {
 		return fmt.Errorf("failed to get kustomize binary path: %w", err)
 	}
-	k := kustomize.NewKustomizeApp(repoRoot, appPath, q.Repo.GetGitCreds(credsStore), q.Repo.Repo, kustomizeBinary, q.Repo.Proxy, q.Repo.NoProxy)
+	k := kustomize.NewKustomizeApp(repoRoot, appPath, q.Repo.GetGitCreds(credsStore), q.Repo.Repo, kustomizeBinary, q.Repo.GetProxy(), q.Repo.NoProxy)
 	fakeManifestRequest := apiclient.ManifestRequest{
 		AppName:           q.AppName,
 		Namespace:         "", // FIXME: omit it for now
@@ -2463,7 +2585,7 @@ func (s *Service) GetRevisionMetadata(_ context.Context, q *apiclient.RepoServer
 }
 
 func (s *Service) GetOCIMetadata(ctx context.Context, q *apiclient.RepoServerRevisionChartDetailsRequest) (*v1alpha1.OCIMetadata, error) {
-	client, err := s.newOCIClient(q.Repo.Repo, q.Repo.GetOCICreds(), q.Repo.Proxy, q.Repo.NoProxy, s.initConstants.OCIMediaTypes, oci.WithIndexCache(s.cache), oci.WithImagePaths(s.ociPaths), oci.WithManifestMaxExtractedSize(s.initConstants.OCIManifestMaxExtractedSize), oci.WithDisableManifestMaxExtractedSize(s.initConstants.DisableOCIManifestMaxExtractedSize))
+	client, err := s.newOCIClient(q.Repo.Repo, q.Repo.GetOCICreds(), q.Repo.GetProxy(), q.Repo.NoProxy, s.initConstants.OCIMediaTypes, oci.WithIndexCache(s.cache), oci.WithImagePaths(s.ociPaths), oci.WithManifestMaxExtractedSize(s.initConstants.OCIManifestMaxExtractedSize), oci.WithDisableManifestMaxExtractedSize(s.initConstants.DisableOCIManifestMaxExtractedSize))
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize oci client: %w", err)
 	}
@@ -2508,7 +2630,7 @@ func (s *Service) GetRevisionChartDetails(_ context.Context, q *apiclient.RepoSe
 		return nil, fmt.Errorf("error extracting chart: %w", err)
 	}
 	defer utilio.Close(closer)
-	helmCmd, err := helm.NewCmdWithVersion(chartPath, q.Repo.EnableOCI, q.Repo.Proxy, q.Repo.NoProxy)
+	helmCmd, err := helm.NewCmdWithVersion(chartPath, q.Repo.EnableOCI, q.Repo.GetProxy(), q.Repo.NoProxy)
 	if err != nil {
 		return nil, fmt.Errorf("error creating helm cmd: %w", err)
 	}
@@ -2538,7 +2660,7 @@ func (s *Service) newClient(repo *v1alpha1.Repository, opts ...git.ClientOpts) (
 		return nil, err
 	}
 	opts = append(opts, git.WithEventHandlers(metrics.NewGitClientEventHandlers(s.metricsServer)))
-	return s.newGitClient(repo.Repo, repoPath, repo.GetGitCreds(s.gitCredsStore), repo.IsInsecure(), repo.EnableLFS, repo.Proxy, repo.NoProxy, opts...)
+	return s.newGitClient(repo.Repo, repoPath, repo.GetGitCreds(s.gitCredsStore), repo.IsInsecure(), repo.EnableLFS, repo.GetProxy(), repo.NoProxy, opts...)
 }
 
 // newClientResolveRevision is a helper to perform the common task of instantiating a git client
@@ -2557,7 +2679,7 @@ func (s *Service) newClientResolveRevision(repo *v1alpha1.Repository, revision s
 }
 
 func (s *Service) newOCIClientResolveRevision(ctx context.Context, repo *v1alpha1.Repository, revision string, noRevisionCache bool) (oci.Client, string, error) {
-	ociClient, err := s.newOCIClient(repo.Repo, repo.GetOCICreds(), repo.Proxy, repo.NoProxy, s.initConstants.OCIMediaTypes, oci.WithIndexCache(s.cache), oci.WithImagePaths(s.ociPaths), oci.WithManifestMaxExtractedSize(s.initConstants.OCIManifestMaxExtractedSize), oci.WithDisableManifestMaxExtractedSize(s.initConstants.DisableOCIManifestMaxExtractedSize))
+	ociClient, err := s.newOCIClient(repo.Repo, repo.GetOCICreds(), repo.GetProxy(), repo.NoProxy, s.initConstants.OCIMediaTypes, oci.WithIndexCache(s.cache), oci.WithImagePaths(s.ociPaths), oci.WithManifestMaxExtractedSize(s.initConstants.OCIManifestMaxExtractedSize), oci.WithDisableManifestMaxExtractedSize(s.initConstants.DisableOCIManifestMaxExtractedSize))
 	if err != nil {
 		return nil, "", fmt.Errorf("failed to initialize oci client: %w", err)
 	}
@@ -2570,9 +2692,17 @@ func (s *Service) newOCIClientResolveRevision(ctx context.Context, repo *v1alpha
 	return ociClient, digest, nil
 }
 
+// ociRevisionResolver returns a resolver of the revisions of the referenced OCI repositories
+func (s *Service) ociRevisionResolver(ctx context.Context, noRevisionCache bool) ociRevisionResolver {
+	return func(repo *v1alpha1.Repository, revision string) (string, error) {
+		_, digest, err := s.newOCIClientResolveRevision(ctx, repo, revision, noRevisionCache)
+		return digest, err
+	}
+}
+
 func (s *Service) newHelmClientResolveRevision(repo *v1alpha1.Repository, revision string, chart string, noRevisionCache bool) (helm.Client, string, error) {
 	enableOCI := repo.EnableOCI || helm.IsHelmOciRepo(repo.Repo)
-	helmClient := s.newHelmClient(repo.Repo, repo.GetHelmCreds(), enableOCI, repo.Proxy, repo.NoProxy, helm.WithIndexCache(s.cache), helm.WithChartPaths(s.chartPaths))
+	helmClient := s.newHelmClient(repo.Repo, repo.GetHelmCreds(), enableOCI, repo.GetProxy(), repo.NoProxy, helm.WithIndexCache(s.cache), helm.WithChartPaths(s.chartPaths))
 
 	// Note: This check runs the risk of returning a version which is not found in the helm registry.
 	if versions.IsVersion(revision) {
@@ -2725,7 +2855,7 @@ func checkoutRevision(gitClient git.Client, revision string, submoduleEnabled bo
 }
 
 func (s *Service) GetHelmCharts(_ context.Context, q *apiclient.HelmChartsRequest) (*apiclient.HelmChartsResponse, error) {
-	index, err := s.newHelmClient(q.Repo.Repo, q.Repo.GetHelmCreds(), q.Repo.EnableOCI, q.Repo.Proxy, q.Repo.NoProxy, helm.WithIndexCache(s.cache), helm.WithChartPaths(s.chartPaths)).GetIndex(true, s.initConstants.HelmRegistryMaxIndexSize)
+	index, err := s.newHelmClient(q.Repo.Repo, q.Repo.GetHelmCreds(), q.Repo.EnableOCI, q.Repo.GetProxy(), q.Repo.NoProxy, helm.WithIndexCache(s.cache), helm.WithChartPaths(s.chartPaths)).GetIndex(true, s.initConstants.HelmRegistryMaxIndexSize)
 	if err != nil {
 		return nil, err
 	}
@@ -2747,10 +2877,10 @@ func (s *Service) TestRepository(ctx context.Context, q *apiclient.TestRepositor
 	}
 	checks := map[string]func() error{
 		"git": func() error {
-			return git.TestRepo(repo.Repo, repo.GetGitCreds(s.gitCredsStore), repo.IsInsecure(), repo.IsLFSEnabled(), repo.Proxy, repo.NoProxy)
+			return git.TestRepo(repo.Repo, repo.GetGitCreds(s.gitCredsStore), repo.IsInsecure(), repo.IsLFSEnabled(), repo.GetProxy(), repo.NoProxy)
 		},
 		"oci": func() error {
-			client, err := oci.NewClient(repo.Repo, repo.GetOCICreds(), repo.Proxy, repo.NoProxy, s.initConstants.OCIMediaTypes)
+			client, err := oci.NewClient(repo.Repo, repo.GetOCICreds(), repo.GetProxy(), repo.NoProxy, s.initConstants.OCIMediaTypes)
 			if err != nil {
 				return err
 			}
@@ -2762,10 +2892,10 @@ func (s *Service) TestRepository(ctx context.Context, q *apiclient.TestRepositor
 				if !helm.IsHelmOciRepo(repo.Repo) {
 					return errors.New("OCI Helm repository URL should include hostname and port only")
 				}
-				_, err := helm.NewClient(repo.Repo, repo.GetHelmCreds(), repo.EnableOCI, repo.Proxy, repo.NoProxy).TestHelmOCI()
+				_, err := helm.NewClient(repo.Repo, repo.GetHelmCreds(), repo.EnableOCI, repo.GetProxy(), repo.NoProxy).TestHelmOCI()
 				return err
 			}
-			_, err := helm.NewClient(repo.Repo, repo.GetHelmCreds(), repo.EnableOCI, repo.Proxy, repo.NoProxy).GetIndex(false, s.initConstants.HelmRegistryMaxIndexSize)
+			_, err := helm.NewClient(repo.Repo, repo.GetHelmCreds(), repo.EnableOCI, repo.GetProxy(), repo.NoProxy).GetIndex(false, s.initConstants.HelmRegistryMaxIndexSize)
 			return err
 		},
 	}
@@ -2806,7 +2936,7 @@ func (s *Service) ResolveRevision(ctx context.Context, q *apiclient.ResolveRevis
 			AmbiguousRevision: fmt.Sprintf("%v (%v)", ambiguousRevision, revision),
 		}, nil
 	}
-	gitClient, err := git.NewClient(repo.Repo, repo.GetGitCreds(s.gitCredsStore), repo.IsInsecure(), repo.IsLFSEnabled(), repo.Proxy, repo.NoProxy)
+	gitClient, err := git.NewClient(repo.Repo, repo.GetGitCreds(s.gitCredsStore), repo.IsInsecure(), repo.IsLFSEnabled(), repo.GetProxy(), repo.NoProxy)
 	if err != nil {
 		return &apiclient.ResolveRevisionResponse{Revision: "", AmbiguousRevision: ""}, err
 	}
@@ -3080,7 +3210,7 @@ func (s *Service) updateCachedRevision(logCtx *log.Entry, oldRev string, newRev
 	repoRefs := make(map[string]string)
 	if request.HasMultipleSources && request.ApplicationSource.Helm != nil {
 		var err error
-		repoRefs, err = resolveReferencedSources(true, request.ApplicationSource.Helm, request.RefSources, s.newClientResolveRevision, gitClientOpts)
+		repoRefs, err = resolveReferencedSources(true, request.ApplicationSource.Helm, request.RefSources, s.newClientResolveRevision, s.ociRevisionResolver(context.Background(), request.NoRevisionCache), gitClientOpts)
 		if err != nil {
 			return fmt.Errorf("failed to get repo refs for application %s in repo %s from revision %s: %w", request.AppName, request.GetRepo().Repo, request.Revision, err)
 		}
@@ -271,6 +271,41 @@ func Test_GenerateManifest_KustomizeWithVersionOverride(t *testing.T) {
 	assert.NotNil(t, res)
 }
 
+func Test_GenerateManifests_ToolVersion(t *testing.T) {
+	t.Parallel()
+
+	fakeBinary := func(name string) string {
+		binaryPath := filepath.Join(t.TempDir(), name)
+		require.NoError(t, os.WriteFile(binaryPath, []byte("#!/bin/sh\necho 'apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: "+name+"'\n"), 0o755))
+		return binaryPath
+	}
+	q := apiclient.ManifestRequest{
+		Repo:               &v1alpha1.Repository{},
+		ProjectName:        "something",
+		ProjectSourceRepos: []string{"*"},
+		HelmOptions: &v1alpha1.HelmOptions{
+			Versions: []v1alpha1.HelmVersion{{Name: "v3.14", Path: fakeBinary("helm-v3.14")}},
+		},
+		KustomizeOptions: &v1alpha1.KustomizeOptions{
+			Versions: []v1alpha1.KustomizeVersion{{Name: "v5.4", Path: fakeBinary("kustomize-v5.4")}},
+		},
+	}
+
+	q.ApplicationSource = &v1alpha1.ApplicationSource{Path: ".", Helm: &v1alpha1.ApplicationSourceHelm{Version: "v3.14"}}
+	res, err := GenerateManifests(t.Context(), "./testdata/my-chart", "/", "", &q, false, &git.NoopCredsStore{}, resource.MustParse("0"), nil)
+	require.NoError(t, err)
+	require.Len(t, res.Manifests, 1)
+	assert.Contains(t, res.Manifests[0], "helm-v3.14")
+	assert.Equal(t, "v3.14", res.ToolVersion)
+
+	q.ApplicationSource = &v1alpha1.ApplicationSource{Path: ".", Kustomize: &v1alpha1.ApplicationSourceKustomize{Version: "v5.4"}}
+	res, err = GenerateManifests(t.Context(), "./testdata/kustomization_yaml", "/", "", &q, false, &git.NoopCredsStore{}, resource.MustParse("0"), nil)
+	require.NoError(t, err)
+	require.Len(t, res.Manifests, 1)
+	assert.Contains(t, res.Manifests[0], "kustomize-v5.4")
+	assert.Equal(t, "v5.4", res.ToolVersion)
+}
+
 func Test_GenerateManifests_NoOutOfBoundsAccess(t *testing.T) {
 	t.Parallel()
 
@@ -1936,9 +1971,9 @@ func TestService_newHelmClientResolveRevision(t *testing.T) {
 
 func TestGetAppDetailsWithAppParameterFile(t *testing.T) {
 	t.Run("No app name set and app specific file exists", func(t *testing.T) {
-		service := newService(t, ".")
-		runWithTempTestdata(t, "multi", func(t *testing.T, path string) {
+		runWithTempTestdata(t, "multi", func(t *testing.T, root, path string) {
 			t.Helper()
+			service := newService(t, root)
 			details, err := service.GetAppDetails(t.Context(), &apiclient.RepoServerAppDetailsQuery{
 				Repo: &v1alpha1.Repository{},
 				Source: &v1alpha1.ApplicationSource{
@@ -1950,9 +1985,9 @@ func TestGetAppDetailsWithAppParameterFile(t *testing.T) {
 		})
 	})
 	t.Run("No app specific override", func(t *testing.T) {
-		service := newService(t, ".")
-		runWithTempTestdata(t, "single-global", func(t *testing.T, path string) {
+		runWithTempTestdata(t, "single-global", func(t *testing.T, root, path string) {
 			t.Helper()
+			service := newService(t, root)
 			details, err := service.GetAppDetails(t.Context(), &apiclient.RepoServerAppDetailsQuery{
 				Repo: &v1alpha1.Repository{},
 				Source: &v1alpha1.ApplicationSource{
@@ -1965,9 +2000,9 @@ func TestGetAppDetailsWithAppParameterFile(t *testing.T) {
 		})
 	})
 	t.Run("Only app specific override", func(t *testing.T) {
-		service := newService(t, ".")
-		runWithTempTestdata(t, "single-app-only", func(t *testing.T, path string) {
+		runWithTempTestdata(t, "single-app-only", func(t *testing.T, root, path string) {
 			t.Helper()
+			service := newService(t, root)
 			details, err := service.GetAppDetails(t.Context(), &apiclient.RepoServerAppDetailsQuery{
 				Repo: &v1alpha1.Repository{},
 				Source: &v1alpha1.ApplicationSource{
@@ -1980,9 +2015,9 @@ func TestGetAppDetailsWithAppParameterFile(t *testing.T) {
 		})
 	})
 	t.Run("App specific override", func(t *testing.T) {
-		service := newService(t, ".")
-		runWithTempTestdata(t, "multi", func(t *testing.T, path string) {
+		runWithTempTestdata(t, "multi", func(t *testing.T, root, path string) {
 			t.Helper()
+			service := newService(t, root)
 			details, err := service.GetAppDetails(t.Context(), &apiclient.RepoServerAppDetailsQuery{
 				Repo: &v1alpha1.Repository{},
 				Source: &v1alpha1.ApplicationSource{
@@ -1995,9 +2030,9 @@ func TestGetAppDetailsWithAppParameterFile(t *testing.T) {
 		})
 	})
 	t.Run("App specific overrides containing non-mergeable field", func(t *testing.T) {
-		service := newService(t, ".")
-		runWithTempTestdata(t, "multi", func(t *testing.T, path string) {
+		runWithTempTestdata(t, "multi", func(t *testing.T, root, path string) {
 			t.Helper()
+			service := newService(t, root)
 			details, err := service.GetAppDetails(t.Context(), &apiclient.RepoServerAppDetailsQuery{
 				Repo: &v1alpha1.Repository{},
 				Source: &v1alpha1.ApplicationSource{
@@ -2010,9 +2045,9 @@ func TestGetAppDetailsWithAppParameterFile(t *testing.T) {
 		})
 	})
 	t.Run("Broken app-specific overrides", func(t *testing.T) {
-		service := newService(t, ".")
-		runWithTempTestdata(t, "multi", func(t *testing.T, path string) {
+		runWithTempTestdata(t, "multi", func(t *testing.T, root, path string) {
 			t.Helper()
+			service := newService(t, root)
 			_, err := service.GetAppDetails(t.Context(), &apiclient.RepoServerAppDetailsQuery{
 				Repo: &v1alpha1.Repository{},
 				Source: &v1alpha1.ApplicationSource{
@@ -2027,35 +2062,29 @@ func TestGetAppDetailsWithAppParameterFile(t *testing.T) {
 
 // There are unit test that will use kustomize set and by that modify the
 // kustomization.yaml. For proper testing, we need to copy the testdata to a
-// temporary path, run the tests, and then throw the copy away again.
-func mkTempParameters(source string) string {
-	tempDir, err := os.MkdirTemp("./testdata", "app-parameters")
-	if err != nil {
-		panic(err)
-	}
+// temporary path, which is removed after the test.
+func mkTempParameters(t *testing.T, source string) string {
+	t.Helper()
+	tempDir := t.TempDir()
 	cmd := exec.Command("cp", "-R", source, tempDir)
-	err = cmd.Run()
-	if err != nil {
-		os.RemoveAll(tempDir)
-		panic(err)
-	}
+	require.NoError(t, cmd.Run())
 	return tempDir
 }
 
 // Simple wrapper run a test with a temporary copy of the testdata, because
-// the test would modify the data when run.
-func runWithTempTestdata(t *testing.T, path string, runner func(t *testing.T, path string)) {
+// the test would modify the data when run. The runner gets the root of the
+// copy and the path of the test app within it.
+func runWithTempTestdata(t *testing.T, path string, runner func(t *testing.T, root string, path string)) {
 	t.Helper()
-	tempDir := mkTempParameters("./testdata/app-parameters")
-	runner(t, filepath.Join(tempDir, "app-parameters", path))
-	os.RemoveAll(tempDir)
+	tempDir := mkTempParameters(t, "./testdata/app-parameters")
+	runner(t, tempDir, filepath.Join("app-parameters", path))
 }
 
 func TestGenerateManifestsWithAppParameterFile(t *testing.T) {
 	t.Run("Single global override", func(t *testing.T) {
-		runWithTempTestdata(t, "single-global", func(t *testing.T, path string) {
+		runWithTempTestdata(t, "single-global", func(t *testing.T, root, path string) {
 			t.Helper()
-			service := newService(t, ".")
+			service := newService(t, root)
 			manifests, err := service.GenerateManifest(t.Context(), &apiclient.ManifestRequest{
 				Repo: &v1alpha1.Repository{},
 				ApplicationSource: &v1alpha1.ApplicationSource{
@@ -2083,9 +2112,9 @@ func TestGenerateManifestsWithAppParameterFile(t *testing.T) {
 	})
 
 	t.Run("Single global override Helm", func(t *testing.T) {
-		runWithTempTestdata(t, "single-global-helm", func(t *testing.T, path string) {
+		runWithTempTestdata(t, "single-global-helm", func(t *testing.T, root, path string) {
 			t.Helper()
-			service := newService(t, ".")
+			service := newService(t, root)
 			manifests, err := service.GenerateManifest(t.Context(), &apiclient.ManifestRequest{
 				Repo: &v1alpha1.Repository{},
 				ApplicationSource: &v1alpha1.ApplicationSource{
@@ -2113,9 +2142,9 @@ func TestGenerateManifestsWithAppParameterFile(t *testing.T) {
 	})
 
 	t.Run("Application specific override", func(t *testing.T) {
-		service := newService(t, ".")
-		runWithTempTestdata(t, "single-app-only", func(t *testing.T, path string) {
+		runWithTempTestdata(t, "single-app-only", func(t *testing.T, root, path string) {
 			t.Helper()
+			service := newService(t, root)
 			manifests, err := service.GenerateManifest(t.Context(), &apiclient.ManifestRequest{
 				Repo: &v1alpha1.Repository{},
 				ApplicationSource: &v1alpha1.ApplicationSource{
@@ -2144,9 +2173,9 @@ func TestGenerateManifestsWithAppParameterFile(t *testing.T) {
 	})
 
 	t.Run("Multi-source with source as ref only does not generate manifests", func(t *testing.T) {
-		service := newService(t, ".")
-		runWithTempTestdata(t, "single-app-only", func(t *testing.T, _ string) {
+		runWithTempTestdata(t, "single-app-only", func(t *testing.T, root, _ string) {
 			t.Helper()
+			service := newService(t, root)
 			manifests, err := service.GenerateManifest(t.Context(), &apiclient.ManifestRequest{
 				Repo: &v1alpha1.Repository{},
 				ApplicationSource: &v1alpha1.ApplicationSource{
@@ -2166,9 +2195,9 @@ func TestGenerateManifestsWithAppParameterFile(t *testing.T) {
 	})
 
 	t.Run("Application specific override for other app", func(t *testing.T) {
-		service := newService(t, ".")
-		runWithTempTestdata(t, "single-app-only", func(t *testing.T, path string) {
+		runWithTempTestdata(t, "single-app-only", func(t *testing.T, root, path string) {
 			t.Helper()
+			service := newService(t, root)
 			manifests, err := service.GenerateManifest(t.Context(), &apiclient.ManifestRequest{
 				Repo: &v1alpha1.Repository{},
 				ApplicationSource: &v1alpha1.ApplicationSource{
@@ -2197,9 +2226,9 @@ func TestGenerateManifestsWithAppParameterFile(t *testing.T) {
 	})
 
 	t.Run("Override info does not appear in cache key", func(t *testing.T) {
-		service := newService(t, ".")
-		runWithTempTestdata(t, "single-global", func(t *testing.T, path string) {
+		runWithTempTestdata(t, "single-global", func(t *testing.T, root, path string) {
 			t.Helper()
+			service := newService(t, root)
 			source := &v1alpha1.ApplicationSource{
 				Path: path,
 			}
@@ -3405,6 +3434,9 @@ func Test_getResolvedValueFiles(t *testing.T) {
 	paths := utilio.NewRandomizedTempPaths(tempDir)
 
 	paths.Add(git.NormalizeGitURL("https://github.com/org/repo1"), path.Join(tempDir, "repo1"))
+	refPaths := &refSourcePaths{TempPaths: paths, ociPaths: map[string]string{
+		git.NormalizeGitURL("oci://registry.example.com/org/values"): path.Join(tempDir, "oci-values"),
+	}}
 
 	testCases := []struct {
 		name         string
@@ -3434,6 +3466,19 @@ func Test_getResolvedValueFiles(t *testing.T) {
 			},
 			expectedPath: path.Join(tempDir, "repo1", "values.yaml"),
 		},
+		{
+			name:    "oci ref",
+			rawPath: "$ref/values-prod.yaml",
+			env:     &v1alpha1.Env{},
+			refSources: map[string]*v1alpha1.RefTarget{
+				"$ref": {
+					Repo: v1alpha1.Repository{
+						Repo: "oci://registry.example.com/org/values",
+					},
+				},
+			},
+			expectedPath: path.Join(tempDir, "oci-values", "values-prod.yaml"),
+		},
 		{
 			name:    "only ref",
 			rawPath: "$ref",
@@ -3544,7 +3589,7 @@ func Test_getResolvedValueFiles(t *testing.T) {
 		tcc := tc
 		t.Run(tcc.name, func(t *testing.T) {
 			t.Parallel()
-			resolvedPaths, err := getResolvedValueFiles(path.Join(tempDir, "main-repo"), path.Join(tempDir, "main-repo"), tcc.env, []string{}, []string{tcc.rawPath}, tcc.refSources, paths, false)
+			resolvedPaths, err := getResolvedValueFiles(path.Join(tempDir, "main-repo"), path.Join(tempDir, "main-repo"), tcc.env, []string{}, []string{tcc.rawPath}, tcc.refSources, refPaths, false)
 			if !tcc.expectedErr {
 				require.NoError(t, err)
 				require.Len(t, resolvedPaths, 1)
@@ -3557,6 +3602,38 @@ func Test_getResolvedValueFiles(t *testing.T) {
 	}
 }
 
+func Test_resolveReferencedSources(t *testing.T) {
+	t.Parallel()
+
+	refSources := map[string]*v1alpha1.RefTarget{
+		"$git": {Repo: v1alpha1.Repository{Repo: "https://github.com/org/repo1"}, TargetRevision: "main"},
+		"$oci": {Repo: v1alpha1.Repository{Repo: "oci://registry.example.com/org/values"}, TargetRevision: "1.0.0"},
+	}
+	source := &v1alpha1.ApplicationSourceHelm{ValueFiles: []string{"values.yaml", "$git/values.yaml", "$oci/values-prod.yaml"}}
+	newClientResolveRevision := func(repo *v1alpha1.Repository, revision string, _ ...git.ClientOpts) (git.Client, string, error) {
+		assert.Equal(t, "https://github.com/org/repo1", repo.Repo)
+		assert.Equal(t, "main", revision)
+		return nil, "632039659e542ed7de0c170a4fcc1c571b288fc0", nil
+	}
+	resolveOCIRevision := func(repo *v1alpha1.Repository, revision string) (string, error) {
+		assert.Equal(t, "oci://registry.example.com/org/values", repo.Repo)
+		assert.Equal(t, "1.0.0", revision)
+		return "sha256:2d6e6a5ab3d4ebb0b5a8b4a1b3b2e6c14bd4de0fc8f6d1a8c6f0c9a6b2e8f3a4", nil
+	}
+
+	repoRefs, err := resolveReferencedSources(true, source, refSources, newClientResolveRevision, resolveOCIRevision, git.WithCache(nil, false))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		git.NormalizeGitURL("https://github.com/org/repo1"):          "632039659e542ed7de0c170a4fcc1c571b288fc0",
+		git.NormalizeGitURL("oci://registry.example.com/org/values"): "sha256:2d6e6a5ab3d4ebb0b5a8b4a1b3b2e6c14bd4de0fc8f6d1a8c6f0c9a6b2e8f3a4",
+	}, repoRefs)
+
+	_, err = resolveReferencedSources(true, source, refSources, newClientResolveRevision, func(_ *v1alpha1.Repository, _ string) (string, error) {
+		return "", errors.New("not found")
+	}, git.WithCache(nil, false))
+	require.EqualError(t, err, "failed to resolve revision of OCI repo oci://registry.example.com/org/values")
+}
+
 func TestErrorGetGitDirectories(t *testing.T) {
 	// test not using the cache
 	root := "./testdata/git-files-dirs"
@@ -3,6 +3,7 @@ package reposerver
 import (
 	"crypto/tls"
 	"fmt"
+	"net/http"
 	"os"
 	"path/filepath"
 
@@ -29,6 +30,7 @@ import (
 	"github.com/argoproj/argo-cd/v3/util/env"
 	"github.com/argoproj/argo-cd/v3/util/git"
 	grpc_util "github.com/argoproj/argo-cd/v3/util/grpc"
+	"github.com/argoproj/argo-cd/v3/util/spiffe"
 	tlsutil "github.com/argoproj/argo-cd/v3/util/tls"
 )
 
@@ -36,6 +38,8 @@ import (
 type ArgoCDRepoServer struct {
 	repoService *repository.Service
 	opts        []grpc.ServerOption
+	// localOpts are the options of the gRPC server serving the connections within the pod or the process, without TLS
+	localOpts []grpc.ServerOption
 }
 
 // The hostnames to generate self-signed issues with
@@ -46,14 +50,22 @@ func NewServer(metricsServer *metrics.MetricsServer, cache *reposervercache.Cach
 	var tlsConfig *tls.Config
 
 	// Generate or load TLS server certificates to use with this instance of
-	// repository server.
+	// repository server, or use the SPIFFE workload identity for mTLS if
+	// configured.
 	if tlsConfCustomizer != nil {
-		var err error
-		certPath := env.StringFromEnv(common.EnvAppConfigPath, common.DefaultAppConfigPath) + "/reposerver/tls/tls.crt"
-		keyPath := env.StringFromEnv(common.EnvAppConfigPath, common.DefaultAppConfigPath) + "/reposerver/tls/tls.key"
-		tlsConfig, err = tlsutil.CreateServerTLSConfig(certPath, keyPath, tlsHostList)
+		spiffeSource, err := spiffe.DefaultSource()
 		if err != nil {
-			return nil, fmt.Errorf("error creating server TLS config: %w", err)
+			return nil, fmt.Errorf("error loading SPIFFE workload identity: %w", err)
+		}
+		if spiffeSource != nil {
+			tlsConfig = spiffeSource.ServerTLSConfig()
+		} else {
+			certPath := env.StringFromEnv(common.EnvAppConfigPath, common.DefaultAppConfigPath) + "/reposerver/tls/tls.crt"
+			keyPath := env.StringFromEnv(common.EnvAppConfigPath, common.DefaultAppConfigPath) + "/reposerver/tls/tls.key"
+			tlsConfig, err = tlsutil.CreateServerTLSConfig(certPath, keyPath, tlsHostList)
+			if err != nil {
+				return nil, fmt.Errorf("error creating server TLS config: %w", err)
+			}
 		}
 		tlsConfCustomizer(tlsConfig)
 	}
@@ -89,11 +101,14 @@ func NewServer(metricsServer *metrics.MetricsServer, cache *reposervercache.Cach
 		grpc.KeepaliveEnforcementPolicy(
 			keepalive.EnforcementPolicy{
 				MinTime: common.GetGRPCKeepAliveEnforcementMinimum(),
+				// the clients may ping the idle connections, see apiclient.EnvKeepalivePermitWithoutStream
+				PermitWithoutStream: true,
 			},
 		),
 		grpc.StatsHandler(otelgrpc.NewServerHandler()),
 	}
 
+	localOpts := serverOpts
 	// We do allow for non-TLS servers to be created, in case of mTLS will be
 	// implemented by e.g. a sidecar container.
 	if tlsConfig != nil {
@@ -106,13 +121,30 @@ func NewServer(metricsServer *metrics.MetricsServer, cache *reposervercache.Cach
 
 	return &ArgoCDRepoServer{
 		opts:        serverOpts,
+		localOpts:   localOpts,
 		repoService: repoService,
 	}, nil
 }
 
 // CreateGRPC creates new configured grpc server
 func (a *ArgoCDRepoServer) CreateGRPC() *grpc.Server {
-	server := grpc.NewServer(a.opts...)
+	return a.createGRPC(a.opts)
+}
+
+// CreateLocalGRPC creates new configured grpc server without TLS, which serves the connections within the pod on a
+// Unix domain socket, or within the process with an apiclient.InProcessTransport
+func (a *ArgoCDRepoServer) CreateLocalGRPC() *grpc.Server {
+	return a.createGRPC(a.localOpts)
+}
+
+// RegisterSettingsStatusHandler registers the handler returning the versions of the settings received by the repo
+// server on the mux
+func (a *ArgoCDRepoServer) RegisterSettingsStatusHandler(mux *http.ServeMux) {
+	a.repoService.GetSettingsStatus().RegisterStatusHandler(mux)
+}
+
+func (a *ArgoCDRepoServer) createGRPC(opts []grpc.ServerOption) *grpc.Server {
+	server := grpc.NewServer(opts...)
 	versionpkg.RegisterVersionServiceServer(server, version.NewServer(nil, func() (bool, error) {
 		return true, nil
 	}))
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"math"
 	"reflect"
 	"slices"
@@ -288,12 +289,20 @@ func (s *Server) List(ctx context.Context, q *application.ApplicationQuery) (*v1
 		filteredApps = argo.FilterByNameP(filteredApps, *q.Name)
 	}
 
+	// Filter applications by name prefix
+	filteredApps = argo.FilterByNamePrefixP(filteredApps, q.GetNamePrefix())
+
 	// Filter applications by projects
 	filteredApps = argo.FilterByProjectsP(filteredApps, getProjectsFromApplicationQuery(*q))
 
 	// Filter applications by source repo URL
 	filteredApps = argo.FilterByRepoP(filteredApps, q.GetRepo())
 
+	// Filter applications by freeze
+	if q.Frozen != nil {
+		filteredApps = argo.FilterByFrozenP(filteredApps, *q.Frozen, time.Now())
+	}
+
 	newItems := make([]v1alpha1.Application, 0)
 	for _, a := range filteredApps {
 		// Skip any application that is neither in the control plane's namespace
@@ -531,6 +540,7 @@ func (s *Server) GetManifests(ctx context.Context, q *application.ApplicationMan
 		}
 
 		for _, source := range sources {
+			source = proj.GetApplicationSource(source)
 			repo, err := s.db.GetRepository(ctx, source.RepoURL, proj.Name)
 			if err != nil {
 				return fmt.Errorf("error getting repository: %w", err)
@@ -545,7 +555,7 @@ func (s *Server) GetManifests(ctx context.Context, q *application.ApplicationMan
 			if err != nil {
 				return fmt.Errorf("error getting installation ID: %w", err)
 			}
-			trackingMethod, err := s.settingsMgr.GetTrackingMethod()
+			trackingMethod, err := argo.GetTrackingMethod(s.settingsMgr, proj)
 			if err != nil {
 				return fmt.Errorf("error getting trackingMethod from settings: %w", err)
 			}
@@ -637,20 +647,29 @@ func (s *Server) GetManifestsWithFiles(stream application.ApplicationService_Get
 		return err
 	}
 
+	if !s.isNamespaceEnabled(a.Namespace) {
+		return security.NamespaceNotPermittedError(a.Namespace)
+	}
+
 	var manifestInfo *apiclient.ManifestResponse
 	err = s.queryRepoServer(ctx, proj, func(
-		client apiclient.RepoServerServiceClient, helmRepos []*v1alpha1.Repository, helmCreds []*v1alpha1.RepoCreds, _ []*v1alpha1.Repository, _ []*v1alpha1.RepoCreds, helmOptions *v1alpha1.HelmOptions, enableGenerateManifests map[string]bool,
+		client apiclient.RepoServerServiceClient, helmRepos []*v1alpha1.Repository, helmCreds []*v1alpha1.RepoCreds, ociRepos []*v1alpha1.Repository, ociCreds []*v1alpha1.RepoCreds, helmOptions *v1alpha1.HelmOptions, enableGenerateManifests map[string]bool,
 	) error {
 		appInstanceLabelKey, err := s.settingsMgr.GetAppInstanceLabelKey()
 		if err != nil {
 			return fmt.Errorf("error getting app instance label key from settings: %w", err)
 		}
 
-		trackingMethod, err := s.settingsMgr.GetTrackingMethod()
+		trackingMethod, err := argo.GetTrackingMethod(s.settingsMgr, proj)
 		if err != nil {
 			return fmt.Errorf("error getting trackingMethod from settings: %w", err)
 		}
 
+		installationID, err := s.settingsMgr.GetInstallationID()
+		if err != nil {
+			return fmt.Errorf("error getting installation ID: %w", err)
+		}
+
 		config, err := s.getApplicationClusterConfig(ctx, a)
 		if err != nil {
 			return fmt.Errorf("error getting application cluster config: %w", err)
@@ -666,59 +685,104 @@ func (s *Server) GetManifestsWithFiles(stream application.ApplicationService_Get
 			return fmt.Errorf("error getting API resources: %w", err)
 		}
 
-		source := a.Spec.GetSource()
+		// the provided files replace the source at the requested position, while the manifests of the other sources of a
+		// multi-source application are generated from their repositories, as in the manifests generated by the controller
+		sources := []v1alpha1.ApplicationSource{a.Spec.GetSource()}
+		pos := query.GetSourcePosition()
+		if pos == 0 {
+			pos = 1
+		}
+		var refSources v1alpha1.RefTargetRevisionMapping
+		if a.Spec.HasMultipleSources() {
+			sources = a.Spec.GetSources()
+			refSources, err = argo.GetRefSources(ctx, sources, a.Spec.Project, s.db.GetRepository, []string{})
+			if err != nil {
+				return fmt.Errorf("failed to get ref sources: %w", err)
+			}
+		}
+		if pos < 0 || pos > int64(len(sources)) {
+			return errors.New("source position is out of range")
+		}
 
 		proj, err := argo.GetAppProject(ctx, a, applisters.NewAppProjectLister(s.projInformer.GetIndexer()), s.ns, s.settingsMgr, s.db)
 		if err != nil {
 			return fmt.Errorf("error getting app project: %w", err)
 		}
 
-		repo, err := s.db.GetRepository(ctx, a.Spec.GetSource().RepoURL, proj.Name)
-		if err != nil {
-			return fmt.Errorf("error getting repository: %w", err)
-		}
-
 		kustomizeSettings, err := s.settingsMgr.GetKustomizeSettings()
 		if err != nil {
 			return fmt.Errorf("error getting kustomize settings: %w", err)
 		}
 
-		req := &apiclient.ManifestRequest{
-			Repo:                            repo,
-			Revision:                        source.TargetRevision,
-			AppLabelKey:                     appInstanceLabelKey,
-			AppName:                         a.Name,
-			Namespace:                       a.Spec.Destination.Namespace,
-			ApplicationSource:               &source,
-			Repos:                           helmRepos,
-			KustomizeOptions:                kustomizeSettings,
-			KubeVersion:                     serverVersion,
-			ApiVersions:                     argo.APIResourcesToStrings(apiResources, true),
-			HelmRepoCreds:                   helmCreds,
-			HelmOptions:                     helmOptions,
-			TrackingMethod:                  trackingMethod,
-			EnabledSourceTypes:              enableGenerateManifests,
-			ProjectName:                     proj.Name,
-			ProjectSourceRepos:              proj.Spec.SourceRepos,
-			AnnotationManifestGeneratePaths: a.GetAnnotation(v1alpha1.AnnotationKeyManifestGeneratePaths),
-		}
-
-		repoStreamClient, err := client.GenerateManifestWithFiles(stream.Context())
-		if err != nil {
-			return fmt.Errorf("error opening stream: %w", err)
-		}
+		var otherManifests []string
+		for i, source := range sources {
+			source = proj.GetApplicationSource(source)
 
-		err = manifeststream.SendRepoStream(repoStreamClient, stream, req, *query.Checksum)
-		if err != nil {
-			return fmt.Errorf("error sending repo stream: %w", err)
-		}
+			repo, err := s.db.GetRepository(ctx, source.RepoURL, proj.Name)
+			if err != nil {
+				return fmt.Errorf("error getting repository: %w", err)
+			}
 
-		resp, err := repoStreamClient.CloseAndRecv()
-		if err != nil {
-			return fmt.Errorf("error generating manifests: %w", err)
-		}
+			repos := helmRepos
+			helmRepoCreds := helmCreds
+			// the dependencies of a Helm chart of an OCI source may be OCI charts as well
+			if source.IsOCI() {
+				repos = slices.Clone(helmRepos)
+				helmRepoCreds = slices.Clone(helmCreds)
+				repos = append(repos, ociRepos...)
+				helmRepoCreds = append(helmRepoCreds, ociCreds...)
+			}
+
+			req := &apiclient.ManifestRequest{
+				Repo:                            repo,
+				Revision:                        source.TargetRevision,
+				AppLabelKey:                     appInstanceLabelKey,
+				AppName:                         a.InstanceName(s.ns),
+				Namespace:                       a.Spec.Destination.Namespace,
+				ApplicationSource:               &source,
+				Repos:                           repos,
+				KustomizeOptions:                kustomizeSettings,
+				KubeVersion:                     serverVersion,
+				ApiVersions:                     argo.APIResourcesToStrings(apiResources, true),
+				HelmRepoCreds:                   helmRepoCreds,
+				HelmOptions:                     helmOptions,
+				TrackingMethod:                  trackingMethod,
+				EnabledSourceTypes:              enableGenerateManifests,
+				ProjectName:                     proj.Name,
+				ProjectSourceRepos:              proj.Spec.SourceRepos,
+				HasMultipleSources:              a.Spec.HasMultipleSources(),
+				RefSources:                      refSources,
+				AnnotationManifestGeneratePaths: a.GetAnnotation(v1alpha1.AnnotationKeyManifestGeneratePaths),
+				InstallationID:                  installationID,
+			}
+
+			if int64(i+1) != pos {
+				resp, err := client.GenerateManifest(ctx, req)
+				if err != nil {
+					return fmt.Errorf("error generating manifests of source %d: %w", i+1, err)
+				}
+				otherManifests = append(otherManifests, resp.Manifests...)
+				continue
+			}
+
+			repoStreamClient, err := client.GenerateManifestWithFiles(stream.Context())
+			if err != nil {
+				return fmt.Errorf("error opening stream: %w", err)
+			}
+
+			err = manifeststream.SendRepoStream(repoStreamClient, stream, req, *query.Checksum)
+			if err != nil {
+				return fmt.Errorf("error sending repo stream: %w", err)
+			}
+
+			resp, err := repoStreamClient.CloseAndRecv()
+			if err != nil {
+				return fmt.Errorf("error generating manifests: %w", err)
+			}
 
-		manifestInfo = resp
+			manifestInfo = resp
+		}
+		manifestInfo.Manifests = append(manifestInfo.Manifests, otherManifests...)
 		return nil
 	})
 	if err != nil {
@@ -813,7 +877,7 @@ func (s *Server) Get(ctx context.Context, q *application.ApplicationQuery) (*v1a
 			if err != nil {
 				return fmt.Errorf("error getting kustomize settings: %w", err)
 			}
-			trackingMethod, err := s.settingsMgr.GetTrackingMethod()
+			trackingMethod, err := argo.GetTrackingMethod(s.settingsMgr, proj)
 			if err != nil {
 				return fmt.Errorf("error getting trackingMethod from settings: %w", err)
 			}
@@ -1314,6 +1378,10 @@ func (s *Server) validateAndNormalizeApp(ctx context.Context, app *v1alpha1.Appl
 		}
 	}
 
+	if err := s.applySourceNamespaceRule(app, currApp, proj); err != nil {
+		return err
+	}
+
 	if _, err := argo.GetDestinationCluster(ctx, app.Spec.Destination, s.db); err != nil {
 		return status.Errorf(codes.InvalidArgument, "application destination spec for %s is invalid: %s", app.Name, err.Error())
 	}
@@ -1344,6 +1412,41 @@ func (s *Server) validateAndNormalizeApp(ctx context.Context, app *v1alpha1.Appl
 	return nil
 }
 
+// applySourceNamespaceRule applies the source namespace rule of the project matching the namespace of an application:
+// the application gets the default destination of the rule if it has no destination cluster, and it's rejected if it's
+// new to the project while the project already has the maximum number of applications of the rule in the namespace
+func (s *Server) applySourceNamespaceRule(app *v1alpha1.Application, currApp *v1alpha1.Application, proj *v1alpha1.AppProject) error {
+	appNs := s.appNamespaceOrDefault(app.Namespace)
+	rule := proj.GetSourceNamespaceRule(appNs)
+	if rule == nil {
+		return nil
+	}
+	if rule.DefaultDestination != nil && app.Spec.Destination.Server == "" && app.Spec.Destination.Name == "" {
+		namespace := app.Spec.Destination.Namespace
+		app.Spec.Destination = *rule.DefaultDestination.DeepCopy()
+		if namespace != "" {
+			app.Spec.Destination.Namespace = namespace
+		}
+	}
+	if rule.MaxApps <= 0 || (currApp != nil && currApp.Spec.GetProject() == app.Spec.GetProject()) {
+		return nil
+	}
+	apps, err := s.appLister.Applications(appNs).List(labels.Everything())
+	if err != nil {
+		return fmt.Errorf("error listing applications: %w", err)
+	}
+	count := 0
+	for _, a := range apps {
+		if a.Name != app.Name && a.Spec.GetProject() == proj.Name {
+			count++
+		}
+	}
+	if int64(count) >= rule.MaxApps {
+		return status.Errorf(codes.FailedPrecondition, "project %s already has the maximum number of %d applications in the namespace %s", proj.Name, rule.MaxApps, appNs)
+	}
+	return nil
+}
+
 func (s *Server) getApplicationClusterConfig(ctx context.Context, a *v1alpha1.Application) (*rest.Config, error) {
 	cluster, err := argo.GetDestinationCluster(ctx, a.Spec.Destination, s.db)
 	if err != nil {
@@ -1368,6 +1471,18 @@ func (s *Server) getCachedAppState(ctx context.Context, a *v1alpha1.Application,
 		if len(conditions) > 0 {
 			return errors.New(argo.FormatAppConditions(conditions))
 		}
+		// refresh the application once however many requests miss its state concurrently
+		done, err := s.cache.CoalesceAppStateRefresh(ctx, a.InstanceName(s.ns))
+		if err != nil {
+			return fmt.Errorf("error waiting for application refresh: %w", err)
+		}
+		if done == nil {
+			if err = getFromCache(); !errors.Is(err, servercache.ErrCacheMiss) {
+				return err
+			}
+		} else {
+			defer done()
+		}
 		_, err = s.Get(ctx, &application.ApplicationQuery{
 			Name:         ptr.To(a.GetName()),
 			AppNamespace: ptr.To(a.GetNamespace()),
@@ -1562,13 +1677,30 @@ func (s *Server) WatchResourceTree(q *application.ResourcesQuery, ws application
 	}
 
 	cacheKey := argo.AppInstanceName(q.GetApplicationName(), q.GetAppNamespace(), s.ns)
+	// with delta updates, the first message holds the full tree and the next ones only the changes since the previous
+	// message, which are numbered so that the clients can detect the messages they missed
+	var previous *v1alpha1.ApplicationTree
+	var version int64
 	return s.cache.OnAppResourcesTreeChanged(ws.Context(), cacheKey, func() error {
 		var tree v1alpha1.ApplicationTree
 		err := s.cache.GetAppResourcesTree(cacheKey, &tree)
 		if err != nil {
 			return fmt.Errorf("error getting app resource tree: %w", err)
 		}
-		return ws.Send(&tree)
+		if !q.GetDelta() {
+			return ws.Send(&tree)
+		}
+		toSend := &tree
+		if previous != nil {
+			toSend = tree.GetDelta(previous)
+			if toSend == nil {
+				return nil
+			}
+		}
+		version++
+		toSend.Version = version
+		previous = &tree
+		return ws.Send(toSend)
 	})
 }
 
@@ -1819,8 +1951,12 @@ func (s *Server) PodLogs(q *application.ApplicationPodLogsQuery, ws application.
 
 	// from the tree find pods which match query of kind, group, and resource name
 	pods := getSelectedPods(tree.Nodes, q)
+	var hookLogs []cachedHookLogs
 	if len(pods) == 0 {
-		return nil
+		// the pods of the completed hooks may have been deleted, their logs are then served from the cache
+		if hookLogs = s.getCachedHookLogs(a, q); len(hookLogs) == 0 {
+			return nil
+		}
 	}
 
 	maxPodLogsToRender, err := s.settingsMgr.GetMaxPodLogsToRender()
@@ -1828,12 +1964,21 @@ func (s *Server) PodLogs(q *application.ApplicationPodLogsQuery, ws application.
 		return fmt.Errorf("error getting MaxPodLogsToRender config: %w", err)
 	}
 
-	if int64(len(pods)) > maxPodLogsToRender {
+	if int64(len(pods)+len(hookLogs)) > maxPodLogsToRender {
 		return status.Error(codes.InvalidArgument, "max pods to view logs are reached. Please provide more granular query")
 	}
 
 	var streams []chan logEntry
 
+	for _, cached := range hookLogs {
+		logStream := make(chan logEntry)
+		streams = append(streams, logStream)
+		go func() {
+			parseLogsStream(cached.podName, io.NopCloser(strings.NewReader(cached.logs)), logStream)
+			close(logStream)
+		}()
+	}
+
 	for _, pod := range pods {
 		stream, err := kubeClientset.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &corev1.PodLogOptions{
 			Container:    q.GetContainer(),
@@ -1927,6 +2072,51 @@ func (s *Server) PodLogs(q *application.ApplicationPodLogsQuery, ws application.
 	}
 }
 
+// cachedHookLogs holds the logs of a container of a pod of a completed hook
+type cachedHookLogs struct {
+	podName string
+	logs    string
+}
+
+// getCachedHookLogs returns the logs of the pods of the completed hooks of the last operation of the application which
+// match the query, which are kept in the cache after the pods are deleted
+func (s *Server) getCachedHookLogs(a *v1alpha1.Application, q *application.ApplicationPodLogsQuery) []cachedHookLogs {
+	if a.Status.OperationState == nil || a.Status.OperationState.SyncResult == nil {
+		return nil
+	}
+	var res []cachedHookLogs
+	for _, hook := range a.Status.OperationState.SyncResult.Resources {
+		for _, output := range hook.HookOutputs {
+			if !isHookOutputSelected(hook, output, q) {
+				continue
+			}
+			var logs string
+			if err := s.cache.GetHookLogs(a.InstanceName(s.ns), hook.Namespace, output.Pod, output.Container, &logs); err != nil {
+				if !errors.Is(err, servercache.ErrCacheMiss) {
+					log.WithFields(applog.GetAppLogFields(a)).Warnf("Failed to get the logs of hook pod %s/%s: %v", hook.Namespace, output.Pod, err)
+				}
+				continue
+			}
+			res = append(res, cachedHookLogs{podName: output.Pod, logs: logs})
+		}
+	}
+	return res
+}
+
+// isHookOutputSelected returns whether a container of a pod of a hook matches the query, either by the hook or by the
+// name of the pod
+func isHookOutputSelected(hook *v1alpha1.ResourceResult, output v1alpha1.HookOutput, q *application.ApplicationPodLogsQuery) bool {
+	if (q.GetNamespace() != "" && hook.Namespace != q.GetNamespace()) || (q.GetContainer() != "" && output.Container != q.GetContainer()) {
+		return false
+	}
+	if q.GetKind() == kube.PodKind && q.GetGroup() == "" && output.Pod == q.GetResourceName() {
+		return true
+	}
+	return (q.GetResourceName() == "" || hook.Name == q.GetResourceName()) &&
+		(q.GetKind() == "" || hook.Kind == q.GetKind()) &&
+		(q.GetGroup() == "" || hook.Group == q.GetGroup())
+}
+
 // from all of the treeNodes, get the pod who meets the criteria or whose parents meets the criteria
 func getSelectedPods(treeNodes []v1alpha1.ResourceNode, q *application.ApplicationPodLogsQuery) []v1alpha1.ResourceNode {
 	var pods []v1alpha1.ResourceNode
@@ -1990,26 +2180,32 @@ func (s *Server) Sync(ctx context.Context, syncReq *application.ApplicationSyncR
 
 	s.inferResourcesStatusHealth(a)
 
-	canSync, err := proj.Spec.SyncWindows.Matches(a).CanSync(true)
+	breakGlassReason, err := s.breakGlassReason(ctx, a, proj)
 	if err != nil {
-		return a, status.Errorf(codes.PermissionDenied, "cannot sync: invalid sync window: %v", err)
-	}
-	if !canSync {
-		return a, status.Errorf(codes.PermissionDenied, "cannot sync: blocked by sync window")
+		return a, err
 	}
 
 	if err := s.enf.EnforceErr(ctx.Value("claims"), rbac.ResourceApplications, rbac.ActionSync, a.RBACName(s.ns)); err != nil {
 		return nil, err
 	}
 
+	// the applications inherit the default sync policy of their project
+	syncPolicy := proj.GetApplicationSyncPolicy(a.Spec.SyncPolicy)
 	if syncReq.Manifests != nil {
 		if err := s.enf.EnforceErr(ctx.Value("claims"), rbac.ResourceApplications, rbac.ActionOverride, a.RBACName(s.ns)); err != nil {
 			return nil, err
 		}
-		if a.Spec.SyncPolicy != nil && a.Spec.SyncPolicy.IsAutomatedSyncEnabled() && !syncReq.GetDryRun() {
+		if syncPolicy != nil && syncPolicy.IsAutomatedSyncEnabled() && !syncReq.GetDryRun() {
 			return nil, status.Error(codes.FailedPrecondition, "cannot use local sync when Automatic Sync Policy is enabled unless for dry run")
 		}
 	}
+	// the priority of the operation changes the order in which the pending operations are resumed, which only the users
+	// allowed to override the sync of the application can do
+	if syncReq.GetPriority() != 0 {
+		if err := s.enf.EnforceErr(ctx.Value("claims"), rbac.ResourceApplications, rbac.ActionOverride, a.RBACName(s.ns)); err != nil {
+			return nil, err
+		}
+	}
 	if a.DeletionTimestamp != nil {
 		return nil, status.Errorf(codes.FailedPrecondition, "application is deleting")
 	}
@@ -2021,9 +2217,9 @@ func (s *Server) Sync(ctx context.Context, syncReq *application.ApplicationSyncR
 
 	var retry *v1alpha1.RetryStrategy
 	var syncOptions v1alpha1.SyncOptions
-	if a.Spec.SyncPolicy != nil {
-		syncOptions = a.Spec.SyncPolicy.SyncOptions
-		retry = a.Spec.SyncPolicy.Retry
+	if syncPolicy != nil {
+		syncOptions = syncPolicy.SyncOptions
+		retry = syncPolicy.Retry
 	}
 	if syncReq.RetryStrategy != nil {
 		retry = syncReq.RetryStrategy
@@ -2049,6 +2245,13 @@ func (s *Server) Sync(ctx context.Context, syncReq *application.ApplicationSyncR
 			}
 		}
 	}
+	initiatedBy := &v1alpha1.OperationInitiator{Username: session.Username(ctx)}
+	if breakGlassReason != "" {
+		if initiatedBy, err = s.breakGlassSync(ctx, a, breakGlassReason, syncReq); err != nil {
+			return nil, err
+		}
+	}
+
 	op := v1alpha1.Operation{
 		Sync: &v1alpha1.SyncOperation{
 			Revision:     revision,
@@ -2061,8 +2264,9 @@ func (s *Server) Sync(ctx context.Context, syncReq *application.ApplicationSyncR
 			Sources:      a.Spec.Sources,
 			Revisions:    sourceRevisions,
 		},
-		InitiatedBy: v1alpha1.OperationInitiator{Username: session.Username(ctx)},
+		InitiatedBy: *initiatedBy,
 		Info:        syncReq.Infos,
+		Priority:    syncReq.GetPriority(),
 	}
 	if retry != nil {
 		op.Retry = *retry
@@ -2075,6 +2279,9 @@ func (s *Server) Sync(ctx context.Context, syncReq *application.ApplicationSyncR
 	if err != nil {
 		return nil, fmt.Errorf("error setting app operation: %w", err)
 	}
+	if initiatedBy.ApprovedBy != "" {
+		s.clearBreakGlassRequest(a)
+	}
 	partial := ""
 	if len(syncReq.Resources) > 0 {
 		partial = "partial "
@@ -2088,6 +2295,9 @@ func (s *Server) Sync(ctx context.Context, syncReq *application.ApplicationSyncR
 	if syncReq.Manifests != nil {
 		reason = fmt.Sprintf("initiated %ssync locally", partial)
 	}
+	if initiatedBy.ApprovedBy != "" {
+		reason = fmt.Sprintf("%s as a break-glass sync requested by %s (%s): %s", reason, initiatedBy.Username, breakGlassReason, initiatedBy.Justification)
+	}
 	s.logAppEvent(ctx, a, argo.EventReasonOperationStarted, reason)
 	return a, nil
 }
@@ -2134,7 +2344,7 @@ func (s *Server) resolveSourceRevisions(ctx context.Context, a *v1alpha1.Applica
 }
 
 func (s *Server) Rollback(ctx context.Context, rollbackReq *application.ApplicationRollbackRequest) (*v1alpha1.Application, error) {
-	a, _, err := s.getApplicationEnforceRBACClient(ctx, rbac.ActionSync, rollbackReq.GetProject(), rollbackReq.GetAppNamespace(), rollbackReq.GetName(), "")
+	a, proj, err := s.getApplicationEnforceRBACClient(ctx, rbac.ActionSync, rollbackReq.GetProject(), rollbackReq.GetAppNamespace(), rollbackReq.GetName(), "")
 	if err != nil {
 		return nil, err
 	}
@@ -2144,7 +2354,8 @@ func (s *Server) Rollback(ctx context.Context, rollbackReq *application.Applicat
 	if a.DeletionTimestamp != nil {
 		return nil, status.Errorf(codes.FailedPrecondition, "application is deleting")
 	}
-	if a.Spec.SyncPolicy != nil && a.Spec.SyncPolicy.IsAutomatedSyncEnabled() {
+	syncPolicy := proj.GetApplicationSyncPolicy(a.Spec.SyncPolicy)
+	if syncPolicy != nil && syncPolicy.IsAutomatedSyncEnabled() {
 		return nil, status.Errorf(codes.FailedPrecondition, "rollback cannot be initiated when auto-sync is enabled")
 	}
 
@@ -2166,8 +2377,8 @@ func (s *Server) Rollback(ctx context.Context, rollbackReq *application.Applicat
 	}
 
 	var syncOptions v1alpha1.SyncOptions
-	if a.Spec.SyncPolicy != nil {
-		syncOptions = a.Spec.SyncPolicy.SyncOptions
+	if syncPolicy != nil {
+		syncOptions = syncPolicy.SyncOptions
 	}
 
 	// Rollback is just a convenience around Sync
@@ -2309,6 +2520,10 @@ func getAmbiguousRevision(app *v1alpha1.Application, syncReq *application.Applic
 		}
 		if ambiguousRevision == "" {
 			ambiguousRevision = app.Spec.Sources[sourceIndex].TargetRevision
+			// The pinned sources are synced to the revision they are pinned to
+			if pins, err := app.GetPinnedRevisions(); err == nil && pins[sourceIndex+1] != "" {
+				ambiguousRevision = pins[sourceIndex+1]
+			}
 		}
 	} else {
 		ambiguousRevision = syncReq.GetRevision()
@@ -2326,6 +2541,12 @@ func (s *Server) resolveRevision(ctx context.Context, app *v1alpha1.Application,
 		return "", "", nil
 	}
 
+	source := app.Spec.GetSourcePtrByIndex(sourceIndex)
+	// The ref sources referencing the outputs of another application have no revision
+	if source.IsApplicationRef() {
+		return "", "", nil
+	}
+
 	ambiguousRevision := getAmbiguousRevision(app, syncReq, sourceIndex)
 
 	repoURL := app.Spec.GetSource().RepoURL
@@ -2343,7 +2564,6 @@ func (s *Server) resolveRevision(ctx context.Context, app *v1alpha1.Application,
 	}
 	defer utilio.Close(conn)
 
-	source := app.Spec.GetSourcePtrByIndex(sourceIndex)
 	if !source.IsHelm() {
 		if git.IsCommitSHA(ambiguousRevision) {
 			// If it's already a commit SHA, then no need to look it up
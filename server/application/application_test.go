@@ -565,10 +565,11 @@ func createTestApp(testApp string, opts ...func(app *v1alpha1.Application)) *v1a
 }
 
 type TestServerStream struct {
-	ctx        context.Context
-	appName    string
-	headerSent bool
-	project    string
+	ctx            context.Context
+	appName        string
+	headerSent     bool
+	project        string
+	sourcePosition int64
 }
 
 func (t *TestServerStream) SetHeader(metadata.MD) error {
@@ -602,9 +603,10 @@ func (t *TestServerStream) Recv() (*application.ApplicationManifestQueryWithFile
 		t.headerSent = true
 		return &application.ApplicationManifestQueryWithFilesWrapper{Part: &application.ApplicationManifestQueryWithFilesWrapper_Query{
 			Query: &application.ApplicationManifestQueryWithFiles{
-				Name:     ptr.To(t.appName),
-				Project:  ptr.To(t.project),
-				Checksum: ptr.To(""),
+				Name:           ptr.To(t.appName),
+				Project:        ptr.To(t.project),
+				Checksum:       ptr.To(""),
+				SourcePosition: ptr.To(t.sourcePosition),
 			},
 		}}, nil
 	}
@@ -646,10 +648,12 @@ func (t *TestResourceTreeServer) RecvMsg(_ any) error {
 }
 
 type TestPodLogsServer struct {
-	ctx context.Context
+	ctx     context.Context
+	entries []*application.LogEntry
 }
 
-func (t *TestPodLogsServer) Send(_ *application.LogEntry) error {
+func (t *TestPodLogsServer) Send(entry *application.LogEntry) error {
+	t.entries = append(t.entries, entry)
 	return nil
 }
 
@@ -1478,6 +1482,48 @@ func TestCreateApp(t *testing.T) {
 	assert.Equal(t, "default", app.Spec.Project)
 }
 
+func TestCreateAppWithSourceNamespaceRule(t *testing.T) {
+	proj := &v1alpha1.AppProject{
+		ObjectMeta: metav1.ObjectMeta{Name: "quota-proj", Namespace: testNamespace},
+		Spec: v1alpha1.AppProjectSpec{
+			SourceRepos:  []string{"*"},
+			Destinations: []v1alpha1.ApplicationDestination{{Server: "*", Namespace: "*"}},
+			SourceNamespaceRules: []v1alpha1.SourceNamespaceRule{{
+				Pattern:            testNamespace,
+				MaxApps:            1,
+				DefaultDestination: &v1alpha1.ApplicationDestination{Server: "https://cluster-api.example.com", Namespace: "team"},
+			}},
+		},
+	}
+
+	t.Run("DefaultDestination", func(t *testing.T) {
+		appServer := newTestAppServer(t, proj)
+		testApp := newTestApp(func(app *v1alpha1.Application) {
+			app.Spec.Project = proj.Name
+			app.Spec.Destination = v1alpha1.ApplicationDestination{Namespace: "my-ns"}
+		})
+		app, err := appServer.Create(t.Context(), &application.ApplicationCreateRequest{Application: testApp})
+		require.NoError(t, err)
+		assert.Equal(t, "https://cluster-api.example.com", app.Spec.Destination.Server)
+		assert.Equal(t, "my-ns", app.Spec.Destination.Namespace)
+	})
+
+	t.Run("MaxApps", func(t *testing.T) {
+		existingApp := newTestApp(func(app *v1alpha1.Application) {
+			app.Name = "existing-app"
+			app.Spec.Project = proj.Name
+		})
+		appServer := newTestAppServer(t, proj, existingApp)
+		testApp := newTestApp(func(app *v1alpha1.Application) {
+			app.Name = "another-app"
+			app.Spec.Project = proj.Name
+		})
+		_, err := appServer.Create(t.Context(), &application.ApplicationCreateRequest{Application: testApp})
+		require.Error(t, err)
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+}
+
 func TestCreateAppWithDestName(t *testing.T) {
 	appServer := newTestAppServer(t)
 	testApp := newTestAppWithDestName()
@@ -1812,6 +1858,27 @@ p, test-user, applications, update/fake.io/PodTest/*, default/test-app, deny
 	})
 }
 
+func TestSyncPriority(t *testing.T) {
+	//nolint:staticcheck
+	ctx := context.WithValue(t.Context(), "claims", &jwt.RegisteredClaims{Subject: "alice"})
+	f := func(enf *rbac.Enforcer) {
+		_ = enf.SetUserPolicy(`
+p, alice, applications, get, default/test-app, allow
+p, alice, applications, sync, default/test-app, allow
+`)
+	}
+	appServer := newTestAppServerWithEnforcerConfigure(t, f, map[string]string{}, newTestApp())
+
+	// setting the priority of the operation requires the override action
+	_, err := appServer.Sync(ctx, &application.ApplicationSyncRequest{Name: ptr.To("test-app"), Priority: ptr.To(int64(5))})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	app, err := appServer.Sync(ctx, &application.ApplicationSyncRequest{Name: ptr.To("test-app")})
+	require.NoError(t, err)
+	require.NotNil(t, app.Operation)
+	assert.Zero(t, app.Operation.Priority)
+}
+
 func TestSyncAndTerminate(t *testing.T) {
 	ctx := t.Context()
 	appServer := newTestAppServer(t)
@@ -1822,10 +1889,12 @@ func TestSyncAndTerminate(t *testing.T) {
 	}
 	app, err := appServer.Create(ctx, &createReq)
 	require.NoError(t, err)
-	app, err = appServer.Sync(ctx, &application.ApplicationSyncRequest{Name: &app.Name})
+	app, err = appServer.Sync(ctx, &application.ApplicationSyncRequest{Name: &app.Name, Priority: ptr.To(int64(5))})
 	require.NoError(t, err)
 	assert.NotNil(t, app)
-	assert.NotNil(t, app.Operation)
+	require.NotNil(t, app.Operation)
+	assert.Equal(t, int64(5), app.Operation.Priority)
+	assert.NotNil(t, app.Operation.RequestedAt)
 
 	events, err := appServer.kubeclientset.CoreV1().Events(appServer.ns).List(t.Context(), metav1.ListOptions{})
 	require.NoError(t, err)
@@ -1990,6 +2059,59 @@ p, admin, applications, update, my-proj/test-app, allow
 	})
 }
 
+func TestGetManifestsWithFiles_SourcePosition(t *testing.T) {
+	testApp := newTestApp(func(app *v1alpha1.Application) {
+		app.Spec.Source = nil
+		app.Spec.Sources = v1alpha1.ApplicationSources{
+			{RepoURL: fakeRepoURL, Path: "app", TargetRevision: "HEAD"},
+			{RepoURL: fakeRepoURL, TargetRevision: "HEAD", Ref: "values"},
+		}
+	})
+	appServer := newTestAppServer(t, testApp)
+	//nolint:staticcheck
+	ctx := context.WithValue(t.Context(), "claims", &jwt.RegisteredClaims{Subject: "admin"})
+
+	var req *apiclient.ManifestRequest
+	mockWithFilesClient := &mocks.RepoServerService_GenerateManifestWithFilesClient{}
+	mockWithFilesClient.On("Send", mock.MatchedBy(func(r *apiclient.ManifestRequestWithFiles) bool {
+		if r.GetRequest() != nil {
+			req = r.GetRequest()
+		}
+		return true
+	})).Return(nil)
+	mockWithFilesClient.On("CloseAndRecv").Return(&apiclient.ManifestResponse{Manifests: []string{`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"cm"}}`}}, nil)
+	var generated []*apiclient.ManifestRequest
+	mockRepoServiceClient := mocks.RepoServerServiceClient{}
+	mockRepoServiceClient.On("GenerateManifestWithFiles", mock.Anything, mock.Anything).Return(mockWithFilesClient, nil)
+	mockRepoServiceClient.On("GenerateManifest", mock.Anything, mock.MatchedBy(func(r *apiclient.ManifestRequest) bool {
+		generated = append(generated, r)
+		return true
+	})).Return(&apiclient.ManifestResponse{Manifests: []string{`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"cm"}}`}}, nil)
+	appServer.repoClientset = &mocks.Clientset{RepoServerServiceClient: &mockRepoServiceClient}
+
+	err := appServer.GetManifestsWithFiles(&TestServerStream{ctx: ctx, appName: testApp.Name})
+	require.NoError(t, err)
+	require.NotNil(t, req)
+	assert.Equal(t, "app", req.ApplicationSource.Path)
+	assert.True(t, req.HasMultipleSources)
+	assert.Contains(t, req.RefSources, "$values")
+	assert.Equal(t, testApp.InstanceName(appServer.ns), req.AppName)
+	// the manifests of the other source are generated from its repository
+	require.Len(t, generated, 1)
+	assert.Equal(t, "values", generated[0].ApplicationSource.Ref)
+
+	req, generated = nil, nil
+	err = appServer.GetManifestsWithFiles(&TestServerStream{ctx: ctx, appName: testApp.Name, sourcePosition: 2})
+	require.NoError(t, err)
+	require.NotNil(t, req)
+	assert.Equal(t, "values", req.ApplicationSource.Ref)
+	require.Len(t, generated, 1)
+	assert.Equal(t, "app", generated[0].ApplicationSource.Path)
+
+	err = appServer.GetManifestsWithFiles(&TestServerStream{ctx: ctx, appName: testApp.Name, sourcePosition: 3})
+	require.ErrorContains(t, err, "source position is out of range")
+}
+
 func TestAppJsonPatch(t *testing.T) {
 	testApp := newTestAppWithAnnotations()
 	ctx := t.Context()
@@ -2272,6 +2394,54 @@ func TestMaxPodLogsRender(t *testing.T) {
 	})
 }
 
+func TestPodLogsOfCompletedHooks(t *testing.T) {
+	testApp := newTestApp(func(app *v1alpha1.Application) {
+		app.Name = "test"
+		app.Status.OperationState = &v1alpha1.OperationState{SyncResult: &v1alpha1.SyncOperationResult{Resources: v1alpha1.ResourceResults{{
+			Group:       "batch",
+			Kind:        "Job",
+			Namespace:   "default",
+			Name:        "migrate",
+			HookType:    synccommon.HookTypePreSync,
+			HookOutputs: []v1alpha1.HookOutput{{Pod: "migrate-x7k2p", Container: "main", Reason: "Error", ExitCode: 1}},
+		}}}}
+	})
+	appServer := newTestAppServer(t, testApp)
+	err := appstate.NewCache(appServer.cache.GetCache(), time.Hour).SetHookLogs(testApp.InstanceName(appServer.ns), "default", "migrate-x7k2p", "main", "2025-01-01T00:00:00Z applying migration 12\n", time.Hour)
+	require.NoError(t, err)
+	//nolint:staticcheck
+	ctx := context.WithValue(t.Context(), "claims", &jwt.RegisteredClaims{Subject: "test-user"})
+
+	for name, q := range map[string]*application.ApplicationPodLogsQuery{
+		"by hook": {Name: ptr.To("test"), Group: ptr.To("batch"), Kind: ptr.To("Job"), ResourceName: ptr.To("migrate")},
+		"by pod":  {Name: ptr.To("test"), PodName: ptr.To("migrate-x7k2p")},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ws := &TestPodLogsServer{ctx: ctx}
+			require.NoError(t, appServer.PodLogs(q, ws))
+			require.Len(t, ws.entries, 2)
+			assert.Equal(t, "migrate-x7k2p", ws.entries[0].GetPodName())
+			assert.Equal(t, "applying migration 12", ws.entries[0].GetContent())
+			assert.True(t, ws.entries[1].GetLast())
+		})
+	}
+
+	t.Run("other container", func(t *testing.T) {
+		ws := &TestPodLogsServer{ctx: ctx}
+		require.NoError(t, appServer.PodLogs(&application.ApplicationPodLogsQuery{Name: ptr.To("test"), PodName: ptr.To("migrate-x7k2p"), Container: ptr.To("init")}, ws))
+		assert.Empty(t, ws.entries)
+	})
+
+	t.Run("logs permission is required", func(t *testing.T) {
+		appServer.enf.SetDefaultRole("")
+		_ = appServer.enf.SetBuiltinPolicy(`
+p, test-user, applications, get, default/test, allow
+`)
+		err := appServer.PodLogs(&application.ApplicationPodLogsQuery{Name: ptr.To("test"), PodName: ptr.To("migrate-x7k2p")}, &TestPodLogsServer{ctx: ctx})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+}
+
 // createAppServerWithMaxLodLogs creates a new app server with given number of pods and resources
 func createAppServerWithMaxLodLogs(t *testing.T, podNumber int, maxPodLogsToRender ...int64) (*Server, context.Context) {
 	t.Helper()
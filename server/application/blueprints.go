@@ -0,0 +1,120 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/argoproj/argo-cd/v3/applicationset/utils"
+	argocommon "github.com/argoproj/argo-cd/v3/common"
+	"github.com/argoproj/argo-cd/v3/pkg/apiclient/application"
+	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	"github.com/argoproj/argo-cd/v3/util/rbac"
+)
+
+// ListBlueprints returns the application blueprints of the catalog, optionally only the ones from which applications
+// can be created in the given project. Only the blueprints from which the user can create applications in at least one
+// of their projects are returned.
+func (s *Server) ListBlueprints(ctx context.Context, q *application.ApplicationBlueprintQuery) (*v1alpha1.ApplicationBlueprintList, error) {
+	blueprints, err := s.settingsMgr.GetApplicationBlueprints()
+	if err != nil {
+		return nil, fmt.Errorf("error getting application blueprints: %w", err)
+	}
+	list := &v1alpha1.ApplicationBlueprintList{Items: make([]v1alpha1.ApplicationBlueprint, 0)}
+	for _, blueprint := range blueprints {
+		if q.GetName() != "" && blueprint.Name != q.GetName() {
+			continue
+		}
+		projects := blueprint.Projects
+		if q.GetProject() != "" {
+			if !blueprint.IsProjectAllowed(q.GetProject()) {
+				continue
+			}
+			projects = []string{q.GetProject()}
+		}
+		if !slices.ContainsFunc(projects, func(project string) bool {
+			return s.enf.Enforce(ctx.Value("claims"), rbac.ResourceApplications, rbac.ActionCreate, project+"/*")
+		}) {
+			continue
+		}
+		list.Items = append(list.Items, blueprint)
+	}
+	return list, nil
+}
+
+// InstantiateBlueprint creates an application from an application blueprint. The spec of the blueprint is rendered
+// with the values of its parameters, and the application is created like by the Create API, so that the RBAC and the
+// restrictions of the project of the application are enforced.
+func (s *Server) InstantiateBlueprint(ctx context.Context, q *application.ApplicationInstantiateBlueprintRequest) (*v1alpha1.Application, error) {
+	blueprints, err := s.settingsMgr.GetApplicationBlueprints()
+	if err != nil {
+		return nil, fmt.Errorf("error getting application blueprints: %w", err)
+	}
+	i := slices.IndexFunc(blueprints, func(blueprint v1alpha1.ApplicationBlueprint) bool {
+		return blueprint.Name == q.GetBlueprint()
+	})
+	if i < 0 {
+		return nil, status.Errorf(codes.NotFound, "application blueprint %q not found", q.GetBlueprint())
+	}
+	blueprint := blueprints[i]
+	if q.GetProject() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "the project of the application is required")
+	}
+	if !blueprint.IsProjectAllowed(q.GetProject()) {
+		return nil, status.Errorf(codes.PermissionDenied, "applications cannot be created from blueprint %q in project %q", blueprint.Name, q.GetProject())
+	}
+
+	values, err := parseBlueprintParameters(q.GetParameters())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	resolved, err := blueprint.ResolveParameters(values)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	appNs := s.appNamespaceOrDefault(q.GetAppNamespace())
+	params := map[string]any{
+		"app.name":      q.GetName(),
+		"app.namespace": appNs,
+		"app.project":   q.GetProject(),
+	}
+	for name, value := range resolved {
+		params[name] = value
+	}
+	rendered, err := (&utils.Render{}).RenderTemplateParams(&v1alpha1.Application{Spec: *blueprint.Spec.DeepCopy()}, nil, params, false, nil)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "error rendering blueprint %q: %v", blueprint.Name, err)
+	}
+
+	a := &v1alpha1.Application{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        q.GetName(),
+			Namespace:   appNs,
+			Annotations: map[string]string{argocommon.AnnotationKeyAppBlueprint: blueprint.Name},
+		},
+		Spec: rendered.Spec,
+	}
+	// the project is not taken from the blueprint, so that it can't be changed by the values of the parameters
+	a.Spec.Project = q.GetProject()
+	return s.Create(ctx, &application.ApplicationCreateRequest{Application: a, Validate: ptr.To(true)})
+}
+
+// parseBlueprintParameters parses the values of the parameters of a blueprint in the form name=value
+func parseBlueprintParameters(parameters []string) (map[string]string, error) {
+	values := make(map[string]string)
+	for _, parameter := range parameters {
+		name, value, ok := strings.Cut(parameter, "=")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid parameter %q, expected name=value", parameter)
+		}
+		values[name] = value
+	}
+	return values, nil
+}
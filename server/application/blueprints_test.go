@@ -0,0 +1,158 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/utils/ptr"
+
+	"github.com/argoproj/argo-cd/v3/common"
+	"github.com/argoproj/argo-cd/v3/pkg/apiclient/application"
+	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	"github.com/argoproj/argo-cd/v3/util/assets"
+	"github.com/argoproj/argo-cd/v3/util/rbac"
+)
+
+const fakeBlueprints = `
+- name: web-service
+  description: A web service
+  projects: [default]
+  parameters:
+  - name: path
+    required: true
+    pattern: '[a-z/]+'
+  - name: revision
+    default: HEAD
+  spec:
+    project: my-proj
+    source:
+      repoURL: https://github.com/argoproj/argocd-example-apps.git
+      path: '{{path}}'
+      targetRevision: '{{revision}}'
+    destination:
+      server: https://cluster-api.example.com
+      namespace: '{{app.name}}'
+- name: job
+  projects: [my-*]
+  spec:
+    source:
+      repoURL: https://github.com/argoproj/argocd-example-apps.git
+      path: job
+    destination:
+      server: https://cluster-api.example.com
+      namespace: jobs
+`
+
+func newTestBlueprintAppServer(t *testing.T) *Server {
+	t.Helper()
+	f := func(enf *rbac.Enforcer) {
+		_ = enf.SetBuiltinPolicy(assets.BuiltinPolicyCSV)
+		enf.SetDefaultRole("role:admin")
+	}
+	return newTestAppServerWithEnforcerConfigure(t, f, map[string]string{"application.blueprints": fakeBlueprints})
+}
+
+func TestListBlueprints(t *testing.T) {
+	appServer := newTestBlueprintAppServer(t)
+
+	list, err := appServer.ListBlueprints(t.Context(), &application.ApplicationBlueprintQuery{})
+	require.NoError(t, err)
+	require.Len(t, list.Items, 2)
+	assert.Equal(t, "web-service", list.Items[0].Name)
+	assert.Equal(t, "job", list.Items[1].Name)
+
+	list, err = appServer.ListBlueprints(t.Context(), &application.ApplicationBlueprintQuery{Project: ptr.To("my-proj")})
+	require.NoError(t, err)
+	require.Len(t, list.Items, 1)
+	assert.Equal(t, "job", list.Items[0].Name)
+
+	list, err = appServer.ListBlueprints(t.Context(), &application.ApplicationBlueprintQuery{Name: ptr.To("web-service")})
+	require.NoError(t, err)
+	require.Len(t, list.Items, 1)
+	assert.Equal(t, "web-service", list.Items[0].Name)
+}
+
+func TestListBlueprintsRBAC(t *testing.T) {
+	f := func(enf *rbac.Enforcer) {
+		_ = enf.SetBuiltinPolicy(assets.BuiltinPolicyCSV)
+		enf.SetDefaultRole("")
+		_ = enf.SetUserPolicy(`
+p, alice, applications, create, default/*, allow
+p, alice, applications, get, my-proj/*, allow
+`)
+	}
+	appServer := newTestAppServerWithEnforcerConfigure(t, f, map[string]string{"application.blueprints": fakeBlueprints})
+	ctx := context.WithValue(t.Context(), "claims", &jwt.RegisteredClaims{Subject: "alice"})
+
+	// alice can't create applications in the projects of the job blueprint
+	list, err := appServer.ListBlueprints(ctx, &application.ApplicationBlueprintQuery{})
+	require.NoError(t, err)
+	require.Len(t, list.Items, 1)
+	assert.Equal(t, "web-service", list.Items[0].Name)
+
+	list, err = appServer.ListBlueprints(ctx, &application.ApplicationBlueprintQuery{Project: ptr.To("my-proj")})
+	require.NoError(t, err)
+	assert.Empty(t, list.Items)
+}
+
+func TestInstantiateBlueprint(t *testing.T) {
+	t.Run("instantiate", func(t *testing.T) {
+		appServer := newTestBlueprintAppServer(t)
+		app, err := appServer.InstantiateBlueprint(t.Context(), &application.ApplicationInstantiateBlueprintRequest{
+			Blueprint:  ptr.To("web-service"),
+			Name:       ptr.To("guestbook"),
+			Project:    ptr.To("default"),
+			Parameters: []string{"path=guestbook"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "guestbook", app.Name)
+		assert.Equal(t, "default", app.Spec.Project)
+		assert.Equal(t, "web-service", app.Annotations[common.AnnotationKeyAppBlueprint])
+		assert.Empty(t, app.Finalizers)
+		assert.Equal(t, &v1alpha1.ApplicationSource{
+			RepoURL:        "https://github.com/argoproj/argocd-example-apps.git",
+			Path:           "guestbook",
+			TargetRevision: "HEAD",
+		}, app.Spec.Source)
+		assert.Equal(t, "guestbook", app.Spec.Destination.Namespace)
+	})
+
+	t.Run("blueprint not found", func(t *testing.T) {
+		appServer := newTestBlueprintAppServer(t)
+		_, err := appServer.InstantiateBlueprint(t.Context(), &application.ApplicationInstantiateBlueprintRequest{
+			Blueprint: ptr.To("database"),
+			Name:      ptr.To("guestbook"),
+			Project:   ptr.To("default"),
+		})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("project not allowed", func(t *testing.T) {
+		appServer := newTestBlueprintAppServer(t)
+		_, err := appServer.InstantiateBlueprint(t.Context(), &application.ApplicationInstantiateBlueprintRequest{
+			Blueprint:  ptr.To("web-service"),
+			Name:       ptr.To("guestbook"),
+			Project:    ptr.To("my-proj"),
+			Parameters: []string{"path=guestbook"},
+		})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+
+	t.Run("invalid parameters", func(t *testing.T) {
+		appServer := newTestBlueprintAppServer(t)
+		for _, parameters := range [][]string{nil, {"path=Guestbook"}, {"path=guestbook", "replicas=3"}, {"path"}} {
+			_, err := appServer.InstantiateBlueprint(t.Context(), &application.ApplicationInstantiateBlueprintRequest{
+				Blueprint:  ptr.To("web-service"),
+				Name:       ptr.To("guestbook"),
+				Project:    ptr.To("default"),
+				Parameters: parameters,
+			})
+			assert.Equal(t, codes.InvalidArgument, status.Code(err), "parameters %v", parameters)
+		}
+	})
+}
@@ -0,0 +1,91 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/argoproj/argo-cd/v3/pkg/apiclient/application"
+	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	servercache "github.com/argoproj/argo-cd/v3/server/cache"
+	"github.com/argoproj/argo-cd/v3/util/argo"
+	"github.com/argoproj/argo-cd/v3/util/session"
+)
+
+// breakGlassRequestTTL is the duration a break-glass sync request can be approved within
+const breakGlassRequestTTL = time.Hour
+
+// breakGlassReason returns why a sync of the application is a break-glass sync, or an empty string if it isn't. An
+// error is returned if the sync is blocked by a deny sync window and the project doesn't allow break-glass syncs.
+func (s *Server) breakGlassReason(ctx context.Context, a *v1alpha1.Application, proj *v1alpha1.AppProject) (string, error) {
+	canSync, err := proj.Spec.SyncWindows.Matches(a).CanSync(true)
+	if err != nil {
+		return "", status.Errorf(codes.PermissionDenied, "cannot sync: invalid sync window: %v", err)
+	}
+	if !canSync {
+		if !proj.Spec.BreakGlassSync {
+			return "", status.Errorf(codes.PermissionDenied, "cannot sync: blocked by sync window")
+		}
+		return "blocked by sync window", nil
+	}
+	if len(proj.Spec.ProtectedClusters) == 0 {
+		return "", nil
+	}
+	destCluster, err := argo.GetDestinationCluster(ctx, a.Spec.Destination, s.db)
+	if err != nil {
+		return "", status.Errorf(codes.InvalidArgument, "error getting destination cluster: %v", err)
+	}
+	if proj.IsProtectedCluster(destCluster) {
+		return fmt.Sprintf("destination cluster %s is protected", destCluster.Server), nil
+	}
+	return "", nil
+}
+
+// breakGlassSync handles a break-glass sync of the application. Without the approval flag, the sync is recorded as
+// a pending request with its justification and an error is returned. With it, the pending request of another user
+// is approved and the initiator of the sync operation is returned. The pending requests are kept in the cache of the
+// API server, so that only the requests made through the API can be approved.
+func (s *Server) breakGlassSync(ctx context.Context, a *v1alpha1.Application, reason string, syncReq *application.ApplicationSyncRequest) (*v1alpha1.OperationInitiator, error) {
+	username := session.Username(ctx)
+	if !syncReq.GetApproveBreakGlass() {
+		if syncReq.GetJustification() == "" {
+			return nil, status.Errorf(codes.PermissionDenied, "cannot sync: %s, a break-glass sync with a justification is required", reason)
+		}
+		request := &servercache.BreakGlassRequest{AppUID: string(a.UID), RequestedBy: username, Justification: syncReq.GetJustification(), RequestedAt: metav1.Now()}
+		if err := s.cache.SetBreakGlassRequest(a.QualifiedName(), request, breakGlassRequestTTL); err != nil {
+			return nil, fmt.Errorf("error storing break-glass request of application %s: %w", a.QualifiedName(), err)
+		}
+		s.logAppEvent(ctx, a, argo.EventReasonBreakGlassRequested, fmt.Sprintf("requested break-glass sync (%s): %s", reason, request.Justification))
+		return nil, status.Errorf(codes.FailedPrecondition, "break-glass sync requested (%s), it must be approved by another user", reason)
+	}
+
+	request, err := s.cache.GetBreakGlassRequest(a.QualifiedName())
+	if errors.Is(err, servercache.ErrCacheMiss) {
+		return nil, status.Errorf(codes.FailedPrecondition, "cannot approve break-glass sync: no break-glass sync was requested or the request expired")
+	} else if err != nil {
+		return nil, fmt.Errorf("error getting break-glass request of application %s: %w", a.QualifiedName(), err)
+	}
+	if request.AppUID != string(a.UID) {
+		return nil, status.Errorf(codes.FailedPrecondition, "cannot approve break-glass sync: the request was made for an application which was deleted since")
+	}
+	if time.Since(request.RequestedAt.Time) > breakGlassRequestTTL {
+		return nil, status.Errorf(codes.FailedPrecondition, "cannot approve break-glass sync: the request of %s expired", request.RequestedBy)
+	}
+	if request.RequestedBy == username {
+		return nil, status.Errorf(codes.PermissionDenied, "cannot approve break-glass sync: it must be approved by a user other than the requester")
+	}
+	return &v1alpha1.OperationInitiator{Username: request.RequestedBy, Justification: request.Justification, ApprovedBy: username}, nil
+}
+
+// clearBreakGlassRequest removes the approved break-glass sync request of the application
+func (s *Server) clearBreakGlassRequest(a *v1alpha1.Application) {
+	if err := s.cache.SetBreakGlassRequest(a.QualifiedName(), nil, 0); err != nil {
+		log.Warnf("Failed to remove break-glass request of application %s: %v", a.QualifiedName(), err)
+	}
+}
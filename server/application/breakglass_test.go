@@ -0,0 +1,132 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/argoproj/argo-cd/v3/pkg/apiclient/application"
+	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	servercache "github.com/argoproj/argo-cd/v3/server/cache"
+)
+
+func newBreakGlassProject(spec func(spec *v1alpha1.AppProjectSpec)) *v1alpha1.AppProject {
+	proj := &v1alpha1.AppProject{
+		ObjectMeta: metav1.ObjectMeta{Name: "proj-break-glass", Namespace: "default"},
+		Spec: v1alpha1.AppProjectSpec{
+			SourceRepos:  []string{"*"},
+			Destinations: []v1alpha1.ApplicationDestination{{Server: "*", Namespace: "*"}},
+		},
+	}
+	spec(&proj.Spec)
+	return proj
+}
+
+func withUser(ctx context.Context, username string) context.Context {
+	//nolint:staticcheck
+	return context.WithValue(ctx, "claims", &jwt.RegisteredClaims{Subject: username})
+}
+
+func TestSync_BreakGlass(t *testing.T) {
+	denyWindow := &v1alpha1.SyncWindow{Kind: "deny", Schedule: "* * * * *", Duration: "1h", Applications: []string{"*"}}
+	testApp := newTestApp(func(app *v1alpha1.Application) {
+		app.Spec.Project = "proj-break-glass"
+	})
+
+	t.Run("blocked by sync window without break-glass syncs", func(t *testing.T) {
+		appServer := newTestAppServer(t, testApp, newBreakGlassProject(func(spec *v1alpha1.AppProjectSpec) {
+			spec.SyncWindows = v1alpha1.SyncWindows{denyWindow}
+		}))
+		_, err := appServer.Sync(withUser(t.Context(), "alice"), &application.ApplicationSyncRequest{Name: ptr.To(testApp.Name), Justification: ptr.To("hotfix")})
+		require.EqualError(t, err, "rpc error: code = PermissionDenied desc = cannot sync: blocked by sync window")
+	})
+
+	t.Run("requires a justification", func(t *testing.T) {
+		appServer := newTestAppServer(t, testApp, newBreakGlassProject(func(spec *v1alpha1.AppProjectSpec) {
+			spec.SyncWindows = v1alpha1.SyncWindows{denyWindow}
+			spec.BreakGlassSync = true
+		}))
+		_, err := appServer.Sync(withUser(t.Context(), "alice"), &application.ApplicationSyncRequest{Name: ptr.To(testApp.Name)})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+		require.ErrorContains(t, err, "cannot sync: blocked by sync window, a break-glass sync with a justification is required")
+	})
+
+	t.Run("requested and approved during deny window", func(t *testing.T) {
+		appServer := newTestAppServer(t, testApp, newBreakGlassProject(func(spec *v1alpha1.AppProjectSpec) {
+			spec.SyncWindows = v1alpha1.SyncWindows{denyWindow}
+			spec.BreakGlassSync = true
+		}))
+		syncReq := &application.ApplicationSyncRequest{Name: ptr.To(testApp.Name), Justification: ptr.To("hotfix INC-123")}
+		_, err := appServer.Sync(withUser(t.Context(), "alice"), syncReq)
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+		require.ErrorContains(t, err, "break-glass sync requested (blocked by sync window), it must be approved by another user")
+
+		app, err := appServer.appclientset.ArgoprojV1alpha1().Applications("default").Get(t.Context(), testApp.Name, metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.Nil(t, app.Operation)
+		request, err := appServer.cache.GetBreakGlassRequest(app.QualifiedName())
+		require.NoError(t, err)
+		assert.Equal(t, "alice", request.RequestedBy)
+
+		approveReq := &application.ApplicationSyncRequest{Name: ptr.To(testApp.Name), ApproveBreakGlass: ptr.To(true)}
+		_, err = appServer.Sync(withUser(t.Context(), "alice"), approveReq)
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+		require.ErrorContains(t, err, "it must be approved by a user other than the requester")
+
+		_, err = appServer.Sync(withUser(t.Context(), "bob"), approveReq)
+		require.NoError(t, err)
+		app, err = appServer.appclientset.ArgoprojV1alpha1().Applications("default").Get(t.Context(), testApp.Name, metav1.GetOptions{})
+		require.NoError(t, err)
+		require.NotNil(t, app.Operation)
+		assert.Equal(t, v1alpha1.OperationInitiator{Username: "alice", Justification: "hotfix INC-123", ApprovedBy: "bob"}, app.Operation.InitiatedBy)
+		_, err = appServer.cache.GetBreakGlassRequest(app.QualifiedName())
+		require.ErrorIs(t, err, servercache.ErrCacheMiss)
+	})
+
+	t.Run("cannot approve request of deleted application", func(t *testing.T) {
+		appServer := newTestAppServer(t, testApp, newBreakGlassProject(func(spec *v1alpha1.AppProjectSpec) {
+			spec.SyncWindows = v1alpha1.SyncWindows{denyWindow}
+			spec.BreakGlassSync = true
+		}))
+		request := &servercache.BreakGlassRequest{AppUID: "deleted-app-uid", RequestedBy: "alice", Justification: "hotfix", RequestedAt: metav1.Now()}
+		require.NoError(t, appServer.cache.SetBreakGlassRequest(testApp.QualifiedName(), request, time.Hour))
+		_, err := appServer.Sync(withUser(t.Context(), "bob"), &application.ApplicationSyncRequest{Name: ptr.To(testApp.Name), ApproveBreakGlass: ptr.To(true)})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+		require.ErrorContains(t, err, "the request was made for an application which was deleted since")
+	})
+
+	t.Run("cannot approve without request", func(t *testing.T) {
+		appServer := newTestAppServer(t, testApp, newBreakGlassProject(func(spec *v1alpha1.AppProjectSpec) {
+			spec.ProtectedClusters = []string{"https://cluster-api.example.com"}
+		}))
+		_, err := appServer.Sync(withUser(t.Context(), "bob"), &application.ApplicationSyncRequest{Name: ptr.To(testApp.Name), ApproveBreakGlass: ptr.To(true)})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+		require.ErrorContains(t, err, "no break-glass sync was requested")
+	})
+
+	t.Run("protected cluster", func(t *testing.T) {
+		appServer := newTestAppServer(t, testApp, newBreakGlassProject(func(spec *v1alpha1.AppProjectSpec) {
+			spec.ProtectedClusters = []string{"fake-cluster"}
+		}))
+		_, err := appServer.Sync(withUser(t.Context(), "alice"), &application.ApplicationSyncRequest{Name: ptr.To(testApp.Name), Justification: ptr.To("hotfix")})
+		require.ErrorContains(t, err, "break-glass sync requested (destination cluster https://cluster-api.example.com is protected)")
+	})
+
+	t.Run("unprotected cluster", func(t *testing.T) {
+		appServer := newTestAppServer(t, testApp, newBreakGlassProject(func(spec *v1alpha1.AppProjectSpec) {
+			spec.ProtectedClusters = []string{"https://prod-*"}
+		}))
+		app, err := appServer.Sync(withUser(t.Context(), "alice"), &application.ApplicationSyncRequest{Name: ptr.To(testApp.Name)})
+		require.NoError(t, err)
+		require.NotNil(t, app.Operation)
+		assert.Equal(t, v1alpha1.OperationInitiator{Username: "alice"}, app.Operation.InitiatedBy)
+	})
+}
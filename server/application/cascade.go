@@ -0,0 +1,81 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/argoproj/argo-cd/v3/pkg/apiclient/application"
+	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	"github.com/argoproj/argo-cd/v3/util/argo"
+	"github.com/argoproj/argo-cd/v3/util/rbac"
+	"github.com/argoproj/argo-cd/v3/util/session"
+)
+
+// Cascade starts an operation syncing or refreshing the child applications of an application, wave by wave, as
+// ordered by their cascade wave annotation. The progress of the children is reported in the operation state of the
+// parent application.
+func (s *Server) Cascade(ctx context.Context, q *application.ApplicationCascadeRequest) (*v1alpha1.Application, error) {
+	a, _, err := s.getApplicationEnforceRBACClient(ctx, rbac.ActionSync, q.GetProject(), q.GetAppNamespace(), q.GetName(), "")
+	if err != nil {
+		return nil, err
+	}
+	opType := v1alpha1.CascadeOperationTypeSync
+	if q.GetType() != "" {
+		opType = v1alpha1.CascadeOperationType(q.GetType())
+	}
+	childAction := rbac.ActionSync
+	switch opType {
+	case v1alpha1.CascadeOperationTypeSync:
+	case v1alpha1.CascadeOperationTypeRefresh:
+		childAction = rbac.ActionGet
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unknown cascade operation type %q, expected %s or %s", q.GetType(), v1alpha1.CascadeOperationTypeSync, v1alpha1.CascadeOperationTypeRefresh)
+	}
+	if a.DeletionTimestamp != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "application is deleting")
+	}
+	children := argo.GetChildApplications(a)
+	if len(children) == 0 {
+		return nil, status.Errorf(codes.FailedPrecondition, "application %s has no child applications", a.QualifiedName())
+	}
+	// the user must be allowed to sync or refresh every child application which exists, and the operation is only
+	// performed on these applications
+	var authorized []v1alpha1.CascadeChild
+	for _, c := range children {
+		child, err := s.appLister.Applications(c.Namespace).Get(c.Name)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, fmt.Errorf("error getting application %s/%s: %w", c.Namespace, c.Name, err)
+		}
+		if err := s.enf.EnforceErr(ctx.Value("claims"), rbac.ResourceApplications, childAction, child.RBACName(s.ns)); err != nil {
+			return nil, err
+		}
+		authorized = append(authorized, v1alpha1.CascadeChild{Namespace: c.Namespace, Name: c.Name})
+	}
+	if len(authorized) == 0 {
+		return nil, status.Errorf(codes.FailedPrecondition, "none of the child applications of application %s exists", a.QualifiedName())
+	}
+
+	op := v1alpha1.Operation{
+		Cascade: &v1alpha1.CascadeOperation{
+			Type:     opType,
+			Prune:    q.GetPrune(),
+			Children: authorized,
+		},
+		InitiatedBy: v1alpha1.OperationInitiator{Username: session.Username(ctx)},
+	}
+	appIf := s.appclientset.ArgoprojV1alpha1().Applications(a.Namespace)
+	a, err = argo.SetAppOperation(appIf, a.Name, &op)
+	if err != nil {
+		return nil, fmt.Errorf("error setting app operation: %w", err)
+	}
+	s.logAppEvent(ctx, a, argo.EventReasonOperationStarted, fmt.Sprintf("initiated cascade %s of %d child applications", strings.ToLower(string(opType)), len(authorized)))
+	return a, nil
+}
@@ -0,0 +1,95 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/utils/ptr"
+
+	"github.com/argoproj/argo-cd/v3/pkg/apiclient/application"
+	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	"github.com/argoproj/argo-cd/v3/util/rbac"
+)
+
+func newParentTestApp(children ...string) *v1alpha1.Application {
+	return newTestApp(func(app *v1alpha1.Application) {
+		for _, child := range children {
+			app.Status.Resources = append(app.Status.Resources, v1alpha1.ResourceStatus{
+				Group:     "argoproj.io",
+				Version:   "v1alpha1",
+				Kind:      "Application",
+				Namespace: app.Namespace,
+				Name:      child,
+			})
+		}
+	})
+}
+
+func TestCascade(t *testing.T) {
+	//nolint:staticcheck
+	ctx := context.WithValue(t.Context(), "claims", &jwt.RegisteredClaims{Subject: "alice"})
+	child := newTestApp(func(app *v1alpha1.Application) {
+		app.Name = "child-1"
+	})
+
+	t.Run("cascade sync", func(t *testing.T) {
+		appServer := newTestAppServer(t, newParentTestApp("child-1", "child-2"), child)
+		app, err := appServer.Cascade(ctx, &application.ApplicationCascadeRequest{Name: ptr.To("test-app"), Prune: ptr.To(true)})
+		require.NoError(t, err)
+		require.NotNil(t, app.Operation)
+		// the child application which doesn't exist isn't part of the operation
+		assert.Equal(t, &v1alpha1.CascadeOperation{
+			Type:     v1alpha1.CascadeOperationTypeSync,
+			Prune:    true,
+			Children: []v1alpha1.CascadeChild{{Namespace: child.Namespace, Name: "child-1"}},
+		}, app.Operation.Cascade)
+		assert.Nil(t, app.Operation.Sync)
+		assert.Equal(t, "alice", app.Operation.InitiatedBy.Username)
+	})
+
+	t.Run("cascade refresh", func(t *testing.T) {
+		appServer := newTestAppServer(t, newParentTestApp("child-1"), child)
+		app, err := appServer.Cascade(ctx, &application.ApplicationCascadeRequest{Name: ptr.To("test-app"), Type: ptr.To("Refresh")})
+		require.NoError(t, err)
+		require.NotNil(t, app.Operation)
+		assert.Equal(t, v1alpha1.CascadeOperationTypeRefresh, app.Operation.Cascade.Type)
+	})
+
+	t.Run("unknown operation type", func(t *testing.T) {
+		appServer := newTestAppServer(t, newParentTestApp("child-1"), child)
+		_, err := appServer.Cascade(ctx, &application.ApplicationCascadeRequest{Name: ptr.To("test-app"), Type: ptr.To("Delete")})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("no child applications", func(t *testing.T) {
+		appServer := newTestAppServer(t, newTestApp())
+		_, err := appServer.Cascade(ctx, &application.ApplicationCascadeRequest{Name: ptr.To("test-app")})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+
+	t.Run("no existing child applications", func(t *testing.T) {
+		appServer := newTestAppServer(t, newParentTestApp("child-2"))
+		_, err := appServer.Cascade(ctx, &application.ApplicationCascadeRequest{Name: ptr.To("test-app")})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+
+	t.Run("child application not allowed to be synced", func(t *testing.T) {
+		f := func(enf *rbac.Enforcer) {
+			_ = enf.SetUserPolicy(`
+p, alice, applications, *, default/test-app, allow
+p, alice, applications, get, default/child-1, allow
+`)
+		}
+		appServer := newTestAppServerWithEnforcerConfigure(t, f, map[string]string{}, newParentTestApp("child-1"), child)
+		_, err := appServer.Cascade(ctx, &application.ApplicationCascadeRequest{Name: ptr.To("test-app")})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+		_, err = appServer.Cascade(ctx, &application.ApplicationCascadeRequest{Name: ptr.To("test-app"), Type: ptr.To("Refresh")})
+		require.NoError(t, err)
+	})
+}
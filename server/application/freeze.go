@@ -0,0 +1,109 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/argoproj/argo-cd/v3/pkg/apiclient/application"
+	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	"github.com/argoproj/argo-cd/v3/util/argo"
+	"github.com/argoproj/argo-cd/v3/util/rbac"
+	"github.com/argoproj/argo-cd/v3/util/session"
+)
+
+// Freeze disables the automated sync and self heal of an application, and optionally its refresh, until the given time
+// or until the application is thawed. Freezing a frozen application replaces its freeze.
+func (s *Server) Freeze(ctx context.Context, q *application.ApplicationFreezeRequest) (*v1alpha1.Application, error) {
+	a, _, err := s.getApplicationEnforceRBACClient(ctx, rbac.ActionUpdate, q.GetProject(), q.GetAppNamespace(), q.GetName(), "")
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	freeze := &v1alpha1.ApplicationFreeze{
+		FrozenBy:  session.Username(ctx),
+		Reason:    q.GetReason(),
+		FrozenAt:  metav1.NewTime(now),
+		NoRefresh: q.GetNoRefresh(),
+	}
+	if q.GetUntil() != "" {
+		until, err := time.Parse(time.RFC3339, q.GetUntil())
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid time %q, expected RFC3339 format: %v", q.GetUntil(), err)
+		}
+		if !until.After(now) {
+			return nil, status.Errorf(codes.InvalidArgument, "the application cannot be frozen until %s, which is in the past", q.GetUntil())
+		}
+		freeze.Until = &metav1.Time{Time: until}
+	}
+	data, err := json.Marshal(freeze)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling freeze: %w", err)
+	}
+	updated, err := s.patchFreeze(ctx, a, string(data), nil)
+	if err != nil {
+		return nil, err
+	}
+	s.logAppEvent(ctx, a, argo.EventReasonFrozen, "froze application "+formatFreeze(freeze))
+	return updated, nil
+}
+
+// Thaw thaws a frozen application, and requests its refresh to catch up with the changes made while it was frozen
+func (s *Server) Thaw(ctx context.Context, q *application.ApplicationThawRequest) (*v1alpha1.Application, error) {
+	a, _, err := s.getApplicationEnforceRBACClient(ctx, rbac.ActionUpdate, q.GetProject(), q.GetAppNamespace(), q.GetName(), "")
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := a.GetAnnotations()[v1alpha1.AnnotationKeyFreeze]; !ok {
+		return nil, status.Errorf(codes.FailedPrecondition, "application %s is not frozen", a.QualifiedName())
+	}
+	updated, err := s.patchFreeze(ctx, a, nil, string(v1alpha1.RefreshTypeNormal))
+	if err != nil {
+		return nil, err
+	}
+	s.logAppEvent(ctx, a, argo.EventReasonThawed, "thawed application")
+	return updated, nil
+}
+
+// patchFreeze sets the freeze annotation of an application, nil removing it, along with the refresh annotation if
+// refresh isn't nil
+func (s *Server) patchFreeze(ctx context.Context, a *v1alpha1.Application, freeze, refresh any) (*v1alpha1.Application, error) {
+	annotations := map[string]any{v1alpha1.AnnotationKeyFreeze: freeze}
+	if refresh != nil {
+		annotations[v1alpha1.AnnotationKeyRefresh] = refresh
+	}
+	patch, err := json.Marshal(map[string]any{
+		"metadata": map[string]any{
+			"annotations": annotations,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling freeze patch: %w", err)
+	}
+	updated, err := s.appclientset.ArgoprojV1alpha1().Applications(a.Namespace).Patch(ctx, a.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error patching freeze of application %s: %w", a.QualifiedName(), err)
+	}
+	return updated, nil
+}
+
+// formatFreeze formats the freeze for the application events
+func formatFreeze(freeze *v1alpha1.ApplicationFreeze) string {
+	message := "until it is thawed"
+	if freeze.Until != nil {
+		message = "until " + freeze.Until.UTC().Format(time.RFC3339)
+	}
+	if freeze.NoRefresh {
+		message += ", without refresh"
+	}
+	if freeze.Reason != "" {
+		message += ": " + freeze.Reason
+	}
+	return message
+}
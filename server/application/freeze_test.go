@@ -0,0 +1,112 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/utils/ptr"
+
+	"github.com/argoproj/argo-cd/v3/pkg/apiclient/application"
+	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+)
+
+func newFrozenTestApp(name string, freeze string) *v1alpha1.Application {
+	return newTestApp(func(app *v1alpha1.Application) {
+		app.Name = name
+		if freeze != "" {
+			app.Annotations = map[string]string{v1alpha1.AnnotationKeyFreeze: freeze}
+		}
+	})
+}
+
+func TestFreeze(t *testing.T) {
+	//nolint:staticcheck
+	ctx := context.WithValue(t.Context(), "claims", &jwt.RegisteredClaims{Subject: "alice"})
+
+	t.Run("freeze until a given time", func(t *testing.T) {
+		appServer := newTestAppServer(t, newTestApp())
+		until := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+		app, err := appServer.Freeze(ctx, &application.ApplicationFreezeRequest{
+			Name:      ptr.To("test-app"),
+			Until:     ptr.To(until.Format(time.RFC3339)),
+			Reason:    ptr.To("incident 1234"),
+			NoRefresh: ptr.To(true),
+		})
+		require.NoError(t, err)
+		freeze, err := app.GetFreeze()
+		require.NoError(t, err)
+		require.NotNil(t, freeze)
+		assert.Equal(t, "alice", freeze.FrozenBy)
+		assert.Equal(t, "incident 1234", freeze.Reason)
+		assert.True(t, freeze.NoRefresh)
+		require.NotNil(t, freeze.Until)
+		assert.True(t, until.Equal(freeze.Until.Time))
+		assert.True(t, freeze.IsActive(time.Now()))
+	})
+
+	t.Run("freeze until thawed", func(t *testing.T) {
+		appServer := newTestAppServer(t, newTestApp())
+		app, err := appServer.Freeze(ctx, &application.ApplicationFreezeRequest{Name: ptr.To("test-app")})
+		require.NoError(t, err)
+		freeze, err := app.GetFreeze()
+		require.NoError(t, err)
+		require.NotNil(t, freeze)
+		assert.Nil(t, freeze.Until)
+		assert.False(t, freeze.NoRefresh)
+	})
+
+	t.Run("invalid time", func(t *testing.T) {
+		appServer := newTestAppServer(t, newTestApp())
+		_, err := appServer.Freeze(ctx, &application.ApplicationFreezeRequest{Name: ptr.To("test-app"), Until: ptr.To("tomorrow")})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		_, err = appServer.Freeze(ctx, &application.ApplicationFreezeRequest{Name: ptr.To("test-app"), Until: ptr.To("2020-01-01T00:00:00Z")})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		require.ErrorContains(t, err, "which is in the past")
+	})
+}
+
+func TestThaw(t *testing.T) {
+	t.Run("thaw a frozen application", func(t *testing.T) {
+		appServer := newTestAppServer(t, newFrozenTestApp("test-app", `{"frozenAt":"2026-01-01T00:00:00Z"}`))
+		app, err := appServer.Thaw(t.Context(), &application.ApplicationThawRequest{Name: ptr.To("test-app")})
+		require.NoError(t, err)
+		assert.NotContains(t, app.Annotations, v1alpha1.AnnotationKeyFreeze)
+		assert.Equal(t, string(v1alpha1.RefreshTypeNormal), app.Annotations[v1alpha1.AnnotationKeyRefresh])
+	})
+
+	t.Run("application not frozen", func(t *testing.T) {
+		appServer := newTestAppServer(t, newTestApp())
+		_, err := appServer.Thaw(t.Context(), &application.ApplicationThawRequest{Name: ptr.To("test-app")})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+}
+
+func TestListFrozenApps(t *testing.T) {
+	appServer := newTestAppServer(t,
+		newFrozenTestApp("frozen", `{"frozenAt":"2026-01-01T00:00:00Z"}`),
+		newFrozenTestApp("expired", `{"frozenAt":"2026-01-01T00:00:00Z","until":"2026-01-01T01:00:00Z"}`),
+		newFrozenTestApp("not-frozen", ""),
+	)
+	names := func(list *v1alpha1.ApplicationList) []string {
+		var result []string
+		for _, a := range list.Items {
+			result = append(result, a.Name)
+		}
+		return result
+	}
+
+	list, err := appServer.List(t.Context(), &application.ApplicationQuery{Frozen: ptr.To(true)})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"frozen"}, names(list))
+
+	list, err = appServer.List(t.Context(), &application.ApplicationQuery{Frozen: ptr.To(false)})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"expired", "not-frozen"}, names(list))
+}
@@ -0,0 +1,287 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/rest"
+	"k8s.io/utils/ptr"
+
+	"github.com/argoproj/argo-cd/v3/pkg/apiclient/application"
+	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	"github.com/argoproj/argo-cd/v3/util/argo"
+	"github.com/argoproj/argo-cd/v3/util/rbac"
+)
+
+const (
+	// OrphanedResourceOriginHelm is the origin of the orphaned resources installed by a Helm release
+	OrphanedResourceOriginHelm = "Helm"
+	// OrphanedResourceOriginKubectl is the origin of the orphaned resources created or applied with kubectl
+	OrphanedResourceOriginKubectl = "kubectl"
+	// OrphanedResourceOriginController is the origin of the orphaned resources managed by another controller
+	OrphanedResourceOriginController = "Controller"
+	// OrphanedResourceOriginUnknown is the origin of the orphaned resources which can't be inferred
+	OrphanedResourceOriginUnknown = "Unknown"
+
+	// OrphanedResourcesActionAdopt sets the tracking metadata of the application on orphaned resources
+	OrphanedResourcesActionAdopt = "adopt"
+	// OrphanedResourcesActionDelete deletes orphaned resources
+	OrphanedResourcesActionDelete = "delete"
+
+	helmReleaseNameAnnotation = "meta.helm.sh/release-name"
+	managedByLabel            = "app.kubernetes.io/managed-by"
+	instanceLabel             = "app.kubernetes.io/instance"
+)
+
+// ListOrphanedResources returns the orphaned resources in the destination namespace of an application, i.e. the top
+// level resources of the namespace which aren't managed by any application, along with the best guess of their origin
+// inferred from their Helm release metadata, last applied configuration and field managers
+func (s *Server) ListOrphanedResources(ctx context.Context, q *application.OrphanedResourcesQuery) (*application.OrphanedResourcesResponse, error) {
+	a, proj, err := s.getApplicationEnforceRBACInformer(ctx, rbac.ActionGet, q.GetProject(), q.GetAppNamespace(), q.GetName())
+	if err != nil {
+		return nil, err
+	}
+	nodes, config, err := s.getOrphanedNodes(ctx, a, proj)
+	if err != nil {
+		return nil, err
+	}
+	res := &application.OrphanedResourcesResponse{Items: make([]*application.OrphanedResource, 0, len(nodes))}
+	for _, node := range nodes {
+		obj, err := s.kubectl.GetResource(ctx, config, node.GroupKindVersion(), node.Name, node.Namespace)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, fmt.Errorf("error getting resource %s: %w", node.ResourceRef.String(), err)
+		}
+		res.Items = append(res.Items, newOrphanedResource(node, obj))
+	}
+	return res, nil
+}
+
+// RunOrphanedResourcesAction adopts or deletes orphaned resources of an application. Adopting a resource sets the
+// tracking metadata of the application on it, so that the application manages it. The resources are either listed
+// explicitly or all the orphaned resources when requested, and the user must be allowed to update, respectively delete,
+// each of them. The action is performed on all the requested resources even if it fails for some of them, and the
+// failures are reported in the results.
+func (s *Server) RunOrphanedResourcesAction(ctx context.Context, q *application.OrphanedResourcesActionRequest) (*application.OrphanedResourcesActionResponse, error) {
+	var action string
+	switch q.GetAction() {
+	case OrphanedResourcesActionAdopt:
+		action = rbac.ActionUpdate
+	case OrphanedResourcesActionDelete:
+		action = rbac.ActionDelete
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unknown orphaned resources action %q, expected %s or %s", q.GetAction(), OrphanedResourcesActionAdopt, OrphanedResourcesActionDelete)
+	}
+	switch {
+	case q.GetAll() && len(q.Resources) > 0:
+		return nil, status.Errorf(codes.InvalidArgument, "the resources can't be specified when the action is performed on all the orphaned resources")
+	case !q.GetAll() && len(q.Resources) == 0:
+		return nil, status.Errorf(codes.InvalidArgument, "either the resources or all the orphaned resources must be specified")
+	}
+	a, proj, err := s.getApplicationEnforceRBACInformer(ctx, rbac.ActionGet, q.GetProject(), q.GetAppNamespace(), q.GetName())
+	if err != nil {
+		return nil, err
+	}
+	nodes, config, err := s.getOrphanedNodes(ctx, a, proj)
+	if err != nil {
+		return nil, err
+	}
+
+	targets := nodes
+	if !q.GetAll() {
+		targets = make([]v1alpha1.ResourceNode, 0, len(q.Resources))
+		for _, r := range q.Resources {
+			node := findOrphanedNode(nodes, r)
+			if node == nil {
+				return nil, status.Errorf(codes.InvalidArgument, "%s %s %s/%s is not an orphaned resource of application %s", r.GetGroup(), r.GetKind(), r.GetNamespace(), r.GetName(), a.QualifiedName())
+			}
+			targets = append(targets, *node)
+		}
+	}
+	// the action isn't performed on any resource unless the user is allowed to perform it on all of them
+	for _, node := range targets {
+		if err := s.enforceResourceAction(ctx, a, action, node); err != nil {
+			return nil, err
+		}
+	}
+
+	res := &application.OrphanedResourcesActionResponse{Results: make([]*application.OrphanedResourceActionResult, 0, len(targets))}
+	for _, node := range targets {
+		var err error
+		if q.GetAction() == OrphanedResourcesActionAdopt {
+			err = s.adoptOrphanedResource(ctx, config, a, proj, node)
+		} else {
+			propagationPolicy := metav1.DeletePropagationForeground
+			err = s.kubectl.DeleteResource(ctx, config, node.GroupKindVersion(), node.Name, node.Namespace, metav1.DeleteOptions{PropagationPolicy: &propagationPolicy})
+			if err == nil {
+				s.logAppEvent(ctx, a, argo.EventReasonResourceDeleted, fmt.Sprintf("deleted orphaned resource %s/%s '%s'", node.Group, node.Kind, node.Name))
+			}
+		}
+		result := &application.OrphanedResourceActionResult{Resource: newOrphanedResource(node, nil)}
+		if err != nil {
+			result.Error = ptr.To(err.Error())
+		}
+		res.Results = append(res.Results, result)
+	}
+	return res, nil
+}
+
+// getOrphanedNodes returns the orphaned resources of an application, which are only available when the orphaned
+// resources monitoring is enabled in its project, along with the config of its destination cluster
+func (s *Server) getOrphanedNodes(ctx context.Context, a *v1alpha1.Application, proj *v1alpha1.AppProject) ([]v1alpha1.ResourceNode, *rest.Config, error) {
+	if proj.Spec.OrphanedResources == nil {
+		return nil, nil, status.Errorf(codes.FailedPrecondition, "orphaned resources monitoring is not enabled in project %s", proj.Name)
+	}
+	tree, err := s.getAppResources(ctx, a)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error getting app resources: %w", err)
+	}
+	nodes := make([]v1alpha1.ResourceNode, 0, len(tree.OrphanedNodes))
+	for _, node := range tree.OrphanedNodes {
+		// the children of the orphaned resources are part of the orphaned nodes but go along with their parents
+		if len(node.ParentRefs) == 0 {
+			nodes = append(nodes, node)
+		}
+	}
+	config, err := s.getApplicationClusterConfig(ctx, a)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error getting application cluster config: %w", err)
+	}
+	return nodes, config, nil
+}
+
+// adoptOrphanedResource sets the tracking metadata of an application on an orphaned resource
+func (s *Server) adoptOrphanedResource(ctx context.Context, config *rest.Config, a *v1alpha1.Application, proj *v1alpha1.AppProject, node v1alpha1.ResourceNode) error {
+	appLabelKey, err := s.settingsMgr.GetAppInstanceLabelKey()
+	if err != nil {
+		return fmt.Errorf("error getting app instance label key: %w", err)
+	}
+	trackingMethod, err := argo.GetTrackingMethod(s.settingsMgr, proj)
+	if err != nil {
+		return fmt.Errorf("error getting tracking method: %w", err)
+	}
+	installationID, err := s.settingsMgr.GetInstallationID()
+	if err != nil {
+		return fmt.Errorf("error getting installation ID: %w", err)
+	}
+	live, err := s.kubectl.GetResource(ctx, config, node.GroupKindVersion(), node.Name, node.Namespace)
+	if err != nil {
+		return fmt.Errorf("error getting resource: %w", err)
+	}
+	adopted := live.DeepCopy()
+	err = argo.NewResourceTracking().SetAppInstance(adopted, appLabelKey, a.InstanceName(s.ns), a.Spec.Destination.Namespace, v1alpha1.TrackingMethod(trackingMethod), installationID)
+	if err != nil {
+		return fmt.Errorf("error setting app instance: %w", err)
+	}
+	liveBytes, err := json.Marshal(live)
+	if err != nil {
+		return fmt.Errorf("error marshaling live object: %w", err)
+	}
+	adoptedBytes, err := json.Marshal(adopted)
+	if err != nil {
+		return fmt.Errorf("error marshaling adopted object: %w", err)
+	}
+	if _, err = s.patchResource(ctx, config, liveBytes, adoptedBytes, adopted); err != nil {
+		return err
+	}
+	s.logAppEvent(ctx, a, argo.EventReasonResourceUpdated, fmt.Sprintf("adopted orphaned resource %s/%s '%s'", node.Group, node.Kind, node.Name))
+	return nil
+}
+
+// enforceResourceAction checks that the user is allowed to update or delete a resource of an application, like
+// getAppLiveResource: the action on the application allows it unless the fine-grained RBAC inheritance is disabled,
+// and the action on the resource allows it otherwise
+func (s *Server) enforceResourceAction(ctx context.Context, a *v1alpha1.Application, action string, node v1alpha1.ResourceNode) error {
+	fineGrainedInheritanceDisabled, err := s.settingsMgr.ApplicationFineGrainedRBACInheritanceDisabled()
+	if err != nil {
+		return err
+	}
+	claims := ctx.Value("claims")
+	if !fineGrainedInheritanceDisabled && s.enf.Enforce(claims, rbac.ResourceApplications, action, a.RBACName(s.ns)) {
+		return nil
+	}
+	resourceAction := fmt.Sprintf("%s/%s/%s/%s/%s", action, node.Group, node.Kind, node.Namespace, node.Name)
+	return s.enf.EnforceErr(claims, rbac.ResourceApplications, resourceAction, a.RBACName(s.ns))
+}
+
+// findOrphanedNode returns the orphaned node with the group, kind, namespace and name of a resource, or nil
+func findOrphanedNode(nodes []v1alpha1.ResourceNode, r *application.OrphanedResource) *v1alpha1.ResourceNode {
+	for i := range nodes {
+		n := &nodes[i]
+		if n.Group == r.GetGroup() && n.Kind == r.GetKind() && n.Namespace == r.GetNamespace() && n.Name == r.GetName() {
+			return n
+		}
+	}
+	return nil
+}
+
+// newOrphanedResource returns the orphaned resource of a node, with its origin inferred from its live object if any
+func newOrphanedResource(node v1alpha1.ResourceNode, obj *unstructured.Unstructured) *application.OrphanedResource {
+	res := &application.OrphanedResource{
+		Group:     ptr.To(node.Group),
+		Version:   ptr.To(node.Version),
+		Kind:      ptr.To(node.Kind),
+		Namespace: ptr.To(node.Namespace),
+		Name:      ptr.To(node.Name),
+	}
+	if obj != nil {
+		origin, originName, managers := inferOrphanedResourceOrigin(obj)
+		res.Origin = ptr.To(origin)
+		res.OriginName = ptr.To(originName)
+		res.Managers = managers
+	}
+	return res
+}
+
+// inferOrphanedResourceOrigin returns the best guess of the origin of an orphaned resource and the name of the origin,
+// i.e. its Helm release or managing controller, along with the field managers of the resource. The Helm release
+// metadata takes precedence over the last applied configuration of kubectl, itself taking precedence over the field
+// managers, among which the first one, usually the creator of the resource, is the managing controller.
+func inferOrphanedResourceOrigin(obj *unstructured.Unstructured) (string, string, []string) {
+	var managers []string
+	seen := map[string]bool{}
+	for _, f := range obj.GetManagedFields() {
+		if f.Manager != "" && !seen[f.Manager] {
+			seen[f.Manager] = true
+			managers = append(managers, f.Manager)
+		}
+	}
+
+	annotations := obj.GetAnnotations()
+	labels := obj.GetLabels()
+	if release := annotations[helmReleaseNameAnnotation]; release != "" {
+		return OrphanedResourceOriginHelm, release, managers
+	}
+	if strings.EqualFold(labels[managedByLabel], "helm") {
+		release := labels[instanceLabel]
+		if release == "" {
+			release = labels["release"]
+		}
+		return OrphanedResourceOriginHelm, release, managers
+	}
+	if _, ok := annotations[corev1.LastAppliedConfigAnnotation]; ok {
+		return OrphanedResourceOriginKubectl, "", managers
+	}
+	for _, m := range managers {
+		switch {
+		case m == "helm":
+			return OrphanedResourceOriginHelm, "", managers
+		case strings.HasPrefix(m, "kubectl"):
+			return OrphanedResourceOriginKubectl, "", managers
+		}
+	}
+	if len(managers) > 0 {
+		return OrphanedResourceOriginController, managers[0], managers
+	}
+	return OrphanedResourceOriginUnknown, "", managers
+}
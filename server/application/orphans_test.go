@@ -0,0 +1,257 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/argoproj/gitops-engine/pkg/utils/kube/kubetest"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+	"k8s.io/utils/ptr"
+
+	"github.com/argoproj/argo-cd/v3/pkg/apiclient/application"
+	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	servercache "github.com/argoproj/argo-cd/v3/server/cache"
+	"github.com/argoproj/argo-cd/v3/util/cache"
+	"github.com/argoproj/argo-cd/v3/util/cache/appstate"
+)
+
+func TestInferOrphanedResourceOrigin(t *testing.T) {
+	newObj := func(labels, annotations map[string]string, managers ...string) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{}
+		obj.SetLabels(labels)
+		obj.SetAnnotations(annotations)
+		var managedFields []metav1.ManagedFieldsEntry
+		for _, m := range managers {
+			managedFields = append(managedFields, metav1.ManagedFieldsEntry{Manager: m})
+		}
+		obj.SetManagedFields(managedFields)
+		return obj
+	}
+	testCases := []struct {
+		name       string
+		obj        *unstructured.Unstructured
+		origin     string
+		originName string
+		managers   []string
+	}{
+		{
+			name:       "Helm release annotation",
+			obj:        newObj(map[string]string{"app.kubernetes.io/managed-by": "Helm"}, map[string]string{"meta.helm.sh/release-name": "my-release"}, "helm", "kube-controller-manager"),
+			origin:     OrphanedResourceOriginHelm,
+			originName: "my-release",
+			managers:   []string{"helm", "kube-controller-manager"},
+		},
+		{
+			name:       "Helm labels",
+			obj:        newObj(map[string]string{"app.kubernetes.io/managed-by": "Helm", "app.kubernetes.io/instance": "my-release"}, nil),
+			origin:     OrphanedResourceOriginHelm,
+			originName: "my-release",
+		},
+		{
+			name:     "last applied configuration",
+			obj:      newObj(nil, map[string]string{corev1.LastAppliedConfigAnnotation: "{}"}, "kubectl-client-side-apply"),
+			origin:   OrphanedResourceOriginKubectl,
+			managers: []string{"kubectl-client-side-apply"},
+		},
+		{
+			name:     "kubectl field manager",
+			obj:      newObj(nil, nil, "kubectl-create", "kubectl-create"),
+			origin:   OrphanedResourceOriginKubectl,
+			managers: []string{"kubectl-create"},
+		},
+		{
+			name:       "controller field manager",
+			obj:        newObj(nil, nil, "cert-manager", "kube-controller-manager"),
+			origin:     OrphanedResourceOriginController,
+			originName: "cert-manager",
+			managers:   []string{"cert-manager", "kube-controller-manager"},
+		},
+		{
+			name:   "unknown",
+			obj:    newObj(nil, nil),
+			origin: OrphanedResourceOriginUnknown,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			origin, originName, managers := inferOrphanedResourceOrigin(tc.obj)
+			assert.Equal(t, tc.origin, origin)
+			assert.Equal(t, tc.originName, originName)
+			assert.Equal(t, tc.managers, managers)
+		})
+	}
+}
+
+// recordingDeleteKubectl is a mock kubectl recording the names of the deleted resources
+type recordingDeleteKubectl struct {
+	*kubetest.MockKubectlCmd
+	deleted []string
+}
+
+func (k *recordingDeleteKubectl) DeleteResource(ctx context.Context, config *rest.Config, gvk schema.GroupVersionKind, name string, namespace string, deleteOptions metav1.DeleteOptions) error {
+	k.deleted = append(k.deleted, name)
+	return k.MockKubectlCmd.DeleteResource(ctx, config, gvk, name, namespace, deleteOptions)
+}
+
+func TestOrphanedResources(t *testing.T) {
+	//nolint:staticcheck
+	ctx := context.WithValue(t.Context(), "claims", &jwt.RegisteredClaims{Subject: "admin"})
+	configMap := func(name string) v1alpha1.ResourceNode {
+		return v1alpha1.ResourceNode{ResourceRef: v1alpha1.ResourceRef{Version: "v1", Kind: "ConfigMap", Namespace: "default", Name: name}}
+	}
+	pod := v1alpha1.ResourceNode{
+		ResourceRef: v1alpha1.ResourceRef{Version: "v1", Kind: "Pod", Namespace: "default", Name: "pod"},
+		ParentRefs:  []v1alpha1.ResourceRef{{Kind: "ReplicaSet", Namespace: "default", Name: "rs"}},
+	}
+	newServer := func(t *testing.T, orphanedResources *v1alpha1.OrphanedResourcesMonitorSettings, kubectl *kubetest.MockKubectlCmd) *Server {
+		t.Helper()
+		proj := &v1alpha1.AppProject{
+			ObjectMeta: metav1.ObjectMeta{Name: "orphans", Namespace: testNamespace},
+			Spec: v1alpha1.AppProjectSpec{
+				SourceRepos:       []string{"*"},
+				Destinations:      []v1alpha1.ApplicationDestination{{Server: "*", Namespace: "*"}},
+				OrphanedResources: orphanedResources,
+			},
+		}
+		appServer := newTestAppServer(t, proj, newTestApp(func(app *v1alpha1.Application) {
+			app.Spec.Project = proj.Name
+		}))
+		appStateCache := appstate.NewCache(cache.NewCache(cache.NewInMemoryCache(time.Hour)), time.Hour)
+		require.NoError(t, appStateCache.SetAppResourcesTree("test-app", &v1alpha1.ApplicationTree{
+			OrphanedNodes: []v1alpha1.ResourceNode{configMap("helm-cm"), configMap("other-cm"), pod},
+		}))
+		appServer.cache = servercache.NewCache(appStateCache, time.Hour, time.Hour)
+		appServer.kubectl = kubectl
+		return appServer
+	}
+	kubectl := (&kubetest.MockKubectlCmd{}).WithGetResourceFunc(func(_ context.Context, _ *rest.Config, gvk schema.GroupVersionKind, name string, namespace string) (*unstructured.Unstructured, error) {
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(gvk)
+		obj.SetName(name)
+		obj.SetNamespace(namespace)
+		if name == "helm-cm" {
+			obj.SetAnnotations(map[string]string{"meta.helm.sh/release-name": "my-release"})
+		}
+		return obj, nil
+	})
+
+	t.Run("monitoring disabled", func(t *testing.T) {
+		appServer := newServer(t, nil, kubectl)
+		_, err := appServer.ListOrphanedResources(ctx, &application.OrphanedResourcesQuery{Name: ptr.To("test-app")})
+		require.Error(t, err)
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+
+	t.Run("list", func(t *testing.T) {
+		appServer := newServer(t, &v1alpha1.OrphanedResourcesMonitorSettings{}, kubectl)
+		res, err := appServer.ListOrphanedResources(ctx, &application.OrphanedResourcesQuery{Name: ptr.To("test-app")})
+		require.NoError(t, err)
+		require.Len(t, res.Items, 2)
+		assert.Equal(t, "helm-cm", res.Items[0].GetName())
+		assert.Equal(t, OrphanedResourceOriginHelm, res.Items[0].GetOrigin())
+		assert.Equal(t, "my-release", res.Items[0].GetOriginName())
+		assert.Equal(t, "other-cm", res.Items[1].GetName())
+		assert.Equal(t, OrphanedResourceOriginUnknown, res.Items[1].GetOrigin())
+	})
+
+	t.Run("unknown action", func(t *testing.T) {
+		appServer := newServer(t, &v1alpha1.OrphanedResourcesMonitorSettings{}, kubectl)
+		_, err := appServer.RunOrphanedResourcesAction(ctx, &application.OrphanedResourcesActionRequest{Name: ptr.To("test-app"), Action: ptr.To("move")})
+		require.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("no resources", func(t *testing.T) {
+		appServer := newServer(t, &v1alpha1.OrphanedResourcesMonitorSettings{}, kubectl)
+		_, err := appServer.RunOrphanedResourcesAction(ctx, &application.OrphanedResourcesActionRequest{Name: ptr.To("test-app"), Action: ptr.To(OrphanedResourcesActionDelete)})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		_, err = appServer.RunOrphanedResourcesAction(ctx, &application.OrphanedResourcesActionRequest{
+			Name:      ptr.To("test-app"),
+			Action:    ptr.To(OrphanedResourcesActionDelete),
+			All:       ptr.To(true),
+			Resources: []*application.OrphanedResource{{Version: ptr.To("v1"), Kind: ptr.To("ConfigMap"), Namespace: ptr.To("default"), Name: ptr.To("other-cm")}},
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("resource not allowed to be deleted", func(t *testing.T) {
+		deleteKubectl := &recordingDeleteKubectl{MockKubectlCmd: &kubetest.MockKubectlCmd{}}
+		appServer := newServer(t, &v1alpha1.OrphanedResourcesMonitorSettings{}, deleteKubectl.MockKubectlCmd)
+		appServer.kubectl = deleteKubectl
+		appServer.enf.SetDefaultRole("")
+		require.NoError(t, appServer.enf.SetUserPolicy(`
+p, alice, applications, get, orphans/test-app, allow
+p, alice, applications, delete/*/ConfigMap/default/helm-cm, orphans/test-app, allow
+`))
+		//nolint:staticcheck
+		aliceCtx := context.WithValue(t.Context(), "claims", &jwt.RegisteredClaims{Subject: "alice"})
+		request := func(all bool, names ...string) *application.OrphanedResourcesActionRequest {
+			q := &application.OrphanedResourcesActionRequest{Name: ptr.To("test-app"), Action: ptr.To(OrphanedResourcesActionDelete), All: ptr.To(all)}
+			for _, name := range names {
+				q.Resources = append(q.Resources, &application.OrphanedResource{Version: ptr.To("v1"), Kind: ptr.To("ConfigMap"), Namespace: ptr.To("default"), Name: ptr.To(name)})
+			}
+			return q
+		}
+
+		// none of the resources is deleted unless the user is allowed to delete all of them
+		_, err := appServer.RunOrphanedResourcesAction(aliceCtx, request(true))
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+		_, err = appServer.RunOrphanedResourcesAction(aliceCtx, request(false, "helm-cm", "other-cm"))
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+		assert.Empty(t, deleteKubectl.deleted)
+
+		res, err := appServer.RunOrphanedResourcesAction(aliceCtx, request(false, "helm-cm"))
+		require.NoError(t, err)
+		require.Len(t, res.Results, 1)
+		assert.Empty(t, res.Results[0].GetError())
+		assert.Equal(t, []string{"helm-cm"}, deleteKubectl.deleted)
+	})
+
+	t.Run("resource not orphaned", func(t *testing.T) {
+		appServer := newServer(t, &v1alpha1.OrphanedResourcesMonitorSettings{}, kubectl)
+		_, err := appServer.RunOrphanedResourcesAction(ctx, &application.OrphanedResourcesActionRequest{
+			Name:      ptr.To("test-app"),
+			Action:    ptr.To(OrphanedResourcesActionDelete),
+			Resources: []*application.OrphanedResource{{Kind: ptr.To("Pod"), Namespace: ptr.To("default"), Name: ptr.To("pod")}},
+		})
+		require.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		deleteKubectl := &kubetest.MockKubectlCmd{Commands: map[string]kubetest.KubectlOutput{"other-cm": {Err: errors.New("forbidden")}}}
+		appServer := newServer(t, &v1alpha1.OrphanedResourcesMonitorSettings{}, deleteKubectl)
+		res, err := appServer.RunOrphanedResourcesAction(ctx, &application.OrphanedResourcesActionRequest{Name: ptr.To("test-app"), Action: ptr.To(OrphanedResourcesActionDelete), All: ptr.To(true)})
+		require.NoError(t, err)
+		require.Len(t, res.Results, 2)
+		assert.Equal(t, "helm-cm", res.Results[0].Resource.GetName())
+		assert.Empty(t, res.Results[0].GetError())
+		assert.Equal(t, "other-cm", res.Results[1].Resource.GetName())
+		assert.Equal(t, "forbidden", res.Results[1].GetError())
+	})
+
+	t.Run("adopt", func(t *testing.T) {
+		appServer := newServer(t, &v1alpha1.OrphanedResourcesMonitorSettings{}, kubectl)
+		res, err := appServer.RunOrphanedResourcesAction(ctx, &application.OrphanedResourcesActionRequest{
+			Name:      ptr.To("test-app"),
+			Action:    ptr.To(OrphanedResourcesActionAdopt),
+			Resources: []*application.OrphanedResource{{Version: ptr.To("v1"), Kind: ptr.To("ConfigMap"), Namespace: ptr.To("default"), Name: ptr.To("other-cm")}},
+		})
+		require.NoError(t, err)
+		require.Len(t, res.Results, 1)
+		assert.Equal(t, "other-cm", res.Results[0].Resource.GetName())
+		assert.Empty(t, res.Results[0].GetError())
+	})
+}
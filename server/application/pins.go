@@ -0,0 +1,237 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/argoproj/argo-cd/v3/pkg/apiclient/application"
+	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	"github.com/argoproj/argo-cd/v3/util/argo"
+	"github.com/argoproj/argo-cd/v3/util/rbac"
+)
+
+// PinSources pins sources of a multi-source application to revisions, overriding their target revisions. Sources
+// pinned to an empty revision are unpinned. If resolved is set, the sources are pinned to the versions their version
+// constraints resolved to instead.
+func (s *Server) PinSources(ctx context.Context, q *application.ApplicationPinSourcesRequest) (*v1alpha1.Application, error) {
+	a, _, err := s.getApplicationEnforceRBACClient(ctx, rbac.ActionUpdate, q.GetProject(), q.GetAppNamespace(), q.GetName(), "")
+	if err != nil {
+		return nil, err
+	}
+	if q.GetResolved() {
+		if len(q.GetRevisions()) > 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "revisions cannot be specified when pinning to the resolved versions")
+		}
+		return s.pinResolvedVersions(ctx, a, q.GetSourcePositions())
+	}
+	if !a.Spec.HasMultipleSources() {
+		return nil, status.Errorf(codes.InvalidArgument, "revisions can only be pinned for multi-source applications")
+	}
+	if len(q.GetSourcePositions()) != len(q.GetRevisions()) {
+		return nil, status.Errorf(codes.InvalidArgument, "the number of source positions and revisions must match")
+	}
+	pins, err := a.GetPinnedRevisions()
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "%v", err)
+	}
+	if pins == nil {
+		pins = make(map[int]string)
+	}
+	for i, pos := range q.GetSourcePositions() {
+		if pos <= 0 || int(pos) > len(a.Spec.Sources) {
+			return nil, status.Errorf(codes.InvalidArgument, "source position %d is out of range, the application has %d sources", pos, len(a.Spec.Sources))
+		}
+		if revision := q.GetRevisions()[i]; revision != "" {
+			pins[int(pos)] = revision
+		} else {
+			delete(pins, int(pos))
+		}
+	}
+	updated, err := s.patchPinnedRevisions(ctx, a, pins)
+	if err != nil {
+		return nil, err
+	}
+	s.logAppEvent(ctx, a, argo.EventReasonResourceUpdated, "pinned revisions: "+formatPinnedRevisions(pins))
+	return updated, nil
+}
+
+// pinResolvedVersions pins the sources at the given positions, or else all the sources whose target revision is a
+// version constraint, to the versions the constraints resolved to at the last comparison, so that the same versions
+// are deployed until they are unpinned. The target revision of a single source application is replaced instead.
+func (s *Server) pinResolvedVersions(ctx context.Context, a *v1alpha1.Application, positions []int64) (*v1alpha1.Application, error) {
+	sources := a.Spec.GetSources()
+	constraints := a.Status.Sync.VersionConstraints
+	resolved := a.Status.Sync.Revisions
+	if !a.Spec.HasMultipleSources() {
+		resolved = []string{a.Status.Sync.Revision}
+	}
+	if len(positions) == 0 {
+		for i, constraint := range constraints {
+			if constraint != "" {
+				positions = append(positions, int64(i+1))
+			}
+		}
+		if len(positions) == 0 {
+			return nil, status.Errorf(codes.FailedPrecondition, "application %s has no version constraint resolved at the last comparison", a.QualifiedName())
+		}
+	}
+	versions := make(map[int]string)
+	for _, pos := range positions {
+		if pos <= 0 || int(pos) > len(sources) {
+			return nil, status.Errorf(codes.InvalidArgument, "source position %d is out of range, the application has %d sources", pos, len(sources))
+		}
+		i := int(pos) - 1
+		if i >= len(constraints) || constraints[i] == "" || i >= len(resolved) || resolved[i] == "" {
+			return nil, status.Errorf(codes.FailedPrecondition, "source position %d has no version constraint resolved at the last comparison", pos)
+		}
+		if constraints[i] != sources[i].TargetRevision {
+			return nil, status.Errorf(codes.FailedPrecondition, "the target revision of source position %d changed since the last comparison", pos)
+		}
+		versions[int(pos)] = resolved[i]
+	}
+
+	if a.Spec.HasMultipleSources() {
+		pins, err := a.GetPinnedRevisions()
+		if err != nil {
+			return nil, status.Errorf(codes.FailedPrecondition, "%v", err)
+		}
+		if pins == nil {
+			pins = make(map[int]string)
+		}
+		maps.Copy(pins, versions)
+		updated, err := s.patchPinnedRevisions(ctx, a, pins)
+		if err != nil {
+			return nil, err
+		}
+		s.logAppEvent(ctx, a, argo.EventReasonResourceUpdated, "pinned revisions to the resolved versions: "+formatPinnedRevisions(pins))
+		return updated, nil
+	}
+
+	patch, err := json.Marshal(map[string]any{
+		"metadata": map[string]any{
+			"annotations": map[string]any{
+				v1alpha1.AnnotationKeyRefresh: string(v1alpha1.RefreshTypeNormal),
+			},
+		},
+		"spec": map[string]any{
+			"source": map[string]any{
+				"targetRevision": versions[1],
+			},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling target revision patch: %w", err)
+	}
+	updated, err := s.appclientset.ArgoprojV1alpha1().Applications(a.Namespace).Patch(ctx, a.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error patching target revision of application %s: %w", a.QualifiedName(), err)
+	}
+	s.logAppEvent(ctx, a, argo.EventReasonResourceUpdated, fmt.Sprintf("pinned target revision %s to the resolved version %s", constraints[0], versions[1]))
+	return updated, nil
+}
+
+// PromotePins copies the pinned revisions of another application to the sources of the application with the same
+// repository, chart and path, e.g. to promote the revisions tested in one environment to the next one
+func (s *Server) PromotePins(ctx context.Context, q *application.ApplicationPromotePinsRequest) (*v1alpha1.Application, error) {
+	a, _, err := s.getApplicationEnforceRBACClient(ctx, rbac.ActionUpdate, q.GetProject(), q.GetAppNamespace(), q.GetName(), "")
+	if err != nil {
+		return nil, err
+	}
+	from, _, err := s.getApplicationEnforceRBACInformer(ctx, rbac.ActionGet, "", q.GetFromAppNamespace(), q.GetFromName())
+	if err != nil {
+		return nil, err
+	}
+	if !a.Spec.HasMultipleSources() || !from.Spec.HasMultipleSources() {
+		return nil, status.Errorf(codes.InvalidArgument, "revisions can only be promoted between multi-source applications")
+	}
+	fromPins, err := from.GetPinnedRevisions()
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "%v", err)
+	}
+	pins, err := a.GetPinnedRevisions()
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "%v", err)
+	}
+	if pins == nil {
+		pins = make(map[int]string)
+	}
+	promoted := 0
+	for pos, revision := range fromPins {
+		if pos <= 0 || pos > len(from.Spec.Sources) {
+			continue
+		}
+		for i, source := range a.Spec.Sources {
+			if isSamePinnedSource(from.Spec.Sources[pos-1], source) {
+				pins[i+1] = revision
+				promoted++
+			}
+		}
+	}
+	if promoted == 0 {
+		return nil, status.Errorf(codes.FailedPrecondition, "application %s has no pinned revisions matching the sources of application %s", from.QualifiedName(), a.QualifiedName())
+	}
+	updated, err := s.patchPinnedRevisions(ctx, a, pins)
+	if err != nil {
+		return nil, err
+	}
+	s.logAppEvent(ctx, a, argo.EventReasonResourceUpdated, fmt.Sprintf("promoted pinned revisions of %s: %s", from.QualifiedName(), formatPinnedRevisions(pins)))
+	return updated, nil
+}
+
+// isSamePinnedSource returns whether the sources deploy the same repository, chart and path, regardless of their
+// target revisions and parameters
+func isSamePinnedSource(a, b v1alpha1.ApplicationSource) bool {
+	return a.RepoURL == b.RepoURL && a.Chart == b.Chart && a.Path == b.Path
+}
+
+// patchPinnedRevisions sets the pinned revisions annotation of the application, or removes it if there are no pinned
+// revisions, and requests a refresh of the application
+func (s *Server) patchPinnedRevisions(ctx context.Context, a *v1alpha1.Application, pins map[int]string) (*v1alpha1.Application, error) {
+	var value any
+	if len(pins) > 0 {
+		data, err := json.Marshal(pins)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling pinned revisions: %w", err)
+		}
+		value = string(data)
+	}
+	patch, err := json.Marshal(map[string]any{
+		"metadata": map[string]any{
+			"annotations": map[string]any{
+				v1alpha1.AnnotationKeyPinnedRevisions: value,
+				v1alpha1.AnnotationKeyRefresh:         string(v1alpha1.RefreshTypeNormal),
+			},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling pinned revisions patch: %w", err)
+	}
+	updated, err := s.appclientset.ArgoprojV1alpha1().Applications(a.Namespace).Patch(ctx, a.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error patching pinned revisions of application %s: %w", a.QualifiedName(), err)
+	}
+	return updated, nil
+}
+
+// formatPinnedRevisions formats the pinned revisions for the application events
+func formatPinnedRevisions(pins map[int]string) string {
+	if len(pins) == 0 {
+		return "none"
+	}
+	positions := slices.Sorted(maps.Keys(pins))
+	items := make([]string, len(positions))
+	for i, pos := range positions {
+		items[i] = strconv.Itoa(pos) + "=" + pins[pos]
+	}
+	return strings.Join(items, ", ")
+}
@@ -0,0 +1,151 @@
+package application
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/utils/ptr"
+
+	"github.com/argoproj/argo-cd/v3/pkg/apiclient/application"
+	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+)
+
+func newPinnedTestApp(name string, pins string) *v1alpha1.Application {
+	return newTestApp(func(app *v1alpha1.Application) {
+		app.Name = name
+		app.Spec.Source = nil
+		app.Spec.Sources = v1alpha1.ApplicationSources{
+			{RepoURL: "https://github.com/argoproj/argocd-example-apps.git", Path: "guestbook", TargetRevision: "HEAD"},
+			{RepoURL: "https://argoproj.github.io/argo-helm", Chart: "argo-cd", TargetRevision: "8.*"},
+		}
+		if pins != "" {
+			app.Annotations = map[string]string{v1alpha1.AnnotationKeyPinnedRevisions: pins}
+		}
+	})
+}
+
+func TestPinSources(t *testing.T) {
+	t.Run("pin and unpin", func(t *testing.T) {
+		appServer := newTestAppServer(t, newPinnedTestApp("staging", `{"1":"8f3a2c1"}`))
+		app, err := appServer.PinSources(t.Context(), &application.ApplicationPinSourcesRequest{
+			Name:            ptr.To("staging"),
+			SourcePositions: []int64{1, 2},
+			Revisions:       []string{"", "8.1.0"},
+		})
+		require.NoError(t, err)
+		pins, err := app.GetPinnedRevisions()
+		require.NoError(t, err)
+		assert.Equal(t, map[int]string{2: "8.1.0"}, pins)
+		assert.Equal(t, string(v1alpha1.RefreshTypeNormal), app.Annotations[v1alpha1.AnnotationKeyRefresh])
+
+		app, err = appServer.PinSources(t.Context(), &application.ApplicationPinSourcesRequest{
+			Name:            ptr.To("staging"),
+			SourcePositions: []int64{2},
+			Revisions:       []string{""},
+		})
+		require.NoError(t, err)
+		assert.NotContains(t, app.Annotations, v1alpha1.AnnotationKeyPinnedRevisions)
+	})
+
+	t.Run("invalid source position", func(t *testing.T) {
+		appServer := newTestAppServer(t, newPinnedTestApp("staging", ""))
+		_, err := appServer.PinSources(t.Context(), &application.ApplicationPinSourcesRequest{
+			Name:            ptr.To("staging"),
+			SourcePositions: []int64{3},
+			Revisions:       []string{"8.1.0"},
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		require.ErrorContains(t, err, "source position 3 is out of range, the application has 2 sources")
+	})
+
+	t.Run("single source application", func(t *testing.T) {
+		appServer := newTestAppServer(t, newTestApp())
+		_, err := appServer.PinSources(t.Context(), &application.ApplicationPinSourcesRequest{
+			Name:            ptr.To("test-app"),
+			SourcePositions: []int64{1},
+			Revisions:       []string{"8.1.0"},
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("pin to resolved versions", func(t *testing.T) {
+		staging := newPinnedTestApp("staging", `{"1":"8f3a2c1"}`)
+		staging.Status.Sync.Revisions = []string{"8f3a2c1", "8.1.3"}
+		staging.Status.Sync.VersionConstraints = []string{"", "8.*"}
+		appServer := newTestAppServer(t, staging)
+		app, err := appServer.PinSources(t.Context(), &application.ApplicationPinSourcesRequest{
+			Name:     ptr.To("staging"),
+			Resolved: ptr.To(true),
+		})
+		require.NoError(t, err)
+		pins, err := app.GetPinnedRevisions()
+		require.NoError(t, err)
+		assert.Equal(t, map[int]string{1: "8f3a2c1", 2: "8.1.3"}, pins)
+
+		_, err = appServer.PinSources(t.Context(), &application.ApplicationPinSourcesRequest{
+			Name:            ptr.To("staging"),
+			SourcePositions: []int64{1},
+			Resolved:        ptr.To(true),
+		})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+		require.ErrorContains(t, err, "source position 1 has no version constraint resolved at the last comparison")
+	})
+
+	t.Run("pin single source application to resolved version", func(t *testing.T) {
+		appServer := newTestAppServer(t, newTestApp(func(app *v1alpha1.Application) {
+			app.Spec.Source = &v1alpha1.ApplicationSource{RepoURL: "https://argoproj.github.io/argo-helm", Chart: "argo-cd", TargetRevision: "8.*"}
+			app.Status.Sync.Revision = "8.1.3"
+			app.Status.Sync.VersionConstraints = []string{"8.*"}
+		}))
+		app, err := appServer.PinSources(t.Context(), &application.ApplicationPinSourcesRequest{
+			Name:     ptr.To("test-app"),
+			Resolved: ptr.To(true),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "8.1.3", app.Spec.Source.TargetRevision)
+		assert.Equal(t, string(v1alpha1.RefreshTypeNormal), app.Annotations[v1alpha1.AnnotationKeyRefresh])
+	})
+
+	t.Run("target revision changed since the last comparison", func(t *testing.T) {
+		staging := newPinnedTestApp("staging", "")
+		staging.Status.Sync.Revisions = []string{"8f3a2c1", "7.9.0"}
+		staging.Status.Sync.VersionConstraints = []string{"", "7.*"}
+		appServer := newTestAppServer(t, staging)
+		_, err := appServer.PinSources(t.Context(), &application.ApplicationPinSourcesRequest{
+			Name:     ptr.To("staging"),
+			Resolved: ptr.To(true),
+		})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+		require.ErrorContains(t, err, "the target revision of source position 2 changed since the last comparison")
+	})
+}
+
+func TestPromotePins(t *testing.T) {
+	t.Run("promote", func(t *testing.T) {
+		production := newPinnedTestApp("production", `{"1":"5d2e9b0"}`)
+		// the sources of production are in a different order than the sources of staging
+		production.Spec.Sources[0], production.Spec.Sources[1] = production.Spec.Sources[1], production.Spec.Sources[0]
+		appServer := newTestAppServer(t, newPinnedTestApp("staging", `{"1":"8f3a2c1","2":"8.1.0"}`), production)
+		app, err := appServer.PromotePins(t.Context(), &application.ApplicationPromotePinsRequest{
+			Name:     ptr.To("production"),
+			FromName: ptr.To("staging"),
+		})
+		require.NoError(t, err)
+		pins, err := app.GetPinnedRevisions()
+		require.NoError(t, err)
+		assert.Equal(t, map[int]string{1: "8.1.0", 2: "8f3a2c1"}, pins)
+	})
+
+	t.Run("nothing to promote", func(t *testing.T) {
+		appServer := newTestAppServer(t, newPinnedTestApp("staging", ""), newPinnedTestApp("production", ""))
+		_, err := appServer.PromotePins(t.Context(), &application.ApplicationPromotePinsRequest{
+			Name:     ptr.To("production"),
+			FromName: ptr.To("staging"),
+		})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+		require.ErrorContains(t, err, "application default/staging has no pinned revisions matching the sources of application default/production")
+	})
+}
@@ -0,0 +1,172 @@
+package application
+
+import (
+	"fmt"
+	"net/url"
+
+	synccommon "github.com/argoproj/gitops-engine/pkg/sync/common"
+	"github.com/argoproj/gitops-engine/pkg/utils/kube"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+
+	argocommon "github.com/argoproj/argo-cd/v3/common"
+	"github.com/argoproj/argo-cd/v3/pkg/apiclient/application"
+	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	"github.com/argoproj/argo-cd/v3/util/argo"
+	"github.com/argoproj/argo-cd/v3/util/rbac"
+)
+
+// WatchSyncProgress streams the progress of the resources of the sync operation of an application. The first event of
+// a sync operation holds the progress of all its resources, and the next events only the resources whose progress
+// changed.
+func (s *Server) WatchSyncProgress(q *application.ApplicationSyncProgressQuery, ws application.ApplicationService_WatchSyncProgressServer) error {
+	appName := q.GetName()
+	appNs := s.appNamespaceOrDefault(q.GetAppNamespace())
+	claims := ws.Context().Value("claims")
+
+	// subscribe before getting the application, so that no change is missed in between
+	events := make(chan *v1alpha1.ApplicationWatchEvent, watchAPIBufferSize)
+	unsubscribe := s.appBroadcaster.Subscribe(events, func(event *v1alpha1.ApplicationWatchEvent) bool {
+		return event.Application.Name == appName && event.Application.Namespace == appNs
+	})
+	defer unsubscribe()
+
+	a, _, err := s.getApplicationEnforceRBACInformer(ws.Context(), rbac.ActionGet, q.GetProject(), q.GetAppNamespace(), appName)
+	if err != nil {
+		return err
+	}
+
+	var previous *v1alpha1.ApplicationSyncProgressEvent
+	var operationStartedAt metav1.Time
+	sendProgress := func(app *v1alpha1.Application) error {
+		state := app.Status.OperationState
+		if state == nil || state.Operation.Sync == nil {
+			return nil
+		}
+		// the progress of a new sync operation is sent in full
+		if !state.StartedAt.Equal(&operationStartedAt) {
+			previous = nil
+			operationStartedAt = state.StartedAt
+		}
+		progress := getSyncProgress(app)
+		toSend := progress
+		if previous != nil {
+			toSend = progress.GetDelta(previous)
+			if toSend == nil {
+				return nil
+			}
+		}
+		previous = progress
+		return ws.Send(toSend)
+	}
+
+	if err := sendProgress(a); err != nil {
+		return err
+	}
+	for {
+		select {
+		case event := <-events:
+			if event.Type == watch.Deleted {
+				return nil
+			}
+			// the application may have been moved to a project the caller can't access since the watch started
+			if q.GetProject() != "" && event.Application.Spec.GetProject() != q.GetProject() {
+				return argocommon.PermissionDeniedAPIError
+			}
+			if !s.enf.Enforce(claims, rbac.ResourceApplications, rbac.ActionGet, event.Application.RBACName(s.ns)) {
+				return argocommon.PermissionDeniedAPIError
+			}
+			if err := sendProgress(&event.Application); err != nil {
+				return err
+			}
+		case <-ws.Context().Done():
+			return nil
+		}
+	}
+}
+
+// getSyncProgress returns the progress of the resources of the sync operation of the application, from the results of
+// the resources synced so far and, while the operation is running, the out of sync resources which are yet to be synced
+func getSyncProgress(a *v1alpha1.Application) *v1alpha1.ApplicationSyncProgressEvent {
+	state := a.Status.OperationState
+	progress := &v1alpha1.ApplicationSyncProgressEvent{Phase: state.Phase, Message: state.Message}
+	started := make(map[kube.ResourceKey]bool)
+	if state.SyncResult != nil {
+		for _, res := range state.SyncResult.Resources {
+			resProgress := v1alpha1.ResourceSyncProgress{
+				Group:     res.Group,
+				Kind:      res.Kind,
+				Namespace: res.Namespace,
+				Name:      res.Name,
+				SyncPhase: res.SyncPhase,
+				Stage:     getResourceSyncStage(res),
+				Message:   res.Message,
+				HookType:  res.HookType,
+			}
+			if resProgress.Stage == v1alpha1.SyncProgressStageHookRunning {
+				resProgress.LogsPath = getHookLogsPath(a, res)
+			}
+			progress.Resources = append(progress.Resources, resProgress)
+			started[kube.NewResourceKey(res.Group, res.Kind, res.Namespace, res.Name)] = true
+		}
+	}
+	if state.Phase.Completed() {
+		return progress
+	}
+	sync := state.Operation.Sync
+	for _, res := range a.Status.Resources {
+		if res.Status != v1alpha1.SyncStatusCodeOutOfSync || res.Hook || started[kube.NewResourceKey(res.Group, res.Kind, res.Namespace, res.Name)] {
+			continue
+		}
+		if res.RequiresPruning && !sync.Prune {
+			continue
+		}
+		if len(sync.Resources) > 0 && !argo.ContainsSyncResource(res.Name, res.Namespace, res.GroupVersionKind(), sync.Resources) {
+			continue
+		}
+		progress.Resources = append(progress.Resources, v1alpha1.ResourceSyncProgress{
+			Group:     res.Group,
+			Kind:      res.Kind,
+			Namespace: res.Namespace,
+			Name:      res.Name,
+			SyncPhase: synccommon.SyncPhaseSync,
+			Stage:     v1alpha1.SyncProgressStageQueued,
+		})
+	}
+	return progress
+}
+
+// getResourceSyncStage returns the stage of the sync of a resource from its result
+func getResourceSyncStage(res *v1alpha1.ResourceResult) v1alpha1.SyncProgressStage {
+	switch {
+	case res.Status == synccommon.ResultCodeSyncFailed || res.HookPhase == synccommon.OperationFailed || res.HookPhase == synccommon.OperationError:
+		return v1alpha1.SyncProgressStageFailed
+	case res.HookPhase == synccommon.OperationSucceeded:
+		return v1alpha1.SyncProgressStageSucceeded
+	case res.HookType != "" && res.HookPhase == synccommon.OperationRunning:
+		return v1alpha1.SyncProgressStageHookRunning
+	case res.HookType != "":
+		return v1alpha1.SyncProgressStageApplying
+	case res.Status == "" && res.HookPhase == "":
+		return v1alpha1.SyncProgressStageQueued
+	case res.Status == "":
+		return v1alpha1.SyncProgressStageApplying
+	case res.HookPhase == synccommon.OperationRunning:
+		// the resource is applied, and the sync waits for it to become healthy
+		return v1alpha1.SyncProgressStageWaitingHealth
+	default:
+		return v1alpha1.SyncProgressStageSucceeded
+	}
+}
+
+// getHookLogsPath returns the path of the pod logs API returning the logs of the pods of a hook
+func getHookLogsPath(a *v1alpha1.Application, res *v1alpha1.ResourceResult) string {
+	query := url.Values{}
+	query.Set("appNamespace", a.Namespace)
+	query.Set("namespace", res.Namespace)
+	query.Set("group", res.Group)
+	query.Set("kind", res.Kind)
+	query.Set("resourceName", res.Name)
+	query.Set("follow", "true")
+	return fmt.Sprintf("/api/v1/applications/%s/logs?%s", url.PathEscape(a.Name), query.Encode())
+}
@@ -0,0 +1,170 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	synccommon "github.com/argoproj/gitops-engine/pkg/sync/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"k8s.io/utils/ptr"
+
+	"github.com/argoproj/argo-cd/v3/pkg/apiclient/application"
+	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+)
+
+func newSyncingTestApp(phase synccommon.OperationPhase, results ...*v1alpha1.ResourceResult) *v1alpha1.Application {
+	return newTestApp(func(app *v1alpha1.Application) {
+		app.Status.OperationState = &v1alpha1.OperationState{
+			Operation:  v1alpha1.Operation{Sync: &v1alpha1.SyncOperation{}},
+			Phase:      phase,
+			SyncResult: &v1alpha1.SyncOperationResult{Resources: results},
+		}
+		app.Status.Resources = []v1alpha1.ResourceStatus{
+			{Group: "apps", Version: "v1", Kind: "Deployment", Namespace: "default", Name: "guestbook-ui", Status: v1alpha1.SyncStatusCodeOutOfSync},
+			{Version: "v1", Kind: "Service", Namespace: "default", Name: "guestbook-ui", Status: v1alpha1.SyncStatusCodeSynced},
+			{Version: "v1", Kind: "ConfigMap", Namespace: "default", Name: "obsolete", Status: v1alpha1.SyncStatusCodeOutOfSync, RequiresPruning: true},
+		}
+	})
+}
+
+func TestGetSyncProgress(t *testing.T) {
+	t.Run("queued resources", func(t *testing.T) {
+		progress := getSyncProgress(newSyncingTestApp(synccommon.OperationRunning))
+		assert.Equal(t, synccommon.OperationRunning, progress.Phase)
+		assert.Equal(t, []v1alpha1.ResourceSyncProgress{{
+			Group:     "apps",
+			Kind:      "Deployment",
+			Namespace: "default",
+			Name:      "guestbook-ui",
+			SyncPhase: synccommon.SyncPhaseSync,
+			Stage:     v1alpha1.SyncProgressStageQueued,
+		}}, progress.Resources)
+	})
+
+	t.Run("stages of the resources", func(t *testing.T) {
+		progress := getSyncProgress(newSyncingTestApp(synccommon.OperationRunning,
+			&v1alpha1.ResourceResult{Kind: "Job", Namespace: "default", Name: "migrate", SyncPhase: synccommon.SyncPhasePreSync, HookType: synccommon.HookTypePreSync, HookPhase: synccommon.OperationSucceeded},
+			&v1alpha1.ResourceResult{Group: "apps", Kind: "Deployment", Namespace: "default", Name: "guestbook-ui", SyncPhase: synccommon.SyncPhaseSync, Status: synccommon.ResultCodeSynced, HookPhase: synccommon.OperationRunning, Message: "deployment.apps/guestbook-ui configured"},
+			&v1alpha1.ResourceResult{Kind: "Job", Namespace: "default", Name: "smoke-test", SyncPhase: synccommon.SyncPhasePostSync, HookType: synccommon.HookTypePostSync, HookPhase: synccommon.OperationRunning},
+			&v1alpha1.ResourceResult{Kind: "ConfigMap", Namespace: "default", Name: "settings", SyncPhase: synccommon.SyncPhaseSync, Status: synccommon.ResultCodeSyncFailed},
+		))
+		stages := make(map[string]v1alpha1.SyncProgressStage)
+		for _, res := range progress.Resources {
+			stages[res.Name] = res.Stage
+		}
+		assert.Equal(t, map[string]v1alpha1.SyncProgressStage{
+			"migrate":      v1alpha1.SyncProgressStageSucceeded,
+			"guestbook-ui": v1alpha1.SyncProgressStageWaitingHealth,
+			"smoke-test":   v1alpha1.SyncProgressStageHookRunning,
+			"settings":     v1alpha1.SyncProgressStageFailed,
+		}, stages)
+		assert.Equal(t, "/api/v1/applications/test-app/logs?appNamespace=default&follow=true&group=&kind=Job&namespace=default&resourceName=smoke-test", progress.Resources[2].LogsPath)
+		assert.Empty(t, progress.Resources[0].LogsPath)
+	})
+
+	t.Run("pruned resources are queued with prune enabled", func(t *testing.T) {
+		app := newSyncingTestApp(synccommon.OperationRunning)
+		app.Status.OperationState.Operation.Sync.Prune = true
+		progress := getSyncProgress(app)
+		assert.Len(t, progress.Resources, 2)
+		assert.Equal(t, "obsolete", progress.Resources[1].Name)
+	})
+
+	t.Run("resources out of a partial sync are not queued", func(t *testing.T) {
+		app := newSyncingTestApp(synccommon.OperationRunning)
+		app.Status.OperationState.Operation.Sync.Resources = []v1alpha1.SyncOperationResource{{Kind: "Service", Name: "guestbook-ui"}}
+		assert.Empty(t, getSyncProgress(app).Resources)
+	})
+
+	t.Run("completed operation", func(t *testing.T) {
+		progress := getSyncProgress(newSyncingTestApp(synccommon.OperationSucceeded,
+			&v1alpha1.ResourceResult{Group: "apps", Kind: "Deployment", Namespace: "default", Name: "guestbook-ui", SyncPhase: synccommon.SyncPhaseSync, Status: synccommon.ResultCodeSynced, HookPhase: synccommon.OperationSucceeded},
+		))
+		assert.Equal(t, []v1alpha1.ResourceSyncProgress{{
+			Group:     "apps",
+			Kind:      "Deployment",
+			Namespace: "default",
+			Name:      "guestbook-ui",
+			SyncPhase: synccommon.SyncPhaseSync,
+			Stage:     v1alpha1.SyncProgressStageSucceeded,
+		}}, progress.Resources)
+	})
+}
+
+type testSyncProgressServer struct {
+	ctx    context.Context
+	events chan *v1alpha1.ApplicationSyncProgressEvent
+}
+
+func (t *testSyncProgressServer) Send(event *v1alpha1.ApplicationSyncProgressEvent) error {
+	t.events <- event
+	return nil
+}
+
+func (t *testSyncProgressServer) SetHeader(metadata.MD) error {
+	return nil
+}
+
+func (t *testSyncProgressServer) SendHeader(metadata.MD) error {
+	return nil
+}
+
+func (t *testSyncProgressServer) SetTrailer(metadata.MD) {}
+
+func (t *testSyncProgressServer) Context() context.Context {
+	return t.ctx
+}
+
+func (t *testSyncProgressServer) SendMsg(_ any) error {
+	return nil
+}
+
+func (t *testSyncProgressServer) RecvMsg(_ any) error {
+	return nil
+}
+
+func TestWatchSyncProgress(t *testing.T) {
+	testApp := newSyncingTestApp(synccommon.OperationRunning)
+	watch := func(t *testing.T, appServer *Server) (*testSyncProgressServer, chan error) {
+		t.Helper()
+		appServer.appBroadcaster = &broadcasterHandler{}
+		ws := &testSyncProgressServer{ctx: t.Context(), events: make(chan *v1alpha1.ApplicationSyncProgressEvent, 1)}
+		done := make(chan error, 1)
+		go func() {
+			done <- appServer.WatchSyncProgress(&application.ApplicationSyncProgressQuery{Name: ptr.To(testApp.Name)}, ws)
+		}()
+		// the progress of the current operation is sent first
+		event := <-ws.events
+		require.Len(t, event.Resources, 1)
+		return ws, done
+	}
+
+	t.Run("progress updates are streamed", func(t *testing.T) {
+		appServer := newTestAppServer(t, testApp)
+		ws, _ := watch(t, appServer)
+
+		updated := newSyncingTestApp(synccommon.OperationRunning,
+			&v1alpha1.ResourceResult{Group: "apps", Kind: "Deployment", Namespace: "default", Name: "guestbook-ui", SyncPhase: synccommon.SyncPhaseSync},
+		)
+		updated.Status.OperationState.Message = "syncing"
+		appServer.appBroadcaster.OnUpdate(testApp, updated)
+		event := <-ws.events
+		assert.Equal(t, "syncing", event.Message)
+	})
+
+	t.Run("stream ends when the caller is no longer permitted", func(t *testing.T) {
+		appServer := newTestAppServer(t, testApp)
+		_, done := watch(t, appServer)
+
+		appServer.enf.SetDefaultRole("")
+		updated := testApp.DeepCopy()
+		updated.Status.OperationState.Message = "syncing"
+		appServer.appBroadcaster.OnUpdate(testApp, updated)
+		err := <-done
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+}
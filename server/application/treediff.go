@@ -0,0 +1,103 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/argoproj/argo-cd/v3/pkg/apiclient/application"
+	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	servercache "github.com/argoproj/argo-cd/v3/server/cache"
+	appstatecache "github.com/argoproj/argo-cd/v3/util/cache/appstate"
+	"github.com/argoproj/argo-cd/v3/util/rbac"
+)
+
+// ResourceTreeDiff returns the resources which appeared, disappeared or changed health between two snapshots of the
+// resource tree of an application, which the application controller takes when its resource tree snapshot interval is
+// set. The changes start from the latest snapshot taken at or before the from time, or the oldest snapshot, and end at
+// the latest snapshot taken at or before the to time, or the current tree.
+func (s *Server) ResourceTreeDiff(ctx context.Context, q *application.ResourceTreeDiffQuery) (*v1alpha1.ResourceTreeDiff, error) {
+	a, _, err := s.getApplicationEnforceRBACInformer(ctx, rbac.ActionGet, q.GetProject(), q.GetAppNamespace(), q.GetName())
+	if err != nil {
+		return nil, err
+	}
+	from, err := parseSnapshotTime("from", q.GetFrom())
+	if err != nil {
+		return nil, err
+	}
+	to, err := parseSnapshotTime("to", q.GetTo())
+	if err != nil {
+		return nil, err
+	}
+
+	var snapshots []appstatecache.ResourceTreeSnapshot
+	err = s.cache.GetAppResourcesTreeSnapshots(a.InstanceName(s.ns), &snapshots)
+	if err != nil && !errors.Is(err, servercache.ErrCacheMiss) {
+		return nil, fmt.Errorf("error getting the resource tree snapshots: %w", err)
+	}
+	if len(snapshots) == 0 {
+		return nil, status.Errorf(codes.NotFound, "no snapshot of the resource tree of application %s, snapshots are taken when the resource tree snapshot interval of the application controller is set", a.QualifiedName())
+	}
+
+	fromSnapshot := findSnapshotAt(snapshots, from)
+	if fromSnapshot == nil {
+		fromSnapshot = &snapshots[0]
+	}
+	var toNodes []v1alpha1.ResourceNode
+	var toTime time.Time
+	if to.IsZero() {
+		tree, err := s.getAppResources(ctx, a)
+		if err != nil {
+			return nil, err
+		}
+		toNodes, toTime = tree.Nodes, time.Now().UTC().Truncate(time.Second)
+	} else {
+		toSnapshot := findSnapshotAt(snapshots, to)
+		if toSnapshot == nil {
+			return nil, status.Errorf(codes.InvalidArgument, "no snapshot of the resource tree of application %s taken at or before %s", a.QualifiedName(), q.GetTo())
+		}
+		toNodes, toTime = toSnapshot.Nodes, toSnapshot.Time
+	}
+	if toTime.Before(fromSnapshot.Time) {
+		return nil, status.Errorf(codes.InvalidArgument, "the snapshot selected by from, taken at %s, is newer than the one selected by to, taken at %s", fromSnapshot.Time.Format(time.RFC3339), toTime.Format(time.RFC3339))
+	}
+
+	diff := v1alpha1.NewResourceTreeDiff(fromSnapshot.Nodes, toNodes)
+	diff.From = metav1.NewTime(fromSnapshot.Time)
+	diff.To = metav1.NewTime(toTime)
+	for _, snapshot := range snapshots {
+		diff.Snapshots = append(diff.Snapshots, metav1.NewTime(snapshot.Time))
+	}
+	return diff, nil
+}
+
+// parseSnapshotTime parses a time of a resource tree diff query, which is zero if it is empty
+func parseSnapshotTime(name string, value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, status.Errorf(codes.InvalidArgument, "invalid %s time %q, expected the RFC3339 format: %v", name, value, err)
+	}
+	return t, nil
+}
+
+// findSnapshotAt returns the latest of the snapshots, ordered from the oldest to the newest, taken at or before the
+// given time, or nil if there is none or the time is zero
+func findSnapshotAt(snapshots []appstatecache.ResourceTreeSnapshot, t time.Time) *appstatecache.ResourceTreeSnapshot {
+	if t.IsZero() {
+		return nil
+	}
+	for i := len(snapshots) - 1; i >= 0; i-- {
+		if !snapshots[i].Time.After(t) {
+			return &snapshots[i]
+		}
+	}
+	return nil
+}
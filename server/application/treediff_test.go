@@ -0,0 +1,95 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/argoproj/gitops-engine/pkg/health"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/argoproj/argo-cd/v3/pkg/apiclient/application"
+	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	servercache "github.com/argoproj/argo-cd/v3/server/cache"
+	"github.com/argoproj/argo-cd/v3/util/cache"
+	"github.com/argoproj/argo-cd/v3/util/cache/appstate"
+)
+
+func TestResourceTreeDiff(t *testing.T) {
+	//nolint:staticcheck
+	ctx := context.WithValue(t.Context(), "claims", &jwt.RegisteredClaims{Subject: "admin"})
+	healthy := &v1alpha1.HealthStatus{Status: health.HealthStatusHealthy}
+	degraded := &v1alpha1.HealthStatus{Status: health.HealthStatusDegraded}
+	pod := func(name string, health *v1alpha1.HealthStatus) v1alpha1.ResourceNode {
+		return v1alpha1.ResourceNode{ResourceRef: v1alpha1.ResourceRef{Kind: "Pod", Namespace: "default", Name: name}, Health: health}
+	}
+	yesterday := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	snapshots := []appstate.ResourceTreeSnapshot{
+		{Time: yesterday, Nodes: []v1alpha1.ResourceNode{pod("pod-1", healthy), pod("pod-2", healthy)}},
+		{Time: yesterday.Add(time.Hour), Nodes: []v1alpha1.ResourceNode{pod("pod-1", degraded), pod("pod-3", healthy)}},
+	}
+	newServer := func(t *testing.T, snapshots []appstate.ResourceTreeSnapshot) *Server {
+		t.Helper()
+		appServer := newTestAppServer(t, newTestApp())
+		appStateCache := appstate.NewCache(cache.NewCache(cache.NewInMemoryCache(time.Hour)), time.Hour)
+		require.NoError(t, appStateCache.SetAppResourcesTree("test-app", &v1alpha1.ApplicationTree{Nodes: []v1alpha1.ResourceNode{pod("pod-3", degraded)}}))
+		if snapshots != nil {
+			require.NoError(t, appStateCache.SetAppResourcesTreeSnapshots("test-app", snapshots, time.Hour))
+		}
+		appServer.cache = servercache.NewCache(appStateCache, time.Hour, time.Hour)
+		return appServer
+	}
+
+	t.Run("diff between two snapshots", func(t *testing.T) {
+		appServer := newServer(t, snapshots)
+		diff, err := appServer.ResourceTreeDiff(ctx, &application.ResourceTreeDiffQuery{
+			Name: ptr.To("test-app"),
+			From: ptr.To("2025-01-01T10:30:00Z"),
+			To:   ptr.To("2025-01-01T11:00:00Z"),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, &v1alpha1.ResourceTreeDiff{
+			From:          metav1.NewTime(yesterday),
+			To:            metav1.NewTime(yesterday.Add(time.Hour)),
+			Appeared:      []v1alpha1.ResourceNode{pod("pod-3", healthy)},
+			Disappeared:   []v1alpha1.ResourceNode{pod("pod-2", healthy)},
+			HealthChanged: []v1alpha1.ResourceNodeHealthChange{{ResourceRef: pod("pod-1", nil).ResourceRef, Health: degraded, PreviousHealth: healthy}},
+			Snapshots:     []metav1.Time{metav1.NewTime(yesterday), metav1.NewTime(yesterday.Add(time.Hour))},
+		}, diff)
+	})
+
+	t.Run("diff from the oldest snapshot to the current tree", func(t *testing.T) {
+		appServer := newServer(t, snapshots)
+		diff, err := appServer.ResourceTreeDiff(ctx, &application.ResourceTreeDiffQuery{Name: ptr.To("test-app")})
+		require.NoError(t, err)
+		assert.Equal(t, metav1.NewTime(yesterday), diff.From)
+		assert.WithinDuration(t, time.Now(), diff.To.Time, 2*time.Second)
+		assert.Equal(t, []v1alpha1.ResourceNode{pod("pod-3", degraded)}, diff.Appeared)
+		assert.Equal(t, []v1alpha1.ResourceNode{pod("pod-1", healthy), pod("pod-2", healthy)}, diff.Disappeared)
+		assert.Empty(t, diff.HealthChanged)
+	})
+
+	t.Run("no snapshot before to", func(t *testing.T) {
+		appServer := newServer(t, snapshots)
+		_, err := appServer.ResourceTreeDiff(ctx, &application.ResourceTreeDiffQuery{Name: ptr.To("test-app"), To: ptr.To("2024-12-31T00:00:00Z")})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("invalid time", func(t *testing.T) {
+		appServer := newServer(t, snapshots)
+		_, err := appServer.ResourceTreeDiff(ctx, &application.ResourceTreeDiffQuery{Name: ptr.To("test-app"), From: ptr.To("yesterday")})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("no snapshot", func(t *testing.T) {
+		appServer := newServer(t, nil)
+		_, err := appServer.ResourceTreeDiff(ctx, &application.ResourceTreeDiffQuery{Name: ptr.To("test-app")})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+}
@@ -267,7 +267,7 @@ func (s *Server) generateApplicationSetApps(ctx context.Context, logEntry *log.E
 	argoCDService := services.NewArgoCDService(s.db, s.GitSubmoduleEnabled, s.repoClientSet, s.EnableNewGitFileGlobbing)
 	appSetGenerators := generators.GetGenerators(ctx, s.client, s.k8sClient, namespace, argoCDService, s.dynamicClient, scmConfig)
 
-	apps, _, err := appsettemplate.GenerateApplications(logEntry, appset, appSetGenerators, &appsetutils.Render{}, s.client)
+	apps, _, err := appsettemplate.GenerateApplications(ctx, logEntry, appset, appSetGenerators, &appsetutils.Render{}, s.client)
 	if err != nil {
 		return nil, fmt.Errorf("error generating applications: %w", err)
 	}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	appv1 "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
 	cacheutil "github.com/argoproj/argo-cd/v3/util/cache"
@@ -55,6 +56,14 @@ func (c *Cache) GetAppResourcesTree(appName string, res *appv1.ApplicationTree)
 	return c.cache.GetAppResourcesTree(appName, res)
 }
 
+func (c *Cache) GetAppResourcesTreeSnapshots(appName string, res *[]appstatecache.ResourceTreeSnapshot) error {
+	return c.cache.GetAppResourcesTreeSnapshots(appName, res)
+}
+
+func (c *Cache) GetHookLogs(appName string, namespace string, podName string, container string, res *string) error {
+	return c.cache.GetHookLogs(appName, namespace, podName, container, res)
+}
+
 func (c *Cache) OnAppResourcesTreeChanged(ctx context.Context, appName string, callback func() error) error {
 	return c.cache.OnAppResourcesTreeChanged(ctx, appName, callback)
 }
@@ -63,6 +72,10 @@ func (c *Cache) GetAppManagedResources(appName string, res *[]*appv1.ResourceDif
 	return c.cache.GetAppManagedResources(appName, res)
 }
 
+func (c *Cache) CoalesceAppStateRefresh(ctx context.Context, appName string) (func(), error) {
+	return c.cache.CoalesceAppStateRefresh(ctx, appName)
+}
+
 func (c *Cache) SetRepoConnectionState(repo string, project string, state *appv1.ConnectionState) error {
 	return c.cache.SetItem(repoConnectionStateKey(repo, project), &state, c.connectionStatusCacheExpiration, state == nil)
 }
@@ -77,6 +90,33 @@ func (c *Cache) GetRepoConnectionState(repo string, project string) (appv1.Conne
 	return res, err
 }
 
+// BreakGlassRequest is a pending break-glass sync request of an application. It is only created by the API server, so
+// that it can't be forged by the users allowed to update the application.
+type BreakGlassRequest struct {
+	AppUID        string      `json:"appUID"`
+	RequestedBy   string      `json:"requestedBy"`
+	Justification string      `json:"justification"`
+	RequestedAt   metav1.Time `json:"requestedAt"`
+}
+
+// SetBreakGlassRequest stores the pending break-glass sync request of an application until it expires, or removes it
+// if the request is nil
+func (c *Cache) SetBreakGlassRequest(appName string, request *BreakGlassRequest, expiration time.Duration) error {
+	return c.cache.SetItem(breakGlassRequestKey(appName), request, expiration, request == nil)
+}
+
+func (c *Cache) GetBreakGlassRequest(appName string) (*BreakGlassRequest, error) {
+	res := &BreakGlassRequest{}
+	if err := c.cache.GetItem(breakGlassRequestKey(appName), res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func breakGlassRequestKey(appName string) string {
+	return fmt.Sprintf("app|%s|break-glass-request", appName)
+}
+
 func (c *Cache) GetClusterInfo(server string, res *appv1.ClusterInfo) error {
 	return c.cache.GetClusterInfo(server, res)
 }
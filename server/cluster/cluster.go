@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/url"
+	"strings"
 	"time"
 
 	"github.com/argoproj/gitops-engine/pkg/utils/kube"
@@ -66,6 +67,9 @@ func (s *Server) List(ctx context.Context, q *cluster.ClusterQuery) (*appv1.Clus
 	// Filter clusters by name
 	filteredItems = filterClustersByName(filteredItems, q.Name)
 
+	// Filter clusters by name prefix
+	filteredItems = filterClustersByNamePrefix(filteredItems, q.NamePrefix)
+
 	// Filter clusters by server
 	filteredItems = filterClustersByServer(filteredItems, q.Server)
 
@@ -126,6 +130,19 @@ func filterClustersByName(clusters []appv1.Cluster, name string) []appv1.Cluster
 	return items
 }
 
+func filterClustersByNamePrefix(clusters []appv1.Cluster, prefix string) []appv1.Cluster {
+	if prefix == "" {
+		return clusters
+	}
+	items := make([]appv1.Cluster, 0)
+	for i := 0; i < len(clusters); i++ {
+		if strings.HasPrefix(clusters[i].Name, prefix) {
+			items = append(items, clusters[i])
+		}
+	}
+	return items
+}
+
 func filterClustersByServer(clusters []appv1.Cluster, server string) []appv1.Cluster {
 	if server == "" {
 		return clusters
@@ -273,6 +290,9 @@ var clusterFieldsByPath = map[string]func(updated *appv1.Cluster, existing *appv
 	"namespaces": func(updated *appv1.Cluster, existing *appv1.Cluster) {
 		updated.Namespaces = existing.Namespaces
 	},
+	"resourceGroups": func(updated *appv1.Cluster, existing *appv1.Cluster) {
+		updated.ResourceGroups = existing.ResourceGroups
+	},
 	"config": func(updated *appv1.Cluster, existing *appv1.Cluster) {
 		updated.Config = existing.Config
 	},
@@ -291,6 +311,21 @@ var clusterFieldsByPath = map[string]func(updated *appv1.Cluster, existing *appv
 	"project": func(updated *appv1.Cluster, existing *appv1.Cluster) {
 		updated.Project = existing.Project
 	},
+	"cacheResyncPeriod": func(updated *appv1.Cluster, existing *appv1.Cluster) {
+		updated.CacheResyncPeriod = existing.CacheResyncPeriod
+	},
+	"cacheSyncRetryTimeout": func(updated *appv1.Cluster, existing *appv1.Cluster) {
+		updated.CacheSyncRetryTimeout = existing.CacheSyncRetryTimeout
+	},
+	"cacheListPageSize": func(updated *appv1.Cluster, existing *appv1.Cluster) {
+		updated.CacheListPageSize = existing.CacheListPageSize
+	},
+	"minReadyNodes": func(updated *appv1.Cluster, existing *appv1.Cluster) {
+		updated.MinReadyNodes = existing.MinReadyNodes
+	},
+	"draining": func(updated *appv1.Cluster, existing *appv1.Cluster) {
+		updated.Draining = existing.Draining
+	},
 }
 
 // Update updates a cluster
@@ -324,6 +359,15 @@ func (s *Server) Update(ctx context.Context, q *cluster.ClusterUpdateRequest) (*
 		return nil, fmt.Errorf("failed to get REST config for cluster: %w", err)
 	}
 
+	if q.Cluster.Draining {
+		// the connection to a draining cluster isn't tested, since the cluster might be unreachable while it is drained
+		clust, err := s.db.UpdateCluster(ctx, q.Cluster)
+		if err != nil {
+			return nil, fmt.Errorf("failed to update cluster in database: %w", err)
+		}
+		return s.toAPIResponse(clust), nil
+	}
+
 	// Test the token we just created before persisting it
 	serverVersion, err := s.kubectl.GetServerVersion(clusterRESTConfig)
 	if err != nil {
@@ -476,6 +520,10 @@ func (s *Server) toAPIResponse(clust *appv1.Cluster) *appv1.Cluster {
 	clust.Config.Password = ""
 	clust.Config.BearerToken = ""
 	clust.Config.KeyData = nil
+	clust.Config.ProxyPassword = ""
+	if clust.Config.JumpHostConfig != nil {
+		clust.Config.JumpHostConfig.SSHPrivateKey = ""
+	}
 	if clust.Config.ExecProviderConfig != nil {
 		// We can't know what the user has put into args or
 		// env vars on the exec provider that might be sensitive
@@ -701,6 +701,16 @@ func TestListCluster(t *testing.T) {
 				Items:    []v1alpha1.Cluster{barCluster},
 			},
 		},
+		{
+			name: "filter by name prefix",
+			q: &cluster.ClusterQuery{
+				NamePrefix: "te",
+			},
+			want: &v1alpha1.ClusterList{
+				ListMeta: metav1.ListMeta{},
+				Items:    []v1alpha1.Cluster{bazCluster},
+			},
+		},
 	}
 	for _, tt := range tests {
 		tt := tt
@@ -0,0 +1,104 @@
+package fips
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/argoproj/argo-cd/v3/util/fips"
+)
+
+const (
+	// URLPath is the path of the endpoint reporting the FIPS compliance status of the components
+	URLPath = "/api/v1/fips"
+
+	ComponentServer                = "server"
+	ComponentApplicationController = "application-controller"
+	ComponentRepoServer            = "repo-server"
+)
+
+// ComponentStatus is the FIPS compliance status of an Argo CD component
+type ComponentStatus struct {
+	fips.Status
+	// Component is the name of the component
+	Component string `json:"component"`
+	// Error is set if the status of the component couldn't be retrieved
+	Error string `json:"error,omitempty"`
+}
+
+// Response is the FIPS compliance status of the Argo CD components
+type Response struct {
+	// Compliant is true if all the components are FIPS compliant
+	Compliant  bool              `json:"compliant"`
+	Components []ComponentStatus `json:"components"`
+}
+
+// NewHandler creates a handler reporting the FIPS compliance status of the Argo CD components. The status of the API
+// server is computed in process, while the status of the application controller and the repo server are retrieved
+// from the FIPS status endpoint on their metrics port.
+func NewHandler(controllerMetricsAddress string, repoServerMetricsAddress string) *Handler {
+	return &Handler{
+		metricsAddresses: map[string]string{
+			ComponentApplicationController: controllerMetricsAddress,
+			ComponentRepoServer:            repoServerMetricsAddress,
+		},
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Handler serves the api/v1/fips endpoint
+type Handler struct {
+	// metricsAddresses are the addresses of the metrics ports of the remote components
+	metricsAddresses map[string]string
+	client           *http.Client
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	response := Response{Components: []ComponentStatus{{Component: ComponentServer, Status: fips.GetStatus()}}}
+	for _, component := range []string{ComponentApplicationController, ComponentRepoServer} {
+		status := ComponentStatus{Component: component}
+		if address := h.metricsAddresses[component]; address == "" {
+			status.Error = "metrics address is not configured"
+		} else if remote, err := h.getRemoteStatus(r, address); err != nil {
+			status.Error = err.Error()
+		} else {
+			status.Status = *remote
+		}
+		response.Components = append(response.Components, status)
+	}
+	response.Compliant = true
+	for _, status := range response.Components {
+		response.Compliant = response.Compliant && status.Compliant
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+// getRemoteStatus retrieves the FIPS compliance status of a remote component from the status endpoint on its metrics
+// port
+func (h *Handler) getRemoteStatus(r *http.Request, address string) (*fips.Status, error) {
+	statusURL := url.URL{Scheme: "http", Host: address, Path: fips.StatusPath}
+	req, err := http.NewRequestWithContext(r.Context(), http.MethodGet, statusURL.String(), http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get FIPS status: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get FIPS status: unexpected status code %d", resp.StatusCode)
+	}
+	var status fips.Status
+	if err := json.NewDecoder(resp.Body).Decode(&status); err != nil {
+		return nil, fmt.Errorf("failed to decode FIPS status: %w", err)
+	}
+	return &status, nil
+}
@@ -0,0 +1,60 @@
+package fips
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/argoproj/argo-cd/v3/util/fips"
+)
+
+func serve(t *testing.T, handler http.Handler) Response {
+	t.Helper()
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, URLPath, http.NoBody))
+	require.Equal(t, http.StatusOK, rr.Code)
+	var response Response
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	return response
+}
+
+func TestHandler(t *testing.T) {
+	repoServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, fips.StatusPath, r.URL.Path)
+		_ = json.NewEncoder(w).Encode(fips.Status{Enabled: true, CryptoModule: true, Compliant: true})
+	}))
+	defer repoServer.Close()
+
+	response := serve(t, NewHandler("", strings.TrimPrefix(repoServer.URL, "http://")))
+	require.Len(t, response.Components, 3)
+	assert.Equal(t, ComponentServer, response.Components[0].Component)
+	assert.Equal(t, fips.GetStatus(), response.Components[0].Status)
+	assert.Equal(t, ComponentApplicationController, response.Components[1].Component)
+	assert.NotEmpty(t, response.Components[1].Error)
+	assert.Equal(t, ComponentRepoServer, response.Components[2].Component)
+	assert.Empty(t, response.Components[2].Error)
+	assert.True(t, response.Components[2].Compliant)
+	assert.False(t, response.Compliant)
+}
+
+func TestHandler_RemoteError(t *testing.T) {
+	repoServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer repoServer.Close()
+
+	response := serve(t, NewHandler("", strings.TrimPrefix(repoServer.URL, "http://")))
+	require.Len(t, response.Components, 3)
+	assert.Contains(t, response.Components[2].Error, "unexpected status code 404")
+}
+
+func TestHandler_MethodNotAllowed(t *testing.T) {
+	rr := httptest.NewRecorder()
+	NewHandler("", "").ServeHTTP(rr, httptest.NewRequest(http.MethodPost, URLPath, http.NoBody))
+	assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+}
@@ -8,14 +8,20 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/redis/go-redis/v9"
 
 	"github.com/argoproj/argo-cd/v3/common"
+	repoapiclient "github.com/argoproj/argo-cd/v3/reposerver/apiclient"
+	cacheutil "github.com/argoproj/argo-cd/v3/util/cache"
+	"github.com/argoproj/argo-cd/v3/util/fips"
 	"github.com/argoproj/argo-cd/v3/util/metrics/kubectl"
 	"github.com/argoproj/argo-cd/v3/util/profile"
+	"github.com/argoproj/argo-cd/v3/util/settings"
 )
 
 type MetricsServer struct {
 	*http.Server
+	registry                 *prometheus.Registry
 	redisRequestCounter      *prometheus.CounterVec
 	redisRequestHistogram    *prometheus.HistogramVec
 	extensionRequestCounter  *prometheus.CounterVec
@@ -70,8 +76,9 @@ var (
 	)
 )
 
-// NewMetricsServer returns a new prometheus server which collects api server metrics
-func NewMetricsServer(host string, port int) *MetricsServer {
+// NewMetricsServer returns a new prometheus server which collects api server metrics, and returns the version of the
+// settings of the api server if the settings manager is set
+func NewMetricsServer(host string, port int, settingsMgr *settings.SettingsManager) *MetricsServer {
 	mux := http.NewServeMux()
 	registry := prometheus.NewRegistry()
 	mux.Handle("/metrics", promhttp.HandlerFor(prometheus.Gatherers{
@@ -81,6 +88,10 @@ func NewMetricsServer(host string, port int) *MetricsServer {
 	argoVersion.WithLabelValues(common.GetVersion().Version).Set(1)
 
 	profile.RegisterProfiler(mux)
+	fips.RegisterStatusHandler(mux)
+	if settingsMgr != nil {
+		settingsMgr.RegisterStatusHandler(mux)
+	}
 
 	registry.MustRegister(redisRequestCounter)
 	registry.MustRegister(redisRequestHistogram)
@@ -88,6 +99,7 @@ func NewMetricsServer(host string, port int) *MetricsServer {
 	registry.MustRegister(extensionRequestDuration)
 	registry.MustRegister(loginRequestCounter)
 	registry.MustRegister(argoVersion)
+	repoapiclient.RegisterMetrics(registry)
 
 	kubectl.RegisterWithClientGo()
 	kubectl.RegisterWithPrometheus(registry)
@@ -97,6 +109,7 @@ func NewMetricsServer(host string, port int) *MetricsServer {
 			Addr:    fmt.Sprintf("%s:%d", host, port),
 			Handler: mux,
 		},
+		registry:                 registry,
 		redisRequestCounter:      redisRequestCounter,
 		redisRequestHistogram:    redisRequestHistogram,
 		extensionRequestCounter:  extensionRequestCounter,
@@ -114,6 +127,11 @@ func (m *MetricsServer) ObserveRedisRequestDuration(duration time.Duration) {
 	m.redisRequestHistogram.WithLabelValues("argocd-server").Observe(duration.Seconds())
 }
 
+// RegisterRedisClient registers the collector of the health and connection pool metrics of the Redis client
+func (m *MetricsServer) RegisterRedisClient(client redis.UniversalClient) {
+	m.registry.MustRegister(cacheutil.NewRedisClientCollector(client, "argocd-server"))
+}
+
 func (m *MetricsServer) IncExtensionRequestCounter(extension string, status int) {
 	m.extensionRequestCounter.WithLabelValues(extension, strconv.Itoa(status)).Inc()
 }
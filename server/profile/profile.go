@@ -0,0 +1,103 @@
+package profile
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/argoproj/argo-cd/v3/util/profile"
+	"github.com/argoproj/argo-cd/v3/util/rbac"
+)
+
+const (
+	// URLPrefix is the prefix of the endpoint capturing the profiles of the components
+	URLPrefix = "/api/v1/profiles"
+
+	ComponentServer                = "server"
+	ComponentApplicationController = "application-controller"
+	ComponentRepoServer            = "repo-server"
+)
+
+// NewHandler creates a handler capturing the profiles of the Argo CD components on demand. The profiles of the API
+// server are captured in process, while the profiles of the application controller and the repo server are captured
+// from the profiling endpoint on their metrics port.
+func NewHandler(enf *rbac.Enforcer, controllerMetricsAddress string, repoServerMetricsAddress string) *Handler {
+	return &Handler{
+		enf: enf,
+		metricsAddresses: map[string]string{
+			ComponentApplicationController: controllerMetricsAddress,
+			ComponentRepoServer:            repoServerMetricsAddress,
+		},
+		client: &http.Client{Timeout: profile.MaxCaptureDuration + time.Minute},
+	}
+}
+
+// Handler serves the api/v1/profiles/<component> endpoint, which downloads a profile of the component. The type and
+// the duration of the profile are set by the 'type' and 'seconds' query parameters.
+type Handler struct {
+	enf *rbac.Enforcer
+	// metricsAddresses are the addresses of the metrics ports of the remote components
+	metricsAddresses map[string]string
+	client           *http.Client
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	component := strings.TrimPrefix(r.URL.Path, URLPrefix+"/")
+	if err := h.enf.EnforceErr(r.Context().Value("claims"), rbac.ResourceProfiles, rbac.ActionGet, component); err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+	profileType, _, err := profile.ParseCaptureRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	log.WithFields(log.Fields{"component": component, "type": profileType}).Info("Capturing profile")
+	if component == ComponentServer {
+		profile.CaptureHandler("argocd-"+component).ServeHTTP(w, r)
+		return
+	}
+	address, ok := h.metricsAddresses[component]
+	if !ok {
+		http.Error(w, fmt.Sprintf("unknown component %q, must be one of %s, %s or %s", component, ComponentServer, ComponentApplicationController, ComponentRepoServer), http.StatusNotFound)
+		return
+	}
+	h.captureRemote(w, r, component, address)
+}
+
+// captureRemote captures the profile of a remote component from the profiling endpoint on its metrics port
+func (h *Handler) captureRemote(w http.ResponseWriter, r *http.Request, component string, address string) {
+	captureURL := url.URL{Scheme: "http", Host: address, Path: profile.CapturePath, RawQuery: r.URL.RawQuery}
+	req, err := http.NewRequestWithContext(r.Context(), http.MethodGet, captureURL.String(), http.NoBody)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	resp, err := h.client.Do(req)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to capture profile of %s: %v", component, err), http.StatusBadGateway)
+		return
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		http.Error(w, fmt.Sprintf("failed to capture profile of %s: %s", component, strings.TrimSpace(string(message))), http.StatusBadGateway)
+		return
+	}
+	for _, header := range []string{"Content-Type", "Content-Disposition"} {
+		w.Header().Set(header, resp.Header.Get(header))
+	}
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		log.Warnf("Failed to download profile of %s: %v", component, err)
+	}
+}
@@ -0,0 +1,80 @@
+package profile
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/argoproj/argo-cd/v3/common"
+	"github.com/argoproj/argo-cd/v3/util/profile"
+	"github.com/argoproj/argo-cd/v3/util/rbac"
+)
+
+func newTestHandler(t *testing.T, allowed bool, repoServerMetricsAddress string) *Handler {
+	t.Helper()
+	enforcer := rbac.NewEnforcer(fake.NewClientset(), "argocd", common.ArgoCDRBACConfigMapName, nil)
+	enforcer.SetClaimsEnforcerFunc(func(_ jwt.Claims, _ ...any) bool {
+		return allowed
+	})
+	return NewHandler(enforcer, "localhost:0", repoServerMetricsAddress)
+}
+
+func serve(handler http.Handler, target string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, target, http.NoBody)
+	//nolint:staticcheck
+	req = req.WithContext(context.WithValue(req.Context(), "claims", &jwt.RegisteredClaims{Subject: "admin"}))
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	return rr
+}
+
+func TestHandler_Forbidden(t *testing.T) {
+	rr := serve(newTestHandler(t, false, ""), URLPrefix+"/server?type=heap")
+	assert.Equal(t, http.StatusForbidden, rr.Code)
+}
+
+func TestHandler_Server(t *testing.T) {
+	rr := serve(newTestHandler(t, true, ""), URLPrefix+"/server?type=goroutine")
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.True(t, strings.HasPrefix(rr.Header().Get("Content-Disposition"), `attachment; filename="argocd-server-goroutine-`))
+	assert.NotEmpty(t, rr.Body.Bytes())
+}
+
+func TestHandler_RepoServer(t *testing.T) {
+	repoServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, profile.CapturePath, r.URL.Path)
+		assert.Equal(t, "heap", r.URL.Query().Get("type"))
+		w.Header().Set("Content-Disposition", `attachment; filename="argocd-repo-server-heap.pb.gz"`)
+		_, _ = w.Write([]byte("profile"))
+	}))
+	defer repoServer.Close()
+
+	rr := serve(newTestHandler(t, true, strings.TrimPrefix(repoServer.URL, "http://")), URLPrefix+"/repo-server?type=heap")
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, `attachment; filename="argocd-repo-server-heap.pb.gz"`, rr.Header().Get("Content-Disposition"))
+	assert.Equal(t, "profile", rr.Body.String())
+}
+
+func TestHandler_RepoServerProfilerDisabled(t *testing.T) {
+	repoServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "Profiler endpoint is not enabled in 'argocd-cmd-params-cm' ConfigMap", http.StatusUnauthorized)
+	}))
+	defer repoServer.Close()
+
+	rr := serve(newTestHandler(t, true, strings.TrimPrefix(repoServer.URL, "http://")), URLPrefix+"/repo-server?type=heap")
+	assert.Equal(t, http.StatusBadGateway, rr.Code)
+	assert.Contains(t, rr.Body.String(), "Profiler endpoint is not enabled")
+}
+
+func TestHandler_InvalidRequest(t *testing.T) {
+	handler := newTestHandler(t, true, "")
+	assert.Equal(t, http.StatusNotFound, serve(handler, URLPrefix+"/dex-server?type=heap").Code)
+	assert.Equal(t, http.StatusBadRequest, serve(handler, URLPrefix+"/server?seconds=abc").Code)
+}
@@ -76,6 +76,9 @@ func validateProject(proj *v1alpha1.AppProject) error {
 	if err != nil {
 		return status.Errorf(codes.InvalidArgument, "policy syntax error: %s", err.Error())
 	}
+	if proj.Spec.TrackingMethod != "" && !argo.IsTrackingMethodSupported(proj.Spec.TrackingMethod) {
+		return status.Errorf(codes.InvalidArgument, "unsupported tracking method %q", proj.Spec.TrackingMethod)
+	}
 	return nil
 }
 
@@ -284,12 +287,15 @@ func (s *Server) Create(ctx context.Context, q *project.ProjectCreateRequest) (*
 }
 
 // List returns list of projects
-func (s *Server) List(ctx context.Context, _ *project.ProjectQuery) (*v1alpha1.AppProjectList, error) {
+func (s *Server) List(ctx context.Context, q *project.ProjectQuery) (*v1alpha1.AppProjectList, error) {
 	list, err := s.appclientset.ArgoprojV1alpha1().AppProjects(s.ns).List(ctx, metav1.ListOptions{})
 	if list != nil {
 		newItems := make([]v1alpha1.AppProject, 0)
 		for i := range list.Items {
 			project := list.Items[i]
+			if !strings.HasPrefix(project.Name, q.GetNamePrefix()) {
+				continue
+			}
 			if s.enf.Enforce(ctx.Value("claims"), rbac.ResourceProjects, rbac.ActionGet, project.Name) {
 				newItems = append(newItems, project)
 			}
@@ -328,6 +328,23 @@ func TestProjectServer(t *testing.T) {
 		require.NoError(t, err)
 	})
 
+	t.Run("TestListProjectsByNamePrefix", func(t *testing.T) {
+		otherProj := v1alpha1.AppProject{
+			ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: testNamespace},
+		}
+		argoDB := db.NewDB("default", settingsMgr, kubeclientset)
+		projectServer := NewServer(testNamespace, fake.NewSimpleClientset(), apps.NewSimpleClientset(&existingProj, &otherProj), enforcer, sync.NewKeyLock(), nil, nil, projInformer, settingsMgr, argoDB, testEnableEventList)
+
+		list, err := projectServer.List(t.Context(), &project.ProjectQuery{NamePrefix: "te"})
+		require.NoError(t, err)
+		require.Len(t, list.Items, 1)
+		assert.Equal(t, "test", list.Items[0].Name)
+
+		list, err = projectServer.List(t.Context(), &project.ProjectQuery{})
+		require.NoError(t, err)
+		assert.Len(t, list.Items, 2)
+	})
+
 	t.Run("TestDeleteDefaultProjectFailure", func(t *testing.T) {
 		defaultProj := v1alpha1.AppProject{
 			ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "default"},
@@ -680,6 +697,20 @@ p, role:admin, projects, update, *, allow`)
 		assert.ErrorContains(t, err, "effect must be: 'allow' or 'deny'")
 	})
 
+	t.Run("TestValidateProjectUnsupportedTrackingMethodFailure", func(t *testing.T) {
+		proj := existingProj.DeepCopy()
+		proj.Spec.TrackingMethod = "ownerReference"
+		argoDB := db.NewDB("default", settingsMgr, kubeclientset)
+		projectServer := NewServer("default", fake.NewSimpleClientset(), apps.NewSimpleClientset(proj), enforcer, sync.NewKeyLock(), nil, policyEnf, projInformer, settingsMgr, argoDB, testEnableEventList)
+		_, err := projectServer.Update(t.Context(), &project.ProjectUpdateRequest{Project: proj})
+		require.ErrorContains(t, err, `unsupported tracking method "ownerReference"`)
+
+		proj.Spec.TrackingMethod = v1alpha1.TrackingMethodLabel
+		updatedProj, err := projectServer.Update(t.Context(), &project.ProjectUpdateRequest{Project: proj})
+		require.NoError(t, err)
+		assert.Equal(t, v1alpha1.TrackingMethodLabel, updatedProj.Spec.TrackingMethod)
+	})
+
 	t.Run("TestNormalizeProjectRolePolicies", func(t *testing.T) {
 		action := "create"
 		object := "testApplication"
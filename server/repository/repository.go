@@ -685,6 +685,7 @@ func (s *Server) ValidateAccess(ctx context.Context, q *repositorypkg.RepoAccess
 		Repo:                       q.Repo,
 		Type:                       q.Type,
 		Name:                       q.Name,
+		Project:                    q.Project,
 		Username:                   q.Username,
 		Password:                   q.Password,
 		BearerToken:                q.BearerToken,
@@ -701,6 +702,12 @@ func (s *Server) ValidateAccess(ctx context.Context, q *repositorypkg.RepoAccess
 		GCPServiceAccountKey:       q.GcpServiceAccountKey,
 		InsecureOCIForceHttp:       q.InsecureOciForceHttp,
 		UseAzureWorkloadIdentity:   q.UseAzureWorkloadIdentity,
+		OIDCTokenExchangeURL:       q.OidcTokenExchangeURL,
+		OIDCTokenExchangeAudience:  q.OidcTokenExchangeAudience,
+		UseKerberos:                q.UseKerberos,
+		NoProxy:                    q.NoProxy,
+		ProxyUsername:              q.ProxyUsername,
+		ProxyPassword:              q.ProxyPassword,
 	}
 
 	// If repo does not have credentials, check if there are credentials stored
@@ -714,6 +721,9 @@ func (s *Server) ValidateAccess(ctx context.Context, q *repositorypkg.RepoAccess
 			repo.CopyCredentialsFrom(repoCreds)
 		}
 	}
+	if err := db.ValidateOIDCTokenExchange(s.settings, repo.OIDCTokenExchangeURL, repo.Project); err != nil {
+		return nil, err
+	}
 	err := s.testRepo(ctx, repo)
 	if err != nil {
 		return nil, err
@@ -736,6 +746,7 @@ func (s *Server) ValidateWriteAccess(ctx context.Context, q *repositorypkg.RepoA
 		Repo:                       q.Repo,
 		Type:                       q.Type,
 		Name:                       q.Name,
+		Project:                    q.Project,
 		Username:                   q.Username,
 		Password:                   q.Password,
 		BearerToken:                q.BearerToken,
@@ -751,8 +762,17 @@ func (s *Server) ValidateWriteAccess(ctx context.Context, q *repositorypkg.RepoA
 		Proxy:                      q.Proxy,
 		GCPServiceAccountKey:       q.GcpServiceAccountKey,
 		UseAzureWorkloadIdentity:   q.UseAzureWorkloadIdentity,
+		OIDCTokenExchangeURL:       q.OidcTokenExchangeURL,
+		OIDCTokenExchangeAudience:  q.OidcTokenExchangeAudience,
+		UseKerberos:                q.UseKerberos,
+		NoProxy:                    q.NoProxy,
+		ProxyUsername:              q.ProxyUsername,
+		ProxyPassword:              q.ProxyPassword,
 	}
 
+	if err := db.ValidateOIDCTokenExchange(s.settings, repo.OIDCTokenExchangeURL, repo.Project); err != nil {
+		return nil, err
+	}
 	err := s.testRepo(ctx, repo)
 	if err != nil {
 		return nil, err
@@ -93,10 +93,12 @@ import (
 	"github.com/argoproj/argo-cd/v3/server/certificate"
 	"github.com/argoproj/argo-cd/v3/server/cluster"
 	"github.com/argoproj/argo-cd/v3/server/extension"
+	"github.com/argoproj/argo-cd/v3/server/fips"
 	"github.com/argoproj/argo-cd/v3/server/gpgkey"
 	"github.com/argoproj/argo-cd/v3/server/logout"
 	"github.com/argoproj/argo-cd/v3/server/metrics"
 	"github.com/argoproj/argo-cd/v3/server/notification"
+	"github.com/argoproj/argo-cd/v3/server/profile"
 	"github.com/argoproj/argo-cd/v3/server/project"
 	"github.com/argoproj/argo-cd/v3/server/rbacpolicy"
 	"github.com/argoproj/argo-cd/v3/server/repocreds"
@@ -125,6 +127,7 @@ import (
 	"github.com/argoproj/argo-cd/v3/util/rbac"
 	util_session "github.com/argoproj/argo-cd/v3/util/session"
 	settings_util "github.com/argoproj/argo-cd/v3/util/settings"
+	"github.com/argoproj/argo-cd/v3/util/settings/validation"
 	"github.com/argoproj/argo-cd/v3/util/swagger"
 	tlsutil "github.com/argoproj/argo-cd/v3/util/tls"
 	"github.com/argoproj/argo-cd/v3/util/webhook"
@@ -229,16 +232,21 @@ type ArgoCDServerOpts struct {
 	RepoClientset           repoapiclient.Clientset
 	Cache                   *servercache.Cache
 	RepoServerCache         *repocache.Cache
-	RedisClient             *redis.Client
+	RedisClient             redis.UniversalClient
 	TLSConfigCustomizer     tlsutil.ConfigCustomizer
 	XFrameOptions           string
 	ContentSecurityPolicy   string
 	ApplicationNamespaces   []string
 	EnableProxyExtension    bool
 	WebhookParallelism      int
+	WebhookPrecacheLimit    int
 	EnableK8sEvent          []string
 	HydratorEnabled         bool
 	SyncWithReplaceAllowed  bool
+	// ControllerMetricsAddress and RepoServerMetricsAddress are the addresses the profiles and the FIPS compliance
+	// status of the application controller and the repo server are retrieved from
+	ControllerMetricsAddress string
+	RepoServerMetricsAddress string
 }
 
 type ApplicationSetOpts struct {
@@ -349,7 +357,7 @@ func NewServer(ctx context.Context, opts ArgoCDServerOpts, appsetOpts Applicatio
 	secretInformer := k8s.NewSecretInformer(opts.KubeClientset, opts.Namespace, "argocd-notifications-secret")
 	configMapInformer := k8s.NewConfigMapInformer(opts.KubeClientset, opts.Namespace, "argocd-notifications-cm")
 
-	apiFactory := api.NewFactory(settings_notif.GetFactorySettings(argocdService, "argocd-notifications-secret", "argocd-notifications-cm", false), opts.Namespace, secretInformer, configMapInformer)
+	apiFactory := api.NewFactory(settings_notif.GetFactorySettings(argocdService, "argocd-notifications-secret", "argocd-notifications-cm", false, nil), opts.Namespace, secretInformer, configMapInformer)
 
 	dbInstance := db.NewDB(opts.Namespace, settingsMgr, opts.KubeClientset)
 	logger := log.NewEntry(log.StandardLogger())
@@ -562,7 +570,7 @@ func (server *ArgoCDServer) Run(ctx context.Context, listeners *Listeners) {
 		}
 	}()
 
-	metricsServ := metrics.NewMetricsServer(server.MetricsHost, server.MetricsPort)
+	metricsServ := metrics.NewMetricsServer(server.MetricsHost, server.MetricsPort, server.settingsMgr)
 	if server.RedisClient != nil {
 		cacheutil.CollectMetrics(server.RedisClient, metricsServ, server.userStateStorage.GetLockObject())
 	}
@@ -1209,6 +1217,14 @@ func (server *ArgoCDServer) newHTTPServer(ctx context.Context, port int, grpcWeb
 	th := util_session.WithAuthMiddleware(server.DisableAuth, server.sessionMgr, terminal)
 	mux.Handle("/terminal", th)
 
+	// Profiles of the components are captured on demand by admins
+	profileHandler := profile.NewHandler(server.enf, server.ControllerMetricsAddress, server.RepoServerMetricsAddress)
+	mux.Handle(profile.URLPrefix+"/", util_session.WithAuthMiddleware(server.DisableAuth, server.sessionMgr, profileHandler))
+
+	// FIPS compliance status of the components
+	fipsHandler := fips.NewHandler(server.ControllerMetricsAddress, server.RepoServerMetricsAddress)
+	mux.Handle(fips.URLPath, util_session.WithAuthMiddleware(server.DisableAuth, server.sessionMgr, fipsHandler))
+
 	// Proxy extension is currently an alpha feature and is disabled
 	// by default.
 	if server.EnableProxyExtension {
@@ -1241,10 +1257,13 @@ func (server *ArgoCDServer) newHTTPServer(ctx context.Context, port int, grpcWeb
 
 	// Webhook handler for git events (Note: cache timeouts are hardcoded because API server does not write to cache and not really using them)
 	argoDB := db.NewDB(server.Namespace, server.settingsMgr, server.KubeClientset)
-	acdWebhookHandler := webhook.NewHandler(server.Namespace, server.ApplicationNamespaces, server.WebhookParallelism, server.AppClientset, server.settings, server.settingsMgr, server.RepoServerCache, server.Cache, argoDB, server.settingsMgr.GetMaxWebhookPayloadSize())
+	acdWebhookHandler := webhook.NewHandler(server.Namespace, server.ApplicationNamespaces, server.WebhookParallelism, server.WebhookPrecacheLimit, server.AppClientset, server.RepoClientset, server.settings, server.settingsMgr, server.RepoServerCache, server.Cache, argoDB, server.settingsMgr.GetMaxWebhookPayloadSize())
 
 	mux.HandleFunc("/api/webhook", acdWebhookHandler.Handler)
 
+	// Validating admission webhook rejecting the malformed updates of the settings config maps
+	mux.Handle(validation.AdmissionPath, validation.NewAdmissionHandler(server.Namespace))
+
 	// Serve cli binaries directly from API server
 	registerDownloadHandlers(mux, "/download")
 
@@ -170,6 +170,9 @@ export const ApplicationNodeInfo = (props: {
                 )
             } as any);
         }
+        if (props.controlled.summary.dryRunError) {
+            attributes.push({title: 'DRY RUN ERROR', value: `${props.controlled.summary.dryRunError.reason}: ${props.controlled.summary.dryRunError.message}`});
+        }
         if (props.controlled.summary.health !== undefined) {
             attributes.push({
                 title: 'HEALTH',
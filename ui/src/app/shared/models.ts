@@ -396,6 +396,12 @@ export interface ResourceStatus {
     requiresDeletionConfirmation?: boolean;
     syncWave?: number;
     orphaned?: boolean;
+    dryRunError?: ResourceDryRunError;
+}
+
+export interface ResourceDryRunError {
+    reason: string;
+    message: string;
 }
 
 export interface ResourceRef {
@@ -438,6 +444,10 @@ export interface ApplicationTree {
     nodes: ResourceNode[];
     orphanedNodes: ResourceNode[];
     hosts: Node[];
+    version?: string;
+    delta?: boolean;
+    removedNodes?: ResourceRef[];
+    removedOrphanedNodes?: ResourceRef[];
 }
 
 export interface ResourceID {
@@ -483,6 +493,7 @@ export interface ApplicationStatus {
     operationState?: OperationState;
     summary?: ApplicationSummary;
     sourceHydrator?: SourceHydratorStatus;
+    toolVersions?: string[];
 }
 
 export interface SourceHydratorStatus {
@@ -798,9 +809,17 @@ export interface ProjectSignatureKey {
     keyID: string;
 }
 
+export interface SourceNamespaceRule {
+    pattern: string;
+    maxApps?: number;
+    defaultDestination?: ApplicationDestination;
+}
+
 export interface ProjectSpec {
     sourceRepos: string[];
     sourceNamespaces: string[];
+    sourceNamespaceRules?: SourceNamespaceRule[];
+    trackingMethod?: string;
     destinations: ApplicationDestination[];
     destinationServiceAccounts: ApplicationDestinationServiceAccount[];
     description: string;
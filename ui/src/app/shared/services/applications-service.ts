@@ -1,6 +1,6 @@
 import * as deepMerge from 'deepmerge';
 import {Observable} from 'rxjs';
-import {map, repeat, retry} from 'rxjs/operators';
+import {map, repeat, retry, scan} from 'rxjs/operators';
 
 import * as models from '../models';
 import {isValidURL} from '../utils';
@@ -13,6 +13,28 @@ interface QueryOptions {
     appNamespace?: string;
 }
 
+function resourceRefKey(ref: models.ResourceRef) {
+    return `${ref.group}/${ref.kind}/${ref.namespace}/${ref.name}`;
+}
+
+function applyNodesDelta(nodes: models.ResourceNode[], upserted: models.ResourceNode[], removed: models.ResourceRef[]) {
+    const byKey = new Map<string, models.ResourceNode>();
+    (nodes || []).forEach(node => byKey.set(resourceRefKey(node), node));
+    (removed || []).forEach(ref => byKey.delete(resourceRefKey(ref)));
+    (upserted || []).forEach(node => byKey.set(resourceRefKey(node), node));
+    return Array.from(byKey.values());
+}
+
+// applyTreeDelta applies the changes streamed by the resource tree watch to the previous version of the tree
+function applyTreeDelta(tree: models.ApplicationTree, delta: models.ApplicationTree): models.ApplicationTree {
+    return {
+        nodes: applyNodesDelta(tree.nodes, delta.nodes, delta.removedNodes),
+        orphanedNodes: applyNodesDelta(tree.orphanedNodes, delta.orphanedNodes, delta.removedOrphanedNodes),
+        hosts: delta.hosts,
+        version: delta.version
+    };
+}
+
 function optionsToSearch(options?: QueryOptions) {
     if (options) {
         return {fields: (options.exclude ? '-' : '') + options.fields.join(','), selector: options.selector || '', appNamespace: options.appNamespace || ''};
@@ -97,8 +119,9 @@ export class ApplicationsService {
 
     public watchResourceTree(name: string, appNamespace: string): Observable<models.ApplicationTree> {
         return requests
-            .loadEventSource(`/stream/applications/${name}/resource-tree?appNamespace=${appNamespace}`)
-            .pipe(map(data => JSON.parse(data).result as models.ApplicationTree));
+            .loadEventSource(`/stream/applications/${name}/resource-tree?appNamespace=${appNamespace}&delta=true`)
+            .pipe(map(data => JSON.parse(data).result as models.ApplicationTree))
+            .pipe(scan((tree: models.ApplicationTree, update: models.ApplicationTree) => (update.delta && tree ? applyTreeDelta(tree, update) : update), null));
     }
 
     public managedResources(name: string, appNamespace: string, options: {id?: models.ResourceID; fields?: string[]} = {}): Promise<models.ResourceDiff[]> {
@@ -26,6 +26,7 @@ import (
 
 	"github.com/argoproj/argo-cd/v3/util/gpg"
 
+	"github.com/argoproj/argo-cd/v3/pkg/apis/application"
 	argoappv1 "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
 	"github.com/argoproj/argo-cd/v3/pkg/client/clientset/versioned/typed/application/v1alpha1"
 	applicationsv1 "github.com/argoproj/argo-cd/v3/pkg/client/listers/application/v1alpha1"
@@ -225,6 +226,33 @@ func FilterByNameP(apps []*argoappv1.Application, name string) []*argoappv1.Appl
 	return items
 }
 
+// FilterByNamePrefixP returns pointer applications whose name starts with the given prefix
+func FilterByNamePrefixP(apps []*argoappv1.Application, prefix string) []*argoappv1.Application {
+	if prefix == "" {
+		return apps
+	}
+	items := make([]*argoappv1.Application, 0)
+	for i := 0; i < len(apps); i++ {
+		if strings.HasPrefix(apps[i].Name, prefix) {
+			items = append(items, apps[i])
+		}
+	}
+	return items
+}
+
+// FilterByFrozenP returns pointer applications which are frozen at the given time if frozen is true, or which aren't
+// frozen otherwise. The applications whose freeze is invalid aren't frozen.
+func FilterByFrozenP(apps []*argoappv1.Application, frozen bool, now time.Time) []*argoappv1.Application {
+	items := make([]*argoappv1.Application, 0)
+	for i := 0; i < len(apps); i++ {
+		freeze, _ := apps[i].GetFreeze()
+		if freeze.IsActive(now) == frozen {
+			items = append(items, apps[i])
+		}
+	}
+	return items
+}
+
 // RefreshApp updates the refresh annotation of an application to coerce the controller to process it
 func RefreshApp(appIf v1alpha1.ApplicationInterface, name string, refreshType argoappv1.RefreshType, hydrate bool) (*argoappv1.Application, error) {
 	metadata := map[string]any{
@@ -413,6 +441,9 @@ func validateRepo(ctx context.Context,
 	errMessage := ""
 
 	for _, source := range sources {
+		if source.IsApplicationRef() {
+			continue
+		}
 		repo, err := db.GetRepository(ctx, source.RepoURL, proj.Name)
 		if err != nil {
 			return nil, err
@@ -499,7 +530,7 @@ func GetRefSources(ctx context.Context, sources argoappv1.ApplicationSources, pr
 		}
 		// Get Repositories for all sources before generating Manifests
 		for i, source := range sources {
-			if source.Ref == "" {
+			if source.Ref == "" || source.IsApplicationRef() {
 				continue
 			}
 
@@ -522,6 +553,40 @@ func GetRefSources(ctx context.Context, sources argoappv1.ApplicationSources, pr
 	return refSources, nil
 }
 
+// GetApplicationRefSources creates a map of ref keys to the ref sources referencing the outputs of another application
+func GetApplicationRefSources(sources argoappv1.ApplicationSources) map[string]*argoappv1.ApplicationSource {
+	appRefSources := make(map[string]*argoappv1.ApplicationSource)
+	for i := range sources {
+		if sources[i].IsApplicationRef() {
+			appRefSources["$"+sources[i].Ref] = &sources[i]
+		}
+	}
+	return appRefSources
+}
+
+// SplitApplicationRefValueFiles returns a copy of the source without the Helm value files referencing the outputs of
+// another application, along with these value files.
+func SplitApplicationRefValueFiles(source argoappv1.ApplicationSource, appRefSources map[string]*argoappv1.ApplicationSource) (argoappv1.ApplicationSource, []string) {
+	if source.Helm == nil || len(appRefSources) == 0 {
+		return source, nil
+	}
+	var valueFiles, appRefValueFiles []string
+	for _, valueFile := range source.Helm.ValueFiles {
+		refKey, _, _ := strings.Cut(valueFile, "/")
+		if _, ok := appRefSources[refKey]; ok {
+			appRefValueFiles = append(appRefValueFiles, valueFile)
+		} else {
+			valueFiles = append(valueFiles, valueFile)
+		}
+	}
+	if len(appRefValueFiles) == 0 {
+		return source, nil
+	}
+	result := source.DeepCopy()
+	result.Helm.ValueFiles = valueFiles
+	return *result, appRefValueFiles
+}
+
 func validateSourcePermissions(source argoappv1.ApplicationSource, hasMultipleSources bool) []argoappv1.ApplicationCondition {
 	var conditions []argoappv1.ApplicationCondition
 	if hasMultipleSources {
@@ -765,7 +830,14 @@ func verifyGenerateManifests(
 		return conditions // Can't perform the next check without settings.
 	}
 
+	appRefSources := GetApplicationRefSources(sources)
 	for _, source := range sources {
+		if source.IsApplicationRef() {
+			continue
+		}
+		// The outputs of other applications are only available to the application controller
+		source, _ = SplitApplicationRefValueFiles(source, appRefSources)
+		source = proj.GetApplicationSource(source)
 		repoRes, err := db.GetRepository(ctx, source.RepoURL, proj.Name)
 		if err != nil {
 			conditions = append(conditions, argoappv1.ApplicationCondition{
@@ -792,7 +864,7 @@ func verifyGenerateManifests(
 			continue
 		}
 
-		trackingMethod, err := settingsMgr.GetTrackingMethod()
+		trackingMethod, err := GetTrackingMethod(settingsMgr, proj)
 		if err != nil {
 			conditions = append(conditions, argoappv1.ApplicationCondition{
 				Type:    argoappv1.ApplicationConditionInvalidSpecError,
@@ -820,11 +892,13 @@ func verifyGenerateManifests(
 
 		req := apiclient.ManifestRequest{
 			Repo: &argoappv1.Repository{
-				Repo:    source.RepoURL,
-				Type:    repoRes.Type,
-				Name:    repoRes.Name,
-				Proxy:   repoRes.Proxy,
-				NoProxy: repoRes.NoProxy,
+				Repo:          source.RepoURL,
+				Type:          repoRes.Type,
+				Name:          repoRes.Name,
+				Proxy:         repoRes.Proxy,
+				NoProxy:       repoRes.NoProxy,
+				ProxyUsername: repoRes.ProxyUsername,
+				ProxyPassword: repoRes.ProxyPassword,
 			},
 			VerifySignature:                 verifySignature,
 			Repos:                           repos,
@@ -868,6 +942,10 @@ func verifyGenerateManifests(
 
 // SetAppOperation updates an application with the specified operation, retrying conflict errors
 func SetAppOperation(appIf v1alpha1.ApplicationInterface, appName string, op *argoappv1.Operation) (*argoappv1.Application, error) {
+	if op.RequestedAt == nil {
+		now := metav1.Now()
+		op.RequestedAt = &now
+	}
 	for {
 		a, err := appIf.Get(context.Background(), appName, metav1.GetOptions{})
 		if err != nil {
@@ -880,7 +958,7 @@ func SetAppOperation(appIf v1alpha1.ApplicationInterface, appName string, op *ar
 		a.Operation = op
 		a.Status.OperationState = nil
 		a, err = appIf.Update(context.Background(), a, metav1.UpdateOptions{})
-		if op.Sync == nil {
+		if op.Sync == nil && op.Cascade == nil {
 			return nil, status.Errorf(codes.InvalidArgument, "Operation unspecified")
 		}
 		if err == nil {
@@ -893,6 +971,25 @@ func SetAppOperation(appIf v1alpha1.ApplicationInterface, appName string, op *ar
 	}
 }
 
+// GetChildApplications returns the applications managed by the given application, as recorded in its status.
+func GetChildApplications(app *argoappv1.Application) []types.NamespacedName {
+	var children []types.NamespacedName
+	for _, res := range app.Status.Resources {
+		if res.Group != application.Group || res.Kind != application.ApplicationKind {
+			continue
+		}
+		namespace := res.Namespace
+		if namespace == "" {
+			namespace = app.Namespace
+		}
+		if namespace == app.Namespace && res.Name == app.Name {
+			continue
+		}
+		children = append(children, types.NamespacedName{Namespace: namespace, Name: res.Name})
+	}
+	return children
+}
+
 // ContainsSyncResource determines if the given resource exists in the provided slice of sync operation resources.
 func ContainsSyncResource(name string, namespace string, gvk schema.GroupVersionKind, rr []argoappv1.SyncOperationResource) bool {
 	for _, r := range rr {
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/argoproj/gitops-engine/pkg/utils/kube"
 	"github.com/argoproj/gitops-engine/pkg/utils/kube/kubetest"
@@ -18,6 +19,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/tools/cache"
 
@@ -918,8 +920,41 @@ func TestSetAppOperations(t *testing.T) {
 		appIf := appclientset.NewSimpleClientset(&a).ArgoprojV1alpha1().Applications("default")
 		app, err := SetAppOperation(appIf, "someapp", &argoappv1.Operation{Sync: &argoappv1.SyncOperation{Revision: "aaa"}})
 		require.NoError(t, err)
-		assert.NotNil(t, app)
+		require.NotNil(t, app)
+		assert.NotNil(t, app.Operation.RequestedAt)
 	})
+	t.Run("Cascade", func(t *testing.T) {
+		a := argoappv1.Application{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "someapp",
+				Namespace: "default",
+			},
+		}
+		appIf := appclientset.NewSimpleClientset(&a).ArgoprojV1alpha1().Applications("default")
+		app, err := SetAppOperation(appIf, "someapp", &argoappv1.Operation{Cascade: &argoappv1.CascadeOperation{Type: argoappv1.CascadeOperationTypeSync}})
+		require.NoError(t, err)
+		require.NotNil(t, app)
+		assert.NotNil(t, app.Operation.Cascade)
+	})
+}
+
+func TestGetChildApplications(t *testing.T) {
+	app := &argoappv1.Application{
+		ObjectMeta: metav1.ObjectMeta{Name: "parent", Namespace: "argocd"},
+		Status: argoappv1.ApplicationStatus{Resources: []argoappv1.ResourceStatus{
+			{Group: "argoproj.io", Kind: "Application", Name: "child-1"},
+			{Group: "argoproj.io", Kind: "Application", Namespace: "apps", Name: "child-2"},
+			{Group: "argoproj.io", Kind: "Application", Namespace: "argocd", Name: "parent"},
+			{Group: "argoproj.io", Kind: "AppProject", Namespace: "argocd", Name: "default"},
+			{Kind: "ConfigMap", Namespace: "argocd", Name: "child-3"},
+		}},
+	}
+
+	assert.Equal(t, []types.NamespacedName{
+		{Namespace: "argocd", Name: "child-1"},
+		{Namespace: "apps", Name: "child-2"},
+	}, GetChildApplications(app))
+	assert.Empty(t, GetChildApplications(&argoappv1.Application{}))
 }
 
 func TestGetDestinationCluster(t *testing.T) {
@@ -1076,6 +1111,52 @@ func TestFilterByNameP(t *testing.T) {
 	})
 }
 
+func TestFilterByNamePrefixP(t *testing.T) {
+	apps := []*argoappv1.Application{
+		{ObjectMeta: metav1.ObjectMeta{Name: "foo"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "foobar"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "bar"}},
+	}
+
+	t.Run("Prefix is empty string", func(t *testing.T) {
+		res := FilterByNamePrefixP(apps, "")
+		assert.Len(t, res, 3)
+	})
+
+	t.Run("Apps by prefix", func(t *testing.T) {
+		res := FilterByNamePrefixP(apps, "foo")
+		require.Len(t, res, 2)
+		assert.Equal(t, "foo", res[0].Name)
+		assert.Equal(t, "foobar", res[1].Name)
+	})
+
+	t.Run("No matching app", func(t *testing.T) {
+		res := FilterByNamePrefixP(apps, "baz")
+		assert.Empty(t, res)
+	})
+}
+
+func TestFilterByFrozenP(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	apps := []*argoappv1.Application{
+		{ObjectMeta: metav1.ObjectMeta{Name: "frozen", Annotations: map[string]string{argoappv1.AnnotationKeyFreeze: `{"frozenAt":"2025-12-31T00:00:00Z"}`}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "frozen-until", Annotations: map[string]string{argoappv1.AnnotationKeyFreeze: `{"frozenAt":"2025-12-31T00:00:00Z","until":"2026-01-02T00:00:00Z"}`}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "expired", Annotations: map[string]string{argoappv1.AnnotationKeyFreeze: `{"frozenAt":"2025-12-31T00:00:00Z","until":"2025-12-31T12:00:00Z"}`}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "invalid", Annotations: map[string]string{argoappv1.AnnotationKeyFreeze: "yes"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "not-frozen"}},
+	}
+	names := func(apps []*argoappv1.Application) []string {
+		var result []string
+		for _, a := range apps {
+			result = append(result, a.Name)
+		}
+		return result
+	}
+
+	assert.Equal(t, []string{"frozen", "frozen-until"}, names(FilterByFrozenP(apps, true, now)))
+	assert.Equal(t, []string{"expired", "invalid", "not-frozen"}, names(FilterByFrozenP(apps, false, now)))
+}
+
 func TestGetGlobalProjects(t *testing.T) {
 	t.Run("Multiple global projects", func(t *testing.T) {
 		namespace := "default"
@@ -1354,6 +1435,45 @@ func Test_GetRefSources(t *testing.T) {
 		require.Error(t, err)
 		assert.Empty(t, refSources)
 	})
+
+	t.Run("application ref", func(t *testing.T) {
+		argoSpec := getMultiSourceAppSpec(argoappv1.ApplicationSources{
+			{RepoURL: "argocd-app://argocd/platform", Ref: "platform"},
+			{RepoURL: "file://" + repoPath},
+		})
+
+		refSources, err := GetRefSources(t.Context(), argoSpec.Sources, argoSpec.Project, func(_ context.Context, _ string, _ string) (*argoappv1.Repository, error) {
+			return nil, errors.New("repo does not exist")
+		}, []string{})
+
+		require.NoError(t, err)
+		assert.Empty(t, refSources)
+	})
+}
+
+func Test_SplitApplicationRefValueFiles(t *testing.T) {
+	sources := argoappv1.ApplicationSources{
+		{RepoURL: "argocd-app://platform", Ref: "platform"},
+		{RepoURL: "https://github.com/argoproj/argocd-example-apps", Ref: "values"},
+		{
+			RepoURL: "https://argoproj.github.io/argo-helm",
+			Chart:   "argo-cd",
+			Helm: &argoappv1.ApplicationSourceHelm{
+				ValueFiles: []string{"values.yaml", "$platform/endpoints", "$values/values-prod.yaml", "$platform/endpoints/values.yaml"},
+			},
+		},
+	}
+	appRefSources := GetApplicationRefSources(sources)
+	assert.Equal(t, map[string]*argoappv1.ApplicationSource{"$platform": &sources[0]}, appRefSources)
+
+	source, appRefValueFiles := SplitApplicationRefValueFiles(sources[2], appRefSources)
+	assert.Equal(t, []string{"$platform/endpoints", "$platform/endpoints/values.yaml"}, appRefValueFiles)
+	assert.Equal(t, []string{"values.yaml", "$values/values-prod.yaml"}, source.Helm.ValueFiles)
+	assert.Len(t, sources[2].Helm.ValueFiles, 4)
+
+	source, appRefValueFiles = SplitApplicationRefValueFiles(sources[1], appRefSources)
+	assert.Empty(t, appRefValueFiles)
+	assert.Equal(t, sources[1], source)
 }
 
 func TestValidatePermissionsMultipleSources(t *testing.T) {
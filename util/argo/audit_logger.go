@@ -36,13 +36,19 @@ type ObjectRef struct {
 }
 
 const (
-	EventReasonStatusRefreshed    = "StatusRefreshed"
-	EventReasonResourceCreated    = "ResourceCreated"
-	EventReasonResourceUpdated    = "ResourceUpdated"
-	EventReasonResourceDeleted    = "ResourceDeleted"
-	EventReasonResourceActionRan  = "ResourceActionRan"
-	EventReasonOperationStarted   = "OperationStarted"
-	EventReasonOperationCompleted = "OperationCompleted"
+	EventReasonStatusRefreshed     = "StatusRefreshed"
+	EventReasonResourceCreated     = "ResourceCreated"
+	EventReasonResourceUpdated     = "ResourceUpdated"
+	EventReasonResourceDeleted     = "ResourceDeleted"
+	EventReasonResourceActionRan   = "ResourceActionRan"
+	EventReasonOperationStarted    = "OperationStarted"
+	EventReasonOperationCompleted  = "OperationCompleted"
+	EventReasonBreakGlassRequested = "BreakGlassRequested"
+	EventReasonCredentialsHealthy  = "CredentialsHealthy"
+	EventReasonCredentialsExpiring = "CredentialsExpiring"
+	EventReasonCredentialsDegraded = "CredentialsDegraded"
+	EventReasonFrozen              = "Frozen"
+	EventReasonThawed              = "Thawed"
 )
 
 func (l *AuditLogger) logEvent(objMeta ObjectRef, gvk schema.GroupVersionKind, info EventInfo, message string, logFields map[string]string, eventLabels map[string]string) {
@@ -178,6 +184,21 @@ func (l *AuditLogger) LogAppProjEvent(proj *v1alpha1.AppProject, info EventInfo,
 	l.logEvent(objectMeta, v1alpha1.AppProjectSchemaGroupVersionKind, info, message, nil, nil)
 }
 
+// LogSecretEvent logs an event about a secret, e.g. about the health of the credentials of a repository secret
+func (l *AuditLogger) LogSecretEvent(secret *corev1.Secret, info EventInfo, message string, logFields map[string]string) {
+	if !l.enableK8SEventLog(info) {
+		return
+	}
+
+	objectMeta := ObjectRef{
+		Name:            secret.Name,
+		Namespace:       secret.Namespace,
+		ResourceVersion: secret.ResourceVersion,
+		UID:             secret.UID,
+	}
+	l.logEvent(objectMeta, corev1.SchemeGroupVersion.WithKind("Secret"), info, message, logFields, nil)
+}
+
 func NewAuditLogger(kIf kubernetes.Interface, component string, enableK8sEvent []string) *AuditLogger {
 	return &AuditLogger{
 		kIf:            kIf,
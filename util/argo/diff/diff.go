@@ -105,6 +105,13 @@ func (b *DiffConfigBuilder) WithServerSideDryRunner(ssdr diff.ServerSideDryRunne
 	return b
 }
 
+// WithServerSideDryRunOpts defines the batching, parallelism and timeout of the
+// server-side dry-run applies run by the server-side diff.
+func (b *DiffConfigBuilder) WithServerSideDryRunOpts(opts ServerSideDryRunOpts) *DiffConfigBuilder {
+	b.diffConfig.serverSideDryRunOpts = opts
+	return b
+}
+
 func (b *DiffConfigBuilder) WithServerSideDiff(ssd bool) *DiffConfigBuilder {
 	b.diffConfig.serverSideDiff = ssd
 	return b
@@ -163,6 +170,9 @@ type DiffConfig interface {
 
 	ServerSideDiff() bool
 	ServerSideDryRunner() diff.ServerSideDryRunner
+	// ServerSideDryRunOpts returns the batching, parallelism and timeout of
+	// the server-side dry-run applies.
+	ServerSideDryRunOpts() ServerSideDryRunOpts
 	IgnoreMutationWebhook() bool
 
 	IgnoreNormalizerOpts() normalizers.IgnoreNormalizerOpts
@@ -184,6 +194,7 @@ type diffConfig struct {
 	manager               string
 	serverSideDiff        bool
 	serverSideDryRunner   diff.ServerSideDryRunner
+	serverSideDryRunOpts  ServerSideDryRunOpts
 	ignoreMutationWebhook bool
 	ignoreNormalizerOpts  normalizers.IgnoreNormalizerOpts
 }
@@ -240,6 +251,10 @@ func (c *diffConfig) ServerSideDryRunner() diff.ServerSideDryRunner {
 	return c.serverSideDryRunner
 }
 
+func (c *diffConfig) ServerSideDryRunOpts() ServerSideDryRunOpts {
+	return c.serverSideDryRunOpts
+}
+
 func (c *diffConfig) ServerSideDiff() bool {
 	return c.serverSideDiff
 }
@@ -298,6 +313,24 @@ func StateDiff(live, config *unstructured.Unstructured, diffConfig DiffConfig) (
 // StateDiffs will apply all required normalizations and calculate the diffs between
 // the live and the config/desired states.
 func StateDiffs(lives, configs []*unstructured.Unstructured, diffConfig DiffConfig) (*diff.DiffResultList, error) {
+	return stateDiffs(lives, configs, diffConfig, nil)
+}
+
+// StateDiffsWithDryRunErrors calculates the diffs like StateDiffs, except that the failure of the server-side dry-run
+// apply of a resource doesn't fail the diffs when the server-side diff is enabled. The resource is diffed without
+// server-side diff instead, and the failure is returned at the index of the resource.
+func StateDiffsWithDryRunErrors(lives, configs []*unstructured.Unstructured, diffConfig DiffConfig) (*diff.DiffResultList, []*v1alpha1.ResourceDryRunError, error) {
+	dryRunErrors := make([]*v1alpha1.ResourceDryRunError, len(configs))
+	results, err := stateDiffs(lives, configs, diffConfig, dryRunErrors)
+	if err != nil {
+		return nil, nil, err
+	}
+	return results, dryRunErrors, nil
+}
+
+// stateDiffs calculates the diffs between the live and the config/desired states. The failures of the server-side
+// dry-run applies are recorded in dryRunErrors unless it is nil.
+func stateDiffs(lives, configs []*unstructured.Unstructured, diffConfig DiffConfig, dryRunErrors []*v1alpha1.ResourceDryRunError) (*diff.DiffResultList, error) {
 	normResults, err := preDiffNormalize(lives, configs, diffConfig)
 	if err != nil {
 		return nil, fmt.Errorf("failed to perform pre-diff normalization: %w", err)
@@ -315,7 +348,7 @@ func StateDiffs(lives, configs []*unstructured.Unstructured, diffConfig DiffConf
 		diff.WithGVKParser(diffConfig.GVKParser()),
 		diff.WithManager(diffConfig.Manager()),
 		diff.WithServerSideDiff(diffConfig.ServerSideDiff()),
-		diff.WithServerSideDryRunner(diffConfig.ServerSideDryRunner()),
+		diff.WithServerSideDryRunner(serverSideDryRunner(diffConfig)),
 		diff.WithIgnoreMutationWebhook(diffConfig.IgnoreMutationWebhook()),
 	}
 
@@ -325,12 +358,19 @@ func StateDiffs(lives, configs []*unstructured.Unstructured, diffConfig DiffConf
 
 	useCache, cachedDiff := diffConfig.DiffFromCache(diffConfig.AppName())
 	if useCache && cachedDiff != nil {
-		cached, err := diffArrayCached(normResults.Targets, normResults.Lives, cachedDiff, diffOpts...)
+		cached, err := diffArrayCached(normResults.Targets, normResults.Lives, cachedDiff, diffConfig, dryRunErrors, diffOpts...)
 		if err != nil {
 			return nil, fmt.Errorf("failed to calculate diff from cache: %w", err)
 		}
 		return cached, nil
 	}
+	if diffConfig.ServerSideDiff() {
+		array, err := diffArrayServerSide(normResults.Targets, normResults.Lives, diffConfig, dryRunErrors, diffOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to calculate diff: %w", err)
+		}
+		return array, nil
+	}
 	array, err := diff.DiffArray(normResults.Targets, normResults.Lives, diffOpts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to calculate diff: %w", err)
@@ -338,7 +378,7 @@ func StateDiffs(lives, configs []*unstructured.Unstructured, diffConfig DiffConf
 	return array, nil
 }
 
-func diffArrayCached(configArray []*unstructured.Unstructured, liveArray []*unstructured.Unstructured, cachedDiff []*v1alpha1.ResourceDiff, opts ...diff.Option) (*diff.DiffResultList, error) {
+func diffArrayCached(configArray []*unstructured.Unstructured, liveArray []*unstructured.Unstructured, cachedDiff []*v1alpha1.ResourceDiff, diffConfig DiffConfig, dryRunErrors []*v1alpha1.ResourceDryRunError, opts ...diff.Option) (*diff.DiffResultList, error) {
 	numItems := len(configArray)
 	if len(liveArray) != numItems {
 		return nil, errors.New("left and right arrays have mismatched lengths")
@@ -371,8 +411,11 @@ func diffArrayCached(configArray []*unstructured.Unstructured, liveArray []*unst
 				PredictedLive:  []byte(cachedDiff.PredictedLiveState),
 				Modified:       cachedDiff.Modified,
 			}
+			if dryRunErrors != nil {
+				dryRunErrors[i] = cachedDiff.DryRunError
+			}
 		} else {
-			res, err := diff.Diff(configArray[i], liveArray[i], opts...)
+			res, err := diffResource(configArray[i], liveArray[i], diffConfig, dryRunErrors, i, opts...)
 			if err != nil {
 				return nil, err
 			}
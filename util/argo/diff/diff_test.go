@@ -1,11 +1,18 @@
 package diff_test
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
 	testutil "github.com/argoproj/argo-cd/v3/test"
@@ -249,3 +256,181 @@ func TestDiffConfigBuilder(t *testing.T) {
 		require.Nil(t, diffConfig)
 	})
 }
+
+type fakeDryRunner struct {
+	err error
+}
+
+func (r *fakeDryRunner) Run(_ context.Context, obj *unstructured.Unstructured, _ string) (string, error) {
+	if r.err != nil {
+		return "", r.err
+	}
+	data, err := json.Marshal(obj)
+	return string(data), err
+}
+
+func TestStateDiffsWithDryRunErrors(t *testing.T) {
+	diffConfig := func(t *testing.T, dryRunner *fakeDryRunner) argo.DiffConfig {
+		t.Helper()
+		diffConfig, err := argo.NewDiffConfigBuilder().
+			WithDiffSettings(nil, nil, true, normalizers.IgnoreNormalizerOpts{}).
+			WithTracking("", "").
+			WithNoCache().
+			WithServerSideDiff(true).
+			WithServerSideDryRunner(dryRunner).
+			WithIgnoreMutationWebhook(false).
+			WithManager("argocd-controller").
+			Build()
+		require.NoError(t, err)
+		return diffConfig
+	}
+	lives := []*unstructured.Unstructured{testutil.YamlToUnstructured(testdata.LiveDeploymentWithManagedReplicaYaml)}
+	configs := []*unstructured.Unstructured{testutil.YamlToUnstructured(testdata.DesiredDeploymentYaml)}
+
+	t.Run("will record the dry-run failure of the resource", func(t *testing.T) {
+		dryRunner := &fakeDryRunner{err: errors.New(`admission webhook "validate.example.com" denied the request: replicas must be odd`)}
+
+		results, dryRunErrors, err := argo.StateDiffsWithDryRunErrors(lives, configs, diffConfig(t, dryRunner))
+
+		require.NoError(t, err)
+		require.Len(t, results.Diffs, 1)
+		require.Len(t, dryRunErrors, 1)
+		require.NotNil(t, dryRunErrors[0])
+		assert.Equal(t, v1alpha1.ResourceDryRunErrorReasonWebhookRejected, dryRunErrors[0].Reason)
+		assert.Contains(t, dryRunErrors[0].Message, "replicas must be odd")
+
+		_, err = argo.StateDiffs(lives, configs, diffConfig(t, dryRunner))
+		require.Error(t, err)
+	})
+	t.Run("will not record anything if the dry-run succeeds", func(t *testing.T) {
+		results, dryRunErrors, err := argo.StateDiffsWithDryRunErrors(lives, configs, diffConfig(t, &fakeDryRunner{}))
+
+		require.NoError(t, err)
+		require.Len(t, results.Diffs, 1)
+		assert.Equal(t, []*v1alpha1.ResourceDryRunError{nil}, dryRunErrors)
+	})
+}
+
+type concurrencyDryRunner struct {
+	fakeDryRunner
+	mu            sync.Mutex
+	running       int
+	maxRunning    int
+	runs          int
+	withDeadlines int
+}
+
+func (r *concurrencyDryRunner) Run(ctx context.Context, obj *unstructured.Unstructured, manager string) (string, error) {
+	r.mu.Lock()
+	r.running++
+	r.runs++
+	r.maxRunning = max(r.maxRunning, r.running)
+	if _, ok := ctx.Deadline(); ok {
+		r.withDeadlines++
+	}
+	r.mu.Unlock()
+	time.Sleep(10 * time.Millisecond)
+	r.mu.Lock()
+	r.running--
+	r.mu.Unlock()
+	return r.fakeDryRunner.Run(ctx, obj, manager)
+}
+
+func TestStateDiffsWithServerSideDryRunOpts(t *testing.T) {
+	var lives, configs []*unstructured.Unstructured
+	for range 6 {
+		lives = append(lives, testutil.YamlToUnstructured(testdata.LiveDeploymentWithManagedReplicaYaml))
+		configs = append(configs, testutil.YamlToUnstructured(testdata.DesiredDeploymentYaml))
+	}
+	diffConfig := func(t *testing.T, dryRunner *concurrencyDryRunner, opts argo.ServerSideDryRunOpts) argo.DiffConfig {
+		t.Helper()
+		diffConfig, err := argo.NewDiffConfigBuilder().
+			WithDiffSettings(nil, nil, true, normalizers.IgnoreNormalizerOpts{}).
+			WithTracking("", "").
+			WithNoCache().
+			WithServerSideDiff(true).
+			WithServerSideDryRunner(dryRunner).
+			WithServerSideDryRunOpts(opts).
+			WithIgnoreMutationWebhook(false).
+			WithManager("argocd-controller").
+			Build()
+		require.NoError(t, err)
+		return diffConfig
+	}
+
+	t.Run("will run the dry-run applies serially by default", func(t *testing.T) {
+		dryRunner := &concurrencyDryRunner{}
+
+		results, err := argo.StateDiffs(lives, configs, diffConfig(t, dryRunner, argo.ServerSideDryRunOpts{}))
+
+		require.NoError(t, err)
+		require.Len(t, results.Diffs, 6)
+		assert.Equal(t, 6, dryRunner.runs)
+		assert.Equal(t, 1, dryRunner.maxRunning)
+		assert.Equal(t, 0, dryRunner.withDeadlines)
+	})
+	t.Run("will run the dry-run applies of a batch concurrently", func(t *testing.T) {
+		dryRunner := &concurrencyDryRunner{}
+
+		results, err := argo.StateDiffs(lives, configs, diffConfig(t, dryRunner, argo.ServerSideDryRunOpts{BatchSize: 2, Parallelism: 4}))
+
+		require.NoError(t, err)
+		require.Len(t, results.Diffs, 6)
+		assert.Equal(t, 6, dryRunner.runs)
+		assert.Equal(t, 2, dryRunner.maxRunning)
+	})
+	t.Run("will apply the timeout to the dry-run applies", func(t *testing.T) {
+		dryRunner := &concurrencyDryRunner{}
+
+		_, dryRunErrors, err := argo.StateDiffsWithDryRunErrors(lives, configs, diffConfig(t, dryRunner, argo.ServerSideDryRunOpts{Parallelism: 3, Timeout: time.Minute}))
+
+		require.NoError(t, err)
+		assert.Len(t, dryRunErrors, 6)
+		assert.Equal(t, 6, dryRunner.withDeadlines)
+		assert.LessOrEqual(t, dryRunner.maxRunning, 3)
+	})
+}
+
+// blockingDryRunner is a dry runner blocking until its context is done
+type blockingDryRunner struct{}
+
+func (r *blockingDryRunner) Run(ctx context.Context, _ *unstructured.Unstructured, _ string) (string, error) {
+	select {
+	case <-ctx.Done():
+		return "", ctx.Err()
+	case <-time.After(10 * time.Second):
+		return "", errors.New("dry-run apply didn't time out")
+	}
+}
+
+func TestStateDiffsWithBlockingDryRunner(t *testing.T) {
+	lives := []*unstructured.Unstructured{testutil.YamlToUnstructured(testdata.LiveDeploymentWithManagedReplicaYaml)}
+	configs := []*unstructured.Unstructured{testutil.YamlToUnstructured(testdata.DesiredDeploymentYaml)}
+	diffConfig, err := argo.NewDiffConfigBuilder().
+		WithDiffSettings(nil, nil, true, normalizers.IgnoreNormalizerOpts{}).
+		WithTracking("", "").
+		WithNoCache().
+		WithServerSideDiff(true).
+		WithServerSideDryRunner(&blockingDryRunner{}).
+		WithServerSideDryRunOpts(argo.ServerSideDryRunOpts{Timeout: 100 * time.Millisecond}).
+		WithIgnoreMutationWebhook(false).
+		WithManager("argocd-controller").
+		Build()
+	require.NoError(t, err)
+
+	results, dryRunErrors, err := argo.StateDiffsWithDryRunErrors(lives, configs, diffConfig)
+
+	// the dry-run apply times out, and the resource is diffed without server-side diff
+	require.NoError(t, err)
+	require.Len(t, results.Diffs, 1)
+	require.Len(t, dryRunErrors, 1)
+	assert.Equal(t, v1alpha1.ResourceDryRunErrorReasonFailed, dryRunErrors[0].Reason)
+	assert.Contains(t, dryRunErrors[0].Message, context.DeadlineExceeded.Error())
+}
+
+func TestNewResourceDryRunError(t *testing.T) {
+	assert.Equal(t, v1alpha1.ResourceDryRunErrorReasonWebhookRejected, argo.NewResourceDryRunError(errors.New(`admission webhook "policy.example.com" denied the request: forbidden`)).Reason)
+	assert.Equal(t, v1alpha1.ResourceDryRunErrorReasonFieldConflict, argo.NewResourceDryRunError(errors.New(`Apply failed with 1 conflict: conflict with "kubectl": .spec.replicas`)).Reason)
+	assert.Equal(t, v1alpha1.ResourceDryRunErrorReasonFieldConflict, argo.NewResourceDryRunError(apierrors.NewConflict(schema.GroupResource{Resource: "deployments"}, "guestbook", errors.New("the object has been modified"))).Reason)
+	assert.Equal(t, v1alpha1.ResourceDryRunErrorReasonFailed, argo.NewResourceDryRunError(errors.New("connection refused")).Reason)
+}
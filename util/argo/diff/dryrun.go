@@ -0,0 +1,152 @@
+package diff
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/argoproj/gitops-engine/pkg/diff"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+)
+
+// recordingDryRunner is a server-side dry runner recording the failure of the dry-run apply
+type recordingDryRunner struct {
+	diff.ServerSideDryRunner
+	err error
+}
+
+func (r *recordingDryRunner) Run(ctx context.Context, obj *unstructured.Unstructured, manager string) (string, error) {
+	res, err := r.ServerSideDryRunner.Run(ctx, obj, manager)
+	if err != nil {
+		r.err = err
+	}
+	return res, err
+}
+
+// ServerSideDryRunOpts defines how the server-side dry-run applies of the server-side diff are run
+type ServerSideDryRunOpts struct {
+	// BatchSize is the number of resources diffed per batch, the batches being diffed one after the other to avoid
+	// bursts of requests to the API server. 0 diffs all the resources in a single batch.
+	BatchSize int
+	// Parallelism is the number of resources of a batch diffed concurrently. Values lower than 2 diff them serially.
+	Parallelism int
+	// Timeout is the timeout of each dry-run apply. 0 disables the timeout.
+	Timeout time.Duration
+}
+
+// timeoutDryRunner is a server-side dry runner applying a timeout to each dry-run apply
+type timeoutDryRunner struct {
+	diff.ServerSideDryRunner
+	timeout time.Duration
+}
+
+func (r *timeoutDryRunner) Run(ctx context.Context, obj *unstructured.Unstructured, manager string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	return r.ServerSideDryRunner.Run(ctx, obj, manager)
+}
+
+// serverSideDryRunner returns the server-side dry runner of the diff config, applying the timeout of the dry-run
+// applies if any
+func serverSideDryRunner(diffConfig DiffConfig) diff.ServerSideDryRunner {
+	dryRunner := diffConfig.ServerSideDryRunner()
+	if dryRunner == nil || diffConfig.ServerSideDryRunOpts().Timeout <= 0 {
+		return dryRunner
+	}
+	return &timeoutDryRunner{ServerSideDryRunner: dryRunner, timeout: diffConfig.ServerSideDryRunOpts().Timeout}
+}
+
+// diffArrayServerSide diffs the resources like diff.DiffArray, in batches of resources diffed concurrently as defined
+// by the server-side dry-run options of the diff config. The failures of the server-side dry-run applies are
+// recorded in dryRunErrors unless it is nil.
+func diffArrayServerSide(configArray, liveArray []*unstructured.Unstructured, diffConfig DiffConfig, dryRunErrors []*v1alpha1.ResourceDryRunError, opts ...diff.Option) (*diff.DiffResultList, error) {
+	numItems := len(configArray)
+	if len(liveArray) != numItems {
+		return nil, errors.New("left and right arrays have mismatched lengths")
+	}
+	dryRunOpts := diffConfig.ServerSideDryRunOpts()
+	batchSize := dryRunOpts.BatchSize
+	if batchSize <= 0 {
+		batchSize = numItems
+	}
+	parallelism := max(dryRunOpts.Parallelism, 1)
+
+	diffResultList := diff.DiffResultList{
+		Diffs: make([]diff.DiffResult, numItems),
+	}
+	errs := make([]error, numItems)
+	for start := 0; start < numItems; start += batchSize {
+		end := min(start+batchSize, numItems)
+		indexes := make(chan int, end-start)
+		for i := start; i < end; i++ {
+			indexes <- i
+		}
+		close(indexes)
+
+		var wg sync.WaitGroup
+		for range min(parallelism, end-start) {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := range indexes {
+					res, err := diffResource(configArray[i], liveArray[i], diffConfig, dryRunErrors, i, opts...)
+					if err != nil {
+						errs[i] = err
+						continue
+					}
+					diffResultList.Diffs[i] = *res
+				}
+			}()
+		}
+		wg.Wait()
+
+		// the first failure in the order of the resources is returned, like diff.DiffArray does
+		for i := start; i < end; i++ {
+			if errs[i] != nil {
+				return nil, errs[i]
+			}
+		}
+	}
+	for _, res := range diffResultList.Diffs {
+		if res.Modified {
+			diffResultList.Modified = true
+		}
+	}
+	return &diffResultList, nil
+}
+
+// diffResource diffs a resource. When the failures of the server-side dry-run applies are recorded and the dry-run
+// apply of the resource fails, the failure is recorded at the index of the resource in dryRunErrors, and the resource
+// is diffed without server-side diff instead.
+func diffResource(config, live *unstructured.Unstructured, diffConfig DiffConfig, dryRunErrors []*v1alpha1.ResourceDryRunError, index int, opts ...diff.Option) (*diff.DiffResult, error) {
+	if dryRunErrors == nil || !diffConfig.ServerSideDiff() || diffConfig.ServerSideDryRunner() == nil {
+		return diff.Diff(config, live, opts...)
+	}
+	dryRunner := &recordingDryRunner{ServerSideDryRunner: serverSideDryRunner(diffConfig)}
+	res, err := diff.Diff(config, live, append(append([]diff.Option{}, opts...), diff.WithServerSideDryRunner(dryRunner))...)
+	if err == nil || dryRunner.err == nil {
+		return res, err
+	}
+	dryRunErrors[index] = NewResourceDryRunError(dryRunner.err)
+	return diff.Diff(config, live, append(append([]diff.Option{}, opts...), diff.WithServerSideDiff(false))...)
+}
+
+// NewResourceDryRunError returns the failure of the server-side dry-run apply of a resource for the error returned by
+// the dry-run apply
+func NewResourceDryRunError(err error) *v1alpha1.ResourceDryRunError {
+	message := err.Error()
+	reason := v1alpha1.ResourceDryRunErrorReasonFailed
+	switch {
+	case strings.Contains(message, "admission webhook") && strings.Contains(message, "denied the request"):
+		reason = v1alpha1.ResourceDryRunErrorReasonWebhookRejected
+	case apierrors.IsConflict(err) || strings.Contains(message, "Apply failed with"):
+		// the conflicts of the server-side applies are reported as "Apply failed with N conflict(s)"
+		reason = v1alpha1.ResourceDryRunErrorReasonFieldConflict
+	}
+	return &v1alpha1.ResourceDryRunError{Reason: reason, Message: message}
+}
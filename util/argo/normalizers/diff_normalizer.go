@@ -28,6 +28,7 @@ type normalizerPatch interface {
 	GetGroupKind() schema.GroupKind
 	GetNamespace() string
 	GetName() string
+	GetRule() string
 	// Apply(un *unstructured.Unstructured) (error)
 	Apply(data []byte) ([]byte, error)
 }
@@ -36,6 +37,8 @@ type baseNormalizerPatch struct {
 	groupKind schema.GroupKind
 	namespace string
 	name      string
+	// rule describes the ignore rule of the patch, e.g. "spec.ignoreDifferences[0]: jsonPointer /spec/replicas"
+	rule string
 }
 
 func (np *baseNormalizerPatch) GetGroupKind() schema.GroupKind {
@@ -50,6 +53,10 @@ func (np *baseNormalizerPatch) GetName() string {
 	return np.name
 }
 
+func (np *baseNormalizerPatch) GetRule() string {
+	return np.rule
+}
+
 type jsonPatchNormalizerPatch struct {
 	baseNormalizerPatch
 	patch *jsonpatch.Patch
@@ -119,6 +126,19 @@ func (opts *IgnoreNormalizerOpts) getJQExecutionTimeout() time.Duration {
 
 // NewIgnoreNormalizer creates diff normalizer which removes ignored fields according to given application spec and resource overrides
 func NewIgnoreNormalizer(ignore []v1alpha1.ResourceIgnoreDifferences, overrides map[string]v1alpha1.ResourceOverride, opts IgnoreNormalizerOpts) (diff.Normalizer, error) {
+	patches, err := newIgnorePatches(ignore, overrides, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &ignoreNormalizer{patches: patches}, nil
+}
+
+// newIgnorePatches creates the patches removing the ignored fields of the given application spec and resource overrides
+func newIgnorePatches(ignore []v1alpha1.ResourceIgnoreDifferences, overrides map[string]v1alpha1.ResourceOverride, opts IgnoreNormalizerOpts) ([]normalizerPatch, error) {
+	origins := make([]string, len(ignore))
+	for i := range ignore {
+		origins[i] = fmt.Sprintf("spec.ignoreDifferences[%d]", i)
+	}
 	for key, override := range overrides {
 		group, kind, err := getGroupKindForOverrideKey(key)
 		if err != nil {
@@ -136,6 +156,7 @@ func NewIgnoreNormalizer(ignore []v1alpha1.ResourceIgnoreDifferences, overrides
 				resourceIgnoreDifference.JQPathExpressions = override.IgnoreDifferences.JQPathExpressions
 			}
 			ignore = append(ignore, resourceIgnoreDifference)
+			origins = append(origins, getOriginForOverrideKey(key))
 		}
 	}
 	patches := make([]normalizerPatch, 0)
@@ -154,6 +175,7 @@ func NewIgnoreNormalizer(ignore []v1alpha1.ResourceIgnoreDifferences, overrides
 					groupKind: schema.GroupKind{Group: ignore[i].Group, Kind: ignore[i].Kind},
 					name:      ignore[i].Name,
 					namespace: ignore[i].Namespace,
+					rule:      fmt.Sprintf("%s: jsonPointer %s", origins[i], path),
 				},
 				patch: &patch,
 			})
@@ -172,24 +194,21 @@ func NewIgnoreNormalizer(ignore []v1alpha1.ResourceIgnoreDifferences, overrides
 					groupKind: schema.GroupKind{Group: ignore[i].Group, Kind: ignore[i].Kind},
 					name:      ignore[i].Name,
 					namespace: ignore[i].Namespace,
+					rule:      fmt.Sprintf("%s: jqPathExpression %s", origins[i], pathExpression),
 				},
 				code:               jqDeletionCode,
 				jqExecutionTimeout: opts.getJQExecutionTimeout(),
 			})
 		}
 	}
-	return &ignoreNormalizer{patches: patches}, nil
+	return patches, nil
 }
 
-// Normalize removes fields from supplied resource using json paths from matching items of specified resources ignored differences list
-func (n *ignoreNormalizer) Normalize(un *unstructured.Unstructured) error {
-	if un == nil {
-		return errors.New("invalid argument: unstructured is nil")
-	}
+// matchPatches returns the patches which apply to the given resource
+func matchPatches(patches []normalizerPatch, un *unstructured.Unstructured) []normalizerPatch {
+	groupKind := un.GroupVersionKind().GroupKind()
 	matched := make([]normalizerPatch, 0)
-	for _, patch := range n.patches {
-		groupKind := un.GroupVersionKind().GroupKind()
-
+	for _, patch := range patches {
 		if glob.Match(patch.GetGroupKind().Group, groupKind.Group) &&
 			glob.Match(patch.GetGroupKind().Kind, groupKind.Kind) &&
 			(patch.GetName() == "" || patch.GetName() == un.GetName()) &&
@@ -197,6 +216,15 @@ func (n *ignoreNormalizer) Normalize(un *unstructured.Unstructured) error {
 			matched = append(matched, patch)
 		}
 	}
+	return matched
+}
+
+// Normalize removes fields from supplied resource using json paths from matching items of specified resources ignored differences list
+func (n *ignoreNormalizer) Normalize(un *unstructured.Unstructured) error {
+	if un == nil {
+		return errors.New("invalid argument: unstructured is nil")
+	}
+	matched := matchPatches(n.patches, un)
 	if len(matched) == 0 {
 		return nil
 	}
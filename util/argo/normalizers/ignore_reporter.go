@@ -0,0 +1,124 @@
+package normalizers
+
+import (
+	"encoding/json"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+)
+
+var jsonPointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// IgnoreReporter reports the differences between live and target resources which are hidden by the ignored fields
+// of an application spec and resource overrides
+type IgnoreReporter struct {
+	patches []normalizerPatch
+}
+
+// NewIgnoreReporter creates a reporter of the differences hidden by the ignored fields of the given application spec and
+// resource overrides
+func NewIgnoreReporter(ignore []v1alpha1.ResourceIgnoreDifferences, overrides map[string]v1alpha1.ResourceOverride, opts IgnoreNormalizerOpts) (*IgnoreReporter, error) {
+	patches, err := newIgnorePatches(ignore, overrides, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &IgnoreReporter{patches: patches}, nil
+}
+
+// SuppressedDifferences returns the fields of the target resource whose value differs from the live resource and which
+// are removed by an ignore rule, together with the rule. Fields which are only defined by the live resource are not
+// reported since they are not compared.
+func (r *IgnoreReporter) SuppressedDifferences(live, target *unstructured.Unstructured) ([]v1alpha1.SuppressedDifference, error) {
+	if live == nil || target == nil {
+		return nil, nil
+	}
+	matched := matchPatches(r.patches, target)
+	if len(matched) == 0 {
+		return nil, nil
+	}
+	targetData, err := json.Marshal(target)
+	if err != nil {
+		return nil, err
+	}
+	targetFields, err := flattenJSON(targetData)
+	if err != nil {
+		return nil, err
+	}
+	liveData, err := json.Marshal(live)
+	if err != nil {
+		return nil, err
+	}
+	liveFields, err := flattenJSON(liveData)
+	if err != nil {
+		return nil, err
+	}
+
+	var suppressed []v1alpha1.SuppressedDifference
+	for _, patch := range matched {
+		patchedData, err := patch.Apply(targetData)
+		if err != nil {
+			if shouldLogError(err) {
+				log.Debugf("Failed to apply normalization: %v", err)
+			}
+			continue
+		}
+		patchedFields, err := flattenJSON(patchedData)
+		if err != nil {
+			return nil, err
+		}
+		for path, value := range targetFields {
+			if patchedValue, ok := patchedFields[path]; ok && reflect.DeepEqual(value, patchedValue) {
+				continue
+			}
+			if liveValue, ok := liveFields[path]; ok && reflect.DeepEqual(value, liveValue) {
+				continue
+			}
+			suppressed = append(suppressed, v1alpha1.SuppressedDifference{Path: path, Rule: patch.GetRule()})
+		}
+	}
+	slices.SortFunc(suppressed, func(a, b v1alpha1.SuppressedDifference) int {
+		if c := strings.Compare(a.Path, b.Path); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Rule, b.Rule)
+	})
+	return suppressed, nil
+}
+
+// flattenJSON returns the leaf values of a JSON document by their JSON pointer
+func flattenJSON(data []byte) (map[string]any, error) {
+	var doc any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	fields := make(map[string]any)
+	flattenValue("", doc, fields)
+	return fields, nil
+}
+
+func flattenValue(path string, value any, fields map[string]any) {
+	switch v := value.(type) {
+	case map[string]any:
+		if len(v) == 0 {
+			fields[path] = v
+		}
+		for key, item := range v {
+			flattenValue(path+"/"+jsonPointerEscaper.Replace(key), item, fields)
+		}
+	case []any:
+		if len(v) == 0 {
+			fields[path] = v
+		}
+		for i, item := range v {
+			flattenValue(path+"/"+strconv.Itoa(i), item, fields)
+		}
+	default:
+		fields[path] = v
+	}
+}
@@ -0,0 +1,66 @@
+package normalizers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	"github.com/argoproj/argo-cd/v3/test"
+)
+
+func TestIgnoreReporter_SuppressedDifferences(t *testing.T) {
+	target := test.NewDeployment()
+	live := test.NewDeployment()
+	require.NoError(t, unstructured.SetNestedField(live.Object, int64(5), "spec", "replicas"))
+	require.NoError(t, unstructured.SetNestedField(live.Object, "nginx", "metadata", "labels", "app"))
+
+	reporter, err := NewIgnoreReporter([]v1alpha1.ResourceIgnoreDifferences{{
+		Group:        "apps",
+		Kind:         "Deployment",
+		JSONPointers: []string{"/spec/replicas", "/spec/selector"},
+	}, {
+		Kind:         "ConfigMap",
+		JSONPointers: []string{"/data"},
+	}}, map[string]v1alpha1.ResourceOverride{
+		"apps/Deployment": {
+			IgnoreDifferences: v1alpha1.OverrideIgnoreDiff{JQPathExpressions: []string{".spec.template.spec.containers[].image", ".spec.replicas"}},
+		},
+	}, IgnoreNormalizerOpts{})
+	require.NoError(t, err)
+
+	t.Run("differences hidden by rules", func(t *testing.T) {
+		suppressed, err := reporter.SuppressedDifferences(live, target)
+		require.NoError(t, err)
+		assert.Equal(t, []v1alpha1.SuppressedDifference{
+			{Path: "/spec/replicas", Rule: "resource.customizations.ignoreDifferences.apps_Deployment: jqPathExpression .spec.replicas"},
+			{Path: "/spec/replicas", Rule: "spec.ignoreDifferences[0]: jsonPointer /spec/replicas"},
+		}, suppressed)
+	})
+
+	t.Run("ignored fields without differences", func(t *testing.T) {
+		configMap := test.NewConfigMap()
+		suppressed, err := reporter.SuppressedDifferences(configMap, configMap)
+		require.NoError(t, err)
+		assert.Empty(t, suppressed)
+	})
+
+	t.Run("missing live resource", func(t *testing.T) {
+		suppressed, err := reporter.SuppressedDifferences(nil, target)
+		require.NoError(t, err)
+		assert.Empty(t, suppressed)
+	})
+}
+
+func TestFlattenJSON(t *testing.T) {
+	fields, err := flattenJSON([]byte(`{"metadata":{"annotations":{"example.com/key":"value"}},"spec":{"ports":[{"port":80}],"selector":{},"tags":[]}}`))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"/metadata/annotations/example.com~1key": "value",
+		"/spec/ports/0/port":                     float64(80),
+		"/spec/selector":                         map[string]any{},
+		"/spec/tags":                             []any{},
+	}, fields)
+}
@@ -20,3 +20,11 @@ func getGroupKindForOverrideKey(key string) (string, string, error) {
 	}
 	return group, kind, nil
 }
+
+// getOriginForOverrideKey returns the argocd-cm key of the ignored differences of a resource override key
+func getOriginForOverrideKey(key string) string {
+	if key == "*/*" {
+		return "resource.customizations.ignoreDifferences.all"
+	}
+	return "resource.customizations.ignoreDifferences." + strings.ReplaceAll(key, "/", "_")
+}
@@ -28,6 +28,7 @@ type ResourceTracking interface {
 	BuildAppInstanceValue(value AppInstanceValue) string
 	ParseAppInstanceValue(value string) (*AppInstanceValue, error)
 	Normalize(config, live *unstructured.Unstructured, labelKey, trackingMethod string) error
+	IsSelfReferencedObj(live, config *unstructured.Unstructured, appName string, trackingMethod v1alpha1.TrackingMethod, installationID string) bool
 }
 
 // AppInstanceValue store information about resource tracking info
@@ -49,56 +50,16 @@ func IsOldTrackingMethod(trackingMethod string) bool {
 	return trackingMethod == "" || trackingMethod == string(v1alpha1.TrackingMethodLabel)
 }
 
-func (rt *resourceTracking) getAppInstanceValue(un *unstructured.Unstructured, installationID string) *AppInstanceValue {
-	if installationID != "" && un.GetAnnotations() == nil || un.GetAnnotations()[common.AnnotationInstallationID] != installationID {
-		return nil
-	}
-	appInstanceAnnotation, err := kube.GetAppInstanceAnnotation(un, common.AnnotationKeyAppInstance)
-	if err != nil {
-		return nil
-	}
-	value, err := rt.ParseAppInstanceValue(appInstanceAnnotation)
-	if err != nil {
-		return nil
-	}
-	return value
-}
-
 // GetAppName retrieve application name base on tracking method
 func (rt *resourceTracking) GetAppName(un *unstructured.Unstructured, key string, trackingMethod v1alpha1.TrackingMethod, instanceID string) string {
-	retrieveAppInstanceValue := func() string {
-		value := rt.getAppInstanceValue(un, instanceID)
-		if value != nil {
-			return value.ApplicationName
-		}
-		return ""
-	}
-	switch trackingMethod {
-	case v1alpha1.TrackingMethodLabel:
-		label, err := kube.GetAppInstanceLabel(un, key)
-		if err != nil {
-			return ""
-		}
-		return label
-	case v1alpha1.TrackingMethodAnnotationAndLabel:
-		return retrieveAppInstanceValue()
-	case v1alpha1.TrackingMethodAnnotation:
-		return retrieveAppInstanceValue()
-	default:
-		return retrieveAppInstanceValue()
-	}
+	return GetTrackingProvider(trackingMethod).GetAppName(un, key, instanceID)
 }
 
 // GetAppInstance returns the representation of the app instance annotation.
 // If the tracking method does not support metadata, or the annotation could
 // not be parsed, it returns nil.
 func (rt *resourceTracking) GetAppInstance(un *unstructured.Unstructured, trackingMethod v1alpha1.TrackingMethod, instanceID string) *AppInstanceValue {
-	switch trackingMethod {
-	case v1alpha1.TrackingMethodAnnotation, v1alpha1.TrackingMethodAnnotationAndLabel:
-		return rt.getAppInstanceValue(un, instanceID)
-	default:
-		return nil
-	}
+	return GetTrackingProvider(trackingMethod).GetAppInstance(un, instanceID)
 }
 
 // UnstructuredToAppInstanceValue will build the AppInstanceValue based
@@ -122,61 +83,30 @@ func UnstructuredToAppInstanceValue(un *unstructured.Unstructured, appName, name
 
 // SetAppInstance set label/annotation base on tracking method
 func (rt *resourceTracking) SetAppInstance(un *unstructured.Unstructured, key, val, namespace string, trackingMethod v1alpha1.TrackingMethod, instanceID string) error {
-	setAppInstanceAnnotation := func() error {
-		appInstanceValue := UnstructuredToAppInstanceValue(un, val, namespace)
-		if instanceID != "" {
-			if err := kube.SetAppInstanceAnnotation(un, common.AnnotationInstallationID, instanceID); err != nil {
-				return err
-			}
-		} else {
-			if err := kube.RemoveAnnotation(un, common.AnnotationInstallationID); err != nil {
-				return err
-			}
-		}
-		return kube.SetAppInstanceAnnotation(un, common.AnnotationKeyAppInstance, rt.BuildAppInstanceValue(appInstanceValue))
-	}
-	switch trackingMethod {
-	case v1alpha1.TrackingMethodLabel:
-		err := kube.SetAppInstanceLabel(un, key, val)
-		if err != nil {
-			return fmt.Errorf("failed to set app instance label: %w", err)
-		}
-		return nil
-	case v1alpha1.TrackingMethodAnnotation:
-		return setAppInstanceAnnotation()
-	case v1alpha1.TrackingMethodAnnotationAndLabel:
-		err := setAppInstanceAnnotation()
-		if err != nil {
-			return err
-		}
-		if len(val) > LabelMaxLength {
-			val = val[:LabelMaxLength]
-			// Prevent errors if the truncated name ends in a special character.
-			// See https://github.com/argoproj/argo-cd/issues/18237.
-			for !OkEndPattern.MatchString(val) {
-				if len(val) <= 1 {
-					return errors.New("failed to set app instance label: unable to truncate label to not end with a special character")
-				}
-				val = val[:len(val)-1]
-			}
-		}
-		err = kube.SetAppInstanceLabel(un, key, val)
-		if err != nil {
-			return fmt.Errorf("failed to set app instance label: %w", err)
-		}
-		return nil
-	default:
-		return setAppInstanceAnnotation()
-	}
+	return GetTrackingProvider(trackingMethod).SetAppInstance(un, key, val, namespace, instanceID)
+}
+
+// IsSelfReferencedObj returns whether the given live object is managed by the application according to the provider
+// of the tracking method, see TrackingProvider.IsSelfReferencedObj
+func (rt *resourceTracking) IsSelfReferencedObj(live, config *unstructured.Unstructured, appName string, trackingMethod v1alpha1.TrackingMethod, instanceID string) bool {
+	return GetTrackingProvider(trackingMethod).IsSelfReferencedObj(live, config, appName, instanceID)
 }
 
 // BuildAppInstanceValue build resource tracking id in format <application-name>;<group>/<kind>/<namespace>/<name>
 func (rt *resourceTracking) BuildAppInstanceValue(value AppInstanceValue) string {
+	return buildAppInstanceValue(value)
+}
+
+func buildAppInstanceValue(value AppInstanceValue) string {
 	return fmt.Sprintf("%s:%s/%s:%s/%s", value.ApplicationName, value.Group, value.Kind, value.Namespace, value.Name)
 }
 
 // ParseAppInstanceValue parse resource tracking id from format <application-name>:<group>/<kind>:<namespace>/<name> to struct
 func (rt *resourceTracking) ParseAppInstanceValue(value string) (*AppInstanceValue, error) {
+	return parseAppInstanceValue(value)
+}
+
+func parseAppInstanceValue(value string) (*AppInstanceValue, error) {
 	var appInstanceValue AppInstanceValue
 	parts := strings.SplitN(value, ":", 3)
 	appInstanceValue.ApplicationName = parts[0]
@@ -0,0 +1,213 @@
+package argo
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/argoproj/argo-cd/v3/common"
+	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	"github.com/argoproj/argo-cd/v3/util/kube"
+	"github.com/argoproj/argo-cd/v3/util/settings"
+)
+
+// TrackingProvider is a strategy tracking the resources of the applications, selected by the tracking method set in
+// the settings or in the project of the applications. Alternative strategies, such as for operators stripping the
+// labels and annotations of the resources they manage, are plugged with RegisterTrackingProvider.
+type TrackingProvider interface {
+	// GetAppName returns the name of the application tracking a resource, or an empty string if it isn't tracked
+	GetAppName(un *unstructured.Unstructured, key string, installationID string) string
+	// GetAppInstance returns the tracking id of a resource, or nil if the resource isn't tracked or the provider
+	// doesn't track the group, kind, namespace and name of the resources
+	GetAppInstance(un *unstructured.Unstructured, installationID string) *AppInstanceValue
+	// SetAppInstance sets the tracking metadata of an application on a resource
+	SetAppInstance(un *unstructured.Unstructured, key, val, namespace string, installationID string) error
+	// IsSelfReferencedObj returns whether a live resource is managed by an application, given the desired state of
+	// the resource if any, so that the tracking metadata accidentally copied to another resource doesn't make it part
+	// of the application
+	IsSelfReferencedObj(live, config *unstructured.Unstructured, appName string, installationID string) bool
+}
+
+var (
+	trackingProvidersLock sync.RWMutex
+	trackingProviders     = map[v1alpha1.TrackingMethod]TrackingProvider{
+		v1alpha1.TrackingMethodLabel:              &labelTrackingProvider{},
+		v1alpha1.TrackingMethodAnnotation:         &annotationTrackingProvider{},
+		v1alpha1.TrackingMethodAnnotationAndLabel: &annotationAndLabelTrackingProvider{},
+	}
+)
+
+// RegisterTrackingProvider registers the provider of a tracking method, replacing the provider of the method if any
+func RegisterTrackingProvider(trackingMethod v1alpha1.TrackingMethod, provider TrackingProvider) {
+	trackingProvidersLock.Lock()
+	defer trackingProvidersLock.Unlock()
+	trackingProviders[trackingMethod] = provider
+}
+
+// IsTrackingMethodSupported returns whether a provider is registered for a tracking method
+func IsTrackingMethodSupported(trackingMethod v1alpha1.TrackingMethod) bool {
+	trackingProvidersLock.RLock()
+	defer trackingProvidersLock.RUnlock()
+	_, ok := trackingProviders[trackingMethod]
+	return ok
+}
+
+// GetTrackingProvider returns the provider of a tracking method, which is the annotation tracking for the methods
+// without provider
+func GetTrackingProvider(trackingMethod v1alpha1.TrackingMethod) TrackingProvider {
+	trackingProvidersLock.RLock()
+	defer trackingProvidersLock.RUnlock()
+	if provider, ok := trackingProviders[trackingMethod]; ok {
+		return provider
+	}
+	return trackingProviders[v1alpha1.TrackingMethodAnnotation]
+}
+
+// TrackingMethodSource provides the tracking method of the settings
+type TrackingMethodSource interface {
+	GetTrackingMethod() (string, error)
+}
+
+var _ TrackingMethodSource = &settings.SettingsManager{}
+
+// GetTrackingMethod returns the tracking method of the applications of a project, which is the one set in the project
+// if any, or the one of the settings
+func GetTrackingMethod(settingsSrc TrackingMethodSource, proj *v1alpha1.AppProject) (string, error) {
+	if proj != nil && proj.Spec.TrackingMethod != "" {
+		return string(proj.Spec.TrackingMethod), nil
+	}
+	return settingsSrc.GetTrackingMethod()
+}
+
+// labelTrackingProvider tracks the resources with the app instance label, which only holds the application name
+type labelTrackingProvider struct{}
+
+func (p *labelTrackingProvider) GetAppName(un *unstructured.Unstructured, key string, _ string) string {
+	label, err := kube.GetAppInstanceLabel(un, key)
+	if err != nil {
+		return ""
+	}
+	return label
+}
+
+func (p *labelTrackingProvider) GetAppInstance(_ *unstructured.Unstructured, _ string) *AppInstanceValue {
+	return nil
+}
+
+func (p *labelTrackingProvider) SetAppInstance(un *unstructured.Unstructured, key, val, _ string, _ string) error {
+	err := kube.SetAppInstanceLabel(un, key, val)
+	if err != nil {
+		return fmt.Errorf("failed to set app instance label: %w", err)
+	}
+	return nil
+}
+
+// IsSelfReferencedObj always returns true, since the label doesn't contain the metadata required to determine whether
+// the resource is the one tracked by the application, so the resource is assumed to be managed
+func (p *labelTrackingProvider) IsSelfReferencedObj(_, _ *unstructured.Unstructured, _ string, _ string) bool {
+	return true
+}
+
+// annotationTrackingProvider tracks the resources with the app instance annotation, holding the tracking id of the
+// resources, i.e. the application name along with their group, kind, namespace and name
+type annotationTrackingProvider struct{}
+
+func (p *annotationTrackingProvider) GetAppName(un *unstructured.Unstructured, _ string, installationID string) string {
+	value := p.GetAppInstance(un, installationID)
+	if value != nil {
+		return value.ApplicationName
+	}
+	return ""
+}
+
+func (p *annotationTrackingProvider) GetAppInstance(un *unstructured.Unstructured, installationID string) *AppInstanceValue {
+	if installationID != "" && un.GetAnnotations() == nil || un.GetAnnotations()[common.AnnotationInstallationID] != installationID {
+		return nil
+	}
+	appInstanceAnnotation, err := kube.GetAppInstanceAnnotation(un, common.AnnotationKeyAppInstance)
+	if err != nil {
+		return nil
+	}
+	value, err := parseAppInstanceValue(appInstanceAnnotation)
+	if err != nil {
+		return nil
+	}
+	return value
+}
+
+func (p *annotationTrackingProvider) SetAppInstance(un *unstructured.Unstructured, _, val, namespace string, installationID string) error {
+	appInstanceValue := UnstructuredToAppInstanceValue(un, val, namespace)
+	if installationID != "" {
+		if err := kube.SetAppInstanceAnnotation(un, common.AnnotationInstallationID, installationID); err != nil {
+			return err
+		}
+	} else {
+		if err := kube.RemoveAnnotation(un, common.AnnotationInstallationID); err != nil {
+			return err
+		}
+	}
+	return kube.SetAppInstanceAnnotation(un, common.AnnotationKeyAppInstance, buildAppInstanceValue(appInstanceValue))
+}
+
+// IsSelfReferencedObj returns true when all of the properties of the tracking id (app name, namespace, group and kind)
+// match the properties of the live object. The tracking id is built from the desired state if any, which is
+// important when there is an API group upgrade, see https://github.com/argoproj/argo-cd/pull/11012. Otherwise, the
+// live object is compared with its own tracking id, and the live object without tracking id isn't managed, since it
+// was attributed to the application with another tracking method than the one of the application.
+// Reference: https://github.com/argoproj/argo-cd/issues/8683
+func (p *annotationTrackingProvider) IsSelfReferencedObj(live, config *unstructured.Unstructured, appName string, installationID string) bool {
+	if live == nil {
+		return true
+	}
+	if config != nil {
+		return matchesAppInstanceValue(live, UnstructuredToAppInstanceValue(config, appName, ""))
+	}
+	// Cluster scoped objects carry the app's destination namespace in the tracking annotation, but are unique in
+	// GVK + name combination.
+	appInstance := p.GetAppInstance(live, installationID)
+	if appInstance == nil {
+		return false
+	}
+	return matchesAppInstanceValue(live, *appInstance)
+}
+
+// annotationAndLabelTrackingProvider tracks the resources with the app instance annotation, and additionally sets the
+// app instance label for informational purposes
+type annotationAndLabelTrackingProvider struct {
+	annotationTrackingProvider
+}
+
+func (p *annotationAndLabelTrackingProvider) SetAppInstance(un *unstructured.Unstructured, key, val, namespace string, installationID string) error {
+	err := p.annotationTrackingProvider.SetAppInstance(un, key, val, namespace, installationID)
+	if err != nil {
+		return err
+	}
+	if len(val) > LabelMaxLength {
+		val = val[:LabelMaxLength]
+		// Prevent errors if the truncated name ends in a special character.
+		// See https://github.com/argoproj/argo-cd/issues/18237.
+		for !OkEndPattern.MatchString(val) {
+			if len(val) <= 1 {
+				return errors.New("failed to set app instance label: unable to truncate label to not end with a special character")
+			}
+			val = val[:len(val)-1]
+		}
+	}
+	err = kube.SetAppInstanceLabel(un, key, val)
+	if err != nil {
+		return fmt.Errorf("failed to set app instance label: %w", err)
+	}
+	return nil
+}
+
+// matchesAppInstanceValue returns true if the given Tracking ID (`aiv`) matches the given object. It returns false
+// when the ID doesn't match. This sometimes happens when a tracking label or annotation gets accidentally copied to a
+// different resource.
+func matchesAppInstanceValue(obj *unstructured.Unstructured, aiv AppInstanceValue) bool {
+	return (obj.GetNamespace() == aiv.Namespace || obj.GetNamespace() == "") &&
+		obj.GetName() == aiv.Name &&
+		obj.GetObjectKind().GroupVersionKind().Group == aiv.Group &&
+		obj.GetObjectKind().GroupVersionKind().Kind == aiv.Kind
+}
@@ -0,0 +1,122 @@
+package argo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/argoproj/argo-cd/v3/common"
+	"github.com/argoproj/argo-cd/v3/pkg/apis/application"
+	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	"github.com/argoproj/argo-cd/v3/test"
+	"github.com/argoproj/argo-cd/v3/util/settings"
+)
+
+// ownerReferenceTrackingProvider tracks the resources with an owner reference to their application
+type ownerReferenceTrackingProvider struct{}
+
+func (p *ownerReferenceTrackingProvider) GetAppName(un *unstructured.Unstructured, _ string, _ string) string {
+	for _, ref := range un.GetOwnerReferences() {
+		if ref.Kind == application.ApplicationKind {
+			return ref.Name
+		}
+	}
+	return ""
+}
+
+func (p *ownerReferenceTrackingProvider) GetAppInstance(_ *unstructured.Unstructured, _ string) *AppInstanceValue {
+	return nil
+}
+
+func (p *ownerReferenceTrackingProvider) SetAppInstance(un *unstructured.Unstructured, _, val, _ string, _ string) error {
+	un.SetOwnerReferences([]metav1.OwnerReference{{APIVersion: application.Group + "/v1alpha1", Kind: application.ApplicationKind, Name: val}})
+	return nil
+}
+
+func (p *ownerReferenceTrackingProvider) IsSelfReferencedObj(live, _ *unstructured.Unstructured, appName string, _ string) bool {
+	return live == nil || p.GetAppName(live, "", "") == appName
+}
+
+func TestRegisterTrackingProvider(t *testing.T) {
+	trackingMethod := v1alpha1.TrackingMethod("test-owner-reference")
+	assert.False(t, IsTrackingMethodSupported(trackingMethod))
+	assert.IsType(t, &annotationTrackingProvider{}, GetTrackingProvider(trackingMethod))
+
+	RegisterTrackingProvider(trackingMethod, &ownerReferenceTrackingProvider{})
+	assert.True(t, IsTrackingMethodSupported(trackingMethod))
+
+	resourceTracking := NewResourceTracking()
+	obj := &unstructured.Unstructured{}
+	obj.SetKind("ConfigMap")
+	obj.SetName("my-cm")
+	require.NoError(t, resourceTracking.SetAppInstance(obj, common.LabelKeyAppInstance, "my-app", "", trackingMethod, ""))
+	assert.Empty(t, obj.GetLabels())
+	assert.Empty(t, obj.GetAnnotations())
+	assert.Equal(t, "my-app", resourceTracking.GetAppName(obj, common.LabelKeyAppInstance, trackingMethod, ""))
+	assert.Nil(t, resourceTracking.GetAppInstance(obj, trackingMethod, ""))
+	assert.True(t, resourceTracking.IsSelfReferencedObj(obj, nil, "my-app", trackingMethod, ""))
+	assert.False(t, resourceTracking.IsSelfReferencedObj(obj, nil, "other-app", trackingMethod, ""))
+}
+
+func TestIsSelfReferencedObj(t *testing.T) {
+	resourceTracking := NewResourceTracking()
+	newObj := func(group, kind, namespace, name string) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{}
+		obj.SetAPIVersion(group + "/v1")
+		obj.SetKind(kind)
+		obj.SetNamespace(namespace)
+		obj.SetName(name)
+		return obj
+	}
+	live := newObj("apps", "Deployment", "default", "my-deploy")
+	require.NoError(t, resourceTracking.SetAppInstance(live, common.LabelKeyAppInstance, "my-app", "default", v1alpha1.TrackingMethodAnnotation, ""))
+	copied := newObj("apps", "Deployment", "default", "other-deploy")
+	copied.SetAnnotations(live.GetAnnotations())
+
+	for _, trackingMethod := range []v1alpha1.TrackingMethod{v1alpha1.TrackingMethodAnnotation, v1alpha1.TrackingMethodAnnotationAndLabel} {
+		assert.True(t, resourceTracking.IsSelfReferencedObj(nil, nil, "my-app", trackingMethod, ""))
+		assert.True(t, resourceTracking.IsSelfReferencedObj(live, nil, "my-app", trackingMethod, ""))
+		assert.False(t, resourceTracking.IsSelfReferencedObj(copied, nil, "my-app", trackingMethod, ""))
+		assert.True(t, resourceTracking.IsSelfReferencedObj(live, newObj("apps", "Deployment", "default", "my-deploy"), "my-app", trackingMethod, ""))
+		assert.False(t, resourceTracking.IsSelfReferencedObj(live, newObj("extensions", "Deployment", "default", "my-deploy"), "my-app", trackingMethod, ""))
+	}
+	// the label doesn't hold the metadata required to tell the copied resources apart
+	assert.True(t, resourceTracking.IsSelfReferencedObj(copied, nil, "my-app", v1alpha1.TrackingMethodLabel, ""))
+
+	// the resources tracked with another method than the one of the application are not managed by the application
+	labeled := newObj("apps", "Deployment", "default", "my-deploy")
+	require.NoError(t, resourceTracking.SetAppInstance(labeled, common.LabelKeyAppInstance, "my-app", "default", v1alpha1.TrackingMethodLabel, ""))
+	assert.False(t, resourceTracking.IsSelfReferencedObj(labeled, nil, "my-app", v1alpha1.TrackingMethodAnnotation, ""))
+	assert.True(t, resourceTracking.IsSelfReferencedObj(labeled, nil, "my-app", v1alpha1.TrackingMethodLabel, ""))
+}
+
+func TestGetTrackingMethod(t *testing.T) {
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      common.ArgoCDConfigMapName,
+			Namespace: test.FakeArgoCDNamespace,
+			Labels:    map[string]string{"app.kubernetes.io/part-of": "argocd"},
+		},
+		Data: map[string]string{"application.resourceTrackingMethod": string(v1alpha1.TrackingMethodAnnotationAndLabel)},
+	}
+	settingsMgr := settings.NewSettingsManager(t.Context(), fake.NewClientset(cm), test.FakeArgoCDNamespace)
+
+	trackingMethod, err := GetTrackingMethod(settingsMgr, nil)
+	require.NoError(t, err)
+	assert.Equal(t, string(v1alpha1.TrackingMethodAnnotationAndLabel), trackingMethod)
+
+	proj := &v1alpha1.AppProject{}
+	trackingMethod, err = GetTrackingMethod(settingsMgr, proj)
+	require.NoError(t, err)
+	assert.Equal(t, string(v1alpha1.TrackingMethodAnnotationAndLabel), trackingMethod)
+
+	proj.Spec.TrackingMethod = v1alpha1.TrackingMethodLabel
+	trackingMethod, err = GetTrackingMethod(settingsMgr, proj)
+	require.NoError(t, err)
+	assert.Equal(t, string(v1alpha1.TrackingMethodLabel), trackingMethod)
+}
@@ -0,0 +1,237 @@
+package attestation
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	// PayloadTypeInToto is the payload type of the DSSE envelopes containing an in-toto statement
+	PayloadTypeInToto = "application/vnd.in-toto+json"
+
+	// PredicateTypeSLSAProvenanceV1 is the predicate type of the SLSA provenance v1
+	PredicateTypeSLSAProvenanceV1 = "https://slsa.dev/provenance/v1"
+	// PredicateTypeSLSAProvenanceV02 is the predicate type of the SLSA provenance v0.2
+	PredicateTypeSLSAProvenanceV02 = "https://slsa.dev/provenance/v0.2"
+)
+
+// Envelope is a DSSE envelope, see https://github.com/secure-systems-lab/dsse/blob/master/envelope.md
+type Envelope struct {
+	PayloadType string      `json:"payloadType"`
+	Payload     string      `json:"payload"`
+	Signatures  []Signature `json:"signatures"`
+}
+
+// Signature is a signature of a DSSE envelope
+type Signature struct {
+	KeyID string `json:"keyid,omitempty"`
+	Sig   string `json:"sig"`
+}
+
+// Statement is an in-toto statement, see https://github.com/in-toto/attestation/blob/main/spec/v1/statement.md
+type Statement struct {
+	Type          string          `json:"_type"`
+	Subject       []Subject       `json:"subject"`
+	PredicateType string          `json:"predicateType"`
+	Predicate     json.RawMessage `json:"predicate"`
+}
+
+// Subject is a subject of an in-toto statement
+type Subject struct {
+	Name   string            `json:"name,omitempty"`
+	Digest map[string]string `json:"digest"`
+}
+
+// Provenance contains the fields of a SLSA provenance which are verified by the provenance policies
+type Provenance struct {
+	BuilderID        string
+	SourceRepository string
+}
+
+// ParseEnvelope parses a DSSE envelope
+func ParseEnvelope(data []byte) (*Envelope, error) {
+	var envelope Envelope
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return nil, fmt.Errorf("error decoding DSSE envelope: %w", err)
+	}
+	if envelope.PayloadType == "" || envelope.Payload == "" {
+		return nil, errors.New("DSSE envelope has no payload")
+	}
+	return &envelope, nil
+}
+
+// ParsePublicKeys parses the PEM encoded public keys
+func ParsePublicKeys(data string) ([]crypto.PublicKey, error) {
+	var keys []crypto.PublicKey
+	rest := []byte(data)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing public key: %w", err)
+		}
+		keys = append(keys, key)
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("no PEM encoded public key found")
+	}
+	return keys, nil
+}
+
+// pae returns the pre-authentication encoding of the payload, which is what the signatures of a DSSE envelope sign
+func pae(payloadType string, payload []byte) []byte {
+	return []byte(fmt.Sprintf("DSSEv1 %d %s %d %s", len(payloadType), payloadType, len(payload), payload))
+}
+
+// Verify checks that the envelope is signed by one of the given keys, and returns its decoded payload
+func (e *Envelope) Verify(keys []crypto.PublicKey) ([]byte, error) {
+	payload, err := base64.StdEncoding.DecodeString(e.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding DSSE payload: %w", err)
+	}
+	message := pae(e.PayloadType, payload)
+	for _, signature := range e.Signatures {
+		sig, err := base64.StdEncoding.DecodeString(signature.Sig)
+		if err != nil {
+			continue
+		}
+		for _, key := range keys {
+			if verifySignature(key, message, sig) {
+				return payload, nil
+			}
+		}
+	}
+	return nil, errors.New("no signature of the DSSE envelope matches a trusted key")
+}
+
+func verifySignature(key crypto.PublicKey, message []byte, sig []byte) bool {
+	digest := sha256.Sum256(message)
+	switch key := key.(type) {
+	case *ecdsa.PublicKey:
+		return ecdsa.VerifyASN1(key, digest[:], sig)
+	case *rsa.PublicKey:
+		if rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], sig) == nil {
+			return true
+		}
+		return rsa.VerifyPSS(key, crypto.SHA256, digest[:], sig, nil) == nil
+	case ed25519.PublicKey:
+		return ed25519.Verify(key, message, sig)
+	default:
+		return false
+	}
+}
+
+// ParseStatement parses the in-toto statement of the payload of a DSSE envelope
+func ParseStatement(payloadType string, payload []byte) (*Statement, error) {
+	if payloadType != PayloadTypeInToto {
+		return nil, fmt.Errorf("unsupported payload type %s", payloadType)
+	}
+	var statement Statement
+	if err := json.Unmarshal(payload, &statement); err != nil {
+		return nil, fmt.Errorf("error decoding in-toto statement: %w", err)
+	}
+	return &statement, nil
+}
+
+// HasSubject returns whether the statement has a subject with the given digest, e.g. sha256:abc
+func (s *Statement) HasSubject(digest string) bool {
+	algorithm, value, ok := strings.Cut(digest, ":")
+	if !ok {
+		return false
+	}
+	for _, subject := range s.Subject {
+		if strings.EqualFold(subject.Digest[algorithm], value) {
+			return true
+		}
+	}
+	return false
+}
+
+// Provenance returns the builder and the source repository of the SLSA provenance of the statement
+func (s *Statement) Provenance() (*Provenance, error) {
+	switch s.PredicateType {
+	case PredicateTypeSLSAProvenanceV1:
+		var predicate struct {
+			BuildDefinition struct {
+				ExternalParameters struct {
+					Workflow struct {
+						Repository string `json:"repository"`
+					} `json:"workflow"`
+				} `json:"externalParameters"`
+				ResolvedDependencies []struct {
+					URI string `json:"uri"`
+				} `json:"resolvedDependencies"`
+			} `json:"buildDefinition"`
+			RunDetails struct {
+				Builder struct {
+					ID string `json:"id"`
+				} `json:"builder"`
+			} `json:"runDetails"`
+		}
+		if err := json.Unmarshal(s.Predicate, &predicate); err != nil {
+			return nil, fmt.Errorf("error decoding SLSA provenance: %w", err)
+		}
+		provenance := &Provenance{
+			BuilderID:        predicate.RunDetails.Builder.ID,
+			SourceRepository: predicate.BuildDefinition.ExternalParameters.Workflow.Repository,
+		}
+		if provenance.SourceRepository == "" && len(predicate.BuildDefinition.ResolvedDependencies) > 0 {
+			provenance.SourceRepository = normalizeSourceURI(predicate.BuildDefinition.ResolvedDependencies[0].URI)
+		}
+		return provenance, nil
+	case PredicateTypeSLSAProvenanceV02:
+		var predicate struct {
+			Builder struct {
+				ID string `json:"id"`
+			} `json:"builder"`
+			Invocation struct {
+				ConfigSource struct {
+					URI string `json:"uri"`
+				} `json:"configSource"`
+			} `json:"invocation"`
+			Materials []struct {
+				URI string `json:"uri"`
+			} `json:"materials"`
+		}
+		if err := json.Unmarshal(s.Predicate, &predicate); err != nil {
+			return nil, fmt.Errorf("error decoding SLSA provenance: %w", err)
+		}
+		provenance := &Provenance{
+			BuilderID:        predicate.Builder.ID,
+			SourceRepository: normalizeSourceURI(predicate.Invocation.ConfigSource.URI),
+		}
+		if provenance.SourceRepository == "" && len(predicate.Materials) > 0 {
+			provenance.SourceRepository = normalizeSourceURI(predicate.Materials[0].URI)
+		}
+		return provenance, nil
+	default:
+		return nil, fmt.Errorf("unsupported predicate type %s", s.PredicateType)
+	}
+}
+
+// normalizeSourceURI returns the repository of a source URI of a provenance, e.g. https://github.com/example/repo for
+// git+https://github.com/example/repo@refs/heads/main
+func normalizeSourceURI(uri string) string {
+	uri = strings.TrimPrefix(uri, "git+")
+	if scheme, rest, ok := strings.Cut(uri, "://"); ok {
+		if i := strings.Index(rest, "@"); i >= 0 {
+			rest = rest[:i]
+		}
+		uri = scheme + "://" + rest
+	}
+	return strings.TrimSuffix(uri, ".git")
+}
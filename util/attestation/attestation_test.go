@@ -0,0 +1,143 @@
+package attestation
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func publicKeyPEM(t *testing.T, key crypto.PublicKey) string {
+	t.Helper()
+	der, err := x509.MarshalPKIXPublicKey(key)
+	require.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+}
+
+func signedEnvelope(t *testing.T, key crypto.Signer, payload []byte) *Envelope {
+	t.Helper()
+	message := pae(PayloadTypeInToto, payload)
+	var sig []byte
+	var err error
+	switch key := key.(type) {
+	case ed25519.PrivateKey:
+		sig = ed25519.Sign(key, message)
+	default:
+		digest := sha256.Sum256(message)
+		sig, err = key.Sign(rand.Reader, digest[:], crypto.SHA256)
+		require.NoError(t, err)
+	}
+	return &Envelope{
+		PayloadType: PayloadTypeInToto,
+		Payload:     base64.StdEncoding.EncodeToString(payload),
+		Signatures:  []Signature{{Sig: base64.StdEncoding.EncodeToString(sig)}},
+	}
+}
+
+func TestParsePublicKeys(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	edKey, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	keys, err := ParsePublicKeys(publicKeyPEM(t, &ecKey.PublicKey) + publicKeyPEM(t, edKey))
+	require.NoError(t, err)
+	assert.Len(t, keys, 2)
+
+	_, err = ParsePublicKeys("not a key")
+	require.EqualError(t, err, "no PEM encoded public key found")
+}
+
+func TestEnvelope_Verify(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	edPublicKey, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	payload := []byte(`{"_type":"https://in-toto.io/Statement/v1"}`)
+
+	t.Run("ECDSA", func(t *testing.T) {
+		verified, err := signedEnvelope(t, ecKey, payload).Verify([]crypto.PublicKey{&otherKey.PublicKey, &ecKey.PublicKey})
+		require.NoError(t, err)
+		assert.Equal(t, payload, verified)
+	})
+
+	t.Run("Ed25519", func(t *testing.T) {
+		verified, err := signedEnvelope(t, edKey, payload).Verify([]crypto.PublicKey{edPublicKey})
+		require.NoError(t, err)
+		assert.Equal(t, payload, verified)
+	})
+
+	t.Run("untrusted key", func(t *testing.T) {
+		_, err := signedEnvelope(t, otherKey, payload).Verify([]crypto.PublicKey{&ecKey.PublicKey})
+		require.EqualError(t, err, "no signature of the DSSE envelope matches a trusted key")
+	})
+
+	t.Run("tampered payload", func(t *testing.T) {
+		envelope := signedEnvelope(t, ecKey, payload)
+		envelope.Payload = base64.StdEncoding.EncodeToString([]byte(`{"_type":"tampered"}`))
+		_, err := envelope.Verify([]crypto.PublicKey{&ecKey.PublicKey})
+		require.Error(t, err)
+	})
+}
+
+func TestStatement_HasSubject(t *testing.T) {
+	statement := &Statement{Subject: []Subject{{Name: "chart", Digest: map[string]string{"sha256": "abc"}}}}
+	assert.True(t, statement.HasSubject("sha256:abc"))
+	assert.False(t, statement.HasSubject("sha256:def"))
+	assert.False(t, statement.HasSubject("abc"))
+}
+
+func TestStatement_Provenance(t *testing.T) {
+	newStatement := func(predicateType string, predicate any) *Statement {
+		data, err := json.Marshal(predicate)
+		require.NoError(t, err)
+		return &Statement{PredicateType: predicateType, Predicate: data}
+	}
+
+	t.Run("v1", func(t *testing.T) {
+		provenance, err := newStatement(PredicateTypeSLSAProvenanceV1, map[string]any{
+			"buildDefinition": map[string]any{
+				"externalParameters": map[string]any{"workflow": map[string]any{"repository": "https://github.com/example/charts"}},
+			},
+			"runDetails": map[string]any{"builder": map[string]any{"id": "https://github.com/actions/runner"}},
+		}).Provenance()
+		require.NoError(t, err)
+		assert.Equal(t, &Provenance{BuilderID: "https://github.com/actions/runner", SourceRepository: "https://github.com/example/charts"}, provenance)
+	})
+
+	t.Run("v1 with resolved dependencies", func(t *testing.T) {
+		provenance, err := newStatement(PredicateTypeSLSAProvenanceV1, map[string]any{
+			"buildDefinition": map[string]any{
+				"resolvedDependencies": []any{map[string]any{"uri": "git+https://github.com/example/charts@refs/heads/main"}},
+			},
+		}).Provenance()
+		require.NoError(t, err)
+		assert.Equal(t, "https://github.com/example/charts", provenance.SourceRepository)
+	})
+
+	t.Run("v0.2", func(t *testing.T) {
+		provenance, err := newStatement(PredicateTypeSLSAProvenanceV02, map[string]any{
+			"builder":    map[string]any{"id": "https://tekton.dev/chains/v2"},
+			"invocation": map[string]any{"configSource": map[string]any{"uri": "git+https://github.com/example/charts.git@refs/tags/v1.0.0"}},
+		}).Provenance()
+		require.NoError(t, err)
+		assert.Equal(t, &Provenance{BuilderID: "https://tekton.dev/chains/v2", SourceRepository: "https://github.com/example/charts"}, provenance)
+	})
+
+	t.Run("unsupported predicate", func(t *testing.T) {
+		_, err := newStatement("https://spdx.dev/Document", map[string]any{}).Provenance()
+		require.EqualError(t, err, "unsupported predicate type https://spdx.dev/Document")
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -71,6 +72,28 @@ func (c *Cache) SetAppManagedResources(appName string, managedResources []*appv1
 	return c.SetItem(appManagedResourcesKey(appName), managedResources, c.appStateCacheExpiration, managedResources == nil)
 }
 
+func appDiffSettingsHashKey(appName string) string {
+	return "app|diff-settings-hash|" + appName
+}
+
+// GetAppDiffSettingsHash returns the hash of the diff settings the cached managed resources of the application were
+// computed with.
+func (c *Cache) GetAppDiffSettingsHash(appName string, res *string) error {
+	return c.GetItem(appDiffSettingsHashKey(appName), res)
+}
+
+// SetAppDiffSettingsHash stores the hash of the diff settings the cached managed resources of the application were
+// computed with.
+func (c *Cache) SetAppDiffSettingsHash(appName string, hash string) error {
+	return c.SetItem(appDiffSettingsHashKey(appName), hash, c.appStateCacheExpiration, hash == "")
+}
+
+// CoalesceAppStateRefresh coalesces the refreshes of an application which follow concurrent cache misses of its
+// resource tree or managed resources, see cacheutil.Cache.CoalesceRegeneration
+func (c *Cache) CoalesceAppStateRefresh(ctx context.Context, appName string) (func(), error) {
+	return c.Cache.CoalesceRegeneration(ctx, "app|state|"+appName)
+}
+
 func appResourcesTreeKey(appName string, shard int64) string {
 	key := "app|resources-tree|" + appName
 	if shard > 0 {
@@ -121,6 +144,112 @@ func (c *Cache) SetAppResourcesTree(appName string, resourcesTree *appv1.Applica
 	return c.Cache.NotifyUpdated(appManagedResourcesKey(appName))
 }
 
+// ResourceTreeSnapshot is a snapshot of the nodes of the resource tree of an application
+type ResourceTreeSnapshot struct {
+	Time  time.Time            `json:"time"`
+	Nodes []appv1.ResourceNode `json:"nodes,omitempty"`
+}
+
+func appResourcesTreeSnapshotsKey(appName string) string {
+	return "app|resources-tree-snapshots|" + appName
+}
+
+// GetAppResourcesTreeSnapshots returns the snapshots of the resource tree of the application, from the oldest to the
+// newest
+func (c *Cache) GetAppResourcesTreeSnapshots(appName string, res *[]ResourceTreeSnapshot) error {
+	return c.GetItem(appResourcesTreeSnapshotsKey(appName), res)
+}
+
+// SetAppResourcesTreeSnapshots stores the snapshots of the resource tree of the application, which expire after the
+// given retention unless they are stored again
+func (c *Cache) SetAppResourcesTreeSnapshots(appName string, snapshots []ResourceTreeSnapshot, retention time.Duration) error {
+	return c.SetItem(appResourcesTreeSnapshotsKey(appName), snapshots, retention, snapshots == nil)
+}
+
+func hookLogsKey(appName string, namespace string, podName string, container string) string {
+	return fmt.Sprintf("app|hook-logs|%s|%s|%s|%s", appName, namespace, podName, container)
+}
+
+// GetHookLogs returns the logs of a container of a pod of a completed hook of the application
+func (c *Cache) GetHookLogs(appName string, namespace string, podName string, container string, res *string) error {
+	return c.GetItem(hookLogsKey(appName, namespace, podName, container), res)
+}
+
+// SetHookLogs stores the logs of a container of a pod of a completed hook of the application, which expire after the
+// given retention
+func (c *Cache) SetHookLogs(appName string, namespace string, podName string, container string, logs string, retention time.Duration) error {
+	return c.SetItem(hookLogsKey(appName, namespace, podName, container), logs, retention, false)
+}
+
+// appStateCacheKeyPrefixes are the prefixes of the keys of the cache entries of the applications. The manifests are
+// cached by the repo server with keys in the form mfst|<tracking key>|<app name>|...
+var appStateCacheKeyPrefixes = []string{"app|managed-resources|", "app|diff-settings-hash|", "app|resources-tree|", "app|resources-tree-snapshots|", "app|hook-logs|", "mfst|"}
+
+// StaleEntry is a cache entry of an application which doesn't exist anymore
+type StaleEntry struct {
+	Key     string
+	AppName string
+	Size    int64
+}
+
+// appNameFromKey returns the name of the application of a cache entry, or false if the key isn't the key of the entry
+// of an application
+func appNameFromKey(key string) (string, bool) {
+	switch {
+	case strings.HasPrefix(key, "app|managed-resources|"):
+		return strings.TrimPrefix(key, "app|managed-resources|"), true
+	case strings.HasPrefix(key, "app|diff-settings-hash|"):
+		return strings.TrimPrefix(key, "app|diff-settings-hash|"), true
+	case strings.HasPrefix(key, "app|resources-tree-snapshots|"):
+		return strings.TrimPrefix(key, "app|resources-tree-snapshots|"), true
+	case strings.HasPrefix(key, "app|resources-tree|"):
+		appName, _, _ := strings.Cut(strings.TrimPrefix(key, "app|resources-tree|"), "|")
+		return appName, true
+	case strings.HasPrefix(key, "app|hook-logs|"):
+		appName, _, _ := strings.Cut(strings.TrimPrefix(key, "app|hook-logs|"), "|")
+		return appName, true
+	case strings.HasPrefix(key, "mfst|"):
+		parts := strings.SplitN(key, "|", 4)
+		if len(parts) < 4 {
+			return "", false
+		}
+		return parts[2], true
+	}
+	return "", false
+}
+
+// CollectGarbage scans the managed resources, diff settings hashes, resource trees, resource tree snapshots, hook logs and manifests cached
+// for the applications, and deletes the entries of the applications for which exists returns false, unless dryRun is
+// true. The applications are identified by their instance name. It returns the stale entries which were found, or which were
+// deleted before an error.
+func (c *Cache) CollectGarbage(ctx context.Context, exists func(appName string) bool, dryRun bool) ([]StaleEntry, error) {
+	var stale []StaleEntry
+	seen := map[string]bool{}
+	for _, prefix := range appStateCacheKeyPrefixes {
+		err := c.Cache.ScanItems(ctx, prefix, func(key string, size int64) error {
+			appName, ok := appNameFromKey(key)
+			if !ok || seen[key] || exists(appName) {
+				return nil
+			}
+			seen[key] = true
+			stale = append(stale, StaleEntry{Key: key, AppName: appName, Size: size})
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error scanning the cache entries with prefix %s: %w", prefix, err)
+		}
+	}
+	if dryRun {
+		return stale, nil
+	}
+	for i, entry := range stale {
+		if err := c.Cache.DeleteItem(entry.Key); err != nil {
+			return stale[:i], fmt.Errorf("error deleting the cache entry %s: %w", entry.Key, err)
+		}
+	}
+	return stale, nil
+}
+
 func (c *Cache) SetClusterInfo(server string, info *appv1.ClusterInfo) error {
 	return c.SetItem(clusterInfoKey(server), info, clusterInfoCacheExpiration, info == nil)
 }
@@ -41,6 +41,21 @@ func TestCache_GetAppManagedResources(t *testing.T) {
 	assert.Equal(t, &[]*ResourceDiff{{Name: "my-name"}}, value)
 }
 
+func TestCache_GetAppDiffSettingsHash(t *testing.T) {
+	cache := newFixtures().Cache
+	// cache miss
+	var value string
+	err := cache.GetAppDiffSettingsHash("my-appname", &value)
+	assert.Equal(t, ErrCacheMiss, err)
+	// populate cache
+	err = cache.SetAppDiffSettingsHash("my-appname", "my-hash")
+	require.NoError(t, err)
+	// cache hit
+	err = cache.GetAppDiffSettingsHash("my-appname", &value)
+	require.NoError(t, err)
+	assert.Equal(t, "my-hash", value)
+}
+
 func TestCache_GetAppResourcesTree(t *testing.T) {
 	cache := newFixtures().Cache
 	// cache miss
@@ -59,6 +74,38 @@ func TestCache_GetAppResourcesTree(t *testing.T) {
 	assert.Equal(t, &ApplicationTree{Nodes: []ResourceNode{{}}}, value)
 }
 
+func TestCache_GetAppResourcesTreeSnapshots(t *testing.T) {
+	cache := newFixtures().Cache
+	var value []ResourceTreeSnapshot
+	err := cache.GetAppResourcesTreeSnapshots("my-appname", &value)
+	assert.Equal(t, ErrCacheMiss, err)
+	snapshots := []ResourceTreeSnapshot{{Time: time.Unix(1000, 0).UTC(), Nodes: []ResourceNode{{ResourceRef: ResourceRef{Kind: "Pod", Name: "my-pod"}}}}}
+	err = cache.SetAppResourcesTreeSnapshots("my-appname", snapshots, time.Hour)
+	require.NoError(t, err)
+	err = cache.GetAppResourcesTreeSnapshots("my-appname", &value)
+	require.NoError(t, err)
+	assert.Equal(t, snapshots, value)
+	// deletion
+	err = cache.SetAppResourcesTreeSnapshots("my-appname", nil, time.Hour)
+	require.NoError(t, err)
+	err = cache.GetAppResourcesTreeSnapshots("my-appname", &value)
+	assert.Equal(t, ErrCacheMiss, err)
+}
+
+func TestCache_GetHookLogs(t *testing.T) {
+	cache := newFixtures().Cache
+	var value string
+	err := cache.GetHookLogs("my-appname", "default", "migrate-x7k2p", "main", &value)
+	assert.Equal(t, ErrCacheMiss, err)
+	err = cache.SetHookLogs("my-appname", "default", "migrate-x7k2p", "main", "applying migration 12", time.Hour)
+	require.NoError(t, err)
+	err = cache.GetHookLogs("my-appname", "default", "migrate-x7k2p", "main", &value)
+	require.NoError(t, err)
+	assert.Equal(t, "applying migration 12", value)
+	err = cache.GetHookLogs("my-appname", "default", "migrate-x7k2p", "init", &value)
+	assert.Equal(t, ErrCacheMiss, err)
+}
+
 func TestCache_GetClusterInfo(t *testing.T) {
 	cache := newFixtures().Cache
 	// cache miss
@@ -82,3 +129,42 @@ func TestAddCacheFlagsToCmd(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, 1*time.Hour, cache.appStateCacheExpiration)
 }
+
+func TestCache_CollectGarbage(t *testing.T) {
+	cache := newFixtures().Cache
+	require.NoError(t, cache.SetAppManagedResources("my-app", []*ResourceDiff{{Name: "my-name"}}))
+	require.NoError(t, cache.SetAppManagedResources("deleted-app", []*ResourceDiff{{Name: "my-name"}}))
+	require.NoError(t, cache.SetAppDiffSettingsHash("deleted-app", "1234"))
+	require.NoError(t, cache.SetAppResourcesTree("deleted-app", &ApplicationTree{Nodes: []ResourceNode{{}}}))
+	require.NoError(t, cache.SetAppResourcesTreeSnapshots("deleted-app", []ResourceTreeSnapshot{{Time: time.Now()}}, time.Hour))
+	require.NoError(t, cache.SetHookLogs("deleted-app", "default", "migrate-x7k2p", "main", "logs", time.Hour))
+	require.NoError(t, cache.Cache.SetItem("mfst|app.kubernetes.io/instance|deleted-app|HEAD|default|1234", "manifests", nil))
+	require.NoError(t, cache.SetClusterInfo("https://kubernetes.default.svc", &ClusterInfo{}))
+	exists := func(appName string) bool {
+		return appName == "my-app"
+	}
+
+	stale, err := cache.CollectGarbage(t.Context(), exists, true)
+	require.NoError(t, err)
+	assert.Len(t, stale, 6)
+	for _, entry := range stale {
+		assert.Equal(t, "deleted-app", entry.AppName)
+		assert.Positive(t, entry.Size)
+	}
+	require.NoError(t, cache.GetAppManagedResources("deleted-app", &[]*ResourceDiff{}))
+
+	stale, err = cache.CollectGarbage(t.Context(), exists, false)
+	require.NoError(t, err)
+	assert.Len(t, stale, 6)
+	assert.Equal(t, ErrCacheMiss, cache.GetAppManagedResources("deleted-app", &[]*ResourceDiff{}))
+	assert.Equal(t, ErrCacheMiss, cache.GetAppDiffSettingsHash("deleted-app", new(string)))
+	assert.Equal(t, ErrCacheMiss, cache.GetAppResourcesTree("deleted-app", &ApplicationTree{}))
+	assert.Equal(t, ErrCacheMiss, cache.GetAppResourcesTreeSnapshots("deleted-app", &[]ResourceTreeSnapshot{}))
+	assert.Equal(t, ErrCacheMiss, cache.GetHookLogs("deleted-app", "default", "migrate-x7k2p", "main", new(string)))
+	require.NoError(t, cache.GetAppManagedResources("my-app", &[]*ResourceDiff{}))
+	require.NoError(t, cache.GetClusterInfo("https://kubernetes.default.svc", &ClusterInfo{}))
+
+	stale, err = cache.CollectGarbage(t.Context(), exists, false)
+	require.NoError(t, err)
+	assert.Empty(t, stale)
+}
@@ -41,7 +41,7 @@ const (
 )
 
 func NewCache(client CacheClient) *Cache {
-	return &Cache{client}
+	return &Cache{client: client}
 }
 
 func buildRedisClient(redisAddress, password, username string, redisDB, maxRetries int, tlsConfig *tls.Config) *redis.Client {
@@ -63,6 +63,24 @@ func buildRedisClient(redisAddress, password, username string, redisDB, maxRetri
 	return client
 }
 
+func buildClusterRedisClient(password, username string, maxRetries int, tlsConfig *tls.Config, clusterAddresses []string) *redis.ClusterClient {
+	opts := &redis.ClusterOptions{
+		Addrs:      clusterAddresses,
+		Password:   password,
+		MaxRetries: maxRetries,
+		TLSConfig:  tlsConfig,
+		Username:   username,
+	}
+
+	client := redis.NewClusterClient(opts)
+
+	client.AddHook(redis.Hook(NewArgoRedisHook(func() {
+		*client = *buildClusterRedisClient(password, username, maxRetries, tlsConfig, clusterAddresses)
+	})))
+
+	return client
+}
+
 func buildFailoverRedisClient(sentinelMaster, sentinelUsername, sentinelPassword, password, username string, redisDB, maxRetries int, tlsConfig *tls.Config, sentinelAddresses []string) *redis.Client {
 	opts := &redis.FailoverOptions{
 		MasterName:       sentinelMaster,
@@ -87,10 +105,13 @@ func buildFailoverRedisClient(sentinelMaster, sentinelUsername, sentinelPassword
 
 type Options struct {
 	FlagPrefix      string
-	OnClientCreated func(client *redis.Client)
+	OnClientCreated func(client redis.UniversalClient)
+	// EmbeddedBackendSupported adds the flags selecting the embedded cache backend. It is only suitable for the
+	// components whose cache is not read by the other components, since the embedded cache is not shared.
+	EmbeddedBackendSupported bool
 }
 
-func (o *Options) callOnClientCreated(client *redis.Client) {
+func (o *Options) callOnClientCreated(client redis.UniversalClient) {
 	if o.OnClientCreated != nil {
 		o.OnClientCreated(client)
 	}
@@ -109,6 +130,9 @@ func mergeOptions(opts ...Options) Options {
 		if o.OnClientCreated != nil {
 			result.OnClientCreated = o.OnClientCreated
 		}
+		if o.EmbeddedBackendSupported {
+			result.EmbeddedBackendSupported = true
+		}
 	}
 	return result
 }
@@ -134,12 +158,14 @@ func AddCacheFlagsToCmd(cmd *cobra.Command, opts ...Options) func() (*Cache, err
 	redisAddress := ""
 	sentinelAddresses := make([]string, 0)
 	sentinelMaster := ""
+	clusterAddresses := make([]string, 0)
 	redisDB := 0
 	redisCACertificate := ""
 	redisClientCertificate := ""
 	redisClientKey := ""
 	redisUseTLS := false
 	insecureRedis := false
+	redisTLSServerName := ""
 	compressionStr := ""
 	opt := mergeOptions(opts...)
 	var defaultCacheExpiration time.Duration
@@ -152,6 +178,8 @@ func AddCacheFlagsToCmd(cmd *cobra.Command, opts ...Options) func() (*Cache, err
 	sentinelAddressesSrc := getFlagVal(cmd, opt, "sentinel", cmd.Flags().GetStringArray)
 	cmd.Flags().StringVar(&sentinelMaster, opt.FlagPrefix+"sentinelmaster", "master", "Redis sentinel master group name.")
 	sentinelMasterSrc := getFlagVal(cmd, opt, "sentinelmaster", cmd.Flags().GetString)
+	cmd.Flags().StringArrayVar(&clusterAddresses, opt.FlagPrefix+"redis-cluster", env.StringsFromEnv(opt.getEnvPrefix()+"REDIS_CLUSTER_NODES", []string{}, ","), "Redis Cluster node hostname and port (e.g. argocd-redis-cluster-0:6379). Connects to Redis in cluster mode, the remaining nodes are discovered from the given ones.")
+	clusterAddressesSrc := getFlagVal(cmd, opt, "redis-cluster", cmd.Flags().GetStringArray)
 	cmd.Flags().DurationVar(&defaultCacheExpiration, opt.FlagPrefix+"default-cache-expiration", env.ParseDurationFromEnv("ARGOCD_DEFAULT_CACHE_EXPIRATION", 24*time.Hour, 0, math.MaxInt64), "Cache expiration default")
 	defaultCacheExpirationSrc := getFlagVal(cmd, opt, "default-cache-expiration", cmd.Flags().GetDuration)
 	cmd.Flags().BoolVar(&redisUseTLS, opt.FlagPrefix+"redis-use-tls", false, "Use TLS when connecting to Redis. ")
@@ -162,26 +190,48 @@ func AddCacheFlagsToCmd(cmd *cobra.Command, opts ...Options) func() (*Cache, err
 	redisClientKeySrc := getFlagVal(cmd, opt, "redis-client-key", cmd.Flags().GetString)
 	cmd.Flags().BoolVar(&insecureRedis, opt.FlagPrefix+"redis-insecure-skip-tls-verify", false, "Skip Redis server certificate validation.")
 	insecureRedisSrc := getFlagVal(cmd, opt, "redis-insecure-skip-tls-verify", cmd.Flags().GetBool)
+	cmd.Flags().StringVar(&redisTLSServerName, opt.FlagPrefix+"redis-tls-server-name", env.StringFromEnv(opt.getEnvPrefix()+"REDIS_TLS_SERVER_NAME", ""), "Server name sent as TLS SNI and used to validate the Redis server certificates. If not specified, the hostname of each Redis node is used.")
+	redisTLSServerNameSrc := getFlagVal(cmd, opt, "redis-tls-server-name", cmd.Flags().GetString)
 	cmd.Flags().StringVar(&redisCACertificate, opt.FlagPrefix+"redis-ca-certificate", "", "Path to Redis server CA certificate (e.g. /etc/certs/redis/ca.crt). If not specified, system trusted CAs will be used for server certificate validation.")
 	redisCACertificateSrc := getFlagVal(cmd, opt, "redis-ca-certificate", cmd.Flags().GetString)
-	cmd.Flags().StringVar(&compressionStr, opt.FlagPrefix+CLIFlagRedisCompress, env.StringFromEnv(opt.getEnvPrefix()+"REDIS_COMPRESSION", string(RedisCompressionGZip)), "Enable compression for data sent to Redis with the required compression algorithm. (possible values: gzip, none)")
+	cmd.Flags().StringVar(&compressionStr, opt.FlagPrefix+CLIFlagRedisCompress, env.StringFromEnv(opt.getEnvPrefix()+"REDIS_COMPRESSION", string(RedisCompressionGZip)), "Enable compression for data sent to Redis with the required compression algorithm. (possible values: gzip, zstd, none)")
 	compressionStrSrc := getFlagVal(cmd, opt, CLIFlagRedisCompress, cmd.Flags().GetString)
+	cacheBackend := CacheBackendRedis
+	embeddedCachePath := ""
+	if opt.EmbeddedBackendSupported {
+		cmd.Flags().StringVar(&cacheBackend, opt.FlagPrefix+"cache-backend", env.StringFromEnv("ARGOCD_CACHE_BACKEND", CacheBackendRedis), "Cache backend. The embedded backend keeps the cache in memory so that Redis is not needed, but is not shared with the other components. (possible values: redis, embedded)")
+		cmd.Flags().StringVar(&embeddedCachePath, opt.FlagPrefix+"embedded-cache-path", env.StringFromEnv("ARGOCD_EMBEDDED_CACHE_PATH", ""), "Path of the file persisting the embedded cache across restarts. The embedded cache is only kept in memory if empty.")
+	}
 	return func() (*Cache, error) {
 		redisAddress := redisAddressSrc()
 		redisDB := redisDBSrc()
 		sentinelAddresses := sentinelAddressesSrc()
 		sentinelMaster := sentinelMasterSrc()
+		clusterAddresses := clusterAddressesSrc()
 		defaultCacheExpiration := defaultCacheExpirationSrc()
 		redisUseTLS := redisUseTLSSrc()
 		redisClientCertificate := redisClientCertificateSrc()
 		redisClientKey := redisClientKeySrc()
 		insecureRedis := insecureRedisSrc()
+		redisTLSServerName := redisTLSServerNameSrc()
 		redisCACertificate := redisCACertificateSrc()
 		compressionStr := compressionStrSrc()
 
+		switch cacheBackend {
+		case CacheBackendEmbedded:
+			client, err := NewEmbeddedCache(defaultCacheExpiration, embeddedCachePath)
+			if err != nil {
+				return nil, err
+			}
+			return NewCache(client), nil
+		case CacheBackendRedis:
+		default:
+			return nil, fmt.Errorf("unknown cache backend: %s", cacheBackend)
+		}
+
 		var tlsConfig *tls.Config
 		if redisUseTLS {
-			tlsConfig = &tls.Config{}
+			tlsConfig = &tls.Config{ServerName: redisTLSServerName}
 			if redisClientCertificate != "" {
 				clientCert, err := tls.LoadX509KeyPair(redisClientCertificate, redisClientKey)
 				if err != nil {
@@ -230,6 +280,17 @@ func AddCacheFlagsToCmd(cmd *cobra.Command, opts ...Options) func() (*Cache, err
 		if err != nil {
 			return nil, err
 		}
+		if len(clusterAddresses) > 0 {
+			if len(sentinelAddresses) > 0 {
+				return nil, errors.New("redis cluster and sentinel addresses are mutually exclusive")
+			}
+			if redisDB != 0 {
+				return nil, errors.New("redis cluster only supports database 0")
+			}
+			client := buildClusterRedisClient(password, username, maxRetries, tlsConfig, clusterAddresses)
+			opt.callOnClientCreated(client)
+			return NewCache(NewRedisCache(client, defaultCacheExpiration, compression)), nil
+		}
 		if len(sentinelAddresses) > 0 {
 			client := buildFailoverRedisClient(sentinelMaster, sentinelUsername, sentinelPassword, password, username, redisDB, maxRetries, tlsConfig, sentinelAddresses)
 			opt.callOnClientCreated(client)
@@ -247,7 +308,9 @@ func AddCacheFlagsToCmd(cmd *cobra.Command, opts ...Options) func() (*Cache, err
 
 // Cache provides strongly types methods to store and retrieve values from shared cache
 type Cache struct {
-	client CacheClient
+	client        CacheClient
+	metrics       CacheMetricsRegistry
+	regenerations regenerations
 }
 
 func (c *Cache) GetClient() CacheClient {
@@ -258,6 +321,11 @@ func (c *Cache) SetClient(client CacheClient) {
 	c.client = client
 }
 
+// SetMetricsRegistry sets the registry recording the requests of the cache, nil disables the metrics
+func (c *Cache) SetMetricsRegistry(registry CacheMetricsRegistry) {
+	c.metrics = registry
+}
+
 func (c *Cache) RenameItem(oldKey string, newKey string, expiration time.Duration) error {
 	return c.client.Rename(fmt.Sprintf("%s|%s", oldKey, common.CacheVersion), fmt.Sprintf("%s|%s", newKey, common.CacheVersion), expiration)
 }
@@ -279,19 +347,48 @@ func (c *Cache) SetItem(key string, item any, opts *CacheActionOpts) error {
 	}
 	fullKey := c.generateFullKey(key)
 	client := c.GetClient()
+	startTime := time.Now()
 	if opts.Delete {
-		return client.Delete(fullKey)
+		err := client.Delete(fullKey)
+		c.recordRequest(key, cacheOperationDelete, err, startTime)
+		return err
 	}
-	return client.Set(&Item{Key: fullKey, Object: item, CacheActionOpts: *opts})
+	err := client.Set(&Item{Key: fullKey, Object: item, CacheActionOpts: *opts})
+	c.recordRequest(key, cacheOperationSet, err, startTime)
+	return err
 }
 
 func (c *Cache) GetItem(key string, item any) error {
-	key = c.generateFullKey(key)
+	fullKey := c.generateFullKey(key)
 	if item == nil {
-		return fmt.Errorf("cannot get item into a nil for key %s", key)
+		return fmt.Errorf("cannot get item into a nil for key %s", fullKey)
 	}
 	client := c.GetClient()
-	return client.Get(key, item)
+	startTime := time.Now()
+	err := client.Get(fullKey, item)
+	c.recordRequest(key, cacheOperationGet, err, startTime)
+	return err
+}
+
+// DeleteItem deletes an item from the cache
+func (c *Cache) DeleteItem(key string) error {
+	startTime := time.Now()
+	err := c.GetClient().Delete(c.generateFullKey(key))
+	c.recordRequest(key, cacheOperationDelete, err, startTime)
+	return err
+}
+
+// ScanItems calls the callback with the key and the size in bytes of the items of the current cache version whose key
+// starts with the prefix
+func (c *Cache) ScanItems(ctx context.Context, prefix string, callback func(key string, size int64) error) error {
+	suffix := "|" + common.CacheVersion
+	return c.GetClient().Scan(ctx, prefix, func(fullKey string, size int64) error {
+		key, ok := strings.CutSuffix(fullKey, suffix)
+		if !ok {
+			return nil
+		}
+		return callback(key, size)
+	})
 }
 
 func (c *Cache) OnUpdated(ctx context.Context, key string, callback func() error) error {
@@ -87,3 +87,48 @@ func TestGenerateCacheKey(t *testing.T) {
 	testKey := cache.generateFullKey("testkey")
 	assert.Equal(t, "testkey|"+common.CacheVersion, testKey)
 }
+
+type fakeCacheMetrics struct {
+	requests  map[string]int
+	durations map[string]int
+}
+
+func (m *fakeCacheMetrics) IncCacheRequest(entryType, operation, result string) {
+	m.requests[entryType+"/"+operation+"/"+result]++
+}
+
+func (m *fakeCacheMetrics) ObserveCacheRequestDuration(entryType, operation string, _ time.Duration) {
+	m.durations[entryType+"/"+operation]++
+}
+
+func TestCacheMetrics(t *testing.T) {
+	metrics := &fakeCacheMetrics{requests: map[string]int{}, durations: map[string]int{}}
+	cache := NewCache(NewInMemoryCache(60 * time.Second))
+	cache.SetMetricsRegistry(metrics)
+
+	var output string
+	require.ErrorIs(t, cache.GetItem("mfst|app|revision", &output), ErrCacheMiss)
+	require.NoError(t, cache.SetItem("mfst|app|revision", "manifests", nil))
+	require.NoError(t, cache.GetItem("mfst|app|revision", &output))
+	require.NoError(t, cache.SetItem("app|resources-tree|app", "tree", &CacheActionOpts{Delete: true}))
+
+	assert.Equal(t, map[string]int{
+		"manifests/get/miss":           1,
+		"manifests/get/hit":            1,
+		"manifests/set/success":        1,
+		"resource-tree/delete/success": 1,
+	}, metrics.requests)
+	assert.Equal(t, map[string]int{
+		"manifests/get":        2,
+		"manifests/set":        1,
+		"resource-tree/delete": 1,
+	}, metrics.durations)
+}
+
+func TestCacheEntryType(t *testing.T) {
+	assert.Equal(t, "manifests", CacheEntryType("mfst|label|app|HEAD|default|123"))
+	assert.Equal(t, "app-managed-resources", CacheEntryType("app|managed-resources|my-app"))
+	assert.Equal(t, "resource-tree", CacheEntryType("app|resources-tree|my-app|1"))
+	assert.Equal(t, "git-refs", CacheEntryType("git-refs|https://github.com/argoproj/argo-cd"))
+	assert.Equal(t, "foo", CacheEntryType("foo"))
+}
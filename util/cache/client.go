@@ -34,4 +34,6 @@ type CacheClient interface {
 	Delete(key string) error
 	OnUpdated(ctx context.Context, key string, callback func() error) error
 	NotifyUpdated(key string) error
+	// Scan calls the callback with the key and the size in bytes of the items whose key starts with the prefix
+	Scan(ctx context.Context, prefix string, callback func(key string, size int64) error) error
 }
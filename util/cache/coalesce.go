@@ -0,0 +1,43 @@
+package cache
+
+import (
+	"context"
+	"sync"
+)
+
+// regenerations tracks the items being regenerated after a cache miss, so that concurrent cache misses of an item
+// result in a single regeneration
+type regenerations struct {
+	lock     sync.Mutex
+	inflight map[string]chan struct{}
+}
+
+// CoalesceRegeneration coalesces the regenerations of the item cached with the given key which follow concurrent
+// cache misses. The first caller gets a function which it must call once it has regenerated and cached the item, or
+// failed to. The other callers wait until then and get a nil function, after which they are expected to get the item
+// from the cache, and to only regenerate it themselves if it is still missing.
+func (c *Cache) CoalesceRegeneration(ctx context.Context, key string) (func(), error) {
+	c.regenerations.lock.Lock()
+	if inflight, ok := c.regenerations.inflight[key]; ok {
+		c.regenerations.lock.Unlock()
+		select {
+		case <-inflight:
+			return nil, nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	if c.regenerations.inflight == nil {
+		c.regenerations.inflight = map[string]chan struct{}{}
+	}
+	inflight := make(chan struct{})
+	c.regenerations.inflight[key] = inflight
+	c.regenerations.lock.Unlock()
+
+	return sync.OnceFunc(func() {
+		c.regenerations.lock.Lock()
+		delete(c.regenerations.inflight, key)
+		c.regenerations.lock.Unlock()
+		close(inflight)
+	}), nil
+}
@@ -0,0 +1,67 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCoalesceRegeneration(t *testing.T) {
+	cache := NewCache(NewInMemoryCache(time.Minute))
+
+	done, err := cache.CoalesceRegeneration(t.Context(), "foo")
+	require.NoError(t, err)
+	require.NotNil(t, done)
+
+	// other keys are regenerated independently
+	otherDone, err := cache.CoalesceRegeneration(t.Context(), "bar")
+	require.NoError(t, err)
+	require.NotNil(t, otherDone)
+	otherDone()
+
+	var waiting sync.WaitGroup
+	var released atomic.Int32
+	for i := 0; i < 10; i++ {
+		waiting.Add(1)
+		go func() {
+			defer waiting.Done()
+			waiterDone, err := cache.CoalesceRegeneration(context.Background(), "foo")
+			assert.NoError(t, err)
+			assert.Nil(t, waiterDone)
+			released.Add(1)
+		}()
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, int32(0), released.Load())
+	done()
+	// calling the function again is a no-op
+	done()
+	waiting.Wait()
+	assert.Equal(t, int32(10), released.Load())
+
+	// the key can be regenerated again once the regeneration is done
+	done, err = cache.CoalesceRegeneration(t.Context(), "foo")
+	require.NoError(t, err)
+	require.NotNil(t, done)
+	done()
+}
+
+func TestCoalesceRegeneration_ContextDone(t *testing.T) {
+	cache := NewCache(NewInMemoryCache(time.Minute))
+
+	done, err := cache.CoalesceRegeneration(t.Context(), "foo")
+	require.NoError(t, err)
+	defer done()
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	waiterDone, err := cache.CoalesceRegeneration(ctx, "foo")
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Nil(t, waiterDone)
+}
@@ -0,0 +1,204 @@
+package cache
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	bolt "go.etcd.io/bbolt"
+)
+
+const (
+	// CacheBackendRedis stores the cache in Redis, which is shared by all the Argo CD components
+	CacheBackendRedis = "redis"
+	// CacheBackendEmbedded stores the cache in the memory of the component, optionally persisted in a local file
+	CacheBackendEmbedded = "embedded"
+)
+
+// embeddedCacheBucket is the bucket of the bbolt database holding the persisted cache items
+var embeddedCacheBucket = []byte("cache")
+
+// NewEmbeddedCache returns a cache client which keeps the items in memory, and persists them in the bbolt database at
+// the given path if it is not empty, so that the cache survives restarts of the component.
+func NewEmbeddedCache(expiration time.Duration, path string) (*EmbeddedCache, error) {
+	c := &EmbeddedCache{
+		InMemoryCache: NewInMemoryCache(expiration),
+		subscribers:   map[string]map[chan struct{}]bool{},
+	}
+	if path == "" {
+		return c, nil
+	}
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: 10 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("error opening embedded cache database %s: %w", path, err)
+	}
+	c.db = db
+	if err := c.load(); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("error loading embedded cache database %s: %w", path, err)
+	}
+	// the items expired or deleted from memory are deleted from the database as well
+	c.memCache.OnEvicted(func(key string, _ any) {
+		if err := c.db.Batch(func(tx *bolt.Tx) error {
+			return tx.Bucket(embeddedCacheBucket).Delete([]byte(key))
+		}); err != nil {
+			log.Warnf("Failed to delete %s from the embedded cache database: %v", key, err)
+		}
+	})
+	return c, nil
+}
+
+// compile-time validation of adherence of the CacheClient contract
+var _ CacheClient = &EmbeddedCache{}
+
+// EmbeddedCache is a cache client which doesn't need Redis. Unlike Redis, it is not shared between the components, and
+// update notifications are only delivered within the component.
+type EmbeddedCache struct {
+	*InMemoryCache
+	db *bolt.DB
+
+	lock        sync.Mutex
+	subscribers map[string]map[chan struct{}]bool
+}
+
+// load loads the unexpired items of the database in memory, and deletes the expired ones
+func (c *EmbeddedCache) load() error {
+	now := time.Now()
+	return c.db.Update(func(tx *bolt.Tx) error {
+		bucket, err := tx.CreateBucketIfNotExists(embeddedCacheBucket)
+		if err != nil {
+			return err
+		}
+		var expired [][]byte
+		err = bucket.ForEach(func(key, value []byte) error {
+			expiresAt, data, err := decodeEmbeddedItem(value)
+			if err != nil {
+				return err
+			}
+			if !expiresAt.IsZero() && !expiresAt.After(now) {
+				expired = append(expired, key)
+				return nil
+			}
+			var expiration time.Duration
+			if !expiresAt.IsZero() {
+				expiration = expiresAt.Sub(now)
+			}
+			c.memCache.Set(string(key), *bytes.NewBuffer(bytes.Clone(data)), expiration)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		for _, key := range expired {
+			if err := bucket.Delete(key); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// persist stores the item cached in memory with the given key in the database
+func (c *EmbeddedCache) persist(key string) error {
+	if c.db == nil {
+		return nil
+	}
+	value, expiresAt, found := c.memCache.GetWithExpiration(key)
+	if !found {
+		return nil
+	}
+	buf := value.(bytes.Buffer)
+	return c.db.Batch(func(tx *bolt.Tx) error {
+		return tx.Bucket(embeddedCacheBucket).Put([]byte(key), encodeEmbeddedItem(expiresAt, buf.Bytes()))
+	})
+}
+
+// encodeEmbeddedItem prefixes the data of an item with its expiration time in Unix nanoseconds, zero if it never expires
+func encodeEmbeddedItem(expiresAt time.Time, data []byte) []byte {
+	value := make([]byte, 8, 8+len(data))
+	if !expiresAt.IsZero() {
+		binary.BigEndian.PutUint64(value, uint64(expiresAt.UnixNano()))
+	}
+	return append(value, data...)
+}
+
+func decodeEmbeddedItem(value []byte) (time.Time, []byte, error) {
+	if len(value) < 8 {
+		return time.Time{}, nil, errors.New("invalid embedded cache item")
+	}
+	var expiresAt time.Time
+	if nanos := binary.BigEndian.Uint64(value[:8]); nanos != 0 {
+		expiresAt = time.Unix(0, int64(nanos))
+	}
+	return expiresAt, value[8:], nil
+}
+
+func (c *EmbeddedCache) Set(item *Item) error {
+	if err := c.InMemoryCache.Set(item); err != nil {
+		return err
+	}
+	return c.persist(item.Key)
+}
+
+func (c *EmbeddedCache) Rename(oldKey string, newKey string, expiration time.Duration) error {
+	if err := c.InMemoryCache.Rename(oldKey, newKey, expiration); err != nil {
+		return err
+	}
+	return c.persist(newKey)
+}
+
+// Close closes the database of the cache, if any
+func (c *EmbeddedCache) Close() error {
+	if c.db == nil {
+		return nil
+	}
+	return c.db.Close()
+}
+
+func (c *EmbeddedCache) OnUpdated(ctx context.Context, key string, callback func() error) error {
+	ch := make(chan struct{}, 1)
+	c.lock.Lock()
+	if c.subscribers[key] == nil {
+		c.subscribers[key] = map[chan struct{}]bool{}
+	}
+	c.subscribers[key][ch] = true
+	c.lock.Unlock()
+
+	defer func() {
+		c.lock.Lock()
+		delete(c.subscribers[key], ch)
+		if len(c.subscribers[key]) == 0 {
+			delete(c.subscribers, key)
+		}
+		c.lock.Unlock()
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ch:
+			if err := callback(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (c *EmbeddedCache) NotifyUpdated(key string) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for ch := range c.subscribers[key] {
+		// the subscribers which haven't handled the previous notification yet don't need another one
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package cache
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmbeddedCache(t *testing.T) {
+	client, err := NewEmbeddedCache(time.Hour, "")
+	require.NoError(t, err)
+	cache := NewCache(client)
+
+	require.NoError(t, cache.SetItem("foo", "bar", &CacheActionOpts{}))
+	var res string
+	require.NoError(t, cache.GetItem("foo", &res))
+	assert.Equal(t, "bar", res)
+
+	require.NoError(t, cache.SetItem("foo", "", &CacheActionOpts{Delete: true}))
+	assert.ErrorIs(t, cache.GetItem("foo", &res), ErrCacheMiss)
+}
+
+func TestEmbeddedCache_Persistence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache.db")
+	client, err := NewEmbeddedCache(time.Hour, path)
+	require.NoError(t, err)
+
+	require.NoError(t, client.Set(&Item{Key: "kept", Object: "value"}))
+	require.NoError(t, client.Set(&Item{Key: "deleted", Object: "value"}))
+	require.NoError(t, client.Delete("deleted"))
+	require.NoError(t, client.Set(&Item{Key: "old", Object: "renamed"}))
+	require.NoError(t, client.Rename("old", "new", time.Hour))
+	require.NoError(t, client.Set(&Item{Key: "expired", Object: "value", CacheActionOpts: CacheActionOpts{Expiration: time.Millisecond}}))
+	require.NoError(t, client.Close())
+
+	time.Sleep(10 * time.Millisecond)
+	client, err = NewEmbeddedCache(time.Hour, path)
+	require.NoError(t, err)
+	defer client.Close()
+
+	var res string
+	require.NoError(t, client.Get("kept", &res))
+	assert.Equal(t, "value", res)
+	require.NoError(t, client.Get("new", &res))
+	assert.Equal(t, "renamed", res)
+	for _, key := range []string{"deleted", "old", "expired"} {
+		assert.ErrorIs(t, client.Get(key, &res), ErrCacheMiss, key)
+	}
+}
+
+func TestEmbeddedCache_OnUpdated(t *testing.T) {
+	client, err := NewEmbeddedCache(time.Hour, "")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+	updated := make(chan struct{}, 1)
+	go func() {
+		_ = client.OnUpdated(ctx, "foo", func() error {
+			updated <- struct{}{}
+			return nil
+		})
+	}()
+
+	assert.Eventually(t, func() bool {
+		require.NoError(t, client.NotifyUpdated("foo"))
+		select {
+		case <-updated:
+			return true
+		default:
+			return false
+		}
+	}, time.Second, 10*time.Millisecond)
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/gob"
 	"fmt"
+	"strings"
 	"time"
 
 	gocache "github.com/patrickmn/go-cache"
@@ -97,6 +98,19 @@ func (i *InMemoryCache) NotifyUpdated(_ string) error {
 	return nil
 }
 
+func (i *InMemoryCache) Scan(_ context.Context, prefix string, callback func(key string, size int64) error) error {
+	for key, value := range i.memCache.Items() {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		buf := value.Object.(bytes.Buffer)
+		if err := callback(key, int64(buf.Len())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Items return a list of items in the cache; requires passing a constructor function
 // so that the items can be decoded from gob format.
 func (i *InMemoryCache) Items(createNewObject func() any) (map[string]any, error) {
@@ -0,0 +1,70 @@
+package cache
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+const (
+	cacheOperationGet    = "get"
+	cacheOperationSet    = "set"
+	cacheOperationDelete = "delete"
+
+	cacheResultHit     = "hit"
+	cacheResultMiss    = "miss"
+	cacheResultSuccess = "success"
+	cacheResultError   = "error"
+)
+
+// cacheEntryTypes maps the prefixes of cache keys to the types of the cached entries. Keys without a known prefix are
+// typed by their first segment.
+var cacheEntryTypes = []struct {
+	prefix    string
+	entryType string
+}{
+	{prefix: "mfst|", entryType: "manifests"},
+	{prefix: "app|managed-resources|", entryType: "app-managed-resources"},
+	{prefix: "app|resources-tree|", entryType: "resource-tree"},
+	{prefix: "appdetails|", entryType: "app-details"},
+	{prefix: "cluster|info|", entryType: "cluster-info"},
+}
+
+// CacheMetricsRegistry records the requests of the cache segmented by the type of the cached entries
+type CacheMetricsRegistry interface {
+	IncCacheRequest(entryType, operation, result string)
+	ObserveCacheRequestDuration(entryType, operation string, duration time.Duration)
+}
+
+// CacheEntryType returns the type of the entry cached with the given key
+func CacheEntryType(key string) string {
+	for _, t := range cacheEntryTypes {
+		if strings.HasPrefix(key, t.prefix) {
+			return t.entryType
+		}
+	}
+	entryType, _, _ := strings.Cut(key, "|")
+	return entryType
+}
+
+func cacheRequestResult(operation string, err error) string {
+	switch {
+	case operation == cacheOperationGet && err == nil:
+		return cacheResultHit
+	case operation == cacheOperationGet && errors.Is(err, ErrCacheMiss):
+		return cacheResultMiss
+	case err == nil:
+		return cacheResultSuccess
+	}
+	return cacheResultError
+}
+
+// recordRequest records a request of the cache if a metrics registry is set
+func (c *Cache) recordRequest(key string, operation string, err error, startTime time.Time) {
+	if c.metrics == nil {
+		return
+	}
+	entryType := CacheEntryType(key)
+	c.metrics.IncCacheRequest(entryType, operation, cacheRequestResult(operation, err))
+	c.metrics.ObserveCacheRequestDuration(entryType, operation, time.Since(startTime))
+}
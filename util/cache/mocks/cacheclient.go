@@ -72,3 +72,11 @@ func (c *MockCacheClient) NotifyUpdated(key string) error {
 	}
 	return c.BaseCache.NotifyUpdated(key)
 }
+
+func (c *MockCacheClient) Scan(ctx context.Context, prefix string, callback func(key string, size int64) error) error {
+	args := c.Called(ctx, prefix, callback)
+	if len(args) > 0 && args.Get(0) != nil {
+		return args.Get(0).(error)
+	}
+	return c.BaseCache.Scan(ctx, prefix, callback)
+}
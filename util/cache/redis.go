@@ -8,13 +8,17 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"math"
 	"net"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/argoproj/argo-cd/v3/util/env"
 	utilio "github.com/argoproj/argo-cd/v3/util/io"
 
 	rediscache "github.com/go-redis/cache/v9"
+	"github.com/klauspost/compress/zstd"
 	"github.com/redis/go-redis/v9"
 )
 
@@ -23,6 +27,24 @@ type RedisCompressionType string
 var (
 	RedisCompressionNone RedisCompressionType = "none"
 	RedisCompressionGZip RedisCompressionType = "gzip"
+	// RedisCompressionZstd compresses the entries larger than the zstd compression threshold with zstd. The entries are
+	// prefixed with the version of their encoding, so that compressed and uncompressed entries can be told apart.
+	RedisCompressionZstd RedisCompressionType = "zstd"
+)
+
+const (
+	// zstdEncodingVersionRaw is the encoding version of the zstd compression type entries which are stored uncompressed
+	zstdEncodingVersionRaw byte = 0
+	// zstdEncodingVersionZstd is the encoding version of the zstd compression type entries which are compressed
+	zstdEncodingVersionZstd byte = 1
+)
+
+var (
+	// zstdCompressionThreshold is the size in bytes from which the entries are compressed with the zstd compression type
+	zstdCompressionThreshold = env.ParseNumFromEnv("ARGOCD_REDIS_ZSTD_COMPRESSION_THRESHOLD", 4096, 0, math.MaxInt32)
+	// zstdEncoder and zstdDecoder are safe for concurrent use of EncodeAll and DecodeAll
+	zstdEncoder, _ = zstd.NewWriter(nil)
+	zstdDecoder, _ = zstd.NewReader(nil)
 )
 
 func CompressionTypeFromString(s string) (RedisCompressionType, error) {
@@ -31,11 +53,13 @@ func CompressionTypeFromString(s string) (RedisCompressionType, error) {
 		return RedisCompressionNone, nil
 	case string(RedisCompressionGZip):
 		return RedisCompressionGZip, nil
+	case string(RedisCompressionZstd):
+		return RedisCompressionZstd, nil
 	}
 	return "", fmt.Errorf("unknown compression type: %s", s)
 }
 
-func NewRedisCache(client *redis.Client, expiration time.Duration, compressionType RedisCompressionType) CacheClient {
+func NewRedisCache(client redis.UniversalClient, expiration time.Duration, compressionType RedisCompressionType) CacheClient {
 	return &redisCache{
 		client:               client,
 		expiration:           expiration,
@@ -49,7 +73,7 @@ var _ CacheClient = &redisCache{}
 
 type redisCache struct {
 	expiration           time.Duration
-	client               *redis.Client
+	client               redis.UniversalClient
 	cache                *rediscache.Cache
 	redisCompressionType RedisCompressionType
 }
@@ -58,6 +82,8 @@ func (r *redisCache) getKey(key string) string {
 	switch r.redisCompressionType {
 	case RedisCompressionGZip:
 		return key + ".gz"
+	case RedisCompressionZstd:
+		return key + ".zst"
 	default:
 		return key
 	}
@@ -84,10 +110,45 @@ func (r *redisCache) marshal(obj any) ([]byte, error) {
 			return nil, err
 		}
 	}
+	if r.redisCompressionType == RedisCompressionZstd {
+		return encodeZstd(buf.Bytes()), nil
+	}
 	return buf.Bytes(), nil
 }
 
+// encodeZstd prefixes the data with its encoding version, compressing it if it reaches the compression threshold
+func encodeZstd(data []byte) []byte {
+	if len(data) < zstdCompressionThreshold {
+		return append([]byte{zstdEncodingVersionRaw}, data...)
+	}
+	return zstdEncoder.EncodeAll(data, []byte{zstdEncodingVersionZstd})
+}
+
+// decodeZstd decodes the data encoded by encodeZstd
+func decodeZstd(data []byte) ([]byte, error) {
+	if len(data) == 0 {
+		return nil, errors.New("failed to decode cached data: missing encoding version")
+	}
+	switch data[0] {
+	case zstdEncodingVersionRaw:
+		return data[1:], nil
+	case zstdEncodingVersionZstd:
+		decoded, err := zstdDecoder.DecodeAll(data[1:], nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress cached data: %w", err)
+		}
+		return decoded, nil
+	}
+	return nil, fmt.Errorf("failed to decode cached data: unknown encoding version %d", data[0])
+}
+
 func (r *redisCache) unmarshal(data []byte, obj any) error {
+	if r.redisCompressionType == RedisCompressionZstd {
+		var err error
+		if data, err = decodeZstd(data); err != nil {
+			return err
+		}
+	}
 	buf := bytes.NewReader(data)
 	var reader io.Reader = buf
 	if r.redisCompressionType == RedisCompressionGZip {
@@ -103,7 +164,10 @@ func (r *redisCache) unmarshal(data []byte, obj any) error {
 	return nil
 }
 
-func (r *redisCache) Rename(oldKey string, newKey string, _ time.Duration) error {
+func (r *redisCache) Rename(oldKey string, newKey string, expiration time.Duration) error {
+	if _, ok := r.client.(*redis.ClusterClient); ok {
+		return r.renameAcrossSlots(oldKey, newKey, expiration)
+	}
 	err := r.client.Rename(context.TODO(), r.getKey(oldKey), r.getKey(newKey)).Err()
 	if err != nil && err.Error() == "ERR no such key" {
 		err = ErrCacheMiss
@@ -112,6 +176,26 @@ func (r *redisCache) Rename(oldKey string, newKey string, _ time.Duration) error
 	return err
 }
 
+// renameAcrossSlots renames a key in Redis Cluster, where RENAME fails unless both keys belong to the same hash slot,
+// by copying the value of the key to the new key and deleting it. Unlike RENAME, it is not atomic.
+func (r *redisCache) renameAcrossSlots(oldKey string, newKey string, expiration time.Duration) error {
+	if expiration == 0 {
+		expiration = r.expiration
+	}
+	ctx := context.TODO()
+	data, err := r.client.Get(ctx, r.getKey(oldKey)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return ErrCacheMiss
+	}
+	if err != nil {
+		return err
+	}
+	if err := r.client.Set(ctx, r.getKey(newKey), data, expiration).Err(); err != nil {
+		return err
+	}
+	return r.client.Del(ctx, r.getKey(oldKey)).Err()
+}
+
 func (r *redisCache) Set(item *Item) error {
 	expiration := item.CacheActionOpts.Expiration
 	if expiration == 0 {
@@ -168,9 +252,56 @@ func (r *redisCache) NotifyUpdated(key string) error {
 	return r.client.Publish(context.TODO(), key, "").Err()
 }
 
+// Scan scans the keys matching the prefix with SCAN, on every master node when Redis runs in cluster mode, and gets
+// the size of their values with STRLEN. A key may be passed more than once to the callback, as SCAN only guarantees
+// that the keys which exist during the whole scan are returned.
+func (r *redisCache) Scan(ctx context.Context, prefix string, callback func(key string, size int64) error) error {
+	suffix := r.getKey("")
+	match := escapeRedisPattern(prefix) + "*" + escapeRedisPattern(suffix)
+	scan := func(ctx context.Context, client redis.Cmdable, callback func(key string, size int64) error) error {
+		iter := client.Scan(ctx, 0, match, 1000).Iterator()
+		for iter.Next(ctx) {
+			size, err := client.StrLen(ctx, iter.Val()).Result()
+			if err != nil {
+				return err
+			}
+			if err := callback(strings.TrimSuffix(iter.Val(), suffix), size); err != nil {
+				return err
+			}
+		}
+		return iter.Err()
+	}
+	if cluster, ok := r.client.(*redis.ClusterClient); ok {
+		// the master nodes are scanned concurrently, so the calls of the callback are serialized
+		var lock sync.Mutex
+		return cluster.ForEachMaster(ctx, func(ctx context.Context, client *redis.Client) error {
+			return scan(ctx, client, func(key string, size int64) error {
+				lock.Lock()
+				defer lock.Unlock()
+				return callback(key, size)
+			})
+		})
+	}
+	return scan(ctx, r.client, callback)
+}
+
+// escapeRedisPattern escapes the special characters of the glob-style patterns of Redis
+func escapeRedisPattern(s string) string {
+	var b strings.Builder
+	for _, c := range s {
+		if strings.ContainsRune(`*?[]^\`, c) {
+			b.WriteRune('\\')
+		}
+		b.WriteRune(c)
+	}
+	return b.String()
+}
+
 type MetricsRegistry interface {
 	IncRedisRequest(failed bool)
 	ObserveRedisRequestDuration(duration time.Duration)
+	// RegisterRedisClient registers the collector of the health and connection pool metrics of the Redis client
+	RegisterRedisClient(client redis.UniversalClient)
 }
 
 type redisHook struct {
@@ -202,10 +333,15 @@ func (redisHook) ProcessPipelineHook(_ redis.ProcessPipelineHook) redis.ProcessP
 
 // CollectMetrics add transport wrapper that pushes metrics into the specified metrics registry
 // Lock should be shared between functions that can add/process a Redis hook.
-func CollectMetrics(client *redis.Client, registry MetricsRegistry, lock *sync.RWMutex) {
+// The client is nil when the embedded cache backend is used, in which case there is nothing to collect.
+func CollectMetrics(client redis.UniversalClient, registry MetricsRegistry, lock *sync.RWMutex) {
+	if client == nil {
+		return
+	}
 	if lock != nil {
 		lock.Lock()
 		defer lock.Unlock()
 	}
 	client.AddHook(&redisHook{registry: registry})
+	registry.RegisterRedisClient(client)
 }
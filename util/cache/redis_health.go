@@ -0,0 +1,66 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/redis/go-redis/v9"
+)
+
+// redisHealthCheckTimeout is the timeout of the ping checking the health of Redis when the metrics are collected
+const redisHealthCheckTimeout = time.Second
+
+var (
+	descRedisUp = prometheus.NewDesc(
+		"argocd_redis_up",
+		"Whether Redis answered the last health check, 1 if it did and 0 otherwise.",
+		[]string{"initiator"}, nil,
+	)
+	descRedisPoolConnections = prometheus.NewDesc(
+		"argocd_redis_pool_connections",
+		"Number of connections in the Redis connection pool, by connection state.",
+		[]string{"initiator", "state"}, nil,
+	)
+	descRedisPoolRequests = prometheus.NewDesc(
+		"argocd_redis_pool_requests_total",
+		"Number of requests for a connection of the Redis connection pool, by result.",
+		[]string{"initiator", "result"}, nil,
+	)
+)
+
+// redisClientCollector collects the health and connection pool metrics of a Redis client. With Redis Cluster, the
+// pool metrics are the totals of the connection pools of all the nodes.
+type redisClientCollector struct {
+	client    redis.UniversalClient
+	initiator string
+}
+
+// NewRedisClientCollector returns a collector of the health and connection pool metrics of a Redis client
+func NewRedisClientCollector(client redis.UniversalClient, initiator string) prometheus.Collector {
+	return &redisClientCollector{client: client, initiator: initiator}
+}
+
+func (c *redisClientCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- descRedisUp
+	ch <- descRedisPoolConnections
+	ch <- descRedisPoolRequests
+}
+
+func (c *redisClientCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), redisHealthCheckTimeout)
+	defer cancel()
+	up := 1.0
+	if err := c.client.Ping(ctx).Err(); err != nil {
+		up = 0
+	}
+	ch <- prometheus.MustNewConstMetric(descRedisUp, prometheus.GaugeValue, up, c.initiator)
+
+	stats := c.client.PoolStats()
+	ch <- prometheus.MustNewConstMetric(descRedisPoolConnections, prometheus.GaugeValue, float64(stats.TotalConns), c.initiator, "total")
+	ch <- prometheus.MustNewConstMetric(descRedisPoolConnections, prometheus.GaugeValue, float64(stats.IdleConns), c.initiator, "idle")
+	ch <- prometheus.MustNewConstMetric(descRedisPoolConnections, prometheus.GaugeValue, float64(stats.StaleConns), c.initiator, "stale")
+	ch <- prometheus.MustNewConstMetric(descRedisPoolRequests, prometheus.CounterValue, float64(stats.Hits), c.initiator, "hit")
+	ch <- prometheus.MustNewConstMetric(descRedisPoolRequests, prometheus.CounterValue, float64(stats.Misses), c.initiator, "miss")
+	ch <- prometheus.MustNewConstMetric(descRedisPoolRequests, prometheus.CounterValue, float64(stats.Timeouts), c.initiator, "timeout")
+}
@@ -5,11 +5,13 @@ import (
 	"compress/gzip"
 	"io"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/alicebob/miniredis/v2"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	promcm "github.com/prometheus/client_model/go"
 	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/assert"
@@ -35,6 +37,7 @@ var (
 type MockMetricsServer struct {
 	redisRequestCounter   *prometheus.CounterVec
 	redisRequestHistogram *prometheus.HistogramVec
+	redisClients          []redis.UniversalClient
 }
 
 func NewMockMetricsServer() *MockMetricsServer {
@@ -55,6 +58,10 @@ func (m *MockMetricsServer) ObserveRedisRequestDuration(duration time.Duration)
 	m.redisRequestHistogram.WithLabelValues("mock").Observe(duration.Seconds())
 }
 
+func (m *MockMetricsServer) RegisterRedisClient(client redis.UniversalClient) {
+	m.redisClients = append(m.redisClients, client)
+}
+
 func TestRedisSetCache(t *testing.T) {
 	mr, err := miniredis.Run()
 	if err != nil {
@@ -122,6 +129,52 @@ func TestRedisSetCacheCompressed(t *testing.T) {
 	assert.Equal(t, testValue, result)
 }
 
+func TestRedisSetCacheZstdCompressed(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		panic(err)
+	}
+	defer mr.Close()
+
+	redisClient := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	client := NewRedisCache(redisClient, 10*time.Second, RedisCompressionZstd)
+
+	t.Run("small entries are not compressed", func(t *testing.T) {
+		testValue := "my-value"
+		require.NoError(t, client.Set(&Item{Key: "small-key", Object: testValue}))
+
+		data, err := redisClient.Get(t.Context(), "small-key.zst").Bytes()
+		require.NoError(t, err)
+		assert.Equal(t, zstdEncodingVersionRaw, data[0])
+		assert.JSONEq(t, `"my-value"`, string(data[1:]))
+
+		var result string
+		require.NoError(t, client.Get("small-key", &result))
+		assert.Equal(t, testValue, result)
+	})
+
+	t.Run("large entries are compressed", func(t *testing.T) {
+		testValue := strings.Repeat("my-value", zstdCompressionThreshold)
+		require.NoError(t, client.Set(&Item{Key: "large-key", Object: testValue}))
+
+		data, err := redisClient.Get(t.Context(), "large-key.zst").Bytes()
+		require.NoError(t, err)
+		assert.Equal(t, zstdEncodingVersionZstd, data[0])
+		assert.Less(t, len(data), len(testValue)/10)
+
+		var result string
+		require.NoError(t, client.Get("large-key", &result))
+		assert.Equal(t, testValue, result)
+	})
+
+	t.Run("unknown encoding version", func(t *testing.T) {
+		require.NoError(t, redisClient.Set(t.Context(), "unknown-key.zst", []byte{42, '"', '"'}, 0).Err())
+
+		var result string
+		require.ErrorContains(t, client.Get("unknown-key", &result), "unknown encoding version 42")
+	})
+}
+
 func TestRedisMetrics(t *testing.T) {
 	mr, err := miniredis.Run()
 	if err != nil {
@@ -135,6 +188,7 @@ func TestRedisMetrics(t *testing.T) {
 	faultyRedisClient := redis.NewClient(&redis.Options{Addr: "invalidredishost.invalid:12345"})
 	CollectMetrics(redisClient, ms, nil)
 	CollectMetrics(faultyRedisClient, ms, nil)
+	assert.Len(t, ms.redisClients, 2)
 
 	client := NewRedisCache(redisClient, 60*time.Second, RedisCompressionNone)
 	faultyClient := NewRedisCache(faultyRedisClient, 60*time.Second, RedisCompressionNone)
@@ -168,3 +222,69 @@ func TestRedisMetrics(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, 3, int(metric.Histogram.GetSampleCount()))
 }
+
+func TestRedisClientCollector(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		panic(err)
+	}
+	defer mr.Close()
+
+	redisClient := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	require.NoError(t, testutil.CollectAndCompare(NewRedisClientCollector(redisClient, "mock"), strings.NewReader(`
+# HELP argocd_redis_up Whether Redis answered the last health check, 1 if it did and 0 otherwise.
+# TYPE argocd_redis_up gauge
+argocd_redis_up{initiator="mock"} 1
+`), "argocd_redis_up"))
+	assert.Equal(t, 7, testutil.CollectAndCount(NewRedisClientCollector(redisClient, "mock")))
+
+	mr.Close()
+	require.NoError(t, testutil.CollectAndCompare(NewRedisClientCollector(redisClient, "mock"), strings.NewReader(`
+# HELP argocd_redis_up Whether Redis answered the last health check, 1 if it did and 0 otherwise.
+# TYPE argocd_redis_up gauge
+argocd_redis_up{initiator="mock"} 0
+`), "argocd_redis_up"))
+}
+
+func TestRedisClusterCache(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		panic(err)
+	}
+	defer mr.Close()
+
+	client := NewRedisCache(redis.NewClusterClient(&redis.ClusterOptions{Addrs: []string{mr.Addr()}}), 10*time.Second, RedisCompressionNone)
+	require.NoError(t, client.Set(&Item{Key: "old-key", Object: "my-value"}))
+	require.NoError(t, client.Rename("old-key", "new-key", time.Minute))
+
+	var result string
+	require.NoError(t, client.Get("new-key", &result))
+	assert.Equal(t, "my-value", result)
+	require.ErrorIs(t, client.Get("old-key", &result), ErrCacheMiss)
+	require.ErrorIs(t, client.Rename("old-key", "other-key", time.Minute), ErrCacheMiss)
+}
+
+func TestRedisScan(t *testing.T) {
+	mr, err := miniredis.Run()
+	require.NoError(t, err)
+	defer mr.Close()
+
+	redisClient := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	client := NewRedisCache(redisClient, 10*time.Second, RedisCompressionGZip)
+	require.NoError(t, client.Set(&Item{Key: "app|my-app", Object: "my-value"}))
+	require.NoError(t, client.Set(&Item{Key: "app|other-app", Object: "other-value"}))
+	require.NoError(t, client.Set(&Item{Key: "cluster|my-cluster", Object: "my-value"}))
+	// keys stored with another compression type are not scanned
+	require.NoError(t, redisClient.Set(t.Context(), "app|uncompressed", "my-value", 0).Err())
+
+	sizes := map[string]int64{}
+	require.NoError(t, client.Scan(t.Context(), "app|", func(key string, size int64) error {
+		sizes[key] = size
+		return nil
+	}))
+	data, err := redisClient.Get(t.Context(), "app|my-app.gz").Bytes()
+	require.NoError(t, err)
+	assert.Len(t, sizes, 2)
+	assert.Equal(t, int64(len(data)), sizes["app|my-app"])
+	assert.Contains(t, sizes, "app|other-app")
+}
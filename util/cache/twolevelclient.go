@@ -74,3 +74,8 @@ func (c *twoLevelClient) OnUpdated(ctx context.Context, key string, callback fun
 func (c *twoLevelClient) NotifyUpdated(key string) error {
 	return c.externalCache.NotifyUpdated(key)
 }
+
+// Scan scans the external cache, which holds all the items of the in-memory cache.
+func (c *twoLevelClient) Scan(ctx context.Context, prefix string, callback func(key string, size int64) error) error {
+	return c.externalCache.Scan(ctx, prefix, callback)
+}
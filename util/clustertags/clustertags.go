@@ -0,0 +1,139 @@
+package clustertags
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/eks"
+	"golang.org/x/oauth2/google"
+
+	"github.com/argoproj/argo-cd/v3/util/workloadidentity"
+)
+
+const (
+	gcpCloudPlatformScope  = "https://www.googleapis.com/auth/cloud-platform"
+	azureManagementScope   = "https://management.azure.com/.default"
+	azureManagedClusterAPI = "2024-09-01"
+)
+
+var (
+	gkeClusterIDRegexp = regexp.MustCompile(`^projects/[^/]+/locations/[^/]+/clusters/[^/]+$`)
+	aksClusterIDRegexp = regexp.MustCompile(`(?i)^/subscriptions/[^/]+/resourceGroups/[^/]+/providers/Microsoft\.ContainerService/managedClusters/[^/]+$`)
+
+	defaultCloudClusterTagsReader = &cloudClusterTagsReader{
+		gcpContainerEndpoint:    "https://container.googleapis.com/v1/",
+		azureManagementEndpoint: "https://management.azure.com",
+		gcpClient: func(ctx context.Context) (*http.Client, error) {
+			return google.DefaultClient(ctx, gcpCloudPlatformScope)
+		},
+		awsTags: awsClusterTags,
+	}
+)
+
+// CloudClusterTags returns the tags of the cloud managed cluster with the given ID, read with the workload identity of
+// the component. The ID is one of:
+//   - the ARN of an EKS cluster: arn:aws:eks:<region>:<account>:cluster/<name>
+//   - the resource name of a GKE cluster: projects/<project>/locations/<location>/clusters/<name>
+//   - the resource ID of an AKS cluster: /subscriptions/<subscription>/resourceGroups/<group>/providers/Microsoft.ContainerService/managedClusters/<name>
+//
+// The resource labels of the GKE clusters are returned as their tags.
+func CloudClusterTags(ctx context.Context, cloudClusterID string) (map[string]string, error) {
+	return defaultCloudClusterTagsReader.tags(ctx, cloudClusterID)
+}
+
+type cloudClusterTagsReader struct {
+	gcpContainerEndpoint    string
+	azureManagementEndpoint string
+	gcpClient               func(ctx context.Context) (*http.Client, error)
+	azureTokenProvider      workloadidentity.TokenProvider
+	awsTags                 func(ctx context.Context, region, clusterName string) (map[string]string, error)
+}
+
+func (r *cloudClusterTagsReader) tags(ctx context.Context, cloudClusterID string) (map[string]string, error) {
+	switch {
+	case arn.IsARN(cloudClusterID):
+		clusterARN, err := arn.Parse(cloudClusterID)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing cluster ARN %s: %w", cloudClusterID, err)
+		}
+		clusterName, ok := strings.CutPrefix(clusterARN.Resource, "cluster/")
+		if clusterARN.Service != "eks" || !ok {
+			return nil, fmt.Errorf("%s is not the ARN of an EKS cluster", cloudClusterID)
+		}
+		return r.awsTags(ctx, clusterARN.Region, clusterName)
+	case gkeClusterIDRegexp.MatchString(cloudClusterID):
+		client, err := r.gcpClient(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("error finding GCP default credentials: %w", err)
+		}
+		var cluster struct {
+			ResourceLabels map[string]string `json:"resourceLabels"`
+		}
+		if err := getJSON(ctx, client, r.gcpContainerEndpoint+cloudClusterID, nil, &cluster); err != nil {
+			return nil, fmt.Errorf("error getting GKE cluster %s: %w", cloudClusterID, err)
+		}
+		return cluster.ResourceLabels, nil
+	case aksClusterIDRegexp.MatchString(cloudClusterID):
+		tp := r.azureTokenProvider
+		if tp == nil {
+			tp = workloadidentity.NewWorkloadIdentityTokenProvider()
+		}
+		token, err := tp.GetToken(azureManagementScope)
+		if err != nil {
+			return nil, fmt.Errorf("error getting Azure workload identity token: %w", err)
+		}
+		var cluster struct {
+			Tags map[string]string `json:"tags"`
+		}
+		url := r.azureManagementEndpoint + cloudClusterID + "?api-version=" + azureManagedClusterAPI
+		header := http.Header{"Authorization": []string{"Bearer " + token.AccessToken}}
+		if err := getJSON(ctx, http.DefaultClient, url, header, &cluster); err != nil {
+			return nil, fmt.Errorf("error getting AKS cluster %s: %w", cloudClusterID, err)
+		}
+		return cluster.Tags, nil
+	}
+	return nil, fmt.Errorf("%q is neither the ARN of an EKS cluster, nor the resource name of a GKE cluster, nor the resource ID of an AKS cluster", cloudClusterID)
+}
+
+func awsClusterTags(ctx context.Context, region, clusterName string) (map[string]string, error) {
+	sess, err := session.NewSession(&aws.Config{Region: aws.String(region)})
+	if err != nil {
+		return nil, fmt.Errorf("error creating new AWS session: %w", err)
+	}
+	out, err := eks.New(sess).DescribeClusterWithContext(ctx, &eks.DescribeClusterInput{Name: aws.String(clusterName)})
+	if err != nil {
+		return nil, fmt.Errorf("error describing EKS cluster %s: %w", clusterName, err)
+	}
+	return aws.StringValueMap(out.Cluster.Tags), nil
+}
+
+func getJSON(ctx context.Context, client *http.Client, url string, header http.Header, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
+	if err != nil {
+		return err
+	}
+	for k, values := range header {
+		req.Header[k] = values
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s: %s", resp.Status, body)
+	}
+	return json.Unmarshal(body, v)
+}
@@ -0,0 +1,87 @@
+package clustertags
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/argoproj/argo-cd/v3/util/workloadidentity"
+)
+
+type mockTokenProvider struct {
+	scope string
+	token *workloadidentity.Token
+}
+
+func (p *mockTokenProvider) GetToken(scope string) (*workloadidentity.Token, error) {
+	p.scope = scope
+	return p.token, nil
+}
+
+func TestCloudClusterTags(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/gke/projects/my-project/locations/europe-west1/clusters/my-cluster":
+			_, _ = w.Write([]byte(`{"name":"my-cluster","resourceLabels":{"team":"payments"}}`))
+		case "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.ContainerService/managedClusters/my-cluster":
+			if r.Header.Get("Authorization") != "Bearer azure-token" || r.URL.Query().Get("api-version") == "" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			_, _ = w.Write([]byte(`{"name":"my-cluster","tags":{"env":"prod"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	var awsRegion, awsClusterName string
+	reader := &cloudClusterTagsReader{
+		gcpContainerEndpoint:    server.URL + "/gke/",
+		azureManagementEndpoint: server.URL,
+		gcpClient: func(_ context.Context) (*http.Client, error) {
+			return http.DefaultClient, nil
+		},
+		azureTokenProvider: &mockTokenProvider{token: &workloadidentity.Token{AccessToken: "azure-token", ExpiresOn: time.Now().Add(time.Hour)}},
+		awsTags: func(_ context.Context, region, clusterName string) (map[string]string, error) {
+			awsRegion, awsClusterName = region, clusterName
+			return map[string]string{"owner": "platform"}, nil
+		},
+	}
+
+	t.Run("EKS", func(t *testing.T) {
+		tags, err := reader.tags(t.Context(), "arn:aws:eks:us-west-2:123456789012:cluster/my-cluster")
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"owner": "platform"}, tags)
+		assert.Equal(t, "us-west-2", awsRegion)
+		assert.Equal(t, "my-cluster", awsClusterName)
+
+		_, err = reader.tags(t.Context(), "arn:aws:iam::123456789012:role/my-role")
+		require.ErrorContains(t, err, "is not the ARN of an EKS cluster")
+	})
+
+	t.Run("GKE", func(t *testing.T) {
+		tags, err := reader.tags(t.Context(), "projects/my-project/locations/europe-west1/clusters/my-cluster")
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"team": "payments"}, tags)
+
+		_, err = reader.tags(t.Context(), "projects/my-project/locations/europe-west1/clusters/unknown")
+		require.ErrorContains(t, err, "404")
+	})
+
+	t.Run("AKS", func(t *testing.T) {
+		tags, err := reader.tags(t.Context(), "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.ContainerService/managedClusters/my-cluster")
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"env": "prod"}, tags)
+	})
+
+	t.Run("unknown ID", func(t *testing.T) {
+		_, err := reader.tags(t.Context(), "my-cluster")
+		require.ErrorContains(t, err, "is neither the ARN of an EKS cluster")
+	})
+}
@@ -0,0 +1,173 @@
+// Package clustertoken implements the built-in providers of short-lived tokens authenticating to clusters with the
+// cloud workload identity of the Argo CD components. They are registered by the Argo CD binary, so that the API types
+// don't depend on the cloud SDKs.
+package clustertoken
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/sts"
+	gocache "github.com/patrickmn/go-cache"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+	"k8s.io/client-go/transport"
+
+	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	"github.com/argoproj/argo-cd/v3/util/workloadidentity"
+)
+
+const (
+	// ProviderAWS mints the tokens of EKS clusters with the AWS IAM identity of the component, such as an
+	// IAM role for its service account (IRSA)
+	ProviderAWS = "aws"
+	// ProviderGCP mints the tokens of GKE clusters with the GCP workload identity of the component
+	ProviderGCP = "gcp"
+	// ProviderAzure mints the tokens of AKS clusters with the Azure workload identity of the component
+	ProviderAzure = "azure"
+
+	// DefaultAKSServerApplicationID is the application ID of the Azure Kubernetes Service AAD server
+	DefaultAKSServerApplicationID = "6dae42f8-4368-4678-94ff-3960e28e3630"
+
+	// tokenExpiryLeeway is how long before they expire the cluster tokens are refreshed
+	tokenExpiryLeeway = time.Minute
+	// tokenSourceExpiration is how long the token sources are kept after they were last requested, so that the sources
+	// of removed clusters are dropped
+	tokenSourceExpiration = time.Hour
+
+	awsClusterIDHeader = "x-k8s-aws-id"
+	// awsPresignParam is ignored by STS, the presigned requests are valid for 15 minutes after they are signed
+	awsPresignParam         = 60
+	awsPresignedURLExpiry   = 15 * time.Minute
+	awsClusterTokenPrefix   = "k8s-aws-v1."
+	gcpCloudPlatformScope   = "https://www.googleapis.com/auth/cloud-platform"
+	gcpUserInfoEmailScope   = "https://www.googleapis.com/auth/userinfo.email"
+	azureDefaultScopeSuffix = "/.default"
+)
+
+var tokenSources = gocache.New(tokenSourceExpiration, tokenSourceExpiration)
+
+// tokenSourceKey returns the key of the token source of a configuration
+func tokenSourceKey(config v1alpha1.TokenProviderConfig) string {
+	return strings.Join([]string{config.Type, config.ClusterName, config.RoleARN, config.ServerApplicationID}, "|")
+}
+
+// TokenSource returns the source of the tokens of the given configuration. The tokens are cached until shortly before
+// they expire, and the sources are shared by all the clients with the same configuration so that the tokens are only
+// minted again when they are about to expire or are rejected by the cluster. The sources which aren't requested for an
+// hour are dropped.
+func TokenSource(config v1alpha1.TokenProviderConfig) (transport.ResettableTokenSource, error) {
+	key := tokenSourceKey(config)
+	if ts, ok := tokenSources.Get(key); ok {
+		// requesting a source postpones its expiration
+		tokenSources.SetDefault(key, ts)
+		return ts.(transport.ResettableTokenSource), nil
+	}
+
+	var base oauth2.TokenSource
+	switch config.Type {
+	case ProviderAWS:
+		if config.ClusterName == "" {
+			return nil, errors.New("the cluster name is required by the aws token provider")
+		}
+		base = &awsClusterTokenSource{clusterName: config.ClusterName, roleARN: config.RoleARN}
+	case ProviderGCP:
+		base = &gcpClusterTokenSource{}
+	case ProviderAzure:
+		serverID := config.ServerApplicationID
+		if serverID == "" {
+			serverID = DefaultAKSServerApplicationID
+		}
+		base = &azureClusterTokenSource{scope: serverID + azureDefaultScopeSuffix}
+	default:
+		return nil, fmt.Errorf("unknown cluster token provider %q, must be one of %s, %s or %s", config.Type, ProviderAWS, ProviderGCP, ProviderAzure)
+	}
+	ts := transport.NewCachedTokenSource(base)
+	// concurrent requests of the same new source may each create one, only the first one is kept
+	if err := tokenSources.Add(key, ts, gocache.DefaultExpiration); err != nil {
+		if existing, ok := tokenSources.Get(key); ok {
+			return existing.(transport.ResettableTokenSource), nil
+		}
+	}
+	return ts, nil
+}
+
+// awsClusterTokenSource mints the tokens of an EKS cluster, which are presigned STS GetCallerIdentity requests
+type awsClusterTokenSource struct {
+	clusterName string
+	roleARN     string
+}
+
+func (s *awsClusterTokenSource) Token() (*oauth2.Token, error) {
+	sess, err := session.NewSession()
+	if err != nil {
+		return nil, fmt.Errorf("error creating new AWS session: %w", err)
+	}
+	stsAPI := sts.New(sess)
+	if s.roleARN != "" {
+		stsAPI = sts.New(sess, &aws.Config{Credentials: stscreds.NewCredentials(sess, s.roleARN)})
+	}
+	request, _ := stsAPI.GetCallerIdentityRequest(&sts.GetCallerIdentityInput{})
+	request.HTTPRequest.Header.Add(awsClusterIDHeader, s.clusterName)
+	signedAt := time.Now()
+	signed, err := request.Presign(awsPresignParam)
+	if err != nil {
+		return nil, fmt.Errorf("error presigning AWS request: %w", err)
+	}
+	return &oauth2.Token{
+		AccessToken: awsClusterTokenPrefix + base64.RawURLEncoding.EncodeToString([]byte(signed)),
+		Expiry:      signedAt.Add(awsPresignedURLExpiry - tokenExpiryLeeway),
+	}, nil
+}
+
+// gcpClusterTokenSource mints the tokens of a GKE cluster from the application default credentials
+type gcpClusterTokenSource struct {
+	lock sync.Mutex
+	ts   oauth2.TokenSource
+}
+
+func (s *gcpClusterTokenSource) Token() (*oauth2.Token, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.ts == nil {
+		// userinfo.email is required to authenticate to GKE with the email of the service account instead of its ID
+		ts, err := google.DefaultTokenSource(context.Background(), gcpCloudPlatformScope, gcpUserInfoEmailScope)
+		if err != nil {
+			return nil, fmt.Errorf("error finding GCP default credentials: %w", err)
+		}
+		s.ts = ts
+	}
+	token, err := s.ts.Token()
+	if err != nil {
+		return nil, err
+	}
+	return &oauth2.Token{AccessToken: token.AccessToken, Expiry: token.Expiry.Add(-tokenExpiryLeeway)}, nil
+}
+
+// azureClusterTokenSource mints the tokens of an AKS cluster with the Azure workload identity
+type azureClusterTokenSource struct {
+	scope string
+	once  sync.Once
+	tp    workloadidentity.TokenProvider
+}
+
+func (s *azureClusterTokenSource) Token() (*oauth2.Token, error) {
+	s.once.Do(func() {
+		if s.tp == nil {
+			s.tp = workloadidentity.NewWorkloadIdentityTokenProvider()
+		}
+	})
+	token, err := s.tp.GetToken(s.scope)
+	if err != nil {
+		return nil, fmt.Errorf("error getting Azure workload identity token: %w", err)
+	}
+	return &oauth2.Token{AccessToken: token.AccessToken, Expiry: token.ExpiresOn.Add(-tokenExpiryLeeway)}, nil
+}
@@ -0,0 +1,67 @@
+package clustertoken
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	"github.com/argoproj/argo-cd/v3/util/workloadidentity"
+)
+
+type mockTokenProvider struct {
+	scope string
+	token *workloadidentity.Token
+}
+
+func (p *mockTokenProvider) GetToken(scope string) (*workloadidentity.Token, error) {
+	p.scope = scope
+	return p.token, nil
+}
+
+func TestTokenSource(t *testing.T) {
+	_, err := TokenSource(v1alpha1.TokenProviderConfig{Type: "unknown"})
+	require.ErrorContains(t, err, `unknown cluster token provider "unknown"`)
+
+	_, err = TokenSource(v1alpha1.TokenProviderConfig{Type: ProviderAWS})
+	require.ErrorContains(t, err, "the cluster name is required")
+
+	config := v1alpha1.TokenProviderConfig{Type: ProviderAWS, ClusterName: "my-cluster"}
+	ts, err := TokenSource(config)
+	require.NoError(t, err)
+	sameTS, err := TokenSource(config)
+	require.NoError(t, err)
+	assert.Same(t, ts, sameTS)
+
+	otherTS, err := TokenSource(v1alpha1.TokenProviderConfig{Type: ProviderAWS, ClusterName: "other-cluster"})
+	require.NoError(t, err)
+	assert.NotSame(t, ts, otherTS)
+}
+
+func TestAzureClusterTokenSource(t *testing.T) {
+	expiresOn := time.Now().Add(time.Hour)
+	tp := &mockTokenProvider{token: &workloadidentity.Token{AccessToken: "token", ExpiresOn: expiresOn}}
+	ts := &azureClusterTokenSource{scope: DefaultAKSServerApplicationID + azureDefaultScopeSuffix, tp: tp}
+
+	token, err := ts.Token()
+	require.NoError(t, err)
+	assert.Equal(t, "token", token.AccessToken)
+	assert.Equal(t, expiresOn.Add(-tokenExpiryLeeway), token.Expiry)
+	assert.Equal(t, "6dae42f8-4368-4678-94ff-3960e28e3630/.default", tp.scope)
+}
+
+func TestTokenSource_Expiration(t *testing.T) {
+	config := v1alpha1.TokenProviderConfig{Type: ProviderGCP}
+	ts, err := TokenSource(config)
+	require.NoError(t, err)
+
+	// the source isn't requested anymore until it expires
+	tokenSources.Set(tokenSourceKey(config), ts, time.Nanosecond)
+	time.Sleep(time.Millisecond)
+
+	newTS, err := TokenSource(config)
+	require.NoError(t, err)
+	assert.NotSame(t, ts, newTS)
+}
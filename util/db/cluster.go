@@ -362,6 +362,9 @@ func clusterToSecret(c *appv1.Cluster, secret *corev1.Secret) error {
 	if len(c.Namespaces) != 0 {
 		data["namespaces"] = []byte(strings.Join(c.Namespaces, ","))
 	}
+	if len(c.ResourceGroups) != 0 {
+		data["resourceGroups"] = []byte(strings.Join(c.ResourceGroups, ","))
+	}
 	configBytes, err := json.Marshal(c.Config)
 	if err != nil {
 		return err
@@ -376,6 +379,21 @@ func clusterToSecret(c *appv1.Cluster, secret *corev1.Secret) error {
 	if c.Project != "" {
 		data["project"] = []byte(c.Project)
 	}
+	if c.CacheResyncPeriod != "" {
+		data["cacheResyncPeriod"] = []byte(c.CacheResyncPeriod)
+	}
+	if c.CacheSyncRetryTimeout != "" {
+		data["cacheSyncRetryTimeout"] = []byte(c.CacheSyncRetryTimeout)
+	}
+	if c.CacheListPageSize != 0 {
+		data["cacheListPageSize"] = []byte(strconv.FormatInt(c.CacheListPageSize, 10))
+	}
+	if c.MinReadyNodes != 0 {
+		data["minReadyNodes"] = []byte(strconv.FormatInt(c.MinReadyNodes, 10))
+	}
+	if c.Draining {
+		data["draining"] = []byte("true")
+	}
 	secret.Data = data
 
 	secret.Labels = c.Labels
@@ -394,11 +412,16 @@ func clusterToSecret(c *appv1.Cluster, secret *corev1.Secret) error {
 		delete(secret.Annotations, appv1.AnnotationKeyRefresh)
 	}
 	addSecretMetadata(secret, common.LabelValueSecretTypeCluster)
-	return nil
+	return encryptSecretData(secret, clusterSecretEncryptedKeys)
 }
 
 // SecretToCluster converts a secret into a Cluster object
 func SecretToCluster(s *corev1.Secret) (*appv1.Cluster, error) {
+	s, err := decryptSecretData(s)
+	if err != nil {
+		return nil, err
+	}
+
 	var config appv1.ClusterConfig
 	if len(s.Data["config"]) > 0 {
 		err := json.Unmarshal(s.Data["config"], &config)
@@ -413,6 +436,12 @@ func SecretToCluster(s *corev1.Secret) (*appv1.Cluster, error) {
 			namespaces = append(namespaces, ns)
 		}
 	}
+	var resourceGroups []string
+	for _, group := range strings.Split(string(s.Data["resourceGroups"]), ",") {
+		if group = strings.TrimSpace(group); group != "" {
+			resourceGroups = append(resourceGroups, group)
+		}
+	}
 	var refreshRequestedAt *metav1.Time
 	if v, found := s.Annotations[appv1.AnnotationKeyRefresh]; found {
 		requestedAt, err := time.Parse(time.RFC3339, v)
@@ -430,6 +459,22 @@ func SecretToCluster(s *corev1.Secret) (*appv1.Cluster, error) {
 			shard = ptr.To(int64(val))
 		}
 	}
+	var cacheListPageSize int64
+	if pageSizeStr := s.Data["cacheListPageSize"]; pageSizeStr != nil {
+		if val, err := strconv.ParseInt(string(pageSizeStr), 10, 64); err != nil {
+			log.Warnf("Error while parsing cache list page size in cluster secret '%s': %v", s.Name, err)
+		} else {
+			cacheListPageSize = val
+		}
+	}
+	var minReadyNodes int64
+	if minReadyNodesStr := s.Data["minReadyNodes"]; minReadyNodesStr != nil {
+		if val, err := strconv.ParseInt(string(minReadyNodesStr), 10, 64); err != nil {
+			log.Warnf("Error while parsing minimum ready nodes in cluster secret '%s': %v", s.Name, err)
+		} else {
+			minReadyNodes = val
+		}
+	}
 
 	// copy labels and annotations excluding system ones
 	labels := map[string]string{}
@@ -446,17 +491,23 @@ func SecretToCluster(s *corev1.Secret) (*appv1.Cluster, error) {
 	}
 
 	cluster := appv1.Cluster{
-		ID:                 string(s.UID),
-		Server:             strings.TrimRight(string(s.Data["server"]), "/"),
-		Name:               string(s.Data["name"]),
-		Namespaces:         namespaces,
-		ClusterResources:   string(s.Data["clusterResources"]) == "true",
-		Config:             config,
-		RefreshRequestedAt: refreshRequestedAt,
-		Shard:              shard,
-		Project:            string(s.Data["project"]),
-		Labels:             labels,
-		Annotations:        annotations,
+		ID:                    string(s.UID),
+		Server:                strings.TrimRight(string(s.Data["server"]), "/"),
+		Name:                  string(s.Data["name"]),
+		Namespaces:            namespaces,
+		ResourceGroups:        resourceGroups,
+		ClusterResources:      string(s.Data["clusterResources"]) == "true",
+		Config:                config,
+		RefreshRequestedAt:    refreshRequestedAt,
+		Shard:                 shard,
+		Project:               string(s.Data["project"]),
+		Labels:                labels,
+		Annotations:           annotations,
+		CacheResyncPeriod:     string(s.Data["cacheResyncPeriod"]),
+		CacheSyncRetryTimeout: string(s.Data["cacheSyncRetryTimeout"]),
+		CacheListPageSize:     cacheListPageSize,
+		MinReadyNodes:         minReadyNodes,
+		Draining:              string(s.Data["draining"]) == "true",
 	}
 	return &cluster, nil
 }
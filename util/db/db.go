@@ -63,6 +63,9 @@ type ArgoDB interface {
 	// DeleteRepository deletes a repository from config
 	DeleteRepository(ctx context.Context, name, project string) error
 
+	// ListRepositorySecrets lists the secrets of the repositories and of the repository credential sets
+	ListRepositorySecrets(ctx context.Context) ([]*RepositorySecret, error)
+
 	// CreateWriteRepository creates a repository with write credentials
 	CreateWriteRepository(ctx context.Context, r *appv1.Repository) (*appv1.Repository, error)
 	// GetWriteRepository returns a repository by URL with write credentials
@@ -197,6 +197,46 @@ func TestGetRepositoryCredentials(t *testing.T) {
 	}
 }
 
+func TestListRepositorySecrets(t *testing.T) {
+	clientset := getClientset(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "invalid-repo",
+			Namespace: testNamespace,
+			Labels:    map[string]string{common.LabelKeySecretType: common.LabelValueSecretTypeRepository},
+		},
+		Data: map[string][]byte{"url": []byte("https://invalid/repo"), "insecure": []byte("maybe")},
+	})
+	db := NewDB(testNamespace, settings.NewSettingsManager(t.Context(), clientset, testNamespace), clientset)
+	_, err := db.CreateRepository(t.Context(), &v1alpha1.Repository{Repo: "https://secured/repo"})
+	require.NoError(t, err)
+	_, err = db.CreateRepositoryCredentials(t.Context(), &v1alpha1.RepoCreds{
+		URL:      "https://secured",
+		Username: "test-username",
+		Password: "test-password",
+	})
+	require.NoError(t, err)
+
+	secrets, err := db.ListRepositorySecrets(t.Context())
+	require.NoError(t, err)
+	require.Len(t, secrets, 3)
+	byURL := map[string]*RepositorySecret{}
+	for _, secret := range secrets {
+		if secret.Repository != nil {
+			byURL[secret.Repository.Repo] = secret
+		} else {
+			byURL[secret.RepoCreds.URL] = secret
+		}
+	}
+
+	// the repositories don't inherit the credentials of the repository credentials
+	require.NoError(t, byURL["https://secured/repo"].Err)
+	assert.Empty(t, byURL["https://secured/repo"].Repository.Username)
+	require.NoError(t, byURL["https://secured"].Err)
+	assert.Equal(t, "test-username", byURL["https://secured"].RepoCreds.Username)
+	assert.Equal(t, common.LabelValueSecretTypeRepoCreds, byURL["https://secured"].Secret.Labels[common.LabelKeySecretType])
+	assert.Error(t, byURL["https://invalid/repo"].Err)
+}
+
 func TestCreateExistingRepository(t *testing.T) {
 	clientset := getClientset()
 	db := NewDB(testNamespace, settings.NewSettingsManager(t.Context(), clientset, testNamespace), clientset)
@@ -2103,6 +2103,68 @@ func (_c *ArgoDB_ListRepositoryCredentials_Call) RunAndReturn(run func(ctx conte
 	return _c
 }
 
+// ListRepositorySecrets provides a mock function for the type ArgoDB
+func (_mock *ArgoDB) ListRepositorySecrets(ctx context.Context) ([]*db.RepositorySecret, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListRepositorySecrets")
+	}
+
+	var r0 []*db.RepositorySecret
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]*db.RepositorySecret, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []*db.RepositorySecret); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*db.RepositorySecret)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// ArgoDB_ListRepositorySecrets_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListRepositorySecrets'
+type ArgoDB_ListRepositorySecrets_Call struct {
+	*mock.Call
+}
+
+// ListRepositorySecrets is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *ArgoDB_Expecter) ListRepositorySecrets(ctx interface{}) *ArgoDB_ListRepositorySecrets_Call {
+	return &ArgoDB_ListRepositorySecrets_Call{Call: _e.mock.On("ListRepositorySecrets", ctx)}
+}
+
+func (_c *ArgoDB_ListRepositorySecrets_Call) Run(run func(ctx context.Context)) *ArgoDB_ListRepositorySecrets_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *ArgoDB_ListRepositorySecrets_Call) Return(repositorySecrets []*db.RepositorySecret, err error) *ArgoDB_ListRepositorySecrets_Call {
+	_c.Call.Return(repositorySecrets, err)
+	return _c
+}
+
+func (_c *ArgoDB_ListRepositorySecrets_Call) RunAndReturn(run func(ctx context.Context) ([]*db.RepositorySecret, error)) *ArgoDB_ListRepositorySecrets_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ListWriteRepositories provides a mock function for the type ArgoDB
 func (_mock *ArgoDB) ListWriteRepositories(ctx context.Context) ([]*v1alpha1.Repository, error) {
 	ret := _mock.Called(ctx)
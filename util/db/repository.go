@@ -12,6 +12,7 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	"github.com/argoproj/argo-cd/v3/common"
 	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
 	"github.com/argoproj/argo-cd/v3/util/settings"
 )
@@ -52,6 +53,9 @@ type repositoryBackend interface {
 }
 
 func (db *db) CreateRepository(ctx context.Context, r *v1alpha1.Repository) (*v1alpha1.Repository, error) {
+	if err := ValidateOIDCTokenExchange(db.settingsMgr, r.OIDCTokenExchangeURL, r.Project); err != nil {
+		return nil, err
+	}
 	secretBackend := db.repoBackend()
 
 	secretExists, err := secretBackend.RepositoryExists(ctx, r.Repo, r.Project, false)
@@ -67,6 +71,9 @@ func (db *db) CreateRepository(ctx context.Context, r *v1alpha1.Repository) (*v1
 }
 
 func (db *db) CreateWriteRepository(ctx context.Context, r *v1alpha1.Repository) (*v1alpha1.Repository, error) {
+	if err := ValidateOIDCTokenExchange(db.settingsMgr, r.OIDCTokenExchangeURL, r.Project); err != nil {
+		return nil, err
+	}
 	secretBackend := db.repoWriteBackend()
 	secretExists, err := secretBackend.RepositoryExists(ctx, r.Repo, r.Project, false)
 	if err != nil {
@@ -89,6 +96,7 @@ func (db *db) GetRepository(ctx context.Context, repoURL, project string) (*v1al
 	if err := db.enrichCredsToRepo(ctx, repository); err != nil {
 		return repository, fmt.Errorf("unable to enrich repository %q info with credentials: %w", repoURL, err)
 	}
+	db.dropDisallowedOIDCTokenExchange(repository)
 
 	return repository, err
 }
@@ -103,6 +111,7 @@ func (db *db) GetWriteRepository(ctx context.Context, repoURL, project string) (
 	// if err := db.enrichCredsToRepo(ctx, repository); err != nil {
 	//	 return repository, fmt.Errorf("unable to enrich write repository %q info with credentials: %w", repoURL, err)
 	// }
+	db.dropDisallowedOIDCTokenExchange(repository)
 
 	return repository, err
 }
@@ -130,6 +139,7 @@ func (db *db) getRepositories(indexer, project string) ([]*v1alpha1.Repository,
 		if err != nil {
 			return nil, err
 		}
+		db.dropDisallowedOIDCTokenExchange(repo)
 		res = append(res, repo)
 	}
 	return res, nil
@@ -169,6 +179,42 @@ func (db *db) ListWriteRepositories(ctx context.Context) ([]*v1alpha1.Repository
 	return db.listRepositories(ctx, nil, true)
 }
 
+// RepositorySecret is the secret of a repository or of a repository credential set
+type RepositorySecret struct {
+	Secret *corev1.Secret
+	// Repository is the repository of a repository secret, without the credentials it inherits
+	Repository *v1alpha1.Repository
+	// RepoCreds is the credential set of a repository credentials secret
+	RepoCreds *v1alpha1.RepoCreds
+	// Err is the error decoding the secret, if any
+	Err error
+}
+
+func (db *db) ListRepositorySecrets(_ context.Context) ([]*RepositorySecret, error) {
+	var result []*RepositorySecret
+	secrets, err := db.listSecretsByType(common.LabelValueSecretTypeRepository)
+	if err != nil {
+		return nil, err
+	}
+	for _, secret := range secrets {
+		repository, err := secretToRepository(secret)
+		result = append(result, &RepositorySecret{Secret: secret, Repository: repository, Err: err})
+	}
+	secrets, err = db.listSecretsByType(common.LabelValueSecretTypeRepoCreds)
+	if err != nil {
+		return nil, err
+	}
+	backend := &secretsRepositoryBackend{db: db}
+	for _, secret := range secrets {
+		repoCreds, err := backend.secretToRepoCred(secret)
+		if repoCreds == nil {
+			repoCreds = &v1alpha1.RepoCreds{URL: string(secret.Data["url"])}
+		}
+		result = append(result, &RepositorySecret{Secret: secret, RepoCreds: repoCreds, Err: err})
+	}
+	return result, nil
+}
+
 func (db *db) listRepositories(ctx context.Context, repoType *string, writeCreds bool) ([]*v1alpha1.Repository, error) {
 	var backend repositoryBackend
 	if writeCreds {
@@ -184,6 +230,9 @@ func (db *db) listRepositories(ctx context.Context, repoType *string, writeCreds
 	if err != nil {
 		return nil, err
 	}
+	for _, repository := range repositories {
+		db.dropDisallowedOIDCTokenExchange(repository)
+	}
 
 	return repositories, nil
 }
@@ -202,6 +251,9 @@ func (db *db) ListOCIRepositories(ctx context.Context) ([]*v1alpha1.Repository,
 
 // UpdateRepository updates a repository
 func (db *db) UpdateRepository(ctx context.Context, r *v1alpha1.Repository) (*v1alpha1.Repository, error) {
+	if err := ValidateOIDCTokenExchange(db.settingsMgr, r.OIDCTokenExchangeURL, r.Project); err != nil {
+		return nil, err
+	}
 	secretsBackend := db.repoBackend()
 	exists, err := secretsBackend.RepositoryExists(ctx, r.Repo, r.Project, false)
 	if err != nil {
@@ -214,6 +266,9 @@ func (db *db) UpdateRepository(ctx context.Context, r *v1alpha1.Repository) (*v1
 }
 
 func (db *db) UpdateWriteRepository(ctx context.Context, r *v1alpha1.Repository) (*v1alpha1.Repository, error) {
+	if err := ValidateOIDCTokenExchange(db.settingsMgr, r.OIDCTokenExchangeURL, r.Project); err != nil {
+		return nil, err
+	}
 	secretBackend := db.repoWriteBackend()
 	exists, err := secretBackend.RepositoryExists(ctx, r.Repo, r.Project, false)
 	if err != nil {
@@ -336,6 +391,9 @@ func (db *db) GetAllOCIRepositoryCredentials(ctx context.Context) ([]*v1alpha1.R
 
 // CreateRepositoryCredentials creates a repository credential set
 func (db *db) CreateRepositoryCredentials(ctx context.Context, r *v1alpha1.RepoCreds) (*v1alpha1.RepoCreds, error) {
+	if err := ValidateOIDCTokenExchange(db.settingsMgr, r.OIDCTokenExchangeURL, ""); err != nil {
+		return nil, err
+	}
 	secretBackend := db.repoBackend()
 
 	secretExists, err := secretBackend.RepositoryExists(ctx, r.URL, "", false)
@@ -352,6 +410,9 @@ func (db *db) CreateRepositoryCredentials(ctx context.Context, r *v1alpha1.RepoC
 
 // CreateWriteRepositoryCredentials creates a repository write credential set
 func (db *db) CreateWriteRepositoryCredentials(ctx context.Context, r *v1alpha1.RepoCreds) (*v1alpha1.RepoCreds, error) {
+	if err := ValidateOIDCTokenExchange(db.settingsMgr, r.OIDCTokenExchangeURL, ""); err != nil {
+		return nil, err
+	}
 	secretBackend := db.repoWriteBackend()
 	secretExists, err := secretBackend.RepoCredsExists(ctx, r.URL)
 	if err != nil {
@@ -367,6 +428,9 @@ func (db *db) CreateWriteRepositoryCredentials(ctx context.Context, r *v1alpha1.
 
 // UpdateRepositoryCredentials updates a repository credential set
 func (db *db) UpdateRepositoryCredentials(ctx context.Context, r *v1alpha1.RepoCreds) (*v1alpha1.RepoCreds, error) {
+	if err := ValidateOIDCTokenExchange(db.settingsMgr, r.OIDCTokenExchangeURL, ""); err != nil {
+		return nil, err
+	}
 	secretsBackend := db.repoBackend()
 	exists, err := secretsBackend.RepoCredsExists(ctx, r.URL)
 	if err != nil {
@@ -380,6 +444,9 @@ func (db *db) UpdateRepositoryCredentials(ctx context.Context, r *v1alpha1.RepoC
 
 // UpdateWriteRepositoryCredentials updates a repository write credential set
 func (db *db) UpdateWriteRepositoryCredentials(ctx context.Context, r *v1alpha1.RepoCreds) (*v1alpha1.RepoCreds, error) {
+	if err := ValidateOIDCTokenExchange(db.settingsMgr, r.OIDCTokenExchangeURL, ""); err != nil {
+		return nil, err
+	}
 	secretBackend := db.repoWriteBackend()
 	exists, err := secretBackend.RepoCredsExists(ctx, r.URL)
 	if err != nil {
@@ -454,6 +521,35 @@ func (db *db) enrichCredsToRepo(ctx context.Context, repository *v1alpha1.Reposi
 	return nil
 }
 
+// ValidateOIDCTokenExchange returns an error if a repository of the given project exchanges the OIDC token of the
+// workload at an endpoint which isn't allowed in argocd-cm. The repositories scoped to a project can't exchange it.
+func ValidateOIDCTokenExchange(settingsMgr *settings.SettingsManager, tokenURL string, project string) error {
+	if tokenURL == "" {
+		return nil
+	}
+	if project != "" {
+		return status.Errorf(codes.InvalidArgument, "OIDC token exchange isn't allowed for the repositories scoped to project %q", project)
+	}
+	allowed, err := settingsMgr.IsOIDCTokenExchangeURLAllowed(tokenURL)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return status.Errorf(codes.InvalidArgument, "OIDC token exchange URL %q isn't allowed, it must be listed in oidc.tokenExchange.allowedURLs of argocd-cm", tokenURL)
+	}
+	return nil
+}
+
+// dropDisallowedOIDCTokenExchange removes the OIDC token exchange of a repository if it isn't allowed, e.g. because it
+// was inherited from a credential template by a repository scoped to a project
+func (db *db) dropDisallowedOIDCTokenExchange(repository *v1alpha1.Repository) {
+	if err := ValidateOIDCTokenExchange(db.settingsMgr, repository.OIDCTokenExchangeURL, repository.Project); err != nil {
+		log.Warnf("Ignoring the OIDC token exchange of repository %s: %v", repository.Repo, err)
+		repository.OIDCTokenExchangeURL = ""
+		repository.OIDCTokenExchangeAudience = ""
+	}
+}
+
 // RepoURLToSecretName hashes repo URL to a secret name using a formula. This is used when
 // repositories are _imperatively_ created and need its credentials to be stored in a secret.
 // NOTE: this formula should not be considered stable and may change in future releases.
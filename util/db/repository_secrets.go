@@ -34,7 +34,9 @@ func (s *secretsRepositoryBackend) CreateRepository(ctx context.Context, reposit
 		},
 	}
 
-	s.repositoryToSecret(repository, repositorySecret)
+	if err := s.repositoryToSecret(repository, repositorySecret); err != nil {
+		return nil, err
+	}
 
 	_, err := s.db.createSecret(ctx, repositorySecret)
 	if err != nil {
@@ -142,7 +144,9 @@ func (s *secretsRepositoryBackend) UpdateRepository(ctx context.Context, reposit
 		return nil, err
 	}
 
-	s.repositoryToSecret(repository, repositorySecret)
+	if err := s.repositoryToSecret(repository, repositorySecret); err != nil {
+		return nil, err
+	}
 
 	_, err = s.db.kubeclientset.CoreV1().Secrets(s.db.ns).Update(ctx, repositorySecret, metav1.UpdateOptions{})
 	if err != nil {
@@ -187,7 +191,9 @@ func (s *secretsRepositoryBackend) CreateRepoCreds(ctx context.Context, repoCred
 		},
 	}
 
-	repoCredsToSecret(repoCreds, repoCredsSecret)
+	if err := repoCredsToSecret(repoCreds, repoCredsSecret); err != nil {
+		return nil, err
+	}
 
 	_, err := s.db.createSecret(ctx, repoCredsSecret)
 	if err != nil {
@@ -237,7 +243,9 @@ func (s *secretsRepositoryBackend) UpdateRepoCreds(ctx context.Context, repoCred
 		return nil, err
 	}
 
-	repoCredsToSecret(repoCreds, repoCredsSecret)
+	if err := repoCredsToSecret(repoCreds, repoCredsSecret); err != nil {
+		return nil, err
+	}
 
 	repoCredsSecret, err = s.db.kubeclientset.CoreV1().Secrets(s.db.ns).Update(ctx, repoCredsSecret, metav1.UpdateOptions{})
 	if err != nil {
@@ -323,6 +331,17 @@ func (s *secretsRepositoryBackend) GetAllOCIRepoCreds(_ context.Context) ([]*app
 }
 
 func secretToRepository(secret *corev1.Secret) (*appsv1.Repository, error) {
+	decrypted, err := decryptSecretData(secret)
+	if err != nil {
+		return &appsv1.Repository{
+			Name:    string(secret.Data["name"]),
+			Repo:    string(secret.Data["url"]),
+			Type:    string(secret.Data["type"]),
+			Project: string(secret.Data["project"]),
+		}, err
+	}
+	secret = decrypted
+
 	repository := &appsv1.Repository{
 		Name:                       string(secret.Data["name"]),
 		Repo:                       string(secret.Data["url"]),
@@ -337,6 +356,10 @@ func secretToRepository(secret *corev1.Secret) (*appsv1.Repository, error) {
 		GitHubAppEnterpriseBaseURL: string(secret.Data["githubAppEnterpriseBaseUrl"]),
 		Proxy:                      string(secret.Data["proxy"]),
 		NoProxy:                    string(secret.Data["noProxy"]),
+		ProxyUsername:              string(secret.Data["proxyUsername"]),
+		ProxyPassword:              string(secret.Data["proxyPassword"]),
+		OIDCTokenExchangeURL:       string(secret.Data["oidcTokenExchangeURL"]),
+		OIDCTokenExchangeAudience:  string(secret.Data["oidcTokenExchangeAudience"]),
 		Project:                    string(secret.Data["project"]),
 		GCPServiceAccountKey:       string(secret.Data["gcpServiceAccountKey"]),
 	}
@@ -395,10 +418,16 @@ func secretToRepository(secret *corev1.Secret) (*appsv1.Repository, error) {
 	}
 	repository.UseAzureWorkloadIdentity = useAzureWorkloadIdentity
 
+	useKerberos, err := boolOrFalse(secret, "useKerberos")
+	if err != nil {
+		return repository, err
+	}
+	repository.UseKerberos = useKerberos
+
 	return repository, nil
 }
 
-func (s *secretsRepositoryBackend) repositoryToSecret(repository *appsv1.Repository, secret *corev1.Secret) {
+func (s *secretsRepositoryBackend) repositoryToSecret(repository *appsv1.Repository, secret *corev1.Secret) error {
 	if secret.Data == nil {
 		secret.Data = make(map[string][]byte)
 	}
@@ -424,13 +453,24 @@ func (s *secretsRepositoryBackend) repositoryToSecret(repository *appsv1.Reposit
 	updateSecretBool(secret, "enableLfs", repository.EnableLFS)
 	updateSecretString(secret, "proxy", repository.Proxy)
 	updateSecretString(secret, "noProxy", repository.NoProxy)
+	updateSecretString(secret, "proxyUsername", repository.ProxyUsername)
+	updateSecretString(secret, "proxyPassword", repository.ProxyPassword)
 	updateSecretString(secret, "gcpServiceAccountKey", repository.GCPServiceAccountKey)
 	updateSecretBool(secret, "forceHttpBasicAuth", repository.ForceHttpBasicAuth)
 	updateSecretBool(secret, "useAzureWorkloadIdentity", repository.UseAzureWorkloadIdentity)
+	updateSecretBool(secret, "useKerberos", repository.UseKerberos)
+	updateSecretString(secret, "oidcTokenExchangeURL", repository.OIDCTokenExchangeURL)
+	updateSecretString(secret, "oidcTokenExchangeAudience", repository.OIDCTokenExchangeAudience)
 	addSecretMetadata(secret, s.getSecretType())
+	return encryptSecretData(secret, repositorySecretEncryptedKeys)
 }
 
 func (s *secretsRepositoryBackend) secretToRepoCred(secret *corev1.Secret) (*appsv1.RepoCreds, error) {
+	secret, err := decryptSecretData(secret)
+	if err != nil {
+		return nil, err
+	}
+
 	repository := &appsv1.RepoCreds{
 		URL:                        string(secret.Data["url"]),
 		Username:                   string(secret.Data["username"]),
@@ -445,6 +485,10 @@ func (s *secretsRepositoryBackend) secretToRepoCred(secret *corev1.Secret) (*app
 		GCPServiceAccountKey:       string(secret.Data["gcpServiceAccountKey"]),
 		Proxy:                      string(secret.Data["proxy"]),
 		NoProxy:                    string(secret.Data["noProxy"]),
+		ProxyUsername:              string(secret.Data["proxyUsername"]),
+		ProxyPassword:              string(secret.Data["proxyPassword"]),
+		OIDCTokenExchangeURL:       string(secret.Data["oidcTokenExchangeURL"]),
+		OIDCTokenExchangeAudience:  string(secret.Data["oidcTokenExchangeAudience"]),
 	}
 
 	enableOCI, err := boolOrFalse(secret, "enableOCI")
@@ -483,10 +527,16 @@ func (s *secretsRepositoryBackend) secretToRepoCred(secret *corev1.Secret) (*app
 	}
 	repository.UseAzureWorkloadIdentity = useAzureWorkloadIdentity
 
+	useKerberos, err := boolOrFalse(secret, "useKerberos")
+	if err != nil {
+		return repository, err
+	}
+	repository.UseKerberos = useKerberos
+
 	return repository, nil
 }
 
-func repoCredsToSecret(repoCreds *appsv1.RepoCreds, secret *corev1.Secret) {
+func repoCredsToSecret(repoCreds *appsv1.RepoCreds, secret *corev1.Secret) error {
 	if secret.Data == nil {
 		secret.Data = make(map[string][]byte)
 	}
@@ -508,9 +558,15 @@ func repoCredsToSecret(repoCreds *appsv1.RepoCreds, secret *corev1.Secret) {
 	updateSecretString(secret, "gcpServiceAccountKey", repoCreds.GCPServiceAccountKey)
 	updateSecretString(secret, "proxy", repoCreds.Proxy)
 	updateSecretString(secret, "noProxy", repoCreds.NoProxy)
+	updateSecretString(secret, "proxyUsername", repoCreds.ProxyUsername)
+	updateSecretString(secret, "proxyPassword", repoCreds.ProxyPassword)
 	updateSecretBool(secret, "forceHttpBasicAuth", repoCreds.ForceHttpBasicAuth)
 	updateSecretBool(secret, "useAzureWorkloadIdentity", repoCreds.UseAzureWorkloadIdentity)
+	updateSecretBool(secret, "useKerberos", repoCreds.UseKerberos)
+	updateSecretString(secret, "oidcTokenExchangeURL", repoCreds.OIDCTokenExchangeURL)
+	updateSecretString(secret, "oidcTokenExchangeAudience", repoCreds.OIDCTokenExchangeAudience)
 	addSecretMetadata(secret, common.LabelValueSecretTypeRepoCreds)
+	return encryptSecretData(secret, repositorySecretEncryptedKeys)
 }
 
 func (s *secretsRepositoryBackend) getRepositorySecret(repoURL, project string, allowFallback bool) (*corev1.Secret, error) {
@@ -224,6 +224,49 @@ func TestDb_GetRepositoryCredentials(t *testing.T) {
 	assert.Nil(t, repoCreds)
 }
 
+func TestDb_OIDCTokenExchange(t *testing.T) {
+	repoCredsSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNamespace,
+			Name:      "some-repocreds-secret",
+			Labels: map[string]string{
+				common.LabelKeySecretType: common.LabelValueSecretTypeRepoCreds,
+			},
+		},
+		Data: map[string][]byte{
+			"type":                 []byte("git"),
+			"url":                  []byte("https://git.example.com"),
+			"oidcTokenExchangeURL": []byte("https://sts.example.com/token"),
+		},
+	}
+	clientset := getClientset(repoCredsSecret)
+	cm, err := clientset.CoreV1().ConfigMaps(testNamespace).Get(t.Context(), common.ArgoCDConfigMapName, metav1.GetOptions{})
+	require.NoError(t, err)
+	cm.Data = map[string]string{"oidc.tokenExchange.allowedURLs": "https://sts.example.com/token"}
+	_, err = clientset.CoreV1().ConfigMaps(testNamespace).Update(t.Context(), cm, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	testee := NewDB(testNamespace, settings.NewSettingsManager(t.Context(), clientset, testNamespace), clientset)
+
+	_, err = testee.CreateRepository(t.Context(), &appsv1.Repository{Repo: "https://git.example.com/other", OIDCTokenExchangeURL: "https://attacker.example.com/token"})
+	require.ErrorContains(t, err, `OIDC token exchange URL "https://attacker.example.com/token" isn't allowed`)
+	_, err = testee.CreateRepository(t.Context(), &appsv1.Repository{Repo: "https://git.example.com/other", Project: "default", OIDCTokenExchangeURL: "https://sts.example.com/token"})
+	require.ErrorContains(t, err, `OIDC token exchange isn't allowed for the repositories scoped to project "default"`)
+	_, err = testee.CreateRepositoryCredentials(t.Context(), &appsv1.RepoCreds{URL: "https://git.example.com/org", OIDCTokenExchangeURL: "https://attacker.example.com/token"})
+	require.ErrorContains(t, err, `OIDC token exchange URL "https://attacker.example.com/token" isn't allowed`)
+	_, err = testee.CreateRepository(t.Context(), &appsv1.Repository{Repo: "https://git.example.com/allowed", OIDCTokenExchangeURL: "https://sts.example.com/token"})
+	require.NoError(t, err)
+
+	// the credential template applies to the repositories which aren't scoped to a project
+	repository, err := testee.GetRepository(t.Context(), "https://git.example.com/repo", "")
+	require.NoError(t, err)
+	assert.Equal(t, "https://sts.example.com/token", repository.OIDCTokenExchangeURL)
+	_, err = testee.CreateRepository(t.Context(), &appsv1.Repository{Repo: "https://git.example.com/scoped", Project: "default"})
+	require.NoError(t, err)
+	repository, err = testee.GetRepository(t.Context(), "https://git.example.com/scoped", "default")
+	require.NoError(t, err)
+	assert.Empty(t, repository.OIDCTokenExchangeURL)
+}
+
 func TestRepoURLToSecretName(t *testing.T) {
 	tables := []struct {
 		repoURL    string
@@ -0,0 +1,270 @@
+package db
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/argoproj/argo-cd/v3/common"
+	"github.com/argoproj/argo-cd/v3/util/crypto"
+	"github.com/argoproj/argo-cd/v3/util/env"
+	"github.com/argoproj/argo-cd/v3/util/kms"
+)
+
+const (
+	// EnvSecretEncryptionKey is an environment variable containing the URI of the KMS key encrypting the credentials
+	// stored in the secrets of the repositories, the repository credentials and the clusters
+	EnvSecretEncryptionKey = "ARGOCD_SECRET_ENCRYPTION_KEY"
+	// EnvSecretDecryptionKeys is an environment variable containing the comma separated URIs of the previous KMS keys,
+	// which still decrypt the credentials encrypted before the encryption key was rotated
+	EnvSecretDecryptionKeys = "ARGOCD_SECRET_DECRYPTION_KEYS"
+
+	// encryptedValuePrefix is the prefix of the encrypted values of the secrets
+	encryptedValuePrefix = "argocd-encrypted:v1:"
+	// secretEncryptionTimeout is the timeout of the calls to the KMS
+	secretEncryptionTimeout = 30 * time.Second
+)
+
+var (
+	// repositorySecretEncryptedKeys are the keys of the repository and repository credentials secrets which are encrypted
+	repositorySecretEncryptedKeys = []string{"password", "bearerToken", "sshPrivateKey", "tlsClientCertKey", "githubAppPrivateKey", "gcpServiceAccountKey", "proxyPassword"}
+	// clusterSecretEncryptedKeys are the keys of the cluster secrets which are encrypted
+	clusterSecretEncryptedKeys = []string{"config"}
+
+	getSecretEncrypter = sync.OnceValues(func() (*secretEncrypter, error) {
+		return newSecretEncrypter(env.StringFromEnv(EnvSecretEncryptionKey, ""), env.StringsFromEnv(EnvSecretDecryptionKeys, nil, ","))
+	})
+)
+
+// encryptedValue is an encrypted value of a secret, whose data encryption key is encrypted with a KMS key
+type encryptedValue struct {
+	// Key is the URI of the KMS key encrypting the data encryption key
+	Key string `json:"key"`
+	// DataKey is the encrypted data encryption key
+	DataKey []byte `json:"dataKey"`
+	// Data is the value encrypted with the data encryption key
+	Data []byte `json:"data"`
+}
+
+// secretEncrypter encrypts the values of the secrets with a random data encryption key, itself encrypted with a KMS
+// key, and decrypts them with the KMS key they were encrypted with
+type secretEncrypter struct {
+	encryptionKey string
+	keys          map[string]kms.KeyEncrypter
+
+	lock sync.Mutex
+	// dataKeys caches the decrypted data encryption keys, by KMS key URI and encrypted data encryption key
+	dataKeys map[string][]byte
+}
+
+func newSecretEncrypter(encryptionKey string, decryptionKeys []string) (*secretEncrypter, error) {
+	e := &secretEncrypter{
+		encryptionKey: encryptionKey,
+		keys:          map[string]kms.KeyEncrypter{},
+		dataKeys:      map[string][]byte{},
+	}
+	for _, keyURI := range append([]string{encryptionKey}, decryptionKeys...) {
+		keyURI = strings.TrimSpace(keyURI)
+		if _, ok := e.keys[keyURI]; ok || keyURI == "" {
+			continue
+		}
+		key, err := kms.NewKeyEncrypter(keyURI)
+		if err != nil {
+			return nil, fmt.Errorf("error initializing secret encryption key %s: %w", keyURI, err)
+		}
+		e.keys[keyURI] = key
+	}
+	return e, nil
+}
+
+// encrypt encrypts the non empty values of the given keys of the secret, which aren't already encrypted with the
+// encryption key. The values encrypted with a previous key are encrypted again with the encryption key.
+func (e *secretEncrypter) encrypt(ctx context.Context, secret *corev1.Secret, keys []string) error {
+	if e.encryptionKey == "" {
+		return nil
+	}
+	var dataKey, encryptedDataKey []byte
+	for _, key := range keys {
+		value := secret.Data[key]
+		if len(value) == 0 {
+			continue
+		}
+		if encrypted, ok, err := parseEncryptedValue(value); err != nil {
+			return fmt.Errorf("error parsing encrypted key %s of secret %s: %w", key, secret.Name, err)
+		} else if ok {
+			if encrypted.Key == e.encryptionKey {
+				continue
+			}
+			if value, err = e.decryptValue(ctx, encrypted); err != nil {
+				return fmt.Errorf("error decrypting key %s of secret %s: %w", key, secret.Name, err)
+			}
+		}
+		if dataKey == nil {
+			dataKey = make([]byte, 32)
+			if _, err := rand.Read(dataKey); err != nil {
+				return fmt.Errorf("error generating data encryption key: %w", err)
+			}
+			var err error
+			if encryptedDataKey, err = e.keys[e.encryptionKey].Encrypt(ctx, dataKey); err != nil {
+				return err
+			}
+		}
+		data, err := crypto.Encrypt(value, dataKey)
+		if err != nil {
+			return fmt.Errorf("error encrypting key %s of secret %s: %w", key, secret.Name, err)
+		}
+		encrypted, err := json.Marshal(encryptedValue{Key: e.encryptionKey, DataKey: encryptedDataKey, Data: data})
+		if err != nil {
+			return err
+		}
+		secret.Data[key] = []byte(encryptedValuePrefix + base64.StdEncoding.EncodeToString(encrypted))
+	}
+	return nil
+}
+
+// decrypt returns the secret with its encrypted values decrypted. The secret is returned as is if it has no encrypted
+// value, and copied otherwise.
+func (e *secretEncrypter) decrypt(ctx context.Context, secret *corev1.Secret) (*corev1.Secret, error) {
+	var decrypted *corev1.Secret
+	for key, value := range secret.Data {
+		encrypted, ok, err := parseEncryptedValue(value)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing encrypted key %s of secret %s: %w", key, secret.Name, err)
+		}
+		if !ok {
+			continue
+		}
+		plaintext, err := e.decryptValue(ctx, encrypted)
+		if err != nil {
+			return nil, fmt.Errorf("error decrypting key %s of secret %s: %w", key, secret.Name, err)
+		}
+		if decrypted == nil {
+			decrypted = secret.DeepCopy()
+		}
+		decrypted.Data[key] = plaintext
+	}
+	if decrypted == nil {
+		return secret, nil
+	}
+	return decrypted, nil
+}
+
+func (e *secretEncrypter) decryptValue(ctx context.Context, encrypted *encryptedValue) ([]byte, error) {
+	cacheKey := encrypted.Key + "/" + base64.StdEncoding.EncodeToString(encrypted.DataKey)
+	e.lock.Lock()
+	dataKey, ok := e.dataKeys[cacheKey]
+	e.lock.Unlock()
+	if !ok {
+		key, ok := e.keys[encrypted.Key]
+		if !ok {
+			return nil, fmt.Errorf("the value is encrypted with key %s, which is neither configured with %s nor with %s", encrypted.Key, EnvSecretEncryptionKey, EnvSecretDecryptionKeys)
+		}
+		var err error
+		if dataKey, err = key.Decrypt(ctx, encrypted.DataKey); err != nil {
+			return nil, err
+		}
+		e.lock.Lock()
+		e.dataKeys[cacheKey] = dataKey
+		e.lock.Unlock()
+	}
+	return crypto.Decrypt(encrypted.Data, dataKey)
+}
+
+// parseEncryptedValue returns the encrypted value of a secret, or false if the value isn't encrypted
+func parseEncryptedValue(value []byte) (*encryptedValue, bool, error) {
+	encoded, ok := strings.CutPrefix(string(value), encryptedValuePrefix)
+	if !ok {
+		return nil, false, nil
+	}
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, false, err
+	}
+	var encrypted encryptedValue
+	if err := json.Unmarshal(data, &encrypted); err != nil {
+		return nil, false, err
+	}
+	return &encrypted, true, nil
+}
+
+// encryptSecretData encrypts the given keys of the secret with the key configured with ARGOCD_SECRET_ENCRYPTION_KEY,
+// if any
+func encryptSecretData(secret *corev1.Secret, keys []string) error {
+	e, err := getSecretEncrypter()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), secretEncryptionTimeout)
+	defer cancel()
+	return e.encrypt(ctx, secret, keys)
+}
+
+// decryptSecretData returns the secret with its encrypted values decrypted
+func decryptSecretData(secret *corev1.Secret) (*corev1.Secret, error) {
+	for _, value := range secret.Data {
+		if strings.HasPrefix(string(value), encryptedValuePrefix) {
+			e, err := getSecretEncrypter()
+			if err != nil {
+				return nil, err
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), secretEncryptionTimeout)
+			defer cancel()
+			return e.decrypt(ctx, secret)
+		}
+	}
+	return secret, nil
+}
+
+// ReencryptSecrets encrypts again the credentials of the repository, repository credentials and cluster secrets of
+// the namespace with the key configured with ARGOCD_SECRET_ENCRYPTION_KEY, e.g. after a key rotation, and returns the
+// names of the updated secrets. The credentials which aren't encrypted yet are encrypted.
+func ReencryptSecrets(ctx context.Context, kubeclientset kubernetes.Interface, namespace string) ([]string, error) {
+	e, err := getSecretEncrypter()
+	if err != nil {
+		return nil, err
+	}
+	if e.encryptionKey == "" {
+		return nil, fmt.Errorf("%s must be set to encrypt the secrets", EnvSecretEncryptionKey)
+	}
+	encryptedKeys := map[string][]string{
+		common.LabelValueSecretTypeCluster:         clusterSecretEncryptedKeys,
+		common.LabelValueSecretTypeRepository:      repositorySecretEncryptedKeys,
+		common.LabelValueSecretTypeRepositoryWrite: repositorySecretEncryptedKeys,
+		common.LabelValueSecretTypeRepoCreds:       repositorySecretEncryptedKeys,
+	}
+	secrets, err := kubeclientset.CoreV1().Secrets(namespace).List(ctx, metav1.ListOptions{LabelSelector: common.LabelKeySecretType})
+	if err != nil {
+		return nil, fmt.Errorf("error listing secrets: %w", err)
+	}
+	var updated []string
+	for i := range secrets.Items {
+		secret := &secrets.Items[i]
+		keys, ok := encryptedKeys[secret.Labels[common.LabelKeySecretType]]
+		if !ok {
+			continue
+		}
+		original := secret.DeepCopy()
+		if err := e.encrypt(ctx, secret, keys); err != nil {
+			return updated, err
+		}
+		if maps.EqualFunc(original.Data, secret.Data, bytes.Equal) {
+			continue
+		}
+		if _, err := kubeclientset.CoreV1().Secrets(namespace).Update(ctx, secret, metav1.UpdateOptions{}); err != nil {
+			return updated, fmt.Errorf("error updating secret %s: %w", secret.Name, err)
+		}
+		updated = append(updated, secret.Name)
+	}
+	return updated, nil
+}
@@ -0,0 +1,191 @@
+package db
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"filippo.io/age"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/argoproj/argo-cd/v3/common"
+	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	"github.com/argoproj/argo-cd/v3/util/settings"
+)
+
+// newTestKeyURI writes a random age identity and returns its URI
+func newTestKeyURI(t *testing.T) string {
+	t.Helper()
+	identity, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "identity")
+	require.NoError(t, os.WriteFile(path, []byte(identity.String()), 0o600))
+	return "age://" + path
+}
+
+// useTestSecretEncrypter configures the secret encryption of the test
+func useTestSecretEncrypter(t *testing.T, encryptionKey string, decryptionKeys ...string) *secretEncrypter {
+	t.Helper()
+	e, err := newSecretEncrypter(encryptionKey, decryptionKeys)
+	require.NoError(t, err)
+	previous := getSecretEncrypter
+	getSecretEncrypter = func() (*secretEncrypter, error) {
+		return e, nil
+	}
+	t.Cleanup(func() {
+		getSecretEncrypter = previous
+	})
+	return e
+}
+
+func TestSecretEncrypter(t *testing.T) {
+	oldKey := newTestKeyURI(t)
+	newKey := newTestKeyURI(t)
+	newSecret := func() *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "repo"},
+			Data:       map[string][]byte{"url": []byte("https://github.com/example/repo"), "password": []byte("secret"), "bearerToken": {}},
+		}
+	}
+
+	t.Run("encrypt and decrypt", func(t *testing.T) {
+		e, err := newSecretEncrypter(oldKey, nil)
+		require.NoError(t, err)
+		secret := newSecret()
+		require.NoError(t, e.encrypt(t.Context(), secret, repositorySecretEncryptedKeys))
+		assert.Equal(t, "https://github.com/example/repo", string(secret.Data["url"]))
+		assert.Empty(t, secret.Data["bearerToken"])
+		assert.True(t, strings.HasPrefix(string(secret.Data["password"]), encryptedValuePrefix))
+
+		encrypted := secret.DeepCopy()
+		require.NoError(t, e.encrypt(t.Context(), secret, repositorySecretEncryptedKeys))
+		assert.Equal(t, encrypted.Data, secret.Data)
+
+		decrypted, err := e.decrypt(t.Context(), secret)
+		require.NoError(t, err)
+		assert.Equal(t, newSecret().Data, decrypted.Data)
+		assert.Equal(t, encrypted.Data, secret.Data)
+	})
+
+	t.Run("rotation", func(t *testing.T) {
+		old, err := newSecretEncrypter(oldKey, nil)
+		require.NoError(t, err)
+		secret := newSecret()
+		require.NoError(t, old.encrypt(t.Context(), secret, repositorySecretEncryptedKeys))
+
+		e, err := newSecretEncrypter(newKey, nil)
+		require.NoError(t, err)
+		_, err = e.decrypt(t.Context(), secret)
+		require.ErrorContains(t, err, "is neither configured with ARGOCD_SECRET_ENCRYPTION_KEY nor with ARGOCD_SECRET_DECRYPTION_KEYS")
+
+		e, err = newSecretEncrypter(newKey, []string{oldKey})
+		require.NoError(t, err)
+		require.NoError(t, e.encrypt(t.Context(), secret, repositorySecretEncryptedKeys))
+		encrypted, ok, err := parseEncryptedValue(secret.Data["password"])
+		require.NoError(t, err)
+		require.True(t, ok)
+		assert.Equal(t, newKey, encrypted.Key)
+
+		e, err = newSecretEncrypter(newKey, nil)
+		require.NoError(t, err)
+		decrypted, err := e.decrypt(t.Context(), secret)
+		require.NoError(t, err)
+		assert.Equal(t, "secret", string(decrypted.Data["password"]))
+	})
+
+	t.Run("no encryption key", func(t *testing.T) {
+		e, err := newSecretEncrypter("", nil)
+		require.NoError(t, err)
+		secret := newSecret()
+		require.NoError(t, e.encrypt(t.Context(), secret, repositorySecretEncryptedKeys))
+		assert.Equal(t, newSecret().Data, secret.Data)
+	})
+}
+
+func TestSecretEncryption_Repository(t *testing.T) {
+	useTestSecretEncrypter(t, newTestKeyURI(t))
+	clientset := getClientset()
+	db := NewDB(testNamespace, settings.NewSettingsManager(t.Context(), clientset, testNamespace), clientset)
+
+	_, err := db.CreateRepository(t.Context(), &v1alpha1.Repository{
+		Repo:     "https://github.com/argoproj/argocd-example-apps",
+		Username: "test-username",
+		Password: "test-password",
+	})
+	require.NoError(t, err)
+
+	secret, err := clientset.CoreV1().Secrets(testNamespace).Get(t.Context(), RepoURLToSecretName(repoSecretPrefix, "https://github.com/argoproj/argocd-example-apps", ""), metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "test-username", string(secret.Data["username"]))
+	assert.NotContains(t, string(secret.Data["password"]), "test-password")
+
+	repo, err := db.GetRepository(t.Context(), "https://github.com/argoproj/argocd-example-apps", "")
+	require.NoError(t, err)
+	assert.Equal(t, "test-password", repo.Password)
+}
+
+func TestSecretEncryption_Cluster(t *testing.T) {
+	useTestSecretEncrypter(t, newTestKeyURI(t))
+	clientset := getClientset()
+	db := NewDB(testNamespace, settings.NewSettingsManager(t.Context(), clientset, testNamespace), clientset)
+
+	_, err := db.CreateCluster(t.Context(), &v1alpha1.Cluster{
+		Server: "https://mycluster",
+		Config: v1alpha1.ClusterConfig{BearerToken: "test-token"},
+	})
+	require.NoError(t, err)
+
+	secrets, err := clientset.CoreV1().Secrets(testNamespace).List(t.Context(), metav1.ListOptions{LabelSelector: common.LabelKeySecretType + "=" + common.LabelValueSecretTypeCluster})
+	require.NoError(t, err)
+	require.Len(t, secrets.Items, 1)
+	assert.Equal(t, "https://mycluster", string(secrets.Items[0].Data["server"]))
+	assert.NotContains(t, string(secrets.Items[0].Data["config"]), "test-token")
+
+	cluster, err := SecretToCluster(&secrets.Items[0])
+	require.NoError(t, err)
+	assert.Equal(t, "test-token", cluster.Config.BearerToken)
+}
+
+func TestReencryptSecrets(t *testing.T) {
+	oldKey := newTestKeyURI(t)
+	old := useTestSecretEncrypter(t, oldKey)
+	encrypted := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "encrypted", Namespace: testNamespace, Labels: map[string]string{common.LabelKeySecretType: common.LabelValueSecretTypeRepository}},
+		Data:       map[string][]byte{"url": []byte("https://github.com/example/encrypted"), "password": []byte("secret")},
+	}
+	require.NoError(t, old.encrypt(t.Context(), encrypted, repositorySecretEncryptedKeys))
+	plaintext := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "plaintext", Namespace: testNamespace, Labels: map[string]string{common.LabelKeySecretType: common.LabelValueSecretTypeRepoCreds}},
+		Data:       map[string][]byte{"url": []byte("https://github.com/example"), "sshPrivateKey": []byte("key")},
+	}
+	other := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: testNamespace, Labels: map[string]string{common.LabelKeySecretType: common.LabelValueSecretTypeSCMCreds}},
+		Data:       map[string][]byte{"password": []byte("secret")},
+	}
+	clientset := getClientset(encrypted, plaintext, other)
+
+	newKey := newTestKeyURI(t)
+	useTestSecretEncrypter(t, newKey, oldKey)
+	updated, err := ReencryptSecrets(t.Context(), clientset, testNamespace)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"encrypted", "plaintext"}, updated)
+
+	useTestSecretEncrypter(t, newKey)
+	for _, name := range []string{"encrypted", "plaintext"} {
+		secret, err := clientset.CoreV1().Secrets(testNamespace).Get(t.Context(), name, metav1.GetOptions{})
+		require.NoError(t, err)
+		_, err = decryptSecretData(secret)
+		require.NoError(t, err)
+	}
+	secret, err := clientset.CoreV1().Secrets(testNamespace).Get(t.Context(), "other", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "secret", string(secret.Data["password"]))
+
+	updated, err = ReencryptSecrets(t.Context(), clientset, testNamespace)
+	require.NoError(t, err)
+	assert.Empty(t, updated)
+}
@@ -0,0 +1,105 @@
+package fips
+
+import (
+	"crypto/fips140"
+	"crypto/tls"
+	"encoding/json"
+	"net/http"
+	"slices"
+
+	"github.com/argoproj/argo-cd/v3/common"
+	"github.com/argoproj/argo-cd/v3/util/env"
+)
+
+// ApprovedCipherSuites are the FIPS 140-3 approved TLS 1.2 cipher suites. The TLS 1.3 cipher suites aren't
+// configurable, and are restricted to the approved ones by the Go Cryptographic Module in FIPS 140-3 mode.
+var ApprovedCipherSuites = []uint16{
+	tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+	tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+	tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+	tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+}
+
+// ApprovedCurves are the FIPS 140-3 approved elliptic curves of the TLS key exchanges
+var ApprovedCurves = []tls.CurveID{tls.CurveP256, tls.CurveP384, tls.CurveP521}
+
+// ApprovedJWTAlgorithms are the FIPS 140-3 approved signature algorithms of the OIDC tokens
+var ApprovedJWTAlgorithms = []string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512", "PS256", "PS384", "PS512"}
+
+// ApprovedPGPAlgorithms are the FIPS 140-3 approved public key algorithms of the GnuPG signatures, as reported by
+// gpg
+var ApprovedPGPAlgorithms = []string{"RSA", "ECDSA", "EDDSA"}
+
+// WeakPGPDigests are the digest algorithms of the GnuPG signatures which are rejected in FIPS mode
+var WeakPGPDigests = []string{"SHA1", "RIPEMD160"}
+
+const (
+	// MinTLSVersion is the minimum TLS version allowed in FIPS mode
+	MinTLSVersion = tls.VersionTLS12
+	// StatusPath is the path of the FIPS compliance status on the metrics server of the components
+	StatusPath = "/debug/fips"
+)
+
+// Enabled returns whether the FIPS mode is enabled, either because the Go Cryptographic Module runs in FIPS 140-3
+// mode, i.e. Argo CD was built with GOFIPS140 or started with GODEBUG=fips140=on, or with ARGOCD_FIPS_MODE
+func Enabled() bool {
+	return fips140.Enabled() || env.ParseBoolFromEnv(common.EnvFIPSMode, false)
+}
+
+// IsCipherSuiteApproved returns whether the TLS cipher suite is approved in FIPS mode
+func IsCipherSuiteApproved(id uint16) bool {
+	return slices.Contains(ApprovedCipherSuites, id)
+}
+
+// IsPGPAlgorithmApproved returns whether the public key algorithm of a GnuPG signature is approved in FIPS mode
+func IsPGPAlgorithmApproved(algorithm string) bool {
+	return slices.Contains(ApprovedPGPAlgorithms, algorithm)
+}
+
+// Status is the FIPS compliance status of an Argo CD component
+type Status struct {
+	// Enabled is true if the FIPS mode is enabled
+	Enabled bool `json:"enabled"`
+	// CryptoModule is true if the Go Cryptographic Module runs in FIPS 140-3 mode
+	CryptoModule bool `json:"cryptoModule"`
+	// Compliant is true if the FIPS mode is enabled and the cryptographic operations are performed by the Go
+	// Cryptographic Module in FIPS 140-3 mode
+	Compliant bool `json:"compliant"`
+	// TLSMinVersion is the minimum TLS version allowed in FIPS mode
+	TLSMinVersion string `json:"tlsMinVersion,omitempty"`
+	// TLSCipherSuites are the TLS 1.2 cipher suites allowed in FIPS mode
+	TLSCipherSuites []string `json:"tlsCipherSuites,omitempty"`
+	// JWTAlgorithms are the signature algorithms of the OIDC tokens allowed in FIPS mode
+	JWTAlgorithms []string `json:"jwtAlgorithms,omitempty"`
+	// PGPAlgorithms are the public key algorithms of the GnuPG signatures allowed in FIPS mode
+	PGPAlgorithms []string `json:"pgpAlgorithms,omitempty"`
+}
+
+// GetStatus returns the FIPS compliance status of the component
+func GetStatus() Status {
+	status := Status{
+		Enabled:      Enabled(),
+		CryptoModule: fips140.Enabled(),
+	}
+	status.Compliant = status.Enabled && status.CryptoModule
+	if status.Enabled {
+		status.TLSMinVersion = tls.VersionName(MinTLSVersion)
+		for _, id := range ApprovedCipherSuites {
+			status.TLSCipherSuites = append(status.TLSCipherSuites, tls.CipherSuiteName(id))
+		}
+		status.JWTAlgorithms = ApprovedJWTAlgorithms
+		status.PGPAlgorithms = ApprovedPGPAlgorithms
+	}
+	return status
+}
+
+// StatusHandler is an HTTP handler returning the FIPS compliance status of the component
+func StatusHandler(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(GetStatus())
+}
+
+// RegisterStatusHandler registers the FIPS compliance status handler on the mux
+func RegisterStatusHandler(mux *http.ServeMux) {
+	mux.HandleFunc(StatusPath, StatusHandler)
+}
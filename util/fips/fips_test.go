@@ -0,0 +1,54 @@
+package fips
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/argoproj/argo-cd/v3/common"
+)
+
+func TestGetStatus(t *testing.T) {
+	t.Run("Disabled", func(t *testing.T) {
+		t.Setenv(common.EnvFIPSMode, "false")
+		status := GetStatus()
+		if status.CryptoModule {
+			t.Skip("the Go Cryptographic Module runs in FIPS 140-3 mode")
+		}
+		assert.False(t, status.Enabled)
+		assert.False(t, status.Compliant)
+		assert.Empty(t, status.TLSCipherSuites)
+	})
+	t.Run("Enabled", func(t *testing.T) {
+		t.Setenv(common.EnvFIPSMode, "true")
+		status := GetStatus()
+		assert.True(t, status.Enabled)
+		assert.Equal(t, status.CryptoModule, status.Compliant)
+		assert.Equal(t, "TLS 1.2", status.TLSMinVersion)
+		assert.Contains(t, status.TLSCipherSuites, "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256")
+		assert.Equal(t, ApprovedJWTAlgorithms, status.JWTAlgorithms)
+	})
+}
+
+func TestIsCipherSuiteApproved(t *testing.T) {
+	assert.True(t, IsCipherSuiteApproved(tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384))
+	assert.False(t, IsCipherSuiteApproved(tls.TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256))
+	assert.False(t, IsCipherSuiteApproved(tls.TLS_RSA_WITH_AES_128_CBC_SHA))
+}
+
+func TestRegisterStatusHandler(t *testing.T) {
+	t.Setenv(common.EnvFIPSMode, "true")
+	mux := http.NewServeMux()
+	RegisterStatusHandler(mux)
+	rr := httptest.NewRecorder()
+	mux.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, StatusPath, http.NoBody))
+	require.Equal(t, http.StatusOK, rr.Code)
+	var status Status
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &status))
+	assert.True(t, status.Enabled)
+}
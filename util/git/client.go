@@ -167,6 +167,8 @@ type nativeGitClient struct {
 type runOpts struct {
 	SkipErrorLogging bool
 	CaptureStderr    bool
+	// Dir is the directory in which the command runs, the root of the repository if empty
+	Dir string
 }
 
 var (
@@ -371,6 +373,14 @@ func newAuth(repoURL string, creds Creds) (transport.AuthMethod, error) {
 
 		auth := githttp.TokenAuth{Token: token}
 		return &auth, nil
+	case OIDCTokenExchangeCreds:
+		token, err := creds.getAccessToken()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get access token from creds: %w", err)
+		}
+
+		auth := githttp.BasicAuth{Username: creds.username, Password: token}
+		return &auth, nil
 	}
 
 	return nil, nil
@@ -605,6 +615,25 @@ func (m *nativeGitClient) getRefs() ([]*plumbing.Reference, error) {
 		defer done()
 	}
 
+	res, err := m.listRemoteRefs()
+	if err == nil && m.gitRefCache != nil {
+		if err := m.gitRefCache.SetGitReferences(m.repoURL, res); err != nil {
+			log.Warnf("Failed to store git references to cache: %v", err)
+		} else {
+			// Since we successfully overwrote the lock with valid data, we don't need to unlock
+			needsUnlock = false
+		}
+		return res, nil
+	}
+	return res, err
+}
+
+// listRemoteRefs lists the references of the remote repository
+func (m *nativeGitClient) listRemoteRefs() ([]*plumbing.Reference, error) {
+	if _, ok := m.creds.(KerberosCreds); ok {
+		// go-git doesn't support the Negotiate authentication, so the references are listed by the git CLI
+		return m.listRemoteCLI()
+	}
 	repo, err := git.Init(memory.NewStorage(), nil)
 	if err != nil {
 		return nil, err
@@ -620,17 +649,58 @@ func (m *nativeGitClient) getRefs() ([]*plumbing.Reference, error) {
 	if err != nil {
 		return nil, err
 	}
-	res, err := listRemote(remote, &git.ListOptions{Auth: auth}, m.insecure, m.creds, m.proxy, m.noProxy)
-	if err == nil && m.gitRefCache != nil {
-		if err := m.gitRefCache.SetGitReferences(m.repoURL, res); err != nil {
-			log.Warnf("Failed to store git references to cache: %v", err)
-		} else {
-			// Since we successfully overwrote the lock with valid data, we don't need to unlock
-			needsUnlock = false
+	return listRemote(remote, &git.ListOptions{Auth: auth}, m.insecure, m.creds, m.proxy, m.noProxy)
+}
+
+// listRemoteCLI lists the references of the remote repository with `git ls-remote`. Like listRemote, the tags are
+// resolved to the commits they point to.
+func (m *nativeGitClient) listRemoteCLI() ([]*plumbing.Reference, error) {
+	// the repository may not be cloned yet, so the command doesn't run in its root
+	out, err := m.runCredentialedCmdOutput(runOpts{Dir: os.TempDir()}, "ls-remote", "--symref", m.repoURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list remote references: %w", err)
+	}
+	return parseLsRemoteOutput(out), nil
+}
+
+// parseLsRemoteOutput parses the output of `git ls-remote --symref`, e.g.
+//
+//	ref: refs/heads/main	HEAD
+//	a67038ae2e9cb9b9b16423702f98b41e36601001	HEAD
+//	a67038ae2e9cb9b9b16423702f98b41e36601001	refs/heads/main
+//	1c9f6dfd5b1c8a4be7e6e8fa0f0c1a1eb1e3d2a4	refs/tags/v1.0.0
+//	a67038ae2e9cb9b9b16423702f98b41e36601001	refs/tags/v1.0.0^{}
+func parseLsRemoteOutput(out string) []*plumbing.Reference {
+	var refs []*plumbing.Reference
+	symbolic := map[string]bool{}
+	peeled := map[string]plumbing.Hash{}
+	var hashRefs [][2]string
+	for _, line := range strings.Split(out, "\n") {
+		value, name, ok := strings.Cut(strings.TrimSpace(line), "\t")
+		if !ok {
+			continue
+		}
+		switch {
+		case strings.HasPrefix(value, "ref: "):
+			symbolic[name] = true
+			refs = append(refs, plumbing.NewSymbolicReference(plumbing.ReferenceName(name), plumbing.ReferenceName(strings.TrimPrefix(value, "ref: "))))
+		case strings.HasSuffix(name, "^{}"):
+			peeled[strings.TrimSuffix(name, "^{}")] = plumbing.NewHash(value)
+		default:
+			hashRefs = append(hashRefs, [2]string{value, name})
 		}
-		return res, nil
 	}
-	return res, err
+	for _, ref := range hashRefs {
+		hash, name := plumbing.NewHash(ref[0]), ref[1]
+		if symbolic[name] {
+			continue
+		}
+		if commit, ok := peeled[name]; ok && plumbing.ReferenceName(name).IsTag() {
+			hash = commit
+		}
+		refs = append(refs, plumbing.NewHashReference(plumbing.ReferenceName(name), hash))
+	}
+	return refs
 }
 
 func (m *nativeGitClient) LsRefs() (*Refs, error) {
@@ -1074,9 +1144,15 @@ func (m *nativeGitClient) runCmd(args ...string) (string, error) {
 
 // runCredentialedCmd is a convenience function to run a git command with username/password credentials
 func (m *nativeGitClient) runCredentialedCmd(args ...string) error {
+	_, err := m.runCredentialedCmdOutput(runOpts{}, args...)
+	return err
+}
+
+// runCredentialedCmdOutput runs a git command with the credentials and returns its output
+func (m *nativeGitClient) runCredentialedCmdOutput(ropts runOpts, args ...string) (string, error) {
 	closer, environ, err := m.creds.Environ()
 	if err != nil {
-		return err
+		return "", err
 	}
 	defer func() { _ = closer.Close() }()
 
@@ -1087,17 +1163,23 @@ func (m *nativeGitClient) runCredentialedCmd(args ...string) error {
 			args = append([]string{"--config-env", "http.extraHeader=" + forceBasicAuthHeaderEnv}, args...)
 		} else if strings.HasPrefix(e, bearerAuthHeaderEnv+"=") {
 			args = append([]string{"--config-env", "http.extraHeader=" + bearerAuthHeaderEnv}, args...)
+		} else if strings.HasPrefix(e, kerberosCCacheEnv+"=") {
+			// If Kerberos tickets are used, tell Git to attempt the Negotiate
+			// authentication without a username and password
+			args = append([]string{"-c", "http.emptyAuth=true"}, args...)
 		}
 	}
 
 	cmd := exec.Command("git", args...)
 	cmd.Env = append(cmd.Env, environ...)
-	_, err = m.runCmdOutput(cmd, runOpts{})
-	return err
+	return m.runCmdOutput(cmd, ropts)
 }
 
 func (m *nativeGitClient) runCmdOutput(cmd *exec.Cmd, ropts runOpts) (string, error) {
 	cmd.Dir = m.root
+	if ropts.Dir != "" {
+		cmd.Dir = ropts.Dir
+	}
 	cmd.Env = append(os.Environ(), cmd.Env...)
 	// Set $HOME to nowhere, so we can execute Git regardless of any external
 	// authentication keys (e.g. in ~/.ssh) -- this is especially important for
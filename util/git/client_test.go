@@ -982,6 +982,15 @@ func Test_nativeGitClient_runCredentialedCmd(t *testing.T) {
 			expectedEnv:  []string{bearerAuthHeaderEnv + "=Bearer test-token"},
 			expectedErr:  false,
 		},
+		{
+			name: "kerberos credential cache set",
+			creds: &mockCreds{
+				environ: []string{kerberosCCacheEnv + "=FILE:/tmp/krb5cc_test"},
+			},
+			expectedArgs: []string{"-c", "http.emptyAuth=true", "status"},
+			expectedEnv:  []string{kerberosCCacheEnv + "=FILE:/tmp/krb5cc_test"},
+			expectedErr:  false,
+		},
 		{
 			name: "no auth header set",
 			creds: &mockCreds{
@@ -1028,6 +1037,42 @@ func Test_nativeGitClient_runCredentialedCmd(t *testing.T) {
 	}
 }
 
+func Test_parseLsRemoteOutput(t *testing.T) {
+	refs := parseLsRemoteOutput(`ref: refs/heads/main	HEAD
+a67038ae2e9cb9b9b16423702f98b41e36601001	HEAD
+a67038ae2e9cb9b9b16423702f98b41e36601001	refs/heads/main
+1c9f6dfd5b1c8a4be7e6e8fa0f0c1a1eb1e3d2a4	refs/tags/v1.0.0
+a67038ae2e9cb9b9b16423702f98b41e36601001	refs/tags/v1.0.0^{}
+`)
+
+	assert.Equal(t, []*plumbing.Reference{
+		plumbing.NewSymbolicReference(plumbing.HEAD, "refs/heads/main"),
+		plumbing.NewReferenceFromStrings("refs/heads/main", "a67038ae2e9cb9b9b16423702f98b41e36601001"),
+		plumbing.NewReferenceFromStrings("refs/tags/v1.0.0", "a67038ae2e9cb9b9b16423702f98b41e36601001"),
+	}, refs)
+}
+
+func Test_nativeGitClient_LsRemote_Kerberos(t *testing.T) {
+	tempDir, err := _createEmptyGitRepo()
+	require.NoError(t, err)
+	err = runCmd(tempDir, "git", "tag", "v1.0.0", "-a", "-m", "Create annotated tag")
+	require.NoError(t, err)
+	out, err := outputCmd(tempDir, "git", "rev-parse", "HEAD")
+	require.NoError(t, err)
+	commitSHA := strings.TrimSpace(string(out))
+
+	// the references are listed by the git CLI, the repository not being cloned yet
+	client, err := NewClientExt("file://"+tempDir, filepath.Join(t.TempDir(), "repo"), NewKerberosCreds(false), false, false, "", "")
+	require.NoError(t, err)
+
+	sha, err := client.LsRemote("v1.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, commitSHA, sha)
+	sha, err = client.LsRemote("HEAD")
+	require.NoError(t, err)
+	assert.Equal(t, commitSHA, sha)
+}
+
 func Test_LsFiles_RaceCondition(t *testing.T) {
 	// Create two temporary directories and initialize them as git repositories
 	tempDir1 := t.TempDir()
@@ -12,8 +12,10 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"os/exec"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/google/go-github/v69/github"
@@ -31,6 +33,8 @@ import (
 	"github.com/argoproj/argo-cd/v3/common"
 	argoutils "github.com/argoproj/argo-cd/v3/util"
 	certutil "github.com/argoproj/argo-cd/v3/util/cert"
+	"github.com/argoproj/argo-cd/v3/util/env"
+	executil "github.com/argoproj/argo-cd/v3/util/exec"
 	utilio "github.com/argoproj/argo-cd/v3/util/io"
 	"github.com/argoproj/argo-cd/v3/util/workloadidentity"
 )
@@ -43,6 +47,12 @@ var (
 
 	// In memory cache for storing Azure tokens
 	azureTokenCache *gocache.Cache
+
+	// In memory cache for storing the access tokens exchanged for the OIDC token of the workload
+	oidcTokenExchangeCache *gocache.Cache
+
+	// kerberosTicketLock serializes the requests of Kerberos tickets to the credential cache
+	kerberosTicketLock sync.Mutex
 )
 
 const (
@@ -52,6 +62,12 @@ const (
 	bearerAuthHeaderEnv       = "ARGOCD_GIT_BEARER_AUTH_HEADER"
 	// This is the resource id of the OAuth application of Azure Devops.
 	azureDevopsEntraResourceId = "499b84ac-1321-427f-aa17-267ca6975798/.default"
+	// oidcTokenExchangeUsername is the username used with the exchanged access token if none is configured
+	oidcTokenExchangeUsername = "oauth2"
+	// oidcTokenExpiryMargin is the margin before the expiry of the exchanged access tokens at which they're exchanged again
+	oidcTokenExpiryMargin = 5 * time.Minute
+	// kerberosCCacheEnv is the environment variable used by the Kerberos libraries to locate the credential cache
+	kerberosCCacheEnv = "KRB5CCNAME"
 )
 
 func init() {
@@ -66,6 +82,7 @@ func init() {
 	// oauth2.TokenSource handles fetching new Tokens once they are expired. The oauth2.TokenSource itself does not expire.
 	googleCloudTokenSource = gocache.New(gocache.NoExpiration, 0)
 	azureTokenCache = gocache.New(gocache.NoExpiration, 0)
+	oidcTokenExchangeCache = gocache.New(gocache.NoExpiration, time.Minute)
 }
 
 type NoopCredsStore struct{}
@@ -745,3 +762,186 @@ func (creds AzureWorkloadIdentityCreds) GetAzureDevOpsAccessToken() (string, err
 	accessToken, err := creds.getAccessToken(azureDevopsEntraResourceId) // wellknown resourceid of Azure DevOps
 	return accessToken, err
 }
+
+var _ Creds = OIDCTokenExchangeCreds{}
+
+// OIDCTokenExchangeCreds authenticates with a short-lived access token, which the OAuth 2.0 token exchange endpoint
+// (RFC 8693) of the SCM or of a security token service issues in exchange for the OIDC token of the workload, e.g. its
+// projected Kubernetes service account token.
+type OIDCTokenExchangeCreds struct {
+	username  string
+	url       string
+	audience  string
+	tokenPath string
+	insecure  bool
+	proxy     string
+	noProxy   string
+	store     CredsStore
+}
+
+// NewOIDCTokenExchangeCreds returns the credentials exchanging the OIDC token read from the file configured with
+// ARGOCD_OIDC_TOKEN_FILE at the given token exchange endpoint
+func NewOIDCTokenExchangeCreds(username string, tokenURL string, audience string, insecure bool, proxy string, noProxy string, store CredsStore) OIDCTokenExchangeCreds {
+	if username == "" {
+		username = oidcTokenExchangeUsername
+	}
+	return OIDCTokenExchangeCreds{
+		username:  username,
+		url:       tokenURL,
+		audience:  audience,
+		tokenPath: env.StringFromEnv(common.EnvOIDCTokenFile, common.DefaultOIDCTokenFilePath),
+		insecure:  insecure,
+		proxy:     proxy,
+		noProxy:   noProxy,
+		store:     store,
+	}
+}
+
+// GetUserInfo returns the username and email address for the credentials, if they're available.
+func (creds OIDCTokenExchangeCreds) GetUserInfo(_ context.Context) (string, string, error) {
+	// Email not implemented for HTTPS creds.
+	return creds.username, "", nil
+}
+
+func (creds OIDCTokenExchangeCreds) Environ() (io.Closer, []string, error) {
+	token, err := creds.getAccessToken()
+	if err != nil {
+		return NopCloser{}, nil, err
+	}
+	var env []string
+	// GIT_SSL_NO_VERIFY is used to tell git not to validate the server's cert at
+	// all.
+	if creds.insecure {
+		env = append(env, "GIT_SSL_NO_VERIFY=true")
+	}
+	nonce := creds.store.Add(creds.username, token)
+	env = append(env, creds.store.Environ(nonce)...)
+	return utilio.NewCloser(func() error {
+		creds.store.Remove(nonce)
+		return nil
+	}), env, nil
+}
+
+// getAccessToken exchanges the OIDC token of the workload for an access token, which is cached until shortly before
+// it expires
+func (creds OIDCTokenExchangeCreds) getAccessToken() (string, error) {
+	subjectToken, err := os.ReadFile(creds.tokenPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read OIDC token of the workload: %w", err)
+	}
+	key, err := argoutils.GenerateCacheKey("%s %s %s", creds.url, creds.audience, subjectToken)
+	if err != nil {
+		return "", fmt.Errorf("failed to get get SHA256 hash for OIDC token exchange credentials: %w", err)
+	}
+	if t, found := oidcTokenExchangeCache.Get(key); found {
+		return t.(string), nil
+	}
+
+	form := url.Values{
+		"grant_type":           {"urn:ietf:params:oauth:grant-type:token-exchange"},
+		"subject_token":        {strings.TrimSpace(string(subjectToken))},
+		"subject_token_type":   {"urn:ietf:params:oauth:token-type:jwt"},
+		"requested_token_type": {"urn:ietf:params:oauth:token-type:access_token"},
+	}
+	if creds.audience != "" {
+		form.Set("audience", creds.audience)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, creds.url, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	resp, err := GetRepoHTTPClient(creds.url, creds.insecure, creds, creds.proxy, creds.noProxy).Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to exchange OIDC token at %s: %w", creds.url, err)
+	}
+	defer utilio.Close(resp.Body)
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read OIDC token exchange response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to exchange OIDC token at %s: unexpected status %s: %s", creds.url, resp.Status, body)
+	}
+	var res struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int64  `json:"expires_in"`
+	}
+	if err := json.Unmarshal(body, &res); err != nil {
+		return "", fmt.Errorf("failed to parse OIDC token exchange response: %w", err)
+	}
+	if res.AccessToken == "" {
+		return "", fmt.Errorf("OIDC token exchange response of %s doesn't contain an access token", creds.url)
+	}
+	if expiry := time.Duration(res.ExpiresIn)*time.Second - oidcTokenExpiryMargin; expiry > 0 {
+		oidcTokenExchangeCache.Set(key, res.AccessToken, expiry)
+	}
+	return res.AccessToken, nil
+}
+
+var _ Creds = KerberosCreds{}
+
+// KerberosCreds authenticates at the Git repositories served over HTTP(S) with SPNEGO (HTTP Negotiate), using the
+// Kerberos tickets of the repo server. The tickets are obtained with the keytab configured with
+// ARGOCD_GIT_KERBEROS_KEYTAB or, if none is configured, read from the credential cache, e.g. maintained by a sidecar.
+type KerberosCreds struct {
+	principal string
+	keytab    string
+	ccache    string
+	insecure  bool
+}
+
+// NewKerberosCreds returns the credentials using the Kerberos principal, keytab and credential cache configured in the
+// environment of the repo server
+func NewKerberosCreds(insecure bool) KerberosCreds {
+	return KerberosCreds{
+		principal: env.StringFromEnv(common.EnvGitKerberosPrincipal, ""),
+		keytab:    env.StringFromEnv(common.EnvGitKerberosKeytab, ""),
+		ccache:    env.StringFromEnv(common.EnvGitKerberosCCache, common.DefaultGitKerberosCCachePath),
+		insecure:  insecure,
+	}
+}
+
+// GetUserInfo returns the username and email address for the credentials, if they're available.
+func (creds KerberosCreds) GetUserInfo(_ context.Context) (string, string, error) {
+	// Email not implemented for Kerberos creds.
+	return creds.principal, "", nil
+}
+
+func (creds KerberosCreds) Environ() (io.Closer, []string, error) {
+	if err := creds.obtainTicket(); err != nil {
+		return NopCloser{}, nil, err
+	}
+	env := []string{fmt.Sprintf("%s=FILE:%s", kerberosCCacheEnv, creds.ccache)}
+	// GIT_SSL_NO_VERIFY is used to tell git not to validate the server's cert at
+	// all.
+	if creds.insecure {
+		env = append(env, "GIT_SSL_NO_VERIFY=true")
+	}
+	return NopCloser{}, env, nil
+}
+
+// obtainTicket obtains a ticket for the principal with the keytab, unless the credential cache already holds a valid
+// ticket
+func (creds KerberosCreds) obtainTicket() error {
+	if creds.keytab == "" {
+		return nil
+	}
+	kerberosTicketLock.Lock()
+	defer kerberosTicketLock.Unlock()
+	// klist -s exits with a non-zero status if the credential cache doesn't hold any valid ticket
+	if err := exec.Command("klist", "-s", "-c", creds.ccache).Run(); err == nil {
+		return nil
+	}
+	args := []string{"-k", "-t", creds.keytab, "-c", creds.ccache}
+	if creds.principal != "" {
+		args = append(args, creds.principal)
+	}
+	if _, err := executil.Run(exec.Command("kinit", args...)); err != nil {
+		return fmt.Errorf("failed to obtain Kerberos ticket with keytab %s: %w", creds.keytab, err)
+	}
+	return nil
+}
@@ -2,9 +2,13 @@ package git
 
 import (
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path"
+	"path/filepath"
 	"regexp"
 	"strings"
 	"testing"
@@ -19,6 +23,7 @@ import (
 
 	argoio "github.com/argoproj/gitops-engine/pkg/utils/io"
 
+	"github.com/argoproj/argo-cd/v3/common"
 	"github.com/argoproj/argo-cd/v3/util/cert"
 	utilio "github.com/argoproj/argo-cd/v3/util/io"
 	"github.com/argoproj/argo-cd/v3/util/workloadidentity"
@@ -512,3 +517,139 @@ func TestAzureWorkloadIdentityCreds_ReuseTokenIfExistingIsNotExpired(t *testing.
 func resetAzureTokenCache() {
 	azureTokenCache = gocache.New(gocache.NoExpiration, 0)
 }
+
+func newOIDCTokenExchangeServer(t *testing.T, expiresIn int) (*httptest.Server, *int) {
+	t.Helper()
+	exchanges := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, r.ParseForm())
+		assert.Equal(t, "urn:ietf:params:oauth:grant-type:token-exchange", r.PostForm.Get("grant_type"))
+		assert.Equal(t, "urn:ietf:params:oauth:token-type:jwt", r.PostForm.Get("subject_token_type"))
+		if r.PostForm.Get("subject_token") != "workload-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error":"invalid_grant"}`))
+			return
+		}
+		exchanges++
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"access_token":      fmt.Sprintf("access-token-%d-%s", exchanges, r.PostForm.Get("audience")),
+			"issued_token_type": "urn:ietf:params:oauth:token-type:access_token",
+			"token_type":        "Bearer",
+			"expires_in":        expiresIn,
+		})
+	}))
+	t.Cleanup(server.Close)
+	return server, &exchanges
+}
+
+func newTestOIDCTokenExchangeCreds(t *testing.T, url string, subjectToken string, store CredsStore) OIDCTokenExchangeCreds {
+	t.Helper()
+	tokenPath := path.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenPath, []byte(subjectToken+"\n"), 0o600))
+	t.Setenv(common.EnvOIDCTokenFile, tokenPath)
+	return NewOIDCTokenExchangeCreds("", url, "gitlab", false, "", "", store)
+}
+
+func TestOIDCTokenExchangeCreds_Environ(t *testing.T) {
+	oidcTokenExchangeCache = gocache.New(gocache.NoExpiration, 0)
+	server, exchanges := newOIDCTokenExchangeServer(t, 3600)
+	store := &memoryCredsStore{creds: make(map[string]cred)}
+	creds := newTestOIDCTokenExchangeCreds(t, server.URL, "workload-token", store)
+
+	closer, _, err := creds.Environ()
+	require.NoError(t, err)
+	require.Len(t, store.creds, 1)
+	for _, value := range store.creds {
+		assert.Equal(t, "oauth2", value.username)
+		assert.Equal(t, "access-token-1-gitlab", value.password)
+	}
+	utilio.Close(closer)
+	assert.Empty(t, store.creds)
+
+	// the access token is cached until shortly before it expires
+	_, _, err = creds.Environ()
+	require.NoError(t, err)
+	assert.Equal(t, 1, *exchanges)
+
+	user, email, err := creds.GetUserInfo(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, "oauth2", user)
+	assert.Empty(t, email)
+}
+
+func TestOIDCTokenExchangeCreds_ShortLivedToken(t *testing.T) {
+	oidcTokenExchangeCache = gocache.New(gocache.NoExpiration, 0)
+	server, exchanges := newOIDCTokenExchangeServer(t, 60)
+	creds := newTestOIDCTokenExchangeCreds(t, server.URL, "workload-token", &memoryCredsStore{creds: make(map[string]cred)})
+
+	token, err := creds.getAccessToken()
+	require.NoError(t, err)
+	assert.Equal(t, "access-token-1-gitlab", token)
+	token, err = creds.getAccessToken()
+	require.NoError(t, err)
+	assert.Equal(t, "access-token-2-gitlab", token)
+	assert.Equal(t, 2, *exchanges)
+}
+
+func TestOIDCTokenExchangeCreds_Error(t *testing.T) {
+	oidcTokenExchangeCache = gocache.New(gocache.NoExpiration, 0)
+	server, _ := newOIDCTokenExchangeServer(t, 3600)
+	store := &memoryCredsStore{creds: make(map[string]cred)}
+	creds := newTestOIDCTokenExchangeCreds(t, server.URL, "other-token", store)
+
+	closer, env, err := creds.Environ()
+	require.EqualError(t, err, "failed to exchange OIDC token at "+server.URL+`: unexpected status 401 Unauthorized: {"error":"invalid_grant"}`)
+	assert.Equal(t, NopCloser{}, closer)
+	assert.Nil(t, env)
+
+	creds.tokenPath = path.Join(t.TempDir(), "missing")
+	_, err = creds.getAccessToken()
+	require.ErrorContains(t, err, "failed to read OIDC token of the workload")
+}
+
+// fakeKerberosCommands installs fake kinit and klist commands, klist succeeding if the credential cache exists
+func fakeKerberosCommands(t *testing.T) {
+	t.Helper()
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "klist"), []byte("#!/bin/sh\n[ -f \"$3\" ]\n"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "kinit"), []byte("#!/bin/sh\necho \"$@\" >> \"$5\"\n"), 0o755))
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+}
+
+func TestKerberosCreds_Environ(t *testing.T) {
+	ccache := filepath.Join(t.TempDir(), "krb5cc")
+	t.Setenv(common.EnvGitKerberosCCache, ccache)
+
+	t.Run("WithoutKeytab", func(t *testing.T) {
+		creds := NewKerberosCreds(true)
+
+		closer, env, err := creds.Environ()
+		require.NoError(t, err)
+		defer closer.Close()
+		assert.Equal(t, []string{"KRB5CCNAME=FILE:" + ccache, "GIT_SSL_NO_VERIFY=true"}, env)
+		// the tickets of the credential cache are used as is
+		assert.NoFileExists(t, ccache)
+	})
+
+	t.Run("WithKeytab", func(t *testing.T) {
+		fakeKerberosCommands(t)
+		t.Setenv(common.EnvGitKerberosPrincipal, "argocd@EXAMPLE.COM")
+		t.Setenv(common.EnvGitKerberosKeytab, "/app/config/kerberos/krb5.keytab")
+		creds := NewKerberosCreds(false)
+
+		user, _, err := creds.GetUserInfo(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, "argocd@EXAMPLE.COM", user)
+
+		for i := 0; i < 2; i++ {
+			closer, env, err := creds.Environ()
+			require.NoError(t, err)
+			_ = closer.Close()
+			assert.Equal(t, []string{"KRB5CCNAME=FILE:" + ccache}, env)
+		}
+		// the ticket is only obtained once, while the credential cache holds it
+		kinitArgs, err := os.ReadFile(ccache)
+		require.NoError(t, err)
+		assert.Equal(t, "-k -t /app/config/kerberos/krb5.keytab -c "+ccache+" argocd@EXAMPLE.COM\n", string(kinitArgs))
+	})
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/argoproj/argo-cd/v3/common"
 	appsv1 "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
 	executil "github.com/argoproj/argo-cd/v3/util/exec"
+	"github.com/argoproj/argo-cd/v3/util/fips"
 )
 
 // Regular expression to match public key beginning
@@ -62,6 +63,9 @@ Expire-Date: 6m
 // Canary marker for GNUPGHOME created by Argo CD
 const canaryMarkerFilename = ".argocd-generated"
 
+// Name of the GnuPG configuration file in GNUPGHOME
+const gpgConfFilename = "gpg.conf"
+
 type PGPKeyID string
 
 func isHexString(s string) bool {
@@ -251,6 +255,18 @@ func InitializeGnuPG() error {
 		return fmt.Errorf("could not create canary: %w", err)
 	}
 
+	// In FIPS mode, the signatures made with digest algorithms which aren't approved are rejected
+	if fips.Enabled() {
+		conf := ""
+		for _, digest := range fips.WeakPGPDigests {
+			conf += "weak-digest " + digest + "\n"
+		}
+		err = os.WriteFile(filepath.Join(gnuPgHome, gpgConfFilename), []byte(conf), 0o644)
+		if err != nil {
+			return fmt.Errorf("could not create %s: %w", gpgConfFilename, err)
+		}
+	}
+
 	f, err := os.CreateTemp("", "gpg-key-recipe")
 	if err != nil {
 		return err
@@ -659,6 +675,10 @@ func ParseGitCommitVerification(signature string) PGPVerifyResult {
 					result.Trust = TrustUnknown
 				}
 				result.Message = "Success verifying the commit signature."
+				if result.Result == VerifyResultGood && fips.Enabled() && !fips.IsPGPAlgorithmApproved(result.Cipher) {
+					result.Result = VerifyResultInvalid
+					result.Message = fmt.Sprintf("Signature algorithm %s is not allowed in FIPS mode.", result.Cipher)
+				}
 			}
 
 			// No more data to parse here
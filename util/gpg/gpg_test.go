@@ -6,6 +6,7 @@ import (
 	"os"
 	"os/exec"
 	"path"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -451,6 +452,31 @@ func Test_GPG_ParseGitCommitVerification(t *testing.T) {
 	}
 }
 
+func Test_GPG_InitializeGnuPG_FIPS(t *testing.T) {
+	p := initTempDir(t)
+	t.Setenv(common.EnvFIPSMode, "true")
+
+	err := InitializeGnuPG()
+	require.NoError(t, err)
+	conf, err := os.ReadFile(path.Join(p, "gpg.conf"))
+	require.NoError(t, err)
+	assert.Equal(t, "weak-digest SHA1\nweak-digest RIPEMD160\n", string(conf))
+}
+
+func Test_ParseGitCommitVerification_FIPS(t *testing.T) {
+	t.Setenv(common.EnvFIPSMode, "true")
+
+	c, err := os.ReadFile("testdata/good_signature.txt")
+	require.NoError(t, err)
+	res := ParseGitCommitVerification(string(c))
+	assert.Equal(t, VerifyResultGood, res.Result)
+
+	res = ParseGitCommitVerification(strings.Replace(string(c), "using RSA key", "using DSA key", 1))
+	assert.Equal(t, "DSA", res.Cipher)
+	assert.Equal(t, VerifyResultInvalid, res.Result)
+	assert.Equal(t, "Signature algorithm DSA is not allowed in FIPS mode.", res.Message)
+}
+
 func Test_GetGnuPGHomePath(t *testing.T) {
 	t.Run("empty", func(t *testing.T) {
 		t.Setenv(common.EnvGnuPGHome, "")
@@ -27,6 +27,8 @@ type Cmd struct {
 	IsHelmOci bool
 	proxy     string
 	noProxy   string
+	// binaryPath is the path to the helm binary, which is found in the PATH if empty
+	binaryPath string
 }
 
 func NewCmd(workDir string, version string, proxy string, noProxy string) (*Cmd, error) {
@@ -46,12 +48,19 @@ func NewCmdWithVersion(workDir string, isHelmOci bool, proxy string, noProxy str
 	return &Cmd{WorkDir: workDir, helmHome: tmpDir, IsHelmOci: isHelmOci, proxy: proxy, noProxy: noProxy}, err
 }
 
+func (c Cmd) getBinaryPath() string {
+	if c.binaryPath != "" {
+		return c.binaryPath
+	}
+	return "helm"
+}
+
 var redactor = func(text string) string {
 	return regexp.MustCompile("(--username|--password) [^ ]*").ReplaceAllString(text, "$1 ******")
 }
 
 func (c Cmd) run(args ...string) (string, string, error) {
-	cmd := exec.Command("helm", args...)
+	cmd := exec.Command(c.getBinaryPath(), args...)
 	cmd.Dir = c.WorkDir
 	cmd.Env = os.Environ()
 	if !c.IsLocal {
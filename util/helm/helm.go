@@ -41,13 +41,21 @@ type Helm interface {
 	Dispose()
 }
 
-// NewHelmApp create a new wrapper to run commands on the `helm` command-line tool.
-func NewHelmApp(workDir string, repos []HelmRepository, isLocal bool, version string, proxy string, noProxy string, passCredentials bool) (Helm, error) {
-	cmd, err := NewCmd(workDir, version, proxy, noProxy)
+// NewHelmApp create a new wrapper to run commands on the `helm` command-line tool. The binaryPath is the path to the
+// binary of a Helm version registered in the settings, in which case the version is the name of the registered version.
+func NewHelmApp(workDir string, repos []HelmRepository, isLocal bool, version string, binaryPath string, proxy string, noProxy string, passCredentials bool) (Helm, error) {
+	var cmd *Cmd
+	var err error
+	if binaryPath != "" {
+		cmd, err = NewCmdWithVersion(workDir, false, proxy, noProxy)
+	} else {
+		cmd, err = NewCmd(workDir, version, proxy, noProxy)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to create new helm command: %w", err)
 	}
 	cmd.IsLocal = isLocal
+	cmd.binaryPath = binaryPath
 
 	return &helm{repos: repos, cmd: *cmd, passCredentials: passCredentials}, nil
 }
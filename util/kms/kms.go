@@ -0,0 +1,293 @@
+package kms
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+
+	"filippo.io/age"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/session"
+	awskms "github.com/aws/aws-sdk-go/service/kms"
+	"golang.org/x/oauth2/google"
+
+	"github.com/argoproj/argo-cd/v3/util/workloadidentity"
+)
+
+const (
+	// SchemeAWSKMS is the scheme of the URIs of the AWS KMS keys, e.g. awskms://arn:aws:kms:us-east-1:111122223333:key/1234abcd-12ab-34cd-56ef-1234567890ab
+	SchemeAWSKMS = "awskms"
+	// SchemeGCPKMS is the scheme of the URIs of the GCP KMS keys, e.g. gcpkms://projects/my-project/locations/global/keyRings/argocd/cryptoKeys/secrets
+	SchemeGCPKMS = "gcpkms"
+	// SchemeAzureKeyVault is the scheme of the URIs of the Azure Key Vault keys, e.g. azurekeyvault://my-vault.vault.azure.net/keys/argocd/0123456789abcdef
+	SchemeAzureKeyVault = "azurekeyvault"
+	// SchemeAge is the scheme of the URIs of the local files containing age X25519 identities, e.g. age:///app/config/kms/identity
+	SchemeAge = "age"
+
+	azureKeyVaultScope      = "https://vault.azure.net/.default"
+	azureKeyVaultAPIVersion = "7.4"
+	azureKeyVaultAlgorithm  = "RSA-OAEP-256"
+)
+
+var (
+	gcpKeyNameRegexp       = regexp.MustCompile(`^projects/[^/]+/locations/[^/]+/keyRings/[^/]+/cryptoKeys/[^/]+$`)
+	azureKeyVaultKeyRegexp = regexp.MustCompile(`^[^/]+/keys/[^/]+/[^/]+$`)
+)
+
+// KeyEncrypter encrypts and decrypts the data encryption keys with a key encryption key managed by a KMS
+type KeyEncrypter interface {
+	Encrypt(ctx context.Context, plaintext []byte) ([]byte, error)
+	Decrypt(ctx context.Context, ciphertext []byte) ([]byte, error)
+}
+
+// NewKeyEncrypter returns the KeyEncrypter of the key with the given URI, whose scheme is one of awskms, gcpkms,
+// azurekeyvault or age. The AWS, GCP and Azure keys are used with the workload identity of the component.
+func NewKeyEncrypter(keyURI string) (KeyEncrypter, error) {
+	scheme, key, ok := strings.Cut(keyURI, "://")
+	if !ok || key == "" {
+		return nil, fmt.Errorf("invalid key URI %q, must be <scheme>://<key>", keyURI)
+	}
+	switch scheme {
+	case SchemeAWSKMS:
+		region := ""
+		if arn.IsARN(key) {
+			keyARN, err := arn.Parse(key)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing key ARN %s: %w", key, err)
+			}
+			region = keyARN.Region
+		}
+		return &awsKeyEncrypter{keyID: key, region: region}, nil
+	case SchemeGCPKMS:
+		if !gcpKeyNameRegexp.MatchString(key) {
+			return nil, fmt.Errorf("invalid GCP KMS key %q, must be projects/<project>/locations/<location>/keyRings/<key ring>/cryptoKeys/<key>", key)
+		}
+		return &gcpKeyEncrypter{
+			keyName:  key,
+			endpoint: "https://cloudkms.googleapis.com/v1/",
+			client: func(ctx context.Context) (*http.Client, error) {
+				return google.DefaultClient(ctx, "https://www.googleapis.com/auth/cloudkms")
+			},
+		}, nil
+	case SchemeAzureKeyVault:
+		if !azureKeyVaultKeyRegexp.MatchString(key) {
+			return nil, fmt.Errorf("invalid Azure Key Vault key %q, must be <vault host>/keys/<key>/<version>", key)
+		}
+		return &azureKeyEncrypter{keyURL: "https://" + key}, nil
+	case SchemeAge:
+		data, err := os.ReadFile(key)
+		if err != nil {
+			return nil, fmt.Errorf("error reading identity file %s: %w", key, err)
+		}
+		identities, err := age.ParseIdentities(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("error parsing identity file %s: %w", key, err)
+		}
+		var recipients []age.Recipient
+		for _, identity := range identities {
+			if x25519, ok := identity.(*age.X25519Identity); ok {
+				recipients = append(recipients, x25519.Recipient())
+			}
+		}
+		if len(recipients) == 0 {
+			return nil, fmt.Errorf("identity file %s must contain an age X25519 identity", key)
+		}
+		return &ageKeyEncrypter{identities: identities, recipients: recipients}, nil
+	}
+	return nil, fmt.Errorf("unsupported key URI scheme %q, must be one of %s, %s, %s or %s", scheme, SchemeAWSKMS, SchemeGCPKMS, SchemeAzureKeyVault, SchemeAge)
+}
+
+type awsKeyEncrypter struct {
+	keyID  string
+	region string
+
+	lock   sync.Mutex
+	client *awskms.KMS
+}
+
+func (e *awsKeyEncrypter) getClient() (*awskms.KMS, error) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	if e.client == nil {
+		config := &aws.Config{}
+		if e.region != "" {
+			config.Region = aws.String(e.region)
+		}
+		sess, err := session.NewSession(config)
+		if err != nil {
+			return nil, fmt.Errorf("error creating new AWS session: %w", err)
+		}
+		e.client = awskms.New(sess)
+	}
+	return e.client, nil
+}
+
+func (e *awsKeyEncrypter) Encrypt(ctx context.Context, plaintext []byte) ([]byte, error) {
+	client, err := e.getClient()
+	if err != nil {
+		return nil, err
+	}
+	out, err := client.EncryptWithContext(ctx, &awskms.EncryptInput{KeyId: aws.String(e.keyID), Plaintext: plaintext})
+	if err != nil {
+		return nil, fmt.Errorf("error encrypting with AWS KMS key %s: %w", e.keyID, err)
+	}
+	return out.CiphertextBlob, nil
+}
+
+func (e *awsKeyEncrypter) Decrypt(ctx context.Context, ciphertext []byte) ([]byte, error) {
+	client, err := e.getClient()
+	if err != nil {
+		return nil, err
+	}
+	out, err := client.DecryptWithContext(ctx, &awskms.DecryptInput{KeyId: aws.String(e.keyID), CiphertextBlob: ciphertext})
+	if err != nil {
+		return nil, fmt.Errorf("error decrypting with AWS KMS key %s: %w", e.keyID, err)
+	}
+	return out.Plaintext, nil
+}
+
+type gcpKeyEncrypter struct {
+	keyName  string
+	endpoint string
+	client   func(ctx context.Context) (*http.Client, error)
+}
+
+func (e *gcpKeyEncrypter) Encrypt(ctx context.Context, plaintext []byte) ([]byte, error) {
+	var res struct {
+		Ciphertext []byte `json:"ciphertext"`
+	}
+	if err := e.call(ctx, "encrypt", map[string][]byte{"plaintext": plaintext}, &res); err != nil {
+		return nil, fmt.Errorf("error encrypting with GCP KMS key %s: %w", e.keyName, err)
+	}
+	return res.Ciphertext, nil
+}
+
+func (e *gcpKeyEncrypter) Decrypt(ctx context.Context, ciphertext []byte) ([]byte, error) {
+	var res struct {
+		Plaintext []byte `json:"plaintext"`
+	}
+	if err := e.call(ctx, "decrypt", map[string][]byte{"ciphertext": ciphertext}, &res); err != nil {
+		return nil, fmt.Errorf("error decrypting with GCP KMS key %s: %w", e.keyName, err)
+	}
+	return res.Plaintext, nil
+}
+
+func (e *gcpKeyEncrypter) call(ctx context.Context, method string, req any, res any) error {
+	client, err := e.client(ctx)
+	if err != nil {
+		return fmt.Errorf("error finding GCP default credentials: %w", err)
+	}
+	return postJSON(ctx, client, e.endpoint+e.keyName+":"+method, nil, req, res)
+}
+
+type azureKeyEncrypter struct {
+	keyURL        string
+	tokenProvider workloadidentity.TokenProvider
+}
+
+func (e *azureKeyEncrypter) Encrypt(ctx context.Context, plaintext []byte) ([]byte, error) {
+	value, err := e.call(ctx, "wrapkey", plaintext)
+	if err != nil {
+		return nil, fmt.Errorf("error wrapping with Azure Key Vault key %s: %w", e.keyURL, err)
+	}
+	return value, nil
+}
+
+func (e *azureKeyEncrypter) Decrypt(ctx context.Context, ciphertext []byte) ([]byte, error) {
+	value, err := e.call(ctx, "unwrapkey", ciphertext)
+	if err != nil {
+		return nil, fmt.Errorf("error unwrapping with Azure Key Vault key %s: %w", e.keyURL, err)
+	}
+	return value, nil
+}
+
+func (e *azureKeyEncrypter) call(ctx context.Context, operation string, value []byte) ([]byte, error) {
+	tp := e.tokenProvider
+	if tp == nil {
+		tp = workloadidentity.NewWorkloadIdentityTokenProvider()
+	}
+	token, err := tp.GetToken(azureKeyVaultScope)
+	if err != nil {
+		return nil, fmt.Errorf("error getting Azure workload identity token: %w", err)
+	}
+	req := map[string]string{"alg": azureKeyVaultAlgorithm, "value": base64.RawURLEncoding.EncodeToString(value)}
+	var res struct {
+		Value string `json:"value"`
+	}
+	url := e.keyURL + "/" + operation + "?api-version=" + azureKeyVaultAPIVersion
+	header := http.Header{"Authorization": []string{"Bearer " + token.AccessToken}}
+	if err := postJSON(ctx, http.DefaultClient, url, header, req, &res); err != nil {
+		return nil, err
+	}
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(res.Value, "="))
+}
+
+// ageKeyEncrypter encrypts the data keys to the recipients of its identities, so that any of them decrypts them
+type ageKeyEncrypter struct {
+	identities []age.Identity
+	recipients []age.Recipient
+}
+
+func (e *ageKeyEncrypter) Encrypt(_ context.Context, plaintext []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	w, err := age.Encrypt(&buf, e.recipients...)
+	if err != nil {
+		return nil, fmt.Errorf("error encrypting with age: %w", err)
+	}
+	if _, err := w.Write(plaintext); err != nil {
+		return nil, fmt.Errorf("error encrypting with age: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return nil, fmt.Errorf("error encrypting with age: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func (e *ageKeyEncrypter) Decrypt(_ context.Context, ciphertext []byte) ([]byte, error) {
+	r, err := age.Decrypt(bytes.NewReader(ciphertext), e.identities...)
+	if err != nil {
+		return nil, fmt.Errorf("error decrypting with age: %w", err)
+	}
+	plaintext, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("error decrypting with age: %w", err)
+	}
+	return plaintext, nil
+}
+
+func postJSON(ctx context.Context, client *http.Client, url string, header http.Header, req any, res any) error {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	for k, values := range header {
+		httpReq.Header[k] = values
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(httpReq)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s: %s", resp.Status, data)
+	}
+	return json.Unmarshal(data, res)
+}
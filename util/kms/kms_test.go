@@ -0,0 +1,143 @@
+package kms
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"filippo.io/age"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/argoproj/argo-cd/v3/util/workloadidentity"
+	"github.com/argoproj/argo-cd/v3/util/workloadidentity/mocks"
+)
+
+func TestNewKeyEncrypter(t *testing.T) {
+	e, err := NewKeyEncrypter("awskms://arn:aws:kms:eu-west-1:111122223333:key/1234abcd")
+	require.NoError(t, err)
+	assert.Equal(t, "eu-west-1", e.(*awsKeyEncrypter).region)
+
+	e, err = NewKeyEncrypter("awskms://alias/argocd")
+	require.NoError(t, err)
+	assert.Empty(t, e.(*awsKeyEncrypter).region)
+
+	_, err = NewKeyEncrypter("gcpkms://projects/p/locations/global/keyRings/r/cryptoKeys/k")
+	require.NoError(t, err)
+	_, err = NewKeyEncrypter("azurekeyvault://vault.vault.azure.net/keys/argocd/0123")
+	require.NoError(t, err)
+
+	invalidURIs := map[string]string{
+		"argocd":                            `invalid key URI "argocd", must be <scheme>://<key>`,
+		"vault://secret/argocd":             `unsupported key URI scheme "vault", must be one of awskms, gcpkms, azurekeyvault or age`,
+		"gcpkms://projects/p/keyRings/r":    `invalid GCP KMS key "projects/p/keyRings/r", must be projects/<project>/locations/<location>/keyRings/<key ring>/cryptoKeys/<key>`,
+		"azurekeyvault://vault/keys/argocd": `invalid Azure Key Vault key "vault/keys/argocd", must be <vault host>/keys/<key>/<version>`,
+		"age:///does/not/exist/argocd.key":  "error reading identity file /does/not/exist/argocd.key",
+	}
+	for uri, expectedErr := range invalidURIs {
+		_, err := NewKeyEncrypter(uri)
+		require.ErrorContains(t, err, expectedErr)
+	}
+}
+
+func TestAgeKeyEncrypter(t *testing.T) {
+	dir := t.TempDir()
+	writeIdentity := func(name string) string {
+		identity, err := age.GenerateX25519Identity()
+		require.NoError(t, err)
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte("# public key: "+identity.Recipient().String()+"\n"+identity.String()+"\n"), 0o600))
+		return path
+	}
+	keyPath := writeIdentity("key")
+	otherKeyPath := writeIdentity("other")
+	invalidKeyPath := filepath.Join(dir, "invalid")
+	require.NoError(t, os.WriteFile(invalidKeyPath, []byte("not an identity"), 0o600))
+
+	e, err := NewKeyEncrypter("age://" + keyPath)
+	require.NoError(t, err)
+	ciphertext, err := e.Encrypt(t.Context(), []byte("data key"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(ciphertext), "data key")
+	plaintext, err := e.Decrypt(t.Context(), ciphertext)
+	require.NoError(t, err)
+	assert.Equal(t, "data key", string(plaintext))
+
+	other, err := NewKeyEncrypter("age://" + otherKeyPath)
+	require.NoError(t, err)
+	_, err = other.Decrypt(t.Context(), ciphertext)
+	require.ErrorContains(t, err, "error decrypting with age")
+
+	_, err = NewKeyEncrypter("age://" + invalidKeyPath)
+	require.ErrorContains(t, err, "error parsing identity file "+invalidKeyPath)
+}
+
+func TestGCPKeyEncrypter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string][]byte
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		switch r.URL.Path {
+		case "/projects/p/locations/global/keyRings/r/cryptoKeys/k:encrypt":
+			_ = json.NewEncoder(w).Encode(map[string][]byte{"ciphertext": append([]byte("wrapped:"), req["plaintext"]...)})
+		case "/projects/p/locations/global/keyRings/r/cryptoKeys/k:decrypt":
+			_ = json.NewEncoder(w).Encode(map[string][]byte{"plaintext": req["ciphertext"][len("wrapped:"):]})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	e := &gcpKeyEncrypter{
+		keyName:  "projects/p/locations/global/keyRings/r/cryptoKeys/k",
+		endpoint: server.URL + "/",
+		client: func(_ context.Context) (*http.Client, error) {
+			return server.Client(), nil
+		},
+	}
+	ciphertext, err := e.Encrypt(t.Context(), []byte("data key"))
+	require.NoError(t, err)
+	assert.Equal(t, "wrapped:data key", string(ciphertext))
+	plaintext, err := e.Decrypt(t.Context(), ciphertext)
+	require.NoError(t, err)
+	assert.Equal(t, "data key", string(plaintext))
+
+	e.keyName = "projects/p/locations/global/keyRings/r/cryptoKeys/missing"
+	_, err = e.Encrypt(t.Context(), []byte("data key"))
+	require.ErrorContains(t, err, "error encrypting with GCP KMS key projects/p/locations/global/keyRings/r/cryptoKeys/missing: unexpected status 404 Not Found")
+}
+
+func TestAzureKeyEncrypter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer azure-token", r.Header.Get("Authorization"))
+		assert.Equal(t, azureKeyVaultAPIVersion, r.URL.Query().Get("api-version"))
+		var req map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, azureKeyVaultAlgorithm, req["alg"])
+		value, err := base64.RawURLEncoding.DecodeString(req["value"])
+		require.NoError(t, err)
+		switch r.URL.Path {
+		case "/keys/argocd/0123/wrapkey":
+			value = append([]byte("wrapped:"), value...)
+		case "/keys/argocd/0123/unwrapkey":
+			value = value[len("wrapped:"):]
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{"kid": "argocd", "value": base64.RawURLEncoding.EncodeToString(value)})
+	}))
+	defer server.Close()
+
+	tokenProvider := &mocks.TokenProvider{}
+	tokenProvider.EXPECT().GetToken(azureKeyVaultScope).Return(&workloadidentity.Token{AccessToken: "azure-token", ExpiresOn: time.Now().Add(time.Hour)}, nil)
+	e := &azureKeyEncrypter{keyURL: server.URL + "/keys/argocd/0123", tokenProvider: tokenProvider}
+	ciphertext, err := e.Encrypt(t.Context(), []byte("data key"))
+	require.NoError(t, err)
+	assert.Equal(t, "wrapped:data key", string(ciphertext))
+	plaintext, err := e.Decrypt(t.Context(), ciphertext)
+	require.NoError(t, err)
+	assert.Equal(t, "data key", string(plaintext))
+}
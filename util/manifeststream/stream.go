@@ -38,9 +38,10 @@ type RepoStreamReceiver interface {
 	Recv() (*apiclient.ManifestRequestWithFiles, error)
 }
 
-// SendApplicationManifestQueryWithFiles compresses a folder and sends it over the stream
-func SendApplicationManifestQueryWithFiles(ctx context.Context, stream ApplicationStreamSender, appName string, appNs string, dir string, inclusions []string) error {
-	f, filesWritten, checksum, err := tgzstream.CompressFiles(dir, inclusions, nil)
+// SendApplicationManifestQueryWithFiles compresses a folder and sends it over the stream, as the files of the source of
+// the application at the given 1-based position, or of its first source if the position is 0
+func SendApplicationManifestQueryWithFiles(ctx context.Context, stream ApplicationStreamSender, appName string, appNs string, dir string, inclusions []string, exclusions []string, sourcePosition int64) error {
+	f, filesWritten, checksum, err := tgzstream.CompressFiles(dir, inclusions, exclusions)
 	if err != nil {
 		return fmt.Errorf("failed to compress files: %w", err)
 	}
@@ -51,9 +52,10 @@ func SendApplicationManifestQueryWithFiles(ctx context.Context, stream Applicati
 	err = stream.Send(&applicationpkg.ApplicationManifestQueryWithFilesWrapper{
 		Part: &applicationpkg.ApplicationManifestQueryWithFilesWrapper_Query{
 			Query: &applicationpkg.ApplicationManifestQueryWithFiles{
-				Name:         &appName,
-				Checksum:     &checksum,
-				AppNamespace: &appNs,
+				Name:           &appName,
+				Checksum:       &checksum,
+				AppNamespace:   &appNs,
+				SourcePosition: &sourcePosition,
 			},
 		},
 	})
@@ -88,7 +88,7 @@ func TestManifestStream(t *testing.T) {
 	appDir := filepath.Join(getTestDataDir(t), "app")
 
 	go func() {
-		err := manifeststream.SendApplicationManifestQueryWithFiles(t.Context(), appStreamMock, "test", "test", appDir, nil)
+		err := manifeststream.SendApplicationManifestQueryWithFiles(t.Context(), appStreamMock, "test", "test", appDir, nil, []string{".git"}, 2)
 		assert.NoError(t, err)
 		appStreamMock.done <- true
 	}()
@@ -96,6 +96,7 @@ func TestManifestStream(t *testing.T) {
 	query, err := manifeststream.ReceiveApplicationManifestQueryWithFiles(appStreamMock)
 	require.NoError(t, err)
 	require.NotNil(t, query)
+	assert.Equal(t, int64(2), query.GetSourcePosition())
 
 	req := &apiclient.ManifestRequest{}
 
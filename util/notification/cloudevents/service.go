@@ -0,0 +1,196 @@
+package cloudevents
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/argoproj/notifications-engine/pkg/services"
+	"github.com/google/uuid"
+)
+
+const (
+	// ServiceType is the type of the CloudEvents service, configured using service.cloudevents keys
+	ServiceType = "cloudevents"
+
+	// ModeStructured sends the whole event as the JSON body of the request
+	ModeStructured = "structured"
+	// ModeBinary sends the attributes of the event as ce- headers and its data as the body of the request
+	ModeBinary = "binary"
+
+	specVersion                 = "1.0"
+	defaultSource               = "argocd"
+	defaultType                 = "io.argoproj.argocd.notification.v1"
+	structuredContentType       = "application/cloudevents+json"
+	dataContentType             = "application/json"
+	requestTimeout              = 30 * time.Second
+	binaryAttributeHeaderPrefix = "ce-"
+)
+
+// Options configures the CloudEvents service
+type Options struct {
+	// RecipientURLs maps recipients to the URLs of the HTTP sinks the events are sent to
+	RecipientURLs map[string]string `json:"recipientUrls"`
+	// Source is the default source attribute of the events
+	Source string `json:"source,omitempty"`
+	// Mode is the content mode of the events, either structured or binary, defaults to structured
+	Mode string `json:"mode,omitempty"`
+	// Headers are added to the requests, e.g. to authenticate to the sink
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+// eventNotification holds the attributes and the data of an event, templated as the JSON body of the cloudevents
+// webhook of a notification template
+type eventNotification struct {
+	Type       string          `json:"type,omitempty"`
+	Source     string          `json:"source,omitempty"`
+	Subject    string          `json:"subject,omitempty"`
+	DataSchema string          `json:"dataschema,omitempty"`
+	Data       json.RawMessage `json:"data,omitempty"`
+}
+
+// event is a CloudEvents 1.0 event in the JSON format
+type event struct {
+	SpecVersion     string          `json:"specversion"`
+	ID              string          `json:"id"`
+	Source          string          `json:"source"`
+	Type            string          `json:"type"`
+	Subject         string          `json:"subject,omitempty"`
+	Time            string          `json:"time"`
+	DataSchema      string          `json:"dataschema,omitempty"`
+	DataContentType string          `json:"datacontenttype"`
+	Data            json.RawMessage `json:"data"`
+}
+
+type service struct {
+	opts   Options
+	client *http.Client
+	now    func() time.Time
+}
+
+// NewService returns a notification service which sends notifications as CloudEvents 1.0 to HTTP sinks, e.g. Knative
+// brokers or event bus proxies
+func NewService(opts Options) (services.NotificationService, error) {
+	if opts.Source == "" {
+		opts.Source = defaultSource
+	}
+	if opts.Mode == "" {
+		opts.Mode = ModeStructured
+	}
+	if opts.Mode != ModeStructured && opts.Mode != ModeBinary {
+		return nil, fmt.Errorf("invalid mode '%s', must be either %s or %s", opts.Mode, ModeStructured, ModeBinary)
+	}
+	return &service{opts: opts, client: &http.Client{Timeout: requestTimeout}, now: time.Now}, nil
+}
+
+// newEvent returns the event of a notification. The attributes and the data of the event are read from the body of the
+// cloudevents webhook of the notification if set, which must be a JSON object, and the data defaults to an object
+// holding the message of the notification.
+func (s *service) newEvent(notification services.Notification) (*event, error) {
+	n := &eventNotification{}
+	// the webhook bodies are the only free-form fields of notifications templated by the engine
+	if webhook, ok := notification.Webhook[ServiceType]; ok && strings.TrimSpace(webhook.Body) != "" {
+		if err := json.Unmarshal([]byte(webhook.Body), n); err != nil {
+			return nil, fmt.Errorf("cloudevents webhook body is not a valid JSON object: %w", err)
+		}
+	}
+
+	ev := &event{
+		SpecVersion:     specVersion,
+		ID:              uuid.NewString(),
+		Source:          s.opts.Source,
+		Type:            defaultType,
+		Subject:         n.Subject,
+		Time:            s.now().UTC().Format(time.RFC3339),
+		DataSchema:      n.DataSchema,
+		DataContentType: dataContentType,
+	}
+	if n.Source != "" {
+		ev.Source = n.Source
+	}
+	if n.Type != "" {
+		ev.Type = n.Type
+	}
+	if len(n.Data) > 0 {
+		ev.Data = n.Data
+	} else {
+		data, err := json.Marshal(map[string]string{"message": notification.Message})
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal event data: %w", err)
+		}
+		ev.Data = data
+	}
+	return ev, nil
+}
+
+// newRequest returns the request sending an event to a sink using the content mode of the service
+func (s *service) newRequest(ctx context.Context, ev *event, sinkURL string) (*http.Request, error) {
+	var body []byte
+	var contentType string
+	if s.opts.Mode == ModeBinary {
+		body, contentType = ev.Data, ev.DataContentType
+	} else {
+		data, err := json.Marshal(ev)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal CloudEvent: %w", err)
+		}
+		body, contentType = data, structuredContentType
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sinkURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CloudEvents request: %w", err)
+	}
+	for k, v := range s.opts.Headers {
+		req.Header.Set(k, v)
+	}
+	req.Header.Set("Content-Type", contentType)
+	if s.opts.Mode == ModeBinary {
+		attributes := map[string]string{
+			"specversion": ev.SpecVersion,
+			"id":          ev.ID,
+			"source":      ev.Source,
+			"type":        ev.Type,
+			"subject":     ev.Subject,
+			"time":        ev.Time,
+			"dataschema":  ev.DataSchema,
+		}
+		for k, v := range attributes {
+			if v != "" {
+				req.Header.Set(binaryAttributeHeaderPrefix+k, v)
+			}
+		}
+	}
+	return req, nil
+}
+
+func (s *service) Send(notification services.Notification, dest services.Destination) error {
+	sinkURL, ok := s.opts.RecipientURLs[dest.Recipient]
+	if !ok {
+		return fmt.Errorf("no CloudEvents sink URL configured for recipient %s", dest.Recipient)
+	}
+	ev, err := s.newEvent(notification)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	req, err := s.newRequest(ctx, ev, sinkURL)
+	if err != nil {
+		return err
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send CloudEvent: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("event rejected by CloudEvents sink with status %d: %s", resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package cloudevents
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/argoproj/notifications-engine/pkg/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newNotification(t *testing.T, data string) services.Notification {
+	t.Helper()
+	var notification services.Notification
+	require.NoError(t, json.Unmarshal([]byte(data), &notification))
+	return notification
+}
+
+func newTestService(t *testing.T, opts Options) *service {
+	t.Helper()
+	s, err := NewService(opts)
+	require.NoError(t, err)
+	res := s.(*service)
+	res.now = func() time.Time { return time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC) }
+	return res
+}
+
+func TestNewService_InvalidMode(t *testing.T) {
+	_, err := NewService(Options{Mode: "batched"})
+	require.EqualError(t, err, "invalid mode 'batched', must be either structured or binary")
+}
+
+func TestNewEvent(t *testing.T) {
+	s := newTestService(t, Options{})
+	t.Run("Message", func(t *testing.T) {
+		ev, err := s.newEvent(newNotification(t, `{"message": "Application guestbook has been synced."}`))
+		require.NoError(t, err)
+		assert.Equal(t, specVersion, ev.SpecVersion)
+		assert.NotEmpty(t, ev.ID)
+		assert.Equal(t, defaultSource, ev.Source)
+		assert.Equal(t, defaultType, ev.Type)
+		assert.Equal(t, "2024-01-02T03:04:05Z", ev.Time)
+		assert.JSONEq(t, `{"message": "Application guestbook has been synced."}`, string(ev.Data))
+	})
+	t.Run("CloudEventsFields", func(t *testing.T) {
+		ev, err := s.newEvent(newNotification(t, `{"webhook": {"cloudevents": {"body": "{\"type\": \"io.argoproj.argocd.app.sync.succeeded.v1\", \"source\": \"https://argocd.example.com\", \"subject\": \"argocd/guestbook\", \"dataschema\": \"https://example.com/schemas/app-sync.v1.json\", \"data\": {\"app\": \"guestbook\", \"revision\": \"abc123\"}}"}}}`))
+		require.NoError(t, err)
+		assert.Equal(t, "io.argoproj.argocd.app.sync.succeeded.v1", ev.Type)
+		assert.Equal(t, "https://argocd.example.com", ev.Source)
+		assert.Equal(t, "argocd/guestbook", ev.Subject)
+		assert.Equal(t, "https://example.com/schemas/app-sync.v1.json", ev.DataSchema)
+		assert.JSONEq(t, `{"app": "guestbook", "revision": "abc123"}`, string(ev.Data))
+	})
+	t.Run("OtherWebhook", func(t *testing.T) {
+		ev, err := s.newEvent(newNotification(t, `{"message": "synced", "webhook": {"github": {"body": "{\"type\": \"other\"}"}}}`))
+		require.NoError(t, err)
+		assert.Equal(t, defaultType, ev.Type)
+		assert.JSONEq(t, `{"message": "synced"}`, string(ev.Data))
+	})
+	t.Run("InvalidBody", func(t *testing.T) {
+		_, err := s.newEvent(newNotification(t, `{"webhook": {"cloudevents": {"body": "{\"data\": not-json}"}}}`))
+		require.ErrorContains(t, err, "cloudevents webhook body is not a valid JSON object")
+	})
+}
+
+func TestService_Send(t *testing.T) {
+	var received *http.Request
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var err error
+		received = r
+		body, err = io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+	notification := newNotification(t, `{"webhook": {"cloudevents": {"body": "{\"type\": \"io.argoproj.argocd.app.deployed.v1\", \"data\": {\"app\": \"guestbook\"}}"}}}`)
+
+	t.Run("Structured", func(t *testing.T) {
+		s := newTestService(t, Options{RecipientURLs: map[string]string{"broker": server.URL}, Headers: map[string]string{"Authorization": "Bearer token"}})
+		err := s.Send(notification, services.Destination{Service: ServiceType, Recipient: "broker"})
+		require.NoError(t, err)
+		assert.Equal(t, structuredContentType, received.Header.Get("Content-Type"))
+		assert.Equal(t, "Bearer token", received.Header.Get("Authorization"))
+		var ev event
+		require.NoError(t, json.Unmarshal(body, &ev))
+		assert.Equal(t, "io.argoproj.argocd.app.deployed.v1", ev.Type)
+		assert.JSONEq(t, `{"app": "guestbook"}`, string(ev.Data))
+	})
+	t.Run("Binary", func(t *testing.T) {
+		s := newTestService(t, Options{RecipientURLs: map[string]string{"broker": server.URL}, Mode: ModeBinary})
+		err := s.Send(notification, services.Destination{Service: ServiceType, Recipient: "broker"})
+		require.NoError(t, err)
+		assert.Equal(t, dataContentType, received.Header.Get("Content-Type"))
+		assert.Equal(t, specVersion, received.Header.Get("ce-specversion"))
+		assert.Equal(t, "io.argoproj.argocd.app.deployed.v1", received.Header.Get("ce-type"))
+		assert.Equal(t, defaultSource, received.Header.Get("ce-source"))
+		assert.Empty(t, received.Header.Get("ce-subject"))
+		assert.JSONEq(t, `{"app": "guestbook"}`, string(body))
+	})
+	t.Run("UnknownRecipient", func(t *testing.T) {
+		s := newTestService(t, Options{})
+		err := s.Send(notification, services.Destination{Service: ServiceType, Recipient: "other"})
+		require.EqualError(t, err, "no CloudEvents sink URL configured for recipient other")
+	})
+}
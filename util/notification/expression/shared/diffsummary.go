@@ -0,0 +1,66 @@
+package shared
+
+import (
+	"sort"
+
+	"github.com/argoproj/gitops-engine/pkg/health"
+
+	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+)
+
+// maxTopChangedKinds is the number of kinds listed in the top changed kinds of a diff summary
+const maxTopChangedKinds = 5
+
+// KindCount is the number of differing resources of a kind
+type KindCount struct {
+	Kind  string `json:"kind"`
+	Count int    `json:"count"`
+}
+
+// DiffSummary summarizes the differences between the desired and the live state found by the latest comparison of
+// an application
+type DiffSummary struct {
+	// Number of resources which do not exist yet and will be created
+	Created int `json:"created"`
+	// Number of existing resources which differ from their desired state
+	Changed int `json:"changed"`
+	// Number of resources which are no longer desired and will be pruned
+	Pruned int `json:"pruned"`
+	// Kinds with the most differing resources, in descending order
+	TopChangedKinds []KindCount `json:"topChangedKinds"`
+}
+
+// NewDiffSummary returns the diff summary of the resource statuses of an application. Hooks are not part of the
+// desired state and are ignored.
+func NewDiffSummary(resources []v1alpha1.ResourceStatus) DiffSummary {
+	summary := DiffSummary{TopChangedKinds: []KindCount{}}
+	kindCounts := map[string]int{}
+	for _, res := range resources {
+		switch {
+		case res.Hook:
+			continue
+		case res.RequiresPruning:
+			summary.Pruned++
+		case res.Status != v1alpha1.SyncStatusCodeOutOfSync:
+			continue
+		case res.Health != nil && res.Health.Status == health.HealthStatusMissing:
+			summary.Created++
+		default:
+			summary.Changed++
+		}
+		kindCounts[res.Kind]++
+	}
+	for kind, count := range kindCounts {
+		summary.TopChangedKinds = append(summary.TopChangedKinds, KindCount{Kind: kind, Count: count})
+	}
+	sort.Slice(summary.TopChangedKinds, func(i, j int) bool {
+		if summary.TopChangedKinds[i].Count != summary.TopChangedKinds[j].Count {
+			return summary.TopChangedKinds[i].Count > summary.TopChangedKinds[j].Count
+		}
+		return summary.TopChangedKinds[i].Kind < summary.TopChangedKinds[j].Kind
+	})
+	if len(summary.TopChangedKinds) > maxTopChangedKinds {
+		summary.TopChangedKinds = summary.TopChangedKinds[:maxTopChangedKinds]
+	}
+	return summary
+}
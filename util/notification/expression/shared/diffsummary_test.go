@@ -0,0 +1,46 @@
+package shared
+
+import (
+	"testing"
+
+	"github.com/argoproj/gitops-engine/pkg/health"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+)
+
+func TestNewDiffSummary(t *testing.T) {
+	t.Run("NoResources", func(t *testing.T) {
+		assert.Equal(t, DiffSummary{TopChangedKinds: []KindCount{}}, NewDiffSummary(nil))
+	})
+	t.Run("CountsDifferences", func(t *testing.T) {
+		summary := NewDiffSummary([]v1alpha1.ResourceStatus{
+			{Kind: "Deployment", Name: "a", Status: v1alpha1.SyncStatusCodeOutOfSync},
+			{Kind: "Deployment", Name: "b", Status: v1alpha1.SyncStatusCodeOutOfSync, Health: &v1alpha1.HealthStatus{Status: health.HealthStatusMissing}},
+			{Kind: "Service", Name: "a", Status: v1alpha1.SyncStatusCodeOutOfSync, Health: &v1alpha1.HealthStatus{Status: health.HealthStatusHealthy}},
+			{Kind: "ConfigMap", Name: "a", Status: v1alpha1.SyncStatusCodeOutOfSync, RequiresPruning: true},
+			{Kind: "Job", Name: "a", Status: v1alpha1.SyncStatusCodeOutOfSync, Hook: true},
+			{Kind: "Secret", Name: "a", Status: v1alpha1.SyncStatusCodeSynced},
+		})
+		assert.Equal(t, DiffSummary{
+			Created: 1,
+			Changed: 2,
+			Pruned:  1,
+			TopChangedKinds: []KindCount{
+				{Kind: "Deployment", Count: 2},
+				{Kind: "ConfigMap", Count: 1},
+				{Kind: "Service", Count: 1},
+			},
+		}, summary)
+	})
+	t.Run("LimitsTopChangedKinds", func(t *testing.T) {
+		var resources []v1alpha1.ResourceStatus
+		for _, kind := range []string{"A", "B", "C", "D", "E", "F"} {
+			resources = append(resources, v1alpha1.ResourceStatus{Kind: kind, Status: v1alpha1.SyncStatusCodeOutOfSync})
+		}
+		summary := NewDiffSummary(resources)
+		assert.Equal(t, 6, summary.Changed)
+		assert.Len(t, summary.TopChangedKinds, maxTopChangedKinds)
+		assert.Equal(t, "A", summary.TopChangedKinds[0].Kind)
+	})
+}
@@ -0,0 +1,108 @@
+package shared
+
+import (
+	"time"
+
+	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+)
+
+const (
+	syncWindowKindDeny = "deny"
+	// denyExitedPeriod is how long after the end of a deny window the application is considered to have exited it
+	denyExitedPeriod = time.Hour
+)
+
+// ActiveSyncWindow is a sync window which currently applies to an application
+type ActiveSyncWindow struct {
+	Kind        string `json:"kind"`
+	Schedule    string `json:"schedule"`
+	Duration    string `json:"duration"`
+	TimeZone    string `json:"timeZone,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// SyncWindowsState is the state of the sync windows of a project which match an application
+type SyncWindowsState struct {
+	// Whether an automated sync is currently allowed by the sync windows
+	CanSync bool `json:"canSync"`
+	// Whether a deny window matches the application, whether it is active or not
+	HasDeny bool `json:"hasDeny"`
+	// Whether an active deny window currently prevents syncs of the application
+	ActiveDeny bool `json:"activeDeny"`
+	// Sync windows which are currently active
+	Active []ActiveSyncWindow `json:"active"`
+	// End of the last deny window the application was in, if it ended within the last hour and no deny window is
+	// active anymore
+	DenyExitedAt string `json:"denyExitedAt"`
+	// Error found evaluating the sync windows, which then prevent syncs
+	Error string `json:"error,omitempty"`
+}
+
+// NewSyncWindowsState returns the state of the sync windows of the project of an application. An application without
+// project has no sync windows.
+func NewSyncWindowsState(app *v1alpha1.Application, proj *v1alpha1.AppProject) SyncWindowsState {
+	return newSyncWindowsState(app, proj, time.Now())
+}
+
+func newSyncWindowsState(app *v1alpha1.Application, proj *v1alpha1.AppProject, now time.Time) SyncWindowsState {
+	state := SyncWindowsState{CanSync: true, Active: []ActiveSyncWindow{}}
+	if proj == nil {
+		return state
+	}
+	windows := proj.Spec.SyncWindows.Matches(app)
+	if !windows.HasWindows() {
+		return state
+	}
+	for _, w := range *windows {
+		if w.Kind == syncWindowKindDeny {
+			state.HasDeny = true
+		}
+	}
+	canSync, err := windows.CanSync(false)
+	state.CanSync = canSync
+	if err != nil {
+		state.Error = err.Error()
+		return state
+	}
+	active, err := windows.Active()
+	if err != nil {
+		state.Error = err.Error()
+		return state
+	}
+	if active != nil {
+		for _, w := range *active {
+			if w.Kind == syncWindowKindDeny {
+				state.ActiveDeny = true
+			}
+			state.Active = append(state.Active, ActiveSyncWindow{
+				Kind:        w.Kind,
+				Schedule:    w.Schedule,
+				Duration:    w.Duration,
+				TimeZone:    w.TimeZone,
+				Description: w.Description,
+			})
+		}
+	}
+	if state.ActiveDeny {
+		return state
+	}
+	var denyExitedAt *time.Time
+	for _, w := range *windows {
+		if w.Kind != syncWindowKindDeny {
+			continue
+		}
+		end, err := w.LastEnd(now, denyExitedPeriod)
+		if err != nil {
+			state.Error = err.Error()
+			return state
+		}
+		// the application was in the window only if it already existed when the window ended
+		if end != nil && app.CreationTimestamp.Time.Before(*end) && (denyExitedAt == nil || end.After(*denyExitedAt)) {
+			denyExitedAt = end
+		}
+	}
+	if denyExitedAt != nil {
+		state.DenyExitedAt = denyExitedAt.UTC().Format(time.RFC3339)
+	}
+	return state
+}
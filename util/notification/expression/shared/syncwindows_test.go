@@ -0,0 +1,76 @@
+package shared
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+)
+
+func TestNewSyncWindowsState(t *testing.T) {
+	app := &v1alpha1.Application{
+		ObjectMeta: metav1.ObjectMeta{Name: "guestbook"},
+		Spec:       v1alpha1.ApplicationSpec{Destination: v1alpha1.ApplicationDestination{Namespace: "default", Server: "https://kubernetes.default.svc"}},
+	}
+	newProject := func(windows ...*v1alpha1.SyncWindow) *v1alpha1.AppProject {
+		return &v1alpha1.AppProject{Spec: v1alpha1.AppProjectSpec{SyncWindows: windows}}
+	}
+
+	t.Run("NoProject", func(t *testing.T) {
+		assert.Equal(t, SyncWindowsState{CanSync: true, Active: []ActiveSyncWindow{}}, NewSyncWindowsState(app, nil))
+	})
+	t.Run("ActiveDeny", func(t *testing.T) {
+		state := NewSyncWindowsState(app, newProject(&v1alpha1.SyncWindow{
+			Kind: "deny", Schedule: "* * * * *", Duration: "1h", Applications: []string{"guestbook"}, Description: "freeze",
+		}))
+		assert.False(t, state.CanSync)
+		assert.True(t, state.HasDeny)
+		assert.True(t, state.ActiveDeny)
+		assert.Equal(t, []ActiveSyncWindow{{Kind: "deny", Schedule: "* * * * *", Duration: "1h", Description: "freeze"}}, state.Active)
+	})
+	t.Run("InactiveDeny", func(t *testing.T) {
+		state := NewSyncWindowsState(app, newProject(&v1alpha1.SyncWindow{
+			Kind: "deny", Schedule: "0 0 1 1 *", Duration: "1m", Applications: []string{"guestbook"},
+		}))
+		assert.True(t, state.CanSync)
+		assert.True(t, state.HasDeny)
+		assert.False(t, state.ActiveDeny)
+		assert.Empty(t, state.Active)
+	})
+	t.Run("NotMatching", func(t *testing.T) {
+		state := NewSyncWindowsState(app, newProject(&v1alpha1.SyncWindow{
+			Kind: "deny", Schedule: "* * * * *", Duration: "1h", Applications: []string{"other"},
+		}))
+		assert.Equal(t, SyncWindowsState{CanSync: true, Active: []ActiveSyncWindow{}}, state)
+	})
+	t.Run("InvalidWindow", func(t *testing.T) {
+		state := NewSyncWindowsState(app, newProject(&v1alpha1.SyncWindow{
+			Kind: "deny", Schedule: "invalid", Duration: "1h", Applications: []string{"guestbook"},
+		}))
+		assert.False(t, state.CanSync)
+		assert.NotEmpty(t, state.Error)
+	})
+	t.Run("DenyExited", func(t *testing.T) {
+		proj := newProject(&v1alpha1.SyncWindow{
+			Kind: "deny", Schedule: "0 0 1 1 *", Duration: "1m", Applications: []string{"guestbook"},
+		})
+		now := time.Date(2024, 1, 1, 0, 30, 0, 0, time.UTC)
+		assert.Equal(t, "2024-01-01T00:01:00Z", newSyncWindowsState(app, proj, now).DenyExitedAt)
+		// the window ended too long ago
+		assert.Empty(t, newSyncWindowsState(app, proj, now.Add(2*time.Hour)).DenyExitedAt)
+		// the application was created after the window
+		created := app.DeepCopy()
+		created.CreationTimestamp = metav1.NewTime(now.Add(-10 * time.Minute))
+		assert.Empty(t, newSyncWindowsState(created, proj, now).DenyExitedAt)
+	})
+	t.Run("DenyStillActive", func(t *testing.T) {
+		state := newSyncWindowsState(app, newProject(&v1alpha1.SyncWindow{
+			Kind: "deny", Schedule: "* * * * *", Duration: "1h", Applications: []string{"guestbook"},
+		}), time.Now())
+		assert.True(t, state.ActiveDeny)
+		assert.Empty(t, state.DenyExitedAt)
+	})
+}
@@ -0,0 +1,211 @@
+package pagerduty
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/argoproj/notifications-engine/pkg/services"
+)
+
+const (
+	// ServiceType is the type of the PagerDuty Events API v2 service, configured using service.pagerdutyevents keys
+	ServiceType = "pagerdutyevents"
+
+	defaultEventsURL = "https://events.pagerduty.com/v2/enqueue"
+	defaultDedupKey  = "{{.source}}"
+	requestTimeout   = 30 * time.Second
+
+	eventActionTrigger = "trigger"
+	eventActionResolve = "resolve"
+)
+
+// defaultSeverities maps application health statuses to PagerDuty severities
+var defaultSeverities = map[string]string{
+	"Degraded":    "critical",
+	"Missing":     "error",
+	"Unknown":     "warning",
+	"Progressing": "info",
+	"Suspended":   "info",
+}
+
+// pagerDutySeverities are the severities accepted by the PagerDuty Events API v2
+var pagerDutySeverities = []string{"critical", "error", "warning", "info"}
+
+// EventsOptions configures the PagerDuty Events API v2 service
+type EventsOptions struct {
+	// RoutingKeys maps recipients to the routing keys of the Events API v2 integrations of PagerDuty services
+	RoutingKeys map[string]string `json:"routingKeys"`
+	// DedupKey is the template of the deduplication key of the events, evaluated using the fields of the event
+	DedupKey string `json:"dedupKey,omitempty"`
+	// Severities maps the rendered severities, e.g. health statuses, to PagerDuty severities
+	Severities map[string]string `json:"severities,omitempty"`
+	// ResolveOn lists the rendered severities which resolve the incident of the deduplication key instead of
+	// triggering one
+	ResolveOn []string `json:"resolveOn,omitempty"`
+	// URL of the Events API, defaults to the PagerDuty Events API v2 enqueue endpoint
+	URL string `json:"url,omitempty"`
+}
+
+// eventNotification holds the fields of the pagerdutyv2 block of a notification template
+type eventNotification struct {
+	Summary   string `json:"summary"`
+	Severity  string `json:"severity"`
+	Source    string `json:"source"`
+	Component string `json:"component,omitempty"`
+	Group     string `json:"group,omitempty"`
+	Class     string `json:"class,omitempty"`
+	URL       string `json:"url,omitempty"`
+}
+
+type eventPayload struct {
+	Summary   string `json:"summary"`
+	Severity  string `json:"severity"`
+	Source    string `json:"source"`
+	Component string `json:"component,omitempty"`
+	Group     string `json:"group,omitempty"`
+	Class     string `json:"class,omitempty"`
+}
+
+type eventLink struct {
+	Href string `json:"href"`
+	Text string `json:"text"`
+}
+
+type event struct {
+	RoutingKey  string        `json:"routing_key"`
+	EventAction string        `json:"event_action"`
+	DedupKey    string        `json:"dedup_key"`
+	Payload     *eventPayload `json:"payload,omitempty"`
+	Links       []eventLink   `json:"links,omitempty"`
+}
+
+type eventsService struct {
+	opts     EventsOptions
+	dedupKey *template.Template
+	client   *http.Client
+}
+
+// NewEventsService returns a notification service which sends the pagerdutyv2 block of notifications to the
+// PagerDuty Events API v2. Events whose severity is listed in ResolveOn resolve the incident of their deduplication
+// key, so that an application becoming healthy again resolves the incident opened when it degraded.
+func NewEventsService(opts EventsOptions) (services.NotificationService, error) {
+	if opts.DedupKey == "" {
+		opts.DedupKey = defaultDedupKey
+	}
+	if opts.Severities == nil {
+		opts.Severities = defaultSeverities
+	}
+	if opts.ResolveOn == nil {
+		opts.ResolveOn = []string{"Healthy"}
+	}
+	if opts.URL == "" {
+		opts.URL = defaultEventsURL
+	}
+	dedupKey, err := template.New("dedupKey").Option("missingkey=zero").Parse(opts.DedupKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid dedup key template: %w", err)
+	}
+	return &eventsService{opts: opts, dedupKey: dedupKey, client: &http.Client{Timeout: requestTimeout}}, nil
+}
+
+// newEvent returns the event to send for a notification to the given recipient
+func (s *eventsService) newEvent(notification services.Notification, recipient string) (*event, error) {
+	routingKey, ok := s.opts.RoutingKeys[recipient]
+	if !ok {
+		return nil, fmt.Errorf("no routing key configured for recipient %s", recipient)
+	}
+	// the pagerdutyv2 block is read from the JSON representation of the notification, which matches the template
+	data, err := json.Marshal(notification)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal notification: %w", err)
+	}
+	var fields struct {
+		PagerDutyV2 *eventNotification `json:"pagerdutyv2"`
+	}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal notification: %w", err)
+	}
+	if fields.PagerDutyV2 == nil {
+		return nil, errors.New("notification template has no pagerdutyv2 fields")
+	}
+	n := fields.PagerDutyV2
+
+	var dedupKey bytes.Buffer
+	if err := s.dedupKey.Execute(&dedupKey, map[string]string{
+		"summary":   n.Summary,
+		"severity":  n.Severity,
+		"source":    n.Source,
+		"component": n.Component,
+		"group":     n.Group,
+		"class":     n.Class,
+		"recipient": recipient,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to render dedup key: %w", err)
+	}
+	ev := &event{RoutingKey: routingKey, DedupKey: strings.TrimSpace(dedupKey.String())}
+	if ev.DedupKey == "" {
+		return nil, errors.New("dedup key must not be empty")
+	}
+
+	if slices.Contains(s.opts.ResolveOn, n.Severity) {
+		ev.EventAction = eventActionResolve
+		return ev, nil
+	}
+	severity := n.Severity
+	if mapped, ok := s.opts.Severities[severity]; ok {
+		severity = mapped
+	}
+	if !slices.Contains(pagerDutySeverities, severity) {
+		return nil, fmt.Errorf("severity '%s' is neither a PagerDuty severity nor mapped to one", n.Severity)
+	}
+	ev.EventAction = eventActionTrigger
+	ev.Payload = &eventPayload{
+		Summary:   n.Summary,
+		Severity:  severity,
+		Source:    n.Source,
+		Component: n.Component,
+		Group:     n.Group,
+		Class:     n.Class,
+	}
+	if n.URL != "" {
+		ev.Links = []eventLink{{Href: n.URL, Text: "View in Argo CD"}}
+	}
+	return ev, nil
+}
+
+func (s *eventsService) Send(notification services.Notification, dest services.Destination) error {
+	ev, err := s.newEvent(notification, dest.Recipient)
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(ev)
+	if err != nil {
+		return fmt.Errorf("failed to marshal PagerDuty event: %w", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.opts.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create PagerDuty request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send PagerDuty event: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusAccepted {
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("event rejected by PagerDuty with status %d: %s", resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package pagerduty
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/argoproj/notifications-engine/pkg/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newNotification(t *testing.T, severity string) services.Notification {
+	t.Helper()
+	var notification services.Notification
+	err := json.Unmarshal([]byte(`{"pagerdutyv2": {
+		"summary": "Application guestbook is `+severity+`",
+		"severity": "`+severity+`",
+		"source": "guestbook",
+		"url": "https://argocd.example.com/applications/guestbook"
+	}}`), &notification)
+	require.NoError(t, err)
+	return notification
+}
+
+func TestEventsService(t *testing.T) {
+	var received []event
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		var ev event
+		assert.NoError(t, json.Unmarshal(data, &ev))
+		received = append(received, ev)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	service, err := NewEventsService(EventsOptions{
+		RoutingKeys: map[string]string{"my-service": "routing-key"},
+		DedupKey:    "argocd/{{.source}}",
+		URL:         server.URL,
+	})
+	require.NoError(t, err)
+	dest := services.Destination{Service: ServiceType, Recipient: "my-service"}
+
+	require.NoError(t, service.Send(newNotification(t, "Degraded"), dest))
+	require.NoError(t, service.Send(newNotification(t, "Healthy"), dest))
+	require.Len(t, received, 2)
+
+	assert.Equal(t, event{
+		RoutingKey:  "routing-key",
+		EventAction: eventActionTrigger,
+		DedupKey:    "argocd/guestbook",
+		Payload: &eventPayload{
+			Summary:  "Application guestbook is Degraded",
+			Severity: "critical",
+			Source:   "guestbook",
+		},
+		Links: []eventLink{{Href: "https://argocd.example.com/applications/guestbook", Text: "View in Argo CD"}},
+	}, received[0])
+	assert.Equal(t, event{
+		RoutingKey:  "routing-key",
+		EventAction: eventActionResolve,
+		DedupKey:    "argocd/guestbook",
+	}, received[1])
+}
+
+func TestEventsService_Errors(t *testing.T) {
+	service, err := NewEventsService(EventsOptions{RoutingKeys: map[string]string{"my-service": "routing-key"}})
+	require.NoError(t, err)
+	s := service.(*eventsService)
+
+	_, err = s.newEvent(newNotification(t, "Degraded"), "other-service")
+	require.EqualError(t, err, "no routing key configured for recipient other-service")
+
+	_, err = s.newEvent(services.Notification{Message: "no pagerduty fields"}, "my-service")
+	require.EqualError(t, err, "notification template has no pagerdutyv2 fields")
+
+	_, err = s.newEvent(newNotification(t, "Broken"), "my-service")
+	require.EqualError(t, err, "severity 'Broken' is neither a PagerDuty severity nor mapped to one")
+
+	ev, err := s.newEvent(newNotification(t, "warning"), "my-service")
+	require.NoError(t, err)
+	assert.Equal(t, "warning", ev.Payload.Severity)
+	assert.Equal(t, "guestbook", ev.DedupKey)
+
+	_, err = NewEventsService(EventsOptions{DedupKey: "{{.source"})
+	require.ErrorContains(t, err, "invalid dedup key template")
+}
@@ -0,0 +1,218 @@
+package settings
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/argoproj/notifications-engine/pkg/api"
+	"github.com/argoproj/notifications-engine/pkg/services"
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// batchingKeyPrefix is the prefix of the ConfigMap keys which enable batching for a notification service, e.g.
+	// batching.slack
+	batchingKeyPrefix = "batching."
+	// batchedRecipientSeparator separates the trigger from the recipient of the destinations of batched services. It
+	// can't be part of a trigger name, which is a ConfigMap key.
+	batchedRecipientSeparator = "|"
+)
+
+type batchingConfig struct {
+	// Window is the duration over which notifications are aggregated into a single message
+	Window string `json:"window"`
+}
+
+// notificationBatch holds the notifications of a trigger sent to a destination during the current window
+type notificationBatch struct {
+	service       services.NotificationService
+	trigger       string
+	dest          services.Destination
+	window        time.Duration
+	notifications []services.Notification
+}
+
+// notificationBatcher aggregates the notifications sent to batched services. Batches are kept outside of the services
+// so that pending notifications are not lost when the services are recreated after a settings change.
+type notificationBatcher struct {
+	lock      sync.Mutex
+	batches   map[string]*notificationBatch
+	afterFunc func(d time.Duration, f func())
+}
+
+func newNotificationBatcher() *notificationBatcher {
+	return &notificationBatcher{
+		batches: map[string]*notificationBatch{},
+		afterFunc: func(d time.Duration, f func()) {
+			time.AfterFunc(d, f)
+		},
+	}
+}
+
+// IsBatchedService returns whether batching is configured for a notification service in the ConfigMap
+func IsBatchedService(configMap *corev1.ConfigMap, service string) bool {
+	_, ok := configMap.Data[batchingKeyPrefix+service]
+	return ok
+}
+
+// BatchedRecipient returns the recipient of a destination of a batched service, tagged with the trigger of the
+// notifications sent to it, so that the notifications of different triggers are batched separately
+func BatchedRecipient(trigger, recipient string) string {
+	return trigger + batchedRecipientSeparator + recipient
+}
+
+// ParseBatchedRecipient returns the trigger and the recipient of a destination of a batched service. The trigger is
+// empty if the recipient is not tagged.
+func ParseBatchedRecipient(recipient string) (string, string) {
+	trigger, untagged, ok := strings.Cut(recipient, batchedRecipientSeparator)
+	if !ok {
+		return "", recipient
+	}
+	return trigger, untagged
+}
+
+// applyBatchingConfig wraps every service which has a batching configuration into a service which aggregates the
+// notifications of a trigger sent to a recipient over the configured window
+func (b *notificationBatcher) applyBatchingConfig(cfg *api.Config, configMap *corev1.ConfigMap) error {
+	for k, v := range configMap.Data {
+		if !strings.HasPrefix(k, batchingKeyPrefix) {
+			continue
+		}
+		name := strings.TrimPrefix(k, batchingKeyPrefix)
+		newService, ok := cfg.Services[name]
+		if !ok {
+			return fmt.Errorf("batching is configured for unknown notification service '%s'", name)
+		}
+		var batching batchingConfig
+		if err := yaml.Unmarshal([]byte(v), &batching); err != nil {
+			return fmt.Errorf("error unmarshaling batching config of notification service '%s': %w", name, err)
+		}
+		window, err := time.ParseDuration(batching.Window)
+		if err != nil || window <= 0 {
+			return fmt.Errorf("invalid batching window '%s' of notification service '%s'", batching.Window, name)
+		}
+		cfg.Services[name] = func() (services.NotificationService, error) {
+			service, err := newService()
+			if err != nil {
+				return nil, err
+			}
+			return &batchingService{batcher: b, name: name, window: window, service: service}, nil
+		}
+	}
+	return nil
+}
+
+// add adds a notification to the batch of its trigger and destination, starting a new batch if there is none
+func (b *notificationBatcher) add(name string, window time.Duration, service services.NotificationService, trigger string, notification services.Notification, dest services.Destination) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	key := name + "/" + trigger + "/" + dest.Recipient
+	batch, ok := b.batches[key]
+	if !ok {
+		batch = &notificationBatch{trigger: trigger, dest: dest, window: window}
+		b.batches[key] = batch
+		b.afterFunc(window, func() {
+			b.flush(key)
+		})
+	}
+	batch.service = service
+	batch.notifications = append(batch.notifications, notification)
+}
+
+// flush sends the notifications of a batch as a single summarized message
+func (b *notificationBatcher) flush(key string) {
+	b.lock.Lock()
+	batch, ok := b.batches[key]
+	delete(b.batches, key)
+	b.lock.Unlock()
+	if !ok || len(batch.notifications) == 0 {
+		return
+	}
+	if err := batch.service.Send(summarizeNotifications(batch.trigger, batch.notifications, batch.window), batch.dest); err != nil {
+		log.Errorf("Failed to send %d batched notifications of trigger %s to %s: %v", len(batch.notifications), batch.trigger, batch.dest, err)
+	}
+}
+
+// summarizeNotifications returns a single notification holding the notifications of a batch. The messages of the
+// notifications are listed in full, and the Slack and Mattermost attachments and blocks of all the notifications are
+// concatenated. Other service specific fields can't be combined and are taken from the latest notification. A single
+// notification is returned unchanged.
+func summarizeNotifications(trigger string, notifications []services.Notification, window time.Duration) services.Notification {
+	if len(notifications) == 1 {
+		return notifications[0]
+	}
+	title := fmt.Sprintf("%d notifications in the last %s", len(notifications), window)
+	if trigger != "" {
+		title = fmt.Sprintf("%d notifications of trigger %s in the last %s", len(notifications), trigger, window)
+	}
+	var messages []string
+	var slackAttachments, slackBlocks, mattermostAttachments []string
+	for _, notification := range notifications {
+		if message := strings.TrimSpace(notification.Message); message != "" {
+			messages = append(messages, message)
+		} else if notification.Email != nil && notification.Email.Subject != "" {
+			messages = append(messages, notification.Email.Subject)
+		}
+		if notification.Slack != nil {
+			slackAttachments = append(slackAttachments, notification.Slack.Attachments)
+			slackBlocks = append(slackBlocks, notification.Slack.Blocks)
+		}
+		if notification.Mattermost != nil {
+			mattermostAttachments = append(mattermostAttachments, notification.Mattermost.Attachments)
+		}
+	}
+	message := title + ":\n\n" + strings.Join(messages, "\n\n")
+
+	summary := notifications[len(notifications)-1]
+	summary.Message = message
+	summary.Email = &services.EmailNotification{Subject: title, Body: message}
+	if summary.Slack != nil {
+		slack := *summary.Slack
+		slack.Attachments = concatJSONArrays(slackAttachments)
+		slack.Blocks = concatJSONArrays(slackBlocks)
+		summary.Slack = &slack
+	}
+	if summary.Mattermost != nil {
+		summary.Mattermost = &services.MattermostNotification{Attachments: concatJSONArrays(mattermostAttachments)}
+	}
+	return summary
+}
+
+// concatJSONArrays concatenates the items of JSON arrays, ignoring the values which are empty or not arrays
+func concatJSONArrays(arrays []string) string {
+	var items []json.RawMessage
+	for _, array := range arrays {
+		var arrayItems []json.RawMessage
+		if err := json.Unmarshal([]byte(array), &arrayItems); err == nil {
+			items = append(items, arrayItems...)
+		}
+	}
+	if len(items) == 0 {
+		return ""
+	}
+	data, err := json.Marshal(items)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// batchingService is a notification service which hands the notifications over to the batcher instead of sending them
+type batchingService struct {
+	batcher *notificationBatcher
+	name    string
+	window  time.Duration
+	service services.NotificationService
+}
+
+func (s *batchingService) Send(notification services.Notification, dest services.Destination) error {
+	trigger, recipient := ParseBatchedRecipient(dest.Recipient)
+	dest.Recipient = recipient
+	s.batcher.add(s.name, s.window, s.service, trigger, notification, dest)
+	return nil
+}
@@ -0,0 +1,105 @@
+package settings
+
+import (
+	"testing"
+	"time"
+
+	"github.com/argoproj/notifications-engine/pkg/api"
+	"github.com/argoproj/notifications-engine/pkg/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+type recordingService struct {
+	sent []services.Notification
+}
+
+func (s *recordingService) Send(notification services.Notification, _ services.Destination) error {
+	s.sent = append(s.sent, notification)
+	return nil
+}
+
+func TestApplyBatchingConfig(t *testing.T) {
+	newConfig := func(service services.NotificationService) *api.Config {
+		return &api.Config{Services: map[string]api.ServiceFactory{
+			"slack": func() (services.NotificationService, error) {
+				return service, nil
+			},
+		}}
+	}
+
+	t.Run("BatchesNotificationsPerTriggerAndRecipient", func(t *testing.T) {
+		slack := &recordingService{}
+		cfg := newConfig(slack)
+		batcher := newNotificationBatcher()
+		var flushes []func()
+		batcher.afterFunc = func(_ time.Duration, f func()) {
+			flushes = append(flushes, f)
+		}
+		err := batcher.applyBatchingConfig(cfg, &corev1.ConfigMap{Data: map[string]string{"batching.slack": "window: 10m"}})
+		require.NoError(t, err)
+
+		service, err := cfg.Services["slack"]()
+		require.NoError(t, err)
+		degraded := services.Destination{Service: "slack", Recipient: BatchedRecipient("on-health-degraded", "alerts")}
+		require.NoError(t, service.Send(services.Notification{Message: "Application guestbook has degraded."}, degraded))
+		require.NoError(t, service.Send(services.Notification{Message: "Application helm-guestbook has degraded.\nDetails"}, degraded))
+		require.NoError(t, service.Send(services.Notification{Message: "Application guestbook is synced."}, services.Destination{Service: "slack", Recipient: BatchedRecipient("on-sync-succeeded", "alerts")}))
+		require.NoError(t, service.Send(services.Notification{Message: "Application kustomize-guestbook has degraded."}, services.Destination{Service: "slack", Recipient: BatchedRecipient("on-health-degraded", "team")}))
+		assert.Empty(t, slack.sent)
+		require.Len(t, flushes, 3)
+
+		flushes[0]()
+		require.Len(t, slack.sent, 1)
+		assert.Equal(t, "2 notifications of trigger on-health-degraded in the last 10m0s:\n\nApplication guestbook has degraded.\n\nApplication helm-guestbook has degraded.\nDetails", slack.sent[0].Message)
+
+		flushes[1]()
+		require.Len(t, slack.sent, 2)
+		assert.Equal(t, "Application guestbook is synced.", slack.sent[1].Message)
+
+		flushes[2]()
+		require.Len(t, slack.sent, 3)
+		assert.Equal(t, "Application kustomize-guestbook has degraded.", slack.sent[2].Message)
+	})
+
+	t.Run("UnknownService", func(t *testing.T) {
+		err := newNotificationBatcher().applyBatchingConfig(newConfig(&recordingService{}), &corev1.ConfigMap{Data: map[string]string{"batching.email": "window: 10m"}})
+		assert.EqualError(t, err, "batching is configured for unknown notification service 'email'")
+	})
+
+	t.Run("InvalidWindow", func(t *testing.T) {
+		err := newNotificationBatcher().applyBatchingConfig(newConfig(&recordingService{}), &corev1.ConfigMap{Data: map[string]string{"batching.slack": "window: soon"}})
+		assert.EqualError(t, err, "invalid batching window 'soon' of notification service 'slack'")
+	})
+}
+
+func TestParseBatchedRecipient(t *testing.T) {
+	trigger, recipient := ParseBatchedRecipient(BatchedRecipient("on-health-degraded", "alerts"))
+	assert.Equal(t, "on-health-degraded", trigger)
+	assert.Equal(t, "alerts", recipient)
+
+	trigger, recipient = ParseBatchedRecipient("alerts")
+	assert.Empty(t, trigger)
+	assert.Equal(t, "alerts", recipient)
+}
+
+func TestSummarizeNotifications(t *testing.T) {
+	summary := summarizeNotifications("on-health-degraded", []services.Notification{{
+		Message: "Application guestbook has degraded.",
+		Slack:   &services.SlackNotification{Attachments: `[{"title":"guestbook"}]`, GroupingKey: "guestbook"},
+	}, {
+		Message: "Application helm-guestbook has degraded.",
+		Slack:   &services.SlackNotification{Attachments: `[{"title":"helm-guestbook"}]`, GroupingKey: "helm-guestbook"},
+	}}, 10*time.Minute)
+
+	assert.Equal(t, "2 notifications of trigger on-health-degraded in the last 10m0s:\n\nApplication guestbook has degraded.\n\nApplication helm-guestbook has degraded.", summary.Message)
+	assert.Equal(t, "2 notifications of trigger on-health-degraded in the last 10m0s", summary.Email.Subject)
+	assert.Equal(t, summary.Message, summary.Email.Body)
+	assert.JSONEq(t, `[{"title":"guestbook"},{"title":"helm-guestbook"}]`, summary.Slack.Attachments)
+	assert.Empty(t, summary.Slack.Blocks)
+	assert.Equal(t, "helm-guestbook", summary.Slack.GroupingKey)
+
+	single := services.Notification{Message: "Application guestbook has degraded."}
+	assert.Equal(t, single, summarizeNotifications("on-health-degraded", []services.Notification{single}, 10*time.Minute))
+}
@@ -0,0 +1,83 @@
+package settings
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/argoproj/notifications-engine/pkg/api"
+	"github.com/argoproj/notifications-engine/pkg/services"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/argoproj/argo-cd/v3/util/notification/cloudevents"
+	"github.com/argoproj/argo-cd/v3/util/notification/pagerduty"
+	"github.com/argoproj/argo-cd/v3/util/notification/teams"
+	argosettings "github.com/argoproj/argo-cd/v3/util/settings"
+)
+
+// argoCDServices holds the constructors of the notification services which are implemented by Argo CD rather than by
+// the notifications engine, by service type
+var argoCDServices = map[string]func(optsData []byte) (services.NotificationService, error){
+	cloudevents.ServiceType: func(optsData []byte) (services.NotificationService, error) {
+		var opts cloudevents.Options
+		if err := json.Unmarshal(optsData, &opts); err != nil {
+			return nil, err
+		}
+		return cloudevents.NewService(opts)
+	},
+	pagerduty.ServiceType: func(optsData []byte) (services.NotificationService, error) {
+		var opts pagerduty.EventsOptions
+		if err := json.Unmarshal(optsData, &opts); err != nil {
+			return nil, err
+		}
+		return pagerduty.NewEventsService(opts)
+	},
+	teams.WorkflowsServiceType: func(optsData []byte) (services.NotificationService, error) {
+		var opts teams.WorkflowsOptions
+		if err := json.Unmarshal(optsData, &opts); err != nil {
+			return nil, err
+		}
+		return teams.NewWorkflowsService(opts), nil
+	},
+}
+
+// applyArgoCDServices configures the notification services which are implemented by Argo CD rather than by the
+// notifications engine. They use the same service.<type>.(<custom-name>) keys as the services of the engine.
+func applyArgoCDServices(cfg *api.Config, configMap *corev1.ConfigMap, secret *corev1.Secret) error {
+	secretValues := map[string]string{}
+	if secret != nil {
+		for k, v := range secret.Data {
+			secretValues[k] = string(v)
+		}
+	}
+	for k, v := range configMap.Data {
+		parts := strings.Split(k, ".")
+		if len(parts) < 2 || parts[0] != "service" {
+			continue
+		}
+		newService, ok := argoCDServices[parts[1]]
+		if !ok {
+			continue
+		}
+		name := parts[1]
+		if len(parts) > 2 {
+			name = strings.Join(parts[2:], ".")
+		}
+		var opts map[string]any
+		if err := yaml.Unmarshal([]byte(v), &opts); err != nil {
+			return fmt.Errorf("error unmarshaling config of notification service '%s': %w", name, err)
+		}
+		optsData, err := json.Marshal(argosettings.ReplaceMapSecrets(opts, secretValues))
+		if err != nil {
+			return fmt.Errorf("error marshaling config of notification service '%s': %w", name, err)
+		}
+		if _, err := newService(optsData); err != nil {
+			return fmt.Errorf("invalid config of notification service '%s': %w", name, err)
+		}
+		cfg.Services[name] = func() (services.NotificationService, error) {
+			return newService(optsData)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package settings
+
+import (
+	"testing"
+
+	"github.com/argoproj/notifications-engine/pkg/api"
+	"github.com/argoproj/notifications-engine/pkg/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestApplyArgoCDServices(t *testing.T) {
+	cfg := &api.Config{Services: map[string]api.ServiceFactory{
+		"slack": func() (services.NotificationService, error) {
+			return nil, nil
+		},
+	}}
+	err := applyArgoCDServices(cfg, &corev1.ConfigMap{Data: map[string]string{
+		"service.slack":                  "token: $slack-token",
+		"service.pagerdutyevents":        "routingKeys:\n  my-service: $pagerduty-key",
+		"service.pagerdutyevents.oncall": "routingKeys:\n  my-service: $pagerduty-key",
+		"service.teamsworkflows":         "recipientUrls:\n  my-channel: $teams-url",
+		"service.cloudevents.broker":     "recipientUrls:\n  default: http://broker-ingress.knative-eventing/argocd/default",
+	}}, &corev1.Secret{Data: map[string][]byte{"pagerduty-key": []byte("routing-key")}})
+	require.NoError(t, err)
+
+	assert.Contains(t, cfg.Services, "slack")
+	for _, name := range []string{"pagerdutyevents", "oncall", "teamsworkflows", "broker"} {
+		require.Contains(t, cfg.Services, name)
+		service, err := cfg.Services[name]()
+		require.NoError(t, err)
+		assert.NotNil(t, service)
+	}
+
+	err = applyArgoCDServices(cfg, &corev1.ConfigMap{Data: map[string]string{"service.pagerdutyevents": "routingKeys: invalid"}}, nil)
+	require.ErrorContains(t, err, "invalid config of notification service 'pagerdutyevents'")
+
+	err = applyArgoCDServices(cfg, &corev1.ConfigMap{Data: map[string]string{"service.pagerdutyevents": "dedupKey: '{{.source'"}}, nil)
+	require.ErrorContains(t, err, "invalid dedup key template")
+
+	err = applyArgoCDServices(cfg, &corev1.ConfigMap{Data: map[string]string{"service.cloudevents": "mode: batched"}}, nil)
+	require.ErrorContains(t, err, "invalid mode 'batched'")
+}
@@ -1,28 +1,43 @@
 package settings
 
 import (
+	"encoding/json"
 	"errors"
+	"maps"
 
 	"github.com/argoproj/notifications-engine/pkg/api"
 	"github.com/argoproj/notifications-engine/pkg/services"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/yaml"
 
+	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
 	"github.com/argoproj/argo-cd/v3/util/notification/expression"
+	"github.com/argoproj/argo-cd/v3/util/notification/expression/shared"
 
 	service "github.com/argoproj/argo-cd/v3/util/notification/argocd"
 )
 
-func GetFactorySettings(argocdService service.Service, secretName, configMapName string, selfServiceNotificationEnabled bool) api.Settings {
+// AppProjectGetter returns the project of an application, or nil if it is not found
+type AppProjectGetter func(app *unstructured.Unstructured) *unstructured.Unstructured
+
+func GetFactorySettings(argocdService service.Service, secretName, configMapName string, selfServiceNotificationEnabled bool, getAppProject AppProjectGetter) api.Settings {
+	batcher := newNotificationBatcher()
 	return api.Settings{
 		SecretName:    secretName,
 		ConfigMapName: configMapName,
 		InitGetVars: func(cfg *api.Config, configMap *corev1.ConfigMap, secret *corev1.Secret) (api.GetVars, error) {
+			if err := applyArgoCDServices(cfg, configMap, secret); err != nil {
+				return nil, err
+			}
+			if err := batcher.applyBatchingConfig(cfg, configMap); err != nil {
+				return nil, err
+			}
 			if selfServiceNotificationEnabled {
-				return initGetVarsWithoutSecret(argocdService, cfg, configMap, secret)
+				return initGetVarsWithoutSecret(argocdService, cfg, configMap, secret, getAppProject)
 			}
-			return initGetVars(argocdService, cfg, configMap, secret)
+			return initGetVars(argocdService, cfg, configMap, secret, getAppProject)
 		},
 	}
 }
@@ -36,11 +51,14 @@ func GetFactorySettingsForCLI(argocdService service.Service, secretName, configM
 			if argocdService == nil {
 				return nil, errors.New("argocdService is not initialized")
 			}
+			if err := applyArgoCDServices(cfg, configMap, secret); err != nil {
+				return nil, err
+			}
 
 			if selfServiceNotificationEnabled {
-				return initGetVarsWithoutSecret(argocdService, cfg, configMap, secret)
+				return initGetVarsWithoutSecret(argocdService, cfg, configMap, secret, nil)
 			}
-			return initGetVars(argocdService, cfg, configMap, secret)
+			return initGetVars(argocdService, cfg, configMap, secret, nil)
 		},
 	}
 }
@@ -58,7 +76,7 @@ func getContext(cfg *api.Config, configMap *corev1.ConfigMap, secret *corev1.Sec
 	return context, nil
 }
 
-func initGetVarsWithoutSecret(argocdService service.Service, cfg *api.Config, configMap *corev1.ConfigMap, secret *corev1.Secret) (api.GetVars, error) {
+func initGetVarsWithoutSecret(argocdService service.Service, cfg *api.Config, configMap *corev1.ConfigMap, secret *corev1.Secret, getAppProject AppProjectGetter) (api.GetVars, error) {
 	context, err := getContext(cfg, configMap, secret)
 	if err != nil {
 		return nil, err
@@ -66,13 +84,14 @@ func initGetVarsWithoutSecret(argocdService service.Service, cfg *api.Config, co
 
 	return func(obj map[string]any, dest services.Destination) map[string]any {
 		return expression.Spawn(&unstructured.Unstructured{Object: obj}, argocdService, map[string]any{
-			"app":     obj,
-			"context": injectLegacyVar(context, dest.Service),
+			"app":         newAppVars(obj),
+			"context":     injectLegacyVar(context, dest.Service),
+			"syncWindows": newSyncWindowsVars(obj, getAppProject),
 		})
 	}, nil
 }
 
-func initGetVars(argocdService service.Service, cfg *api.Config, configMap *corev1.ConfigMap, secret *corev1.Secret) (api.GetVars, error) {
+func initGetVars(argocdService service.Service, cfg *api.Config, configMap *corev1.ConfigMap, secret *corev1.Secret, getAppProject AppProjectGetter) (api.GetVars, error) {
 	context, err := getContext(cfg, configMap, secret)
 	if err != nil {
 		return nil, err
@@ -80,9 +99,54 @@ func initGetVars(argocdService service.Service, cfg *api.Config, configMap *core
 
 	return func(obj map[string]any, dest services.Destination) map[string]any {
 		return expression.Spawn(&unstructured.Unstructured{Object: obj}, argocdService, map[string]any{
-			"app":     obj,
-			"context": injectLegacyVar(context, dest.Service),
-			"secrets": secret.Data,
+			"app":         newAppVars(obj),
+			"context":     injectLegacyVar(context, dest.Service),
+			"secrets":     secret.Data,
+			"syncWindows": newSyncWindowsVars(obj, getAppProject),
 		})
 	}, nil
 }
+
+// newAppVars returns the application as exposed to templates and triggers, extended with the summary of the
+// differences found by its latest comparison
+func newAppVars(obj map[string]any) map[string]any {
+	app := make(map[string]any, len(obj)+1)
+	maps.Copy(app, obj)
+	app["diffSummary"] = newDiffSummaryVars(obj)
+	return app
+}
+
+func newDiffSummaryVars(obj map[string]any) map[string]any {
+	var resources []v1alpha1.ResourceStatus
+	if resourcesObj, ok, err := unstructured.NestedSlice(obj, "status", "resources"); ok && err == nil {
+		if data, err := json.Marshal(resourcesObj); err == nil {
+			_ = json.Unmarshal(data, &resources)
+		}
+	}
+	// templates and triggers access the application using the keys of its JSON representation, so does the summary
+	summary := map[string]any{}
+	if data, err := json.Marshal(shared.NewDiffSummary(resources)); err == nil {
+		_ = json.Unmarshal(data, &summary)
+	}
+	return summary
+}
+
+// newSyncWindowsVars returns the state of the sync windows of the project which match the application, as exposed to
+// templates and triggers
+func newSyncWindowsVars(obj map[string]any, getAppProject AppProjectGetter) map[string]any {
+	var app v1alpha1.Application
+	var proj *v1alpha1.AppProject
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj, &app); err == nil && getAppProject != nil {
+		if projObj := getAppProject(&unstructured.Unstructured{Object: obj}); projObj != nil {
+			proj = &v1alpha1.AppProject{}
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(projObj.Object, proj); err != nil {
+				proj = nil
+			}
+		}
+	}
+	state := map[string]any{}
+	if data, err := json.Marshal(shared.NewSyncWindowsState(&app, proj)); err == nil {
+		_ = json.Unmarshal(data, &state)
+	}
+	return state
+}
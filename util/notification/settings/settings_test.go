@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/client-go/kubernetes/fake"
 
 	"github.com/argoproj/argo-cd/v3/reposerver/apiclient/mocks"
@@ -68,7 +69,7 @@ func TestInitGetVars(t *testing.T) {
 	}
 	emptyAppData := map[string]any{}
 
-	varsProvider, _ := initGetVars(argocdService, &config, &notificationsCm, &notificationsSecret)
+	varsProvider, _ := initGetVars(argocdService, &config, &notificationsCm, &notificationsSecret, nil)
 
 	t.Run("Vars provider serves Application data on app key", func(t *testing.T) {
 		appData := map[string]any{
@@ -76,7 +77,37 @@ func TestInitGetVars(t *testing.T) {
 		}
 		result := varsProvider(appData, testDestination)
 		assert.NotNil(t, t, result["app"])
-		assert.Equal(t, result["app"], appData)
+		app, ok := result["app"].(map[string]any)
+		require.True(t, ok)
+		assert.Equal(t, "app-name", app["name"])
+		assert.Contains(t, app, "diffSummary")
+	})
+	t.Run("Vars provider serves diff summary of the application", func(t *testing.T) {
+		appData := map[string]any{
+			"status": map[string]any{
+				"resources": []any{
+					map[string]any{"kind": "Deployment", "name": "guestbook-ui", "status": "OutOfSync", "health": map[string]any{"status": "Missing"}},
+					map[string]any{"kind": "Service", "name": "guestbook-ui", "status": "OutOfSync"},
+					map[string]any{"kind": "ConfigMap", "name": "old", "status": "OutOfSync", "requiresPruning": true},
+					map[string]any{"kind": "Job", "name": "migrate", "status": "OutOfSync", "hook": true},
+					map[string]any{"kind": "Secret", "name": "guestbook", "status": "Synced"},
+				},
+			},
+		}
+		result := varsProvider(appData, testDestination)
+		app, ok := result["app"].(map[string]any)
+		require.True(t, ok)
+		assert.Equal(t, map[string]any{
+			"created": float64(1),
+			"changed": float64(1),
+			"pruned":  float64(1),
+			"topChangedKinds": []any{
+				map[string]any{"kind": "ConfigMap", "count": float64(1)},
+				map[string]any{"kind": "Deployment", "count": float64(1)},
+				map[string]any{"kind": "Service", "count": float64(1)},
+			},
+		}, app["diffSummary"])
+		assert.NotContains(t, appData, "diffSummary")
 	})
 	t.Run("Vars provider serves notification context data on context key", func(t *testing.T) {
 		expectedContext := map[string]string{
@@ -92,4 +123,26 @@ func TestInitGetVars(t *testing.T) {
 		assert.NotNil(t, result["secrets"])
 		assert.Equal(t, result["secrets"], notificationsSecret.Data)
 	})
+	t.Run("Vars provider serves sync windows of the project on syncWindows key", func(t *testing.T) {
+		result := varsProvider(emptyAppData, testDestination)
+		assert.Equal(t, map[string]any{"canSync": true, "hasDeny": false, "activeDeny": false, "active": []any{}, "denyExitedAt": ""}, result["syncWindows"])
+
+		getAppProject := func(_ *unstructured.Unstructured) *unstructured.Unstructured {
+			return &unstructured.Unstructured{Object: map[string]any{
+				"spec": map[string]any{
+					"syncWindows": []any{
+						map[string]any{"kind": "deny", "schedule": "* * * * *", "duration": "1h", "applications": []any{"*"}},
+					},
+				},
+			}}
+		}
+		projVarsProvider, err := initGetVars(argocdService, &config, &notificationsCm, &notificationsSecret, getAppProject)
+		require.NoError(t, err)
+		result = projVarsProvider(map[string]any{"metadata": map[string]any{"name": "guestbook"}}, testDestination)
+		syncWindows, ok := result["syncWindows"].(map[string]any)
+		require.True(t, ok)
+		assert.Equal(t, false, syncWindows["canSync"])
+		assert.Equal(t, true, syncWindows["activeDeny"])
+		assert.Len(t, syncWindows["active"], 1)
+	})
 }
@@ -0,0 +1,202 @@
+package teams
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/argoproj/notifications-engine/pkg/services"
+)
+
+const (
+	// WorkflowsServiceType is the type of the Teams Workflows service, configured using service.teamsworkflows keys
+	WorkflowsServiceType = "teamsworkflows"
+
+	adaptiveCardContentType = "application/vnd.microsoft.card.adaptive"
+	adaptiveCardSchema      = "http://adaptivecards.io/schemas/adaptive-card.json"
+	adaptiveCardVersion     = "1.4"
+	requestTimeout          = 30 * time.Second
+)
+
+// WorkflowsOptions configures the Teams Workflows service
+type WorkflowsOptions struct {
+	// RecipientURLs maps recipients to the URLs of the Workflows webhooks of Teams channels
+	RecipientURLs map[string]string `json:"recipientUrls"`
+}
+
+// teamsNotification holds the fields of the teams block of a notification template which are used by the service
+type teamsNotification struct {
+	Template        string `json:"template,omitempty"`
+	Title           string `json:"title,omitempty"`
+	Text            string `json:"text,omitempty"`
+	Facts           string `json:"facts,omitempty"`
+	Sections        string `json:"sections,omitempty"`
+	PotentialAction string `json:"potentialAction,omitempty"`
+}
+
+type messageCardFact struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type messageCardSection struct {
+	Facts []messageCardFact `json:"facts"`
+}
+
+type messageCardAction struct {
+	Type    string `json:"@type"`
+	Name    string `json:"name"`
+	Targets []struct {
+		URI string `json:"uri"`
+	} `json:"targets"`
+}
+
+type adaptiveCardAttachment struct {
+	ContentType string         `json:"contentType"`
+	Content     map[string]any `json:"content"`
+}
+
+type workflowsMessage struct {
+	Type        string                   `json:"type"`
+	Attachments []adaptiveCardAttachment `json:"attachments"`
+}
+
+type workflowsService struct {
+	opts   WorkflowsOptions
+	client *http.Client
+}
+
+// NewWorkflowsService returns a notification service which posts Adaptive Cards to Teams Workflows webhooks, which
+// replace the retired Office 365 connectors used by the teams service of the notifications engine
+func NewWorkflowsService(opts WorkflowsOptions) services.NotificationService {
+	return &workflowsService{opts: opts, client: &http.Client{Timeout: requestTimeout}}
+}
+
+// newAdaptiveCard returns the Adaptive Card of a notification. The template field of the teams block is used as the
+// card if set, otherwise the card is built from its title, text, facts and actions, falling back to the message.
+func newAdaptiveCard(notification services.Notification) (map[string]any, error) {
+	// the teams block is read from the JSON representation of the notification, which matches the template
+	data, err := json.Marshal(notification)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal notification: %w", err)
+	}
+	var fields struct {
+		Teams *teamsNotification `json:"teams"`
+	}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal notification: %w", err)
+	}
+	n := fields.Teams
+	if n == nil {
+		n = &teamsNotification{}
+	}
+
+	if n.Template != "" {
+		var card map[string]any
+		if err := json.Unmarshal([]byte(n.Template), &card); err != nil {
+			return nil, fmt.Errorf("teams template is not a valid Adaptive Card: %w", err)
+		}
+		return card, nil
+	}
+
+	var body []any
+	if n.Title != "" {
+		body = append(body, map[string]any{"type": "TextBlock", "text": n.Title, "size": "Large", "weight": "Bolder", "wrap": true})
+	}
+	text := n.Text
+	if text == "" {
+		text = notification.Message
+	}
+	if text != "" {
+		body = append(body, map[string]any{"type": "TextBlock", "text": text, "wrap": true})
+	}
+
+	var facts []messageCardFact
+	if n.Facts != "" {
+		if err := json.Unmarshal([]byte(n.Facts), &facts); err != nil {
+			return nil, fmt.Errorf("teams facts is not a valid list of facts: %w", err)
+		}
+	}
+	if n.Sections != "" {
+		var sections []messageCardSection
+		if err := json.Unmarshal([]byte(n.Sections), &sections); err != nil {
+			return nil, fmt.Errorf("teams sections is not a valid list of sections: %w", err)
+		}
+		for _, section := range sections {
+			facts = append(facts, section.Facts...)
+		}
+	}
+	if len(facts) > 0 {
+		factSet := make([]any, 0, len(facts))
+		for _, fact := range facts {
+			factSet = append(factSet, map[string]any{"title": fact.Name, "value": fact.Value})
+		}
+		body = append(body, map[string]any{"type": "FactSet", "facts": factSet})
+	}
+
+	card := map[string]any{
+		"$schema": adaptiveCardSchema,
+		"type":    "AdaptiveCard",
+		"version": adaptiveCardVersion,
+		"body":    body,
+		"msteams": map[string]any{"width": "Full"},
+	}
+	if n.PotentialAction != "" {
+		var potentialActions []messageCardAction
+		if err := json.Unmarshal([]byte(n.PotentialAction), &potentialActions); err != nil {
+			return nil, fmt.Errorf("teams potentialAction is not a valid list of actions: %w", err)
+		}
+		var actions []any
+		for _, action := range potentialActions {
+			// only links can be expressed as actions of an Adaptive Card posted by a webhook
+			if action.Type != "OpenUri" || len(action.Targets) == 0 {
+				continue
+			}
+			actions = append(actions, map[string]any{"type": "Action.OpenUrl", "title": action.Name, "url": action.Targets[0].URI})
+		}
+		if len(actions) > 0 {
+			card["actions"] = actions
+		}
+	}
+	return card, nil
+}
+
+func (s *workflowsService) Send(notification services.Notification, dest services.Destination) error {
+	webhookURL, ok := s.opts.RecipientURLs[dest.Recipient]
+	if !ok {
+		return fmt.Errorf("no Workflows webhook URL configured for recipient %s", dest.Recipient)
+	}
+	card, err := newAdaptiveCard(notification)
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(workflowsMessage{
+		Type:        "message",
+		Attachments: []adaptiveCardAttachment{{ContentType: adaptiveCardContentType, Content: card}},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal Teams message: %w", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create Teams request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send Teams message: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("message rejected by Teams with status %d: %s", resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+	return nil
+}
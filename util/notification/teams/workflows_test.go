@@ -0,0 +1,84 @@
+package teams
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/argoproj/notifications-engine/pkg/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newNotification(t *testing.T, data string) services.Notification {
+	t.Helper()
+	var notification services.Notification
+	require.NoError(t, json.Unmarshal([]byte(data), &notification))
+	return notification
+}
+
+func TestNewAdaptiveCard(t *testing.T) {
+	t.Run("Message", func(t *testing.T) {
+		card, err := newAdaptiveCard(newNotification(t, `{"message": "Application guestbook has been synced."}`))
+		require.NoError(t, err)
+		assert.Equal(t, []any{
+			map[string]any{"type": "TextBlock", "text": "Application guestbook has been synced.", "wrap": true},
+		}, card["body"])
+		assert.NotContains(t, card, "actions")
+	})
+	t.Run("TeamsFields", func(t *testing.T) {
+		card, err := newAdaptiveCard(newNotification(t, `{"teams": {
+			"title": "Application guestbook has been synced",
+			"text": "Sync succeeded.",
+			"facts": "[{\"name\": \"Sync Status\", \"value\": \"Synced\"}]",
+			"sections": "[{\"facts\": [{\"name\": \"Repository\", \"value\": \"https://github.com/argoproj/argocd-example-apps\"}]}]",
+			"potentialAction": "[{\"@type\": \"OpenUri\", \"name\": \"Open\", \"targets\": [{\"os\": \"default\", \"uri\": \"https://argocd.example.com/applications/guestbook\"}]}]"
+		}}`))
+		require.NoError(t, err)
+		assert.Equal(t, "AdaptiveCard", card["type"])
+		assert.Equal(t, []any{
+			map[string]any{"type": "TextBlock", "text": "Application guestbook has been synced", "size": "Large", "weight": "Bolder", "wrap": true},
+			map[string]any{"type": "TextBlock", "text": "Sync succeeded.", "wrap": true},
+			map[string]any{"type": "FactSet", "facts": []any{
+				map[string]any{"title": "Sync Status", "value": "Synced"},
+				map[string]any{"title": "Repository", "value": "https://github.com/argoproj/argocd-example-apps"},
+			}},
+		}, card["body"])
+		assert.Equal(t, []any{
+			map[string]any{"type": "Action.OpenUrl", "title": "Open", "url": "https://argocd.example.com/applications/guestbook"},
+		}, card["actions"])
+	})
+	t.Run("Template", func(t *testing.T) {
+		card, err := newAdaptiveCard(newNotification(t, `{"teams": {"template": "{\"type\": \"AdaptiveCard\", \"version\": \"1.5\"}"}}`))
+		require.NoError(t, err)
+		assert.Equal(t, map[string]any{"type": "AdaptiveCard", "version": "1.5"}, card)
+	})
+	t.Run("InvalidFacts", func(t *testing.T) {
+		_, err := newAdaptiveCard(newNotification(t, `{"teams": {"facts": "not-json"}}`))
+		require.ErrorContains(t, err, "teams facts is not a valid list of facts")
+	})
+}
+
+func TestWorkflowsService_Send(t *testing.T) {
+	var received workflowsMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.NoError(t, json.Unmarshal(data, &received))
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	service := NewWorkflowsService(WorkflowsOptions{RecipientURLs: map[string]string{"my-channel": server.URL}})
+	err := service.Send(newNotification(t, `{"message": "hello"}`), services.Destination{Service: WorkflowsServiceType, Recipient: "my-channel"})
+	require.NoError(t, err)
+	assert.Equal(t, "message", received.Type)
+	require.Len(t, received.Attachments, 1)
+	assert.Equal(t, adaptiveCardContentType, received.Attachments[0].ContentType)
+	assert.Equal(t, "AdaptiveCard", received.Attachments[0].Content["type"])
+
+	err = service.Send(newNotification(t, `{"message": "hello"}`), services.Destination{Service: WorkflowsServiceType, Recipient: "other-channel"})
+	require.EqualError(t, err, "no Workflows webhook URL configured for recipient other-channel")
+}
@@ -34,11 +34,24 @@ import (
 	"github.com/argoproj/argo-cd/v3/util/proxy"
 
 	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
 	"oras.land/oras-go/v2/content/file"
+	"oras.land/oras-go/v2/errdef"
+	"oras.land/oras-go/v2/registry"
 	"oras.land/oras-go/v2/registry/remote"
 	"oras.land/oras-go/v2/registry/remote/auth"
 )
 
+const (
+	// MediaTypeDSSEEnvelope is the media type of the layers of the attestations containing a DSSE envelope
+	MediaTypeDSSEEnvelope = "application/vnd.dsse.envelope.v1+json"
+	// MediaTypeInTotoStatement is the artifact type of the attestations containing an in-toto statement
+	MediaTypeInTotoStatement = "application/vnd.in-toto+json"
+
+	// maxAttestationSize is the maximum size of the manifests and the envelopes of the attestations
+	maxAttestationSize = 4 * 1024 * 1024
+)
+
 var (
 	globalLock = sync.NewKeyLock()
 	indexLock  = sync.NewKeyLock()
@@ -74,6 +87,9 @@ type Client interface {
 
 	// GetTags retrieves the list of tags for the repository.
 	GetTags(ctx context.Context, noCache bool) ([]string, error)
+
+	// Attestations retrieves the DSSE envelopes of the attestations attached to the OCI image of the given digest.
+	Attestations(ctx context.Context, digest string) ([][]byte, error)
 }
 
 type Creds struct {
@@ -292,6 +308,65 @@ func (c *nativeOCIClient) DigestMetadata(ctx context.Context, digest string) (*i
 	return getOCIManifest(ctx, digest, repo)
 }
 
+// Attestations returns the DSSE envelopes of the attestations attached to the OCI image of the given digest, either with
+// the OCI referrers API or with the tag scheme of cosign.
+func (c *nativeOCIClient) Attestations(ctx context.Context, digest string) ([][]byte, error) {
+	desc, err := c.repo.Resolve(ctx, digest)
+	if err != nil {
+		return nil, fmt.Errorf("error resolving oci repo from digest, %w", err)
+	}
+
+	var manifests []imagev1.Descriptor
+	if store, ok := c.repo.(content.ReadOnlyGraphStorage); ok {
+		referrers, err := registry.Referrers(ctx, store, desc, "")
+		if err != nil {
+			return nil, fmt.Errorf("error listing referrers of digest %s: %w", digest, err)
+		}
+		for _, referrer := range referrers {
+			if referrer.ArtifactType == MediaTypeDSSEEnvelope || referrer.ArtifactType == MediaTypeInTotoStatement {
+				manifests = append(manifests, referrer)
+			}
+		}
+	}
+	cosignTag := fmt.Sprintf("%s-%s.att", desc.Digest.Algorithm(), desc.Digest.Encoded())
+	cosignDesc, err := c.repo.Resolve(ctx, cosignTag)
+	switch {
+	case err == nil:
+		manifests = append(manifests, cosignDesc)
+	case !errors.Is(err, errdef.ErrNotFound):
+		return nil, fmt.Errorf("error resolving attestation tag %s: %w", cosignTag, err)
+	}
+
+	var envelopes [][]byte
+	for _, manifestDesc := range manifests {
+		if manifestDesc.Size > maxAttestationSize {
+			return nil, fmt.Errorf("attestation manifest %s exceeds the maximum size of %d bytes", manifestDesc.Digest, maxAttestationSize)
+		}
+		data, err := content.FetchAll(ctx, c.repo, manifestDesc)
+		if err != nil {
+			return nil, fmt.Errorf("error fetching attestation manifest %s: %w", manifestDesc.Digest, err)
+		}
+		manifest := imagev1.Manifest{}
+		if err := json.Unmarshal(data, &manifest); err != nil {
+			return nil, fmt.Errorf("error decoding attestation manifest %s: %w", manifestDesc.Digest, err)
+		}
+		for _, layer := range manifest.Layers {
+			if layer.MediaType != MediaTypeDSSEEnvelope {
+				continue
+			}
+			if layer.Size > maxAttestationSize {
+				return nil, fmt.Errorf("attestation %s exceeds the maximum size of %d bytes", layer.Digest, maxAttestationSize)
+			}
+			envelope, err := content.FetchAll(ctx, c.repo, layer)
+			if err != nil {
+				return nil, fmt.Errorf("error fetching attestation %s: %w", layer.Digest, err)
+			}
+			envelopes = append(envelopes, envelope)
+		}
+	}
+	return envelopes, nil
+}
+
 func (c *nativeOCIClient) ResolveRevision(ctx context.Context, revision string, noCache bool) (string, error) {
 	digest, err := c.resolveDigest(ctx, revision) // Lookup explicit revision
 	if err != nil {
@@ -7,6 +7,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"os"
 	"path/filepath"
@@ -436,3 +437,51 @@ func Test_nativeOCIClient_ResolveRevision(t *testing.T) {
 		})
 	}
 }
+
+func Test_nativeOCIClient_Attestations(t *testing.T) {
+	store := memory.New()
+	artifactDigest := generateManifest(t, store)
+	artifactDesc, err := store.Resolve(t.Context(), artifactDigest)
+	require.NoError(t, err)
+
+	pushAttestation := func(artifactType string, subject *imagev1.Descriptor, envelope string) imagev1.Descriptor {
+		layerDesc := content.NewDescriptorFromBytes(MediaTypeDSSEEnvelope, []byte(envelope))
+		require.NoError(t, store.Push(t.Context(), layerDesc, bytes.NewReader([]byte(envelope))))
+		configDesc := content.NewDescriptorFromBytes(imagev1.MediaTypeEmptyJSON, []byte("{}"))
+		exists, err := store.Exists(t.Context(), configDesc)
+		require.NoError(t, err)
+		if !exists {
+			require.NoError(t, store.Push(t.Context(), configDesc, bytes.NewReader([]byte("{}"))))
+		}
+		manifestBlob, err := json.Marshal(imagev1.Manifest{
+			Versioned:    specs.Versioned{SchemaVersion: 2},
+			MediaType:    imagev1.MediaTypeImageManifest,
+			ArtifactType: artifactType,
+			Config:       configDesc,
+			Layers:       []imagev1.Descriptor{layerDesc},
+			Subject:      subject,
+		})
+		require.NoError(t, err)
+		manifestDesc := content.NewDescriptorFromBytes(imagev1.MediaTypeImageManifest, manifestBlob)
+		manifestDesc.ArtifactType = artifactType
+		require.NoError(t, store.Push(t.Context(), manifestDesc, bytes.NewReader(manifestBlob)))
+		return manifestDesc
+	}
+
+	c := newClientWithLock("", globalLock, store, nil, func(_ context.Context) error {
+		return nil
+	}, nil)
+
+	envelopes, err := c.Attestations(t.Context(), artifactDigest)
+	require.NoError(t, err)
+	require.Empty(t, envelopes)
+
+	pushAttestation(MediaTypeInTotoStatement, &artifactDesc, `{"payloadType":"referrer"}`)
+	pushAttestation("application/spdx+json", &artifactDesc, `{"payloadType":"sbom"}`)
+	cosignDesc := pushAttestation("", nil, `{"payloadType":"cosign"}`)
+	require.NoError(t, store.Tag(t.Context(), cosignDesc, fmt.Sprintf("sha256-%s.att", artifactDesc.Digest.Encoded())))
+
+	envelopes, err = c.Attestations(t.Context(), artifactDigest)
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{[]byte(`{"payloadType":"referrer"}`), []byte(`{"payloadType":"cosign"}`)}, envelopes)
+}
@@ -39,6 +39,74 @@ func (_m *Client) EXPECT() *Client_Expecter {
 	return &Client_Expecter{mock: &_m.Mock}
 }
 
+// Attestations provides a mock function for the type Client
+func (_mock *Client) Attestations(ctx context.Context, digest string) ([][]byte, error) {
+	ret := _mock.Called(ctx, digest)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Attestations")
+	}
+
+	var r0 [][]byte
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([][]byte, error)); ok {
+		return returnFunc(ctx, digest)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) [][]byte); ok {
+		r0 = returnFunc(ctx, digest)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([][]byte)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, digest)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Client_Attestations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Attestations'
+type Client_Attestations_Call struct {
+	*mock.Call
+}
+
+// Attestations is a helper method to define mock.On call
+//   - ctx context.Context
+//   - digest string
+func (_e *Client_Expecter) Attestations(ctx interface{}, digest interface{}) *Client_Attestations_Call {
+	return &Client_Attestations_Call{Call: _e.mock.On("Attestations", ctx, digest)}
+}
+
+func (_c *Client_Attestations_Call) Run(run func(ctx context.Context, digest string)) *Client_Attestations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Client_Attestations_Call) Return(bytess [][]byte, err error) *Client_Attestations_Call {
+	_c.Call.Return(bytess, err)
+	return _c
+}
+
+func (_c *Client_Attestations_Call) RunAndReturn(run func(ctx context.Context, digest string) ([][]byte, error)) *Client_Attestations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CleanCache provides a mock function for the type Client
 func (_mock *Client) CleanCache(revision string) error {
 	ret := _mock.Called(revision)
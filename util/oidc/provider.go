@@ -11,6 +11,7 @@ import (
 	log "github.com/sirupsen/logrus"
 	"golang.org/x/oauth2"
 
+	"github.com/argoproj/argo-cd/v3/util/fips"
 	"github.com/argoproj/argo-cd/v3/util/security"
 	"github.com/argoproj/argo-cd/v3/util/settings"
 )
@@ -156,6 +157,10 @@ func (p *providerImpl) verify(clientID, tokenString string, skipClientIDCheck bo
 		return nil, err
 	}
 	config := &gooidc.Config{ClientID: clientID, SkipClientIDCheck: skipClientIDCheck}
+	if fips.Enabled() {
+		// only the FIPS approved signature algorithms are accepted, instead of the ones advertised by the provider
+		config.SupportedSigningAlgs = fips.ApprovedJWTAlgorithms
+	}
 	verifier := prov.Verifier(config)
 	idToken, err := verifier.Verify(ctx, tokenString)
 	if err != nil {
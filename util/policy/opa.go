@@ -0,0 +1,308 @@
+package policy
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// RegoFileExtension is the extension of the files of the Rego modules
+	RegoFileExtension = ".rego"
+
+	// opaRequestTimeout is the timeout of the requests to the OPA server
+	opaRequestTimeout = 30 * time.Second
+	// maxCachedResults is the number of evaluation results cached by an OPA client before its cache is reset
+	maxCachedResults = 10000
+	// policiesPackage is the package under which the packages of the modules of each policy are namespaced
+	policiesPackage = "argocd_policies"
+)
+
+var (
+	packageClauseRegexp = regexp.MustCompile(`(?m)^(\s*package\s+)([^\s#]+)`)
+	dataImportRegexp    = regexp.MustCompile(`(?m)^(\s*import\s+)data\.`)
+)
+
+// violationRules are the rules reporting violations, and warningRules the rules reporting warnings, following the
+// conventions of conftest
+var (
+	violationRules = []string{"deny", "violation"}
+	warningRules   = []string{"warn"}
+)
+
+// Bundle is a bundle of Rego modules
+type Bundle struct {
+	// Modules contains the sources of the Rego modules by their file names
+	Modules map[string]string
+}
+
+// Digest returns the digest of the modules of the bundle
+func (b *Bundle) Digest() string {
+	h := sha256.New()
+	names := make([]string, 0, len(b.Modules))
+	for name := range b.Modules {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		_, _ = fmt.Fprintf(h, "%s\x00%s\x00", name, b.Modules[name])
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// LoadBundle loads the Rego modules of the files of the given directory and its subdirectories
+func LoadBundle(dir string) (*Bundle, error) {
+	bundle := &Bundle{Modules: map[string]string{}}
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(d.Name(), RegoFileExtension) || strings.HasSuffix(d.Name(), "_test"+RegoFileExtension) {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		name, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		bundle.Modules[filepath.ToSlash(name)] = string(data)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error loading Rego modules: %w", err)
+	}
+	if len(bundle.Modules) == 0 {
+		return nil, errors.New("no Rego module found")
+	}
+	return bundle, nil
+}
+
+// Result is the result of the evaluation of a policy against a manifest
+type Result struct {
+	// Violations contains the messages of the deny and violation rules
+	Violations []string
+	// Warnings contains the messages of the warn rules
+	Warnings []string
+}
+
+type loadedBundle struct {
+	digest  string
+	modules []string
+}
+
+// OPAClient evaluates Rego policies with an OPA server, through its REST API. The modules of the bundles are loaded
+// into the server when they change, and the results of the evaluations are cached.
+type OPAClient struct {
+	url    string
+	client *http.Client
+
+	lock    sync.Mutex
+	bundles map[string]loadedBundle
+	results map[string]*Result
+}
+
+// NewOPAClient returns a client of the OPA server with the given URL
+func NewOPAClient(serverURL string) *OPAClient {
+	return &OPAClient{
+		url:     strings.TrimSuffix(serverURL, "/"),
+		client:  &http.Client{Timeout: opaRequestTimeout},
+		bundles: map[string]loadedBundle{},
+		results: map[string]*Result{},
+	}
+}
+
+// Evaluate evaluates the rules of the given package against the given manifest, which is the input of the rules. The
+// modules of the bundle are loaded into the OPA server with the given policy ID as prefix, replacing the modules
+// previously loaded with the same prefix. The packages of the modules, and their imports of data documents, are
+// namespaced by policy ID, so that the policies can't read or override the rules of the other policies.
+func (c *OPAClient) Evaluate(ctx context.Context, policyID string, bundle *Bundle, pkg string, manifest map[string]any) (*Result, error) {
+	input, err := json.Marshal(map[string]any{"input": manifest})
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling input: %w", err)
+	}
+	digest := bundle.Digest()
+	resultKey := fmt.Sprintf("%x", sha256.Sum256([]byte(policyID+"\x00"+digest+"\x00"+pkg+"\x00"+string(input))))
+
+	c.lock.Lock()
+	if result, ok := c.results[resultKey]; ok {
+		c.lock.Unlock()
+		return result, nil
+	}
+	err = c.loadBundle(ctx, policyID, bundle, digest)
+	c.lock.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Result map[string]json.RawMessage `json:"result"`
+	}
+	dataPath := strings.ReplaceAll(policyNamespace(policyID)+"."+pkg, ".", "/")
+	if err := c.do(ctx, http.MethodPost, "/v1/data/"+dataPath, "application/json", input, &resp); err != nil {
+		return nil, fmt.Errorf("error evaluating package %s: %w", pkg, err)
+	}
+	if resp.Result == nil {
+		return nil, fmt.Errorf("package %s is not defined", pkg)
+	}
+	result := &Result{}
+	for _, rule := range violationRules {
+		result.Violations = append(result.Violations, ruleMessages(rule, resp.Result[rule])...)
+	}
+	for _, rule := range warningRules {
+		result.Warnings = append(result.Warnings, ruleMessages(rule, resp.Result[rule])...)
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if len(c.results) >= maxCachedResults {
+		c.results = map[string]*Result{}
+	}
+	c.results[resultKey] = result
+	return result, nil
+}
+
+// loadBundle loads the modules of the bundle into the OPA server, unless they are already loaded, and deletes the
+// modules of the previous version of the bundle which don't exist anymore
+func (c *OPAClient) loadBundle(ctx context.Context, policyID string, bundle *Bundle, digest string) error {
+	loaded := c.bundles[policyID]
+	if loaded.digest == digest {
+		return nil
+	}
+	namespace := policyNamespace(policyID)
+	var modules []string
+	for name, source := range bundle.Modules {
+		namespaced, err := namespaceModule(source, namespace)
+		if err != nil {
+			return fmt.Errorf("error loading Rego module %s: %w", name, err)
+		}
+		id := policyID + "/" + name
+		if err := c.do(ctx, http.MethodPut, "/v1/policies/"+escapePath(id), "text/plain", []byte(namespaced), nil); err != nil {
+			return fmt.Errorf("error loading Rego module %s: %w", name, err)
+		}
+		modules = append(modules, id)
+	}
+	for _, id := range loaded.modules {
+		if slices.Contains(modules, id) {
+			continue
+		}
+		if err := c.do(ctx, http.MethodDelete, "/v1/policies/"+escapePath(id), "", nil, nil); err != nil {
+			return fmt.Errorf("error deleting Rego module %s: %w", id, err)
+		}
+	}
+	c.bundles[policyID] = loadedBundle{digest: digest, modules: modules}
+	return nil
+}
+
+// policyNamespace returns the package under which the packages of the modules of a policy are namespaced
+func policyNamespace(policyID string) string {
+	sum := sha256.Sum256([]byte(policyID))
+	return policiesPackage + ".p" + hex.EncodeToString(sum[:8])
+}
+
+// namespaceModule prefixes the package of a Rego module, and the data documents it imports, with the given namespace
+func namespaceModule(source string, namespace string) (string, error) {
+	if len(packageClauseRegexp.FindAllStringIndex(source, -1)) != 1 {
+		return "", errors.New("the module must declare exactly one package")
+	}
+	source = packageClauseRegexp.ReplaceAllString(source, "${1}"+namespace+".${2}")
+	return dataImportRegexp.ReplaceAllString(source, "${1}data."+namespace+"."), nil
+}
+
+func (c *OPAClient) do(ctx context.Context, method, path, contentType string, body []byte, v any) error {
+	req, err := http.NewRequestWithContext(ctx, method, c.url+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		var opaErr struct {
+			Message string `json:"message"`
+			Errors  []struct {
+				Message string `json:"message"`
+			} `json:"errors"`
+		}
+		if json.Unmarshal(data, &opaErr) == nil && opaErr.Message != "" {
+			msg := opaErr.Message
+			for _, e := range opaErr.Errors {
+				msg += ": " + e.Message
+			}
+			return fmt.Errorf("OPA server returned %s: %s", resp.Status, msg)
+		}
+		return fmt.Errorf("OPA server returned %s", resp.Status)
+	}
+	if v == nil {
+		return nil
+	}
+	return json.Unmarshal(data, v)
+}
+
+// ruleMessages returns the messages of the values of a rule, which are either strings or objects with a msg field
+func ruleMessages(rule string, values json.RawMessage) []string {
+	if len(values) == 0 {
+		return nil
+	}
+	var items []json.RawMessage
+	if err := json.Unmarshal(values, &items); err != nil {
+		// the rule isn't a set, e.g. a boolean rule
+		var value bool
+		if json.Unmarshal(values, &value) == nil && !value {
+			return nil
+		}
+		return []string{fmt.Sprintf("%s is %s", rule, values)}
+	}
+	var messages []string
+	for _, item := range items {
+		var msg string
+		if json.Unmarshal(item, &msg) == nil {
+			messages = append(messages, msg)
+			continue
+		}
+		var obj struct {
+			Msg string `json:"msg"`
+		}
+		if json.Unmarshal(item, &obj) == nil && obj.Msg != "" {
+			messages = append(messages, obj.Msg)
+			continue
+		}
+		messages = append(messages, string(item))
+	}
+	slices.Sort(messages)
+	return messages
+}
+
+func escapePath(id string) string {
+	parts := strings.Split(id, "/")
+	for i := range parts {
+		parts[i] = url.PathEscape(parts[i])
+	}
+	return strings.Join(parts, "/")
+}
@@ -0,0 +1,182 @@
+package policy
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeOPAServer records the loaded modules and answers the queries of the main package, which denies the manifests
+// without a team label and warns about the manifests without an owner annotation
+type fakeOPAServer struct {
+	lock    sync.Mutex
+	modules map[string]string
+	queries int
+}
+
+func (s *fakeOPAServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	switch {
+	case r.Method == http.MethodPut:
+		body, _ := io.ReadAll(r.Body)
+		s.modules[r.URL.Path] = string(body)
+		_, _ = w.Write([]byte(`{}`))
+	case r.Method == http.MethodDelete:
+		delete(s.modules, r.URL.Path)
+		_, _ = w.Write([]byte(`{}`))
+	case strings.HasSuffix(r.URL.Path, "/main"):
+		s.queries++
+		var req struct {
+			Input struct {
+				Metadata struct {
+					Labels      map[string]string `json:"labels"`
+					Annotations map[string]string `json:"annotations"`
+				} `json:"metadata"`
+			} `json:"input"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		result := map[string]any{"deny": []any{}, "warn": []any{}}
+		if req.Input.Metadata.Labels["team"] == "" {
+			result["deny"] = []any{"missing team label"}
+		}
+		if req.Input.Metadata.Annotations["owner"] == "" {
+			result["warn"] = []any{map[string]any{"msg": "missing owner annotation"}}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"result": result})
+	case strings.HasSuffix(r.URL.Path, "/broken"):
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"code":"invalid_parameter","message":"error(s) occurred while compiling module(s)","errors":[{"message":"rego_parse_error: unexpected eof token"}]}`))
+	default:
+		_, _ = w.Write([]byte(`{}`))
+	}
+}
+
+func TestOPAClient_Evaluate(t *testing.T) {
+	opa := &fakeOPAServer{modules: map[string]string{}}
+	server := httptest.NewServer(opa)
+	defer server.Close()
+	client := NewOPAClient(server.URL)
+
+	bundle := &Bundle{Modules: map[string]string{"main.rego": "package main", "lib/labels.rego": "package lib"}}
+	manifest := map[string]any{"kind": "ConfigMap", "metadata": map[string]any{"name": "cm"}}
+
+	result, err := client.Evaluate(t.Context(), "argocd/default/labels", bundle, "main", manifest)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"missing team label"}, result.Violations)
+	assert.Equal(t, []string{"missing owner annotation"}, result.Warnings)
+	namespace := policyNamespace("argocd/default/labels")
+	assert.Equal(t, map[string]string{
+		"/v1/policies/argocd/default/labels/main.rego":       "package " + namespace + ".main",
+		"/v1/policies/argocd/default/labels/lib/labels.rego": "package " + namespace + ".lib",
+	}, opa.modules)
+
+	t.Run("results are cached", func(t *testing.T) {
+		_, err := client.Evaluate(t.Context(), "argocd/default/labels", bundle, "main", manifest)
+		require.NoError(t, err)
+		assert.Equal(t, 1, opa.queries)
+	})
+
+	t.Run("compliant manifest", func(t *testing.T) {
+		result, err := client.Evaluate(t.Context(), "argocd/default/labels", bundle, "main", map[string]any{
+			"metadata": map[string]any{"labels": map[string]any{"team": "a"}, "annotations": map[string]any{"owner": "b"}},
+		})
+		require.NoError(t, err)
+		assert.Empty(t, result.Violations)
+		assert.Empty(t, result.Warnings)
+	})
+
+	t.Run("bundle update replaces the modules", func(t *testing.T) {
+		updated := &Bundle{Modules: map[string]string{"main.rego": "package main\n"}}
+		_, err := client.Evaluate(t.Context(), "argocd/default/labels", updated, "main", manifest)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"/v1/policies/argocd/default/labels/main.rego": "package " + namespace + ".main\n"}, opa.modules)
+	})
+
+	t.Run("undefined package", func(t *testing.T) {
+		_, err := client.Evaluate(t.Context(), "argocd/default/labels", bundle, "undefined.pkg", manifest)
+		require.EqualError(t, err, "package undefined.pkg is not defined")
+	})
+
+	t.Run("policies are namespaced", func(t *testing.T) {
+		other := policyNamespace("argocd/other/labels")
+		assert.NotEqual(t, namespace, other)
+		_, err := client.Evaluate(t.Context(), "argocd/other/labels", bundle, "main", manifest)
+		require.NoError(t, err)
+		assert.Equal(t, "package "+other+".main", opa.modules["/v1/policies/argocd/other/labels/main.rego"])
+	})
+
+	t.Run("module without package", func(t *testing.T) {
+		_, err := client.Evaluate(t.Context(), "argocd/default/invalid", &Bundle{Modules: map[string]string{"main.rego": "deny := true"}}, "main", manifest)
+		require.EqualError(t, err, "error loading Rego module main.rego: the module must declare exactly one package")
+	})
+
+	t.Run("OPA error", func(t *testing.T) {
+		_, err := client.Evaluate(t.Context(), "argocd/default/labels", bundle, "broken", manifest)
+		require.ErrorContains(t, err, "error(s) occurred while compiling module(s): rego_parse_error: unexpected eof token")
+	})
+}
+
+func TestNamespaceModule(t *testing.T) {
+	source := `# labels policy
+package argocd.labels
+
+import data.lib.teams
+import rego.v1
+import input.metadata
+
+deny contains msg if {
+	not teams.valid(metadata.labels.team)
+	msg := "invalid team"
+}
+`
+	namespaced, err := namespaceModule(source, "argocd_policies.p1234")
+	require.NoError(t, err)
+	assert.Equal(t, `# labels policy
+package argocd_policies.p1234.argocd.labels
+
+import data.argocd_policies.p1234.lib.teams
+import rego.v1
+import input.metadata
+
+deny contains msg if {
+	not teams.valid(metadata.labels.team)
+	msg := "invalid team"
+}
+`, namespaced)
+
+	_, err = namespaceModule("package a\npackage b", "argocd_policies.p1234")
+	require.Error(t, err)
+}
+
+func TestRuleMessages(t *testing.T) {
+	assert.Nil(t, ruleMessages("deny", nil))
+	assert.Nil(t, ruleMessages("deny", json.RawMessage(`false`)))
+	assert.Equal(t, []string{"deny is true"}, ruleMessages("deny", json.RawMessage(`true`)))
+	assert.Equal(t, []string{"a", "b", `{"code":1}`}, ruleMessages("deny", json.RawMessage(`["b",{"msg":"a"},{"code":1}]`)))
+}
+
+func TestLoadBundle(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "lib"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "main.rego"), []byte("package main"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "main_test.rego"), []byte("package main"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "lib", "util.rego"), []byte("package lib"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("# policies"), 0o644))
+
+	bundle, err := LoadBundle(dir)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"main.rego": "package main", "lib/util.rego": "package lib"}, bundle.Modules)
+
+	_, err = LoadBundle(t.TempDir())
+	require.EqualError(t, err, "no Rego module found")
+}
@@ -0,0 +1,99 @@
+package profile
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/argoproj/argo-cd/v3/util/env"
+)
+
+// exportCPUProfileDuration is the duration of the CPU profiles sampled at each export
+const exportCPUProfileDuration = 10 * time.Second
+
+// ExportConfig configures the periodic export of profiles to a sink
+type ExportConfig struct {
+	// Address is the URL of the sink the profiles are posted to
+	Address string
+	// Interval is the interval at which profiles are captured and exported
+	Interval time.Duration
+	// Types are the types of the exported profiles
+	Types []string
+}
+
+// ExportConfigFromEnv returns the configuration of the periodic profile export from the environment
+func ExportConfigFromEnv() ExportConfig {
+	return ExportConfig{
+		Address:  env.StringFromEnv("ARGOCD_PROFILER_EXPORT_ADDRESS", ""),
+		Interval: env.ParseDurationFromEnv("ARGOCD_PROFILER_EXPORT_INTERVAL", time.Minute, exportCPUProfileDuration, 24*time.Hour),
+		Types:    env.StringsFromEnv("ARGOCD_PROFILER_EXPORT_TYPES", ProfileTypes, ","),
+	}
+}
+
+// StartExporter periodically captures the profiles of the component and posts them to the sink until the context is
+// done. Each profile is posted as the body of a request whose 'name', 'type', 'from' and 'until' query parameters hold
+// the component, the profile type and the time range of the profile as unix timestamps. Nothing is exported if no
+// sink address is configured.
+func StartExporter(ctx context.Context, component string, config ExportConfig) {
+	if config.Address == "" {
+		return
+	}
+	log.Infof("Exporting %v profiles to %s every %v", config.Types, config.Address, config.Interval)
+	go func() {
+		ticker := time.NewTicker(config.Interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			for _, profileType := range config.Types {
+				if err := exportProfile(ctx, http.DefaultClient, config.Address, component, profileType); err != nil {
+					log.Warnf("Failed to export %s profile: %v", profileType, err)
+				}
+			}
+		}
+	}()
+}
+
+func exportProfile(ctx context.Context, client *http.Client, address string, component string, profileType string) error {
+	var buf bytes.Buffer
+	from := time.Now()
+	if err := Capture(ctx, &buf, profileType, exportCPUProfileDuration); err != nil {
+		return err
+	}
+	until := time.Now()
+
+	sinkURL, err := url.Parse(address)
+	if err != nil {
+		return fmt.Errorf("invalid profile sink address %q: %w", address, err)
+	}
+	query := sinkURL.Query()
+	query.Set("name", component)
+	query.Set("type", profileType)
+	query.Set("from", strconv.FormatInt(from.Unix(), 10))
+	query.Set("until", strconv.FormatInt(until.Unix(), 10))
+	sinkURL.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sinkURL.String(), &buf)
+	if err != nil {
+		return fmt.Errorf("failed to create profile export request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post profile: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("profile sink responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package profile
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportProfile(t *testing.T) {
+	var received *http.Request
+	var body []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	err := exportProfile(t.Context(), srv.Client(), srv.URL+"/ingest?format=pprof", "argocd-repo-server", ProfileTypeGoroutine)
+	require.NoError(t, err)
+	require.NotNil(t, received)
+	assert.Equal(t, http.MethodPost, received.Method)
+	assert.Equal(t, "/ingest", received.URL.Path)
+	assert.Equal(t, "pprof", received.URL.Query().Get("format"))
+	assert.Equal(t, "argocd-repo-server", received.URL.Query().Get("name"))
+	assert.Equal(t, ProfileTypeGoroutine, received.URL.Query().Get("type"))
+	assert.NotEmpty(t, received.URL.Query().Get("from"))
+	assert.NotEmpty(t, received.URL.Query().Get("until"))
+	assert.NotEmpty(t, body)
+}
+
+func TestExportProfile_SinkError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	err := exportProfile(t.Context(), srv.Client(), srv.URL, "argocd-repo-server", ProfileTypeHeap)
+	require.ErrorContains(t, err, "profile sink responded with status 500")
+}
@@ -1,13 +1,41 @@
 package profile
 
 import (
+	"context"
+	"fmt"
+	"io"
 	"net/http"
 	"net/http/pprof"
 	"os"
+	"path/filepath"
+	runtimepprof "runtime/pprof"
+	"slices"
+	"strconv"
+	"time"
 
 	"github.com/argoproj/argo-cd/v3/util/env"
 )
 
+const (
+	// ProfileTypeCPU is the type of the CPU profiles, which sample the CPU usage during the capture
+	ProfileTypeCPU = "cpu"
+	// ProfileTypeHeap is the type of the heap profiles, which sample the memory allocations of live objects
+	ProfileTypeHeap = "heap"
+	// ProfileTypeGoroutine is the type of the goroutine profiles, which hold the stack traces of all goroutines
+	ProfileTypeGoroutine = "goroutine"
+
+	// CapturePath is the path of the endpoint capturing profiles, on the metrics port of each component
+	CapturePath = "/debug/profile/capture"
+
+	// DefaultCaptureDuration is the duration of the CPU profiles captured without an explicit duration
+	DefaultCaptureDuration = 30 * time.Second
+	// MaxCaptureDuration is the maximum duration of the captured CPU profiles
+	MaxCaptureDuration = 5 * time.Minute
+)
+
+// ProfileTypes are the types of the profiles which can be captured
+var ProfileTypes = []string{ProfileTypeCPU, ProfileTypeHeap, ProfileTypeGoroutine}
+
 var enableProfilerFilePath = env.StringFromEnv("ARGOCD_ENABLE_PROFILER_FILE_PATH", "/home/argocd/params/profiler.enabled")
 
 func wrapHandler(handler http.HandlerFunc) http.HandlerFunc {
@@ -27,4 +55,74 @@ func RegisterProfiler(mux *http.ServeMux) {
 	mux.HandleFunc("/debug/pprof/profile", wrapHandler(pprof.Profile))
 	mux.HandleFunc("/debug/pprof/symbol", wrapHandler(pprof.Symbol))
 	mux.HandleFunc("/debug/pprof/trace", wrapHandler(pprof.Trace))
+	mux.HandleFunc(CapturePath, wrapHandler(CaptureHandler(filepath.Base(os.Args[0]))))
+}
+
+// Capture writes a profile of the given type to w. CPU profiles are sampled for the given duration, or until the
+// context is done, while the other profiles are snapshots.
+func Capture(ctx context.Context, w io.Writer, profileType string, duration time.Duration) error {
+	switch profileType {
+	case ProfileTypeCPU:
+		if err := runtimepprof.StartCPUProfile(w); err != nil {
+			return fmt.Errorf("failed to start CPU profile: %w", err)
+		}
+		select {
+		case <-ctx.Done():
+		case <-time.After(duration):
+		}
+		runtimepprof.StopCPUProfile()
+		return nil
+	case ProfileTypeHeap, ProfileTypeGoroutine:
+		return runtimepprof.Lookup(profileType).WriteTo(w, 0)
+	}
+	return fmt.Errorf("unknown profile type %q, must be one of %v", profileType, ProfileTypes)
+}
+
+// ParseCaptureRequest returns the type and the duration of the profile requested by the 'type' and 'seconds' query
+// parameters of the request
+func ParseCaptureRequest(r *http.Request) (string, time.Duration, error) {
+	profileType := r.URL.Query().Get("type")
+	if profileType == "" {
+		profileType = ProfileTypeCPU
+	}
+	duration := DefaultCaptureDuration
+	if seconds := r.URL.Query().Get("seconds"); seconds != "" {
+		s, err := strconv.Atoi(seconds)
+		if err != nil || s <= 0 {
+			return "", 0, fmt.Errorf("invalid number of seconds %q", seconds)
+		}
+		duration = time.Duration(s) * time.Second
+	}
+	if duration > MaxCaptureDuration {
+		return "", 0, fmt.Errorf("profiles cannot be captured for more than %v", MaxCaptureDuration)
+	}
+	return profileType, duration, nil
+}
+
+// ProfileFileName returns the name of the file in which a profile of a component is downloaded
+func ProfileFileName(component string, profileType string, capturedAt time.Time) string {
+	return fmt.Sprintf("%s-%s-%s.pb.gz", component, profileType, capturedAt.UTC().Format("20060102T150405Z"))
+}
+
+// CaptureHandler returns a handler capturing the profile requested by the 'type' and 'seconds' query parameters and
+// downloading it as a file
+func CaptureHandler(component string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		profileType, duration, err := ParseCaptureRequest(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if !slices.Contains(ProfileTypes, profileType) {
+			http.Error(w, fmt.Sprintf("unknown profile type %q, must be one of %v", profileType, ProfileTypes), http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", ProfileFileName(component, profileType, time.Now())))
+		if err := Capture(r.Context(), w, profileType, duration); err != nil {
+			// nothing has been written yet if the profile could not be captured
+			w.Header().Del("Content-Disposition")
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	}
 }
@@ -1,11 +1,14 @@
 package profile
 
 import (
+	"bytes"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"testing"
+	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -44,3 +47,33 @@ func TestRegisterProfile_FileExist(t *testing.T) {
 	_ = f.Close()
 	_ = os.Remove(f.Name())
 }
+
+func TestCapture(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, Capture(t.Context(), &buf, ProfileTypeHeap, 0))
+	assert.NotEmpty(t, buf.Bytes())
+
+	buf.Reset()
+	require.NoError(t, Capture(t.Context(), &buf, ProfileTypeCPU, 100*time.Millisecond))
+	assert.NotEmpty(t, buf.Bytes())
+
+	require.ErrorContains(t, Capture(t.Context(), &buf, "unknown", 0), `unknown profile type "unknown"`)
+}
+
+func TestCaptureHandler(t *testing.T) {
+	srv := httptest.NewServer(CaptureHandler("argocd-server"))
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "?type=goroutine")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Regexp(t, `^attachment; filename="argocd-server-goroutine-\d{8}T\d{6}Z\.pb\.gz"$`, resp.Header.Get("Content-Disposition"))
+
+	for _, query := range []string{"?type=unknown", "?seconds=abc", "?seconds=-1", "?seconds=3600"} {
+		resp, err := http.Get(srv.URL + query)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, query)
+	}
+}
@@ -0,0 +1,226 @@
+package proxy
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// jumpHostDialTimeout is the timeout of the connections to the jump hosts
+const jumpHostDialTimeout = 30 * time.Second
+
+// jumpHostIdleTimeout is how long the SSH connection to a jump host is kept once no connection is tunneled through it
+// anymore. The idle jump hosts are then closed and forgotten.
+var jumpHostIdleTimeout = 10 * time.Minute
+
+// JumpHostConfig is the configuration of an SSH jump host (bastion) through which the connections are tunneled
+type JumpHostConfig struct {
+	// Address is the host:port address of the SSH server of the jump host
+	Address string
+	// Username is the user authenticating to the jump host
+	Username string
+	// SSHPrivateKey is the PEM-encoded private key authenticating the user to the jump host
+	SSHPrivateKey string
+	// KnownHosts contains the known_hosts entries of the jump host, used to verify its host key
+	KnownHosts string
+}
+
+// DialFunc dials a connection to the given address
+type DialFunc func(ctx context.Context, network, address string) (net.Conn, error)
+
+var (
+	jumpHostsLock sync.Mutex
+	jumpHosts     = map[JumpHostConfig]*jumpHost{}
+)
+
+// JumpHostDialer returns a function dialing the connections through the SSH jump host of the given configuration.
+// The SSH connection to a jump host is shared by all the dialers with the same configuration, is established again
+// when it is broken, and is closed once it has been idle for jumpHostIdleTimeout.
+func JumpHostDialer(config JumpHostConfig) (DialFunc, error) {
+	jumpHostsLock.Lock()
+	defer jumpHostsLock.Unlock()
+	if host, ok := jumpHosts[config]; ok {
+		return host.DialContext, nil
+	}
+
+	if config.Address == "" || config.Username == "" {
+		return nil, errors.New("the address and the username of the jump host are required")
+	}
+	signer, err := ssh.ParsePrivateKey([]byte(config.SSHPrivateKey))
+	if err != nil {
+		return nil, fmt.Errorf("error parsing SSH private key of jump host %s: %w", config.Address, err)
+	}
+	hostKeyCallback, err := knownHostKeysCallback(config.KnownHosts)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing known hosts of jump host %s: %w", config.Address, err)
+	}
+	host := &jumpHost{
+		key:     config,
+		address: config.Address,
+		config: &ssh.ClientConfig{
+			User:            config.Username,
+			Auth:            []ssh.AuthMethod{ssh.PublicKeys(signer)},
+			HostKeyCallback: hostKeyCallback,
+			Timeout:         jumpHostDialTimeout,
+		},
+	}
+	jumpHosts[config] = host
+	return host.DialContext, nil
+}
+
+// knownHostKeysCallback returns a callback accepting the host keys of the given known_hosts entries
+func knownHostKeysCallback(knownHosts string) (ssh.HostKeyCallback, error) {
+	var keys [][]byte
+	rest := []byte(knownHosts)
+	for {
+		_, _, key, _, next, err := ssh.ParseKnownHosts(rest)
+		if err != nil {
+			break
+		}
+		keys = append(keys, key.Marshal())
+		rest = next
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("no host key found")
+	}
+	return func(hostname string, _ net.Addr, key ssh.PublicKey) error {
+		marshaled := key.Marshal()
+		for _, known := range keys {
+			if bytes.Equal(known, marshaled) {
+				return nil
+			}
+		}
+		return fmt.Errorf("host key of jump host %s is not known", hostname)
+	}, nil
+}
+
+type jumpHost struct {
+	key     JumpHostConfig
+	address string
+	config  *ssh.ClientConfig
+
+	lock   sync.Mutex
+	client *ssh.Client
+	// conns is the number of open connections tunneled through the jump host
+	conns     int
+	idleTimer *time.Timer
+}
+
+// DialContext dials a connection through the jump host, reconnecting to the jump host once if its connection is broken
+func (h *jumpHost) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	client, err := h.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := client.DialContext(ctx, network, address)
+	if err == nil {
+		return h.opened(conn), nil
+	}
+	// the connection to the jump host might be broken, it is established again and the dial is retried
+	h.disconnect(client)
+	if client, err = h.connect(ctx); err != nil {
+		return nil, err
+	}
+	conn, err = client.DialContext(ctx, network, address)
+	if err != nil {
+		return nil, fmt.Errorf("error dialing %s through jump host %s: %w", address, h.address, err)
+	}
+	return h.opened(conn), nil
+}
+
+// opened counts a connection tunneled through the jump host until it is closed
+func (h *jumpHost) opened(conn net.Conn) net.Conn {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.conns++
+	if h.idleTimer != nil {
+		h.idleTimer.Stop()
+		h.idleTimer = nil
+	}
+	return &jumpHostConn{Conn: conn, host: h}
+}
+
+func (h *jumpHost) closed() {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.conns--
+	if h.conns == 0 {
+		h.idleTimer = time.AfterFunc(jumpHostIdleTimeout, h.closeIdle)
+	}
+}
+
+// closeIdle closes the SSH connection to the jump host and forgets the jump host if no connection was tunneled
+// through it since it became idle
+func (h *jumpHost) closeIdle() {
+	h.lock.Lock()
+	if h.conns > 0 {
+		h.lock.Unlock()
+		return
+	}
+	h.idleTimer = nil
+	client := h.client
+	h.client = nil
+	h.lock.Unlock()
+	if client != nil {
+		_ = client.Close()
+	}
+
+	jumpHostsLock.Lock()
+	defer jumpHostsLock.Unlock()
+	if jumpHosts[h.key] == h {
+		delete(jumpHosts, h.key)
+	}
+}
+
+// jumpHostConn is a connection tunneled through a jump host
+type jumpHostConn struct {
+	net.Conn
+	host      *jumpHost
+	closeOnce sync.Once
+}
+
+func (c *jumpHostConn) Close() error {
+	err := c.Conn.Close()
+	c.closeOnce.Do(c.host.closed)
+	return err
+}
+
+func (h *jumpHost) connect(ctx context.Context) (*ssh.Client, error) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	if h.client != nil {
+		return h.client, nil
+	}
+	dialer := &net.Dialer{Timeout: jumpHostDialTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", h.address)
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to jump host %s: %w", h.address, err)
+	}
+	sshConn, chans, reqs, err := ssh.NewClientConn(conn, h.address, h.config)
+	if err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("error establishing SSH connection to jump host %s: %w", h.address, err)
+	}
+	h.client = ssh.NewClient(sshConn, chans, reqs)
+	go func(client *ssh.Client) {
+		// the client is forgotten once the connection is closed, so that the next dial establishes it again
+		_ = client.Wait()
+		h.disconnect(client)
+	}(h.client)
+	return h.client, nil
+}
+
+func (h *jumpHost) disconnect(client *ssh.Client) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	if h.client == client {
+		h.client = nil
+	}
+	_ = client.Close()
+}
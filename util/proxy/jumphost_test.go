@@ -0,0 +1,184 @@
+package proxy
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+// startTestJumpHost starts an SSH server forwarding the direct-tcpip channels of the clients authenticated with the
+// given key, and returns its address and host key
+func startTestJumpHost(t *testing.T, clientKey ssh.PublicKey) (string, ssh.PublicKey) {
+	t.Helper()
+	_, hostPrivateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	hostSigner, err := ssh.NewSignerFromKey(hostPrivateKey)
+	require.NoError(t, err)
+	config := &ssh.ServerConfig{
+		PublicKeyCallback: func(_ ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			if string(key.Marshal()) != string(clientKey.Marshal()) {
+				return nil, assert.AnError
+			}
+			return nil, nil
+		},
+	}
+	config.AddHostKey(hostSigner)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				_, chans, reqs, err := ssh.NewServerConn(conn, config)
+				if err != nil {
+					return
+				}
+				go ssh.DiscardRequests(reqs)
+				for newChannel := range chans {
+					var target struct {
+						Host       string
+						Port       uint32
+						OriginHost string
+						OriginPort uint32
+					}
+					if newChannel.ChannelType() != "direct-tcpip" || ssh.Unmarshal(newChannel.ExtraData(), &target) != nil {
+						_ = newChannel.Reject(ssh.UnknownChannelType, "unsupported")
+						continue
+					}
+					targetConn, err := net.Dial("tcp", net.JoinHostPort(target.Host, fmt.Sprint(target.Port)))
+					if err != nil {
+						_ = newChannel.Reject(ssh.ConnectionFailed, err.Error())
+						continue
+					}
+					channel, channelReqs, err := newChannel.Accept()
+					if err != nil {
+						_ = targetConn.Close()
+						continue
+					}
+					go ssh.DiscardRequests(channelReqs)
+					go func() {
+						_, _ = io.Copy(channel, targetConn)
+						_ = channel.Close()
+					}()
+					go func() {
+						_, _ = io.Copy(targetConn, channel)
+						_ = targetConn.Close()
+					}()
+				}
+			}()
+		}
+	}()
+	return listener.Addr().String(), hostSigner.PublicKey()
+}
+
+func newTestClientKey(t *testing.T) (string, ssh.PublicKey) {
+	t.Helper()
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	block, err := ssh.MarshalPrivateKey(privateKey, "")
+	require.NoError(t, err)
+	signer, err := ssh.NewSignerFromKey(privateKey)
+	require.NoError(t, err)
+	return string(pem.EncodeToMemory(block)), signer.PublicKey()
+}
+
+func TestJumpHostDialer(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("through the jump host"))
+	}))
+	defer server.Close()
+
+	privateKey, publicKey := newTestClientKey(t)
+	address, hostKey := startTestJumpHost(t, publicKey)
+
+	t.Run("dials through the jump host", func(t *testing.T) {
+		dial, err := JumpHostDialer(JumpHostConfig{
+			Address:       address,
+			Username:      "argocd",
+			SSHPrivateKey: privateKey,
+			KnownHosts:    "jump-host " + string(ssh.MarshalAuthorizedKey(hostKey)),
+		})
+		require.NoError(t, err)
+		client := &http.Client{Transport: &http.Transport{DialContext: dial}}
+		resp, err := client.Get(server.URL)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		assert.Equal(t, "through the jump host", string(body))
+	})
+
+	t.Run("unknown host key", func(t *testing.T) {
+		_, otherKey := newTestClientKey(t)
+		dial, err := JumpHostDialer(JumpHostConfig{
+			Address:       address,
+			Username:      "argocd",
+			SSHPrivateKey: privateKey,
+			KnownHosts:    "jump-host " + string(ssh.MarshalAuthorizedKey(otherKey)),
+		})
+		require.NoError(t, err)
+		_, err = dial(t.Context(), "tcp", server.Listener.Addr().String())
+		require.ErrorContains(t, err, "is not known")
+	})
+
+	t.Run("invalid configuration", func(t *testing.T) {
+		_, err := JumpHostDialer(JumpHostConfig{Address: address, Username: "argocd", SSHPrivateKey: privateKey})
+		require.ErrorContains(t, err, "no host key found")
+		_, err = JumpHostDialer(JumpHostConfig{Address: address})
+		require.ErrorContains(t, err, "required")
+	})
+
+	t.Run("closes idle jump hosts", func(t *testing.T) {
+		defer func(timeout time.Duration) { jumpHostIdleTimeout = timeout }(jumpHostIdleTimeout)
+		jumpHostIdleTimeout = 10 * time.Millisecond
+		config := JumpHostConfig{
+			Address:       address,
+			Username:      "idle",
+			SSHPrivateKey: privateKey,
+			KnownHosts:    "jump-host " + string(ssh.MarshalAuthorizedKey(hostKey)),
+		}
+		dial, err := JumpHostDialer(config)
+		require.NoError(t, err)
+		conn, err := dial(t.Context(), "tcp", server.Listener.Addr().String())
+		require.NoError(t, err)
+
+		// the jump host is kept while a connection is open
+		time.Sleep(50 * time.Millisecond)
+		jumpHostsLock.Lock()
+		host := jumpHosts[config]
+		jumpHostsLock.Unlock()
+		require.NotNil(t, host)
+
+		require.NoError(t, conn.Close())
+		assert.Eventually(t, func() bool {
+			jumpHostsLock.Lock()
+			defer jumpHostsLock.Unlock()
+			_, ok := jumpHosts[config]
+			return !ok
+		}, time.Second, 10*time.Millisecond)
+		host.lock.Lock()
+		assert.Nil(t, host.client)
+		host.lock.Unlock()
+
+		// the dialers still connect again to forgotten jump hosts
+		conn, err = dial(t.Context(), "tcp", server.Listener.Addr().String())
+		require.NoError(t, err)
+		require.NoError(t, conn.Close())
+	})
+}
@@ -26,6 +26,20 @@ func UpsertEnv(cmd *exec.Cmd, proxy string, noProxy string) []string {
 	return append(envs, httpProxy(proxy), httpsProxy(proxy), noProxyVar(noProxy))
 }
 
+// WithCredentials returns the proxy URL authenticating with the given credentials, which override the credentials of
+// the URL, if any. The URL is returned as is if no username is given or if it can't be parsed.
+func WithCredentials(proxy string, username string, password string) string {
+	if proxy == "" || username == "" {
+		return proxy
+	}
+	u, err := url.Parse(proxy)
+	if err != nil || u.Host == "" {
+		return proxy
+	}
+	u.User = url.UserPassword(username, password)
+	return u.String()
+}
+
 // GetCallback returns the proxy callback function
 func GetCallback(proxy string, noProxy string) func(*http.Request) (*url.URL, error) {
 	if proxy != "" {
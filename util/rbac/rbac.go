@@ -69,6 +69,7 @@ const (
 	ResourceLogs              = "logs"
 	ResourceExec              = "exec"
 	ResourceExtensions        = "extensions"
+	ResourceProfiles          = "profiles"
 
 	// please add new items to Actions
 	ActionGet      = "get"
@@ -96,6 +97,7 @@ var (
 		ResourceLogs,
 		ResourceExec,
 		ResourceExtensions,
+		ResourceProfiles,
 	}
 	Actions = []string{
 		ActionGet,
@@ -19,7 +19,7 @@ const (
 
 type userStateStorage struct {
 	attempts            map[string]LoginAttempts
-	redis               *redis.Client
+	redis               redis.UniversalClient
 	revokedTokens       map[string]bool
 	recentRevokedTokens map[string]bool
 	lock                sync.RWMutex
@@ -28,7 +28,7 @@ type userStateStorage struct {
 
 var _ UserStateStorage = &userStateStorage{}
 
-func NewUserStateStorage(redis *redis.Client) *userStateStorage {
+func NewUserStateStorage(redis redis.UniversalClient) *userStateStorage {
 	return &userStateStorage{
 		attempts:            map[string]LoginAttempts{},
 		revokedTokens:       map[string]bool{},
@@ -82,10 +82,10 @@ func (storage *userStateStorage) loadRevokedTokensSafe() {
 	}
 }
 
-func (storage *userStateStorage) loadRevokedTokens() error {
+func scanRevokedTokens(ctx context.Context, client redis.Cmdable) (map[string]bool, error) {
 	redisRevokedTokens := map[string]bool{}
-	iterator := storage.redis.Scan(context.Background(), 0, revokedTokenPrefix+"*", 10000).Iterator()
-	for iterator.Next(context.Background()) {
+	iterator := client.Scan(ctx, 0, revokedTokenPrefix+"*", 10000).Iterator()
+	for iterator.Next(ctx) {
 		parts := strings.Split(iterator.Val(), "|")
 		if len(parts) != 2 {
 			log.Warnf("Unexpected redis key prefixed with '%s'. Must have token id after the prefix but got: '%s'.",
@@ -95,8 +95,29 @@ func (storage *userStateStorage) loadRevokedTokens() error {
 		}
 		redisRevokedTokens[parts[1]] = true
 	}
-	if iterator.Err() != nil {
-		return iterator.Err()
+	return redisRevokedTokens, iterator.Err()
+}
+
+func (storage *userStateStorage) loadRevokedTokens() error {
+	redisRevokedTokens := map[string]bool{}
+	var err error
+	if cluster, ok := storage.redis.(*redis.ClusterClient); ok {
+		// the keys of a cluster are spread across its nodes, each of which is scanned
+		var lock sync.Mutex
+		err = cluster.ForEachMaster(context.Background(), func(ctx context.Context, client *redis.Client) error {
+			tokens, err := scanRevokedTokens(ctx, client)
+			lock.Lock()
+			defer lock.Unlock()
+			for token := range tokens {
+				redisRevokedTokens[token] = true
+			}
+			return err
+		})
+	} else {
+		redisRevokedTokens, err = scanRevokedTokens(context.Background(), storage.redis)
+	}
+	if err != nil {
+		return err
 	}
 
 	storage.lock.Lock()
@@ -0,0 +1,230 @@
+package settings
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/argoproj/argo-cd/v3/util/hash"
+)
+
+// StatusPath is the path of the settings status on the metrics server of the components
+const StatusPath = "/debug/settings"
+
+// ConsumerStatus is the status of a subsystem which applies the settings live
+type ConsumerStatus struct {
+	// Name is the name of the subsystem
+	Name string `json:"name"`
+	// Version is the version of the settings last applied by the subsystem
+	Version string `json:"version,omitempty"`
+	// ReloadedAt is the time at which the subsystem last applied the settings
+	ReloadedAt *time.Time `json:"reloadedAt,omitempty"`
+	// Error is the error of the last reload of the settings by the subsystem
+	Error string `json:"error,omitempty"`
+}
+
+// Status is the version of the settings of a component, and of the settings applied by its subsystems
+type Status struct {
+	// Version is the version of the current settings
+	Version string `json:"version"`
+	// Consumers are the statuses of the subsystems which apply the settings live
+	Consumers []ConsumerStatus `json:"consumers"`
+}
+
+type settingsConsumer struct {
+	reload func() error
+	status ConsumerStatus
+}
+
+// consumers holds the subsystems notified of the changes of the settings
+type consumers struct {
+	// lock protects the list of consumers and their statuses
+	lock  sync.Mutex
+	items []*settingsConsumer
+	// reloadLock serializes the reloads, so that the consumers apply the versions of the settings in order
+	reloadLock sync.Mutex
+}
+
+// RegisterConsumer registers a subsystem which applies the settings live: the reload function is called with the
+// settings already loaded when they are registered, and then every time the config maps or secrets labeled with
+// app.kubernetes.io/part-of=argocd change. The returned function unregisters the subsystem.
+func (mgr *SettingsManager) RegisterConsumer(name string, reload func() error) func() {
+	consumer := &settingsConsumer{reload: reload, status: ConsumerStatus{Name: name}}
+	mgr.consumers.lock.Lock()
+	mgr.consumers.items = append(mgr.consumers.items, consumer)
+	mgr.consumers.lock.Unlock()
+	go mgr.reloadConsumers()
+	return func() {
+		mgr.consumers.lock.Lock()
+		defer mgr.consumers.lock.Unlock()
+		mgr.consumers.items = slices.DeleteFunc(mgr.consumers.items, func(c *settingsConsumer) bool {
+			return c == consumer
+		})
+	}
+}
+
+// reloadConsumers calls the reload function of the consumers which didn't apply the current version of the settings
+func (mgr *SettingsManager) reloadConsumers() {
+	mgr.consumers.reloadLock.Lock()
+	defer mgr.consumers.reloadLock.Unlock()
+
+	version, err := mgr.GetSettingsVersion()
+	if err != nil {
+		log.Warnf("Failed to get the version of the settings: %v", err)
+		return
+	}
+	mgr.consumers.lock.Lock()
+	items := slices.Clone(mgr.consumers.items)
+	mgr.consumers.lock.Unlock()
+
+	for _, consumer := range items {
+		mgr.consumers.lock.Lock()
+		applied := consumer.status.Version == version && consumer.status.Error == ""
+		mgr.consumers.lock.Unlock()
+		if applied {
+			continue
+		}
+		err := consumer.reload()
+		now := time.Now()
+		mgr.consumers.lock.Lock()
+		consumer.status.Version = version
+		consumer.status.ReloadedAt = &now
+		consumer.status.Error = ""
+		if err != nil {
+			consumer.status.Error = err.Error()
+		}
+		mgr.consumers.lock.Unlock()
+		if err != nil {
+			log.Warnf("Failed to reload the settings of %s: %v", consumer.status.Name, err)
+		} else {
+			log.Infof("Reloaded the settings of %s at version %s", consumer.status.Name, version)
+		}
+	}
+}
+
+// GetSettingsVersion returns the version of the settings, which is the hash of the resource versions of the config
+// maps and secrets labeled with app.kubernetes.io/part-of=argocd. The components watching the same settings have the
+// same version.
+func (mgr *SettingsManager) GetSettingsVersion() (string, error) {
+	if err := mgr.ensureSynced(false); err != nil {
+		return "", err
+	}
+	selector, err := labels.Parse(partOfArgoCDSelector)
+	if err != nil {
+		return "", fmt.Errorf("error parsing Argo CD selector %w", err)
+	}
+	configMaps, err := mgr.configmaps.ConfigMaps(mgr.namespace).List(selector)
+	if err != nil {
+		return "", fmt.Errorf("error listing config maps: %w", err)
+	}
+	secrets, err := mgr.secrets.Secrets(mgr.namespace).List(selector)
+	if err != nil {
+		return "", fmt.Errorf("error listing secrets: %w", err)
+	}
+	var versions []string
+	for _, cm := range configMaps {
+		versions = append(versions, "configmap/"+cm.Name+"="+cm.ResourceVersion)
+	}
+	for _, secret := range secrets {
+		versions = append(versions, "secret/"+secret.Name+"="+secret.ResourceVersion)
+	}
+	sort.Strings(versions)
+	return fmt.Sprintf("%08x", hash.FNVa(strings.Join(versions, ","))), nil
+}
+
+// GetStatus returns the version of the settings, and of the settings applied by the registered consumers
+func (mgr *SettingsManager) GetStatus() (*Status, error) {
+	version, err := mgr.GetSettingsVersion()
+	if err != nil {
+		return nil, err
+	}
+	status := &Status{Version: version, Consumers: []ConsumerStatus{}}
+	mgr.consumers.lock.Lock()
+	defer mgr.consumers.lock.Unlock()
+	for _, consumer := range mgr.consumers.items {
+		status.Consumers = append(status.Consumers, consumer.status)
+	}
+	return status, nil
+}
+
+// StatusHandler is an HTTP handler returning the status of the settings of the component
+func (mgr *SettingsManager) StatusHandler(w http.ResponseWriter, _ *http.Request) {
+	status, err := mgr.GetStatus()
+	writeStatus(w, status, err)
+}
+
+// RegisterStatusHandler registers the settings status handler on the mux
+func (mgr *SettingsManager) RegisterStatusHandler(mux *http.ServeMux) {
+	mux.HandleFunc(StatusPath, mgr.StatusHandler)
+}
+
+// ReceivedStatus is the status of the settings of a component which doesn't watch the settings, such as the repo
+// server, but receives them with the requests of the other components
+type ReceivedStatus struct {
+	lock   sync.Mutex
+	status Status
+}
+
+// NewReceivedStatus returns the status of the settings of a component which receives them with the requests
+func NewReceivedStatus() *ReceivedStatus {
+	return &ReceivedStatus{status: Status{Consumers: []ConsumerStatus{}}}
+}
+
+// Record records the version of the settings received with a request handled by the subsystem. Requests without
+// version, e.g. from older components, are ignored.
+func (s *ReceivedStatus) Record(name string, version string) {
+	if version == "" {
+		return
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.status.Version = version
+	for i := range s.status.Consumers {
+		consumer := &s.status.Consumers[i]
+		if consumer.Name != name {
+			continue
+		}
+		if consumer.Version != version {
+			now := time.Now()
+			consumer.Version = version
+			consumer.ReloadedAt = &now
+		}
+		return
+	}
+	now := time.Now()
+	s.status.Consumers = append(s.status.Consumers, ConsumerStatus{Name: name, Version: version, ReloadedAt: &now})
+}
+
+// GetStatus returns the version of the settings last received, and of the settings last received by each subsystem
+func (s *ReceivedStatus) GetStatus() *Status {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return &Status{Version: s.status.Version, Consumers: slices.Clone(s.status.Consumers)}
+}
+
+// StatusHandler is an HTTP handler returning the status of the settings received by the component
+func (s *ReceivedStatus) StatusHandler(w http.ResponseWriter, _ *http.Request) {
+	writeStatus(w, s.GetStatus(), nil)
+}
+
+// RegisterStatusHandler registers the settings status handler on the mux
+func (s *ReceivedStatus) RegisterStatusHandler(mux *http.ServeMux) {
+	mux.HandleFunc(StatusPath, s.StatusHandler)
+}
+
+func writeStatus(w http.ResponseWriter, status *Status, err error) {
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(status)
+}
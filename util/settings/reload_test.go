@@ -0,0 +1,108 @@
+package settings
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/argoproj/argo-cd/v3/common"
+)
+
+func TestSettingsManager_RegisterConsumer(t *testing.T) {
+	kubeClient, settingsManager := fixtures(map[string]string{"resource.customLabels": "team"})
+	version, err := settingsManager.GetSettingsVersion()
+	require.NoError(t, err)
+
+	var reloads atomic.Int32
+	var failing atomic.Bool
+	unregister := settingsManager.RegisterConsumer("test", func() error {
+		reloads.Add(1)
+		if failing.Load() {
+			return errors.New("reload failed")
+		}
+		return nil
+	})
+	consumerStatus := func() ConsumerStatus {
+		status, err := settingsManager.GetStatus()
+		require.NoError(t, err)
+		require.Len(t, status.Consumers, 1)
+		return status.Consumers[0]
+	}
+	require.Eventually(t, func() bool { return consumerStatus().Version == version }, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, int32(1), reloads.Load())
+
+	// the consumer is reloaded with the new version of the settings when they change
+	failing.Store(true)
+	cm, err := kubeClient.CoreV1().ConfigMaps("default").Get(t.Context(), common.ArgoCDConfigMapName, metav1.GetOptions{})
+	require.NoError(t, err)
+	cm.Data["resource.customLabels"] = "team,env"
+	cm.ResourceVersion = "2"
+	_, err = kubeClient.CoreV1().ConfigMaps("default").Update(t.Context(), cm, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	require.Eventually(t, func() bool { return consumerStatus().Error == "reload failed" }, 5*time.Second, 10*time.Millisecond)
+	newVersion, err := settingsManager.GetSettingsVersion()
+	require.NoError(t, err)
+	assert.NotEqual(t, version, newVersion)
+	assert.Equal(t, newVersion, consumerStatus().Version)
+	assert.Equal(t, int32(2), reloads.Load())
+
+	// the failed reloads are retried on the next change of the settings
+	failing.Store(false)
+	settingsManager.reloadConsumers()
+	assert.Empty(t, consumerStatus().Error)
+	assert.Equal(t, int32(3), reloads.Load())
+
+	// the consumers which applied the current version aren't reloaded again
+	settingsManager.reloadConsumers()
+	assert.Equal(t, int32(3), reloads.Load())
+
+	unregister()
+	status, err := settingsManager.GetStatus()
+	require.NoError(t, err)
+	assert.Empty(t, status.Consumers)
+}
+
+func TestSettingsManager_StatusHandler(t *testing.T) {
+	_, settingsManager := fixtures(nil)
+	mux := http.NewServeMux()
+	settingsManager.RegisterStatusHandler(mux)
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, StatusPath, http.NoBody))
+	require.Equal(t, http.StatusOK, w.Code)
+	version, err := settingsManager.GetSettingsVersion()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"version":"`+version+`","consumers":[]}`, w.Body.String())
+}
+
+func TestReceivedStatus(t *testing.T) {
+	status := NewReceivedStatus()
+	status.Record("manifest-generation", "")
+	assert.Empty(t, status.GetStatus().Version)
+
+	status.Record("manifest-generation", "v1")
+	reloadedAt := status.GetStatus().Consumers[0].ReloadedAt
+	status.Record("manifest-generation", "v1")
+	assert.Same(t, reloadedAt, status.GetStatus().Consumers[0].ReloadedAt)
+
+	status.Record("manifest-generation", "v2")
+	mux := http.NewServeMux()
+	status.RegisterStatusHandler(mux)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, StatusPath, http.NoBody))
+	require.Equal(t, http.StatusOK, w.Code)
+	var got Status
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+	assert.Equal(t, "v2", got.Version)
+	require.Len(t, got.Consumers, 1)
+	assert.Equal(t, "manifest-generation", got.Consumers[0].Name)
+	assert.Equal(t, "v2", got.Consumers[0].Version)
+}
@@ -14,6 +14,8 @@ import (
 	"net/url"
 	"path"
 	"reflect"
+	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
@@ -309,6 +311,20 @@ func GetKustomizeBinaryPath(ks *v1alpha1.KustomizeOptions, source v1alpha1.Appli
 	return "", nil
 }
 
+// GetHelmBinaryPath returns the path to the helm binary of the Helm version of the ApplicationSource, or an empty string
+// for the default binary when the source doesn't select a version registered in the HelmOptions.
+func GetHelmBinaryPath(ho *v1alpha1.HelmOptions, source v1alpha1.ApplicationSource) string {
+	if ho == nil || source.Helm == nil || source.Helm.Version == "" {
+		return ""
+	}
+	for _, ver := range ho.Versions {
+		if ver.Name == source.Helm.Version {
+			return ver.Path
+		}
+	}
+	return ""
+}
+
 // Credentials for accessing a Git repository
 type Repository struct {
 	// The URL to the repository
@@ -452,6 +468,8 @@ const (
 	settingsResourceTrackingMethodKey = "application.resourceTrackingMethod"
 	// allowedNodeLabelsKey is the key to the list of allowed node labels for the application pod view
 	allowedNodeLabelsKey = "application.allowedNodeLabels"
+	// settingsRevisionHistoryExportURLKey is the key of the URL the revision history purged from the applications is exported to
+	settingsRevisionHistoryExportURLKey = "application.revisionHistoryExportURL"
 	// settingsInstallationID holds the key for the instance installation ID
 	settingsInstallationID = "installationID"
 	// resourcesCustomizationsKey is the key to the map of resource overrides
@@ -482,6 +500,8 @@ const (
 	userSessionDurationKey = "users.session.duration"
 	// diffOptions is the key where diff options are configured
 	resourceCompareOptionsKey = "resource.compareoptions"
+	// resourcePlaintextSecretsKey is the key where the detection of plaintext Secrets in rendered manifests is configured
+	resourcePlaintextSecretsKey = "resource.plaintextSecrets"
 	// settingUICSSURLKey designates the key for user-defined CSS URL for UI customization
 	settingUICSSURLKey = "ui.cssurl"
 	// settingUIBannerContentKey designates the key for content of user-defined info banner for UI
@@ -516,19 +536,25 @@ const (
 	settingsMaxPodLogsToRender = "server.maxPodLogsToRender"
 	// helmValuesFileSchemesKey is the key to configure the list of supported helm values file schemas
 	helmValuesFileSchemesKey = "helm.valuesFileSchemes"
+	// helmPathPrefixKey is a helm path for a specific version
+	helmPathPrefixKey = "helm.path"
 	// execEnabledKey is the key to configure whether the UI exec feature is enabled
 	execEnabledKey = "exec.enabled"
 	// execShellsKey is the key to configure which shells are allowed for `exec` and in what order they are tried
 	execShellsKey = "exec.shells"
 	// oidcTLSInsecureSkipVerifyKey is the key to configure whether TLS cert verification is skipped for OIDC connections
 	oidcTLSInsecureSkipVerifyKey = "oidc.tls.insecure.skip.verify"
+	// oidcTokenExchangeAllowedURLsKey is the key to configure the comma separated token exchange endpoints at which the repositories may exchange the OIDC token of the workload
+	oidcTokenExchangeAllowedURLsKey = "oidc.tokenExchange.allowedURLs"
 	// ApplicationDeepLinks is the application deep link key
 	ApplicationDeepLinks = "application.links"
 	// ProjectDeepLinks is the project deep link key
 	ProjectDeepLinks = "project.links"
 	// ResourceDeepLinks is the resource deep link key
 	ResourceDeepLinks = "resource.links"
-	extensionConfig   = "extension.config"
+	// applicationBlueprintsKey is the key to configure the catalog of application blueprints
+	applicationBlueprintsKey = "application.blueprints"
+	extensionConfig          = "extension.config"
 	// RespectRBAC is the key to configure argocd to respect rbac while watching for resources
 	RespectRBAC            = "resource.respectRBAC"
 	RespectRBACValueStrict = "strict"
@@ -565,6 +591,8 @@ type SettingsManager struct {
 	mutex                 *sync.Mutex
 	initContextCancel     func()
 	reposOrClusterChanged func()
+	// consumers are the subsystems which apply the settings live, see RegisterConsumer
+	consumers consumers
 }
 
 type incompleteSettingsError struct {
@@ -592,6 +620,52 @@ type ArgoCDDiffOptions struct {
 	IgnoreDifferencesOnResourceUpdates bool `json:"ignoreDifferencesOnResourceUpdates,omitempty"`
 }
 
+const (
+	// PlaintextSecretsEnforcementBlock reports the plaintext Secrets as errors, which prevent the applications from being synced
+	PlaintextSecretsEnforcementBlock = "block"
+	// PlaintextSecretsEnforcementWarn reports the plaintext Secrets as warnings
+	PlaintextSecretsEnforcementWarn = "warn"
+)
+
+// PlaintextSecretsSettings configures the detection of the Secrets with plaintext values in the rendered manifests
+type PlaintextSecretsSettings struct {
+	// Enforcement is either block, the default, or warn
+	Enforcement string `json:"enforcement,omitempty"`
+	// AllowedAnnotations are the annotations of the Secrets whose values are injected by an allowed mechanism, e.g. a
+	// secret management plugin
+	AllowedAnnotations []string `json:"allowedAnnotations,omitempty"`
+	// AllowedValuePatterns are the regular expressions of the values which aren't secrets, e.g. the placeholders
+	// replaced by a secret management plugin
+	AllowedValuePatterns []string `json:"allowedValuePatterns,omitempty"`
+
+	allowedValueRegexps []*regexp.Regexp
+}
+
+// IsWarning returns whether the plaintext Secrets are only reported as warnings
+func (s *PlaintextSecretsSettings) IsWarning() bool {
+	return s.Enforcement == PlaintextSecretsEnforcementWarn
+}
+
+// IsAllowedAnnotated returns whether the given Secret annotations contain one of the allowed annotations
+func (s *PlaintextSecretsSettings) IsAllowedAnnotated(annotations map[string]string) bool {
+	for _, annotation := range s.AllowedAnnotations {
+		if _, ok := annotations[annotation]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// IsAllowedValue returns whether the given Secret value matches one of the allowed value patterns
+func (s *PlaintextSecretsSettings) IsAllowedValue(value string) bool {
+	for _, re := range s.allowedValueRegexps {
+		if re.MatchString(value) {
+			return true
+		}
+	}
+	return false
+}
+
 func (e *incompleteSettingsError) Error() string {
 	return e.message
 }
@@ -835,6 +909,16 @@ func (mgr *SettingsManager) GetInstallationID() (string, error) {
 	return argoCDCM.Data[settingsInstallationID], nil
 }
 
+// GetRevisionHistoryExportURL returns the URL the revision history items purged from the applications are posted to,
+// or an empty string if they aren't exported
+func (mgr *SettingsManager) GetRevisionHistoryExportURL() (string, error) {
+	argoCDCM, err := mgr.getConfigMap()
+	if err != nil {
+		return "", err
+	}
+	return argoCDCM.Data[settingsRevisionHistoryExportURLKey], nil
+}
+
 func (mgr *SettingsManager) GetPasswordPattern() (string, error) {
 	argoCDCM, err := mgr.getConfigMap()
 	if err != nil {
@@ -888,6 +972,22 @@ func (mgr *SettingsManager) GetDeepLinks(deeplinkType string) ([]DeepLink, error
 	return deepLinks, nil
 }
 
+// GetApplicationBlueprints returns the catalog of application blueprints
+func (mgr *SettingsManager) GetApplicationBlueprints() ([]v1alpha1.ApplicationBlueprint, error) {
+	argoCDCM, err := mgr.getConfigMap()
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving argocd-cm: %w", err)
+	}
+	blueprints := make([]v1alpha1.ApplicationBlueprint, 0)
+	if value, ok := argoCDCM.Data[applicationBlueprintsKey]; ok {
+		err := yaml.Unmarshal([]byte(value), &blueprints)
+		if err != nil {
+			return nil, fmt.Errorf("error unmarshalling application blueprints: %w", err)
+		}
+	}
+	return blueprints, nil
+}
+
 func (mgr *SettingsManager) GetEnabledSourceTypes() (map[string]bool, error) {
 	argoCDCM, err := mgr.getConfigMap()
 	if err != nil {
@@ -1127,6 +1227,36 @@ func (mgr *SettingsManager) GetResourceCompareOptions() (ArgoCDDiffOptions, erro
 	return diffOptions, nil
 }
 
+// GetPlaintextSecretsSettings loads the settings of the detection of plaintext Secrets from the ConfigMap. It returns
+// nil if the detection isn't enabled.
+func (mgr *SettingsManager) GetPlaintextSecretsSettings() (*PlaintextSecretsSettings, error) {
+	argoCDCM, err := mgr.getConfigMap()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get argo-cd config map: %w", err)
+	}
+	value, ok := argoCDCM.Data[resourcePlaintextSecretsKey]
+	if !ok {
+		return nil, nil
+	}
+	plaintextSecrets := &PlaintextSecretsSettings{}
+	if err := yaml.Unmarshal([]byte(value), plaintextSecrets); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %s: %w", resourcePlaintextSecretsKey, err)
+	}
+	switch plaintextSecrets.Enforcement {
+	case "", PlaintextSecretsEnforcementBlock, PlaintextSecretsEnforcementWarn:
+	default:
+		return nil, fmt.Errorf("invalid %s enforcement '%s', must be one of %s or %s", resourcePlaintextSecretsKey, plaintextSecrets.Enforcement, PlaintextSecretsEnforcementBlock, PlaintextSecretsEnforcementWarn)
+	}
+	for _, pattern := range plaintextSecrets.AllowedValuePatterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s allowed value pattern '%s': %w", resourcePlaintextSecretsKey, pattern, err)
+		}
+		plaintextSecrets.allowedValueRegexps = append(plaintextSecrets.allowedValueRegexps, re)
+	}
+	return plaintextSecrets, nil
+}
+
 // GetHelmSettings returns helm settings
 func (mgr *SettingsManager) GetHelmSettings() (*v1alpha1.HelmOptions, error) {
 	argoCDCM, err := mgr.getConfigMap()
@@ -1143,6 +1273,18 @@ func (mgr *SettingsManager) GetHelmSettings() (*v1alpha1.HelmOptions, error) {
 	} else {
 		helmOptions.ValuesFileSchemes = []string{"https", "http"}
 	}
+	// extract version and path from helm.path.<version>
+	for k, v := range argoCDCM.Data {
+		if strings.HasPrefix(k, helmPathPrefixKey+".") {
+			helmOptions.Versions = append(helmOptions.Versions, v1alpha1.HelmVersion{
+				Name: k[len(helmPathPrefixKey)+1:],
+				Path: v,
+			})
+		}
+	}
+	slices.SortFunc(helmOptions.Versions, func(a, b v1alpha1.HelmVersion) int {
+		return strings.Compare(a.Name, b.Name)
+	})
 	return helmOptions, nil
 }
 
@@ -1318,6 +1460,7 @@ func (mgr *SettingsManager) initialize(ctx context.Context) error {
 	log.Info("Configmap/secret informer synced")
 
 	tryNotify := func() {
+		go mgr.reloadConsumers()
 		newSettings, err := mgr.GetSettings()
 		if err != nil {
 			log.Warnf("Unable to parse updated settings: %v", err)
@@ -2282,6 +2425,21 @@ func (mgr *SettingsManager) GetMaxWebhookPayloadSize() int64 {
 	return maxPayloadSizeMB * 1024 * 1024
 }
 
+// IsOIDCTokenExchangeURLAllowed returns true if the repositories may exchange the OIDC token of the workload at the
+// given token exchange endpoint, which must be listed in argocd-cm
+func (mgr *SettingsManager) IsOIDCTokenExchangeURLAllowed(tokenURL string) (bool, error) {
+	argoCDCM, err := mgr.getConfigMap()
+	if err != nil {
+		return false, fmt.Errorf("error checking %s property in configmap: %w", oidcTokenExchangeAllowedURLsKey, err)
+	}
+	for _, allowedURL := range strings.Split(argoCDCM.Data[oidcTokenExchangeAllowedURLsKey], ",") {
+		if allowedURL = strings.TrimSpace(allowedURL); allowedURL != "" && allowedURL == tokenURL {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 // IsImpersonationEnabled returns true if application sync with impersonation feature is enabled in argocd-cm configmap
 func (mgr *SettingsManager) IsImpersonationEnabled() (bool, error) {
 	cm, err := mgr.getConfigMap()
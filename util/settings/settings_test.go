@@ -693,6 +693,53 @@ func TestGetResourceCompareOptions(t *testing.T) {
 	}
 }
 
+func TestGetPlaintextSecretsSettings(t *testing.T) {
+	t.Run("disabled by default", func(t *testing.T) {
+		_, settingsManager := fixtures(map[string]string{})
+		plaintextSecrets, err := settingsManager.GetPlaintextSecretsSettings()
+		require.NoError(t, err)
+		assert.Nil(t, plaintextSecrets)
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		_, settingsManager := fixtures(map[string]string{
+			"resource.plaintextSecrets": `
+enforcement: warn
+allowedAnnotations:
+- avp.kubernetes.io/path
+allowedValuePatterns:
+- ^<path:[^>]+>$
+`,
+		})
+		plaintextSecrets, err := settingsManager.GetPlaintextSecretsSettings()
+		require.NoError(t, err)
+		assert.True(t, plaintextSecrets.IsWarning())
+		assert.True(t, plaintextSecrets.IsAllowedAnnotated(map[string]string{"avp.kubernetes.io/path": "secret/data/app"}))
+		assert.False(t, plaintextSecrets.IsAllowedAnnotated(map[string]string{"other": "value"}))
+		assert.True(t, plaintextSecrets.IsAllowedValue("<path:secret/data/app#password>"))
+		assert.False(t, plaintextSecrets.IsAllowedValue("hunter2"))
+	})
+
+	t.Run("empty value enables blocking", func(t *testing.T) {
+		_, settingsManager := fixtures(map[string]string{"resource.plaintextSecrets": ""})
+		plaintextSecrets, err := settingsManager.GetPlaintextSecretsSettings()
+		require.NoError(t, err)
+		assert.False(t, plaintextSecrets.IsWarning())
+	})
+
+	t.Run("invalid enforcement", func(t *testing.T) {
+		_, settingsManager := fixtures(map[string]string{"resource.plaintextSecrets": "enforcement: audit"})
+		_, err := settingsManager.GetPlaintextSecretsSettings()
+		require.EqualError(t, err, "invalid resource.plaintextSecrets enforcement 'audit', must be one of block or warn")
+	})
+
+	t.Run("invalid pattern", func(t *testing.T) {
+		_, settingsManager := fixtures(map[string]string{"resource.plaintextSecrets": "allowedValuePatterns: ['(']"})
+		_, err := settingsManager.GetPlaintextSecretsSettings()
+		require.ErrorContains(t, err, "invalid resource.plaintextSecrets allowed value pattern '('")
+	})
+}
+
 func TestSettingsManager_GetKustomizeBuildOptions(t *testing.T) {
 	t.Run("Empty", func(t *testing.T) {
 		_, settingsManager := fixtures(map[string]string{})
@@ -814,6 +861,20 @@ func TestSettingsManager_GetEventLabelKeys(t *testing.T) {
 	}
 }
 
+func Test_GetHelmBinaryPath(t *testing.T) {
+	ho := &v1alpha1.HelmOptions{
+		Versions: []v1alpha1.HelmVersion{
+			{Name: "v3.14", Path: "path_v3.14"},
+			{Name: "v3.16", Path: "path_v3.16"},
+		},
+	}
+
+	assert.Empty(t, GetHelmBinaryPath(nil, v1alpha1.ApplicationSource{Helm: &v1alpha1.ApplicationSourceHelm{Version: "v3.14"}}))
+	assert.Empty(t, GetHelmBinaryPath(ho, v1alpha1.ApplicationSource{}))
+	assert.Empty(t, GetHelmBinaryPath(ho, v1alpha1.ApplicationSource{Helm: &v1alpha1.ApplicationSourceHelm{Version: "v3"}}))
+	assert.Equal(t, "path_v3.16", GetHelmBinaryPath(ho, v1alpha1.ApplicationSource{Helm: &v1alpha1.ApplicationSourceHelm{Version: "v3.16"}}))
+}
+
 func Test_GetKustomizeBinaryPath(t *testing.T) {
 	ko := &v1alpha1.KustomizeOptions{
 		BuildOptions: "--opt1 val1",
@@ -1460,9 +1521,10 @@ func TestGetEnableManifestGeneration(t *testing.T) {
 
 func TestGetHelmSettings(t *testing.T) {
 	testCases := []struct {
-		name     string
-		data     map[string]string
-		expected []string
+		name             string
+		data             map[string]string
+		expected         []string
+		expectedVersions []v1alpha1.HelmVersion
 	}{{
 		name:     "Default",
 		data:     map[string]string{},
@@ -1479,6 +1541,14 @@ func TestGetHelmSettings(t *testing.T) {
 			"helm.valuesFileSchemes": "",
 		},
 		expected: nil,
+	}, {
+		name: "Versions",
+		data: map[string]string{
+			"helm.path.v3.16": "/custom-tools/helm-v3.16",
+			"helm.path.v3.14": "/custom-tools/helm-v3.14",
+		},
+		expected:         []string{"http", "https"},
+		expectedVersions: []v1alpha1.HelmVersion{{Name: "v3.14", Path: "/custom-tools/helm-v3.14"}, {Name: "v3.16", Path: "/custom-tools/helm-v3.16"}},
 	}}
 
 	for i := range testCases {
@@ -1523,6 +1593,7 @@ func TestGetHelmSettings(t *testing.T) {
 			require.NoError(t, err)
 
 			assert.ElementsMatch(t, tc.expected, helmSettings.ValuesFileSchemes)
+			assert.Equal(t, tc.expectedVersions, helmSettings.Versions)
 		})
 	}
 }
@@ -1876,6 +1947,23 @@ func TestIsImpersonationEnabled(t *testing.T) {
 		"when user enables the flag in argocd-cm config map, IsImpersonationEnabled() must not return any error")
 }
 
+func TestIsOIDCTokenExchangeURLAllowed(t *testing.T) {
+	_, settingsManager := fixtures(map[string]string{})
+	allowed, err := settingsManager.IsOIDCTokenExchangeURLAllowed("https://sts.example.com/token")
+	require.NoError(t, err)
+	assert.False(t, allowed)
+
+	_, settingsManager = fixtures(map[string]string{
+		"oidc.tokenExchange.allowedURLs": "https://sts.example.com/token, https://git.example.com/oauth/token",
+	})
+	allowed, err = settingsManager.IsOIDCTokenExchangeURLAllowed("https://git.example.com/oauth/token")
+	require.NoError(t, err)
+	assert.True(t, allowed)
+	allowed, err = settingsManager.IsOIDCTokenExchangeURLAllowed("https://sts.example.com/token/other")
+	require.NoError(t, err)
+	assert.False(t, allowed)
+}
+
 func TestSettingsManager_GetHideSecretAnnotations(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -1948,3 +2036,47 @@ func TestSettingsManager_GetAllowedNodeLabels(t *testing.T) {
 		})
 	}
 }
+
+func TestSettingsManager_GetApplicationBlueprints(t *testing.T) {
+	t.Run("no blueprints", func(t *testing.T) {
+		_, settingsManager := fixtures(map[string]string{})
+		blueprints, err := settingsManager.GetApplicationBlueprints()
+		require.NoError(t, err)
+		assert.Empty(t, blueprints)
+	})
+
+	t.Run("blueprints", func(t *testing.T) {
+		_, settingsManager := fixtures(map[string]string{
+			applicationBlueprintsKey: `
+- name: web-service
+  description: A web service
+  projects: [team-*]
+  parameters:
+  - name: image
+    required: true
+  spec:
+    source:
+      repoURL: https://github.com/argoproj/argocd-example-apps
+      path: helm-guestbook
+    destination:
+      server: https://kubernetes.default.svc
+      namespace: '{{app.name}}'
+`,
+		})
+		blueprints, err := settingsManager.GetApplicationBlueprints()
+		require.NoError(t, err)
+		require.Len(t, blueprints, 1)
+		assert.Equal(t, "web-service", blueprints[0].Name)
+		assert.Equal(t, []string{"team-*"}, blueprints[0].Projects)
+		assert.Equal(t, []v1alpha1.ApplicationBlueprintParameter{{Name: "image", Required: true}}, blueprints[0].Parameters)
+		assert.Equal(t, "{{app.name}}", blueprints[0].Spec.Destination.Namespace)
+	})
+
+	t.Run("invalid blueprints", func(t *testing.T) {
+		_, settingsManager := fixtures(map[string]string{
+			applicationBlueprintsKey: "invalid",
+		})
+		_, err := settingsManager.GetApplicationBlueprints()
+		require.ErrorContains(t, err, "error unmarshalling application blueprints")
+	})
+}
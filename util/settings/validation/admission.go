@@ -0,0 +1,141 @@
+package validation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/argoproj/argo-cd/v3/common"
+	"github.com/argoproj/argo-cd/v3/util/settings"
+)
+
+// AdmissionPath is the path of the admission webhook validating the settings config maps on the API server
+const AdmissionPath = "/api/admission/settings"
+
+// maxAdmissionReviewSize is the maximum size of an admission review, a config map being at most 1MiB
+const maxAdmissionReviewSize = 3 * 1024 * 1024
+
+// configMapValidators are the validators of the settings stored in each of the config maps validated on admission
+var configMapValidators = map[string][]func(*settings.SettingsManager) (string, error){
+	common.ArgoCDConfigMapName: {
+		func(manager *settings.SettingsManager) (string, error) {
+			_, err := manager.GetResourceOverrides()
+			return "", err
+		},
+		func(manager *settings.SettingsManager) (string, error) {
+			_, err := manager.GetResourcesFilter()
+			return "", err
+		},
+		ValidateLuaCustomizations,
+		ValidateIgnoreDifferences,
+	},
+	common.ArgoCDRBACConfigMapName:          {ValidateRBAC},
+	common.ArgoCDNotificationsConfigMapName: {ValidateNotifications},
+}
+
+// newSettingsManager returns a settings manager reading the given config maps instead of the cluster, along with an
+// empty argocd-cm if it isn't given
+func newSettingsManager(ctx context.Context, namespace string, cms ...*corev1.ConfigMap) *settings.SettingsManager {
+	objs := []runtime.Object{}
+	hasArgoCDCM := false
+	for _, cm := range append(cms, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: common.ArgoCDConfigMapName}}) {
+		if cm.Name == common.ArgoCDConfigMapName {
+			if hasArgoCDCM {
+				continue
+			}
+			hasArgoCDCM = true
+		}
+		cm = cm.DeepCopy()
+		cm.Namespace = namespace
+		if cm.Labels == nil {
+			cm.Labels = map[string]string{}
+		}
+		cm.Labels["app.kubernetes.io/part-of"] = "argocd"
+		objs = append(objs, cm)
+	}
+	return settings.NewSettingsManager(ctx, fake.NewClientset(objs...), namespace)
+}
+
+// ValidateConfigMap validates the settings stored in a config map before it is persisted, so that malformed settings,
+// such as invalid Lua scripts, RBAC policies or ignore rules, are rejected instead of breaking the components at
+// runtime. Config maps other than argocd-cm, argocd-rbac-cm and argocd-notifications-cm are not validated.
+func ValidateConfigMap(ctx context.Context, cm *corev1.ConfigMap) error {
+	validators, ok := configMapValidators[cm.Name]
+	if !ok {
+		return nil
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	manager := newSettingsManager(ctx, cm.Namespace, cm)
+	var errs []string
+	for _, validator := range validators {
+		if _, err := validator(manager); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid %s: %s", cm.Name, strings.Join(errs, "\n"))
+	}
+	return nil
+}
+
+// AdmissionHandler is the handler of the validating admission webhook of the settings config maps
+type AdmissionHandler struct {
+	namespace string
+}
+
+// NewAdmissionHandler returns the handler of the validating admission webhook of the config maps of the given namespace
+func NewAdmissionHandler(namespace string) *AdmissionHandler {
+	return &AdmissionHandler{namespace: namespace}
+}
+
+// ServeHTTP decodes an admission review, validates the config map it contains and responds with the same review
+// completed with the decision
+func (h *AdmissionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	var review admissionv1.AdmissionReview
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxAdmissionReviewSize)).Decode(&review); err != nil {
+		http.Error(w, fmt.Sprintf("error decoding admission review: %v", err), http.StatusBadRequest)
+		return
+	}
+	if review.Request == nil {
+		http.Error(w, "admission review has no request", http.StatusBadRequest)
+		return
+	}
+	review.Response = h.review(r.Context(), review.Request)
+	review.Request = nil
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(review)
+}
+
+func (h *AdmissionHandler) review(ctx context.Context, req *admissionv1.AdmissionRequest) *admissionv1.AdmissionResponse {
+	res := &admissionv1.AdmissionResponse{UID: req.UID, Allowed: true}
+	if req.Namespace != h.namespace || req.Kind.Kind != "ConfigMap" || (req.Operation != admissionv1.Create && req.Operation != admissionv1.Update) {
+		return res
+	}
+	var cm corev1.ConfigMap
+	if err := json.Unmarshal(req.Object.Raw, &cm); err != nil {
+		res.Allowed = false
+		res.Result = &metav1.Status{Status: metav1.StatusFailure, Code: http.StatusBadRequest, Reason: metav1.StatusReasonBadRequest, Message: fmt.Sprintf("error decoding config map: %v", err)}
+		return res
+	}
+	cm.Namespace = req.Namespace
+	if err := ValidateConfigMap(ctx, &cm); err != nil {
+		log.WithFields(log.Fields{"configmap": cm.Name, "user": req.UserInfo.Username}).Infof("Rejected the update of the settings: %v", err)
+		res.Allowed = false
+		res.Result = &metav1.Status{Status: metav1.StatusFailure, Code: http.StatusUnprocessableEntity, Reason: metav1.StatusReasonInvalid, Message: err.Error()}
+	}
+	return res
+}
@@ -0,0 +1,134 @@
+package validation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/argoproj/argo-cd/v3/common"
+)
+
+func newConfigMap(name string, data map[string]string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "argocd"}, Data: data}
+}
+
+func TestValidateConfigMap(t *testing.T) {
+	t.Run("ValidArgoCDCM", func(t *testing.T) {
+		err := ValidateConfigMap(context.Background(), newConfigMap(common.ArgoCDConfigMapName, map[string]string{
+			"resource.customizations.health.apps_Deployment": `hs = {}
+hs.status = "Healthy"
+return hs`,
+			"resource.customizations.ignoreDifferences.apps_Deployment": `jqPathExpressions:
+- .spec.replicas`,
+		}))
+		require.NoError(t, err)
+	})
+
+	t.Run("InvalidLua", func(t *testing.T) {
+		err := ValidateConfigMap(context.Background(), newConfigMap(common.ArgoCDConfigMapName, map[string]string{
+			"resource.customizations.health.apps_Deployment": `hs = {
+return hs`,
+		}))
+		assert.ErrorContains(t, err, "invalid argocd-cm: invalid health.lua of apps/Deployment")
+	})
+
+	t.Run("InvalidIgnoreDifferences", func(t *testing.T) {
+		err := ValidateConfigMap(context.Background(), newConfigMap(common.ArgoCDConfigMapName, map[string]string{
+			"resource.customizations.ignoreDifferences.apps_Deployment": `jqPathExpressions:
+- .spec.[`,
+		}))
+		assert.ErrorContains(t, err, "JQ path expression '.spec.[' is invalid")
+	})
+
+	t.Run("InvalidResourceExclusions", func(t *testing.T) {
+		err := ValidateConfigMap(context.Background(), newConfigMap(common.ArgoCDConfigMapName, map[string]string{
+			"resource.exclusions": `kinds: [`,
+		}))
+		assert.ErrorContains(t, err, "error unmarshalling excluded resources")
+	})
+
+	t.Run("InvalidRBAC", func(t *testing.T) {
+		err := ValidateConfigMap(context.Background(), newConfigMap(common.ArgoCDRBACConfigMapName, map[string]string{
+			"policy.csv": `p, role:org-admin, applications, get`,
+		}))
+		assert.ErrorContains(t, err, "invalid argocd-rbac-cm: policy.csv line 1")
+	})
+
+	t.Run("OtherConfigMap", func(t *testing.T) {
+		err := ValidateConfigMap(context.Background(), newConfigMap("other", map[string]string{
+			"policy.csv": `p, role:org-admin, applications, get`,
+		}))
+		require.NoError(t, err)
+	})
+}
+
+func TestAdmissionHandler(t *testing.T) {
+	review := func(t *testing.T, namespace string, operation admissionv1.Operation, cm *corev1.ConfigMap) *admissionv1.AdmissionResponse {
+		t.Helper()
+		raw, err := json.Marshal(cm)
+		require.NoError(t, err)
+		body, err := json.Marshal(admissionv1.AdmissionReview{
+			TypeMeta: metav1.TypeMeta{APIVersion: "admission.k8s.io/v1", Kind: "AdmissionReview"},
+			Request: &admissionv1.AdmissionRequest{
+				UID:       "123",
+				Kind:      metav1.GroupVersionKind{Version: "v1", Kind: "ConfigMap"},
+				Name:      cm.Name,
+				Namespace: namespace,
+				Operation: operation,
+				Object:    runtime.RawExtension{Raw: raw},
+			},
+		})
+		require.NoError(t, err)
+		w := httptest.NewRecorder()
+		NewAdmissionHandler("argocd").ServeHTTP(w, httptest.NewRequest(http.MethodPost, AdmissionPath, bytes.NewReader(body)))
+		require.Equal(t, http.StatusOK, w.Code)
+		var res admissionv1.AdmissionReview
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+		assert.Equal(t, "AdmissionReview", res.Kind)
+		require.NotNil(t, res.Response)
+		assert.Equal(t, "123", string(res.Response.UID))
+		return res.Response
+	}
+	invalidRBAC := newConfigMap(common.ArgoCDRBACConfigMapName, map[string]string{"policy.csv": `p, role:org-admin, applications, get`})
+
+	t.Run("Valid", func(t *testing.T) {
+		res := review(t, "argocd", admissionv1.Update, newConfigMap(common.ArgoCDRBACConfigMapName, map[string]string{
+			"policy.csv": `p, role:org-admin, applications, *, */*, allow`,
+		}))
+		assert.True(t, res.Allowed)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		res := review(t, "argocd", admissionv1.Update, invalidRBAC)
+		assert.False(t, res.Allowed)
+		require.NotNil(t, res.Result)
+		assert.Equal(t, metav1.StatusReasonInvalid, res.Result.Reason)
+		assert.Contains(t, res.Result.Message, "policy.csv line 1")
+	})
+
+	t.Run("OtherNamespace", func(t *testing.T) {
+		res := review(t, "other", admissionv1.Update, invalidRBAC)
+		assert.True(t, res.Allowed)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		res := review(t, "argocd", admissionv1.Delete, invalidRBAC)
+		assert.True(t, res.Allowed)
+	})
+
+	t.Run("MalformedReview", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		NewAdmissionHandler("argocd").ServeHTTP(w, httptest.NewRequest(http.MethodPost, AdmissionPath, bytes.NewReader([]byte("{"))))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
@@ -0,0 +1,354 @@
+// Package validation validates the settings stored in the Argo CD config maps, without applying them
+package validation
+
+import (
+	"encoding/csv"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/expr-lang/expr"
+	"github.com/itchyny/gojq"
+	lua "github.com/yuin/gopher-lua"
+	"github.com/yuin/gopher-lua/parse"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/yaml"
+
+	"github.com/argoproj/argo-cd/v3/common"
+	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	"github.com/argoproj/argo-cd/v3/util/rbac"
+	"github.com/argoproj/argo-cd/v3/util/settings"
+)
+
+// errorLineRegex matches the line number reported by Lua ("line:3(column:1)") and YAML ("line 3:") errors
+var errorLineRegex = regexp.MustCompile(`line[: ](\d+)`)
+
+// withLineContext appends the offending line of the source to an error which reports a line number
+func withLineContext(err error, source string) error {
+	match := errorLineRegex.FindStringSubmatch(err.Error())
+	if match == nil {
+		return err
+	}
+	lineNum, _ := strconv.Atoi(match[1])
+	lines := strings.Split(source, "\n")
+	if lineNum < 1 || lineNum > len(lines) {
+		return err
+	}
+	return fmt.Errorf("%w\n%5d | %s", err, lineNum, lines[lineNum-1])
+}
+
+// compileLua checks that a Lua script is syntactically correct without executing it
+func compileLua(name, script string) error {
+	chunk, err := parse.Parse(strings.NewReader(script), name)
+	if err == nil {
+		_, err = lua.Compile(chunk, name)
+	}
+	if err != nil {
+		return withLineContext(err, script)
+	}
+	return nil
+}
+
+// sortedOverrideKeys returns the group/kind keys of resource overrides in a stable order
+func sortedOverrideKeys(overrides map[string]v1alpha1.ResourceOverride) []string {
+	keys := make([]string, 0, len(overrides))
+	for k := range overrides {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// ValidateLuaCustomizations compiles the health checks and actions of all resource customizations
+func ValidateLuaCustomizations(manager *settings.SettingsManager) (string, error) {
+	overrides, err := manager.GetResourceOverrides()
+	if err != nil {
+		return "", err
+	}
+	var errs []string
+	scripts := 0
+	for _, gk := range sortedOverrideKeys(overrides) {
+		override := overrides[gk]
+		if override.HealthLua != "" {
+			scripts++
+			if err := compileLua("health.lua", override.HealthLua); err != nil {
+				errs = append(errs, fmt.Sprintf("invalid health.lua of %s: %v", gk, err))
+			}
+		}
+		if override.Actions == "" {
+			continue
+		}
+		var actions v1alpha1.ResourceActions
+		if err := yaml.Unmarshal([]byte(override.Actions), &actions); err != nil {
+			errs = append(errs, fmt.Sprintf("invalid actions of %s: %v", gk, withLineContext(err, override.Actions)))
+			continue
+		}
+		if actions.ActionDiscoveryLua != "" {
+			scripts++
+			if err := compileLua("discovery.lua", actions.ActionDiscoveryLua); err != nil {
+				errs = append(errs, fmt.Sprintf("invalid discovery.lua of %s: %v", gk, err))
+			}
+		}
+		for _, definition := range actions.Definitions {
+			scripts++
+			if err := compileLua("action.lua", definition.ActionLua); err != nil {
+				errs = append(errs, fmt.Sprintf("invalid action.lua of action '%s' of %s: %v", definition.Name, gk, err))
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return "", fmt.Errorf("%s", strings.Join(errs, "\n"))
+	}
+	return fmt.Sprintf("%d Lua scripts", scripts), nil
+}
+
+// validateJSONPointer checks that a pointer follows RFC 6901
+func validateJSONPointer(pointer string) error {
+	if pointer != "" && !strings.HasPrefix(pointer, "/") {
+		return fmt.Errorf("JSON pointer '%s' must start with '/'", pointer)
+	}
+	for i := 0; i < len(pointer); i++ {
+		if pointer[i] == '~' && (i+1 >= len(pointer) || (pointer[i+1] != '0' && pointer[i+1] != '1')) {
+			return fmt.Errorf("JSON pointer '%s' contains an invalid escape sequence at position %d", pointer, i+1)
+		}
+	}
+	return nil
+}
+
+// validateJQPathExpression compiles a JQ path expression the same way the diff normalizer does
+func validateJQPathExpression(pathExpression string) error {
+	query, err := gojq.Parse(fmt.Sprintf("del(%s)", pathExpression))
+	if err == nil {
+		_, err = gojq.Compile(query)
+	}
+	if err != nil {
+		return fmt.Errorf("JQ path expression '%s' is invalid: %w", pathExpression, err)
+	}
+	return nil
+}
+
+func validateOverrideIgnoreDiff(field string, ignoreDiff v1alpha1.OverrideIgnoreDiff) []string {
+	var errs []string
+	for _, pointer := range ignoreDiff.JSONPointers {
+		if err := validateJSONPointer(pointer); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", field, err))
+		}
+	}
+	for _, pathExpression := range ignoreDiff.JQPathExpressions {
+		if err := validateJQPathExpression(pathExpression); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", field, err))
+		}
+	}
+	for _, manager := range ignoreDiff.ManagedFieldsManagers {
+		if strings.TrimSpace(manager) == "" {
+			errs = append(errs, field+": managed fields manager must not be empty")
+		}
+	}
+	return errs
+}
+
+// ValidateIgnoreDifferences checks the JSON pointers, JQ path expressions and managed fields managers of the
+// ignoreDifferences and ignoreResourceUpdates customizations
+func ValidateIgnoreDifferences(manager *settings.SettingsManager) (string, error) {
+	overrides, err := manager.GetResourceOverrides()
+	if err != nil {
+		return "", err
+	}
+	var errs []string
+	rules := 0
+	for _, gk := range sortedOverrideKeys(overrides) {
+		override := overrides[gk]
+		for field, ignoreDiff := range map[string]v1alpha1.OverrideIgnoreDiff{
+			"ignoreDifferences":     override.IgnoreDifferences,
+			"ignoreResourceUpdates": override.IgnoreResourceUpdates,
+		} {
+			rules += len(ignoreDiff.JSONPointers) + len(ignoreDiff.JQPathExpressions) + len(ignoreDiff.ManagedFieldsManagers)
+			for _, e := range validateOverrideIgnoreDiff(field, ignoreDiff) {
+				errs = append(errs, fmt.Sprintf("invalid %s of %s", e, gk))
+			}
+		}
+	}
+	if len(errs) > 0 {
+		sort.Strings(errs)
+		return "", fmt.Errorf("%s", strings.Join(errs, "\n"))
+	}
+	return fmt.Sprintf("%d ignore rules", rules), nil
+}
+
+// validateRBACPolicyLines checks every line of a policy CSV for the expected number of fields, a valid effect
+// and, if the regex match mode is used, valid regular expressions. Returns the number of policy lines.
+func validateRBACPolicyLines(key, policy, matchMode string) (int, []string) {
+	var errs []string
+	count := 0
+	for i, line := range strings.Split(policy, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		count++
+		lineErr := func(format string, args ...any) {
+			errs = append(errs, fmt.Sprintf("%s line %d: %s\n%5d | %s", key, i+1, fmt.Sprintf(format, args...), i+1, line))
+		}
+		reader := csv.NewReader(strings.NewReader(line))
+		reader.TrimLeadingSpace = true
+		record, err := reader.Read()
+		if err != nil {
+			lineErr("%v", err)
+			continue
+		}
+		for j := range record {
+			record[j] = strings.TrimSpace(record[j])
+		}
+		switch record[0] {
+		case "p":
+			if len(record) != 6 {
+				lineErr("expected 'p, subject, resource, action, object, effect' but got %d fields", len(record))
+				continue
+			}
+			if record[5] != "allow" && record[5] != "deny" {
+				lineErr("effect must be one of allow|deny, got '%s'", record[5])
+			}
+			if matchMode == rbac.RegexMatchMode {
+				for _, pattern := range record[2:5] {
+					if _, err := regexp.Compile(pattern); err != nil {
+						lineErr("invalid regular expression '%s': %v", pattern, err)
+					}
+				}
+			}
+		case "g":
+			if len(record) != 3 {
+				lineErr("expected 'g, subject, role' but got %d fields", len(record))
+			}
+		default:
+			lineErr("unknown policy type '%s', expected p or g", record[0])
+		}
+	}
+	return count, errs
+}
+
+// ValidateRBAC validates the match mode and policies of the argocd-rbac-cm ConfigMap, if it is provided
+func ValidateRBAC(manager *settings.SettingsManager) (string, error) {
+	cm, err := manager.GetConfigMapByName(common.ArgoCDRBACConfigMapName)
+	if apierrors.IsNotFound(err) {
+		return common.ArgoCDRBACConfigMapName + " is not provided", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	matchMode := cm.Data[rbac.ConfigMapMatchModeKey]
+	var errs []string
+	if matchMode != "" && matchMode != rbac.GlobMatchMode && matchMode != rbac.RegexMatchMode {
+		errs = append(errs, fmt.Sprintf("invalid %s '%s', must be one of %s|%s", rbac.ConfigMapMatchModeKey, matchMode, rbac.GlobMatchMode, rbac.RegexMatchMode))
+	}
+	var keys []string
+	for k := range cm.Data {
+		if strings.HasPrefix(k, "policy.") && strings.HasSuffix(k, ".csv") {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	lines := 0
+	for _, k := range keys {
+		count, lineErrs := validateRBACPolicyLines(k, cm.Data[k], matchMode)
+		lines += count
+		errs = append(errs, lineErrs...)
+	}
+	if len(errs) == 0 {
+		if err := rbac.ValidatePolicy(rbac.PolicyCSV(cm.Data)); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if len(errs) > 0 {
+		return "", fmt.Errorf("%s", strings.Join(errs, "\n"))
+	}
+	if matchMode == "" {
+		matchMode = rbac.GlobMatchMode
+	}
+	return fmt.Sprintf("%d policy lines (match mode: %s)", lines, matchMode), nil
+}
+
+// notificationTriggerCondition is the subset of a notification trigger condition which is validated
+type notificationTriggerCondition struct {
+	When string   `json:"when"`
+	Send []string `json:"send"`
+}
+
+// ValidateNotifications validates the templates, triggers, subscriptions and default triggers of the
+// argocd-notifications-cm ConfigMap, if it is provided
+func ValidateNotifications(manager *settings.SettingsManager) (string, error) {
+	cm, err := manager.GetConfigMapByName(common.ArgoCDNotificationsConfigMapName)
+	if apierrors.IsNotFound(err) {
+		return common.ArgoCDNotificationsConfigMapName + " is not provided", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	var errs []string
+	templates := map[string]bool{}
+	triggers := map[string][]notificationTriggerCondition{}
+	keys := make([]string, 0, len(cm.Data))
+	for k := range cm.Data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		value := cm.Data[k]
+		switch {
+		case strings.HasPrefix(k, "template."):
+			var template map[string]any
+			if err := yaml.Unmarshal([]byte(value), &template); err != nil {
+				errs = append(errs, fmt.Sprintf("invalid %s: %v", k, withLineContext(err, value)))
+			}
+			templates[strings.TrimPrefix(k, "template.")] = true
+		case strings.HasPrefix(k, "trigger."):
+			var conditions []notificationTriggerCondition
+			if err := yaml.Unmarshal([]byte(value), &conditions); err != nil {
+				errs = append(errs, fmt.Sprintf("invalid %s: %v", k, withLineContext(err, value)))
+				continue
+			}
+			for _, condition := range conditions {
+				if _, err := expr.Compile(condition.When); err != nil {
+					errs = append(errs, fmt.Sprintf("invalid condition '%s' of %s: %v", condition.When, k, err))
+				}
+			}
+			triggers[strings.TrimPrefix(k, "trigger.")] = conditions
+		case strings.HasPrefix(k, "service.") || k == "context" || k == "subscriptions" || k == "defaultTriggers":
+			var parsed any
+			if err := yaml.Unmarshal([]byte(value), &parsed); err != nil {
+				errs = append(errs, fmt.Sprintf("invalid %s: %v", k, withLineContext(err, value)))
+			}
+		}
+	}
+	for _, name := range sortedTriggerNames(triggers) {
+		for _, condition := range triggers[name] {
+			for _, template := range condition.Send {
+				if !templates[template] {
+					errs = append(errs, fmt.Sprintf("trigger.%s sends unknown template '%s'", name, template))
+				}
+			}
+		}
+	}
+	var defaultTriggers []string
+	if err := yaml.Unmarshal([]byte(cm.Data["defaultTriggers"]), &defaultTriggers); err == nil {
+		for _, trigger := range defaultTriggers {
+			if _, ok := triggers[trigger]; !ok {
+				errs = append(errs, fmt.Sprintf("defaultTriggers references unknown trigger '%s'", trigger))
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return "", fmt.Errorf("%s", strings.Join(errs, "\n"))
+	}
+	return fmt.Sprintf("%d templates, %d triggers", len(templates), len(triggers)), nil
+}
+
+func sortedTriggerNames(triggers map[string][]notificationTriggerCondition) []string {
+	names := make([]string, 0, len(triggers))
+	for name := range triggers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,90 @@
+package validation
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/argoproj/argo-cd/v3/common"
+)
+
+func TestValidateRBAC(t *testing.T) {
+	newRBACCM := func(data map[string]string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: common.ArgoCDRBACConfigMapName}, Data: data}
+	}
+
+	t.Run("Valid", func(t *testing.T) {
+		summary, err := ValidateRBAC(newSettingsManager(context.Background(), "default", newRBACCM(map[string]string{
+			"policy.csv": `# comment
+p, role:org-admin, applications, *, */*, allow
+g, my-org:team, role:org-admin`,
+		})))
+		require.NoError(t, err)
+		assert.Equal(t, "2 policy lines (match mode: glob)", summary)
+	})
+
+	t.Run("InvalidLines", func(t *testing.T) {
+		_, err := ValidateRBAC(newSettingsManager(context.Background(), "default", newRBACCM(map[string]string{
+			"policy.csv": `p, role:org-admin, applications, *, */*, allow
+p, role:org-admin, applications, get
+g, my-org:team, role:org-admin`,
+			"policy.overlay.csv": `p, role:readonly, applications, get, */*, permit`,
+		})))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "policy.csv line 2: expected 'p, subject, resource, action, object, effect' but got 4 fields")
+		assert.Contains(t, err.Error(), "    2 | p, role:org-admin, applications, get")
+		assert.Contains(t, err.Error(), "policy.overlay.csv line 1: effect must be one of allow|deny, got 'permit'")
+	})
+
+	t.Run("InvalidRegex", func(t *testing.T) {
+		_, err := ValidateRBAC(newSettingsManager(context.Background(), "default", newRBACCM(map[string]string{
+			"policy.matchMode": "regex",
+			"policy.csv":       `p, role:org-admin, applications, *, .*, allow`,
+		})))
+		assert.ErrorContains(t, err, "invalid regular expression '*'")
+	})
+}
+
+func TestValidateNotifications(t *testing.T) {
+	newNotificationsCM := func(data map[string]string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: common.ArgoCDNotificationsConfigMapName}, Data: data}
+	}
+
+	t.Run("Valid", func(t *testing.T) {
+		summary, err := ValidateNotifications(newSettingsManager(context.Background(), "default", newNotificationsCM(map[string]string{
+			"template.app-deployed": `message: Application {{.app.metadata.name}} is now running new version.`,
+			"trigger.on-deployed": `- when: app.status.operationState.phase in ['Succeeded'] and app.status.health.status == 'Healthy'
+  send: [app-deployed]`,
+			"defaultTriggers": `- on-deployed`,
+		})))
+		require.NoError(t, err)
+		assert.Equal(t, "1 templates, 1 triggers", summary)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := ValidateNotifications(newSettingsManager(context.Background(), "default", newNotificationsCM(map[string]string{
+			"trigger.on-deployed": `- when: app.status.operationState.phase in ['Succeeded'
+  send: [app-deployed]`,
+			"trigger.on-synced": `- when: app.status.sync.status == 'Synced'
+  send: [app-synced]`,
+			"defaultTriggers": `- on-created`,
+		})))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid condition 'app.status.operationState.phase in ['Succeeded'' of trigger.on-deployed")
+		assert.Contains(t, err.Error(), "trigger.on-synced sends unknown template 'app-synced'")
+		assert.Contains(t, err.Error(), "defaultTriggers references unknown trigger 'on-created'")
+	})
+}
+
+func TestWithLineContext(t *testing.T) {
+	err := withLineContext(errors.New("health.lua line:2(column:3) near 'end': syntax error"), "hs = {}\nend")
+	assert.EqualError(t, err, "health.lua line:2(column:3) near 'end': syntax error\n    2 | end")
+
+	err = withLineContext(errors.New("no line number"), "hs = {}")
+	assert.EqualError(t, err, "no line number")
+}
@@ -0,0 +1,258 @@
+package spiffe
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/argoproj/argo-cd/v3/common"
+	"github.com/argoproj/argo-cd/v3/util/env"
+	"github.com/argoproj/argo-cd/v3/util/glob"
+)
+
+const (
+	// CertFileName is the name of the file containing the X.509 SVID of the workload, followed by its intermediates
+	CertFileName = "tls.crt"
+	// KeyFileName is the name of the file containing the private key of the X.509 SVID
+	KeyFileName = "tls.key"
+	// BundleFileName is the name of the file containing the X.509 trust bundle of the trust domain
+	BundleFileName = "ca.crt"
+
+	// reloadInterval is the minimum interval between two checks of the SVID files for a rotation
+	reloadInterval = 10 * time.Second
+)
+
+var defaultSource = sync.OnceValues(func() (*Source, error) {
+	path := env.StringFromEnv(common.EnvSPIFFESVIDPath, "")
+	if path == "" {
+		return nil, nil
+	}
+	return NewSource(path, env.StringsFromEnv(common.EnvSPIFFEAllowedIDs, nil, ","))
+})
+
+// DefaultSource returns the Source of the SVID files in the directory configured with ARGOCD_SPIFFE_SVID_PATH, which
+// authorizes the peers whose SPIFFE IDs match the patterns configured with ARGOCD_SPIFFE_ALLOWED_IDS. It returns nil
+// if no directory is configured.
+func DefaultSource() (*Source, error) {
+	return defaultSource()
+}
+
+// Source provides the X.509 SVID of the workload and the trust bundle of its trust domain, from the files written by
+// the SPIFFE helper of SPIRE or by the cert-manager CSI driver. The files are read again when they're rotated.
+type Source struct {
+	dir        string
+	allowedIDs []string
+
+	lock      sync.RWMutex
+	cert      *tls.Certificate
+	id        *url.URL
+	bundle    *x509.CertPool
+	modTime   time.Time
+	checkedAt time.Time
+}
+
+// NewSource returns the Source of the SVID files in the given directory. The peers must belong to the trust domain of
+// the SVID and, if any pattern is given, have a SPIFFE ID matching one of them.
+func NewSource(dir string, allowedIDs []string) (*Source, error) {
+	for _, pattern := range allowedIDs {
+		if _, err := glob.MatchWithError(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid allowed SPIFFE ID pattern '%s': %w", pattern, err)
+		}
+	}
+	s := &Source{dir: dir, allowedIDs: allowedIDs}
+	modTime, err := s.getModTime()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.load(modTime); err != nil {
+		return nil, err
+	}
+	log.Infof("Loaded X.509 SVID of %s from %s", s.id, dir)
+	return s, nil
+}
+
+// ID returns the SPIFFE ID of the workload
+func (s *Source) ID() string {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.id.String()
+}
+
+// ServerTLSConfig returns the TLS configuration of a server presenting the SVID, which requires the clients to
+// present an SVID of an authorized workload
+func (s *Source) ServerTLSConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetCertificate: func(_ *tls.ClientHelloInfo) (*tls.Certificate, error) {
+			return s.getCertificate(), nil
+		},
+		ClientAuth:            tls.RequireAnyClientCert,
+		VerifyPeerCertificate: s.verifyPeerCertificate,
+	}
+}
+
+// ClientTLSConfig returns the TLS configuration of a client presenting the SVID, which requires the server to
+// present an SVID of an authorized workload
+func (s *Source) ClientTLSConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetClientCertificate: func(_ *tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			return s.getCertificate(), nil
+		},
+		// SVIDs identify workloads rather than host names, so the server certificate is verified against the trust
+		// bundle by verifyPeerCertificate instead.
+		InsecureSkipVerify:    true, //nolint:gosec
+		VerifyPeerCertificate: s.verifyPeerCertificate,
+	}
+}
+
+// IDFromCertificate returns the SPIFFE ID of an X.509 SVID, i.e. its single spiffe:// URI SAN
+func IDFromCertificate(cert *x509.Certificate) (*url.URL, error) {
+	if len(cert.URIs) != 1 {
+		return nil, fmt.Errorf("an X.509 SVID must have exactly one URI SAN, got %d", len(cert.URIs))
+	}
+	id := cert.URIs[0]
+	if id.Scheme != "spiffe" || id.Host == "" || id.User != nil || id.Port() != "" || id.RawQuery != "" || id.Fragment != "" {
+		return nil, fmt.Errorf("invalid SPIFFE ID '%s'", id)
+	}
+	return id, nil
+}
+
+func (s *Source) getCertificate() *tls.Certificate {
+	s.reloadIfRotated()
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.cert
+}
+
+func (s *Source) verifyPeerCertificate(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+	if len(rawCerts) == 0 {
+		return errors.New("the peer didn't present an X.509 SVID")
+	}
+	certs := make([]*x509.Certificate, len(rawCerts))
+	for i, raw := range rawCerts {
+		cert, err := x509.ParseCertificate(raw)
+		if err != nil {
+			return fmt.Errorf("error parsing the peer certificate: %w", err)
+		}
+		certs[i] = cert
+	}
+	s.reloadIfRotated()
+	s.lock.RLock()
+	bundle, ownID := s.bundle, s.id
+	s.lock.RUnlock()
+
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+	if _, err := certs[0].Verify(x509.VerifyOptions{
+		Roots:         bundle,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	}); err != nil {
+		return fmt.Errorf("error verifying the X.509 SVID of the peer: %w", err)
+	}
+	id, err := IDFromCertificate(certs[0])
+	if err != nil {
+		return err
+	}
+	if id.Host != ownID.Host {
+		return fmt.Errorf("SPIFFE ID '%s' of the peer doesn't belong to trust domain '%s'", id, ownID.Host)
+	}
+	if len(s.allowedIDs) == 0 {
+		return nil
+	}
+	for _, pattern := range s.allowedIDs {
+		if glob.Match(pattern, id.String()) {
+			return nil
+		}
+	}
+	return fmt.Errorf("SPIFFE ID '%s' of the peer isn't allowed", id)
+}
+
+// reloadIfRotated reads the SVID files again if they were modified since they were read. The previous SVID is kept if
+// the new files can't be read, e.g. while they're being written.
+func (s *Source) reloadIfRotated() {
+	s.lock.RLock()
+	checked := time.Since(s.checkedAt) < reloadInterval
+	s.lock.RUnlock()
+	if checked {
+		return
+	}
+	s.lock.Lock()
+	s.checkedAt = time.Now()
+	previous := s.modTime
+	s.lock.Unlock()
+
+	modTime, err := s.getModTime()
+	if err != nil {
+		log.Warnf("Failed to check the X.509 SVID files in %s: %v", s.dir, err)
+		return
+	}
+	if modTime.Equal(previous) {
+		return
+	}
+	if err := s.load(modTime); err != nil {
+		log.Warnf("Failed to reload the rotated X.509 SVID: %v", err)
+		return
+	}
+	log.Infof("Reloaded rotated X.509 SVID of %s from %s", s.ID(), s.dir)
+}
+
+// getModTime returns the latest modification time of the SVID files
+func (s *Source) getModTime() (time.Time, error) {
+	var modTime time.Time
+	for _, name := range []string{CertFileName, KeyFileName, BundleFileName} {
+		info, err := os.Stat(filepath.Join(s.dir, name))
+		if err != nil {
+			return time.Time{}, err
+		}
+		if info.ModTime().After(modTime) {
+			modTime = info.ModTime()
+		}
+	}
+	return modTime, nil
+}
+
+func (s *Source) load(modTime time.Time) error {
+	certPath := filepath.Join(s.dir, CertFileName)
+	cert, err := tls.LoadX509KeyPair(certPath, filepath.Join(s.dir, KeyFileName))
+	if err != nil {
+		return fmt.Errorf("error loading X.509 SVID from %s: %w", s.dir, err)
+	}
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		return fmt.Errorf("error parsing X.509 SVID %s: %w", certPath, err)
+	}
+	id, err := IDFromCertificate(leaf)
+	if err != nil {
+		return fmt.Errorf("error loading X.509 SVID %s: %w", certPath, err)
+	}
+	cert.Leaf = leaf
+	bundlePath := filepath.Join(s.dir, BundleFileName)
+	data, err := os.ReadFile(bundlePath)
+	if err != nil {
+		return fmt.Errorf("error reading trust bundle %s: %w", bundlePath, err)
+	}
+	bundle := x509.NewCertPool()
+	if !bundle.AppendCertsFromPEM(data) {
+		return fmt.Errorf("trust bundle %s doesn't contain any PEM encoded certificate", bundlePath)
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.cert = &cert
+	s.id = id
+	s.bundle = bundle
+	s.modTime = modTime
+	return nil
+}
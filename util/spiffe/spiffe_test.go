@@ -0,0 +1,186 @@
+package spiffe
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newTestCA(t *testing.T) *testCA {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "SPIFFE CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &testCA{cert: cert, key: key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+// writeSVID writes an X.509 SVID of the given SPIFFE IDs signed by the CA, and the trust bundle, to the directory
+func (ca *testCA) writeSVID(t *testing.T, dir string, ids ...string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	for _, id := range ids {
+		u, err := url.Parse(id)
+		require.NoError(t, err)
+		template.URIs = append(template.URIs, u)
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, CertFileName), pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, KeyFileName), pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, BundleFileName), ca.pem, 0o600))
+}
+
+func newTestSource(t *testing.T, ca *testCA, id string, allowedIDs ...string) *Source {
+	t.Helper()
+	dir := t.TempDir()
+	ca.writeSVID(t, dir, id)
+	s, err := NewSource(dir, allowedIDs)
+	require.NoError(t, err)
+	return s
+}
+
+// handshake returns the errors of the TLS handshake of a client and a server
+func handshake(t *testing.T, client *Source, server *Source) (error, error) {
+	t.Helper()
+	clientConn, serverConn := net.Pipe()
+	defer clientConn.Close()
+	defer serverConn.Close()
+	serverErr := make(chan error, 1)
+	go func() {
+		conn := tls.Server(serverConn, server.ServerTLSConfig())
+		err := conn.HandshakeContext(t.Context())
+		if err != nil {
+			_ = serverConn.Close()
+		}
+		serverErr <- err
+	}()
+	conn := tls.Client(clientConn, client.ClientTLSConfig())
+	clientErr := conn.HandshakeContext(t.Context())
+	_ = clientConn.Close()
+	return clientErr, <-serverErr
+}
+
+func TestNewSource(t *testing.T) {
+	ca := newTestCA(t)
+	s := newTestSource(t, ca, "spiffe://example.org/ns/argocd/sa/argocd-repo-server")
+	assert.Equal(t, "spiffe://example.org/ns/argocd/sa/argocd-repo-server", s.ID())
+
+	_, err := NewSource(t.TempDir(), nil)
+	require.ErrorContains(t, err, "no such file or directory")
+
+	dir := t.TempDir()
+	ca.writeSVID(t, dir, "https://example.org/argocd")
+	_, err = NewSource(dir, nil)
+	require.ErrorContains(t, err, "invalid SPIFFE ID 'https://example.org/argocd'")
+
+	dir = t.TempDir()
+	ca.writeSVID(t, dir, "spiffe://example.org/a", "spiffe://example.org/b")
+	_, err = NewSource(dir, nil)
+	require.ErrorContains(t, err, "an X.509 SVID must have exactly one URI SAN, got 2")
+
+	dir = t.TempDir()
+	ca.writeSVID(t, dir, "spiffe://example.org/a")
+	_, err = NewSource(dir, []string{"spiffe://example.org/[a"})
+	require.ErrorContains(t, err, "invalid allowed SPIFFE ID pattern 'spiffe://example.org/[a'")
+}
+
+func TestSource_mTLS(t *testing.T) {
+	ca := newTestCA(t)
+	server := newTestSource(t, ca, "spiffe://example.org/ns/argocd/sa/argocd-repo-server", "spiffe://example.org/ns/argocd/sa/argocd-*")
+
+	t.Run("authorized client", func(t *testing.T) {
+		client := newTestSource(t, ca, "spiffe://example.org/ns/argocd/sa/argocd-application-controller")
+		clientErr, serverErr := handshake(t, client, server)
+		require.NoError(t, clientErr)
+		require.NoError(t, serverErr)
+	})
+
+	t.Run("client not allowed", func(t *testing.T) {
+		client := newTestSource(t, ca, "spiffe://example.org/ns/default/sa/default")
+		_, serverErr := handshake(t, client, server)
+		require.ErrorContains(t, serverErr, "SPIFFE ID 'spiffe://example.org/ns/default/sa/default' of the peer isn't allowed")
+	})
+
+	t.Run("client of another trust domain", func(t *testing.T) {
+		client := newTestSource(t, ca, "spiffe://other.org/ns/argocd/sa/argocd-server")
+		clientErr, _ := handshake(t, client, server)
+		require.ErrorContains(t, clientErr, "SPIFFE ID 'spiffe://example.org/ns/argocd/sa/argocd-repo-server' of the peer doesn't belong to trust domain 'other.org'")
+	})
+
+	t.Run("untrusted client", func(t *testing.T) {
+		client := newTestSource(t, newTestCA(t), "spiffe://example.org/ns/argocd/sa/argocd-server")
+		clientErr, _ := handshake(t, client, server)
+		require.ErrorContains(t, clientErr, "error verifying the X.509 SVID of the peer")
+	})
+}
+
+func TestSource_Rotation(t *testing.T) {
+	ca := newTestCA(t)
+	dir := t.TempDir()
+	ca.writeSVID(t, dir, "spiffe://example.org/argocd-server")
+	s, err := NewSource(dir, nil)
+	require.NoError(t, err)
+	cert := s.getCertificate()
+
+	ca.writeSVID(t, dir, "spiffe://example.org/argocd-server-rotated")
+	future := time.Now().Add(time.Minute)
+	for _, name := range []string{CertFileName, KeyFileName, BundleFileName} {
+		require.NoError(t, os.Chtimes(filepath.Join(dir, name), future, future))
+	}
+	assert.Same(t, cert, s.getCertificate(), "the files must not be checked again before the reload interval")
+
+	s.checkedAt = time.Time{}
+	assert.NotSame(t, cert, s.getCertificate())
+	assert.Equal(t, "spiffe://example.org/argocd-server-rotated", s.ID())
+
+	// the previous SVID is kept while the files are invalid
+	require.NoError(t, os.WriteFile(filepath.Join(dir, KeyFileName), []byte("invalid"), 0o600))
+	future = future.Add(time.Minute)
+	require.NoError(t, os.Chtimes(filepath.Join(dir, KeyFileName), future, future))
+	s.checkedAt = time.Time{}
+	rotated := s.getCertificate()
+	require.NotNil(t, rotated)
+	assert.Equal(t, "spiffe://example.org/argocd-server-rotated", s.ID())
+}
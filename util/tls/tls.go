@@ -22,6 +22,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/argoproj/argo-cd/v3/util/env"
+	"github.com/argoproj/argo-cd/v3/util/fips"
 )
 
 const (
@@ -129,6 +130,10 @@ func getTLSConfigCustomizer(minVersionStr, maxVersionStr, tlsCiphersStr string)
 	if minVersion > maxVersion {
 		return nil, fmt.Errorf("minimum TLS version %s must not be higher than maximum TLS version %s", minVersionStr, maxVersionStr)
 	}
+	fipsEnabled := fips.Enabled()
+	if fipsEnabled && minVersion < fips.MinTLSVersion {
+		return nil, fmt.Errorf("minimum TLS version %s is not allowed in FIPS mode, must be at least %s", minVersionStr, tls.VersionName(fips.MinTLSVersion))
+	}
 
 	// Cipher suites for TLSv1.3 are not configurable
 	if minVersion == tls.VersionTLS13 {
@@ -152,6 +157,13 @@ func getTLSConfigCustomizer(minVersionStr, maxVersionStr, tlsCiphersStr string)
 		if err != nil {
 			return nil, fmt.Errorf("error retrieving TLS cipher suites: %w", err)
 		}
+		if fipsEnabled {
+			for _, id := range cipherSuites {
+				if !fips.IsCipherSuiteApproved(id) {
+					return nil, fmt.Errorf("TLS cipher suite %s is not allowed in FIPS mode", tls.CipherSuiteName(id))
+				}
+			}
+		}
 	} else {
 		cipherSuites = make([]uint16, 0)
 	}
@@ -160,6 +172,9 @@ func getTLSConfigCustomizer(minVersionStr, maxVersionStr, tlsCiphersStr string)
 		config.MinVersion = minVersion
 		config.MaxVersion = maxVersion
 		config.CipherSuites = cipherSuites
+		if fipsEnabled {
+			config.CurvePreferences = fips.ApprovedCurves
+		}
 	}, nil
 }
 
@@ -15,6 +15,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/argoproj/argo-cd/v3/common"
 )
 
 var chain = `-----BEGIN CERTIFICATE-----
@@ -357,6 +359,23 @@ func TestGetTLSConfigCustomizer(t *testing.T) {
 	})
 }
 
+func TestGetTLSConfigCustomizer_FIPS(t *testing.T) {
+	t.Setenv(common.EnvFIPSMode, "true")
+
+	cfunc, err := getTLSConfigCustomizer(DefaultTLSMinVersion, DefaultTLSMaxVersion, DefaultTLSCipherSuite)
+	require.NoError(t, err)
+	config := tls.Config{}
+	cfunc(&config)
+	assert.Equal(t, []uint16{tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384}, config.CipherSuites)
+	assert.Equal(t, []tls.CurveID{tls.CurveP256, tls.CurveP384, tls.CurveP521}, config.CurvePreferences)
+
+	_, err = getTLSConfigCustomizer("1.1", DefaultTLSMaxVersion, DefaultTLSCipherSuite)
+	require.EqualError(t, err, "minimum TLS version 1.1 is not allowed in FIPS mode, must be at least TLS 1.2")
+
+	_, err = getTLSConfigCustomizer(DefaultTLSMinVersion, DefaultTLSMaxVersion, "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256:TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256")
+	require.EqualError(t, err, "TLS cipher suite TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256 is not allowed in FIPS mode")
+}
+
 func TestBestEffortSystemCertPool(t *testing.T) {
 	pool := BestEffortSystemCertPool()
 	assert.NotNil(t, pool)
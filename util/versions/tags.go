@@ -71,3 +71,16 @@ func IsConstraint(revision string) bool {
 	_, err := semver.NewConstraint(revision)
 	return err == nil
 }
+
+// Satisfies returns true if the given version satisfies the given semver constraint
+func Satisfies(version, constraint string) bool {
+	v, err := semver.NewVersion(version)
+	if err != nil {
+		return false
+	}
+	constraints, err := semver.NewConstraint(constraint)
+	if err != nil {
+		return false
+	}
+	return constraints.Check(v)
+}
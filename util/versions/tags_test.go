@@ -89,3 +89,11 @@ func TestTags_IsConstraint(t *testing.T) {
 		assert.True(t, IsConstraint("*"))
 	})
 }
+
+func TestTags_Satisfies(t *testing.T) {
+	assert.True(t, Satisfies("0.7.2", "0.7.*"))
+	assert.True(t, Satisfies("v0.7.2", ">0.5.0,<0.8.0"))
+	assert.False(t, Satisfies("0.8.0", "0.7.*"))
+	assert.False(t, Satisfies("latest", "0.7.*"))
+	assert.False(t, Satisfies("0.7.2", "not a constraint"))
+}
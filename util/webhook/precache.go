@@ -0,0 +1,229 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	"github.com/argoproj/argo-cd/v3/reposerver/apiclient"
+	"github.com/argoproj/argo-cd/v3/util/argo"
+	"github.com/argoproj/argo-cd/v3/util/gpg"
+	utilio "github.com/argoproj/argo-cd/v3/util/io"
+)
+
+// precacheQueueSize is the maximum number of applications waiting for their manifests to be precached
+const precacheQueueSize = 1000
+
+// precacheTimeout is the maximum duration an application refreshed by a push event waits for its manifests to be
+// precached before being refreshed
+var precacheTimeout = 1 * time.Minute
+
+// precacheRequest is an application waiting for its manifests to be precached, which is refreshed once they are or
+// once the precache timeout expires, whichever comes first
+type precacheRequest struct {
+	app      *v1alpha1.Application
+	deadline time.Time
+	refresh  func()
+}
+
+// startPrecacheWorkerPool starts the workers rendering the manifests of the applications refreshed by push events
+func (a *ArgoCDWebhookHandler) startPrecacheWorkerPool(precacheParallelism int) {
+	for i := 0; i < precacheParallelism; i++ {
+		a.Add(1)
+		go func() {
+			defer a.Done()
+			for {
+				req, ok := <-a.precacheQueue
+				if !ok {
+					return
+				}
+				ctx, cancel := context.WithDeadline(context.Background(), req.deadline)
+				// the application was already refreshed if the precache timeout expired while it was queued
+				if ctx.Err() == nil {
+					if err := a.precacheManifests(ctx, req.app); err != nil {
+						log.Warnf("Failed to precache manifests of app '%s': %v", req.app.QualifiedName(), err)
+					}
+				}
+				cancel()
+				req.refresh()
+			}
+		}()
+	}
+}
+
+// refreshAppAfterPrecache queues an application to precache its manifests, and refreshes it once they are precached or
+// once the precache timeout expires, so that the controller renders the manifests from the cache of the repo-server.
+// The application is refreshed right away when the precaching is disabled or the queue is full, and isn't queued
+// again while it is waiting for its refresh.
+func (a *ArgoCDWebhookHandler) refreshAppAfterPrecache(app *v1alpha1.Application) {
+	if a.precacheQueue == nil {
+		a.refreshApp(app)
+		return
+	}
+	if _, pending := a.precachePending.LoadOrStore(app.QualifiedName(), true); pending {
+		return
+	}
+	var once sync.Once
+	req := &precacheRequest{app: app, deadline: time.Now().Add(precacheTimeout)}
+	req.refresh = func() {
+		once.Do(func() {
+			a.precachePending.Delete(app.QualifiedName())
+			a.refreshApp(app)
+		})
+	}
+	time.AfterFunc(precacheTimeout, req.refresh)
+	select {
+	case a.precacheQueue <- req:
+	default:
+		log.Infof("Precache queue is full, refreshing app '%s' without precaching its manifests", app.QualifiedName())
+		req.refresh()
+	}
+}
+
+// refreshApp requests a normal refresh of an application to the controller
+func (a *ArgoCDWebhookHandler) refreshApp(app *v1alpha1.Application) {
+	namespacedAppInterface := a.appClientset.ArgoprojV1alpha1().Applications(app.Namespace)
+	_, err := argo.RefreshApp(namespacedAppInterface, app.Name, v1alpha1.RefreshTypeNormal, true)
+	if err != nil {
+		log.Warnf("Failed to refresh app '%s' for controller reprocessing: %v", app.Name, err)
+	}
+}
+
+// precacheManifests fetches the target revisions of the sources of an application and renders their manifests on the
+// repo-server, with the same parameters as the controller so that the manifests are already cached when the controller
+// refreshes the application
+func (a *ArgoCDWebhookHandler) precacheManifests(ctx context.Context, app *v1alpha1.Application) error {
+	sources := app.Spec.GetSources()
+	// the hydrated sources and the outputs of the referenced applications are only known by the controller
+	if app.Spec.SourceHydrator != nil || len(argo.GetApplicationRefSources(sources)) > 0 {
+		return nil
+	}
+
+	proj, err := a.appClientset.ArgoprojV1alpha1().AppProjects(a.ns).Get(ctx, app.Spec.GetProject(), metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("error getting project: %w", err)
+	}
+	destCluster, err := argo.GetDestinationCluster(ctx, app.Spec.Destination, a.db)
+	if err != nil {
+		return fmt.Errorf("error validating destination: %w", err)
+	}
+	var clusterInfo v1alpha1.ClusterInfo
+	if err := a.serverCache.GetClusterInfo(destCluster.Server, &clusterInfo); err != nil {
+		return fmt.Errorf("error getting cluster info: %w", err)
+	}
+
+	appLabelKey, err := a.settingsSrc.GetAppInstanceLabelKey()
+	if err != nil {
+		return fmt.Errorf("error getting app instance label key: %w", err)
+	}
+	trackingMethod, err := argo.GetTrackingMethod(a.settingsSrc, proj)
+	if err != nil {
+		return fmt.Errorf("error getting tracking method: %w", err)
+	}
+	installationID, err := a.settingsSrc.GetInstallationID()
+	if err != nil {
+		return fmt.Errorf("error getting installation ID: %w", err)
+	}
+	enabledSourceTypes, err := a.settingsSrc.GetEnabledSourceTypes()
+	if err != nil {
+		return fmt.Errorf("error getting enabled source types: %w", err)
+	}
+	kustomizeSettings, err := a.settingsSrc.GetKustomizeSettings()
+	if err != nil {
+		return fmt.Errorf("error getting Kustomize settings: %w", err)
+	}
+	helmOptions, err := a.settingsSrc.GetHelmSettings()
+	if err != nil {
+		return fmt.Errorf("error getting Helm settings: %w", err)
+	}
+
+	helmRepos, helmRepoCreds, err := getPermittedRepos(ctx, proj, a.db.ListHelmRepositories, a.db.GetAllHelmRepositoryCredentials)
+	if err != nil {
+		return fmt.Errorf("error getting permitted Helm repositories: %w", err)
+	}
+	ociRepos, ociRepoCreds, err := getPermittedRepos(ctx, proj, a.db.ListOCIRepositories, a.db.GetAllOCIRepositoryCredentials)
+	if err != nil {
+		return fmt.Errorf("error getting permitted OCI repositories: %w", err)
+	}
+
+	refSources, err := argo.GetRefSources(ctx, sources, app.Spec.Project, a.db.GetRepository, []string{})
+	if err != nil {
+		return fmt.Errorf("error getting ref sources: %w", err)
+	}
+
+	conn, repoClient, err := a.repoClientset.NewRepoServerClient()
+	if err != nil {
+		return fmt.Errorf("error connecting to repo server: %w", err)
+	}
+	defer utilio.Close(conn)
+
+	for i, source := range sources {
+		source = proj.GetApplicationSource(source)
+		repo, err := a.db.GetRepository(ctx, source.RepoURL, proj.Name)
+		if err != nil {
+			return fmt.Errorf("error getting repo %q: %w", source.RepoURL, err)
+		}
+		repos := helmRepos
+		repoCreds := helmRepoCreds
+		if source.IsOCI() {
+			repos = append(slices.Clone(helmRepos), ociRepos...)
+			repoCreds = append(slices.Clone(helmRepoCreds), ociRepoCreds...)
+		}
+		_, err = repoClient.GenerateManifest(ctx, &apiclient.ManifestRequest{
+			Repo:                            repo,
+			Repos:                           repos,
+			Revision:                        source.TargetRevision,
+			NoRevisionCache:                 true,
+			AppLabelKey:                     appLabelKey,
+			AppName:                         app.InstanceName(a.ns),
+			Namespace:                       app.Spec.Destination.Namespace,
+			ApplicationSource:               &source,
+			KustomizeOptions:                kustomizeSettings,
+			KubeVersion:                     clusterInfo.ServerVersion,
+			ApiVersions:                     clusterInfo.APIVersions,
+			VerifySignature:                 len(proj.Spec.SignatureKeys) > 0 && gpg.IsGPGEnabled(),
+			HelmRepoCreds:                   repoCreds,
+			TrackingMethod:                  trackingMethod,
+			EnabledSourceTypes:              enabledSourceTypes,
+			HelmOptions:                     helmOptions,
+			HasMultipleSources:              app.Spec.HasMultipleSources(),
+			RefSources:                      refSources,
+			ProjectName:                     proj.Name,
+			ProjectSourceRepos:              proj.Spec.SourceRepos,
+			AnnotationManifestGeneratePaths: app.GetAnnotation(v1alpha1.AnnotationKeyManifestGeneratePaths),
+			InstallationID:                  installationID,
+		})
+		if err != nil {
+			return fmt.Errorf("error generating manifests for source %d of %d: %w", i+1, len(sources), err)
+		}
+	}
+	log.Infof("Precached manifests of app '%s'", app.QualifiedName())
+	return nil
+}
+
+// getPermittedRepos returns the repositories and the repository credentials permitted in a project
+func getPermittedRepos(ctx context.Context, proj *v1alpha1.AppProject, listRepos func(context.Context) ([]*v1alpha1.Repository, error), listRepoCreds func(context.Context) ([]*v1alpha1.RepoCreds, error)) ([]*v1alpha1.Repository, []*v1alpha1.RepoCreds, error) {
+	repos, err := listRepos(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error listing repositories: %w", err)
+	}
+	permittedRepos, err := argo.GetPermittedRepos(proj, repos)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error getting permitted repositories: %w", err)
+	}
+	repoCreds, err := listRepoCreds(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error listing repository credentials: %w", err)
+	}
+	permittedRepoCreds, err := argo.GetPermittedReposCredentials(proj, repoCreds)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error getting permitted repository credentials: %w", err)
+	}
+	return permittedRepos, permittedRepoCreds, nil
+}
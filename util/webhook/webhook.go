@@ -28,6 +28,7 @@ import (
 	"github.com/argoproj/argo-cd/v3/common"
 	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
 	appclientset "github.com/argoproj/argo-cd/v3/pkg/client/clientset/versioned"
+	"github.com/argoproj/argo-cd/v3/reposerver/apiclient"
 	"github.com/argoproj/argo-cd/v3/reposerver/cache"
 	servercache "github.com/argoproj/argo-cd/v3/server/cache"
 	"github.com/argoproj/argo-cd/v3/util/app/path"
@@ -42,6 +43,9 @@ type settingsSource interface {
 	GetAppInstanceLabelKey() (string, error)
 	GetTrackingMethod() (string, error)
 	GetInstallationID() (string, error)
+	GetEnabledSourceTypes() (map[string]bool, error)
+	GetKustomizeSettings() (*v1alpha1.KustomizeOptions, error)
+	GetHelmSettings() (*v1alpha1.HelmOptions, error)
 }
 
 // https://www.rfc-editor.org/rfc/rfc3986#section-3.2.1
@@ -60,6 +64,7 @@ type ArgoCDWebhookHandler struct {
 	ns                     string
 	appNs                  []string
 	appClientset           appclientset.Interface
+	repoClientset          apiclient.Clientset
 	github                 *github.Webhook
 	gitlab                 *gitlab.Webhook
 	bitbucket              *bitbucket.Webhook
@@ -69,10 +74,15 @@ type ArgoCDWebhookHandler struct {
 	settings               *settings.ArgoCDSettings
 	settingsSrc            settingsSource
 	queue                  chan any
+	precacheQueue          chan *precacheRequest
+	precachePending        sync.Map
 	maxWebhookPayloadSizeB int64
 }
 
-func NewHandler(namespace string, applicationNamespaces []string, webhookParallelism int, appClientset appclientset.Interface, set *settings.ArgoCDSettings, settingsSrc settingsSource, repoCache *cache.Cache, serverCache *servercache.Cache, argoDB db.ArgoDB, maxWebhookPayloadSizeB int64) *ArgoCDWebhookHandler {
+// NewHandler returns a webhook handler refreshing the applications affected by push events. When
+// webhookPrecacheParallelism is positive, the manifests of the refreshed applications are additionally rendered on the
+// repo-server in the background, by as many workers, to warm up the cache before the controller refreshes them.
+func NewHandler(namespace string, applicationNamespaces []string, webhookParallelism int, webhookPrecacheParallelism int, appClientset appclientset.Interface, repoClientset apiclient.Clientset, set *settings.ArgoCDSettings, settingsSrc settingsSource, repoCache *cache.Cache, serverCache *servercache.Cache, argoDB db.ArgoDB, maxWebhookPayloadSizeB int64) *ArgoCDWebhookHandler {
 	githubWebhook, err := github.New(github.Options.Secret(set.WebhookGitHubSecret))
 	if err != nil {
 		log.Warnf("Unable to init the GitHub webhook")
@@ -102,6 +112,7 @@ func NewHandler(namespace string, applicationNamespaces []string, webhookParalle
 		ns:                     namespace,
 		appNs:                  applicationNamespaces,
 		appClientset:           appClientset,
+		repoClientset:          repoClientset,
 		github:                 githubWebhook,
 		gitlab:                 gitlabWebhook,
 		bitbucket:              bitbucketWebhook,
@@ -118,6 +129,10 @@ func NewHandler(namespace string, applicationNamespaces []string, webhookParalle
 	}
 
 	acdWebhook.startWorkerPool(webhookParallelism)
+	if webhookPrecacheParallelism > 0 {
+		acdWebhook.precacheQueue = make(chan *precacheRequest, precacheQueueSize)
+		acdWebhook.startPrecacheWorkerPool(webhookPrecacheParallelism)
+	}
 
 	return &acdWebhook
 }
@@ -325,11 +340,6 @@ func (a *ArgoCDWebhookHandler) HandleEvent(payload any) {
 		log.Warnf("Failed to get installation ID: %v", err)
 		return
 	}
-	trackingMethod, err := a.settingsSrc.GetTrackingMethod()
-	if err != nil {
-		log.Warnf("Failed to get trackingMethod: %v", err)
-		return
-	}
 	appInstanceLabelKey, err := a.settingsSrc.GetAppInstanceLabelKey()
 	if err != nil {
 		log.Warnf("Failed to get appInstanceLabelKey: %v", err)
@@ -372,16 +382,11 @@ func (a *ArgoCDWebhookHandler) HandleEvent(payload any) {
 				if sourceRevisionHasChanged(source, revision, touchedHead) && sourceUsesURL(source, webURL, repoRegexp) {
 					refreshPaths := path.GetAppRefreshPaths(&app)
 					if path.AppFilesHaveChanged(refreshPaths, changedFiles) {
-						namespacedAppInterface := a.appClientset.ArgoprojV1alpha1().Applications(app.Namespace)
-						_, err = argo.RefreshApp(namespacedAppInterface, app.Name, v1alpha1.RefreshTypeNormal, true)
-						if err != nil {
-							log.Warnf("Failed to refresh app '%s' for controller reprocessing: %v", app.Name, err)
-							continue
-						}
+						a.refreshAppAfterPrecache(&app)
 						// No need to refresh multiple times if multiple sources match.
 						break
 					} else if change.shaBefore != "" && change.shaAfter != "" {
-						if err := a.storePreviouslyCachedManifests(&app, change, trackingMethod, appInstanceLabelKey, installationID); err != nil {
+						if err := a.storePreviouslyCachedManifests(&app, change, appInstanceLabelKey, installationID); err != nil {
 							log.Warnf("Failed to store cached manifests of previous revision for app '%s': %v", app.Name, err)
 						}
 					}
@@ -435,7 +440,7 @@ func getURLRegex(originalURL string, regexpFormat string) (*regexp.Regexp, error
 	return repoRegexp, nil
 }
 
-func (a *ArgoCDWebhookHandler) storePreviouslyCachedManifests(app *v1alpha1.Application, change changeInfo, trackingMethod string, appInstanceLabelKey string, installationID string) error {
+func (a *ArgoCDWebhookHandler) storePreviouslyCachedManifests(app *v1alpha1.Application, change changeInfo, appInstanceLabelKey string, installationID string) error {
 	destCluster, err := argo.GetDestinationCluster(context.Background(), app.Spec.Destination, a.db)
 	if err != nil {
 		return fmt.Errorf("error validating destination: %w", err)
@@ -458,6 +463,14 @@ func (a *ArgoCDWebhookHandler) storePreviouslyCachedManifests(app *v1alpha1.Appl
 	if err != nil {
 		return fmt.Errorf("error getting ref sources: %w", err)
 	}
+	proj, err := a.appClientset.ArgoprojV1alpha1().AppProjects(a.ns).Get(context.Background(), app.Spec.GetProject(), metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("error getting project: %w", err)
+	}
+	trackingMethod, err := argo.GetTrackingMethod(a.settingsSrc, proj)
+	if err != nil {
+		return fmt.Errorf("error getting tracking method: %w", err)
+	}
 	source := app.Spec.GetSource()
 	cache.LogDebugManifestCacheKeyFields("moving manifests cache", "webhook app revision changed", change.shaBefore, &source, refSources, &clusterInfo, app.Spec.Destination.Namespace, trackingMethod, appInstanceLabelKey, app.Name, nil)
 
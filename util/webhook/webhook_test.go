@@ -40,6 +40,8 @@ import (
 
 	"github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
 	appclientset "github.com/argoproj/argo-cd/v3/pkg/client/clientset/versioned/fake"
+	"github.com/argoproj/argo-cd/v3/reposerver/apiclient"
+	repomocks "github.com/argoproj/argo-cd/v3/reposerver/apiclient/mocks"
 	"github.com/argoproj/argo-cd/v3/reposerver/cache"
 	cacheutil "github.com/argoproj/argo-cd/v3/util/cache"
 	"github.com/argoproj/argo-cd/v3/util/settings"
@@ -59,6 +61,18 @@ func (f fakeSettingsSrc) GetInstallationID() (string, error) {
 	return "", nil
 }
 
+func (f fakeSettingsSrc) GetEnabledSourceTypes() (map[string]bool, error) {
+	return map[string]bool{}, nil
+}
+
+func (f fakeSettingsSrc) GetKustomizeSettings() (*v1alpha1.KustomizeOptions, error) {
+	return &v1alpha1.KustomizeOptions{}, nil
+}
+
+func (f fakeSettingsSrc) GetHelmSettings() (*v1alpha1.HelmOptions, error) {
+	return &v1alpha1.HelmOptions{}, nil
+}
+
 type reactorDef struct {
 	verb     string
 	resource string
@@ -110,7 +124,7 @@ func newMockHandler(reactor *reactorDef, applicationNamespaces []string, maxPayl
 	}
 	cacheClient := cacheutil.NewCache(cacheutil.NewInMemoryCache(1 * time.Hour))
 
-	return NewHandler("argocd", applicationNamespaces, 10, appClientset, argoSettings, &fakeSettingsSrc{}, cache.NewCache(
+	return NewHandler("argocd", applicationNamespaces, 10, 0, appClientset, &repomocks.Clientset{}, argoSettings, &fakeSettingsSrc{}, cache.NewCache(
 		cacheClient,
 		1*time.Minute,
 		1*time.Minute,
@@ -376,6 +390,149 @@ func TestGitHubCommitEvent_Hydrate(t *testing.T) {
 	hook.Reset()
 }
 
+func TestGitHubCommitEvent_Precache(t *testing.T) {
+	app := &v1alpha1.Application{
+		ObjectMeta: metav1.ObjectMeta{Name: "app-to-precache", Namespace: "argocd"},
+		Spec: v1alpha1.ApplicationSpec{
+			Project:     "default",
+			Source:      &v1alpha1.ApplicationSource{RepoURL: "https://github.com/jessesuen/test-repo", Path: ".", TargetRevision: "master"},
+			Destination: v1alpha1.ApplicationDestination{Server: "https://kubernetes.default.svc", Namespace: "guestbook"},
+		},
+	}
+	proj := &v1alpha1.AppProject{
+		ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "argocd"},
+		Spec:       v1alpha1.AppProjectSpec{SourceRepos: []string{"*"}, TrackingMethod: v1alpha1.TrackingMethodLabel},
+	}
+	repo := &v1alpha1.Repository{Repo: "https://github.com/jessesuen/test-repo"}
+	mockDB := mocks.ArgoDB{}
+	mockDB.On("GetCluster", mock.Anything, "https://kubernetes.default.svc").Return(&v1alpha1.Cluster{Server: "https://kubernetes.default.svc"}, nil)
+	mockDB.On("GetRepository", mock.Anything, repo.Repo, "default").Return(repo, nil)
+	mockDB.On("ListHelmRepositories", mock.Anything).Return(nil, nil)
+	mockDB.On("ListOCIRepositories", mock.Anything).Return(nil, nil)
+	mockDB.On("GetAllHelmRepositoryCredentials", mock.Anything).Return(nil, nil)
+	mockDB.On("GetAllOCIRepositoryCredentials", mock.Anything).Return(nil, nil)
+	requests := make(chan *apiclient.ManifestRequest, 1)
+	generated := make(chan struct{})
+	repoClient := &repomocks.RepoServerServiceClient{}
+	repoClient.On("GenerateManifest", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		requests <- args.Get(1).(*apiclient.ManifestRequest)
+		<-generated
+	}).Return(&apiclient.ManifestResponse{}, nil)
+	appClientset := appclientset.NewSimpleClientset(app, proj)
+	refreshed := make(chan string, 1)
+	appClientset.PrependReactor("patch", "applications", func(action kubetesting.Action) (bool, runtime.Object, error) {
+		refreshed <- action.(kubetesting.PatchAction).GetName()
+		return false, nil, nil
+	})
+
+	cacheClient := cacheutil.NewCache(cacheutil.NewInMemoryCache(1 * time.Hour))
+	serverCache := servercache.NewCache(appstate.NewCache(cacheClient, time.Minute), time.Minute, time.Minute)
+	require.NoError(t, serverCache.SetClusterInfo("https://kubernetes.default.svc", &v1alpha1.ClusterInfo{ServerVersion: "1.32", APIVersions: []string{"apps/v1"}}))
+	h := NewHandler("argocd", []string{}, 1, 1, appClientset, &repomocks.Clientset{RepoServerServiceClient: repoClient}, &settings.ArgoCDSettings{}, &fakeSettingsSrc{},
+		cache.NewCache(cacheClient, time.Minute, time.Minute, 10*time.Second), serverCache, &mockDB, int64(50)*1024*1024)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/webhook", http.NoBody)
+	req.Header.Set("X-GitHub-Event", "push")
+	eventJSON, err := os.ReadFile("testdata/github-commit-event.json")
+	require.NoError(t, err)
+	req.Body = io.NopCloser(bytes.NewReader(eventJSON))
+	w := httptest.NewRecorder()
+	h.Handler(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	select {
+	case request := <-requests:
+		assert.Equal(t, repo, request.Repo)
+		assert.Equal(t, "master", request.Revision)
+		assert.True(t, request.NoRevisionCache)
+		assert.Equal(t, "app-to-precache", request.AppName)
+		assert.Equal(t, "guestbook", request.Namespace)
+		assert.Equal(t, "1.32", request.KubeVersion)
+		assert.Equal(t, []string{"apps/v1"}, request.ApiVersions)
+		assert.Equal(t, string(v1alpha1.TrackingMethodLabel), request.TrackingMethod)
+		assert.Equal(t, "mycompany.com/appname", request.AppLabelKey)
+		assert.Equal(t, "default", request.ProjectName)
+	case <-time.After(10 * time.Second):
+		t.Fatal("manifests of the refreshed app weren't precached")
+	}
+	// the app is refreshed once its manifests are precached
+	assert.Empty(t, refreshed)
+	close(generated)
+	select {
+	case name := <-refreshed:
+		assert.Equal(t, "app-to-precache", name)
+	case <-time.After(10 * time.Second):
+		t.Fatal("app wasn't refreshed after its manifests were precached")
+	}
+	close(h.queue)
+	close(h.precacheQueue)
+	h.Wait()
+}
+
+func TestRefreshAppAfterPrecache(t *testing.T) {
+	newApp := func(name string) *v1alpha1.Application {
+		return &v1alpha1.Application{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "argocd"}}
+	}
+	newHandler := func() (*ArgoCDWebhookHandler, chan string) {
+		appClientset := appclientset.NewSimpleClientset(newApp("app-1"), newApp("app-2"))
+		refreshed := make(chan string, 2)
+		appClientset.PrependReactor("patch", "applications", func(action kubetesting.Action) (bool, runtime.Object, error) {
+			refreshed <- action.(kubetesting.PatchAction).GetName()
+			return false, nil, nil
+		})
+		return &ArgoCDWebhookHandler{appClientset: appClientset}, refreshed
+	}
+
+	t.Run("app is refreshed right away when the precaching is disabled", func(t *testing.T) {
+		h, refreshed := newHandler()
+		h.refreshAppAfterPrecache(newApp("app-1"))
+		assert.Equal(t, "app-1", <-refreshed)
+	})
+
+	t.Run("app is refreshed once, after its manifests are precached", func(t *testing.T) {
+		h, refreshed := newHandler()
+		h.precacheQueue = make(chan *precacheRequest, 1)
+		h.refreshAppAfterPrecache(newApp("app-1"))
+		h.refreshAppAfterPrecache(newApp("app-1"))
+		assert.Len(t, h.precacheQueue, 1)
+		assert.Empty(t, refreshed)
+
+		// the queue is full
+		h.refreshAppAfterPrecache(newApp("app-2"))
+		assert.Equal(t, "app-2", <-refreshed)
+		_, pending := h.precachePending.Load("argocd/app-2")
+		assert.False(t, pending)
+
+		req := <-h.precacheQueue
+		assert.Equal(t, "app-1", req.app.Name)
+		req.refresh()
+		req.refresh()
+		assert.Equal(t, "app-1", <-refreshed)
+		assert.Empty(t, refreshed)
+		_, pending = h.precachePending.Load("argocd/app-1")
+		assert.False(t, pending)
+	})
+
+	t.Run("app is refreshed when its manifests aren't precached before the timeout", func(t *testing.T) {
+		precacheTimeout = 10 * time.Millisecond
+		defer func() { precacheTimeout = time.Minute }()
+		h, refreshed := newHandler()
+		h.precacheQueue = make(chan *precacheRequest, 1)
+		h.refreshAppAfterPrecache(newApp("app-1"))
+		select {
+		case name := <-refreshed:
+			assert.Equal(t, "app-1", name)
+		case <-time.After(10 * time.Second):
+			t.Fatal("app wasn't refreshed after the precache timeout")
+		}
+		// the workers skip the precaching of the app, whose deadline expired
+		req := <-h.precacheQueue
+		assert.False(t, time.Now().Before(req.deadline))
+		_, pending := h.precachePending.Load("argocd/app-1")
+		assert.False(t, pending)
+	})
+}
+
 func TestGitHubTagEvent(t *testing.T) {
 	hook := test.NewGlobal()
 	h := NewMockHandler(nil, []string{})